@@ -0,0 +1,270 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"time"
+)
+
+const (
+	pagerDutyEventsURL         = "https://events.pagerduty.com/v2/enqueue"
+	defaultAlertSinkRateWindow = time.Minute
+)
+
+// alertSink sends tuples as alerts to Slack, PagerDuty, or a generic webhook.
+// Alerts having the same deduplication key are sent at most once in the
+// deduplication window, and the number of alerts sent in the rate window is
+// limited so that a flood of tuples doesn't page humans repeatedly.
+type alertSink struct {
+	http        *httpSink
+	payload     *alertPayloadFormatter
+	dedupWindow time.Duration
+	rateLimit   int
+	rateWindow  time.Duration
+
+	m        sync.Mutex
+	lastSent map[string]time.Time
+	sent     []time.Time
+}
+
+func (s *alertSink) Write(ctx *core.Context, t *core.Tuple) error {
+	key, err := s.payload.dedupKey(t)
+	if err != nil {
+		return err
+	}
+	now := ctx.Clock().Now()
+
+	s.m.Lock()
+	if s.dedupWindow > 0 {
+		for k, last := range s.lastSent {
+			if now.Sub(last) >= s.dedupWindow {
+				delete(s.lastSent, k)
+			}
+		}
+		if _, ok := s.lastSent[key]; ok {
+			s.m.Unlock()
+			return nil // This is intended, so it isn't an error.
+		}
+	}
+	if s.rateLimit > 0 {
+		i := 0
+		for i < len(s.sent) && now.Sub(s.sent[i]) >= s.rateWindow {
+			i++
+		}
+		s.sent = s.sent[i:]
+		if len(s.sent) >= s.rateLimit {
+			s.m.Unlock()
+			return fmt.Errorf("the alert was suppressed because %v alerts were sent in %v", s.rateLimit, s.rateWindow)
+		}
+		s.sent = append(s.sent, now)
+	}
+	if s.dedupWindow > 0 {
+		s.lastSent[key] = now
+	}
+	s.m.Unlock()
+
+	if err := s.http.Write(ctx, t); err != nil {
+		// The alert should be sent again when the same tuple arrives.
+		s.m.Lock()
+		if last, ok := s.lastSent[key]; ok && last.Equal(now) {
+			delete(s.lastSent, key)
+		}
+		s.m.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *alertSink) Close(ctx *core.Context) error {
+	return s.http.Close(ctx)
+}
+
+// alertPayloadFormatter formats a tuple into a request body of the service.
+type alertPayloadFormatter struct {
+	service    string
+	message    TupleFormatter
+	key        TupleFormatter
+	routingKey string
+	severity   string
+	source     string
+}
+
+// dedupKey returns the deduplication key of the tuple. The message is used
+// as the key when dedup_key parameter isn't given.
+func (f *alertPayloadFormatter) dedupKey(t *core.Tuple) (string, error) {
+	if f.key == nil {
+		return f.message.FormatTuple(t)
+	}
+	return f.key.FormatTuple(t)
+}
+
+func (f *alertPayloadFormatter) FormatTuple(t *core.Tuple) (string, error) {
+	msg, err := f.message.FormatTuple(t)
+	if err != nil {
+		return "", err
+	}
+
+	var m data.Map
+	switch f.service {
+	case "slack":
+		m = data.Map{
+			"text": data.String(msg),
+		}
+	case "pagerduty":
+		m = data.Map{
+			"routing_key":  data.String(f.routingKey),
+			"event_action": data.String("trigger"),
+			"payload": data.Map{
+				"summary":        data.String(msg),
+				"source":         data.String(f.source),
+				"severity":       data.String(f.severity),
+				"timestamp":      data.String(t.Timestamp.UTC().Format(time.RFC3339Nano)),
+				"custom_details": t.Data,
+			},
+		}
+		if f.key != nil {
+			k, err := f.key.FormatTuple(t)
+			if err != nil {
+				return "", err
+			}
+			m["dedup_key"] = data.String(k)
+		}
+	default:
+		k, err := f.dedupKey(t)
+		if err != nil {
+			return "", err
+		}
+		m = data.Map{
+			"message":   data.String(msg),
+			"dedup_key": data.String(k),
+			"severity":  data.String(f.severity),
+			"data":      t.Data,
+		}
+	}
+	return m.String(), nil
+}
+
+// createAlertSink creates a sink sending tuples as alerts. It accepts the
+// following parameters in addition to ones of the "http" sink except
+// batching parameters:
+//
+//   - service: "slack", "pagerduty", or "webhook", which is required.
+//   - url: the URL of the Slack incoming webhook or the webhook. The URL of
+//     PagerDuty Events API v2 is used by default for "pagerduty".
+//   - template, format: the message of an alert as NewTupleFormatter
+//     accepts. It's the text of a Slack message and the summary of a
+//     PagerDuty event.
+//   - routing_key: the integration key of PagerDuty, which is required for
+//     "pagerduty".
+//   - severity: "critical", "error", "warning", or "info". The default value
+//     is "error".
+//   - source: the source of PagerDuty events. The default value is
+//     "sensorbee".
+//   - dedup_key: a format string of the deduplication key like the "format"
+//     parameter of NewTupleFormatter. The message is used as the key by
+//     default.
+//   - dedup_window: alerts having the same key are only sent once in this
+//     duration. Deduplication is disabled by default.
+//   - rate_limit: the maximum number of alerts sent in rate_window. Alerts
+//     exceeding it are dropped. It's unlimited by default.
+//   - rate_window: the window of rate_limit. The default value is 1 minute.
+func createAlertSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	f := &alertPayloadFormatter{
+		severity: "error",
+		source:   "sensorbee",
+	}
+	v, ok := params["service"]
+	if !ok {
+		return nil, errors.New("'service' parameter is missing")
+	}
+	var err error
+	if f.service, err = data.AsString(v); err != nil {
+		return nil, fmt.Errorf("'service' parameter must be a string: %v", err)
+	}
+
+	httpParams := data.Map{}
+	for k, v := range params {
+		switch k {
+		case "service", "routing_key", "severity", "source", "dedup_key", "dedup_window",
+			"rate_limit", "rate_window", "template", "format":
+		case "batch_size", "batch_format", "flush_interval":
+			return nil, fmt.Errorf("'%v' parameter isn't supported by alert sinks", k)
+		default:
+			httpParams[k] = v
+		}
+	}
+
+	switch f.service {
+	case "slack", "webhook":
+	case "pagerduty":
+		v, ok := params["routing_key"]
+		if !ok {
+			return nil, errors.New("'routing_key' parameter is required for pagerduty")
+		}
+		if f.routingKey, err = data.AsString(v); err != nil {
+			return nil, fmt.Errorf("'routing_key' parameter must be a string: %v", err)
+		}
+		if _, ok := httpParams["url"]; !ok {
+			httpParams["url"] = data.String(pagerDutyEventsURL)
+		}
+	default:
+		return nil, fmt.Errorf("'service' parameter must be one of slack, pagerduty, and webhook: %v", f.service)
+	}
+
+	if v, ok := params["severity"]; ok {
+		if f.severity, err = data.AsString(v); err != nil {
+			return nil, fmt.Errorf("'severity' parameter must be a string: %v", err)
+		}
+		switch f.severity {
+		case "critical", "error", "warning", "info":
+		default:
+			return nil, fmt.Errorf("'severity' parameter must be one of critical, error, warning, and info: %v", f.severity)
+		}
+	}
+	if v, ok := params["source"]; ok {
+		if f.source, err = data.AsString(v); err != nil {
+			return nil, fmt.Errorf("'source' parameter must be a string: %v", err)
+		}
+	}
+	if f.message, err = NewTupleFormatter(params); err != nil {
+		return nil, err
+	}
+	if v, ok := params["dedup_key"]; ok {
+		k, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'dedup_key' parameter must be a string: %v", err)
+		}
+		if f.key, err = newPlaceholderTupleFormatter(k); err != nil {
+			return nil, fmt.Errorf("'dedup_key' parameter is invalid: %v", err)
+		}
+	}
+
+	s := &alertSink{
+		payload:  f,
+		lastSent: map[string]time.Time{},
+	}
+	if s.dedupWindow, err = extractDurationParameter(params, "dedup_window", 0); err != nil {
+		return nil, err
+	}
+	if s.rateLimit, err = extractPositiveIntParameter(params, "rate_limit", 0); err != nil {
+		return nil, err
+	}
+	if s.rateWindow, err = extractDurationParameter(params, "rate_window", defaultAlertSinkRateWindow); err != nil {
+		return nil, err
+	}
+
+	hs, err := createHTTPSink(ctx, ioParams, httpParams)
+	if err != nil {
+		return nil, err
+	}
+	s.http = hs.(*httpSink)
+	s.http.body = f
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSinkCreator("alert", SinkCreatorFunc(createAlertSink))
+}
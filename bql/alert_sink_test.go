@@ -0,0 +1,169 @@
+package bql
+
+import (
+	"encoding/json"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestAlertSink(t *testing.T) {
+	clock := core.NewFakeClock(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+	ctx := core.NewContext(&core.ContextConfig{
+		Clock: clock,
+	})
+	newTuple := func(host string, v int) *core.Tuple {
+		return core.NewTuple(data.Map{
+			"host":  data.String(host),
+			"value": data.Int(v),
+		})
+	}
+
+	Convey("Given an HTTP server", t, func() {
+		srv := newHTTPSinkTestServer()
+		Reset(srv.Close)
+
+		Convey("When sending alerts to Slack", func() {
+			si, err := createAlertSink(ctx, &IOParams{}, data.Map{
+				"service": data.String("slack"),
+				"url":     data.String(srv.URL),
+				"format":  data.String("{host} is down"),
+			})
+			So(err, ShouldBeNil)
+			So(si.Write(ctx, newTuple("h1", 1)), ShouldBeNil)
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should send a Slack message", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 1)
+				var js map[string]interface{}
+				So(json.Unmarshal([]byte(rs[0].body), &js), ShouldBeNil)
+				So(js, ShouldResemble, map[string]interface{}{"text": "h1 is down"})
+			})
+		})
+
+		Convey("When sending alerts to PagerDuty", func() {
+			si, err := createAlertSink(ctx, &IOParams{}, data.Map{
+				"service":     data.String("pagerduty"),
+				"url":         data.String(srv.URL),
+				"routing_key": data.String("key"),
+				"severity":    data.String("critical"),
+				"format":      data.String("{host} is down"),
+				"dedup_key":   data.String("{host}"),
+			})
+			So(err, ShouldBeNil)
+			So(si.Write(ctx, newTuple("h1", 1)), ShouldBeNil)
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should send a PagerDuty event", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 1)
+				var js map[string]interface{}
+				So(json.Unmarshal([]byte(rs[0].body), &js), ShouldBeNil)
+				So(js["routing_key"], ShouldEqual, "key")
+				So(js["event_action"], ShouldEqual, "trigger")
+				So(js["dedup_key"], ShouldEqual, "h1")
+				p := js["payload"].(map[string]interface{})
+				So(p["summary"], ShouldEqual, "h1 is down")
+				So(p["severity"], ShouldEqual, "critical")
+				So(p["custom_details"].(map[string]interface{})["value"], ShouldEqual, 1)
+			})
+		})
+
+		Convey("When sending alerts with a deduplication window", func() {
+			si, err := createAlertSink(ctx, &IOParams{}, data.Map{
+				"service":      data.String("webhook"),
+				"url":          data.String(srv.URL),
+				"dedup_key":    data.String("{host}"),
+				"dedup_window": data.String("1m"),
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			So(si.Write(ctx, newTuple("h1", 1)), ShouldBeNil)
+			So(si.Write(ctx, newTuple("h1", 2)), ShouldBeNil)
+			So(si.Write(ctx, newTuple("h2", 3)), ShouldBeNil)
+
+			Convey("Then duplicated alerts should be suppressed", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 2)
+				var js map[string]interface{}
+				So(json.Unmarshal([]byte(rs[1].body), &js), ShouldBeNil)
+				So(js["dedup_key"], ShouldEqual, "h2")
+			})
+
+			Convey("Then the same alert should be sent after the window", func() {
+				clock.Advance(time.Minute)
+				So(si.Write(ctx, newTuple("h1", 4)), ShouldBeNil)
+				So(len(srv.received()), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When sending alerts with a rate limit", func() {
+			si, err := createAlertSink(ctx, &IOParams{}, data.Map{
+				"service":     data.String("webhook"),
+				"url":         data.String(srv.URL),
+				"rate_limit":  data.Int(2),
+				"rate_window": data.String("10s"),
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			So(si.Write(ctx, newTuple("h1", 1)), ShouldBeNil)
+			So(si.Write(ctx, newTuple("h2", 2)), ShouldBeNil)
+
+			Convey("Then alerts exceeding the limit should be dropped", func() {
+				So(si.Write(ctx, newTuple("h3", 3)), ShouldNotBeNil)
+				So(len(srv.received()), ShouldEqual, 2)
+			})
+
+			Convey("Then alerts should be sent again after the window", func() {
+				clock.Advance(10 * time.Second)
+				So(si.Write(ctx, newTuple("h3", 3)), ShouldBeNil)
+				So(len(srv.received()), ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		cases := map[string]data.Map{
+			"no service":      data.Map{"url": data.String("http://localhost")},
+			"unknown service": data.Map{"service": data.String("mail"), "url": data.String("http://localhost")},
+			"pagerduty without a routing key": data.Map{
+				"service": data.String("pagerduty"),
+			},
+			"slack without a url": data.Map{"service": data.String("slack")},
+			"an unknown severity": data.Map{
+				"service":  data.String("slack"),
+				"url":      data.String("http://localhost"),
+				"severity": data.String("fatal"),
+			},
+			"a batch size": data.Map{
+				"service":    data.String("slack"),
+				"url":        data.String("http://localhost"),
+				"batch_size": data.Int(10),
+			},
+			"a zero rate limit": data.Map{
+				"service":    data.String("slack"),
+				"url":        data.String("http://localhost"),
+				"rate_limit": data.Int(0),
+			},
+		}
+		for title, params := range cases {
+			params := params
+			Convey("When creating an alert sink with "+title, func() {
+				_, err := createAlertSink(core.NewContext(nil), &IOParams{}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,379 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// MembershipState is a shared state having a set of values. Assertion boxes
+// use it to check that values of tuples refer to known values, e.g. that a
+// device ID is registered. The vocabulary state implements this interface.
+type MembershipState interface {
+	core.SharedState
+
+	// Contains returns true when the state has the value.
+	Contains(v data.Value) (bool, error)
+}
+
+// assertionAction controls what an assertion box does with tuples violating
+// its rules.
+type assertionAction int
+
+const (
+	// assertionAnnotate writes all tuples with names of violated rules.
+	assertionAnnotate assertionAction = iota
+
+	// assertionDrop discards tuples violating rules.
+	assertionDrop
+
+	// assertionDivert reports tuples violating rules as dropped tuples.
+	assertionDivert
+)
+
+func (a assertionAction) String() string {
+	switch a {
+	case assertionAnnotate:
+		return "annotate"
+	case assertionDrop:
+		return "drop"
+	case assertionDivert:
+		return "divert"
+	default:
+		return "unknown"
+	}
+}
+
+// assertionRule is a named boolean rule on a field of tuples. A tuple
+// violates the rule when the field doesn't satisfy any of the conditions.
+type assertionRule struct {
+	name     string
+	field    data.Path
+	required bool
+	min      *float64
+	max      *float64
+	regex    *regexp.Regexp
+	values   data.Array
+	state    string
+
+	numViolations int64
+}
+
+// assertionBox evaluates a set of named data-quality rules on each tuple.
+// It's created by
+//
+//	CREATE BOX checked TYPE assert FROM s WITH rules={
+//	    "temp_range": {"field": "temp", "min": -40, "max": 80},
+//	    "id_format": {"field": "id", "regex": "^[0-9a-f]{8}$"},
+//	    "known_device": {"field": "device", "state": "devices"}
+//	}, action="divert";
+//
+// It has following parameters:
+//
+//	rules: a map from names of rules to their conditions.
+//	action: "annotate", "drop", or "divert" (default: "annotate").
+//	field: the path to the field having an array of names of violated rules
+//	    when action is "annotate" (default: "violations").
+//
+// Each rule has following conditions. Only field is required:
+//
+//	field: the path to the field checked by the rule.
+//	required: whether a missing field or null violates the rule. When it's
+//	    false, other conditions are only checked for present values
+//	    (default: true).
+//	min, max: the inclusive range of a numeric value.
+//	regex: a regular expression which a string value must match.
+//	values: an array of allowed values.
+//	state: the name of a state implementing MembershipState which must
+//	    contain the value. The state is looked up for each tuple so that it
+//	    can be replaced.
+//
+// When action is "annotate", all tuples are written with the array, which is
+// empty when the tuple doesn't violate any rule. When action is "drop",
+// violating tuples are discarded. When action is "divert", they're reported
+// as dropped tuples so that they can be received from a dropped_tuples
+// source and routed to a violations stream. The number of violations of
+// each rule is reported as the counter "violations.<rule name>" to Metrics
+// of the topology and shown in the status of the box.
+type assertionBox struct {
+	name   string
+	rules  []*assertionRule
+	action assertionAction
+	field  data.Path
+
+	numTuples     int64
+	numViolations int64
+}
+
+var (
+	_ core.StatefulBox = &assertionBox{}
+)
+
+func createAssertionBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	b := &assertionBox{
+		name:   ioParams.Name,
+		action: assertionAnnotate,
+		field:  data.MustCompilePath("violations"),
+	}
+
+	v, ok := params["rules"]
+	if !ok {
+		return nil, errors.New("'rules' parameter is missing")
+	}
+	rules, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("'rules' parameter must be a map: %v", err)
+	}
+	if len(rules) == 0 {
+		return nil, errors.New("'rules' parameter must have at least one rule")
+	}
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r, err := parseAssertionRule(ctx, name, rules[name])
+		if err != nil {
+			return nil, err
+		}
+		b.rules = append(b.rules, r)
+	}
+
+	if v, ok := params["action"]; ok {
+		s, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'action' parameter must be a string: %v", err)
+		}
+		switch strings.ToLower(s) {
+		case "annotate":
+			b.action = assertionAnnotate
+		case "drop":
+			b.action = assertionDrop
+		case "divert":
+			b.action = assertionDivert
+		default:
+			return nil, fmt.Errorf("'action' parameter must be annotate, drop, or divert: %v", s)
+		}
+	}
+	if v, ok := params["field"]; ok {
+		s, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'field' parameter must be a string: %v", err)
+		}
+		if b.field, err = data.CompilePath(s); err != nil {
+			return nil, fmt.Errorf("'field' parameter has an invalid path: %v", err)
+		}
+	}
+	return b, nil
+}
+
+func parseAssertionRule(ctx *core.Context, name string, v data.Value) (*assertionRule, error) {
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("the rule '%v' must be a map: %v", name, err)
+	}
+	r := &assertionRule{
+		name:     name,
+		required: true,
+	}
+	for k, v := range m {
+		switch k {
+		case "field":
+			s, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("'field' of the rule '%v' must be a string: %v", name, err)
+			}
+			if r.field, err = data.CompilePath(s); err != nil {
+				return nil, fmt.Errorf("the rule '%v' has an invalid path: %v", name, err)
+			}
+		case "required":
+			if r.required, err = data.AsBool(v); err != nil {
+				return nil, fmt.Errorf("'required' of the rule '%v' must be a bool: %v", name, err)
+			}
+		case "min", "max":
+			f, err := data.ToFloat(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' of the rule '%v' must be a number: %v", k, name, err)
+			}
+			if k == "min" {
+				r.min = &f
+			} else {
+				r.max = &f
+			}
+		case "regex":
+			s, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("'regex' of the rule '%v' must be a string: %v", name, err)
+			}
+			if r.regex, err = regexp.Compile(s); err != nil {
+				return nil, fmt.Errorf("the rule '%v' has an invalid regular expression: %v", name, err)
+			}
+		case "values":
+			if r.values, err = data.AsArray(v); err != nil {
+				return nil, fmt.Errorf("'values' of the rule '%v' must be an array: %v", name, err)
+			}
+		case "state":
+			if r.state, err = data.AsString(v); err != nil {
+				return nil, fmt.Errorf("'state' of the rule '%v' must be a string: %v", name, err)
+			}
+			if _, err := lookupMembershipState(ctx, r.state); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("the rule '%v' has an unknown condition: %v", name, k)
+		}
+	}
+	if r.field == nil {
+		return nil, fmt.Errorf("the rule '%v' doesn't have 'field'", name)
+	}
+	if r.min != nil && r.max != nil && *r.min > *r.max {
+		return nil, fmt.Errorf("'min' of the rule '%v' must not be greater than 'max'", name)
+	}
+	return r, nil
+}
+
+func lookupMembershipState(ctx *core.Context, name string) (MembershipState, error) {
+	st, err := ctx.SharedStates.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(MembershipState)
+	if !ok {
+		return nil, fmt.Errorf("the state '%v' cannot be used for membership checks", name)
+	}
+	return s, nil
+}
+
+// check returns true when the tuple satisfies the rule. It only returns an
+// error when the rule cannot be evaluated, e.g. when its state is missing.
+func (r *assertionRule) check(ctx *core.Context, d data.Map) (bool, error) {
+	v, err := d.Get(r.field)
+	if err != nil || v.Type() == data.TypeNull {
+		return !r.required, nil
+	}
+
+	if r.min != nil || r.max != nil {
+		if t := v.Type(); t != data.TypeInt && t != data.TypeFloat {
+			return false, nil
+		}
+		f, err := data.ToFloat(v)
+		if err != nil {
+			return false, nil
+		}
+		if (r.min != nil && f < *r.min) || (r.max != nil && f > *r.max) {
+			return false, nil
+		}
+	}
+	if r.regex != nil {
+		s, err := data.AsString(v)
+		if err != nil || !r.regex.MatchString(s) {
+			return false, nil
+		}
+	}
+	if r.values != nil {
+		found := false
+		for _, e := range r.values {
+			if data.Equal(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	if r.state != "" {
+		s, err := lookupMembershipState(ctx, r.state)
+		if err != nil {
+			return false, err
+		}
+		ok, err := s.Contains(v)
+		if err != nil || !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (b *assertionBox) Init(ctx *core.Context) error {
+	return nil
+}
+
+func (b *assertionBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	var violated data.Array
+	for _, r := range b.rules {
+		ok, err := r.check(ctx, t.Data)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+		violated = append(violated, data.String(r.name))
+		atomic.AddInt64(&r.numViolations, 1)
+		ctx.AddCounter(core.NTBox, b.name, "violations."+r.name, 1)
+	}
+	atomic.AddInt64(&b.numTuples, 1)
+	if len(violated) > 0 {
+		atomic.AddInt64(&b.numViolations, 1)
+	}
+
+	switch b.action {
+	case assertionAnnotate:
+		if t.Flags.IsSet(core.TFSharedData) {
+			t.Data = t.Data.Copy()
+			t.Flags.Clear(core.TFSharedData)
+		}
+		if violated == nil {
+			violated = data.Array{}
+		}
+		if err := t.Data.Set(b.field, violated); err != nil {
+			return err
+		}
+	case assertionDrop:
+		if len(violated) > 0 {
+			return nil
+		}
+	case assertionDivert:
+		if len(violated) > 0 {
+			names := make([]string, len(violated))
+			for i, n := range violated {
+				names[i], _ = data.AsString(n)
+			}
+			ctx.DivertTuple(t, core.NTBox, b.name,
+				fmt.Errorf("the tuple violates rules: %v", strings.Join(names, ", ")))
+			return nil
+		}
+	}
+	return w.Write(ctx, t)
+}
+
+func (b *assertionBox) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// Status returns the status of the box.
+func (b *assertionBox) Status() data.Map {
+	rules := data.Map{}
+	for _, r := range b.rules {
+		rules[r.name] = data.Map{
+			"num_violations": data.Int(atomic.LoadInt64(&r.numViolations)),
+		}
+	}
+	return data.Map{
+		"action":         data.String(b.action.String()),
+		"num_tuples":     data.Int(atomic.LoadInt64(&b.numTuples)),
+		"num_violations": data.Int(atomic.LoadInt64(&b.numViolations)),
+		"rules":          rules,
+	}
+}
+
+func init() {
+	MustRegisterGlobalBoxCreator("assert", BoxCreatorFunc(createAssertionBox))
+}
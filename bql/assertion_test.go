@@ -0,0 +1,121 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestAssertionBox(t *testing.T) {
+	Convey("Given a topology builder having a stream and a vocabulary", t, func() {
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STREAM s AS SELECT RSTREAM int, "dev" || int::string AS device, null AS note
+				FROM source [RANGE 1 TUPLES];
+			CREATE STATE devices TYPE vocabulary WITH values=["dev1", "dev2", "dev3"];
+			CREATE SINK snk TYPE collector;`), ShouldBeNil)
+		rules := `rules={
+				"int_range": {"field": "int", "min": 2},
+				"known_device": {"field": "device", "state": "devices"},
+				"device_format": {"field": "device", "regex": "^dev[0-9]$"},
+				"optional_note": {"field": "note", "required": false, "values": ["ok"]}
+			}`
+
+		run := func(params string) *tupleCollectorSink {
+			So(addBQLToTopology(tb, `
+				CREATE BOX b TYPE assert FROM s WITH `+params+`;
+				INSERT INTO snk FROM b;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sn, err := tp.Sink("snk")
+			So(err, ShouldBeNil)
+			return sn.Sink().(*tupleCollectorSink)
+		}
+
+		Convey("When annotating violations", func() {
+			si := run(rules)
+			si.Wait(4)
+
+			Convey("Then each tuple should have names of violated rules", func() {
+				So(si.get(0).Data["violations"], ShouldResemble, data.Array{data.String("int_range")})
+				So(si.get(1).Data["violations"], ShouldResemble, data.Array{})
+				So(si.get(3).Data["violations"], ShouldResemble, data.Array{data.String("known_device")})
+			})
+
+			Convey("Then the status should have hit counts of rules", func() {
+				bn, err := tp.Box("b")
+				So(err, ShouldBeNil)
+				st := bn.Status()["box"].(data.Map)
+				So(st["num_tuples"], ShouldEqual, data.Int(4))
+				So(st["num_violations"], ShouldEqual, data.Int(2))
+				v, err := st.Get(data.MustCompilePath("rules.known_device.num_violations"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(1))
+				v, err = st.Get(data.MustCompilePath("rules.optional_note.num_violations"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(0))
+			})
+		})
+
+		Convey("When dropping violations", func() {
+			si := run(rules + `, action="drop"`)
+			si.Wait(2)
+
+			Convey("Then only valid tuples should be written", func() {
+				So(si.len(), ShouldEqual, 2)
+				So(si.get(0).Data["int"], ShouldEqual, data.Int(2))
+				So(si.get(1).Data["int"], ShouldEqual, data.Int(3))
+				_, ok := si.get(0).Data["violations"]
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When diverting violations", func() {
+			So(addBQLToTopology(tb, `
+				CREATE SOURCE dropped TYPE dropped_tuples;
+				CREATE STREAM violations AS SELECT RSTREAM data.int AS int, error
+					FROM dropped [RANGE 1 TUPLES] WHERE node_name = "b";
+				CREATE SINK vsnk TYPE collector;
+				INSERT INTO vsnk FROM violations;`), ShouldBeNil)
+			si := run(rules + `, action="divert"`)
+			si.Wait(2)
+			vn, err := tp.Sink("vsnk")
+			So(err, ShouldBeNil)
+			vsi := vn.Sink().(*tupleCollectorSink)
+			vsi.Wait(2)
+
+			Convey("Then violating tuples should be routed to the violations stream", func() {
+				So(si.len(), ShouldEqual, 2)
+				So(vsi.len(), ShouldEqual, 2)
+				So(vsi.get(0).Data["int"], ShouldEqual, data.Int(1))
+				So(vsi.get(0).Data["error"], ShouldEqual, data.String("the tuple violates rules: int_range"))
+				So(vsi.get(1).Data["int"], ShouldEqual, data.Int(4))
+			})
+		})
+
+		Convey("When creating assertion boxes with invalid parameters", func() {
+			for _, params := range []string{
+				`action="drop"`,
+				`rules={}`,
+				`rules=["a"]`,
+				`rules={"r": 1}`,
+				`rules={"r": {"min": 1}}`,
+				`rules={"r": {"field": "a", "min": "x"}}`,
+				`rules={"r": {"field": "a", "min": 2, "max": 1}}`,
+				`rules={"r": {"field": "a", "regex": "("}}`,
+				`rules={"r": {"field": "a", "state": "no_such_state"}}`,
+				`rules={"r": {"field": "a", "unknown": 1}}`,
+				`rules={"r": {"field": "a"}}, action="ignore"`,
+				`rules={"r": {"field": "a"}}, field="/a"`,
+			} {
+				err := addBQLToTopology(tb, `CREATE BOX b TYPE assert FROM s WITH `+params+`;`)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,370 @@
+package bql
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"math"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAudioSampleRate = 16000
+	defaultAudioChannels   = 1
+	defaultAudioDuration   = time.Second
+	audioMaxSampleRate     = 384000
+	audioMaxChannels       = 8
+	audioMaxChunkSize      = 64 * 1024 * 1024
+)
+
+// audioSampleFormats has sizes of samples in bytes and WAV format codes of
+// sample formats.
+var audioSampleFormats = map[string]struct {
+	size      int
+	wavFormat uint16
+}{
+	"s16le": {2, 1}, // WAVE_FORMAT_PCM
+	"f32le": {4, 3}, // WAVE_FORMAT_IEEE_FLOAT
+}
+
+// defaultAudioInputFormat returns the ffmpeg input format capturing audio
+// devices on the platform.
+func defaultAudioInputFormat() string {
+	switch runtime.GOOS {
+	case "linux":
+		return "alsa"
+	case "darwin":
+		return "avfoundation"
+	case "windows":
+		return "dshow"
+	}
+	return ""
+}
+
+// audioSource captures audio with ffmpeg and emits a tuple for each chunk of
+// interleaved PCM samples. Chunks have window frames and start every hop
+// frames, so that they overlap when hop is less than window. A tuple has
+// following fields:
+//
+//	audio: the samples, which have a WAV header when wav is true
+//	format: "pcm" or "wav"
+//	sample_format: "s16le" or "f32le"
+//	sample_rate: the number of frames per second
+//	channels: the number of channels
+//	samples: the number of frames in the chunk
+//	duration: the duration of the chunk in seconds
+//	sequence: the number of chunks emitted before the chunk
+//	captured_at: the time when the first frame of the chunk is captured
+//
+// The timestamp of a tuple is captured_at, which is estimated from the time
+// when the chunk is received.
+//
+// When ffmpeg exits, GenerateStream returns an error and ffmpeg is started
+// again by the supervisor, which can be configured by reconnect_ parameters
+// of CREATE SOURCE. The sequence number is kept across restarts.
+type audioSource struct {
+	name         string
+	ffmpeg       string
+	args         []string
+	sampleFormat string
+	sampleRate   int
+	channels     int
+	window       int
+	hop          int
+	wav          bool
+	ioParams     *IOParams
+	stopCh       chan struct{}
+
+	// seq is only used by GenerateStream, which isn't called concurrently.
+	seq int64
+
+	m       sync.Mutex
+	stopped bool
+
+	// proc is the running ffmpeg. It's closed by Stop to abort reading.
+	proc *ffmpegProcess
+}
+
+func (s *audioSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	var writeErr error
+	p, err := s.start()
+	if err == nil {
+		writeErr, err = s.read(ctx, w, p)
+	}
+	s.close()
+	if writeErr != nil {
+		return writeErr
+	}
+
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	return fmt.Errorf("cannot capture audio from %v: %v", s.name, err)
+}
+
+func (s *audioSource) start() (*ffmpegProcess, error) {
+	p, err := startFFmpeg(s.ffmpeg, s.args)
+	if err != nil {
+		return nil, err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		p.Close()
+		return nil, errors.New("the source has already been stopped")
+	}
+	s.proc = p
+	return p, nil
+}
+
+// read emits chunks until reading the output of ffmpeg fails. It returns an
+// error from w as writeErr and an error from ffmpeg as readErr. A partial
+// chunk at the end of the output is discarded.
+func (s *audioSource) read(ctx *core.Context, w core.Writer, p *ffmpegProcess) (writeErr, readErr error) {
+	frameSize := audioSampleFormats[s.sampleFormat].size * s.channels
+	windowSize, hopSize := s.window*frameSize, s.hop*frameSize
+	duration := time.Duration(s.window) * time.Second / time.Duration(s.sampleRate)
+
+	buf := make([]byte, windowSize)
+	if _, err := io.ReadFull(p.stdout, buf); err != nil {
+		return nil, p.exitError(err)
+	}
+	for {
+		start := ctx.Clock().Now().Add(-duration)
+		audio, format := buf, "pcm"
+		if s.wav {
+			f := audioSampleFormats[s.sampleFormat]
+			audio = appendWAVHeader(make([]byte, 0, wavHeaderSize+len(buf)),
+				f.wavFormat, s.channels, s.sampleRate, f.size, len(buf))
+			audio, format = append(audio, buf...), "wav"
+		}
+		t := core.NewTuple(data.Map{
+			"audio":         data.Blob(audio),
+			"format":        data.String(format),
+			"sample_format": data.String(s.sampleFormat),
+			"sample_rate":   data.Int(s.sampleRate),
+			"channels":      data.Int(s.channels),
+			"samples":       data.Int(s.window),
+			"duration":      data.Float(duration.Seconds()),
+			"sequence":      data.Int(s.seq),
+			"captured_at":   data.Timestamp(start),
+		})
+		t.Timestamp = start
+		t.ProcTimestamp = ctx.Clock().Now()
+		if err := w.Write(ctx, t); err != nil {
+			return err, nil
+		}
+		s.seq++
+
+		// The next chunk shares windowSize-hopSize bytes with this one. buf
+		// is reallocated since the blob of this chunk may still be used.
+		next := make([]byte, windowSize)
+		copy(next, buf[hopSize:])
+		if _, err := io.ReadFull(p.stdout, next[windowSize-hopSize:]); err != nil {
+			return nil, p.exitError(err)
+		}
+		buf = next
+	}
+}
+
+func (s *audioSource) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.proc != nil {
+		s.proc.Close()
+		s.proc = nil
+	}
+}
+
+func (s *audioSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	close(s.stopCh)
+	if s.proc != nil {
+		s.proc.Close()
+		s.proc = nil
+	}
+	return nil
+}
+
+func (s *audioSource) reconnectsBySupervisor() {}
+
+// wavHeaderSize is the size of the canonical WAV header.
+const wavHeaderSize = 44
+
+// appendWAVHeader appends a WAV header of dataSize bytes of samples to b.
+func appendWAVHeader(b []byte, format uint16, channels, sampleRate, sampleSize, dataSize int) []byte {
+	le := binary.LittleEndian
+	b = append(b, "RIFF"...)
+	b = le.AppendUint32(b, uint32(wavHeaderSize-8+dataSize))
+	b = append(b, "WAVEfmt "...)
+	b = le.AppendUint32(b, 16)
+	b = le.AppendUint16(b, format)
+	b = le.AppendUint16(b, uint16(channels))
+	b = le.AppendUint32(b, uint32(sampleRate))
+	b = le.AppendUint32(b, uint32(sampleRate*channels*sampleSize))
+	b = le.AppendUint16(b, uint16(channels*sampleSize))
+	b = le.AppendUint16(b, uint16(sampleSize*8))
+	b = append(b, "data"...)
+	return le.AppendUint32(b, uint32(dataSize))
+}
+
+// createAudioSource creates a source capturing audio from a device or a
+// stream. It accepts following parameters:
+//
+//	device: the name of a capture device such as "hw:1,0" or "default"
+//	input_format: the ffmpeg input format capturing the device (default:
+//	    "alsa" on Linux, "avfoundation" on macOS, and "dshow" on Windows)
+//	url: the URL of a stream such as "rtsp://camera/stream"
+//	sample_rate: the number of frames per second (default: 16000)
+//	channels: the number of channels (default: 1)
+//	sample_format: "s16le" or "f32le" (default: "s16le")
+//	duration: the duration of a chunk (default: 1s)
+//	hop: the interval between the starts of chunks, which must not be longer
+//	    than duration (default: duration)
+//	wav: true to add WAV headers to chunks (default: false)
+//	ffmpeg: the path of ffmpeg (default: "ffmpeg")
+//	rtsp_transport: "tcp" or "udp" (default: "tcp")
+//
+// Either device or url is required. Audio is captured and resampled by
+// ffmpeg, which must be installed. The name of a device depends on the
+// input format, for example ":0" for avfoundation and "audio=Microphone"
+// for dshow.
+func createAudioSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &audioSource{
+		sampleFormat: "s16le",
+		sampleRate:   defaultAudioSampleRate,
+		channels:     defaultAudioChannels,
+		ioParams:     ioParams,
+		stopCh:       make(chan struct{}),
+	}
+
+	for key, p := range map[string]struct {
+		dst *int
+		max int
+	}{
+		"sample_rate": {&s.sampleRate, audioMaxSampleRate},
+		"channels":    {&s.channels, audioMaxChannels},
+	} {
+		if v, ok := params[key]; ok {
+			n, err := data.AsInt(v)
+			if err != nil || n < 1 || n > int64(p.max) {
+				return nil, fmt.Errorf("'%v' parameter must be an integer in [1, %v]: %v", key, p.max, v)
+			}
+			*p.dst = int(n)
+		}
+	}
+
+	if v, ok := params["sample_format"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'sample_format' parameter must be a string: %v", err)
+		}
+		if _, ok := audioSampleFormats[strings.ToLower(f)]; !ok {
+			return nil, fmt.Errorf("'sample_format' parameter must be s16le or f32le: %v", f)
+		}
+		s.sampleFormat = strings.ToLower(f)
+	}
+
+	duration, err := extractDurationParameter(params, "duration", defaultAudioDuration)
+	if err != nil {
+		return nil, err
+	}
+	hop, err := extractDurationParameter(params, "hop", duration)
+	if err != nil {
+		return nil, err
+	}
+	if hop > duration {
+		return nil, fmt.Errorf("'hop' parameter must not be longer than 'duration' parameter: %v", hop)
+	}
+	for key, p := range map[string]struct {
+		d   time.Duration
+		dst *int
+	}{
+		"duration": {duration, &s.window},
+		"hop":      {hop, &s.hop},
+	} {
+		n := math.Round(p.d.Seconds() * float64(s.sampleRate))
+		if n < 1 {
+			return nil, fmt.Errorf("'%v' parameter must have at least one frame: %v", key, p.d)
+		}
+		*p.dst = int(n)
+	}
+	if s.window*s.channels*audioSampleFormats[s.sampleFormat].size > audioMaxChunkSize {
+		return nil, fmt.Errorf("'duration' parameter is too long: %v", duration)
+	}
+
+	if v, ok := params["wav"]; ok {
+		if s.wav, err = data.AsBool(v); err != nil {
+			return nil, fmt.Errorf("'wav' parameter must be bool: %v", err)
+		}
+	}
+
+	path, transport, err := extractFFmpegParameters(params)
+	if err != nil {
+		return nil, err
+	}
+	s.ffmpeg = path
+
+	dv, hasDevice := params["device"]
+	uv, hasURL := params["url"]
+	switch {
+	case hasDevice && hasURL:
+		return nil, errors.New("'device' and 'url' parameters cannot be specified at once")
+	case hasDevice:
+		d, err := data.AsString(dv)
+		if err != nil {
+			return nil, fmt.Errorf("'device' parameter must be a string: %v", err)
+		}
+		f := defaultAudioInputFormat()
+		if v, ok := params["input_format"]; ok {
+			if f, err = data.AsString(v); err != nil {
+				return nil, fmt.Errorf("'input_format' parameter must be a string: %v", err)
+			}
+		}
+		if f == "" {
+			return nil, errors.New("'input_format' parameter is required on this platform")
+		}
+		s.name = d
+		s.args = []string{"-f", f, "-i", d}
+
+	case hasURL:
+		str, err := data.AsString(uv)
+		if err != nil {
+			return nil, fmt.Errorf("'url' parameter must be a string: %v", err)
+		}
+		u, err := url.Parse(str)
+		if err != nil || u.Scheme == "" {
+			return nil, fmt.Errorf("'url' parameter must be a URL: %v", str)
+		}
+		s.name = u.Redacted()
+		if scheme := strings.ToLower(u.Scheme); scheme == "rtsp" || scheme == "rtsps" {
+			s.args = append(s.args, "-rtsp_transport", transport)
+		}
+		s.args = append(s.args, "-i", str)
+
+	default:
+		return nil, errors.New("'device' or 'url' parameter is required")
+	}
+	s.args = append(s.args, "-vn", "-ac", strconv.Itoa(s.channels), "-ar", strconv.Itoa(s.sampleRate),
+		"-f", s.sampleFormat, "-c:a", "pcm_"+s.sampleFormat, "-")
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("audio", SourceCreatorFunc(createAudioSource))
+}
@@ -0,0 +1,464 @@
+package bql
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/ble"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/frame"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBLEScanInterval = 10 * time.Millisecond
+	defaultBLEScanWindow   = 10 * time.Millisecond
+	bleScanUnit            = 625 * time.Microsecond
+	bleCommandTimeout      = 2 * time.Second
+
+	// bleMaxPacketSize is the size of the header and the largest
+	// parameters of an HCI event.
+	bleMaxPacketSize = 3 + 255
+)
+
+// bleManufacturerLayout is the layout of manufacturer specific data having
+// the company identifier and starting with the prefix.
+type bleManufacturerLayout struct {
+	companyID uint16
+	prefix    []byte
+	layout    *frame.Layout
+}
+
+// bleSource scans BLE advertisements with an HCI device and emits a tuple for
+// each advertising report. A tuple has following fields:
+//
+//	address: the address of the advertiser such as "AA:BB:CC:DD:EE:FF"
+//	address_type: "public" or "random"
+//	event_type: "adv_ind", "adv_direct_ind", "adv_scan_ind",
+//	    "adv_nonconn_ind", or "scan_rsp"
+//	rssi: the received signal strength in dBm
+//	data: the raw advertising data
+//
+// It also has following fields when the advertising data has them:
+//
+//	name: the local name
+//	tx_power: the TX power level in dBm
+//	flags: the flags
+//	services: an array of service UUIDs
+//	service_data: a map from 16-bit service UUIDs to data
+//	company_id: the company identifier of manufacturer specific data
+//	manufacturer_data: manufacturer specific data after the identifier
+//	manufacturer: fields of manufacturer_data decoded by the first layout
+//	    matching it
+//
+// The timestamp of a tuple is the time when it's received.
+//
+// When the device fails, for example because it's unplugged, GenerateStream
+// returns an error and the device is reopened by the supervisor, which can
+// be configured by reconnect_ parameters of CREATE SOURCE.
+type bleSource struct {
+	dev              int
+	active           bool
+	interval         uint16
+	window           uint16
+	filterDuplicates bool
+	addresses        map[string]bool
+	hasMinRSSI       bool
+	minRSSI          int
+	layouts          []*bleManufacturerLayout
+	ioParams         *IOParams
+	stopCh           chan struct{}
+
+	// open opens a socket of an HCI device. It's openHCISocket except in
+	// tests.
+	open func(dev int) (io.ReadWriteCloser, error)
+
+	m       sync.Mutex
+	stopped bool
+
+	// conn is the socket being read. It's closed by Stop to abort reading.
+	conn io.ReadWriteCloser
+}
+
+func (s *bleSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	var writeErr error
+	conn, err := s.start()
+	if err == nil {
+		writeErr, err = s.scan(ctx, w, conn)
+	}
+	s.close()
+	if writeErr != nil {
+		return writeErr
+	}
+
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	return fmt.Errorf("cannot scan BLE advertisements with hci%v: %v", s.dev, err)
+}
+
+// start opens the device and enables scanning.
+func (s *bleSource) start() (io.ReadWriteCloser, error) {
+	conn, err := s.open(s.dev)
+	if err != nil {
+		return nil, err
+	}
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		conn.Close()
+		return nil, errors.New("the source has already been stopped")
+	}
+	s.conn = conn
+	s.m.Unlock()
+
+	// Scanning is disabled first because parameters cannot be changed while
+	// scanning. The status is ignored since it fails when the device isn't
+	// scanning.
+	if _, err := bleCommand(conn, ble.SetScanEnable(false, false)); err != nil {
+		return nil, err
+	}
+	for _, c := range [][]byte{
+		ble.SetScanParameters(s.active, s.interval, s.window),
+		ble.SetScanEnable(true, s.filterDuplicates),
+	} {
+		status, err := bleCommand(conn, c)
+		if err != nil {
+			return nil, err
+		}
+		if status != 0 {
+			return nil, fmt.Errorf("the device rejected the command %#04x with the status %#02x",
+				uint16(c[1])|uint16(c[2])<<8, status)
+		}
+	}
+	return conn, nil
+}
+
+// bleCommand sends a command and returns the status of it. Events received
+// before the command completes are discarded.
+func bleCommand(conn io.ReadWriter, c []byte) (byte, error) {
+	if d, ok := conn.(interface {
+		SetReadDeadline(t time.Time) error
+	}); ok {
+		if err := d.SetReadDeadline(time.Now().Add(bleCommandTimeout)); err == nil {
+			defer d.SetReadDeadline(time.Time{})
+		}
+	}
+	if _, err := conn.Write(c); err != nil {
+		return 0, err
+	}
+	op := uint16(c[1]) | uint16(c[2])<<8
+	buf := make([]byte, bleMaxPacketSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+		if o, status, ok := ble.ParseCommandComplete(buf[:n]); ok && o == op {
+			return status, nil
+		}
+	}
+}
+
+// scan emits tuples until reading the device fails. It returns an error from
+// w as writeErr and an error from the device as readErr.
+func (s *bleSource) scan(ctx *core.Context, w core.Writer, conn io.Reader) (writeErr, readErr error) {
+	buf := make([]byte, bleMaxPacketSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		as, err := ble.ParseEvent(buf[:n])
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("body", hex.EncodeToString(buf[:n])).
+				Warning("Ignoring a malformed HCI event")
+			continue
+		}
+		for _, a := range as {
+			if s.addresses != nil && !s.addresses[a.Address] {
+				continue
+			}
+			if s.hasMinRSSI && a.RSSI < s.minRSSI {
+				continue
+			}
+			t := core.NewTuple(s.advertisement(ctx, a))
+			t.Timestamp = ctx.Clock().Now()
+			t.ProcTimestamp = t.Timestamp
+			if err := w.Write(ctx, t); err != nil {
+				return err, nil
+			}
+		}
+	}
+}
+
+func (s *bleSource) advertisement(ctx *core.Context, a *ble.Advertisement) data.Map {
+	m := data.Map{
+		"address":      data.String(a.Address),
+		"address_type": data.String("public"),
+		"event_type":   data.String(a.EventType.String()),
+		"rssi":         data.Int(a.RSSI),
+		"data":         data.Blob(a.Data),
+	}
+	if a.RandomAddress {
+		m["address_type"] = data.String("random")
+	}
+
+	d, err := ble.ParseAdvertisingData(a.Data)
+	if err != nil {
+		ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+			WithField("address", a.Address).
+			WithField("body", hex.EncodeToString(a.Data)).
+			Warning("Cannot decode advertising data")
+		return m
+	}
+	if d.LocalName != "" {
+		m["name"] = data.String(d.LocalName)
+	}
+	if d.TxPower != nil {
+		m["tx_power"] = data.Int(*d.TxPower)
+	}
+	if d.Flags != nil {
+		m["flags"] = data.Int(*d.Flags)
+	}
+	if len(d.Services) > 0 {
+		ss := make(data.Array, len(d.Services))
+		for i, u := range d.Services {
+			ss[i] = data.String(u)
+		}
+		m["services"] = ss
+	}
+	if len(d.ServiceData) > 0 {
+		sd := data.Map{}
+		for u, b := range d.ServiceData {
+			sd[u] = data.Blob(b)
+		}
+		m["service_data"] = sd
+	}
+	if d.ManufacturerData != nil {
+		m["company_id"] = data.Int(d.CompanyID)
+		m["manufacturer_data"] = data.Blob(d.ManufacturerData)
+		for _, l := range s.layouts {
+			if l.companyID != d.CompanyID || !strings.HasPrefix(string(d.ManufacturerData), string(l.prefix)) ||
+				len(d.ManufacturerData) < l.layout.Size {
+				continue
+			}
+			mm, err := l.layout.Decode(d.ManufacturerData)
+			if err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField("address", a.Address).
+					WithField("body", hex.EncodeToString(d.ManufacturerData)).
+					Warning("Cannot decode manufacturer specific data")
+				break
+			}
+			m["manufacturer"] = mm
+			break
+		}
+	}
+	return m
+}
+
+// close disables scanning and closes the device.
+func (s *bleSource) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closeConn()
+}
+
+func (s *bleSource) closeConn() {
+	if s.conn == nil {
+		return
+	}
+	// This is the best effort and the result isn't waited for.
+	s.conn.Write(ble.SetScanEnable(false, false))
+	s.conn.Close()
+	s.conn = nil
+}
+
+func (s *bleSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	close(s.stopCh)
+	s.closeConn()
+	return nil
+}
+
+func (s *bleSource) reconnectsBySupervisor() {}
+
+// createBLESource creates a source scanning BLE advertisements. It accepts
+// following parameters:
+//
+//	device: the index of the HCI device such as 0 or "hci0" (default: 0)
+//	active: true to send scan requests to receive scan responses
+//	    (default: false)
+//	scan_interval: the interval of scanning in [2.5ms, 10.24s]
+//	    (default: 10ms)
+//	scan_window: the duration of scanning in each interval, which must not
+//	    be longer than scan_interval (default: 10ms)
+//	filter_duplicates: true to let the device report each advertiser only
+//	    once (default: false)
+//	addresses: an array of addresses of advertisers emitted (default: all)
+//	min_rssi: the minimum RSSI of advertisements emitted
+//	manufacturer_data: an array of layouts of manufacturer specific data
+//
+// A layout of manufacturer specific data has company_id, prefix having a hex
+// string that the data starts with, and parameters of frame.NewLayout. The
+// offsets of fields start at the beginning of the data after the company
+// identifier. Data shorter than the layout isn't decoded.
+//
+// The source requires CAP_NET_RAW and CAP_NET_ADMIN, and is only supported
+// on Linux.
+func createBLESource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &bleSource{
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+		open:     openHCISocket,
+	}
+
+	if v, ok := params["device"]; ok {
+		var d int64
+		var err error
+		if v.Type() == data.TypeString {
+			str, _ := data.AsString(v)
+			d, err = strconv.ParseInt(strings.TrimPrefix(str, "hci"), 10, 32)
+		} else {
+			d, err = data.AsInt(v)
+		}
+		if err != nil || d < 0 || d > 0xffff {
+			return nil, fmt.Errorf("'device' parameter must be an index of an HCI device: %v", v)
+		}
+		s.dev = int(d)
+	}
+
+	for key, dst := range map[string]*bool{
+		"active":            &s.active,
+		"filter_duplicates": &s.filterDuplicates,
+	} {
+		if v, ok := params[key]; ok {
+			b, err := data.AsBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter must be bool: %v", key, err)
+			}
+			*dst = b
+		}
+	}
+
+	var interval, window time.Duration
+	for key, dst := range map[string]struct {
+		d   *time.Duration
+		u   *uint16
+		def time.Duration
+	}{
+		"scan_interval": {&interval, &s.interval, defaultBLEScanInterval},
+		"scan_window":   {&window, &s.window, defaultBLEScanWindow},
+	} {
+		*dst.d = dst.def
+		if v, ok := params[key]; ok {
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter should have a duration: %v", key, err)
+			}
+			*dst.d = d
+		}
+		u := *dst.d / bleScanUnit
+		if u < 4 || u > 16384 {
+			return nil, fmt.Errorf("'%v' parameter must be in [2.5ms, 10.24s]: %v", key, *dst.d)
+		}
+		*dst.u = uint16(u)
+	}
+	if window > interval {
+		return nil, fmt.Errorf("'scan_window' parameter must not be longer than 'scan_interval' parameter: %v", window)
+	}
+
+	if v, ok := params["addresses"]; ok {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'addresses' parameter must be an array: %v", err)
+		}
+		s.addresses = map[string]bool{}
+		for _, e := range a {
+			addr, err := data.AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("'addresses' parameter must be an array of strings: %v", err)
+			}
+			s.addresses[strings.ToUpper(addr)] = true
+		}
+	}
+
+	if v, ok := params["min_rssi"]; ok {
+		r, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'min_rssi' parameter must be an integer: %v", err)
+		}
+		s.hasMinRSSI = true
+		s.minRSSI = int(r)
+	}
+
+	if v, ok := params["manufacturer_data"]; ok {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'manufacturer_data' parameter must be an array: %v", err)
+		}
+		for i, e := range a {
+			l, err := newBLEManufacturerLayout(e)
+			if err != nil {
+				return nil, fmt.Errorf("'manufacturer_data' parameter has an invalid layout at %v: %v", i, err)
+			}
+			s.layouts = append(s.layouts, l)
+		}
+	}
+	return s, nil
+}
+
+func newBLEManufacturerLayout(v data.Value) (*bleManufacturerLayout, error) {
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, err
+	}
+	l := &bleManufacturerLayout{}
+	c, ok := m["company_id"]
+	if !ok {
+		return nil, errors.New("company_id is missing")
+	}
+	id, err := data.AsInt(c)
+	if err != nil {
+		return nil, fmt.Errorf("company_id must be an integer: %v", err)
+	}
+	if id < 0 || id > 0xffff {
+		return nil, fmt.Errorf("company_id must be in [0, 65535]: %v", id)
+	}
+	l.companyID = uint16(id)
+
+	if p, ok := m["prefix"]; ok {
+		h, err := data.AsString(p)
+		if err != nil {
+			return nil, fmt.Errorf("prefix must be a string: %v", err)
+		}
+		if l.prefix, err = hex.DecodeString(h); err != nil {
+			return nil, fmt.Errorf("prefix must be a hex string: %v", err)
+		}
+	}
+	if l.layout, err = frame.NewLayout(m); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("ble", SourceCreatorFunc(createBLESource))
+}
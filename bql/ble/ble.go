@@ -0,0 +1,319 @@
+// Package ble encodes HCI commands scanning Bluetooth Low Energy
+// advertisements and decodes advertising reports sent by controllers. It only
+// supports legacy advertisements, which are reported by controllers scanning
+// with the LE Set Scan Enable command.
+package ble
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// CommandPacket and EventPacket are types of HCI packets. A packet sent to
+	// or received from an HCI socket starts with its type.
+	CommandPacket = 0x01
+	EventPacket   = 0x04
+
+	// EventCommandComplete is the code of the Command Complete event.
+	EventCommandComplete = 0x0e
+
+	// EventCommandStatus is the code of the Command Status event.
+	EventCommandStatus = 0x0f
+
+	// EventLEMeta is the code of the LE Meta event, which has LE
+	// Advertising Reports.
+	EventLEMeta = 0x3e
+
+	subeventAdvertisingReport = 0x02
+
+	// OpSetScanParameters and OpSetScanEnable are opcodes of LE Set Scan
+	// Parameters and LE Set Scan Enable commands.
+	OpSetScanParameters = 0x200b
+	OpSetScanEnable     = 0x200c
+)
+
+// Command returns an HCI command packet.
+func Command(opcode uint16, params ...byte) []byte {
+	b := make([]byte, 4, 4+len(params))
+	b[0] = CommandPacket
+	binary.LittleEndian.PutUint16(b[1:], opcode)
+	b[3] = byte(len(params))
+	return append(b, params...)
+}
+
+// SetScanParameters returns an LE Set Scan Parameters command. interval and
+// window are in units of 0.625 ms and must be in [4, 16384]. Scan requests
+// are sent to advertisers when active is true.
+func SetScanParameters(active bool, interval, window uint16) []byte {
+	p := make([]byte, 7)
+	if active {
+		p[0] = 1
+	}
+	binary.LittleEndian.PutUint16(p[1:], interval)
+	binary.LittleEndian.PutUint16(p[3:], window)
+	// p[5] is the own address type, which is public, and p[6] is the filter
+	// policy accepting all advertisements.
+	return Command(OpSetScanParameters, p...)
+}
+
+// SetScanEnable returns an LE Set Scan Enable command. The controller
+// reports each advertiser only once while scanning when filterDuplicates is
+// true.
+func SetScanEnable(enable, filterDuplicates bool) []byte {
+	p := []byte{0, 0}
+	if enable {
+		p[0] = 1
+	}
+	if filterDuplicates {
+		p[1] = 1
+	}
+	return Command(OpSetScanEnable, p...)
+}
+
+// ParseCommandComplete parses a Command Complete or Command Status event
+// packet. It returns false when the packet isn't one of them.
+func ParseCommandComplete(b []byte) (opcode uint16, status byte, ok bool) {
+	if len(b) < 3 || b[0] != EventPacket || int(b[2]) != len(b)-3 {
+		return 0, 0, false
+	}
+	switch p := b[3:]; b[1] {
+	case EventCommandComplete:
+		if len(p) < 4 {
+			return 0, 0, false
+		}
+		return binary.LittleEndian.Uint16(p[1:]), p[3], true
+	case EventCommandStatus:
+		if len(p) < 4 {
+			return 0, 0, false
+		}
+		return binary.LittleEndian.Uint16(p[2:]), p[0], true
+	}
+	return 0, 0, false
+}
+
+// EventType is the type of an advertising report.
+type EventType byte
+
+const (
+	// AdvInd is a connectable undirected advertisement.
+	AdvInd EventType = iota
+
+	// AdvDirectInd is a connectable directed advertisement.
+	AdvDirectInd
+
+	// AdvScanInd is a scannable undirected advertisement.
+	AdvScanInd
+
+	// AdvNonconnInd is a non-connectable undirected advertisement such as a
+	// beacon.
+	AdvNonconnInd
+
+	// ScanRsp is a scan response to an active scan.
+	ScanRsp
+)
+
+func (t EventType) String() string {
+	switch t {
+	case AdvInd:
+		return "adv_ind"
+	case AdvDirectInd:
+		return "adv_direct_ind"
+	case AdvScanInd:
+		return "adv_scan_ind"
+	case AdvNonconnInd:
+		return "adv_nonconn_ind"
+	case ScanRsp:
+		return "scan_rsp"
+	}
+	return fmt.Sprintf("unknown(%d)", byte(t))
+}
+
+// Advertisement is an advertising report.
+type Advertisement struct {
+	EventType EventType
+
+	// Address is the device address such as "AA:BB:CC:DD:EE:FF".
+	// RandomAddress is true when it's a random address.
+	Address       string
+	RandomAddress bool
+
+	// RSSI is the received signal strength in dBm.
+	RSSI int
+
+	// Data is the advertising data.
+	Data []byte
+}
+
+// ParseEvent parses an HCI event packet. It returns advertisements when the
+// packet is an LE Meta event having LE Advertising Reports. It returns nil
+// for other events.
+func ParseEvent(b []byte) ([]*Advertisement, error) {
+	if len(b) < 3 || b[0] != EventPacket {
+		return nil, errors.New("not an HCI event packet")
+	}
+	if int(b[2]) != len(b)-3 {
+		return nil, fmt.Errorf("the event has %v bytes of parameters but its length is %v", len(b)-3, b[2])
+	}
+	if b[1] != EventLEMeta || len(b) < 4 || b[3] != subeventAdvertisingReport {
+		return nil, nil
+	}
+	p := b[4:]
+	if len(p) < 1 {
+		return nil, errors.New("the number of reports is missing")
+	}
+	n := int(p[0])
+	p = p[1:]
+
+	// Reports are laid out one after another as Linux reads them.
+	var as []*Advertisement
+	for i := 0; i < n; i++ {
+		if len(p) < 9 {
+			return nil, fmt.Errorf("report %v is truncated", i)
+		}
+		a := &Advertisement{
+			EventType:     EventType(p[0]),
+			RandomAddress: p[1] != 0,
+			Address:       formatAddress(p[2:8]),
+		}
+		l := int(p[8])
+		if len(p) < 9+l+1 {
+			return nil, fmt.Errorf("report %v is truncated", i)
+		}
+		a.Data = append([]byte(nil), p[9:9+l]...)
+		a.RSSI = int(int8(p[9+l]))
+		as = append(as, a)
+		p = p[9+l+1:]
+	}
+	return as, nil
+}
+
+// formatAddress formats an address sent in the little endian.
+func formatAddress(b []byte) string {
+	s := make([]string, len(b))
+	for i := range b {
+		s[i] = fmt.Sprintf("%02X", b[len(b)-1-i])
+	}
+	return strings.Join(s, ":")
+}
+
+// Types of AD structures in advertising data.
+const (
+	adFlags                 = 0x01
+	adIncomplete16BitUUIDs  = 0x02
+	adComplete16BitUUIDs    = 0x03
+	adIncomplete32BitUUIDs  = 0x04
+	adComplete32BitUUIDs    = 0x05
+	adIncomplete128BitUUIDs = 0x06
+	adComplete128BitUUIDs   = 0x07
+	adShortLocalName        = 0x08
+	adCompleteLocalName     = 0x09
+	adTxPower               = 0x0a
+	adServiceData16BitUUID  = 0x16
+	adManufacturerData      = 0xff
+)
+
+// AdvertisingData is decoded advertising data. Unsupported AD structures
+// are ignored.
+type AdvertisingData struct {
+	// Flags is nil when the data doesn't have flags.
+	Flags *byte
+
+	// LocalName is the complete or shortened local name.
+	LocalName string
+
+	// TxPower is nil when the data doesn't have the TX power level.
+	TxPower *int
+
+	// Services are UUIDs of services. 16-bit and 32-bit UUIDs are formatted
+	// as hex strings such as "180f", and 128-bit UUIDs are formatted as
+	// "0000180f-0000-1000-8000-00805f9b34fb".
+	Services []string
+
+	// ServiceData is data of services having 16-bit UUIDs.
+	ServiceData map[string][]byte
+
+	// CompanyID is the company identifier of the manufacturer specific data.
+	// ManufacturerData is the data following the identifier. It's nil when
+	// the advertising data doesn't have manufacturer specific data.
+	CompanyID        uint16
+	ManufacturerData []byte
+}
+
+// ParseAdvertisingData parses AD structures in advertising data.
+func ParseAdvertisingData(b []byte) (*AdvertisingData, error) {
+	d := &AdvertisingData{}
+	for len(b) > 0 {
+		l := int(b[0])
+		if l == 0 {
+			// The rest of the data is padding.
+			break
+		}
+		if len(b) < 1+l {
+			return nil, fmt.Errorf("an AD structure of %v bytes is truncated to %v bytes", l, len(b)-1)
+		}
+		t, v := b[1], b[2:1+l]
+		b = b[1+l:]
+
+		switch t {
+		case adFlags:
+			if len(v) >= 1 {
+				f := v[0]
+				d.Flags = &f
+			}
+		case adIncomplete16BitUUIDs, adComplete16BitUUIDs:
+			d.Services = appendUUIDs(d.Services, v, 2)
+		case adIncomplete32BitUUIDs, adComplete32BitUUIDs:
+			d.Services = appendUUIDs(d.Services, v, 4)
+		case adIncomplete128BitUUIDs, adComplete128BitUUIDs:
+			d.Services = appendUUIDs(d.Services, v, 16)
+		case adShortLocalName:
+			if d.LocalName == "" {
+				d.LocalName = string(v)
+			}
+		case adCompleteLocalName:
+			d.LocalName = string(v)
+		case adTxPower:
+			if len(v) >= 1 {
+				p := int(int8(v[0]))
+				d.TxPower = &p
+			}
+		case adServiceData16BitUUID:
+			if len(v) < 2 {
+				return nil, errors.New("service data doesn't have a UUID")
+			}
+			if d.ServiceData == nil {
+				d.ServiceData = map[string][]byte{}
+			}
+			d.ServiceData[formatUUID(v[:2])] = append([]byte{}, v[2:]...)
+		case adManufacturerData:
+			if len(v) < 2 {
+				return nil, errors.New("manufacturer specific data doesn't have a company identifier")
+			}
+			d.CompanyID = binary.LittleEndian.Uint16(v)
+			d.ManufacturerData = append([]byte{}, v[2:]...)
+		}
+	}
+	return d, nil
+}
+
+func appendUUIDs(uuids []string, b []byte, size int) []string {
+	for ; len(b) >= size; b = b[size:] {
+		uuids = append(uuids, formatUUID(b[:size]))
+	}
+	return uuids
+}
+
+// formatUUID formats a UUID sent in the little endian.
+func formatUUID(b []byte) string {
+	s := make([]byte, 0, len(b)*2+4)
+	for i := len(b) - 1; i >= 0; i-- {
+		s = append(s, fmt.Sprintf("%02x", b[i])...)
+		if len(b) == 16 && (i == 12 || i == 10 || i == 8 || i == 6) {
+			s = append(s, '-')
+		}
+	}
+	return string(s)
+}
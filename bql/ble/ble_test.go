@@ -0,0 +1,153 @@
+package ble
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+// iBeacon is advertising data of an iBeacon having the major 1 and the minor
+// 258.
+var iBeacon = []byte{
+	0x02, 0x01, 0x06,
+	0x1a, 0xff, 0x4c, 0x00, 0x02, 0x15,
+	0xf7, 0x82, 0x6d, 0xa6, 0x4f, 0xa2, 0x4e, 0x98, 0x80, 0x24, 0xbc, 0x5b, 0x71, 0xe0, 0x89, 0x3e,
+	0x00, 0x01, 0x01, 0x02, 0xc5,
+}
+
+// report returns an LE Meta event having an LE Advertising Report.
+func report(t EventType, random bool, addr []byte, rssi int8, d []byte) []byte {
+	p := []byte{subeventAdvertisingReport, 1, byte(t), 0}
+	if random {
+		p[3] = 1
+	}
+	for i := len(addr) - 1; i >= 0; i-- {
+		p = append(p, addr[i])
+	}
+	p = append(p, byte(len(d)))
+	p = append(p, d...)
+	p = append(p, byte(rssi))
+	return append([]byte{EventPacket, EventLEMeta, byte(len(p))}, p...)
+}
+
+func TestCommands(t *testing.T) {
+	Convey("Given commands", t, func() {
+		Convey("When encoding them", func() {
+			p := SetScanParameters(true, 16, 8)
+			e := SetScanEnable(true, false)
+
+			Convey("Then they should be HCI command packets", func() {
+				So(p, ShouldResemble, []byte{0x01, 0x0b, 0x20, 7, 1, 16, 0, 8, 0, 0, 0})
+				So(e, ShouldResemble, []byte{0x01, 0x0c, 0x20, 2, 1, 0})
+			})
+		})
+
+		Convey("When parsing their results", func() {
+			op, status, ok := ParseCommandComplete([]byte{0x04, 0x0e, 4, 1, 0x0c, 0x20, 0x0c})
+			op2, status2, ok2 := ParseCommandComplete([]byte{0x04, 0x0f, 4, 0x01, 1, 0x0b, 0x20})
+			_, _, ok3 := ParseCommandComplete([]byte{0x04, 0x0e, 5, 1, 0x0c, 0x20, 0x0c})
+
+			Convey("Then opcodes and statuses should be returned", func() {
+				So(ok, ShouldBeTrue)
+				So(op, ShouldEqual, OpSetScanEnable)
+				So(status, ShouldEqual, 0x0c)
+				So(ok2, ShouldBeTrue)
+				So(op2, ShouldEqual, OpSetScanParameters)
+				So(status2, ShouldEqual, 1)
+				So(ok3, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestParseEvent(t *testing.T) {
+	Convey("Given an advertising report", t, func() {
+		b := report(AdvNonconnInd, true, []byte{0xc1, 0x02, 0x03, 0x04, 0x05, 0x06}, -60, iBeacon)
+
+		Convey("When parsing it", func() {
+			as, err := ParseEvent(b)
+
+			Convey("Then it should have an advertisement", func() {
+				So(err, ShouldBeNil)
+				So(as, ShouldResemble, []*Advertisement{{
+					EventType:     AdvNonconnInd,
+					Address:       "C1:02:03:04:05:06",
+					RandomAddress: true,
+					RSSI:          -60,
+					Data:          iBeacon,
+				}})
+				So(as[0].EventType.String(), ShouldEqual, "adv_nonconn_ind")
+			})
+		})
+
+		Convey("When parsing truncated reports", func() {
+			Convey("Then it should fail", func() {
+				for i := 4; i < len(b); i++ {
+					c := append([]byte{}, b[:i]...)
+					c[2] = byte(i - 3)
+					_, err := ParseEvent(c)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When parsing other events", func() {
+			as, err := ParseEvent([]byte{0x04, 0x0e, 4, 1, 0x0c, 0x20, 0x00})
+
+			Convey("Then it should return nothing", func() {
+				So(err, ShouldBeNil)
+				So(as, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestParseAdvertisingData(t *testing.T) {
+	Convey("Given advertising data", t, func() {
+		Convey("When parsing an iBeacon", func() {
+			d, err := ParseAdvertisingData(iBeacon)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have flags and manufacturer specific data", func() {
+				So(*d.Flags, ShouldEqual, 0x06)
+				So(d.CompanyID, ShouldEqual, 0x004c)
+				So(d.ManufacturerData, ShouldResemble, iBeacon[7:])
+			})
+		})
+
+		Convey("When parsing data having services and a name", func() {
+			d, err := ParseAdvertisingData([]byte{
+				0x05, 0x03, 0x0f, 0x18, 0x0a, 0x18,
+				0x11, 0x07, 0xfb, 0x34, 0x9b, 0x5f, 0x80, 0x00, 0x00, 0x80, 0x00, 0x10, 0x00, 0x00, 0x0d, 0x18, 0x00, 0x00,
+				0x04, 0x16, 0x0f, 0x18, 0x64,
+				0x05, 0x08, 'T', 'h', 'e', 'r',
+				0x07, 0x09, 'T', 'h', 'e', 'r', 'm', 'o',
+				0x02, 0x0a, 0xf4,
+				0x00, 0x00,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be decoded", func() {
+				So(d.Services, ShouldResemble, []string{"180f", "180a", "0000180d-0000-1000-8000-00805f9b34fb"})
+				So(d.ServiceData, ShouldResemble, map[string][]byte{"180f": {0x64}})
+				So(d.LocalName, ShouldEqual, "Thermo")
+				So(*d.TxPower, ShouldEqual, -12)
+				So(d.Flags, ShouldBeNil)
+				So(d.ManufacturerData, ShouldBeNil)
+			})
+		})
+
+		Convey("When parsing malformed data", func() {
+			Convey("Then it should fail", func() {
+				for _, b := range [][]byte{
+					{0x03, 0x01},
+					{0x05},
+					{0x02, 0x16, 0x0f},
+					{0x02, 0xff, 0x4c},
+				} {
+					_, err := ParseAdvertisingData(b)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,17 @@
+//go:build linux && !386
+// +build linux,!386
+
+package bql
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// hciBind binds a socket to an address, which syscall.Bind doesn't support.
+func hciBind(fd int, addr unsafe.Pointer, size uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_BIND, uintptr(fd), uintptr(addr), size); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package bql
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// sysBind is the number of bind(2) called via socketcall(2).
+const sysBind = 2
+
+// hciBind binds a socket to an address, which syscall.Bind doesn't support.
+// Socket system calls are multiplexed by socketcall(2) on 386.
+func hciBind(fd int, addr unsafe.Pointer, size uintptr) error {
+	// The address is copied to the heap since it's passed as uintptr in
+	// args, which must not be moved by growing the stack.
+	buf := make([]byte, size)
+	copy(buf, unsafe.Slice((*byte)(addr), size))
+	args := [3]uintptr{uintptr(fd), uintptr(unsafe.Pointer(&buf[0])), size}
+	_, _, errno := syscall.Syscall(syscall.SYS_SOCKETCALL, sysBind, uintptr(unsafe.Pointer(&args)), 0)
+	runtime.KeepAlive(buf)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+//go:build linux
+// +build linux
+
+package bql
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	btprotoHCI     = 1
+	hciChannelRaw  = 0
+	solHCI         = 0
+	hciFilterOpt   = 2
+	hciEventPacket = 0x04
+)
+
+// hciFilter is struct hci_filter of Linux.
+type hciFilter struct {
+	typeMask  uint32
+	eventMask [2]uint32
+	opcode    uint16
+	_         uint16
+}
+
+// openHCISocket opens a raw socket of the HCI device hci<dev>, which only
+// receives events related to scanning. It requires CAP_NET_RAW and
+// CAP_NET_ADMIN. Read returns an HCI packet and Write sends one.
+func openHCISocket(dev int) (io.ReadWriteCloser, error) {
+	fd, err := syscall.Socket(syscall.AF_BLUETOOTH,
+		syscall.SOCK_RAW|syscall.SOCK_CLOEXEC|syscall.SOCK_NONBLOCK, btprotoHCI)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create an HCI socket: %v", err)
+	}
+
+	// struct sockaddr_hci
+	addr := struct {
+		family  uint16
+		dev     uint16
+		channel uint16
+	}{syscall.AF_BLUETOOTH, uint16(dev), hciChannelRaw}
+	if err := hciBind(fd, unsafe.Pointer(&addr), unsafe.Sizeof(addr)); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("cannot bind the HCI socket to hci%v: %v", dev, err)
+	}
+
+	f := hciFilter{typeMask: 1 << hciEventPacket}
+	for _, e := range []uint{0x0e, 0x0f, 0x3e} { // Command Complete, Command Status, LE Meta
+		f.eventMask[e/32] |= 1 << (e % 32)
+	}
+	fb := (*[unsafe.Sizeof(hciFilter{})]byte)(unsafe.Pointer(&f))
+	if err := syscall.SetsockoptString(fd, solHCI, hciFilterOpt, string(fb[:])); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("cannot set the filter of the HCI socket: %v", err)
+	}
+
+	// The file is polled by the runtime because it's non-blocking, so that
+	// closing it aborts reading.
+	return os.NewFile(uintptr(fd), fmt.Sprintf("hci%v", dev)), nil
+}
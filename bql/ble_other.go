@@ -0,0 +1,15 @@
+//go:build !linux
+// +build !linux
+
+package bql
+
+import (
+	"errors"
+	"io"
+)
+
+// openHCISocket opens a raw socket of an HCI device, which is only supported
+// on Linux.
+func openHCISocket(dev int) (io.ReadWriteCloser, error) {
+	return nil, errors.New("BLE scanning is only supported on Linux")
+}
@@ -0,0 +1,315 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/ble"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeHCIConn is an HCI socket of a fake controller. It completes commands
+// with statuses and sends reports after scanning is enabled.
+type fakeHCIConn struct {
+	statuses map[uint16]byte
+	reports  [][]byte
+	packets  chan []byte
+
+	m        sync.Mutex
+	commands [][]byte
+	closed   chan struct{}
+}
+
+func newFakeHCIConn(statuses map[uint16]byte, reports ...[]byte) *fakeHCIConn {
+	return &fakeHCIConn{
+		statuses: statuses,
+		reports:  reports,
+		packets:  make(chan []byte, len(reports)+8),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (c *fakeHCIConn) Read(b []byte) (int, error) {
+	select {
+	case p := <-c.packets:
+		return copy(b, p), nil
+	case <-c.closed:
+		return 0, io.EOF
+	}
+}
+
+func (c *fakeHCIConn) Write(b []byte) (int, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	select {
+	case <-c.closed:
+		return 0, errors.New("closed")
+	default:
+	}
+	c.commands = append(c.commands, append([]byte{}, b...))
+
+	op := uint16(b[1]) | uint16(b[2])<<8
+	status := c.statuses[op]
+	c.packets <- []byte{ble.EventPacket, ble.EventCommandComplete, 4, 1, b[1], b[2], status}
+	if op == ble.OpSetScanEnable && b[4] == 1 && status == 0 {
+		for _, r := range c.reports {
+			c.packets <- r
+		}
+	}
+	return len(b), nil
+}
+
+func (c *fakeHCIConn) Close() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	select {
+	case <-c.closed:
+	default:
+		close(c.closed)
+	}
+	return nil
+}
+
+func (c *fakeHCIConn) Commands() [][]byte {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.commands
+}
+
+// bleReport returns an LE Meta event having an advertising report from a
+// public address.
+func bleReport(addr []byte, rssi int8, d []byte) []byte {
+	p := []byte{0x02, 1, byte(ble.AdvNonconnInd), 0}
+	for i := len(addr) - 1; i >= 0; i-- {
+		p = append(p, addr[i])
+	}
+	p = append(p, byte(len(d)))
+	p = append(p, d...)
+	p = append(p, byte(rssi))
+	return append([]byte{ble.EventPacket, ble.EventLEMeta, byte(len(p))}, p...)
+}
+
+func TestBLESource(t *testing.T) {
+	iBeacon := []byte{
+		0x02, 0x01, 0x06,
+		0x1a, 0xff, 0x4c, 0x00, 0x02, 0x15,
+		0xf7, 0x82, 0x6d, 0xa6, 0x4f, 0xa2, 0x4e, 0x98, 0x80, 0x24, 0xbc, 0x5b, 0x71, 0xe0, 0x89, 0x3e,
+		0x00, 0x01, 0x01, 0x02, 0xc5,
+	}
+	thermometer := []byte{
+		0x03, 0x03, 0x0f, 0x18,
+		0x04, 0x16, 0x0f, 0x18, 0x64,
+		0x07, 0x09, 'T', 'h', 'e', 'r', 'm', 'o',
+		0x02, 0x0a, 0xf4,
+	}
+	beacon := []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x01}
+	sensor := []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x02}
+
+	Convey("Given a BLE source", t, func() {
+		ctx := core.NewContext(nil)
+
+		// run runs the source with conns and returns n tuples emitted.
+		run := func(params data.Map, n int, conns ...*fakeHCIConn) []*core.Tuple {
+			src, err := createBLESource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			var m sync.Mutex
+			src.(*bleSource).open = func(dev int) (io.ReadWriteCloser, error) {
+				m.Lock()
+				defer m.Unlock()
+				if len(conns) == 0 {
+					return nil, errors.New("no device")
+				}
+				c := conns[0]
+				conns = conns[1:]
+				return c, nil
+			}
+			s := core.NewSupervisedSource(src, &core.SupervisorConfig{
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+			})
+
+			ch := make(chan *core.Tuple, n)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					ch <- t
+					return nil
+				}))
+			}()
+			ts := make([]*core.Tuple, n)
+			for i := range ts {
+				ts[i] = <-ch
+			}
+			So(s.Stop(ctx), ShouldBeNil)
+			So(<-done, ShouldBeNil)
+			So(s.Stop(ctx), ShouldBeNil)
+			return ts
+		}
+
+		Convey("When scanning advertisements", func() {
+			c := newFakeHCIConn(nil,
+				[]byte{ble.EventPacket, ble.EventLEMeta, 2, 0x02}, // malformed
+				bleReport(beacon, -60, iBeacon),
+				bleReport(sensor, -70, thermometer))
+			ts := run(data.Map{
+				"active":      data.True,
+				"scan_window": data.String("5ms"),
+				"manufacturer_data": data.Array{
+					data.Map{
+						"company_id": data.Int(0x4c),
+						"prefix":     data.String("0216"),
+						"fields": data.Array{
+							data.Map{"name": data.String("other"), "offset": data.Int(0), "type": data.String("uint8")},
+						},
+					},
+					data.Map{
+						"company_id": data.Int(0x4c),
+						"prefix":     data.String("0215"),
+						"fields": data.Array{
+							data.Map{"name": data.String("major"), "offset": data.Int(18), "type": data.String("uint16")},
+							data.Map{"name": data.String("minor"), "offset": data.Int(20), "type": data.String("uint16")},
+							data.Map{"name": data.String("tx_power"), "offset": data.Int(22), "type": data.String("int8")},
+						},
+					},
+				},
+			}, 2, c)
+
+			Convey("Then scanning should be enabled with the parameters", func() {
+				cs := c.Commands()
+				So(len(cs), ShouldBeGreaterThanOrEqualTo, 3)
+				So(cs[0], ShouldResemble, ble.SetScanEnable(false, false))
+				So(cs[1], ShouldResemble, ble.SetScanParameters(true, 16, 8))
+				So(cs[2], ShouldResemble, ble.SetScanEnable(true, false))
+			})
+
+			Convey("Then advertisements should be emitted", func() {
+				So(ts[0].Data, ShouldResemble, data.Map{
+					"address":           data.String("AA:BB:CC:DD:EE:01"),
+					"address_type":      data.String("public"),
+					"event_type":        data.String("adv_nonconn_ind"),
+					"rssi":              data.Int(-60),
+					"data":              data.Blob(iBeacon),
+					"flags":             data.Int(6),
+					"company_id":        data.Int(0x4c),
+					"manufacturer_data": data.Blob(iBeacon[7:]),
+					"manufacturer": data.Map{
+						"major":    data.Int(1),
+						"minor":    data.Int(258),
+						"tx_power": data.Int(-59),
+					},
+				})
+				So(ts[1].Data, ShouldResemble, data.Map{
+					"address":      data.String("AA:BB:CC:DD:EE:02"),
+					"address_type": data.String("public"),
+					"event_type":   data.String("adv_nonconn_ind"),
+					"rssi":         data.Int(-70),
+					"data":         data.Blob(thermometer),
+					"name":         data.String("Thermo"),
+					"tx_power":     data.Int(-12),
+					"services":     data.Array{data.String("180f")},
+					"service_data": data.Map{"180f": data.Blob{0x64}},
+				})
+			})
+
+			Convey("Then the device should be closed", func() {
+				_, err := c.Write(ble.SetScanEnable(false, false))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When filtering advertisements", func() {
+			c := newFakeHCIConn(nil,
+				bleReport(beacon, -60, iBeacon),
+				bleReport(sensor, -90, thermometer),
+				bleReport(sensor, -70, thermometer))
+			ts := run(data.Map{
+				"addresses": data.Array{data.String("aa:bb:cc:dd:ee:02")},
+				"min_rssi":  data.Int(-80),
+			}, 1, c)
+
+			Convey("Then only advertisements matching filters should be emitted", func() {
+				So(ts[0].Data["address"], ShouldEqual, data.String("AA:BB:CC:DD:EE:02"))
+				So(ts[0].Data["rssi"], ShouldEqual, data.Int(-70))
+			})
+		})
+
+		Convey("When the device rejects a command", func() {
+			c1 := newFakeHCIConn(map[uint16]byte{ble.OpSetScanParameters: 0x12}, bleReport(beacon, -60, iBeacon))
+			c2 := newFakeHCIConn(nil, bleReport(sensor, -70, thermometer))
+			ts := run(data.Map{}, 1, c1, c2)
+
+			Convey("Then the device should be reopened after disabling scanning", func() {
+				cs := c1.Commands()
+				So(len(cs), ShouldEqual, 3)
+				So(cs[2], ShouldResemble, ble.SetScanEnable(false, false))
+				So(ts[0].Data["address"], ShouldEqual, data.String("AA:BB:CC:DD:EE:02"))
+			})
+		})
+
+		Convey("When the device isn't available without the supervisor", func() {
+			s, err := createBLESource(ctx, &IOParams{}, data.Map{})
+			So(err, ShouldBeNil)
+			s.(*bleSource).open = func(dev int) (io.ReadWriteCloser, error) {
+				return nil, errors.New("no device")
+			}
+			err = s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				return nil
+			}))
+
+			Convey("Then GenerateStream should return the error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "no device")
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		ctx := core.NewContext(nil)
+		layout := func(k string, v data.Value) data.Map {
+			m := data.Map{
+				"company_id": data.Int(0x4c),
+				"fields": data.Array{
+					data.Map{"name": data.String("v"), "offset": data.Int(0), "type": data.String("uint8")},
+				},
+			}
+			m[k] = v
+			return m
+		}
+
+		Convey("When creating sources", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{"device": data.String("usb0")},
+					{"device": data.Int(-1)},
+					{"active": data.String("yes")},
+					{"scan_interval": data.String("2ms")},
+					{"scan_window": data.String("11s")},
+					{"scan_interval": data.String("20ms"), "scan_window": data.String("30ms")},
+					{"addresses": data.String("AA:BB:CC:DD:EE:FF")},
+					{"addresses": data.Array{data.Int(1)}},
+					{"min_rssi": data.String("strong")},
+					{"manufacturer_data": data.Map{}},
+					{"manufacturer_data": data.Array{layout("company_id", data.Int(0x10000))}},
+					{"manufacturer_data": data.Array{layout("prefix", data.String("xyz"))}},
+					{"manufacturer_data": data.Array{layout("fields", data.Array{})}},
+				} {
+					_, err := createBLESource(ctx, &IOParams{}, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When creating a source with a device name", func() {
+			s, err := createBLESource(ctx, &IOParams{}, data.Map{"device": data.String("hci1")})
+
+			Convey("Then it should have the index", func() {
+				So(err, ShouldBeNil)
+				So(s.(*bleSource).dev, ShouldEqual, 1)
+			})
+		})
+	})
+}
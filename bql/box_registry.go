@@ -0,0 +1,151 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"sync"
+)
+
+// BoxCreator is an interface which creates instances of a Box.
+type BoxCreator interface {
+	// CreateBox creates a new Box instance using given parameters.
+	CreateBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error)
+}
+
+type boxCreatorFunc func(*core.Context, *IOParams, data.Map) (core.Box, error)
+
+func (f boxCreatorFunc) CreateBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	return f(ctx, ioParams, params)
+}
+
+// BoxCreatorFunc creates a BoxCreator from a function.
+func BoxCreatorFunc(f func(*core.Context, *IOParams, data.Map) (core.Box, error)) BoxCreator {
+	return boxCreatorFunc(f)
+}
+
+// BoxCreatorRegistry manages creators of Boxs.
+type BoxCreatorRegistry interface {
+	// Register adds a Box creator to the registry. It returns an error if
+	// the type name is already registered.
+	Register(typeName string, c BoxCreator) error
+
+	// Lookup returns a Box creator having the type name. It returns
+	// core.NotExistError if it doesn't have the creator.
+	Lookup(typeName string) (BoxCreator, error)
+
+	// List returns all creators the registry has. The caller can safely modify
+	// the map returned from this method.
+	List() (map[string]BoxCreator, error)
+
+	// Unregister removes a creator from the registry. It returns core.NotExistError
+	// when the registry doesn't have a creator having the type name.
+	//
+	// The registry itself doesn't support cascading delete. It should properly
+	// done by the caller.
+	Unregister(typeName string) error
+}
+
+type defaultBoxCreatorRegistry struct {
+	m        sync.RWMutex
+	creators map[string]BoxCreator
+}
+
+// NewDefaultBoxCreatorRegistry returns a BoxCreatorRegistry having a
+// default implementation.
+func NewDefaultBoxCreatorRegistry() BoxCreatorRegistry {
+	return &defaultBoxCreatorRegistry{
+		creators: map[string]BoxCreator{},
+	}
+}
+
+func (r *defaultBoxCreatorRegistry) Register(typeName string, c BoxCreator) error {
+	if err := core.ValidateSymbol(typeName); err != nil {
+		return fmt.Errorf("invalid name for box type: %s", err.Error())
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	lowerName := strings.ToLower(typeName)
+	if _, ok := r.creators[lowerName]; ok {
+		return fmt.Errorf("box type '%v' is already registered", typeName)
+	}
+	r.creators[lowerName] = c
+	return nil
+}
+
+func (r *defaultBoxCreatorRegistry) Lookup(typeName string) (BoxCreator, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if c, ok := r.creators[strings.ToLower(typeName)]; ok {
+		return c, nil
+	}
+	return nil, core.NotExistError(fmt.Errorf("box type '%v' is not registered", typeName))
+}
+
+func (r *defaultBoxCreatorRegistry) List() (map[string]BoxCreator, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	m := make(map[string]BoxCreator, len(r.creators))
+	for t, c := range r.creators {
+		m[t] = c
+	}
+	return m, nil
+}
+
+func (r *defaultBoxCreatorRegistry) Unregister(typeName string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	tn := strings.ToLower(typeName)
+	if _, ok := r.creators[tn]; !ok {
+		return core.NotExistError(fmt.Errorf("box type '%v' is not registered", typeName))
+	}
+	delete(r.creators, tn)
+	return nil
+}
+
+var (
+	globalBoxCreatorRegistry = NewDefaultBoxCreatorRegistry()
+)
+
+// RegisterGlobalBoxCreator adds a BoxCreator which can be referred from
+// all topologies. BoxCreators registered after running topologies might not
+// be seen by those topologies. Call it from init functions to avoid such
+// conditions.
+func RegisterGlobalBoxCreator(typeName string, c BoxCreator) error {
+	return globalBoxCreatorRegistry.Register(typeName, c)
+}
+
+// MustRegisterGlobalBoxCreator is like RegisterGlobalBoxCreator but panics
+// if an error occurred.
+func MustRegisterGlobalBoxCreator(typeName string, c BoxCreator) {
+	if err := globalBoxCreatorRegistry.Register(typeName, c); err != nil {
+		panic(fmt.Errorf("bql.MustRegisterGlobalBoxCreator: cannot register '%v': %v", typeName, err))
+	}
+}
+
+// CopyGlobalBoxCreatorRegistry creates a new independent copy of the global
+// BoxCreatorRegistry.
+func CopyGlobalBoxCreatorRegistry() (BoxCreatorRegistry, error) {
+	return CopyBoxCreatorRegistry(globalBoxCreatorRegistry)
+}
+
+// CopyBoxCreatorRegistry creates a new independent copy of the given
+// BoxCreatorRegistry.
+func CopyBoxCreatorRegistry(src BoxCreatorRegistry) (BoxCreatorRegistry, error) {
+	r := NewDefaultBoxCreatorRegistry()
+	m, err := src.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for t, c := range m {
+		if err := r.Register(t, c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,136 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func createForwardBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	return core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+		return w.Write(ctx, t)
+	}), nil
+}
+
+func TestEmptyDefaultBoxCreatorRegistry(t *testing.T) {
+	Convey("Given an empty default Box registry", t, func() {
+		r := NewDefaultBoxCreatorRegistry()
+
+		Convey("When adding a creator function", func() {
+			err := r.Register("test_box", BoxCreatorFunc(createForwardBox))
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When looking up a nonexistent creator", func() {
+			_, err := r.Lookup("test_box")
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When retrieving a list of creators", func() {
+			m, err := r.List()
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And the list should be empty", func() {
+					So(m, ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When unregistering a nonexistent creator", func() {
+			err := r.Unregister("test_box")
+
+			Convey("Then it shouldn't fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestDefaultBoxCreatorRegistry(t *testing.T) {
+	ctx := core.NewContext(nil)
+
+	Convey("Given an default Box registry having two types", t, func() {
+		r := NewDefaultBoxCreatorRegistry()
+		So(r.Register("TEST_box", BoxCreatorFunc(createForwardBox)), ShouldBeNil)
+		So(r.Register("TEST_box2", BoxCreatorFunc(createForwardBox)), ShouldBeNil)
+
+		Convey("When adding a new type having the registered type name", func() {
+			err := r.Register("TEST_BOX", BoxCreatorFunc(createForwardBox))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When looking up a creator", func() {
+			c, err := r.Lookup("TEST_BOX2")
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And it should have the expected type", func() {
+					s, err := c.CreateBox(ctx, &IOParams{}, nil)
+					So(err, ShouldBeNil)
+					So(s, ShouldHaveSameTypeAs, core.BoxFunc(nil))
+				})
+			})
+		})
+
+		Convey("When retrieving a list of creators", func() {
+			m, err := r.List()
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And the list should have all creators", func() {
+					So(len(m), ShouldEqual, 2)
+					So(m["test_box"], ShouldNotBeNil)
+					So(m["test_box2"], ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("When unregistering a creator", func() {
+			err := r.Unregister("test_BOX")
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And the unregistered creator shouldn't be found", func() {
+					_, err := r.Lookup("test_box")
+					So(core.IsNotExist(err), ShouldBeTrue)
+				})
+
+				Convey("And the other creator should be found", func() {
+					_, err := r.Lookup("test_box2")
+					So(err, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
+
+func TestGlobalBoxCreatorRegistry(t *testing.T) {
+	Convey("Given a default Global Box registry", t, func() {
+		r, err := CopyGlobalBoxCreatorRegistry()
+		So(r, ShouldNotBeNil)
+		So(err, ShouldBeNil)
+
+		Convey("When looking up a predefined script creator", func() {
+			_, err := r.Lookup("SCRIPT")
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -1,17 +1,22 @@
 package bql
 
 import (
+	"errors"
 	"github.com/Sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"math/rand"
 	"sync"
 	"time"
 )
 
 type bqlBox struct {
+	// name is the name of the box in the topology. It's used to report
+	// metrics.
+	name string
 	// stmt is the BQL statement executed by this box
 	stmt *parser.SelectStmt
 	// reg holds functions that can be used in this box
@@ -32,12 +37,18 @@ type bqlBox struct {
 	// emitterSamplingType holds a value different from
 	// parser.UnspecifiedSamplingType if output sampling is active
 	emitterSamplingType parser.EmitterSamplingType
+	// inputSampling holds a value in (0,1] if this box should only
+	// process a randomly chosen subset of input tuples
+	inputSampling float64
 	// genCount holds the number of items generated so far
 	// (i.e. computed by the underlying execution plan). this is only
 	// used if the count-based sampling is active.
 	genCount int64
 	// emitCount holds the number of items emitted so far
 	emitCount int64
+	// numEvicted holds the number of tuples evicted from windows of
+	// the plan which have already been reported
+	numEvicted int64
 	// lastTuple points to the last tuple that was generated by
 	// the underlying plan.
 	lastTuple *core.Tuple
@@ -50,6 +61,30 @@ type bqlBox struct {
 	// removeMe is a function to remove this bqlBox from its
 	// topology. A nil check must be done before calling.
 	removeMe func()
+	// spill is the configuration to spill large windows to disk.
+	// Spilling is disabled when it's nil.
+	spill *execution.SpillConfig
+	// arena enables the plan to reuse temporary structures when it's
+	// supported by the plan.
+	arena bool
+	// windowPool shares window buffers of the plan with other boxes having
+	// identical windows. Windows aren't shared when it's nil.
+	windowPool *execution.WindowBufferPool
+	// parallelAggregation is the configuration to evaluate groups in
+	// parallel. Groups are evaluated sequentially when it's nil.
+	parallelAggregation *execution.ParallelAggregation
+	// triggers holds input names of triggers. When it isn't empty, this
+	// box only emits results when it receives a tuple from one of them.
+	triggers []string
+	// evictionHook is called with tuples leaving windows of the plan. It's
+	// set to the plan in Init before spilling is enabled.
+	evictionHook execution.EvictionHook
+	// keyTTL is how long state of each key, such as the last row emitted
+	// for a key of WHEN CHANGED BY, is kept after the key was seen last
+	// time. State is kept forever when it's 0.
+	keyTTL time.Duration
+	// keyed is true when the plan keeps state for each key.
+	keyed bool
 }
 
 func NewBQLBox(stmt *parser.SelectStmt, reg udf.FunctionRegistry) *bqlBox {
@@ -65,6 +100,8 @@ func (b *bqlBox) Init(ctx *core.Context) error {
 	b.emitterLimit = analyzedPlan.EmitterLimit
 	b.emitterSampling = analyzedPlan.EmitterSampling
 	b.emitterSamplingType = analyzedPlan.EmitterSamplingType
+	b.inputSampling = analyzedPlan.InputSampling
+	b.keyed = len(analyzedPlan.EmitterChangeKeys) > 0
 	optimizedPlan, err := analyzedPlan.LogicalOptimize()
 	if err != nil {
 		return err
@@ -73,6 +110,63 @@ func (b *bqlBox) Init(ctx *core.Context) error {
 	if err != nil {
 		return err
 	}
+	if p, ok := b.execPlan.(execution.ResourceLimitedPlan); ok {
+		p.SetResourceController(ctx.Resources)
+	}
+	if b.evictionHook != nil {
+		p, ok := b.execPlan.(execution.EvictionHookPlan)
+		if !ok {
+			return errors.New("the statement doesn't have windows to evict tuples from")
+		}
+		if err := p.SetEvictionHook(b.evictionHook); err != nil {
+			return err
+		}
+	}
+	if b.spill != nil {
+		if p, ok := b.execPlan.(execution.SpillablePlan); ok {
+			if err := p.EnableSpill(b.spill); err != nil {
+				// The statement can still run without spilling.
+				ctx.ErrLog(err).WithField("node_type", "box").
+					Warn("Cannot enable spilling windows to disk")
+			}
+		}
+	}
+	if b.windowPool != nil {
+		if p, ok := b.execPlan.(execution.SharedWindowPlan); ok {
+			if err := p.SetWindowBufferPool(b.windowPool); err != nil {
+				// The statement can still run with its own windows.
+				ctx.ErrLog(err).WithField("node_type", "box").
+					Warn("Cannot share windows with other statements")
+			}
+		}
+	}
+	if b.arena {
+		if p, ok := b.execPlan.(execution.ArenaPlan); ok {
+			p.EnableArena()
+		}
+	}
+	if b.parallelAggregation != nil {
+		if p, ok := b.execPlan.(execution.ParallelAggregationPlan); ok {
+			if err := p.EnableParallelAggregation(b.parallelAggregation); err != nil {
+				return err
+			}
+		}
+	}
+	if len(b.triggers) > 0 {
+		p, ok := b.execPlan.(execution.TriggerablePlan)
+		if !ok {
+			return errors.New("the statement cannot be driven by triggers")
+		}
+		if err := p.SetTriggers(b.triggers); err != nil {
+			return err
+		}
+	}
+	if b.keyed && b.keyTTL > 0 {
+		if p, ok := b.execPlan.(execution.KeyedPlan); ok {
+			p.SetKeyTTL(b.keyTTL)
+			go b.keySweeper(ctx, p)
+		}
+	}
 	if b.emitterSamplingType == parser.TimeBasedSampling {
 		go b.timeEmitter(ctx)
 	}
@@ -93,11 +187,18 @@ func (b *bqlBox) Process(ctx *core.Context, t *core.Tuple, s core.Writer) error
 		return nil
 	}
 
+	// skip tuples which aren't chosen by the input sampling. each tuple
+	// is chosen independently so that the sample is representative.
+	if b.inputSampling >= 0 && rand.Float64() >= b.inputSampling {
+		return nil
+	}
+
 	// feed tuple into plan
 	resultData, err := b.execPlan.Process(t)
 	if err != nil {
 		return err
 	}
+	b.logEvictedTuples(ctx)
 
 	// emit result data as tuples
 	for _, data := range resultData {
@@ -163,13 +264,31 @@ func (b *bqlBox) Process(ctx *core.Context, t *core.Tuple, s core.Writer) error
 	return nil
 }
 
+// logEvictedTuples writes a warning log when the plan evicted tuples from
+// its window buffers because the topology ran out of its resources.
+func (b *bqlBox) logEvictedTuples(ctx *core.Context) {
+	p, ok := b.execPlan.(execution.ResourceLimitedPlan)
+	if !ok {
+		return
+	}
+	n := p.NumEvictedTuples()
+	if n == b.numEvicted {
+		return
+	}
+	ctx.Log().WithFields(logrus.Fields{
+		"node_type":   "box",
+		"num_evicted": n - b.numEvicted,
+	}).Warn("Tuples were evicted from windows because the topology exceeded its resource limits")
+	b.numEvicted = n
+}
+
 func (b *bqlBox) timeEmitter(ctx *core.Context) {
 	// invariant: b.emitterSamplingType == TimeBasedSampling
 
 	// generate a ticker that will tick every time we need to emit a tuple
-	ticker := time.NewTicker(time.Duration(b.emitterSampling * float64(time.Second)))
+	ticker := ctx.Clock().NewTicker(time.Duration(b.emitterSampling * float64(time.Second)))
 	defer ticker.Stop()
-	for _ = range ticker.C {
+	for _ = range ticker.C() {
 		shouldContinue := func() bool {
 			// we need to lock here because we access the `stopped` flag, the
 			// `lastTuple` and `lastWriter` pointer, as well as`emitCount`
@@ -220,14 +339,131 @@ func (b *bqlBox) timeEmitter(ctx *core.Context) {
 	}
 }
 
+// keySweeper removes state of keys which haven't been seen for the TTL
+// every TTL, so state of a key is removed at most twice the TTL after the
+// key was seen last time. It reports the number of expired keys as the
+// counter "keys.expired" and the number of live keys as the gauge
+// "keys.live" to Metrics of the topology.
+func (b *bqlBox) keySweeper(ctx *core.Context, p execution.KeyedPlan) {
+	ticker := ctx.Clock().NewTicker(b.keyTTL)
+	defer ticker.Stop()
+	for _ = range ticker.C() {
+		// the stopped flag is shared with the time-based emitter
+		b.timeEmitterMutex.Lock()
+		stopped := b.stopped
+		b.timeEmitterMutex.Unlock()
+		if stopped {
+			return
+		}
+
+		b.mutex.Lock()
+		n := p.SweepExpiredKeys()
+		b.mutex.Unlock()
+		if n > 0 {
+			ctx.AddCounter(core.NTBox, b.name, "keys.expired", int64(n))
+		}
+		ctx.SetGauge(core.NTBox, b.name, "keys.live", p.NumKeys())
+	}
+}
+
 func (b *bqlBox) Terminate(ctx *core.Context) error {
 	// signal to the time-based emitter that it should stop
 	b.timeEmitterMutex.Lock()
 	b.stopped = true
 	b.timeEmitterMutex.Unlock()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if p, ok := b.execPlan.(execution.ResourceLimitedPlan); ok {
+		p.ReleaseResources()
+	}
+	return nil
+}
+
+// windowStates returns states of windows of the plan keyed by aliases of
+// inputs. It returns false when the plan doesn't have windows.
+func (b *bqlBox) windowStates() (map[string]execution.WindowState, bool) {
+	p, ok := b.execPlan.(execution.WindowStatePlan)
+	if !ok {
+		return nil, false
+	}
+	return p.WindowStates(), true
+}
+
+// setEvictionHook replaces the eviction hook of the plan. It can be called
+// while the box is running.
+func (b *bqlBox) setEvictionHook(h execution.EvictionHook) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	p, ok := b.execPlan.(execution.EvictionHookPlan)
+	if !ok {
+		return errors.New("the statement doesn't have windows to evict tuples from")
+	}
+	if err := p.SetEvictionHook(h); err != nil {
+		return err
+	}
+	b.evictionHook = h
+	return nil
+}
+
+// windowTuples returns copies of tuples in windows of the plan so that they
+// can be restored by restoreWindows in another process. It returns false
+// when the plan doesn't have windows.
+func (b *bqlBox) windowTuples() ([]*core.Tuple, bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	p, ok := b.execPlan.(execution.SnapshotPlan)
+	if !ok {
+		return nil, false, nil
+	}
+	ts, err := p.WindowTuples()
+	return ts, true, err
+}
+
+// restoreWindows processes tuples returned from windowTuples with the plan
+// to rebuild its windows. Results computed from the tuples have already been
+// emitted by the box which took the tuples, so they're discarded. The
+// eviction hook isn't called while the tuples are processed for the same
+// reason.
+func (b *bqlBox) restoreWindows(tuples []*core.Tuple) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.execPlan.(execution.SnapshotPlan); !ok {
+		return errors.New("the statement doesn't have windows to restore")
+	}
+	if h := b.evictionHook; h != nil {
+		p := b.execPlan.(execution.EvictionHookPlan)
+		if err := p.SetEvictionHook(nil); err != nil {
+			return err
+		}
+		defer p.SetEvictionHook(h)
+	}
+	for _, t := range tuples {
+		if _, err := b.execPlan.Process(t); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Status returns the status of the box. It has states of windows in
+// "windows" so that users can see what the statement is computing. When the
+// statement keeps state for each key, the number of keys is in "num_keys".
+func (b *bqlBox) Status() data.Map {
+	m := data.Map{}
+	if states, ok := b.windowStates(); ok {
+		ws := make(data.Map, len(states))
+		for alias, st := range states {
+			ws[alias] = st.Map()
+		}
+		m["windows"] = ws
+	}
+	if p, ok := b.execPlan.(execution.KeyedPlan); ok && b.keyed {
+		m["num_keys"] = data.Int(p.NumKeys())
+	}
+	return m
+}
+
 func (b *bqlBox) callRemoveMeIgnoringPanic() {
 	defer func() {
 		recover()
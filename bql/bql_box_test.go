@@ -42,7 +42,9 @@ func setupTopology(stmt string, trace bool) (*TopologyBuilder, error) {
 func TestBasicBQLBoxConnectivity(t *testing.T) {
 	tuples := mkTuples(4)
 	tup2 := tuples[1].ShallowCopy()
+	tup2.Offset, tup2.OffsetSource = 2, "source"
 	tup4 := tuples[3].ShallowCopy()
+	tup4.Offset, tup4.OffsetSource = 4, "source"
 
 	Convey("Given an ISTREAM/2 SECONDS BQL statement", t, func() {
 		s := "CREATE STREAM box AS SELECT " +
@@ -123,6 +125,7 @@ func TestBasicBQLBoxConnectivity(t *testing.T) {
 func TestBQLBoxEmitterParams(t *testing.T) {
 	tuples := mkTuples(4)
 	tup2 := tuples[1].ShallowCopy()
+	tup2.Offset, tup2.OffsetSource = 2, "source"
 
 	Convey("Given a BQL statement with a LIMIT clause", t, func() {
 		s := "CREATE STREAM box AS SELECT " +
@@ -381,6 +384,149 @@ func TestBQLBoxEmitterParams(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a BQL statement sampling all input tuples", t, func() {
+		s := "CREATE STREAM box AS SELECT " +
+			`RSTREAM int FROM duplicate("source", 10) [RANGE 1 TUPLES] SAMPLE 100 PERCENT`
+		tb, err := setupTopology(s, true)
+		So(err, ShouldBeNil)
+		dt := tb.Topology()
+		Reset(func() {
+			dt.Stop()
+		})
+
+		sin, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sin.Sink().(*tupleCollectorSink)
+
+		Convey("When 4 tuples are emitted by the source", func() {
+
+			Convey("Then the sink receives all of them", func() {
+				si.Wait(40)
+				So(si.len(), ShouldEqual, 40)
+			})
+		})
+	})
+
+	Convey("Given BQL statements with invalid sampling rates", t, func() {
+		for _, rate := range []string{"0", "0.0", "101", "-1"} {
+			s := "CREATE STREAM box AS SELECT " +
+				`RSTREAM int FROM source [RANGE 1 TUPLES] SAMPLE ` + rate + ` PERCENT`
+
+			Convey("Then creating a box with "+rate+" percent should fail", func() {
+				_, err := setupTopology(s, true)
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+}
+
+func TestBQLBoxWithFakeClock(t *testing.T) {
+	Convey("Given a topology having a fake clock", t, func() {
+		start := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+		clock := core.NewFakeClock(start)
+		dt, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+			Clock: clock,
+		}), "testTopology")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE source TYPE dummy WITH num=4;`), ShouldBeNil)
+
+		Convey("When using now() and clock_timestamp()", func() {
+			So(addBQLToTopology(tb, `
+				CREATE STREAM box AS SELECT RSTREAM int, now() AS now, clock_timestamp() AS clock
+					FROM source [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM box;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+			si.Wait(4)
+
+			Convey("Then they should return the time of the clock", func() {
+				So(si.get(0).Data["now"], ShouldResemble, data.Timestamp(start))
+				So(si.get(3).Data["clock"], ShouldResemble, data.Timestamp(start))
+			})
+		})
+
+		Convey("When using an EVERY 1 SECONDS clause", func() {
+			So(addBQLToTopology(tb, `
+				CREATE STREAM box AS SELECT RSTREAM [EVERY 1 SECONDS] int FROM source [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM box;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+			bn, err := dt.Box("box")
+			So(err, ShouldBeNil)
+			b := bn.Box().(*bqlBox)
+
+			// wait until the box processes all tuples and the emitter starts
+			for {
+				b.timeEmitterMutex.Lock()
+				done := b.lastTuple != nil && b.lastTuple.Data["int"] == data.Int(4)
+				b.timeEmitterMutex.Unlock()
+				if done {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			clock.BlockUntil(1)
+
+			Convey("Then nothing should be emitted until the clock advances", func() {
+				clock.Advance(999 * time.Millisecond)
+				So(si.len(), ShouldEqual, 0)
+
+				Convey("And the last tuple should be emitted after a second", func() {
+					clock.Advance(time.Millisecond)
+					si.Wait(1)
+					So(si.len(), ShouldEqual, 1)
+					So(si.get(0).Data["int"], ShouldEqual, data.Int(4))
+				})
+			})
+		})
+
+		Convey("When using WHEN CHANGED BY with a key TTL", func() {
+			tb.KeyTTL = 10 * time.Second
+			So(addBQLToTopology(tb, `
+				CREATE STREAM box AS SELECT RSTREAM [WHEN CHANGED BY k] int % 2 AS k, int
+					FROM source [RANGE 1 TUPLES];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM box;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sin.Sink().(*tupleCollectorSink)
+			si.Wait(4)
+			bn, err := dt.Box("box")
+			So(err, ShouldBeNil)
+			clock.BlockUntil(1)
+
+			Convey("Then the status should have the number of live keys", func() {
+				So(bn.Status()["box"].(data.Map)["num_keys"], ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then keys should be removed after the TTL", func() {
+				clock.Advance(10 * time.Second)
+				clock.Advance(10 * time.Second)
+				var n data.Value
+				for i := 0; i < 1000; i++ {
+					n = bn.Status()["box"].(data.Map)["num_keys"]
+					if n == data.Int(0) {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				So(n, ShouldEqual, data.Int(0))
+			})
+		})
+	})
 }
 
 func TestBasicBQLBoxUnionCapability(t *testing.T) {
@@ -655,6 +801,81 @@ func TestBQLBoxGroupByCapability(t *testing.T) {
 	})
 }
 
+func TestBQLBoxPartition(t *testing.T) {
+	Convey("Given an ISTREAM statement with PARTITION BY", t, func() {
+		s := "CREATE STREAM box AS SELECT ISTREAM int " +
+			"FROM source [RANGE 1 TUPLES, PARTITION BY source:int INSTANCE 1 OF 2]"
+		tb, err := setupTopology(s, false)
+		So(err, ShouldBeNil)
+		dt := tb.Topology()
+		Reset(func() {
+			dt.Stop()
+		})
+
+		sin, err := dt.Sink("snk")
+		So(err, ShouldBeNil)
+		si := sin.Sink().(*tupleCollectorSink)
+
+		Convey("When 4 tuples are emitted by the source", func() {
+			var expected []data.Value
+			for _, t := range mkTuples(4) {
+				if core.PartitionOf(t.Data["int"], 2) == 1 {
+					expected = append(expected, t.Data["int"])
+				}
+			}
+
+			Convey("Then the sink should only receive tuples in the partition", func() {
+				si.Wait(len(expected))
+				So(si.len(), ShouldEqual, len(expected))
+				for i, v := range expected {
+					So(si.get(i).Data["int"], ShouldEqual, v)
+				}
+			})
+		})
+	})
+
+	Convey("Given a topology builder with a source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, "CREATE PAUSED SOURCE source TYPE dummy"), ShouldBeNil)
+
+		Convey("When the partition index is out of range", func() {
+			err := addBQLToTopology(tb, "CREATE STREAM box AS SELECT ISTREAM int "+
+				"FROM source [RANGE 1 TUPLES, PARTITION BY int INSTANCE 2 OF 2]")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the partition key refers to another stream", func() {
+			err := addBQLToTopology(tb, "CREATE STREAM box AS SELECT ISTREAM int "+
+				"FROM source [RANGE 1 TUPLES, PARTITION BY other:int INSTANCE 0 OF 2]")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "other streams")
+			})
+		})
+
+		Convey("When PARTITION BY is used in a self-join", func() {
+			err := addBQLToTopology(tb, "CREATE STREAM box AS SELECT ISTREAM a:int "+
+				"FROM source [RANGE 1 TUPLES, PARTITION BY int INSTANCE 0 OF 2] AS a, "+
+				"source [RANGE 1 TUPLES] AS b")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				_, err := dt.Box("box")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
 func TestBQLBoxUDSF(t *testing.T) {
 	Convey("Given a topology using UDSF", t, func() {
 		tb, err := setupTopology(`CREATE STREAM box AS SELECT RSTREAM duplicate:int FROM duplicate("source", 3) [RANGE 1 TUPLES]`, false)
@@ -0,0 +1,329 @@
+// Package bqltest provides a runner of unit tests of BQL statements. A test
+// case has input tuples of sources, BQL statements to be tested, and rows
+// expected to be emitted from a stream. The runner executes the statements
+// in a new topology, feeds input tuples to it, and reports differences
+// between expected and actual rows.
+//
+// Test cases can be written in Go:
+//
+//	res, err := bqltest.Run(&bqltest.Case{
+//		Inputs: map[string][]data.Map{
+//			"src": {{"a": data.Int(1)}, {"a": data.Int(2)}},
+//		},
+//		BQL:      "SELECT RSTREAM a FROM src [RANGE 1 TUPLES] WHERE a > 1",
+//		Expected: []data.Map{{"a": data.Int(2)}},
+//	})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	if !res.Passed() {
+//		t.Error(res)
+//	}
+//
+// or in a YAML file loaded by LoadFile. See ParseCases for the format.
+//
+// Built-in functions aren't registered by this package. Import
+// gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin to use them.
+package bqltest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultNow is the time of the clock used by a test case when Case.Now is
+// zero.
+var DefaultNow = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	// outputStreamName is the name of the stream created from a SELECT
+	// statement at the end of Case.BQL.
+	outputStreamName = "bqltest_output"
+
+	outputSinkName = "bqltest_output_sink"
+)
+
+// Case is a test case of BQL statements.
+type Case struct {
+	// Name is the name of the test case.
+	Name string
+
+	// Inputs has input tuples of each source. A source is created for each
+	// key and emits tuples in the given order.
+	Inputs map[string][]data.Map
+
+	// TimestampField is the name of the field having the timestamp of an
+	// input tuple. When it's empty or an input tuple doesn't have the field,
+	// the current time of the clock is used.
+	TimestampField string
+
+	// Now is the time returned by the clock of the topology, which is also
+	// returned by now() and clock_timestamp(). DefaultNow is used when it's
+	// zero. The clock doesn't advance while a test case runs.
+	Now time.Time
+
+	// BQL has statements to be tested. When the last statement is a SELECT
+	// statement, rows emitted from it are compared with Expected. Otherwise,
+	// rows emitted from the stream specified by Output are compared.
+	BQL string
+
+	// Output is the name of the stream whose rows are compared with
+	// Expected. It's required unless BQL ends with a SELECT statement.
+	Output string
+
+	// Expected has rows expected to be emitted from the output stream.
+	Expected []data.Map
+
+	// Unordered disables checking the order of rows.
+	Unordered bool
+}
+
+// Result is the result of a test case.
+type Result struct {
+	// Case is the test case executed.
+	Case *Case
+
+	// Actual has rows actually emitted from the output stream.
+	Actual []data.Map
+
+	// Diffs has descriptions of differences between expected and actual
+	// rows. It's empty when the test case passed.
+	Diffs []string
+}
+
+// Passed returns true when the actual rows match the expected rows.
+func (r *Result) Passed() bool {
+	return len(r.Diffs) == 0
+}
+
+// String returns a report of the result.
+func (r *Result) String() string {
+	b := bytes.NewBuffer(nil)
+	name := r.Case.Name
+	if name == "" {
+		name = "(unnamed)"
+	}
+	if r.Passed() {
+		fmt.Fprintf(b, "PASS: %v", name)
+		return b.String()
+	}
+	fmt.Fprintf(b, "FAIL: %v", name)
+	for _, d := range r.Diffs {
+		fmt.Fprintf(b, "\n    %v", d)
+	}
+	return b.String()
+}
+
+// Run executes a test case. It returns an error when the statements cannot
+// be executed. Mismatches of rows are reported in Result instead.
+func Run(c *Case) (*Result, error) {
+	stmts, err := parser.New().ParseStmts(c.BQL)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) == 0 {
+		return nil, errors.New("no statement is given")
+	}
+	output := c.Output
+	if s, ok := stmts[len(stmts)-1].(parser.SelectStmt); ok {
+		stmts[len(stmts)-1] = parser.CreateStreamAsSelectStmt{
+			Name:   parser.StreamIdentifier(outputStreamName),
+			Select: s,
+		}
+		output = outputStreamName
+	}
+	if output == "" {
+		return nil, errors.New("the output stream isn't specified")
+	}
+
+	now := c.Now
+	if now.IsZero() {
+		now = DefaultNow
+	}
+	ctx := core.NewContext(&core.ContextConfig{
+		Clock: core.NewFakeClock(now),
+	})
+	tp, err := core.NewDefaultTopology(ctx, "bqltest")
+	if err != nil {
+		return nil, err
+	}
+	stopped := false
+	defer func() {
+		if !stopped {
+			tp.Stop()
+		}
+	}()
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		return nil, err
+	}
+
+	var tsPath data.Path
+	if c.TimestampField != "" {
+		if tsPath, err = data.CompilePath(c.TimestampField); err != nil {
+			return nil, fmt.Errorf("invalid timestamp field: %v", err)
+		}
+	}
+	names := make([]string, 0, len(c.Inputs))
+	for name := range c.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	srcs := make([]core.SourceNode, 0, len(names))
+	for _, name := range names {
+		ts, err := newTuples(ctx, c.Inputs[name], tsPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input of '%v': %v", name, err)
+		}
+		src := core.ImplementSourceStop(&inputSource{tuples: ts})
+		sn, err := tp.AddSource(name, src, &core.SourceConfig{
+			PausedOnStartup: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		srcs = append(srcs, sn)
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tb.AddStmt(stmt); err != nil {
+			return nil, err
+		}
+	}
+	sink := &outputSink{}
+	sn, err := tp.AddSink(outputSinkName, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := sn.Input(output, nil); err != nil {
+		return nil, err
+	}
+
+	for _, src := range srcs {
+		if err := src.Resume(); err != nil {
+			return nil, err
+		}
+	}
+	for _, src := range srcs {
+		src.State().Wait(core.TSStopped)
+	}
+	// Stopping the topology waits until all tuples are processed.
+	stopped = true
+	if err := tp.Stop(); err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		Case:   c,
+		Actual: sink.rows,
+	}
+	if c.Unordered {
+		res.Diffs = diffUnordered(c.Expected, res.Actual)
+	} else {
+		res.Diffs = diffOrdered(c.Expected, res.Actual)
+	}
+	return res, nil
+}
+
+func newTuples(ctx *core.Context, ms []data.Map, tsPath data.Path) ([]*core.Tuple, error) {
+	now := ctx.Clock().Now()
+	ts := make([]*core.Tuple, len(ms))
+	for i, m := range ms {
+		t := &core.Tuple{
+			Data:          m.Copy(),
+			Timestamp:     now,
+			ProcTimestamp: now,
+		}
+		if tsPath != nil {
+			if v, err := m.Get(tsPath); err == nil {
+				if t.Timestamp, err = data.ToTimestamp(v); err != nil {
+					return nil, fmt.Errorf("the %v-th tuple has an invalid timestamp: %v", i, err)
+				}
+			}
+		}
+		ts[i] = t
+	}
+	return ts, nil
+}
+
+func diffOrdered(expected, actual []data.Map) []string {
+	var diffs []string
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			diffs = append(diffs, fmt.Sprintf("row %v: expected %v but got nothing", i, expected[i]))
+		case i >= len(expected):
+			diffs = append(diffs, fmt.Sprintf("row %v: unexpected %v", i, actual[i]))
+		case !data.Equal(expected[i], actual[i]):
+			diffs = append(diffs, fmt.Sprintf("row %v: expected %v but got %v", i, expected[i], actual[i]))
+		}
+	}
+	return diffs
+}
+
+func diffUnordered(expected, actual []data.Map) []string {
+	var diffs []string
+	used := make([]bool, len(actual))
+	for _, e := range expected {
+		found := false
+		for i, a := range actual {
+			if !used[i] && data.Equal(e, a) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diffs = append(diffs, fmt.Sprintf("missing row %v", e))
+		}
+	}
+	for i, a := range actual {
+		if !used[i] {
+			diffs = append(diffs, fmt.Sprintf("unexpected row %v", a))
+		}
+	}
+	return diffs
+}
+
+// inputSource emits given tuples and stops.
+type inputSource struct {
+	tuples []*core.Tuple
+}
+
+func (s *inputSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	for _, t := range s.tuples {
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *inputSource) Stop(ctx *core.Context) error {
+	return nil
+}
+
+// outputSink collects rows emitted from the output stream.
+type outputSink struct {
+	m    sync.Mutex
+	rows []data.Map
+}
+
+func (s *outputSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.rows = append(s.rows, t.Data.Copy())
+	return nil
+}
+
+func (s *outputSink) Close(ctx *core.Context) error {
+	return nil
+}
@@ -0,0 +1,152 @@
+package bqltest
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	_ "gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	inputs := map[string][]data.Map{
+		"src": {
+			{"a": data.Int(1), "k": data.String("x")},
+			{"a": data.Int(2), "k": data.String("y")},
+			{"a": data.Int(3), "k": data.String("x")},
+		},
+	}
+
+	Convey("Given a test case ending with a SELECT statement", t, func() {
+		c := &Case{
+			Name:     "filter",
+			Inputs:   inputs,
+			BQL:      "SELECT RSTREAM a FROM src [RANGE 1 TUPLES] WHERE a > 1",
+			Expected: []data.Map{{"a": data.Int(2)}, {"a": data.Int(3)}},
+		}
+
+		Convey("When running it", func() {
+			res, err := Run(c)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should pass", func() {
+				So(res.Passed(), ShouldBeTrue)
+				So(res.String(), ShouldEqual, "PASS: filter")
+			})
+		})
+
+		Convey("When the expected rows are different", func() {
+			c.Expected = []data.Map{{"a": data.Int(3)}}
+			res, err := Run(c)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail with differences", func() {
+				So(res.Passed(), ShouldBeFalse)
+				So(res.Actual, ShouldResemble, []data.Map{{"a": data.Int(2)}, {"a": data.Int(3)}})
+				So(res.Diffs, ShouldHaveLength, 2)
+				So(res.Diffs[0], ShouldEqual, `row 0: expected {"a":3} but got {"a":2}`)
+				So(res.Diffs[1], ShouldEqual, `row 1: unexpected {"a":3}`)
+				So(res.String(), ShouldStartWith, "FAIL: filter\n")
+			})
+		})
+
+		Convey("When the order of the expected rows is different", func() {
+			c.Expected = []data.Map{{"a": data.Int(3)}, {"a": data.Int(2)}}
+
+			Convey("Then it should fail if the order is checked", func() {
+				res, err := Run(c)
+				So(err, ShouldBeNil)
+				So(res.Passed(), ShouldBeFalse)
+			})
+
+			Convey("Then it should pass if the order isn't checked", func() {
+				c.Unordered = true
+				res, err := Run(c)
+				So(err, ShouldBeNil)
+				So(res.Passed(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When some rows are missing in the unordered mode", func() {
+			c.Unordered = true
+			c.Expected = []data.Map{{"a": data.Int(3)}, {"a": data.Int(4)}}
+			res, err := Run(c)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should report missing and unexpected rows", func() {
+				So(res.Diffs, ShouldResemble, []string{`missing row {"a":4}`, `unexpected row {"a":2}`})
+			})
+		})
+	})
+
+	Convey("Given a test case having an output stream", t, func() {
+		c := &Case{
+			Inputs: inputs,
+			BQL: `CREATE STREAM s AS SELECT RSTREAM k, count(*) AS n FROM src [RANGE 3 TUPLES] GROUP BY k;
+				CREATE STREAM result AS SELECT RSTREAM * FROM s [RANGE 1 TUPLES] WHERE n > 1;`,
+			Output:   "result",
+			Expected: []data.Map{{"k": data.String("x"), "n": data.Int(2)}},
+		}
+
+		Convey("When running it", func() {
+			res, err := Run(c)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should pass", func() {
+				So(res.Passed(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the output isn't specified", func() {
+			c.Output = ""
+			_, err := Run(c)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a test case using time", t, func() {
+		now := time.Date(2016, 1, 1, 0, 0, 10, 0, time.UTC)
+		c := &Case{
+			Inputs: map[string][]data.Map{
+				"src": {
+					{"a": data.Int(1), "ts": data.String("2016-01-01T00:00:00Z")},
+					{"a": data.Int(2), "ts": data.String("2016-01-01T00:00:01Z")},
+					{"a": data.Int(3), "ts": data.String("2016-01-01T00:00:03Z")},
+				},
+			},
+			TimestampField: "ts",
+			Now:            now,
+			BQL:            "SELECT RSTREAM sum(a) AS s, now() AS now FROM src [RANGE 2 SECONDS]",
+			Expected: []data.Map{
+				{"s": data.Int(1), "now": data.Timestamp(now)},
+				{"s": data.Int(3), "now": data.Timestamp(now)},
+				{"s": data.Int(5), "now": data.Timestamp(now)},
+			},
+		}
+
+		Convey("When running it", func() {
+			res, err := Run(c)
+			So(err, ShouldBeNil)
+
+			Convey("Then windows and now() should be deterministic", func() {
+				So(res.Diffs, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given invalid test cases", t, func() {
+		for _, c := range []*Case{
+			{BQL: ""},
+			{BQL: "SELECT"},
+			{BQL: "SELECT RSTREAM * FROM no_such_source [RANGE 1 TUPLES]"},
+			{Inputs: inputs, BQL: "SELECT RSTREAM * FROM src [RANGE 1 TUPLES]", TimestampField: "a["},
+			{Inputs: inputs, BQL: "SELECT RSTREAM * FROM src [RANGE 1 TUPLES]", TimestampField: "k"},
+		} {
+			_, err := Run(c)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package bqltest
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+)
+
+// LoadFile loads test cases from a YAML file. See ParseCases for the format.
+func LoadFile(path string) ([]*Case, error) {
+	in, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var yml map[string]interface{}
+	if err := yaml.Unmarshal(in, &yml); err != nil {
+		return nil, fmt.Errorf("cannot parse %v: %v", path, err)
+	}
+	m, err := data.NewMap(yml)
+	if err != nil {
+		return nil, fmt.Errorf("%v has invalid values: %v", path, err)
+	}
+	return ParseCases(m)
+}
+
+// ParseCases creates test cases from a map. The map has "tests" field
+// having an array of test cases like the following YAML:
+//
+//	tests:
+//	  - name: filter
+//	    inputs:
+//	      src:
+//	        - {a: 1, ts: "2016-01-01T00:00:00Z"}
+//	        - {a: 2, ts: "2016-01-01T00:00:01Z"}
+//	    timestamp_field: ts
+//	    bql: SELECT RSTREAM a FROM src [RANGE 1 TUPLES] WHERE a > 1;
+//	    expected:
+//	      - {a: 2}
+//
+// Each test case has fields corresponding to fields of Case: name, inputs,
+// timestamp_field, now, bql, output, expected, and unordered. now is a
+// timestamp or a string in RFC3339 format.
+func ParseCases(m data.Map) ([]*Case, error) {
+	v, ok := m["tests"]
+	if !ok {
+		return nil, errors.New("'tests' field is missing")
+	}
+	a, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("'tests' field must be an array: %v", err)
+	}
+	cs := make([]*Case, len(a))
+	for i, v := range a {
+		c, err := parseCase(v)
+		if err != nil {
+			return nil, fmt.Errorf("the %v-th test case is invalid: %v", i, err)
+		}
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("test %v", i)
+		}
+		cs[i] = c
+	}
+	return cs, nil
+}
+
+func parseCase(v data.Value) (*Case, error) {
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("a test case must be a map: %v", err)
+	}
+	c := &Case{}
+	for k, v := range m {
+		var err error
+		switch k {
+		case "name":
+			c.Name, err = data.AsString(v)
+		case "inputs":
+			c.Inputs, err = parseInputs(v)
+		case "timestamp_field":
+			c.TimestampField, err = data.AsString(v)
+		case "now":
+			c.Now, err = data.ToTimestamp(v)
+		case "bql":
+			c.BQL, err = data.AsString(v)
+		case "output":
+			c.Output, err = data.AsString(v)
+		case "expected":
+			c.Expected, err = parseRows(v)
+		case "unordered":
+			c.Unordered, err = data.AsBool(v)
+		default:
+			return nil, fmt.Errorf("unknown field: %v", k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("'%v' field is invalid: %v", k, err)
+		}
+	}
+	if c.BQL == "" {
+		return nil, errors.New("'bql' field is missing")
+	}
+	return c, nil
+}
+
+func parseInputs(v data.Value) (map[string][]data.Map, error) {
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, err
+	}
+	inputs := map[string][]data.Map{}
+	for name, v := range m {
+		rows, err := parseRows(v)
+		if err != nil {
+			return nil, fmt.Errorf("input of '%v' is invalid: %v", name, err)
+		}
+		inputs[name] = rows
+	}
+	return inputs, nil
+}
+
+func parseRows(v data.Value) ([]data.Map, error) {
+	if v.Type() == data.TypeNull {
+		return nil, nil
+	}
+	a, err := data.AsArray(v)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]data.Map, len(a))
+	for i, r := range a {
+		if rows[i], err = data.AsMap(r); err != nil {
+			return nil, fmt.Errorf("the %v-th row must be a map: %v", i, err)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,101 @@
+package bqltest
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadFile(t *testing.T) {
+	Convey("Given a YAML file having test cases", t, func() {
+		dir, err := ioutil.TempDir("", "bqltest")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		path := filepath.Join(dir, "test.yaml")
+		So(ioutil.WriteFile(path, []byte(`
+tests:
+  - name: filter
+    inputs:
+      src:
+        - {a: 1, ts: "2016-01-01T00:00:00Z"}
+        - {a: 2, ts: "2016-01-01T00:00:01Z"}
+    timestamp_field: ts
+    now: "2016-01-01T00:01:00Z"
+    bql: SELECT RSTREAM a FROM src [RANGE 1 TUPLES] WHERE a > 1;
+    expected:
+      - {a: 2}
+  - inputs:
+      src:
+        - {a: 1}
+    bql: |
+      CREATE STREAM result AS SELECT RSTREAM a FROM src [RANGE 1 TUPLES];
+    output: result
+    expected: []
+    unordered: true
+`), 0644), ShouldBeNil)
+
+		Convey("When loading it", func() {
+			cs, err := LoadFile(path)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have all test cases", func() {
+				So(cs, ShouldHaveLength, 2)
+				c := cs[0]
+				So(c.Name, ShouldEqual, "filter")
+				So(c.Inputs["src"], ShouldHaveLength, 2)
+				So(c.Inputs["src"][1]["a"], ShouldEqual, data.Int(2))
+				So(c.TimestampField, ShouldEqual, "ts")
+				So(c.Now, ShouldResemble, time.Date(2016, 1, 1, 0, 1, 0, 0, time.UTC))
+				So(c.Expected, ShouldResemble, []data.Map{{"a": data.Int(2)}})
+
+				c = cs[1]
+				So(c.Name, ShouldEqual, "test 1")
+				So(c.Output, ShouldEqual, "result")
+				So(c.Expected, ShouldBeEmpty)
+				So(c.Unordered, ShouldBeTrue)
+			})
+
+			Convey("Then the first test case should pass", func() {
+				res, err := Run(cs[0])
+				So(err, ShouldBeNil)
+				So(res.Passed(), ShouldBeTrue)
+			})
+
+			Convey("Then the second test case should fail", func() {
+				res, err := Run(cs[1])
+				So(err, ShouldBeNil)
+				So(res.Diffs, ShouldResemble, []string{`unexpected row {"a":1}`})
+			})
+		})
+	})
+
+	Convey("Given invalid test cases", t, func() {
+		for _, m := range []data.Map{
+			{},
+			{"tests": data.String("a")},
+			{"tests": data.Array{data.Int(1)}},
+			{"tests": data.Array{data.Map{"name": data.String("no bql")}}},
+			{"tests": data.Array{data.Map{"bql": data.String("a"), "unknown": data.Int(1)}}},
+			{"tests": data.Array{data.Map{"bql": data.String("a"), "inputs": data.Map{"src": data.Array{data.Int(1)}}}}},
+			{"tests": data.Array{data.Map{"bql": data.String("a"), "expected": data.Map{}}}},
+			{"tests": data.Array{data.Map{"bql": data.String("a"), "now": data.String("yesterday")}}},
+		} {
+			_, err := ParseCases(m)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Given a missing file", t, func() {
+		_, err := LoadFile("/no/such/file.yaml")
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
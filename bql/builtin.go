@@ -3,14 +3,18 @@ package bql
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/compress"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/frame"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"io"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -38,11 +42,146 @@ func init() {
 	MustRegisterGlobalSinkCreator("uds", SinkCreatorFunc(createSharedStateSink))
 }
 
+// recordDecoder decodes records read by readerSource.
+type recordDecoder interface {
+	// Decode returns the next record. It returns io.EOF when no record is
+	// left. When a record is malformed, it returns *malformedRecordError and
+	// the next call decodes the next record.
+	Decode() (data.Map, error)
+
+	// LineNumber returns the line number of the record decoded last.
+	LineNumber() int
+}
+
+// malformedRecordError is returned from recordDecoder when a record cannot
+// be decoded. The record is skipped and the next one can be decoded.
+type malformedRecordError struct {
+	lineNumber int
+	body       string
+	err        error
+}
+
+func (e *malformedRecordError) Error() string {
+	return e.err.Error()
+}
+
+// jsonlDecoder decodes JSON lines. Empty lines are skipped.
+type jsonlDecoder struct {
+	r          *bufio.Reader
+	lineNumber int
+}
+
+func (d *jsonlDecoder) Decode() (data.Map, error) {
+	for {
+		d.lineNumber++
+		line, err := d.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err == io.EOF {
+				return nil, err
+			}
+			continue
+		}
+
+		m, err := data.UnmarshalJSON(line)
+		if err != nil {
+			return nil, &malformedRecordError{
+				lineNumber: d.lineNumber,
+				body:       string(line),
+				err:        err,
+			}
+		}
+		return m, nil
+	}
+}
+
+func (d *jsonlDecoder) LineNumber() int {
+	return d.lineNumber
+}
+
+// xmlDecoder decodes a stream of XML elements. Its line numbers are the
+// numbers of records decoded so far because positions in the input aren't
+// tracked.
+type xmlDecoder struct {
+	d          *data.XMLDecoder
+	lineNumber int
+}
+
+func (d *xmlDecoder) Decode() (data.Map, error) {
+	m, err := d.d.DecodeMap()
+	if err != nil {
+		return nil, err
+	}
+	d.lineNumber++
+	return m, nil
+}
+
+func (d *xmlDecoder) LineNumber() int {
+	return d.lineNumber
+}
+
+// binaryDecoder decodes consecutive fixed-size binary frames. Its line
+// numbers are the numbers of frames read so far. A partial frame at the end
+// of the input is reported as a malformed record.
+type binaryDecoder struct {
+	r          io.Reader
+	layout     *frame.Layout
+	buf        []byte
+	lineNumber int
+}
+
+func (d *binaryDecoder) Decode() (data.Map, error) {
+	n, err := io.ReadFull(d.r, d.buf)
+	if err == io.EOF {
+		return nil, err
+	}
+	d.lineNumber++
+	if err == io.ErrUnexpectedEOF {
+		return nil, &malformedRecordError{
+			lineNumber: d.lineNumber,
+			body:       hex.EncodeToString(d.buf[:n]),
+			err:        fmt.Errorf("the last frame has only %v bytes", n),
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	m, err := d.layout.Decode(d.buf)
+	if err != nil {
+		return nil, &malformedRecordError{
+			lineNumber: d.lineNumber,
+			body:       hex.EncodeToString(d.buf),
+			err:        err,
+		}
+	}
+	return m, nil
+}
+
+func (d *binaryDecoder) LineNumber() int {
+	return d.lineNumber
+}
+
 type readerSource struct {
 	filename string
 	tsField  data.Path
 	ioParams *IOParams
 
+	// csv has parameters of the CSV codec. xml has options of the XML codec
+	// and xmlRecord is the name of elements decoded as tuples. layout is the
+	// layout of frames of the binary codec. Lines are decoded as JSON when
+	// none of them is set.
+	csv       *csvConfig
+	xml       *data.XMLOptions
+	xmlRecord string
+	layout    *frame.Layout
+
+	// codec decompresses the input file when it isn't nil.
+	codec compress.Codec
+
 	// repeat is the number of times that the input data is read. When its value
 	// is less than 0, the source will read the input again and again until it's
 	// stopped. When the value is 0, the source only read the input once. When
@@ -54,6 +193,11 @@ type readerSource struct {
 	// tuples as fast as possible.
 	interval time.Duration
 	stopCh   chan struct{}
+
+	// progress has the position of the file when it's read by a reader of
+	// a partitioned file source. Records which have already been written
+	// are skipped when the file is read again by another reader.
+	progress *fileProgress
 }
 
 func (s *readerSource) GenerateStream(ctx *core.Context, w core.Writer) error {
@@ -70,34 +214,68 @@ func (s *readerSource) generateStream(ctx *core.Context, w core.Writer) error {
 	if err != nil {
 		return err
 	}
+	var rc io.ReadCloser = f
+	var skip int64
+	if s.progress != nil {
+		rc = &progressReadCloser{ReadCloser: f, p: s.progress}
+		skip = atomic.LoadInt64(&s.progress.records)
+	}
+	if s.codec != nil {
+		if rc, err = compress.NewReadCloser(s.codec, rc); err != nil {
+			f.Close()
+			return err
+		}
+	}
 	defer func() {
-		if err := f.Close(); err != nil {
+		if err := rc.Close(); err != nil {
 			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
 				Warning("Cannot close the file")
 		}
 	}()
 
-	r := bufio.NewReader(f)
-	next := time.Now()
-	for lineNumber := 0; ; lineNumber++ {
-		line, err := r.ReadBytes('\n')
-		if err != nil && err != io.EOF {
+	var dec recordDecoder
+	lineNumberField := "jsonl_line_number"
+	if s.csv != nil {
+		dec = newCSVDecoder(s.csv, rc)
+		lineNumberField = "csv_line_number"
+	} else if s.xml != nil {
+		d, err := data.NewXMLDecoder(rc, s.xml)
+		if err != nil {
 			return err
 		}
+		d.Record = s.xmlRecord
+		dec = &xmlDecoder{d: d}
+		lineNumberField = "xml_record_number"
+	} else if s.layout != nil {
+		dec = &binaryDecoder{
+			r:      bufio.NewReader(rc),
+			layout: s.layout,
+			buf:    make([]byte, s.layout.Size),
+		}
+		lineNumberField = "binary_frame_number"
+	} else {
+		dec = &jsonlDecoder{
+			r:          bufio.NewReader(rc),
+			lineNumber: -1,
+		}
+	}
 
-		line = bytes.TrimSpace(line)
-		if len(line) == 0 {
-			if err == io.EOF {
-				break
-			}
+	clock := ctx.Clock()
+	next := clock.Now()
+	var n int64 // the number of records decoded
+	for {
+		m, err := dec.Decode()
+		if err == io.EOF {
+			break
+		} else if e, ok := err.(*malformedRecordError); ok {
+			ctx.ErrLog(e.err).WithField("node_name", s.ioParams.Name).
+				WithField(lineNumberField, e.lineNumber).
+				WithField("body", e.body).Warning("Ignoring a malformed record")
 			continue
+		} else if err != nil {
+			return err
 		}
-
-		m := data.Map{}
-		if err := json.Unmarshal(line, &m); err != nil {
-			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
-				WithField("jsonl_line_number", lineNumber).
-				WithField("body", string(line)).Warning("Ignoring the line due to a json parse error")
+		if n++; n <= skip {
 			continue
 		}
 
@@ -111,7 +289,7 @@ func (s *readerSource) generateStream(ctx *core.Context, w core.Writer) error {
 			if v, err := t.Data.Get(s.tsField); err == nil {
 				if ts, err := data.ToTimestamp(v); err != nil {
 					ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
-						WithField("jsonl_line_number", lineNumber).
+						WithField(lineNumberField, dec.LineNumber()).
 						WithField("timestamp_field", s.tsField).
 						WithField("timestamp_field_value", v).
 						Warning("Cannot convert a value in timestamp_field to a timestamp")
@@ -124,22 +302,27 @@ func (s *readerSource) generateStream(ctx *core.Context, w core.Writer) error {
 		if err := w.Write(ctx, t); err != nil {
 			return err
 		}
+		if s.progress != nil {
+			atomic.StoreInt64(&s.progress.records, n)
+		}
 
 		if s.interval > 0 {
 			// wait as accurate as possible
-			now := time.Now()
+			now := clock.Now()
 			next = next.Add(s.interval)
 			if next.Before(now) {
 				// delayed too much and should be rescheduled.
 				next = now.Add(s.interval)
 			}
 
+			timer := clock.NewTimer(next.Sub(now))
 			select {
 			case <-s.stopCh:
+				timer.Stop()
 				// This works as long as createFileSource returns a source
 				// wrapped with core.NewRewindableSource or core.ImplementSourceStop.
 				return core.ErrSourceStopped
-			case <-time.After(next.Sub(now)):
+			case <-timer.C():
 			}
 		}
 	}
@@ -152,8 +335,6 @@ func (s *readerSource) Stop(ctx *core.Context) error {
 }
 
 func createFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
-	// TODO: add format parameter
-
 	fpath, err := extractPathParameter(params)
 	if err != nil {
 		return nil, err
@@ -196,13 +377,50 @@ func createFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (c
 		}
 		interval = i
 	}
+
+	csv, err := extractCSVConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	xmlOpts, xmlRecord, err := extractXMLParameters(params)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := extractFrameLayout(params)
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := extractCompressionParameter(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var readers int64
+	if v, ok := params["readers"]; ok {
+		if readers, err = data.AsInt(v); err != nil {
+			return nil, fmt.Errorf("'readers' parameter must be an integer: %v", err)
+		}
+		if readers < 1 {
+			return nil, fmt.Errorf("'readers' parameter must be positive: %v", readers)
+		}
+	}
+
 	s := &readerSource{
-		filename: fpath,
-		tsField:  tsField,
-		ioParams: ioParams,
-		repeat:   repeat,
-		interval: interval,
-		stopCh:   make(chan struct{}),
+		filename:  fpath,
+		tsField:   tsField,
+		ioParams:  ioParams,
+		csv:       csv,
+		xml:       xmlOpts,
+		xmlRecord: xmlRecord,
+		layout:    layout,
+		codec:     codec,
+		repeat:    repeat,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+	}
+	if readers > 0 {
+		return newPartitionedFileSource(fpath, s, int(readers), rewindable)
 	}
 	if rewindable {
 		return core.NewRewindableSource(s), nil
@@ -210,6 +428,50 @@ func createFileSource(ctx *core.Context, ioParams *IOParams, params data.Map) (c
 	return core.ImplementSourceStop(s), nil
 }
 
+// extractXMLParameters returns options of the XML codec when 'codec'
+// parameter is "xml". It accepts parameters of data.NewXMLOptions and
+// 'xml_record' having the name of elements decoded as tuples. Each top-level
+// element is decoded as a tuple when 'xml_record' is missing. Because XML
+// cannot be decoded after a syntax error, the source stops with an error
+// instead of skipping a malformed record.
+func extractXMLParameters(params data.Map) (*data.XMLOptions, string, error) {
+	codec, err := extractCodecParameter(params)
+	if err != nil {
+		return nil, "", err
+	}
+	if codec != "xml" {
+		return nil, "", nil
+	}
+	opts, err := data.NewXMLOptions(params)
+	if err != nil {
+		return nil, "", fmt.Errorf("parameters of the xml codec are invalid: %v", err)
+	}
+	var record string
+	if v, ok := params["xml_record"]; ok {
+		if record, err = data.AsString(v); err != nil {
+			return nil, "", fmt.Errorf("'xml_record' parameter must be a string: %v", err)
+		}
+	}
+	return opts, record, nil
+}
+
+// extractFrameLayout returns the layout of frames when 'codec' parameter is
+// "binary". It accepts parameters of frame.NewLayout.
+func extractFrameLayout(params data.Map) (*frame.Layout, error) {
+	codec, err := extractCodecParameter(params)
+	if err != nil {
+		return nil, err
+	}
+	if codec != "binary" {
+		return nil, nil
+	}
+	l, err := frame.NewLayout(params)
+	if err != nil {
+		return nil, fmt.Errorf("parameters of the binary codec are invalid: %v", err)
+	}
+	return l, nil
+}
+
 // extractPathParameter retrieve 'path' parameter in the WITH clause of
 // CREATE SOURCE or CREATE SINK statement.
 func extractPathParameter(params data.Map) (string, error) {
@@ -224,6 +486,44 @@ func extractPathParameter(params data.Map) (string, error) {
 	return f, nil
 }
 
+// extractCodecParameter retrieves 'codec' parameter in the WITH clause of
+// CREATE SOURCE or CREATE SINK statement. It returns "json" when the
+// parameter is missing. Supported codecs are "json", "csv", "tsv", "xml",
+// and "binary".
+func extractCodecParameter(params data.Map) (string, error) {
+	v, ok := params["codec"]
+	if !ok {
+		return "json", nil
+	}
+	s, err := data.AsString(v)
+	if err != nil {
+		return "", fmt.Errorf("'codec' parameter must be a string: %v", err)
+	}
+	switch c := strings.ToLower(s); c {
+	case "json", "csv", "tsv", "xml", "binary":
+		return c, nil
+	}
+	return "", fmt.Errorf("'codec' parameter has an unsupported codec: %v", s)
+}
+
+// extractCompressionParameter retrieves 'compression' parameter in the WITH
+// clause of CREATE SOURCE or CREATE SINK statement. It returns nil when the
+// parameter is missing or "none".
+func extractCompressionParameter(params data.Map) (compress.Codec, error) {
+	v, ok := params["compression"]
+	if !ok {
+		return nil, nil
+	}
+	n, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'compression' parameter must be a string: %v", err)
+	}
+	if strings.ToLower(n) == "none" {
+		return nil, nil
+	}
+	return compress.Lookup(n)
+}
+
 func init() {
 	MustRegisterGlobalSourceCreator("file", SourceCreatorFunc(createFileSource))
 }
@@ -231,16 +531,21 @@ func init() {
 type writerSink struct {
 	m           sync.Mutex
 	w           io.Writer
+	f           TupleFormatter
 	shouldClose bool
+
+	// skipHeader is true when the header shouldn't be written or has
+	// already been written.
+	skipHeader bool
 }
 
 func (s *writerSink) Write(ctx *core.Context, t *core.Tuple) error {
-	// TODO: support custom formatting. There're several things that need to
-	// be considered such as concurrent formatting, zero-copy write, and so on.
 	// While encoding tuples outside the lock supports concurrent formatting,
 	// it makes it difficult to support zero-copy write.
-
-	js := t.Data.String() // Format this outside the lock
+	line, err := s.f.FormatTuple(t) // Format this outside the lock
+	if err != nil {
+		return err
+	}
 
 	// This lock is required to avoid interleaving JSONs.
 	s.m.Lock()
@@ -248,7 +553,19 @@ func (s *writerSink) Write(ctx *core.Context, t *core.Tuple) error {
 	if s.w == nil {
 		return errors.New("the sink is already closed")
 	}
-	_, err := fmt.Fprintln(s.w, js)
+	if hf, ok := s.f.(headerTupleFormatter); ok && !s.skipHeader {
+		h, err := hf.FormatHeader(t)
+		if err != nil {
+			return err
+		}
+		if h != "" {
+			if _, err := fmt.Fprintln(s.w, h); err != nil {
+				return err
+			}
+		}
+		s.skipHeader = true
+	}
+	_, err = fmt.Fprintln(s.w, line)
 	return err
 }
 
@@ -267,23 +584,32 @@ func (s *writerSink) Close(ctx *core.Context) error {
 }
 
 func createStdoutSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	f, err := NewTupleFormatter(params)
+	if err != nil {
+		return nil, err
+	}
 	return &writerSink{
 		w: os.Stdout,
+		f: f,
 	}, nil
 }
 
 func createFileSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
 	// TODO: currently this sink isn't secure because it accepts any path.
 	// TODO: support buffering
-	// TODO: provide "format" parameter to support output formats other than "jsonl".
-	//       "jsonl" should be the default value.
-	// TODO: support "compression" parameter with values like "gz".
 
 	fpath, err := extractPathParameter(params)
 	if err != nil {
 		return nil, err
 	}
 
+	// Tuples are written as JSON lines unless "template", "format", or
+	// "codec" is given.
+	f, err := NewTupleFormatter(params)
+	if err != nil {
+		return nil, err
+	}
+
 	flags := os.O_WRONLY | os.O_APPEND | os.O_CREATE
 	if v, ok := params["truncate"]; ok {
 		t, err := data.AsBool(v)
@@ -295,13 +621,38 @@ func createFileSink(ctx *core.Context, ioParams *IOParams, params data.Map) (cor
 		}
 	}
 
+	codec, err := extractCompressionParameter(params)
+	if err != nil {
+		return nil, err
+	}
+
 	file, err := os.OpenFile(fpath, flags, 0644)
 	if err != nil {
 		return nil, err
 	}
+
+	// The header isn't written again when appending to a non-empty file.
+	st, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	var w io.WriteCloser = file
+	if codec != nil {
+		// Appending to a compressed file creates a new compressed stream
+		// after the existing one. Readers of concatenated streams such as
+		// gzip's can still read the whole file.
+		if w, err = compress.NewWriteCloser(codec, file); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
 	return &writerSink{
-		w:           file,
+		w:           w,
+		f:           f,
 		shouldClose: true,
+		skipHeader:  st.Size() > 0,
 	}, nil
 }
 
@@ -318,6 +669,175 @@ func init() {
 	MustRegisterGlobalSourceCreator("dropped_tuples", SourceCreatorFunc(createDroppedTupleCollectorSource))
 }
 
+func createSystemEventSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	return core.NewSystemEventSource(), nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("system_events", SourceCreatorFunc(createSystemEventSource))
+}
+
+// createLatencySource creates a source periodically emitting latencies of
+// sources and sinks in the topology (see core.NewLatencySource). It accepts
+// 'interval' parameter, which is the interval between reports and 1 second
+// by default.
+func createLatencySource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	interval := time.Second
+	for k, v := range params {
+		switch k {
+		case "interval":
+			i, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("'interval' parameter should have a duration: %v", err)
+			}
+			if i <= 0 {
+				return nil, fmt.Errorf("'interval' parameter must be positive: %v", v)
+			}
+			interval = i
+		default:
+			return nil, fmt.Errorf("unknown parameter: %v", k)
+		}
+	}
+	return core.NewLatencySource(interval)
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("latencies", SourceCreatorFunc(createLatencySource))
+}
+
+// timerSource periodically emits tick tuples. A tick tuple has the sequence
+// number of the tick in "tick" and the scheduled time in "scheduled_at".
+// Its timestamp is also set to the scheduled time.
+//
+// A stream created by a timer source can be used as a trigger of a SELECT
+// statement. See TopologyBuilder.timerTriggers for details.
+type timerSource struct {
+	// interval is the interval between two consecutive ticks. It's only
+	// used when cron is nil.
+	interval time.Duration
+	cron     *cronSchedule
+	location *time.Location
+	stopCh   chan struct{}
+}
+
+func (s *timerSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	clock := ctx.Clock()
+	start := clock.Now()
+	next := s.next(start, start)
+	for tick := int64(1); ; tick++ {
+		if next.IsZero() {
+			// The cron expression will never match.
+			<-s.stopCh
+			return nil
+		}
+
+		timer := clock.NewTimer(next.Sub(clock.Now()))
+		select {
+		case <-s.stopCh:
+			timer.Stop()
+			return nil
+		case <-timer.C():
+		}
+		now := clock.Now()
+
+		t := &core.Tuple{
+			Timestamp:     next,
+			ProcTimestamp: now,
+			Data: data.Map{
+				"tick":         data.Int(tick),
+				"scheduled_at": data.Timestamp(next),
+			},
+		}
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+
+		next = s.next(next, now)
+	}
+}
+
+// next returns the time of the tick following prev.
+func (s *timerSource) next(prev, now time.Time) time.Time {
+	if s.cron != nil {
+		if prev.Before(now) {
+			// skip ticks which have already been missed
+			prev = now
+		}
+		return s.cron.next(prev.In(s.location))
+	}
+
+	next := prev.Add(s.interval)
+	if next.Before(now) {
+		// delayed too much and should be rescheduled.
+		next = now.Add(s.interval)
+	}
+	return next
+}
+
+func (s *timerSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+// createTimerSource creates a timer source. It accepts following parameters:
+//
+//	interval: the interval between ticks such as 10 (seconds) or "5m"
+//	cron: a cron expression such as "*/5 * * * *" or "@hourly"
+//	location: the name of the time zone used for cron, e.g. "Asia/Tokyo"
+//
+// Either interval or cron must be given.
+func createTimerSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &timerSource{
+		location: time.Local,
+		stopCh:   make(chan struct{}),
+	}
+
+	if v, ok := params["interval"]; ok {
+		i, err := data.ToDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("'interval' parameter should have a duration: %v", err)
+		}
+		if i <= 0 {
+			return nil, fmt.Errorf("'interval' parameter must be positive: %v", v)
+		}
+		s.interval = i
+	}
+
+	if v, ok := params["cron"]; ok {
+		if s.interval > 0 {
+			return nil, errors.New("'interval' and 'cron' parameters cannot be given at the same time")
+		}
+		e, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'cron' parameter must be a string: %v", err)
+		}
+		c, err := parseCronSchedule(e)
+		if err != nil {
+			return nil, fmt.Errorf("'cron' parameter has an invalid expression: %v", err)
+		}
+		s.cron = c
+	} else if s.interval == 0 {
+		return nil, errors.New("either 'interval' or 'cron' parameter is required")
+	}
+
+	if v, ok := params["location"]; ok {
+		l, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'location' parameter must be a string: %v", err)
+		}
+		loc, err := time.LoadLocation(l)
+		if err != nil {
+			return nil, fmt.Errorf("'location' parameter has an invalid time zone: %v", err)
+		}
+		s.location = loc
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("timer", SourceCreatorFunc(createTimerSource))
+}
+
 type nodeStatusSource struct {
 	topology core.Topology
 	interval time.Duration
@@ -325,14 +845,17 @@ type nodeStatusSource struct {
 }
 
 func (s *nodeStatusSource) GenerateStream(ctx *core.Context, w core.Writer) error {
-	next := time.Now().Add(s.interval)
+	clock := ctx.Clock()
+	next := clock.Now().Add(s.interval)
 	for {
+		timer := clock.NewTimer(next.Sub(clock.Now()))
 		select {
 		case <-s.stopCh:
+			timer.Stop()
 			return nil
-		case <-time.After(next.Sub(time.Now())):
+		case <-timer.C():
 		}
-		now := time.Now()
+		now := clock.Now()
 
 		for name, n := range s.topology.Nodes() {
 			t := &core.Tuple{
@@ -386,17 +909,20 @@ type edgeStatusSource struct {
 }
 
 func (s *edgeStatusSource) GenerateStream(ctx *core.Context, w core.Writer) error {
-	next := time.Now().Add(s.interval)
+	clock := ctx.Clock()
+	next := clock.Now().Add(s.interval)
 
 	inputPath := data.MustCompilePath("input_stats.inputs")
 
 	for {
+		timer := clock.NewTimer(next.Sub(clock.Now()))
 		select {
 		case <-s.stopCh:
+			timer.Stop()
 			return nil
-		case <-time.After(next.Sub(time.Now())):
+		case <-timer.C():
 		}
-		now := time.Now()
+		now := clock.Now()
 
 		// collect all nodes that can receive data
 		receivers := map[string]core.Node{}
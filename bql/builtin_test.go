@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -255,3 +256,261 @@ func TestFileSource(t *testing.T) {
 		})
 	})
 }
+
+func TestCompressedFile(t *testing.T) {
+	Convey("Given a file sink compressing tuples", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_bql_compressed_file")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		params := data.Map{
+			"path":        data.String(filepath.Join(dir, "out.jsonl.gz")),
+			"compression": data.String("gzip"),
+		}
+		write := func(n int) {
+			si, err := createFileSink(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			for i := 0; i < n; i++ {
+				So(si.Write(ctx, core.NewTuple(data.Map{"int": data.Int(i)})), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+		}
+
+		Convey("When writing tuples twice", func() {
+			write(2)
+			write(3)
+
+			Convey("Then the file should be compressed", func() {
+				b, err := ioutil.ReadFile(filepath.Join(dir, "out.jsonl.gz"))
+				So(err, ShouldBeNil)
+				So(b[:2], ShouldResemble, []byte{0x1f, 0x8b})
+			})
+
+			Convey("Then a file source should read all tuples", func() {
+				w := &testFileWriter{}
+				w.c = sync.NewCond(&w.m)
+				s, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldBeNil)
+				So(s.GenerateStream(ctx, w), ShouldBeNil)
+				So(w.cnt, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When using an unknown codec", func() {
+			params["compression"] = data.String("lzma")
+
+			Convey("Then creating a sink should fail", func() {
+				_, err := createFileSink(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then creating a source should fail", func() {
+				_, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestTimerSource(t *testing.T) {
+	Convey("Given a context", t, func() {
+		ctx := core.NewContext(nil)
+		w := &testFileWriter{}
+		w.c = sync.NewCond(&w.m)
+
+		Convey("When creating a timer source with an interval", func() {
+			s, err := createTimerSource(ctx, &IOParams{}, data.Map{
+				"interval": data.Float(0.001),
+			})
+			So(err, ShouldBeNil)
+
+			ch := make(chan error, 1)
+			go func() {
+				ch <- s.GenerateStream(ctx, w)
+			}()
+			Reset(func() {
+				s.Stop(ctx)
+				<-ch
+			})
+
+			Convey("Then it should emit ticks with proper intervals", func() {
+				w.wait(3)
+
+				w.m.Lock()
+				defer w.m.Unlock()
+				for i := 1; i < len(w.tss); i++ {
+					So(w.tss[i], ShouldHappenOnOrAfter, w.tss[i-1].Add(time.Millisecond))
+				}
+			})
+		})
+
+		Convey("When creating a timer source with a cron expression", func() {
+			s, err := createTimerSource(ctx, &IOParams{}, data.Map{
+				"cron":     data.String("0 0 * * *"),
+				"location": data.String("UTC"),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should schedule ticks by the expression", func() {
+				ts := s.(*timerSource)
+				now := time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC)
+				So(ts.next(now, now), ShouldResemble, time.Date(2015, time.April, 11, 0, 0, 0, 0, time.UTC))
+			})
+		})
+
+		Convey("When creating a timer source with invalid parameters", func() {
+			for _, params := range []data.Map{
+				{},
+				{"interval": data.Int(0)},
+				{"interval": data.String("5 minutes")},
+				{"cron": data.String("* * *")},
+				{"cron": data.Int(1)},
+				{"interval": data.Int(1), "cron": data.String("* * * * *")},
+				{"interval": data.Int(1), "location": data.String("No/Such_Zone")},
+			} {
+				params := params
+				Convey(fmt.Sprintf("Then %v should result in an error", params), func() {
+					_, err := createTimerSource(ctx, &IOParams{}, params)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestXMLFileSource(t *testing.T) {
+	Convey("Given an XML file", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_bql_xml_file")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		path := filepath.Join(dir, "in.xml")
+		So(ioutil.WriteFile(path, []byte(`<?xml version="1.0"?>
+<readings>
+  <reading id="1"><temp>21.5</temp></reading>
+  <reading id="2"><temp>22</temp></reading>
+</readings>`), 0644), ShouldBeNil)
+		params := data.Map{
+			"path":  data.String(path),
+			"codec": data.String("xml"),
+		}
+		read := func() ([]data.Map, error) {
+			var ms []data.Map
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			err = s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				ms = append(ms, t.Data)
+				return nil
+			}))
+			return ms, err
+		}
+
+		Convey("When reading it without records", func() {
+			ms, err := read()
+			So(err, ShouldBeNil)
+
+			Convey("Then the document should be a tuple", func() {
+				So(ms, ShouldHaveLength, 1)
+				So(ms[0], ShouldContainKey, "readings")
+			})
+		})
+
+		Convey("When reading records with type inference", func() {
+			params["xml_record"] = data.String("reading")
+			params["infer_types"] = data.True
+			ms, err := read()
+			So(err, ShouldBeNil)
+
+			Convey("Then each record should be a tuple", func() {
+				So(ms, ShouldResemble, []data.Map{
+					{"@id": data.Int(1), "temp": data.Float(21.5)},
+					{"@id": data.Int(2), "temp": data.Int(22)},
+				})
+			})
+		})
+
+		Convey("When reading a broken file", func() {
+			So(ioutil.WriteFile(path, []byte(`<a><b></a>`), 0644), ShouldBeNil)
+			_, err := read()
+
+			Convey("Then the source should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a source with invalid options", func() {
+			params["namespaces"] = data.String("drop")
+			_, err := createFileSource(ctx, &IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a sink with the codec", func() {
+			params["path"] = data.String(filepath.Join(dir, "out.xml"))
+			_, err := createFileSink(ctx, &IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestBinaryFileSource(t *testing.T) {
+	Convey("Given a file having binary frames", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_bql_binary_file")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		path := filepath.Join(dir, "in.bin")
+		So(ioutil.WriteFile(path, []byte{
+			0x00, 0x01, 0x00, 0xd7,
+			0x00, 0x02, 0xff, 0xf6,
+			0x00, 0x03, // partial frame
+		}, 0644), ShouldBeNil)
+		params := data.Map{
+			"path":  data.String(path),
+			"codec": data.String("binary"),
+			"fields": data.Array{
+				data.Map{"name": data.String("id"), "offset": data.Int(0), "type": data.String("uint16")},
+				data.Map{"name": data.String("temp"), "offset": data.Int(2), "type": data.String("int16"), "scale": data.Float(0.1)},
+			},
+		}
+
+		Convey("When reading it", func() {
+			var ms []data.Map
+			s, err := createFileSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			err = s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				ms = append(ms, t.Data)
+				return nil
+			}))
+			So(err, ShouldBeNil)
+
+			Convey("Then each complete frame should be a tuple", func() {
+				So(ms, ShouldHaveLength, 2)
+				So(ms[0]["id"], ShouldEqual, data.Int(1))
+				So(ms[0]["temp"], ShouldAlmostEqual, 21.5, 1e-9)
+				So(ms[1]["id"], ShouldEqual, data.Int(2))
+				So(ms[1]["temp"], ShouldAlmostEqual, -1.0, 1e-9)
+			})
+		})
+
+		Convey("When the layout is invalid", func() {
+			delete(params, "fields")
+			_, err := createFileSource(ctx, &IOParams{}, params)
+
+			Convey("Then creating the source should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,181 @@
+// Package compress provides a registry of compression codecs shared by
+// compression UDFs and sources and sinks supporting a compression parameter.
+//
+// "gzip" and "zlib" are always available. Other codecs such as "zstd" and
+// "snappy" are provided by subpackages and registered when they're imported
+// for side effects:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/compress/zstd"
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Codec creates compressing writers and decompressing readers of a
+// compression format.
+type Codec interface {
+	// NewWriter returns a writer compressing data written to it into w.
+	// The writer must be closed to flush all data, but closing it doesn't
+	// close w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+
+	// NewReader returns a reader decompressing data read from r. Closing
+	// the reader doesn't close r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	codecsMutex sync.RWMutex
+	codecs      = map[string]Codec{}
+)
+
+// Register registers a Codec with the name. The name is case-insensitive.
+func Register(name string, c Codec) error {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	n := strings.ToLower(name)
+	if _, ok := codecs[n]; ok {
+		return fmt.Errorf("compression codec '%v' is already registered", name)
+	}
+	codecs[n] = c
+	return nil
+}
+
+// MustRegister is like Register but panics if an error occurred.
+func MustRegister(name string, c Codec) {
+	if err := Register(name, c); err != nil {
+		panic(fmt.Errorf("compress.MustRegister: cannot register '%v': %v", name, err))
+	}
+}
+
+// Lookup returns the Codec registered with the name. It returns
+// core.NotExistError when the codec isn't registered.
+func Lookup(name string) (Codec, error) {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+	c, ok := codecs[strings.ToLower(name)]
+	if !ok {
+		return nil, core.NotExistError(fmt.Errorf("compression codec '%v' is not registered", name))
+	}
+	return c, nil
+}
+
+// Names returns names of registered codecs in sorted order.
+func Names() []string {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+	ns := make([]string, 0, len(codecs))
+	for n := range codecs {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+// Compress compresses b with the codec.
+func Compress(c Codec, b []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := c.NewWriter(buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(b); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses b with the codec.
+func Decompress(c Codec, b []byte) ([]byte, error) {
+	r, err := c.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+type writeCloser struct {
+	io.WriteCloser
+	c io.Closer
+}
+
+func (w *writeCloser) Close() error {
+	err := w.WriteCloser.Close()
+	if e := w.c.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// NewWriteCloser returns a writer compressing data into w. Unlike
+// Codec.NewWriter, closing the returned writer also closes w.
+func NewWriteCloser(c Codec, w io.WriteCloser) (io.WriteCloser, error) {
+	cw, err := c.NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	return &writeCloser{WriteCloser: cw, c: w}, nil
+}
+
+type readCloser struct {
+	io.ReadCloser
+	c io.Closer
+}
+
+func (r *readCloser) Close() error {
+	err := r.ReadCloser.Close()
+	if e := r.c.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// NewReadCloser returns a reader decompressing data read from r. Unlike
+// Codec.NewReader, closing the returned reader also closes r.
+func NewReadCloser(c Codec, r io.ReadCloser) (io.ReadCloser, error) {
+	cr, err := c.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &readCloser{ReadCloser: cr, c: r}, nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+type zlibCodec struct{}
+
+func (zlibCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zlib.NewWriter(w), nil
+}
+
+func (zlibCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+func init() {
+	MustRegister("gzip", gzipCodec{})
+	MustRegister("zlib", zlibCodec{})
+}
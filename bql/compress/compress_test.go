@@ -0,0 +1,99 @@
+package compress
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"io/ioutil"
+	"testing"
+)
+
+type testCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *testCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestCodecs(t *testing.T) {
+	Convey("Given the codec registry", t, func() {
+		Convey("When looking up built-in codecs", func() {
+			Convey("Then gzip and zlib should be available", func() {
+				So(Names(), ShouldContain, "gzip")
+				So(Names(), ShouldContain, "zlib")
+				_, err := Lookup("GZIP")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When looking up a missing codec", func() {
+			_, err := Lookup("lzma")
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When registering a codec with an existing name", func() {
+			err := Register("gzip", gzipCodec{})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		for _, n := range []string{"gzip", "zlib"} {
+			c, err := Lookup(n)
+			So(err, ShouldBeNil)
+
+			Convey("When compressing data with "+n, func() {
+				in := bytes.Repeat([]byte("sensorbee "), 100)
+				b, err := Compress(c, in)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should be smaller", func() {
+					So(len(b), ShouldBeLessThan, len(in))
+				})
+
+				Convey("Then it should be decompressed to the original data", func() {
+					d, err := Decompress(c, b)
+					So(err, ShouldBeNil)
+					So(d, ShouldResemble, in)
+				})
+
+				Convey("Then decompressing broken data should fail", func() {
+					_, err := Decompress(c, b[:len(b)/2])
+					So(err, ShouldNotBeNil)
+				})
+			})
+
+			Convey("When streaming data with "+n, func() {
+				buf := &testCloser{}
+				w, err := NewWriteCloser(c, buf)
+				So(err, ShouldBeNil)
+				_, err = w.Write([]byte("abc"))
+				So(err, ShouldBeNil)
+				So(w.Close(), ShouldBeNil)
+
+				Convey("Then closing the writer should close the underlying writer", func() {
+					So(buf.closed, ShouldBeTrue)
+				})
+
+				Convey("Then it should be read by a reader", func() {
+					src := &testCloser{}
+					src.Write(buf.Bytes())
+					r, err := NewReadCloser(c, src)
+					So(err, ShouldBeNil)
+					b, err := ioutil.ReadAll(r)
+					So(err, ShouldBeNil)
+					So(string(b), ShouldEqual, "abc")
+					So(r.Close(), ShouldBeNil)
+					So(src.closed, ShouldBeTrue)
+				})
+			})
+		}
+	})
+}
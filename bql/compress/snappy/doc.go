@@ -0,0 +1,11 @@
+// Package snappy registers the "snappy" compression codec implemented by
+// github.com/golang/snappy. Data is encoded in the snappy framing format so
+// that it can be streamed. Since it adds a dependency, the codec is only
+// built with the snappy build tag:
+//
+//	go build -tags snappy
+//
+// Import this package for side effects to register the codec:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/compress/snappy"
+package snappy
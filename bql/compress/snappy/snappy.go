@@ -0,0 +1,28 @@
+//go:build snappy
+// +build snappy
+
+package snappy
+
+import (
+	"github.com/golang/snappy"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/compress"
+	"io"
+	"io/ioutil"
+)
+
+// Codec is a compress.Codec for the snappy framing format.
+type Codec struct{}
+
+// NewWriter returns a snappy encoder writing to w.
+func (Codec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+// NewReader returns a snappy decoder reading from r.
+func (Codec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(snappy.NewReader(r)), nil
+}
+
+func init() {
+	compress.MustRegister("snappy", Codec{})
+}
@@ -0,0 +1,10 @@
+// Package zstd registers the "zstd" compression codec implemented by
+// github.com/klauspost/compress/zstd. Since it adds a dependency, the codec
+// is only built with the zstd build tag:
+//
+//	go build -tags zstd
+//
+// Import this package for side effects to register the codec:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/compress/zstd"
+package zstd
@@ -0,0 +1,31 @@
+//go:build zstd
+// +build zstd
+
+package zstd
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/compress"
+	"io"
+)
+
+// Codec is a compress.Codec for Zstandard.
+type Codec struct{}
+
+// NewWriter returns a Zstandard encoder writing to w.
+func (Codec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+// NewReader returns a Zstandard decoder reading from r.
+func (Codec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+func init() {
+	compress.MustRegister("zstd", Codec{})
+}
@@ -0,0 +1,187 @@
+package bql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a schedule written in the standard cron format having five
+// fields: minute, hour, day of month, month, and day of week. Each field
+// supports "*", numbers, ranges like "1-5", lists like "1,3,5", and steps
+// like "*/15" or "0-30/10".
+type cronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar and dowStar are true when the field is "*". When both day
+	// of month and day of week are restricted, a day matches if either of
+	// them matches, as in the traditional cron.
+	domStar bool
+	dowStar bool
+}
+
+type cronFieldRange struct {
+	name     string
+	min, max int
+}
+
+var (
+	cronMinute = cronFieldRange{"minute", 0, 59}
+	cronHour   = cronFieldRange{"hour", 0, 23}
+	cronDOM    = cronFieldRange{"day of month", 1, 31}
+	cronMonth  = cronFieldRange{"month", 1, 12}
+	cronDOW    = cronFieldRange{"day of week", 0, 7} // 0 and 7 are Sunday
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCronSchedule parses a cron expression. In addition to the five-field
+// format, it supports descriptors such as "@hourly" or "@daily".
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("a cron expression must have 5 fields: %v", expr)
+	}
+
+	s := &cronSchedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	for i, f := range []struct {
+		dst *uint64
+		r   *cronFieldRange
+	}{
+		{&s.minute, &cronMinute},
+		{&s.hour, &cronHour},
+		{&s.dom, &cronDOM},
+		{&s.month, &cronMonth},
+		{&s.dow, &cronDOW},
+	} {
+		bits, err := parseCronField(fields[i], f.r)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+func parseCronField(field string, r *cronFieldRange) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %v field: %v", r.name, part)
+			}
+			step = s
+			part = part[:i]
+		}
+
+		var begin, end int
+		switch {
+		case part == "*":
+			begin, end = r.min, r.max
+		case strings.Contains(part, "-"):
+			i := strings.Index(part, "-")
+			b, err := parseCronValue(part[:i], r)
+			if err != nil {
+				return 0, err
+			}
+			e, err := parseCronValue(part[i+1:], r)
+			if err != nil {
+				return 0, err
+			}
+			if b > e {
+				return 0, fmt.Errorf("invalid range in %v field: %v", r.name, part)
+			}
+			begin, end = b, e
+		default:
+			v, err := parseCronValue(part, r)
+			if err != nil {
+				return 0, err
+			}
+			begin, end = v, v
+			if step > 1 {
+				// "5/10" means "5-max/10"
+				end = r.max
+			}
+		}
+
+		for v := begin; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, r *cronFieldRange) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %v field: %v", r.name, s)
+	}
+	if v < r.min || v > r.max {
+		return 0, fmt.Errorf("%v field must be in [%v, %v]: %v", r.name, r.min, r.max, v)
+	}
+	return v, nil
+}
+
+// next returns the earliest time matching the schedule which is after t.
+// It returns the zero time when there's no such time within five years,
+// e.g. "0 0 30 2 *".
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
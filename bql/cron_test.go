@@ -0,0 +1,54 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestCronSchedule(t *testing.T) {
+	base := time.Date(2015, time.April, 10, 10, 23, 30, 0, time.UTC) // Friday
+
+	Convey("Given cron expressions", t, func() {
+		cases := []struct {
+			expr string
+			next time.Time
+		}{
+			{"* * * * *", time.Date(2015, time.April, 10, 10, 24, 0, 0, time.UTC)},
+			{"*/5 * * * *", time.Date(2015, time.April, 10, 10, 25, 0, 0, time.UTC)},
+			{"0 * * * *", time.Date(2015, time.April, 10, 11, 0, 0, 0, time.UTC)},
+			{"@hourly", time.Date(2015, time.April, 10, 11, 0, 0, 0, time.UTC)},
+			{"30 9 * * *", time.Date(2015, time.April, 11, 9, 30, 0, 0, time.UTC)},
+			{"0 0 * * 1-5", time.Date(2015, time.April, 13, 0, 0, 0, 0, time.UTC)},
+			{"0 0 * * 7", time.Date(2015, time.April, 12, 0, 0, 0, 0, time.UTC)},
+			{"0 0 1 * *", time.Date(2015, time.May, 1, 0, 0, 0, 0, time.UTC)},
+			{"0 0 1,15 * 1", time.Date(2015, time.April, 13, 0, 0, 0, 0, time.UTC)},
+			{"15,45 10 * * *", time.Date(2015, time.April, 10, 10, 45, 0, 0, time.UTC)},
+			{"0 0 29 2 *", time.Date(2016, time.February, 29, 0, 0, 0, 0, time.UTC)},
+			{"0 0 30 2 *", time.Time{}},
+		}
+
+		for _, c := range cases {
+			c := c
+			Convey("Then "+c.expr+" should compute the next time", func() {
+				s, err := parseCronSchedule(c.expr)
+				So(err, ShouldBeNil)
+				So(s.next(base), ShouldResemble, c.next)
+			})
+		}
+	})
+
+	Convey("Given invalid cron expressions", t, func() {
+		for _, e := range []string{
+			"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *",
+			"* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *",
+			"a * * * *",
+		} {
+			e := e
+			Convey("Then parsing '"+e+"' should fail", func() {
+				_, err := parseCronSchedule(e)
+				So(err, ShouldNotBeNil)
+			})
+		}
+	})
+}
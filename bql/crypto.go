@@ -0,0 +1,380 @@
+package bql
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"sync/atomic"
+)
+
+// aesKeyState has a key of AES-GCM used by encrypt, decrypt, and keyed_hash
+// functions and field_crypto boxes. The key is usually given as a secret so
+// that it's never written in BQL nor exposed through statuses:
+//
+//	CREATE STATE pii_key TYPE aes_key WITH key="${secret:pii_key}";
+//
+// It has following parameters:
+//
+//	key: the base64-encoded key having 16, 24, or 32 bytes, which selects
+//	    AES-128, AES-192, or AES-256, respectively.
+type aesKeyState struct {
+	aead cipher.AEAD
+	key  []byte
+}
+
+var (
+	_ core.SharedState = &aesKeyState{}
+)
+
+// The first byte of a plaintext tells how the value is serialized.
+const (
+	plainMsgpack byte = iota
+	plainBlob
+)
+
+func createAESKeyState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	v, ok := params["key"]
+	if !ok {
+		return nil, errors.New("'key' parameter is missing")
+	}
+	s, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'key' parameter must be a string: %v", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		// The error isn't included because it might contain a part of the key.
+		return nil, errors.New("'key' parameter must be base64-encoded")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("'key' parameter must have 16, 24, or 32 bytes: %v", len(key))
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesKeyState{
+		aead: aead,
+		key:  key,
+	}, nil
+}
+
+// Encrypt encrypts a value. The result has a random nonce followed by the
+// ciphertext so that the same value is encrypted differently each time.
+// The value is serialized with its type and restored as it was by Decrypt
+// except that timestamps are restored as integers of microseconds.
+func (s *aesKeyState) Encrypt(v data.Value) ([]byte, error) {
+	var plain []byte
+	if b, err := data.AsBlob(v); err == nil {
+		// msgpack doesn't distinguish blobs from strings, so blobs are
+		// stored as they are.
+		plain = append([]byte{plainBlob}, b...)
+	} else {
+		m, err := data.MarshalMsgpack(data.Map{"v": v})
+		if err != nil {
+			return nil, err
+		}
+		plain = append([]byte{plainMsgpack}, m...)
+	}
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plain)+s.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+// Decrypt decrypts a value encrypted by Encrypt.
+func (s *aesKeyState) Decrypt(b []byte) (data.Value, error) {
+	n := s.aead.NonceSize()
+	if len(b) < n+s.aead.Overhead() {
+		return nil, errors.New("the ciphertext is too short")
+	}
+	plain, err := s.aead.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		return nil, errors.New("cannot decrypt the ciphertext")
+	}
+	if len(plain) == 0 {
+		return nil, errors.New("the plaintext is empty")
+	}
+	switch plain[0] {
+	case plainBlob:
+		return data.Blob(plain[1:]), nil
+	case plainMsgpack:
+	default:
+		return nil, fmt.Errorf("the plaintext has an unknown format: %v", plain[0])
+	}
+	m, err := data.UnmarshalMsgpack(plain[1:])
+	if err != nil {
+		return nil, err
+	}
+	v, ok := m["v"]
+	if !ok {
+		return nil, errors.New("the plaintext doesn't have a value")
+	}
+	return v, nil
+}
+
+// Hash computes HMAC-SHA256 of a value with the key and returns it in
+// hexadecimal format. Strings and blobs are hashed as they are and other
+// values are hashed in their JSON representations. Since the hash is keyed,
+// it can be used to pseudonymize values without being reversed by
+// dictionary attacks.
+func (s *aesKeyState) Hash(v data.Value) (string, error) {
+	var b []byte
+	switch v.Type() {
+	case data.TypeString:
+		str, _ := data.AsString(v)
+		b = []byte(str)
+	case data.TypeBlob:
+		b, _ = data.AsBlob(v)
+	default:
+		b = []byte(v.String())
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(b)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (s *aesKeyState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+func lookupAESKeyState(ctx *core.Context, name data.Value) (*aesKeyState, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of a state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*aesKeyState)
+	if !ok {
+		return nil, fmt.Errorf("the state '%v' isn't an aes_key", n)
+	}
+	return s, nil
+}
+
+// encryptFunc encrypts a value with AES-GCM using the key in an aes_key
+// state.
+//
+// It can be used in BQL as `encrypt`.
+//
+//	Input: String (name of the state), Any
+//	Return Type: Blob
+var encryptFunc = udf.BinaryFunc(func(ctx *core.Context, name, v data.Value) (data.Value, error) {
+	s, err := lookupAESKeyState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if v.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	b, err := s.Encrypt(v)
+	if err != nil {
+		return nil, err
+	}
+	return data.Blob(b), nil
+})
+
+// decryptFunc decrypts a value encrypted by `encrypt` or a field_crypto box
+// using the key in an aes_key state. It fails when the ciphertext has been
+// tampered with or encrypted with another key.
+//
+// It can be used in BQL as `decrypt`.
+//
+//	Input: String (name of the state), Blob
+//	Return Type: Any
+var decryptFunc = udf.BinaryFunc(func(ctx *core.Context, name, v data.Value) (data.Value, error) {
+	s, err := lookupAESKeyState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if v.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	b, err := data.AsBlob(v)
+	if err != nil {
+		return nil, err
+	}
+	return s.Decrypt(b)
+})
+
+// keyedHashFunc computes HMAC-SHA256 of a value using the key in an aes_key
+// state in hexadecimal format.
+//
+// It can be used in BQL as `keyed_hash`.
+//
+//	Input: String (name of the state), Any
+//	Return Type: String
+var keyedHashFunc = udf.BinaryFunc(func(ctx *core.Context, name, v data.Value) (data.Value, error) {
+	s, err := lookupAESKeyState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if v.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	h, err := s.Hash(v)
+	if err != nil {
+		return nil, err
+	}
+	return data.String(h), nil
+})
+
+// fieldCryptoBox encrypts or hashes fields having personal information
+// before tuples leave the topology. It's created by
+//
+//	CREATE BOX secure TYPE field_crypto FROM s WITH
+//	    key="pii_key", encrypt=["name", "address.street"], hash=["email"];
+//
+// It has following parameters:
+//
+//	key: the name of an aes_key state.
+//	encrypt: an array of paths to fields encrypted like `encrypt`.
+//	hash: an array of paths to fields replaced with keyed hashes like
+//	    `keyed_hash`.
+//
+// At least one of encrypt and hash is required. Missing fields and null
+// values are left as they are. The state is looked up for each tuple so that
+// the key can be rotated by replacing the state.
+type fieldCryptoBox struct {
+	key     string
+	encrypt []data.Path
+	hash    []data.Path
+
+	numEncrypted int64
+	numHashed    int64
+}
+
+var (
+	_ core.StatefulBox = &fieldCryptoBox{}
+)
+
+func createFieldCryptoBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	b := &fieldCryptoBox{}
+	v, ok := params["key"]
+	if !ok {
+		return nil, errors.New("'key' parameter is missing")
+	}
+	k, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'key' parameter must be a string: %v", err)
+	}
+	if _, err := lookupAESKeyState(ctx, v); err != nil {
+		return nil, err
+	}
+	b.key = k
+
+	parse := func(name string) ([]data.Path, error) {
+		v, ok := params[name]
+		if !ok {
+			return nil, nil
+		}
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'%v' parameter must be an array: %v", name, err)
+		}
+		ps := make([]data.Path, len(a))
+		for i, f := range a {
+			s, err := data.AsString(f)
+			if err != nil {
+				return nil, fmt.Errorf("a field in '%v' parameter must be a string: %v", name, err)
+			}
+			if ps[i], err = data.CompilePath(s); err != nil {
+				return nil, fmt.Errorf("'%v' parameter has an invalid path: %v", name, err)
+			}
+		}
+		return ps, nil
+	}
+	if b.encrypt, err = parse("encrypt"); err != nil {
+		return nil, err
+	}
+	if b.hash, err = parse("hash"); err != nil {
+		return nil, err
+	}
+	if len(b.encrypt) == 0 && len(b.hash) == 0 {
+		return nil, errors.New("either 'encrypt' or 'hash' parameter must have fields")
+	}
+	return b, nil
+}
+
+func (b *fieldCryptoBox) Init(ctx *core.Context) error {
+	return nil
+}
+
+func (b *fieldCryptoBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	s, err := lookupAESKeyState(ctx, data.String(b.key))
+	if err != nil {
+		return err
+	}
+	if t.Flags.IsSet(core.TFSharedData) {
+		t.Data = t.Data.Copy()
+		t.Flags.Clear(core.TFSharedData)
+	}
+
+	var encrypted, hashed int64
+	for _, p := range b.encrypt {
+		v, err := t.Data.Get(p)
+		if err != nil || v.Type() == data.TypeNull {
+			continue
+		}
+		c, err := s.Encrypt(v)
+		if err != nil {
+			return err
+		}
+		if err := t.Data.Set(p, data.Blob(c)); err != nil {
+			return err
+		}
+		encrypted++
+	}
+	for _, p := range b.hash {
+		v, err := t.Data.Get(p)
+		if err != nil || v.Type() == data.TypeNull {
+			continue
+		}
+		h, err := s.Hash(v)
+		if err != nil {
+			return err
+		}
+		if err := t.Data.Set(p, data.String(h)); err != nil {
+			return err
+		}
+		hashed++
+	}
+	atomic.AddInt64(&b.numEncrypted, encrypted)
+	atomic.AddInt64(&b.numHashed, hashed)
+	return w.Write(ctx, t)
+}
+
+func (b *fieldCryptoBox) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// Status returns the status of the box.
+func (b *fieldCryptoBox) Status() data.Map {
+	return data.Map{
+		"key":           data.String(b.key),
+		"num_encrypted": data.Int(atomic.LoadInt64(&b.numEncrypted)),
+		"num_hashed":    data.Int(atomic.LoadInt64(&b.numHashed)),
+	}
+}
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("aes_key", udf.UDSCreatorFunc(createAESKeyState))
+	udf.MustRegisterGlobalUDF("encrypt", encryptFunc)
+	udf.MustRegisterGlobalUDF("decrypt", decryptFunc)
+	udf.MustRegisterGlobalUDF("keyed_hash", keyedHashFunc)
+	MustRegisterGlobalBoxCreator("field_crypto", BoxCreatorFunc(createFieldCryptoBox))
+}
@@ -0,0 +1,200 @@
+package bql
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestFieldCrypto(t *testing.T) {
+	Convey("Given a topology builder having a key from a secret", t, func() {
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		key := "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=" // 32 bytes
+		tb.Secrets = mapSecretProvider{"pii_key": key}
+		So(addBQLToTopology(tb, `CREATE STATE k TYPE aes_key WITH key="${secret:pii_key}";`), ShouldBeNil)
+		ctx := tp.Context()
+
+		Convey("When encrypting values", func() {
+			enc, err := tb.Reg.Lookup("encrypt", 2)
+			So(err, ShouldBeNil)
+			dec, err := tb.Reg.Lookup("decrypt", 2)
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be decrypted to the original values", func() {
+				for _, v := range []data.Value{
+					data.String("alice"),
+					data.Int(42),
+					data.Float(1.5),
+					data.Blob("\x00\x01"),
+					data.Array{data.String("a"), data.Bool(true)},
+					data.Map{"street": data.String("1st Ave")},
+				} {
+					c, err := enc.Call(ctx, data.String("k"), v)
+					So(err, ShouldBeNil)
+					So(c.Type(), ShouldEqual, data.TypeBlob)
+					p, err := dec.Call(ctx, data.String("k"), c)
+					So(err, ShouldBeNil)
+					So(data.Equal(p, v), ShouldBeTrue)
+				}
+			})
+
+			Convey("Then the same value should be encrypted differently", func() {
+				c1, err := enc.Call(ctx, data.String("k"), data.String("alice"))
+				So(err, ShouldBeNil)
+				c2, err := enc.Call(ctx, data.String("k"), data.String("alice"))
+				So(err, ShouldBeNil)
+				So(c1, ShouldNotResemble, c2)
+			})
+
+			Convey("Then a tampered ciphertext should be rejected", func() {
+				c, err := enc.Call(ctx, data.String("k"), data.String("alice"))
+				So(err, ShouldBeNil)
+				b, _ := data.AsBlob(c)
+				b[len(b)-1] ^= 1
+				_, err = dec.Call(ctx, data.String("k"), data.Blob(b))
+				So(err, ShouldNotBeNil)
+				_, err = dec.Call(ctx, data.String("k"), data.Blob("short"))
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then null should be returned as it is", func() {
+				c, err := enc.Call(ctx, data.String("k"), data.Null{})
+				So(err, ShouldBeNil)
+				So(c, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When hashing values", func() {
+			h, err := tb.Reg.Lookup("keyed_hash", 2)
+			So(err, ShouldBeNil)
+			v1, err := h.Call(ctx, data.String("k"), data.String("alice@example.com"))
+			So(err, ShouldBeNil)
+			v2, err := h.Call(ctx, data.String("k"), data.String("alice@example.com"))
+			So(err, ShouldBeNil)
+
+			Convey("Then the hash should be deterministic", func() {
+				So(v1, ShouldEqual, v2)
+				s, _ := data.AsString(v1)
+				So(s, ShouldHaveLength, 64)
+			})
+
+			Convey("Then another key should produce another hash", func() {
+				So(addBQLToTopology(tb, `CREATE STATE k2 TYPE aes_key WITH key="MDEyMzQ1Njc4OWFiY2RlZg==";`), ShouldBeNil)
+				v3, err := h.Call(ctx, data.String("k2"), data.String("alice@example.com"))
+				So(err, ShouldBeNil)
+				So(v3, ShouldNotEqual, v1)
+			})
+		})
+
+		Convey("When using a field_crypto box", func() {
+			So(addBQLToTopology(tb, `
+				CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+				CREATE STREAM s AS SELECT RSTREAM int AS id, "alice" AS name, {"email": "a@example.com"} AS contact, null AS phone
+					FROM source [RANGE 1 TUPLES];
+				CREATE BOX b TYPE field_crypto FROM s WITH key="k", encrypt=["name", "phone", "missing"], hash=["contact.email"];
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM b;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sn, err := tp.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sn.Sink().(*tupleCollectorSink)
+			si.Wait(4)
+			d := si.get(0).Data
+
+			Convey("Then configured fields should be encrypted", func() {
+				So(d["id"], ShouldEqual, data.Int(1))
+				So(d["name"].Type(), ShouldEqual, data.TypeBlob)
+				dec, err := tb.Reg.Lookup("decrypt", 2)
+				So(err, ShouldBeNil)
+				v, err := dec.Call(tp.Context(), data.String("k"), d["name"])
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("alice"))
+			})
+
+			Convey("Then configured fields should be hashed", func() {
+				email, err := data.AsString(d["contact"].(data.Map)["email"])
+				So(err, ShouldBeNil)
+				So(email, ShouldHaveLength, 64)
+			})
+
+			Convey("Then null and missing fields should be left as they are", func() {
+				So(d["phone"], ShouldResemble, data.Null{})
+				_, ok := d["missing"]
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then the key shouldn't appear in the status", func() {
+				st := si.get(0).Data.String()
+				So(st, ShouldNotContainSubstring, key)
+				bn, err := tp.Box("b")
+				So(err, ShouldBeNil)
+				So(bn.Status().String(), ShouldNotContainSubstring, key)
+			})
+		})
+
+		Convey("When creating invalid key states", func() {
+			for _, params := range []string{
+				``,
+				`WITH key=1`,
+				`WITH key="not base64!"`,
+				`WITH key="MDEyMzQ1"`,
+			} {
+				err := addBQLToTopology(tb, `CREATE STATE bad TYPE aes_key `+params+`;`)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldNotContainSubstring, "MDEyMzQ1")
+			}
+		})
+
+		Convey("When creating field_crypto boxes with invalid parameters", func() {
+			So(addBQLToTopology(tb, `CREATE PAUSED SOURCE source TYPE dummy;`), ShouldBeNil)
+			for _, params := range []string{
+				`encrypt=["a"]`,
+				`key="no_such_state", encrypt=["a"]`,
+				`key="k"`,
+				`key="k", encrypt="a"`,
+				`key="k", hash=[1]`,
+				`key="k", hash=["/a"]`,
+			} {
+				err := addBQLToTopology(tb, `CREATE BOX b TYPE field_crypto FROM source WITH `+params+`;`)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestAESKeyStateHash(t *testing.T) {
+	Convey("Given an aes_key state", t, func() {
+		s, err := createAESKeyState(nil, data.Map{"key": data.String("MDEyMzQ1Njc4OWFiY2RlZg==")})
+		So(err, ShouldBeNil)
+		k := s.(*aesKeyState)
+
+		Convey("When hashing a string and a blob having the same bytes", func() {
+			h1, err := k.Hash(data.String("abc"))
+			So(err, ShouldBeNil)
+			h2, err := k.Hash(data.Blob("abc"))
+			So(err, ShouldBeNil)
+
+			Convey("Then they should have the same hash", func() {
+				So(h1, ShouldEqual, h2)
+			})
+		})
+
+		Convey("When encrypting a large value", func() {
+			v := data.Blob(bytes.Repeat([]byte("x"), 1<<16))
+			c, err := k.Encrypt(v)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be decrypted", func() {
+				d, err := k.Decrypt(c)
+				So(err, ShouldBeNil)
+				So(d, ShouldResemble, v)
+			})
+		})
+	})
+}
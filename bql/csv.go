@@ -0,0 +1,620 @@
+package bql
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// csvType is a type of values in a column of CSV.
+type csvType int
+
+const (
+	csvString csvType = iota
+	csvInt
+	csvFloat
+	csvBool
+	csvTimestamp
+)
+
+var csvTypes = map[string]csvType{
+	"string":    csvString,
+	"int":       csvInt,
+	"float":     csvFloat,
+	"bool":      csvBool,
+	"timestamp": csvTimestamp,
+}
+
+// csvMalformedPolicy is how the file source handles a record which doesn't
+// match its columns or has a value which cannot be converted to the type of
+// the column.
+type csvMalformedPolicy int
+
+const (
+	// csvSkipMalformed skips the record after logging a warning.
+	csvSkipMalformed csvMalformedPolicy = iota
+
+	// csvFailOnMalformed stops the source with an error.
+	csvFailOnMalformed
+
+	// csvLenient sets NULL to missing fields, ignores extra fields, and keeps
+	// values which cannot be converted as strings.
+	csvLenient
+)
+
+// csvConfig has parameters of the CSV codec. See extractCSVConfig for
+// details.
+type csvConfig struct {
+	delimiter rune
+
+	// quote is the character quoting fields. Fields aren't quoted when it's 0.
+	quote rune
+
+	header     bool
+	columns    []string
+	types      map[string]csvType
+	inferTypes bool
+	malformed  csvMalformedPolicy
+}
+
+// extractCSVConfig returns csvConfig when 'codec' parameter is "csv" or
+// "tsv". It returns nil for other codecs. The CSV codec accepts following
+// parameters:
+//
+//   - delimiter: a character separating fields. The default value is "," for
+//     "csv" and "\t" for "tsv".
+//   - quote: a character quoting fields having delimiters, quotes, or
+//     newlines. An empty string disables quoting. The default value is `"`
+//     for "csv" and "" for "tsv".
+//   - header: true when the first line has names of columns. The default
+//     value is true.
+//   - columns: an array of names of columns. It's used instead of the header
+//     when both are given. Sinks write values of JSON paths given by it and
+//     use keys of the first tuple sorted by their names when it's missing.
+//     Sources name columns as "c1", "c2", and so on when there's neither the
+//     header nor it.
+//   - types: a map from names of columns to one of "string", "int", "float",
+//     "bool", and "timestamp". Empty fields are NULL except for "string".
+//     "timestamp" accepts RFC3339 strings and UNIX times in seconds.
+//   - infer_types: true to infer types of columns not in 'types'. Empty
+//     fields are NULL, "true" and "false" are bool, and numbers are int or
+//     float. Other fields are strings. The default value is true.
+//   - malformed_rows: "skip", "fail", or "lenient". See csvMalformedPolicy
+//     for details. Records which cannot be parsed are skipped even with
+//     "lenient". The default value is "skip".
+//
+// 'types', 'infer_types', and 'malformed_rows' are only used by sources.
+func extractCSVConfig(params data.Map) (*csvConfig, error) {
+	codec, err := extractCodecParameter(params)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &csvConfig{
+		header:     true,
+		inferTypes: true,
+	}
+	switch codec {
+	case "csv":
+		c.delimiter = ','
+		c.quote = '"'
+	case "tsv":
+		c.delimiter = '\t'
+	default:
+		return nil, nil
+	}
+
+	char := func(name string, allowEmpty bool) (rune, bool, error) {
+		v, ok := params[name]
+		if !ok {
+			return 0, false, nil
+		}
+		s, err := data.AsString(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("'%v' parameter must be a string: %v", name, err)
+		}
+		if s == "" && allowEmpty {
+			return 0, true, nil
+		}
+		r, n := utf8.DecodeRuneInString(s)
+		if n != len(s) || r == utf8.RuneError || r == '\r' || r == '\n' {
+			return 0, false, fmt.Errorf("'%v' parameter must be a character other than newlines: %v", name, s)
+		}
+		return r, true, nil
+	}
+	if r, ok, err := char("delimiter", false); err != nil {
+		return nil, err
+	} else if ok {
+		c.delimiter = r
+	}
+	if r, ok, err := char("quote", true); err != nil {
+		return nil, err
+	} else if ok {
+		c.quote = r
+	}
+	if c.delimiter == c.quote {
+		return nil, errors.New("'delimiter' and 'quote' parameters must be different")
+	}
+
+	if v, ok := params["header"]; ok {
+		b, err := data.AsBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("'header' parameter must be bool: %v", err)
+		}
+		c.header = b
+	}
+
+	if v, ok := params["columns"]; ok {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'columns' parameter must be an array: %v", err)
+		}
+		if len(a) == 0 {
+			return nil, errors.New("'columns' parameter must have at least one column")
+		}
+		for _, e := range a {
+			s, err := data.AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("'columns' parameter must be an array of strings: %v", err)
+			}
+			c.columns = append(c.columns, s)
+		}
+		if err := validateCSVColumns(c.columns); err != nil {
+			return nil, fmt.Errorf("'columns' parameter is invalid: %v", err)
+		}
+	}
+
+	if v, ok := params["types"]; ok {
+		m, err := data.AsMap(v)
+		if err != nil {
+			return nil, fmt.Errorf("'types' parameter must be a map: %v", err)
+		}
+		c.types = make(map[string]csvType, len(m))
+		for col, tv := range m {
+			s, err := data.AsString(tv)
+			if err != nil {
+				return nil, fmt.Errorf("'types' parameter has an invalid type of %v: %v", col, err)
+			}
+			t, ok := csvTypes[strings.ToLower(s)]
+			if !ok {
+				return nil, fmt.Errorf("'types' parameter has an unsupported type of %v: %v", col, s)
+			}
+			c.types[col] = t
+		}
+	}
+
+	if v, ok := params["infer_types"]; ok {
+		b, err := data.AsBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("'infer_types' parameter must be bool: %v", err)
+		}
+		c.inferTypes = b
+	}
+
+	if v, ok := params["malformed_rows"]; ok {
+		s, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'malformed_rows' parameter must be a string: %v", err)
+		}
+		switch strings.ToLower(s) {
+		case "skip":
+			c.malformed = csvSkipMalformed
+		case "fail":
+			c.malformed = csvFailOnMalformed
+		case "lenient":
+			c.malformed = csvLenient
+		default:
+			return nil, fmt.Errorf("'malformed_rows' parameter must be one of skip, fail, and lenient: %v", s)
+		}
+	}
+	return c, nil
+}
+
+func validateCSVColumns(cols []string) error {
+	seen := make(map[string]bool, len(cols))
+	for _, c := range cols {
+		if c == "" {
+			return errors.New("a column name cannot be empty")
+		}
+		if seen[c] {
+			return fmt.Errorf("duplicated column: %v", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// formatRecord returns a line having fields. Fields are quoted only when
+// they have delimiters, quotes, or newlines.
+func (c *csvConfig) formatRecord(fields []string) (string, error) {
+	b := bytes.NewBuffer(nil)
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteRune(c.delimiter)
+		}
+		special := strings.ContainsRune(f, c.delimiter) || strings.ContainsAny(f, "\r\n")
+		if c.quote == 0 {
+			if special {
+				return "", fmt.Errorf("a field cannot have delimiters or newlines without quoting: %q", f)
+			}
+			b.WriteString(f)
+			continue
+		}
+		if !special && !strings.ContainsRune(f, c.quote) {
+			b.WriteString(f)
+			continue
+		}
+		q := string(c.quote)
+		b.WriteString(q)
+		b.WriteString(strings.Replace(f, q, q+q, -1))
+		b.WriteString(q)
+	}
+	return b.String(), nil
+}
+
+// csvReader reads records from CSV. A quoted field can have newlines.
+type csvReader struct {
+	r         *bufio.Reader
+	delimiter rune
+	quote     rune
+
+	// lineNumber is the number of lines read so far.
+	lineNumber int
+
+	// line is the text of the record read last. It's used to report
+	// malformed records.
+	line string
+}
+
+func newCSVReader(c *csvConfig, r io.Reader) *csvReader {
+	return &csvReader{
+		r:         bufio.NewReader(r),
+		delimiter: c.delimiter,
+		quote:     c.quote,
+	}
+}
+
+// readLine returns the next line without its trailing newline. It returns
+// io.EOF when no line is left.
+func (r *csvReader) readLine() (string, error) {
+	line, err := r.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	if r.lineNumber == 0 {
+		line = strings.TrimPrefix(line, "\ufeff") // UTF-8 BOM
+	}
+	r.lineNumber++
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Read returns fields of the next record. Empty lines are skipped. It
+// returns io.EOF when no record is left. csvSyntaxError is returned for a
+// malformed record, and Read can be called again to read the next record.
+func (r *csvReader) Read() ([]string, error) {
+	var line string
+	for line == "" {
+		l, err := r.readLine()
+		if err != nil {
+			return nil, err
+		}
+		line = l
+	}
+	r.line = line
+
+	var (
+		fields []string
+		field  []rune
+		quoted bool // true while reading a quoted field
+		closed bool // true after a quoted field is closed
+	)
+	for {
+		rs := []rune(line)
+		for i := 0; i < len(rs); i++ {
+			c := rs[i]
+			switch {
+			case quoted && c == r.quote:
+				if i+1 < len(rs) && rs[i+1] == r.quote {
+					field = append(field, c)
+					i++
+				} else {
+					quoted = false
+					closed = true
+				}
+			case quoted:
+				field = append(field, c)
+			case c == r.delimiter:
+				fields = append(fields, string(field))
+				field = field[:0]
+				closed = false
+			case closed:
+				return nil, csvSyntaxError(fmt.Sprintf("unexpected %q after a quoted field", c))
+			case c == r.quote && r.quote != 0 && len(field) == 0:
+				quoted = true
+			default:
+				field = append(field, c)
+			}
+		}
+		if !quoted {
+			break
+		}
+
+		// The quoted field continues to the next line.
+		l, err := r.readLine()
+		if err == io.EOF {
+			return nil, csvSyntaxError("a quoted field isn't closed")
+		} else if err != nil {
+			return nil, err
+		}
+		field = append(field, '\n')
+		line = l
+		r.line += "\n" + l
+	}
+	return append(fields, string(field)), nil
+}
+
+// csvSyntaxError is returned from csvReader when a record cannot be parsed.
+type csvSyntaxError string
+
+func (e csvSyntaxError) Error() string {
+	return string(e)
+}
+
+// csvDecoder decodes records of CSV into data.Map.
+type csvDecoder struct {
+	c *csvConfig
+	r *csvReader
+
+	// columns are names of columns. It's nil when neither the header nor
+	// 'columns' parameter is given.
+	columns    []string
+	headerRead bool
+	lineNumber int
+}
+
+func newCSVDecoder(c *csvConfig, r io.Reader) *csvDecoder {
+	return &csvDecoder{
+		c:       c,
+		r:       newCSVReader(c, r),
+		columns: c.columns,
+	}
+}
+
+func (d *csvDecoder) Decode() (data.Map, error) {
+	if d.c.header && !d.headerRead {
+		d.headerRead = true
+		h, err := d.r.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, fmt.Errorf("cannot read the header: %v", err)
+		}
+		if d.columns == nil {
+			if err := validateCSVColumns(h); err != nil {
+				return nil, fmt.Errorf("the header is invalid: %v", err)
+			}
+			d.columns = h
+		}
+	}
+
+	fields, err := d.r.Read()
+	d.lineNumber = d.r.lineNumber
+	if err != nil {
+		if _, ok := err.(csvSyntaxError); ok {
+			return nil, d.malformed(err)
+		}
+		return nil, err
+	}
+
+	if d.columns != nil && len(fields) != len(d.columns) {
+		if d.c.malformed != csvLenient {
+			return nil, d.malformed(fmt.Errorf("the record has %v fields but %v columns are expected",
+				len(fields), len(d.columns)))
+		}
+		for len(fields) < len(d.columns) {
+			fields = append(fields, "")
+		}
+		fields = fields[:len(d.columns)]
+	}
+
+	m := make(data.Map, len(fields))
+	for i, f := range fields {
+		var col string
+		if d.columns != nil {
+			col = d.columns[i]
+		} else {
+			col = fmt.Sprintf("c%v", i+1)
+		}
+		v, err := d.convert(col, f)
+		if err != nil {
+			if d.c.malformed != csvLenient {
+				return nil, d.malformed(fmt.Errorf("column %v: %v", col, err))
+			}
+			v = data.String(f)
+		}
+		m[col] = v
+	}
+	return m, nil
+}
+
+// LineNumber returns the number of the last line of the record decoded last.
+func (d *csvDecoder) LineNumber() int {
+	return d.lineNumber
+}
+
+func (d *csvDecoder) malformed(err error) error {
+	if d.c.malformed == csvFailOnMalformed {
+		return fmt.Errorf("line %v has a malformed record: %v", d.lineNumber, err)
+	}
+	return &malformedRecordError{
+		lineNumber: d.lineNumber,
+		body:       d.r.line,
+		err:        err,
+	}
+}
+
+func (d *csvDecoder) convert(col, s string) (data.Value, error) {
+	t, ok := d.c.types[col]
+	if !ok {
+		if d.c.inferTypes {
+			return inferCSVValue(s), nil
+		}
+		return data.String(s), nil
+	}
+	if t == csvString {
+		return data.String(s), nil
+	}
+	if s == "" {
+		return data.Null{}, nil
+	}
+
+	switch t {
+	case csvInt:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to int", s)
+		}
+		return data.Int(i), nil
+	case csvFloat:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to float", s)
+		}
+		return data.Float(f), nil
+	case csvBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to bool", s)
+		}
+		return data.Bool(b), nil
+	case csvTimestamp:
+		ts, err := data.ToTimestamp(inferCSVValue(s))
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to timestamp", s)
+		}
+		return data.Timestamp(ts), nil
+	}
+	return nil, fmt.Errorf("unsupported type: %v", t)
+}
+
+// inferCSVValue converts a field to a value of the type inferred from it.
+func inferCSVValue(s string) data.Value {
+	if s == "" {
+		return data.Null{}
+	}
+	switch strings.ToLower(s) {
+	case "true":
+		return data.True
+	case "false":
+		return data.False
+	}
+
+	// Only decimal numbers are converted so that fields such as "NaN" and
+	// "0x1F" are kept as strings.
+	if strings.Trim(s, "0123456789+-.eE") != "" {
+		return data.String(s)
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return data.Int(i)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return data.Float(f)
+	}
+	return data.String(s)
+}
+
+// csvTupleFormatter writes tuples as records of CSV.
+type csvTupleFormatter struct {
+	c *csvConfig
+
+	// paths are JSON paths of columns given by 'columns' parameter. When
+	// it's nil, columns are keys of the first tuple, which are set to keys
+	// by init.
+	paths []data.Path
+	once  sync.Once
+	keys  []string
+}
+
+func newCSVTupleFormatter(c *csvConfig) (*csvTupleFormatter, error) {
+	f := &csvTupleFormatter{
+		c: c,
+	}
+	for _, col := range c.columns {
+		p, err := data.CompilePath(col)
+		if err != nil {
+			return nil, fmt.Errorf("'columns' parameter has an invalid path '%v': %v", col, err)
+		}
+		f.paths = append(f.paths, p)
+	}
+	return f, nil
+}
+
+func (f *csvTupleFormatter) init(t *core.Tuple) {
+	f.once.Do(func() {
+		if f.paths != nil {
+			return
+		}
+		for k := range t.Data {
+			f.keys = append(f.keys, k)
+		}
+		sort.Strings(f.keys)
+	})
+}
+
+// FormatHeader returns the header line. t is the first tuple written by the
+// sink. It returns an empty string when the header is disabled.
+func (f *csvTupleFormatter) FormatHeader(t *core.Tuple) (string, error) {
+	if !f.c.header {
+		return "", nil
+	}
+	f.init(t)
+	if f.paths != nil {
+		return f.c.formatRecord(f.c.columns)
+	}
+	return f.c.formatRecord(f.keys)
+}
+
+// FormatTuple writes values of columns. Missing fields and NULLs are written
+// as empty fields. Maps and arrays are written as JSON.
+func (f *csvTupleFormatter) FormatTuple(t *core.Tuple) (string, error) {
+	f.init(t)
+	var fields []string
+	add := func(v data.Value, ok bool) error {
+		if !ok {
+			fields = append(fields, "")
+			return nil
+		}
+		s, err := data.ToString(v)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, s)
+		return nil
+	}
+	if f.paths != nil {
+		for _, p := range f.paths {
+			v, err := t.Data.Get(p)
+			if err := add(v, err == nil); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		for _, k := range f.keys {
+			v, ok := t.Data[k]
+			if err := add(v, ok); err != nil {
+				return "", err
+			}
+		}
+	}
+	return f.c.formatRecord(fields)
+}
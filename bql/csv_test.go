@@ -0,0 +1,385 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractCSVConfig(t *testing.T) {
+	Convey("Given parameters of the CSV codec", t, func() {
+		Convey("When the codec isn't given", func() {
+			c, err := extractCSVConfig(data.Map{})
+
+			Convey("Then it should return nil", func() {
+				So(err, ShouldBeNil)
+				So(c, ShouldBeNil)
+			})
+		})
+
+		Convey("When the codec is csv", func() {
+			c, err := extractCSVConfig(data.Map{"codec": data.String("csv")})
+
+			Convey("Then it should have default parameters", func() {
+				So(err, ShouldBeNil)
+				So(c, ShouldResemble, &csvConfig{
+					delimiter:  ',',
+					quote:      '"',
+					header:     true,
+					inferTypes: true,
+				})
+			})
+		})
+
+		Convey("When the codec is tsv", func() {
+			c, err := extractCSVConfig(data.Map{"codec": data.String("TSV")})
+
+			Convey("Then it should be delimited by tabs without quoting", func() {
+				So(err, ShouldBeNil)
+				So(c.delimiter, ShouldEqual, '\t')
+				So(c.quote, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When all parameters are given", func() {
+			c, err := extractCSVConfig(data.Map{
+				"codec":          data.String("csv"),
+				"delimiter":      data.String(";"),
+				"quote":          data.String("'"),
+				"header":         data.False,
+				"columns":        data.Array{data.String("a"), data.String("b")},
+				"types":          data.Map{"a": data.String("int")},
+				"infer_types":    data.False,
+				"malformed_rows": data.String("lenient"),
+			})
+
+			Convey("Then it should have them", func() {
+				So(err, ShouldBeNil)
+				So(c, ShouldResemble, &csvConfig{
+					delimiter:  ';',
+					quote:      '\'',
+					columns:    []string{"a", "b"},
+					types:      map[string]csvType{"a": csvInt},
+					inferTypes: false,
+					malformed:  csvLenient,
+				})
+			})
+		})
+
+		Convey("When parameters are invalid", func() {
+			for _, p := range []data.Map{
+				{"codec": data.String("yaml")},
+				{"codec": data.String("csv"), "delimiter": data.String(",,")},
+				{"codec": data.String("csv"), "delimiter": data.String("")},
+				{"codec": data.String("csv"), "delimiter": data.String("\n")},
+				{"codec": data.String("csv"), "quote": data.String(",")},
+				{"codec": data.String("csv"), "columns": data.Array{}},
+				{"codec": data.String("csv"), "columns": data.Array{data.String("a"), data.String("a")}},
+				{"codec": data.String("csv"), "types": data.Map{"a": data.String("date")}},
+				{"codec": data.String("csv"), "malformed_rows": data.String("ignore")},
+			} {
+				_, err := extractCSVConfig(p)
+
+				Convey("Then it should fail: "+p.String(), func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestCSVDecoder(t *testing.T) {
+	decodeAll := func(params data.Map, in string) ([]data.Map, []error) {
+		params["codec"] = data.String("csv")
+		c, err := extractCSVConfig(params)
+		So(err, ShouldBeNil)
+		d := newCSVDecoder(c, strings.NewReader(in))
+		var ms []data.Map
+		var errs []error
+		for {
+			m, err := d.Decode()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				errs = append(errs, err)
+				if _, ok := err.(*malformedRecordError); !ok {
+					break
+				}
+				continue
+			}
+			ms = append(ms, m)
+		}
+		return ms, errs
+	}
+
+	Convey("Given CSV with a header", t, func() {
+		in := "\ufeffid,name,temp,ok,note\r\n" +
+			"1,\"sensor, 1\",21.5,true,\n" +
+			"\n" +
+			"2,\"say \"\"hi\"\"\",-3,FALSE,\"multi\nline\"\n"
+
+		Convey("When decoding it with type inference", func() {
+			ms, errs := decodeAll(data.Map{}, in)
+
+			Convey("Then it should have values of inferred types", func() {
+				So(errs, ShouldBeEmpty)
+				So(ms, ShouldResemble, []data.Map{
+					{
+						"id":   data.Int(1),
+						"name": data.String("sensor, 1"),
+						"temp": data.Float(21.5),
+						"ok":   data.True,
+						"note": data.Null{},
+					},
+					{
+						"id":   data.Int(2),
+						"name": data.String(`say "hi"`),
+						"temp": data.Int(-3),
+						"ok":   data.False,
+						"note": data.String("multi\nline"),
+					},
+				})
+			})
+		})
+
+		Convey("When decoding it with type hints and without inference", func() {
+			ms, errs := decodeAll(data.Map{
+				"types": data.Map{
+					"id":   data.String("string"),
+					"temp": data.String("float"),
+				},
+				"infer_types": data.False,
+			}, in)
+
+			Convey("Then it should have values of the given types", func() {
+				So(errs, ShouldBeEmpty)
+				So(ms, ShouldHaveLength, 2)
+				So(ms[1]["id"], ShouldEqual, data.String("2"))
+				So(ms[1]["temp"], ShouldEqual, data.Float(-3))
+				So(ms[1]["ok"], ShouldEqual, data.String("FALSE"))
+				So(ms[0]["note"], ShouldEqual, data.String(""))
+			})
+		})
+
+		Convey("When decoding it with columns", func() {
+			ms, errs := decodeAll(data.Map{
+				"columns": data.Array{data.String("a"), data.String("b"), data.String("c"),
+					data.String("d"), data.String("e")},
+			}, in)
+
+			Convey("Then the header should be skipped", func() {
+				So(errs, ShouldBeEmpty)
+				So(ms, ShouldHaveLength, 2)
+				So(ms[0]["a"], ShouldEqual, data.Int(1))
+			})
+		})
+	})
+
+	Convey("Given CSV without a header", t, func() {
+		in := "1,2016-01-02T03:04:05Z\n2,1451703845\n"
+
+		Convey("When decoding it without columns", func() {
+			ms, errs := decodeAll(data.Map{
+				"header": data.False,
+				"types":  data.Map{"c2": data.String("timestamp")},
+			}, in)
+
+			Convey("Then columns should be numbered", func() {
+				So(errs, ShouldBeEmpty)
+				ts := data.Timestamp(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+				So(ms, ShouldHaveLength, 2)
+				So(ms[0]["c1"], ShouldEqual, data.Int(1))
+				So(time.Time(ms[0]["c2"].(data.Timestamp)).Equal(time.Time(ts)), ShouldBeTrue)
+				So(time.Time(ms[1]["c2"].(data.Timestamp)).Equal(time.Time(ts)), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given CSV having malformed rows", t, func() {
+		in := "a,b\n1,2\n3\n4,x\n5,6,7\n\"8,9\n"
+		params := data.Map{"types": data.Map{"b": data.String("int")}}
+
+		Convey("When decoding it with the default policy", func() {
+			ms, errs := decodeAll(params, in)
+
+			Convey("Then malformed rows should be skipped", func() {
+				So(ms, ShouldResemble, []data.Map{{"a": data.Int(1), "b": data.Int(2)}})
+				So(errs, ShouldHaveLength, 4)
+				e := errs[0].(*malformedRecordError)
+				So(e.lineNumber, ShouldEqual, 3)
+				So(e.body, ShouldEqual, "3")
+			})
+		})
+
+		Convey("When decoding it with the lenient policy", func() {
+			params["malformed_rows"] = data.String("lenient")
+			ms, errs := decodeAll(params, in)
+
+			Convey("Then rows should be fixed except for unparsable ones", func() {
+				So(ms, ShouldResemble, []data.Map{
+					{"a": data.Int(1), "b": data.Int(2)},
+					{"a": data.Int(3), "b": data.Null{}},
+					{"a": data.Int(4), "b": data.String("x")},
+					{"a": data.Int(5), "b": data.Int(6)},
+				})
+				So(errs, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When decoding it with the fail policy", func() {
+			params["malformed_rows"] = data.String("fail")
+			ms, errs := decodeAll(params, in)
+
+			Convey("Then it should stop at the first malformed row", func() {
+				So(ms, ShouldHaveLength, 1)
+				So(errs, ShouldHaveLength, 1)
+				_, ok := errs[0].(*malformedRecordError)
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestCSVTupleFormatter(t *testing.T) {
+	tuple := core.NewTuple(data.Map{
+		"name": data.String(`a "b", c`),
+		"temp": data.Float(21.5),
+		"tags": data.Array{data.String("x")},
+		"none": data.Null{},
+	})
+
+	Convey("Given a tuple", t, func() {
+		Convey("When formatting it as CSV without columns", func() {
+			f, err := NewTupleFormatter(data.Map{"codec": data.String("csv")})
+			So(err, ShouldBeNil)
+			hf := f.(headerTupleFormatter)
+
+			Convey("Then the header should have sorted keys", func() {
+				h, err := hf.FormatHeader(tuple)
+				So(err, ShouldBeNil)
+				So(h, ShouldEqual, "name,none,tags,temp")
+			})
+
+			Convey("Then fields should be quoted when necessary", func() {
+				s, err := f.FormatTuple(tuple)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, `"a ""b"", c",,"[""x""]",21.5`)
+			})
+		})
+
+		Convey("When formatting it as TSV with columns", func() {
+			f, err := NewTupleFormatter(data.Map{
+				"codec":   data.String("tsv"),
+				"columns": data.Array{data.String("temp"), data.String("tags[0]"), data.String("missing")},
+				"header":  data.False,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should write values of the columns", func() {
+				s, err := f.FormatTuple(tuple)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, "21.5\tx\t")
+			})
+
+			Convey("Then it shouldn't write the header", func() {
+				h, err := f.(headerTupleFormatter).FormatHeader(tuple)
+				So(err, ShouldBeNil)
+				So(h, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When formatting a field having a delimiter without quoting", func() {
+			f, err := NewTupleFormatter(data.Map{
+				"codec":   data.String("csv"),
+				"quote":   data.String(""),
+				"columns": data.Array{data.String("name")},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				_, err := f.FormatTuple(tuple)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When giving a codec with a format", func() {
+			_, err := NewTupleFormatter(data.Map{
+				"codec":  data.String("csv"),
+				"format": data.String("{name}"),
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestCSVFile(t *testing.T) {
+	Convey("Given a file sink writing CSV", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_bql_csv_file")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		path := filepath.Join(dir, "out.csv")
+		params := data.Map{
+			"path":  data.String(path),
+			"codec": data.String("csv"),
+		}
+		write := func(from, to int) {
+			si, err := createFileSink(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			for i := from; i < to; i++ {
+				So(si.Write(ctx, core.NewTuple(data.Map{
+					"int": data.Int(i),
+					"str": data.String("a,b"),
+				})), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+		}
+
+		Convey("When writing tuples twice", func() {
+			write(0, 2)
+			write(2, 3)
+
+			Convey("Then the header should be written only once", func() {
+				b, err := ioutil.ReadFile(path)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "int,str\n0,\"a,b\"\n1,\"a,b\"\n2,\"a,b\"\n")
+			})
+
+			Convey("Then a file source should read all tuples", func() {
+				var ms []data.Map
+				s, err := createFileSource(ctx, &IOParams{}, params)
+				So(err, ShouldBeNil)
+				So(s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					ms = append(ms, t.Data)
+					return nil
+				})), ShouldBeNil)
+				So(ms, ShouldHaveLength, 3)
+				So(ms[2], ShouldResemble, data.Map{"int": data.Int(2), "str": data.String("a,b")})
+			})
+		})
+
+		Convey("When truncating the file", func() {
+			write(0, 2)
+			params["truncate"] = data.True
+			write(2, 3)
+
+			Convey("Then the header should be written again", func() {
+				b, err := ioutil.ReadFile(path)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "int,str\n2,\"a,b\"\n")
+			})
+		})
+	})
+}
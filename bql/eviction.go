@@ -0,0 +1,109 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"strings"
+	"sync"
+)
+
+// SetEvictionHook sets a hook called with tuples leaving windows of a stream
+// created by a SELECT statement, either because they're out of the window or
+// because they were evicted due to resource limits. The hook receives the
+// alias of the input and the tuple as it arrived at the stream. It's called
+// while the stream is processing a tuple, so it must not block for a long
+// time. A nil hook removes the current one. It returns an error when the box
+// isn't created by a SELECT statement having windows or when spilling windows
+// is enabled.
+func SetEvictionHook(b core.Box, h execution.EvictionHook) error {
+	bb, ok := b.(*bqlBox)
+	if !ok {
+		return errors.New("the box isn't created by a SELECT statement")
+	}
+	return bb.setEvictionHook(h)
+}
+
+// evictionSource is a source emitting tuples evicted from windows of a
+// stream. It's created by "ON EVICT EMIT TO" of CREATE STREAM and owned by
+// the stream.
+type evictionSource struct {
+	// owner is the name of the stream writing tuples to this source.
+	owner string
+
+	m    sync.Mutex
+	ctx  *core.Context
+	w    core.Writer
+	stop chan struct{}
+}
+
+func newEvictionSource(owner string) *evictionSource {
+	return &evictionSource{
+		owner: owner,
+		stop:  make(chan struct{}),
+	}
+}
+
+func (s *evictionSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	s.ctx = ctx
+	s.w = w
+	s.m.Unlock()
+	<-s.stop
+	return nil
+}
+
+// hook is an execution.EvictionHook writing evicted tuples to the stream.
+// Tuples are dropped while the source isn't running.
+func (s *evictionSource) hook(alias string, t *core.Tuple) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.w == nil {
+		return
+	}
+	if err := s.w.Write(s.ctx, t); err != nil {
+		s.ctx.ErrLog(err).WithField("node_type", core.NTSource).
+			Error("Cannot write an evicted tuple")
+	}
+}
+
+func (s *evictionSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	s.w = nil
+	s.m.Unlock()
+	close(s.stop)
+	return nil
+}
+
+// addEvictionTarget adds a source emitting tuples evicted from windows of
+// the stream having the name owner. When the stream is being replaced, the
+// source created for the previous stream is reused so that its consumers
+// stay connected. created is false in that case.
+func (tb *TopologyBuilder) addEvictionTarget(owner, name string) (s *evictionSource, created bool, err error) {
+	if strings.EqualFold(owner, name) {
+		return nil, false, fmt.Errorf("'%v' cannot emit evicted tuples to itself", owner)
+	}
+	if src, err := tb.topology.Source(name); err == nil {
+		if s, ok := src.Source().(*evictionSource); ok && strings.EqualFold(s.owner, owner) {
+			return s, false, nil
+		}
+	}
+	s = newEvictionSource(owner)
+	if _, err := tb.topology.AddSource(name, s, nil); err != nil {
+		return nil, false, err
+	}
+	return s, true, nil
+}
+
+// evictionTargets returns names of streams receiving tuples evicted from
+// windows of the stream having the name.
+func (tb *TopologyBuilder) evictionTargets(owner string) []string {
+	var names []string
+	for n, src := range tb.topology.Sources() {
+		if s, ok := src.Source().(*evictionSource); ok && strings.EqualFold(s.owner, owner) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
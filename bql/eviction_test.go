@@ -0,0 +1,172 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+)
+
+func TestEvictionTarget(t *testing.T) {
+	Convey("Given a topology with a stream emitting evicted tuples", t, func() {
+		tb, err := setupTopology(`
+			CREATE STREAM box AS SELECT RSTREAM * FROM source [RANGE 2 TUPLES]
+				ON EVICT EMIT TO expired;
+			CREATE SINK esnk TYPE collector;
+			INSERT INTO esnk FROM expired;`, false)
+		So(err, ShouldBeNil)
+		dt := tb.Topology()
+		Reset(func() {
+			dt.Stop()
+		})
+
+		Convey("When all tuples are processed", func() {
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			sin.Sink().(*tupleCollectorSink).Wait(7)
+			esin, err := dt.Sink("esnk")
+			So(err, ShouldBeNil)
+			es := esin.Sink().(*tupleCollectorSink)
+			es.Wait(2)
+
+			Convey("Then tuples leaving the window should be emitted", func() {
+				So(len(es.Tuples), ShouldEqual, 2)
+				So(es.Tuples[0].Data["int"], ShouldEqual, data.Int(1))
+				So(es.Tuples[1].Data["int"], ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When dropping the stream without CASCADE", func() {
+			err := addBQLToTopology(tb, "DROP STREAM box;")
+
+			Convey("Then it should fail because the target is used", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "esnk")
+			})
+		})
+
+		Convey("When dropping the stream with CASCADE", func() {
+			So(addBQLToTopology(tb, "DROP STREAM box CASCADE;"), ShouldBeNil)
+
+			Convey("Then the target should also be dropped", func() {
+				_, err := dt.Node("expired")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When replacing the stream with the same target", func() {
+			So(addBQLToTopology(tb, `CREATE OR REPLACE STREAM box AS
+				SELECT RSTREAM * FROM source [RANGE 3 TUPLES] ON EVICT EMIT TO expired;`), ShouldBeNil)
+
+			Convey("Then the target should keep its consumers", func() {
+				sin, err := dt.Sink("esnk")
+				So(err, ShouldBeNil)
+				So(sin.Inputs(), ShouldContainKey, "expired")
+			})
+		})
+
+		Convey("When replacing the stream with another target", func() {
+			So(addBQLToTopology(tb, `CREATE OR REPLACE STREAM box AS
+				SELECT RSTREAM * FROM source [RANGE 3 TUPLES] ON EVICT EMIT TO expired2;`), ShouldBeNil)
+
+			Convey("Then the previous target should be removed", func() {
+				_, err := dt.Node("expired")
+				So(core.IsNotExist(err), ShouldBeTrue)
+				_, err = dt.Source("expired2")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When creating another stream with the same target", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM box2 AS
+				SELECT RSTREAM * FROM source [RANGE 3 TUPLES] ON EVICT EMIT TO expired;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				_, err := dt.Node("box2")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a topology with a source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, "CREATE PAUSED SOURCE source TYPE dummy;"), ShouldBeNil)
+
+		Convey("When creating a stream emitting evicted tuples to itself", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM box AS
+				SELECT RSTREAM * FROM source [RANGE 2 TUPLES] ON EVICT EMIT TO box;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a stream which doesn't keep tuples with a target", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM box AS
+				SELECT RSTREAM * FROM source [RANGE 1 TUPLES] ON EVICT EMIT TO expired;`)
+
+			Convey("Then it should fail without leaving the target", func() {
+				So(err, ShouldNotBeNil)
+				_, err := dt.Node("expired")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestSetEvictionHook(t *testing.T) {
+	Convey("Given a topology with a stream having a window", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STREAM box AS SELECT ISTREAM * FROM source [RANGE 1 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM box;`), ShouldBeNil)
+		b, err := dt.Box("box")
+		So(err, ShouldBeNil)
+
+		Convey("When setting a hook and processing tuples", func() {
+			var (
+				m       sync.Mutex
+				evicted []data.Value
+			)
+			So(SetEvictionHook(b.Box(), func(alias string, t *core.Tuple) {
+				m.Lock()
+				defer m.Unlock()
+				evicted = append(evicted, t.Data["int"])
+			}), ShouldBeNil)
+			So(addBQLToTopology(tb, "RESUME SOURCE source;"), ShouldBeNil)
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			sin.Sink().(*tupleCollectorSink).Wait(4)
+
+			Convey("Then the hook should receive evicted tuples", func() {
+				m.Lock()
+				defer m.Unlock()
+				So(evicted, ShouldResemble, []data.Value{data.Int(1), data.Int(2), data.Int(3)})
+			})
+		})
+
+		Convey("When setting a hook to a non-BQL box", func() {
+			err := SetEvictionHook(core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+				return nil
+			}), func(alias string, t *core.Tuple) {})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,193 @@
+package execution
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// numericLocale has separators used to write numbers in a locale.
+type numericLocale struct {
+	group   rune
+	decimal rune
+}
+
+// numericLocales has locales which can be given to FORMAT of CAST for
+// numbers. Locales can also be specified with a region such as "de_DE" or
+// "de-DE". The language is used when the region isn't listed.
+var numericLocales = map[string]numericLocale{
+	"en":    {',', '.'},
+	"ja":    {',', '.'},
+	"ko":    {',', '.'},
+	"zh":    {',', '.'},
+	"da":    {'.', ','},
+	"de":    {'.', ','},
+	"es":    {'.', ','},
+	"id":    {'.', ','},
+	"it":    {'.', ','},
+	"nl":    {'.', ','},
+	"pt":    {'.', ','},
+	"tr":    {'.', ','},
+	"cs":    {' ', ','},
+	"fi":    {' ', ','},
+	"fr":    {' ', ','},
+	"nb":    {' ', ','},
+	"pl":    {' ', ','},
+	"ru":    {' ', ','},
+	"sv":    {' ', ','},
+	"de_ch": {'\'', '.'},
+}
+
+func lookupNumericLocale(name string) (numericLocale, bool) {
+	name = strings.Replace(strings.ToLower(name), "-", "_", -1)
+	if l, ok := numericLocales[name]; ok {
+		return l, true
+	}
+	if i := strings.Index(name, "_"); i >= 0 {
+		l, ok := numericLocales[name[:i]]
+		return l, ok
+	}
+	return numericLocale{}, false
+}
+
+// normalize removes group separators from a number written in the locale
+// and replaces its decimal separator with '.'.
+func (l numericLocale) normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == l.decimal:
+			return '.'
+		case r == l.group:
+			return -1
+		case l.group == ' ' && (r == '\u00a0' || r == '\u202f'):
+			// non-breaking spaces are also used as group separators
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+}
+
+func (l numericLocale) parseInt(s string) (int64, error) {
+	return strconv.ParseInt(l.normalize(s), 10, 64)
+}
+
+func (l numericLocale) parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(l.normalize(s), 64)
+}
+
+// format writes a number formatted by strconv in the locale.
+func (l numericLocale) format(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	b := bytes.NewBuffer(nil)
+	b.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteRune(l.group)
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteRune(l.decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// newFormattedTypeCast creates a type cast having the FORMAT clause. The
+// format is only applied to the following conversions and other values are
+// converted as if FORMAT was omitted:
+//
+//  * String to Timestamp: the string is parsed with the format as a layout
+//    of time.Parse
+//  * Timestamp to String: the timestamp is formatted with the format as a
+//    layout of time.Time.Format
+//  * String to Int or Float: the string is parsed as a number written in
+//    the locale given as the format, e.g. "1.234,5" in "de"
+//  * Int or Float to String: the number is written in the locale given as
+//    the format
+func newFormattedTypeCast(e Evaluator, t parser.Type, format string) (Evaluator, error) {
+	ev, err := newTypeCast(e, t, "")
+	if err != nil {
+		return nil, err
+	}
+	conv := ev.(*typeCast).converter
+
+	switch t {
+	case parser.Timestamp:
+		return &typeCast{e, func(v data.Value) (data.Value, error) {
+			if v.Type() != data.TypeString {
+				return conv(v)
+			}
+			s, _ := data.AsString(v)
+			x, err := time.Parse(format, s)
+			if err != nil {
+				return nil, err
+			}
+			return data.Timestamp(x), nil
+		}}, nil
+
+	case parser.Int, parser.Float:
+		l, ok := lookupNumericLocale(format)
+		if !ok {
+			return nil, fmt.Errorf("unknown numeric locale: %v", format)
+		}
+		return &typeCast{e, func(v data.Value) (data.Value, error) {
+			if v.Type() != data.TypeString {
+				return conv(v)
+			}
+			s, _ := data.AsString(v)
+			if t == parser.Int {
+				x, err := l.parseInt(s)
+				if err != nil {
+					return nil, err
+				}
+				return data.Int(x), nil
+			}
+			x, err := l.parseFloat(s)
+			if err != nil {
+				return nil, err
+			}
+			return data.Float(x), nil
+		}}, nil
+
+	case parser.String:
+		// The format is either a layout or a locale and which one is used
+		// depends on the type of the value.
+		l, isLocale := lookupNumericLocale(format)
+		return &typeCast{e, func(v data.Value) (data.Value, error) {
+			switch v.Type() {
+			case data.TypeTimestamp:
+				x, _ := data.AsTimestamp(v)
+				return data.String(x.Format(format)), nil
+			case data.TypeInt, data.TypeFloat:
+				if !isLocale {
+					return nil, fmt.Errorf("unknown numeric locale: %v", format)
+				}
+				if v.Type() == data.TypeInt {
+					x, _ := data.AsInt(v)
+					return data.String(l.format(strconv.FormatInt(x, 10))), nil
+				}
+				x, _ := data.AsFloat(v)
+				if math.IsNaN(x) || math.IsInf(x, 0) {
+					return conv(v)
+				}
+				return data.String(l.format(strconv.FormatFloat(x, 'f', -1, 64))), nil
+			}
+			return conv(v)
+		}}, nil
+	}
+	return nil, fmt.Errorf("FORMAT isn't supported for %s", t)
+}
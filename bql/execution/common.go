@@ -22,6 +22,8 @@ type commonExecutionPlan struct {
 	// filter stores the evaluator of the filter condition,
 	// or nil if there is no WHERE clause.
 	filter Evaluator
+	// clock provides the time returned by the now() function.
+	clock core.Clock
 }
 
 func prepareProjections(projections []aliasedExpression, reg udf.FunctionRegistry) ([]aliasedEvaluator, error) {
@@ -82,12 +84,50 @@ func prepareGroupList(groupList []FlatExpression, reg udf.FunctionRegistry) ([]E
 //   {"alias": {"col_0": ..., "col_1": ...}}
 // is transformed into
 //   {"alias": {"col_0": ..., "col_1": ...},
-//    "alias:meta:TS": (timestamp of the given tuple)}
-// so that the Evaluator created from a parser.RowMeta AST struct works correctly.
+//    "alias:meta:TS": (timestamp of the given tuple),
+//    "alias:meta:PROC_TS": (processing timestamp of the given tuple),
+//    "alias:meta:INPUT_NAME": (input name of the given tuple),
+//    "alias:meta:TRACE": (trace of the given tuple, if any),
+//    "alias:meta:META": (metadata map of the given tuple, if any)}
+// so that the Evaluator created from a parser.RowMeta or parser.RowMetadata
+// AST struct works correctly.
 func setMetadata(where data.Map, alias string, t *core.Tuple) {
 	// this key format is also used in ExpressionToEvaluator()
-	tsKey := fmt.Sprintf("%s:meta:%s", alias, parser.TimestampMeta)
-	where[tsKey] = data.Timestamp(t.Timestamp)
+	where[rowMetaKey(alias, parser.TimestampMeta)] = data.Timestamp(t.Timestamp)
+	where[rowMetaKey(alias, parser.ProcTimestampMeta)] = data.Timestamp(t.ProcTimestamp)
+	where[rowMetaKey(alias, parser.InputNameMeta)] = data.String(t.InputName)
+	if len(t.Trace) > 0 {
+		where[rowMetaKey(alias, parser.TraceMeta)] = traceToArray(t.Trace)
+	}
+	if m := t.AllMeta(); m != nil {
+		where[metadataKey(alias)] = m
+	}
+}
+
+// rowMetaKey returns the key of the meta information of tuples from the
+// relation having the alias.
+func rowMetaKey(alias string, m parser.MetaInformation) string {
+	return fmt.Sprintf("%s:meta:%s", alias, m)
+}
+
+// traceToArray converts the trace of a tuple to an array of maps having
+// "timestamp", "type", and "msg".
+func traceToArray(tr []core.TraceEvent) data.Array {
+	a := make(data.Array, len(tr))
+	for i, ev := range tr {
+		a[i] = data.Map{
+			"timestamp": data.Timestamp(ev.Timestamp),
+			"type":      data.String(ev.Type.String()),
+			"msg":       data.String(ev.Msg),
+		}
+	}
+	return a
+}
+
+// metadataKey returns the key of the metadata map of tuples from the relation
+// having the alias.
+func metadataKey(alias string) string {
+	return alias + ":meta:META"
 }
 
 // assignOutputValue writes the given Value `value` to the given
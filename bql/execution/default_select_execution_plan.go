@@ -9,6 +9,9 @@ import (
 
 type defaultSelectExecutionPlan struct {
 	streamRelationStreamExecutionPlan
+	// windowFuncs compute values of window functions which are written
+	// to each row before projections are evaluated.
+	windowFuncs []*windowFunc
 }
 
 // CanBuildDefaultSelectExecutionPlan checks whether the given statement
@@ -31,8 +34,17 @@ func NewDefaultSelectExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (P
 	if err != nil {
 		return nil, err
 	}
+	windowFuncs := make([]*windowFunc, len(lp.windowFuncs))
+	for i, w := range lp.windowFuncs {
+		wf, err := newWindowFunc(w, reg)
+		if err != nil {
+			return nil, err
+		}
+		windowFuncs[i] = wf
+	}
 	return &defaultSelectExecutionPlan{
 		*underlying,
+		windowFuncs,
 	}, nil
 }
 
@@ -77,18 +89,17 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 
 	// function to compute the projection values and store
 	// the result in the `output` slice
-	evalItem := func(io *inputRowWithCachedResult) error {
+	evalItem := func(io *inputRowWithCachedResult, d data.Map, cache data.Value) error {
 		// if we have a cached result, use this
-		if io.cache != nil {
-			cachedResults, err := data.AsMap(io.cache)
+		if cache != nil {
+			cachedResults, err := data.AsMap(cache)
 			if err != nil {
-				return fmt.Errorf("cached data was not a map: %v", io.cache)
+				return fmt.Errorf("cached data was not a map: %v", cache)
 			}
 			output = append(output, resultRow{row: cachedResults, hash: io.hash})
 			return nil
 		}
 		// otherwise, compute all the expressions
-		d := *io.input
 		result := data.Map(make(map[string]data.Value, len(ep.projections)))
 		for _, proj := range ep.projections {
 			value, err := proj.evaluator.Eval(d)
@@ -100,18 +111,44 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 			}
 		}
 		// update the fields of the input data for the next iteration
-		io.cache = result
-		io.hash = data.Hash(io.cache)
+		// (a spilled row doesn't keep its result in memory, and results
+		// of window functions depend on other rows)
+		hash := data.Hash(result)
+		if io.spilled == nil && len(ep.windowFuncs) == 0 {
+			io.cache = result
+			io.hash = hash
+		}
 		// since we have no grouping etc., "output data" = "cached data"
 		// and "hash of output data" = "hash of cached data"
-		output = append(output, resultRow{row: result, hash: io.hash})
+		output = append(output, resultRow{row: result, hash: hash})
+		return nil
+	}
+
+	if len(ep.windowFuncs) > 0 {
+		items, rows, err := ep.computeWindowFuncs()
+		if err != nil {
+			rollback()
+			return err
+		}
+		for i, item := range items {
+			if err := evalItem(item, rows[i], nil); err != nil {
+				rollback()
+				return err
+			}
+		}
+		ep.curResults = output
 		return nil
 	}
 
 	// compute the output for each item in ep.filteredInputRows
 	for e := ep.filteredInputRows.Front(); e != nil; e = e.Next() {
 		item := e.Value.(*inputRowWithCachedResult)
-		if err := evalItem(item); err != nil {
+		d, cache, err := ep.rowData(item)
+		if err != nil {
+			rollback()
+			return err
+		}
+		if err := evalItem(item, d, cache); err != nil {
 			rollback()
 			return err
 		}
@@ -120,3 +157,35 @@ func (ep *defaultSelectExecutionPlan) performQueryOnBuffer() error {
 	ep.curResults = output
 	return nil
 }
+
+// computeWindowFuncs returns the items in ep.filteredInputRows and their
+// data having values of window functions. The data are shallow copies of
+// the input so that values of window functions aren't kept in the window.
+func (ep *defaultSelectExecutionPlan) computeWindowFuncs() ([]*inputRowWithCachedResult, []data.Map, error) {
+	items := make([]*inputRowWithCachedResult, 0, ep.filteredInputRows.Len())
+	rows := make([]data.Map, 0, ep.filteredInputRows.Len())
+	for e := ep.filteredInputRows.Front(); e != nil; e = e.Next() {
+		item := e.Value.(*inputRowWithCachedResult)
+		d, _, err := ep.rowData(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		row := make(data.Map, len(d)+len(ep.windowFuncs))
+		for k, v := range d {
+			row[k] = v
+		}
+		items = append(items, item)
+		rows = append(rows, row)
+	}
+
+	for _, wf := range ep.windowFuncs {
+		values, err := wf.compute(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, v := range values {
+			rows[i][wf.key] = v
+		}
+	}
+	return items, rows, nil
+}
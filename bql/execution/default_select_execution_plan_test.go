@@ -185,6 +185,78 @@ func TestDefaultSelectExecutionPlan(t *testing.T) {
 		})
 	})
 
+	// Select system columns of the tuple
+	Convey("Given a SELECT clause with system columns", t, func() {
+		tuples := getTuples(4)
+		tuples[1].AddEvent(core.TraceEvent{
+			Timestamp: time.Date(2015, time.April, 10, 10, 25, 0, 0, time.UTC),
+			Type:      core.ETInput,
+			Msg:       "box",
+		})
+		s := `CREATE STREAM box AS SELECT ISTREAM proc_ts(), input_name(), trace() FROM src [RANGE 2 SECONDS] WHERE proc_ts() > ts()`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then those values should appear in %v", idx), func() {
+					tr := data.Array{}
+					if idx == 1 {
+						tr = data.Array{data.Map{
+							"timestamp": data.Timestamp(time.Date(2015, time.April, 10, 10, 25, 0, 0, time.UTC)),
+							"type":      data.String("input"),
+							"msg":       data.String("box"),
+						}}
+					}
+					So(len(out), ShouldEqual, 1)
+					So(out[0], ShouldResemble, data.Map{
+						"proc_ts":    data.Timestamp(time.Date(2015, time.April, 10, 10, 24, idx, 0, time.UTC)),
+						"input_name": data.String("src"),
+						"trace":      tr,
+					})
+				})
+			}
+
+		})
+	})
+
+	// Select the tuple's metadata
+	Convey("Given a SELECT clause with metadata", t, func() {
+		tuples := getTuples(4)
+		for i, tup := range tuples {
+			if i%2 == 0 {
+				tup.SetPartition(int64(i))
+			}
+		}
+		s := `CREATE STREAM box AS SELECT ISTREAM int, meta("partition"), meta("tenant") AS t FROM src [RANGE 2 SECONDS] WHERE meta("partition") IS NOT NULL`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			for idx, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+
+				Convey(fmt.Sprintf("Then those values should appear in %v", idx), func() {
+					if idx%2 == 0 {
+						So(len(out), ShouldEqual, 1)
+						So(out[0], ShouldResemble, data.Map{
+							"int":       data.Int(idx + 1),
+							"partition": data.Int(idx),
+							"t":         data.Null{},
+						})
+					} else {
+						So(out, ShouldBeEmpty)
+					}
+				})
+			}
+
+		})
+	})
+
 	// Select a non-existing column
 	Convey("Given a SELECT clause with a non-existing column", t, func() {
 		tuples := getTuples(4)
@@ -2116,6 +2188,40 @@ func BenchmarkNormalExecutionBigWindow(b *testing.B) {
 	}
 }
 
+func BenchmarkProjectionExecution(b *testing.B) {
+	s := `CREATE STREAM box AS SELECT ISTREAM src:id, src:sensor.name, src:sensor.location.lat,
+			src:sensor.location.lon, src:values[0], src:values[-1], src:meta["unit"],
+			src:missing IS MISSING AS m FROM src [RANGE 1 TUPLES]`
+	plan, err := createDefaultSelectPlan2(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	tmplTup := core.Tuple{
+		Data: data.Map{
+			"id": data.Int(-1),
+			"sensor": data.Map{
+				"name":     data.String("sensor-01"),
+				"location": data.Map{"lat": data.Float(35.6), "lon": data.Float(139.7)},
+			},
+			"values": data.Array{data.Float(1.5), data.Float(2.5)},
+			"meta":   data.Map{"unit": data.String("celsius")},
+		},
+		InputName:     "src",
+		Timestamp:     time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC),
+		ProcTimestamp: time.Date(2015, time.April, 10, 10, 24, 0, 0, time.UTC),
+		BatchID:       7,
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		inTup := tmplTup.Copy()
+		inTup.Data["id"] = data.Int(n)
+		_, err := plan.Process(inTup)
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
 func BenchmarkTimeBasedExecution(b *testing.B) {
 	s := `CREATE STREAM box AS SELECT ISTREAM cast(3+4-6+1 as float), 3.0::int*4/2+1=7.0,
 			null, [2.0,3] = [2,3.0] FROM src [RANGE 5 SECONDS]`
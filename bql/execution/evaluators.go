@@ -76,14 +76,18 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 	switch obj := ast.(type) {
 	case rowMeta:
 		// construct a key for reading as used in setMetadata() for writing
-		metaKey := fmt.Sprintf(`["%s:meta:%s"]`, obj.Relation, obj.MetaType)
-		if obj.MetaType == parser.TimestampMeta {
-			pa, err := newPathAccess(metaKey)
-			if err != nil {
-				return nil, err
-			}
-			return &timestampCast{pa}, nil
-		}
+		metaKey := rowMetaKey(obj.Relation, obj.MetaType)
+		switch obj.MetaType {
+		case parser.TimestampMeta, parser.ProcTimestampMeta:
+			return &timestampCast{&metaAccess{metaKey, nil}}, nil
+		case parser.InputNameMeta:
+			return &metaAccess{metaKey, nil}, nil
+		case parser.TraceMeta:
+			// setMetadata() doesn't write an empty trace
+			return &metaAccess{metaKey, data.Array{}}, nil
+		}
+	case rowMetadata:
+		return &metadataAccess{metadataKey(obj.Relation), obj.Key}, nil
 	case stmtMeta:
 		// construct a key for reading as used in setMetadata() for writing
 		metaKey := fmt.Sprintf(`[":meta:%s"]`, obj.MetaType)
@@ -103,7 +107,14 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 				path = obj.Relation + "." + path
 			}
 		}
-		return newPathAccess(path)
+		e, err := newPathAccess(path)
+		if err != nil {
+			return nil, err
+		}
+		if ctx := reg.Context(); ctx != nil {
+			e.(*pathAccess).lenient = &ctx.Flags.LenientEvaluation
+		}
+		return e, nil
 	case aggInputRef:
 		return newPathAccess(obj.Ref)
 	case nullLiteral:
@@ -148,6 +159,10 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 			return newGreaterOrnewEqual(bo), nil
 		case parser.NotEqual:
 			return newNot(newEqual(bo)), nil
+		case parser.IsDistinctFrom:
+			return &distinctFrom{bo, false}, nil
+		case parser.IsNotDistinctFrom:
+			return &distinctFrom{bo, true}, nil
 		case parser.Concat:
 			return &concat{bo}, nil
 		case parser.Is:
@@ -204,7 +219,7 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		if err != nil {
 			return nil, err
 		}
-		return newTypeCast(expr, obj.Target)
+		return newTypeCast(expr, obj.Target, obj.Format)
 	case funcAppAST:
 		// lookup function in function registry
 		// (the registry will decide if the requested function
@@ -226,6 +241,10 @@ func ExpressionToEvaluator(ast FlatExpression, reg udf.FunctionRegistry) (Evalua
 		return FuncApp(fName, f, reg.Context(), evals), nil
 	case aggregateInputSorter:
 		return newSortedInputAggFuncApp(obj.funcAppAST, obj.ID, obj.Ordering, reg)
+	case windowFuncAST:
+		// the value is computed over the window and written to the row
+		// before projections are evaluated
+		return &metaAccess{obj.key(), nil}, nil
 	case arrayAST:
 		// compute child Evaluators
 		evals := make([]Evaluator, len(obj.Expressions))
@@ -337,9 +356,22 @@ func (s *stringConstant) Eval(input data.Value) (data.Value, error) {
 // JSON path.
 type pathAccess struct {
 	path data.Path
+	// lenient returns NULL instead of an error when the path isn't found
+	// while it's enabled. It's core.ContextFlags.LenientEvaluation of the
+	// topology and can be nil.
+	lenient *core.AtomicFlag
 }
 
 func (fa *pathAccess) Eval(input data.Value) (data.Value, error) {
+	v, err := fa.get(input)
+	if err != nil && fa.lenient != nil && fa.lenient.Enabled() {
+		return data.Null{}, nil
+	}
+	return v, err
+}
+
+// get returns the value at the path regardless of the evaluation mode.
+func (fa *pathAccess) get(input data.Value) (data.Value, error) {
 	aMap, err := data.AsMap(input)
 	if err != nil {
 		return nil, err
@@ -352,7 +384,57 @@ func newPathAccess(s string) (Evaluator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &pathAccess{path}, nil
+	return &pathAccess{path: path}, nil
+}
+
+// metaAccess reads meta information of a tuple written by setMetadata().
+// When the input doesn't have the key, it returns missing, or an error if
+// missing is nil.
+type metaAccess struct {
+	key     string
+	missing data.Value
+}
+
+func (ma *metaAccess) Eval(input data.Value) (data.Value, error) {
+	aMap, err := data.AsMap(input)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := aMap[ma.key]
+	if !ok {
+		if ma.missing == nil {
+			return nil, fmt.Errorf("meta information '%s' wasn't found", ma.key)
+		}
+		return ma.missing, nil
+	}
+	return v, nil
+}
+
+// metadataAccess reads a metadata value of a tuple written by setMetadata().
+// It returns NULL when the tuple doesn't have the key.
+type metadataAccess struct {
+	mapKey string
+	key    string
+}
+
+func (ma *metadataAccess) Eval(input data.Value) (data.Value, error) {
+	aMap, err := data.AsMap(input)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := aMap[ma.mapKey]
+	if !ok {
+		return data.Null{}, nil
+	}
+	meta, err := data.AsMap(m)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := meta[ma.key]
+	if !ok {
+		return data.Null{}, nil
+	}
+	return v, nil
 }
 
 type missingPathCheck struct {
@@ -365,7 +447,7 @@ func (m *missingPathCheck) Eval(input data.Value) (data.Value, error) {
 		return nil, fmt.Errorf("expected Map for IS MISSING check, not %s", input.Type())
 	}
 	// we assume that if there was any error, the value was missing
-	if _, err := m.eval.Eval(input); err != nil {
+	if _, err := m.eval.get(input); err != nil {
 		return data.Bool(true != m.negate), nil
 	}
 	return data.Bool(false != m.negate), nil
@@ -396,7 +478,10 @@ func (t *typeCast) Eval(input data.Value) (data.Value, error) {
 	return t.converter(val)
 }
 
-func newTypeCast(e Evaluator, t parser.Type) (Evaluator, error) {
+func newTypeCast(e Evaluator, t parser.Type, format string) (Evaluator, error) {
+	if format != "" {
+		return newFormattedTypeCast(e, t, format)
+	}
 	switch t {
 	case parser.Bool:
 		conv := func(v data.Value) (data.Value, error) {
@@ -452,6 +537,11 @@ func newTypeCast(e Evaluator, t parser.Type) (Evaluator, error) {
 			return data.Timestamp(x), nil
 		}
 		return &typeCast{e, conv}, nil
+	case parser.Vector:
+		conv := func(v data.Value) (data.Value, error) {
+			return data.ToVector(v)
+		}
+		return &typeCast{e, conv}, nil
 	}
 	return nil, fmt.Errorf("no converter for type %s known", t)
 }
@@ -665,6 +755,22 @@ func (cbo *compBinOp) Eval(input data.Value) (data.Value, error) {
 	return data.Bool(res), nil
 }
 
+// distinctFrom compares two values like `=` except that NULL is treated
+// as an ordinary value, i.e., NULL IS NOT DISTINCT FROM NULL is true and
+// the result is never NULL.
+type distinctFrom struct {
+	binOp
+	negate bool
+}
+
+func (d *distinctFrom) Eval(input data.Value) (data.Value, error) {
+	leftVal, rightVal, err := d.evalLeftAndRight(input)
+	if err != nil {
+		return nil, err
+	}
+	return data.Bool(!data.Equal(leftVal, rightVal) != d.negate), nil
+}
+
 func newEqual(bo binOp) Evaluator {
 	cmpOp := func(leftVal data.Value, rightVal data.Value) (bool, error) {
 		return data.Equal(leftVal, rightVal), nil
@@ -960,8 +1066,9 @@ func FuncApp(name string, f udf.UDF, ctx *core.Context, params []Evaluator) Eval
 /// Aggregate Function with Sorted Input
 
 type sortEvaluator struct {
-	eval      Evaluator
-	ascending bool
+	eval       Evaluator
+	ascending  bool
+	nullsFirst bool
 }
 
 type sortedInputAggFuncApp struct {
@@ -996,7 +1103,7 @@ func (s *sortedInputAggFuncApp) Eval(input data.Value) (v data.Value, err error)
 		if err != nil {
 			return nil, err
 		}
-		sortData[i] = sortArray{arr, sortEval.ascending}
+		sortData[i] = sortArray{arr, sortEval.ascending, sortEval.nullsFirst}
 	}
 
 	// sort an array of indexes, then write the actual data to a new array
@@ -1077,7 +1184,7 @@ func newSortedInputAggFuncApp(obj funcAppAST, id string, ordering []sortExpressi
 		if err != nil {
 			return nil, err
 		}
-		sortEvals[i] = sortEvaluator{e, sortExpr.Ascending}
+		sortEvals[i] = sortEvaluator{e, sortExpr.Ascending, sortExpr.NullsFirst}
 	}
 
 	// lookup function in function registry
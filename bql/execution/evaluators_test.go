@@ -53,6 +53,83 @@ func TestEvaluators(t *testing.T) {
 	}
 }
 
+func TestLenientEvaluation(t *testing.T) {
+	Convey("Given an expression referring to fields", t, func() {
+		ctx := core.NewContext(nil)
+		reg := &testFuncRegistry{ctx: ctx}
+		eval := func(e parser.Expression, input data.Map) (data.Value, error) {
+			flatExpr, err := ParserExprToFlatExpr(e, reg)
+			So(err, ShouldBeNil)
+			ev, err := ExpressionToEvaluator(flatExpr, reg)
+			So(err, ShouldBeNil)
+			return ev.Eval(input)
+		}
+		plus := parser.BinaryOpAST{parser.Plus, parser.RowValue{"", "a"}, parser.NumericLiteral{1}}
+		isMissing := parser.BinaryOpAST{parser.Is, parser.RowValue{"", "a"}, parser.Missing{}}
+		input := data.Map{"b": data.Int(1)}
+
+		Convey("When the topology evaluates expressions strictly", func() {
+			Convey("Then a missing field should be an error", func() {
+				_, err := eval(plus, input)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then IS MISSING should be true", func() {
+				v, err := eval(isMissing, input)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.True)
+			})
+		})
+
+		Convey("When the topology evaluates expressions leniently", func() {
+			ctx.Flags.LenientEvaluation.Set(true)
+
+			Convey("Then a missing field should be NULL", func() {
+				v, err := eval(plus, input)
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+
+			Convey("Then a present field should be evaluated as usual", func() {
+				v, err := eval(plus, data.Map{"a": data.Int(1)})
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then IS MISSING should still be true", func() {
+				v, err := eval(isMissing, input)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.True)
+			})
+		})
+	})
+}
+
+func TestInvalidCastFormat(t *testing.T) {
+	Convey("Given type casts having invalid formats", t, func() {
+		reg := &testFuncRegistry{ctx: core.NewContext(nil)}
+		casts := []parser.TypeCastAST{
+			{parser.RowValue{"", "a"}, parser.Int, "xx"},
+			{parser.RowValue{"", "a"}, parser.Float, "2006-01-02"},
+			{parser.RowValue{"", "a"}, parser.Bool, "en"},
+			{parser.RowValue{"", "a"}, parser.Blob, "en"},
+		}
+
+		for _, c := range casts {
+			c := c
+			Convey(fmt.Sprintf("When creating an evaluator of %v", c), func() {
+				flatExpr, err := ParserExprToFlatExpr(c, reg)
+				So(err, ShouldBeNil)
+				_, err = ExpressionToEvaluator(flatExpr, reg)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
 func TestFoldableExecution(t *testing.T) {
 	testCases := []struct {
 		ast      parser.Expression
@@ -126,9 +203,9 @@ func TestFoldableExecution(t *testing.T) {
 			false, nil},
 		{parser.AliasAST{parser.NumericLiteral{7}, "hoge"},
 			true, data.Int(7)},
-		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Float},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Float, ""},
 			false, nil},
-		{parser.TypeCastAST{parser.NumericLiteral{7}, parser.Float},
+		{parser.TypeCastAST{parser.NumericLiteral{7}, parser.Float, ""},
 			true, data.Float(7.0)},
 		{parser.FuncAppAST{parser.FuncName("now"),
 			parser.ExpressionsAST{[]parser.Expression{}}, nil},
@@ -257,7 +334,7 @@ func TestFuncAppConversion(t *testing.T) {
 				parser.ExpressionsAST{[]parser.Expression{
 					parser.RowValue{"", "a"},
 				}},
-				[]parser.SortedExpressionAST{{parser.RowValue{"", "a"}, parser.Yes}}}
+				[]parser.SortedExpressionAST{{parser.RowValue{"", "a"}, parser.Yes, parser.UnspecifiedKeyword}}}
 
 			Convey("Then converting to an Evaluator fails", func() {
 				// we cannot even get the flat expression in that case
@@ -302,7 +379,7 @@ func TestAggFuncAppConversion(t *testing.T) {
 		{"array_agg(a ORDER BY a ASC) FROM x [RANGE 1 TUPLES]", "",
 			aggregateInputSorter{
 				funcAppAST{"array_agg", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-				[]sortExpression{sortExpression{aggInputRef{"g_f12cd6bc"}, true}},
+				[]sortExpression{sortExpression{aggInputRef{"g_f12cd6bc"}, true, true}},
 				"ccd0ef22",
 			},
 			map[string]FlatExpression{
@@ -329,7 +406,7 @@ func TestAggFuncAppConversion(t *testing.T) {
 		{"array_agg(a ORDER BY b DESC) FROM x [RANGE 1 TUPLES]", "",
 			aggregateInputSorter{
 				funcAppAST{"array_agg", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-				[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false}},
+				[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false, false}},
 				"d7196f56",
 			},
 			map[string]FlatExpression{
@@ -367,8 +444,8 @@ func TestAggFuncAppConversion(t *testing.T) {
 		{"array_agg(a ORDER BY b DESC, a) FROM x [RANGE 1 TUPLES]", "",
 			aggregateInputSorter{
 				funcAppAST{"array_agg", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-				[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false},
-					sortExpression{aggInputRef{"g_f12cd6bc"}, true}},
+				[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false, false},
+					sortExpression{aggInputRef{"g_f12cd6bc"}, true, true}},
 				"24925706",
 			},
 			map[string]FlatExpression{
@@ -407,17 +484,17 @@ func TestAggFuncAppConversion(t *testing.T) {
 						funcAppAST{"array_agg",
 							[]FlatExpression{aggInputRef{Ref: "g_f12cd6bc"}}},
 						[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"},
-							false}},
+							false, false}},
 						"d7196f56"},
-					parser.String},
+					parser.String, ""},
 				typeCastAST{
 					aggregateInputSorter{
 						funcAppAST{"array_agg",
 							[]FlatExpression{aggInputRef{Ref: "g_f12cd6bc"}}},
 						[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"},
-							true}},
+							true, true}},
 						"cd35e18d"},
-					parser.String},
+					parser.String, ""},
 			},
 			map[string]FlatExpression{
 				"g_f12cd6bc": rowValue{"x", "a"},
@@ -448,7 +525,7 @@ func TestAggFuncAppConversion(t *testing.T) {
 		{"array_agg(f(a) ORDER BY f(a)) FROM x [RANGE 1 TUPLES] GROUP BY a", "",
 			aggregateInputSorter{
 				funcAppAST{"array_agg", []FlatExpression{aggInputRef{"g_2523c3a2_0"}}},
-				[]sortExpression{sortExpression{aggInputRef{"g_2523c3a2_1"}, true}},
+				[]sortExpression{sortExpression{aggInputRef{"g_2523c3a2_1"}, true, true}},
 				"cf2e24d7",
 			},
 			map[string]FlatExpression{
@@ -1488,6 +1565,40 @@ func getTestCases() []struct {
 				{data.Map{"a": data.Null{}}, data.Bool(true)},
 			},
 		},
+		// IsDistinctFrom
+		{parser.BinaryOpAST{parser.IsDistinctFrom, parser.RowValue{"", "a"}, parser.RowValue{"", "b"}},
+			[]evalTest{
+				// not a map:
+				{data.Int(17), nil},
+				// keys not present:
+				{data.Map{"a": data.Int(17)}, nil},
+				// same values => false
+				{data.Map{"a": data.Int(1), "b": data.Int(1)}, data.Bool(false)},
+				{data.Map{"a": data.Int(1), "b": data.Float(1)}, data.Bool(false)},
+				{data.Map{"a": data.Null{}, "b": data.Null{}}, data.Bool(false)},
+				// different values => true
+				{data.Map{"a": data.Int(1), "b": data.Int(2)}, data.Bool(true)},
+				{data.Map{"a": data.Int(1), "b": data.String("1")}, data.Bool(true)},
+				// NULL is compared with other values
+				{data.Map{"a": data.Null{}, "b": data.Int(2)}, data.Bool(true)},
+				{data.Map{"a": data.Int(1), "b": data.Null{}}, data.Bool(true)},
+			},
+		},
+		// IsNotDistinctFrom
+		{parser.BinaryOpAST{parser.IsNotDistinctFrom, parser.RowValue{"", "a"}, parser.RowValue{"", "b"}},
+			[]evalTest{
+				// not a map:
+				{data.Int(17), nil},
+				// keys not present:
+				{data.Map{"a": data.Int(17)}, nil},
+				// same values => true
+				{data.Map{"a": data.Int(1), "b": data.Int(1)}, data.Bool(true)},
+				{data.Map{"a": data.Null{}, "b": data.Null{}}, data.Bool(true)},
+				// different values => false
+				{data.Map{"a": data.Int(1), "b": data.Int(2)}, data.Bool(false)},
+				{data.Map{"a": data.Null{}, "b": data.Int(2)}, data.Bool(false)},
+			},
+		},
 		/// Computational Operations
 		// Plus
 		{parser.BinaryOpAST{parser.Plus, parser.RowValue{"", "a"}, parser.RowValue{"", "b"}},
@@ -1646,7 +1757,7 @@ func getTestCases() []struct {
 			},
 		},
 		// Type Cast
-		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Int},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Int, ""},
 			[]evalTest{
 				// not a map:
 				{data.Int(17), nil},
@@ -1669,7 +1780,7 @@ func getTestCases() []struct {
 				{data.Map{"a": data.Map{"b": data.Int(3)}}, nil},
 			},
 		},
-		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.String},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.String, ""},
 			[]evalTest{
 				// not a map:
 				{data.Int(17), nil},
@@ -1691,6 +1802,69 @@ func getTestCases() []struct {
 				{data.Map{"a": data.Null{}}, data.Null{}},
 			},
 		},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Vector, ""},
+			[]evalTest{
+				// not a map:
+				{data.Int(17), nil},
+				// keys not present:
+				{data.Map{"x": data.Int(17)}, nil},
+				// key present and convertable => ok
+				{data.Map{"a": data.Array{data.Int(2), data.Float(0.5)}}, data.Vector{2, 0.5}},
+				{data.Map{"a": data.Vector{1, 2}}, data.Vector{1, 2}},
+				{data.Map{"a": data.Blob("\x00\x00\x80\x3f")}, data.Vector{1}},
+				// null propagation
+				{data.Map{"a": data.Null{}}, data.Null{}},
+				// key present and other data type => error
+				{data.Map{"a": data.Int(17)}, nil},
+				{data.Map{"a": data.String("日本語")}, nil},
+				{data.Map{"a": data.Array{data.String("a")}}, nil},
+				{data.Map{"a": data.Map{"b": data.Int(3)}}, nil},
+			},
+		},
+		// Type Cast with FORMAT
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Timestamp, "2006-01-02 15:04"},
+			[]evalTest{
+				// strings are parsed with the layout
+				{data.Map{"a": data.String("2016-01-02 03:04")},
+					data.Timestamp(time.Date(2016, 1, 2, 3, 4, 0, 0, time.UTC))},
+				{data.Map{"a": data.String("2016-01-02T03:04:00Z")}, nil},
+				// other values are converted as usual
+				{data.Map{"a": data.Int(0)}, data.Timestamp(time.Unix(0, 0))},
+				// null propagation
+				{data.Map{"a": data.Null{}}, data.Null{}},
+			},
+		},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Float, "de_DE"},
+			[]evalTest{
+				{data.Map{"a": data.String("1.234,5")}, data.Float(1234.5)},
+				{data.Map{"a": data.String("-0,25")}, data.Float(-0.25)},
+				{data.Map{"a": data.String("1,2,3")}, nil},
+				{data.Map{"a": data.Int(3)}, data.Float(3)},
+			},
+		},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.Int, "fr"},
+			[]evalTest{
+				{data.Map{"a": data.String("1 234 567")}, data.Int(1234567)},
+				{data.Map{"a": data.String("1\u00a0234")}, data.Int(1234)},
+				{data.Map{"a": data.String("12,5")}, nil},
+			},
+		},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.String, "en"},
+			[]evalTest{
+				{data.Map{"a": data.Int(-1234567)}, data.String("-1,234,567")},
+				{data.Map{"a": data.Float(1234.5)}, data.String("1,234.5")},
+				{data.Map{"a": data.Float(123)}, data.String("123")},
+				{data.Map{"a": data.Bool(true)}, data.String("true")},
+			},
+		},
+		{parser.TypeCastAST{parser.RowValue{"", "a"}, parser.String, "02/01/2006"},
+			[]evalTest{
+				{data.Map{"a": data.Timestamp(time.Date(2016, 1, 2, 3, 4, 0, 0, time.UTC))},
+					data.String("02/01/2016")},
+				// the format isn't a locale
+				{data.Map{"a": data.Int(1)}, nil},
+			},
+		},
 		/// Function Application
 		{parser.FuncAppAST{parser.FuncName("plusone"),
 			parser.ExpressionsAST{[]parser.Expression{parser.RowValue{"", "a"}}}, nil},
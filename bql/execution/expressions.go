@@ -87,6 +87,8 @@ func ParserExprToFlatExpr(e parser.Expression, reg udf.FunctionRegistry) (FlatEx
 	switch obj := e.(type) {
 	case parser.RowMeta:
 		return rowMeta{obj.Relation, obj.MetaType}, nil
+	case parser.RowMetadata:
+		return rowMetadata{obj.Relation, obj.Key}, nil
 	case parser.RowValue:
 		return rowValue{obj.Relation, obj.Column}, nil
 	case parser.AliasAST:
@@ -145,7 +147,7 @@ func ParserExprToFlatExpr(e parser.Expression, reg udf.FunctionRegistry) (FlatEx
 		if err != nil {
 			return nil, err
 		}
-		return typeCastAST{expr, obj.Target}, nil
+		return typeCastAST{expr, obj.Target, obj.Format}, nil
 	case parser.FuncAppAST:
 		// exception for now()
 		if string(obj.Function) == "now" && len(obj.Expressions) == 0 && len(obj.Ordering) == 0 {
@@ -176,6 +178,8 @@ func ParserExprToFlatExpr(e parser.Expression, reg udf.FunctionRegistry) (FlatEx
 			exprs[i] = expr
 		}
 		return funcAppAST{obj.Function, exprs}, nil
+	case parser.WindowFuncAppAST:
+		return windowFuncToFlatExpr(obj, reg)
 	case parser.ArrayAST:
 		// compute child expressions
 		exprs := make([]FlatExpression, len(obj.Expressions))
@@ -305,7 +309,7 @@ func ParserExprToMaybeAggregate(e parser.Expression, aggIdx int, reg udf.Functio
 		if err != nil {
 			return nil, nil, err
 		}
-		return typeCastAST{expr, obj.Target}, agg, nil
+		return typeCastAST{expr, obj.Target, obj.Format}, agg, nil
 	case parser.FuncAppAST:
 		// exception for now()
 		if string(obj.Function) == "now" && len(obj.Expressions) == 0 {
@@ -407,9 +411,14 @@ func ParserExprToMaybeAggregate(e parser.Expression, aggIdx int, reg udf.Functio
 						ascending = false
 						orderHash.Write([]byte(" DESC"))
 					}
+					nullsFirst := ascending
+					if sortExpr.NullsFirst != parser.UnspecifiedKeyword {
+						nullsFirst = sortExpr.NullsFirst == parser.Yes
+						orderHash.Write([]byte(" " + nullsOrderString(nullsFirst)))
+					}
 					orderHash.Write([]byte(","))
 					ordering[i] = sortExpression{
-						aggInputRef{exprID}, ascending,
+						aggInputRef{exprID}, ascending, nullsFirst,
 					}
 					returnAgg[exprID] = expr
 				}
@@ -660,9 +669,14 @@ func (u unaryOpAST) ContainsWildcard() bool {
 type typeCastAST struct {
 	Expr   FlatExpression
 	Target parser.Type
+	Format string
 }
 
 func (t typeCastAST) Repr() string {
+	if t.Format != "" {
+		return fmt.Sprintf("CAST(%s AS %s FORMAT %s)", t.Expr.Repr(), t.Target,
+			parser.StringLiteral{Value: t.Format}.String())
+	}
 	return fmt.Sprintf("CAST(%s AS %s)", t.Expr.Repr(), t.Target)
 }
 
@@ -718,6 +732,17 @@ func (f funcAppAST) ContainsWildcard() bool {
 type sortExpression struct {
 	Value     aggInputRef
 	Ascending bool
+	// NullsFirst is true when NULLs come before other values. It's the
+	// same as Ascending unless NULLS FIRST/LAST is given.
+	NullsFirst bool
+}
+
+// nullsOrderString returns NULLS FIRST or NULLS LAST.
+func nullsOrderString(nullsFirst bool) string {
+	if nullsFirst {
+		return "NULLS FIRST"
+	}
+	return "NULLS LAST"
 }
 
 type aggregateInputSorter struct {
@@ -739,6 +764,9 @@ func (a aggregateInputSorter) Repr() string {
 		} else {
 			ordering[i] += " DESC"
 		}
+		if e.NullsFirst != e.Ascending {
+			ordering[i] += " " + nullsOrderString(e.NullsFirst)
+		}
 	}
 	return fmt.Sprintf("%s(%s ORDER BY %s)", a.Function,
 		strings.Join(reprs, ","), strings.Join(ordering, ","))
@@ -997,8 +1025,10 @@ func (rm rowMeta) Repr() string {
 	return fmt.Sprintf("%#v", rm)
 }
 
+// Columns returns a pseudo column representing the meta information so that
+// it can be checked against columns in the GROUP BY clause.
 func (rm rowMeta) Columns() []rowValue {
-	return nil
+	return []rowValue{{rm.Relation, parser.RowMeta{MetaType: rm.MetaType}.String()}}
 }
 
 func (rm rowMeta) Volatility() VolatilityType {
@@ -1009,6 +1039,29 @@ func (rm rowMeta) ContainsWildcard() bool {
 	return false
 }
 
+type rowMetadata struct {
+	Relation string
+	Key      string
+}
+
+func (rm rowMetadata) Repr() string {
+	return fmt.Sprintf("%#v", rm)
+}
+
+// Columns returns a pseudo column representing the metadata. See
+// rowMeta.Columns.
+func (rm rowMetadata) Columns() []rowValue {
+	return []rowValue{{rm.Relation, parser.RowMetadata{Key: rm.Key}.String()}}
+}
+
+func (rm rowMetadata) Volatility() VolatilityType {
+	return Immutable
+}
+
+func (rm rowMetadata) ContainsWildcard() bool {
+	return false
+}
+
 type numericLiteral struct {
 	Value int64
 }
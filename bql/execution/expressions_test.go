@@ -21,7 +21,7 @@ func TestFlatExpressionConverter(t *testing.T) {
 		"true":  {boolLiteral{true}, Immutable, false, nil},
 		"NULL":  {nullLiteral{}, Immutable, false, nil},
 		"a":     {rowValue{"", "a"}, Immutable, false, []rowValue{{"", "a"}}},
-		"ts()":  {rowMeta{"", parser.TimestampMeta}, Immutable, false, nil},
+		"ts()":  {rowMeta{"", parser.TimestampMeta}, Immutable, false, []rowValue{{"", "ts()"}}},
 		"now()": {stmtMeta{parser.NowMeta}, Stable, false, nil},
 		"2":     {numericLiteral{2}, Immutable, false, nil},
 		"1.2":   {floatLiteral{1.2}, Immutable, false, nil},
@@ -29,7 +29,7 @@ func TestFlatExpressionConverter(t *testing.T) {
 		"*":     {wildcardAST{}, Stable, true, nil},
 		"x:*":   {wildcardAST{"x"}, Stable, true, nil},
 		// Type Cast
-		"CAST(2 AS FLOAT)": {typeCastAST{numericLiteral{2}, parser.Float}, Immutable, false, nil},
+		"CAST(2 AS FLOAT)": {typeCastAST{numericLiteral{2}, parser.Float, ""}, Immutable, false, nil},
 		// Function Application
 		"f(a)": {funcAppAST{parser.FuncName("f"),
 			[]FlatExpression{rowValue{"", "a"}}}, Volatile, false, []rowValue{{"", "a"}}},
@@ -20,7 +20,9 @@ func CanBuildFilterPlan(lp *LogicalPlan, reg udf.FunctionRegistry) bool {
 		return false
 	}
 	return !lp.GroupingStmt &&
+		len(lp.windowFuncs) == 0 &&
 		lp.EmitterType == parser.Rstream &&
+		!lp.EmitterOnChange &&
 		lp.Relations[0].Unit == parser.Tuples &&
 		lp.Relations[0].Value == 1
 }
@@ -43,6 +45,7 @@ func NewFilterPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (PhysicalPlan, err
 	return &filterPlan{commonExecutionPlan{
 		projections: projs,
 		filter:      filter,
+		clock:       reg.Context().Clock(),
 	}, lp.Relations[0].Alias}, nil
 }
 
@@ -55,7 +58,7 @@ func (ep *filterPlan) Process(input *core.Tuple) ([]data.Map, error) {
 
 	// add the information accessed by the now() function
 	// to each item
-	d[":meta:NOW"] = data.Timestamp(time.Now().In(time.UTC))
+	d[":meta:NOW"] = data.Timestamp(ep.clock.Now().In(time.UTC))
 
 	// evaluate filter condition and convert to bool
 	if ep.filter != nil {
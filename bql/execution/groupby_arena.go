@@ -0,0 +1,157 @@
+package execution
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+const (
+	// maxArenaBufferCap is the maximum capacity of a buffer of inputs of an
+	// aggregate function kept in groupArena. Larger buffers are released
+	// after each computation so that a single huge group doesn't keep its
+	// memory forever.
+	maxArenaBufferCap = 1 << 16
+
+	// groupListChunkSize is the minimum number of group lists allocated at
+	// once.
+	groupListChunkSize = 256
+)
+
+// groupArena keeps temporary structures used by
+// groupbyExecutionPlan.performQueryOnBuffer and reuses them in the next
+// computation, which reduces the load of GC when windows have many groups.
+// Only structures which don't escape from a computation are reused: inputs
+// of aggregate functions are copied to new arrays before they're passed to
+// the functions because functions like array_agg return them as they are,
+// and result rows are emitted to other nodes.
+//
+// All methods can be called on a nil groupArena, in which case they
+// allocate new structures every time.
+type groupArena struct {
+	groups    map[data.HashValue][]*tmpGroupData
+	groupKeys []data.HashValue
+
+	// slab has all groups ever created. The first used groups are in use.
+	slab []*tmpGroupData
+	used int
+
+	// lists is a chunk of memory from which lists of groups having the same
+	// hash value are carved. The first listsUsed elements are in use.
+	lists     []*tmpGroupData
+	listsUsed int
+}
+
+func newGroupArena() *groupArena {
+	return &groupArena{
+		groups: map[data.HashValue][]*tmpGroupData{},
+	}
+}
+
+// groupMap returns an empty map to look up groups by their hash values.
+func (a *groupArena) groupMap() map[data.HashValue][]*tmpGroupData {
+	if a == nil {
+		return map[data.HashValue][]*tmpGroupData{}
+	}
+	return a.groups
+}
+
+// keys returns an empty slice to keep hash values of groups.
+func (a *groupArena) keys() []data.HashValue {
+	if a == nil {
+		return []data.HashValue{}
+	}
+	return a.groupKeys[:0]
+}
+
+// newGroup returns a tmpGroupData having empty inputs for aggregate
+// functions of each given key.
+func (a *groupArena) newGroup(group data.Array, nonAggData data.Map, aggKeys map[string]Evaluator) *tmpGroupData {
+	if a == nil {
+		g := &tmpGroupData{
+			group,
+			map[string][]data.Value{},
+			nonAggData,
+		}
+		for key := range aggKeys {
+			g.aggData[key] = make([]data.Value, 0, 1)
+		}
+		return g
+	}
+
+	if a.used == len(a.slab) {
+		a.slab = append(a.slab, &tmpGroupData{
+			aggData: make(map[string][]data.Value, len(aggKeys)),
+		})
+	}
+	g := a.slab[a.used]
+	a.used++
+	g.group = group
+	g.nonAggData = nonAggData
+	for key := range aggKeys {
+		g.aggData[key] = g.aggData[key][:0]
+	}
+	return g
+}
+
+// newGroupList returns a list only having the given group. The list can be
+// extended by append without affecting other lists.
+func (a *groupArena) newGroupList(g *tmpGroupData) []*tmpGroupData {
+	if a == nil {
+		return []*tmpGroupData{g}
+	}
+	if a.listsUsed == len(a.lists) {
+		// lists carved from the old chunk are still in use, so a new
+		// chunk is allocated. It's large enough for the next computation.
+		a.lists = make([]*tmpGroupData, 2*len(a.lists)+groupListChunkSize)
+		a.listsUsed = 0
+	}
+	i := a.listsUsed
+	l := a.lists[i : i+1 : i+1]
+	a.listsUsed++
+	l[0] = g
+	return l
+}
+
+// aggInput returns inputs of the aggregate function of the key in the group
+// as an Array which can be passed to the function.
+func (a *groupArena) aggInput(g *tmpGroupData, key string) data.Array {
+	if a == nil {
+		arr := data.Array(g.aggData[key])
+		delete(g.aggData, key)
+		return arr
+	}
+	buf := g.aggData[key]
+	arr := make(data.Array, len(buf))
+	copy(arr, buf)
+	return arr
+}
+
+// reset makes all structures available for the next computation. groups and
+// groupKeys must be the ones returned from groupMap and keys, respectively.
+func (a *groupArena) reset(groups map[data.HashValue][]*tmpGroupData, groupKeys []data.HashValue) {
+	if a == nil {
+		return
+	}
+	for k := range groups {
+		delete(groups, k)
+	}
+	a.groupKeys = groupKeys[:0]
+
+	for _, g := range a.slab[:a.used] {
+		// values must be cleared so that they can be collected by GC
+		g.group = nil
+		g.nonAggData = nil
+		for key, buf := range g.aggData {
+			if cap(buf) > maxArenaBufferCap {
+				delete(g.aggData, key)
+				continue
+			}
+			for i := range buf {
+				buf[i] = nil
+			}
+			g.aggData[key] = buf[:0]
+		}
+	}
+	a.used = 0
+	// lists only refer to groups in slab, so they don't have to be cleared
+	a.listsUsed = 0
+}
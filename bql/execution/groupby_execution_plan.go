@@ -9,6 +9,19 @@ import (
 
 type groupbyExecutionPlan struct {
 	streamRelationStreamExecutionPlan
+
+	// arena keeps temporary structures of performQueryOnBuffer to reuse
+	// them. It's nil unless EnableArena is called.
+	arena *groupArena
+
+	// projectionExprs and reg are used to create evaluators of projections
+	// for each worker of parallel aggregation.
+	projectionExprs []aliasedExpression
+	reg             udf.FunctionRegistry
+
+	// workers evaluates groups in parallel. It's nil unless
+	// EnableParallelAggregation is called.
+	workers *groupWorkers
 }
 
 // tmpGroupData is an intermediate data structure to represent
@@ -47,10 +60,34 @@ func NewGroupbyExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (Physica
 		return nil, err
 	}
 	return &groupbyExecutionPlan{
-		*underlying,
+		streamRelationStreamExecutionPlan: *underlying,
+		projectionExprs:                   lp.Projections,
+		reg:                               reg,
 	}, nil
 }
 
+// EnableArena makes the plan reuse temporary structures such as groups
+// and buffers of inputs of aggregate functions after each computation.
+func (ep *groupbyExecutionPlan) EnableArena() {
+	if ep.arena == nil {
+		ep.arena = newGroupArena()
+	}
+}
+
+// EnableParallelAggregation makes the plan evaluate groups with a pool of
+// workers when a window has many groups. It must be called before the first
+// call of Process.
+func (ep *groupbyExecutionPlan) EnableParallelAggregation(c *ParallelAggregation) error {
+	w, err := newGroupWorkers(c, func() ([]aliasedEvaluator, error) {
+		return prepareProjections(ep.projectionExprs, ep.reg)
+	})
+	if err != nil {
+		return err
+	}
+	ep.workers = w
+	return nil
+}
+
 // Process takes an input tuple and returns a slice of Map values that
 // correspond to the results of the query represented by this execution
 // plan. Note that the order of items in the returned slice is undefined
@@ -102,10 +139,13 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 
 	// groups holds one item for every combination of values that
 	// appear in the GROUP BY clause
-	groups := map[data.HashValue][]*tmpGroupData{}
+	groups := ep.arena.groupMap()
 	// we also keep a list of group keys so that we can still loop
 	// over them in the order they were added
-	groupKeys := []data.HashValue{}
+	groupKeys := ep.arena.keys()
+	defer func() {
+		ep.arena.reset(groups, groupKeys)
+	}()
 
 	// findOrCreateGroup looks up the group that has the given
 	// groupValues in the `groups`map. if there is no such
@@ -113,23 +153,10 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 	// is used as a representative of this group's values.
 	findOrCreateGroup := func(groupValues []data.Value, groupHash data.HashValue, nonGroupValues data.Map) (*tmpGroupData, error) {
 		mkGroup := func() *tmpGroupData {
-			newGroup := &tmpGroupData{
-				// the values that make up this group
-				groupValues,
-				// the input values of the aggregate functions
-				map[string][]data.Value{},
-				// a representative set of values for this group for later evaluation
-				// TODO actually we don't need the whole map,
-				//      just the parts common to the whole group
-				nonGroupValues.Copy(),
-			}
-			// initialize the map with the aggregate function inputs
-			for _, proj := range ep.projections {
-				for key := range proj.aggrEvals {
-					newGroup.aggData[key] = make([]data.Value, 0, 1)
-				}
-			}
-			return newGroup
+			// a representative set of values for this group for later evaluation
+			// TODO actually we don't need the whole map,
+			//      just the parts common to the whole group
+			return ep.arena.newGroup(groupValues, nonGroupValues.Copy(), allAggEvaluators)
 		}
 
 		// find the correct group
@@ -138,7 +165,7 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		// if there is no such group, create one
 		if !exists {
 			group = mkGroup()
-			groups[groupHash] = []*tmpGroupData{group}
+			groups[groupHash] = ep.arena.newGroupList(group)
 			groupKeys = append(groupKeys, groupHash)
 		} else {
 			// if we arrive here, there is a group with the same hash value
@@ -163,12 +190,16 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 	// function to compute the grouping expressions and store the
 	// input for aggregate functions in the correct group.
 	evalItem := func(io *inputRowWithCachedResult) error {
+		input, cache, err := ep.rowData(io)
+		if err != nil {
+			return err
+		}
 		var itemGroupValues data.Array
 		// if we have a cached result, use this
-		if io.cache != nil {
-			cachedGroupValues, err := data.AsArray(io.cache)
+		if cache != nil {
+			cachedGroupValues, err := data.AsArray(cache)
 			if err != nil {
-				return fmt.Errorf("cached data was not an array: %v", io.cache)
+				return fmt.Errorf("cached data was not an array: %v", cache)
 			}
 			itemGroupValues = cachedGroupValues
 		} else {
@@ -177,17 +208,21 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 			itemGroupValues = make([]data.Value, len(ep.groupList))
 			for i, eval := range ep.groupList {
 				// ordinary "flat" expression
-				value, err := eval.Eval(*io.input)
+				value, err := eval.Eval(input)
 				if err != nil {
 					return err
 				}
 				itemGroupValues[i] = value
 			}
-			io.cache = itemGroupValues
-			io.hash = data.Hash(io.cache)
+			// a spilled row doesn't keep its result in memory, but
+			// its hash is required to find the group
+			if io.spilled == nil {
+				io.cache = itemGroupValues
+			}
+			io.hash = data.Hash(itemGroupValues)
 		}
 
-		itemGroup, err := findOrCreateGroup(itemGroupValues, io.hash, *io.input)
+		itemGroup, err := findOrCreateGroup(itemGroupValues, io.hash, input)
 		if err != nil {
 			return err
 		}
@@ -195,7 +230,7 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		// now compute all the input data for the aggregate functions,
 		// e.g. for `SELECT count(a) + max(b/2)`, compute `a` and `b/2`
 		for key, agg := range allAggEvaluators {
-			value, err := agg.Eval(*io.input)
+			value, err := agg.Eval(input)
 			if err != nil {
 				return err
 			}
@@ -205,20 +240,23 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 		return nil
 	}
 
-	evalGroup := func(group *tmpGroupData) error {
-		result := data.Map(make(map[string]data.Value, len(ep.projections)))
+	// evalGroup computes the result row of the group with the given
+	// evaluators of projections. It returns nil when the group is dropped
+	// by HAVING. It can be called concurrently for different groups with
+	// different evaluators.
+	evalGroup := func(group *tmpGroupData, projections []aliasedEvaluator) (*resultRow, error) {
+		result := data.Map(make(map[string]data.Value, len(projections)))
 		// collect input for aggregate functions into an array
 		// within each group
 		for key := range allAggEvaluators {
-			group.nonAggData[key] = data.Array(group.aggData[key])
-			delete(group.aggData, key)
+			group.nonAggData[key] = ep.arena.aggInput(group, key)
 		}
 		// evaluate HAVING condition, if there is one
-		for _, proj := range ep.projections {
+		for _, proj := range projections {
 			if proj.alias == ":having:" {
 				havingResult, err := proj.evaluator.Eval(group.nonAggData)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				// a NULL value is definitely not "true", so since we
 				// have only a binary decision, we should drop tuples
@@ -227,32 +265,31 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 				if havingResult.Type() != data.TypeNull {
 					havingResultBool, err = data.AsBool(havingResult)
 					if err != nil {
-						return err
+						return nil, err
 					}
 				}
 				// if it evaluated to false, do not further process this group
 				if !havingResultBool {
-					return nil
+					return nil, nil
 				}
 				break
 			}
 		}
 		// now evaluate all other projections
-		for _, proj := range ep.projections {
+		for _, proj := range projections {
 			if proj.alias == ":having:" {
 				continue
 			}
 			// now evaluate this projection on the flattened data
 			value, err := proj.evaluator.Eval(group.nonAggData)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if err := assignOutputValue(result, proj.alias, proj.aliasPath, value); err != nil {
-				return err
+				return nil, err
 			}
 		}
-		output = append(output, resultRow{row: result, hash: data.Hash(result)})
-		return nil
+		return &resultRow{row: result, hash: data.Hash(result)}, nil
 	}
 
 	evalNoGroup := func() error {
@@ -302,12 +339,35 @@ func (ep *groupbyExecutionPlan) performQueryOnBuffer() error {
 	// if we arrive here, then the input for the aggregation functions
 	// is in the `group` list and we need to compute aggregation and output.
 	// NB. we do not directly loop over the `groups` map to avoid random order.
-	for _, groupKey := range groupKeys {
-		groupsWithSameHash := groups[groupKey]
-		for _, group := range groupsWithSameHash {
-			if err := evalGroup(group); err != nil {
-				rollback()
-				return err
+	if ep.workers.accepts(len(groupKeys)) {
+		// groups are evaluated by workers and their results are assembled
+		// in the same order as the sequential evaluation below
+		flatGroups := make([]*tmpGroupData, 0, len(groupKeys))
+		for _, groupKey := range groupKeys {
+			flatGroups = append(flatGroups, groups[groupKey]...)
+		}
+		results, err := ep.workers.evalGroups(flatGroups, evalGroup)
+		if err != nil {
+			rollback()
+			return err
+		}
+		for _, r := range results {
+			if r != nil {
+				output = append(output, *r)
+			}
+		}
+	} else {
+		for _, groupKey := range groupKeys {
+			groupsWithSameHash := groups[groupKey]
+			for _, group := range groupsWithSameHash {
+				r, err := evalGroup(group, ep.projections)
+				if err != nil {
+					rollback()
+					return err
+				}
+				if r != nil {
+					output = append(output, *r)
+				}
 			}
 		}
 	}
@@ -44,6 +44,32 @@ func getOtherTuples() []*core.Tuple {
 }
 
 func TestGroupbyExecutionPlan(t *testing.T) {
+	Convey("Given a SELECT clause with GROUP BY metadata", t, func() {
+		tuples := getOtherTuples()
+		for i, tup := range tuples {
+			tup.SetPartition(int64(i % 2))
+		}
+
+		s := `CREATE STREAM box AS SELECT RSTREAM meta("partition") AS p, input_name(), count(*) AS c FROM src [RANGE 3 TUPLES] GROUP BY meta("partition"), input_name()`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out []data.Map
+			for _, inTup := range tuples {
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then tuples should be grouped by the metadata", func() {
+				So(out, ShouldResemble, []data.Map{
+					{"p": data.Int(1), "input_name": data.String("src"), "c": data.Int(2)},
+					{"p": data.Int(0), "input_name": data.String("src"), "c": data.Int(1)},
+				})
+			})
+		})
+	})
+
 	Convey("Given a SELECT clause with GROUP BY but no aggregation", t, func() {
 		tuples := getOtherTuples()
 
@@ -812,11 +838,11 @@ func TestGroupbyExecutionPlan(t *testing.T) {
 	})
 
 	Convey("Given an SELECT statement with an invalid GROUP BY (4)", t, func() {
-		// using an expression in the GROUP BY clause
+		// grouping by metadata doesn't allow other columns
 		s := `CREATE STREAM box AS SELECT RSTREAM foo, count(int) FROM src [RANGE 3 TUPLES] GROUP BY ts()`
 		_, err := createGroupbyPlan(s, t)
 		So(err, ShouldNotBeNil)
-		So(err.Error(), ShouldEqual, `grouping by expressions is not supported yet`)
+		So(err.Error(), ShouldEqual, `column "src:foo" must appear in the GROUP BY clause or be used in an aggregate function`)
 	})
 
 	Convey("Given an SELECT statement with an unknown UDAF", t, func() {
@@ -829,10 +855,11 @@ func TestGroupbyExecutionPlan(t *testing.T) {
 
 	Convey("Given an SELECT statement with a timestamp", t, func() {
 		// using metadata in the SELECT clause (outside of aggregate)
+		// without having it in the GROUP BY clause
 		s := `CREATE STREAM box AS SELECT RSTREAM ts() FROM src [RANGE 3 TUPLES] GROUP BY foo`
 		_, err := createGroupbyPlan(s, t)
 		So(err, ShouldNotBeNil)
-		So(err.Error(), ShouldEqual, `using metadata 'TS' in GROUP BY statements is not supported yet`)
+		So(err.Error(), ShouldEqual, `column "src:ts()" must appear in the GROUP BY clause or be used in an aggregate function`)
 	})
 }
 
@@ -1317,3 +1344,148 @@ func BenchmarkComplicatedGroupExecution(b *testing.B) {
 		}
 	}
 }
+
+func TestGroupbyExecutionPlanArena(t *testing.T) {
+	stmts := []string{
+		`CREATE STREAM box AS SELECT RSTREAM foo, count(int) AS c, array_agg(int) AS a FROM src [RANGE 5 TUPLES] GROUP BY foo`,
+		`CREATE STREAM box AS SELECT ISTREAM foo, array_agg(int) AS a FROM src [RANGE 4 TUPLES] GROUP BY foo HAVING count(*) > 1`,
+		`CREATE STREAM box AS SELECT DSTREAM foo, sum(int) AS s FROM src [RANGE 3 TUPLES] GROUP BY foo`,
+		`CREATE STREAM box AS SELECT RSTREAM count(*) AS c FROM src [RANGE 2 TUPLES]`,
+	}
+
+	for _, s := range stmts {
+		s := s
+		Convey(fmt.Sprintf("Given a plan of '%v' using an arena", s), t, func() {
+			plan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+			plan.(ArenaPlan).EnableArena()
+			expectedPlan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+
+			Convey("When feeding it with tuples", func() {
+				tuples := getTuples(20)
+				for i, t := range tuples {
+					t.Data["foo"] = data.Int(i % 3)
+				}
+				var outputs, copies [][]data.Map
+				for _, t := range tuples {
+					actual, err := plan.Process(t.Copy())
+					So(err, ShouldBeNil)
+					expected, err := expectedPlan.Process(t.Copy())
+					So(err, ShouldBeNil)
+					So(actual, ShouldResemble, expected)
+
+					outputs = append(outputs, actual)
+					var c []data.Map
+					for _, m := range actual {
+						c = append(c, m.Copy())
+					}
+					copies = append(copies, c)
+				}
+
+				Convey("Then results emitted before should not be modified", func() {
+					So(outputs, ShouldResemble, copies)
+				})
+			})
+		})
+	}
+}
+
+func TestGroupbyExecutionPlanParallel(t *testing.T) {
+	stmts := []string{
+		`CREATE STREAM box AS SELECT RSTREAM foo, count(int) AS c, array_agg(int) AS a FROM src [RANGE 300 TUPLES] GROUP BY foo`,
+		`CREATE STREAM box AS SELECT ISTREAM foo, sum(int) AS s FROM src [RANGE 250 TUPLES] GROUP BY foo HAVING count(*) > 1`,
+		`CREATE STREAM box AS SELECT RSTREAM count(*) AS c FROM src [RANGE 2 TUPLES]`,
+	}
+
+	for _, s := range stmts {
+		s := s
+		Convey(fmt.Sprintf("Given a plan of '%v' evaluating groups in parallel", s), t, func() {
+			plan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+			So(plan.(ParallelAggregationPlan).EnableParallelAggregation(&ParallelAggregation{
+				Workers:   4,
+				MinGroups: 2,
+			}), ShouldBeNil)
+			expectedPlan, err := createGroupbyPlan(s, t)
+			So(err, ShouldBeNil)
+
+			Convey("When feeding it with tuples", func() {
+				tuples := getTuples(400)
+				for i, t := range tuples {
+					t.Data["foo"] = data.Int(i % 200)
+				}
+
+				Convey("Then it should emit the same results in the same order", func() {
+					for _, t := range tuples {
+						actual, err := plan.Process(t.Copy())
+						So(err, ShouldBeNil)
+						expected, err := expectedPlan.Process(t.Copy())
+						So(err, ShouldBeNil)
+						So(actual, ShouldResemble, expected)
+					}
+				})
+			})
+		})
+	}
+
+	Convey("Given a plan evaluating groups in parallel with a failing projection", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM foo, count(*) / (foo - 150) AS x FROM src [RANGE 200 TUPLES] GROUP BY foo`
+		plan, err := createGroupbyPlan(s, t)
+		So(err, ShouldBeNil)
+		So(plan.(ParallelAggregationPlan).EnableParallelAggregation(&ParallelAggregation{
+			Workers:   4,
+			MinGroups: 2,
+		}), ShouldBeNil)
+
+		Convey("When feeding it with tuples making a group fail", func() {
+			tuples := getTuples(200)
+			for i, t := range tuples {
+				t.Data["foo"] = data.Int(i)
+			}
+			var err error
+			for _, t := range tuples {
+				if _, err = plan.Process(t); err != nil {
+					break
+				}
+			}
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func BenchmarkManyGroupsExecution(b *testing.B) {
+	s := `CREATE STREAM box AS SELECT RSTREAM foo, count(int), max(int) FROM src [RANGE 5000 TUPLES] GROUP BY foo`
+	for _, arena := range []bool{false, true} {
+		arena := arena
+		b.Run(fmt.Sprintf("arena=%v", arena), func(b *testing.B) {
+			plan, err := createGroupbyPlan2(s)
+			if err != nil {
+				panic(err.Error())
+			}
+			if arena {
+				plan.(ArenaPlan).EnableArena()
+			}
+			tmplTup := core.Tuple{
+				Data:          data.Map{"int": data.Int(-1)},
+				InputName:     "src",
+				Timestamp:     time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC),
+				ProcTimestamp: time.Date(2015, time.April, 10, 10, 24, 0, 0, time.UTC),
+				BatchID:       7,
+			}
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				inTup := tmplTup.Copy()
+				inTup.Data["int"] = data.Int(n)
+				inTup.Data["foo"] = data.Int(n % 1000)
+				_, err := plan.Process(inTup)
+				if err != nil {
+					panic(err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package execution
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultParallelAggregationMinGroups is the default value of
+	// ParallelAggregation.MinGroups.
+	DefaultParallelAggregationMinGroups = 1024
+
+	// parallelAggregationChunkSize is the number of groups a worker takes
+	// at once.
+	parallelAggregationChunkSize = 64
+)
+
+// ParallelAggregation has configuration parameters to evaluate groups of a
+// SELECT statement with GROUP BY in parallel. Groups are independent of each
+// other, so aggregate functions, HAVING, and projections of each group can
+// be evaluated by a pool of workers when a window has many groups. Results
+// are assembled in the same order as the sequential evaluation, so the
+// output doesn't depend on the number of workers.
+//
+// Rows in the window are still grouped by one goroutine. Functions used in
+// projections must be safe to call concurrently, which is already required
+// for functions used in statements running in parallel.
+type ParallelAggregation struct {
+	// Workers is the number of goroutines evaluating groups. When it's 0 or
+	// less, runtime.NumCPU() is used.
+	Workers int
+
+	// MinGroups is the minimum number of groups to evaluate them in
+	// parallel. Groups are evaluated sequentially when the window has
+	// fewer groups. When it's 0 or less,
+	// DefaultParallelAggregationMinGroups is used.
+	MinGroups int
+}
+
+// groupWorkers evaluates groups with a pool of workers. Each worker has its
+// own evaluators of projections because evaluators aren't thread-safe.
+type groupWorkers struct {
+	minGroups   int
+	projections [][]aliasedEvaluator
+}
+
+// newGroupWorkers creates groupWorkers from the configuration. prepare
+// creates a new set of evaluators of projections for a worker.
+func newGroupWorkers(c *ParallelAggregation, prepare func() ([]aliasedEvaluator, error)) (*groupWorkers, error) {
+	n := c.Workers
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	w := &groupWorkers{
+		minGroups:   c.MinGroups,
+		projections: make([][]aliasedEvaluator, n),
+	}
+	if w.minGroups <= 0 {
+		w.minGroups = DefaultParallelAggregationMinGroups
+	}
+	for i := range w.projections {
+		projs, err := prepare()
+		if err != nil {
+			return nil, err
+		}
+		w.projections[i] = projs
+	}
+	return w, nil
+}
+
+// accepts returns true when n groups should be evaluated in parallel. It can
+// be called on a nil groupWorkers, in which case it returns false.
+func (w *groupWorkers) accepts(n int) bool {
+	return w != nil && len(w.projections) > 1 && n >= w.minGroups
+}
+
+// evalGroups evaluates all groups with eval and returns results in the order
+// of groups. A result is nil when its group doesn't produce a row. When
+// evaluation of some groups fails, the error of the first one of them is
+// returned.
+func (w *groupWorkers) evalGroups(groups []*tmpGroupData,
+	eval func(g *tmpGroupData, projections []aliasedEvaluator) (*resultRow, error)) ([]*resultRow, error) {
+	results := make([]*resultRow, len(groups))
+	errs := make([]error, len(groups))
+	var next int64
+	var wg sync.WaitGroup
+	for _, projs := range w.projections {
+		wg.Add(1)
+		go func(projs []aliasedEvaluator) {
+			defer wg.Done()
+			for {
+				end := int(atomic.AddInt64(&next, parallelAggregationChunkSize))
+				begin := end - parallelAggregationChunkSize
+				if begin >= len(groups) {
+					return
+				}
+				if end > len(groups) {
+					end = len(groups)
+				}
+				for i := begin; i < end; i++ {
+					results[i], errs[i] = evalGroupRecovering(groups[i], projs, eval)
+				}
+			}
+		}(projs)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// evalGroupRecovering calls eval and converts a panic into an error because
+// a panic in a worker cannot be recovered by the caller of Process.
+func evalGroupRecovering(g *tmpGroupData, projections []aliasedEvaluator,
+	eval func(g *tmpGroupData, projections []aliasedEvaluator) (*resultRow, error)) (r *resultRow, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			r = nil
+			err = fmt.Errorf("evaluating a group panicked: %v", e)
+		}
+	}()
+	return eval(g, projections)
+}
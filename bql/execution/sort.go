@@ -5,10 +5,12 @@ import (
 )
 
 // sortArray holds an array of values of which we want to find the order,
-// plus a flag whether we want ascending order or not.
+// plus flags whether we want ascending order or not and whether NULLs
+// come before other values or not.
 type sortArray struct {
-	values    data.Array
-	ascending bool
+	values     data.Array
+	ascending  bool
+	nullsFirst bool
 }
 
 // indexSlice is a data structure that allows to put the `indexes` integer
@@ -16,9 +18,9 @@ type sortArray struct {
 //
 // If `indexes` holds the values {0, 1, 2} and `ordering` is
 //   []sortArray{
-//     {[]data.Value{data.Int(1), data.Int(1), data.Int(1)}, true},
-//     {[]data.Value{data.Int(5), data.Int(5), data.Int(6)}, false},
-//     {[]data.Value{data.Int(8), data.Int(9), data.Int(7)}, true},
+//     {[]data.Value{data.Int(1), data.Int(1), data.Int(1)}, true, true},
+//     {[]data.Value{data.Int(5), data.Int(5), data.Int(6)}, false, false},
+//     {[]data.Value{data.Int(8), data.Int(9), data.Int(7)}, true, true},
 //   }
 // then after sort.Sort() on that indexSlice, `indexes` will have
 // the value `{2, 0, 1}` because `ordering[2]` is the smallest item
@@ -42,6 +44,10 @@ func (s *indexSlice) Less(i, j int) bool {
 		jVal := order.values[s.indexes[j]]
 		if data.Equal(iVal, jVal) {
 			continue
+		}
+		iNull, jNull := iVal.Type() == data.TypeNull, jVal.Type() == data.TypeNull
+		if iNull || jNull {
+			return iNull == order.nullsFirst
 		} else if order.ascending {
 			return data.Less(iVal, jVal)
 		}
@@ -16,42 +16,63 @@ func TestSorting(t *testing.T) {
 		/// one sorting dimension
 		// already sorted
 		{[]sortArray{
-			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, true},
+			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, true, true},
 		}, []int{0, 1, 2}},
 		// some other order
 		{[]sortArray{
-			{[]data.Value{data.Int(2), data.Int(3), data.Int(1)}, true},
+			{[]data.Value{data.Int(2), data.Int(3), data.Int(1)}, true, true},
 		}, []int{2, 0, 1}},
 		// sort descending
 		{[]sortArray{
-			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, false},
+			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, false, false},
 		}, []int{2, 1, 0}},
 		// sort some other order descending
 		{[]sortArray{
-			{[]data.Value{data.Int(2), data.Int(3), data.Int(1)}, false},
+			{[]data.Value{data.Int(2), data.Int(3), data.Int(1)}, false, false},
 		}, []int{1, 0, 2}},
 		/// two sorting dimensions
 		// already sorted
 		{[]sortArray{
-			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, true},
-			{[]data.Value{data.Int(4), data.Int(5), data.Int(6)}, true},
+			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, true, true},
+			{[]data.Value{data.Int(4), data.Int(5), data.Int(6)}, true, true},
 		}, []int{0, 1, 2}},
 		// second is ignored if first is correct
 		{[]sortArray{
-			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, true},
-			{[]data.Value{data.Int(4), data.Int(5), data.Int(6)}, false},
+			{[]data.Value{data.Int(1), data.Int(2), data.Int(3)}, true, true},
+			{[]data.Value{data.Int(4), data.Int(5), data.Int(6)}, false, false},
 		}, []int{0, 1, 2}},
 		// second is used if first is the same everywhere
 		{[]sortArray{
-			{[]data.Value{data.Int(1), data.Int(1), data.Int(1)}, true},
-			{[]data.Value{data.Int(4), data.Int(5), data.Int(6)}, false},
+			{[]data.Value{data.Int(1), data.Int(1), data.Int(1)}, true, true},
+			{[]data.Value{data.Int(4), data.Int(5), data.Int(6)}, false, false},
 		}, []int{2, 1, 0}},
 		// third is used if first and second are the same
 		{[]sortArray{
-			{[]data.Value{data.Int(1), data.Int(1), data.Int(1)}, true},
-			{[]data.Value{data.Int(5), data.Int(5), data.Int(6)}, false},
-			{[]data.Value{data.Int(8), data.Int(9), data.Int(7)}, true},
+			{[]data.Value{data.Int(1), data.Int(1), data.Int(1)}, true, true},
+			{[]data.Value{data.Int(5), data.Int(5), data.Int(6)}, false, false},
+			{[]data.Value{data.Int(8), data.Int(9), data.Int(7)}, true, true},
 		}, []int{2, 0, 1}},
+		/// NULL placement
+		// NULL is the smallest value by default
+		{[]sortArray{
+			{[]data.Value{data.Int(2), data.Null{}, data.Int(1)}, true, true},
+		}, []int{1, 2, 0}},
+		{[]sortArray{
+			{[]data.Value{data.Int(2), data.Null{}, data.Int(1)}, false, false},
+		}, []int{0, 2, 1}},
+		// NULLS LAST
+		{[]sortArray{
+			{[]data.Value{data.Int(2), data.Null{}, data.Int(1)}, true, false},
+		}, []int{2, 0, 1}},
+		// NULLS FIRST
+		{[]sortArray{
+			{[]data.Value{data.Int(2), data.Null{}, data.Int(1)}, false, true},
+		}, []int{1, 0, 2}},
+		// NULLs are peers
+		{[]sortArray{
+			{[]data.Value{data.Null{}, data.Int(1), data.Null{}}, true, false},
+			{[]data.Value{data.Int(5), data.Int(5), data.Int(4)}, true, true},
+		}, []int{1, 2, 0}},
 	}
 
 	for _, tc := range testCases {
@@ -2,11 +2,14 @@ package execution
 
 import (
 	"container/list"
+	"errors"
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,11 +17,38 @@ type inputBuffer struct {
 	tuples     *list.List
 	windowSize float64
 	windowType parser.IntervalUnit
+	// numSpilled is the number of tuples spilled to disk. Spilled
+	// tuples are always at the beginning of tuples.
+	numSpilled int
+	// firstInMemory is the oldest tuple which isn't spilled. It's nil
+	// when no tuple is kept in memory.
+	firstInMemory *list.Element
+	// memorySize is the approximate total size of tuples kept in memory.
+	memorySize int64
+	// shared is the buffer shared with other plans. Tuples are kept in it
+	// and tuples in this buffer refer to them. It's nil when the buffer
+	// isn't shared.
+	shared *sharedWindow
+	// seenEvictions is the number of evictions from the shared buffer
+	// which this buffer has already followed.
+	seenEvictions int64
 }
 
 type tupleWithDerivedInputRows struct {
 	tuple *core.Tuple
 	rows  []*inputRowWithCachedResult
+	// size is the approximate size of the tuple in memory. It's 0 when
+	// the tuple is spilled.
+	size int64
+	// acquired is true when size is reported to core.ResourceController.
+	acquired bool
+	// spilled is true when the tuple and rows derived from it are
+	// spilled to disk.
+	spilled bool
+	// shared is the tuple in the shared buffer which this tuple refers
+	// to. Its resources are reported by the shared buffer instead of
+	// acquired. It's nil when the buffer isn't shared.
+	shared *sharedTuple
 }
 
 func (i *inputBuffer) isTimeBased() bool {
@@ -32,6 +62,10 @@ type inputRowWithCachedResult struct {
 	input *data.Map
 	cache data.Value
 	hash  data.HashValue
+	// spilled is a reference to the record having input and cache
+	// when they're spilled to disk. input and cache are nil in that
+	// case. Use rowData to access them.
+	spilled *spillRecord
 }
 
 // resultRow holds data for a tuple to be emitted (sooner or later)
@@ -104,6 +138,54 @@ type streamRelationStreamExecutionPlan struct {
 	// the last tuple was appended to. this is valid after
 	// `addTupleToBuffer` has returned.
 	lastTupleBuffers map[string]bool
+	// resources tracks tuples kept in buffers. It's nil when resources
+	// aren't limited.
+	resources *core.ResourceController
+	// numEvicted is the total number of tuples evicted from buffers
+	// because the topology ran out of its resources.
+	numEvicted int64
+	// spillRing stores tuples spilled from buffers. It's nil when
+	// spilling is disabled.
+	spillRing *spillRing
+	// spillMemoryTuples is the number of tuples kept in memory for
+	// each buffer when spilling is enabled.
+	spillMemoryTuples int
+	// triggers holds input names of triggers. When it isn't empty, a
+	// query is only performed when a tuple arrives from one of them.
+	triggers map[string]bool
+	// emitOnChange is true when results are only emitted when they have
+	// changed (WHEN CHANGED).
+	emitOnChange bool
+	// changeKeys holds paths to keys given by WHEN CHANGED BY. When it
+	// isn't empty, a row is suppressed if the last row emitted with the
+	// same key is equal to it.
+	changeKeys []data.Path
+	// lastEmitted holds the last row emitted for each key, grouped by
+	// hash values of the keys.
+	lastEmitted map[data.HashValue][]keyedResultRow
+	// numKeys is the number of keys in lastEmitted. It's accessed
+	// atomically so that NumKeys can be called concurrently.
+	numKeys int64
+	// keyTTL is how long a key is kept in lastEmitted after it was seen
+	// last time. Keys are kept forever when it's 0.
+	keyTTL time.Duration
+	// windowStates holds states of buffers after the last call of
+	// process as map[string]WindowState keyed by aliases of inputs. It's
+	// updated separately from buffers so that WindowStates can be called
+	// while process is running.
+	windowStates atomic.Value
+	// evictionHook is called with tuples removed from buffers. It's nil
+	// when no hook is set.
+	evictionHook EvictionHook
+}
+
+// keyedResultRow is a row emitted with a key computed by WHEN CHANGED BY.
+type keyedResultRow struct {
+	key data.Array
+	row data.Map
+	// lastSeen is the time when a row having the key was computed last
+	// time.
+	lastSeen time.Time
 }
 
 func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegistry) (*streamRelationStreamExecutionPlan, error) {
@@ -133,7 +215,9 @@ func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegis
 		rangeUnit := rel.Unit
 		// the alias of the relation is the key of the buffer
 		buffers[rel.Alias] = &inputBuffer{
-			tuples, rangeValue, rangeUnit,
+			tuples:     tuples,
+			windowSize: rangeValue,
+			windowType: rangeUnit,
 		}
 	}
 
@@ -142,6 +226,7 @@ func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegis
 			projections: projs,
 			groupList:   groupList,
 			filter:      filter,
+			clock:       reg.Context().Clock(),
 		},
 		relations:            lp.Relations,
 		buffers:              buffers,
@@ -150,6 +235,9 @@ func newStreamRelationStreamExecutionPlan(lp *LogicalPlan, reg udf.FunctionRegis
 		prevResults:          []resultRow{},
 		prevHashesForIstream: map[data.HashValue][]resultRowCount{},
 		filteredInputRows:    list.New(),
+		emitOnChange:         lp.EmitterOnChange,
+		changeKeys:           lp.EmitterChangeKeys,
+		lastEmitted:          map[data.HashValue][]keyedResultRow{},
 	}, nil
 }
 
@@ -196,16 +284,37 @@ func (ep *streamRelationStreamExecutionPlan) addTupleToBuffer(t *core.Tuple) err
 	ep.lastTupleBuffers = make(map[string]bool, numAppends)
 	for _, rel := range ep.relations {
 		if t.InputName == ep.relationKey(&rel) {
-			// because the tuple is always cached, ShallowCopy is required here.
-			editTuple := t.ShallowCopy()
-			// nest the data in a one-element map using the alias as the key
-			editTuple.Data = data.Map{rel.Alias: editTuple.Data}
-			// wrap this in a container struct
-			editTupleCont := tupleWithDerivedInputRows{
-				tuple: editTuple,
-			}
 			buffer := ep.buffers[rel.Alias]
-			buffer.tuples.PushBack(&editTupleCont)
+			var editTupleCont tupleWithDerivedInputRows
+			if buffer.shared != nil {
+				// the wrapped tuple is kept in the shared buffer and
+				// other plans may refer to it, so it must not be modified
+				st := buffer.shared.acquire(t, ep.resources)
+				editTupleCont = tupleWithDerivedInputRows{
+					tuple:  st.tuple,
+					size:   st.size,
+					shared: st,
+				}
+			} else {
+				// because the tuple is always cached, ShallowCopy is required here.
+				editTuple := t.ShallowCopy()
+				// nest the data in a one-element map using the alias as the key
+				editTuple.Data = data.Map{rel.Alias: editTuple.Data}
+				// wrap this in a container struct
+				editTupleCont = tupleWithDerivedInputRows{
+					tuple: editTuple,
+				}
+				editTupleCont.size = data.ApproxSize(editTuple.Data)
+				if ep.resources != nil && ep.resources.Enabled() {
+					ep.resources.Acquire(1, editTupleCont.size)
+					editTupleCont.acquired = true
+				}
+			}
+			buffer.memorySize += editTupleCont.size
+			e := buffer.tuples.PushBack(&editTupleCont)
+			if buffer.firstInMemory == nil {
+				buffer.firstInMemory = e
+			}
 			ep.lastTupleBuffers[rel.Alias] = true
 		}
 	}
@@ -231,13 +340,7 @@ func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curT
 				for e := buffer.tuples.Front(); e != nil && i < curBufSize-windowSizeInt; e = next {
 					next = e.Next()
 					i++
-					tupCont := e.Value.(*tupleWithDerivedInputRows)
-					// mark input rows that are derived from outdated
-					// tuples for deletion
-					for _, inputRow := range tupCont.rows {
-						expiredInputRows[inputRow] = true
-					}
-					buffer.tuples.Remove(e)
+					ep.removeTupleFromBuffer(buffer, e, expiredInputRows)
 				}
 			}
 
@@ -254,19 +357,72 @@ func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curT
 				tupCont := e.Value.(*tupleWithDerivedInputRows)
 				dur := curTupTime.Sub(tupCont.tuple.Timestamp)
 				if dur.Seconds() > windowSizeSeconds {
-					// mark input rows that are derived from outdated
-					// tuples for deletion
-					for _, inputRow := range tupCont.rows {
-						expiredInputRows[inputRow] = true
-					}
-					buffer.tuples.Remove(e)
+					ep.removeTupleFromBuffer(buffer, e, expiredInputRows)
 				}
 			}
 		} else {
 			return fmt.Errorf("unknown window type: %+v", *buffer)
 		}
 	}
-	// now delete all rows marked for deletion
+	ep.removeExpiredInputRows(expiredInputRows)
+	return nil
+}
+
+// removeTupleFromBuffer removes a tuple from the buffer and marks input rows
+// derived from the tuple for deletion. It also releases resources of the
+// tuple.
+func (ep *streamRelationStreamExecutionPlan) removeTupleFromBuffer(buffer *inputBuffer,
+	e *list.Element, expiredInputRows map[*inputRowWithCachedResult]bool) {
+	tupCont := e.Value.(*tupleWithDerivedInputRows)
+	// mark input rows that are derived from outdated
+	// tuples for deletion
+	for _, inputRow := range tupCont.rows {
+		expiredInputRows[inputRow] = true
+	}
+	if e == buffer.firstInMemory {
+		buffer.firstInMemory = e.Next()
+	}
+	buffer.tuples.Remove(e)
+	buffer.memorySize -= tupCont.size
+	if ep.evictionHook != nil {
+		ep.callEvictionHook(tupCont.tuple)
+	}
+	if tupCont.acquired {
+		ep.resources.Release(1, tupCont.size)
+	}
+	if tupCont.shared != nil {
+		tupCont.shared.w.release(tupCont.shared, ep.resources)
+	}
+	if tupCont.spilled {
+		buffer.numSpilled--
+		for _, inputRow := range tupCont.rows {
+			if inputRow.spilled != nil {
+				ep.spillRing.free(inputRow.spilled)
+			}
+		}
+	}
+}
+
+// callEvictionHook calls the eviction hook with a tuple removed from the
+// buffer. Data of the tuple is unwrapped from the map keyed by the alias so
+// that the hook receives the tuple as it arrived.
+func (ep *streamRelationStreamExecutionPlan) callEvictionHook(t *core.Tuple) {
+	for alias, v := range t.Data {
+		m, err := data.AsMap(v)
+		if err != nil {
+			continue // this doesn't happen
+		}
+		tup := t.ShallowCopy()
+		tup.Data = m
+		ep.evictionHook(alias, tup)
+	}
+}
+
+// removeExpiredInputRows deletes all rows marked for deletion.
+func (ep *streamRelationStreamExecutionPlan) removeExpiredInputRows(expiredInputRows map[*inputRowWithCachedResult]bool) {
+	if len(expiredInputRows) == 0 {
+		return
+	}
 	var next *list.Element
 	for e := ep.filteredInputRows.Front(); e != nil; e = next {
 		next = e.Next()
@@ -275,10 +431,382 @@ func (ep *streamRelationStreamExecutionPlan) removeOutdatedTuplesFromBuffer(curT
 			ep.filteredInputRows.Remove(e)
 		}
 	}
+}
+
+// evictTuplesFromBuffer removes the oldest tuples from buffers while the
+// resource usage of the topology exceeds its limits. The latest tuple of
+// each buffer is never evicted so that the window isn't empty. Tuples spilled
+// to disk aren't evicted because they don't use memory.
+func (ep *streamRelationStreamExecutionPlan) evictTuplesFromBuffer() {
+	if ep.resources == nil {
+		return
+	}
+
+	expiredInputRows := map[*inputRowWithCachedResult]bool{}
+	ep.removeEvictedSharedTuples(expiredInputRows)
+	for ep.resources.Exceeded() {
+		// find the buffer having the oldest tuple
+		var (
+			oldestBuf *inputBuffer
+			oldest    *list.Element
+		)
+		for _, buffer := range ep.buffers {
+			if buffer.tuples.Len()-buffer.numSpilled <= 1 {
+				continue
+			}
+			e := buffer.firstInMemory
+			if oldest == nil || e.Value.(*tupleWithDerivedInputRows).tuple.Timestamp.Before(
+				oldest.Value.(*tupleWithDerivedInputRows).tuple.Timestamp) {
+				oldestBuf, oldest = buffer, e
+			}
+		}
+		if oldest == nil {
+			break // nothing can be evicted from this plan
+		}
+		if st := oldest.Value.(*tupleWithDerivedInputRows).shared; st != nil {
+			// the tuple is evicted from all plans sharing the buffer
+			st.w.evict(st, ep.resources)
+		}
+		ep.removeTupleFromBuffer(oldestBuf, oldest, expiredInputRows)
+		ep.numEvicted++
+	}
+	ep.removeExpiredInputRows(expiredInputRows)
+}
 
+// removeEvictedSharedTuples removes tuples which have been evicted from
+// shared buffers by other plans.
+func (ep *streamRelationStreamExecutionPlan) removeEvictedSharedTuples(expiredInputRows map[*inputRowWithCachedResult]bool) {
+	for _, buffer := range ep.buffers {
+		if buffer.shared == nil {
+			continue
+		}
+		n := buffer.shared.evictions()
+		if n == buffer.seenEvictions {
+			continue
+		}
+		buffer.seenEvictions = n
+		var next *list.Element
+		for e := buffer.tuples.Front(); e != nil; e = next {
+			next = e.Next()
+			st := e.Value.(*tupleWithDerivedInputRows).shared
+			if st != nil && st.w.isEvicted(st) {
+				ep.removeTupleFromBuffer(buffer, e, expiredInputRows)
+				ep.numEvicted++
+			}
+		}
+	}
+}
+
+// SetResourceController sets a ResourceController which tracks tuples kept
+// in buffers. It must be called before the first call of Process.
+func (ep *streamRelationStreamExecutionPlan) SetResourceController(r *core.ResourceController) {
+	ep.resources = r
+}
+
+// NumEvictedTuples returns the total number of tuples evicted from buffers
+// because the topology ran out of its resources.
+func (ep *streamRelationStreamExecutionPlan) NumEvictedTuples() int64 {
+	return ep.numEvicted
+}
+
+// ReleaseResources releases resources of all tuples kept in buffers. It also
+// removes the file used to spill tuples and detaches shared buffers.
+func (ep *streamRelationStreamExecutionPlan) ReleaseResources() {
+	for _, buffer := range ep.buffers {
+		for e := buffer.tuples.Front(); e != nil; e = e.Next() {
+			tupCont := e.Value.(*tupleWithDerivedInputRows)
+			if tupCont.acquired {
+				ep.resources.Release(1, tupCont.size)
+				tupCont.acquired = false
+			}
+			if tupCont.shared != nil {
+				tupCont.shared.w.release(tupCont.shared, ep.resources)
+				tupCont.shared = nil
+			}
+		}
+		if buffer.shared != nil {
+			buffer.shared.pool.detach(buffer.shared)
+			buffer.shared = nil
+		}
+	}
+
+	if ep.spillRing != nil {
+		// Spilled tuples cannot be accessed after this.
+		ep.spillRing.close()
+		ep.spillRing = nil
+	}
+}
+
+// EnableSpill enables spilling old tuples in buffers to disk. It must be
+// called before the first call of Process. Statements having joins don't
+// support spilling.
+func (ep *streamRelationStreamExecutionPlan) EnableSpill(config *SpillConfig) error {
+	if len(ep.relations) > 1 {
+		return errors.New("spilling windows isn't supported by statements having joins")
+	}
+	for _, buffer := range ep.buffers {
+		if buffer.shared != nil {
+			return errors.New("spilling windows isn't supported with shared windows")
+		}
+	}
+	if ep.evictionHook != nil {
+		return errors.New("spilling windows isn't supported with an eviction hook")
+	}
+	size := config.InitialSize
+	if size <= 0 {
+		size = DefaultSpillInitialSize
+	}
+	r, err := newSpillRing(config.Dir, size)
+	if err != nil {
+		return err
+	}
+	ep.spillRing = r
+	ep.spillMemoryTuples = config.MemoryTuples
+	if ep.spillMemoryTuples <= 0 {
+		ep.spillMemoryTuples = DefaultSpillMemoryTuples
+	}
 	return nil
 }
 
+// SetWindowBufferPool makes buffers of inputs share tuples with plans having
+// identical windows and aliases through the pool. It must be called before the first
+// call of Process. It cannot be used with spilling.
+func (ep *streamRelationStreamExecutionPlan) SetWindowBufferPool(p *WindowBufferPool) error {
+	if ep.spillRing != nil {
+		return errors.New("windows cannot be shared when spilling windows is enabled")
+	}
+	for i := range ep.relations {
+		rel := &ep.relations[i]
+		buffer := ep.buffers[rel.Alias]
+		if rel.Type != parser.ActualStream || buffer.shared != nil {
+			continue
+		}
+		buffer.shared = p.attach(windowKey{
+			input:      ep.relationKey(rel),
+			alias:      rel.Alias,
+			windowSize: buffer.windowSize,
+			windowType: buffer.windowType,
+		})
+	}
+	return nil
+}
+
+// SetEvictionHook sets a hook called with tuples removed from buffers. It
+// cannot be used with spilling.
+func (ep *streamRelationStreamExecutionPlan) SetEvictionHook(h EvictionHook) error {
+	if h != nil && ep.spillRing != nil {
+		return errors.New("an eviction hook cannot be set when spilling windows is enabled")
+	}
+	ep.evictionHook = h
+	return nil
+}
+
+// SetTriggers sets input names of triggers. When triggers are set, tuples
+// from other inputs are only added to buffers and no result is emitted for
+// them. Results are computed when a tuple arrives from a trigger and they're
+// compared with the results computed at the previous trigger.
+func (ep *streamRelationStreamExecutionPlan) SetTriggers(inputNames []string) error {
+	triggers := make(map[string]bool, len(inputNames))
+	for _, n := range inputNames {
+		found := false
+		for _, rel := range ep.relations {
+			if n == ep.relationKey(&rel) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("the statement doesn't have an input named '%v'", n)
+		}
+		triggers[n] = true
+	}
+	ep.triggers = triggers
+	return nil
+}
+
+// SetKeyTTL sets how long the last row emitted for each key of WHEN CHANGED
+// BY is kept after a row having the key was computed last time. When a row
+// having an expired key is computed again, it's emitted as a new one.
+func (ep *streamRelationStreamExecutionPlan) SetKeyTTL(ttl time.Duration) {
+	ep.keyTTL = ttl
+}
+
+// SweepExpiredKeys removes keys of WHEN CHANGED BY which haven't been seen
+// for the TTL.
+func (ep *streamRelationStreamExecutionPlan) SweepExpiredKeys() int {
+	if ep.keyTTL <= 0 {
+		return 0
+	}
+	deadline := ep.clock.Now().In(time.UTC).Add(-ep.keyTTL)
+	removed := 0
+	for h, rows := range ep.lastEmitted {
+		live := rows[:0]
+		for _, r := range rows {
+			if r.lastSeen.Before(deadline) {
+				removed++
+				continue
+			}
+			live = append(live, r)
+		}
+		if len(live) == 0 {
+			delete(ep.lastEmitted, h)
+		} else {
+			// clear removed rows so that they can be garbage collected
+			for i := len(live); i < len(rows); i++ {
+				rows[i] = keyedResultRow{}
+			}
+			ep.lastEmitted[h] = live
+		}
+	}
+	atomic.AddInt64(&ep.numKeys, -int64(removed))
+	return removed
+}
+
+// NumKeys returns the number of keys of WHEN CHANGED BY whose last rows are
+// kept.
+func (ep *streamRelationStreamExecutionPlan) NumKeys() int64 {
+	return atomic.LoadInt64(&ep.numKeys)
+}
+
+// WindowTuples returns copies of tuples in buffers in the order of their
+// timestamps. When an input has more than one buffer, e.g. on self-join, only
+// tuples of the largest buffer are returned because processing them again
+// also fills the smaller ones.
+func (ep *streamRelationStreamExecutionPlan) WindowTuples() ([]*core.Tuple, error) {
+	var keys []string
+	largest := map[string]*parser.AliasedStreamWindowAST{}
+	for i := range ep.relations {
+		rel := &ep.relations[i]
+		buffer := ep.buffers[rel.Alias]
+		if buffer.numSpilled > 0 {
+			return nil, fmt.Errorf("the window of '%v' has tuples spilled to disk", rel.Alias)
+		}
+		key := ep.relationKey(rel)
+		l, ok := largest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || ep.buffers[l.Alias].tuples.Len() < buffer.tuples.Len() {
+			largest[key] = rel
+		}
+	}
+
+	var tuples []*core.Tuple
+	for _, key := range keys {
+		rel := largest[key]
+		for e := ep.buffers[rel.Alias].tuples.Front(); e != nil; e = e.Next() {
+			t := e.Value.(*tupleWithDerivedInputRows).tuple.Copy()
+			d, err := data.AsMap(t.Data[rel.Alias])
+			if err != nil {
+				return nil, err
+			}
+			t.Data = d
+			t.InputName = key
+			tuples = append(tuples, t)
+		}
+	}
+	sort.SliceStable(tuples, func(i, j int) bool {
+		return tuples[i].Timestamp.Before(tuples[j].Timestamp)
+	})
+	return tuples, nil
+}
+
+// spillTuplesFromBuffer writes the oldest tuples kept in memory to disk until
+// each buffer has at most ep.spillMemoryTuples tuples in memory. Because this
+// method doesn't support joins, each tuple has at most one derived row. Rows
+// must have their cached results before they're spilled so that they don't
+// have to be recomputed.
+func (ep *streamRelationStreamExecutionPlan) spillTuplesFromBuffer() error {
+	if ep.spillRing == nil {
+		return nil
+	}
+
+	for _, buffer := range ep.buffers {
+		for buffer.tuples.Len()-buffer.numSpilled > ep.spillMemoryTuples {
+			e := buffer.firstInMemory
+			tupCont := e.Value.(*tupleWithDerivedInputRows)
+			for _, row := range tupCont.rows {
+				b := encodeSpilledRow(*row.input, row.cache, ep.spillRing.dict)
+				rec, err := ep.spillRing.write(b)
+				if err != nil {
+					return err
+				}
+				row.spilled = rec
+				row.input = nil
+				row.cache = nil
+			}
+			// Data of old tuples isn't accessed again when the statement
+			// doesn't have joins.
+			tupCont.tuple.Data = nil
+			tupCont.spilled = true
+			// spilled tuples don't use memory
+			if tupCont.acquired {
+				ep.resources.Release(1, tupCont.size)
+				tupCont.acquired = false
+			}
+			buffer.memorySize -= tupCont.size
+			tupCont.size = 0
+			buffer.numSpilled++
+			buffer.firstInMemory = e.Next()
+		}
+	}
+	return nil
+}
+
+// updateWindowStates takes a snapshot of states of buffers for
+// WindowStates.
+func (ep *streamRelationStreamExecutionPlan) updateWindowStates() {
+	states := make(map[string]WindowState, len(ep.buffers))
+	for alias, buffer := range ep.buffers {
+		st := WindowState{
+			NumTuples:  buffer.tuples.Len(),
+			NumSpilled: buffer.numSpilled,
+			MemorySize: buffer.memorySize,
+			WindowSize: buffer.windowSize,
+			WindowType: buffer.windowType,
+		}
+		if buffer.shared != nil {
+			st.SharedBy = buffer.shared.numRefs()
+		}
+		if front := buffer.tuples.Front(); front != nil {
+			st.Oldest = front.Value.(*tupleWithDerivedInputRows).tuple.Timestamp
+			st.Newest = buffer.tuples.Back().Value.(*tupleWithDerivedInputRows).tuple.Timestamp
+		}
+		states[alias] = st
+	}
+	ep.windowStates.Store(states)
+}
+
+// WindowStates returns states of buffers keyed by aliases of inputs.
+func (ep *streamRelationStreamExecutionPlan) WindowStates() map[string]WindowState {
+	if states, ok := ep.windowStates.Load().(map[string]WindowState); ok {
+		return states
+	}
+
+	// Process hasn't been called yet.
+	states := make(map[string]WindowState, len(ep.buffers))
+	for alias, buffer := range ep.buffers {
+		states[alias] = WindowState{
+			WindowSize: buffer.windowSize,
+			WindowType: buffer.windowType,
+		}
+	}
+	return states
+}
+
+// rowData returns the input and the cached result of the row. When the row is
+// spilled to disk, they're reloaded from the disk but aren't kept in memory.
+func (ep *streamRelationStreamExecutionPlan) rowData(io *inputRowWithCachedResult) (data.Map, data.Value, error) {
+	if io.spilled == nil {
+		return *io.input, io.cache, nil
+	}
+	b, err := ep.spillRing.read(io.spilled)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodeSpilledRow(b, ep.spillRing.dict)
+}
+
 // previousMultiplicity returns how often the given map was emitted
 // in the previous run. This is required for an ISTREAM emitter.
 func (ep *streamRelationStreamExecutionPlan) previousMultiplicity(r *resultRow) int {
@@ -403,12 +931,79 @@ func (ep *streamRelationStreamExecutionPlan) computeResultTuples() ([]data.Map,
 	return nil, fmt.Errorf("emitter type '%s' not implemented", ep.emitterType)
 }
 
+// resultsChanged returns true when the results of this run's query differ
+// from the results of the previous run's query. The order of rows isn't
+// taken into account.
+func (ep *streamRelationStreamExecutionPlan) resultsChanged() bool {
+	if len(ep.curResults) != len(ep.prevResults) {
+		return true
+	}
+	curHashes := make(map[data.HashValue][]resultRowCount, len(ep.curResults))
+	for _, res := range ep.curResults {
+		ep.incrAndGetMultiplicity(&res, curHashes)
+	}
+	// since both results have the same number of rows, they're equal when
+	// no row in the previous results appears more often than in the
+	// current results
+	counts := make(map[data.HashValue][]resultRowCount, len(ep.prevResults))
+	for _, prevItem := range ep.prevResults {
+		if ep.incrAndGetMultiplicity(&prevItem, counts) > ep.currentMultiplicity(&prevItem, curHashes) {
+			return true
+		}
+	}
+	return false
+}
+
+// suppressUnchangedRows removes rows which are equal to the last row
+// emitted with the same key given by WHEN CHANGED BY.
+func (ep *streamRelationStreamExecutionPlan) suppressUnchangedRows(output []data.Map) []data.Map {
+	res := output[:0]
+	for _, row := range output {
+		key := make(data.Array, len(ep.changeKeys))
+		for i, p := range ep.changeKeys {
+			v, err := row.Get(p)
+			if err != nil {
+				v = data.Null{}
+			}
+			key[i] = v
+		}
+		h := data.Hash(key)
+
+		rows := ep.lastEmitted[h]
+		found := false
+		for i, r := range rows {
+			if !data.Equal(r.key, key) {
+				continue
+			}
+			found = true
+			rows[i].lastSeen = ep.now
+			if data.Equal(r.row, row) {
+				break
+			}
+			rows[i].row = row.Copy()
+			res = append(res, row)
+			break
+		}
+		if !found {
+			ep.lastEmitted[h] = append(rows, keyedResultRow{
+				key:      key,
+				row:      row.Copy(),
+				lastSeen: ep.now,
+			})
+			atomic.AddInt64(&ep.numKeys, 1)
+			res = append(res, row)
+		}
+	}
+	return res
+}
+
 // Process takes an input tuple, a function that represents the "subclassing"
 // plan's core functionality and returns a slice of Map values that correspond
 // to the results of the query represented by this execution plan. Note that the
 // order of items in the returned slice is undefined and cannot be relied on.
 func (ep *streamRelationStreamExecutionPlan) process(input *core.Tuple, performQueryOnBuffer func() error) ([]data.Map, error) {
-	ep.now = time.Now().In(time.UTC)
+	ep.now = ep.clock.Now().In(time.UTC)
+	defer ep.updateWindowStates()
 
 	// stream-to-relation:
 	// updates the internal buffer with correct window data
@@ -418,6 +1013,7 @@ func (ep *streamRelationStreamExecutionPlan) process(input *core.Tuple, performQ
 	if err := ep.removeOutdatedTuplesFromBuffer(input.Timestamp); err != nil {
 		return nil, err
 	}
+	ep.evictTuplesFromBuffer()
 
 	// relation-to-relation:
 	// performs a SELECT query on buffer and writes result
@@ -425,13 +1021,33 @@ func (ep *streamRelationStreamExecutionPlan) process(input *core.Tuple, performQ
 	if err := ep.filterInputTuples(); err != nil {
 		return nil, err
 	}
+	if len(ep.triggers) > 0 && !ep.triggers[input.InputName] {
+		// wait for the next trigger without performing the query
+		if err := ep.spillTuplesFromBuffer(); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
 	if err := performQueryOnBuffer(); err != nil {
 		return nil, err
 	}
+	if err := ep.spillTuplesFromBuffer(); err != nil {
+		return nil, err
+	}
 
 	// relation-to-stream:
 	// compute new/old/all result data and return it
-	return ep.computeResultTuples()
+	if ep.emitOnChange && len(ep.changeKeys) == 0 && !ep.resultsChanged() {
+		return nil, nil
+	}
+	output, err := ep.computeResultTuples()
+	if err != nil {
+		return nil, err
+	}
+	if len(ep.changeKeys) > 0 {
+		output = ep.suppressUnchangedRows(output)
+	}
+	return output, nil
 }
 
 func (ep *streamRelationStreamExecutionPlan) filterInputTuples() error {
@@ -2,8 +2,12 @@ package execution
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"testing"
+	"time"
 )
 
 func TestMultiplicityHandling(t *testing.T) {
@@ -49,3 +53,328 @@ func TestMultiplicityHandling(t *testing.T) {
 		})
 	})
 }
+
+func TestWindowEvictionByResourceLimits(t *testing.T) {
+	Convey("Given a plan having a window with a resource controller", t, func() {
+		tuples := getTuples(4)
+		s := `CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 5 TUPLES]`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+		p, ok := plan.(ResourceLimitedPlan)
+		So(ok, ShouldBeTrue)
+		r := core.NewResourceController(&core.ResourceLimits{MaxTuples: 2})
+		p.SetResourceController(r)
+
+		Convey("When feeding it with more tuples than the budget", func() {
+			var out []data.Map
+			for _, inTup := range tuples {
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the oldest tuples should be evicted", func() {
+				So(len(out), ShouldEqual, 2)
+				So(p.NumEvictedTuples(), ShouldEqual, 2)
+				n, _ := r.Usage()
+				So(n, ShouldEqual, 2)
+			})
+
+			Convey("Then all resources should be released after releasing them", func() {
+				p.ReleaseResources()
+				n, m := r.Usage()
+				So(n, ShouldEqual, 0)
+				So(m, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestTriggers(t *testing.T) {
+	tick := func() *core.Tuple {
+		return &core.Tuple{
+			Data:      data.Map{"tick": data.Int(1)},
+			InputName: "tick",
+		}
+	}
+
+	Convey("Given a plan aggregating a window with a trigger", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM count(*) AS n FROM src [RANGE 3 TUPLES], tick [RANGE 1 TUPLES]`
+		plan, err := createGroupbyPlan2(s)
+		So(err, ShouldBeNil)
+		So(plan.(TriggerablePlan).SetTriggers([]string{"tick"}), ShouldBeNil)
+
+		Convey("When feeding it with tuples from the non-trigger input", func() {
+			for _, inTup := range getTuples(4) {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+				So(out, ShouldBeEmpty)
+			}
+
+			Convey("Then the trigger should emit the result on the window", func() {
+				out, err := plan.Process(tick())
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, []data.Map{{"n": data.Int(3)}})
+			})
+		})
+	})
+
+	Convey("Given a plan selecting ISTREAM with a trigger", t, func() {
+		s := `CREATE STREAM box AS SELECT ISTREAM src:int FROM src [RANGE 2 TUPLES], tick [RANGE 1 TUPLES]`
+		plan, err := createDefaultSelectPlan2(s)
+		So(err, ShouldBeNil)
+		So(plan.(TriggerablePlan).SetTriggers([]string{"tick"}), ShouldBeNil)
+		tuples := getTuples(4)
+
+		Convey("When triggering it twice", func() {
+			for _, inTup := range tuples[:3] {
+				_, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+			out1, err := plan.Process(tick())
+			So(err, ShouldBeNil)
+			_, err = plan.Process(tuples[3])
+			So(err, ShouldBeNil)
+			out2, err := plan.Process(tick())
+			So(err, ShouldBeNil)
+
+			Convey("Then it should emit rows inserted since the previous trigger", func() {
+				So(len(out1), ShouldEqual, 2)
+				So(out1, ShouldContain, data.Map{"int": data.Int(2)})
+				So(out1, ShouldContain, data.Map{"int": data.Int(3)})
+				So(out2, ShouldResemble, []data.Map{{"int": data.Int(4)}})
+			})
+		})
+	})
+
+	Convey("Given a plan having a join", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM * FROM src [RANGE 2 TUPLES], tick [RANGE 1 TUPLES]`
+		plan, err := createDefaultSelectPlan2(s)
+		So(err, ShouldBeNil)
+
+		Convey("When setting an unknown input as a trigger", func() {
+			err := plan.(TriggerablePlan).SetTriggers([]string{"unknown"})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestEmitOnChange(t *testing.T) {
+	Convey("Given a plan emitting results when they have changed", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM [WHEN CHANGED] int / 3 AS d FROM src [RANGE 1 TUPLES]`
+		plan, err := createDefaultSelectPlan2(s)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var outs [][]data.Map
+			for _, inTup := range getTuples(6) {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+				outs = append(outs, out)
+			}
+
+			Convey("Then it should only emit changed results", func() {
+				So(outs[0], ShouldResemble, []data.Map{{"d": data.Int(0)}})
+				So(outs[1], ShouldBeEmpty)
+				So(outs[2], ShouldResemble, []data.Map{{"d": data.Int(1)}})
+				So(outs[3], ShouldBeEmpty)
+				So(outs[4], ShouldBeEmpty)
+				So(outs[5], ShouldResemble, []data.Map{{"d": data.Int(2)}})
+			})
+		})
+	})
+
+	Convey("Given a plan suppressing unchanged rows per key", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM [WHEN CHANGED BY k] k, v FROM src [RANGE 1 TUPLES]`
+		plan, err := createDefaultSelectPlan2(s)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			inputs := []data.Map{
+				{"k": data.String("a"), "v": data.Int(1)},
+				{"k": data.String("b"), "v": data.Int(1)},
+				{"k": data.String("a"), "v": data.Int(1)},
+				{"k": data.String("a"), "v": data.Int(2)},
+				{"k": data.String("b"), "v": data.Int(1)},
+			}
+			var outs [][]data.Map
+			for i, in := range inputs {
+				out, err := plan.Process(&core.Tuple{
+					Data:      in,
+					InputName: "src",
+					Timestamp: time.Date(2015, time.April, 10, 10, 23, i, 0, time.UTC),
+				})
+				So(err, ShouldBeNil)
+				outs = append(outs, out)
+			}
+
+			Convey("Then it should only emit rows changed since the last one having the same key", func() {
+				So(outs[0], ShouldResemble, []data.Map{inputs[0]})
+				So(outs[1], ShouldResemble, []data.Map{inputs[1]})
+				So(outs[2], ShouldBeEmpty)
+				So(outs[3], ShouldResemble, []data.Map{inputs[3]})
+				So(outs[4], ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a plan suppressing unchanged rows per key with a TTL", t, func() {
+		start := time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC)
+		clock := core.NewFakeClock(start)
+		reg := udf.CopyGlobalUDFRegistry(core.NewContext(&core.ContextConfig{
+			Clock: clock,
+		}))
+		stmt, _, err := parser.New().ParseStmt(
+			`CREATE STREAM box AS SELECT RSTREAM [WHEN CHANGED BY k] k, v FROM src [RANGE 1 TUPLES]`)
+		So(err, ShouldBeNil)
+		lp, err := Analyze(stmt.(parser.CreateStreamAsSelectStmt).Select, reg)
+		So(err, ShouldBeNil)
+		plan, err := NewDefaultSelectExecutionPlan(lp, reg)
+		So(err, ShouldBeNil)
+		p, ok := plan.(KeyedPlan)
+		So(ok, ShouldBeTrue)
+		p.SetKeyTTL(10 * time.Second)
+
+		process := func(k string) []data.Map {
+			out, err := plan.Process(&core.Tuple{
+				Data:      data.Map{"k": data.String(k), "v": data.Int(1)},
+				InputName: "src",
+				Timestamp: clock.Now(),
+			})
+			So(err, ShouldBeNil)
+			return out
+		}
+
+		Convey("When keys are seen at different times", func() {
+			So(process("a"), ShouldHaveLength, 1)
+			clock.Advance(6 * time.Second)
+			So(process("b"), ShouldHaveLength, 1)
+			clock.Advance(6 * time.Second)
+			So(p.NumKeys(), ShouldEqual, 2)
+
+			Convey("Then sweeping should only remove expired keys", func() {
+				So(p.SweepExpiredKeys(), ShouldEqual, 1)
+				So(p.NumKeys(), ShouldEqual, 1)
+				So(p.SweepExpiredKeys(), ShouldEqual, 0)
+
+				Convey("And a row having the expired key should be emitted again", func() {
+					So(process("a"), ShouldHaveLength, 1)
+					So(process("b"), ShouldBeEmpty)
+					So(p.NumKeys(), ShouldEqual, 2)
+				})
+			})
+
+			Convey("Then seeing a key again should extend its lifetime", func() {
+				So(process("a"), ShouldBeEmpty)
+				clock.Advance(6 * time.Second)
+				So(p.SweepExpiredKeys(), ShouldEqual, 1)
+				So(process("a"), ShouldBeEmpty)
+				So(process("b"), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a statement using WHEN CHANGED without BY with ISTREAM", t, func() {
+		s := `CREATE STREAM box AS SELECT ISTREAM [WHEN CHANGED] int FROM src [RANGE 1 TUPLES]`
+
+		Convey("When creating a plan", func() {
+			_, err := createDefaultSelectPlan2(s)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestEvictionHook(t *testing.T) {
+	Convey("Given a plan having a window with an eviction hook", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 2 TUPLES]`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+		p, ok := plan.(EvictionHookPlan)
+		So(ok, ShouldBeTrue)
+		aliases := []string{}
+		evicted := []data.Map{}
+		So(p.SetEvictionHook(func(alias string, t *core.Tuple) {
+			aliases = append(aliases, alias)
+			evicted = append(evicted, t.Data)
+		}), ShouldBeNil)
+
+		Convey("When feeding it with more tuples than the window", func() {
+			for _, inTup := range getTuples(4) {
+				_, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the hook should receive tuples leaving the window", func() {
+				So(aliases, ShouldResemble, []string{"src", "src"})
+				So(evicted, ShouldResemble, []data.Map{
+					{"int": data.Int(1)},
+					{"int": data.Int(2)},
+				})
+			})
+		})
+
+		Convey("When removing the hook", func() {
+			So(p.SetEvictionHook(nil), ShouldBeNil)
+			for _, inTup := range getTuples(4) {
+				_, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the hook shouldn't be called", func() {
+				So(evicted, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When enabling spilling", func() {
+			err := plan.(SpillablePlan).EnableSpill(&SpillConfig{})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestWindowTuples(t *testing.T) {
+	Convey("Given a plan having a self-join", t, func() {
+		s := `CREATE STREAM box AS SELECT RSTREAM a:int FROM src [RANGE 2 TUPLES] AS a, src [RANGE 3 TUPLES] AS b`
+		plan, err := createDefaultSelectPlan2(s)
+		So(err, ShouldBeNil)
+		p, ok := plan.(SnapshotPlan)
+		So(ok, ShouldBeTrue)
+
+		Convey("When feeding it with more tuples than windows", func() {
+			for _, inTup := range getTuples(4) {
+				_, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+			tuples, err := p.WindowTuples()
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return tuples of the largest window", func() {
+				So(len(tuples), ShouldEqual, 3)
+				for i, t := range tuples {
+					So(t.InputName, ShouldEqual, "src")
+					So(t.Data, ShouldResemble, data.Map{"int": data.Int(i + 2)})
+				}
+			})
+
+			Convey("Then processing them with another plan should rebuild windows", func() {
+				plan2, err := createDefaultSelectPlan2(s)
+				So(err, ShouldBeNil)
+				for _, t := range tuples {
+					_, err := plan2.Process(t)
+					So(err, ShouldBeNil)
+				}
+				states := plan2.(WindowStatePlan).WindowStates()
+				So(states["a"].NumTuples, ShouldEqual, 2)
+				So(states["b"].NumTuples, ShouldEqual, 3)
+			})
+		})
+	})
+}
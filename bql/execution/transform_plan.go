@@ -9,6 +9,7 @@ import (
 	"math"
 	"regexp"
 	"strings"
+	"time"
 )
 
 const (
@@ -42,11 +43,20 @@ type LogicalPlan struct {
 	EmitterLimit        int64
 	EmitterSampling     float64
 	EmitterSamplingType parser.EmitterSamplingType
-	Projections         []aliasedExpression
+	// EmitterOnChange is true when WHEN CHANGED is specified.
+	EmitterOnChange bool
+	// EmitterChangeKeys holds paths to keys given by WHEN CHANGED BY.
+	EmitterChangeKeys []data.Path
+	// InputSampling is the probability in (0,1] with which each input
+	// tuple is processed. It's -1 when SAMPLE isn't specified.
+	InputSampling float64
+	Projections   []aliasedExpression
 	parser.WindowedFromAST
 	Filter    FlatExpression
 	GroupList []FlatExpression
 	parser.HavingAST
+	// windowFuncs holds window functions used in projections.
+	windowFuncs []windowFuncAST
 }
 
 // PhysicalPlan is a physical interface that is capable of
@@ -70,6 +80,205 @@ type PhysicalPlan interface {
 	Process(input *core.Tuple) ([]data.Map, error)
 }
 
+// ResourceLimitedPlan is a PhysicalPlan having window buffers whose usage of
+// resources can be limited by core.ResourceController. When the usage of the
+// topology exceeds its limits, the plan evicts the oldest tuples from its
+// window buffers.
+type ResourceLimitedPlan interface {
+	PhysicalPlan
+
+	// SetResourceController sets a ResourceController to which the plan
+	// reports tuples kept in its window buffers. It must be called before
+	// the first call of Process.
+	SetResourceController(r *core.ResourceController)
+
+	// NumEvictedTuples returns the total number of tuples evicted from
+	// window buffers because the topology ran out of its resources.
+	NumEvictedTuples() int64
+
+	// ReleaseResources releases resources of all tuples kept in window
+	// buffers including files used to spill them. Process must not be
+	// called after calling this method.
+	ReleaseResources()
+}
+
+// SpillablePlan is a PhysicalPlan which can spill old contents of its windows
+// to disk.
+type SpillablePlan interface {
+	PhysicalPlan
+
+	// EnableSpill enables spilling with the given configuration. It must be
+	// called before the first call of Process. It returns an error when
+	// the statement doesn't support spilling. Files used to spill windows
+	// are removed by ResourceLimitedPlan.ReleaseResources.
+	EnableSpill(config *SpillConfig) error
+}
+
+// ArenaPlan is a PhysicalPlan which can reuse memory of temporary structures
+// used to compute results from its windows.
+type ArenaPlan interface {
+	PhysicalPlan
+
+	// EnableArena makes the plan keep temporary structures used in each
+	// computation of results and reuse them in the next computation instead
+	// of allocating new ones. It reduces GC pauses when windows have many
+	// groups at the cost of memory kept between computations. It must be
+	// called before the first call of Process.
+	EnableArena()
+}
+
+// TriggerablePlan is a PhysicalPlan whose results are only emitted when
+// tuples arrive from specific inputs called triggers. Tuples from other
+// inputs only update windows.
+type TriggerablePlan interface {
+	PhysicalPlan
+
+	// SetTriggers sets input names of triggers. It must be called before
+	// the first call of Process. It returns an error when an input name
+	// isn't used by the statement.
+	SetTriggers(inputNames []string) error
+}
+
+// EvictionHook is called with a tuple which has left a window buffer of
+// the input whose alias is given. The tuple must not be modified.
+type EvictionHook func(alias string, t *core.Tuple)
+
+// EvictionHookPlan is a PhysicalPlan which can notify tuples leaving its
+// window buffers, either because they're out of the window or because they
+// were evicted due to resource limits.
+type EvictionHookPlan interface {
+	PhysicalPlan
+
+	// SetEvictionHook sets a hook called in Process with tuples removed
+	// from window buffers. A nil hook removes the current one. It must not
+	// be called concurrently with Process. It returns an error when
+	// spilling is enabled because data of spilled tuples isn't kept.
+	SetEvictionHook(h EvictionHook) error
+}
+
+// ParallelAggregationPlan is a PhysicalPlan which can evaluate groups of
+// GROUP BY in parallel.
+type ParallelAggregationPlan interface {
+	PhysicalPlan
+
+	// EnableParallelAggregation makes the plan evaluate groups with a pool
+	// of workers when a window has many groups. It must be called before
+	// the first call of Process.
+	EnableParallelAggregation(c *ParallelAggregation) error
+}
+
+// SharedWindowPlan is a PhysicalPlan whose window buffers can share tuples
+// with other plans having identical windows to save memory.
+type SharedWindowPlan interface {
+	PhysicalPlan
+
+	// SetWindowBufferPool makes window buffers of the plan share tuples
+	// through the pool. It must be called before the first call of Process.
+	// Shared buffers are detached by ResourceLimitedPlan.ReleaseResources.
+	// It returns an error when spilling is enabled.
+	SetWindowBufferPool(p *WindowBufferPool) error
+}
+
+// WindowStatePlan is a PhysicalPlan which can report states of its window
+// buffers for diagnosis.
+type WindowStatePlan interface {
+	PhysicalPlan
+
+	// WindowStates returns states of window buffers after the last call of
+	// Process, keyed by aliases of inputs. Unlike Process, it can be called
+	// concurrently. The returned map must not be modified.
+	WindowStates() map[string]WindowState
+}
+
+// KeyedPlan is a PhysicalPlan keeping state for each key, such as the last
+// row emitted for each key of WHEN CHANGED BY. Because the number of keys
+// isn't bounded, state of keys which haven't been seen for a while can be
+// removed by a TTL.
+type KeyedPlan interface {
+	PhysicalPlan
+
+	// SetKeyTTL sets how long state of a key is kept after the key was seen
+	// last time. 0 keeps state forever, which is the default. It must be
+	// called before the first call of Process.
+	SetKeyTTL(ttl time.Duration)
+
+	// SweepExpiredKeys removes state of keys which haven't been seen for
+	// the TTL and returns the number of removed keys. It doesn't do
+	// anything when the TTL is 0. It must not be called concurrently with
+	// Process.
+	SweepExpiredKeys() int
+
+	// NumKeys returns the number of keys having state. Unlike Process, it
+	// can be called concurrently.
+	NumKeys() int64
+}
+
+// SnapshotPlan is a PhysicalPlan whose window buffers can be taken out so
+// that they can be restored in another process, e.g. by a savepoint.
+type SnapshotPlan interface {
+	PhysicalPlan
+
+	// WindowTuples returns copies of tuples in window buffers in the order
+	// of their timestamps. Each tuple has the data and the InputName it had
+	// when it was given to Process, so that processing the tuples with
+	// Process of a new plan rebuilds the buffers. It returns an error when
+	// some tuples are spilled to disk. It must not be called concurrently
+	// with Process.
+	WindowTuples() ([]*core.Tuple, error)
+}
+
+// WindowState is the state of a window buffer of an input.
+type WindowState struct {
+	// NumTuples is the number of tuples in the buffer including spilled
+	// ones.
+	NumTuples int
+
+	// NumSpilled is the number of tuples spilled to disk.
+	NumSpilled int
+
+	// Oldest and Newest are timestamps of the oldest and the newest tuples
+	// in the buffer. They're zero when the buffer is empty.
+	Oldest time.Time
+	Newest time.Time
+
+	// MemorySize is the approximate size of tuples kept in memory in bytes.
+	// Tuples in a shared buffer are counted by each plan sharing them.
+	MemorySize int64
+
+	// SharedBy is the number of buffers sharing tuples through a
+	// WindowBufferPool including this one. It's 0 when the buffer isn't
+	// shared.
+	SharedBy int
+
+	// WindowSize and WindowType are the specification of the window.
+	WindowSize float64
+	WindowType parser.IntervalUnit
+}
+
+// Map returns the state as a data.Map. Timestamps are null when the buffer
+// is empty.
+func (s *WindowState) Map() data.Map {
+	m := data.Map{
+		"num_tuples":       data.Int(s.NumTuples),
+		"num_spilled":      data.Int(s.NumSpilled),
+		"oldest_timestamp": data.Null{},
+		"newest_timestamp": data.Null{},
+		"memory_size":      data.Int(s.MemorySize),
+		"window": data.Map{
+			"size": data.Float(s.WindowSize),
+			"unit": data.String(s.WindowType.String()),
+		},
+	}
+	if s.SharedBy > 0 {
+		m["shared_by"] = data.Int(s.SharedBy)
+	}
+	if s.NumTuples > 0 {
+		m["oldest_timestamp"] = data.Timestamp(s.Oldest)
+		m["newest_timestamp"] = data.Timestamp(s.Newest)
+	}
+	return m
+}
+
 // Analyze checks the given SELECT statement for logical errors
 // (references to unknown tables etc.) and creates a LogicalPlan
 // that is internally consistent.
@@ -138,8 +347,19 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 		colHeader := fmt.Sprintf("col_%v", i)
 		switch projType := expr.(type) {
 		case parser.RowMeta:
-			if projType.MetaType == parser.TimestampMeta {
+			switch projType.MetaType {
+			case parser.TimestampMeta:
 				colHeader = "ts"
+			case parser.ProcTimestampMeta:
+				colHeader = "proc_ts"
+			case parser.InputNameMeta:
+				colHeader = "input_name"
+			case parser.TraceMeta:
+				colHeader = "trace"
+			}
+		case parser.RowMetadata:
+			if simpleColumnNameRe.MatchString(projType.Key) {
+				colHeader = projType.Key
 			}
 		case parser.RowValue:
 			// We can only use the column name as an alias if it is not
@@ -197,6 +417,9 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 			}
 			return nil, err
 		}
+		if len(collectWindowFuncs(filterFlatExpr)) > 0 {
+			return nil, fmt.Errorf("window functions not allowed in WHERE clause")
+		}
 		filterExpr = filterFlatExpr
 	}
 
@@ -212,18 +435,34 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 			}
 			return nil, err
 		}
-		// at the moment we only support grouping by single columns,
-		// not expressions
-		col, ok := flatExpr.(rowValue)
-		if !ok {
+		// at the moment we only support grouping by single columns
+		// or meta information, not expressions
+		switch flatExpr.(type) {
+		case rowValue, rowMeta, rowMetadata:
+		default:
 			err := fmt.Errorf("grouping by expressions is not supported yet")
 			return nil, err
 		}
-		groupCols[i] = col
+		groupCols[i] = flatExpr.Columns()[0]
 		flatGroupExprs[i] = flatExpr
 	}
 	groupingMode = groupingMode || len(flatGroupExprs) > 0
 
+	// window functions are computed over rows in the window, which
+	// doesn't work with groups
+	var windowFuncs []windowFuncAST
+	for _, expr := range flatProjExprs {
+		windowFuncs = append(windowFuncs, collectWindowFuncs(expr.expr)...)
+		for _, agg := range expr.aggrInputs {
+			windowFuncs = append(windowFuncs, collectWindowFuncs(agg)...)
+		}
+	}
+	if groupingMode && len(windowFuncs) > 0 {
+		err := fmt.Errorf("window functions cannot be used with GROUP BY " +
+			"or aggregate functions")
+		return nil, err
+	}
+
 	// check if grouping is done correctly
 	if groupingMode {
 		for _, expr := range flatProjExprs {
@@ -234,11 +473,6 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 			}
 			// all columns mentioned outside of an aggregate
 			// function must be in the GROUP BY clause
-			if rm, ok := expr.expr.(rowMeta); ok {
-				err := fmt.Errorf("using metadata '%s' in GROUP BY statements is "+
-					"not supported yet", rm.MetaType)
-				return nil, err
-			}
 			usedCols := expr.expr.Columns()
 			for _, usedCol := range usedCols {
 				// look for this col in the GROUP BY clause
@@ -262,6 +496,8 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 	emitLimit := int64(-1)
 	emitSampling := float64(-1)
 	emitSamplingType := parser.UnspecifiedSamplingType
+	emitOnChange := false
+	var emitChangeKeys []data.Path
 	for _, opt := range s.EmitterAST.EmitterOptions {
 		switch obj := opt.(type) {
 		default:
@@ -304,7 +540,39 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 				emitSampling = v / 100 // project to [0,1] interval
 			}
 			emitSamplingType = obj.Type
+		case parser.EmitterChange:
+			if len(obj.Keys) == 0 && s.EmitterAST.EmitterType != parser.Rstream {
+				return nil, fmt.Errorf("WHEN CHANGED without BY can only be " +
+					"used with RSTREAM")
+			}
+			for _, k := range obj.Keys {
+				p, err := data.CompilePath(k)
+				if err != nil {
+					return nil, fmt.Errorf("WHEN CHANGED BY has an invalid key '%v': %v", k, err)
+				}
+				emitChangeKeys = append(emitChangeKeys, p)
+			}
+			emitOnChange = true
+		}
+	}
+
+	// validate the input sampling rate
+	inputSampling := float64(-1)
+	if s.InputSamplingAST.Sample != nil {
+		var v float64
+		switch obj := s.InputSamplingAST.Sample.(type) {
+		default:
+			return nil, fmt.Errorf("unknown sampling rate: %+v", obj)
+		case parser.NumericLiteral:
+			v = float64(obj.Value)
+		case parser.FloatLiteral:
+			v = obj.Value
+		}
+		if v <= 0 || v > 100 {
+			return nil, fmt.Errorf("SAMPLE parameter must have a "+
+				"value greater than 0 and at most 100, not %v", v)
 		}
+		inputSampling = v / 100 // project to (0,1] interval
 	}
 
 	return &LogicalPlan{
@@ -313,11 +581,15 @@ func flattenExpressions(s *parser.SelectStmt, reg udf.FunctionRegistry) (*Logica
 		emitLimit,
 		emitSampling,
 		emitSamplingType,
+		emitOnChange,
+		emitChangeKeys,
+		inputSampling,
 		flatProjExprs,
 		s.WindowedFromAST,
 		filterExpr,
 		flatGroupExprs,
 		s.HavingAST,
+		windowFuncs,
 	}, nil
 }
 
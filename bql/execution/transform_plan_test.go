@@ -47,12 +47,12 @@ func TestRelationChecker(t *testing.T) {
 	r := parser.IntervalAST{parser.FloatLiteral{2}, parser.Tuples}
 	singleFrom := parser.WindowedFromAST{
 		[]parser.AliasedStreamWindowAST{
-			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "t", nil}, r, 0, parser.Wait}, ""},
+			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "t", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, ""},
 		},
 	}
 	singleFromAlias := parser.WindowedFromAST{
 		[]parser.AliasedStreamWindowAST{
-			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "s", nil}, r, 0, parser.Wait}, "t"},
+			{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "s", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, "t"},
 		},
 	}
 	two := parser.NumericLiteral{2}
@@ -120,7 +120,7 @@ func TestRelationChecker(t *testing.T) {
 		{&parser.SelectStmt{
 			ProjectionsAST: parser.ProjectionsAST{[]parser.Expression{
 				parser.FuncAppAST{"f", parser.ExpressionsAST{[]parser.Expression{a}},
-					[]parser.SortedExpressionAST{{b, parser.UnspecifiedKeyword}}},
+					[]parser.SortedExpressionAST{{b, parser.UnspecifiedKeyword, parser.UnspecifiedKeyword}}},
 			}},
 			WindowedFromAST: singleFrom,
 		}, ""},
@@ -178,7 +178,7 @@ func TestRelationChecker(t *testing.T) {
 		{&parser.SelectStmt{
 			ProjectionsAST: parser.ProjectionsAST{[]parser.Expression{
 				parser.FuncAppAST{"f", parser.ExpressionsAST{[]parser.Expression{a}},
-					[]parser.SortedExpressionAST{{tB, parser.UnspecifiedKeyword}}},
+					[]parser.SortedExpressionAST{{tB, parser.UnspecifiedKeyword, parser.UnspecifiedKeyword}}},
 			}},
 			WindowedFromAST: singleFrom,
 		}, "cannot refer to relations"},
@@ -186,7 +186,7 @@ func TestRelationChecker(t *testing.T) {
 		{&parser.SelectStmt{
 			ProjectionsAST: parser.ProjectionsAST{[]parser.Expression{
 				parser.FuncAppAST{"f", parser.ExpressionsAST{[]parser.Expression{tA}},
-					[]parser.SortedExpressionAST{{b, parser.UnspecifiedKeyword}}},
+					[]parser.SortedExpressionAST{{b, parser.UnspecifiedKeyword, parser.UnspecifiedKeyword}}},
 			}},
 			WindowedFromAST: singleFrom,
 		}, "cannot refer to relations"},
@@ -533,7 +533,7 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, ""},
 				}},
 		}, ""},
 		// SELECT 2 FROM a AS b         -> OK
@@ -541,7 +541,7 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, "b"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, "b"},
 				}},
 		}, ""},
 		// SELECT 2 FROM a AS b, a      -> OK
@@ -549,8 +549,8 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, "b"},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, "b"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, ""},
 				}},
 		}, ""},
 		// SELECT 2 FROM a AS b, c AS a -> OK
@@ -558,8 +558,8 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, "b"},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "c", nil}, r, 0, parser.Wait}, "a"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, "b"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "c", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, "a"},
 				}},
 		}, ""},
 		// SELECT 2 FROM a, a           -> NG
@@ -567,8 +567,8 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, ""},
 				}},
 		}, "cannot use relations"},
 		// SELECT 2 FROM a, b AS a      -> NG
@@ -576,8 +576,8 @@ func TestRelationAliasing(t *testing.T) {
 			ProjectionsAST: proj,
 			WindowedFromAST: parser.WindowedFromAST{
 				[]parser.AliasedStreamWindowAST{
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait}, ""},
-					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "b", nil}, r, 0, parser.Wait}, "a"},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "a", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, ""},
+					{parser.StreamWindowAST{parser.Stream{parser.ActualStream, "b", nil}, r, 0, parser.Wait, parser.UnspecifiedPipeOption, nil}, "a"},
 				}},
 		}, "cannot use relations"},
 	}
@@ -773,7 +773,7 @@ func TestAggregateChecker(t *testing.T) {
 		{"count(a ORDER BY a ASC) FROM x [RANGE 1 TUPLES]", "",
 			aggregateInputSorter{
 				funcAppAST{"count", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-				[]sortExpression{sortExpression{aggInputRef{"g_f12cd6bc"}, true}},
+				[]sortExpression{sortExpression{aggInputRef{"g_f12cd6bc"}, true, true}},
 				"ccd0ef22",
 			},
 			map[string]FlatExpression{
@@ -784,7 +784,7 @@ func TestAggregateChecker(t *testing.T) {
 		{"count(a ORDER BY b DESC) FROM x [RANGE 1 TUPLES]", "",
 			aggregateInputSorter{
 				funcAppAST{"count", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-				[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false}},
+				[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false, false}},
 				"d7196f56",
 			},
 			map[string]FlatExpression{
@@ -797,12 +797,12 @@ func TestAggregateChecker(t *testing.T) {
 			binaryOpAST{parser.Plus,
 				aggregateInputSorter{
 					funcAppAST{"count", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-					[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false}},
+					[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, false, false}},
 					"d7196f56",
 				},
 				aggregateInputSorter{
 					funcAppAST{"count", []FlatExpression{aggInputRef{"g_f12cd6bc"}}},
-					[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, true}},
+					[]sortExpression{sortExpression{aggInputRef{"g_77d2dd39"}, true, true}},
 					"cd35e18d",
 				},
 			},
@@ -861,6 +861,24 @@ func TestAggregateChecker(t *testing.T) {
 		{"a FROM x [RANGE 1 TUPLES] GROUP BY b",
 			"column \"x:a\" must appear in the GROUP BY clause or be used in an aggregate function", nil, nil},
 
+		{"ts(), count(a) FROM x [RANGE 1 TUPLES] GROUP BY ts()", "",
+			rowMeta{"x", parser.TimestampMeta},
+			nil},
+
+		{`meta("partition"), count(a) FROM x [RANGE 1 TUPLES] GROUP BY meta("partition")`, "",
+			rowMetadata{"x", "partition"},
+			nil},
+
+		{"input_name()::STRING FROM x [RANGE 1 TUPLES] GROUP BY input_name()", "",
+			typeCastAST{rowMeta{"x", parser.InputNameMeta}, parser.String, ""},
+			nil},
+
+		{"ts(), count(a) FROM x [RANGE 1 TUPLES] GROUP BY proc_ts()",
+			"column \"x:ts()\" must appear in the GROUP BY clause or be used in an aggregate function", nil, nil},
+
+		{`meta("tenant") FROM x [RANGE 1 TUPLES] GROUP BY meta("partition")`,
+			"column \"x:meta(\"tenant\")\" must appear in the GROUP BY clause or be used in an aggregate function", nil, nil},
+
 		{"a FROM x [RANGE 1 TUPLES] AS y GROUP BY b",
 			"column \"y:a\" must appear in the GROUP BY clause or be used in an aggregate function", nil, nil},
 
@@ -1034,7 +1052,7 @@ func TestVolatileAggregateChecker(t *testing.T) {
 			[]FlatExpression{
 				aggregateInputSorter{
 					funcAppAST{"count", []FlatExpression{aggInputRef{"g_2523c3a2_0"}}},
-					[]sortExpression{sortExpression{aggInputRef{"g_2523c3a2_1"}, true}},
+					[]sortExpression{sortExpression{aggInputRef{"g_2523c3a2_1"}, true, true}},
 					"cf2e24d7",
 				},
 			},
@@ -0,0 +1,485 @@
+package execution
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+)
+
+// windowFuncAST is a function application having an OVER clause. Its value
+// is computed over all rows in the current window before projections are
+// evaluated, so it's a flat expression reading the precomputed value from
+// the row.
+type windowFuncAST struct {
+	Function    parser.FuncName
+	Expressions []FlatExpression
+	PartitionBy []FlatExpression
+	OrderBy     []windowSortExpression
+}
+
+type windowSortExpression struct {
+	Expr       FlatExpression
+	Ascending  bool
+	NullsFirst bool
+}
+
+func (w windowFuncAST) Repr() string {
+	reprs := make([]string, len(w.Expressions))
+	for i, e := range w.Expressions {
+		reprs[i] = e.Repr()
+	}
+	partition := make([]string, len(w.PartitionBy))
+	for i, e := range w.PartitionBy {
+		partition[i] = e.Repr()
+	}
+	ordering := make([]string, len(w.OrderBy))
+	for i, e := range w.OrderBy {
+		ordering[i] = e.Expr.Repr()
+		if e.Ascending {
+			ordering[i] += " ASC"
+		} else {
+			ordering[i] += " DESC"
+		}
+		if e.NullsFirst != e.Ascending {
+			ordering[i] += " " + nullsOrderString(e.NullsFirst)
+		}
+	}
+	return fmt.Sprintf("%s(%s) OVER (PARTITION BY %s ORDER BY %s)", w.Function,
+		strings.Join(reprs, ","), strings.Join(partition, ","),
+		strings.Join(ordering, ","))
+}
+
+func (w windowFuncAST) Columns() []rowValue {
+	var allColumns []rowValue
+	for _, e := range w.Expressions {
+		allColumns = append(allColumns, e.Columns()...)
+	}
+	for _, e := range w.PartitionBy {
+		allColumns = append(allColumns, e.Columns()...)
+	}
+	for _, e := range w.OrderBy {
+		allColumns = append(allColumns, e.Expr.Columns()...)
+	}
+	return allColumns
+}
+
+func (w windowFuncAST) Volatility() VolatilityType {
+	// the value depends on other rows in the window
+	return Volatile
+}
+
+func (w windowFuncAST) ContainsWildcard() bool {
+	for _, e := range w.Expressions {
+		if e.ContainsWildcard() {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the key of the row in which the value of the window function
+// is stored. Window functions having the same representation share the key.
+func (w windowFuncAST) key() string {
+	h := sha1.New()
+	h.Write([]byte(w.Repr()))
+	return ":meta:WINDOW:" + hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// windowOnlyFuncs are functions which can only be used with OVER. The values
+// are the minimum and the maximum number of arguments.
+var windowOnlyFuncs = map[string][2]int{
+	"row_number": {0, 0},
+	"rank":       {0, 0},
+	"dense_rank": {0, 0},
+	"lag":        {1, 3},
+	"lead":       {1, 3},
+}
+
+// windowFuncToFlatExpr converts a function application having an OVER
+// clause into a windowFuncAST. The function must be one of windowOnlyFuncs
+// or an aggregate function.
+func windowFuncToFlatExpr(obj parser.WindowFuncAppAST, reg udf.FunctionRegistry) (FlatExpression, error) {
+	name := string(obj.Function)
+	if len(obj.Ordering) > 0 {
+		return nil, fmt.Errorf("you cannot use ORDER BY in arguments of "+
+			"window function '%s', use ORDER BY in OVER instead", name)
+	}
+	if arity, ok := windowOnlyFuncs[name]; ok {
+		if n := len(obj.Expressions); n < arity[0] || n > arity[1] {
+			return nil, fmt.Errorf("window function '%s' cannot be called "+
+				"with %d arguments", name, n)
+		}
+	} else {
+		function, err := reg.Lookup(name, len(obj.Expressions))
+		if err != nil {
+			return nil, err
+		}
+		if !isAggregateFunc(function, len(obj.Expressions)) {
+			return nil, fmt.Errorf("function '%s' cannot be used with OVER "+
+				"because it isn't an aggregate function", name)
+		}
+	}
+
+	flatten := func(e parser.Expression) (FlatExpression, error) {
+		if _, ok := e.(parser.Wildcard); ok && name == "count" {
+			// replace the wildcard by an always non-null expression
+			e = parser.NumericLiteral{1}
+		}
+		expr, err := ParserExprToFlatExpr(e, reg)
+		if err != nil {
+			// return a prettier error message
+			if strings.HasPrefix(err.Error(), "you cannot use aggregate") {
+				err = fmt.Errorf("aggregate functions cannot be used in window functions")
+			}
+			return nil, err
+		}
+		if len(collectWindowFuncs(expr)) > 0 {
+			return nil, fmt.Errorf("window functions cannot be nested")
+		}
+		return expr, nil
+	}
+	exprs := make([]FlatExpression, len(obj.Expressions))
+	for i, ast := range obj.Expressions {
+		expr, err := flatten(ast)
+		if err != nil {
+			return nil, err
+		}
+		exprs[i] = expr
+	}
+	partition := make([]FlatExpression, len(obj.PartitionBy))
+	for i, ast := range obj.PartitionBy {
+		expr, err := flatten(ast)
+		if err != nil {
+			return nil, err
+		}
+		partition[i] = expr
+	}
+	ordering := make([]windowSortExpression, len(obj.OrderBy))
+	for i, sortExpr := range obj.OrderBy {
+		expr, err := flatten(sortExpr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		ascending := sortExpr.Ascending != parser.No
+		nullsFirst := ascending
+		if sortExpr.NullsFirst != parser.UnspecifiedKeyword {
+			nullsFirst = sortExpr.NullsFirst == parser.Yes
+		}
+		ordering[i] = windowSortExpression{expr, ascending, nullsFirst}
+	}
+	return windowFuncAST{obj.Function, exprs, partition, ordering}, nil
+}
+
+// collectWindowFuncs returns all window functions used in the expression.
+func collectWindowFuncs(e FlatExpression) []windowFuncAST {
+	var fs []windowFuncAST
+	var walk func(e FlatExpression)
+	walkAll := func(es []FlatExpression) {
+		for _, e := range es {
+			walk(e)
+		}
+	}
+	walk = func(e FlatExpression) {
+		switch obj := e.(type) {
+		case windowFuncAST:
+			fs = append(fs, obj)
+		case binaryOpAST:
+			walk(obj.Left)
+			walk(obj.Right)
+		case unaryOpAST:
+			walk(obj.Expr)
+		case typeCastAST:
+			walk(obj.Expr)
+		case funcAppAST:
+			walkAll(obj.Expressions)
+		case aggregateInputSorter:
+			walkAll(obj.Expressions)
+		case arrayAST:
+			walkAll(obj.Expressions)
+		case mapAST:
+			for _, pair := range obj.Entries {
+				walk(pair.Value)
+			}
+		case caseAST:
+			walk(obj.Reference)
+			for _, pair := range obj.Checks {
+				walk(pair.When)
+				walk(pair.Then)
+			}
+			walk(obj.Default)
+		}
+	}
+	if e != nil {
+		walk(e)
+	}
+	return fs
+}
+
+// windowFunc computes values of a window function for all rows in the
+// current window.
+type windowFunc struct {
+	key  string
+	name string
+	// f is the aggregate function. It's nil for windowOnlyFuncs.
+	f        udf.UDF
+	ctx      *core.Context
+	args     []Evaluator
+	aggParam []bool
+
+	partition  []Evaluator
+	ordering   []Evaluator
+	ascending  []bool
+	nullsFirst []bool
+}
+
+func newWindowFunc(w windowFuncAST, reg udf.FunctionRegistry) (*windowFunc, error) {
+	toEvaluators := func(es []FlatExpression) ([]Evaluator, error) {
+		evals := make([]Evaluator, len(es))
+		for i, e := range es {
+			eval, err := ExpressionToEvaluator(e, reg)
+			if err != nil {
+				return nil, err
+			}
+			evals[i] = eval
+		}
+		return evals, nil
+	}
+
+	wf := &windowFunc{
+		key:        w.key(),
+		name:       string(w.Function),
+		ctx:        reg.Context(),
+		aggParam:   make([]bool, len(w.Expressions)),
+		ascending:  make([]bool, len(w.OrderBy)),
+		nullsFirst: make([]bool, len(w.OrderBy)),
+	}
+	if _, ok := windowOnlyFuncs[wf.name]; !ok {
+		f, err := reg.Lookup(wf.name, len(w.Expressions))
+		if err != nil {
+			return nil, err
+		}
+		wf.f = f
+		for i := range w.Expressions {
+			wf.aggParam[i] = f.IsAggregationParameter(i)
+		}
+	}
+	var err error
+	if wf.args, err = toEvaluators(w.Expressions); err != nil {
+		return nil, err
+	}
+	if wf.partition, err = toEvaluators(w.PartitionBy); err != nil {
+		return nil, err
+	}
+	ordering := make([]FlatExpression, len(w.OrderBy))
+	for i, e := range w.OrderBy {
+		ordering[i] = e.Expr
+		wf.ascending[i] = e.Ascending
+		wf.nullsFirst[i] = e.NullsFirst
+	}
+	if wf.ordering, err = toEvaluators(ordering); err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
+
+// compute returns values of the window function for the given rows. The
+// i-th value corresponds to the i-th row.
+func (wf *windowFunc) compute(rows []data.Map) ([]data.Value, error) {
+	evalAll := func(e Evaluator) (data.Array, error) {
+		vs := make(data.Array, len(rows))
+		for i, r := range rows {
+			v, err := e.Eval(r)
+			if err != nil {
+				return nil, err
+			}
+			vs[i] = v
+		}
+		return vs, nil
+	}
+
+	// split rows into partitions
+	partitions, err := wf.partitionRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// sort rows in each partition
+	ordering := make([]sortArray, len(wf.ordering))
+	for i, e := range wf.ordering {
+		vs, err := evalAll(e)
+		if err != nil {
+			return nil, err
+		}
+		ordering[i] = sortArray{vs, wf.ascending[i], wf.nullsFirst[i]}
+	}
+	for _, p := range partitions {
+		sort.Stable(&indexSlice{p, ordering})
+	}
+	peers := func(i, j int) bool {
+		for _, o := range ordering {
+			if !data.Equal(o.values[i], o.values[j]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// values of arguments used over the frame
+	var argValues []data.Array
+	if wf.f != nil || wf.name == "lag" || wf.name == "lead" {
+		argValues = make([]data.Array, len(wf.args))
+		for i, e := range wf.args {
+			if wf.f != nil && !wf.aggParam[i] {
+				continue
+			}
+			vs, err := evalAll(e)
+			if err != nil {
+				return nil, err
+			}
+			argValues[i] = vs
+			if wf.f == nil {
+				// only the first argument of lag/lead is read from
+				// other rows
+				break
+			}
+		}
+	}
+
+	results := make([]data.Value, len(rows))
+	for _, p := range partitions {
+		rank, denseRank, frameEnd := 0, 0, 0
+		for pos, idx := range p {
+			if pos == frameEnd {
+				// the frame ends with the last peer of the current row
+				rank = pos + 1
+				denseRank++
+				frameEnd++
+				for frameEnd < len(p) && peers(p[frameEnd], idx) {
+					frameEnd++
+				}
+			}
+
+			var v data.Value
+			switch {
+			case wf.f != nil:
+				v, err = wf.callAggregate(rows[idx], p[:frameEnd], argValues)
+			case wf.name == "row_number":
+				v = data.Int(pos + 1)
+			case wf.name == "rank":
+				v = data.Int(rank)
+			case wf.name == "dense_rank":
+				v = data.Int(denseRank)
+			case wf.name == "lag":
+				v, err = wf.offsetValue(rows[idx], p, pos, -1, argValues[0])
+			case wf.name == "lead":
+				v, err = wf.offsetValue(rows[idx], p, pos, 1, argValues[0])
+			}
+			if err != nil {
+				return nil, err
+			}
+			results[idx] = v
+		}
+	}
+	return results, nil
+}
+
+// partitionRows returns indexes of rows grouped by values of PARTITION BY.
+// Partitions are in the order of their first rows.
+func (wf *windowFunc) partitionRows(rows []data.Map) ([][]int, error) {
+	if len(wf.partition) == 0 {
+		p := make([]int, len(rows))
+		for i := range p {
+			p[i] = i
+		}
+		return [][]int{p}, nil
+	}
+
+	var (
+		partitions [][]int
+		keys       []data.Array
+	)
+	byHash := map[data.HashValue][]int{}
+	for i, r := range rows {
+		key := make(data.Array, len(wf.partition))
+		for j, e := range wf.partition {
+			v, err := e.Eval(r)
+			if err != nil {
+				return nil, err
+			}
+			key[j] = v
+		}
+		h := data.Hash(key)
+		found := false
+		for _, pi := range byHash[h] {
+			if data.Equal(keys[pi], key) {
+				partitions[pi] = append(partitions[pi], i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			byHash[h] = append(byHash[h], len(partitions))
+			partitions = append(partitions, []int{i})
+			keys = append(keys, key)
+		}
+	}
+	return partitions, nil
+}
+
+// callAggregate calls the aggregate function with values of aggregation
+// parameters of rows in the frame. Other parameters are evaluated on the
+// current row.
+func (wf *windowFunc) callAggregate(row data.Map, frame []int, argValues []data.Array) (data.Value, error) {
+	args := make([]data.Value, len(wf.args))
+	for i, e := range wf.args {
+		if !wf.aggParam[i] {
+			v, err := e.Eval(row)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+			continue
+		}
+		vs := make(data.Array, len(frame))
+		for j, idx := range frame {
+			vs[j] = argValues[i][idx]
+		}
+		args[i] = vs
+	}
+	return wf.f.Call(wf.ctx, args...)
+}
+
+// offsetValue returns the value of the row which is n rows before (dir is
+// -1) or after (dir is 1) the current row in the partition. n is given by
+// the second argument and defaults to 1. When there's no such row, the
+// third argument evaluated on the current row or NULL is returned.
+func (wf *windowFunc) offsetValue(row data.Map, p []int, pos, dir int, values data.Array) (data.Value, error) {
+	n := int64(1)
+	if len(wf.args) > 1 {
+		v, err := wf.args[1].Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		n, err = data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("the offset of %s must be an integer: %v", wf.name, err)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("the offset of %s must not be negative: %v", wf.name, n)
+		}
+	}
+	target := int64(pos) + int64(dir)*n
+	if target >= 0 && target < int64(len(p)) {
+		return values[p[target]], nil
+	}
+	if len(wf.args) > 2 {
+		return wf.args[2].Eval(row)
+	}
+	return data.Null{}, nil
+}
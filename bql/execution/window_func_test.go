@@ -0,0 +1,141 @@
+package execution
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"testing"
+)
+
+// sortByInt sorts results by their "int" values because the order of
+// results returned by Process is undefined.
+func sortByInt(out []data.Map) {
+	sort.Sort(byInt(out))
+}
+
+type byInt []data.Map
+
+func (b byInt) Len() int           { return len(b) }
+func (b byInt) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byInt) Less(i, j int) bool { return data.Less(b[i]["int"], b[j]["int"]) }
+
+func TestWindowFunctions(t *testing.T) {
+	Convey("Given a SELECT clause with window functions", t, func() {
+		tuples := getTuples(4)
+		s := `CREATE STREAM box AS SELECT RSTREAM int,
+			row_number() OVER (PARTITION BY int % 2 ORDER BY int DESC) AS rn,
+			lag(int) OVER (ORDER BY int) AS prev,
+			lead(int, 2, 0) OVER (ORDER BY int) AS next2,
+			sum(int) OVER (ORDER BY int) AS running,
+			count(*) OVER () AS cnt
+			FROM src [RANGE 4 TUPLES]`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out []data.Map
+			for _, inTup := range tuples {
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+			sortByInt(out)
+
+			Convey("Then values should be computed over the window", func() {
+				So(out, ShouldResemble, []data.Map{
+					{"int": data.Int(1), "rn": data.Int(2), "prev": data.Null{},
+						"next2": data.Int(3), "running": data.Int(1), "cnt": data.Int(4)},
+					{"int": data.Int(2), "rn": data.Int(2), "prev": data.Int(1),
+						"next2": data.Int(4), "running": data.Int(3), "cnt": data.Int(4)},
+					{"int": data.Int(3), "rn": data.Int(1), "prev": data.Int(2),
+						"next2": data.Int(0), "running": data.Int(6), "cnt": data.Int(4)},
+					{"int": data.Int(4), "rn": data.Int(1), "prev": data.Int(3),
+						"next2": data.Int(0), "running": data.Int(10), "cnt": data.Int(4)},
+				})
+			})
+		})
+	})
+
+	Convey("Given a SELECT clause with window functions ordered by values with ties", t, func() {
+		tuples := getTuples(4)
+		s := `CREATE STREAM box AS SELECT RSTREAM int,
+			rank() OVER (ORDER BY int / 2) AS r,
+			dense_rank() OVER (ORDER BY int / 2) AS dr,
+			sum(int) OVER (ORDER BY int / 2) AS running
+			FROM src [RANGE 4 TUPLES]`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var out []data.Map
+			for _, inTup := range tuples {
+				out, err = plan.Process(inTup)
+				So(err, ShouldBeNil)
+			}
+			sortByInt(out)
+
+			Convey("Then peers should have the same values", func() {
+				So(out, ShouldResemble, []data.Map{
+					{"int": data.Int(1), "r": data.Int(1), "dr": data.Int(1), "running": data.Int(1)},
+					{"int": data.Int(2), "r": data.Int(2), "dr": data.Int(2), "running": data.Int(6)},
+					{"int": data.Int(3), "r": data.Int(2), "dr": data.Int(2), "running": data.Int(6)},
+					{"int": data.Int(4), "r": data.Int(4), "dr": data.Int(3), "running": data.Int(10)},
+				})
+			})
+		})
+	})
+
+	Convey("Given a SELECT clause with a window function and ISTREAM", t, func() {
+		tuples := getTuples(3)
+		s := `CREATE STREAM box AS SELECT ISTREAM int,
+			row_number() OVER (ORDER BY int DESC) AS rn FROM src [RANGE 2 TUPLES]`
+		plan, err := createDefaultSelectPlan(s, t)
+		So(err, ShouldBeNil)
+
+		Convey("When feeding it with tuples", func() {
+			var outs [][]data.Map
+			for _, inTup := range tuples {
+				out, err := plan.Process(inTup)
+				So(err, ShouldBeNil)
+				sortByInt(out)
+				outs = append(outs, out)
+			}
+
+			Convey("Then rows whose values changed should be emitted again", func() {
+				So(outs, ShouldResemble, [][]data.Map{
+					{{"int": data.Int(1), "rn": data.Int(1)}},
+					{{"int": data.Int(1), "rn": data.Int(2)}, {"int": data.Int(2), "rn": data.Int(1)}},
+					{{"int": data.Int(2), "rn": data.Int(2)}, {"int": data.Int(3), "rn": data.Int(1)}},
+				})
+			})
+		})
+	})
+
+	Convey("Given invalid statements with window functions", t, func() {
+		stmts := map[string]string{
+			"in WHERE": `SELECT RSTREAM int FROM src [RANGE 2 TUPLES]
+				WHERE row_number() OVER () = 1`,
+			"with GROUP BY": `SELECT RSTREAM int, row_number() OVER ()
+				FROM src [RANGE 2 TUPLES] GROUP BY int`,
+			"with aggregates": `SELECT RSTREAM count(*), row_number() OVER ()
+				FROM src [RANGE 2 TUPLES]`,
+			"with a non-aggregate function": `SELECT RSTREAM abs(int) OVER ()
+				FROM src [RANGE 2 TUPLES]`,
+			"nested": `SELECT RSTREAM sum(row_number() OVER ()) OVER ()
+				FROM src [RANGE 2 TUPLES]`,
+			"with a wrong number of arguments": `SELECT RSTREAM row_number(int) OVER ()
+				FROM src [RANGE 2 TUPLES]`,
+			"with ORDER BY in arguments": `SELECT RSTREAM array_agg(int ORDER BY int) OVER ()
+				FROM src [RANGE 2 TUPLES]`,
+		}
+		for name, s := range stmts {
+			s := "CREATE STREAM box AS " + s
+			Convey("When creating a plan for a statement "+name, func() {
+				_, err := createDefaultSelectPlan(s, t)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
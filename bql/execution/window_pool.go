@@ -0,0 +1,227 @@
+package execution
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"reflect"
+	"sync"
+)
+
+// WindowBufferPool shares tuples kept in windows between execution plans of
+// statements reading the same stream with identical windows and aliases,
+// e.g. `FROM s [RANGE 10 SECONDS]`. Without the pool, each plan wraps every
+// tuple it receives in a new core.Tuple whose Data is a map keyed by the
+// alias, and reports its size to core.ResourceController separately, although
+// all plans refer to the same Data. With the pool, the wrapped tuple is
+// created and accounted once in the shared buffer and all plans keep it in
+// their windows as it is. A tuple is released when all plans have removed it
+// from their windows.
+//
+// Each plan still keeps its own list of tuples in its window and rows
+// derived from them because windows of plans advance independently, e.g.
+// when their WHERE clauses or input sampling differ. Tuples in a shared
+// buffer must not be modified by plans.
+//
+// Shared buffers are reference-counted by plans and removed from the pool
+// when the last plan releases its resources. A tuple evicted from a shared
+// buffer because the topology ran out of its resources is evicted from all
+// plans sharing the buffer.
+//
+// Buffers of UDSFs aren't shared because each of them is only read by its
+// statement. Plans spilling windows to disk don't share buffers either.
+//
+// All methods of WindowBufferPool are thread-safe.
+type WindowBufferPool struct {
+	m       sync.Mutex
+	windows map[windowKey]*sharedWindow
+}
+
+// windowKey identifies a shared buffer by the input name, the alias, and the
+// window specification.
+type windowKey struct {
+	input      string
+	alias      string
+	windowSize float64
+	windowType parser.IntervalUnit
+}
+
+// NewWindowBufferPool creates a new WindowBufferPool having no buffer.
+func NewWindowBufferPool() *WindowBufferPool {
+	return &WindowBufferPool{
+		windows: map[windowKey]*sharedWindow{},
+	}
+}
+
+// NumWindows returns the number of shared buffers in the pool.
+func (p *WindowBufferPool) NumWindows() int {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return len(p.windows)
+}
+
+// attach returns the shared buffer of the key. A buffer is created when the
+// pool doesn't have it yet.
+func (p *WindowBufferPool) attach(key windowKey) *sharedWindow {
+	p.m.Lock()
+	defer p.m.Unlock()
+	w, ok := p.windows[key]
+	if !ok {
+		w = &sharedWindow{
+			pool:    p,
+			key:     key,
+			entries: map[uintptr]*sharedTuple{},
+		}
+		p.windows[key] = w
+	}
+	w.m.Lock()
+	w.refs++
+	w.m.Unlock()
+	return w
+}
+
+// detach releases a reference to the shared buffer. The buffer is removed
+// from the pool when it isn't referenced anymore.
+func (p *WindowBufferPool) detach(w *sharedWindow) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.refs--
+	if w.refs == 0 && p.windows[w.key] == w {
+		delete(p.windows, w.key)
+	}
+}
+
+// sharedWindow is a window buffer shared by plans. It only keeps tuples and
+// their resources. Which tuples are in the window of each plan is still
+// managed by the plan.
+type sharedWindow struct {
+	pool *WindowBufferPool
+	key  windowKey
+
+	m sync.Mutex
+	// refs is the number of plan buffers attached to this buffer.
+	refs int
+	// entries holds tuples keyed by identities of their data so that a
+	// tuple delivered to multiple plans can be found.
+	entries map[uintptr]*sharedTuple
+	// numEvicted is the total number of tuples evicted due to resource
+	// limits. Plans check it to find tuples evicted by other plans.
+	numEvicted int64
+}
+
+// sharedTuple is a tuple kept in a shared buffer.
+type sharedTuple struct {
+	w  *sharedWindow
+	id uintptr
+
+	// tuple and size are immutable. tuple has Data wrapped in a map keyed
+	// by the alias of the input, which is what plans keep in their windows.
+	tuple *core.Tuple
+	size  int64
+
+	// Following fields are protected by w.m.
+	refs     int
+	acquired bool
+	evicted  bool
+}
+
+// dataIdentity returns the identity of the data of the tuple. Tuples written
+// to multiple destinations share their data, so deliveries of the same tuple
+// to different plans have the same identity. It returns 0 when the tuple
+// doesn't have data.
+func dataIdentity(t *core.Tuple) uintptr {
+	if t.Data == nil {
+		return 0
+	}
+	return reflect.ValueOf(t.Data).Pointer()
+}
+
+// acquire returns the shared tuple of t. A new one having Data of t wrapped
+// in a map keyed by the alias is created and reported to the
+// ResourceController when the buffer doesn't have t yet.
+func (w *sharedWindow) acquire(t *core.Tuple, resources *core.ResourceController) *sharedTuple {
+	id := dataIdentity(t)
+	w.m.Lock()
+	defer w.m.Unlock()
+	if st, ok := w.entries[id]; ok && id != 0 {
+		st.refs++
+		return st
+	}
+
+	wrapped := t.ShallowCopy()
+	wrapped.Data = data.Map{w.key.alias: t.Data}
+	st := &sharedTuple{
+		w:     w,
+		id:    id,
+		tuple: wrapped,
+		size:  data.ApproxSize(wrapped.Data),
+		refs:  1,
+	}
+	if resources != nil && resources.Enabled() {
+		resources.Acquire(1, st.size)
+		st.acquired = true
+	}
+	if id != 0 {
+		w.entries[id] = st
+	}
+	return st
+}
+
+// release releases a reference to the shared tuple. The tuple is removed from
+// the buffer when no plan refers to it.
+func (w *sharedWindow) release(st *sharedTuple, resources *core.ResourceController) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	st.refs--
+	if st.refs > 0 {
+		return
+	}
+	w.removeWithoutLock(st, resources)
+}
+
+// evict removes the shared tuple from the buffer for all plans because the
+// topology ran out of its resources. Plans still referring to the tuple
+// remove it by themselves after finding it with isEvicted.
+func (w *sharedWindow) evict(st *sharedTuple, resources *core.ResourceController) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if st.evicted {
+		return
+	}
+	st.evicted = true
+	w.numEvicted++
+	w.removeWithoutLock(st, resources)
+}
+
+func (w *sharedWindow) removeWithoutLock(st *sharedTuple, resources *core.ResourceController) {
+	if w.entries[st.id] == st {
+		delete(w.entries, st.id)
+	}
+	if st.acquired {
+		resources.Release(1, st.size)
+		st.acquired = false
+	}
+}
+
+// isEvicted returns true when the shared tuple has been evicted.
+func (w *sharedWindow) isEvicted(st *sharedTuple) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return st.evicted
+}
+
+// evictions returns the total number of tuples evicted from the buffer.
+func (w *sharedWindow) evictions() int64 {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.numEvicted
+}
+
+// numRefs returns the number of plan buffers attached to the buffer.
+func (w *sharedWindow) numRefs() int {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.refs
+}
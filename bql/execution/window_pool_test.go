@@ -0,0 +1,137 @@
+package execution
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestWindowBufferPool(t *testing.T) {
+	Convey("Given plans reading the same stream with a window buffer pool", t, func() {
+		pool := NewWindowBufferPool()
+		createPlan := func(s string) PhysicalPlan {
+			plan, err := createDefaultSelectPlan(s, t)
+			So(err, ShouldBeNil)
+			p, ok := plan.(SharedWindowPlan)
+			So(ok, ShouldBeTrue)
+			So(p.SetWindowBufferPool(pool), ShouldBeNil)
+			return plan
+		}
+		p1 := createPlan(`CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 3 TUPLES]`)
+		p2 := createPlan(`CREATE STREAM box AS SELECT RSTREAM int * 2 AS x FROM src [RANGE 3 TUPLES]`)
+		plans := []PhysicalPlan{p1, p2}
+
+		// feed writes each tuple to all plans like a pipe having multiple
+		// destinations.
+		feed := func(tuples []*core.Tuple) [][]data.Map {
+			outs := make([][]data.Map, len(plans))
+			for _, t := range tuples {
+				t.Flags.Set(core.TFShared)
+				for i, p := range plans {
+					out, err := p.Process(t.ShallowCopy())
+					So(err, ShouldBeNil)
+					outs[i] = out
+				}
+			}
+			return outs
+		}
+
+		Convey("Then plans having identical windows should share a buffer", func() {
+			So(pool.NumWindows(), ShouldEqual, 1)
+
+			Convey("And a plan having a different window is added", func() {
+				p3 := createPlan(`CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 2 TUPLES]`)
+
+				Convey("Then it should have its own buffer", func() {
+					So(pool.NumWindows(), ShouldEqual, 2)
+					p3.(ResourceLimitedPlan).ReleaseResources()
+					So(pool.NumWindows(), ShouldEqual, 1)
+				})
+			})
+		})
+
+		Convey("When feeding them with tuples", func() {
+			r := core.NewResourceController(&core.ResourceLimits{MaxTuples: 100})
+			for _, p := range plans {
+				p.(ResourceLimitedPlan).SetResourceController(r)
+			}
+			outs := feed(getTuples(4))
+
+			Convey("Then each plan should compute results from its window", func() {
+				So(outs[0], ShouldResemble, []data.Map{
+					{"int": data.Int(2)}, {"int": data.Int(3)}, {"int": data.Int(4)},
+				})
+				So(outs[1], ShouldResemble, []data.Map{
+					{"x": data.Int(4)}, {"x": data.Int(6)}, {"x": data.Int(8)},
+				})
+			})
+
+			Convey("Then plans should keep the same tuples in their windows", func() {
+				w1 := p1.(*defaultSelectExecutionPlan).buffers["src"].tuples
+				w2 := p2.(*defaultSelectExecutionPlan).buffers["src"].tuples
+				So(w1.Len(), ShouldEqual, 3)
+				So(w2.Len(), ShouldEqual, 3)
+				for e1, e2 := w1.Front(), w2.Front(); e1 != nil; e1, e2 = e1.Next(), e2.Next() {
+					t1 := e1.Value.(*tupleWithDerivedInputRows).tuple
+					t2 := e2.Value.(*tupleWithDerivedInputRows).tuple
+					So(t1, ShouldEqual, t2)
+				}
+			})
+
+			Convey("Then tuples should be accounted only once", func() {
+				n, _ := r.Usage()
+				So(n, ShouldEqual, 3)
+			})
+
+			Convey("Then window states should report the sharing", func() {
+				st := p1.(WindowStatePlan).WindowStates()["src"]
+				So(st.NumTuples, ShouldEqual, 3)
+				So(st.SharedBy, ShouldEqual, 2)
+				So(st.Map()["shared_by"], ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then the shared buffer should be kept until all plans release it", func() {
+				p1.(ResourceLimitedPlan).ReleaseResources()
+				So(pool.NumWindows(), ShouldEqual, 1)
+				n, _ := r.Usage()
+				So(n, ShouldEqual, 3)
+
+				p2.(ResourceLimitedPlan).ReleaseResources()
+				So(pool.NumWindows(), ShouldEqual, 0)
+				n, m := r.Usage()
+				So(n, ShouldEqual, 0)
+				So(m, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When feeding them with more tuples than the budget", func() {
+			r := core.NewResourceController(&core.ResourceLimits{MaxTuples: 2})
+			for _, p := range plans {
+				p.(ResourceLimitedPlan).SetResourceController(r)
+			}
+			outs := feed(getTuples(4))
+
+			Convey("Then tuples evicted by a plan should be evicted from all plans", func() {
+				So(outs[0], ShouldResemble, []data.Map{
+					{"int": data.Int(3)}, {"int": data.Int(4)},
+				})
+				So(outs[1], ShouldResemble, []data.Map{
+					{"x": data.Int(6)}, {"x": data.Int(8)},
+				})
+				So(p1.(ResourceLimitedPlan).NumEvictedTuples(), ShouldEqual, 2)
+				So(p2.(ResourceLimitedPlan).NumEvictedTuples(), ShouldEqual, 2)
+				n, _ := r.Usage()
+				So(n, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When enabling spilling on a plan sharing windows", func() {
+			err := p1.(SpillablePlan).EnableSpill(&SpillConfig{Dir: "."})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,241 @@
+package execution
+
+import (
+	"container/list"
+	"errors"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	// DefaultSpillMemoryTuples is the default number of the most recent
+	// tuples kept in memory for each window when spilling is enabled.
+	DefaultSpillMemoryTuples = 1024
+
+	// DefaultSpillInitialSize is the default initial size of a spill file.
+	DefaultSpillInitialSize = 1 << 20
+)
+
+// SpillConfig has configuration parameters to spill old contents of large
+// windows to disk. When spilling is enabled, only the most recent tuples of
+// each window are kept in memory. Older tuples are written to a memory-mapped
+// file and transparently reloaded when the window is recomputed.
+type SpillConfig struct {
+	// Dir is the directory where spill files are created. When it's empty,
+	// the default directory for temporary files is used.
+	Dir string
+
+	// MemoryTuples is the number of the most recent tuples of each window
+	// kept in memory. When it's 0 or less, DefaultSpillMemoryTuples is used.
+	MemoryTuples int
+
+	// InitialSize is the initial size of a spill file in bytes. The file
+	// grows when it gets full. When it's 0 or less, DefaultSpillInitialSize
+	// is used.
+	InitialSize int64
+}
+
+// spillStorage is a region of bytes backed by a file.
+type spillStorage interface {
+	readAt(p []byte, off int64) error
+	writeAt(p []byte, off int64) error
+
+	// resize changes the size of the region. Contents in the region don't
+	// have to be preserved.
+	resize(size int64) error
+
+	// close closes the storage and removes the file.
+	close() error
+}
+
+// spillRecord is a reference to a record written in spillRing.
+type spillRecord struct {
+	off   int64
+	size  int64
+	freed bool
+	elem  *list.Element
+}
+
+// spillRing is an on-disk ring buffer which stores serialized contents of
+// windows. Records are written in FIFO order and the space of a record is
+// reused after it and all records written before it are freed, which fits
+// well with how tuples are removed from windows.
+type spillRing struct {
+	s       spillStorage
+	cap     int64
+	head    int64
+	records *list.List
+
+	// dict has names of fields in spilled rows. Names are never removed
+	// from it because records may still refer to them after they're freed.
+	dict *data.FieldDictionary
+}
+
+func newSpillRing(dir string, size int64) (*spillRing, error) {
+	f, err := ioutil.TempFile(dir, "sensorbee-window-spill-")
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSpillStorage(f, size)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &spillRing{
+		s:       s,
+		cap:     size,
+		records: list.New(),
+		dict:    data.NewFieldDictionary(),
+	}, nil
+}
+
+// write writes a record to the ring. The ring grows when it doesn't have
+// enough space for the record.
+func (r *spillRing) write(p []byte) (*spillRecord, error) {
+	if len(p) == 0 {
+		return nil, errors.New("cannot spill an empty record")
+	}
+	n := int64(len(p))
+	off, ok := r.alloc(n)
+	if !ok {
+		if err := r.grow(n); err != nil {
+			return nil, err
+		}
+		off, _ = r.alloc(n) // this never fails after growing
+	}
+	if err := r.s.writeAt(p, off); err != nil {
+		return nil, err
+	}
+	rec := &spillRecord{
+		off:  off,
+		size: n,
+	}
+	rec.elem = r.records.PushBack(rec)
+	r.head = off + n
+	return rec, nil
+}
+
+// alloc returns the offset where a new record having n bytes can be written.
+func (r *spillRing) alloc(n int64) (int64, bool) {
+	if r.records.Len() == 0 {
+		return 0, n <= r.cap
+	}
+
+	tail := r.records.Front().Value.(*spillRecord).off
+	if r.head > tail {
+		// live records are in [tail, head)
+		if r.head+n <= r.cap {
+			return r.head, true
+		}
+		// wrap around
+		return 0, n < tail
+	}
+	// live records are in [tail, cap) and [0, head)
+	return r.head, r.head+n < tail
+}
+
+// grow extends the ring so that it can have a new record having n bytes.
+// Live records are compacted to the beginning of the ring.
+func (r *spillRing) grow(n int64) error {
+	var (
+		recs []*spillRecord
+		bufs [][]byte
+		used int64
+	)
+	for e := r.records.Front(); e != nil; e = e.Next() {
+		rec := e.Value.(*spillRecord)
+		if rec.freed {
+			continue
+		}
+		b, err := r.read(rec)
+		if err != nil {
+			return err
+		}
+		recs = append(recs, rec)
+		bufs = append(bufs, b)
+		used += rec.size
+	}
+
+	newCap := r.cap * 2
+	for newCap < used+n {
+		newCap *= 2
+	}
+	if err := r.s.resize(newCap); err != nil {
+		return err
+	}
+	r.cap = newCap
+
+	r.records.Init()
+	r.head = 0
+	for i, rec := range recs {
+		if err := r.s.writeAt(bufs[i], r.head); err != nil {
+			return err
+		}
+		rec.off = r.head
+		rec.elem = r.records.PushBack(rec)
+		r.head += rec.size
+	}
+	return nil
+}
+
+func (r *spillRing) read(rec *spillRecord) ([]byte, error) {
+	b := make([]byte, rec.size)
+	if err := r.s.readAt(b, rec.off); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// free releases the space of the record.
+func (r *spillRing) free(rec *spillRecord) {
+	rec.freed = true
+	for e := r.records.Front(); e != nil; e = r.records.Front() {
+		if !e.Value.(*spillRecord).freed {
+			break
+		}
+		r.records.Remove(e)
+	}
+	if r.records.Len() == 0 {
+		r.head = 0
+	}
+}
+
+func (r *spillRing) close() error {
+	r.records.Init()
+	return r.s.close()
+}
+
+// encodeSpilledRow serializes an input row and its cached result with the
+// binary format of the data package. The cached result is written after the
+// input row only when it exists.
+func encodeSpilledRow(input data.Map, cache data.Value, dict *data.FieldDictionary) []byte {
+	b := data.AppendBinary(nil, input, dict)
+	if cache != nil {
+		b = data.AppendBinary(b, cache, dict)
+	}
+	return b
+}
+
+// decodeSpilledRow deserializes an input row and its cached result encoded
+// by encodeSpilledRow.
+func decodeSpilledRow(b []byte, dict *data.FieldDictionary) (data.Map, data.Value, error) {
+	in, n, err := data.DecodeBinary(b, dict)
+	if err != nil {
+		return nil, nil, err
+	}
+	input, err := data.AsMap(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if n == len(b) {
+		return input, nil, nil
+	}
+	cache, err := data.UnmarshalBinary(b[n:], dict)
+	if err != nil {
+		return nil, nil, err
+	}
+	return input, cache, nil
+}
@@ -0,0 +1,46 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package execution
+
+import (
+	"os"
+)
+
+// fileSpillStorage is a spillStorage directly reading and writing a file. It's
+// used on platforms where memory-mapped files aren't supported.
+type fileSpillStorage struct {
+	f *os.File
+}
+
+func newSpillStorage(f *os.File, size int64) (spillStorage, error) {
+	s := &fileSpillStorage{
+		f: f,
+	}
+	if err := s.resize(size); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSpillStorage) readAt(p []byte, off int64) error {
+	_, err := s.f.ReadAt(p, off)
+	return err
+}
+
+func (s *fileSpillStorage) writeAt(p []byte, off int64) error {
+	_, err := s.f.WriteAt(p, off)
+	return err
+}
+
+func (s *fileSpillStorage) resize(size int64) error {
+	return s.f.Truncate(size)
+}
+
+func (s *fileSpillStorage) close() error {
+	err := s.f.Close()
+	if e := os.Remove(s.f.Name()); err == nil {
+		err = e
+	}
+	return err
+}
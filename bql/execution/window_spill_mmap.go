@@ -0,0 +1,72 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package execution
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapSpillStorage is a spillStorage using a memory-mapped file.
+type mmapSpillStorage struct {
+	f    *os.File
+	data []byte
+}
+
+func newSpillStorage(f *os.File, size int64) (spillStorage, error) {
+	s := &mmapSpillStorage{
+		f: f,
+	}
+	if err := s.resize(size); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *mmapSpillStorage) readAt(p []byte, off int64) error {
+	copy(p, s.data[off:off+int64(len(p))])
+	return nil
+}
+
+func (s *mmapSpillStorage) writeAt(p []byte, off int64) error {
+	copy(s.data[off:off+int64(len(p))], p)
+	return nil
+}
+
+func (s *mmapSpillStorage) resize(size int64) error {
+	if err := s.unmap(); err != nil {
+		return err
+	}
+	if err := s.f.Truncate(size); err != nil {
+		return err
+	}
+	d, err := syscall.Mmap(int(s.f.Fd()), 0, int(size), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+	s.data = d
+	return nil
+}
+
+func (s *mmapSpillStorage) unmap() error {
+	if s.data == nil {
+		return nil
+	}
+	if err := syscall.Munmap(s.data); err != nil {
+		return err
+	}
+	s.data = nil
+	return nil
+}
+
+func (s *mmapSpillStorage) close() error {
+	err := s.unmap()
+	if e := s.f.Close(); err == nil {
+		err = e
+	}
+	if e := os.Remove(s.f.Name()); err == nil {
+		err = e
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package execution
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSpillRing(t *testing.T) {
+	Convey("Given a spill ring", t, func() {
+		dir, err := ioutil.TempDir("", "sensorbee-spill-test")
+		So(err, ShouldBeNil)
+		r, err := newSpillRing(dir, 16)
+		So(err, ShouldBeNil)
+		Reset(func() {
+			r.close()
+			os.RemoveAll(dir)
+		})
+
+		Convey("When writing records", func() {
+			r1, err := r.write([]byte("abcdef"))
+			So(err, ShouldBeNil)
+			r2, err := r.write([]byte("ghijkl"))
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be read", func() {
+				b, err := r.read(r1)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "abcdef")
+				b, err = r.read(r2)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "ghijkl")
+			})
+
+			Convey("Then the space should be reused after freeing the oldest one", func() {
+				r.free(r1)
+				r3, err := r.write([]byte("mnopq"))
+				So(err, ShouldBeNil)
+				So(r3.off, ShouldEqual, 0)
+				So(r.cap, ShouldEqual, 16)
+
+				b, err := r.read(r2)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "ghijkl")
+				b, err = r.read(r3)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "mnopq")
+			})
+
+			Convey("Then the space shouldn't be reused when the oldest one is alive", func() {
+				r.free(r2)
+				r3, err := r.write([]byte("mnopq"))
+				So(err, ShouldBeNil)
+				So(r3.off, ShouldNotEqual, 0)
+				So(r.cap, ShouldBeGreaterThan, 16)
+			})
+
+			Convey("Then the ring should grow when it's full", func() {
+				r3, err := r.write([]byte("mnopqrstu"))
+				So(err, ShouldBeNil)
+				So(r.cap, ShouldBeGreaterThan, 16)
+
+				for i, c := range []struct {
+					rec *spillRecord
+					s   string
+				}{{r1, "abcdef"}, {r2, "ghijkl"}, {r3, "mnopqrstu"}} {
+					b, err := r.read(c.rec)
+					So(err, ShouldBeNil)
+					So(string(b), ShouldEqual, c.s)
+					So(c.rec.off, ShouldEqual, i*6)
+				}
+			})
+		})
+	})
+}
+
+func TestSpilledRowEncoding(t *testing.T) {
+	Convey("Given a row having values of all types", t, func() {
+		now := time.Date(2015, time.April, 10, 10, 23, 0, 123, time.UTC)
+		input := data.Map{
+			"s": data.Map{
+				"null":      data.Null{},
+				"bool":      data.True,
+				"int":       data.Int(-10),
+				"float":     data.Float(1.5),
+				"string":    data.String("str"),
+				"blob":      data.Blob("blob"),
+				"timestamp": data.Timestamp(now),
+				"array":     data.Array{data.Int(1), data.String("a")},
+				"vector":    data.Vector{1, 0.5},
+			},
+		}
+		dict := data.NewFieldDictionary()
+
+		Convey("When encoding and decoding it without a cache", func() {
+			b := encodeSpilledRow(input, nil, dict)
+			in, cache, err := decodeSpilledRow(b, dict)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be the same as the original", func() {
+				So(in, ShouldResemble, input)
+				So(cache, ShouldBeNil)
+			})
+		})
+
+		Convey("When encoding and decoding it with a cache", func() {
+			b := encodeSpilledRow(input, data.Array{data.Null{}}, dict)
+			_, cache, err := decodeSpilledRow(b, dict)
+			So(err, ShouldBeNil)
+
+			Convey("Then the cache should also be decoded", func() {
+				So(cache, ShouldResemble, data.Array{data.Null{}})
+			})
+		})
+	})
+}
+
+func TestWindowSpill(t *testing.T) {
+	stmts := []string{
+		`CREATE STREAM box AS SELECT RSTREAM int FROM src [RANGE 5 TUPLES]`,
+		`CREATE STREAM box AS SELECT ISTREAM int FROM src [RANGE 5 TUPLES] WHERE int % 2 = 0`,
+		`CREATE STREAM box AS SELECT RSTREAM count(*) AS c, int FROM src [RANGE 3 SECONDS] GROUP BY int`,
+	}
+
+	for _, s := range stmts {
+		s := s
+		Convey(fmt.Sprintf("Given plans having windows with and without spilling: %v", s), t, func() {
+			dir, err := ioutil.TempDir("", "sensorbee-spill-test")
+			So(err, ShouldBeNil)
+			ref, err := createDefaultSelectOrGroupbyPlan(s)
+			So(err, ShouldBeNil)
+			plan, err := createDefaultSelectOrGroupbyPlan(s)
+			So(err, ShouldBeNil)
+			So(plan.(SpillablePlan).EnableSpill(&SpillConfig{
+				Dir:          dir,
+				MemoryTuples: 1,
+				InitialSize:  32,
+			}), ShouldBeNil)
+			Reset(func() {
+				plan.(ResourceLimitedPlan).ReleaseResources()
+				os.RemoveAll(dir)
+			})
+
+			Convey("When feeding them with tuples", func() {
+				Convey("Then they should emit the same results", func() {
+					for _, inTup := range getTuples(10) {
+						expected, err := ref.Process(inTup.Copy())
+						So(err, ShouldBeNil)
+						actual, err := plan.Process(inTup.Copy())
+						So(err, ShouldBeNil)
+						So(len(actual), ShouldEqual, len(expected))
+						for _, e := range expected {
+							So(actual, ShouldContain, e)
+						}
+					}
+				})
+			})
+		})
+	}
+}
+
+func createDefaultSelectOrGroupbyPlan(s string) (PhysicalPlan, error) {
+	if p, err := createDefaultSelectPlan2(s); err == nil {
+		return p, nil
+	}
+	return createGroupbyPlan2(s)
+}
@@ -0,0 +1,120 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultFFmpeg       = "ffmpeg"
+	ffmpegMaxStderrSize = 4096
+)
+
+// ffmpegProcess is an ffmpeg process writing decoded media to the standard
+// output.
+type ffmpegProcess struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr *ffmpegStderr
+
+	waitOnce sync.Once
+	waitErr  error
+}
+
+// startFFmpeg starts ffmpeg at path with args. Common arguments suppressing
+// the banner and logs other than errors are prepended to args.
+func startFFmpeg(path string, args []string) (*ffmpegProcess, error) {
+	cmd := exec.Command(path, append([]string{"-hide_banner", "-loglevel", "error", "-nostdin"}, args...)...)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	p := &ffmpegProcess{
+		cmd:    cmd,
+		stdout: out,
+		stderr: &ffmpegStderr{},
+	}
+	cmd.Stderr = p.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cannot start ffmpeg: %v", err)
+	}
+	return p, nil
+}
+
+// exitError returns an error describing why reading the standard output
+// failed with readErr. It waits for the process to exit and returns the
+// error with the output of the process when it failed.
+func (p *ffmpegProcess) exitError(readErr error) error {
+	if err := p.wait(); err != nil {
+		msg := strings.TrimSpace(string(p.stderr.b))
+		if msg == "" {
+			return fmt.Errorf("ffmpeg exited: %v", err)
+		}
+		return fmt.Errorf("ffmpeg exited: %v: %v", err, msg)
+	}
+	if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+		return errors.New("ffmpeg exited at the end of the stream")
+	}
+	return readErr
+}
+
+// wait waits for the process to exit. The standard error can be read after
+// it returns.
+func (p *ffmpegProcess) wait() error {
+	p.waitOnce.Do(func() {
+		p.waitErr = p.cmd.Wait()
+	})
+	return p.waitErr
+}
+
+// Close kills the process and waits for it to exit.
+func (p *ffmpegProcess) Close() error {
+	p.cmd.Process.Kill()
+	p.wait()
+	return nil
+}
+
+// ffmpegStderr keeps the beginning of the standard error of ffmpeg.
+type ffmpegStderr struct {
+	b []byte
+}
+
+func (e *ffmpegStderr) Write(p []byte) (int, error) {
+	if n := ffmpegMaxStderrSize - len(e.b); n > 0 {
+		if len(p) < n {
+			n = len(p)
+		}
+		e.b = append(e.b, p[:n]...)
+	}
+	return len(p), nil
+}
+
+// extractFFmpegParameters retrieves 'ffmpeg' parameter having the path of
+// ffmpeg and 'rtsp_transport' parameter, which is "tcp" or "udp". The
+// transport is "tcp" by default.
+func extractFFmpegParameters(params data.Map) (path, transport string, err error) {
+	path = defaultFFmpeg
+	if v, ok := params["ffmpeg"]; ok {
+		if path, err = data.AsString(v); err != nil {
+			return "", "", fmt.Errorf("'ffmpeg' parameter must be a string: %v", err)
+		}
+	}
+	transport = "tcp"
+	if v, ok := params["rtsp_transport"]; ok {
+		t, err := data.AsString(v)
+		if err != nil {
+			return "", "", fmt.Errorf("'rtsp_transport' parameter must be a string: %v", err)
+		}
+		switch transport = strings.ToLower(t); transport {
+		case "tcp", "udp":
+		default:
+			return "", "", fmt.Errorf("'rtsp_transport' parameter must be tcp or udp: %v", t)
+		}
+	}
+	return path, transport, nil
+}
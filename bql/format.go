@@ -0,0 +1,34 @@
+package bql
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"strings"
+)
+
+// Format returns the canonical representation of a statement returned from
+// the BQL parser. Keywords are written in upper case, each clause of a SELECT
+// statement is put on its own line, and the statement is terminated by a
+// semicolon. Statements having the same meaning are formatted to the same
+// string regardless of spaces, comments, or cases of keywords, so tools can
+// use it to diff or normalize queries.
+func Format(stmt interface{}) (string, error) {
+	return parser.Format(stmt)
+}
+
+// FormatBQL parses all statements in bql and returns them formatted by
+// Format. Statements are separated by blank lines.
+func FormatBQL(bql string) (string, error) {
+	stmts, err := parser.New().ParseStmts(bql)
+	if err != nil {
+		return "", err
+	}
+	fs := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		f, err := Format(stmt)
+		if err != nil {
+			return "", err
+		}
+		fs[i] = f
+	}
+	return strings.Join(fs, "\n\n"), nil
+}
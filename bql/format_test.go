@@ -0,0 +1,42 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFormatBQL(t *testing.T) {
+	Convey("Given BQL statements having comments and extra spaces", t, func() {
+		bql := `-- a source
+			create  source s type dummy;
+			create stream x as select istream * from s [range 1 tuples] where a>1;`
+
+		Convey("When formatting them", func() {
+			f, err := FormatBQL(bql)
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be normalized", func() {
+				So(f, ShouldEqual, `CREATE SOURCE s TYPE dummy;
+
+CREATE STREAM x AS
+  SELECT ISTREAM *
+  FROM s [RANGE 1 TUPLES]
+  WHERE a > 1;`)
+			})
+
+			Convey("Then formatting the result again should give the same result", func() {
+				f2, err := FormatBQL(f)
+				So(err, ShouldBeNil)
+				So(f2, ShouldEqual, f)
+			})
+		})
+
+		Convey("When formatting statements having a syntax error", func() {
+			_, err := FormatBQL(`CREATE SOURCE s TYPE dummy; CREATE SINK`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
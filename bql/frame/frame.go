@@ -0,0 +1,469 @@
+// Package frame decodes fixed-format binary frames such as registers of
+// Modbus devices and packets of proprietary UDP protocols into data.Map.
+// The format of frames is declared by a Layout created from parameters of
+// BQL so that frames can be decoded without writing Go:
+//
+//	CREATE SOURCE frames TYPE file WITH path="frames.bin", codec="binary",
+//	    fields=[
+//	        {"name": "id", "offset": 0, "type": "uint16"},
+//	        {"name": "temp", "offset": 2, "type": "int16", "scale": 0.1},
+//	        {"name": "alarm", "offset": 4, "type": "uint8", "bit": 7},
+//	        {"name": "power", "offset": 6, "type": "float32", "word_swap": true}
+//	    ];
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"strings"
+)
+
+// FieldType is a type of a field in a frame.
+type FieldType int
+
+const (
+	// Int8 to Uint64 are integers having the sizes. They're decoded as Int.
+	// Uint64 values larger than the maximum value of Int are errors.
+	Int8 FieldType = iota
+	Uint8
+	Int16
+	Uint16
+	Int32
+	Uint32
+	Int64
+	Uint64
+
+	// Float32 and Float64 are IEEE 754 floating point numbers decoded as
+	// Float.
+	Float32
+	Float64
+
+	// Bool is a byte decoded as Bool. It's true when the byte, or the bit
+	// given by Field.Bit, isn't 0.
+	Bool
+
+	// Bytes is a byte sequence having Field.Length bytes decoded as Blob.
+	Bytes
+
+	// String is a string having Field.Length bytes. Trailing NUL characters
+	// and spaces are removed.
+	String
+)
+
+var fieldTypes = map[string]FieldType{
+	"int8":    Int8,
+	"uint8":   Uint8,
+	"int16":   Int16,
+	"uint16":  Uint16,
+	"int32":   Int32,
+	"uint32":  Uint32,
+	"int64":   Int64,
+	"uint64":  Uint64,
+	"float32": Float32,
+	"float64": Float64,
+	"bool":    Bool,
+	"bytes":   Bytes,
+	"string":  String,
+}
+
+func (t FieldType) String() string {
+	for n, ft := range fieldTypes {
+		if ft == t {
+			return n
+		}
+	}
+	return "unknown"
+}
+
+// size returns the size of the type in bytes. It returns 0 for Bytes and
+// String.
+func (t FieldType) size() int {
+	switch t {
+	case Int8, Uint8, Bool:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float32:
+		return 4
+	case Int64, Uint64, Float64:
+		return 8
+	}
+	return 0
+}
+
+func (t FieldType) isInteger() bool {
+	return t <= Uint64
+}
+
+func (t FieldType) isSigned() bool {
+	switch t {
+	case Int8, Int16, Int32, Int64:
+		return true
+	}
+	return false
+}
+
+// Field is a field in a frame.
+type Field struct {
+	// Name is the JSON path where the decoded value is stored.
+	Name string
+
+	// Offset is the offset of the field from the head of the frame in bytes.
+	Offset int
+
+	// Type is the type of the field.
+	Type FieldType
+
+	// Length is the length of Bytes and String in bytes.
+	Length int
+
+	// LittleEndian is true when the field is in little endian. Fields are
+	// in big endian by default.
+	LittleEndian bool
+
+	// WordSwap swaps the order of 16-bit words of 32-bit and 64-bit fields,
+	// which some Modbus devices use to store values across registers.
+	WordSwap bool
+
+	// Bit and Bits extract a bitfield of an integer or Bool. Bit is the
+	// position of the least significant bit of the bitfield, which is 0
+	// for the least significant bit of the field, and Bits is its width.
+	// Bits is 0 when the whole field is used. Signed bitfields are
+	// sign-extended.
+	Bit  int
+	Bits int
+
+	// Scale and Bias convert a number to Scale*value+Bias, which is always
+	// Float. They aren't applied when Scale is 1 and Bias is 0.
+	Scale float64
+	Bias  float64
+
+	path data.Path
+}
+
+// Layout is the layout of fields in a frame.
+type Layout struct {
+	// Fields are fields in the frame.
+	Fields []*Field
+
+	// Size is the size of the frame in bytes. It's at least the end of the
+	// last field.
+	Size int
+}
+
+// NewLayout creates a Layout from parameters. It accepts following
+// parameters:
+//
+//	fields: an array of maps describing fields (required)
+//	endian: "big" or "little", the default endian of fields (default: "big")
+//	frame_size: the size of the frame when it has padding after the last
+//	    field
+//
+// A field has following parameters:
+//
+//	name: the JSON path of the field in the decoded map (required)
+//	offset: the offset of the field in bytes (required)
+//	type: "int8", "uint8", "int16", "uint16", "int32", "uint32", "int64",
+//	    "uint64", "float32", "float64", "bool", "bytes", or "string"
+//	    (required)
+//	length: the length of "bytes" and "string" in bytes
+//	endian: "big" or "little"
+//	word_swap: true to swap 16-bit words of 32-bit and 64-bit fields
+//	bit: the position of the least significant bit of a bitfield
+//	bits: the width of a bitfield (default: 1 when bit is given)
+//	scale: the factor multiplied to the value (default: 1)
+//	bias: the value added to the scaled value (default: 0)
+//
+// Other parameters are ignored so that params can have parameters of other
+// components.
+func NewLayout(params data.Map) (*Layout, error) {
+	v, ok := params["fields"]
+	if !ok {
+		return nil, errors.New("fields: missing")
+	}
+	fs, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("fields: %v", err)
+	}
+	if len(fs) == 0 {
+		return nil, errors.New("fields: must have at least one field")
+	}
+	little := false
+	if v, ok := params["endian"]; ok {
+		if little, err = parseEndian(v); err != nil {
+			return nil, err
+		}
+	}
+
+	l := &Layout{}
+	for i, fv := range fs {
+		fm, err := data.AsMap(fv)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%v]: %v", i, err)
+		}
+		f, err := newField(fm, little)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%v]: %v", i, err)
+		}
+		l.Fields = append(l.Fields, f)
+		if end := f.Offset + f.size(); end > l.Size {
+			l.Size = end
+		}
+	}
+	if v, ok := params["frame_size"]; ok {
+		s, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("frame_size: %v", err)
+		}
+		if s < int64(l.Size) {
+			return nil, fmt.Errorf("frame_size: must be at least %v to have all fields: %v", l.Size, s)
+		}
+		l.Size = int(s)
+	}
+	return l, nil
+}
+
+func parseEndian(v data.Value) (bool, error) {
+	s, err := data.AsString(v)
+	if err != nil {
+		return false, fmt.Errorf("endian: %v", err)
+	}
+	switch strings.ToLower(s) {
+	case "big":
+		return false, nil
+	case "little":
+		return true, nil
+	}
+	return false, fmt.Errorf("endian: must be big or little: %v", s)
+}
+
+func newField(m data.Map, little bool) (*Field, error) {
+	f := &Field{
+		LittleEndian: little,
+		Scale:        1,
+	}
+	getInt := func(key string, required bool) (int, bool, error) {
+		v, ok := m[key]
+		if !ok {
+			if required {
+				return 0, false, fmt.Errorf("%v: missing", key)
+			}
+			return 0, false, nil
+		}
+		i, err := data.AsInt(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("%v: %v", key, err)
+		}
+		if i < 0 {
+			return 0, false, fmt.Errorf("%v: must not be negative: %v", key, i)
+		}
+		return int(i), true, nil
+	}
+	getFloat := func(key string, dst *float64) error {
+		if v, ok := m[key]; ok {
+			x, err := data.ToFloat(v)
+			if err != nil {
+				return fmt.Errorf("%v: %v", key, err)
+			}
+			*dst = x
+		}
+		return nil
+	}
+
+	v, ok := m["name"]
+	if !ok {
+		return nil, errors.New("name: missing")
+	}
+	name, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("name: %v", err)
+	}
+	if f.path, err = data.CompilePath(name); err != nil {
+		return nil, fmt.Errorf("name: invalid path '%v': %v", name, err)
+	}
+	f.Name = name
+
+	if f.Offset, _, err = getInt("offset", true); err != nil {
+		return nil, err
+	}
+
+	v, ok = m["type"]
+	if !ok {
+		return nil, errors.New("type: missing")
+	}
+	t, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("type: %v", err)
+	}
+	if f.Type, ok = fieldTypes[strings.ToLower(t)]; !ok {
+		return nil, fmt.Errorf("type: unsupported type: %v", t)
+	}
+
+	length, hasLength, err := getInt("length", false)
+	if err != nil {
+		return nil, err
+	}
+	if f.Type == Bytes || f.Type == String {
+		if !hasLength || length == 0 {
+			return nil, fmt.Errorf("length: must be positive for %v", f.Type)
+		}
+		f.Length = length
+	} else if hasLength {
+		return nil, fmt.Errorf("length: cannot be given for %v", f.Type)
+	}
+
+	if v, ok := m["endian"]; ok {
+		if f.LittleEndian, err = parseEndian(v); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := m["word_swap"]; ok {
+		if f.WordSwap, err = data.AsBool(v); err != nil {
+			return nil, fmt.Errorf("word_swap: %v", err)
+		}
+		if f.WordSwap && f.Type.size() < 4 {
+			return nil, fmt.Errorf("word_swap: cannot be applied to %v", f.Type)
+		}
+	}
+
+	bit, hasBit, err := getInt("bit", false)
+	if err != nil {
+		return nil, err
+	}
+	bits, hasBits, err := getInt("bits", false)
+	if err != nil {
+		return nil, err
+	}
+	if hasBit || hasBits {
+		if !f.Type.isInteger() && f.Type != Bool {
+			return nil, fmt.Errorf("bit: cannot be applied to %v", f.Type)
+		}
+		if !hasBits {
+			bits = 1
+		}
+		if bits == 0 || bit+bits > 8*f.Type.size() {
+			return nil, fmt.Errorf("bit: the bitfield at %v having %v bits doesn't fit in %v", bit, bits, f.Type)
+		}
+		f.Bit, f.Bits = bit, bits
+	}
+
+	if err := getFloat("scale", &f.Scale); err != nil {
+		return nil, err
+	}
+	if err := getFloat("bias", &f.Bias); err != nil {
+		return nil, err
+	}
+	if f.scaled() && !f.Type.isInteger() && f.Type != Float32 && f.Type != Float64 {
+		return nil, fmt.Errorf("scale: cannot be applied to %v", f.Type)
+	}
+	return f, nil
+}
+
+func (f *Field) size() int {
+	if f.Type == Bytes || f.Type == String {
+		return f.Length
+	}
+	return f.Type.size()
+}
+
+func (f *Field) scaled() bool {
+	return f.Scale != 1 || f.Bias != 0
+}
+
+// Decode decodes a frame. b must have at least Size bytes and extra bytes
+// are ignored.
+func (l *Layout) Decode(b []byte) (data.Map, error) {
+	m := data.Map{}
+	if err := l.DecodeInto(b, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DecodeInto decodes a frame and sets its fields to m so that fields of
+// multiple frames can be merged into one map. m can be modified even when
+// it returns an error.
+func (l *Layout) DecodeInto(b []byte, m data.Map) error {
+	if len(b) < l.Size {
+		return fmt.Errorf("the frame has %v bytes but %v bytes are required", len(b), l.Size)
+	}
+	for _, f := range l.Fields {
+		v, err := f.decode(b[f.Offset : f.Offset+f.size()])
+		if err != nil {
+			return fmt.Errorf("cannot decode %v: %v", f.Name, err)
+		}
+		if err := m.Set(f.path, v); err != nil {
+			return fmt.Errorf("cannot set %v: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (f *Field) decode(b []byte) (data.Value, error) {
+	switch f.Type {
+	case Bytes:
+		return data.Blob(append([]byte{}, b...)), nil
+	case String:
+		return data.String(strings.TrimRight(string(b), "\x00 ")), nil
+	}
+
+	if f.WordSwap {
+		s := make([]byte, len(b))
+		for i := 0; i < len(b); i += 2 {
+			copy(s[len(b)-i-2:len(b)-i], b[i:i+2])
+		}
+		b = s
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if f.LittleEndian {
+		order = binary.LittleEndian
+	}
+	var raw uint64
+	switch len(b) {
+	case 1:
+		raw = uint64(b[0])
+	case 2:
+		raw = uint64(order.Uint16(b))
+	case 4:
+		raw = uint64(order.Uint32(b))
+	case 8:
+		raw = order.Uint64(b)
+	}
+
+	switch f.Type {
+	case Float32:
+		return f.scale(float64(math.Float32frombits(uint32(raw)))), nil
+	case Float64:
+		return f.scale(math.Float64frombits(raw)), nil
+	}
+
+	width := uint(8 * len(b))
+	if f.Bits > 0 {
+		raw = (raw >> uint(f.Bit)) & (1<<uint(f.Bits) - 1)
+		width = uint(f.Bits)
+	}
+	if f.Type == Bool {
+		return data.Bool(raw != 0), nil
+	}
+	var i int64
+	if f.Type.isSigned() {
+		// sign-extend the value having the width
+		i = int64(raw<<(64-width)) >> (64 - width)
+	} else {
+		if raw > math.MaxInt64 {
+			return nil, fmt.Errorf("%v overflows Int", raw)
+		}
+		i = int64(raw)
+	}
+	if f.scaled() {
+		return f.scale(float64(i)), nil
+	}
+	return data.Int(i), nil
+}
+
+func (f *Field) scale(x float64) data.Value {
+	return data.Float(x*f.Scale + f.Bias)
+}
@@ -0,0 +1,137 @@
+package frame
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func field(name string, offset int, typ string, kvs ...interface{}) data.Map {
+	m := data.Map{
+		"name":   data.String(name),
+		"offset": data.Int(offset),
+		"type":   data.String(typ),
+	}
+	for i := 0; i < len(kvs); i += 2 {
+		v, err := data.NewValue(kvs[i+1])
+		if err != nil {
+			panic(err)
+		}
+		m[kvs[i].(string)] = v
+	}
+	return m
+}
+
+func TestLayout(t *testing.T) {
+	Convey("Given a layout of a frame", t, func() {
+		l, err := NewLayout(data.Map{
+			"fields": data.Array{
+				field("id", 0, "uint16"),
+				field("sensor.temp", 2, "int16", "scale", 0.1, "bias", -10),
+				field("flags.alarm", 4, "bool", "bit", 7),
+				field("flags.mode", 4, "uint8", "bit", 4, "bits", 3),
+				field("flags.offset", 4, "int8", "bits", 4),
+				field("count", 5, "uint32", "endian", "little"),
+				field("power", 9, "float32", "word_swap", true),
+				field("serial", 13, "string", "length", 6),
+				field("raw", 19, "bytes", "length", 2),
+			},
+			"frame_size": data.Int(24),
+		})
+		So(err, ShouldBeNil)
+
+		Convey("Then it should have the size", func() {
+			So(l.Size, ShouldEqual, 24)
+		})
+
+		Convey("When decoding a frame", func() {
+			b := []byte{
+				0x01, 0x02, // id
+				0xff, 0x38, // temp: -200
+				0xdc,                   // flags: 1 101 1100
+				0x78, 0x56, 0x34, 0x12, // count
+				0x00, 0x00, 0x41, 0x20, // power: 10.0 with swapped words
+				'S', 'N', '1', 0, 0, 0, // serial
+				0xca, 0xfe, // raw
+				0, 0, 0, // padding
+			}
+			m, err := l.Decode(b)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have all fields", func() {
+				So(m["id"], ShouldEqual, data.Int(0x0102))
+				temp, err := m.Get(data.MustCompilePath("sensor.temp"))
+				So(err, ShouldBeNil)
+				So(temp, ShouldAlmostEqual, -30.0, 1e-9)
+				So(m["flags"], ShouldResemble, data.Map{
+					"alarm":  data.True,
+					"mode":   data.Int(5),
+					"offset": data.Int(-4),
+				})
+				So(m["count"], ShouldEqual, data.Int(0x12345678))
+				So(m["power"], ShouldEqual, data.Float(10))
+				So(m["serial"], ShouldEqual, data.String("SN1"))
+				So(m["raw"], ShouldResemble, data.Blob{0xca, 0xfe})
+			})
+		})
+
+		Convey("When decoding a short frame", func() {
+			_, err := l.Decode(make([]byte, 20))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a layout in little endian", t, func() {
+		l, err := NewLayout(data.Map{
+			"endian": data.String("little"),
+			"fields": data.Array{
+				field("a", 0, "int32"),
+				field("b", 4, "uint64", "endian", "big"),
+			},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When decoding a frame", func() {
+			m, err := l.Decode([]byte{0xfe, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 1, 0})
+
+			Convey("Then fields should be decoded in their endian", func() {
+				So(err, ShouldBeNil)
+				So(m, ShouldResemble, data.Map{"a": data.Int(-2), "b": data.Int(256)})
+			})
+		})
+
+		Convey("When a uint64 field overflows", func() {
+			_, err := l.Decode([]byte{0, 0, 0, 0, 0xff, 0, 0, 0, 0, 0, 0, 0})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid layouts", t, func() {
+		Convey("Then creating them should fail", func() {
+			for _, p := range []data.Map{
+				{},
+				{"fields": data.Array{}},
+				{"fields": data.Array{field("a", 0, "int24")}},
+				{"fields": data.Array{field("a", -1, "int8")}},
+				{"fields": data.Array{field("a[", 0, "int8")}},
+				{"fields": data.Array{field("a", 0, "string")}},
+				{"fields": data.Array{field("a", 0, "int8", "length", 1)}},
+				{"fields": data.Array{field("a", 0, "int16", "word_swap", true)}},
+				{"fields": data.Array{field("a", 0, "int8", "bit", 6, "bits", 3)}},
+				{"fields": data.Array{field("a", 0, "float32", "bit", 0)}},
+				{"fields": data.Array{field("a", 0, "bytes", "length", 1, "scale", 2)}},
+				{"fields": data.Array{field("a", 0, "int8", "endian", "middle")}},
+				{"fields": data.Array{field("a", 0, "int32")}, "frame_size": data.Int(2)},
+			} {
+				_, err := NewLayout(p)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
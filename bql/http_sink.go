@@ -0,0 +1,598 @@
+package bql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/compress"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHTTPSinkTimeout        = 10 * time.Second
+	defaultHTTPSinkFlushInterval  = time.Second
+	defaultHTTPSinkMaxRetries     = 3
+	defaultHTTPSinkInitialBackoff = 100 * time.Millisecond
+	defaultHTTPSinkMaxBackoff     = 10 * time.Second
+)
+
+// httpSink sends tuples to an HTTP endpoint. Tuples are sent one by one
+// unless batch_size is greater than 1. A batch is sent when it becomes full
+// or flush_interval has passed since the last flush. Tuples in a batch are
+// grouped by URLs rendered from the URL template. The batch size can be
+// changed by adaptive batching when the sink sends batches.
+//
+// Requests failed due to connection errors or 408, 429, and 5xx responses are
+// retried with exponential backoff. Other 4xx responses mean tuples will never
+// be accepted, so they aren't retried and the tuples are written to the
+// dead-letter file when it's given. Backoff is waited on the Clock of the
+// Context, and it's given up when the sink is closed or the node is stopped
+// so that they aren't blocked by retries.
+type httpSink struct {
+	cli            *http.Client
+	method         string
+	url            *placeholderTupleFormatter
+	header         http.Header
+	body           TupleFormatter
+	codec          compress.Codec
+	encoding       string
+	batched        bool
+	ndjson         bool
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	// sendMutex serializes flushes so that batches are sent in order.
+	sendMutex sync.Mutex
+
+	m         sync.Mutex
+	batchSize int
+	batch     []*core.Tuple
+	closed    bool
+
+	dlMutex    sync.Mutex
+	deadLetter io.WriteCloser
+
+	// stop is closed when the sink is closed. flusherDone is only used when
+	// the sink sends batches.
+	stop        chan struct{}
+	flusherDone chan struct{}
+}
+
+// httpStatusError is returned when the server responds with a non-2xx status.
+type httpStatusError struct {
+	status int
+	body   string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("the server responded with %v: %v", e.status, e.body)
+}
+
+// ErrorCode returns "http_" followed by the status code, e.g. "http_404", so
+// that recovery policies can dispatch on it.
+func (e *httpStatusError) ErrorCode() string {
+	return fmt.Sprintf("http_%v", e.status)
+}
+
+// retriable returns true when the request might succeed if it's sent again.
+func (e *httpStatusError) retriable() bool {
+	return e.status >= 500 || e.status == http.StatusRequestTimeout ||
+		e.status == http.StatusTooManyRequests
+}
+
+func (s *httpSink) Write(ctx *core.Context, t *core.Tuple) error {
+	return s.WriteContext(context.Background(), ctx, t)
+}
+
+// WriteContext writes a tuple like Write. It implements core.ContextSink so
+// that requests and backoff of retries are canceled when the node is
+// stopped.
+func (s *httpSink) WriteContext(c context.Context, ctx *core.Context, t *core.Tuple) error {
+	if !s.batched {
+		u, err := s.url.FormatTuple(t)
+		if err != nil {
+			s.writeDeadLetter(ctx, "", err, t)
+			return err
+		}
+		s.sendMutex.Lock()
+		defer s.sendMutex.Unlock()
+		return s.send(c, ctx, u, []*core.Tuple{t})
+	}
+
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		return errors.New("the sink is already closed")
+	}
+	s.batch = append(s.batch, t)
+	full := len(s.batch) >= s.batchSize
+	s.m.Unlock()
+
+	if full {
+		// Errors are logged by flush because they aren't related to t.
+		s.flush(c, ctx)
+	}
+	return nil
+}
+
+// SetBatchSize changes the maximum number of tuples in a batch. It implements
+// core.BatchSizer. It doesn't do anything when the sink doesn't send batches
+// since the format of request bodies would change.
+func (s *httpSink) SetBatchSize(ctx *core.Context, n int) {
+	if !s.batched || n < 1 {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.batchSize = n
+}
+
+// Flush sends all tuples in the current batch. It implements core.Flushable.
+func (s *httpSink) Flush(ctx *core.Context) error {
+	return s.flush(context.Background(), ctx)
+}
+
+// flush sends all tuples in the current batch. It returns the first error
+// after logging all of them.
+func (s *httpSink) flush(c context.Context, ctx *core.Context) error {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	s.m.Lock()
+	batch := s.batch
+	s.batch = nil
+	s.m.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	// Group tuples by URLs while keeping their order in each group.
+	var urls []string
+	groups := map[string][]*core.Tuple{}
+	for _, t := range batch {
+		u, err := s.url.FormatTuple(t)
+		if err != nil {
+			s.writeDeadLetter(ctx, "", err, t)
+			continue
+		}
+		if _, ok := groups[u]; !ok {
+			urls = append(urls, u)
+		}
+		groups[u] = append(groups[u], t)
+	}
+	var firstErr error
+	for _, u := range urls {
+		if err := s.send(c, ctx, u, groups[u]); err != nil {
+			ctx.ErrLog(err).WithField("url", u).WithField("num_tuples", len(groups[u])).
+				Error("Cannot send a batch of tuples")
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// send sends tuples to the URL. The caller must hold sendMutex.
+func (s *httpSink) send(c context.Context, ctx *core.Context, u string, ts []*core.Tuple) error {
+	lines := make([]string, 0, len(ts))
+	valid := make([]*core.Tuple, 0, len(ts))
+	for _, t := range ts {
+		l, err := s.body.FormatTuple(t)
+		if err != nil {
+			s.writeDeadLetter(ctx, u, err, t)
+			continue
+		}
+		lines = append(lines, l)
+		valid = append(valid, t)
+	}
+	if len(lines) == 0 {
+		return errors.New("no tuple could be formatted")
+	}
+
+	var body []byte
+	switch {
+	case !s.batched:
+		body = []byte(lines[0])
+	case s.ndjson:
+		body = []byte(strings.Join(lines, "\n") + "\n")
+	default:
+		body = []byte("[" + strings.Join(lines, ",") + "]")
+	}
+	if s.codec != nil {
+		b, err := compress.Compress(s.codec, body)
+		if err != nil {
+			return err
+		}
+		body = b
+	}
+
+	backoff := s.initialBackoff
+	var err error
+	for retries := 0; ; retries++ {
+		err = s.do(c, u, body)
+		if err == nil {
+			return nil
+		}
+		if e, ok := err.(*httpStatusError); ok && !e.retriable() {
+			break
+		}
+		if retries >= s.maxRetries {
+			break
+		}
+		if !s.waitBackoff(c, ctx, backoff) {
+			ctx.ErrLog(err).WithField("url", u).Info("Gave up retrying the request because the sink is stopped")
+			break
+		}
+		ctx.ErrLog(err).WithField("url", u).Info("Retrying the request")
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+	for _, t := range valid {
+		s.writeDeadLetter(ctx, u, err, t)
+	}
+	return err
+}
+
+// waitBackoff waits for d on the Clock of the Context. It returns false when
+// the sink is closed or c is canceled before d passes.
+func (s *httpSink) waitBackoff(c context.Context, ctx *core.Context, d time.Duration) bool {
+	timer := ctx.Clock().NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return true
+	case <-s.stop:
+		return false
+	case <-c.Done():
+		return false
+	}
+}
+
+func (s *httpSink) do(c context.Context, u string, body []byte) error {
+	req, err := http.NewRequestWithContext(c, s.method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, vs := range s.header {
+		req.Header[k] = vs
+	}
+	if s.encoding != "" {
+		req.Header.Set("Content-Encoding", s.encoding)
+	}
+
+	res, err := s.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// Reading the body is necessary to reuse the connection.
+	b, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	io.Copy(ioutil.Discard, res.Body)
+	if res.StatusCode < 200 || 300 <= res.StatusCode {
+		return &httpStatusError{
+			status: res.StatusCode,
+			body:   string(b),
+		}
+	}
+	return nil
+}
+
+// writeDeadLetter writes the tuple which couldn't be sent to the dead-letter
+// file. The tuple is only logged when the sink doesn't have the file.
+func (s *httpSink) writeDeadLetter(ctx *core.Context, u string, err error, t *core.Tuple) {
+	m := data.Map{
+		"url":   data.String(u),
+		"error": data.String(err.Error()),
+		"data":  t.Data,
+	}
+	if e, ok := err.(*httpStatusError); ok {
+		m["status"] = data.Int(e.status)
+	}
+	if c := core.ErrorCode(err); c != "" {
+		m["error_code"] = data.String(c)
+	}
+
+	s.dlMutex.Lock()
+	defer s.dlMutex.Unlock()
+	if s.deadLetter == nil {
+		ctx.ErrLog(err).WithField("url", u).Error("Cannot send a tuple")
+		return
+	}
+	if _, e := fmt.Fprintln(s.deadLetter, m.String()); e != nil {
+		ctx.ErrLog(e).Error("Cannot write a tuple to the dead-letter file")
+	}
+}
+
+func (s *httpSink) flusher(ctx *core.Context, interval time.Duration) {
+	defer close(s.flusherDone)
+	ticker := ctx.Clock().NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C():
+			s.flush(context.Background(), ctx)
+		}
+	}
+}
+
+func (s *httpSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.m.Unlock()
+
+	// Closing stop also makes senders waiting for backoff give up so that
+	// the final flush doesn't wait for them. Tuples in the final flush are
+	// only sent once.
+	close(s.stop)
+	if s.batched {
+		<-s.flusherDone
+	}
+	s.flush(context.Background(), ctx)
+
+	s.dlMutex.Lock()
+	defer s.dlMutex.Unlock()
+	if s.deadLetter == nil {
+		return nil
+	}
+	err := s.deadLetter.Close()
+	s.deadLetter = nil
+	return err
+}
+
+// createHTTPSink creates a sink sending tuples to an HTTP endpoint. It
+// accepts the following parameters:
+//
+//   - url: the URL of the endpoint, which is required. It can have
+//     placeholders of JSON paths in braces like the "format" parameter of
+//     NewTupleFormatter, e.g. "http://host/devices/{device.id}". Values are
+//     escaped as path segments, so the scheme and the host must be literals.
+//   - method: the HTTP method. The default value is "POST".
+//   - headers: a map of additional headers.
+//   - basic_auth: a map having "username" and "password".
+//   - bearer_token: a token sent in the Authorization header.
+//   - timeout: the timeout of a request. The default value is 10 seconds.
+//   - compression: the name of a codec compressing request bodies.
+//   - template, format: the format of each tuple as NewTupleFormatter accepts.
+//     A tuple is written as a JSON object by default.
+//   - batch_size: the maximum number of tuples sent in a request. The default
+//     value is 1, which sends a tuple as a request body.
+//   - batch_format: "json_array" or "ndjson". The default value is
+//     "json_array".
+//   - flush_interval: the interval of sending a partial batch. The default
+//     value is 1 second.
+//   - max_retries: the maximum number of retries. The default value is 3.
+//   - initial_backoff, max_backoff: the backoff before the first retry and
+//     the maximum backoff. The backoff doubles on each retry. The default
+//     values are 100ms and 10s.
+//   - dead_letter_path: the path of a file to which tuples which couldn't be
+//     sent are appended as JSON lines having "url", "error", "status", and
+//     "data".
+//   - tls: TLS parameters described in TLSConfig.
+func createHTTPSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	s := &httpSink{
+		method:         "POST",
+		header:         http.Header{},
+		batchSize:      1,
+		maxRetries:     defaultHTTPSinkMaxRetries,
+		initialBackoff: defaultHTTPSinkInitialBackoff,
+		maxBackoff:     defaultHTTPSinkMaxBackoff,
+		stop:           make(chan struct{}),
+	}
+
+	v, ok := params["url"]
+	if !ok {
+		return nil, errors.New("'url' parameter is missing")
+	}
+	u, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'url' parameter must be a string: %v", err)
+	}
+	if s.url, err = newPlaceholderTupleFormatter(u); err != nil {
+		return nil, fmt.Errorf("'url' parameter is invalid: %v", err)
+	}
+	s.url.escape = url.PathEscape
+	if pu, err := url.Parse(s.url.literals[0]); err != nil {
+		return nil, fmt.Errorf("'url' parameter is invalid: %v", err)
+	} else if pu.Scheme != "http" && pu.Scheme != "https" {
+		return nil, fmt.Errorf("'url' parameter must start with http:// or https://: %v", u)
+	}
+
+	if v, ok := params["method"]; ok {
+		m, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'method' parameter must be a string: %v", err)
+		}
+		s.method = strings.ToUpper(m)
+	}
+
+	if s.batchSize, err = extractPositiveIntParameter(params, "batch_size", 1); err != nil {
+		return nil, err
+	}
+	s.batched = s.batchSize > 1
+	if v, ok := params["batch_format"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'batch_format' parameter must be a string: %v", err)
+		}
+		switch f {
+		case "json_array":
+		case "ndjson":
+			s.ndjson = true
+		default:
+			return nil, fmt.Errorf("'batch_format' parameter must be 'json_array' or 'ndjson': %v", f)
+		}
+	}
+	s.header.Set("Content-Type", "application/json")
+	if s.ndjson && s.batched {
+		s.header.Set("Content-Type", "application/x-ndjson")
+	}
+
+	if v, ok := params["headers"]; ok {
+		hs, err := data.AsMap(v)
+		if err != nil {
+			return nil, fmt.Errorf("'headers' parameter must be a map: %v", err)
+		}
+		for k, v := range hs {
+			h, err := data.ToString(v)
+			if err != nil {
+				return nil, fmt.Errorf("the value of header '%v' must be a string: %v", k, err)
+			}
+			s.header.Set(k, h)
+		}
+	}
+	if err := setHTTPSinkAuth(s.header, params); err != nil {
+		return nil, err
+	}
+
+	if s.body, err = NewTupleFormatter(params); err != nil {
+		return nil, err
+	}
+	if s.codec, err = extractCompressionParameter(params); err != nil {
+		return nil, err
+	}
+	if s.codec != nil {
+		n, _ := data.AsString(params["compression"])
+		s.encoding = strings.ToLower(n)
+		if s.encoding == "zlib" {
+			s.encoding = "deflate" // HTTP calls zlib format "deflate"
+		}
+	}
+
+	timeout, err := extractDurationParameter(params, "timeout", defaultHTTPSinkTimeout)
+	if err != nil {
+		return nil, err
+	}
+	flushInterval, err := extractDurationParameter(params, "flush_interval", defaultHTTPSinkFlushInterval)
+	if err != nil {
+		return nil, err
+	}
+	if s.initialBackoff, err = extractDurationParameter(params, "initial_backoff", defaultHTTPSinkInitialBackoff); err != nil {
+		return nil, err
+	}
+	if s.maxBackoff, err = extractDurationParameter(params, "max_backoff", defaultHTTPSinkMaxBackoff); err != nil {
+		return nil, err
+	}
+	if v, ok := params["max_retries"]; ok {
+		n, err := data.ToInt(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("'max_retries' parameter must be a non-negative integer: %v", v)
+		}
+		s.maxRetries = int(n)
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	if tc, err := LookupTLSConfig(params); err != nil {
+		return nil, err
+	} else if tc != nil {
+		if transport.TLSClientConfig, err = tc.ClientConfig(); err != nil {
+			return nil, err
+		}
+	}
+	s.cli = &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+
+	if v, ok := params["dead_letter_path"]; ok {
+		p, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'dead_letter_path' parameter must be a string: %v", err)
+		}
+		f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, err
+		}
+		s.deadLetter = f
+	}
+
+	if s.batched {
+		s.flusherDone = make(chan struct{})
+		go s.flusher(ctx, flushInterval)
+	}
+	return s, nil
+}
+
+func setHTTPSinkAuth(h http.Header, params data.Map) error {
+	if v, ok := params["basic_auth"]; ok {
+		m, err := data.AsMap(v)
+		if err != nil {
+			return fmt.Errorf("'basic_auth' parameter must be a map: %v", err)
+		}
+		user, err := data.AsString(m["username"])
+		if err != nil {
+			return fmt.Errorf("'basic_auth' parameter must have a string 'username': %v", err)
+		}
+		pass, err := data.AsString(m["password"])
+		if err != nil {
+			return fmt.Errorf("'basic_auth' parameter must have a string 'password': %v", err)
+		}
+		req := http.Request{Header: http.Header{}}
+		req.SetBasicAuth(user, pass)
+		h.Set("Authorization", req.Header.Get("Authorization"))
+	}
+	if v, ok := params["bearer_token"]; ok {
+		if h.Get("Authorization") != "" {
+			return errors.New("'basic_auth' and 'bearer_token' parameters cannot be specified at once")
+		}
+		t, err := data.AsString(v)
+		if err != nil {
+			return fmt.Errorf("'bearer_token' parameter must be a string: %v", err)
+		}
+		h.Set("Authorization", "Bearer "+t)
+	}
+	return nil
+}
+
+// extractDurationParameter retrieves a positive duration parameter which is
+// a number of seconds or a duration string. It returns def when params
+// doesn't have the parameter.
+func extractDurationParameter(params data.Map, name string, def time.Duration) (time.Duration, error) {
+	v, ok := params[name]
+	if !ok {
+		return def, nil
+	}
+	d, err := data.ToDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("'%v' parameter must be a positive duration: %v", name, v)
+	}
+	return d, nil
+}
+
+// extractPositiveIntParameter retrieves a positive integer parameter. It
+// returns def when params doesn't have the parameter.
+func extractPositiveIntParameter(params data.Map, name string, def int) (int, error) {
+	v, ok := params[name]
+	if !ok {
+		return def, nil
+	}
+	n, err := data.ToInt(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("'%v' parameter must be a positive integer: %v", name, v)
+	}
+	return int(n), nil
+}
+
+func init() {
+	MustRegisterGlobalSinkCreator("http", SinkCreatorFunc(createHTTPSink))
+}
@@ -0,0 +1,368 @@
+package bql
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type httpSinkTestRequest struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+type httpSinkTestServer struct {
+	*httptest.Server
+	m        sync.Mutex
+	requests []*httpSinkTestRequest
+
+	// statuses are returned in order. 200 is returned after all of them are
+	// returned.
+	statuses []int
+}
+
+func newHTTPSinkTestServer(statuses ...int) *httpSinkTestServer {
+	s := &httpSinkTestServer{
+		statuses: statuses,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body, _ = ioutil.ReadAll(gr)
+		} else {
+			body, _ = ioutil.ReadAll(r.Body)
+		}
+
+		s.m.Lock()
+		defer s.m.Unlock()
+		s.requests = append(s.requests, &httpSinkTestRequest{
+			path:   r.URL.Path,
+			header: r.Header,
+			body:   string(body),
+		})
+		if len(s.statuses) > 0 {
+			w.WriteHeader(s.statuses[0])
+			s.statuses = s.statuses[1:]
+		}
+	}))
+	return s
+}
+
+func (s *httpSinkTestServer) received() []*httpSinkTestRequest {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]*httpSinkTestRequest{}, s.requests...)
+}
+
+func TestHTTPSink(t *testing.T) {
+	ctx := core.NewContext(nil)
+	newTuple := func(i int) *core.Tuple {
+		return core.NewTuple(data.Map{
+			"id":     data.Int(i),
+			"device": data.String("dev 1"),
+		})
+	}
+
+	Convey("Given an HTTP server", t, func() {
+		srv := newHTTPSinkTestServer()
+		Reset(srv.Close)
+
+		Convey("When sending tuples one by one", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":          data.String(srv.URL + "/devices/{device}"),
+				"headers":      data.Map{"X-Test": data.String("a")},
+				"bearer_token": data.String("token"),
+			})
+			So(err, ShouldBeNil)
+			for i := 0; i < 2; i++ {
+				So(si.Write(ctx, newTuple(i)), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then each tuple should be sent to the rendered URL", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 2)
+				for i, r := range rs {
+					So(r.path, ShouldEqual, "/devices/dev 1")
+					So(r.body, ShouldEqual, newTuple(i).Data.String())
+					So(r.header.Get("Content-Type"), ShouldEqual, "application/json")
+					So(r.header.Get("X-Test"), ShouldEqual, "a")
+					So(r.header.Get("Authorization"), ShouldEqual, "Bearer token")
+				}
+			})
+		})
+
+		Convey("When sending tuples in a batch as a JSON array", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":         data.String(srv.URL),
+				"batch_size":  data.Int(3),
+				"compression": data.String("gzip"),
+			})
+			So(err, ShouldBeNil)
+			for i := 0; i < 4; i++ {
+				So(si.Write(ctx, newTuple(i)), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then tuples should be sent in batches", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 2)
+				var a []map[string]interface{}
+				So(json.Unmarshal([]byte(rs[0].body), &a), ShouldBeNil)
+				So(len(a), ShouldEqual, 3)
+				So(a[2]["id"], ShouldEqual, 2)
+				So(json.Unmarshal([]byte(rs[1].body), &a), ShouldBeNil)
+				So(len(a), ShouldEqual, 1)
+				So(a[0]["id"], ShouldEqual, 3)
+				So(rs[0].header.Get("Content-Encoding"), ShouldEqual, "gzip")
+			})
+		})
+
+		Convey("When sending tuples in a batch as NDJSON with a format", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":          data.String(srv.URL),
+				"batch_size":   data.Int(10),
+				"batch_format": data.String("ndjson"),
+				"template":     data.String(`{"n":{{.id}}}`),
+			})
+			So(err, ShouldBeNil)
+			for i := 0; i < 2; i++ {
+				So(si.Write(ctx, newTuple(i)), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then the partial batch should be sent on close", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 1)
+				So(rs[0].body, ShouldEqual, "{\"n\":0}\n{\"n\":1}\n")
+				So(rs[0].header.Get("Content-Type"), ShouldEqual, "application/x-ndjson")
+			})
+		})
+
+		Convey("When flushing a partial batch", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":            data.String(srv.URL),
+				"batch_size":     data.Int(10),
+				"flush_interval": data.String("1h"),
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+			for i := 0; i < 2; i++ {
+				So(si.Write(ctx, newTuple(i)), ShouldBeNil)
+			}
+			So(si.(core.Flushable).Flush(ctx), ShouldBeNil)
+
+			Convey("Then the batch should be sent before the sink is closed", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 1)
+				var a []map[string]interface{}
+				So(json.Unmarshal([]byte(rs[0].body), &a), ShouldBeNil)
+				So(len(a), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When changing the batch size", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":            data.String(srv.URL),
+				"batch_size":     data.Int(10),
+				"flush_interval": data.String("1h"),
+			})
+			So(err, ShouldBeNil)
+			si.(core.BatchSizer).SetBatchSize(ctx, 2)
+			for i := 0; i < 4; i++ {
+				So(si.Write(ctx, newTuple(i)), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then tuples should be sent in batches of the new size", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 2)
+				var a []map[string]interface{}
+				So(json.Unmarshal([]byte(rs[1].body), &a), ShouldBeNil)
+				So(len(a), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When changing the batch size of a sink sending tuples one by one", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url": data.String(srv.URL),
+			})
+			So(err, ShouldBeNil)
+			si.(core.BatchSizer).SetBatchSize(ctx, 2)
+			So(si.Write(ctx, newTuple(0)), ShouldBeNil)
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then the tuple should still be sent by itself", func() {
+				rs := srv.received()
+				So(len(rs), ShouldEqual, 1)
+				So(rs[0].body, ShouldEqual, newTuple(0).Data.String())
+			})
+		})
+	})
+
+	Convey("Given an HTTP server failing temporarily", t, func() {
+		srv := newHTTPSinkTestServer(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		Reset(srv.Close)
+
+		Convey("When sending a tuple", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":             data.String(srv.URL),
+				"initial_backoff": data.String("1ms"),
+			})
+			So(err, ShouldBeNil)
+			err = si.Write(ctx, newTuple(0))
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should succeed after retries", func() {
+				So(err, ShouldBeNil)
+				So(len(srv.received()), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When sending a tuple with fewer retries", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":             data.String(srv.URL),
+				"initial_backoff": data.String("1ms"),
+				"max_retries":     data.Int(1),
+			})
+			So(err, ShouldBeNil)
+			err = si.Write(ctx, newTuple(0))
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(len(srv.received()), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When closing the sink while a tuple is waiting for backoff", func() {
+			// backoff never passes because the clock doesn't advance
+			fctx := core.NewContext(&core.ContextConfig{
+				Clock: core.NewFakeClock(time.Now()),
+			})
+			si, err := createHTTPSink(fctx, &IOParams{}, data.Map{
+				"url": data.String(srv.URL),
+			})
+			So(err, ShouldBeNil)
+			ch := make(chan error, 1)
+			go func() {
+				ch <- si.Write(fctx, newTuple(0))
+			}()
+			So(si.Close(fctx), ShouldBeNil)
+
+			Convey("Then the write should give up retrying", func() {
+				So(<-ch, ShouldNotBeNil)
+				So(len(srv.received()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When writing a tuple with a canceled context", func() {
+			fctx := core.NewContext(&core.ContextConfig{
+				Clock: core.NewFakeClock(time.Now()),
+			})
+			si, err := createHTTPSink(fctx, &IOParams{}, data.Map{
+				"url": data.String(srv.URL),
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(fctx)
+			})
+			c, cancel := context.WithCancel(context.Background())
+			cancel()
+			err = si.(core.ContextSink).WriteContext(c, fctx, newTuple(0))
+
+			Convey("Then it should fail without sending the tuple", func() {
+				So(err, ShouldNotBeNil)
+				So(srv.received(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given an HTTP server rejecting requests", t, func() {
+		srv := newHTTPSinkTestServer(http.StatusBadRequest)
+		Reset(srv.Close)
+		dir, err := ioutil.TempDir("", "sensorbee_http_sink_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		dl := filepath.Join(dir, "dead_letter.jsonl")
+
+		Convey("When sending a tuple", func() {
+			si, err := createHTTPSink(ctx, &IOParams{}, data.Map{
+				"url":              data.String(srv.URL),
+				"dead_letter_path": data.String(dl),
+				"initial_backoff":  data.String("1ms"),
+			})
+			So(err, ShouldBeNil)
+			err = si.Write(ctx, newTuple(0))
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should fail without retries", func() {
+				So(err, ShouldNotBeNil)
+				So(len(srv.received()), ShouldEqual, 1)
+				So(core.ErrorCode(err), ShouldEqual, "http_400")
+			})
+
+			Convey("Then the tuple should be written to the dead-letter file", func() {
+				b, err := ioutil.ReadFile(dl)
+				So(err, ShouldBeNil)
+				lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+				So(len(lines), ShouldEqual, 1)
+				var js map[string]interface{}
+				So(json.Unmarshal([]byte(lines[0]), &js), ShouldBeNil)
+				So(js["status"], ShouldEqual, http.StatusBadRequest)
+				So(js["error_code"], ShouldEqual, "http_400")
+				So(js["data"].(map[string]interface{})["id"], ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		cases := map[string]data.Map{
+			"no url":                    data.Map{},
+			"a url without a scheme":    data.Map{"url": data.String("localhost/a")},
+			"a url having a bad path":   data.Map{"url": data.String("http://localhost/{a[}")},
+			"a zero batch size":         data.Map{"url": data.String("http://localhost"), "batch_size": data.Int(0)},
+			"an unknown batch format":   data.Map{"url": data.String("http://localhost"), "batch_format": data.String("csv")},
+			"a negative timeout":        data.Map{"url": data.String("http://localhost"), "timeout": data.Int(-1)},
+			"an unknown compression":    data.Map{"url": data.String("http://localhost"), "compression": data.String("no_such_codec")},
+			"a negative number retries": data.Map{"url": data.String("http://localhost"), "max_retries": data.Int(-1)},
+			"both auth parameters": data.Map{
+				"url":          data.String("http://localhost"),
+				"basic_auth":   data.Map{"username": data.String("u"), "password": data.String("p")},
+				"bearer_token": data.String("t"),
+			},
+		}
+		for title, params := range cases {
+			params := params
+			Convey("When creating an HTTP sink with "+title, func() {
+				_, err := createHTTPSink(core.NewContext(nil), &IOParams{}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
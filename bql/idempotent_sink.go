@@ -0,0 +1,151 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"time"
+)
+
+const defaultIdempotencyKeyTTL = 24 * time.Hour
+
+// idempotencyKeysCreator creates core.IdempotencyKeys. It accepts following
+// parameters:
+//
+//	ttl: the duration for which keys are kept (default: 24h)
+type idempotencyKeysCreator struct{}
+
+var (
+	_ udf.UDSLoader = &idempotencyKeysCreator{}
+)
+
+func (c *idempotencyKeysCreator) CreateState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	ttl := defaultIdempotencyKeyTTL
+	for k, v := range params {
+		switch k {
+		case "ttl":
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("'ttl' parameter should have a duration: %v", err)
+			}
+			ttl = d
+		default:
+			return nil, fmt.Errorf("unknown parameter: %v", k)
+		}
+	}
+	return core.NewIdempotencyKeys(ttl)
+}
+
+func (c *idempotencyKeysCreator) LoadState(ctx *core.Context, r io.Reader, params data.Map) (core.SharedState, error) {
+	s, err := core.NewIdempotencyKeys(defaultIdempotencyKeyTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Load(ctx, r, params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("idempotency_keys", &idempotencyKeysCreator{})
+}
+
+// idempotencyParamPrefix is the prefix of parameters of CREATE SINK
+// statements making the sink idempotent with core.NewIdempotentSink:
+//
+//	idempotency_key: a BQL expression computing the key of a tuple, such as
+//	    "order_id" or "device_id || ':' || seq" (required)
+//	idempotency_state: the name of an idempotency_keys state recording keys
+//	    of written tuples (required)
+//
+// For example,
+//
+//	CREATE STATE sent_orders TYPE idempotency_keys WITH ttl="24h";
+//	CREATE SINK orders TYPE http WITH url="...",
+//	    idempotency_key="order_id", idempotency_state="sent_orders";
+const idempotencyParamPrefix = "idempotency_"
+
+// idempotencyConfig is a configuration of an idempotent sink.
+type idempotencyConfig struct {
+	key   func(t *core.Tuple) (data.Value, error)
+	state string
+}
+
+// extractIdempotencyConfig removes parameters of an idempotent sink from
+// params and creates a config from them. It returns nil when params doesn't
+// have any of the parameters.
+func (tb *TopologyBuilder) extractIdempotencyConfig(params data.Map) (*idempotencyConfig, error) {
+	ip := extractPrefixedParams(params, idempotencyParamPrefix)
+	if len(ip) == 0 {
+		return nil, nil
+	}
+	for k := range ip {
+		if k != "key" && k != "state" {
+			return nil, fmt.Errorf("unknown idempotency parameter: %v", k)
+		}
+	}
+
+	c := &idempotencyConfig{}
+	v, ok := ip["key"]
+	if !ok {
+		return nil, errors.New("'idempotency_key' parameter is missing")
+	}
+	k, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'idempotency_key' parameter must be a string: %v", err)
+	}
+	if c.key, err = tb.compileIdempotencyKey(k); err != nil {
+		return nil, fmt.Errorf("'idempotency_key' parameter doesn't have a valid expression: %v", err)
+	}
+
+	if v, ok = ip["state"]; !ok {
+		return nil, errors.New("'idempotency_state' parameter is missing")
+	}
+	if c.state, err = data.AsString(v); err != nil {
+		return nil, fmt.Errorf("'idempotency_state' parameter must be a string: %v", err)
+	}
+	st, err := tb.topology.Context().SharedStates.Get(c.state)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := st.(*core.IdempotencyKeys); !ok {
+		return nil, fmt.Errorf("the state '%v' doesn't have idempotency keys", c.state)
+	}
+	return c, nil
+}
+
+// compileIdempotencyKey compiles an expression computing the key of a tuple.
+// The expression can refer to fields of the tuple as the ON clause of EVAL
+// statements does.
+func (tb *TopologyBuilder) compileIdempotencyKey(s string) (func(t *core.Tuple) (data.Value, error), error) {
+	res, rest, err := parser.New().ParseStmt("EVAL " + s)
+	if err != nil {
+		return nil, err
+	}
+	stmt, ok := res.(parser.EvalStmt)
+	if !ok || stmt.Input != nil || rest != "" {
+		return nil, fmt.Errorf("it must be a single expression: %v", s)
+	}
+	if rels := stmt.Expr.ReferencedRelations(); len(rels) > 1 || (len(rels) == 1 && !rels[""]) {
+		return nil, errors.New("stream prefixes cannot be used")
+	}
+	expr := stmt.Expr.RenameReferencedRelation("", "input")
+	flatExpr, err := execution.ParserExprToFlatExpr(expr, tb.Reg)
+	if err != nil {
+		return nil, err
+	}
+	eval, err := execution.ExpressionToEvaluator(flatExpr, tb.Reg)
+	if err != nil {
+		return nil, err
+	}
+	return func(t *core.Tuple) (data.Value, error) {
+		// nest the data so that access via JSON path works properly
+		return eval.Eval(data.Map{"input": t.Data})
+	}, nil
+}
@@ -0,0 +1,120 @@
+package bql
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotentSink(t *testing.T) {
+	Convey("Given a topology builder having idempotency keys", t, func() {
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STATE sent TYPE idempotency_keys WITH ttl="1h";
+			CREATE STATE vocab TYPE vocabulary WITH values=["a"];`), ShouldBeNil)
+
+		Convey("When writing tuples having duplicated keys to a sink", func() {
+			dir, err := ioutil.TempDir("", "sbtest_idempotent_sink")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				os.RemoveAll(dir)
+			})
+			path := filepath.Join(dir, "out.jsonl")
+			So(addBQLToTopology(tb, fmt.Sprintf(`
+				CREATE SINK snk TYPE file WITH path=%q,
+					idempotency_key="int %% 2", idempotency_state="sent";
+				INSERT INTO snk FROM source;
+				RESUME SOURCE source;`, path)), ShouldBeNil)
+
+			sn, err := tp.Sink("snk")
+			So(err, ShouldBeNil)
+			suppressed := data.MustCompilePath("sink.idempotency.num_suppressed")
+			for i := 0; ; i++ {
+				v, err := sn.Status().Get(suppressed)
+				So(err, ShouldBeNil)
+				if v == data.Int(2) {
+					break
+				}
+				So(i, ShouldBeLessThan, 1000)
+				time.Sleep(5 * time.Millisecond)
+			}
+			So(addBQLToTopology(tb, `DROP SINK snk;`), ShouldBeNil)
+
+			Convey("Then only the first tuple of each key should be written", func() {
+				b, err := ioutil.ReadFile(path)
+				So(err, ShouldBeNil)
+				lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+				So(len(lines), ShouldEqual, 2)
+				So(lines[0], ShouldContainSubstring, `"int":1`)
+				So(lines[1], ShouldContainSubstring, `"int":2`)
+			})
+
+			Convey("Then the keys should be recorded in the state", func() {
+				st, err := tp.Context().SharedStates.Get("sent")
+				So(err, ShouldBeNil)
+				So(st.(*core.IdempotencyKeys).Len(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When creating sinks with invalid idempotency parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, params := range []string{
+					`idempotency_key="int"`,
+					`idempotency_state="sent"`,
+					`idempotency_key="int", idempotency_state="sent", idempotency_ttl="1h"`,
+					`idempotency_key=1, idempotency_state="sent"`,
+					`idempotency_key="int +", idempotency_state="sent"`,
+					`idempotency_key="int; DROP STATE sent", idempotency_state="sent"`,
+					`idempotency_key="int ON {}", idempotency_state="sent"`,
+					`idempotency_key="s:int", idempotency_state="sent"`,
+					`idempotency_key="count(int)", idempotency_state="sent"`,
+					`idempotency_key="int", idempotency_state="missing"`,
+					`idempotency_key="int", idempotency_state="vocab"`,
+				} {
+					err := addBQLToTopology(tb, `CREATE SINK snk TYPE collector WITH `+params)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+
+	Convey("Given the idempotency_keys state creator", t, func() {
+		ctx := core.NewContext(nil)
+		c := &idempotencyKeysCreator{}
+
+		Convey("When creating a state without parameters", func() {
+			st, err := c.CreateState(ctx, data.Map{})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should keep keys for a day", func() {
+				So(st.(*core.IdempotencyKeys).TTL(), ShouldEqual, 24*time.Hour)
+			})
+		})
+
+		Convey("When creating states with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{"ttl": data.String("0s")},
+					{"ttl": data.String("soon")},
+					{"size": data.Int(1)},
+				} {
+					_, err := c.CreateState(ctx, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
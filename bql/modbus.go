@@ -0,0 +1,564 @@
+package bql
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/frame"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultModbusPort     = "502"
+	defaultModbusInterval = time.Second
+	defaultModbusTimeout  = 3 * time.Second
+
+	// maxModbusRegisters and maxModbusBits are the maximum numbers of
+	// registers and bits read by a request.
+	maxModbusRegisters = 125
+	maxModbusBits      = 2000
+)
+
+// modbusTable is a table of a Modbus data model.
+type modbusTable int
+
+const (
+	modbusCoil modbusTable = iota
+	modbusDiscreteInput
+	modbusHoldingRegister
+	modbusInputRegister
+)
+
+var modbusTables = map[string]modbusTable{
+	"coil":     modbusCoil,
+	"discrete": modbusDiscreteInput,
+	"holding":  modbusHoldingRegister,
+	"input":    modbusInputRegister,
+}
+
+// function returns the function code reading the table.
+func (t modbusTable) function() byte {
+	return byte(t) + 1
+}
+
+func (t modbusTable) isBit() bool {
+	return t == modbusCoil || t == modbusDiscreteInput
+}
+
+// modbusExceptionError is an exception response returned from a server. The
+// connection can still be used after the error.
+type modbusExceptionError struct {
+	function byte
+	code     byte
+}
+
+func (e *modbusExceptionError) Error() string {
+	desc := "unknown exception"
+	switch e.code {
+	case 1:
+		desc = "illegal function"
+	case 2:
+		desc = "illegal data address"
+	case 3:
+		desc = "illegal data value"
+	case 4:
+		desc = "server device failure"
+	case 6:
+		desc = "server device busy"
+	case 10:
+		desc = "gateway path unavailable"
+	case 11:
+		desc = "gateway target device failed to respond"
+	}
+	return fmt.Sprintf("the server returned exception %v (%v) for function %v", e.code, desc, e.function)
+}
+
+// modbusConn is a connection to a Modbus TCP server. It sends one request at
+// a time.
+type modbusConn struct {
+	conn    net.Conn
+	unitID  byte
+	timeout time.Duration
+	txID    uint16
+}
+
+func dialModbus(address string, unitID byte, timeout time.Duration) (*modbusConn, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &modbusConn{
+		conn:    conn,
+		unitID:  unitID,
+		timeout: timeout,
+	}, nil
+}
+
+// read reads count registers or bits starting at start from the table. Bits
+// are packed into bytes from the least significant bit. Errors other than
+// *modbusExceptionError mean the connection is broken.
+func (c *modbusConn) read(table modbusTable, start, count uint16) ([]byte, error) {
+	c.txID++
+	fn := table.function()
+	req := make([]byte, 12)
+	binary.BigEndian.PutUint16(req[0:], c.txID)
+	binary.BigEndian.PutUint16(req[2:], 0) // protocol ID
+	binary.BigEndian.PutUint16(req[4:], 6) // length of the rest
+	req[6] = c.unitID
+	req[7] = fn
+	binary.BigEndian.PutUint16(req[8:], start)
+	binary.BigEndian.PutUint16(req[10:], count)
+
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := c.conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		return nil, err
+	}
+	if id := binary.BigEndian.Uint16(header[0:]); id != c.txID {
+		return nil, fmt.Errorf("the transaction ID of the response is %v but %v is expected", id, c.txID)
+	}
+	if p := binary.BigEndian.Uint16(header[2:]); p != 0 {
+		return nil, fmt.Errorf("the response has an unsupported protocol ID: %v", p)
+	}
+	l := int(binary.BigEndian.Uint16(header[4:]))
+	if l < 2 || l > 254 {
+		return nil, fmt.Errorf("the response has an invalid length: %v", l)
+	}
+	pdu := make([]byte, l-1)
+	if _, err := io.ReadFull(c.conn, pdu); err != nil {
+		return nil, err
+	}
+
+	switch pdu[0] {
+	case fn:
+	case fn | 0x80:
+		if len(pdu) < 2 {
+			return nil, errors.New("the exception response doesn't have the code")
+		}
+		return nil, &modbusExceptionError{function: fn, code: pdu[1]}
+	default:
+		return nil, fmt.Errorf("the response has function %v but %v is expected", pdu[0], fn)
+	}
+
+	n := int(count) * 2
+	if table.isBit() {
+		n = (int(count) + 7) / 8
+	}
+	if len(pdu) < 2 || int(pdu[1]) != n || len(pdu) != n+2 {
+		return nil, fmt.Errorf("the response should have %v bytes of data", n)
+	}
+	return pdu[2:], nil
+}
+
+func (c *modbusConn) Close() error {
+	return c.conn.Close()
+}
+
+// modbusGroup is a range of registers or bits read by a request.
+type modbusGroup struct {
+	name   string
+	table  modbusTable
+	start  uint16
+	count  uint16
+	layout *frame.Layout
+}
+
+// modbusSource polls register groups at an interval. By default, it emits a
+// tuple having fields of all groups for each poll cycle. A cycle is skipped
+// when reading any group fails. When perGroup is true, it emits a tuple for
+// each group instead, which has the name of the group in "group".
+//
+// The timestamp of a tuple is the time when the cycle starts.
+//
+// When the connection cannot be established or it's broken, GenerateStream
+// returns an error and the source is reconnected by the supervisor, which
+// can be configured by reconnect_ parameters of CREATE SOURCE. Exception
+// responses don't break the connection.
+type modbusSource struct {
+	address  string
+	unitID   byte
+	interval time.Duration
+	timeout  time.Duration
+	groups   []*modbusGroup
+	perGroup bool
+	ioParams *IOParams
+	stopCh   chan struct{}
+
+	// conn is the current connection. It's nil while disconnected.
+	conn *modbusConn
+}
+
+func (s *modbusSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	defer s.disconnect()
+
+	clock := ctx.Clock()
+	next := clock.Now()
+	for {
+		if err := s.poll(ctx, w, next); err != nil {
+			return err
+		}
+
+		now := clock.Now()
+		next = next.Add(s.interval)
+		if next.Before(now) {
+			// delayed too much and should be rescheduled.
+			next = now.Add(s.interval)
+		}
+		timer := clock.NewTimer(next.Sub(now))
+		select {
+		case <-s.stopCh:
+			timer.Stop()
+			return nil
+		case <-timer.C():
+		}
+	}
+}
+
+// poll runs a poll cycle. It returns errors from w and connection errors.
+func (s *modbusSource) poll(ctx *core.Context, w core.Writer, ts time.Time) error {
+	if s.conn == nil {
+		c, err := dialModbus(s.address, s.unitID, s.timeout)
+		if err != nil {
+			return fmt.Errorf("cannot connect to the Modbus server %v: %v", s.address, err)
+		}
+		s.conn = c
+	}
+
+	m := data.Map{}
+	for _, g := range s.groups {
+		b, err := s.conn.read(g.table, g.start, g.count)
+		if err != nil {
+			if _, ok := err.(*modbusExceptionError); !ok {
+				return fmt.Errorf("lost the connection to the Modbus server %v: %v", s.address, err)
+			}
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("group", g.name).Warning("Cannot read the register group")
+			if s.perGroup {
+				continue
+			}
+			return nil
+		}
+
+		if s.perGroup {
+			m = data.Map{"group": data.String(g.name)}
+		}
+		if err := g.layout.DecodeInto(b, m); err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("group", g.name).Warning("Cannot decode the register group")
+			if s.perGroup {
+				continue
+			}
+			return nil
+		}
+		if s.perGroup {
+			if err := s.write(ctx, w, ts, m); err != nil {
+				return err
+			}
+		}
+	}
+	if !s.perGroup {
+		return s.write(ctx, w, ts, m)
+	}
+	return nil
+}
+
+func (s *modbusSource) write(ctx *core.Context, w core.Writer, ts time.Time, m data.Map) error {
+	t := core.NewTuple(m)
+	t.Timestamp = ts
+	return w.Write(ctx, t)
+}
+
+func (s *modbusSource) disconnect() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *modbusSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+func (s *modbusSource) reconnectsBySupervisor() {}
+
+// createModbusSource creates a Modbus TCP source. It accepts following
+// parameters:
+//
+//	address: the address of the server such as "plc1:502" (required)
+//	unit_id: the unit identifier of the device (default: 1)
+//	interval: the interval between poll cycles (default: 1s)
+//	timeout: the timeout of connecting and of each request (default: 3s)
+//	emit: "cycle" to emit a tuple per cycle or "group" to emit a tuple per
+//	    register group (default: "cycle")
+//	groups: an array of register groups (required)
+//
+// A register group has following parameters:
+//
+//	name: the name of the group (required when emit is "group")
+//	table: "holding", "input", "coil", or "discrete" (required)
+//	start: the first address read (default: the lowest address of fields)
+//	count: the number of registers or bits read (default: the number
+//	    needed by fields)
+//	endian: the endian of bytes in each register (default: "big")
+//	fields: an array of fields (required)
+//
+// A field of "holding" and "input" has "register" having the address of its
+// first register and parameters of fields of frame.NewLayout except offset.
+// A field of "coil" and "discrete" has "name" and "address" and is Bool.
+func createModbusSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &modbusSource{
+		unitID:   1,
+		interval: defaultModbusInterval,
+		timeout:  defaultModbusTimeout,
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+	}
+
+	v, ok := params["address"]
+	if !ok {
+		return nil, errors.New("'address' parameter is missing")
+	}
+	addr, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'address' parameter must be a string: %v", err)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, defaultModbusPort)
+	}
+	s.address = addr
+
+	if v, ok := params["unit_id"]; ok {
+		id, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'unit_id' parameter must be an integer: %v", err)
+		}
+		if id < 0 || id > 255 {
+			return nil, fmt.Errorf("'unit_id' parameter must be in [0, 255]: %v", id)
+		}
+		s.unitID = byte(id)
+	}
+
+	for key, dst := range map[string]*time.Duration{
+		"interval": &s.interval,
+		"timeout":  &s.timeout,
+	} {
+		if v, ok := params[key]; ok {
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter should have a duration: %v", key, err)
+			}
+			if d <= 0 {
+				return nil, fmt.Errorf("'%v' parameter must be positive: %v", key, v)
+			}
+			*dst = d
+		}
+	}
+
+	if v, ok := params["emit"]; ok {
+		e, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'emit' parameter must be a string: %v", err)
+		}
+		switch strings.ToLower(e) {
+		case "cycle":
+		case "group":
+			s.perGroup = true
+		default:
+			return nil, fmt.Errorf("'emit' parameter must be cycle or group: %v", e)
+		}
+	}
+
+	v, ok = params["groups"]
+	if !ok {
+		return nil, errors.New("'groups' parameter is missing")
+	}
+	gs, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("'groups' parameter must be an array: %v", err)
+	}
+	if len(gs) == 0 {
+		return nil, errors.New("'groups' parameter must have at least one group")
+	}
+	for i, gv := range gs {
+		gm, err := data.AsMap(gv)
+		if err != nil {
+			return nil, fmt.Errorf("'groups' parameter has an invalid group at %v: %v", i, err)
+		}
+		g, err := newModbusGroup(gm)
+		if err != nil {
+			return nil, fmt.Errorf("'groups' parameter has an invalid group at %v: %v", i, err)
+		}
+		if s.perGroup && g.name == "" {
+			return nil, fmt.Errorf("'groups' parameter has a group without a name at %v", i)
+		}
+		s.groups = append(s.groups, g)
+	}
+	return s, nil
+}
+
+func newModbusGroup(m data.Map) (*modbusGroup, error) {
+	g := &modbusGroup{}
+	if v, ok := m["name"]; ok {
+		n, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("name must be a string: %v", err)
+		}
+		g.name = n
+	}
+
+	v, ok := m["table"]
+	if !ok {
+		return nil, errors.New("table is missing")
+	}
+	t, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("table must be a string: %v", err)
+	}
+	if g.table, ok = modbusTables[strings.ToLower(t)]; !ok {
+		return nil, fmt.Errorf("table must be one of holding, input, coil, and discrete: %v", t)
+	}
+
+	v, ok = m["fields"]
+	if !ok {
+		return nil, errors.New("fields is missing")
+	}
+	fs, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("fields must be an array: %v", err)
+	}
+	if len(fs) == 0 {
+		return nil, errors.New("fields must have at least one field")
+	}
+
+	addrKey := "register"
+	if g.table.isBit() {
+		addrKey = "address"
+	}
+	fields := make([]data.Map, len(fs))
+	addrs := make([]int64, len(fs))
+	start := int64(-1)
+	for i, fv := range fs {
+		f, err := data.AsMap(fv)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%v]: %v", i, err)
+		}
+		v, ok := f[addrKey]
+		if !ok {
+			return nil, fmt.Errorf("fields[%v]: %v is missing", i, addrKey)
+		}
+		a, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("fields[%v]: %v must be an integer: %v", i, addrKey, err)
+		}
+		if a < 0 || a > 0xffff {
+			return nil, fmt.Errorf("fields[%v]: %v must be in [0, 65535]: %v", i, addrKey, a)
+		}
+		if start < 0 || a < start {
+			start = a
+		}
+		fields[i], addrs[i] = f, a
+	}
+
+	if v, ok := m["start"]; ok {
+		s, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("start must be an integer: %v", err)
+		}
+		if s > start {
+			return nil, fmt.Errorf("start must not be greater than the lowest address of fields: %v", s)
+		}
+		if s < 0 {
+			return nil, fmt.Errorf("start must not be negative: %v", s)
+		}
+		start = s
+	}
+	g.start = uint16(start)
+
+	// Fields are converted to fields of frame.Layout having offsets from
+	// the start.
+	lfs := make(data.Array, len(fields))
+	for i, f := range fields {
+		off := addrs[i] - start
+		lf := data.Map{}
+		if g.table.isBit() {
+			for k := range f {
+				if k != "name" && k != "address" {
+					return nil, fmt.Errorf("fields[%v]: %v cannot be given for %v", i, k, strings.ToLower(t))
+				}
+			}
+			lf["name"] = f["name"]
+			lf["type"] = data.String("bool")
+			lf["offset"] = data.Int(off / 8)
+			lf["bit"] = data.Int(off % 8)
+		} else {
+			for k, v := range f {
+				if k == "offset" {
+					return nil, fmt.Errorf("fields[%v]: offset cannot be given, use register instead", i)
+				}
+				if k != addrKey {
+					lf[k] = v
+				}
+			}
+			lf["offset"] = data.Int(off * 2)
+		}
+		lfs[i] = lf
+	}
+	lp := data.Map{"fields": lfs}
+	if v, ok := m["endian"]; ok {
+		lp["endian"] = v
+	}
+	l, err := frame.NewLayout(lp)
+	if err != nil {
+		return nil, err
+	}
+
+	max, unit := maxModbusRegisters, 2
+	if g.table.isBit() {
+		max, unit = maxModbusBits, 1
+	}
+	var count int64
+	if g.table.isBit() {
+		for _, a := range addrs {
+			if a-start+1 > count {
+				count = a - start + 1
+			}
+		}
+	} else {
+		count = int64((l.Size + unit - 1) / unit)
+	}
+	if v, ok := m["count"]; ok {
+		c, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("count must be an integer: %v", err)
+		}
+		if c < count {
+			return nil, fmt.Errorf("count must be at least %v to have all fields: %v", count, c)
+		}
+		count = c
+	}
+	if count > int64(max) {
+		return nil, fmt.Errorf("count must not be greater than %v: %v", max, count)
+	}
+	if start+count > 0x10000 {
+		return nil, fmt.Errorf("the group exceeds the last address: %v + %v", start, count)
+	}
+	g.count = uint16(count)
+	g.layout = l
+	return g, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("modbus", SourceCreatorFunc(createModbusSource))
+}
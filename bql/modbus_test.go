@@ -0,0 +1,267 @@
+package bql
+
+import (
+	"encoding/binary"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeModbusServer serves holding registers and coils. Reading input
+// registers results in an exception.
+type fakeModbusServer struct {
+	l        net.Listener
+	holding  []uint16
+	coils    []bool
+	oneShot  bool
+	m        sync.Mutex
+	accepted int
+}
+
+func newFakeModbusServer() *fakeModbusServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	s := &fakeModbusServer{
+		l:       l,
+		holding: make([]uint16, 256),
+		coils:   make([]bool, 256),
+	}
+	go s.serve()
+	return s
+}
+
+func (s *fakeModbusServer) serve() {
+	for {
+		c, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		s.m.Lock()
+		s.accepted++
+		s.m.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *fakeModbusServer) handle(c net.Conn) {
+	defer c.Close()
+	for {
+		req := make([]byte, 12)
+		if _, err := io.ReadFull(c, req); err != nil {
+			return
+		}
+		fn := req[7]
+		start := int(binary.BigEndian.Uint16(req[8:]))
+		count := int(binary.BigEndian.Uint16(req[10:]))
+
+		var pdu []byte
+		switch fn {
+		case 1:
+			b := make([]byte, (count+7)/8)
+			for i := 0; i < count; i++ {
+				if s.coils[start+i] {
+					b[i/8] |= 1 << uint(i%8)
+				}
+			}
+			pdu = append([]byte{fn, byte(len(b))}, b...)
+		case 3:
+			pdu = []byte{fn, byte(count * 2)}
+			for i := 0; i < count; i++ {
+				pdu = append(pdu, byte(s.holding[start+i]>>8), byte(s.holding[start+i]))
+			}
+		default:
+			pdu = []byte{fn | 0x80, 2}
+		}
+		res := make([]byte, 7, 7+len(pdu))
+		copy(res, req[:4])
+		binary.BigEndian.PutUint16(res[4:], uint16(len(pdu)+1))
+		res[6] = req[6]
+		if _, err := c.Write(append(res, pdu...)); err != nil {
+			return
+		}
+		if s.oneShot {
+			return
+		}
+	}
+}
+
+func TestModbusSource(t *testing.T) {
+	Convey("Given a Modbus server", t, func() {
+		ctx := core.NewContext(nil)
+		srv := newFakeModbusServer()
+		Reset(func() {
+			srv.l.Close()
+		})
+		srv.holding[100] = 0xff38 // -200
+		srv.holding[101] = 0x0105 // flags
+		srv.holding[102] = 0x0000 // 10.0 in float32 with swapped words
+		srv.holding[103] = 0x4120
+		srv.coils[10] = true
+		srv.coils[17] = true
+
+		holding := data.Map{
+			"name":  data.String("meter"),
+			"table": data.String("holding"),
+			"fields": data.Array{
+				data.Map{"name": data.String("temp"), "register": data.Int(100),
+					"type": data.String("int16"), "scale": data.Float(0.1)},
+				data.Map{"name": data.String("flags.run"), "register": data.Int(101),
+					"type": data.String("uint16"), "bit": data.Int(8)},
+				data.Map{"name": data.String("flags.mode"), "register": data.Int(101),
+					"type": data.String("uint16"), "bit": data.Int(0), "bits": data.Int(4)},
+				data.Map{"name": data.String("power"), "register": data.Int(102),
+					"type": data.String("float32"), "word_swap": data.True},
+			},
+		}
+		coils := data.Map{
+			"name":  data.String("relays"),
+			"table": data.String("coil"),
+			"fields": data.Array{
+				data.Map{"name": data.String("relay1"), "address": data.Int(10)},
+				data.Map{"name": data.String("relay2"), "address": data.Int(11)},
+				data.Map{"name": data.String("relay8"), "address": data.Int(17)},
+			},
+		}
+		params := data.Map{
+			"address":  data.String(srv.l.Addr().String()),
+			"interval": data.String("1ms"),
+			"groups":   data.Array{holding, coils},
+		}
+
+		read := func(n int) []*core.Tuple {
+			src, err := createModbusSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			s := core.NewSupervisedSource(src, &core.SupervisorConfig{
+				InitialBackoff: time.Millisecond,
+			})
+			ch := make(chan *core.Tuple, n)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					select {
+					case ch <- t:
+					default:
+					}
+					return nil
+				}))
+			}()
+			var ts []*core.Tuple
+			for i := 0; i < n; i++ {
+				ts = append(ts, <-ch)
+			}
+			So(s.Stop(ctx), ShouldBeNil)
+			So(<-done, ShouldBeNil)
+			return ts
+		}
+
+		Convey("When polling it per cycle", func() {
+			ts := read(2)
+
+			Convey("Then each tuple should have all groups", func() {
+				for _, t := range ts {
+					So(t.Data["temp"], ShouldAlmostEqual, -20.0, 1e-9)
+					So(t.Data["flags"], ShouldResemble, data.Map{
+						"run":  data.Int(1),
+						"mode": data.Int(5),
+					})
+					So(t.Data["power"], ShouldEqual, data.Float(10))
+					So(t.Data["relay1"], ShouldEqual, data.True)
+					So(t.Data["relay2"], ShouldEqual, data.False)
+					So(t.Data["relay8"], ShouldEqual, data.True)
+				}
+			})
+
+			Convey("Then the connection should be reused", func() {
+				srv.m.Lock()
+				defer srv.m.Unlock()
+				So(srv.accepted, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When polling it per group with a failing group", func() {
+			params["emit"] = data.String("group")
+			params["groups"] = data.Array{holding, data.Map{
+				"name":   data.String("inputs"),
+				"table":  data.String("input"),
+				"fields": data.Array{data.Map{"name": data.String("a"), "register": data.Int(0), "type": data.String("uint16")}},
+			}, coils}
+			ts := read(4)
+
+			Convey("Then tuples of other groups should be emitted", func() {
+				for i, t := range ts {
+					g := []string{"meter", "relays"}[i%2]
+					So(t.Data["group"], ShouldEqual, data.String(g))
+				}
+				So(ts[1].Data, ShouldResemble, data.Map{
+					"group":  data.String("relays"),
+					"relay1": data.True,
+					"relay2": data.False,
+					"relay8": data.True,
+				})
+			})
+		})
+
+		Convey("When the server closes connections", func() {
+			srv.oneShot = true
+			params["groups"] = data.Array{holding}
+			ts := read(2)
+
+			Convey("Then the source should be reconnected", func() {
+				So(ts[1].Data["power"], ShouldEqual, data.Float(10))
+				srv.m.Lock()
+				defer srv.m.Unlock()
+				So(srv.accepted, ShouldBeGreaterThanOrEqualTo, 2)
+			})
+		})
+
+		Convey("When the server isn't available without the supervisor", func() {
+			srv.l.Close()
+			s, err := createModbusSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			err = s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				return nil
+			}))
+
+			Convey("Then GenerateStream should return the error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating sources with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{"unit_id": data.Int(256)},
+					{"interval": data.Int(0)},
+					{"emit": data.String("tuple")},
+					{"groups": data.Array{}},
+					{"groups": data.Array{data.Map{"table": data.String("file"), "fields": data.Array{}}}},
+					{"emit": data.String("group"), "groups": data.Array{data.Map{"table": data.String("coil"), "fields": coils["fields"]}}},
+					{"groups": data.Array{data.Map{"table": data.String("holding"), "fields": data.Array{
+						data.Map{"name": data.String("a"), "type": data.String("int16")}}}}},
+					{"groups": data.Array{data.Map{"table": data.String("holding"), "fields": data.Array{
+						data.Map{"name": data.String("a"), "register": data.Int(0), "offset": data.Int(0), "type": data.String("int16")}}}}},
+					{"groups": data.Array{data.Map{"table": data.String("holding"), "start": data.Int(5), "fields": data.Array{
+						data.Map{"name": data.String("a"), "register": data.Int(0), "type": data.String("int16")}}}}},
+					{"groups": data.Array{data.Map{"table": data.String("holding"), "count": data.Int(126), "fields": data.Array{
+						data.Map{"name": data.String("a"), "register": data.Int(0), "type": data.String("int16")}}}}},
+					{"groups": data.Array{data.Map{"table": data.String("holding"), "fields": data.Array{
+						data.Map{"name": data.String("a"), "register": data.Int(65535), "type": data.String("int32")}}}}},
+					{"groups": data.Array{data.Map{"table": data.String("coil"), "fields": data.Array{
+						data.Map{"name": data.String("a"), "address": data.Int(0), "type": data.String("int16")}}}}},
+				} {
+					ps := params.Copy()
+					for k, v := range p {
+						ps[k] = v
+					}
+					_, err := createModbusSource(ctx, &IOParams{}, ps)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
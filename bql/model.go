@@ -0,0 +1,499 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tensor is a dense float32 tensor exchanged with a Model. Data has elements
+// in row-major order.
+type Tensor struct {
+	Shape []int
+	Data  []float32
+}
+
+// Model is a machine learning model used by a model scoring box. Predict
+// may be called concurrently.
+type Model interface {
+	// Predict runs the model with input tensors keyed by their names and
+	// returns output tensors keyed by their names. The first dimension of
+	// all tensors is the batch size.
+	Predict(ctx *core.Context, inputs map[string]*Tensor) (map[string]*Tensor, error)
+
+	// Close releases resources of the model.
+	Close(ctx *core.Context) error
+}
+
+// ModelLoader loads a Model from a file. Loaders are registered by
+// RegisterModelLoader, typically from an init function of a package
+// embedding an inference runtime.
+type ModelLoader interface {
+	// LoadModel loads a model. params has all parameters given to the state
+	// or the box loading the model so that loaders can accept their own
+	// options.
+	LoadModel(ctx *core.Context, path string, params data.Map) (Model, error)
+}
+
+var (
+	modelLoadersMutex sync.RWMutex
+	modelLoaders      = map[string]ModelLoader{}
+)
+
+// RegisterModelLoader registers a ModelLoader for the format such as "onnx".
+// The format is case-insensitive and also used as the file extension to
+// detect the format of a model.
+func RegisterModelLoader(format string, l ModelLoader) error {
+	modelLoadersMutex.Lock()
+	defer modelLoadersMutex.Unlock()
+	f := strings.ToLower(format)
+	if _, ok := modelLoaders[f]; ok {
+		return fmt.Errorf("model loader for '%v' is already registered", format)
+	}
+	modelLoaders[f] = l
+	return nil
+}
+
+// MustRegisterModelLoader is like RegisterModelLoader but panics if an error
+// occurred.
+func MustRegisterModelLoader(format string, l ModelLoader) {
+	if err := RegisterModelLoader(format, l); err != nil {
+		panic(fmt.Errorf("bql.MustRegisterModelLoader: cannot register '%v': %v", format, err))
+	}
+}
+
+// LookupModelLoader returns the ModelLoader for the format. It returns
+// core.NotExistError when no loader is registered for the format.
+func LookupModelLoader(format string) (ModelLoader, error) {
+	modelLoadersMutex.RLock()
+	defer modelLoadersMutex.RUnlock()
+	l, ok := modelLoaders[strings.ToLower(format)]
+	if !ok {
+		return nil, core.NotExistError(fmt.Errorf("model loader for '%v' is not registered", format))
+	}
+	return l, nil
+}
+
+// ModelFormats returns formats having registered loaders in sorted order.
+func ModelFormats() []string {
+	modelLoadersMutex.RLock()
+	defer modelLoadersMutex.RUnlock()
+	fs := make([]string, 0, len(modelLoaders))
+	for f := range modelLoaders {
+		fs = append(fs, f)
+	}
+	sort.Strings(fs)
+	return fs
+}
+
+// loadModel loads a model from the file given by the "model" parameter. The
+// format is given by the "format" parameter or detected from the extension.
+func loadModel(ctx *core.Context, params data.Map) (Model, error) {
+	v, ok := params["model"]
+	if !ok {
+		return nil, errors.New("'model' parameter is missing")
+	}
+	path, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("'model' parameter must be a string: %v", err)
+	}
+
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	if v, ok := params["format"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'format' parameter must be a string: %v", err)
+		}
+		format = f
+	}
+	if format == "" {
+		return nil, errors.New("'format' parameter is required when the model file doesn't have an extension")
+	}
+
+	l, err := LookupModelLoader(format)
+	if err != nil {
+		return nil, err
+	}
+	m, err := l.LoadModel(ctx, path, params)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the model %v: %v", path, err)
+	}
+	return m, nil
+}
+
+// modelState is a SharedState having a Model so that the model can be shared
+// by multiple boxes:
+//
+//	CREATE STATE m TYPE model WITH model="/path/to/model.onnx";
+type modelState struct {
+	model Model
+}
+
+var (
+	_ core.SharedState = &modelState{}
+)
+
+func createModelState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	m, err := loadModel(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &modelState{model: m}, nil
+}
+
+func (s *modelState) Terminate(ctx *core.Context) error {
+	return s.model.Close(ctx)
+}
+
+// modelInput maps fields of a tuple to a row of an input tensor. The value of
+// each field must be a number or an array of numbers, which is flattened.
+type modelInput struct {
+	name   string
+	fields []data.Path
+}
+
+// modelOutput maps a row of an output tensor to a field of a tuple. A row
+// having one element is written as a float and others are written as an
+// array of floats.
+type modelOutput struct {
+	name  string
+	field data.Path
+}
+
+// modelBox scores tuples with a Model. It's created by
+//
+//	CREATE BOX b TYPE model_score FROM s WITH
+//	    model="/path/to/model.onnx",
+//	    inputs={"input": ["x", "y", "z"]},
+//	    outputs={"output": "prediction"},
+//	    batch_size=32, batch_interval=0.1;
+//
+// It has following parameters:
+//
+//	model: the path to the model file. Either model or state is required.
+//	state: the name of a model state created by CREATE STATE ... TYPE model.
+//	format: the format of the model (default: the extension of model).
+//	inputs: a map from names of input tensors to arrays of field paths.
+//	outputs: a map from names of output tensors to field paths.
+//	batch_size: the maximum number of tuples scored at once (default: 1).
+//	batch_interval: the maximum duration tuples wait for a batch to be full
+//	    (default: 0, which means tuples wait until batch_size tuples arrive).
+//
+// Output tuples have the same order as input tuples.
+type modelBox struct {
+	model      Model
+	ownsModel  bool
+	inputs     []*modelInput
+	outputs    []*modelOutput
+	batchSize  int
+	batchIntvl time.Duration
+
+	m          sync.Mutex
+	batch      []*core.Tuple
+	lastWriter core.Writer
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+var (
+	_ core.StatefulBox = &modelBox{}
+)
+
+func createModelBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	b := &modelBox{
+		batchSize: 1,
+	}
+	if err := b.parseParams(params); err != nil {
+		return nil, err
+	}
+
+	if v, ok := params["state"]; ok {
+		name, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'state' parameter must be a string: %v", err)
+		}
+		s, err := ctx.SharedStates.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		ms, ok := s.(*modelState)
+		if !ok {
+			return nil, fmt.Errorf("the state '%v' isn't a model", name)
+		}
+		b.model = ms.model
+	} else {
+		m, err := loadModel(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		b.model = m
+		b.ownsModel = true
+	}
+	return b, nil
+}
+
+func (b *modelBox) parseParams(params data.Map) error {
+	v, ok := params["inputs"]
+	if !ok {
+		return errors.New("'inputs' parameter is missing")
+	}
+	ins, err := data.AsMap(v)
+	if err != nil {
+		return fmt.Errorf("'inputs' parameter must be a map: %v", err)
+	}
+	for name, v := range ins {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return fmt.Errorf("fields of the input '%v' must be an array: %v", name, err)
+		}
+		in := &modelInput{name: name}
+		for _, f := range a {
+			s, err := data.AsString(f)
+			if err != nil {
+				return fmt.Errorf("a field of the input '%v' must be a string: %v", name, err)
+			}
+			p, err := data.CompilePath(s)
+			if err != nil {
+				return fmt.Errorf("the input '%v' has an invalid path: %v", name, err)
+			}
+			in.fields = append(in.fields, p)
+		}
+		if len(in.fields) == 0 {
+			return fmt.Errorf("the input '%v' doesn't have any field", name)
+		}
+		b.inputs = append(b.inputs, in)
+	}
+	if len(b.inputs) == 0 {
+		return errors.New("'inputs' parameter must have at least one input")
+	}
+
+	v, ok = params["outputs"]
+	if !ok {
+		return errors.New("'outputs' parameter is missing")
+	}
+	outs, err := data.AsMap(v)
+	if err != nil {
+		return fmt.Errorf("'outputs' parameter must be a map: %v", err)
+	}
+	for name, v := range outs {
+		s, err := data.AsString(v)
+		if err != nil {
+			return fmt.Errorf("the field of the output '%v' must be a string: %v", name, err)
+		}
+		p, err := data.CompilePath(s)
+		if err != nil {
+			return fmt.Errorf("the output '%v' has an invalid path: %v", name, err)
+		}
+		b.outputs = append(b.outputs, &modelOutput{name: name, field: p})
+	}
+	if len(b.outputs) == 0 {
+		return errors.New("'outputs' parameter must have at least one output")
+	}
+
+	if v, ok := params["batch_size"]; ok {
+		n, err := data.AsInt(v)
+		if err != nil {
+			return fmt.Errorf("'batch_size' parameter must be an integer: %v", err)
+		}
+		if n < 1 {
+			return fmt.Errorf("'batch_size' parameter must be positive: %v", n)
+		}
+		b.batchSize = int(n)
+	}
+	if v, ok := params["batch_interval"]; ok {
+		d, err := data.ToDuration(v)
+		if err != nil {
+			return fmt.Errorf("'batch_interval' parameter should have a duration: %v", err)
+		}
+		b.batchIntvl = d
+	}
+	return nil
+}
+
+func (b *modelBox) Init(ctx *core.Context) error {
+	if b.batchSize > 1 && b.batchIntvl > 0 {
+		b.stopCh = make(chan struct{})
+		b.wg.Add(1)
+		go b.flushPeriodically(ctx)
+	}
+	return nil
+}
+
+func (b *modelBox) flushPeriodically(ctx *core.Context) {
+	defer b.wg.Done()
+	ticker := ctx.Clock().NewTicker(b.batchIntvl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C():
+		}
+
+		b.m.Lock()
+		if len(b.batch) > 0 && b.lastWriter != nil {
+			if err := b.flush(ctx, b.lastWriter); err != nil {
+				ctx.ErrLog(err).WithField("node_type", "box").Error("Cannot score tuples")
+			}
+		}
+		b.m.Unlock()
+	}
+}
+
+func (b *modelBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.batch = append(b.batch, t)
+	b.lastWriter = w
+	if len(b.batch) < b.batchSize {
+		return nil
+	}
+	return b.flush(ctx, w)
+}
+
+// flush scores all tuples in the batch and writes them. The caller must
+// acquire the lock.
+func (b *modelBox) flush(ctx *core.Context, w core.Writer) error {
+	batch := b.batch
+	b.batch = nil
+
+	inputs := make(map[string]*Tensor, len(b.inputs))
+	for _, in := range b.inputs {
+		t, err := in.tensor(batch)
+		if err != nil {
+			return err
+		}
+		inputs[in.name] = t
+	}
+	outputs, err := b.model.Predict(ctx, inputs)
+	if err != nil {
+		return err
+	}
+
+	for _, out := range b.outputs {
+		t, ok := outputs[out.name]
+		if !ok {
+			return fmt.Errorf("the model doesn't have the output '%v'", out.name)
+		}
+		if len(t.Shape) == 0 || t.Shape[0] != len(batch) || len(t.Data)%len(batch) != 0 {
+			return fmt.Errorf("the output '%v' has an invalid shape for %v tuples: %v", out.name, len(batch), t.Shape)
+		}
+	}
+
+	for i, tu := range batch {
+		if tu.Flags.IsSet(core.TFSharedData) {
+			tu.Data = tu.Data.Copy()
+			tu.Flags.Clear(core.TFSharedData)
+		}
+		for _, out := range b.outputs {
+			t := outputs[out.name]
+			n := len(t.Data) / len(batch)
+			row := t.Data[i*n : (i+1)*n]
+			var v data.Value
+			if n == 1 {
+				v = data.Float(row[0])
+			} else {
+				a := make(data.Array, n)
+				for j, f := range row {
+					a[j] = data.Float(f)
+				}
+				v = a
+			}
+			if err := tu.Data.Set(out.field, v); err != nil {
+				return fmt.Errorf("cannot write the output '%v': %v", out.name, err)
+			}
+		}
+		if err := w.Write(ctx, tu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tensor creates a tensor having a row for each tuple.
+func (in *modelInput) tensor(batch []*core.Tuple) (*Tensor, error) {
+	t := &Tensor{}
+	width := -1
+	for _, tu := range batch {
+		n := len(t.Data)
+		for _, p := range in.fields {
+			v, err := tu.Data.Get(p)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read a field of the input '%v': %v", in.name, err)
+			}
+			if t.Data, err = appendFloats(t.Data, v); err != nil {
+				return nil, fmt.Errorf("a field of the input '%v' has an invalid value: %v", in.name, err)
+			}
+		}
+		if w := len(t.Data) - n; width < 0 {
+			width = w
+		} else if w != width {
+			return nil, fmt.Errorf("rows of the input '%v' have different lengths: %v and %v", in.name, width, w)
+		}
+	}
+	t.Shape = []int{len(batch), width}
+	return t, nil
+}
+
+func appendFloats(fs []float32, v data.Value) ([]float32, error) {
+	if v.Type() == data.TypeArray {
+		a, _ := data.AsArray(v)
+		for _, e := range a {
+			var err error
+			if fs, err = appendFloats(fs, e); err != nil {
+				return nil, err
+			}
+		}
+		return fs, nil
+	}
+	f, err := data.ToFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(fs, float32(f)), nil
+}
+
+func (b *modelBox) Terminate(ctx *core.Context) error {
+	if b.stopCh != nil {
+		close(b.stopCh)
+		b.wg.Wait()
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	if len(b.batch) > 0 {
+		ctx.Log().WithFields(logrus.Fields{
+			"node_type":   "box",
+			"num_dropped": len(b.batch),
+		}).Warn("Tuples waiting for a batch were dropped")
+		b.batch = nil
+	}
+	if b.ownsModel {
+		return b.model.Close(ctx)
+	}
+	return nil
+}
+
+// Status returns the status of the box.
+func (b *modelBox) Status() data.Map {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return data.Map{
+		"batch_size":   data.Int(b.batchSize),
+		"num_batched":  data.Int(len(b.batch)),
+		"shared_model": data.Bool(!b.ownsModel),
+	}
+}
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("model", udf.UDSCreatorFunc(createModelState))
+	MustRegisterGlobalBoxCreator("model_score", BoxCreatorFunc(createModelBox))
+}
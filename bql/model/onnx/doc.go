@@ -0,0 +1,14 @@
+// Package onnx provides a ModelLoader for ONNX models based on ONNX Runtime.
+// Since it requires cgo and the shared library of ONNX Runtime, the loader is
+// only built with the onnx build tag:
+//
+//	go build -tags onnx
+//
+// Import this package for side effects to load .onnx files in model states
+// and model scoring boxes:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/model/onnx"
+//
+// The path to the shared library is given by ONNXRUNTIME_LIB environment
+// variable. Input and output tensors must have float32 elements.
+package onnx
@@ -0,0 +1,128 @@
+//go:build onnx
+// +build onnx
+
+package onnx
+
+import (
+	"fmt"
+	ort "github.com/yalue/onnxruntime_go"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"os"
+	"sync"
+)
+
+// LibraryEnv is the name of the environment variable having the path to the
+// shared library of ONNX Runtime.
+const LibraryEnv = "ONNXRUNTIME_LIB"
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+func initialize() error {
+	initOnce.Do(func() {
+		if p := os.Getenv(LibraryEnv); p != "" {
+			ort.SetSharedLibraryPath(p)
+		}
+		initErr = ort.InitializeEnvironment()
+	})
+	return initErr
+}
+
+// Loader is a bql.ModelLoader for ONNX models.
+type Loader struct{}
+
+// LoadModel creates a session of the model. It has inputs and outputs
+// declared in the model.
+func (Loader) LoadModel(ctx *core.Context, path string, params data.Map) (bql.Model, error) {
+	if err := initialize(); err != nil {
+		return nil, fmt.Errorf("cannot initialize ONNX Runtime: %v", err)
+	}
+	ins, outs, err := ort.GetInputOutputInfo(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &model{}
+	for _, i := range ins {
+		m.inputs = append(m.inputs, i.Name)
+	}
+	for _, o := range outs {
+		m.outputs = append(m.outputs, o.Name)
+	}
+	s, err := ort.NewDynamicAdvancedSession(path, m.inputs, m.outputs, nil)
+	if err != nil {
+		return nil, err
+	}
+	m.session = s
+	return m, nil
+}
+
+type model struct {
+	session *ort.DynamicAdvancedSession
+	inputs  []string
+	outputs []string
+}
+
+func (m *model) Predict(ctx *core.Context, inputs map[string]*bql.Tensor) (map[string]*bql.Tensor, error) {
+	ins := make([]ort.Value, len(m.inputs))
+	defer destroyAll(ins)
+	for i, n := range m.inputs {
+		t, ok := inputs[n]
+		if !ok {
+			return nil, fmt.Errorf("the input '%v' is missing", n)
+		}
+		shape := make([]int64, len(t.Shape))
+		for j, d := range t.Shape {
+			shape[j] = int64(d)
+		}
+		v, err := ort.NewTensor(ort.NewShape(shape...), t.Data)
+		if err != nil {
+			return nil, err
+		}
+		ins[i] = v
+	}
+
+	// nil outputs are allocated by ONNX Runtime.
+	outs := make([]ort.Value, len(m.outputs))
+	defer destroyAll(outs)
+	if err := m.session.Run(ins, outs); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*bql.Tensor, len(outs))
+	for i, o := range outs {
+		t, ok := o.(*ort.Tensor[float32])
+		if !ok {
+			return nil, fmt.Errorf("the output '%v' doesn't have float32 elements", m.outputs[i])
+		}
+		shape := t.GetShape()
+		s := make([]int, len(shape))
+		for j, d := range shape {
+			s[j] = int(d)
+		}
+		// GetData refers to the memory owned by the tensor.
+		d := make([]float32, len(t.GetData()))
+		copy(d, t.GetData())
+		res[m.outputs[i]] = &bql.Tensor{Shape: s, Data: d}
+	}
+	return res, nil
+}
+
+func destroyAll(vs []ort.Value) {
+	for _, v := range vs {
+		if v != nil {
+			v.Destroy()
+		}
+	}
+}
+
+func (m *model) Close(ctx *core.Context) error {
+	return m.session.Destroy()
+}
+
+func init() {
+	bql.MustRegisterModelLoader("onnx", Loader{})
+}
@@ -0,0 +1,212 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+)
+
+// testModel computes the sum of each row of the "x" input as the "sum"
+// output and the row itself doubled as the "double" output.
+type testModel struct {
+	m       sync.Mutex
+	batches []int
+	closed  bool
+}
+
+func (m *testModel) Predict(ctx *core.Context, inputs map[string]*Tensor) (map[string]*Tensor, error) {
+	x, ok := inputs["x"]
+	if !ok {
+		return nil, errors.New("x is missing")
+	}
+	n, w := x.Shape[0], x.Shape[1]
+	m.m.Lock()
+	m.batches = append(m.batches, n)
+	m.m.Unlock()
+
+	sum := &Tensor{Shape: []int{n, 1}, Data: make([]float32, n)}
+	double := &Tensor{Shape: []int{n, w}, Data: make([]float32, n*w)}
+	for i := 0; i < n; i++ {
+		for j := 0; j < w; j++ {
+			sum.Data[i] += x.Data[i*w+j]
+			double.Data[i*w+j] = x.Data[i*w+j] * 2
+		}
+	}
+	return map[string]*Tensor{"sum": sum, "double": double}, nil
+}
+
+func (m *testModel) Close(ctx *core.Context) error {
+	m.closed = true
+	return nil
+}
+
+func (m *testModel) numBatches() int {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return len(m.batches)
+}
+
+type testModelLoader struct {
+	models map[string]*testModel
+}
+
+func (l *testModelLoader) LoadModel(ctx *core.Context, path string, params data.Map) (Model, error) {
+	m, ok := l.models[path]
+	if !ok {
+		return nil, errors.New("no such model")
+	}
+	return m, nil
+}
+
+var (
+	testModels = &testModelLoader{models: map[string]*testModel{}}
+)
+
+func init() {
+	MustRegisterModelLoader("test_model", testModels)
+}
+
+func TestModelBox(t *testing.T) {
+	Convey("Given a topology builder and a model", t, func() {
+		m := &testModel{}
+		testModels.models["/models/sum.test_model"] = m
+		Reset(func() {
+			delete(testModels.models, "/models/sum.test_model")
+		})
+
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+			CREATE STREAM s AS SELECT RSTREAM int AS a, [int, int * 10] AS b FROM source [RANGE 1 TUPLES];`), ShouldBeNil)
+
+		run := func(params string) *tupleCollectorSink {
+			So(addBQLToTopology(tb, `
+				CREATE BOX b TYPE model_score FROM s WITH `+params+`;
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM b;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sn, err := tp.Sink("snk")
+			So(err, ShouldBeNil)
+			return sn.Sink().(*tupleCollectorSink)
+		}
+
+		Convey("When scoring tuples one by one", func() {
+			si := run(`model="/models/sum.test_model", inputs={"x": ["a", "b"]},
+				outputs={"sum": "pred.sum", "double": "pred.double"}`)
+
+			Convey("Then predictions should be written to tuples", func() {
+				si.Wait(4)
+				So(si.get(0).Data, ShouldResemble, data.Map{
+					"a": data.Int(1),
+					"b": data.Array{data.Int(1), data.Int(10)},
+					"pred": data.Map{
+						"sum":    data.Float(12),
+						"double": data.Array{data.Float(2), data.Float(2), data.Float(20)},
+					},
+				})
+				So(m.numBatches(), ShouldEqual, 4)
+			})
+		})
+
+		Convey("When scoring tuples in batches", func() {
+			si := run(`model="/models/sum.test_model", inputs={"x": ["a"]}, outputs={"sum": "sum"}, batch_size=2`)
+
+			Convey("Then tuples should be scored together in order", func() {
+				si.Wait(4)
+				for i := 0; i < 4; i++ {
+					So(si.get(i).Data["sum"], ShouldEqual, data.Float(i+1))
+				}
+				So(m.batches, ShouldResemble, []int{2, 2})
+			})
+		})
+
+		Convey("When a batch isn't full", func() {
+			si := run(`model="/models/sum.test_model", inputs={"x": ["a"]}, outputs={"sum": "sum"},
+				batch_size=3, batch_interval=0.01`)
+
+			Convey("Then remaining tuples should be scored after the interval", func() {
+				si.Wait(4)
+				So(si.len(), ShouldEqual, 4)
+				So(si.get(3).Data["sum"], ShouldEqual, data.Float(4))
+			})
+		})
+
+		Convey("When scoring with a model state", func() {
+			So(addBQLToTopology(tb, `CREATE STATE m TYPE model WITH model="/models/sum.test_model";`), ShouldBeNil)
+			si := run(`state="m", inputs={"x": ["a"]}, outputs={"sum": "sum"}`)
+
+			Convey("Then the shared model should be used", func() {
+				si.Wait(4)
+				So(si.get(1).Data["sum"], ShouldEqual, data.Float(2))
+			})
+
+			Convey("Then removing the box shouldn't close the model", func() {
+				So(tp.Remove("b"), ShouldBeNil)
+				So(m.closed, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the box owning the model is removed", func() {
+			So(addBQLToTopology(tb, `CREATE BOX b TYPE model_score FROM s WITH
+				model="/models/sum.test_model", inputs={"x": ["a"]}, outputs={"sum": "sum"};`), ShouldBeNil)
+			So(tp.Remove("b"), ShouldBeNil)
+
+			Convey("Then the model should be closed", func() {
+				So(m.closed, ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating a box with invalid parameters", func() {
+			for _, params := range []string{
+				`inputs={"x": ["a"]}, outputs={"sum": "sum"}`,
+				`model="/models/missing.test_model", inputs={"x": ["a"]}, outputs={"sum": "sum"}`,
+				`model="/models/sum.unknown", inputs={"x": ["a"]}, outputs={"sum": "sum"}`,
+				`model="/models/sum.test_model", outputs={"sum": "sum"}`,
+				`model="/models/sum.test_model", inputs={"x": []}, outputs={"sum": "sum"}`,
+				`model="/models/sum.test_model", inputs={"x": ["a"]}`,
+				`model="/models/sum.test_model", inputs={"x": ["a"]}, outputs={"sum": "sum"}, batch_size=0`,
+				`state="no_such_state", inputs={"x": ["a"]}, outputs={"sum": "sum"}`,
+			} {
+				err := addBQLToTopology(tb, `CREATE BOX b TYPE model_score FROM s WITH `+params+`;`)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestModelLoaderRegistry(t *testing.T) {
+	Convey("Given the model loader registry", t, func() {
+		Convey("When looking up a registered loader", func() {
+			_, err := LookupModelLoader("TEST_MODEL")
+
+			Convey("Then it should be found case-insensitively", func() {
+				So(err, ShouldBeNil)
+				So(ModelFormats(), ShouldContain, "test_model")
+			})
+		})
+
+		Convey("When looking up a missing loader", func() {
+			_, err := LookupModelLoader("pmml")
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When registering the same format again", func() {
+			err := RegisterModelLoader("test_model", testModels)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,308 @@
+package bql
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/nmea"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultGPSDPort               = "2947"
+	nmeaDialTimeout               = 10 * time.Second
+	nmeaMaxSentenceLength         = 4096
+	gpsdWatchCommand              = `?WATCH={"enable":true,"nmea":true};` + "\n"
+	gpsdReportPrefix              = "{"
+	nmeaMalformedSentenceLogLimit = 80
+)
+
+// nmeaSource reads NMEA 0183 sentences from a serial port or gpsd and emits a
+// tuple for each sentence having a position or a velocity. Fields of tuples
+// are described in nmea.Decoder. When a device is a regular file such as a
+// log recorded from a receiver, the source stops at the end of the file.
+//
+// The timestamp of a tuple is the time in the sentence so that it can be used
+// as the event time. The time when the tuple is emitted is used when the
+// sentence doesn't have the time.
+//
+// When reading fails, GenerateStream returns an error and the source is
+// reconnected by the supervisor, which can be configured by reconnect_
+// parameters of CREATE SOURCE. Sentences sent while it's disconnected are
+// lost.
+type nmeaSource struct {
+	device      string
+	config      *serialConfig
+	gpsd        string
+	types       map[string]bool
+	skipInvalid bool
+	ioParams    *IOParams
+	stopCh      chan struct{}
+
+	m       sync.Mutex
+	stopped bool
+
+	// conn is the device or the connection being read. It's closed by Stop
+	// to abort reading.
+	conn io.Closer
+}
+
+func (s *nmeaSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	r, regular, err := s.open()
+	if err == nil {
+		var writeErr error
+		writeErr, err = s.read(ctx, w, r)
+		s.close()
+		if writeErr != nil {
+			return writeErr
+		}
+		if err == nil && regular {
+			return nil
+		}
+	}
+
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	if s.gpsd != "" {
+		return fmt.Errorf("lost the connection to gpsd %v: %v", s.gpsd, err)
+	}
+	return fmt.Errorf("cannot read the device %v: %v", s.device, err)
+}
+
+// open opens the device or connects to gpsd. regular is true when the device
+// is a regular file.
+func (s *nmeaSource) open() (r io.ReadCloser, regular bool, err error) {
+	if s.gpsd != "" {
+		c, err := net.DialTimeout("tcp", s.gpsd, nmeaDialTimeout)
+		if err != nil {
+			return nil, false, err
+		}
+		// gpsd only sends raw sentences after this command.
+		if _, err := io.WriteString(c, gpsdWatchCommand); err != nil {
+			c.Close()
+			return nil, false, err
+		}
+		r = c
+	} else {
+		path, err := resolveSerialDevice(s.device)
+		if err != nil {
+			return nil, false, err
+		}
+		f, err := openSerialPort(path, os.O_RDONLY, s.config)
+		if err != nil {
+			return nil, false, err
+		}
+		if fi, err := f.Stat(); err == nil {
+			regular = fi.Mode().IsRegular()
+		}
+		r = f
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		r.Close()
+		return nil, false, errors.New("the source has already been stopped")
+	}
+	s.conn = r
+	return r, regular, nil
+}
+
+func (s *nmeaSource) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// read emits tuples until r reaches EOF or fails. It returns an error from w
+// as writeErr and an error from r as readErr.
+func (s *nmeaSource) read(ctx *core.Context, w core.Writer, r io.Reader) (writeErr, readErr error) {
+	// Since RMC and ZDA give dates to other sentences, all supported
+	// sentences are decoded even if they aren't emitted.
+	dec := &nmea.Decoder{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 256), nmeaMaxSentenceLength)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || s.gpsd != "" && strings.HasPrefix(line, gpsdReportPrefix) {
+			continue
+		}
+		sen, err := nmea.Parse(line)
+		if err == nil {
+			var m data.Map
+			m, err = dec.Decode(sen)
+			if err == nil {
+				if err := s.write(ctx, w, sen, m); err != nil {
+					return err, nil
+				}
+				continue
+			}
+		}
+		if err == nmea.ErrUnsupported {
+			continue
+		}
+		if len(line) > nmeaMalformedSentenceLogLimit {
+			line = line[:nmeaMalformedSentenceLogLimit] + "..."
+		}
+		ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+			WithField("body", line).Warning("Ignoring a malformed sentence")
+	}
+	return nil, sc.Err()
+}
+
+func (s *nmeaSource) write(ctx *core.Context, w core.Writer, sen *nmea.Sentence, m data.Map) error {
+	if !s.types[sen.Type] {
+		return nil
+	}
+	if v, _ := m["valid"].(data.Bool); s.skipInvalid && !bool(v) {
+		return nil
+	}
+	now := ctx.Clock().Now()
+	t := core.NewTuple(m)
+	t.ProcTimestamp = now
+	if ts, ok := m["time"].(data.Timestamp); ok {
+		t.Timestamp = time.Time(ts)
+	} else {
+		t.Timestamp = now
+	}
+	return w.Write(ctx, t)
+}
+
+func (s *nmeaSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	close(s.stopCh)
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	return nil
+}
+
+func (s *nmeaSource) reconnectsBySupervisor() {}
+
+// createNMEASource creates a source reading NMEA 0183 sentences. It accepts
+// following parameters:
+//
+//	device: the path of the serial port such as "/dev/ttyUSB0" or of a file
+//	    having recorded sentences. It can have wildcards like the serial
+//	    source.
+//	baud_rate, data_bits, parity, stop_bits: the configuration of the
+//	    serial port described in extractSerialConfig
+//	gpsd: the address of gpsd such as "localhost:2947"
+//	sentences: an array of types of sentences emitted, which are "GGA",
+//	    "GLL", "RMC", "VTG", and "ZDA" (default: all of them)
+//	skip_invalid: true to drop sentences without valid fixes
+//	    (default: false)
+//
+// Either device or gpsd is required.
+func createNMEASource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &nmeaSource{
+		types:    map[string]bool{},
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+	}
+
+	for key, dst := range map[string]*string{
+		"device": &s.device,
+		"gpsd":   &s.gpsd,
+	} {
+		if v, ok := params[key]; ok {
+			str, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter must be a string: %v", key, err)
+			}
+			if str == "" {
+				return nil, fmt.Errorf("'%v' parameter must not be empty", key)
+			}
+			*dst = str
+		}
+	}
+	if (s.device == "") == (s.gpsd == "") {
+		return nil, errors.New("either 'device' or 'gpsd' parameter is required")
+	}
+	if s.gpsd != "" {
+		if _, _, err := net.SplitHostPort(s.gpsd); err != nil {
+			s.gpsd = net.JoinHostPort(s.gpsd, defaultGPSDPort)
+		}
+	}
+
+	if s.device != "" {
+		if _, err := filepath.Match(s.device, ""); err != nil {
+			return nil, fmt.Errorf("'device' parameter has an invalid pattern: %v", err)
+		}
+	}
+	config, err := extractSerialConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil && s.device == "" {
+		return nil, errors.New("the serial port cannot be configured without 'device' parameter")
+	}
+	s.config = config
+
+	if v, ok := params["sentences"]; ok {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'sentences' parameter must be an array: %v", err)
+		}
+		if len(a) == 0 {
+			return nil, errors.New("'sentences' parameter must have at least one type")
+		}
+		for _, e := range a {
+			t, err := data.AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("'sentences' parameter must be an array of strings: %v", err)
+			}
+			t = strings.ToUpper(t)
+			supported := false
+			for _, st := range nmea.Types {
+				if st == t {
+					supported = true
+				}
+			}
+			if !supported {
+				return nil, fmt.Errorf("'sentences' parameter must have %v: %v",
+					strings.Join(nmea.Types, ", "), t)
+			}
+			s.types[t] = true
+		}
+	} else {
+		for _, t := range nmea.Types {
+			s.types[t] = true
+		}
+	}
+
+	if v, ok := params["skip_invalid"]; ok {
+		b, err := data.AsBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("'skip_invalid' parameter must be bool: %v", err)
+		}
+		s.skipInvalid = b
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("nmea", SourceCreatorFunc(createNMEASource))
+}
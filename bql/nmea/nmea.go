@@ -0,0 +1,434 @@
+// Package nmea parses NMEA 0183 sentences sent by GPS receivers and decodes
+// positions and velocities in them into data.Map. Latitudes and longitudes
+// are decoded as signed degrees so that they can directly be passed to
+// geospatial functions, and speeds are decoded in meters per second.
+package nmea
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// knot is a knot in meters per second.
+	knot = 1852.0 / 3600
+
+	// kmh is a kilometer per hour in meters per second.
+	kmh = 1000.0 / 3600
+)
+
+// ErrUnsupported is returned from Decoder.Decode when the type of a sentence
+// isn't supported.
+var ErrUnsupported = errors.New("nmea: unsupported sentence")
+
+// Types are types of sentences supported by Decoder.
+var Types = []string{"GGA", "GLL", "RMC", "VTG", "ZDA"}
+
+// Sentence is a parsed NMEA 0183 sentence such as
+// "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A".
+type Sentence struct {
+	// Talker is the talker ID such as "GP" or "GN". It's "P" for proprietary
+	// sentences.
+	Talker string
+
+	// Type is the type of the sentence such as "RMC".
+	Type string
+
+	// Fields are fields following the address field. The checksum isn't
+	// included.
+	Fields []string
+}
+
+// Parse parses a sentence. Leading and trailing white spaces including CR
+// and LF are ignored. The checksum is verified when the sentence has it.
+func Parse(line string) (*Sentence, error) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "$") {
+		return nil, errors.New("a sentence must start with '$'")
+	}
+	body := line[1:]
+	if i := strings.LastIndexByte(body, '*'); i >= 0 {
+		sum, err := strconv.ParseUint(body[i+1:], 16, 8)
+		if err != nil || len(body)-i-1 != 2 {
+			return nil, fmt.Errorf("invalid checksum: %v", body[i+1:])
+		}
+		body = body[:i]
+		var c byte
+		for j := 0; j < len(body); j++ {
+			c ^= body[j]
+		}
+		if byte(sum) != c {
+			return nil, fmt.Errorf("checksum mismatch: %02X is expected but %02X is given", c, sum)
+		}
+	}
+
+	fields := strings.Split(body, ",")
+	addr := fields[0]
+	for _, r := range addr {
+		if !('A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			return nil, fmt.Errorf("invalid address field: %v", addr)
+		}
+	}
+	s := &Sentence{
+		Fields: fields[1:],
+	}
+	switch {
+	case strings.HasPrefix(addr, "P") && len(addr) > 1:
+		s.Talker, s.Type = "P", addr[1:]
+	case len(addr) == 5:
+		s.Talker, s.Type = addr[:2], addr[2:]
+	default:
+		return nil, fmt.Errorf("invalid address field: %v", addr)
+	}
+	return s, nil
+}
+
+// Decoder decodes sentences into data.Map. A decoded map has "talker",
+// "sentence" having the type, and "valid" telling whether the receiver has a
+// valid fix. It also has following fields depending on the type, which are
+// omitted when they're empty in the sentence:
+//
+//	GGA: time, lat, lon, fix_quality, satellites, hdop, altitude,
+//	    geoid_separation
+//	GLL: time, lat, lon
+//	RMC: time, lat, lon, speed, course, magnetic_variation
+//	VTG: speed, course, course_magnetic
+//	ZDA: time
+//
+// time is a timestamp in UTC. Since GGA and GLL only have times of day, a
+// Decoder remembers the date of the last RMC or ZDA sentence and time is
+// omitted from GGA and GLL until the date is known. speed is in meters per
+// second and courses are in degrees. magnetic_variation is positive for east.
+//
+// A Decoder isn't thread-safe.
+type Decoder struct {
+	// last is the last time having a date.
+	last time.Time
+}
+
+// Decode decodes a sentence. It returns ErrUnsupported when the type of the
+// sentence isn't one of Types.
+func (d *Decoder) Decode(s *Sentence) (data.Map, error) {
+	var (
+		m   data.Map
+		err error
+	)
+	switch s.Type {
+	case "GGA":
+		m, err = d.decodeGGA(s.Fields)
+	case "GLL":
+		m, err = d.decodeGLL(s.Fields)
+	case "RMC":
+		m, err = d.decodeRMC(s.Fields)
+	case "VTG":
+		m, err = d.decodeVTG(s.Fields)
+	case "ZDA":
+		m, err = d.decodeZDA(s.Fields)
+	default:
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %v: %v", s.Type, err)
+	}
+	m["talker"] = data.String(s.Talker)
+	m["sentence"] = data.String(s.Type)
+	return m, nil
+}
+
+// fields gives access to fields of a sentence by index. Missing trailing
+// fields are regarded as empty ones.
+type fields []string
+
+func (f fields) get(i int) string {
+	if i < len(f) {
+		return f[i]
+	}
+	return ""
+}
+
+func (f fields) float(m data.Map, key string, i int, scale float64) error {
+	s := f.get(i)
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("%v must be a number: %v", key, s)
+	}
+	m[key] = data.Float(v * scale)
+	return nil
+}
+
+func (f fields) int(m data.Map, key string, i int) error {
+	s := f.get(i)
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%v must be an integer: %v", key, s)
+	}
+	m[key] = data.Int(v)
+	return nil
+}
+
+// position sets lat and lon from four fields starting at i: ddmm.mmmm, N or
+// S, dddmm.mmmm, and E or W.
+func (f fields) position(m data.Map, i int) error {
+	lat, ok, err := parseCoordinate(f.get(i), f.get(i+1), "N", "S", 90)
+	if err != nil {
+		return fmt.Errorf("invalid latitude: %v", err)
+	} else if ok {
+		m["lat"] = data.Float(lat)
+	}
+	lon, ok, err := parseCoordinate(f.get(i+2), f.get(i+3), "E", "W", 180)
+	if err != nil {
+		return fmt.Errorf("invalid longitude: %v", err)
+	} else if ok {
+		m["lon"] = data.Float(lon)
+	}
+	return nil
+}
+
+func parseCoordinate(v, hemisphere, pos, neg string, max float64) (float64, bool, error) {
+	if v == "" {
+		return 0, false, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		return 0, false, fmt.Errorf("%v", v)
+	}
+	deg := float64(int(f / 100))
+	minutes := f - deg*100
+	if minutes >= 60 {
+		return 0, false, fmt.Errorf("%v", v)
+	}
+	deg += minutes / 60
+	if deg > max {
+		return 0, false, fmt.Errorf("%v", v)
+	}
+	switch hemisphere {
+	case pos:
+	case neg:
+		deg = -deg
+	default:
+		return 0, false, fmt.Errorf("invalid hemisphere: %v", hemisphere)
+	}
+	return deg, true, nil
+}
+
+// parseTimeOfDay parses hhmmss or hhmmss.sss into the duration since
+// midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	if len(s) < 6 {
+		return 0, fmt.Errorf("invalid time: %v", s)
+	}
+	h, err1 := strconv.Atoi(s[0:2])
+	m, err2 := strconv.Atoi(s[2:4])
+	sec, err3 := strconv.ParseFloat(s[4:], 64)
+	if err1 != nil || err2 != nil || err3 != nil || h < 0 || h > 23 || m < 0 || m > 59 || sec < 0 || sec >= 61 {
+		return 0, fmt.Errorf("invalid time: %v", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(sec*float64(time.Second)+0.5), nil
+}
+
+func date(y, m, d int) (time.Time, error) {
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if t.Year() != y || t.Month() != time.Month(m) || t.Day() != d {
+		return time.Time{}, fmt.Errorf("invalid date: %04d-%02d-%02d", y, m, d)
+	}
+	return t, nil
+}
+
+// setTime sets time from a time of day using the date of the last sentence.
+// When the time of day is much earlier than the last time, the date has
+// changed since the last sentence having a date.
+func (d *Decoder) setTime(m data.Map, s string) error {
+	if s == "" {
+		return nil
+	}
+	tod, err := parseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+	if d.last.IsZero() {
+		return nil
+	}
+	y, mon, day := d.last.Date()
+	t := time.Date(y, mon, day, 0, 0, 0, 0, time.UTC).Add(tod)
+	if d.last.Sub(t) > 12*time.Hour {
+		t = t.AddDate(0, 0, 1)
+	}
+	m["time"] = data.Timestamp(t)
+	return nil
+}
+
+// setDateTime sets time from a date and a time of day and remembers it.
+func (d *Decoder) setDateTime(m data.Map, day time.Time, s string) error {
+	if s == "" {
+		return nil
+	}
+	tod, err := parseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+	d.last = day.Add(tod)
+	m["time"] = data.Timestamp(d.last)
+	return nil
+}
+
+// modeValid returns false when the FAA mode indicator added in NMEA 2.3 is
+// "N", which means the data isn't valid.
+func modeValid(mode string) bool {
+	return mode != "N"
+}
+
+func (d *Decoder) decodeGGA(f fields) (data.Map, error) {
+	m := data.Map{}
+	if err := d.setTime(m, f.get(0)); err != nil {
+		return nil, err
+	}
+	if err := f.position(m, 1); err != nil {
+		return nil, err
+	}
+	if err := f.int(m, "fix_quality", 5); err != nil {
+		return nil, err
+	}
+	if err := f.int(m, "satellites", 6); err != nil {
+		return nil, err
+	}
+	if err := f.float(m, "hdop", 7, 1); err != nil {
+		return nil, err
+	}
+	if err := f.float(m, "altitude", 8, 1); err != nil {
+		return nil, err
+	}
+	if err := f.float(m, "geoid_separation", 10, 1); err != nil {
+		return nil, err
+	}
+	q, _ := m["fix_quality"].(data.Int)
+	m["valid"] = data.Bool(q != 0)
+	return m, nil
+}
+
+func (d *Decoder) decodeGLL(f fields) (data.Map, error) {
+	m := data.Map{}
+	if err := f.position(m, 0); err != nil {
+		return nil, err
+	}
+	if err := d.setTime(m, f.get(4)); err != nil {
+		return nil, err
+	}
+	m["valid"] = data.Bool(f.get(5) == "A" && modeValid(f.get(6)))
+	return m, nil
+}
+
+func (d *Decoder) decodeRMC(f fields) (data.Map, error) {
+	m := data.Map{}
+	if ds := f.get(8); ds != "" {
+		day, err := parseDDMMYY(ds)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.setDateTime(m, day, f.get(0)); err != nil {
+			return nil, err
+		}
+	} else if err := d.setTime(m, f.get(0)); err != nil {
+		return nil, err
+	}
+	if err := f.position(m, 2); err != nil {
+		return nil, err
+	}
+	if err := f.float(m, "speed", 6, knot); err != nil {
+		return nil, err
+	}
+	if err := f.float(m, "course", 7, 1); err != nil {
+		return nil, err
+	}
+	switch f.get(10) {
+	case "E", "":
+		if err := f.float(m, "magnetic_variation", 9, 1); err != nil {
+			return nil, err
+		}
+	case "W":
+		if err := f.float(m, "magnetic_variation", 9, -1); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid direction of magnetic variation: %v", f.get(10))
+	}
+	m["valid"] = data.Bool(f.get(1) == "A" && modeValid(f.get(11)))
+	return m, nil
+}
+
+func parseDDMMYY(s string) (time.Time, error) {
+	if len(s) != 6 {
+		return time.Time{}, fmt.Errorf("invalid date: %v", s)
+	}
+	d, err1 := strconv.Atoi(s[0:2])
+	m, err2 := strconv.Atoi(s[2:4])
+	y, err3 := strconv.Atoi(s[4:6])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %v", s)
+	}
+	// Two-digit years are interpreted as 1980 to 2079 because GPS started
+	// in 1980.
+	if y < 80 {
+		y += 2000
+	} else {
+		y += 1900
+	}
+	return date(y, m, d)
+}
+
+func (d *Decoder) decodeVTG(f fields) (data.Map, error) {
+	m := data.Map{}
+	if err := f.float(m, "course", 0, 1); err != nil {
+		return nil, err
+	}
+	if err := f.float(m, "course_magnetic", 2, 1); err != nil {
+		return nil, err
+	}
+	if f.get(4) != "" {
+		if err := f.float(m, "speed", 4, knot); err != nil {
+			return nil, err
+		}
+	} else if err := f.float(m, "speed", 6, kmh); err != nil {
+		return nil, err
+	}
+	_, hasSpeed := m["speed"]
+	m["valid"] = data.Bool(hasSpeed && modeValid(f.get(8)))
+	return m, nil
+}
+
+func (d *Decoder) decodeZDA(f fields) (data.Map, error) {
+	m := data.Map{}
+	if f.get(1) == "" || f.get(2) == "" || f.get(3) == "" {
+		if err := d.setTime(m, f.get(0)); err != nil {
+			return nil, err
+		}
+		m["valid"] = data.Bool(false)
+		return m, nil
+	}
+	day, err1 := strconv.Atoi(f.get(1))
+	mon, err2 := strconv.Atoi(f.get(2))
+	y, err3 := strconv.Atoi(f.get(3))
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, fmt.Errorf("invalid date: %v/%v/%v", f.get(1), f.get(2), f.get(3))
+	}
+	t, err := date(y, mon, day)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.setDateTime(m, t, f.get(0)); err != nil {
+		return nil, err
+	}
+	_, hasTime := m["time"]
+	m["valid"] = data.Bool(hasTime)
+	return m, nil
+}
@@ -0,0 +1,199 @@
+package nmea
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+// withChecksum appends the checksum to a sentence.
+func withChecksum(s string) string {
+	var c byte
+	for i := 1; i < len(s); i++ {
+		c ^= s[i]
+	}
+	return fmt.Sprintf("%v*%02X", s, c)
+}
+
+func TestParse(t *testing.T) {
+	Convey("Given sentences", t, func() {
+		Convey("When parsing a sentence having a checksum", func() {
+			s, err := Parse("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n")
+
+			Convey("Then it should be parsed", func() {
+				So(err, ShouldBeNil)
+				So(s.Talker, ShouldEqual, "GP")
+				So(s.Type, ShouldEqual, "GGA")
+				So(s.Fields, ShouldResemble, []string{"123519", "4807.038", "N", "01131.000", "E",
+					"1", "08", "0.9", "545.4", "M", "46.9", "M", "", ""})
+			})
+		})
+
+		Convey("When parsing sentences without checksums", func() {
+			s, err := Parse("$GNVTG,,T,,M,0.0,N,0.0,K")
+			So(err, ShouldBeNil)
+			p, err := Parse("$PGRME,15.0,M,45.0,M,25.0,M")
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be parsed", func() {
+				So(s.Talker, ShouldEqual, "GN")
+				So(s.Type, ShouldEqual, "VTG")
+				So(p.Talker, ShouldEqual, "P")
+				So(p.Type, ShouldEqual, "GRME")
+			})
+		})
+
+		Convey("When parsing invalid sentences", func() {
+			Convey("Then it should fail", func() {
+				for _, l := range []string{
+					"",
+					"GPGGA,123519",
+					"$GPGGA,123519*00",
+					"$GPGGA,123519*4",
+					"$GPGGA,123519*XY",
+					"$GPGG,123519",
+					"$gpgga,123519",
+					"$P,123519",
+				} {
+					_, err := Parse(l)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestDecoder(t *testing.T) {
+	Convey("Given a decoder", t, func() {
+		d := &Decoder{}
+		decode := func(l string) (data.Map, error) {
+			s, err := Parse(l)
+			So(err, ShouldBeNil)
+			return d.Decode(s)
+		}
+
+		Convey("When decoding GGA before RMC", func() {
+			m, err := decode("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47")
+			So(err, ShouldBeNil)
+
+			Convey("Then it shouldn't have time", func() {
+				So(m["lat"], ShouldAlmostEqual, data.Float(48.1173), 0.00001)
+				So(m["lon"], ShouldAlmostEqual, data.Float(11.516666), 0.00001)
+				delete(m, "lat")
+				delete(m, "lon")
+				So(m, ShouldResemble, data.Map{
+					"talker":           data.String("GP"),
+					"sentence":         data.String("GGA"),
+					"valid":            data.Bool(true),
+					"fix_quality":      data.Int(1),
+					"satellites":       data.Int(8),
+					"hdop":             data.Float(0.9),
+					"altitude":         data.Float(545.4),
+					"geoid_separation": data.Float(46.9),
+				})
+			})
+		})
+
+		Convey("When decoding RMC", func() {
+			m, err := decode("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the time, the position, and the velocity", func() {
+				So(m["time"], ShouldResemble, data.Timestamp(time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC)))
+				So(m["lat"], ShouldAlmostEqual, data.Float(48.1173), 0.00001)
+				So(m["speed"], ShouldAlmostEqual, data.Float(11.5236), 0.0001)
+				So(m["course"], ShouldEqual, data.Float(84.4))
+				So(m["magnetic_variation"], ShouldEqual, data.Float(-3.1))
+				So(m["valid"], ShouldEqual, data.Bool(true))
+			})
+
+			Convey("And decoding GGA and GLL", func() {
+				g, err := decode("$GPGGA,123520,4807.038,S,01131.000,W,0,00,,,M,,M,,")
+				So(err, ShouldBeNil)
+				l, err := decode(withChecksum("$GPGLL,4916.45,N,12311.12,W,000001,A,"))
+				So(err, ShouldBeNil)
+
+				Convey("Then they should have dates of RMC", func() {
+					So(g["time"], ShouldResemble, data.Timestamp(time.Date(1994, 3, 23, 12, 35, 20, 0, time.UTC)))
+					So(g["lat"], ShouldAlmostEqual, data.Float(-48.1173), 0.00001)
+					So(g["lon"], ShouldAlmostEqual, data.Float(-11.516666), 0.00001)
+					So(g["valid"], ShouldEqual, data.Bool(false))
+					So(g, ShouldNotContainKey, "hdop")
+
+					So(l["time"], ShouldResemble, data.Timestamp(time.Date(1994, 3, 24, 0, 0, 1, 0, time.UTC)))
+					So(l["lat"], ShouldAlmostEqual, data.Float(49.274166), 0.00001)
+					So(l["lon"], ShouldAlmostEqual, data.Float(-123.18533), 0.00001)
+					So(l["valid"], ShouldEqual, data.Bool(true))
+				})
+			})
+		})
+
+		Convey("When decoding RMC without a fix", func() {
+			m, err := decode("$GPRMC,,V,,,,,,,,,,N*53")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be invalid", func() {
+				So(m, ShouldResemble, data.Map{
+					"talker":   data.String("GP"),
+					"sentence": data.String("RMC"),
+					"valid":    data.Bool(false),
+				})
+			})
+		})
+
+		Convey("When decoding VTG", func() {
+			m, err := decode("$GPVTG,054.7,T,034.4,M,005.5,N,010.2,K*48")
+			k, err2 := decode("$GPVTG,054.7,T,,M,,N,036.0,K,A")
+
+			Convey("Then speeds should be in meters per second", func() {
+				So(err, ShouldBeNil)
+				So(m["course"], ShouldEqual, data.Float(54.7))
+				So(m["course_magnetic"], ShouldEqual, data.Float(34.4))
+				So(m["speed"], ShouldAlmostEqual, data.Float(2.829444), 0.00001)
+				So(m["valid"], ShouldEqual, data.Bool(true))
+				So(err2, ShouldBeNil)
+				So(k["speed"], ShouldAlmostEqual, data.Float(10), 0.00001)
+			})
+		})
+
+		Convey("When decoding ZDA", func() {
+			m, err := decode("$GPZDA,201530.00,04,07,2002,00,00*60")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the time", func() {
+				So(m["time"], ShouldResemble, data.Timestamp(time.Date(2002, 7, 4, 20, 15, 30, 0, time.UTC)))
+				So(m["valid"], ShouldEqual, data.Bool(true))
+			})
+		})
+
+		Convey("When decoding unsupported sentences", func() {
+			_, err := decode("$GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00*74")
+
+			Convey("Then it should fail with ErrUnsupported", func() {
+				So(err, ShouldEqual, ErrUnsupported)
+			})
+		})
+
+		Convey("When decoding malformed sentences", func() {
+			Convey("Then it should fail", func() {
+				for _, l := range []string{
+					"$GPRMC,123519,A,4807.038,X,01131.000,E,022.4,084.4,230394,003.1,W",
+					"$GPRMC,123519,A,4867.038,N,01131.000,E,022.4,084.4,230394,003.1,W",
+					"$GPRMC,123519,A,9107.038,N,01131.000,E,022.4,084.4,230394,003.1,W",
+					"$GPRMC,123519,A,4807.038,N,01131.000,E,fast,084.4,230394,003.1,W",
+					"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,300294,003.1,W",
+					"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,X",
+					"$GPRMC,126019,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W",
+					"$GPGGA,12351,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,",
+					"$GPGGA,123519,4807.038,N,01131.000,E,1,8.5,0.9,545.4,M,46.9,M,,",
+					"$GPZDA,201530.00,31,02,2002,00,00",
+				} {
+					_, err := decode(l)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
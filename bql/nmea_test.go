@@ -0,0 +1,164 @@
+package bql
+
+import (
+	"bufio"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testNMEASentences = `$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A
+$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47
+$GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00*74
+$GPVTG,054.7,T,034.4,M,005.5,N,010.2,K*48
+$GPGGA,123520,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*00
+
+$GPRMC,123521,V,,,,,,,230394,,,N*5A
+`
+
+func TestNMEASource(t *testing.T) {
+	Convey("Given a file having NMEA sentences", t, func() {
+		ctx := core.NewContext(nil)
+		f, err := ioutil.TempFile("", "sbtest_nmea")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.Remove(f.Name())
+		})
+		_, err = f.WriteString(testNMEASentences)
+		So(err, ShouldBeNil)
+		So(f.Close(), ShouldBeNil)
+		params := data.Map{"device": data.String(f.Name())}
+
+		read := func() []*core.Tuple {
+			s, err := createNMEASource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			w := &tupleCollectorSink{}
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+			return w.Tuples
+		}
+
+		Convey("When reading it", func() {
+			ts := read()
+
+			Convey("Then supported valid sentences should be emitted", func() {
+				So(len(ts), ShouldEqual, 4)
+				So(ts[0].Data["sentence"], ShouldEqual, data.String("RMC"))
+				So(ts[0].Data["lat"], ShouldAlmostEqual, data.Float(48.1173), 0.00001)
+				So(ts[0].Timestamp, ShouldResemble, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC))
+				So(ts[1].Data["sentence"], ShouldEqual, data.String("GGA"))
+				So(ts[1].Data["altitude"], ShouldEqual, data.Float(545.4))
+				So(ts[1].Timestamp, ShouldResemble, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC))
+				So(ts[2].Data["sentence"], ShouldEqual, data.String("VTG"))
+				So(ts[2].Timestamp, ShouldNotResemble, ts[1].Timestamp)
+				So(ts[3].Data["valid"], ShouldEqual, data.Bool(false))
+				So(ts[3].Timestamp, ShouldResemble, time.Date(1994, 3, 23, 12, 35, 21, 0, time.UTC))
+			})
+		})
+
+		Convey("When reading it with filters", func() {
+			params["sentences"] = data.Array{data.String("gga"), data.String("RMC")}
+			params["skip_invalid"] = data.True
+			ts := read()
+
+			Convey("Then only valid GGA and RMC should be emitted", func() {
+				So(len(ts), ShouldEqual, 2)
+				So(ts[0].Data["sentence"], ShouldEqual, data.String("RMC"))
+				So(ts[1].Data["sentence"], ShouldEqual, data.String("GGA"))
+			})
+		})
+
+		Convey("When creating sources with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{},
+					{"device": data.String("")},
+					{"device": data.Int(1)},
+					{"device": data.String("/dev/ttyS0"), "gpsd": data.String("localhost")},
+					{"gpsd": data.String("localhost"), "baud_rate": data.Int(4800)},
+					{"device": data.String("/dev/ttyS0"), "baud_rate": data.Int(0)},
+					{"device": data.String("/dev/ttyS0"), "sentences": data.Array{}},
+					{"device": data.String("/dev/ttyS0"), "sentences": data.Array{data.String("GSV")}},
+					{"device": data.String("/dev/ttyS0"), "skip_invalid": data.String("yes")},
+				} {
+					_, err := createNMEASource(ctx, &IOParams{}, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+
+	Convey("Given gpsd", t, func() {
+		ctx := core.NewContext(nil)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			l.Close()
+		})
+		commands := make(chan string, 2)
+		go func() {
+			for {
+				c, err := l.Accept()
+				if err != nil {
+					return
+				}
+				cmd, _ := bufio.NewReader(c).ReadString('\n')
+				commands <- cmd
+				c.Write([]byte(`{"class":"VERSION","release":"3.22"}` + "\n" +
+					"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A\r\n"))
+				// The source should be reconnected after this
+				// connection is closed.
+				c.Close()
+			}
+		}()
+
+		src, err := createNMEASource(ctx, &IOParams{}, data.Map{
+			"gpsd": data.String(l.Addr().String()),
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When reading sentences from it without the supervisor", func() {
+			var n int
+			err := src.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				n++
+				return nil
+			}))
+
+			Convey("Then GenerateStream should fail when the connection is closed", func() {
+				So(err, ShouldNotBeNil)
+				So(n, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When reading sentences from it", func() {
+			s := core.NewSupervisedSource(src, &core.SupervisorConfig{
+				InitialBackoff: time.Millisecond,
+			})
+			ch := make(chan *core.Tuple, 2)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					select {
+					case ch <- t:
+					default:
+					}
+					return nil
+				}))
+			}()
+			t1, t2 := <-ch, <-ch
+			So(s.Stop(ctx), ShouldBeNil)
+			So(<-done, ShouldBeNil)
+
+			Convey("Then sentences should be emitted after the watch command", func() {
+				So(strings.HasPrefix(<-commands, "?WATCH="), ShouldBeTrue)
+				So(t1.Data["sentence"], ShouldEqual, data.String("RMC"))
+				So(t2.Data["sentence"], ShouldEqual, data.String("RMC"))
+			})
+		})
+	})
+}
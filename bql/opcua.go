@@ -0,0 +1,442 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOPCUAPublishingInterval = time.Second
+)
+
+// OPCUAClient subscribes to data changes of nodes in OPC UA servers. A client
+// is provided by a package embedding an OPC UA stack and registered by
+// SetOPCUAClient.
+type OPCUAClient interface {
+	// Subscribe connects to the server and creates a subscription having
+	// monitored items of the nodes. It calls f with each data change until
+	// stop is closed, in which case it returns nil. When f returns an error,
+	// Subscribe returns the error. It also returns an error when the
+	// connection or the subscription is lost.
+	//
+	// f is never called concurrently.
+	Subscribe(ctx *core.Context, s *OPCUASubscription, stop <-chan struct{},
+		f func(c *OPCUADataChange) error) error
+}
+
+// OPCUASubscription has parameters of a subscription to an OPC UA server.
+type OPCUASubscription struct {
+	// Endpoint is the URL of the endpoint such as
+	// "opc.tcp://localhost:4840".
+	Endpoint string
+
+	// SecurityPolicy is the name of the security policy such as "None" or
+	// "Basic256Sha256". SecurityMode is "None", "Sign", or
+	// "SignAndEncrypt".
+	SecurityPolicy string
+	SecurityMode   string
+
+	// CertFile and KeyFile are paths of the certificate and the private key
+	// of the client. They're required when SecurityMode isn't "None".
+	CertFile string
+	KeyFile  string
+
+	// Username and Password are used for authentication. The client is
+	// authenticated anonymously when Username is empty.
+	Username string
+	Password string
+
+	// PublishingInterval is the interval at which the server sends
+	// notifications of the subscription.
+	PublishingInterval time.Duration
+
+	// Items are monitored items of the subscription.
+	Items []*OPCUAMonitoredItem
+}
+
+// OPCUAMonitoredItem is a node whose value is monitored.
+type OPCUAMonitoredItem struct {
+	// NodeID is the node ID in the string notation such as "ns=2;s=Temp" or
+	// "i=2258".
+	NodeID string
+
+	// Name is the name of the item in tuples. It's NodeID when it isn't
+	// given by the parameter.
+	Name string
+
+	// SamplingInterval is the interval at which the server samples the
+	// value. The publishing interval is used when it's 0.
+	SamplingInterval time.Duration
+}
+
+// OPCUADataChange is a notification of a change of the value of a monitored
+// item.
+type OPCUADataChange struct {
+	// Item is the monitored item whose value has changed.
+	Item *OPCUAMonitoredItem
+
+	// Value is the new value. It's Null when the server doesn't send a
+	// value, e.g. when the status is bad.
+	Value data.Value
+
+	// StatusCode is the status code of the value.
+	StatusCode uint32
+
+	// SourceTimestamp is the time when the value was produced by its
+	// source and ServerTimestamp is the time when the server received it.
+	// They're zero when the server doesn't send them.
+	SourceTimestamp time.Time
+	ServerTimestamp time.Time
+}
+
+var (
+	opcuaClientMutex sync.RWMutex
+	opcuaClient      OPCUAClient
+
+	// ErrNoOPCUAClient is returned when an OPC UA source is created but no
+	// OPCUAClient is set.
+	ErrNoOPCUAClient = errors.New("OPC UA client isn't available in this build")
+)
+
+// SetOPCUAClient sets the OPCUAClient used by OPC UA sources.
+func SetOPCUAClient(c OPCUAClient) {
+	opcuaClientMutex.Lock()
+	defer opcuaClientMutex.Unlock()
+	opcuaClient = c
+}
+
+func getOPCUAClient() (OPCUAClient, error) {
+	opcuaClientMutex.RLock()
+	defer opcuaClientMutex.RUnlock()
+	if opcuaClient == nil {
+		return nil, ErrNoOPCUAClient
+	}
+	return opcuaClient, nil
+}
+
+// opcuaStatus returns the severity of the status code, which is in the
+// highest two bits.
+func opcuaStatus(code uint32) string {
+	switch code >> 30 {
+	case 0:
+		return "good"
+	case 1:
+		return "uncertain"
+	default:
+		return "bad"
+	}
+}
+
+// opcuaSource emits a tuple for each data change of monitored items. A tuple
+// has following fields:
+//
+//	node_id: the node ID of the item
+//	name: the name of the item
+//	value: the value of the item
+//	status: "good", "uncertain", or "bad"
+//	status_code: the status code of the value
+//	source_timestamp: the source timestamp or null
+//	server_timestamp: the server timestamp or null
+//
+// The timestamp of a tuple is the server timestamp so that it can be used as
+// the event time. The source timestamp, and then the time when the tuple is
+// emitted, are used when the server doesn't send the server timestamp.
+//
+// When the connection is lost, GenerateStream returns an error and the
+// source is reconnected by the supervisor, which can be configured by
+// reconnect_ parameters of CREATE SOURCE. Data changes occurring while it's
+// disconnected are lost.
+type opcuaSource struct {
+	client  OPCUAClient
+	sub     *OPCUASubscription
+	skipBad bool
+	stopCh  chan struct{}
+}
+
+func (s *opcuaSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	clock := ctx.Clock()
+	var writeErr error
+	err := s.client.Subscribe(ctx, s.sub, s.stopCh, func(c *OPCUADataChange) error {
+		if s.skipBad && opcuaStatus(c.StatusCode) == "bad" {
+			return nil
+		}
+		if err := w.Write(ctx, s.tuple(clock.Now(), c)); err != nil {
+			writeErr = err
+			return err
+		}
+		return nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	if err == nil {
+		err = errors.New("the subscription ended unexpectedly")
+	}
+	return fmt.Errorf("lost the connection to the OPC UA server %v: %v", s.sub.Endpoint, err)
+}
+
+func (s *opcuaSource) reconnectsBySupervisor() {}
+
+func (s *opcuaSource) tuple(now time.Time, c *OPCUADataChange) *core.Tuple {
+	ts := func(t time.Time) data.Value {
+		if t.IsZero() {
+			return data.Null{}
+		}
+		return data.Timestamp(t)
+	}
+	v := c.Value
+	if v == nil {
+		v = data.Null{}
+	}
+
+	t := core.NewTuple(data.Map{
+		"node_id":          data.String(c.Item.NodeID),
+		"name":             data.String(c.Item.Name),
+		"value":            v,
+		"status":           data.String(opcuaStatus(c.StatusCode)),
+		"status_code":      data.Int(c.StatusCode),
+		"source_timestamp": ts(c.SourceTimestamp),
+		"server_timestamp": ts(c.ServerTimestamp),
+	})
+	t.ProcTimestamp = now
+	switch {
+	case !c.ServerTimestamp.IsZero():
+		t.Timestamp = c.ServerTimestamp
+	case !c.SourceTimestamp.IsZero():
+		t.Timestamp = c.SourceTimestamp
+	default:
+		t.Timestamp = now
+	}
+	return t
+}
+
+func (s *opcuaSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+var (
+	opcuaNodeIDPattern = regexp.MustCompile(`^(ns=\d+;|nsu=[^;]+;)?[isgb]=.+$`)
+
+	opcuaSecurityPolicies = []string{"None", "Basic128Rsa15", "Basic256",
+		"Basic256Sha256", "Aes128_Sha256_RsaOaep", "Aes256_Sha256_RsaPss"}
+	opcuaSecurityModes = []string{"None", "Sign", "SignAndEncrypt"}
+)
+
+// createOPCUASource creates an OPC UA source. It accepts following
+// parameters:
+//
+//	endpoint: the URL of the endpoint such as "opc.tcp://localhost:4840"
+//	    (required)
+//	nodes: an array of node IDs such as "ns=2;s=Temp", or maps having
+//	    node_id, name, and sampling_interval (required)
+//	publishing_interval: the interval of notifications (default: 1s)
+//	security_policy: "None", "Basic128Rsa15", "Basic256", "Basic256Sha256",
+//	    "Aes128_Sha256_RsaOaep", or "Aes256_Sha256_RsaPss" (default: "None")
+//	security_mode: "None", "Sign", or "SignAndEncrypt" (default: "None",
+//	    or "SignAndEncrypt" when security_policy isn't "None")
+//	cert_file, key_file: the certificate and the private key of the client
+//	    required when security_mode isn't "None"
+//	username, password: the credential of the user (default: anonymous)
+//	skip_bad: true to drop values having bad status codes (default: false)
+func createOPCUASource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	client, err := getOPCUAClient()
+	if err != nil {
+		return nil, err
+	}
+
+	getString := func(key string) (string, bool, error) {
+		v, ok := params[key]
+		if !ok {
+			return "", false, nil
+		}
+		s, err := data.AsString(v)
+		if err != nil {
+			return "", false, fmt.Errorf("'%v' parameter must be a string: %v", key, err)
+		}
+		return s, true, nil
+	}
+	getDuration := func(key string, def time.Duration) (time.Duration, error) {
+		v, ok := params[key]
+		if !ok {
+			return def, nil
+		}
+		d, err := data.ToDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("'%v' parameter should have a duration: %v", key, err)
+		}
+		if d <= 0 {
+			return 0, fmt.Errorf("'%v' parameter must be positive: %v", key, v)
+		}
+		return d, nil
+	}
+
+	sub := &OPCUASubscription{}
+	endpoint, ok, err := getString("endpoint")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("'endpoint' parameter is missing")
+	}
+	if !strings.HasPrefix(endpoint, "opc.tcp://") {
+		return nil, fmt.Errorf("'endpoint' parameter must be an opc.tcp URL: %v", endpoint)
+	}
+	sub.Endpoint = endpoint
+
+	v, ok := params["nodes"]
+	if !ok {
+		return nil, errors.New("'nodes' parameter is missing")
+	}
+	nodes, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("'nodes' parameter must be an array: %v", err)
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("'nodes' parameter must have at least one node")
+	}
+	names := map[string]bool{}
+	for i, n := range nodes {
+		item, err := newOPCUAMonitoredItem(n)
+		if err != nil {
+			return nil, fmt.Errorf("'nodes' parameter has an invalid node at %v: %v", i, err)
+		}
+		if names[item.Name] {
+			return nil, fmt.Errorf("'nodes' parameter has a duplicated name: %v", item.Name)
+		}
+		names[item.Name] = true
+		sub.Items = append(sub.Items, item)
+	}
+
+	if sub.PublishingInterval, err = getDuration("publishing_interval", defaultOPCUAPublishingInterval); err != nil {
+		return nil, err
+	}
+
+	policy, ok, err := getString("security_policy")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		policy = "None"
+	}
+	if sub.SecurityPolicy = findOPCUAName(opcuaSecurityPolicies, policy); sub.SecurityPolicy == "" {
+		return nil, fmt.Errorf("'security_policy' parameter must be one of %v: %v",
+			strings.Join(opcuaSecurityPolicies, ", "), policy)
+	}
+	mode, ok, err := getString("security_mode")
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		mode = "None"
+		if sub.SecurityPolicy != "None" {
+			mode = "SignAndEncrypt"
+		}
+	}
+	if sub.SecurityMode = findOPCUAName(opcuaSecurityModes, mode); sub.SecurityMode == "" {
+		return nil, fmt.Errorf("'security_mode' parameter must be one of %v: %v",
+			strings.Join(opcuaSecurityModes, ", "), mode)
+	}
+	if (sub.SecurityPolicy == "None") != (sub.SecurityMode == "None") {
+		return nil, errors.New("'security_mode' parameter must be None if and only if 'security_policy' parameter is None")
+	}
+
+	if sub.CertFile, _, err = getString("cert_file"); err != nil {
+		return nil, err
+	}
+	if sub.KeyFile, _, err = getString("key_file"); err != nil {
+		return nil, err
+	}
+	if (sub.CertFile == "") != (sub.KeyFile == "") {
+		return nil, errors.New("'cert_file' and 'key_file' parameters must be given together")
+	}
+	if sub.SecurityMode != "None" && sub.CertFile == "" {
+		return nil, fmt.Errorf("'cert_file' and 'key_file' parameters are required for the security mode %v", sub.SecurityMode)
+	}
+
+	if sub.Username, _, err = getString("username"); err != nil {
+		return nil, err
+	}
+	if sub.Password, ok, err = getString("password"); err != nil {
+		return nil, err
+	} else if ok && sub.Username == "" {
+		return nil, errors.New("'password' parameter cannot be given without 'username' parameter")
+	}
+
+	s := &opcuaSource{
+		client: client,
+		sub:    sub,
+		stopCh: make(chan struct{}),
+	}
+	if v, ok := params["skip_bad"]; ok {
+		if s.skipBad, err = data.AsBool(v); err != nil {
+			return nil, fmt.Errorf("'skip_bad' parameter must be bool: %v", err)
+		}
+	}
+	return s, nil
+}
+
+func newOPCUAMonitoredItem(v data.Value) (*OPCUAMonitoredItem, error) {
+	item := &OPCUAMonitoredItem{}
+	if v.Type() == data.TypeString {
+		item.NodeID, _ = data.AsString(v)
+	} else {
+		m, err := data.AsMap(v)
+		if err != nil {
+			return nil, errors.New("a node must be a string or a map")
+		}
+		id, ok := m["node_id"]
+		if !ok {
+			return nil, errors.New("node_id is missing")
+		}
+		if item.NodeID, err = data.AsString(id); err != nil {
+			return nil, fmt.Errorf("node_id must be a string: %v", err)
+		}
+		if n, ok := m["name"]; ok {
+			if item.Name, err = data.AsString(n); err != nil {
+				return nil, fmt.Errorf("name must be a string: %v", err)
+			}
+		}
+		if i, ok := m["sampling_interval"]; ok {
+			d, err := data.ToDuration(i)
+			if err != nil {
+				return nil, fmt.Errorf("sampling_interval should have a duration: %v", err)
+			}
+			if d < 0 {
+				return nil, fmt.Errorf("sampling_interval must not be negative: %v", i)
+			}
+			item.SamplingInterval = d
+		}
+	}
+	if !opcuaNodeIDPattern.MatchString(item.NodeID) {
+		return nil, fmt.Errorf("invalid node ID: %v", item.NodeID)
+	}
+	if item.Name == "" {
+		item.Name = item.NodeID
+	}
+	return item, nil
+}
+
+// findOPCUAName returns the name in names matching s case-insensitively. It
+// returns an empty string when no name matches.
+func findOPCUAName(names []string, s string) string {
+	for _, n := range names {
+		if strings.EqualFold(n, s) {
+			return n
+		}
+	}
+	return ""
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("opcua", SourceCreatorFunc(createOPCUASource))
+}
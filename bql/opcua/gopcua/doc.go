@@ -0,0 +1,18 @@
+// Package gopcua provides a bql.OPCUAClient based on gopcua, an OPC UA stack
+// written in pure Go. Since it adds a dependency, the client is only built
+// with the gopcua build tag:
+//
+//	go build -tags gopcua
+//
+// Import this package for side effects to enable the opcua source type:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/opcua/gopcua"
+//
+// Values of built-in types are converted to corresponding data.Value.
+// LocalizedText and QualifiedName are converted to String having their texts
+// and names, and IDs such as NodeId and Guid are converted to String in their
+// string notations.
+//
+// The client doesn't reconnect automatically. Instead, the source reconnects
+// to the server and recreates the subscription after the connection is lost.
+package gopcua
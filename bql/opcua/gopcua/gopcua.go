@@ -0,0 +1,209 @@
+//go:build gopcua
+// +build gopcua
+
+package gopcua
+
+import (
+	"context"
+	"fmt"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"reflect"
+	"time"
+)
+
+// stateCheckInterval is the interval at which the state of the connection
+// is checked.
+const stateCheckInterval = time.Second
+
+// Client is a bql.OPCUAClient using gopcua.
+type Client struct{}
+
+// Subscribe connects to the server and monitors the items.
+func (cli *Client) Subscribe(ctx *core.Context, s *bql.OPCUASubscription, stop <-chan struct{},
+	f func(c *bql.OPCUADataChange) error) error {
+	c, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts, err := cli.options(c, s)
+	if err != nil {
+		return err
+	}
+	client, err := opcua.NewClient(s.Endpoint, opts...)
+	if err != nil {
+		return err
+	}
+	if err := client.Connect(c); err != nil {
+		return err
+	}
+	defer client.Close(context.Background())
+
+	notifyCh := make(chan *opcua.PublishNotificationData)
+	sub, err := client.Subscribe(c, &opcua.SubscriptionParameters{
+		Interval: s.PublishingInterval,
+	}, notifyCh)
+	if err != nil {
+		return err
+	}
+	defer sub.Cancel(context.Background())
+
+	reqs := make([]*ua.MonitoredItemCreateRequest, len(s.Items))
+	for i, item := range s.Items {
+		id, err := ua.ParseNodeID(item.NodeID)
+		if err != nil {
+			return fmt.Errorf("invalid node ID %v: %v", item.NodeID, err)
+		}
+		// The index of the item is used as the client handle.
+		req := opcua.NewMonitoredItemCreateRequestWithDefaults(id, ua.AttributeIDValue, uint32(i))
+		if item.SamplingInterval > 0 {
+			req.RequestedParameters.SamplingInterval = float64(item.SamplingInterval) / float64(time.Millisecond)
+		}
+		reqs[i] = req
+	}
+	res, err := sub.Monitor(c, ua.TimestampsToReturnBoth, reqs...)
+	if err != nil {
+		return err
+	}
+	for i, r := range res.Results {
+		if r.StatusCode != ua.StatusOK {
+			return fmt.Errorf("cannot monitor %v: %v", s.Items[i].NodeID, r.StatusCode)
+		}
+	}
+
+	ticker := time.NewTicker(stateCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+
+		case <-ticker.C:
+			if st := client.State(); st != opcua.Connected {
+				return fmt.Errorf("the connection is %v", st)
+			}
+
+		case n := <-notifyCh:
+			if n.Error != nil {
+				return n.Error
+			}
+			switch v := n.Value.(type) {
+			case *ua.DataChangeNotification:
+				for _, item := range v.MonitoredItems {
+					if int(item.ClientHandle) >= len(s.Items) {
+						continue
+					}
+					if err := f(newDataChange(ctx, s.Items[item.ClientHandle], item.Value)); err != nil {
+						return err
+					}
+				}
+
+			case *ua.StatusChangeNotification:
+				if v.Status != ua.StatusOK {
+					return fmt.Errorf("the subscription is closed: %v", v.Status)
+				}
+			}
+		}
+	}
+}
+
+func (cli *Client) options(c context.Context, s *bql.OPCUASubscription) ([]opcua.Option, error) {
+	eps, err := opcua.GetEndpoints(c, s.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	ep, err := opcua.SelectEndpoint(eps, s.SecurityPolicy, ua.MessageSecurityModeFromString(s.SecurityMode))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []opcua.Option{
+		opcua.AutoReconnect(false),
+		opcua.SecurityPolicy(s.SecurityPolicy),
+		opcua.SecurityModeString(s.SecurityMode),
+	}
+	if s.CertFile != "" {
+		opts = append(opts, opcua.CertificateFile(s.CertFile), opcua.PrivateKeyFile(s.KeyFile))
+	}
+	if s.Username != "" {
+		opts = append(opts, opcua.AuthUsername(s.Username, s.Password),
+			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeUserName))
+	} else {
+		opts = append(opts, opcua.AuthAnonymous(),
+			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous))
+	}
+	return opts, nil
+}
+
+func newDataChange(ctx *core.Context, item *bql.OPCUAMonitoredItem, dv *ua.DataValue) *bql.OPCUADataChange {
+	c := &bql.OPCUADataChange{
+		Item:  item,
+		Value: data.Null{},
+	}
+	if dv == nil {
+		return c
+	}
+	c.StatusCode = uint32(dv.Status)
+	c.SourceTimestamp = dv.SourceTimestamp
+	c.ServerTimestamp = dv.ServerTimestamp
+	if dv.Value != nil {
+		v, err := convert(dv.Value.Value())
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_id", item.NodeID).
+				Warning("Cannot convert the value of the node")
+		} else {
+			c.Value = v
+		}
+	}
+	return c
+}
+
+// convert converts a value of a variant to data.Value.
+func convert(v interface{}) (data.Value, error) {
+	switch v := v.(type) {
+	case *ua.LocalizedText:
+		if v == nil {
+			return data.Null{}, nil
+		}
+		return data.String(v.Text), nil
+	case *ua.QualifiedName:
+		if v == nil {
+			return data.Null{}, nil
+		}
+		return data.String(v.Name), nil
+	case *ua.NodeID:
+		return data.String(v.String()), nil
+	case *ua.ExpandedNodeID:
+		return data.String(v.String()), nil
+	case *ua.GUID:
+		return data.String(v.String()), nil
+	case ua.StatusCode:
+		return data.Int(v), nil
+	case []byte:
+		return data.Blob(v), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		// e.g. XmlElement
+		return data.String(rv.String()), nil
+	case reflect.Slice:
+		a := make(data.Array, rv.Len())
+		for i := range a {
+			e, err := convert(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			a[i] = e
+		}
+		return a, nil
+	}
+	return data.NewValue(v)
+}
+
+func init() {
+	bql.SetOPCUAClient(&Client{})
+}
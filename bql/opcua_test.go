@@ -0,0 +1,193 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOPCUAClient fails the first subscription and then sends changes.
+type fakeOPCUAClient struct {
+	m       sync.Mutex
+	subs    []*OPCUASubscription
+	changes []*OPCUADataChange
+}
+
+func (c *fakeOPCUAClient) Subscribe(ctx *core.Context, s *OPCUASubscription, stop <-chan struct{},
+	f func(c *OPCUADataChange) error) error {
+	c.m.Lock()
+	c.subs = append(c.subs, s)
+	n := len(c.subs)
+	c.m.Unlock()
+	if n == 1 {
+		return errors.New("connection refused")
+	}
+
+	for _, ch := range c.changes {
+		ch.Item = s.Items[0]
+		if err := f(ch); err != nil {
+			return err
+		}
+	}
+	<-stop
+	return nil
+}
+
+func TestOPCUASource(t *testing.T) {
+	Convey("Given an OPC UA client", t, func() {
+		ctx := core.NewContext(nil)
+		serverTime := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+		c := &fakeOPCUAClient{
+			changes: []*OPCUADataChange{
+				{
+					Value:           data.Float(21.5),
+					SourceTimestamp: serverTime.Add(-time.Second),
+					ServerTimestamp: serverTime,
+				},
+				{
+					StatusCode: 0x80310000, // BadNoCommunication
+				},
+				{
+					Value:           data.Int(1),
+					StatusCode:      0x40000000,
+					SourceTimestamp: serverTime.Add(time.Second),
+				},
+			},
+		}
+		SetOPCUAClient(c)
+		Reset(func() {
+			SetOPCUAClient(nil)
+		})
+		params := data.Map{
+			"endpoint": data.String("opc.tcp://localhost:4840"),
+			"nodes": data.Array{
+				data.Map{
+					"node_id":           data.String("ns=2;s=Temp"),
+					"name":              data.String("temp"),
+					"sampling_interval": data.String("100ms"),
+				},
+				data.String("i=2258"),
+			},
+		}
+
+		read := func(n int) []*core.Tuple {
+			src, err := createOPCUASource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			s := core.NewSupervisedSource(src, &core.SupervisorConfig{
+				InitialBackoff: time.Millisecond,
+			})
+			ch := make(chan *core.Tuple, n)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					ch <- t
+					return nil
+				}))
+			}()
+			var ts []*core.Tuple
+			for i := 0; i < n; i++ {
+				ts = append(ts, <-ch)
+			}
+			So(s.Stop(ctx), ShouldBeNil)
+			So(<-done, ShouldBeNil)
+			return ts
+		}
+
+		Convey("When reading data changes", func() {
+			ts := read(3)
+
+			Convey("Then the source should be reconnected after the failure", func() {
+				So(c.subs, ShouldHaveLength, 2)
+				So(c.subs[1].Items, ShouldResemble, []*OPCUAMonitoredItem{
+					{NodeID: "ns=2;s=Temp", Name: "temp", SamplingInterval: 100 * time.Millisecond},
+					{NodeID: "i=2258", Name: "i=2258"},
+				})
+				So(c.subs[1].SecurityPolicy, ShouldEqual, "None")
+				So(c.subs[1].PublishingInterval, ShouldEqual, time.Second)
+			})
+
+			Convey("Then tuples should have values and statuses", func() {
+				So(ts[0].Data, ShouldResemble, data.Map{
+					"node_id":          data.String("ns=2;s=Temp"),
+					"name":             data.String("temp"),
+					"value":            data.Float(21.5),
+					"status":           data.String("good"),
+					"status_code":      data.Int(0),
+					"source_timestamp": data.Timestamp(serverTime.Add(-time.Second)),
+					"server_timestamp": data.Timestamp(serverTime),
+				})
+				So(ts[1].Data["value"], ShouldResemble, data.Null{})
+				So(ts[1].Data["status"], ShouldEqual, data.String("bad"))
+				So(ts[2].Data["status"], ShouldEqual, data.String("uncertain"))
+				So(ts[2].Data["server_timestamp"], ShouldResemble, data.Null{})
+			})
+
+			Convey("Then timestamps should be event times", func() {
+				So(ts[0].Timestamp, ShouldResemble, serverTime)
+				So(ts[2].Timestamp, ShouldResemble, serverTime.Add(time.Second))
+			})
+		})
+
+		Convey("When skipping bad values", func() {
+			params["skip_bad"] = data.True
+			ts := read(2)
+
+			Convey("Then values having bad status codes should be dropped", func() {
+				So(ts[0].Data["status"], ShouldEqual, data.String("good"))
+				So(ts[1].Data["status"], ShouldEqual, data.String("uncertain"))
+			})
+		})
+
+		Convey("When the subscription fails without the supervisor", func() {
+			s, err := createOPCUASource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			err = s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				return nil
+			}))
+
+			Convey("Then GenerateStream should return the error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "connection refused")
+			})
+		})
+
+		Convey("When creating sources with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{"endpoint": data.String("http://localhost")},
+					{"nodes": data.Array{}},
+					{"nodes": data.Array{data.String("Temp")}},
+					{"nodes": data.Array{data.String("i=1"), data.String("i=1")}},
+					{"nodes": data.Array{data.Map{"name": data.String("a")}}},
+					{"publishing_interval": data.Int(0)},
+					{"security_policy": data.String("Basic512")},
+					{"security_policy": data.String("Basic256Sha256")},
+					{"security_policy": data.String("None"), "security_mode": data.String("Sign")},
+					{"cert_file": data.String("cert.pem")},
+					{"password": data.String("secret")},
+					{"skip_bad": data.String("yes")},
+				} {
+					ps := params.Copy()
+					for k, v := range p {
+						ps[k] = v
+					}
+					_, err := createOPCUASource(ctx, &IOParams{}, ps)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When the client isn't available", func() {
+			SetOPCUAClient(nil)
+			_, err := createOPCUASource(ctx, &IOParams{}, params)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldEqual, ErrNoOPCUAClient)
+			})
+		})
+	})
+}
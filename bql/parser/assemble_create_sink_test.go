@@ -10,6 +10,7 @@ func TestAssembleCreateSink(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct CREATE SINK items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.PushComponent(4, 6, SourceSinkType("b"))
 			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
@@ -23,12 +24,13 @@ func TestAssembleCreateSink(t *testing.T) {
 				Convey("And that item is a CreateSinkStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
+					So(top.begin, ShouldEqual, 0)
 					So(top.end, ShouldEqual, 10)
 					So(top.comp, ShouldHaveSameTypeAs, CreateSinkStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(CreateSinkStmt)
+						So(comp.OrReplace, ShouldEqual, Yes)
 						So(comp.Name, ShouldEqual, "a")
 						So(comp.Type, ShouldEqual, "b")
 						So(len(comp.Params), ShouldEqual, 2)
@@ -10,7 +10,8 @@ func TestAssembleCreateSource(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct CREATE SOURCE items", func() {
-			ps.PushComponent(0, 2, Yes)
+			ps.PushComponent(0, 1, Yes)
+			ps.PushComponent(1, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.PushComponent(4, 6, SourceSinkType("b"))
 			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
@@ -30,6 +31,7 @@ func TestAssembleCreateSource(t *testing.T) {
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(CreateSourceStmt)
+						So(comp.OrReplace, ShouldEqual, Yes)
 						So(comp.Paused, ShouldEqual, Yes)
 						So(comp.Name, ShouldEqual, "a")
 						So(comp.Type, ShouldEqual, "b")
@@ -97,5 +99,31 @@ func TestAssembleCreateSource(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When doing a full CREATE OR REPLACE SOURCE", func() {
+			p.Buffer = `CREATE OR REPLACE PAUSED SOURCE a_1 TYPE b_b`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateSourceStmt{})
+				comp := top.(CreateSourceStmt)
+
+				So(comp.OrReplace, ShouldEqual, Yes)
+				So(comp.Paused, ShouldEqual, Yes)
+				So(comp.Name, ShouldEqual, "a_1")
+				So(comp.Type, ShouldEqual, "b_b")
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
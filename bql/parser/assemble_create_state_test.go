@@ -11,6 +11,7 @@ func TestAssembleCreateState(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct CREATE SINK items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.PushComponent(4, 6, SourceSinkType("b"))
 			ps.PushComponent(6, 8, SourceSinkParamAST{"c", data.String("d")})
@@ -24,12 +25,13 @@ func TestAssembleCreateState(t *testing.T) {
 				Convey("And that item is a CreateStateStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
+					So(top.begin, ShouldEqual, 0)
 					So(top.end, ShouldEqual, 10)
 					So(top.comp, ShouldHaveSameTypeAs, CreateStateStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(CreateStateStmt)
+						So(comp.OrReplace, ShouldEqual, Yes)
 						So(comp.Name, ShouldEqual, "a")
 						So(comp.Type, ShouldEqual, "b")
 						So(len(comp.Params), ShouldEqual, 2)
@@ -142,5 +144,32 @@ func TestAssembleCreateState(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When doing CREATE STATE with nested parameters", func() {
+			p.Buffer = `CREATE STATE a_1 TYPE b WITH c=[[1,2],{"d":[3]}], e={"f":{"g":["h"]}}`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				comp := ps.Peek().comp.(CreateStateStmt)
+				So(len(comp.Params), ShouldEqual, 2)
+				So(comp.Params[0].Value, ShouldResemble, data.Array{
+					data.Array{data.Int(1), data.Int(2)},
+					data.Map{"d": data.Array{data.Int(3)}},
+				})
+				So(comp.Params[1].Value, ShouldResemble, data.Map{
+					"f": data.Map{"g": data.Array{data.String("h")}},
+				})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
@@ -9,6 +9,7 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct CREATE STREAM items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("x"))
 			ps.PushComponent(4, 6, Istream)
 			ps.AssembleEmitterOptions(6, 6)
@@ -24,6 +25,8 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 			ps.EnsureCapacitySpec(12, 13)
 			ps.PushComponent(13, 14, DropOldest)
 			ps.EnsureSheddingSpec(13, 14)
+			ps.EnsurePipeSpec(14, 14)
+			ps.EnsurePartitionSpec(14, 14)
 			ps.AssembleStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.PushComponent(14, 15, Stream{ActualStream, "d", nil})
@@ -32,6 +35,8 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 			ps.AssembleInterval()
 			ps.EnsureCapacitySpec(18, 18)
 			ps.EnsureSheddingSpec(18, 18)
+			ps.EnsurePipeSpec(18, 18)
+			ps.EnsurePartitionSpec(18, 18)
 			ps.AssembleStreamWindow()
 			ps.PushComponent(18, 19, Identifier("x"))
 			ps.AssembleAliasedStreamWindow()
@@ -44,7 +49,9 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 			ps.AssembleGrouping(21, 23)
 			ps.PushComponent(23, 24, RowValue{"", "h"})
 			ps.AssembleHaving(23, 24)
+			ps.AssembleInputSampling(24, 24)
 			ps.AssembleSelect()
+			ps.EnsureEvictionTarget(24, 24)
 			ps.AssembleCreateStreamAsSelect()
 
 			Convey("Then AssembleCreateStreamAsSelect transforms them into one item", func() {
@@ -53,12 +60,13 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 				Convey("And that item is a CreateStreamAsSelectStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
+					So(top.begin, ShouldEqual, 0)
 					So(top.end, ShouldEqual, 24)
 					So(top.comp, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						cssComp := top.comp.(CreateStreamAsSelectStmt)
+						So(cssComp.OrReplace, ShouldEqual, Yes)
 						So(cssComp.Name, ShouldEqual, "x")
 						comp := cssComp.Select
 						So(comp.EmitterType, ShouldEqual, Istream)
@@ -150,6 +158,32 @@ func TestAssembleCreateStreamAsSelect(t *testing.T) {
 				So(comp.GroupList[1], ShouldResemble, RowValue{"", "g"})
 				So(comp.Having, ShouldResemble, RowValue{"", "h"})
 
+				So(cssComp.EvictTo, ShouldEqual, "")
+
+				Convey("And String() should return the original statement", func() {
+					So(cssComp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a SELECT with ON EVICT EMIT TO", func() {
+			p.Buffer = `CREATE STREAM x AS SELECT RSTREAM * FROM c [RANGE 3 SECONDS] ON EVICT EMIT TO expired`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek()
+				So(top.end, ShouldEqual, len(p.Buffer))
+				cssComp := top.comp.(CreateStreamAsSelectStmt)
+				So(cssComp.Name, ShouldEqual, "x")
+				So(cssComp.EvictTo, ShouldEqual, "expired")
+				So(cssComp.Select.Relations[0].Name, ShouldEqual, "c")
+
 				Convey("And String() should return the original statement", func() {
 					So(cssComp.String(), ShouldEqual, p.Buffer)
 				})
@@ -9,6 +9,7 @@ func TestAssembleCreateStreamAsSelectUnion(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct CREATE STREAM items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("x"))
 			ps.PushComponent(4, 6, Istream)
 			ps.AssembleEmitterOptions(6, 6)
@@ -24,6 +25,8 @@ func TestAssembleCreateStreamAsSelectUnion(t *testing.T) {
 			ps.EnsureCapacitySpec(12, 13)
 			ps.PushComponent(13, 14, DropOldest)
 			ps.EnsureSheddingSpec(13, 14)
+			ps.EnsurePipeSpec(14, 14)
+			ps.EnsurePartitionSpec(14, 14)
 			ps.AssembleStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.PushComponent(14, 15, Stream{ActualStream, "d", nil})
@@ -32,6 +35,8 @@ func TestAssembleCreateStreamAsSelectUnion(t *testing.T) {
 			ps.AssembleInterval()
 			ps.EnsureCapacitySpec(18, 18)
 			ps.EnsureSheddingSpec(18, 18)
+			ps.EnsurePipeSpec(18, 18)
+			ps.EnsurePartitionSpec(18, 18)
 			ps.AssembleStreamWindow()
 			ps.PushComponent(18, 19, Identifier("x"))
 			ps.AssembleAliasedStreamWindow()
@@ -44,6 +49,7 @@ func TestAssembleCreateStreamAsSelectUnion(t *testing.T) {
 			ps.AssembleGrouping(21, 23)
 			ps.PushComponent(23, 24, RowValue{"", "h"})
 			ps.AssembleHaving(23, 24)
+			ps.AssembleInputSampling(24, 24)
 			ps.AssembleSelect()
 			ps.AssembleSelectUnion(4, 24)
 			ps.AssembleCreateStreamAsSelectUnion()
@@ -54,12 +60,13 @@ func TestAssembleCreateStreamAsSelectUnion(t *testing.T) {
 				Convey("And that item is a CreateStreamAsSelectUnionStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
+					So(top.begin, ShouldEqual, 0)
 					So(top.end, ShouldEqual, 24)
 					So(top.comp, ShouldHaveSameTypeAs, CreateStreamAsSelectUnionStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						cssComp := top.comp.(CreateStreamAsSelectUnionStmt)
+						So(cssComp.OrReplace, ShouldEqual, Yes)
 						So(cssComp.Name, ShouldEqual, "x")
 						So(len(cssComp.Selects), ShouldEqual, 1)
 						comp := cssComp.Selects[0]
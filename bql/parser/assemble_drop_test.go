@@ -9,7 +9,9 @@ func TestAssembleDropSource(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct DROP SOURCE items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
+			ps.PushComponent(4, 6, Yes)
 			ps.AssembleDropSource()
 
 			Convey("Then AssembleDropSource transforms them into one item", func() {
@@ -18,13 +20,15 @@ func TestAssembleDropSource(t *testing.T) {
 				Convey("And that item is a DropSourceStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
-					So(top.end, ShouldEqual, 4)
+					So(top.begin, ShouldEqual, 0)
+					So(top.end, ShouldEqual, 6)
 					So(top.comp, ShouldHaveSameTypeAs, DropSourceStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(DropSourceStmt)
+						So(comp.IfExists, ShouldEqual, Yes)
 						So(comp.Source, ShouldEqual, "a")
+						So(comp.Cascade, ShouldEqual, Yes)
 					})
 				})
 			})
@@ -64,6 +68,31 @@ func TestAssembleDropSource(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When doing a full DROP SOURCE IF EXISTS CASCADE", func() {
+			p.Buffer = "DROP SOURCE IF EXISTS a_1 CASCADE"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, DropSourceStmt{})
+				comp := top.(DropSourceStmt)
+
+				So(comp.IfExists, ShouldEqual, Yes)
+				So(comp.Source, ShouldEqual, "a_1")
+				So(comp.Cascade, ShouldEqual, Yes)
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
 
@@ -71,7 +100,9 @@ func TestAssembleDropStream(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct DROP STREAM items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
+			ps.PushComponent(4, 6, Yes)
 			ps.AssembleDropStream()
 
 			Convey("Then AssembleDropStream transforms them into one item", func() {
@@ -80,13 +111,15 @@ func TestAssembleDropStream(t *testing.T) {
 				Convey("And that item is a DropStreamStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
-					So(top.end, ShouldEqual, 4)
+					So(top.begin, ShouldEqual, 0)
+					So(top.end, ShouldEqual, 6)
 					So(top.comp, ShouldHaveSameTypeAs, DropStreamStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(DropStreamStmt)
+						So(comp.IfExists, ShouldEqual, Yes)
 						So(comp.Stream, ShouldEqual, "a")
+						So(comp.Cascade, ShouldEqual, Yes)
 					})
 				})
 			})
@@ -133,6 +166,7 @@ func TestAssembleDropSink(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct DROP SINK items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.AssembleDropSink()
 
@@ -142,12 +176,13 @@ func TestAssembleDropSink(t *testing.T) {
 				Convey("And that item is a DropSinkStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
+					So(top.begin, ShouldEqual, 0)
 					So(top.end, ShouldEqual, 4)
 					So(top.comp, ShouldHaveSameTypeAs, DropSinkStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(DropSinkStmt)
+						So(comp.IfExists, ShouldEqual, Yes)
 						So(comp.Sink, ShouldEqual, "a")
 					})
 				})
@@ -195,6 +230,7 @@ func TestAssembleDropState(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 		Convey("When the stack contains the correct DROP STATE items", func() {
+			ps.PushComponent(0, 2, Yes)
 			ps.PushComponent(2, 4, StreamIdentifier("a"))
 			ps.AssembleDropState()
 
@@ -204,12 +240,13 @@ func TestAssembleDropState(t *testing.T) {
 				Convey("And that item is a DropStateStmt", func() {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
-					So(top.begin, ShouldEqual, 2)
+					So(top.begin, ShouldEqual, 0)
 					So(top.end, ShouldEqual, 4)
 					So(top.comp, ShouldHaveSameTypeAs, DropStateStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
 						comp := top.comp.(DropStateStmt)
+						So(comp.IfExists, ShouldEqual, Yes)
 						So(comp.State, ShouldEqual, "a")
 					})
 				})
@@ -250,5 +287,29 @@ func TestAssembleDropState(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When doing a full DROP STATE IF EXISTS", func() {
+			p.Buffer = "DROP STATE IF EXISTS a_1"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, DropStateStmt{})
+				comp := top.(DropStateStmt)
+
+				So(comp.IfExists, ShouldEqual, Yes)
+				So(comp.State, ShouldEqual, "a_1")
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
@@ -387,5 +387,83 @@ func TestAssembleEmitter(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When using RSTREAM with a WHEN CHANGED specifier", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT RSTREAM [WHEN CHANGED] 2 FROM a [RANGE 1 TUPLES]"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
+				comp := top.(CreateStreamAsSelectStmt)
+
+				So(comp.Name, ShouldEqual, "x")
+				So(comp.Select.EmitterType, ShouldEqual, Rstream)
+				So(comp.Select.EmitterOptions, ShouldResemble, []interface{}{
+					EmitterChange{}})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When using ISTREAM with a WHEN CHANGED BY specifier", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM [WHEN CHANGED BY a, b.c] 2 FROM a [RANGE 1 TUPLES]"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
+				comp := top.(CreateStreamAsSelectStmt)
+
+				So(comp.Name, ShouldEqual, "x")
+				So(comp.Select.EmitterType, ShouldEqual, Istream)
+				So(comp.Select.EmitterOptions, ShouldResemble, []interface{}{
+					EmitterChange{[]string{"a", "b.c"}}})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When using RSTREAM with WHEN CHANGED BY and LIMIT specifier", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT RSTREAM [WHEN CHANGED BY a LIMIT 7] 2 FROM a [RANGE 1 TUPLES]"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
+				comp := top.(CreateStreamAsSelectStmt)
+
+				So(comp.Name, ShouldEqual, "x")
+				So(comp.Select.EmitterType, ShouldEqual, Rstream)
+				So(comp.Select.EmitterOptions, ShouldResemble, []interface{}{
+					EmitterChange{[]string{"a"}}, EmitterLimit{7}})
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
 	})
 }
@@ -54,3 +54,55 @@ func TestAssembleFuncApp(t *testing.T) {
 		})
 	})
 }
+
+func TestAssembleWindowFuncApp(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+
+		Convey("When the stack contains a function application with an OVER clause", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 12, FuncAppAST{FuncName("lag"),
+				ExpressionsAST{[]Expression{RowValue{"", "a"}}}, nil})
+			ps.PushComponent(20, 34, ExpressionsAST{[]Expression{RowValue{"", "k"}}})
+			ps.PushComponent(35, 46, ExpressionsAST{[]Expression{
+				SortedExpressionAST{RowValue{"", "ts"}, Yes, UnspecifiedKeyword}}})
+			ps.AssembleWindowFuncApp(12, 47)
+
+			Convey("Then AssembleWindowFuncApp replaces them with a new item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a WindowFuncAppAST", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 47)
+					So(top.comp, ShouldResemble, WindowFuncAppAST{
+						FuncAppAST{FuncName("lag"), ExpressionsAST{[]Expression{RowValue{"", "a"}}}, nil},
+						[]Expression{RowValue{"", "k"}},
+						[]SortedExpressionAST{{RowValue{"", "ts"}, Yes, UnspecifiedKeyword}},
+					})
+				})
+			})
+		})
+
+		Convey("When the function application doesn't have an OVER clause", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 12, FuncAppAST{FuncName("lag"),
+				ExpressionsAST{[]Expression{RowValue{"", "a"}}}, nil})
+			ps.AssembleWindowFuncApp(12, 12)
+
+			Convey("Then AssembleWindowFuncApp doesn't change the stack", func() {
+				So(ps.Len(), ShouldEqual, 2)
+				So(ps.Peek().comp, ShouldHaveSameTypeAs, FuncAppAST{})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+
+			Convey("Then AssembleWindowFuncApp panics", func() {
+				So(func() { ps.AssembleWindowFuncApp(6, 10) }, ShouldPanic)
+			})
+		})
+	})
+}
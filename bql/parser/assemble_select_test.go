@@ -21,6 +21,8 @@ func TestAssembleSelect(t *testing.T) {
 			ps.EnsureCapacitySpec(12, 13)
 			ps.PushComponent(13, 14, DropOldest)
 			ps.EnsureSheddingSpec(13, 14)
+			ps.EnsurePipeSpec(14, 14)
+			ps.EnsurePartitionSpec(14, 14)
 			ps.AssembleStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.PushComponent(14, 15, Stream{ActualStream, "d", nil})
@@ -29,6 +31,8 @@ func TestAssembleSelect(t *testing.T) {
 			ps.AssembleInterval()
 			ps.EnsureCapacitySpec(18, 18)
 			ps.EnsureSheddingSpec(18, 18)
+			ps.EnsurePipeSpec(18, 18)
+			ps.EnsurePartitionSpec(18, 18)
 			ps.AssembleStreamWindow()
 			ps.PushComponent(18, 19, Identifier("x"))
 			ps.AssembleAliasedStreamWindow()
@@ -41,6 +45,8 @@ func TestAssembleSelect(t *testing.T) {
 			ps.AssembleGrouping(24, 28)
 			ps.PushComponent(28, 30, RowValue{"", "h"})
 			ps.AssembleHaving(28, 30)
+			ps.PushComponent(30, 32, FloatLiteral{0.5})
+			ps.AssembleInputSampling(30, 32)
 			ps.AssembleSelect()
 
 			Convey("Then AssembleSelect transforms them into one item", func() {
@@ -50,7 +56,7 @@ func TestAssembleSelect(t *testing.T) {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
 					So(top.begin, ShouldEqual, 4)
-					So(top.end, ShouldEqual, 30)
+					So(top.end, ShouldEqual, 32)
 					So(top.comp, ShouldHaveSameTypeAs, SelectStmt{})
 
 					Convey("And it contains the previously pushed data", func() {
@@ -78,6 +84,7 @@ func TestAssembleSelect(t *testing.T) {
 						So(comp.GroupList[0], ShouldResemble, RowValue{"", "f"})
 						So(comp.GroupList[1], ShouldResemble, RowValue{"", "g"})
 						So(comp.Having, ShouldResemble, RowValue{"", "h"})
+						So(comp.Sample, ShouldResemble, FloatLiteral{0.5})
 					})
 				})
 			})
@@ -104,6 +111,8 @@ func TestAssembleSelect(t *testing.T) {
 			ps.EnsureCapacitySpec(12, 13)
 			ps.PushComponent(13, 14, DropOldest)
 			ps.EnsureSheddingSpec(13, 14)
+			ps.EnsurePipeSpec(14, 14)
+			ps.EnsurePartitionSpec(14, 14)
 			ps.AssembleStreamWindow()
 			ps.EnsureAliasedStreamWindow()
 			ps.PushComponent(14, 15, Stream{ActualStream, "d", nil})
@@ -112,6 +121,8 @@ func TestAssembleSelect(t *testing.T) {
 			ps.AssembleInterval()
 			ps.EnsureCapacitySpec(18, 18)
 			ps.EnsureSheddingSpec(18, 18)
+			ps.EnsurePipeSpec(18, 18)
+			ps.EnsurePartitionSpec(18, 18)
 			ps.AssembleStreamWindow()
 			ps.PushComponent(18, 19, Identifier("x"))
 			ps.AssembleAliasedStreamWindow()
@@ -124,6 +135,7 @@ func TestAssembleSelect(t *testing.T) {
 			ps.AssembleGrouping(24, 28)
 			ps.PushComponent(28, 30, RowValue{"", "h"})
 			ps.AssembleFilter(28, 30) // must be HAVING in correct stmt
+			ps.AssembleInputSampling(30, 30)
 			Convey("Then AssembleSelect panics", func() {
 				So(ps.AssembleSelect, ShouldPanic)
 			})
@@ -134,7 +146,7 @@ func TestAssembleSelect(t *testing.T) {
 		p := &bqlPeg{}
 
 		Convey("When doing a full SELECT", func() {
-			p.Buffer = `SELECT ISTREAM "日本語", b FROM c [RANGE 3 TUPLES, BUFFER SIZE 2, DROP OLDEST IF FULL], d("state", 7) [RANGE 2 SECONDS] AS x WHERE e GROUP BY f, g HAVING h`
+			p.Buffer = `SELECT ISTREAM "日本語", b FROM c [RANGE 3 TUPLES, BUFFER SIZE 2, DROP OLDEST IF FULL], d("state", 7) [RANGE 2 SECONDS] AS x WHERE e GROUP BY f, g HAVING h SAMPLE 1 PERCENT`
 			p.Init()
 
 			Convey("Then the statement should be parsed correctly", func() {
@@ -177,11 +189,27 @@ func TestAssembleSelect(t *testing.T) {
 				So(comp.GroupList[0], ShouldResemble, RowValue{"", "f"})
 				So(comp.GroupList[1], ShouldResemble, RowValue{"", "g"})
 				So(comp.Having, ShouldResemble, RowValue{"", "h"})
+				So(comp.Sample, ShouldResemble, NumericLiteral{1})
 
 				Convey("And String() should return the original statement", func() {
 					So(comp.String(), ShouldEqual, p.Buffer)
 				})
 			})
 		})
+
+		Convey("When doing a SELECT without a SAMPLE clause", func() {
+			p.Buffer = `SELECT ISTREAM a FROM b [RANGE 1 TUPLES] WHERE sample`
+			p.Init()
+
+			Convey("Then the statement should not have a sample", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				comp := p.parseStack.Peek().comp.(SelectStmt)
+				So(comp.Filter, ShouldResemble, RowValue{"", "sample"})
+				So(comp.Sample, ShouldBeNil)
+			})
+		})
 	})
 }
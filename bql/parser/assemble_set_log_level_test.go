@@ -0,0 +1,106 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleSetLogLevel(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct SET LOG LEVEL items", func() {
+			ps.PushComponent(14, 19, Identifier("debug"))
+			ps.PushComponent(24, 28, NodeTarget)
+			ps.PushComponent(29, 33, StreamIdentifier("box1"))
+			ps.AssembleSetLogLevel()
+
+			Convey("Then AssembleSetLogLevel transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a SetLogLevelStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 14)
+					So(top.end, ShouldEqual, 33)
+					So(top.comp, ShouldHaveSameTypeAs, SetLogLevelStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(SetLogLevelStmt)
+						So(comp.Level, ShouldEqual, "debug")
+						So(comp.Target.Type, ShouldEqual, NodeTarget)
+						So(comp.Target.Name, ShouldEqual, "box1")
+					})
+				})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(14, 19, Identifier("debug"))
+			ps.PushComponent(24, 28, Raw{"NODE"}) // must be SettingTargetType
+			ps.PushComponent(29, 33, StreamIdentifier("box1"))
+
+			Convey("Then AssembleSetLogLevel panics", func() {
+				So(ps.AssembleSetLogLevel, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		Convey("When doing a full SET LOG LEVEL for a node", func() {
+			p.Buffer = "SET LOG LEVEL debug FOR NODE box1"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SetLogLevelStmt{})
+				comp := top.(SetLogLevelStmt)
+
+				So(comp.Level, ShouldEqual, "debug")
+				So(comp.Target.Type, ShouldEqual, NodeTarget)
+				So(comp.Target.Name, ShouldEqual, "box1")
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a full SET LOG LEVEL for a topology", func() {
+			p.Buffer = "SET LOG LEVEL warn FOR TOPOLOGY t"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, SetLogLevelStmt{})
+				comp := top.(SetLogLevelStmt)
+
+				So(comp.Level, ShouldEqual, "warn")
+				So(comp.Target.Type, ShouldEqual, TopologyTarget)
+				So(comp.Target.Name, ShouldEqual, "t")
+			})
+		})
+
+		Convey("When SET LOG LEVEL doesn't have a target", func() {
+			p.Buffer = "SET LOG LEVEL debug"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
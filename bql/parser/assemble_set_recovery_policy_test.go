@@ -0,0 +1,90 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleSetRecoveryPolicy(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct SET RECOVERY POLICY items", func() {
+			ps.PushComponent(10, 22, Identifier("restart_node"))
+			ps.PushComponent(27, 31, NodeTarget)
+			ps.PushComponent(32, 36, StreamIdentifier("box1"))
+			ps.PushComponent(36, 36, SourceSinkSpecsAST{})
+			ps.AssembleSetRecoveryPolicy()
+
+			Convey("Then AssembleSetRecoveryPolicy transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a SetRecoveryPolicyStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 10)
+					So(top.end, ShouldEqual, 36)
+					So(top.comp, ShouldHaveSameTypeAs, SetRecoveryPolicyStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(SetRecoveryPolicyStmt)
+						So(comp.Policy, ShouldEqual, "restart_node")
+						So(comp.Target.Type, ShouldEqual, NodeTarget)
+						So(comp.Target.Name, ShouldEqual, "box1")
+						So(len(comp.Params), ShouldEqual, 0)
+					})
+				})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(10, 22, Raw{"restart_node"}) // must be Identifier
+			ps.PushComponent(27, 31, NodeTarget)
+			ps.PushComponent(32, 36, StreamIdentifier("box1"))
+			ps.PushComponent(36, 36, SourceSinkSpecsAST{})
+
+			Convey("Then AssembleSetRecoveryPolicy panics", func() {
+				So(ps.AssembleSetRecoveryPolicy, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		for _, stmt := range []string{
+			"SET RECOVERY POLICY halt FOR NODE box1",
+			"SET RECOVERY POLICY restart_node FOR NODE box1 WITH max_restarts=3",
+			`SET RECOVERY POLICY restart_topology FOR NODE sink1 WITH initial_backoff="1s", max_backoff="1m"`,
+		} {
+			stmt := stmt
+			Convey("When doing a full "+stmt, func() {
+				p.Buffer = stmt
+				p.Init()
+
+				Convey("Then the statement should be parsed correctly", func() {
+					err := p.Parse()
+					So(err, ShouldEqual, nil)
+					p.Execute()
+
+					ps := p.parseStack
+					So(ps.Len(), ShouldEqual, 1)
+					top := ps.Peek().comp
+					So(top, ShouldHaveSameTypeAs, SetRecoveryPolicyStmt{})
+
+					Convey("And String() should return the original statement", func() {
+						So(top.(SetRecoveryPolicyStmt).String(), ShouldEqual, p.Buffer)
+					})
+				})
+			})
+		}
+
+		Convey("When SET RECOVERY POLICY has no policy", func() {
+			p.Buffer = "SET RECOVERY POLICY FOR NODE box1"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
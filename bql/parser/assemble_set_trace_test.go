@@ -0,0 +1,83 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleSetTrace(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains the correct SET TRACE items", func() {
+			ps.PushComponent(10, 12, Yes)
+			ps.PushComponent(17, 25, TopologyTarget)
+			ps.PushComponent(26, 27, StreamIdentifier("t"))
+			ps.AssembleSetTrace()
+
+			Convey("Then AssembleSetTrace transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 1)
+
+				Convey("And that item is a SetTraceStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 10)
+					So(top.end, ShouldEqual, 27)
+					So(top.comp, ShouldHaveSameTypeAs, SetTraceStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(SetTraceStmt)
+						So(comp.Enabled, ShouldEqual, Yes)
+						So(comp.Target.Type, ShouldEqual, TopologyTarget)
+						So(comp.Target.Name, ShouldEqual, "t")
+					})
+				})
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(10, 12, Raw{"ON"}) // must be BinaryKeyword
+			ps.PushComponent(17, 25, TopologyTarget)
+			ps.PushComponent(26, 27, StreamIdentifier("t"))
+
+			Convey("Then AssembleSetTrace panics", func() {
+				So(ps.AssembleSetTrace, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		for _, stmt := range []string{"SET TRACE ON FOR TOPOLOGY t", "SET TRACE OFF FOR NODE box1"} {
+			stmt := stmt
+			Convey("When doing a full "+stmt, func() {
+				p.Buffer = stmt
+				p.Init()
+
+				Convey("Then the statement should be parsed correctly", func() {
+					err := p.Parse()
+					So(err, ShouldEqual, nil)
+					p.Execute()
+
+					ps := p.parseStack
+					So(ps.Len(), ShouldEqual, 1)
+					top := ps.Peek().comp
+					So(top, ShouldHaveSameTypeAs, SetTraceStmt{})
+
+					Convey("And String() should return the original statement", func() {
+						So(top.(SetTraceStmt).String(), ShouldEqual, p.Buffer)
+					})
+				})
+			})
+		}
+
+		Convey("When SET TRACE has an invalid switch", func() {
+			p.Buffer = "SET TRACE MAYBE FOR TOPOLOGY t"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestAssembleShow(t *testing.T) {
+	Convey("Given a parseStack", t, func() {
+		ps := parseStack{}
+		Convey("When the stack contains only a target", func() {
+			ps.PushComponent(2, 4, Raw{"PRE"})
+			ps.PushComponent(5, 12, SourcesTarget)
+			ps.AssembleShow(12, 12)
+
+			Convey("Then AssembleShow transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a ShowStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 5)
+					So(top.end, ShouldEqual, 12)
+					So(top.comp, ShouldHaveSameTypeAs, ShowStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(ShowStmt)
+						So(comp.Target, ShouldEqual, SourcesTarget)
+						So(comp.Topology, ShouldEqual, "")
+					})
+				})
+			})
+		})
+
+		Convey("When the stack contains a target and a topology", func() {
+			ps.PushComponent(2, 4, Raw{"PRE"})
+			ps.PushComponent(5, 12, SinksTarget)
+			ps.PushComponent(16, 20, StreamIdentifier("test"))
+			ps.AssembleShow(12, 20)
+
+			Convey("Then AssembleShow transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a ShowStmt", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 5)
+					So(top.end, ShouldEqual, 20)
+					So(top.comp, ShouldHaveSameTypeAs, ShowStmt{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(ShowStmt)
+						So(comp.Target, ShouldEqual, SinksTarget)
+						So(comp.Topology, ShouldEqual, "test")
+					})
+				})
+			})
+		})
+
+		Convey("When the stack does not contain enough items", func() {
+			f := func() { ps.AssembleShow(12, 12) }
+			Convey("Then AssembleShow panics", func() {
+				So(f, ShouldPanic)
+			})
+		})
+
+		Convey("When the stack contains a wrong item", func() {
+			ps.PushComponent(2, 4, Raw{"PRE"})
+			ps.PushComponent(5, 12, NodeTarget)
+
+			f := func() { ps.AssembleShow(12, 12) }
+			Convey("Then AssembleShow panics", func() {
+				So(f, ShouldPanic)
+			})
+		})
+	})
+
+	Convey("Given a parser", t, func() {
+		p := &bqlPeg{}
+
+		targets := map[string]ShowTargetType{
+			"SOURCES": SourcesTarget,
+			"STREAMS": StreamsTarget,
+			"SINKS":   SinksTarget,
+			"STATES":  StatesTarget,
+			"UDFS":    UDFsTarget,
+		}
+		for name, target := range targets {
+			name, target := name, target
+
+			Convey("When doing a SHOW "+name, func() {
+				p.Buffer = "SHOW " + name
+				p.Init()
+
+				Convey("Then the statement should be parsed correctly", func() {
+					err := p.Parse()
+					So(err, ShouldEqual, nil)
+					p.Execute()
+
+					ps := p.parseStack
+					So(ps.Len(), ShouldEqual, 1)
+					top := ps.Peek().comp
+					So(top, ShouldHaveSameTypeAs, ShowStmt{})
+					comp := top.(ShowStmt)
+
+					So(comp.Target, ShouldEqual, target)
+					So(comp.Topology, ShouldEqual, "")
+
+					Convey("And String() should return the original statement", func() {
+						So(comp.String(), ShouldEqual, p.Buffer)
+					})
+				})
+			})
+		}
+
+		Convey("When doing a SHOW with IN", func() {
+			p.Buffer = "SHOW STREAMS IN my_topology"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, ShowStmt{})
+				comp := top.(ShowStmt)
+
+				So(comp.Target, ShouldEqual, StreamsTarget)
+				So(comp.Topology, ShouldEqual, "my_topology")
+
+				Convey("And String() should return the original statement", func() {
+					So(comp.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When doing a SHOW with an unknown target", func() {
+			p.Buffer = "SHOW NODES"
+			p.Init()
+
+			Convey("Then parsing should fail", func() {
+				So(p.Parse(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -9,10 +9,11 @@ func TestAssembleSortedExpression(t *testing.T) {
 	Convey("Given a parseStack", t, func() {
 		ps := parseStack{}
 
-		Convey("When the stack contains two correct items", func() {
+		Convey("When the stack contains three correct items", func() {
 			ps.PushComponent(0, 6, Raw{"PRE"})
 			ps.PushComponent(6, 7, NumericLiteral{2})
 			ps.PushComponent(7, 8, Yes)
+			ps.PushComponent(8, 20, No)
 			ps.AssembleSortedExpression()
 
 			Convey("Then AssembleSortedExpression replaces them with a new item", func() {
@@ -22,13 +23,14 @@ func TestAssembleSortedExpression(t *testing.T) {
 					top := ps.Peek()
 					So(top, ShouldNotBeNil)
 					So(top.begin, ShouldEqual, 6)
-					So(top.end, ShouldEqual, 8)
+					So(top.end, ShouldEqual, 20)
 					So(top.comp, ShouldHaveSameTypeAs, SortedExpressionAST{})
 
 					Convey("And it contains the previous data", func() {
 						comp := top.comp.(SortedExpressionAST)
 						So(comp.Expr, ShouldResemble, NumericLiteral{2})
 						So(comp.Ascending, ShouldResemble, Yes)
+						So(comp.NullsFirst, ShouldResemble, No)
 					})
 				})
 			})
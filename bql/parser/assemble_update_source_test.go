@@ -89,5 +89,31 @@ func TestAssembleUpdateSource(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("When doing a full UPDATE SOURCE with WITH", func() {
+			p.Buffer = `UPDATE SOURCE a_1 WITH c=27`
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, UpdateSourceStmt{})
+				comp := top.(UpdateSourceStmt)
+
+				So(comp.Name, ShouldEqual, "a_1")
+				So(len(comp.Params), ShouldEqual, 1)
+				So(comp.Params[0].Key, ShouldEqual, "c")
+				So(comp.Params[0].Value, ShouldEqual, data.Int(27))
+
+				Convey("And String() should return the statement with SET", func() {
+					So(comp.String(), ShouldEqual, `UPDATE SOURCE a_1 SET c=27`)
+				})
+			})
+		})
 	})
 }
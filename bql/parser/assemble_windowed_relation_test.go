@@ -17,6 +17,8 @@ func TestAssembleStreamWindow(t *testing.T) {
 			ps.EnsureCapacitySpec(10, 12)
 			ps.PushComponent(12, 14, DropOldest)
 			ps.EnsureSheddingSpec(12, 14)
+			ps.EnsurePipeSpec(14, 14)
+			ps.EnsurePartitionSpec(14, 14)
 			ps.AssembleStreamWindow()
 
 			Convey("Then AssembleStreamWindow transforms them into one item", func() {
@@ -36,6 +38,7 @@ func TestAssembleStreamWindow(t *testing.T) {
 						So(comp.Unit, ShouldEqual, Seconds)
 						So(comp.Capacity, ShouldEqual, 2)
 						So(comp.Shedding, ShouldEqual, DropOldest)
+						So(comp.Partition, ShouldBeNil)
 					})
 				})
 			})
@@ -49,6 +52,8 @@ func TestAssembleStreamWindow(t *testing.T) {
 			ps.EnsureCapacitySpec(10, 12)
 			ps.PushComponent(12, 14, DropNewest)
 			ps.EnsureSheddingSpec(12, 14)
+			ps.EnsurePipeSpec(14, 14)
+			ps.EnsurePartitionSpec(14, 14)
 			ps.AssembleStreamWindow()
 
 			Convey("Then AssembleStreamWindow transforms them into one item", func() {
@@ -73,6 +78,41 @@ func TestAssembleStreamWindow(t *testing.T) {
 			})
 		})
 
+		Convey("When the stack contains a PARTITION BY clause", func() {
+			ps.PushComponent(0, 6, Raw{"PRE"})
+			ps.PushComponent(6, 8, Stream{ActualStream, "a", nil})
+			ps.PushComponent(8, 10, IntervalAST{FloatLiteral{2}, Seconds})
+			ps.EnsureCapacitySpec(10, 10)
+			ps.EnsureSheddingSpec(10, 10)
+			ps.EnsurePipeSpec(10, 10)
+			ps.PushComponent(10, 12, RowValue{"", "k"})
+			ps.PushComponent(12, 13, NumericLiteral{1})
+			ps.PushComponent(13, 14, NumericLiteral{4})
+			ps.AssemblePartitionSpec()
+			ps.EnsurePartitionSpec(13, 14)
+			ps.AssembleStreamWindow()
+
+			Convey("Then AssembleStreamWindow transforms them into one item", func() {
+				So(ps.Len(), ShouldEqual, 2)
+
+				Convey("And that item is a StreamWindowAST", func() {
+					top := ps.Peek()
+					So(top, ShouldNotBeNil)
+					So(top.begin, ShouldEqual, 6)
+					So(top.end, ShouldEqual, 14)
+					So(top.comp, ShouldHaveSameTypeAs, StreamWindowAST{})
+
+					Convey("And it contains the previously pushed data", func() {
+						comp := top.comp.(StreamWindowAST)
+						So(comp.Name, ShouldEqual, "a")
+						So(comp.Capacity, ShouldEqual, UnspecifiedCapacity)
+						So(comp.Shedding, ShouldEqual, UnspecifiedSheddingOption)
+						So(comp.Partition, ShouldResemble, &PartitionAST{RowValue{"", "k"}, 1, 4})
+					})
+				})
+			})
+		})
+
 		Convey("When the stack contains a wrong item", func() {
 			ps.PushComponent(0, 6, Raw{"PRE"})
 			ps.PushComponent(6, 8, Stream{ActualStream, "a", nil})
@@ -120,6 +160,33 @@ func TestAssembleStreamWindow(t *testing.T) {
 			})
 		})
 
+		Convey("When selecting with a FROM (PARTITION BY)", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM a, b FROM c [RANGE 3 TUPLES, BUFFER SIZE 1, PARTITION BY a + 1 INSTANCE 2 OF 4]"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
+				comp := top.(CreateStreamAsSelectStmt).Select
+				So(comp.Relations[0].Name, ShouldEqual, "c")
+				So(comp.Relations[0].Capacity, ShouldEqual, 1)
+				So(comp.Relations[0].Shedding, ShouldEqual, UnspecifiedSheddingOption)
+				So(comp.Relations[0].Partition, ShouldResemble, &PartitionAST{
+					BinaryOpAST{Plus, RowValue{"", "a"}, NumericLiteral{1}}, 2, 4})
+
+				Convey("And String() should return the original statement", func() {
+					stmt := top.(CreateStreamAsSelectStmt)
+					So(stmt.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
 		Convey("When selecting with a FROM (TUPLES/float)", func() {
 			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM a, b FROM c [RANGE 3.0 TUPLES]"
 			p.Init()
@@ -158,6 +225,57 @@ func TestAssembleStreamWindow(t *testing.T) {
 			})
 		})
 
+		Convey("When selecting with a FROM (ring pipe)", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM a, b FROM c [RANGE 3 TUPLES, BUFFER SIZE 8, DROP NEWEST IF FULL, RING PIPE]"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				ps := p.parseStack
+				So(ps.Len(), ShouldEqual, 1)
+				top := ps.Peek().comp
+				So(top, ShouldHaveSameTypeAs, CreateStreamAsSelectStmt{})
+				comp := top.(CreateStreamAsSelectStmt).Select
+				So(comp.Relations[0].Capacity, ShouldEqual, 8)
+				So(comp.Relations[0].Shedding, ShouldEqual, DropNewest)
+				So(comp.Relations[0].Pipe, ShouldEqual, RingPipe)
+
+				Convey("And String() should return the original statement", func() {
+					stmt := top.(CreateStreamAsSelectStmt)
+					So(stmt.String(), ShouldEqual, p.Buffer)
+				})
+			})
+		})
+
+		Convey("When selecting with a FROM (channel pipe)", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM a, b FROM c [RANGE 3 TUPLES, CHANNEL PIPE]"
+			p.Init()
+
+			Convey("Then the statement should be parsed correctly", func() {
+				err := p.Parse()
+				So(err, ShouldEqual, nil)
+				p.Execute()
+
+				top := p.parseStack.Peek().comp
+				comp := top.(CreateStreamAsSelectStmt).Select
+				So(comp.Relations[0].Pipe, ShouldEqual, ChannelPipe)
+				So(top.(CreateStreamAsSelectStmt).String(), ShouldEqual, p.Buffer)
+			})
+		})
+
+		Convey("When selecting with a FROM (pipe before shedding)", func() {
+			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM a, b FROM c [RANGE 3 TUPLES, RING PIPE, WAIT IF FULL]"
+			p.Init()
+
+			Convey("Then parsing the statement should fail", func() {
+				err := p.Parse()
+				So(err, ShouldNotEqual, nil)
+			})
+		})
+
 		Convey("When selecting with a FROM (MILLISECONDS/float)", func() {
 			p.Buffer = "CREATE STREAM x AS SELECT ISTREAM a, b FROM c [RANGE 0.2 MILLISECONDS]"
 			p.Init()
@@ -177,6 +295,7 @@ func TestAssembleStreamWindow(t *testing.T) {
 				So(comp.Relations[0].Unit, ShouldEqual, Milliseconds)
 				So(comp.Relations[0].Capacity, ShouldEqual, UnspecifiedCapacity)
 				So(comp.Relations[0].Shedding, ShouldEqual, UnspecifiedSheddingOption)
+				So(comp.Relations[0].Pipe, ShouldEqual, UnspecifiedPipeOption)
 				So(comp.Relations[0].Alias, ShouldEqual, "")
 
 				Convey("And String() should return the original statement", func() {
@@ -28,6 +28,7 @@ type SelectStmt struct {
 	FilterAST
 	GroupingAST
 	HavingAST
+	InputSamplingAST
 }
 
 func (s SelectStmt) String() string {
@@ -37,6 +38,7 @@ func (s SelectStmt) String() string {
 	str = append(str, s.FilterAST.string())
 	str = append(str, s.GroupingAST.string())
 	str = append(str, s.HavingAST.string())
+	str = append(str, s.InputSamplingAST.string())
 
 	st := []string{}
 	for _, s := range str {
@@ -60,34 +62,44 @@ func (s SelectUnionStmt) String() string {
 }
 
 type CreateStreamAsSelectStmt struct {
-	Name   StreamIdentifier
-	Select SelectStmt
+	OrReplace BinaryKeyword
+	Name      StreamIdentifier
+	Select    SelectStmt
+	// EvictTo is the name of the stream to which tuples evicted from
+	// windows are emitted (ON EVICT EMIT TO). It's empty when it isn't
+	// specified.
+	EvictTo StreamIdentifier
 }
 
 func (s CreateStreamAsSelectStmt) String() string {
-	str := []string{"CREATE", "STREAM", string(s.Name), "AS", s.Select.String()}
+	str := []string{createKeyword(s.OrReplace), "STREAM", string(s.Name), "AS", s.Select.String()}
+	if s.EvictTo != "" {
+		str = append(str, "ON EVICT EMIT TO", string(s.EvictTo))
+	}
 	return strings.Join(str, " ")
 }
 
 type CreateStreamAsSelectUnionStmt struct {
-	Name StreamIdentifier
+	OrReplace BinaryKeyword
+	Name      StreamIdentifier
 	SelectUnionStmt
 }
 
 func (s CreateStreamAsSelectUnionStmt) String() string {
-	str := []string{"CREATE", "STREAM", string(s.Name), "AS", s.SelectUnionStmt.String()}
+	str := []string{createKeyword(s.OrReplace), "STREAM", string(s.Name), "AS", s.SelectUnionStmt.String()}
 	return strings.Join(str, " ")
 }
 
 type CreateSourceStmt struct {
-	Paused BinaryKeyword
-	Name   StreamIdentifier
-	Type   SourceSinkType
+	OrReplace BinaryKeyword
+	Paused    BinaryKeyword
+	Name      StreamIdentifier
+	Type      SourceSinkType
 	SourceSinkSpecsAST
 }
 
 func (s CreateSourceStmt) String() string {
-	str := []string{"CREATE", "SOURCE", string(s.Name), "TYPE", string(s.Type)}
+	str := []string{createKeyword(s.OrReplace), "SOURCE", string(s.Name), "TYPE", string(s.Type)}
 	paused := s.Paused.string("PAUSED", "UNPAUSED")
 	if paused != "" {
 		str = append(str[:1], append([]string{paused}, str[1:]...)...)
@@ -100,13 +112,30 @@ func (s CreateSourceStmt) String() string {
 }
 
 type CreateSinkStmt struct {
-	Name StreamIdentifier
-	Type SourceSinkType
+	OrReplace BinaryKeyword
+	Name      StreamIdentifier
+	Type      SourceSinkType
 	SourceSinkSpecsAST
 }
 
 func (s CreateSinkStmt) String() string {
-	str := []string{"CREATE", "SINK", string(s.Name), "TYPE", string(s.Type)}
+	str := []string{createKeyword(s.OrReplace), "SINK", string(s.Name), "TYPE", string(s.Type)}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
+	return strings.Join(str, " ")
+}
+
+type CreateBoxStmt struct {
+	Name  StreamIdentifier
+	Type  SourceSinkType
+	Input StreamIdentifier
+	SourceSinkSpecsAST
+}
+
+func (s CreateBoxStmt) String() string {
+	str := []string{"CREATE", "BOX", string(s.Name), "TYPE", string(s.Type), "FROM", string(s.Input)}
 	specs := s.SourceSinkSpecsAST.string("WITH")
 	if specs != "" {
 		str = append(str, specs)
@@ -115,13 +144,14 @@ func (s CreateSinkStmt) String() string {
 }
 
 type CreateStateStmt struct {
-	Name StreamIdentifier
-	Type SourceSinkType
+	OrReplace BinaryKeyword
+	Name      StreamIdentifier
+	Type      SourceSinkType
 	SourceSinkSpecsAST
 }
 
 func (s CreateStateStmt) String() string {
-	str := []string{"CREATE", "STATE", string(s.Name), "TYPE", string(s.Type)}
+	str := []string{createKeyword(s.OrReplace), "STATE", string(s.Name), "TYPE", string(s.Type)}
 	specs := s.SourceSinkSpecsAST.string("WITH")
 	if specs != "" {
 		str = append(str, specs)
@@ -209,41 +239,67 @@ func (s RewindSourceStmt) String() string {
 }
 
 type DropSourceStmt struct {
-	Source StreamIdentifier
+	IfExists BinaryKeyword
+	Source   StreamIdentifier
+	Cascade  BinaryKeyword
 }
 
 func (s DropSourceStmt) String() string {
-	str := []string{"DROP", "SOURCE", string(s.Source)}
+	str := append(dropKeywords("SOURCE", s.IfExists), string(s.Source))
+	if s.Cascade == Yes {
+		str = append(str, "CASCADE")
+	}
 	return strings.Join(str, " ")
 }
 
 type DropStreamStmt struct {
-	Stream StreamIdentifier
+	IfExists BinaryKeyword
+	Stream   StreamIdentifier
+	Cascade  BinaryKeyword
 }
 
 func (s DropStreamStmt) String() string {
-	str := []string{"DROP", "STREAM", string(s.Stream)}
+	str := append(dropKeywords("STREAM", s.IfExists), string(s.Stream))
+	if s.Cascade == Yes {
+		str = append(str, "CASCADE")
+	}
 	return strings.Join(str, " ")
 }
 
 type DropSinkStmt struct {
-	Sink StreamIdentifier
+	IfExists BinaryKeyword
+	Sink     StreamIdentifier
 }
 
 func (s DropSinkStmt) String() string {
-	str := []string{"DROP", "SINK", string(s.Sink)}
+	str := append(dropKeywords("SINK", s.IfExists), string(s.Sink))
 	return strings.Join(str, " ")
 }
 
 type DropStateStmt struct {
-	State StreamIdentifier
+	IfExists BinaryKeyword
+	State    StreamIdentifier
 }
 
 func (s DropStateStmt) String() string {
-	str := []string{"DROP", "STATE", string(s.State)}
+	str := append(dropKeywords("STATE", s.IfExists), string(s.State))
 	return strings.Join(str, " ")
 }
 
+func createKeyword(orReplace BinaryKeyword) string {
+	if orReplace == Yes {
+		return "CREATE OR REPLACE"
+	}
+	return "CREATE"
+}
+
+func dropKeywords(kind string, ifExists BinaryKeyword) []string {
+	if ifExists == Yes {
+		return []string{"DROP", kind, "IF EXISTS"}
+	}
+	return []string{"DROP", kind}
+}
+
 type LoadStateStmt struct {
 	Name StreamIdentifier
 	Type SourceSinkType
@@ -303,6 +359,61 @@ func (s SaveStateStmt) String() string {
 	return strings.Join(str, " ")
 }
 
+type LoadPluginStmt struct {
+	Path string
+}
+
+func (s LoadPluginStmt) String() string {
+	str := []string{"LOAD", "PLUGIN", StringLiteral{Value: s.Path}.String()}
+	return strings.Join(str, " ")
+}
+
+type SetLogLevelStmt struct {
+	Level  string
+	Target SettingTarget
+}
+
+func (s SetLogLevelStmt) String() string {
+	str := []string{"SET", "LOG", "LEVEL", s.Level, "FOR", s.Target.String()}
+	return strings.Join(str, " ")
+}
+
+type SetTraceStmt struct {
+	Enabled BinaryKeyword
+	Target  SettingTarget
+}
+
+func (s SetTraceStmt) String() string {
+	str := []string{"SET", "TRACE", s.Enabled.string("ON", "OFF"), "FOR", s.Target.String()}
+	return strings.Join(str, " ")
+}
+
+type SetRecoveryPolicyStmt struct {
+	Policy Identifier
+	Target SettingTarget
+	SourceSinkSpecsAST
+}
+
+func (s SetRecoveryPolicyStmt) String() string {
+	str := []string{"SET", "RECOVERY", "POLICY", string(s.Policy), "FOR", s.Target.String()}
+	specs := s.SourceSinkSpecsAST.string("WITH")
+	if specs != "" {
+		str = append(str, specs)
+	}
+	return strings.Join(str, " ")
+}
+
+// SettingTarget is a node or a topology whose setting is changed by a SET
+// statement.
+type SettingTarget struct {
+	Type SettingTargetType
+	Name StreamIdentifier
+}
+
+func (t SettingTarget) String() string {
+	return t.Type.String() + " " + string(t.Name)
+}
+
 type EvalStmt struct {
 	Expr  Expression
 	Input *MapAST
@@ -316,6 +427,21 @@ func (s EvalStmt) String() string {
 	return strings.Join(str, " ")
 }
 
+// ShowStmt lists entities of a topology. When Topology is empty, the
+// topology on which the statement is issued is used.
+type ShowStmt struct {
+	Target   ShowTargetType
+	Topology StreamIdentifier
+}
+
+func (s ShowStmt) String() string {
+	str := []string{"SHOW", s.Target.String()}
+	if s.Topology != "" {
+		str = append(str, "IN", string(s.Topology))
+	}
+	return strings.Join(str, " ")
+}
+
 type EmitterAST struct {
 	EmitterType    Emitter
 	EmitterOptions []interface{}
@@ -331,6 +457,8 @@ func (a EmitterAST) string() string {
 				optStrings[i] = fmt.Sprintf("LIMIT %d", obj.Limit)
 			case EmitterSampling:
 				optStrings[i] = obj.string()
+			case EmitterChange:
+				optStrings[i] = obj.string()
 			}
 		}
 		s += " [" + strings.Join(optStrings, " ") + "]"
@@ -370,6 +498,21 @@ func (e EmitterSampling) string() string {
 	return ""
 }
 
+// EmitterChange is an emitter option which suppresses results that haven't
+// changed. When Keys is empty, results are only emitted when they're
+// different from the previous results. Otherwise, a row is only emitted when
+// it's different from the row emitted last time with the same keys.
+type EmitterChange struct {
+	Keys []string
+}
+
+func (e EmitterChange) string() string {
+	if len(e.Keys) == 0 {
+		return "WHEN CHANGED"
+	}
+	return "WHEN CHANGED BY " + strings.Join(e.Keys, ", ")
+}
+
 type ProjectionsAST struct {
 	Projections []Expression
 }
@@ -437,8 +580,10 @@ const UnspecifiedCapacity int64 = -1
 type StreamWindowAST struct {
 	Stream
 	IntervalAST
-	Capacity int64
-	Shedding SheddingOption
+	Capacity  int64
+	Shedding  SheddingOption
+	Pipe      PipeOption
+	Partition *PartitionAST
 }
 
 func (a StreamWindowAST) string() string {
@@ -451,7 +596,15 @@ func (a StreamWindowAST) string() string {
 	if a.Shedding != UnspecifiedSheddingOption {
 		shedding = fmt.Sprintf(", %s IF FULL", a.Shedding.String())
 	}
-	suffix := "[" + interval + capacity + shedding + "]"
+	pipe := ""
+	if a.Pipe != UnspecifiedPipeOption {
+		pipe = fmt.Sprintf(", %s PIPE", a.Pipe.String())
+	}
+	partition := ""
+	if a.Partition != nil {
+		partition = ", " + a.Partition.string()
+	}
+	suffix := "[" + interval + capacity + shedding + pipe + partition + "]"
 
 	switch a.Stream.Type {
 	case ActualStream:
@@ -468,6 +621,19 @@ func (a StreamWindowAST) string() string {
 	return "UnknownStreamType"
 }
 
+// PartitionAST is a PARTITION BY clause of an input stream. Tuples are hash
+// partitioned by Expr into Count partitions and only tuples in the partition
+// Index are sent to the input.
+type PartitionAST struct {
+	Expr  Expression
+	Index int64
+	Count int64
+}
+
+func (a PartitionAST) string() string {
+	return fmt.Sprintf("PARTITION BY %s INSTANCE %d OF %d", a.Expr.String(), a.Index, a.Count)
+}
+
 type IntervalAST struct {
 	FloatLiteral
 	Unit IntervalUnit
@@ -515,6 +681,20 @@ func (a HavingAST) string() string {
 	return "HAVING " + a.Having.String()
 }
 
+// InputSamplingAST has the percentage of input tuples which are processed
+// by a SELECT statement. Sample is a NumericLiteral or a FloatLiteral, or
+// nil when the statement doesn't have a SAMPLE clause.
+type InputSamplingAST struct {
+	Sample Expression
+}
+
+func (a InputSamplingAST) string() string {
+	if a.Sample == nil {
+		return ""
+	}
+	return "SAMPLE " + a.Sample.String() + " PERCENT"
+}
+
 type SourceSinkSpecsAST struct {
 	Params []SourceSinkParamAST
 }
@@ -675,6 +855,10 @@ func (u UnaryOpAST) String() string {
 type TypeCastAST struct {
 	Expr   Expression
 	Target Type
+
+	// Format is the format given by the FORMAT clause of CAST. It's empty
+	// when the clause is omitted.
+	Format string
 }
 
 func (u TypeCastAST) ReferencedRelations() map[string]bool {
@@ -683,7 +867,7 @@ func (u TypeCastAST) ReferencedRelations() map[string]bool {
 
 func (u TypeCastAST) RenameReferencedRelation(from, to string) Expression {
 	return TypeCastAST{u.Expr.RenameReferencedRelation(from, to),
-		u.Target}
+		u.Target, u.Format}
 }
 
 func (u TypeCastAST) Foldable() bool {
@@ -691,6 +875,11 @@ func (u TypeCastAST) Foldable() bool {
 }
 
 func (u TypeCastAST) String() string {
+	if u.Format != "" {
+		return "CAST(" + u.Expr.String() + " AS " + u.Target.String() +
+			" FORMAT " + StringLiteral{u.Format}.String() + ")"
+	}
+
 	if rv, ok := u.Expr.(RowValue); ok {
 		return rv.String() + "::" + u.Target.String()
 	}
@@ -699,6 +888,10 @@ func (u TypeCastAST) String() string {
 		return rm.String() + "::" + u.Target.String()
 	}
 
+	if rm, ok := u.Expr.(RowMetadata); ok {
+		return rm.String() + "::" + u.Target.String()
+	}
+
 	return "CAST(" + u.Expr.String() + " AS " + u.Target.String() + ")"
 }
 
@@ -767,9 +960,79 @@ func (f FuncAppAST) String() string {
 	return s + ")"
 }
 
+// WindowFuncAppAST is a function application having an OVER clause. It's
+// evaluated over rows in the current window which have the same values of
+// PartitionBy, in the order given by OrderBy.
+type WindowFuncAppAST struct {
+	FuncAppAST
+	PartitionBy []Expression
+	OrderBy     []SortedExpressionAST
+}
+
+func (w WindowFuncAppAST) ReferencedRelations() map[string]bool {
+	rels := w.FuncAppAST.ReferencedRelations()
+	for _, expr := range w.PartitionBy {
+		for rel := range expr.ReferencedRelations() {
+			rels[rel] = true
+		}
+	}
+	for _, expr := range w.OrderBy {
+		for rel := range expr.ReferencedRelations() {
+			rels[rel] = true
+		}
+	}
+	return rels
+}
+
+func (w WindowFuncAppAST) RenameReferencedRelation(from, to string) Expression {
+	f := w.FuncAppAST.RenameReferencedRelation(from, to).(FuncAppAST)
+	var partition []Expression
+	if w.PartitionBy != nil {
+		partition = make([]Expression, len(w.PartitionBy))
+		for i, expr := range w.PartitionBy {
+			partition[i] = expr.RenameReferencedRelation(from, to)
+		}
+	}
+	var order []SortedExpressionAST
+	if w.OrderBy != nil {
+		order = make([]SortedExpressionAST, len(w.OrderBy))
+		for i, expr := range w.OrderBy {
+			order[i] = expr.RenameReferencedRelation(from, to).(SortedExpressionAST)
+		}
+	}
+	return WindowFuncAppAST{f, partition, order}
+}
+
+func (w WindowFuncAppAST) Foldable() bool {
+	// the result depends on other rows in the window
+	return false
+}
+
+func (w WindowFuncAppAST) String() string {
+	clauses := []string{}
+	if len(w.PartitionBy) > 0 {
+		strs := make([]string, len(w.PartitionBy))
+		for i, expr := range w.PartitionBy {
+			strs[i] = expr.String()
+		}
+		clauses = append(clauses, "PARTITION BY "+strings.Join(strs, ", "))
+	}
+	if len(w.OrderBy) > 0 {
+		strs := make([]string, len(w.OrderBy))
+		for i, expr := range w.OrderBy {
+			strs[i] = expr.String()
+		}
+		clauses = append(clauses, "ORDER BY "+strings.Join(strs, ", "))
+	}
+	return w.FuncAppAST.String() + " OVER (" + strings.Join(clauses, " ") + ")"
+}
+
 type SortedExpressionAST struct {
 	Expr      Expression
 	Ascending BinaryKeyword
+	// NullsFirst is Yes for NULLS FIRST and No for NULLS LAST. When it's
+	// unspecified, NULL is sorted as the smallest value.
+	NullsFirst BinaryKeyword
 }
 
 func (s SortedExpressionAST) ReferencedRelations() map[string]bool {
@@ -778,7 +1041,7 @@ func (s SortedExpressionAST) ReferencedRelations() map[string]bool {
 
 func (s SortedExpressionAST) RenameReferencedRelation(from, to string) Expression {
 	return SortedExpressionAST{s.Expr.RenameReferencedRelation(from, to),
-		s.Ascending}
+		s.Ascending, s.NullsFirst}
 }
 
 func (s SortedExpressionAST) Foldable() bool {
@@ -792,6 +1055,11 @@ func (s SortedExpressionAST) String() string {
 	} else if s.Ascending == No {
 		ret += " DESC"
 	}
+	if s.NullsFirst == Yes {
+		ret += " NULLS FIRST"
+	} else if s.NullsFirst == No {
+		ret += " NULLS LAST"
+	}
 	return ret
 }
 
@@ -1148,6 +1416,48 @@ func NewRowMeta(s string, t MetaInformation) RowMeta {
 	return RowMeta{components[0], t}
 }
 
+// RowMetadata refers to a metadata value of a tuple having the key, e.g.
+// meta("partition"). It's evaluated to NULL when the tuple doesn't have the
+// key.
+type RowMetadata struct {
+	Relation string
+	Key      string
+}
+
+func (rm RowMetadata) ReferencedRelations() map[string]bool {
+	return map[string]bool{rm.Relation: true}
+}
+
+func (rm RowMetadata) RenameReferencedRelation(from, to string) Expression {
+	if rm.Relation == from {
+		return RowMetadata{to, rm.Key}
+	}
+	return rm
+}
+
+func (rm RowMetadata) Foldable() bool {
+	return false
+}
+
+func (rm RowMetadata) String() string {
+	s := "meta(" + StringLiteral{rm.Key}.String() + ")"
+	if rm.Relation != "" {
+		return rm.Relation + ":" + s
+	}
+	return s
+}
+
+// NewRowMetadata creates RowMetadata from s having a form of
+// `[relation:]meta(...)` and the key.
+func NewRowMetadata(s string, key string) RowMetadata {
+	colon := strings.Index(s, ":")
+	if colon < 0 || colon > strings.Index(s, "(") {
+		// the colon is a part of the key
+		return RowMetadata{"", key}
+	}
+	return RowMetadata{s[:colon], key}
+}
+
 type Raw struct {
 	Expr string
 }
@@ -1312,6 +1622,32 @@ func NewStringLiteral(s string) StringLiteral {
 	return StringLiteral{unescaped}
 }
 
+// Placeholder is a placeholder in a prepared statement. Name is empty for
+// a positional placeholder `?` and is the name of a named placeholder
+// `:name`.
+type Placeholder struct {
+	Name string
+}
+
+func (l Placeholder) ReferencedRelations() map[string]bool {
+	return nil
+}
+
+func (l Placeholder) RenameReferencedRelation(from, to string) Expression {
+	return l
+}
+
+func (l Placeholder) Foldable() bool {
+	return true
+}
+
+func (l Placeholder) String() string {
+	if l.Name == "" {
+		return "?"
+	}
+	return ":" + l.Name
+}
+
 type FuncName string
 
 type StreamIdentifier string
@@ -1411,6 +1747,9 @@ const (
 	UnknownMeta MetaInformation = iota
 	TimestampMeta
 	NowMeta
+	ProcTimestampMeta
+	InputNameMeta
+	TraceMeta
 )
 
 func (m MetaInformation) String() string {
@@ -1420,6 +1759,12 @@ func (m MetaInformation) String() string {
 		s = "TS"
 	case NowMeta:
 		s = "NOW"
+	case ProcTimestampMeta:
+		s = "PROC_TS"
+	case InputNameMeta:
+		s = "INPUT_NAME"
+	case TraceMeta:
+		s = "TRACE"
 	}
 	return s
 }
@@ -1431,6 +1776,12 @@ func (m MetaInformation) string() string {
 		s = "ts()"
 	case NowMeta:
 		s = "now()"
+	case ProcTimestampMeta:
+		s = "proc_ts()"
+	case InputNameMeta:
+		s = "input_name()"
+	case TraceMeta:
+		s = "trace()"
 	}
 	return s
 }
@@ -1464,6 +1815,53 @@ func (k BinaryKeyword) string(yes, no string) string {
 	return ""
 }
 
+type SettingTargetType int
+
+const (
+	UnspecifiedSettingTarget SettingTargetType = iota
+	NodeTarget
+	TopologyTarget
+)
+
+func (t SettingTargetType) String() string {
+	s := "UNSPECIFIED"
+	switch t {
+	case NodeTarget:
+		s = "NODE"
+	case TopologyTarget:
+		s = "TOPOLOGY"
+	}
+	return s
+}
+
+type ShowTargetType int
+
+const (
+	UnspecifiedShowTarget ShowTargetType = iota
+	SourcesTarget
+	StreamsTarget
+	SinksTarget
+	StatesTarget
+	UDFsTarget
+)
+
+func (t ShowTargetType) String() string {
+	s := "UNSPECIFIED"
+	switch t {
+	case SourcesTarget:
+		s = "SOURCES"
+	case StreamsTarget:
+		s = "STREAMS"
+	case SinksTarget:
+		s = "SINKS"
+	case StatesTarget:
+		s = "STATES"
+	case UDFsTarget:
+		s = "UDFS"
+	}
+	return s
+}
+
 type SheddingOption int
 
 const (
@@ -1486,6 +1884,25 @@ func (t SheddingOption) String() string {
 	return s
 }
 
+type PipeOption int
+
+const (
+	UnspecifiedPipeOption PipeOption = iota
+	ChannelPipe
+	RingPipe
+)
+
+func (t PipeOption) String() string {
+	s := "UnspecifiedPipeOption"
+	switch t {
+	case ChannelPipe:
+		s = "CHANNEL"
+	case RingPipe:
+		s = "RING"
+	}
+	return s
+}
+
 type Type int
 
 const (
@@ -1498,6 +1915,7 @@ const (
 	Timestamp
 	Array
 	Map
+	Vector
 )
 
 func (t Type) String() string {
@@ -1519,6 +1937,8 @@ func (t Type) String() string {
 		s = "ARRAY"
 	case Map:
 		s = "MAP"
+	case Vector:
+		s = "VECTOR"
 	}
 	return s
 }
@@ -1538,6 +1958,8 @@ const (
 	Greater
 	GreaterOrEqual
 	NotEqual
+	IsDistinctFrom
+	IsNotDistinctFrom
 	Concat
 	Is
 	IsNot
@@ -1557,6 +1979,10 @@ func (op Operator) hasSamePrecedenceAs(rhs Operator) bool {
 	if Less <= op && op <= GreaterOrEqual && Less <= rhs && rhs <= GreaterOrEqual {
 		return true
 	}
+	if IsDistinctFrom <= op && op <= IsNotDistinctFrom &&
+		IsDistinctFrom <= rhs && rhs <= IsNotDistinctFrom {
+		return true
+	}
 	if Is <= op && op <= IsNot && Is <= rhs && rhs <= IsNot {
 		return true
 	}
@@ -1599,6 +2025,10 @@ func (o Operator) String() string {
 		s = ">="
 	case NotEqual:
 		s = "!="
+	case IsDistinctFrom:
+		s = "IS DISTINCT FROM"
+	case IsNotDistinctFrom:
+		s = "IS NOT DISTINCT FROM"
 	case Concat:
 		s = "||"
 	case Is:
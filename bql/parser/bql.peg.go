@@ -21,13 +21,18 @@ const (
 	ruleSourceStmt
 	ruleSinkStmt
 	ruleStateStmt
+	rulePluginStmt
+	ruleBoxStmt
+	ruleSettingStmt
 	ruleStreamStmt
 	ruleSelectStmt
 	ruleSelectUnionStmt
 	ruleCreateStreamAsSelectStmt
+	ruleEvictionTargetOpt
 	ruleCreateStreamAsSelectUnionStmt
 	ruleCreateSourceStmt
 	ruleCreateSinkStmt
+	ruleCreateBoxStmt
 	ruleCreateStateStmt
 	ruleUpdateStateStmt
 	ruleUpdateSourceStmt
@@ -43,7 +48,14 @@ const (
 	ruleLoadStateStmt
 	ruleLoadStateOrCreateStmt
 	ruleSaveStateStmt
+	ruleLoadPluginStmt
+	ruleSetLogLevelStmt
+	ruleSetTraceStmt
+	ruleSetRecoveryPolicyStmt
+	ruleSettingTarget
 	ruleEvalStmt
+	ruleShowStmt
+	ruleShowTarget
 	ruleEmitter
 	ruleEmitterOptions
 	ruleEmitterOptionCombinations
@@ -54,6 +66,8 @@ const (
 	ruleTimeBasedSampling
 	ruleTimeBasedSamplingSeconds
 	ruleTimeBasedSamplingMilliseconds
+	ruleEmitterChange
+	ruleEmitterChangeKey
 	ruleProjections
 	ruleProjection
 	ruleAliasExpression
@@ -66,6 +80,7 @@ const (
 	ruleGrouping
 	ruleGroupList
 	ruleHaving
+	ruleInputSampling
 	ruleRelationLike
 	ruleAliasedStreamWindow
 	ruleStreamWindow
@@ -74,8 +89,13 @@ const (
 	ruleCapacitySpecOpt
 	ruleSheddingSpecOpt
 	ruleSheddingOption
+	rulePipeSpecOpt
+	rulePipeOption
+	rulePartitionSpecOpt
+	rulePartitionSpec
 	ruleSourceSinkSpecs
 	ruleUpdateSourceSinkSpecs
+	ruleUpdateSpecsKeyword
 	ruleSetOptSpecs
 	ruleStateTagOpt
 	ruleSourceSinkParam
@@ -85,6 +105,9 @@ const (
 	ruleParamMapExpr
 	ruleParamKeyValuePair
 	rulePausedOpt
+	ruleOrReplaceOpt
+	ruleIfExistsOpt
+	ruleCascadeOpt
 	ruleExpressionOrWildcard
 	ruleExpression
 	ruleorExpr
@@ -99,13 +122,18 @@ const (
 	rulecastExpr
 	rulebaseExpr
 	ruleFuncTypeCast
+	ruleCastFormatOpt
 	ruleFuncApp
+	ruleWindowClauseOpt
+	ruleWindowPartition
+	ruleWindowOrder
 	ruleFuncAppWithOrderBy
 	ruleFuncAppWithoutOrderBy
 	ruleFuncParams
 	ruleParamsOrder
 	ruleSortedExpression
 	ruleOrderDirectionOpt
+	ruleNullsOrderOpt
 	ruleArrayExpr
 	ruleMapExpr
 	ruleKeyValuePair
@@ -115,6 +143,7 @@ const (
 	ruleWhenThenPair
 	ruleLiteral
 	ruleComparisonOp
+	ruleDistinctOp
 	ruleOtherOp
 	ruleIsOp
 	rulePlusMinusOp
@@ -122,6 +151,10 @@ const (
 	ruleStream
 	ruleRowMeta
 	ruleRowTimestamp
+	ruleRowProcTimestamp
+	ruleRowInputName
+	ruleRowTrace
+	ruleRowMetadata
 	ruleRowValue
 	ruleNumericLiteral
 	ruleNonNegativeNumericLiteral
@@ -134,6 +167,7 @@ const (
 	ruleFALSE
 	ruleWildcard
 	ruleStringLiteral
+	rulePlaceholder
 	ruleISTREAM
 	ruleDSTREAM
 	ruleRSTREAM
@@ -143,13 +177,29 @@ const (
 	ruleWait
 	ruleDropOldest
 	ruleDropNewest
+	ruleChannelPipe
+	ruleRingPipe
 	ruleStreamIdentifier
 	ruleSourceSinkType
 	ruleSourceSinkParamKey
 	rulePaused
 	ruleUnpaused
+	ruleOrReplace
+	ruleIfExists
+	ruleCascade
+	ruleOn
+	ruleOff
+	ruleNodeTarget
+	ruleTopologyTarget
+	ruleSourcesTarget
+	ruleStreamsTarget
+	ruleSinksTarget
+	ruleStatesTarget
+	ruleUDFsTarget
 	ruleAscending
 	ruleDescending
+	ruleNullsFirst
+	ruleNullsLast
 	ruleType
 	ruleBool
 	ruleInt
@@ -159,6 +209,7 @@ const (
 	ruleTimestamp
 	ruleArray
 	ruleMap
+	ruleVector
 	ruleOr
 	ruleAnd
 	ruleNot
@@ -171,6 +222,8 @@ const (
 	ruleConcat
 	ruleIs
 	ruleIsNot
+	ruleIsDistinctFrom
+	ruleIsNotDistinctFrom
 	rulePlus
 	ruleMinus
 	ruleMultiply
@@ -335,6 +388,50 @@ const (
 	ruleAction131
 	ruleAction132
 	ruleAction133
+	ruleAction134
+	ruleAction135
+	ruleAction136
+	ruleAction137
+	ruleAction138
+	ruleAction139
+	ruleAction140
+	ruleAction141
+	ruleAction142
+	ruleAction143
+	ruleAction144
+	ruleAction145
+	ruleAction146
+	ruleAction147
+	ruleAction148
+	ruleAction149
+	ruleAction150
+	ruleAction151
+	ruleAction152
+	ruleAction153
+	ruleAction154
+	ruleAction155
+	ruleAction156
+	ruleAction157
+	ruleAction158
+	ruleAction159
+	ruleAction160
+	ruleAction161
+	ruleAction162
+	ruleAction163
+	ruleAction164
+	ruleAction165
+	ruleAction166
+	ruleAction167
+	ruleAction168
+	ruleAction169
+	ruleAction170
+	ruleAction171
+	ruleAction172
+	ruleAction173
+	ruleAction174
+	ruleAction175
+	ruleAction176
+	ruleAction177
 
 	rulePre
 	ruleIn
@@ -350,13 +447,18 @@ var rul3s = [...]string{
 	"SourceStmt",
 	"SinkStmt",
 	"StateStmt",
+	"PluginStmt",
+	"BoxStmt",
+	"SettingStmt",
 	"StreamStmt",
 	"SelectStmt",
 	"SelectUnionStmt",
 	"CreateStreamAsSelectStmt",
+	"EvictionTargetOpt",
 	"CreateStreamAsSelectUnionStmt",
 	"CreateSourceStmt",
 	"CreateSinkStmt",
+	"CreateBoxStmt",
 	"CreateStateStmt",
 	"UpdateStateStmt",
 	"UpdateSourceStmt",
@@ -372,7 +474,14 @@ var rul3s = [...]string{
 	"LoadStateStmt",
 	"LoadStateOrCreateStmt",
 	"SaveStateStmt",
+	"LoadPluginStmt",
+	"SetLogLevelStmt",
+	"SetTraceStmt",
+	"SetRecoveryPolicyStmt",
+	"SettingTarget",
 	"EvalStmt",
+	"ShowStmt",
+	"ShowTarget",
 	"Emitter",
 	"EmitterOptions",
 	"EmitterOptionCombinations",
@@ -383,6 +492,8 @@ var rul3s = [...]string{
 	"TimeBasedSampling",
 	"TimeBasedSamplingSeconds",
 	"TimeBasedSamplingMilliseconds",
+	"EmitterChange",
+	"EmitterChangeKey",
 	"Projections",
 	"Projection",
 	"AliasExpression",
@@ -395,6 +506,7 @@ var rul3s = [...]string{
 	"Grouping",
 	"GroupList",
 	"Having",
+	"InputSampling",
 	"RelationLike",
 	"AliasedStreamWindow",
 	"StreamWindow",
@@ -403,8 +515,13 @@ var rul3s = [...]string{
 	"CapacitySpecOpt",
 	"SheddingSpecOpt",
 	"SheddingOption",
+	"PipeSpecOpt",
+	"PipeOption",
+	"PartitionSpecOpt",
+	"PartitionSpec",
 	"SourceSinkSpecs",
 	"UpdateSourceSinkSpecs",
+	"UpdateSpecsKeyword",
 	"SetOptSpecs",
 	"StateTagOpt",
 	"SourceSinkParam",
@@ -414,6 +531,9 @@ var rul3s = [...]string{
 	"ParamMapExpr",
 	"ParamKeyValuePair",
 	"PausedOpt",
+	"OrReplaceOpt",
+	"IfExistsOpt",
+	"CascadeOpt",
 	"ExpressionOrWildcard",
 	"Expression",
 	"orExpr",
@@ -428,13 +548,18 @@ var rul3s = [...]string{
 	"castExpr",
 	"baseExpr",
 	"FuncTypeCast",
+	"CastFormatOpt",
 	"FuncApp",
+	"WindowClauseOpt",
+	"WindowPartition",
+	"WindowOrder",
 	"FuncAppWithOrderBy",
 	"FuncAppWithoutOrderBy",
 	"FuncParams",
 	"ParamsOrder",
 	"SortedExpression",
 	"OrderDirectionOpt",
+	"NullsOrderOpt",
 	"ArrayExpr",
 	"MapExpr",
 	"KeyValuePair",
@@ -444,6 +569,7 @@ var rul3s = [...]string{
 	"WhenThenPair",
 	"Literal",
 	"ComparisonOp",
+	"DistinctOp",
 	"OtherOp",
 	"IsOp",
 	"PlusMinusOp",
@@ -451,6 +577,10 @@ var rul3s = [...]string{
 	"Stream",
 	"RowMeta",
 	"RowTimestamp",
+	"RowProcTimestamp",
+	"RowInputName",
+	"RowTrace",
+	"RowMetadata",
 	"RowValue",
 	"NumericLiteral",
 	"NonNegativeNumericLiteral",
@@ -463,6 +593,7 @@ var rul3s = [...]string{
 	"FALSE",
 	"Wildcard",
 	"StringLiteral",
+	"Placeholder",
 	"ISTREAM",
 	"DSTREAM",
 	"RSTREAM",
@@ -472,13 +603,29 @@ var rul3s = [...]string{
 	"Wait",
 	"DropOldest",
 	"DropNewest",
+	"ChannelPipe",
+	"RingPipe",
 	"StreamIdentifier",
 	"SourceSinkType",
 	"SourceSinkParamKey",
 	"Paused",
 	"Unpaused",
+	"OrReplace",
+	"IfExists",
+	"Cascade",
+	"On",
+	"Off",
+	"NodeTarget",
+	"TopologyTarget",
+	"SourcesTarget",
+	"StreamsTarget",
+	"SinksTarget",
+	"StatesTarget",
+	"UDFsTarget",
 	"Ascending",
 	"Descending",
+	"NullsFirst",
+	"NullsLast",
 	"Type",
 	"Bool",
 	"Int",
@@ -488,6 +635,7 @@ var rul3s = [...]string{
 	"Timestamp",
 	"Array",
 	"Map",
+	"Vector",
 	"Or",
 	"And",
 	"Not",
@@ -500,6 +648,8 @@ var rul3s = [...]string{
 	"Concat",
 	"Is",
 	"IsNot",
+	"IsDistinctFrom",
+	"IsNotDistinctFrom",
 	"Plus",
 	"Minus",
 	"Multiply",
@@ -664,6 +814,50 @@ var rul3s = [...]string{
 	"Action131",
 	"Action132",
 	"Action133",
+	"Action134",
+	"Action135",
+	"Action136",
+	"Action137",
+	"Action138",
+	"Action139",
+	"Action140",
+	"Action141",
+	"Action142",
+	"Action143",
+	"Action144",
+	"Action145",
+	"Action146",
+	"Action147",
+	"Action148",
+	"Action149",
+	"Action150",
+	"Action151",
+	"Action152",
+	"Action153",
+	"Action154",
+	"Action155",
+	"Action156",
+	"Action157",
+	"Action158",
+	"Action159",
+	"Action160",
+	"Action161",
+	"Action162",
+	"Action163",
+	"Action164",
+	"Action165",
+	"Action166",
+	"Action167",
+	"Action168",
+	"Action169",
+	"Action170",
+	"Action171",
+	"Action172",
+	"Action173",
+	"Action174",
+	"Action175",
+	"Action176",
+	"Action177",
 
 	"Pre_",
 	"_In_",
@@ -984,7 +1178,7 @@ type bqlPegBackend struct {
 
 	Buffer string
 	buffer []rune
-	rules  [322]func() bool
+	rules  [419]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -1090,541 +1284,725 @@ func (p *bqlPegBackend) Execute() {
 
 		case ruleAction5:
 
-			p.AssembleCreateStreamAsSelectUnion()
+			p.EnsureEvictionTarget(begin, end)
 
 		case ruleAction6:
 
-			p.AssembleCreateSource()
+			p.AssembleCreateStreamAsSelectUnion()
 
 		case ruleAction7:
 
-			p.AssembleCreateSink()
+			p.AssembleCreateSource()
 
 		case ruleAction8:
 
-			p.AssembleCreateState()
+			p.AssembleCreateSink()
 
 		case ruleAction9:
 
-			p.AssembleUpdateState()
+			p.AssembleCreateBox()
 
 		case ruleAction10:
 
-			p.AssembleUpdateSource()
+			p.AssembleCreateState()
 
 		case ruleAction11:
 
-			p.AssembleUpdateSink()
+			p.AssembleUpdateState()
 
 		case ruleAction12:
 
-			p.AssembleInsertIntoFrom()
+			p.AssembleUpdateSource()
 
 		case ruleAction13:
 
-			p.AssemblePauseSource()
+			p.AssembleUpdateSink()
 
 		case ruleAction14:
 
-			p.AssembleResumeSource()
+			p.AssembleInsertIntoFrom()
 
 		case ruleAction15:
 
-			p.AssembleRewindSource()
+			p.AssemblePauseSource()
 
 		case ruleAction16:
 
-			p.AssembleDropSource()
+			p.AssembleResumeSource()
 
 		case ruleAction17:
 
-			p.AssembleDropStream()
+			p.AssembleRewindSource()
 
 		case ruleAction18:
 
-			p.AssembleDropSink()
+			p.AssembleDropSource()
 
 		case ruleAction19:
 
-			p.AssembleDropState()
+			p.AssembleDropStream()
 
 		case ruleAction20:
 
-			p.AssembleLoadState()
+			p.AssembleDropSink()
 
 		case ruleAction21:
 
-			p.AssembleLoadStateOrCreate()
+			p.AssembleDropState()
 
 		case ruleAction22:
 
-			p.AssembleSaveState()
+			p.AssembleLoadState()
 
 		case ruleAction23:
 
-			p.AssembleEval(begin, end)
+			p.AssembleLoadStateOrCreate()
 
 		case ruleAction24:
 
-			p.AssembleEmitter()
+			p.AssembleSaveState()
 
 		case ruleAction25:
 
-			p.AssembleEmitterOptions(begin, end)
+			p.AssembleLoadPlugin()
 
 		case ruleAction26:
 
-			p.AssembleEmitterLimit()
+			p.AssembleSetLogLevel()
 
 		case ruleAction27:
 
-			p.AssembleEmitterSampling(CountBasedSampling, 1)
+			p.AssembleSetTrace()
 
 		case ruleAction28:
 
-			p.AssembleEmitterSampling(RandomizedSampling, 1)
+			p.AssembleSetRecoveryPolicy()
 
 		case ruleAction29:
 
-			p.AssembleEmitterSampling(TimeBasedSampling, 1)
+			p.AssembleEval(begin, end)
 
 		case ruleAction30:
 
-			p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
+			p.AssembleShow(begin, end)
 
 		case ruleAction31:
 
-			p.AssembleProjections(begin, end)
+			p.AssembleEmitter()
 
 		case ruleAction32:
 
-			p.AssembleAlias()
+			p.AssembleEmitterOptions(begin, end)
 
 		case ruleAction33:
 
-			// This is *always* executed, even if there is no
-			// FROM clause present in the statement.
-			p.AssembleWindowedFrom(begin, end)
+			p.AssembleEmitterLimit()
 
 		case ruleAction34:
 
-			p.AssembleInterval()
+			p.AssembleEmitterSampling(CountBasedSampling, 1)
 
 		case ruleAction35:
 
-			p.AssembleInterval()
+			p.AssembleEmitterSampling(RandomizedSampling, 1)
 
 		case ruleAction36:
 
-			// This is *always* executed, even if there is no
-			// WHERE clause present in the statement.
-			p.AssembleFilter(begin, end)
+			p.AssembleEmitterSampling(TimeBasedSampling, 1)
 
 		case ruleAction37:
 
-			// This is *always* executed, even if there is no
-			// GROUP BY clause present in the statement.
-			p.AssembleGrouping(begin, end)
+			p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
 
 		case ruleAction38:
 
-			// This is *always* executed, even if there is no
-			// HAVING clause present in the statement.
-			p.AssembleHaving(begin, end)
+			p.AssembleEmitterChange(begin, end)
 
 		case ruleAction39:
 
-			p.EnsureAliasedStreamWindow()
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, Identifier(substr))
 
 		case ruleAction40:
 
-			p.AssembleAliasedStreamWindow()
+			p.AssembleProjections(begin, end)
 
 		case ruleAction41:
 
-			p.AssembleStreamWindow()
+			p.AssembleAlias()
 
 		case ruleAction42:
 
-			p.AssembleUDSFFuncApp()
+			// This is *always* executed, even if there is no
+			// FROM clause present in the statement.
+			p.AssembleWindowedFrom(begin, end)
 
 		case ruleAction43:
 
-			p.EnsureCapacitySpec(begin, end)
+			p.AssembleInterval()
 
 		case ruleAction44:
 
-			p.EnsureSheddingSpec(begin, end)
+			p.AssembleInterval()
 
 		case ruleAction45:
 
-			p.AssembleSourceSinkSpecs(begin, end)
+			// This is *always* executed, even if there is no
+			// WHERE clause present in the statement.
+			p.AssembleFilter(begin, end)
 
 		case ruleAction46:
 
-			p.AssembleSourceSinkSpecs(begin, end)
+			// This is *always* executed, even if there is no
+			// GROUP BY clause present in the statement.
+			p.AssembleGrouping(begin, end)
 
 		case ruleAction47:
 
-			p.AssembleSourceSinkSpecs(begin, end)
+			// This is *always* executed, even if there is no
+			// HAVING clause present in the statement.
+			p.AssembleHaving(begin, end)
 
 		case ruleAction48:
 
-			p.EnsureIdentifier(begin, end)
+			// This is *always* executed, even if there is no
+			// SAMPLE clause present in the statement.
+			p.AssembleInputSampling(begin, end)
 
 		case ruleAction49:
 
-			p.AssembleSourceSinkParam()
+			p.EnsureAliasedStreamWindow()
 
 		case ruleAction50:
 
-			p.AssembleExpressions(begin, end)
-			p.AssembleArray()
+			p.AssembleAliasedStreamWindow()
 
 		case ruleAction51:
 
-			p.AssembleMap(begin, end)
+			p.AssembleStreamWindow()
 
 		case ruleAction52:
 
-			p.AssembleKeyValuePair()
+			p.AssembleUDSFFuncApp()
 
 		case ruleAction53:
 
-			p.EnsureKeywordPresent(begin, end)
+			p.EnsureCapacitySpec(begin, end)
 
 		case ruleAction54:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.EnsureSheddingSpec(begin, end)
 
 		case ruleAction55:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.EnsurePipeSpec(begin, end)
 
 		case ruleAction56:
 
-			p.AssembleUnaryPrefixOperation(begin, end)
+			p.EnsurePartitionSpec(begin, end)
 
 		case ruleAction57:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssemblePartitionSpec()
 
 		case ruleAction58:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssembleSourceSinkSpecs(begin, end)
 
 		case ruleAction59:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssembleSourceSinkSpecs(begin, end)
 
 		case ruleAction60:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.AssembleSourceSinkSpecs(begin, end)
 
 		case ruleAction61:
 
-			p.AssembleBinaryOperation(begin, end)
+			p.EnsureIdentifier(begin, end)
 
 		case ruleAction62:
 
-			p.AssembleUnaryPrefixOperation(begin, end)
+			p.AssembleSourceSinkParam()
 
 		case ruleAction63:
 
-			p.AssembleTypeCast(begin, end)
+			p.AssembleExpressions(begin, end)
+			p.AssembleArray()
 
 		case ruleAction64:
 
-			p.AssembleTypeCast(begin, end)
+			p.AssembleMap(begin, end)
 
 		case ruleAction65:
 
-			p.AssembleFuncApp()
+			p.AssembleKeyValuePair()
 
 		case ruleAction66:
 
-			p.AssembleExpressions(begin, end)
-			p.AssembleFuncApp()
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction67:
 
-			p.AssembleExpressions(begin, end)
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction68:
 
-			p.AssembleExpressions(begin, end)
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction69:
 
-			p.AssembleSortedExpression()
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction70:
 
-			p.EnsureKeywordPresent(begin, end)
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction71:
 
-			p.AssembleExpressions(begin, end)
-			p.AssembleArray()
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction72:
 
-			p.AssembleMap(begin, end)
+			p.AssembleUnaryPrefixOperation(begin, end)
 
 		case ruleAction73:
 
-			p.AssembleKeyValuePair()
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction74:
 
-			p.AssembleConditionCase(begin, end)
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction75:
 
-			p.AssembleExpressionCase(begin, end)
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction76:
 
-			p.AssembleWhenThenPair()
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction77:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewStream(substr))
+			p.AssembleBinaryOperation(begin, end)
 
 		case ruleAction78:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
+			p.AssembleUnaryPrefixOperation(begin, end)
 
 		case ruleAction79:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewRowValue(substr))
+			p.AssembleTypeCast(begin, end)
 
 		case ruleAction80:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewNumericLiteral(substr))
+			p.AssembleTypeCast(begin, end)
 
 		case ruleAction81:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewNumericLiteral(substr))
+			p.AssembleWindowFuncApp(begin, end)
 
 		case ruleAction82:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewFloatLiteral(substr))
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction83:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, FuncName(substr))
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction84:
 
-			p.PushComponent(begin, end, NewNullLiteral())
+			p.AssembleFuncApp()
 
 		case ruleAction85:
 
-			p.PushComponent(begin, end, NewMissing())
+			p.AssembleExpressions(begin, end)
+			p.AssembleFuncApp()
 
 		case ruleAction86:
 
-			p.PushComponent(begin, end, NewBoolLiteral(true))
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction87:
 
-			p.PushComponent(begin, end, NewBoolLiteral(false))
+			p.AssembleExpressions(begin, end)
 
 		case ruleAction88:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewWildcard(substr))
+			p.AssembleSortedExpression()
 
 		case ruleAction89:
 
-			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, NewStringLiteral(substr))
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction90:
 
-			p.PushComponent(begin, end, Istream)
+			p.EnsureKeywordPresent(begin, end)
 
 		case ruleAction91:
 
-			p.PushComponent(begin, end, Dstream)
+			p.AssembleExpressions(begin, end)
+			p.AssembleArray()
 
 		case ruleAction92:
 
-			p.PushComponent(begin, end, Rstream)
+			p.AssembleMap(begin, end)
 
 		case ruleAction93:
 
-			p.PushComponent(begin, end, Tuples)
+			p.AssembleKeyValuePair()
 
 		case ruleAction94:
 
-			p.PushComponent(begin, end, Seconds)
+			p.AssembleConditionCase(begin, end)
 
 		case ruleAction95:
 
-			p.PushComponent(begin, end, Milliseconds)
+			p.AssembleExpressionCase(begin, end)
 
 		case ruleAction96:
 
-			p.PushComponent(begin, end, Wait)
+			p.AssembleWhenThenPair()
 
 		case ruleAction97:
 
-			p.PushComponent(begin, end, DropOldest)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewStream(substr))
 
 		case ruleAction98:
 
-			p.PushComponent(begin, end, DropNewest)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
 
 		case ruleAction99:
 
 			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, StreamIdentifier(substr))
+			p.PushComponent(begin, end, NewRowMeta(substr, ProcTimestampMeta))
 
 		case ruleAction100:
 
 			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, SourceSinkType(substr))
+			p.PushComponent(begin, end, NewRowMeta(substr, InputNameMeta))
 
 		case ruleAction101:
 
 			substr := string([]rune(buffer)[begin:end])
-			p.PushComponent(begin, end, SourceSinkParamKey(substr))
+			p.PushComponent(begin, end, NewRowMeta(substr, TraceMeta))
 
 		case ruleAction102:
 
-			p.PushComponent(begin, end, Yes)
+			substr := string([]rune(buffer)[begin:end])
+			p.AssembleRowMetadata(begin, end, substr)
 
 		case ruleAction103:
 
-			p.PushComponent(begin, end, No)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewRowValue(substr))
 
 		case ruleAction104:
 
-			p.PushComponent(begin, end, Yes)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewNumericLiteral(substr))
 
 		case ruleAction105:
 
-			p.PushComponent(begin, end, No)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewNumericLiteral(substr))
 
 		case ruleAction106:
 
-			p.PushComponent(begin, end, Bool)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewFloatLiteral(substr))
 
 		case ruleAction107:
 
-			p.PushComponent(begin, end, Int)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, FuncName(substr))
 
 		case ruleAction108:
 
-			p.PushComponent(begin, end, Float)
+			p.PushComponent(begin, end, NewNullLiteral())
 
 		case ruleAction109:
 
-			p.PushComponent(begin, end, String)
+			p.PushComponent(begin, end, NewMissing())
 
 		case ruleAction110:
 
-			p.PushComponent(begin, end, Blob)
+			p.PushComponent(begin, end, NewBoolLiteral(true))
 
 		case ruleAction111:
 
-			p.PushComponent(begin, end, Timestamp)
+			p.PushComponent(begin, end, NewBoolLiteral(false))
 
 		case ruleAction112:
 
-			p.PushComponent(begin, end, Array)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewWildcard(substr))
 
 		case ruleAction113:
 
-			p.PushComponent(begin, end, Map)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, NewStringLiteral(substr))
 
 		case ruleAction114:
 
-			p.PushComponent(begin, end, Or)
+			substr := string([]rune(buffer)[begin:end])
+			p.AssemblePlaceholder(begin, end, substr)
 
 		case ruleAction115:
 
-			p.PushComponent(begin, end, And)
+			p.PushComponent(begin, end, Istream)
 
 		case ruleAction116:
 
-			p.PushComponent(begin, end, Not)
+			p.PushComponent(begin, end, Dstream)
 
 		case ruleAction117:
 
-			p.PushComponent(begin, end, Equal)
+			p.PushComponent(begin, end, Rstream)
 
 		case ruleAction118:
 
-			p.PushComponent(begin, end, Less)
+			p.PushComponent(begin, end, Tuples)
 
 		case ruleAction119:
 
-			p.PushComponent(begin, end, LessOrEqual)
+			p.PushComponent(begin, end, Seconds)
 
 		case ruleAction120:
 
-			p.PushComponent(begin, end, Greater)
+			p.PushComponent(begin, end, Milliseconds)
 
 		case ruleAction121:
 
-			p.PushComponent(begin, end, GreaterOrEqual)
+			p.PushComponent(begin, end, Wait)
 
 		case ruleAction122:
 
-			p.PushComponent(begin, end, NotEqual)
+			p.PushComponent(begin, end, DropOldest)
 
 		case ruleAction123:
 
-			p.PushComponent(begin, end, Concat)
+			p.PushComponent(begin, end, DropNewest)
 
 		case ruleAction124:
 
-			p.PushComponent(begin, end, Is)
+			p.PushComponent(begin, end, ChannelPipe)
 
 		case ruleAction125:
 
-			p.PushComponent(begin, end, IsNot)
+			p.PushComponent(begin, end, RingPipe)
 
 		case ruleAction126:
 
-			p.PushComponent(begin, end, Plus)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, StreamIdentifier(substr))
 
 		case ruleAction127:
 
-			p.PushComponent(begin, end, Minus)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, SourceSinkType(substr))
 
 		case ruleAction128:
 
-			p.PushComponent(begin, end, Multiply)
+			substr := string([]rune(buffer)[begin:end])
+			p.PushComponent(begin, end, SourceSinkParamKey(substr))
 
 		case ruleAction129:
 
-			p.PushComponent(begin, end, Divide)
+			p.PushComponent(begin, end, Yes)
 
 		case ruleAction130:
 
-			p.PushComponent(begin, end, Modulo)
+			p.PushComponent(begin, end, No)
 
 		case ruleAction131:
 
-			p.PushComponent(begin, end, UnaryMinus)
+			p.PushComponent(begin, end, Yes)
 
 		case ruleAction132:
 
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction133:
+
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction134:
+
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction135:
+
+			p.PushComponent(begin, end, No)
+
+		case ruleAction136:
+
+			p.PushComponent(begin, end, NodeTarget)
+
+		case ruleAction137:
+
+			p.PushComponent(begin, end, TopologyTarget)
+
+		case ruleAction138:
+
+			p.PushComponent(begin, end, SourcesTarget)
+
+		case ruleAction139:
+
+			p.PushComponent(begin, end, StreamsTarget)
+
+		case ruleAction140:
+
+			p.PushComponent(begin, end, SinksTarget)
+
+		case ruleAction141:
+
+			p.PushComponent(begin, end, StatesTarget)
+
+		case ruleAction142:
+
+			p.PushComponent(begin, end, UDFsTarget)
+
+		case ruleAction143:
+
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction144:
+
+			p.PushComponent(begin, end, No)
+
+		case ruleAction145:
+
+			p.PushComponent(begin, end, Yes)
+
+		case ruleAction146:
+
+			p.PushComponent(begin, end, No)
+
+		case ruleAction147:
+
+			p.PushComponent(begin, end, Bool)
+
+		case ruleAction148:
+
+			p.PushComponent(begin, end, Int)
+
+		case ruleAction149:
+
+			p.PushComponent(begin, end, Float)
+
+		case ruleAction150:
+
+			p.PushComponent(begin, end, String)
+
+		case ruleAction151:
+
+			p.PushComponent(begin, end, Blob)
+
+		case ruleAction152:
+
+			p.PushComponent(begin, end, Timestamp)
+
+		case ruleAction153:
+
+			p.PushComponent(begin, end, Array)
+
+		case ruleAction154:
+
+			p.PushComponent(begin, end, Map)
+
+		case ruleAction155:
+
+			p.PushComponent(begin, end, Vector)
+
+		case ruleAction156:
+
+			p.PushComponent(begin, end, Or)
+
+		case ruleAction157:
+
+			p.PushComponent(begin, end, And)
+
+		case ruleAction158:
+
+			p.PushComponent(begin, end, Not)
+
+		case ruleAction159:
+
+			p.PushComponent(begin, end, Equal)
+
+		case ruleAction160:
+
+			p.PushComponent(begin, end, Less)
+
+		case ruleAction161:
+
+			p.PushComponent(begin, end, LessOrEqual)
+
+		case ruleAction162:
+
+			p.PushComponent(begin, end, Greater)
+
+		case ruleAction163:
+
+			p.PushComponent(begin, end, GreaterOrEqual)
+
+		case ruleAction164:
+
+			p.PushComponent(begin, end, NotEqual)
+
+		case ruleAction165:
+
+			p.PushComponent(begin, end, Concat)
+
+		case ruleAction166:
+
+			p.PushComponent(begin, end, Is)
+
+		case ruleAction167:
+
+			p.PushComponent(begin, end, IsNot)
+
+		case ruleAction168:
+
+			p.PushComponent(begin, end, IsDistinctFrom)
+
+		case ruleAction169:
+
+			p.PushComponent(begin, end, IsNotDistinctFrom)
+
+		case ruleAction170:
+
+			p.PushComponent(begin, end, Plus)
+
+		case ruleAction171:
+
+			p.PushComponent(begin, end, Minus)
+
+		case ruleAction172:
+
+			p.PushComponent(begin, end, Multiply)
+
+		case ruleAction173:
+
+			p.PushComponent(begin, end, Divide)
+
+		case ruleAction174:
+
+			p.PushComponent(begin, end, Modulo)
+
+		case ruleAction175:
+
+			p.PushComponent(begin, end, UnaryMinus)
+
+		case ruleAction176:
+
 			substr := string([]rune(buffer)[begin:end])
 			p.PushComponent(begin, end, Identifier(substr))
 
-		case ruleAction133:
+		case ruleAction177:
 
 			substr := string([]rune(buffer)[begin:end])
 			p.PushComponent(begin, end, Identifier(substr))
@@ -1813,7 +2191,7 @@ func (p *bqlPegBackend) Init() {
 			position, tokenIndex, depth = position10, tokenIndex10, depth10
 			return false
 		},
-		/* 3 Statement <- <(SelectUnionStmt / SelectStmt / SourceStmt / SinkStmt / StateStmt / StreamStmt / EvalStmt)> */
+		/* 3 Statement <- <(SelectUnionStmt / SelectStmt / SourceStmt / SinkStmt / StateStmt / StreamStmt / EvalStmt / ShowStmt / PluginStmt / BoxStmt / SettingStmt)> */
 		func() bool {
 			position13, tokenIndex13, depth13 := position, tokenIndex, depth
 			{
@@ -1858,6 +2236,30 @@ func (p *bqlPegBackend) Init() {
 				l21:
 					position, tokenIndex, depth = position15, tokenIndex15, depth15
 					if !_rules[ruleEvalStmt]() {
+						goto l22
+					}
+					goto l15
+				l22:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					if !_rules[ruleShowStmt]() {
+						goto l23
+					}
+					goto l15
+				l23:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					if !_rules[rulePluginStmt]() {
+						goto l24
+					}
+					goto l15
+				l24:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					if !_rules[ruleBoxStmt]() {
+						goto l25
+					}
+					goto l15
+				l25:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					if !_rules[ruleSettingStmt]() {
 						goto l13
 					}
 				}
@@ -1872,16960 +2274,24288 @@ func (p *bqlPegBackend) Init() {
 		},
 		/* 4 SourceStmt <- <(CreateSourceStmt / UpdateSourceStmt / DropSourceStmt / PauseSourceStmt / ResumeSourceStmt / RewindSourceStmt)> */
 		func() bool {
-			position22, tokenIndex22, depth22 := position, tokenIndex, depth
+			position26, tokenIndex26, depth26 := position, tokenIndex, depth
 			{
-				position23 := position
+				position27 := position
 				depth++
 				{
-					position24, tokenIndex24, depth24 := position, tokenIndex, depth
+					position28, tokenIndex28, depth28 := position, tokenIndex, depth
 					if !_rules[ruleCreateSourceStmt]() {
-						goto l25
+						goto l29
 					}
-					goto l24
-				l25:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l28
+				l29:
+					position, tokenIndex, depth = position28, tokenIndex28, depth28
 					if !_rules[ruleUpdateSourceStmt]() {
-						goto l26
+						goto l30
 					}
-					goto l24
-				l26:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l28
+				l30:
+					position, tokenIndex, depth = position28, tokenIndex28, depth28
 					if !_rules[ruleDropSourceStmt]() {
-						goto l27
+						goto l31
 					}
-					goto l24
-				l27:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l28
+				l31:
+					position, tokenIndex, depth = position28, tokenIndex28, depth28
 					if !_rules[rulePauseSourceStmt]() {
-						goto l28
+						goto l32
 					}
-					goto l24
-				l28:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l28
+				l32:
+					position, tokenIndex, depth = position28, tokenIndex28, depth28
 					if !_rules[ruleResumeSourceStmt]() {
-						goto l29
+						goto l33
 					}
-					goto l24
-				l29:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l28
+				l33:
+					position, tokenIndex, depth = position28, tokenIndex28, depth28
 					if !_rules[ruleRewindSourceStmt]() {
-						goto l22
+						goto l26
 					}
 				}
-			l24:
+			l28:
 				depth--
-				add(ruleSourceStmt, position23)
+				add(ruleSourceStmt, position27)
 			}
 			return true
-		l22:
-			position, tokenIndex, depth = position22, tokenIndex22, depth22
+		l26:
+			position, tokenIndex, depth = position26, tokenIndex26, depth26
 			return false
 		},
 		/* 5 SinkStmt <- <(CreateSinkStmt / UpdateSinkStmt / DropSinkStmt)> */
 		func() bool {
-			position30, tokenIndex30, depth30 := position, tokenIndex, depth
+			position34, tokenIndex34, depth34 := position, tokenIndex, depth
 			{
-				position31 := position
+				position35 := position
 				depth++
 				{
-					position32, tokenIndex32, depth32 := position, tokenIndex, depth
+					position36, tokenIndex36, depth36 := position, tokenIndex, depth
 					if !_rules[ruleCreateSinkStmt]() {
-						goto l33
+						goto l37
 					}
-					goto l32
-				l33:
-					position, tokenIndex, depth = position32, tokenIndex32, depth32
+					goto l36
+				l37:
+					position, tokenIndex, depth = position36, tokenIndex36, depth36
 					if !_rules[ruleUpdateSinkStmt]() {
-						goto l34
+						goto l38
 					}
-					goto l32
-				l34:
-					position, tokenIndex, depth = position32, tokenIndex32, depth32
+					goto l36
+				l38:
+					position, tokenIndex, depth = position36, tokenIndex36, depth36
 					if !_rules[ruleDropSinkStmt]() {
-						goto l30
+						goto l34
 					}
 				}
-			l32:
+			l36:
 				depth--
-				add(ruleSinkStmt, position31)
+				add(ruleSinkStmt, position35)
 			}
 			return true
-		l30:
-			position, tokenIndex, depth = position30, tokenIndex30, depth30
+		l34:
+			position, tokenIndex, depth = position34, tokenIndex34, depth34
 			return false
 		},
 		/* 6 StateStmt <- <(CreateStateStmt / UpdateStateStmt / DropStateStmt / LoadStateOrCreateStmt / LoadStateStmt / SaveStateStmt)> */
 		func() bool {
-			position35, tokenIndex35, depth35 := position, tokenIndex, depth
+			position39, tokenIndex39, depth39 := position, tokenIndex, depth
 			{
-				position36 := position
+				position40 := position
 				depth++
 				{
-					position37, tokenIndex37, depth37 := position, tokenIndex, depth
+					position41, tokenIndex41, depth41 := position, tokenIndex, depth
 					if !_rules[ruleCreateStateStmt]() {
-						goto l38
+						goto l42
 					}
-					goto l37
-				l38:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l41
+				l42:
+					position, tokenIndex, depth = position41, tokenIndex41, depth41
 					if !_rules[ruleUpdateStateStmt]() {
-						goto l39
+						goto l43
 					}
-					goto l37
-				l39:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l41
+				l43:
+					position, tokenIndex, depth = position41, tokenIndex41, depth41
 					if !_rules[ruleDropStateStmt]() {
-						goto l40
+						goto l44
 					}
-					goto l37
-				l40:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l41
+				l44:
+					position, tokenIndex, depth = position41, tokenIndex41, depth41
 					if !_rules[ruleLoadStateOrCreateStmt]() {
-						goto l41
+						goto l45
 					}
-					goto l37
-				l41:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l41
+				l45:
+					position, tokenIndex, depth = position41, tokenIndex41, depth41
 					if !_rules[ruleLoadStateStmt]() {
-						goto l42
+						goto l46
 					}
-					goto l37
-				l42:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l41
+				l46:
+					position, tokenIndex, depth = position41, tokenIndex41, depth41
 					if !_rules[ruleSaveStateStmt]() {
-						goto l35
+						goto l39
+					}
+				}
+			l41:
+				depth--
+				add(ruleStateStmt, position40)
+			}
+			return true
+		l39:
+			position, tokenIndex, depth = position39, tokenIndex39, depth39
+			return false
+		},
+		/* 7 PluginStmt <- <LoadPluginStmt> */
+		func() bool {
+			position47, tokenIndex47, depth47 := position, tokenIndex, depth
+			{
+				position48 := position
+				depth++
+				if !_rules[ruleLoadPluginStmt]() {
+					goto l47
+				}
+				depth--
+				add(rulePluginStmt, position48)
+			}
+			return true
+		l47:
+			position, tokenIndex, depth = position47, tokenIndex47, depth47
+			return false
+		},
+		/* 8 BoxStmt <- <CreateBoxStmt> */
+		func() bool {
+			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			{
+				position50 := position
+				depth++
+				if !_rules[ruleCreateBoxStmt]() {
+					goto l49
+				}
+				depth--
+				add(ruleBoxStmt, position50)
+			}
+			return true
+		l49:
+			position, tokenIndex, depth = position49, tokenIndex49, depth49
+			return false
+		},
+		/* 9 SettingStmt <- <(SetLogLevelStmt / SetTraceStmt / SetRecoveryPolicyStmt)> */
+		func() bool {
+			position51, tokenIndex51, depth51 := position, tokenIndex, depth
+			{
+				position52 := position
+				depth++
+				{
+					position53, tokenIndex53, depth53 := position, tokenIndex, depth
+					if !_rules[ruleSetLogLevelStmt]() {
+						goto l54
+					}
+					goto l53
+				l54:
+					position, tokenIndex, depth = position53, tokenIndex53, depth53
+					if !_rules[ruleSetTraceStmt]() {
+						goto l55
+					}
+					goto l53
+				l55:
+					position, tokenIndex, depth = position53, tokenIndex53, depth53
+					if !_rules[ruleSetRecoveryPolicyStmt]() {
+						goto l51
 					}
 				}
-			l37:
+			l53:
 				depth--
-				add(ruleStateStmt, position36)
+				add(ruleSettingStmt, position52)
 			}
 			return true
-		l35:
-			position, tokenIndex, depth = position35, tokenIndex35, depth35
+		l51:
+			position, tokenIndex, depth = position51, tokenIndex51, depth51
 			return false
 		},
-		/* 7 StreamStmt <- <(CreateStreamAsSelectUnionStmt / CreateStreamAsSelectStmt / DropStreamStmt / InsertIntoFromStmt)> */
+		/* 10 StreamStmt <- <(CreateStreamAsSelectUnionStmt / CreateStreamAsSelectStmt / DropStreamStmt / InsertIntoFromStmt)> */
 		func() bool {
-			position43, tokenIndex43, depth43 := position, tokenIndex, depth
+			position56, tokenIndex56, depth56 := position, tokenIndex, depth
 			{
-				position44 := position
+				position57 := position
 				depth++
 				{
-					position45, tokenIndex45, depth45 := position, tokenIndex, depth
+					position58, tokenIndex58, depth58 := position, tokenIndex, depth
 					if !_rules[ruleCreateStreamAsSelectUnionStmt]() {
-						goto l46
+						goto l59
 					}
-					goto l45
-				l46:
-					position, tokenIndex, depth = position45, tokenIndex45, depth45
+					goto l58
+				l59:
+					position, tokenIndex, depth = position58, tokenIndex58, depth58
 					if !_rules[ruleCreateStreamAsSelectStmt]() {
-						goto l47
+						goto l60
 					}
-					goto l45
-				l47:
-					position, tokenIndex, depth = position45, tokenIndex45, depth45
+					goto l58
+				l60:
+					position, tokenIndex, depth = position58, tokenIndex58, depth58
 					if !_rules[ruleDropStreamStmt]() {
-						goto l48
+						goto l61
 					}
-					goto l45
-				l48:
-					position, tokenIndex, depth = position45, tokenIndex45, depth45
+					goto l58
+				l61:
+					position, tokenIndex, depth = position58, tokenIndex58, depth58
 					if !_rules[ruleInsertIntoFromStmt]() {
-						goto l43
+						goto l56
 					}
 				}
-			l45:
+			l58:
 				depth--
-				add(ruleStreamStmt, position44)
+				add(ruleStreamStmt, position57)
 			}
 			return true
-		l43:
-			position, tokenIndex, depth = position43, tokenIndex43, depth43
+		l56:
+			position, tokenIndex, depth = position56, tokenIndex56, depth56
 			return false
 		},
-		/* 8 SelectStmt <- <(('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') Emitter Projections WindowedFrom Filter Grouping Having Action2)> */
+		/* 11 SelectStmt <- <(('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') Emitter Projections WindowedFrom Filter Grouping Having InputSampling Action2)> */
 		func() bool {
-			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			position62, tokenIndex62, depth62 := position, tokenIndex, depth
 			{
-				position50 := position
+				position63 := position
 				depth++
 				{
-					position51, tokenIndex51, depth51 := position, tokenIndex, depth
+					position64, tokenIndex64, depth64 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l52
+						goto l65
 					}
 					position++
-					goto l51
-				l52:
-					position, tokenIndex, depth = position51, tokenIndex51, depth51
+					goto l64
+				l65:
+					position, tokenIndex, depth = position64, tokenIndex64, depth64
 					if buffer[position] != rune('S') {
-						goto l49
+						goto l62
 					}
 					position++
 				}
-			l51:
+			l64:
 				{
-					position53, tokenIndex53, depth53 := position, tokenIndex, depth
+					position66, tokenIndex66, depth66 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l54
+						goto l67
 					}
 					position++
-					goto l53
-				l54:
-					position, tokenIndex, depth = position53, tokenIndex53, depth53
+					goto l66
+				l67:
+					position, tokenIndex, depth = position66, tokenIndex66, depth66
 					if buffer[position] != rune('E') {
-						goto l49
+						goto l62
 					}
 					position++
 				}
-			l53:
+			l66:
 				{
-					position55, tokenIndex55, depth55 := position, tokenIndex, depth
+					position68, tokenIndex68, depth68 := position, tokenIndex, depth
 					if buffer[position] != rune('l') {
-						goto l56
+						goto l69
 					}
 					position++
-					goto l55
-				l56:
-					position, tokenIndex, depth = position55, tokenIndex55, depth55
+					goto l68
+				l69:
+					position, tokenIndex, depth = position68, tokenIndex68, depth68
 					if buffer[position] != rune('L') {
-						goto l49
+						goto l62
 					}
 					position++
 				}
-			l55:
+			l68:
 				{
-					position57, tokenIndex57, depth57 := position, tokenIndex, depth
+					position70, tokenIndex70, depth70 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l58
+						goto l71
 					}
 					position++
-					goto l57
-				l58:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
+					goto l70
+				l71:
+					position, tokenIndex, depth = position70, tokenIndex70, depth70
 					if buffer[position] != rune('E') {
-						goto l49
+						goto l62
 					}
 					position++
 				}
-			l57:
+			l70:
 				{
-					position59, tokenIndex59, depth59 := position, tokenIndex, depth
+					position72, tokenIndex72, depth72 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l60
+						goto l73
 					}
 					position++
-					goto l59
-				l60:
-					position, tokenIndex, depth = position59, tokenIndex59, depth59
+					goto l72
+				l73:
+					position, tokenIndex, depth = position72, tokenIndex72, depth72
 					if buffer[position] != rune('C') {
-						goto l49
+						goto l62
 					}
 					position++
 				}
-			l59:
+			l72:
 				{
-					position61, tokenIndex61, depth61 := position, tokenIndex, depth
+					position74, tokenIndex74, depth74 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l62
+						goto l75
 					}
 					position++
-					goto l61
-				l62:
-					position, tokenIndex, depth = position61, tokenIndex61, depth61
+					goto l74
+				l75:
+					position, tokenIndex, depth = position74, tokenIndex74, depth74
 					if buffer[position] != rune('T') {
-						goto l49
+						goto l62
 					}
 					position++
 				}
-			l61:
+			l74:
 				if !_rules[ruleEmitter]() {
-					goto l49
+					goto l62
 				}
 				if !_rules[ruleProjections]() {
-					goto l49
+					goto l62
 				}
 				if !_rules[ruleWindowedFrom]() {
-					goto l49
+					goto l62
 				}
 				if !_rules[ruleFilter]() {
-					goto l49
+					goto l62
 				}
 				if !_rules[ruleGrouping]() {
-					goto l49
+					goto l62
 				}
 				if !_rules[ruleHaving]() {
-					goto l49
+					goto l62
+				}
+				if !_rules[ruleInputSampling]() {
+					goto l62
 				}
 				if !_rules[ruleAction2]() {
-					goto l49
+					goto l62
 				}
 				depth--
-				add(ruleSelectStmt, position50)
+				add(ruleSelectStmt, position63)
 			}
 			return true
-		l49:
-			position, tokenIndex, depth = position49, tokenIndex49, depth49
+		l62:
+			position, tokenIndex, depth = position62, tokenIndex62, depth62
 			return false
 		},
-		/* 9 SelectUnionStmt <- <(<(SelectStmt (sp (('u' / 'U') ('n' / 'N') ('i' / 'I') ('o' / 'O') ('n' / 'N')) sp (('a' / 'A') ('l' / 'L') ('l' / 'L')) sp SelectStmt)+)> Action3)> */
+		/* 12 SelectUnionStmt <- <(<(SelectStmt (sp (('u' / 'U') ('n' / 'N') ('i' / 'I') ('o' / 'O') ('n' / 'N')) sp (('a' / 'A') ('l' / 'L') ('l' / 'L')) sp SelectStmt)+)> Action3)> */
 		func() bool {
-			position63, tokenIndex63, depth63 := position, tokenIndex, depth
+			position76, tokenIndex76, depth76 := position, tokenIndex, depth
 			{
-				position64 := position
+				position77 := position
 				depth++
 				{
-					position65 := position
+					position78 := position
 					depth++
 					if !_rules[ruleSelectStmt]() {
-						goto l63
+						goto l76
 					}
 					if !_rules[rulesp]() {
-						goto l63
+						goto l76
 					}
 					{
-						position68, tokenIndex68, depth68 := position, tokenIndex, depth
+						position81, tokenIndex81, depth81 := position, tokenIndex, depth
 						if buffer[position] != rune('u') {
-							goto l69
+							goto l82
 						}
 						position++
-						goto l68
-					l69:
-						position, tokenIndex, depth = position68, tokenIndex68, depth68
+						goto l81
+					l82:
+						position, tokenIndex, depth = position81, tokenIndex81, depth81
 						if buffer[position] != rune('U') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l68:
+				l81:
 					{
-						position70, tokenIndex70, depth70 := position, tokenIndex, depth
+						position83, tokenIndex83, depth83 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l71
+							goto l84
 						}
 						position++
-						goto l70
-					l71:
-						position, tokenIndex, depth = position70, tokenIndex70, depth70
+						goto l83
+					l84:
+						position, tokenIndex, depth = position83, tokenIndex83, depth83
 						if buffer[position] != rune('N') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l70:
+				l83:
 					{
-						position72, tokenIndex72, depth72 := position, tokenIndex, depth
+						position85, tokenIndex85, depth85 := position, tokenIndex, depth
 						if buffer[position] != rune('i') {
-							goto l73
+							goto l86
 						}
 						position++
-						goto l72
-					l73:
-						position, tokenIndex, depth = position72, tokenIndex72, depth72
+						goto l85
+					l86:
+						position, tokenIndex, depth = position85, tokenIndex85, depth85
 						if buffer[position] != rune('I') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l72:
+				l85:
 					{
-						position74, tokenIndex74, depth74 := position, tokenIndex, depth
+						position87, tokenIndex87, depth87 := position, tokenIndex, depth
 						if buffer[position] != rune('o') {
-							goto l75
+							goto l88
 						}
 						position++
-						goto l74
-					l75:
-						position, tokenIndex, depth = position74, tokenIndex74, depth74
+						goto l87
+					l88:
+						position, tokenIndex, depth = position87, tokenIndex87, depth87
 						if buffer[position] != rune('O') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l74:
+				l87:
 					{
-						position76, tokenIndex76, depth76 := position, tokenIndex, depth
+						position89, tokenIndex89, depth89 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l77
+							goto l90
 						}
 						position++
-						goto l76
-					l77:
-						position, tokenIndex, depth = position76, tokenIndex76, depth76
+						goto l89
+					l90:
+						position, tokenIndex, depth = position89, tokenIndex89, depth89
 						if buffer[position] != rune('N') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l76:
+				l89:
 					if !_rules[rulesp]() {
-						goto l63
+						goto l76
 					}
 					{
-						position78, tokenIndex78, depth78 := position, tokenIndex, depth
+						position91, tokenIndex91, depth91 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l79
+							goto l92
 						}
 						position++
-						goto l78
-					l79:
-						position, tokenIndex, depth = position78, tokenIndex78, depth78
+						goto l91
+					l92:
+						position, tokenIndex, depth = position91, tokenIndex91, depth91
 						if buffer[position] != rune('A') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l78:
+				l91:
 					{
-						position80, tokenIndex80, depth80 := position, tokenIndex, depth
+						position93, tokenIndex93, depth93 := position, tokenIndex, depth
 						if buffer[position] != rune('l') {
-							goto l81
+							goto l94
 						}
 						position++
-						goto l80
-					l81:
-						position, tokenIndex, depth = position80, tokenIndex80, depth80
+						goto l93
+					l94:
+						position, tokenIndex, depth = position93, tokenIndex93, depth93
 						if buffer[position] != rune('L') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l80:
+				l93:
 					{
-						position82, tokenIndex82, depth82 := position, tokenIndex, depth
+						position95, tokenIndex95, depth95 := position, tokenIndex, depth
 						if buffer[position] != rune('l') {
-							goto l83
+							goto l96
 						}
 						position++
-						goto l82
-					l83:
-						position, tokenIndex, depth = position82, tokenIndex82, depth82
+						goto l95
+					l96:
+						position, tokenIndex, depth = position95, tokenIndex95, depth95
 						if buffer[position] != rune('L') {
-							goto l63
+							goto l76
 						}
 						position++
 					}
-				l82:
+				l95:
 					if !_rules[rulesp]() {
-						goto l63
+						goto l76
 					}
 					if !_rules[ruleSelectStmt]() {
-						goto l63
+						goto l76
 					}
-				l66:
+				l79:
 					{
-						position67, tokenIndex67, depth67 := position, tokenIndex, depth
+						position80, tokenIndex80, depth80 := position, tokenIndex, depth
 						if !_rules[rulesp]() {
-							goto l67
+							goto l80
 						}
 						{
-							position84, tokenIndex84, depth84 := position, tokenIndex, depth
+							position97, tokenIndex97, depth97 := position, tokenIndex, depth
 							if buffer[position] != rune('u') {
-								goto l85
+								goto l98
 							}
 							position++
-							goto l84
-						l85:
-							position, tokenIndex, depth = position84, tokenIndex84, depth84
+							goto l97
+						l98:
+							position, tokenIndex, depth = position97, tokenIndex97, depth97
 							if buffer[position] != rune('U') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l84:
+					l97:
 						{
-							position86, tokenIndex86, depth86 := position, tokenIndex, depth
+							position99, tokenIndex99, depth99 := position, tokenIndex, depth
 							if buffer[position] != rune('n') {
-								goto l87
+								goto l100
 							}
 							position++
-							goto l86
-						l87:
-							position, tokenIndex, depth = position86, tokenIndex86, depth86
+							goto l99
+						l100:
+							position, tokenIndex, depth = position99, tokenIndex99, depth99
 							if buffer[position] != rune('N') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l86:
+					l99:
 						{
-							position88, tokenIndex88, depth88 := position, tokenIndex, depth
+							position101, tokenIndex101, depth101 := position, tokenIndex, depth
 							if buffer[position] != rune('i') {
-								goto l89
+								goto l102
 							}
 							position++
-							goto l88
-						l89:
-							position, tokenIndex, depth = position88, tokenIndex88, depth88
+							goto l101
+						l102:
+							position, tokenIndex, depth = position101, tokenIndex101, depth101
 							if buffer[position] != rune('I') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l88:
+					l101:
 						{
-							position90, tokenIndex90, depth90 := position, tokenIndex, depth
+							position103, tokenIndex103, depth103 := position, tokenIndex, depth
 							if buffer[position] != rune('o') {
-								goto l91
+								goto l104
 							}
 							position++
-							goto l90
-						l91:
-							position, tokenIndex, depth = position90, tokenIndex90, depth90
+							goto l103
+						l104:
+							position, tokenIndex, depth = position103, tokenIndex103, depth103
 							if buffer[position] != rune('O') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l90:
+					l103:
 						{
-							position92, tokenIndex92, depth92 := position, tokenIndex, depth
+							position105, tokenIndex105, depth105 := position, tokenIndex, depth
 							if buffer[position] != rune('n') {
-								goto l93
+								goto l106
 							}
 							position++
-							goto l92
-						l93:
-							position, tokenIndex, depth = position92, tokenIndex92, depth92
+							goto l105
+						l106:
+							position, tokenIndex, depth = position105, tokenIndex105, depth105
 							if buffer[position] != rune('N') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l92:
+					l105:
 						if !_rules[rulesp]() {
-							goto l67
+							goto l80
 						}
 						{
-							position94, tokenIndex94, depth94 := position, tokenIndex, depth
+							position107, tokenIndex107, depth107 := position, tokenIndex, depth
 							if buffer[position] != rune('a') {
-								goto l95
+								goto l108
 							}
 							position++
-							goto l94
-						l95:
-							position, tokenIndex, depth = position94, tokenIndex94, depth94
+							goto l107
+						l108:
+							position, tokenIndex, depth = position107, tokenIndex107, depth107
 							if buffer[position] != rune('A') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l94:
+					l107:
 						{
-							position96, tokenIndex96, depth96 := position, tokenIndex, depth
+							position109, tokenIndex109, depth109 := position, tokenIndex, depth
 							if buffer[position] != rune('l') {
-								goto l97
+								goto l110
 							}
 							position++
-							goto l96
-						l97:
-							position, tokenIndex, depth = position96, tokenIndex96, depth96
+							goto l109
+						l110:
+							position, tokenIndex, depth = position109, tokenIndex109, depth109
 							if buffer[position] != rune('L') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l96:
+					l109:
 						{
-							position98, tokenIndex98, depth98 := position, tokenIndex, depth
+							position111, tokenIndex111, depth111 := position, tokenIndex, depth
 							if buffer[position] != rune('l') {
-								goto l99
+								goto l112
 							}
 							position++
-							goto l98
-						l99:
-							position, tokenIndex, depth = position98, tokenIndex98, depth98
+							goto l111
+						l112:
+							position, tokenIndex, depth = position111, tokenIndex111, depth111
 							if buffer[position] != rune('L') {
-								goto l67
+								goto l80
 							}
 							position++
 						}
-					l98:
+					l111:
 						if !_rules[rulesp]() {
-							goto l67
+							goto l80
 						}
 						if !_rules[ruleSelectStmt]() {
-							goto l67
+							goto l80
 						}
-						goto l66
-					l67:
-						position, tokenIndex, depth = position67, tokenIndex67, depth67
+						goto l79
+					l80:
+						position, tokenIndex, depth = position80, tokenIndex80, depth80
 					}
 					depth--
-					add(rulePegText, position65)
+					add(rulePegText, position78)
 				}
 				if !_rules[ruleAction3]() {
-					goto l63
+					goto l76
 				}
 				depth--
-				add(ruleSelectUnionStmt, position64)
+				add(ruleSelectUnionStmt, position77)
 			}
 			return true
-		l63:
-			position, tokenIndex, depth = position63, tokenIndex63, depth63
+		l76:
+			position, tokenIndex, depth = position76, tokenIndex76, depth76
 			return false
 		},
-		/* 10 CreateStreamAsSelectStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectStmt Action4)> */
+		/* 13 CreateStreamAsSelectStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') OrReplaceOpt sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectStmt EvictionTargetOpt Action4)> */
 		func() bool {
-			position100, tokenIndex100, depth100 := position, tokenIndex, depth
+			position113, tokenIndex113, depth113 := position, tokenIndex, depth
 			{
-				position101 := position
+				position114 := position
 				depth++
 				{
-					position102, tokenIndex102, depth102 := position, tokenIndex, depth
+					position115, tokenIndex115, depth115 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l103
+						goto l116
 					}
 					position++
-					goto l102
-				l103:
-					position, tokenIndex, depth = position102, tokenIndex102, depth102
+					goto l115
+				l116:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 					if buffer[position] != rune('C') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l102:
+			l115:
 				{
-					position104, tokenIndex104, depth104 := position, tokenIndex, depth
+					position117, tokenIndex117, depth117 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l105
+						goto l118
 					}
 					position++
-					goto l104
-				l105:
-					position, tokenIndex, depth = position104, tokenIndex104, depth104
+					goto l117
+				l118:
+					position, tokenIndex, depth = position117, tokenIndex117, depth117
 					if buffer[position] != rune('R') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l104:
+			l117:
 				{
-					position106, tokenIndex106, depth106 := position, tokenIndex, depth
+					position119, tokenIndex119, depth119 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l107
+						goto l120
 					}
 					position++
-					goto l106
-				l107:
-					position, tokenIndex, depth = position106, tokenIndex106, depth106
+					goto l119
+				l120:
+					position, tokenIndex, depth = position119, tokenIndex119, depth119
 					if buffer[position] != rune('E') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l106:
+			l119:
 				{
-					position108, tokenIndex108, depth108 := position, tokenIndex, depth
+					position121, tokenIndex121, depth121 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l109
+						goto l122
 					}
 					position++
-					goto l108
-				l109:
-					position, tokenIndex, depth = position108, tokenIndex108, depth108
+					goto l121
+				l122:
+					position, tokenIndex, depth = position121, tokenIndex121, depth121
 					if buffer[position] != rune('A') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l108:
+			l121:
 				{
-					position110, tokenIndex110, depth110 := position, tokenIndex, depth
+					position123, tokenIndex123, depth123 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l111
+						goto l124
 					}
 					position++
-					goto l110
-				l111:
-					position, tokenIndex, depth = position110, tokenIndex110, depth110
+					goto l123
+				l124:
+					position, tokenIndex, depth = position123, tokenIndex123, depth123
 					if buffer[position] != rune('T') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l110:
+			l123:
 				{
-					position112, tokenIndex112, depth112 := position, tokenIndex, depth
+					position125, tokenIndex125, depth125 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l113
+						goto l126
 					}
 					position++
-					goto l112
-				l113:
-					position, tokenIndex, depth = position112, tokenIndex112, depth112
+					goto l125
+				l126:
+					position, tokenIndex, depth = position125, tokenIndex125, depth125
 					if buffer[position] != rune('E') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l112:
+			l125:
+				if !_rules[ruleOrReplaceOpt]() {
+					goto l113
+				}
 				if !_rules[rulesp]() {
-					goto l100
+					goto l113
 				}
 				{
-					position114, tokenIndex114, depth114 := position, tokenIndex, depth
+					position127, tokenIndex127, depth127 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l115
+						goto l128
 					}
 					position++
-					goto l114
-				l115:
-					position, tokenIndex, depth = position114, tokenIndex114, depth114
+					goto l127
+				l128:
+					position, tokenIndex, depth = position127, tokenIndex127, depth127
 					if buffer[position] != rune('S') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l114:
+			l127:
 				{
-					position116, tokenIndex116, depth116 := position, tokenIndex, depth
+					position129, tokenIndex129, depth129 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l117
+						goto l130
 					}
 					position++
-					goto l116
-				l117:
-					position, tokenIndex, depth = position116, tokenIndex116, depth116
+					goto l129
+				l130:
+					position, tokenIndex, depth = position129, tokenIndex129, depth129
 					if buffer[position] != rune('T') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l116:
+			l129:
 				{
-					position118, tokenIndex118, depth118 := position, tokenIndex, depth
+					position131, tokenIndex131, depth131 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l119
+						goto l132
 					}
 					position++
-					goto l118
-				l119:
-					position, tokenIndex, depth = position118, tokenIndex118, depth118
+					goto l131
+				l132:
+					position, tokenIndex, depth = position131, tokenIndex131, depth131
 					if buffer[position] != rune('R') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l118:
+			l131:
 				{
-					position120, tokenIndex120, depth120 := position, tokenIndex, depth
+					position133, tokenIndex133, depth133 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l121
+						goto l134
 					}
 					position++
-					goto l120
-				l121:
-					position, tokenIndex, depth = position120, tokenIndex120, depth120
+					goto l133
+				l134:
+					position, tokenIndex, depth = position133, tokenIndex133, depth133
 					if buffer[position] != rune('E') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l120:
+			l133:
 				{
-					position122, tokenIndex122, depth122 := position, tokenIndex, depth
+					position135, tokenIndex135, depth135 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l123
+						goto l136
 					}
 					position++
-					goto l122
-				l123:
-					position, tokenIndex, depth = position122, tokenIndex122, depth122
+					goto l135
+				l136:
+					position, tokenIndex, depth = position135, tokenIndex135, depth135
 					if buffer[position] != rune('A') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l122:
+			l135:
 				{
-					position124, tokenIndex124, depth124 := position, tokenIndex, depth
+					position137, tokenIndex137, depth137 := position, tokenIndex, depth
 					if buffer[position] != rune('m') {
-						goto l125
+						goto l138
 					}
 					position++
-					goto l124
-				l125:
-					position, tokenIndex, depth = position124, tokenIndex124, depth124
+					goto l137
+				l138:
+					position, tokenIndex, depth = position137, tokenIndex137, depth137
 					if buffer[position] != rune('M') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l124:
+			l137:
 				if !_rules[rulesp]() {
-					goto l100
+					goto l113
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l100
+					goto l113
 				}
 				if !_rules[rulesp]() {
-					goto l100
+					goto l113
 				}
 				{
-					position126, tokenIndex126, depth126 := position, tokenIndex, depth
+					position139, tokenIndex139, depth139 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l127
+						goto l140
 					}
 					position++
-					goto l126
-				l127:
-					position, tokenIndex, depth = position126, tokenIndex126, depth126
+					goto l139
+				l140:
+					position, tokenIndex, depth = position139, tokenIndex139, depth139
 					if buffer[position] != rune('A') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l126:
+			l139:
 				{
-					position128, tokenIndex128, depth128 := position, tokenIndex, depth
+					position141, tokenIndex141, depth141 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l129
+						goto l142
 					}
 					position++
-					goto l128
-				l129:
-					position, tokenIndex, depth = position128, tokenIndex128, depth128
+					goto l141
+				l142:
+					position, tokenIndex, depth = position141, tokenIndex141, depth141
 					if buffer[position] != rune('S') {
-						goto l100
+						goto l113
 					}
 					position++
 				}
-			l128:
+			l141:
 				if !_rules[rulesp]() {
-					goto l100
+					goto l113
 				}
 				if !_rules[ruleSelectStmt]() {
-					goto l100
+					goto l113
+				}
+				if !_rules[ruleEvictionTargetOpt]() {
+					goto l113
 				}
 				if !_rules[ruleAction4]() {
-					goto l100
+					goto l113
+				}
+				depth--
+				add(ruleCreateStreamAsSelectStmt, position114)
+			}
+			return true
+		l113:
+			position, tokenIndex, depth = position113, tokenIndex113, depth113
+			return false
+		},
+		/* 14 EvictionTargetOpt <- <(<(sp (('o' / 'O') ('n' / 'N')) sp (('e' / 'E') ('v' / 'V') ('i' / 'I') ('c' / 'C') ('t' / 'T')) sp (('e' / 'E') ('m' / 'M') ('i' / 'I') ('t' / 'T')) sp (('t' / 'T') ('o' / 'O')) sp StreamIdentifier)?> Action5)> */
+		func() bool {
+			position143, tokenIndex143, depth143 := position, tokenIndex, depth
+			{
+				position144 := position
+				depth++
+				{
+					position145 := position
+					depth++
+					{
+						position146, tokenIndex146, depth146 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l146
+						}
+						{
+							position148, tokenIndex148, depth148 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l149
+							}
+							position++
+							goto l148
+						l149:
+							position, tokenIndex, depth = position148, tokenIndex148, depth148
+							if buffer[position] != rune('O') {
+								goto l146
+							}
+							position++
+						}
+					l148:
+						{
+							position150, tokenIndex150, depth150 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l151
+							}
+							position++
+							goto l150
+						l151:
+							position, tokenIndex, depth = position150, tokenIndex150, depth150
+							if buffer[position] != rune('N') {
+								goto l146
+							}
+							position++
+						}
+					l150:
+						if !_rules[rulesp]() {
+							goto l146
+						}
+						{
+							position152, tokenIndex152, depth152 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l153
+							}
+							position++
+							goto l152
+						l153:
+							position, tokenIndex, depth = position152, tokenIndex152, depth152
+							if buffer[position] != rune('E') {
+								goto l146
+							}
+							position++
+						}
+					l152:
+						{
+							position154, tokenIndex154, depth154 := position, tokenIndex, depth
+							if buffer[position] != rune('v') {
+								goto l155
+							}
+							position++
+							goto l154
+						l155:
+							position, tokenIndex, depth = position154, tokenIndex154, depth154
+							if buffer[position] != rune('V') {
+								goto l146
+							}
+							position++
+						}
+					l154:
+						{
+							position156, tokenIndex156, depth156 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l157
+							}
+							position++
+							goto l156
+						l157:
+							position, tokenIndex, depth = position156, tokenIndex156, depth156
+							if buffer[position] != rune('I') {
+								goto l146
+							}
+							position++
+						}
+					l156:
+						{
+							position158, tokenIndex158, depth158 := position, tokenIndex, depth
+							if buffer[position] != rune('c') {
+								goto l159
+							}
+							position++
+							goto l158
+						l159:
+							position, tokenIndex, depth = position158, tokenIndex158, depth158
+							if buffer[position] != rune('C') {
+								goto l146
+							}
+							position++
+						}
+					l158:
+						{
+							position160, tokenIndex160, depth160 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l161
+							}
+							position++
+							goto l160
+						l161:
+							position, tokenIndex, depth = position160, tokenIndex160, depth160
+							if buffer[position] != rune('T') {
+								goto l146
+							}
+							position++
+						}
+					l160:
+						if !_rules[rulesp]() {
+							goto l146
+						}
+						{
+							position162, tokenIndex162, depth162 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l163
+							}
+							position++
+							goto l162
+						l163:
+							position, tokenIndex, depth = position162, tokenIndex162, depth162
+							if buffer[position] != rune('E') {
+								goto l146
+							}
+							position++
+						}
+					l162:
+						{
+							position164, tokenIndex164, depth164 := position, tokenIndex, depth
+							if buffer[position] != rune('m') {
+								goto l165
+							}
+							position++
+							goto l164
+						l165:
+							position, tokenIndex, depth = position164, tokenIndex164, depth164
+							if buffer[position] != rune('M') {
+								goto l146
+							}
+							position++
+						}
+					l164:
+						{
+							position166, tokenIndex166, depth166 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l167
+							}
+							position++
+							goto l166
+						l167:
+							position, tokenIndex, depth = position166, tokenIndex166, depth166
+							if buffer[position] != rune('I') {
+								goto l146
+							}
+							position++
+						}
+					l166:
+						{
+							position168, tokenIndex168, depth168 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l169
+							}
+							position++
+							goto l168
+						l169:
+							position, tokenIndex, depth = position168, tokenIndex168, depth168
+							if buffer[position] != rune('T') {
+								goto l146
+							}
+							position++
+						}
+					l168:
+						if !_rules[rulesp]() {
+							goto l146
+						}
+						{
+							position170, tokenIndex170, depth170 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l171
+							}
+							position++
+							goto l170
+						l171:
+							position, tokenIndex, depth = position170, tokenIndex170, depth170
+							if buffer[position] != rune('T') {
+								goto l146
+							}
+							position++
+						}
+					l170:
+						{
+							position172, tokenIndex172, depth172 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l173
+							}
+							position++
+							goto l172
+						l173:
+							position, tokenIndex, depth = position172, tokenIndex172, depth172
+							if buffer[position] != rune('O') {
+								goto l146
+							}
+							position++
+						}
+					l172:
+						if !_rules[rulesp]() {
+							goto l146
+						}
+						if !_rules[ruleStreamIdentifier]() {
+							goto l146
+						}
+						goto l147
+					l146:
+						position, tokenIndex, depth = position146, tokenIndex146, depth146
+					}
+				l147:
+					depth--
+					add(rulePegText, position145)
+				}
+				if !_rules[ruleAction5]() {
+					goto l143
 				}
 				depth--
-				add(ruleCreateStreamAsSelectStmt, position101)
+				add(ruleEvictionTargetOpt, position144)
 			}
 			return true
-		l100:
-			position, tokenIndex, depth = position100, tokenIndex100, depth100
+		l143:
+			position, tokenIndex, depth = position143, tokenIndex143, depth143
 			return false
 		},
-		/* 11 CreateStreamAsSelectUnionStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectUnionStmt Action5)> */
+		/* 15 CreateStreamAsSelectUnionStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') OrReplaceOpt sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier sp (('a' / 'A') ('s' / 'S')) sp SelectUnionStmt Action6)> */
 		func() bool {
-			position130, tokenIndex130, depth130 := position, tokenIndex, depth
+			position174, tokenIndex174, depth174 := position, tokenIndex, depth
 			{
-				position131 := position
+				position175 := position
 				depth++
 				{
-					position132, tokenIndex132, depth132 := position, tokenIndex, depth
+					position176, tokenIndex176, depth176 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l133
+						goto l177
 					}
 					position++
-					goto l132
-				l133:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l176
+				l177:
+					position, tokenIndex, depth = position176, tokenIndex176, depth176
 					if buffer[position] != rune('C') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l132:
+			l176:
 				{
-					position134, tokenIndex134, depth134 := position, tokenIndex, depth
+					position178, tokenIndex178, depth178 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l135
+						goto l179
 					}
 					position++
-					goto l134
-				l135:
-					position, tokenIndex, depth = position134, tokenIndex134, depth134
+					goto l178
+				l179:
+					position, tokenIndex, depth = position178, tokenIndex178, depth178
 					if buffer[position] != rune('R') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l134:
+			l178:
 				{
-					position136, tokenIndex136, depth136 := position, tokenIndex, depth
+					position180, tokenIndex180, depth180 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l137
+						goto l181
 					}
 					position++
-					goto l136
-				l137:
-					position, tokenIndex, depth = position136, tokenIndex136, depth136
+					goto l180
+				l181:
+					position, tokenIndex, depth = position180, tokenIndex180, depth180
 					if buffer[position] != rune('E') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l136:
+			l180:
 				{
-					position138, tokenIndex138, depth138 := position, tokenIndex, depth
+					position182, tokenIndex182, depth182 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l139
+						goto l183
 					}
 					position++
-					goto l138
-				l139:
-					position, tokenIndex, depth = position138, tokenIndex138, depth138
+					goto l182
+				l183:
+					position, tokenIndex, depth = position182, tokenIndex182, depth182
 					if buffer[position] != rune('A') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l138:
+			l182:
 				{
-					position140, tokenIndex140, depth140 := position, tokenIndex, depth
+					position184, tokenIndex184, depth184 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l141
+						goto l185
 					}
 					position++
-					goto l140
-				l141:
-					position, tokenIndex, depth = position140, tokenIndex140, depth140
+					goto l184
+				l185:
+					position, tokenIndex, depth = position184, tokenIndex184, depth184
 					if buffer[position] != rune('T') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l140:
+			l184:
 				{
-					position142, tokenIndex142, depth142 := position, tokenIndex, depth
+					position186, tokenIndex186, depth186 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l143
+						goto l187
 					}
 					position++
-					goto l142
-				l143:
-					position, tokenIndex, depth = position142, tokenIndex142, depth142
+					goto l186
+				l187:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
 					if buffer[position] != rune('E') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l142:
+			l186:
+				if !_rules[ruleOrReplaceOpt]() {
+					goto l174
+				}
 				if !_rules[rulesp]() {
-					goto l130
+					goto l174
 				}
 				{
-					position144, tokenIndex144, depth144 := position, tokenIndex, depth
+					position188, tokenIndex188, depth188 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l145
+						goto l189
 					}
 					position++
-					goto l144
-				l145:
-					position, tokenIndex, depth = position144, tokenIndex144, depth144
+					goto l188
+				l189:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('S') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l144:
+			l188:
 				{
-					position146, tokenIndex146, depth146 := position, tokenIndex, depth
+					position190, tokenIndex190, depth190 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l147
+						goto l191
 					}
 					position++
-					goto l146
-				l147:
-					position, tokenIndex, depth = position146, tokenIndex146, depth146
+					goto l190
+				l191:
+					position, tokenIndex, depth = position190, tokenIndex190, depth190
 					if buffer[position] != rune('T') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l146:
+			l190:
 				{
-					position148, tokenIndex148, depth148 := position, tokenIndex, depth
+					position192, tokenIndex192, depth192 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l149
+						goto l193
 					}
 					position++
-					goto l148
-				l149:
-					position, tokenIndex, depth = position148, tokenIndex148, depth148
+					goto l192
+				l193:
+					position, tokenIndex, depth = position192, tokenIndex192, depth192
 					if buffer[position] != rune('R') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l148:
+			l192:
 				{
-					position150, tokenIndex150, depth150 := position, tokenIndex, depth
+					position194, tokenIndex194, depth194 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l151
+						goto l195
 					}
 					position++
-					goto l150
-				l151:
-					position, tokenIndex, depth = position150, tokenIndex150, depth150
+					goto l194
+				l195:
+					position, tokenIndex, depth = position194, tokenIndex194, depth194
 					if buffer[position] != rune('E') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l150:
+			l194:
 				{
-					position152, tokenIndex152, depth152 := position, tokenIndex, depth
+					position196, tokenIndex196, depth196 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l153
+						goto l197
 					}
 					position++
-					goto l152
-				l153:
-					position, tokenIndex, depth = position152, tokenIndex152, depth152
+					goto l196
+				l197:
+					position, tokenIndex, depth = position196, tokenIndex196, depth196
 					if buffer[position] != rune('A') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l152:
+			l196:
 				{
-					position154, tokenIndex154, depth154 := position, tokenIndex, depth
+					position198, tokenIndex198, depth198 := position, tokenIndex, depth
 					if buffer[position] != rune('m') {
-						goto l155
+						goto l199
 					}
 					position++
-					goto l154
-				l155:
-					position, tokenIndex, depth = position154, tokenIndex154, depth154
+					goto l198
+				l199:
+					position, tokenIndex, depth = position198, tokenIndex198, depth198
 					if buffer[position] != rune('M') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l154:
+			l198:
 				if !_rules[rulesp]() {
-					goto l130
+					goto l174
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l130
+					goto l174
 				}
 				if !_rules[rulesp]() {
-					goto l130
+					goto l174
 				}
 				{
-					position156, tokenIndex156, depth156 := position, tokenIndex, depth
+					position200, tokenIndex200, depth200 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l157
+						goto l201
 					}
 					position++
-					goto l156
-				l157:
-					position, tokenIndex, depth = position156, tokenIndex156, depth156
+					goto l200
+				l201:
+					position, tokenIndex, depth = position200, tokenIndex200, depth200
 					if buffer[position] != rune('A') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l156:
+			l200:
 				{
-					position158, tokenIndex158, depth158 := position, tokenIndex, depth
+					position202, tokenIndex202, depth202 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l159
+						goto l203
 					}
 					position++
-					goto l158
-				l159:
-					position, tokenIndex, depth = position158, tokenIndex158, depth158
+					goto l202
+				l203:
+					position, tokenIndex, depth = position202, tokenIndex202, depth202
 					if buffer[position] != rune('S') {
-						goto l130
+						goto l174
 					}
 					position++
 				}
-			l158:
+			l202:
 				if !_rules[rulesp]() {
-					goto l130
+					goto l174
 				}
 				if !_rules[ruleSelectUnionStmt]() {
-					goto l130
+					goto l174
 				}
-				if !_rules[ruleAction5]() {
-					goto l130
+				if !_rules[ruleAction6]() {
+					goto l174
 				}
 				depth--
-				add(ruleCreateStreamAsSelectUnionStmt, position131)
+				add(ruleCreateStreamAsSelectUnionStmt, position175)
 			}
 			return true
-		l130:
-			position, tokenIndex, depth = position130, tokenIndex130, depth130
+		l174:
+			position, tokenIndex, depth = position174, tokenIndex174, depth174
 			return false
 		},
-		/* 12 CreateSourceStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') PausedOpt sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action6)> */
+		/* 16 CreateSourceStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') OrReplaceOpt PausedOpt sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action7)> */
 		func() bool {
-			position160, tokenIndex160, depth160 := position, tokenIndex, depth
+			position204, tokenIndex204, depth204 := position, tokenIndex, depth
 			{
-				position161 := position
+				position205 := position
 				depth++
 				{
-					position162, tokenIndex162, depth162 := position, tokenIndex, depth
+					position206, tokenIndex206, depth206 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l163
+						goto l207
 					}
 					position++
-					goto l162
-				l163:
-					position, tokenIndex, depth = position162, tokenIndex162, depth162
+					goto l206
+				l207:
+					position, tokenIndex, depth = position206, tokenIndex206, depth206
 					if buffer[position] != rune('C') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l162:
+			l206:
 				{
-					position164, tokenIndex164, depth164 := position, tokenIndex, depth
+					position208, tokenIndex208, depth208 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l165
+						goto l209
 					}
 					position++
-					goto l164
-				l165:
-					position, tokenIndex, depth = position164, tokenIndex164, depth164
+					goto l208
+				l209:
+					position, tokenIndex, depth = position208, tokenIndex208, depth208
 					if buffer[position] != rune('R') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l164:
+			l208:
 				{
-					position166, tokenIndex166, depth166 := position, tokenIndex, depth
+					position210, tokenIndex210, depth210 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l167
+						goto l211
 					}
 					position++
-					goto l166
-				l167:
-					position, tokenIndex, depth = position166, tokenIndex166, depth166
+					goto l210
+				l211:
+					position, tokenIndex, depth = position210, tokenIndex210, depth210
 					if buffer[position] != rune('E') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l166:
+			l210:
 				{
-					position168, tokenIndex168, depth168 := position, tokenIndex, depth
+					position212, tokenIndex212, depth212 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l169
+						goto l213
 					}
 					position++
-					goto l168
-				l169:
-					position, tokenIndex, depth = position168, tokenIndex168, depth168
+					goto l212
+				l213:
+					position, tokenIndex, depth = position212, tokenIndex212, depth212
 					if buffer[position] != rune('A') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l168:
+			l212:
 				{
-					position170, tokenIndex170, depth170 := position, tokenIndex, depth
+					position214, tokenIndex214, depth214 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l171
+						goto l215
 					}
 					position++
-					goto l170
-				l171:
-					position, tokenIndex, depth = position170, tokenIndex170, depth170
+					goto l214
+				l215:
+					position, tokenIndex, depth = position214, tokenIndex214, depth214
 					if buffer[position] != rune('T') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l170:
+			l214:
 				{
-					position172, tokenIndex172, depth172 := position, tokenIndex, depth
+					position216, tokenIndex216, depth216 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l173
+						goto l217
 					}
 					position++
-					goto l172
-				l173:
-					position, tokenIndex, depth = position172, tokenIndex172, depth172
+					goto l216
+				l217:
+					position, tokenIndex, depth = position216, tokenIndex216, depth216
 					if buffer[position] != rune('E') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l172:
+			l216:
+				if !_rules[ruleOrReplaceOpt]() {
+					goto l204
+				}
 				if !_rules[rulePausedOpt]() {
-					goto l160
+					goto l204
 				}
 				if !_rules[rulesp]() {
-					goto l160
+					goto l204
 				}
 				{
-					position174, tokenIndex174, depth174 := position, tokenIndex, depth
+					position218, tokenIndex218, depth218 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l175
+						goto l219
 					}
 					position++
-					goto l174
-				l175:
-					position, tokenIndex, depth = position174, tokenIndex174, depth174
+					goto l218
+				l219:
+					position, tokenIndex, depth = position218, tokenIndex218, depth218
 					if buffer[position] != rune('S') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l174:
+			l218:
 				{
-					position176, tokenIndex176, depth176 := position, tokenIndex, depth
+					position220, tokenIndex220, depth220 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l177
+						goto l221
 					}
 					position++
-					goto l176
-				l177:
-					position, tokenIndex, depth = position176, tokenIndex176, depth176
+					goto l220
+				l221:
+					position, tokenIndex, depth = position220, tokenIndex220, depth220
 					if buffer[position] != rune('O') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l176:
+			l220:
 				{
-					position178, tokenIndex178, depth178 := position, tokenIndex, depth
+					position222, tokenIndex222, depth222 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l179
+						goto l223
 					}
 					position++
-					goto l178
-				l179:
-					position, tokenIndex, depth = position178, tokenIndex178, depth178
+					goto l222
+				l223:
+					position, tokenIndex, depth = position222, tokenIndex222, depth222
 					if buffer[position] != rune('U') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l178:
+			l222:
 				{
-					position180, tokenIndex180, depth180 := position, tokenIndex, depth
+					position224, tokenIndex224, depth224 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l181
+						goto l225
 					}
 					position++
-					goto l180
-				l181:
-					position, tokenIndex, depth = position180, tokenIndex180, depth180
+					goto l224
+				l225:
+					position, tokenIndex, depth = position224, tokenIndex224, depth224
 					if buffer[position] != rune('R') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l180:
+			l224:
 				{
-					position182, tokenIndex182, depth182 := position, tokenIndex, depth
+					position226, tokenIndex226, depth226 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l183
+						goto l227
 					}
 					position++
-					goto l182
-				l183:
-					position, tokenIndex, depth = position182, tokenIndex182, depth182
+					goto l226
+				l227:
+					position, tokenIndex, depth = position226, tokenIndex226, depth226
 					if buffer[position] != rune('C') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l182:
+			l226:
 				{
-					position184, tokenIndex184, depth184 := position, tokenIndex, depth
+					position228, tokenIndex228, depth228 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l185
+						goto l229
 					}
 					position++
-					goto l184
-				l185:
-					position, tokenIndex, depth = position184, tokenIndex184, depth184
+					goto l228
+				l229:
+					position, tokenIndex, depth = position228, tokenIndex228, depth228
 					if buffer[position] != rune('E') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l184:
+			l228:
 				if !_rules[rulesp]() {
-					goto l160
+					goto l204
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l160
+					goto l204
 				}
 				if !_rules[rulesp]() {
-					goto l160
+					goto l204
 				}
 				{
-					position186, tokenIndex186, depth186 := position, tokenIndex, depth
+					position230, tokenIndex230, depth230 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l187
+						goto l231
 					}
 					position++
-					goto l186
-				l187:
-					position, tokenIndex, depth = position186, tokenIndex186, depth186
+					goto l230
+				l231:
+					position, tokenIndex, depth = position230, tokenIndex230, depth230
 					if buffer[position] != rune('T') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l186:
+			l230:
 				{
-					position188, tokenIndex188, depth188 := position, tokenIndex, depth
+					position232, tokenIndex232, depth232 := position, tokenIndex, depth
 					if buffer[position] != rune('y') {
-						goto l189
+						goto l233
 					}
 					position++
-					goto l188
-				l189:
-					position, tokenIndex, depth = position188, tokenIndex188, depth188
+					goto l232
+				l233:
+					position, tokenIndex, depth = position232, tokenIndex232, depth232
 					if buffer[position] != rune('Y') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l188:
+			l232:
 				{
-					position190, tokenIndex190, depth190 := position, tokenIndex, depth
+					position234, tokenIndex234, depth234 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l191
+						goto l235
 					}
 					position++
-					goto l190
-				l191:
-					position, tokenIndex, depth = position190, tokenIndex190, depth190
+					goto l234
+				l235:
+					position, tokenIndex, depth = position234, tokenIndex234, depth234
 					if buffer[position] != rune('P') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l190:
+			l234:
 				{
-					position192, tokenIndex192, depth192 := position, tokenIndex, depth
+					position236, tokenIndex236, depth236 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l193
+						goto l237
 					}
 					position++
-					goto l192
-				l193:
-					position, tokenIndex, depth = position192, tokenIndex192, depth192
+					goto l236
+				l237:
+					position, tokenIndex, depth = position236, tokenIndex236, depth236
 					if buffer[position] != rune('E') {
-						goto l160
+						goto l204
 					}
 					position++
 				}
-			l192:
+			l236:
 				if !_rules[rulesp]() {
-					goto l160
+					goto l204
 				}
 				if !_rules[ruleSourceSinkType]() {
-					goto l160
+					goto l204
 				}
 				if !_rules[ruleSourceSinkSpecs]() {
-					goto l160
+					goto l204
 				}
-				if !_rules[ruleAction6]() {
-					goto l160
+				if !_rules[ruleAction7]() {
+					goto l204
 				}
 				depth--
-				add(ruleCreateSourceStmt, position161)
+				add(ruleCreateSourceStmt, position205)
 			}
 			return true
-		l160:
-			position, tokenIndex, depth = position160, tokenIndex160, depth160
+		l204:
+			position, tokenIndex, depth = position204, tokenIndex204, depth204
 			return false
 		},
-		/* 13 CreateSinkStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action7)> */
+		/* 17 CreateSinkStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') OrReplaceOpt sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action8)> */
 		func() bool {
-			position194, tokenIndex194, depth194 := position, tokenIndex, depth
+			position238, tokenIndex238, depth238 := position, tokenIndex, depth
 			{
-				position195 := position
+				position239 := position
 				depth++
 				{
-					position196, tokenIndex196, depth196 := position, tokenIndex, depth
+					position240, tokenIndex240, depth240 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l197
+						goto l241
 					}
 					position++
-					goto l196
-				l197:
-					position, tokenIndex, depth = position196, tokenIndex196, depth196
+					goto l240
+				l241:
+					position, tokenIndex, depth = position240, tokenIndex240, depth240
 					if buffer[position] != rune('C') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l196:
+			l240:
 				{
-					position198, tokenIndex198, depth198 := position, tokenIndex, depth
+					position242, tokenIndex242, depth242 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l199
+						goto l243
 					}
 					position++
-					goto l198
-				l199:
-					position, tokenIndex, depth = position198, tokenIndex198, depth198
+					goto l242
+				l243:
+					position, tokenIndex, depth = position242, tokenIndex242, depth242
 					if buffer[position] != rune('R') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l198:
+			l242:
 				{
-					position200, tokenIndex200, depth200 := position, tokenIndex, depth
+					position244, tokenIndex244, depth244 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l201
+						goto l245
 					}
 					position++
-					goto l200
-				l201:
-					position, tokenIndex, depth = position200, tokenIndex200, depth200
+					goto l244
+				l245:
+					position, tokenIndex, depth = position244, tokenIndex244, depth244
 					if buffer[position] != rune('E') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l200:
+			l244:
 				{
-					position202, tokenIndex202, depth202 := position, tokenIndex, depth
+					position246, tokenIndex246, depth246 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l203
+						goto l247
 					}
 					position++
-					goto l202
-				l203:
-					position, tokenIndex, depth = position202, tokenIndex202, depth202
-					if buffer[position] != rune('A') {
-						goto l194
+					goto l246
+				l247:
+					position, tokenIndex, depth = position246, tokenIndex246, depth246
+					if buffer[position] != rune('A') {
+						goto l238
 					}
 					position++
 				}
-			l202:
+			l246:
 				{
-					position204, tokenIndex204, depth204 := position, tokenIndex, depth
+					position248, tokenIndex248, depth248 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l205
+						goto l249
 					}
 					position++
-					goto l204
-				l205:
-					position, tokenIndex, depth = position204, tokenIndex204, depth204
+					goto l248
+				l249:
+					position, tokenIndex, depth = position248, tokenIndex248, depth248
 					if buffer[position] != rune('T') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l204:
+			l248:
 				{
-					position206, tokenIndex206, depth206 := position, tokenIndex, depth
+					position250, tokenIndex250, depth250 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l207
+						goto l251
 					}
 					position++
-					goto l206
-				l207:
-					position, tokenIndex, depth = position206, tokenIndex206, depth206
+					goto l250
+				l251:
+					position, tokenIndex, depth = position250, tokenIndex250, depth250
 					if buffer[position] != rune('E') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l206:
+			l250:
+				if !_rules[ruleOrReplaceOpt]() {
+					goto l238
+				}
 				if !_rules[rulesp]() {
-					goto l194
+					goto l238
 				}
 				{
-					position208, tokenIndex208, depth208 := position, tokenIndex, depth
+					position252, tokenIndex252, depth252 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l209
+						goto l253
 					}
 					position++
-					goto l208
-				l209:
-					position, tokenIndex, depth = position208, tokenIndex208, depth208
+					goto l252
+				l253:
+					position, tokenIndex, depth = position252, tokenIndex252, depth252
 					if buffer[position] != rune('S') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l208:
+			l252:
 				{
-					position210, tokenIndex210, depth210 := position, tokenIndex, depth
+					position254, tokenIndex254, depth254 := position, tokenIndex, depth
 					if buffer[position] != rune('i') {
-						goto l211
+						goto l255
 					}
 					position++
-					goto l210
-				l211:
-					position, tokenIndex, depth = position210, tokenIndex210, depth210
+					goto l254
+				l255:
+					position, tokenIndex, depth = position254, tokenIndex254, depth254
 					if buffer[position] != rune('I') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l210:
+			l254:
 				{
-					position212, tokenIndex212, depth212 := position, tokenIndex, depth
+					position256, tokenIndex256, depth256 := position, tokenIndex, depth
 					if buffer[position] != rune('n') {
-						goto l213
+						goto l257
 					}
 					position++
-					goto l212
-				l213:
-					position, tokenIndex, depth = position212, tokenIndex212, depth212
+					goto l256
+				l257:
+					position, tokenIndex, depth = position256, tokenIndex256, depth256
 					if buffer[position] != rune('N') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l212:
+			l256:
 				{
-					position214, tokenIndex214, depth214 := position, tokenIndex, depth
+					position258, tokenIndex258, depth258 := position, tokenIndex, depth
 					if buffer[position] != rune('k') {
-						goto l215
+						goto l259
 					}
 					position++
-					goto l214
-				l215:
-					position, tokenIndex, depth = position214, tokenIndex214, depth214
+					goto l258
+				l259:
+					position, tokenIndex, depth = position258, tokenIndex258, depth258
 					if buffer[position] != rune('K') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l214:
+			l258:
 				if !_rules[rulesp]() {
-					goto l194
+					goto l238
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l194
+					goto l238
 				}
 				if !_rules[rulesp]() {
-					goto l194
+					goto l238
 				}
 				{
-					position216, tokenIndex216, depth216 := position, tokenIndex, depth
+					position260, tokenIndex260, depth260 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l217
+						goto l261
 					}
 					position++
-					goto l216
-				l217:
-					position, tokenIndex, depth = position216, tokenIndex216, depth216
+					goto l260
+				l261:
+					position, tokenIndex, depth = position260, tokenIndex260, depth260
 					if buffer[position] != rune('T') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l216:
+			l260:
 				{
-					position218, tokenIndex218, depth218 := position, tokenIndex, depth
+					position262, tokenIndex262, depth262 := position, tokenIndex, depth
 					if buffer[position] != rune('y') {
-						goto l219
+						goto l263
 					}
 					position++
-					goto l218
-				l219:
-					position, tokenIndex, depth = position218, tokenIndex218, depth218
+					goto l262
+				l263:
+					position, tokenIndex, depth = position262, tokenIndex262, depth262
 					if buffer[position] != rune('Y') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l218:
+			l262:
 				{
-					position220, tokenIndex220, depth220 := position, tokenIndex, depth
+					position264, tokenIndex264, depth264 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l221
+						goto l265
 					}
 					position++
-					goto l220
-				l221:
-					position, tokenIndex, depth = position220, tokenIndex220, depth220
+					goto l264
+				l265:
+					position, tokenIndex, depth = position264, tokenIndex264, depth264
 					if buffer[position] != rune('P') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l220:
+			l264:
 				{
-					position222, tokenIndex222, depth222 := position, tokenIndex, depth
+					position266, tokenIndex266, depth266 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l223
+						goto l267
 					}
 					position++
-					goto l222
-				l223:
-					position, tokenIndex, depth = position222, tokenIndex222, depth222
+					goto l266
+				l267:
+					position, tokenIndex, depth = position266, tokenIndex266, depth266
 					if buffer[position] != rune('E') {
-						goto l194
+						goto l238
 					}
 					position++
 				}
-			l222:
+			l266:
 				if !_rules[rulesp]() {
-					goto l194
+					goto l238
 				}
 				if !_rules[ruleSourceSinkType]() {
-					goto l194
+					goto l238
 				}
 				if !_rules[ruleSourceSinkSpecs]() {
-					goto l194
+					goto l238
 				}
-				if !_rules[ruleAction7]() {
-					goto l194
+				if !_rules[ruleAction8]() {
+					goto l238
 				}
 				depth--
-				add(ruleCreateSinkStmt, position195)
+				add(ruleCreateSinkStmt, position239)
 			}
 			return true
-		l194:
-			position, tokenIndex, depth = position194, tokenIndex194, depth194
+		l238:
+			position, tokenIndex, depth = position238, tokenIndex238, depth238
 			return false
 		},
-		/* 14 CreateStateStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action8)> */
+		/* 18 CreateBoxStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('b' / 'B') ('o' / 'O') ('x' / 'X')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp StreamIdentifier SourceSinkSpecs Action9)> */
 		func() bool {
-			position224, tokenIndex224, depth224 := position, tokenIndex, depth
+			position268, tokenIndex268, depth268 := position, tokenIndex, depth
 			{
-				position225 := position
+				position269 := position
 				depth++
 				{
-					position226, tokenIndex226, depth226 := position, tokenIndex, depth
+					position270, tokenIndex270, depth270 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l227
+						goto l271
 					}
 					position++
-					goto l226
-				l227:
-					position, tokenIndex, depth = position226, tokenIndex226, depth226
+					goto l270
+				l271:
+					position, tokenIndex, depth = position270, tokenIndex270, depth270
 					if buffer[position] != rune('C') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l226:
+			l270:
 				{
-					position228, tokenIndex228, depth228 := position, tokenIndex, depth
+					position272, tokenIndex272, depth272 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l229
+						goto l273
 					}
 					position++
-					goto l228
-				l229:
-					position, tokenIndex, depth = position228, tokenIndex228, depth228
+					goto l272
+				l273:
+					position, tokenIndex, depth = position272, tokenIndex272, depth272
 					if buffer[position] != rune('R') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l228:
+			l272:
 				{
-					position230, tokenIndex230, depth230 := position, tokenIndex, depth
+					position274, tokenIndex274, depth274 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l231
+						goto l275
 					}
 					position++
-					goto l230
-				l231:
-					position, tokenIndex, depth = position230, tokenIndex230, depth230
+					goto l274
+				l275:
+					position, tokenIndex, depth = position274, tokenIndex274, depth274
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l230:
+			l274:
 				{
-					position232, tokenIndex232, depth232 := position, tokenIndex, depth
+					position276, tokenIndex276, depth276 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l233
+						goto l277
 					}
 					position++
-					goto l232
-				l233:
-					position, tokenIndex, depth = position232, tokenIndex232, depth232
+					goto l276
+				l277:
+					position, tokenIndex, depth = position276, tokenIndex276, depth276
 					if buffer[position] != rune('A') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l232:
+			l276:
 				{
-					position234, tokenIndex234, depth234 := position, tokenIndex, depth
+					position278, tokenIndex278, depth278 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l235
+						goto l279
 					}
 					position++
-					goto l234
-				l235:
-					position, tokenIndex, depth = position234, tokenIndex234, depth234
+					goto l278
+				l279:
+					position, tokenIndex, depth = position278, tokenIndex278, depth278
 					if buffer[position] != rune('T') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l234:
+			l278:
 				{
-					position236, tokenIndex236, depth236 := position, tokenIndex, depth
+					position280, tokenIndex280, depth280 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l237
+						goto l281
 					}
 					position++
-					goto l236
-				l237:
-					position, tokenIndex, depth = position236, tokenIndex236, depth236
+					goto l280
+				l281:
+					position, tokenIndex, depth = position280, tokenIndex280, depth280
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l236:
+			l280:
 				if !_rules[rulesp]() {
-					goto l224
+					goto l268
 				}
 				{
-					position238, tokenIndex238, depth238 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l239
+					position282, tokenIndex282, depth282 := position, tokenIndex, depth
+					if buffer[position] != rune('b') {
+						goto l283
 					}
 					position++
-					goto l238
-				l239:
-					position, tokenIndex, depth = position238, tokenIndex238, depth238
-					if buffer[position] != rune('S') {
-						goto l224
+					goto l282
+				l283:
+					position, tokenIndex, depth = position282, tokenIndex282, depth282
+					if buffer[position] != rune('B') {
+						goto l268
 					}
 					position++
 				}
-			l238:
+			l282:
 				{
-					position240, tokenIndex240, depth240 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l241
+					position284, tokenIndex284, depth284 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l285
 					}
 					position++
-					goto l240
-				l241:
-					position, tokenIndex, depth = position240, tokenIndex240, depth240
-					if buffer[position] != rune('T') {
-						goto l224
+					goto l284
+				l285:
+					position, tokenIndex, depth = position284, tokenIndex284, depth284
+					if buffer[position] != rune('O') {
+						goto l268
 					}
 					position++
 				}
-			l240:
+			l284:
 				{
-					position242, tokenIndex242, depth242 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l243
+					position286, tokenIndex286, depth286 := position, tokenIndex, depth
+					if buffer[position] != rune('x') {
+						goto l287
 					}
 					position++
-					goto l242
-				l243:
-					position, tokenIndex, depth = position242, tokenIndex242, depth242
-					if buffer[position] != rune('A') {
-						goto l224
+					goto l286
+				l287:
+					position, tokenIndex, depth = position286, tokenIndex286, depth286
+					if buffer[position] != rune('X') {
+						goto l268
 					}
 					position++
 				}
-			l242:
+			l286:
+				if !_rules[rulesp]() {
+					goto l268
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l268
+				}
+				if !_rules[rulesp]() {
+					goto l268
+				}
 				{
-					position244, tokenIndex244, depth244 := position, tokenIndex, depth
+					position288, tokenIndex288, depth288 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l245
+						goto l289
 					}
 					position++
-					goto l244
-				l245:
-					position, tokenIndex, depth = position244, tokenIndex244, depth244
+					goto l288
+				l289:
+					position, tokenIndex, depth = position288, tokenIndex288, depth288
 					if buffer[position] != rune('T') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l244:
+			l288:
 				{
-					position246, tokenIndex246, depth246 := position, tokenIndex, depth
+					position290, tokenIndex290, depth290 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l291
+					}
+					position++
+					goto l290
+				l291:
+					position, tokenIndex, depth = position290, tokenIndex290, depth290
+					if buffer[position] != rune('Y') {
+						goto l268
+					}
+					position++
+				}
+			l290:
+				{
+					position292, tokenIndex292, depth292 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l293
+					}
+					position++
+					goto l292
+				l293:
+					position, tokenIndex, depth = position292, tokenIndex292, depth292
+					if buffer[position] != rune('P') {
+						goto l268
+					}
+					position++
+				}
+			l292:
+				{
+					position294, tokenIndex294, depth294 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l247
+						goto l295
 					}
 					position++
-					goto l246
-				l247:
-					position, tokenIndex, depth = position246, tokenIndex246, depth246
+					goto l294
+				l295:
+					position, tokenIndex, depth = position294, tokenIndex294, depth294
 					if buffer[position] != rune('E') {
-						goto l224
+						goto l268
 					}
 					position++
 				}
-			l246:
+			l294:
 				if !_rules[rulesp]() {
-					goto l224
+					goto l268
 				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l224
+				if !_rules[ruleSourceSinkType]() {
+					goto l268
 				}
 				if !_rules[rulesp]() {
-					goto l224
+					goto l268
 				}
 				{
-					position248, tokenIndex248, depth248 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l249
+					position296, tokenIndex296, depth296 := position, tokenIndex, depth
+					if buffer[position] != rune('f') {
+						goto l297
 					}
 					position++
-					goto l248
-				l249:
-					position, tokenIndex, depth = position248, tokenIndex248, depth248
-					if buffer[position] != rune('T') {
-						goto l224
+					goto l296
+				l297:
+					position, tokenIndex, depth = position296, tokenIndex296, depth296
+					if buffer[position] != rune('F') {
+						goto l268
 					}
 					position++
 				}
-			l248:
+			l296:
 				{
-					position250, tokenIndex250, depth250 := position, tokenIndex, depth
-					if buffer[position] != rune('y') {
-						goto l251
+					position298, tokenIndex298, depth298 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l299
 					}
 					position++
-					goto l250
-				l251:
-					position, tokenIndex, depth = position250, tokenIndex250, depth250
-					if buffer[position] != rune('Y') {
-						goto l224
+					goto l298
+				l299:
+					position, tokenIndex, depth = position298, tokenIndex298, depth298
+					if buffer[position] != rune('R') {
+						goto l268
 					}
 					position++
 				}
-			l250:
+			l298:
 				{
-					position252, tokenIndex252, depth252 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l253
+					position300, tokenIndex300, depth300 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l301
 					}
 					position++
-					goto l252
-				l253:
-					position, tokenIndex, depth = position252, tokenIndex252, depth252
-					if buffer[position] != rune('P') {
-						goto l224
+					goto l300
+				l301:
+					position, tokenIndex, depth = position300, tokenIndex300, depth300
+					if buffer[position] != rune('O') {
+						goto l268
 					}
 					position++
 				}
-			l252:
+			l300:
 				{
-					position254, tokenIndex254, depth254 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l255
+					position302, tokenIndex302, depth302 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l303
 					}
 					position++
-					goto l254
-				l255:
-					position, tokenIndex, depth = position254, tokenIndex254, depth254
-					if buffer[position] != rune('E') {
-						goto l224
+					goto l302
+				l303:
+					position, tokenIndex, depth = position302, tokenIndex302, depth302
+					if buffer[position] != rune('M') {
+						goto l268
 					}
 					position++
 				}
-			l254:
+			l302:
 				if !_rules[rulesp]() {
-					goto l224
+					goto l268
 				}
-				if !_rules[ruleSourceSinkType]() {
-					goto l224
+				if !_rules[ruleStreamIdentifier]() {
+					goto l268
 				}
 				if !_rules[ruleSourceSinkSpecs]() {
-					goto l224
+					goto l268
 				}
-				if !_rules[ruleAction8]() {
-					goto l224
+				if !_rules[ruleAction9]() {
+					goto l268
 				}
 				depth--
-				add(ruleCreateStateStmt, position225)
+				add(ruleCreateBoxStmt, position269)
 			}
 			return true
-		l224:
-			position, tokenIndex, depth = position224, tokenIndex224, depth224
+		l268:
+			position, tokenIndex, depth = position268, tokenIndex268, depth268
 			return false
 		},
-		/* 15 UpdateStateStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action9)> */
+		/* 19 CreateStateStmt <- <(('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E') OrReplaceOpt sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType SourceSinkSpecs Action10)> */
 		func() bool {
-			position256, tokenIndex256, depth256 := position, tokenIndex, depth
+			position304, tokenIndex304, depth304 := position, tokenIndex, depth
 			{
-				position257 := position
+				position305 := position
 				depth++
 				{
-					position258, tokenIndex258, depth258 := position, tokenIndex, depth
-					if buffer[position] != rune('u') {
-						goto l259
+					position306, tokenIndex306, depth306 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l307
 					}
 					position++
-					goto l258
-				l259:
-					position, tokenIndex, depth = position258, tokenIndex258, depth258
-					if buffer[position] != rune('U') {
-						goto l256
+					goto l306
+				l307:
+					position, tokenIndex, depth = position306, tokenIndex306, depth306
+					if buffer[position] != rune('C') {
+						goto l304
 					}
 					position++
 				}
-			l258:
+			l306:
 				{
-					position260, tokenIndex260, depth260 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l261
+					position308, tokenIndex308, depth308 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l309
 					}
 					position++
-					goto l260
-				l261:
-					position, tokenIndex, depth = position260, tokenIndex260, depth260
-					if buffer[position] != rune('P') {
-						goto l256
+					goto l308
+				l309:
+					position, tokenIndex, depth = position308, tokenIndex308, depth308
+					if buffer[position] != rune('R') {
+						goto l304
 					}
 					position++
 				}
-			l260:
+			l308:
 				{
-					position262, tokenIndex262, depth262 := position, tokenIndex, depth
-					if buffer[position] != rune('d') {
-						goto l263
+					position310, tokenIndex310, depth310 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l311
 					}
 					position++
-					goto l262
-				l263:
-					position, tokenIndex, depth = position262, tokenIndex262, depth262
-					if buffer[position] != rune('D') {
-						goto l256
+					goto l310
+				l311:
+					position, tokenIndex, depth = position310, tokenIndex310, depth310
+					if buffer[position] != rune('E') {
+						goto l304
 					}
 					position++
 				}
-			l262:
+			l310:
 				{
-					position264, tokenIndex264, depth264 := position, tokenIndex, depth
+					position312, tokenIndex312, depth312 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l265
+						goto l313
 					}
 					position++
-					goto l264
-				l265:
-					position, tokenIndex, depth = position264, tokenIndex264, depth264
+					goto l312
+				l313:
+					position, tokenIndex, depth = position312, tokenIndex312, depth312
 					if buffer[position] != rune('A') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l264:
+			l312:
 				{
-					position266, tokenIndex266, depth266 := position, tokenIndex, depth
+					position314, tokenIndex314, depth314 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l267
+						goto l315
 					}
 					position++
-					goto l266
-				l267:
-					position, tokenIndex, depth = position266, tokenIndex266, depth266
+					goto l314
+				l315:
+					position, tokenIndex, depth = position314, tokenIndex314, depth314
 					if buffer[position] != rune('T') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l266:
+			l314:
 				{
-					position268, tokenIndex268, depth268 := position, tokenIndex, depth
+					position316, tokenIndex316, depth316 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l269
+						goto l317
 					}
 					position++
-					goto l268
-				l269:
-					position, tokenIndex, depth = position268, tokenIndex268, depth268
+					goto l316
+				l317:
+					position, tokenIndex, depth = position316, tokenIndex316, depth316
 					if buffer[position] != rune('E') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l268:
+			l316:
+				if !_rules[ruleOrReplaceOpt]() {
+					goto l304
+				}
 				if !_rules[rulesp]() {
-					goto l256
+					goto l304
 				}
 				{
-					position270, tokenIndex270, depth270 := position, tokenIndex, depth
+					position318, tokenIndex318, depth318 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l271
+						goto l319
 					}
 					position++
-					goto l270
-				l271:
-					position, tokenIndex, depth = position270, tokenIndex270, depth270
+					goto l318
+				l319:
+					position, tokenIndex, depth = position318, tokenIndex318, depth318
 					if buffer[position] != rune('S') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l270:
+			l318:
 				{
-					position272, tokenIndex272, depth272 := position, tokenIndex, depth
+					position320, tokenIndex320, depth320 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l273
+						goto l321
 					}
 					position++
-					goto l272
-				l273:
-					position, tokenIndex, depth = position272, tokenIndex272, depth272
+					goto l320
+				l321:
+					position, tokenIndex, depth = position320, tokenIndex320, depth320
 					if buffer[position] != rune('T') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l272:
+			l320:
 				{
-					position274, tokenIndex274, depth274 := position, tokenIndex, depth
+					position322, tokenIndex322, depth322 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l275
+						goto l323
 					}
 					position++
-					goto l274
-				l275:
-					position, tokenIndex, depth = position274, tokenIndex274, depth274
+					goto l322
+				l323:
+					position, tokenIndex, depth = position322, tokenIndex322, depth322
 					if buffer[position] != rune('A') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l274:
+			l322:
 				{
-					position276, tokenIndex276, depth276 := position, tokenIndex, depth
+					position324, tokenIndex324, depth324 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l277
+						goto l325
 					}
 					position++
-					goto l276
-				l277:
-					position, tokenIndex, depth = position276, tokenIndex276, depth276
-					if buffer[position] != rune('T') {
-						goto l256
-					}
+					goto l324
+				l325:
+					position, tokenIndex, depth = position324, tokenIndex324, depth324
+					if buffer[position] != rune('T') {
+						goto l304
+					}
 					position++
 				}
-			l276:
+			l324:
 				{
-					position278, tokenIndex278, depth278 := position, tokenIndex, depth
+					position326, tokenIndex326, depth326 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l279
+						goto l327
 					}
 					position++
-					goto l278
-				l279:
-					position, tokenIndex, depth = position278, tokenIndex278, depth278
+					goto l326
+				l327:
+					position, tokenIndex, depth = position326, tokenIndex326, depth326
 					if buffer[position] != rune('E') {
-						goto l256
+						goto l304
 					}
 					position++
 				}
-			l278:
+			l326:
 				if !_rules[rulesp]() {
-					goto l256
+					goto l304
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l256
+					goto l304
 				}
-				if !_rules[ruleUpdateSourceSinkSpecs]() {
-					goto l256
+				if !_rules[rulesp]() {
+					goto l304
 				}
-				if !_rules[ruleAction9]() {
-					goto l256
+				{
+					position328, tokenIndex328, depth328 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l329
+					}
+					position++
+					goto l328
+				l329:
+					position, tokenIndex, depth = position328, tokenIndex328, depth328
+					if buffer[position] != rune('T') {
+						goto l304
+					}
+					position++
+				}
+			l328:
+				{
+					position330, tokenIndex330, depth330 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l331
+					}
+					position++
+					goto l330
+				l331:
+					position, tokenIndex, depth = position330, tokenIndex330, depth330
+					if buffer[position] != rune('Y') {
+						goto l304
+					}
+					position++
+				}
+			l330:
+				{
+					position332, tokenIndex332, depth332 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l333
+					}
+					position++
+					goto l332
+				l333:
+					position, tokenIndex, depth = position332, tokenIndex332, depth332
+					if buffer[position] != rune('P') {
+						goto l304
+					}
+					position++
+				}
+			l332:
+				{
+					position334, tokenIndex334, depth334 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l335
+					}
+					position++
+					goto l334
+				l335:
+					position, tokenIndex, depth = position334, tokenIndex334, depth334
+					if buffer[position] != rune('E') {
+						goto l304
+					}
+					position++
+				}
+			l334:
+				if !_rules[rulesp]() {
+					goto l304
+				}
+				if !_rules[ruleSourceSinkType]() {
+					goto l304
+				}
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l304
+				}
+				if !_rules[ruleAction10]() {
+					goto l304
 				}
 				depth--
-				add(ruleUpdateStateStmt, position257)
+				add(ruleCreateStateStmt, position305)
 			}
 			return true
-		l256:
-			position, tokenIndex, depth = position256, tokenIndex256, depth256
+		l304:
+			position, tokenIndex, depth = position304, tokenIndex304, depth304
 			return false
 		},
-		/* 16 UpdateSourceStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action10)> */
+		/* 20 UpdateStateStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action11)> */
 		func() bool {
-			position280, tokenIndex280, depth280 := position, tokenIndex, depth
+			position336, tokenIndex336, depth336 := position, tokenIndex, depth
 			{
-				position281 := position
+				position337 := position
 				depth++
 				{
-					position282, tokenIndex282, depth282 := position, tokenIndex, depth
+					position338, tokenIndex338, depth338 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l283
+						goto l339
 					}
 					position++
-					goto l282
-				l283:
-					position, tokenIndex, depth = position282, tokenIndex282, depth282
+					goto l338
+				l339:
+					position, tokenIndex, depth = position338, tokenIndex338, depth338
 					if buffer[position] != rune('U') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l282:
+			l338:
 				{
-					position284, tokenIndex284, depth284 := position, tokenIndex, depth
+					position340, tokenIndex340, depth340 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l285
+						goto l341
 					}
 					position++
-					goto l284
-				l285:
-					position, tokenIndex, depth = position284, tokenIndex284, depth284
+					goto l340
+				l341:
+					position, tokenIndex, depth = position340, tokenIndex340, depth340
 					if buffer[position] != rune('P') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l284:
+			l340:
 				{
-					position286, tokenIndex286, depth286 := position, tokenIndex, depth
+					position342, tokenIndex342, depth342 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l287
+						goto l343
 					}
 					position++
-					goto l286
-				l287:
-					position, tokenIndex, depth = position286, tokenIndex286, depth286
+					goto l342
+				l343:
+					position, tokenIndex, depth = position342, tokenIndex342, depth342
 					if buffer[position] != rune('D') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l286:
+			l342:
 				{
-					position288, tokenIndex288, depth288 := position, tokenIndex, depth
+					position344, tokenIndex344, depth344 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l289
+						goto l345
 					}
 					position++
-					goto l288
-				l289:
-					position, tokenIndex, depth = position288, tokenIndex288, depth288
+					goto l344
+				l345:
+					position, tokenIndex, depth = position344, tokenIndex344, depth344
 					if buffer[position] != rune('A') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l288:
+			l344:
 				{
-					position290, tokenIndex290, depth290 := position, tokenIndex, depth
+					position346, tokenIndex346, depth346 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l291
+						goto l347
 					}
 					position++
-					goto l290
-				l291:
-					position, tokenIndex, depth = position290, tokenIndex290, depth290
+					goto l346
+				l347:
+					position, tokenIndex, depth = position346, tokenIndex346, depth346
 					if buffer[position] != rune('T') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l290:
+			l346:
 				{
-					position292, tokenIndex292, depth292 := position, tokenIndex, depth
+					position348, tokenIndex348, depth348 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l293
+						goto l349
 					}
 					position++
-					goto l292
-				l293:
-					position, tokenIndex, depth = position292, tokenIndex292, depth292
+					goto l348
+				l349:
+					position, tokenIndex, depth = position348, tokenIndex348, depth348
 					if buffer[position] != rune('E') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l292:
+			l348:
 				if !_rules[rulesp]() {
-					goto l280
+					goto l336
 				}
 				{
-					position294, tokenIndex294, depth294 := position, tokenIndex, depth
+					position350, tokenIndex350, depth350 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l295
+						goto l351
 					}
 					position++
-					goto l294
-				l295:
-					position, tokenIndex, depth = position294, tokenIndex294, depth294
+					goto l350
+				l351:
+					position, tokenIndex, depth = position350, tokenIndex350, depth350
 					if buffer[position] != rune('S') {
-						goto l280
-					}
-					position++
-				}
-			l294:
-				{
-					position296, tokenIndex296, depth296 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l297
-					}
-					position++
-					goto l296
-				l297:
-					position, tokenIndex, depth = position296, tokenIndex296, depth296
-					if buffer[position] != rune('O') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l296:
+			l350:
 				{
-					position298, tokenIndex298, depth298 := position, tokenIndex, depth
-					if buffer[position] != rune('u') {
-						goto l299
+					position352, tokenIndex352, depth352 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l353
 					}
 					position++
-					goto l298
-				l299:
-					position, tokenIndex, depth = position298, tokenIndex298, depth298
-					if buffer[position] != rune('U') {
-						goto l280
+					goto l352
+				l353:
+					position, tokenIndex, depth = position352, tokenIndex352, depth352
+					if buffer[position] != rune('T') {
+						goto l336
 					}
 					position++
 				}
-			l298:
+			l352:
 				{
-					position300, tokenIndex300, depth300 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l301
+					position354, tokenIndex354, depth354 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l355
 					}
 					position++
-					goto l300
-				l301:
-					position, tokenIndex, depth = position300, tokenIndex300, depth300
-					if buffer[position] != rune('R') {
-						goto l280
+					goto l354
+				l355:
+					position, tokenIndex, depth = position354, tokenIndex354, depth354
+					if buffer[position] != rune('A') {
+						goto l336
 					}
 					position++
 				}
-			l300:
+			l354:
 				{
-					position302, tokenIndex302, depth302 := position, tokenIndex, depth
-					if buffer[position] != rune('c') {
-						goto l303
+					position356, tokenIndex356, depth356 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l357
 					}
 					position++
-					goto l302
-				l303:
-					position, tokenIndex, depth = position302, tokenIndex302, depth302
-					if buffer[position] != rune('C') {
-						goto l280
+					goto l356
+				l357:
+					position, tokenIndex, depth = position356, tokenIndex356, depth356
+					if buffer[position] != rune('T') {
+						goto l336
 					}
 					position++
 				}
-			l302:
+			l356:
 				{
-					position304, tokenIndex304, depth304 := position, tokenIndex, depth
+					position358, tokenIndex358, depth358 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l305
+						goto l359
 					}
 					position++
-					goto l304
-				l305:
-					position, tokenIndex, depth = position304, tokenIndex304, depth304
+					goto l358
+				l359:
+					position, tokenIndex, depth = position358, tokenIndex358, depth358
 					if buffer[position] != rune('E') {
-						goto l280
+						goto l336
 					}
 					position++
 				}
-			l304:
+			l358:
 				if !_rules[rulesp]() {
-					goto l280
+					goto l336
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l280
+					goto l336
 				}
 				if !_rules[ruleUpdateSourceSinkSpecs]() {
-					goto l280
+					goto l336
 				}
-				if !_rules[ruleAction10]() {
-					goto l280
+				if !_rules[ruleAction11]() {
+					goto l336
 				}
 				depth--
-				add(ruleUpdateSourceStmt, position281)
+				add(ruleUpdateStateStmt, position337)
 			}
 			return true
-		l280:
-			position, tokenIndex, depth = position280, tokenIndex280, depth280
+		l336:
+			position, tokenIndex, depth = position336, tokenIndex336, depth336
 			return false
 		},
-		/* 17 UpdateSinkStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier UpdateSourceSinkSpecs Action11)> */
+		/* 21 UpdateSourceStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier UpdateSourceSinkSpecs Action12)> */
 		func() bool {
-			position306, tokenIndex306, depth306 := position, tokenIndex, depth
+			position360, tokenIndex360, depth360 := position, tokenIndex, depth
 			{
-				position307 := position
+				position361 := position
 				depth++
 				{
-					position308, tokenIndex308, depth308 := position, tokenIndex, depth
+					position362, tokenIndex362, depth362 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l309
+						goto l363
 					}
 					position++
-					goto l308
-				l309:
-					position, tokenIndex, depth = position308, tokenIndex308, depth308
+					goto l362
+				l363:
+					position, tokenIndex, depth = position362, tokenIndex362, depth362
 					if buffer[position] != rune('U') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l308:
+			l362:
 				{
-					position310, tokenIndex310, depth310 := position, tokenIndex, depth
+					position364, tokenIndex364, depth364 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l311
+						goto l365
 					}
 					position++
-					goto l310
-				l311:
-					position, tokenIndex, depth = position310, tokenIndex310, depth310
+					goto l364
+				l365:
+					position, tokenIndex, depth = position364, tokenIndex364, depth364
 					if buffer[position] != rune('P') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l310:
+			l364:
 				{
-					position312, tokenIndex312, depth312 := position, tokenIndex, depth
+					position366, tokenIndex366, depth366 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l313
+						goto l367
 					}
 					position++
-					goto l312
-				l313:
-					position, tokenIndex, depth = position312, tokenIndex312, depth312
+					goto l366
+				l367:
+					position, tokenIndex, depth = position366, tokenIndex366, depth366
 					if buffer[position] != rune('D') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l312:
+			l366:
 				{
-					position314, tokenIndex314, depth314 := position, tokenIndex, depth
+					position368, tokenIndex368, depth368 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l315
+						goto l369
 					}
 					position++
-					goto l314
-				l315:
-					position, tokenIndex, depth = position314, tokenIndex314, depth314
+					goto l368
+				l369:
+					position, tokenIndex, depth = position368, tokenIndex368, depth368
 					if buffer[position] != rune('A') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l314:
+			l368:
 				{
-					position316, tokenIndex316, depth316 := position, tokenIndex, depth
+					position370, tokenIndex370, depth370 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l317
+						goto l371
 					}
 					position++
-					goto l316
-				l317:
-					position, tokenIndex, depth = position316, tokenIndex316, depth316
+					goto l370
+				l371:
+					position, tokenIndex, depth = position370, tokenIndex370, depth370
 					if buffer[position] != rune('T') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l316:
+			l370:
 				{
-					position318, tokenIndex318, depth318 := position, tokenIndex, depth
+					position372, tokenIndex372, depth372 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l319
+						goto l373
 					}
 					position++
-					goto l318
-				l319:
-					position, tokenIndex, depth = position318, tokenIndex318, depth318
+					goto l372
+				l373:
+					position, tokenIndex, depth = position372, tokenIndex372, depth372
 					if buffer[position] != rune('E') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l318:
+			l372:
 				if !_rules[rulesp]() {
-					goto l306
+					goto l360
 				}
 				{
-					position320, tokenIndex320, depth320 := position, tokenIndex, depth
+					position374, tokenIndex374, depth374 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l321
+						goto l375
 					}
 					position++
-					goto l320
-				l321:
-					position, tokenIndex, depth = position320, tokenIndex320, depth320
+					goto l374
+				l375:
+					position, tokenIndex, depth = position374, tokenIndex374, depth374
 					if buffer[position] != rune('S') {
-						goto l306
+						goto l360
 					}
 					position++
 				}
-			l320:
+			l374:
 				{
-					position322, tokenIndex322, depth322 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l323
+					position376, tokenIndex376, depth376 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l377
 					}
 					position++
-					goto l322
-				l323:
-					position, tokenIndex, depth = position322, tokenIndex322, depth322
-					if buffer[position] != rune('I') {
-						goto l306
+					goto l376
+				l377:
+					position, tokenIndex, depth = position376, tokenIndex376, depth376
+					if buffer[position] != rune('O') {
+						goto l360
 					}
 					position++
 				}
-			l322:
+			l376:
 				{
-					position324, tokenIndex324, depth324 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l325
+					position378, tokenIndex378, depth378 := position, tokenIndex, depth
+					if buffer[position] != rune('u') {
+						goto l379
 					}
 					position++
-					goto l324
-				l325:
-					position, tokenIndex, depth = position324, tokenIndex324, depth324
-					if buffer[position] != rune('N') {
-						goto l306
+					goto l378
+				l379:
+					position, tokenIndex, depth = position378, tokenIndex378, depth378
+					if buffer[position] != rune('U') {
+						goto l360
 					}
 					position++
 				}
-			l324:
+			l378:
 				{
-					position326, tokenIndex326, depth326 := position, tokenIndex, depth
-					if buffer[position] != rune('k') {
-						goto l327
+					position380, tokenIndex380, depth380 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l381
 					}
 					position++
-					goto l326
-				l327:
-					position, tokenIndex, depth = position326, tokenIndex326, depth326
-					if buffer[position] != rune('K') {
-						goto l306
+					goto l380
+				l381:
+					position, tokenIndex, depth = position380, tokenIndex380, depth380
+					if buffer[position] != rune('R') {
+						goto l360
 					}
 					position++
 				}
-			l326:
+			l380:
+				{
+					position382, tokenIndex382, depth382 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l383
+					}
+					position++
+					goto l382
+				l383:
+					position, tokenIndex, depth = position382, tokenIndex382, depth382
+					if buffer[position] != rune('C') {
+						goto l360
+					}
+					position++
+				}
+			l382:
+				{
+					position384, tokenIndex384, depth384 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l385
+					}
+					position++
+					goto l384
+				l385:
+					position, tokenIndex, depth = position384, tokenIndex384, depth384
+					if buffer[position] != rune('E') {
+						goto l360
+					}
+					position++
+				}
+			l384:
 				if !_rules[rulesp]() {
-					goto l306
+					goto l360
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l306
+					goto l360
 				}
 				if !_rules[ruleUpdateSourceSinkSpecs]() {
-					goto l306
+					goto l360
 				}
-				if !_rules[ruleAction11]() {
-					goto l306
+				if !_rules[ruleAction12]() {
+					goto l360
 				}
 				depth--
-				add(ruleUpdateSinkStmt, position307)
+				add(ruleUpdateSourceStmt, position361)
 			}
 			return true
-		l306:
-			position, tokenIndex, depth = position306, tokenIndex306, depth306
+		l360:
+			position, tokenIndex, depth = position360, tokenIndex360, depth360
 			return false
 		},
-		/* 18 InsertIntoFromStmt <- <(('i' / 'I') ('n' / 'N') ('s' / 'S') ('e' / 'E') ('r' / 'R') ('t' / 'T') sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp StreamIdentifier sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp StreamIdentifier Action12)> */
+		/* 22 UpdateSinkStmt <- <(('u' / 'U') ('p' / 'P') ('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier UpdateSourceSinkSpecs Action13)> */
 		func() bool {
-			position328, tokenIndex328, depth328 := position, tokenIndex, depth
+			position386, tokenIndex386, depth386 := position, tokenIndex, depth
 			{
-				position329 := position
+				position387 := position
 				depth++
 				{
-					position330, tokenIndex330, depth330 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l331
+					position388, tokenIndex388, depth388 := position, tokenIndex, depth
+					if buffer[position] != rune('u') {
+						goto l389
 					}
 					position++
-					goto l330
-				l331:
-					position, tokenIndex, depth = position330, tokenIndex330, depth330
-					if buffer[position] != rune('I') {
-						goto l328
+					goto l388
+				l389:
+					position, tokenIndex, depth = position388, tokenIndex388, depth388
+					if buffer[position] != rune('U') {
+						goto l386
 					}
 					position++
 				}
-			l330:
+			l388:
 				{
-					position332, tokenIndex332, depth332 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l333
+					position390, tokenIndex390, depth390 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l391
 					}
 					position++
-					goto l332
-				l333:
-					position, tokenIndex, depth = position332, tokenIndex332, depth332
-					if buffer[position] != rune('N') {
-						goto l328
+					goto l390
+				l391:
+					position, tokenIndex, depth = position390, tokenIndex390, depth390
+					if buffer[position] != rune('P') {
+						goto l386
 					}
 					position++
 				}
-			l332:
+			l390:
 				{
-					position334, tokenIndex334, depth334 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l335
+					position392, tokenIndex392, depth392 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l393
 					}
 					position++
-					goto l334
-				l335:
-					position, tokenIndex, depth = position334, tokenIndex334, depth334
-					if buffer[position] != rune('S') {
-						goto l328
+					goto l392
+				l393:
+					position, tokenIndex, depth = position392, tokenIndex392, depth392
+					if buffer[position] != rune('D') {
+						goto l386
 					}
 					position++
 				}
-			l334:
+			l392:
 				{
-					position336, tokenIndex336, depth336 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l337
+					position394, tokenIndex394, depth394 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l395
 					}
 					position++
-					goto l336
-				l337:
-					position, tokenIndex, depth = position336, tokenIndex336, depth336
-					if buffer[position] != rune('E') {
-						goto l328
+					goto l394
+				l395:
+					position, tokenIndex, depth = position394, tokenIndex394, depth394
+					if buffer[position] != rune('A') {
+						goto l386
 					}
 					position++
 				}
-			l336:
+			l394:
 				{
-					position338, tokenIndex338, depth338 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l339
+					position396, tokenIndex396, depth396 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l397
 					}
 					position++
-					goto l338
-				l339:
-					position, tokenIndex, depth = position338, tokenIndex338, depth338
-					if buffer[position] != rune('R') {
-						goto l328
+					goto l396
+				l397:
+					position, tokenIndex, depth = position396, tokenIndex396, depth396
+					if buffer[position] != rune('T') {
+						goto l386
 					}
 					position++
 				}
-			l338:
+			l396:
 				{
-					position340, tokenIndex340, depth340 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l341
+					position398, tokenIndex398, depth398 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l399
 					}
 					position++
-					goto l340
-				l341:
-					position, tokenIndex, depth = position340, tokenIndex340, depth340
-					if buffer[position] != rune('T') {
-						goto l328
+					goto l398
+				l399:
+					position, tokenIndex, depth = position398, tokenIndex398, depth398
+					if buffer[position] != rune('E') {
+						goto l386
 					}
 					position++
 				}
-			l340:
+			l398:
 				if !_rules[rulesp]() {
-					goto l328
+					goto l386
 				}
 				{
-					position342, tokenIndex342, depth342 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l343
+					position400, tokenIndex400, depth400 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l401
 					}
 					position++
-					goto l342
-				l343:
-					position, tokenIndex, depth = position342, tokenIndex342, depth342
-					if buffer[position] != rune('I') {
-						goto l328
+					goto l400
+				l401:
+					position, tokenIndex, depth = position400, tokenIndex400, depth400
+					if buffer[position] != rune('S') {
+						goto l386
 					}
 					position++
 				}
-			l342:
+			l400:
 				{
-					position344, tokenIndex344, depth344 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l345
+					position402, tokenIndex402, depth402 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l403
 					}
 					position++
-					goto l344
-				l345:
-					position, tokenIndex, depth = position344, tokenIndex344, depth344
-					if buffer[position] != rune('N') {
-						goto l328
+					goto l402
+				l403:
+					position, tokenIndex, depth = position402, tokenIndex402, depth402
+					if buffer[position] != rune('I') {
+						goto l386
 					}
 					position++
 				}
-			l344:
+			l402:
 				{
-					position346, tokenIndex346, depth346 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l347
+					position404, tokenIndex404, depth404 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l405
 					}
 					position++
-					goto l346
-				l347:
-					position, tokenIndex, depth = position346, tokenIndex346, depth346
-					if buffer[position] != rune('T') {
-						goto l328
+					goto l404
+				l405:
+					position, tokenIndex, depth = position404, tokenIndex404, depth404
+					if buffer[position] != rune('N') {
+						goto l386
 					}
 					position++
 				}
-			l346:
+			l404:
 				{
-					position348, tokenIndex348, depth348 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l349
+					position406, tokenIndex406, depth406 := position, tokenIndex, depth
+					if buffer[position] != rune('k') {
+						goto l407
 					}
 					position++
-					goto l348
-				l349:
-					position, tokenIndex, depth = position348, tokenIndex348, depth348
-					if buffer[position] != rune('O') {
-						goto l328
+					goto l406
+				l407:
+					position, tokenIndex, depth = position406, tokenIndex406, depth406
+					if buffer[position] != rune('K') {
+						goto l386
 					}
 					position++
 				}
-			l348:
+			l406:
 				if !_rules[rulesp]() {
-					goto l328
+					goto l386
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l328
+					goto l386
 				}
-				if !_rules[rulesp]() {
-					goto l328
+				if !_rules[ruleUpdateSourceSinkSpecs]() {
+					goto l386
+				}
+				if !_rules[ruleAction13]() {
+					goto l386
 				}
+				depth--
+				add(ruleUpdateSinkStmt, position387)
+			}
+			return true
+		l386:
+			position, tokenIndex, depth = position386, tokenIndex386, depth386
+			return false
+		},
+		/* 23 InsertIntoFromStmt <- <(('i' / 'I') ('n' / 'N') ('s' / 'S') ('e' / 'E') ('r' / 'R') ('t' / 'T') sp (('i' / 'I') ('n' / 'N') ('t' / 'T') ('o' / 'O')) sp StreamIdentifier sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp StreamIdentifier Action14)> */
+		func() bool {
+			position408, tokenIndex408, depth408 := position, tokenIndex, depth
+			{
+				position409 := position
+				depth++
 				{
-					position350, tokenIndex350, depth350 := position, tokenIndex, depth
-					if buffer[position] != rune('f') {
-						goto l351
+					position410, tokenIndex410, depth410 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l411
 					}
 					position++
-					goto l350
-				l351:
-					position, tokenIndex, depth = position350, tokenIndex350, depth350
-					if buffer[position] != rune('F') {
-						goto l328
+					goto l410
+				l411:
+					position, tokenIndex, depth = position410, tokenIndex410, depth410
+					if buffer[position] != rune('I') {
+						goto l408
 					}
 					position++
 				}
-			l350:
+			l410:
 				{
-					position352, tokenIndex352, depth352 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l353
+					position412, tokenIndex412, depth412 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l413
 					}
 					position++
-					goto l352
-				l353:
-					position, tokenIndex, depth = position352, tokenIndex352, depth352
-					if buffer[position] != rune('R') {
-						goto l328
+					goto l412
+				l413:
+					position, tokenIndex, depth = position412, tokenIndex412, depth412
+					if buffer[position] != rune('N') {
+						goto l408
 					}
 					position++
 				}
-			l352:
+			l412:
 				{
-					position354, tokenIndex354, depth354 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l355
+					position414, tokenIndex414, depth414 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l415
 					}
 					position++
-					goto l354
-				l355:
-					position, tokenIndex, depth = position354, tokenIndex354, depth354
-					if buffer[position] != rune('O') {
-						goto l328
+					goto l414
+				l415:
+					position, tokenIndex, depth = position414, tokenIndex414, depth414
+					if buffer[position] != rune('S') {
+						goto l408
 					}
 					position++
 				}
-			l354:
+			l414:
 				{
-					position356, tokenIndex356, depth356 := position, tokenIndex, depth
-					if buffer[position] != rune('m') {
-						goto l357
+					position416, tokenIndex416, depth416 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l417
 					}
 					position++
-					goto l356
-				l357:
-					position, tokenIndex, depth = position356, tokenIndex356, depth356
-					if buffer[position] != rune('M') {
-						goto l328
+					goto l416
+				l417:
+					position, tokenIndex, depth = position416, tokenIndex416, depth416
+					if buffer[position] != rune('E') {
+						goto l408
 					}
 					position++
 				}
-			l356:
-				if !_rules[rulesp]() {
-					goto l328
-				}
-				if !_rules[ruleStreamIdentifier]() {
-					goto l328
-				}
-				if !_rules[ruleAction12]() {
-					goto l328
-				}
-				depth--
-				add(ruleInsertIntoFromStmt, position329)
-			}
-			return true
-		l328:
-			position, tokenIndex, depth = position328, tokenIndex328, depth328
-			return false
-		},
-		/* 19 PauseSourceStmt <- <(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action13)> */
-		func() bool {
-			position358, tokenIndex358, depth358 := position, tokenIndex, depth
-			{
-				position359 := position
-				depth++
+			l416:
 				{
-					position360, tokenIndex360, depth360 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l361
+					position418, tokenIndex418, depth418 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l419
 					}
 					position++
-					goto l360
-				l361:
-					position, tokenIndex, depth = position360, tokenIndex360, depth360
-					if buffer[position] != rune('P') {
-						goto l358
+					goto l418
+				l419:
+					position, tokenIndex, depth = position418, tokenIndex418, depth418
+					if buffer[position] != rune('R') {
+						goto l408
 					}
 					position++
 				}
-			l360:
+			l418:
 				{
-					position362, tokenIndex362, depth362 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l363
+					position420, tokenIndex420, depth420 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l421
 					}
 					position++
-					goto l362
-				l363:
-					position, tokenIndex, depth = position362, tokenIndex362, depth362
-					if buffer[position] != rune('A') {
-						goto l358
+					goto l420
+				l421:
+					position, tokenIndex, depth = position420, tokenIndex420, depth420
+					if buffer[position] != rune('T') {
+						goto l408
 					}
 					position++
 				}
-			l362:
+			l420:
+				if !_rules[rulesp]() {
+					goto l408
+				}
 				{
-					position364, tokenIndex364, depth364 := position, tokenIndex, depth
-					if buffer[position] != rune('u') {
-						goto l365
+					position422, tokenIndex422, depth422 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l423
 					}
 					position++
-					goto l364
-				l365:
-					position, tokenIndex, depth = position364, tokenIndex364, depth364
-					if buffer[position] != rune('U') {
-						goto l358
+					goto l422
+				l423:
+					position, tokenIndex, depth = position422, tokenIndex422, depth422
+					if buffer[position] != rune('I') {
+						goto l408
 					}
 					position++
 				}
-			l364:
+			l422:
 				{
-					position366, tokenIndex366, depth366 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l367
+					position424, tokenIndex424, depth424 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l425
 					}
 					position++
-					goto l366
-				l367:
-					position, tokenIndex, depth = position366, tokenIndex366, depth366
-					if buffer[position] != rune('S') {
-						goto l358
+					goto l424
+				l425:
+					position, tokenIndex, depth = position424, tokenIndex424, depth424
+					if buffer[position] != rune('N') {
+						goto l408
 					}
 					position++
 				}
-			l366:
+			l424:
 				{
-					position368, tokenIndex368, depth368 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l369
+					position426, tokenIndex426, depth426 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l427
 					}
 					position++
-					goto l368
-				l369:
-					position, tokenIndex, depth = position368, tokenIndex368, depth368
-					if buffer[position] != rune('E') {
-						goto l358
+					goto l426
+				l427:
+					position, tokenIndex, depth = position426, tokenIndex426, depth426
+					if buffer[position] != rune('T') {
+						goto l408
 					}
 					position++
 				}
-			l368:
-				if !_rules[rulesp]() {
-					goto l358
-				}
+			l426:
 				{
-					position370, tokenIndex370, depth370 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l371
-					}
-					position++
-					goto l370
-				l371:
-					position, tokenIndex, depth = position370, tokenIndex370, depth370
-					if buffer[position] != rune('S') {
-						goto l358
-					}
-					position++
-				}
-			l370:
-				{
-					position372, tokenIndex372, depth372 := position, tokenIndex, depth
+					position428, tokenIndex428, depth428 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l373
+						goto l429
 					}
 					position++
-					goto l372
-				l373:
-					position, tokenIndex, depth = position372, tokenIndex372, depth372
+					goto l428
+				l429:
+					position, tokenIndex, depth = position428, tokenIndex428, depth428
 					if buffer[position] != rune('O') {
-						goto l358
+						goto l408
 					}
 					position++
 				}
-			l372:
+			l428:
+				if !_rules[rulesp]() {
+					goto l408
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l408
+				}
+				if !_rules[rulesp]() {
+					goto l408
+				}
 				{
-					position374, tokenIndex374, depth374 := position, tokenIndex, depth
-					if buffer[position] != rune('u') {
-						goto l375
+					position430, tokenIndex430, depth430 := position, tokenIndex, depth
+					if buffer[position] != rune('f') {
+						goto l431
 					}
 					position++
-					goto l374
-				l375:
-					position, tokenIndex, depth = position374, tokenIndex374, depth374
-					if buffer[position] != rune('U') {
-						goto l358
+					goto l430
+				l431:
+					position, tokenIndex, depth = position430, tokenIndex430, depth430
+					if buffer[position] != rune('F') {
+						goto l408
 					}
 					position++
 				}
-			l374:
+			l430:
 				{
-					position376, tokenIndex376, depth376 := position, tokenIndex, depth
+					position432, tokenIndex432, depth432 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l377
+						goto l433
 					}
 					position++
-					goto l376
-				l377:
-					position, tokenIndex, depth = position376, tokenIndex376, depth376
+					goto l432
+				l433:
+					position, tokenIndex, depth = position432, tokenIndex432, depth432
 					if buffer[position] != rune('R') {
-						goto l358
+						goto l408
 					}
 					position++
 				}
-			l376:
+			l432:
 				{
-					position378, tokenIndex378, depth378 := position, tokenIndex, depth
-					if buffer[position] != rune('c') {
-						goto l379
+					position434, tokenIndex434, depth434 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l435
 					}
 					position++
-					goto l378
-				l379:
-					position, tokenIndex, depth = position378, tokenIndex378, depth378
-					if buffer[position] != rune('C') {
-						goto l358
+					goto l434
+				l435:
+					position, tokenIndex, depth = position434, tokenIndex434, depth434
+					if buffer[position] != rune('O') {
+						goto l408
 					}
 					position++
 				}
-			l378:
+			l434:
 				{
-					position380, tokenIndex380, depth380 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l381
+					position436, tokenIndex436, depth436 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l437
 					}
 					position++
-					goto l380
-				l381:
-					position, tokenIndex, depth = position380, tokenIndex380, depth380
-					if buffer[position] != rune('E') {
-						goto l358
+					goto l436
+				l437:
+					position, tokenIndex, depth = position436, tokenIndex436, depth436
+					if buffer[position] != rune('M') {
+						goto l408
 					}
 					position++
 				}
-			l380:
+			l436:
 				if !_rules[rulesp]() {
-					goto l358
+					goto l408
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l358
+					goto l408
 				}
-				if !_rules[ruleAction13]() {
-					goto l358
+				if !_rules[ruleAction14]() {
+					goto l408
 				}
 				depth--
-				add(rulePauseSourceStmt, position359)
+				add(ruleInsertIntoFromStmt, position409)
 			}
 			return true
-		l358:
-			position, tokenIndex, depth = position358, tokenIndex358, depth358
+		l408:
+			position, tokenIndex, depth = position408, tokenIndex408, depth408
 			return false
 		},
-		/* 20 ResumeSourceStmt <- <(('r' / 'R') ('e' / 'E') ('s' / 'S') ('u' / 'U') ('m' / 'M') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action14)> */
+		/* 24 PauseSourceStmt <- <(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action15)> */
 		func() bool {
-			position382, tokenIndex382, depth382 := position, tokenIndex, depth
+			position438, tokenIndex438, depth438 := position, tokenIndex, depth
 			{
-				position383 := position
+				position439 := position
 				depth++
 				{
-					position384, tokenIndex384, depth384 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l385
-					}
-					position++
-					goto l384
-				l385:
-					position, tokenIndex, depth = position384, tokenIndex384, depth384
-					if buffer[position] != rune('R') {
-						goto l382
-					}
-					position++
-				}
-			l384:
-				{
-					position386, tokenIndex386, depth386 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l387
+					position440, tokenIndex440, depth440 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l441
 					}
 					position++
-					goto l386
-				l387:
-					position, tokenIndex, depth = position386, tokenIndex386, depth386
-					if buffer[position] != rune('E') {
-						goto l382
+					goto l440
+				l441:
+					position, tokenIndex, depth = position440, tokenIndex440, depth440
+					if buffer[position] != rune('P') {
+						goto l438
 					}
 					position++
 				}
-			l386:
+			l440:
 				{
-					position388, tokenIndex388, depth388 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l389
+					position442, tokenIndex442, depth442 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l443
 					}
 					position++
-					goto l388
-				l389:
-					position, tokenIndex, depth = position388, tokenIndex388, depth388
-					if buffer[position] != rune('S') {
-						goto l382
+					goto l442
+				l443:
+					position, tokenIndex, depth = position442, tokenIndex442, depth442
+					if buffer[position] != rune('A') {
+						goto l438
 					}
 					position++
 				}
-			l388:
+			l442:
 				{
-					position390, tokenIndex390, depth390 := position, tokenIndex, depth
+					position444, tokenIndex444, depth444 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l391
+						goto l445
 					}
 					position++
-					goto l390
-				l391:
-					position, tokenIndex, depth = position390, tokenIndex390, depth390
+					goto l444
+				l445:
+					position, tokenIndex, depth = position444, tokenIndex444, depth444
 					if buffer[position] != rune('U') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l390:
+			l444:
 				{
-					position392, tokenIndex392, depth392 := position, tokenIndex, depth
-					if buffer[position] != rune('m') {
-						goto l393
+					position446, tokenIndex446, depth446 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l447
 					}
 					position++
-					goto l392
-				l393:
-					position, tokenIndex, depth = position392, tokenIndex392, depth392
-					if buffer[position] != rune('M') {
-						goto l382
+					goto l446
+				l447:
+					position, tokenIndex, depth = position446, tokenIndex446, depth446
+					if buffer[position] != rune('S') {
+						goto l438
 					}
 					position++
 				}
-			l392:
+			l446:
 				{
-					position394, tokenIndex394, depth394 := position, tokenIndex, depth
+					position448, tokenIndex448, depth448 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l395
+						goto l449
 					}
 					position++
-					goto l394
-				l395:
-					position, tokenIndex, depth = position394, tokenIndex394, depth394
+					goto l448
+				l449:
+					position, tokenIndex, depth = position448, tokenIndex448, depth448
 					if buffer[position] != rune('E') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l394:
+			l448:
 				if !_rules[rulesp]() {
-					goto l382
+					goto l438
 				}
 				{
-					position396, tokenIndex396, depth396 := position, tokenIndex, depth
+					position450, tokenIndex450, depth450 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l397
+						goto l451
 					}
 					position++
-					goto l396
-				l397:
-					position, tokenIndex, depth = position396, tokenIndex396, depth396
+					goto l450
+				l451:
+					position, tokenIndex, depth = position450, tokenIndex450, depth450
 					if buffer[position] != rune('S') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l396:
+			l450:
 				{
-					position398, tokenIndex398, depth398 := position, tokenIndex, depth
+					position452, tokenIndex452, depth452 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l399
+						goto l453
 					}
 					position++
-					goto l398
-				l399:
-					position, tokenIndex, depth = position398, tokenIndex398, depth398
+					goto l452
+				l453:
+					position, tokenIndex, depth = position452, tokenIndex452, depth452
 					if buffer[position] != rune('O') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l398:
+			l452:
 				{
-					position400, tokenIndex400, depth400 := position, tokenIndex, depth
+					position454, tokenIndex454, depth454 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l401
+						goto l455
 					}
 					position++
-					goto l400
-				l401:
-					position, tokenIndex, depth = position400, tokenIndex400, depth400
+					goto l454
+				l455:
+					position, tokenIndex, depth = position454, tokenIndex454, depth454
 					if buffer[position] != rune('U') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l400:
+			l454:
 				{
-					position402, tokenIndex402, depth402 := position, tokenIndex, depth
+					position456, tokenIndex456, depth456 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l403
+						goto l457
 					}
 					position++
-					goto l402
-				l403:
-					position, tokenIndex, depth = position402, tokenIndex402, depth402
+					goto l456
+				l457:
+					position, tokenIndex, depth = position456, tokenIndex456, depth456
 					if buffer[position] != rune('R') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l402:
+			l456:
 				{
-					position404, tokenIndex404, depth404 := position, tokenIndex, depth
+					position458, tokenIndex458, depth458 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l405
+						goto l459
 					}
 					position++
-					goto l404
-				l405:
-					position, tokenIndex, depth = position404, tokenIndex404, depth404
+					goto l458
+				l459:
+					position, tokenIndex, depth = position458, tokenIndex458, depth458
 					if buffer[position] != rune('C') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l404:
+			l458:
 				{
-					position406, tokenIndex406, depth406 := position, tokenIndex, depth
+					position460, tokenIndex460, depth460 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l407
+						goto l461
 					}
 					position++
-					goto l406
-				l407:
-					position, tokenIndex, depth = position406, tokenIndex406, depth406
+					goto l460
+				l461:
+					position, tokenIndex, depth = position460, tokenIndex460, depth460
 					if buffer[position] != rune('E') {
-						goto l382
+						goto l438
 					}
 					position++
 				}
-			l406:
+			l460:
 				if !_rules[rulesp]() {
-					goto l382
+					goto l438
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l382
+					goto l438
 				}
-				if !_rules[ruleAction14]() {
-					goto l382
+				if !_rules[ruleAction15]() {
+					goto l438
 				}
 				depth--
-				add(ruleResumeSourceStmt, position383)
+				add(rulePauseSourceStmt, position439)
 			}
 			return true
-		l382:
-			position, tokenIndex, depth = position382, tokenIndex382, depth382
+		l438:
+			position, tokenIndex, depth = position438, tokenIndex438, depth438
 			return false
 		},
-		/* 21 RewindSourceStmt <- <(('r' / 'R') ('e' / 'E') ('w' / 'W') ('i' / 'I') ('n' / 'N') ('d' / 'D') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action15)> */
+		/* 25 ResumeSourceStmt <- <(('r' / 'R') ('e' / 'E') ('s' / 'S') ('u' / 'U') ('m' / 'M') ('e' / 'E') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action16)> */
 		func() bool {
-			position408, tokenIndex408, depth408 := position, tokenIndex, depth
+			position462, tokenIndex462, depth462 := position, tokenIndex, depth
 			{
-				position409 := position
+				position463 := position
 				depth++
 				{
-					position410, tokenIndex410, depth410 := position, tokenIndex, depth
+					position464, tokenIndex464, depth464 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l411
+						goto l465
 					}
 					position++
-					goto l410
-				l411:
-					position, tokenIndex, depth = position410, tokenIndex410, depth410
+					goto l464
+				l465:
+					position, tokenIndex, depth = position464, tokenIndex464, depth464
 					if buffer[position] != rune('R') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l410:
+			l464:
 				{
-					position412, tokenIndex412, depth412 := position, tokenIndex, depth
+					position466, tokenIndex466, depth466 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l413
+						goto l467
 					}
 					position++
-					goto l412
-				l413:
-					position, tokenIndex, depth = position412, tokenIndex412, depth412
+					goto l466
+				l467:
+					position, tokenIndex, depth = position466, tokenIndex466, depth466
 					if buffer[position] != rune('E') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l412:
+			l466:
 				{
-					position414, tokenIndex414, depth414 := position, tokenIndex, depth
-					if buffer[position] != rune('w') {
-						goto l415
+					position468, tokenIndex468, depth468 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l469
 					}
 					position++
-					goto l414
-				l415:
-					position, tokenIndex, depth = position414, tokenIndex414, depth414
-					if buffer[position] != rune('W') {
-						goto l408
+					goto l468
+				l469:
+					position, tokenIndex, depth = position468, tokenIndex468, depth468
+					if buffer[position] != rune('S') {
+						goto l462
 					}
 					position++
 				}
-			l414:
+			l468:
 				{
-					position416, tokenIndex416, depth416 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l417
+					position470, tokenIndex470, depth470 := position, tokenIndex, depth
+					if buffer[position] != rune('u') {
+						goto l471
 					}
 					position++
-					goto l416
-				l417:
-					position, tokenIndex, depth = position416, tokenIndex416, depth416
-					if buffer[position] != rune('I') {
-						goto l408
+					goto l470
+				l471:
+					position, tokenIndex, depth = position470, tokenIndex470, depth470
+					if buffer[position] != rune('U') {
+						goto l462
 					}
 					position++
 				}
-			l416:
+			l470:
 				{
-					position418, tokenIndex418, depth418 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l419
+					position472, tokenIndex472, depth472 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l473
 					}
 					position++
-					goto l418
-				l419:
-					position, tokenIndex, depth = position418, tokenIndex418, depth418
-					if buffer[position] != rune('N') {
-						goto l408
+					goto l472
+				l473:
+					position, tokenIndex, depth = position472, tokenIndex472, depth472
+					if buffer[position] != rune('M') {
+						goto l462
 					}
 					position++
 				}
-			l418:
+			l472:
 				{
-					position420, tokenIndex420, depth420 := position, tokenIndex, depth
-					if buffer[position] != rune('d') {
-						goto l421
+					position474, tokenIndex474, depth474 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l475
 					}
 					position++
-					goto l420
-				l421:
-					position, tokenIndex, depth = position420, tokenIndex420, depth420
-					if buffer[position] != rune('D') {
-						goto l408
+					goto l474
+				l475:
+					position, tokenIndex, depth = position474, tokenIndex474, depth474
+					if buffer[position] != rune('E') {
+						goto l462
 					}
 					position++
 				}
-			l420:
+			l474:
 				if !_rules[rulesp]() {
-					goto l408
+					goto l462
 				}
 				{
-					position422, tokenIndex422, depth422 := position, tokenIndex, depth
+					position476, tokenIndex476, depth476 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l423
+						goto l477
 					}
 					position++
-					goto l422
-				l423:
-					position, tokenIndex, depth = position422, tokenIndex422, depth422
+					goto l476
+				l477:
+					position, tokenIndex, depth = position476, tokenIndex476, depth476
 					if buffer[position] != rune('S') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l422:
+			l476:
 				{
-					position424, tokenIndex424, depth424 := position, tokenIndex, depth
+					position478, tokenIndex478, depth478 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l425
+						goto l479
 					}
 					position++
-					goto l424
-				l425:
-					position, tokenIndex, depth = position424, tokenIndex424, depth424
+					goto l478
+				l479:
+					position, tokenIndex, depth = position478, tokenIndex478, depth478
 					if buffer[position] != rune('O') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l424:
+			l478:
 				{
-					position426, tokenIndex426, depth426 := position, tokenIndex, depth
+					position480, tokenIndex480, depth480 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l427
+						goto l481
 					}
 					position++
-					goto l426
-				l427:
-					position, tokenIndex, depth = position426, tokenIndex426, depth426
+					goto l480
+				l481:
+					position, tokenIndex, depth = position480, tokenIndex480, depth480
 					if buffer[position] != rune('U') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l426:
+			l480:
 				{
-					position428, tokenIndex428, depth428 := position, tokenIndex, depth
+					position482, tokenIndex482, depth482 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l429
+						goto l483
 					}
 					position++
-					goto l428
-				l429:
-					position, tokenIndex, depth = position428, tokenIndex428, depth428
+					goto l482
+				l483:
+					position, tokenIndex, depth = position482, tokenIndex482, depth482
 					if buffer[position] != rune('R') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l428:
+			l482:
 				{
-					position430, tokenIndex430, depth430 := position, tokenIndex, depth
+					position484, tokenIndex484, depth484 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l431
+						goto l485
 					}
 					position++
-					goto l430
-				l431:
-					position, tokenIndex, depth = position430, tokenIndex430, depth430
+					goto l484
+				l485:
+					position, tokenIndex, depth = position484, tokenIndex484, depth484
 					if buffer[position] != rune('C') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l430:
+			l484:
 				{
-					position432, tokenIndex432, depth432 := position, tokenIndex, depth
+					position486, tokenIndex486, depth486 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l433
+						goto l487
 					}
 					position++
-					goto l432
-				l433:
-					position, tokenIndex, depth = position432, tokenIndex432, depth432
+					goto l486
+				l487:
+					position, tokenIndex, depth = position486, tokenIndex486, depth486
 					if buffer[position] != rune('E') {
-						goto l408
+						goto l462
 					}
 					position++
 				}
-			l432:
+			l486:
 				if !_rules[rulesp]() {
-					goto l408
+					goto l462
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l408
+					goto l462
 				}
-				if !_rules[ruleAction15]() {
-					goto l408
+				if !_rules[ruleAction16]() {
+					goto l462
 				}
 				depth--
-				add(ruleRewindSourceStmt, position409)
+				add(ruleResumeSourceStmt, position463)
 			}
 			return true
-		l408:
-			position, tokenIndex, depth = position408, tokenIndex408, depth408
+		l462:
+			position, tokenIndex, depth = position462, tokenIndex462, depth462
 			return false
 		},
-		/* 22 DropSourceStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action16)> */
+		/* 26 RewindSourceStmt <- <(('r' / 'R') ('e' / 'E') ('w' / 'W') ('i' / 'I') ('n' / 'N') ('d' / 'D') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) sp StreamIdentifier Action17)> */
 		func() bool {
-			position434, tokenIndex434, depth434 := position, tokenIndex, depth
+			position488, tokenIndex488, depth488 := position, tokenIndex, depth
 			{
-				position435 := position
+				position489 := position
 				depth++
 				{
-					position436, tokenIndex436, depth436 := position, tokenIndex, depth
-					if buffer[position] != rune('d') {
-						goto l437
-					}
-					position++
-					goto l436
-				l437:
-					position, tokenIndex, depth = position436, tokenIndex436, depth436
-					if buffer[position] != rune('D') {
-						goto l434
-					}
-					position++
-				}
-			l436:
-				{
-					position438, tokenIndex438, depth438 := position, tokenIndex, depth
+					position490, tokenIndex490, depth490 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l439
+						goto l491
 					}
 					position++
-					goto l438
-				l439:
-					position, tokenIndex, depth = position438, tokenIndex438, depth438
+					goto l490
+				l491:
+					position, tokenIndex, depth = position490, tokenIndex490, depth490
 					if buffer[position] != rune('R') {
-						goto l434
+						goto l488
 					}
 					position++
 				}
-			l438:
+			l490:
 				{
-					position440, tokenIndex440, depth440 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l441
+					position492, tokenIndex492, depth492 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l493
 					}
 					position++
-					goto l440
-				l441:
-					position, tokenIndex, depth = position440, tokenIndex440, depth440
-					if buffer[position] != rune('O') {
-						goto l434
+					goto l492
+				l493:
+					position, tokenIndex, depth = position492, tokenIndex492, depth492
+					if buffer[position] != rune('E') {
+						goto l488
 					}
 					position++
 				}
-			l440:
+			l492:
 				{
-					position442, tokenIndex442, depth442 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l443
+					position494, tokenIndex494, depth494 := position, tokenIndex, depth
+					if buffer[position] != rune('w') {
+						goto l495
 					}
 					position++
-					goto l442
-				l443:
-					position, tokenIndex, depth = position442, tokenIndex442, depth442
-					if buffer[position] != rune('P') {
-						goto l434
+					goto l494
+				l495:
+					position, tokenIndex, depth = position494, tokenIndex494, depth494
+					if buffer[position] != rune('W') {
+						goto l488
 					}
 					position++
 				}
-			l442:
-				if !_rules[rulesp]() {
-					goto l434
-				}
+			l494:
 				{
-					position444, tokenIndex444, depth444 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l445
+					position496, tokenIndex496, depth496 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l497
 					}
 					position++
-					goto l444
-				l445:
-					position, tokenIndex, depth = position444, tokenIndex444, depth444
-					if buffer[position] != rune('S') {
-						goto l434
+					goto l496
+				l497:
+					position, tokenIndex, depth = position496, tokenIndex496, depth496
+					if buffer[position] != rune('I') {
+						goto l488
 					}
 					position++
 				}
-			l444:
+			l496:
 				{
-					position446, tokenIndex446, depth446 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l447
+					position498, tokenIndex498, depth498 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l499
 					}
 					position++
-					goto l446
-				l447:
-					position, tokenIndex, depth = position446, tokenIndex446, depth446
+					goto l498
+				l499:
+					position, tokenIndex, depth = position498, tokenIndex498, depth498
+					if buffer[position] != rune('N') {
+						goto l488
+					}
+					position++
+				}
+			l498:
+				{
+					position500, tokenIndex500, depth500 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l501
+					}
+					position++
+					goto l500
+				l501:
+					position, tokenIndex, depth = position500, tokenIndex500, depth500
+					if buffer[position] != rune('D') {
+						goto l488
+					}
+					position++
+				}
+			l500:
+				if !_rules[rulesp]() {
+					goto l488
+				}
+				{
+					position502, tokenIndex502, depth502 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l503
+					}
+					position++
+					goto l502
+				l503:
+					position, tokenIndex, depth = position502, tokenIndex502, depth502
+					if buffer[position] != rune('S') {
+						goto l488
+					}
+					position++
+				}
+			l502:
+				{
+					position504, tokenIndex504, depth504 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l505
+					}
+					position++
+					goto l504
+				l505:
+					position, tokenIndex, depth = position504, tokenIndex504, depth504
 					if buffer[position] != rune('O') {
-						goto l434
+						goto l488
 					}
 					position++
 				}
-			l446:
+			l504:
 				{
-					position448, tokenIndex448, depth448 := position, tokenIndex, depth
+					position506, tokenIndex506, depth506 := position, tokenIndex, depth
 					if buffer[position] != rune('u') {
-						goto l449
+						goto l507
 					}
 					position++
-					goto l448
-				l449:
-					position, tokenIndex, depth = position448, tokenIndex448, depth448
+					goto l506
+				l507:
+					position, tokenIndex, depth = position506, tokenIndex506, depth506
 					if buffer[position] != rune('U') {
-						goto l434
+						goto l488
 					}
 					position++
 				}
-			l448:
+			l506:
 				{
-					position450, tokenIndex450, depth450 := position, tokenIndex, depth
+					position508, tokenIndex508, depth508 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l451
+						goto l509
 					}
 					position++
-					goto l450
-				l451:
-					position, tokenIndex, depth = position450, tokenIndex450, depth450
+					goto l508
+				l509:
+					position, tokenIndex, depth = position508, tokenIndex508, depth508
 					if buffer[position] != rune('R') {
-						goto l434
+						goto l488
 					}
 					position++
 				}
-			l450:
+			l508:
 				{
-					position452, tokenIndex452, depth452 := position, tokenIndex, depth
+					position510, tokenIndex510, depth510 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l453
+						goto l511
 					}
 					position++
-					goto l452
-				l453:
-					position, tokenIndex, depth = position452, tokenIndex452, depth452
+					goto l510
+				l511:
+					position, tokenIndex, depth = position510, tokenIndex510, depth510
 					if buffer[position] != rune('C') {
-						goto l434
+						goto l488
 					}
 					position++
 				}
-			l452:
+			l510:
 				{
-					position454, tokenIndex454, depth454 := position, tokenIndex, depth
+					position512, tokenIndex512, depth512 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l455
+						goto l513
 					}
 					position++
-					goto l454
-				l455:
-					position, tokenIndex, depth = position454, tokenIndex454, depth454
+					goto l512
+				l513:
+					position, tokenIndex, depth = position512, tokenIndex512, depth512
 					if buffer[position] != rune('E') {
-						goto l434
+						goto l488
 					}
 					position++
 				}
-			l454:
+			l512:
 				if !_rules[rulesp]() {
-					goto l434
+					goto l488
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l434
+					goto l488
 				}
-				if !_rules[ruleAction16]() {
-					goto l434
+				if !_rules[ruleAction17]() {
+					goto l488
 				}
 				depth--
-				add(ruleDropSourceStmt, position435)
+				add(ruleRewindSourceStmt, position489)
 			}
 			return true
-		l434:
-			position, tokenIndex, depth = position434, tokenIndex434, depth434
+		l488:
+			position, tokenIndex, depth = position488, tokenIndex488, depth488
 			return false
 		},
-		/* 23 DropStreamStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) sp StreamIdentifier Action17)> */
+		/* 27 DropSourceStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E')) IfExistsOpt sp StreamIdentifier CascadeOpt Action18)> */
 		func() bool {
-			position456, tokenIndex456, depth456 := position, tokenIndex, depth
+			position514, tokenIndex514, depth514 := position, tokenIndex, depth
 			{
-				position457 := position
+				position515 := position
 				depth++
 				{
-					position458, tokenIndex458, depth458 := position, tokenIndex, depth
+					position516, tokenIndex516, depth516 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l459
+						goto l517
 					}
 					position++
-					goto l458
-				l459:
-					position, tokenIndex, depth = position458, tokenIndex458, depth458
+					goto l516
+				l517:
+					position, tokenIndex, depth = position516, tokenIndex516, depth516
 					if buffer[position] != rune('D') {
-						goto l456
+						goto l514
 					}
 					position++
 				}
-			l458:
+			l516:
 				{
-					position460, tokenIndex460, depth460 := position, tokenIndex, depth
+					position518, tokenIndex518, depth518 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l461
+						goto l519
 					}
 					position++
-					goto l460
-				l461:
-					position, tokenIndex, depth = position460, tokenIndex460, depth460
+					goto l518
+				l519:
+					position, tokenIndex, depth = position518, tokenIndex518, depth518
 					if buffer[position] != rune('R') {
-						goto l456
+						goto l514
 					}
 					position++
 				}
-			l460:
+			l518:
 				{
-					position462, tokenIndex462, depth462 := position, tokenIndex, depth
+					position520, tokenIndex520, depth520 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l463
+						goto l521
 					}
 					position++
-					goto l462
-				l463:
-					position, tokenIndex, depth = position462, tokenIndex462, depth462
+					goto l520
+				l521:
+					position, tokenIndex, depth = position520, tokenIndex520, depth520
 					if buffer[position] != rune('O') {
-						goto l456
+						goto l514
 					}
 					position++
 				}
-			l462:
+			l520:
 				{
-					position464, tokenIndex464, depth464 := position, tokenIndex, depth
+					position522, tokenIndex522, depth522 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l465
+						goto l523
 					}
 					position++
-					goto l464
-				l465:
-					position, tokenIndex, depth = position464, tokenIndex464, depth464
+					goto l522
+				l523:
+					position, tokenIndex, depth = position522, tokenIndex522, depth522
 					if buffer[position] != rune('P') {
-						goto l456
+						goto l514
 					}
 					position++
 				}
-			l464:
+			l522:
 				if !_rules[rulesp]() {
-					goto l456
+					goto l514
 				}
 				{
-					position466, tokenIndex466, depth466 := position, tokenIndex, depth
+					position524, tokenIndex524, depth524 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l467
+						goto l525
 					}
 					position++
-					goto l466
-				l467:
-					position, tokenIndex, depth = position466, tokenIndex466, depth466
+					goto l524
+				l525:
+					position, tokenIndex, depth = position524, tokenIndex524, depth524
 					if buffer[position] != rune('S') {
-						goto l456
+						goto l514
 					}
 					position++
 				}
-			l466:
+			l524:
 				{
-					position468, tokenIndex468, depth468 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l469
+					position526, tokenIndex526, depth526 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l527
 					}
 					position++
-					goto l468
-				l469:
-					position, tokenIndex, depth = position468, tokenIndex468, depth468
-					if buffer[position] != rune('T') {
-						goto l456
+					goto l526
+				l527:
+					position, tokenIndex, depth = position526, tokenIndex526, depth526
+					if buffer[position] != rune('O') {
+						goto l514
 					}
 					position++
 				}
-			l468:
+			l526:
 				{
-					position470, tokenIndex470, depth470 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l471
+					position528, tokenIndex528, depth528 := position, tokenIndex, depth
+					if buffer[position] != rune('u') {
+						goto l529
 					}
 					position++
-					goto l470
-				l471:
-					position, tokenIndex, depth = position470, tokenIndex470, depth470
-					if buffer[position] != rune('R') {
-						goto l456
+					goto l528
+				l529:
+					position, tokenIndex, depth = position528, tokenIndex528, depth528
+					if buffer[position] != rune('U') {
+						goto l514
 					}
 					position++
 				}
-			l470:
+			l528:
 				{
-					position472, tokenIndex472, depth472 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l473
+					position530, tokenIndex530, depth530 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l531
 					}
 					position++
-					goto l472
-				l473:
-					position, tokenIndex, depth = position472, tokenIndex472, depth472
-					if buffer[position] != rune('E') {
-						goto l456
+					goto l530
+				l531:
+					position, tokenIndex, depth = position530, tokenIndex530, depth530
+					if buffer[position] != rune('R') {
+						goto l514
 					}
 					position++
 				}
-			l472:
+			l530:
 				{
-					position474, tokenIndex474, depth474 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l475
+					position532, tokenIndex532, depth532 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l533
 					}
 					position++
-					goto l474
-				l475:
-					position, tokenIndex, depth = position474, tokenIndex474, depth474
-					if buffer[position] != rune('A') {
-						goto l456
+					goto l532
+				l533:
+					position, tokenIndex, depth = position532, tokenIndex532, depth532
+					if buffer[position] != rune('C') {
+						goto l514
 					}
 					position++
 				}
-			l474:
+			l532:
 				{
-					position476, tokenIndex476, depth476 := position, tokenIndex, depth
-					if buffer[position] != rune('m') {
-						goto l477
+					position534, tokenIndex534, depth534 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l535
 					}
 					position++
-					goto l476
-				l477:
-					position, tokenIndex, depth = position476, tokenIndex476, depth476
-					if buffer[position] != rune('M') {
-						goto l456
+					goto l534
+				l535:
+					position, tokenIndex, depth = position534, tokenIndex534, depth534
+					if buffer[position] != rune('E') {
+						goto l514
 					}
 					position++
 				}
-			l476:
+			l534:
+				if !_rules[ruleIfExistsOpt]() {
+					goto l514
+				}
 				if !_rules[rulesp]() {
-					goto l456
+					goto l514
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l456
+					goto l514
 				}
-				if !_rules[ruleAction17]() {
-					goto l456
+				if !_rules[ruleCascadeOpt]() {
+					goto l514
 				}
-				depth--
-				add(ruleDropStreamStmt, position457)
-			}
+				if !_rules[ruleAction18]() {
+					goto l514
+				}
+				depth--
+				add(ruleDropSourceStmt, position515)
+			}
 			return true
-		l456:
-			position, tokenIndex, depth = position456, tokenIndex456, depth456
+		l514:
+			position, tokenIndex, depth = position514, tokenIndex514, depth514
 			return false
 		},
-		/* 24 DropSinkStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) sp StreamIdentifier Action18)> */
+		/* 28 DropStreamStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M')) IfExistsOpt sp StreamIdentifier CascadeOpt Action19)> */
 		func() bool {
-			position478, tokenIndex478, depth478 := position, tokenIndex, depth
+			position536, tokenIndex536, depth536 := position, tokenIndex, depth
 			{
-				position479 := position
+				position537 := position
 				depth++
 				{
-					position480, tokenIndex480, depth480 := position, tokenIndex, depth
+					position538, tokenIndex538, depth538 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l481
+						goto l539
 					}
 					position++
-					goto l480
-				l481:
-					position, tokenIndex, depth = position480, tokenIndex480, depth480
+					goto l538
+				l539:
+					position, tokenIndex, depth = position538, tokenIndex538, depth538
 					if buffer[position] != rune('D') {
-						goto l478
+						goto l536
 					}
 					position++
 				}
-			l480:
+			l538:
 				{
-					position482, tokenIndex482, depth482 := position, tokenIndex, depth
+					position540, tokenIndex540, depth540 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l483
+						goto l541
 					}
 					position++
-					goto l482
-				l483:
-					position, tokenIndex, depth = position482, tokenIndex482, depth482
+					goto l540
+				l541:
+					position, tokenIndex, depth = position540, tokenIndex540, depth540
 					if buffer[position] != rune('R') {
-						goto l478
+						goto l536
 					}
 					position++
 				}
-			l482:
+			l540:
 				{
-					position484, tokenIndex484, depth484 := position, tokenIndex, depth
+					position542, tokenIndex542, depth542 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l485
+						goto l543
 					}
 					position++
-					goto l484
-				l485:
-					position, tokenIndex, depth = position484, tokenIndex484, depth484
+					goto l542
+				l543:
+					position, tokenIndex, depth = position542, tokenIndex542, depth542
 					if buffer[position] != rune('O') {
-						goto l478
+						goto l536
 					}
 					position++
 				}
-			l484:
+			l542:
 				{
-					position486, tokenIndex486, depth486 := position, tokenIndex, depth
+					position544, tokenIndex544, depth544 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l487
+						goto l545
 					}
 					position++
-					goto l486
-				l487:
-					position, tokenIndex, depth = position486, tokenIndex486, depth486
+					goto l544
+				l545:
+					position, tokenIndex, depth = position544, tokenIndex544, depth544
 					if buffer[position] != rune('P') {
-						goto l478
+						goto l536
 					}
 					position++
 				}
-			l486:
+			l544:
 				if !_rules[rulesp]() {
-					goto l478
+					goto l536
 				}
 				{
-					position488, tokenIndex488, depth488 := position, tokenIndex, depth
+					position546, tokenIndex546, depth546 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l489
+						goto l547
 					}
 					position++
-					goto l488
-				l489:
-					position, tokenIndex, depth = position488, tokenIndex488, depth488
+					goto l546
+				l547:
+					position, tokenIndex, depth = position546, tokenIndex546, depth546
 					if buffer[position] != rune('S') {
-						goto l478
+						goto l536
 					}
 					position++
 				}
-			l488:
+			l546:
 				{
-					position490, tokenIndex490, depth490 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l491
+					position548, tokenIndex548, depth548 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l549
 					}
 					position++
-					goto l490
-				l491:
-					position, tokenIndex, depth = position490, tokenIndex490, depth490
-					if buffer[position] != rune('I') {
-						goto l478
+					goto l548
+				l549:
+					position, tokenIndex, depth = position548, tokenIndex548, depth548
+					if buffer[position] != rune('T') {
+						goto l536
 					}
 					position++
 				}
-			l490:
+			l548:
 				{
-					position492, tokenIndex492, depth492 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l493
+					position550, tokenIndex550, depth550 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l551
 					}
 					position++
-					goto l492
-				l493:
-					position, tokenIndex, depth = position492, tokenIndex492, depth492
-					if buffer[position] != rune('N') {
-						goto l478
+					goto l550
+				l551:
+					position, tokenIndex, depth = position550, tokenIndex550, depth550
+					if buffer[position] != rune('R') {
+						goto l536
 					}
 					position++
 				}
-			l492:
+			l550:
 				{
-					position494, tokenIndex494, depth494 := position, tokenIndex, depth
-					if buffer[position] != rune('k') {
-						goto l495
+					position552, tokenIndex552, depth552 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l553
 					}
 					position++
-					goto l494
-				l495:
-					position, tokenIndex, depth = position494, tokenIndex494, depth494
-					if buffer[position] != rune('K') {
-						goto l478
+					goto l552
+				l553:
+					position, tokenIndex, depth = position552, tokenIndex552, depth552
+					if buffer[position] != rune('E') {
+						goto l536
 					}
 					position++
 				}
-			l494:
+			l552:
+				{
+					position554, tokenIndex554, depth554 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l555
+					}
+					position++
+					goto l554
+				l555:
+					position, tokenIndex, depth = position554, tokenIndex554, depth554
+					if buffer[position] != rune('A') {
+						goto l536
+					}
+					position++
+				}
+			l554:
+				{
+					position556, tokenIndex556, depth556 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l557
+					}
+					position++
+					goto l556
+				l557:
+					position, tokenIndex, depth = position556, tokenIndex556, depth556
+					if buffer[position] != rune('M') {
+						goto l536
+					}
+					position++
+				}
+			l556:
+				if !_rules[ruleIfExistsOpt]() {
+					goto l536
+				}
 				if !_rules[rulesp]() {
-					goto l478
+					goto l536
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l478
+					goto l536
 				}
-				if !_rules[ruleAction18]() {
-					goto l478
+				if !_rules[ruleCascadeOpt]() {
+					goto l536
+				}
+				if !_rules[ruleAction19]() {
+					goto l536
 				}
 				depth--
-				add(ruleDropSinkStmt, position479)
+				add(ruleDropStreamStmt, position537)
 			}
 			return true
-		l478:
-			position, tokenIndex, depth = position478, tokenIndex478, depth478
+		l536:
+			position, tokenIndex, depth = position536, tokenIndex536, depth536
 			return false
 		},
-		/* 25 DropStateStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier Action19)> */
+		/* 29 DropSinkStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K')) IfExistsOpt sp StreamIdentifier Action20)> */
 		func() bool {
-			position496, tokenIndex496, depth496 := position, tokenIndex, depth
+			position558, tokenIndex558, depth558 := position, tokenIndex, depth
 			{
-				position497 := position
+				position559 := position
 				depth++
 				{
-					position498, tokenIndex498, depth498 := position, tokenIndex, depth
+					position560, tokenIndex560, depth560 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l499
+						goto l561
 					}
 					position++
-					goto l498
-				l499:
-					position, tokenIndex, depth = position498, tokenIndex498, depth498
+					goto l560
+				l561:
+					position, tokenIndex, depth = position560, tokenIndex560, depth560
 					if buffer[position] != rune('D') {
-						goto l496
+						goto l558
 					}
 					position++
 				}
-			l498:
+			l560:
 				{
-					position500, tokenIndex500, depth500 := position, tokenIndex, depth
+					position562, tokenIndex562, depth562 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l501
+						goto l563
 					}
 					position++
-					goto l500
-				l501:
-					position, tokenIndex, depth = position500, tokenIndex500, depth500
+					goto l562
+				l563:
+					position, tokenIndex, depth = position562, tokenIndex562, depth562
 					if buffer[position] != rune('R') {
-						goto l496
+						goto l558
 					}
 					position++
 				}
-			l500:
+			l562:
 				{
-					position502, tokenIndex502, depth502 := position, tokenIndex, depth
+					position564, tokenIndex564, depth564 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l503
+						goto l565
 					}
 					position++
-					goto l502
-				l503:
-					position, tokenIndex, depth = position502, tokenIndex502, depth502
+					goto l564
+				l565:
+					position, tokenIndex, depth = position564, tokenIndex564, depth564
 					if buffer[position] != rune('O') {
-						goto l496
+						goto l558
 					}
 					position++
 				}
-			l502:
+			l564:
 				{
-					position504, tokenIndex504, depth504 := position, tokenIndex, depth
+					position566, tokenIndex566, depth566 := position, tokenIndex, depth
 					if buffer[position] != rune('p') {
-						goto l505
+						goto l567
 					}
 					position++
-					goto l504
-				l505:
-					position, tokenIndex, depth = position504, tokenIndex504, depth504
+					goto l566
+				l567:
+					position, tokenIndex, depth = position566, tokenIndex566, depth566
 					if buffer[position] != rune('P') {
-						goto l496
+						goto l558
 					}
 					position++
 				}
-			l504:
+			l566:
 				if !_rules[rulesp]() {
-					goto l496
+					goto l558
 				}
 				{
-					position506, tokenIndex506, depth506 := position, tokenIndex, depth
+					position568, tokenIndex568, depth568 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l507
+						goto l569
 					}
 					position++
-					goto l506
-				l507:
-					position, tokenIndex, depth = position506, tokenIndex506, depth506
+					goto l568
+				l569:
+					position, tokenIndex, depth = position568, tokenIndex568, depth568
 					if buffer[position] != rune('S') {
-						goto l496
+						goto l558
 					}
 					position++
 				}
-			l506:
+			l568:
 				{
-					position508, tokenIndex508, depth508 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l509
+					position570, tokenIndex570, depth570 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l571
 					}
 					position++
-					goto l508
-				l509:
-					position, tokenIndex, depth = position508, tokenIndex508, depth508
-					if buffer[position] != rune('T') {
-						goto l496
+					goto l570
+				l571:
+					position, tokenIndex, depth = position570, tokenIndex570, depth570
+					if buffer[position] != rune('I') {
+						goto l558
 					}
 					position++
 				}
-			l508:
+			l570:
 				{
-					position510, tokenIndex510, depth510 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l511
+					position572, tokenIndex572, depth572 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l573
 					}
 					position++
-					goto l510
-				l511:
-					position, tokenIndex, depth = position510, tokenIndex510, depth510
-					if buffer[position] != rune('A') {
-						goto l496
+					goto l572
+				l573:
+					position, tokenIndex, depth = position572, tokenIndex572, depth572
+					if buffer[position] != rune('N') {
+						goto l558
 					}
 					position++
 				}
-			l510:
+			l572:
 				{
-					position512, tokenIndex512, depth512 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l513
+					position574, tokenIndex574, depth574 := position, tokenIndex, depth
+					if buffer[position] != rune('k') {
+						goto l575
 					}
 					position++
-					goto l512
-				l513:
-					position, tokenIndex, depth = position512, tokenIndex512, depth512
-					if buffer[position] != rune('T') {
-						goto l496
+					goto l574
+				l575:
+					position, tokenIndex, depth = position574, tokenIndex574, depth574
+					if buffer[position] != rune('K') {
+						goto l558
 					}
 					position++
 				}
-			l512:
-				{
-					position514, tokenIndex514, depth514 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l515
-					}
-					position++
-					goto l514
-				l515:
-					position, tokenIndex, depth = position514, tokenIndex514, depth514
-					if buffer[position] != rune('E') {
-						goto l496
-					}
-					position++
+			l574:
+				if !_rules[ruleIfExistsOpt]() {
+					goto l558
 				}
-			l514:
 				if !_rules[rulesp]() {
-					goto l496
+					goto l558
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l496
+					goto l558
 				}
-				if !_rules[ruleAction19]() {
-					goto l496
+				if !_rules[ruleAction20]() {
+					goto l558
 				}
 				depth--
-				add(ruleDropStateStmt, position497)
+				add(ruleDropSinkStmt, position559)
 			}
 			return true
-		l496:
-			position, tokenIndex, depth = position496, tokenIndex496, depth496
+		l558:
+			position, tokenIndex, depth = position558, tokenIndex558, depth558
 			return false
 		},
-		/* 26 LoadStateStmt <- <(('l' / 'L') ('o' / 'O') ('a' / 'A') ('d' / 'D') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType StateTagOpt SetOptSpecs Action20)> */
+		/* 30 DropStateStmt <- <(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) IfExistsOpt sp StreamIdentifier Action21)> */
 		func() bool {
-			position516, tokenIndex516, depth516 := position, tokenIndex, depth
+			position576, tokenIndex576, depth576 := position, tokenIndex, depth
 			{
-				position517 := position
+				position577 := position
 				depth++
 				{
-					position518, tokenIndex518, depth518 := position, tokenIndex, depth
-					if buffer[position] != rune('l') {
-						goto l519
+					position578, tokenIndex578, depth578 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l579
 					}
 					position++
-					goto l518
-				l519:
-					position, tokenIndex, depth = position518, tokenIndex518, depth518
-					if buffer[position] != rune('L') {
-						goto l516
+					goto l578
+				l579:
+					position, tokenIndex, depth = position578, tokenIndex578, depth578
+					if buffer[position] != rune('D') {
+						goto l576
 					}
 					position++
 				}
-			l518:
+			l578:
 				{
-					position520, tokenIndex520, depth520 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l521
+					position580, tokenIndex580, depth580 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l581
 					}
 					position++
-					goto l520
-				l521:
-					position, tokenIndex, depth = position520, tokenIndex520, depth520
-					if buffer[position] != rune('O') {
-						goto l516
+					goto l580
+				l581:
+					position, tokenIndex, depth = position580, tokenIndex580, depth580
+					if buffer[position] != rune('R') {
+						goto l576
 					}
 					position++
 				}
-			l520:
+			l580:
 				{
-					position522, tokenIndex522, depth522 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l523
+					position582, tokenIndex582, depth582 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l583
 					}
 					position++
-					goto l522
-				l523:
-					position, tokenIndex, depth = position522, tokenIndex522, depth522
-					if buffer[position] != rune('A') {
-						goto l516
+					goto l582
+				l583:
+					position, tokenIndex, depth = position582, tokenIndex582, depth582
+					if buffer[position] != rune('O') {
+						goto l576
 					}
 					position++
 				}
-			l522:
+			l582:
 				{
-					position524, tokenIndex524, depth524 := position, tokenIndex, depth
-					if buffer[position] != rune('d') {
-						goto l525
+					position584, tokenIndex584, depth584 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l585
 					}
 					position++
-					goto l524
-				l525:
-					position, tokenIndex, depth = position524, tokenIndex524, depth524
-					if buffer[position] != rune('D') {
-						goto l516
+					goto l584
+				l585:
+					position, tokenIndex, depth = position584, tokenIndex584, depth584
+					if buffer[position] != rune('P') {
+						goto l576
 					}
 					position++
 				}
-			l524:
+			l584:
 				if !_rules[rulesp]() {
-					goto l516
+					goto l576
 				}
 				{
-					position526, tokenIndex526, depth526 := position, tokenIndex, depth
+					position586, tokenIndex586, depth586 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l527
+						goto l587
 					}
 					position++
-					goto l526
-				l527:
-					position, tokenIndex, depth = position526, tokenIndex526, depth526
+					goto l586
+				l587:
+					position, tokenIndex, depth = position586, tokenIndex586, depth586
 					if buffer[position] != rune('S') {
-						goto l516
+						goto l576
 					}
 					position++
 				}
-			l526:
+			l586:
 				{
-					position528, tokenIndex528, depth528 := position, tokenIndex, depth
+					position588, tokenIndex588, depth588 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l529
+						goto l589
 					}
 					position++
-					goto l528
-				l529:
-					position, tokenIndex, depth = position528, tokenIndex528, depth528
+					goto l588
+				l589:
+					position, tokenIndex, depth = position588, tokenIndex588, depth588
 					if buffer[position] != rune('T') {
-						goto l516
+						goto l576
 					}
 					position++
 				}
-			l528:
+			l588:
 				{
-					position530, tokenIndex530, depth530 := position, tokenIndex, depth
+					position590, tokenIndex590, depth590 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l531
+						goto l591
 					}
 					position++
-					goto l530
-				l531:
-					position, tokenIndex, depth = position530, tokenIndex530, depth530
+					goto l590
+				l591:
+					position, tokenIndex, depth = position590, tokenIndex590, depth590
 					if buffer[position] != rune('A') {
-						goto l516
+						goto l576
 					}
 					position++
 				}
-			l530:
+			l590:
 				{
-					position532, tokenIndex532, depth532 := position, tokenIndex, depth
+					position592, tokenIndex592, depth592 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l533
+						goto l593
 					}
 					position++
-					goto l532
-				l533:
-					position, tokenIndex, depth = position532, tokenIndex532, depth532
+					goto l592
+				l593:
+					position, tokenIndex, depth = position592, tokenIndex592, depth592
 					if buffer[position] != rune('T') {
-						goto l516
+						goto l576
 					}
 					position++
 				}
-			l532:
+			l592:
 				{
-					position534, tokenIndex534, depth534 := position, tokenIndex, depth
+					position594, tokenIndex594, depth594 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l535
+						goto l595
 					}
 					position++
-					goto l534
-				l535:
-					position, tokenIndex, depth = position534, tokenIndex534, depth534
+					goto l594
+				l595:
+					position, tokenIndex, depth = position594, tokenIndex594, depth594
 					if buffer[position] != rune('E') {
-						goto l516
+						goto l576
 					}
 					position++
 				}
-			l534:
+			l594:
+				if !_rules[ruleIfExistsOpt]() {
+					goto l576
+				}
 				if !_rules[rulesp]() {
-					goto l516
+					goto l576
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l516
+					goto l576
 				}
-				if !_rules[rulesp]() {
-					goto l516
+				if !_rules[ruleAction21]() {
+					goto l576
 				}
+				depth--
+				add(ruleDropStateStmt, position577)
+			}
+			return true
+		l576:
+			position, tokenIndex, depth = position576, tokenIndex576, depth576
+			return false
+		},
+		/* 31 LoadStateStmt <- <(('l' / 'L') ('o' / 'O') ('a' / 'A') ('d' / 'D') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier sp (('t' / 'T') ('y' / 'Y') ('p' / 'P') ('e' / 'E')) sp SourceSinkType StateTagOpt SetOptSpecs Action22)> */
+		func() bool {
+			position596, tokenIndex596, depth596 := position, tokenIndex, depth
+			{
+				position597 := position
+				depth++
 				{
-					position536, tokenIndex536, depth536 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l537
+					position598, tokenIndex598, depth598 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l599
 					}
 					position++
-					goto l536
-				l537:
-					position, tokenIndex, depth = position536, tokenIndex536, depth536
-					if buffer[position] != rune('T') {
-						goto l516
+					goto l598
+				l599:
+					position, tokenIndex, depth = position598, tokenIndex598, depth598
+					if buffer[position] != rune('L') {
+						goto l596
 					}
 					position++
 				}
-			l536:
+			l598:
 				{
-					position538, tokenIndex538, depth538 := position, tokenIndex, depth
-					if buffer[position] != rune('y') {
-						goto l539
+					position600, tokenIndex600, depth600 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l601
 					}
 					position++
-					goto l538
-				l539:
-					position, tokenIndex, depth = position538, tokenIndex538, depth538
-					if buffer[position] != rune('Y') {
-						goto l516
+					goto l600
+				l601:
+					position, tokenIndex, depth = position600, tokenIndex600, depth600
+					if buffer[position] != rune('O') {
+						goto l596
 					}
 					position++
 				}
-			l538:
+			l600:
 				{
-					position540, tokenIndex540, depth540 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l541
+					position602, tokenIndex602, depth602 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l603
 					}
 					position++
-					goto l540
-				l541:
-					position, tokenIndex, depth = position540, tokenIndex540, depth540
-					if buffer[position] != rune('P') {
-						goto l516
+					goto l602
+				l603:
+					position, tokenIndex, depth = position602, tokenIndex602, depth602
+					if buffer[position] != rune('A') {
+						goto l596
 					}
 					position++
 				}
-			l540:
+			l602:
 				{
-					position542, tokenIndex542, depth542 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l543
+					position604, tokenIndex604, depth604 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l605
 					}
 					position++
-					goto l542
-				l543:
-					position, tokenIndex, depth = position542, tokenIndex542, depth542
-					if buffer[position] != rune('E') {
-						goto l516
+					goto l604
+				l605:
+					position, tokenIndex, depth = position604, tokenIndex604, depth604
+					if buffer[position] != rune('D') {
+						goto l596
 					}
 					position++
 				}
-			l542:
-				if !_rules[rulesp]() {
-					goto l516
-				}
-				if !_rules[ruleSourceSinkType]() {
-					goto l516
-				}
-				if !_rules[ruleStateTagOpt]() {
-					goto l516
-				}
-				if !_rules[ruleSetOptSpecs]() {
-					goto l516
-				}
-				if !_rules[ruleAction20]() {
-					goto l516
-				}
-				depth--
-				add(ruleLoadStateStmt, position517)
-			}
-			return true
-		l516:
-			position, tokenIndex, depth = position516, tokenIndex516, depth516
-			return false
-		},
-		/* 27 LoadStateOrCreateStmt <- <(LoadStateStmt sp (('o' / 'O') ('r' / 'R')) sp (('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp (('i' / 'I') ('f' / 'F')) sp (('n' / 'N') ('o' / 'O') ('t' / 'T')) sp ((('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') ('d' / 'D')) / (('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))) SourceSinkSpecs Action21)> */
-		func() bool {
-			position544, tokenIndex544, depth544 := position, tokenIndex, depth
-			{
-				position545 := position
-				depth++
-				if !_rules[ruleLoadStateStmt]() {
-					goto l544
-				}
+			l604:
 				if !_rules[rulesp]() {
-					goto l544
+					goto l596
 				}
 				{
-					position546, tokenIndex546, depth546 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l547
+					position606, tokenIndex606, depth606 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l607
 					}
 					position++
-					goto l546
-				l547:
-					position, tokenIndex, depth = position546, tokenIndex546, depth546
-					if buffer[position] != rune('O') {
-						goto l544
+					goto l606
+				l607:
+					position, tokenIndex, depth = position606, tokenIndex606, depth606
+					if buffer[position] != rune('S') {
+						goto l596
 					}
 					position++
 				}
-			l546:
+			l606:
 				{
-					position548, tokenIndex548, depth548 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l549
+					position608, tokenIndex608, depth608 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l609
 					}
 					position++
-					goto l548
-				l549:
-					position, tokenIndex, depth = position548, tokenIndex548, depth548
-					if buffer[position] != rune('R') {
-						goto l544
+					goto l608
+				l609:
+					position, tokenIndex, depth = position608, tokenIndex608, depth608
+					if buffer[position] != rune('T') {
+						goto l596
 					}
 					position++
 				}
-			l548:
-				if !_rules[rulesp]() {
-					goto l544
-				}
+			l608:
 				{
-					position550, tokenIndex550, depth550 := position, tokenIndex, depth
-					if buffer[position] != rune('c') {
-						goto l551
+					position610, tokenIndex610, depth610 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l611
 					}
 					position++
-					goto l550
-				l551:
-					position, tokenIndex, depth = position550, tokenIndex550, depth550
-					if buffer[position] != rune('C') {
-						goto l544
+					goto l610
+				l611:
+					position, tokenIndex, depth = position610, tokenIndex610, depth610
+					if buffer[position] != rune('A') {
+						goto l596
 					}
 					position++
 				}
-			l550:
+			l610:
 				{
-					position552, tokenIndex552, depth552 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l553
+					position612, tokenIndex612, depth612 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l613
 					}
 					position++
-					goto l552
-				l553:
-					position, tokenIndex, depth = position552, tokenIndex552, depth552
+					goto l612
+				l613:
+					position, tokenIndex, depth = position612, tokenIndex612, depth612
+					if buffer[position] != rune('T') {
+						goto l596
+					}
+					position++
+				}
+			l612:
+				{
+					position614, tokenIndex614, depth614 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l615
+					}
+					position++
+					goto l614
+				l615:
+					position, tokenIndex, depth = position614, tokenIndex614, depth614
+					if buffer[position] != rune('E') {
+						goto l596
+					}
+					position++
+				}
+			l614:
+				if !_rules[rulesp]() {
+					goto l596
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l596
+				}
+				if !_rules[rulesp]() {
+					goto l596
+				}
+				{
+					position616, tokenIndex616, depth616 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l617
+					}
+					position++
+					goto l616
+				l617:
+					position, tokenIndex, depth = position616, tokenIndex616, depth616
+					if buffer[position] != rune('T') {
+						goto l596
+					}
+					position++
+				}
+			l616:
+				{
+					position618, tokenIndex618, depth618 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l619
+					}
+					position++
+					goto l618
+				l619:
+					position, tokenIndex, depth = position618, tokenIndex618, depth618
+					if buffer[position] != rune('Y') {
+						goto l596
+					}
+					position++
+				}
+			l618:
+				{
+					position620, tokenIndex620, depth620 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l621
+					}
+					position++
+					goto l620
+				l621:
+					position, tokenIndex, depth = position620, tokenIndex620, depth620
+					if buffer[position] != rune('P') {
+						goto l596
+					}
+					position++
+				}
+			l620:
+				{
+					position622, tokenIndex622, depth622 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l623
+					}
+					position++
+					goto l622
+				l623:
+					position, tokenIndex, depth = position622, tokenIndex622, depth622
+					if buffer[position] != rune('E') {
+						goto l596
+					}
+					position++
+				}
+			l622:
+				if !_rules[rulesp]() {
+					goto l596
+				}
+				if !_rules[ruleSourceSinkType]() {
+					goto l596
+				}
+				if !_rules[ruleStateTagOpt]() {
+					goto l596
+				}
+				if !_rules[ruleSetOptSpecs]() {
+					goto l596
+				}
+				if !_rules[ruleAction22]() {
+					goto l596
+				}
+				depth--
+				add(ruleLoadStateStmt, position597)
+			}
+			return true
+		l596:
+			position, tokenIndex, depth = position596, tokenIndex596, depth596
+			return false
+		},
+		/* 32 LoadStateOrCreateStmt <- <(LoadStateStmt sp (('o' / 'O') ('r' / 'R')) sp (('c' / 'C') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp (('i' / 'I') ('f' / 'F')) sp (('n' / 'N') ('o' / 'O') ('t' / 'T')) sp ((('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') ('d' / 'D')) / (('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))) SourceSinkSpecs Action23)> */
+		func() bool {
+			position624, tokenIndex624, depth624 := position, tokenIndex, depth
+			{
+				position625 := position
+				depth++
+				if !_rules[ruleLoadStateStmt]() {
+					goto l624
+				}
+				if !_rules[rulesp]() {
+					goto l624
+				}
+				{
+					position626, tokenIndex626, depth626 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l627
+					}
+					position++
+					goto l626
+				l627:
+					position, tokenIndex, depth = position626, tokenIndex626, depth626
+					if buffer[position] != rune('O') {
+						goto l624
+					}
+					position++
+				}
+			l626:
+				{
+					position628, tokenIndex628, depth628 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l629
+					}
+					position++
+					goto l628
+				l629:
+					position, tokenIndex, depth = position628, tokenIndex628, depth628
 					if buffer[position] != rune('R') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l552:
+			l628:
+				if !_rules[rulesp]() {
+					goto l624
+				}
 				{
-					position554, tokenIndex554, depth554 := position, tokenIndex, depth
+					position630, tokenIndex630, depth630 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l631
+					}
+					position++
+					goto l630
+				l631:
+					position, tokenIndex, depth = position630, tokenIndex630, depth630
+					if buffer[position] != rune('C') {
+						goto l624
+					}
+					position++
+				}
+			l630:
+				{
+					position632, tokenIndex632, depth632 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l633
+					}
+					position++
+					goto l632
+				l633:
+					position, tokenIndex, depth = position632, tokenIndex632, depth632
+					if buffer[position] != rune('R') {
+						goto l624
+					}
+					position++
+				}
+			l632:
+				{
+					position634, tokenIndex634, depth634 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l555
+						goto l635
 					}
 					position++
-					goto l554
-				l555:
-					position, tokenIndex, depth = position554, tokenIndex554, depth554
+					goto l634
+				l635:
+					position, tokenIndex, depth = position634, tokenIndex634, depth634
 					if buffer[position] != rune('E') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l554:
+			l634:
 				{
-					position556, tokenIndex556, depth556 := position, tokenIndex, depth
+					position636, tokenIndex636, depth636 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l557
+						goto l637
 					}
 					position++
-					goto l556
-				l557:
-					position, tokenIndex, depth = position556, tokenIndex556, depth556
+					goto l636
+				l637:
+					position, tokenIndex, depth = position636, tokenIndex636, depth636
 					if buffer[position] != rune('A') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l556:
+			l636:
 				{
-					position558, tokenIndex558, depth558 := position, tokenIndex, depth
+					position638, tokenIndex638, depth638 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l559
+						goto l639
 					}
 					position++
-					goto l558
-				l559:
-					position, tokenIndex, depth = position558, tokenIndex558, depth558
+					goto l638
+				l639:
+					position, tokenIndex, depth = position638, tokenIndex638, depth638
 					if buffer[position] != rune('T') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l558:
+			l638:
 				{
-					position560, tokenIndex560, depth560 := position, tokenIndex, depth
+					position640, tokenIndex640, depth640 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l561
+						goto l641
 					}
 					position++
-					goto l560
-				l561:
-					position, tokenIndex, depth = position560, tokenIndex560, depth560
+					goto l640
+				l641:
+					position, tokenIndex, depth = position640, tokenIndex640, depth640
 					if buffer[position] != rune('E') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l560:
+			l640:
 				if !_rules[rulesp]() {
-					goto l544
+					goto l624
 				}
 				{
-					position562, tokenIndex562, depth562 := position, tokenIndex, depth
+					position642, tokenIndex642, depth642 := position, tokenIndex, depth
 					if buffer[position] != rune('i') {
-						goto l563
+						goto l643
 					}
 					position++
-					goto l562
-				l563:
-					position, tokenIndex, depth = position562, tokenIndex562, depth562
+					goto l642
+				l643:
+					position, tokenIndex, depth = position642, tokenIndex642, depth642
 					if buffer[position] != rune('I') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l562:
+			l642:
 				{
-					position564, tokenIndex564, depth564 := position, tokenIndex, depth
+					position644, tokenIndex644, depth644 := position, tokenIndex, depth
 					if buffer[position] != rune('f') {
-						goto l565
+						goto l645
 					}
 					position++
-					goto l564
-				l565:
-					position, tokenIndex, depth = position564, tokenIndex564, depth564
+					goto l644
+				l645:
+					position, tokenIndex, depth = position644, tokenIndex644, depth644
 					if buffer[position] != rune('F') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l564:
+			l644:
 				if !_rules[rulesp]() {
-					goto l544
+					goto l624
 				}
 				{
-					position566, tokenIndex566, depth566 := position, tokenIndex, depth
+					position646, tokenIndex646, depth646 := position, tokenIndex, depth
 					if buffer[position] != rune('n') {
-						goto l567
+						goto l647
 					}
 					position++
-					goto l566
-				l567:
-					position, tokenIndex, depth = position566, tokenIndex566, depth566
+					goto l646
+				l647:
+					position, tokenIndex, depth = position646, tokenIndex646, depth646
 					if buffer[position] != rune('N') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l566:
+			l646:
 				{
-					position568, tokenIndex568, depth568 := position, tokenIndex, depth
+					position648, tokenIndex648, depth648 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l569
+						goto l649
 					}
 					position++
-					goto l568
-				l569:
-					position, tokenIndex, depth = position568, tokenIndex568, depth568
+					goto l648
+				l649:
+					position, tokenIndex, depth = position648, tokenIndex648, depth648
 					if buffer[position] != rune('O') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l568:
+			l648:
 				{
-					position570, tokenIndex570, depth570 := position, tokenIndex, depth
+					position650, tokenIndex650, depth650 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l571
+						goto l651
 					}
 					position++
-					goto l570
-				l571:
-					position, tokenIndex, depth = position570, tokenIndex570, depth570
+					goto l650
+				l651:
+					position, tokenIndex, depth = position650, tokenIndex650, depth650
 					if buffer[position] != rune('T') {
-						goto l544
+						goto l624
 					}
 					position++
 				}
-			l570:
+			l650:
 				if !_rules[rulesp]() {
-					goto l544
+					goto l624
 				}
 				{
-					position572, tokenIndex572, depth572 := position, tokenIndex, depth
+					position652, tokenIndex652, depth652 := position, tokenIndex, depth
 					{
-						position574, tokenIndex574, depth574 := position, tokenIndex, depth
+						position654, tokenIndex654, depth654 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l575
+							goto l655
 						}
 						position++
-						goto l574
-					l575:
-						position, tokenIndex, depth = position574, tokenIndex574, depth574
+						goto l654
+					l655:
+						position, tokenIndex, depth = position654, tokenIndex654, depth654
 						if buffer[position] != rune('S') {
-							goto l573
+							goto l653
 						}
 						position++
 					}
-				l574:
+				l654:
 					{
-						position576, tokenIndex576, depth576 := position, tokenIndex, depth
+						position656, tokenIndex656, depth656 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l577
+							goto l657
 						}
 						position++
-						goto l576
-					l577:
-						position, tokenIndex, depth = position576, tokenIndex576, depth576
+						goto l656
+					l657:
+						position, tokenIndex, depth = position656, tokenIndex656, depth656
 						if buffer[position] != rune('A') {
-							goto l573
+							goto l653
 						}
 						position++
 					}
-				l576:
+				l656:
 					{
-						position578, tokenIndex578, depth578 := position, tokenIndex, depth
+						position658, tokenIndex658, depth658 := position, tokenIndex, depth
 						if buffer[position] != rune('v') {
-							goto l579
+							goto l659
 						}
 						position++
-						goto l578
-					l579:
-						position, tokenIndex, depth = position578, tokenIndex578, depth578
+						goto l658
+					l659:
+						position, tokenIndex, depth = position658, tokenIndex658, depth658
 						if buffer[position] != rune('V') {
-							goto l573
+							goto l653
 						}
 						position++
 					}
-				l578:
+				l658:
 					{
-						position580, tokenIndex580, depth580 := position, tokenIndex, depth
+						position660, tokenIndex660, depth660 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l581
+							goto l661
 						}
 						position++
-						goto l580
-					l581:
-						position, tokenIndex, depth = position580, tokenIndex580, depth580
+						goto l660
+					l661:
+						position, tokenIndex, depth = position660, tokenIndex660, depth660
 						if buffer[position] != rune('E') {
-							goto l573
+							goto l653
 						}
 						position++
 					}
-				l580:
+				l660:
 					{
-						position582, tokenIndex582, depth582 := position, tokenIndex, depth
+						position662, tokenIndex662, depth662 := position, tokenIndex, depth
 						if buffer[position] != rune('d') {
-							goto l583
+							goto l663
 						}
 						position++
-						goto l582
-					l583:
-						position, tokenIndex, depth = position582, tokenIndex582, depth582
+						goto l662
+					l663:
+						position, tokenIndex, depth = position662, tokenIndex662, depth662
 						if buffer[position] != rune('D') {
-							goto l573
+							goto l653
 						}
 						position++
 					}
-				l582:
-					goto l572
-				l573:
-					position, tokenIndex, depth = position572, tokenIndex572, depth572
+				l662:
+					goto l652
+				l653:
+					position, tokenIndex, depth = position652, tokenIndex652, depth652
 					{
-						position584, tokenIndex584, depth584 := position, tokenIndex, depth
+						position664, tokenIndex664, depth664 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l585
+							goto l665
 						}
 						position++
-						goto l584
-					l585:
-						position, tokenIndex, depth = position584, tokenIndex584, depth584
+						goto l664
+					l665:
+						position, tokenIndex, depth = position664, tokenIndex664, depth664
 						if buffer[position] != rune('E') {
-							goto l544
+							goto l624
 						}
 						position++
 					}
-				l584:
+				l664:
 					{
-						position586, tokenIndex586, depth586 := position, tokenIndex, depth
+						position666, tokenIndex666, depth666 := position, tokenIndex, depth
 						if buffer[position] != rune('x') {
-							goto l587
+							goto l667
 						}
 						position++
-						goto l586
-					l587:
-						position, tokenIndex, depth = position586, tokenIndex586, depth586
+						goto l666
+					l667:
+						position, tokenIndex, depth = position666, tokenIndex666, depth666
 						if buffer[position] != rune('X') {
-							goto l544
+							goto l624
 						}
 						position++
 					}
-				l586:
+				l666:
 					{
-						position588, tokenIndex588, depth588 := position, tokenIndex, depth
+						position668, tokenIndex668, depth668 := position, tokenIndex, depth
 						if buffer[position] != rune('i') {
-							goto l589
+							goto l669
 						}
 						position++
-						goto l588
-					l589:
-						position, tokenIndex, depth = position588, tokenIndex588, depth588
+						goto l668
+					l669:
+						position, tokenIndex, depth = position668, tokenIndex668, depth668
 						if buffer[position] != rune('I') {
-							goto l544
+							goto l624
 						}
 						position++
 					}
-				l588:
+				l668:
 					{
-						position590, tokenIndex590, depth590 := position, tokenIndex, depth
+						position670, tokenIndex670, depth670 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l591
+							goto l671
 						}
 						position++
-						goto l590
-					l591:
-						position, tokenIndex, depth = position590, tokenIndex590, depth590
+						goto l670
+					l671:
+						position, tokenIndex, depth = position670, tokenIndex670, depth670
 						if buffer[position] != rune('S') {
-							goto l544
+							goto l624
 						}
 						position++
 					}
-				l590:
+				l670:
 					{
-						position592, tokenIndex592, depth592 := position, tokenIndex, depth
+						position672, tokenIndex672, depth672 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l593
+							goto l673
 						}
 						position++
-						goto l592
-					l593:
-						position, tokenIndex, depth = position592, tokenIndex592, depth592
+						goto l672
+					l673:
+						position, tokenIndex, depth = position672, tokenIndex672, depth672
 						if buffer[position] != rune('T') {
-							goto l544
+							goto l624
 						}
 						position++
 					}
-				l592:
+				l672:
 					{
-						position594, tokenIndex594, depth594 := position, tokenIndex, depth
+						position674, tokenIndex674, depth674 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l595
+							goto l675
 						}
 						position++
-						goto l594
-					l595:
-						position, tokenIndex, depth = position594, tokenIndex594, depth594
+						goto l674
+					l675:
+						position, tokenIndex, depth = position674, tokenIndex674, depth674
 						if buffer[position] != rune('S') {
-							goto l544
+							goto l624
 						}
 						position++
 					}
-				l594:
+				l674:
 				}
-			l572:
+			l652:
 				if !_rules[ruleSourceSinkSpecs]() {
-					goto l544
+					goto l624
 				}
-				if !_rules[ruleAction21]() {
-					goto l544
+				if !_rules[ruleAction23]() {
+					goto l624
 				}
 				depth--
-				add(ruleLoadStateOrCreateStmt, position545)
+				add(ruleLoadStateOrCreateStmt, position625)
 			}
 			return true
-		l544:
-			position, tokenIndex, depth = position544, tokenIndex544, depth544
+		l624:
+			position, tokenIndex, depth = position624, tokenIndex624, depth624
 			return false
 		},
-		/* 28 SaveStateStmt <- <(('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier StateTagOpt Action22)> */
+		/* 33 SaveStateStmt <- <(('s' / 'S') ('a' / 'A') ('v' / 'V') ('e' / 'E') sp (('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E')) sp StreamIdentifier StateTagOpt Action24)> */
 		func() bool {
-			position596, tokenIndex596, depth596 := position, tokenIndex, depth
+			position676, tokenIndex676, depth676 := position, tokenIndex, depth
 			{
-				position597 := position
+				position677 := position
 				depth++
 				{
-					position598, tokenIndex598, depth598 := position, tokenIndex, depth
+					position678, tokenIndex678, depth678 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l599
+						goto l679
 					}
 					position++
-					goto l598
-				l599:
-					position, tokenIndex, depth = position598, tokenIndex598, depth598
+					goto l678
+				l679:
+					position, tokenIndex, depth = position678, tokenIndex678, depth678
 					if buffer[position] != rune('S') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l598:
+			l678:
 				{
-					position600, tokenIndex600, depth600 := position, tokenIndex, depth
+					position680, tokenIndex680, depth680 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l601
+						goto l681
 					}
 					position++
-					goto l600
-				l601:
-					position, tokenIndex, depth = position600, tokenIndex600, depth600
+					goto l680
+				l681:
+					position, tokenIndex, depth = position680, tokenIndex680, depth680
 					if buffer[position] != rune('A') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l600:
+			l680:
 				{
-					position602, tokenIndex602, depth602 := position, tokenIndex, depth
+					position682, tokenIndex682, depth682 := position, tokenIndex, depth
 					if buffer[position] != rune('v') {
-						goto l603
+						goto l683
 					}
 					position++
-					goto l602
-				l603:
-					position, tokenIndex, depth = position602, tokenIndex602, depth602
+					goto l682
+				l683:
+					position, tokenIndex, depth = position682, tokenIndex682, depth682
 					if buffer[position] != rune('V') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l602:
+			l682:
 				{
-					position604, tokenIndex604, depth604 := position, tokenIndex, depth
+					position684, tokenIndex684, depth684 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l605
+						goto l685
 					}
 					position++
-					goto l604
-				l605:
-					position, tokenIndex, depth = position604, tokenIndex604, depth604
+					goto l684
+				l685:
+					position, tokenIndex, depth = position684, tokenIndex684, depth684
 					if buffer[position] != rune('E') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l604:
+			l684:
 				if !_rules[rulesp]() {
-					goto l596
+					goto l676
 				}
 				{
-					position606, tokenIndex606, depth606 := position, tokenIndex, depth
+					position686, tokenIndex686, depth686 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l607
+						goto l687
 					}
 					position++
-					goto l606
-				l607:
-					position, tokenIndex, depth = position606, tokenIndex606, depth606
+					goto l686
+				l687:
+					position, tokenIndex, depth = position686, tokenIndex686, depth686
 					if buffer[position] != rune('S') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l606:
+			l686:
 				{
-					position608, tokenIndex608, depth608 := position, tokenIndex, depth
+					position688, tokenIndex688, depth688 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l609
+						goto l689
 					}
 					position++
-					goto l608
-				l609:
-					position, tokenIndex, depth = position608, tokenIndex608, depth608
+					goto l688
+				l689:
+					position, tokenIndex, depth = position688, tokenIndex688, depth688
 					if buffer[position] != rune('T') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l608:
+			l688:
 				{
-					position610, tokenIndex610, depth610 := position, tokenIndex, depth
+					position690, tokenIndex690, depth690 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l611
+						goto l691
 					}
 					position++
-					goto l610
-				l611:
-					position, tokenIndex, depth = position610, tokenIndex610, depth610
+					goto l690
+				l691:
+					position, tokenIndex, depth = position690, tokenIndex690, depth690
 					if buffer[position] != rune('A') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l610:
+			l690:
 				{
-					position612, tokenIndex612, depth612 := position, tokenIndex, depth
+					position692, tokenIndex692, depth692 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l613
+						goto l693
 					}
 					position++
-					goto l612
-				l613:
-					position, tokenIndex, depth = position612, tokenIndex612, depth612
+					goto l692
+				l693:
+					position, tokenIndex, depth = position692, tokenIndex692, depth692
 					if buffer[position] != rune('T') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l612:
+			l692:
 				{
-					position614, tokenIndex614, depth614 := position, tokenIndex, depth
+					position694, tokenIndex694, depth694 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l615
+						goto l695
 					}
 					position++
-					goto l614
-				l615:
-					position, tokenIndex, depth = position614, tokenIndex614, depth614
+					goto l694
+				l695:
+					position, tokenIndex, depth = position694, tokenIndex694, depth694
 					if buffer[position] != rune('E') {
-						goto l596
+						goto l676
 					}
 					position++
 				}
-			l614:
+			l694:
 				if !_rules[rulesp]() {
-					goto l596
+					goto l676
 				}
 				if !_rules[ruleStreamIdentifier]() {
-					goto l596
+					goto l676
 				}
 				if !_rules[ruleStateTagOpt]() {
-					goto l596
+					goto l676
 				}
-				if !_rules[ruleAction22]() {
-					goto l596
+				if !_rules[ruleAction24]() {
+					goto l676
 				}
 				depth--
-				add(ruleSaveStateStmt, position597)
+				add(ruleSaveStateStmt, position677)
 			}
 			return true
-		l596:
-			position, tokenIndex, depth = position596, tokenIndex596, depth596
+		l676:
+			position, tokenIndex, depth = position676, tokenIndex676, depth676
 			return false
 		},
-		/* 29 EvalStmt <- <(('e' / 'E') ('v' / 'V') ('a' / 'A') ('l' / 'L') sp Expression <(sp (('o' / 'O') ('n' / 'N')) sp MapExpr)?> Action23)> */
+		/* 34 LoadPluginStmt <- <(('l' / 'L') ('o' / 'O') ('a' / 'A') ('d' / 'D') sp (('p' / 'P') ('l' / 'L') ('u' / 'U') ('g' / 'G') ('i' / 'I') ('n' / 'N')) sp StringLiteral Action25)> */
 		func() bool {
-			position616, tokenIndex616, depth616 := position, tokenIndex, depth
+			position696, tokenIndex696, depth696 := position, tokenIndex, depth
 			{
-				position617 := position
+				position697 := position
 				depth++
 				{
-					position618, tokenIndex618, depth618 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l619
+					position698, tokenIndex698, depth698 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l699
 					}
 					position++
-					goto l618
-				l619:
-					position, tokenIndex, depth = position618, tokenIndex618, depth618
-					if buffer[position] != rune('E') {
-						goto l616
+					goto l698
+				l699:
+					position, tokenIndex, depth = position698, tokenIndex698, depth698
+					if buffer[position] != rune('L') {
+						goto l696
 					}
 					position++
 				}
-			l618:
+			l698:
 				{
-					position620, tokenIndex620, depth620 := position, tokenIndex, depth
-					if buffer[position] != rune('v') {
-						goto l621
+					position700, tokenIndex700, depth700 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l701
 					}
 					position++
-					goto l620
-				l621:
-					position, tokenIndex, depth = position620, tokenIndex620, depth620
-					if buffer[position] != rune('V') {
-						goto l616
+					goto l700
+				l701:
+					position, tokenIndex, depth = position700, tokenIndex700, depth700
+					if buffer[position] != rune('O') {
+						goto l696
 					}
 					position++
 				}
-			l620:
+			l700:
 				{
-					position622, tokenIndex622, depth622 := position, tokenIndex, depth
+					position702, tokenIndex702, depth702 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l623
+						goto l703
 					}
 					position++
-					goto l622
-				l623:
-					position, tokenIndex, depth = position622, tokenIndex622, depth622
+					goto l702
+				l703:
+					position, tokenIndex, depth = position702, tokenIndex702, depth702
 					if buffer[position] != rune('A') {
-						goto l616
+						goto l696
 					}
 					position++
 				}
-			l622:
+			l702:
 				{
-					position624, tokenIndex624, depth624 := position, tokenIndex, depth
-					if buffer[position] != rune('l') {
-						goto l625
+					position704, tokenIndex704, depth704 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l705
 					}
 					position++
-					goto l624
-				l625:
-					position, tokenIndex, depth = position624, tokenIndex624, depth624
-					if buffer[position] != rune('L') {
-						goto l616
+					goto l704
+				l705:
+					position, tokenIndex, depth = position704, tokenIndex704, depth704
+					if buffer[position] != rune('D') {
+						goto l696
 					}
 					position++
 				}
-			l624:
+			l704:
 				if !_rules[rulesp]() {
-					goto l616
-				}
-				if !_rules[ruleExpression]() {
-					goto l616
+					goto l696
 				}
 				{
-					position626 := position
-					depth++
-					{
-						position627, tokenIndex627, depth627 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l627
-						}
-						{
-							position629, tokenIndex629, depth629 := position, tokenIndex, depth
-							if buffer[position] != rune('o') {
-								goto l630
-							}
-							position++
-							goto l629
-						l630:
-							position, tokenIndex, depth = position629, tokenIndex629, depth629
-							if buffer[position] != rune('O') {
-								goto l627
-							}
-							position++
-						}
-					l629:
-						{
-							position631, tokenIndex631, depth631 := position, tokenIndex, depth
-							if buffer[position] != rune('n') {
-								goto l632
-							}
-							position++
-							goto l631
-						l632:
-							position, tokenIndex, depth = position631, tokenIndex631, depth631
-							if buffer[position] != rune('N') {
-								goto l627
-							}
-							position++
-						}
-					l631:
-						if !_rules[rulesp]() {
-							goto l627
-						}
-						if !_rules[ruleMapExpr]() {
-							goto l627
-						}
-						goto l628
-					l627:
-						position, tokenIndex, depth = position627, tokenIndex627, depth627
+					position706, tokenIndex706, depth706 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l707
 					}
-				l628:
-					depth--
-					add(rulePegText, position626)
-				}
-				if !_rules[ruleAction23]() {
-					goto l616
-				}
-				depth--
-				add(ruleEvalStmt, position617)
-			}
-			return true
-		l616:
-			position, tokenIndex, depth = position616, tokenIndex616, depth616
-			return false
-		},
-		/* 30 Emitter <- <(sp (ISTREAM / DSTREAM / RSTREAM) EmitterOptions Action24)> */
-		func() bool {
-			position633, tokenIndex633, depth633 := position, tokenIndex, depth
-			{
-				position634 := position
-				depth++
-				if !_rules[rulesp]() {
-					goto l633
+					position++
+					goto l706
+				l707:
+					position, tokenIndex, depth = position706, tokenIndex706, depth706
+					if buffer[position] != rune('P') {
+						goto l696
+					}
+					position++
 				}
+			l706:
 				{
-					position635, tokenIndex635, depth635 := position, tokenIndex, depth
-					if !_rules[ruleISTREAM]() {
-						goto l636
+					position708, tokenIndex708, depth708 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l709
 					}
-					goto l635
-				l636:
-					position, tokenIndex, depth = position635, tokenIndex635, depth635
-					if !_rules[ruleDSTREAM]() {
-						goto l637
+					position++
+					goto l708
+				l709:
+					position, tokenIndex, depth = position708, tokenIndex708, depth708
+					if buffer[position] != rune('L') {
+						goto l696
 					}
-					goto l635
-				l637:
-					position, tokenIndex, depth = position635, tokenIndex635, depth635
-					if !_rules[ruleRSTREAM]() {
-						goto l633
+					position++
+				}
+			l708:
+				{
+					position710, tokenIndex710, depth710 := position, tokenIndex, depth
+					if buffer[position] != rune('u') {
+						goto l711
 					}
+					position++
+					goto l710
+				l711:
+					position, tokenIndex, depth = position710, tokenIndex710, depth710
+					if buffer[position] != rune('U') {
+						goto l696
+					}
+					position++
 				}
-			l635:
-				if !_rules[ruleEmitterOptions]() {
-					goto l633
+			l710:
+				{
+					position712, tokenIndex712, depth712 := position, tokenIndex, depth
+					if buffer[position] != rune('g') {
+						goto l713
+					}
+					position++
+					goto l712
+				l713:
+					position, tokenIndex, depth = position712, tokenIndex712, depth712
+					if buffer[position] != rune('G') {
+						goto l696
+					}
+					position++
 				}
-				if !_rules[ruleAction24]() {
-					goto l633
+			l712:
+				{
+					position714, tokenIndex714, depth714 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l715
+					}
+					position++
+					goto l714
+				l715:
+					position, tokenIndex, depth = position714, tokenIndex714, depth714
+					if buffer[position] != rune('I') {
+						goto l696
+					}
+					position++
 				}
-				depth--
-				add(ruleEmitter, position634)
-			}
-			return true
-		l633:
-			position, tokenIndex, depth = position633, tokenIndex633, depth633
-			return false
-		},
-		/* 31 EmitterOptions <- <(<(spOpt '[' spOpt EmitterOptionCombinations spOpt ']')?> Action25)> */
-		func() bool {
-			position638, tokenIndex638, depth638 := position, tokenIndex, depth
-			{
-				position639 := position
-				depth++
+			l714:
 				{
-					position640 := position
-					depth++
-					{
-						position641, tokenIndex641, depth641 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l641
-						}
-						if buffer[position] != rune('[') {
-							goto l641
-						}
-						position++
-						if !_rules[rulespOpt]() {
-							goto l641
-						}
-						if !_rules[ruleEmitterOptionCombinations]() {
-							goto l641
-						}
-						if !_rules[rulespOpt]() {
-							goto l641
-						}
-						if buffer[position] != rune(']') {
-							goto l641
-						}
-						position++
-						goto l642
-					l641:
-						position, tokenIndex, depth = position641, tokenIndex641, depth641
+					position716, tokenIndex716, depth716 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l717
 					}
-				l642:
-					depth--
-					add(rulePegText, position640)
+					position++
+					goto l716
+				l717:
+					position, tokenIndex, depth = position716, tokenIndex716, depth716
+					if buffer[position] != rune('N') {
+						goto l696
+					}
+					position++
+				}
+			l716:
+				if !_rules[rulesp]() {
+					goto l696
+				}
+				if !_rules[ruleStringLiteral]() {
+					goto l696
 				}
 				if !_rules[ruleAction25]() {
-					goto l638
+					goto l696
 				}
 				depth--
-				add(ruleEmitterOptions, position639)
+				add(ruleLoadPluginStmt, position697)
 			}
 			return true
-		l638:
-			position, tokenIndex, depth = position638, tokenIndex638, depth638
+		l696:
+			position, tokenIndex, depth = position696, tokenIndex696, depth696
 			return false
 		},
-		/* 32 EmitterOptionCombinations <- <(EmitterLimit / (EmitterSample sp EmitterLimit) / EmitterSample)> */
+		/* 35 SetLogLevelStmt <- <(('s' / 'S') ('e' / 'E') ('t' / 'T') sp (('l' / 'L') ('o' / 'O') ('g' / 'G')) sp (('l' / 'L') ('e' / 'E') ('v' / 'V') ('e' / 'E') ('l' / 'L')) sp Identifier sp (('f' / 'F') ('o' / 'O') ('r' / 'R')) sp SettingTarget Action26)> */
 		func() bool {
-			position643, tokenIndex643, depth643 := position, tokenIndex, depth
+			position718, tokenIndex718, depth718 := position, tokenIndex, depth
 			{
-				position644 := position
+				position719 := position
 				depth++
 				{
-					position645, tokenIndex645, depth645 := position, tokenIndex, depth
-					if !_rules[ruleEmitterLimit]() {
-						goto l646
+					position720, tokenIndex720, depth720 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l721
 					}
-					goto l645
-				l646:
-					position, tokenIndex, depth = position645, tokenIndex645, depth645
-					if !_rules[ruleEmitterSample]() {
-						goto l647
+					position++
+					goto l720
+				l721:
+					position, tokenIndex, depth = position720, tokenIndex720, depth720
+					if buffer[position] != rune('S') {
+						goto l718
 					}
-					if !_rules[rulesp]() {
-						goto l647
+					position++
+				}
+			l720:
+				{
+					position722, tokenIndex722, depth722 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l723
 					}
-					if !_rules[ruleEmitterLimit]() {
-						goto l647
+					position++
+					goto l722
+				l723:
+					position, tokenIndex, depth = position722, tokenIndex722, depth722
+					if buffer[position] != rune('E') {
+						goto l718
 					}
-					goto l645
-				l647:
-					position, tokenIndex, depth = position645, tokenIndex645, depth645
-					if !_rules[ruleEmitterSample]() {
-						goto l643
+					position++
+				}
+			l722:
+				{
+					position724, tokenIndex724, depth724 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l725
 					}
+					position++
+					goto l724
+				l725:
+					position, tokenIndex, depth = position724, tokenIndex724, depth724
+					if buffer[position] != rune('T') {
+						goto l718
+					}
+					position++
+				}
+			l724:
+				if !_rules[rulesp]() {
+					goto l718
 				}
-			l645:
-				depth--
-				add(ruleEmitterOptionCombinations, position644)
-			}
-			return true
-		l643:
-			position, tokenIndex, depth = position643, tokenIndex643, depth643
-			return false
-		},
-		/* 33 EmitterLimit <- <(('l' / 'L') ('i' / 'I') ('m' / 'M') ('i' / 'I') ('t' / 'T') sp NumericLiteral Action26)> */
-		func() bool {
-			position648, tokenIndex648, depth648 := position, tokenIndex, depth
-			{
-				position649 := position
-				depth++
 				{
-					position650, tokenIndex650, depth650 := position, tokenIndex, depth
+					position726, tokenIndex726, depth726 := position, tokenIndex, depth
 					if buffer[position] != rune('l') {
-						goto l651
+						goto l727
 					}
 					position++
-					goto l650
-				l651:
-					position, tokenIndex, depth = position650, tokenIndex650, depth650
+					goto l726
+				l727:
+					position, tokenIndex, depth = position726, tokenIndex726, depth726
 					if buffer[position] != rune('L') {
-						goto l648
+						goto l718
 					}
 					position++
 				}
-			l650:
+			l726:
 				{
-					position652, tokenIndex652, depth652 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l653
+					position728, tokenIndex728, depth728 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l729
 					}
 					position++
-					goto l652
-				l653:
-					position, tokenIndex, depth = position652, tokenIndex652, depth652
-					if buffer[position] != rune('I') {
-						goto l648
+					goto l728
+				l729:
+					position, tokenIndex, depth = position728, tokenIndex728, depth728
+					if buffer[position] != rune('O') {
+						goto l718
 					}
 					position++
 				}
-			l652:
+			l728:
 				{
-					position654, tokenIndex654, depth654 := position, tokenIndex, depth
-					if buffer[position] != rune('m') {
-						goto l655
+					position730, tokenIndex730, depth730 := position, tokenIndex, depth
+					if buffer[position] != rune('g') {
+						goto l731
 					}
 					position++
-					goto l654
-				l655:
-					position, tokenIndex, depth = position654, tokenIndex654, depth654
-					if buffer[position] != rune('M') {
-						goto l648
+					goto l730
+				l731:
+					position, tokenIndex, depth = position730, tokenIndex730, depth730
+					if buffer[position] != rune('G') {
+						goto l718
 					}
 					position++
 				}
-			l654:
+			l730:
+				if !_rules[rulesp]() {
+					goto l718
+				}
 				{
-					position656, tokenIndex656, depth656 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l657
+					position732, tokenIndex732, depth732 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l733
 					}
 					position++
-					goto l656
-				l657:
-					position, tokenIndex, depth = position656, tokenIndex656, depth656
-					if buffer[position] != rune('I') {
-						goto l648
+					goto l732
+				l733:
+					position, tokenIndex, depth = position732, tokenIndex732, depth732
+					if buffer[position] != rune('L') {
+						goto l718
 					}
 					position++
 				}
-			l656:
+			l732:
 				{
-					position658, tokenIndex658, depth658 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l659
+					position734, tokenIndex734, depth734 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l735
 					}
 					position++
-					goto l658
-				l659:
-					position, tokenIndex, depth = position658, tokenIndex658, depth658
-					if buffer[position] != rune('T') {
-						goto l648
+					goto l734
+				l735:
+					position, tokenIndex, depth = position734, tokenIndex734, depth734
+					if buffer[position] != rune('E') {
+						goto l718
 					}
 					position++
 				}
-			l658:
-				if !_rules[rulesp]() {
-					goto l648
-				}
-				if !_rules[ruleNumericLiteral]() {
-					goto l648
-				}
-				if !_rules[ruleAction26]() {
-					goto l648
-				}
-				depth--
-				add(ruleEmitterLimit, position649)
-			}
-			return true
-		l648:
-			position, tokenIndex, depth = position648, tokenIndex648, depth648
-			return false
-		},
-		/* 34 EmitterSample <- <(CountBasedSampling / RandomizedSampling / TimeBasedSampling)> */
-		func() bool {
-			position660, tokenIndex660, depth660 := position, tokenIndex, depth
-			{
-				position661 := position
-				depth++
+			l734:
 				{
-					position662, tokenIndex662, depth662 := position, tokenIndex, depth
-					if !_rules[ruleCountBasedSampling]() {
-						goto l663
-					}
-					goto l662
-				l663:
-					position, tokenIndex, depth = position662, tokenIndex662, depth662
-					if !_rules[ruleRandomizedSampling]() {
-						goto l664
+					position736, tokenIndex736, depth736 := position, tokenIndex, depth
+					if buffer[position] != rune('v') {
+						goto l737
 					}
-					goto l662
-				l664:
-					position, tokenIndex, depth = position662, tokenIndex662, depth662
-					if !_rules[ruleTimeBasedSampling]() {
-						goto l660
+					position++
+					goto l736
+				l737:
+					position, tokenIndex, depth = position736, tokenIndex736, depth736
+					if buffer[position] != rune('V') {
+						goto l718
 					}
+					position++
 				}
-			l662:
-				depth--
-				add(ruleEmitterSample, position661)
-			}
-			return true
-		l660:
-			position, tokenIndex, depth = position660, tokenIndex660, depth660
-			return false
-		},
-		/* 35 CountBasedSampling <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp NumericLiteral spOpt '-'? spOpt ((('s' / 'S') ('t' / 'T')) / (('n' / 'N') ('d' / 'D')) / (('r' / 'R') ('d' / 'D')) / (('t' / 'T') ('h' / 'H'))) sp (('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E')) Action27)> */
-		func() bool {
-			position665, tokenIndex665, depth665 := position, tokenIndex, depth
-			{
-				position666 := position
-				depth++
+			l736:
 				{
-					position667, tokenIndex667, depth667 := position, tokenIndex, depth
+					position738, tokenIndex738, depth738 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l668
+						goto l739
 					}
 					position++
-					goto l667
-				l668:
-					position, tokenIndex, depth = position667, tokenIndex667, depth667
+					goto l738
+				l739:
+					position, tokenIndex, depth = position738, tokenIndex738, depth738
 					if buffer[position] != rune('E') {
-						goto l665
+						goto l718
 					}
 					position++
 				}
-			l667:
+			l738:
 				{
-					position669, tokenIndex669, depth669 := position, tokenIndex, depth
-					if buffer[position] != rune('v') {
-						goto l670
+					position740, tokenIndex740, depth740 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l741
 					}
 					position++
-					goto l669
-				l670:
-					position, tokenIndex, depth = position669, tokenIndex669, depth669
-					if buffer[position] != rune('V') {
-						goto l665
+					goto l740
+				l741:
+					position, tokenIndex, depth = position740, tokenIndex740, depth740
+					if buffer[position] != rune('L') {
+						goto l718
 					}
 					position++
 				}
-			l669:
+			l740:
+				if !_rules[rulesp]() {
+					goto l718
+				}
+				if !_rules[ruleIdentifier]() {
+					goto l718
+				}
+				if !_rules[rulesp]() {
+					goto l718
+				}
 				{
-					position671, tokenIndex671, depth671 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l672
+					position742, tokenIndex742, depth742 := position, tokenIndex, depth
+					if buffer[position] != rune('f') {
+						goto l743
 					}
 					position++
-					goto l671
-				l672:
-					position, tokenIndex, depth = position671, tokenIndex671, depth671
-					if buffer[position] != rune('E') {
-						goto l665
+					goto l742
+				l743:
+					position, tokenIndex, depth = position742, tokenIndex742, depth742
+					if buffer[position] != rune('F') {
+						goto l718
 					}
 					position++
 				}
-			l671:
+			l742:
 				{
-					position673, tokenIndex673, depth673 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l674
+					position744, tokenIndex744, depth744 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l745
 					}
 					position++
-					goto l673
-				l674:
-					position, tokenIndex, depth = position673, tokenIndex673, depth673
-					if buffer[position] != rune('R') {
-						goto l665
+					goto l744
+				l745:
+					position, tokenIndex, depth = position744, tokenIndex744, depth744
+					if buffer[position] != rune('O') {
+						goto l718
 					}
 					position++
 				}
-			l673:
+			l744:
 				{
-					position675, tokenIndex675, depth675 := position, tokenIndex, depth
-					if buffer[position] != rune('y') {
-						goto l676
+					position746, tokenIndex746, depth746 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l747
 					}
 					position++
-					goto l675
-				l676:
-					position, tokenIndex, depth = position675, tokenIndex675, depth675
-					if buffer[position] != rune('Y') {
-						goto l665
+					goto l746
+				l747:
+					position, tokenIndex, depth = position746, tokenIndex746, depth746
+					if buffer[position] != rune('R') {
+						goto l718
 					}
 					position++
 				}
-			l675:
+			l746:
 				if !_rules[rulesp]() {
-					goto l665
+					goto l718
 				}
-				if !_rules[ruleNumericLiteral]() {
-					goto l665
+				if !_rules[ruleSettingTarget]() {
+					goto l718
 				}
-				if !_rules[rulespOpt]() {
-					goto l665
+				if !_rules[ruleAction26]() {
+					goto l718
 				}
+				depth--
+				add(ruleSetLogLevelStmt, position719)
+			}
+			return true
+		l718:
+			position, tokenIndex, depth = position718, tokenIndex718, depth718
+			return false
+		},
+		/* 36 SetTraceStmt <- <(('s' / 'S') ('e' / 'E') ('t' / 'T') sp (('t' / 'T') ('r' / 'R') ('a' / 'A') ('c' / 'C') ('e' / 'E')) sp (On / Off) sp (('f' / 'F') ('o' / 'O') ('r' / 'R')) sp SettingTarget Action27)> */
+		func() bool {
+			position748, tokenIndex748, depth748 := position, tokenIndex, depth
+			{
+				position749 := position
+				depth++
 				{
-					position677, tokenIndex677, depth677 := position, tokenIndex, depth
-					if buffer[position] != rune('-') {
-						goto l677
+					position750, tokenIndex750, depth750 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l751
+					}
+					position++
+					goto l750
+				l751:
+					position, tokenIndex, depth = position750, tokenIndex750, depth750
+					if buffer[position] != rune('S') {
+						goto l748
 					}
 					position++
-					goto l678
-				l677:
-					position, tokenIndex, depth = position677, tokenIndex677, depth677
-				}
-			l678:
-				if !_rules[rulespOpt]() {
-					goto l665
 				}
+			l750:
 				{
-					position679, tokenIndex679, depth679 := position, tokenIndex, depth
-					{
-						position681, tokenIndex681, depth681 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l682
-						}
-						position++
-						goto l681
-					l682:
-						position, tokenIndex, depth = position681, tokenIndex681, depth681
-						if buffer[position] != rune('S') {
-							goto l680
-						}
-						position++
-					}
-				l681:
-					{
-						position683, tokenIndex683, depth683 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l684
-						}
-						position++
-						goto l683
-					l684:
-						position, tokenIndex, depth = position683, tokenIndex683, depth683
-						if buffer[position] != rune('T') {
-							goto l680
-						}
-						position++
-					}
-				l683:
-					goto l679
-				l680:
-					position, tokenIndex, depth = position679, tokenIndex679, depth679
-					{
-						position686, tokenIndex686, depth686 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l687
-						}
-						position++
-						goto l686
-					l687:
-						position, tokenIndex, depth = position686, tokenIndex686, depth686
-						if buffer[position] != rune('N') {
-							goto l685
-						}
-						position++
-					}
-				l686:
-					{
-						position688, tokenIndex688, depth688 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l689
-						}
-						position++
-						goto l688
-					l689:
-						position, tokenIndex, depth = position688, tokenIndex688, depth688
-						if buffer[position] != rune('D') {
-							goto l685
-						}
-						position++
-					}
-				l688:
-					goto l679
-				l685:
-					position, tokenIndex, depth = position679, tokenIndex679, depth679
-					{
-						position691, tokenIndex691, depth691 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l692
-						}
-						position++
-						goto l691
-					l692:
-						position, tokenIndex, depth = position691, tokenIndex691, depth691
-						if buffer[position] != rune('R') {
-							goto l690
-						}
-						position++
+					position752, tokenIndex752, depth752 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l753
 					}
-				l691:
-					{
-						position693, tokenIndex693, depth693 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l694
-						}
-						position++
-						goto l693
-					l694:
-						position, tokenIndex, depth = position693, tokenIndex693, depth693
-						if buffer[position] != rune('D') {
-							goto l690
-						}
-						position++
+					position++
+					goto l752
+				l753:
+					position, tokenIndex, depth = position752, tokenIndex752, depth752
+					if buffer[position] != rune('E') {
+						goto l748
 					}
-				l693:
-					goto l679
-				l690:
-					position, tokenIndex, depth = position679, tokenIndex679, depth679
-					{
-						position695, tokenIndex695, depth695 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l696
-						}
-						position++
-						goto l695
-					l696:
-						position, tokenIndex, depth = position695, tokenIndex695, depth695
-						if buffer[position] != rune('T') {
-							goto l665
-						}
-						position++
+					position++
+				}
+			l752:
+				{
+					position754, tokenIndex754, depth754 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l755
 					}
-				l695:
-					{
-						position697, tokenIndex697, depth697 := position, tokenIndex, depth
-						if buffer[position] != rune('h') {
-							goto l698
-						}
-						position++
-						goto l697
-					l698:
-						position, tokenIndex, depth = position697, tokenIndex697, depth697
-						if buffer[position] != rune('H') {
-							goto l665
-						}
-						position++
+					position++
+					goto l754
+				l755:
+					position, tokenIndex, depth = position754, tokenIndex754, depth754
+					if buffer[position] != rune('T') {
+						goto l748
 					}
-				l697:
+					position++
 				}
-			l679:
+			l754:
 				if !_rules[rulesp]() {
-					goto l665
+					goto l748
 				}
 				{
-					position699, tokenIndex699, depth699 := position, tokenIndex, depth
+					position756, tokenIndex756, depth756 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l700
+						goto l757
 					}
 					position++
-					goto l699
-				l700:
-					position, tokenIndex, depth = position699, tokenIndex699, depth699
+					goto l756
+				l757:
+					position, tokenIndex, depth = position756, tokenIndex756, depth756
 					if buffer[position] != rune('T') {
-						goto l665
+						goto l748
 					}
 					position++
 				}
-			l699:
+			l756:
 				{
-					position701, tokenIndex701, depth701 := position, tokenIndex, depth
-					if buffer[position] != rune('u') {
-						goto l702
+					position758, tokenIndex758, depth758 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l759
 					}
 					position++
-					goto l701
-				l702:
-					position, tokenIndex, depth = position701, tokenIndex701, depth701
-					if buffer[position] != rune('U') {
-						goto l665
+					goto l758
+				l759:
+					position, tokenIndex, depth = position758, tokenIndex758, depth758
+					if buffer[position] != rune('R') {
+						goto l748
 					}
 					position++
 				}
-			l701:
+			l758:
 				{
-					position703, tokenIndex703, depth703 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l704
+					position760, tokenIndex760, depth760 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l761
 					}
 					position++
-					goto l703
-				l704:
-					position, tokenIndex, depth = position703, tokenIndex703, depth703
-					if buffer[position] != rune('P') {
-						goto l665
+					goto l760
+				l761:
+					position, tokenIndex, depth = position760, tokenIndex760, depth760
+					if buffer[position] != rune('A') {
+						goto l748
 					}
 					position++
 				}
-			l703:
+			l760:
 				{
-					position705, tokenIndex705, depth705 := position, tokenIndex, depth
-					if buffer[position] != rune('l') {
-						goto l706
+					position762, tokenIndex762, depth762 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l763
 					}
 					position++
-					goto l705
-				l706:
-					position, tokenIndex, depth = position705, tokenIndex705, depth705
-					if buffer[position] != rune('L') {
-						goto l665
+					goto l762
+				l763:
+					position, tokenIndex, depth = position762, tokenIndex762, depth762
+					if buffer[position] != rune('C') {
+						goto l748
 					}
 					position++
 				}
-			l705:
+			l762:
 				{
-					position707, tokenIndex707, depth707 := position, tokenIndex, depth
+					position764, tokenIndex764, depth764 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l708
+						goto l765
 					}
 					position++
-					goto l707
-				l708:
-					position, tokenIndex, depth = position707, tokenIndex707, depth707
+					goto l764
+				l765:
+					position, tokenIndex, depth = position764, tokenIndex764, depth764
 					if buffer[position] != rune('E') {
-						goto l665
+						goto l748
 					}
 					position++
 				}
-			l707:
-				if !_rules[ruleAction27]() {
-					goto l665
+			l764:
+				if !_rules[rulesp]() {
+					goto l748
 				}
-				depth--
-				add(ruleCountBasedSampling, position666)
-			}
-			return true
-		l665:
-			position, tokenIndex, depth = position665, tokenIndex665, depth665
-			return false
-		},
-		/* 36 RandomizedSampling <- <(('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E') sp (FloatLiteral / NumericLiteral) spOpt '%' Action28)> */
-		func() bool {
-			position709, tokenIndex709, depth709 := position, tokenIndex, depth
-			{
-				position710 := position
-				depth++
 				{
-					position711, tokenIndex711, depth711 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l712
+					position766, tokenIndex766, depth766 := position, tokenIndex, depth
+					if !_rules[ruleOn]() {
+						goto l767
 					}
-					position++
-					goto l711
-				l712:
-					position, tokenIndex, depth = position711, tokenIndex711, depth711
-					if buffer[position] != rune('S') {
-						goto l709
+					goto l766
+				l767:
+					position, tokenIndex, depth = position766, tokenIndex766, depth766
+					if !_rules[ruleOff]() {
+						goto l748
 					}
-					position++
 				}
-			l711:
+			l766:
+				if !_rules[rulesp]() {
+					goto l748
+				}
 				{
-					position713, tokenIndex713, depth713 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l714
+					position768, tokenIndex768, depth768 := position, tokenIndex, depth
+					if buffer[position] != rune('f') {
+						goto l769
 					}
 					position++
-					goto l713
-				l714:
-					position, tokenIndex, depth = position713, tokenIndex713, depth713
-					if buffer[position] != rune('A') {
-						goto l709
+					goto l768
+				l769:
+					position, tokenIndex, depth = position768, tokenIndex768, depth768
+					if buffer[position] != rune('F') {
+						goto l748
 					}
 					position++
 				}
-			l713:
+			l768:
 				{
-					position715, tokenIndex715, depth715 := position, tokenIndex, depth
-					if buffer[position] != rune('m') {
-						goto l716
+					position770, tokenIndex770, depth770 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l771
 					}
 					position++
-					goto l715
-				l716:
-					position, tokenIndex, depth = position715, tokenIndex715, depth715
-					if buffer[position] != rune('M') {
-						goto l709
+					goto l770
+				l771:
+					position, tokenIndex, depth = position770, tokenIndex770, depth770
+					if buffer[position] != rune('O') {
+						goto l748
 					}
 					position++
 				}
-			l715:
+			l770:
 				{
-					position717, tokenIndex717, depth717 := position, tokenIndex, depth
-					if buffer[position] != rune('p') {
-						goto l718
+					position772, tokenIndex772, depth772 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l773
 					}
 					position++
-					goto l717
-				l718:
-					position, tokenIndex, depth = position717, tokenIndex717, depth717
-					if buffer[position] != rune('P') {
-						goto l709
+					goto l772
+				l773:
+					position, tokenIndex, depth = position772, tokenIndex772, depth772
+					if buffer[position] != rune('R') {
+						goto l748
 					}
 					position++
 				}
-			l717:
+			l772:
+				if !_rules[rulesp]() {
+					goto l748
+				}
+				if !_rules[ruleSettingTarget]() {
+					goto l748
+				}
+				if !_rules[ruleAction27]() {
+					goto l748
+				}
+				depth--
+				add(ruleSetTraceStmt, position749)
+			}
+			return true
+		l748:
+			position, tokenIndex, depth = position748, tokenIndex748, depth748
+			return false
+		},
+		/* 37 SetRecoveryPolicyStmt <- <(('s' / 'S') ('e' / 'E') ('t' / 'T') sp (('r' / 'R') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y')) sp (('p' / 'P') ('o' / 'O') ('l' / 'L') ('i' / 'I') ('c' / 'C') ('y' / 'Y')) sp Identifier sp (('f' / 'F') ('o' / 'O') ('r' / 'R')) sp SettingTarget SourceSinkSpecs Action28)> */
+		func() bool {
+			position774, tokenIndex774, depth774 := position, tokenIndex, depth
+			{
+				position775 := position
+				depth++
 				{
-					position719, tokenIndex719, depth719 := position, tokenIndex, depth
-					if buffer[position] != rune('l') {
-						goto l720
+					position776, tokenIndex776, depth776 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l777
 					}
 					position++
-					goto l719
-				l720:
-					position, tokenIndex, depth = position719, tokenIndex719, depth719
-					if buffer[position] != rune('L') {
-						goto l709
+					goto l776
+				l777:
+					position, tokenIndex, depth = position776, tokenIndex776, depth776
+					if buffer[position] != rune('S') {
+						goto l774
 					}
 					position++
 				}
-			l719:
+			l776:
 				{
-					position721, tokenIndex721, depth721 := position, tokenIndex, depth
+					position778, tokenIndex778, depth778 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l722
+						goto l779
 					}
 					position++
-					goto l721
-				l722:
-					position, tokenIndex, depth = position721, tokenIndex721, depth721
+					goto l778
+				l779:
+					position, tokenIndex, depth = position778, tokenIndex778, depth778
 					if buffer[position] != rune('E') {
-						goto l709
+						goto l774
 					}
 					position++
 				}
-			l721:
-				if !_rules[rulesp]() {
-					goto l709
-				}
+			l778:
 				{
-					position723, tokenIndex723, depth723 := position, tokenIndex, depth
-					if !_rules[ruleFloatLiteral]() {
-						goto l724
+					position780, tokenIndex780, depth780 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l781
 					}
-					goto l723
-				l724:
-					position, tokenIndex, depth = position723, tokenIndex723, depth723
-					if !_rules[ruleNumericLiteral]() {
-						goto l709
+					position++
+					goto l780
+				l781:
+					position, tokenIndex, depth = position780, tokenIndex780, depth780
+					if buffer[position] != rune('T') {
+						goto l774
 					}
+					position++
 				}
-			l723:
-				if !_rules[rulespOpt]() {
-					goto l709
-				}
-				if buffer[position] != rune('%') {
-					goto l709
-				}
-				position++
-				if !_rules[ruleAction28]() {
-					goto l709
+			l780:
+				if !_rules[rulesp]() {
+					goto l774
 				}
-				depth--
-				add(ruleRandomizedSampling, position710)
-			}
-			return true
-		l709:
-			position, tokenIndex, depth = position709, tokenIndex709, depth709
-			return false
-		},
-		/* 37 TimeBasedSampling <- <(TimeBasedSamplingSeconds / TimeBasedSamplingMilliseconds)> */
-		func() bool {
-			position725, tokenIndex725, depth725 := position, tokenIndex, depth
-			{
-				position726 := position
-				depth++
 				{
-					position727, tokenIndex727, depth727 := position, tokenIndex, depth
-					if !_rules[ruleTimeBasedSamplingSeconds]() {
-						goto l728
+					position782, tokenIndex782, depth782 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l783
 					}
-					goto l727
-				l728:
-					position, tokenIndex, depth = position727, tokenIndex727, depth727
-					if !_rules[ruleTimeBasedSamplingMilliseconds]() {
-						goto l725
+					position++
+					goto l782
+				l783:
+					position, tokenIndex, depth = position782, tokenIndex782, depth782
+					if buffer[position] != rune('R') {
+						goto l774
 					}
+					position++
 				}
-			l727:
-				depth--
-				add(ruleTimeBasedSampling, position726)
-			}
-			return true
-		l725:
-			position, tokenIndex, depth = position725, tokenIndex725, depth725
-			return false
-		},
-		/* 38 TimeBasedSamplingSeconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action29)> */
-		func() bool {
-			position729, tokenIndex729, depth729 := position, tokenIndex, depth
-			{
-				position730 := position
-				depth++
+			l782:
 				{
-					position731, tokenIndex731, depth731 := position, tokenIndex, depth
+					position784, tokenIndex784, depth784 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l732
+						goto l785
 					}
 					position++
-					goto l731
-				l732:
-					position, tokenIndex, depth = position731, tokenIndex731, depth731
+					goto l784
+				l785:
+					position, tokenIndex, depth = position784, tokenIndex784, depth784
 					if buffer[position] != rune('E') {
-						goto l729
+						goto l774
+					}
+					position++
+				}
+			l784:
+				{
+					position786, tokenIndex786, depth786 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l787
+					}
+					position++
+					goto l786
+				l787:
+					position, tokenIndex, depth = position786, tokenIndex786, depth786
+					if buffer[position] != rune('C') {
+						goto l774
+					}
+					position++
+				}
+			l786:
+				{
+					position788, tokenIndex788, depth788 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l789
+					}
+					position++
+					goto l788
+				l789:
+					position, tokenIndex, depth = position788, tokenIndex788, depth788
+					if buffer[position] != rune('O') {
+						goto l774
 					}
 					position++
 				}
-			l731:
+			l788:
 				{
-					position733, tokenIndex733, depth733 := position, tokenIndex, depth
+					position790, tokenIndex790, depth790 := position, tokenIndex, depth
 					if buffer[position] != rune('v') {
-						goto l734
+						goto l791
 					}
 					position++
-					goto l733
-				l734:
-					position, tokenIndex, depth = position733, tokenIndex733, depth733
+					goto l790
+				l791:
+					position, tokenIndex, depth = position790, tokenIndex790, depth790
 					if buffer[position] != rune('V') {
-						goto l729
+						goto l774
 					}
 					position++
 				}
-			l733:
+			l790:
 				{
-					position735, tokenIndex735, depth735 := position, tokenIndex, depth
+					position792, tokenIndex792, depth792 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l736
+						goto l793
 					}
 					position++
-					goto l735
-				l736:
-					position, tokenIndex, depth = position735, tokenIndex735, depth735
+					goto l792
+				l793:
+					position, tokenIndex, depth = position792, tokenIndex792, depth792
 					if buffer[position] != rune('E') {
-						goto l729
+						goto l774
 					}
 					position++
 				}
-			l735:
+			l792:
 				{
-					position737, tokenIndex737, depth737 := position, tokenIndex, depth
+					position794, tokenIndex794, depth794 := position, tokenIndex, depth
 					if buffer[position] != rune('r') {
-						goto l738
+						goto l795
 					}
 					position++
-					goto l737
-				l738:
-					position, tokenIndex, depth = position737, tokenIndex737, depth737
+					goto l794
+				l795:
+					position, tokenIndex, depth = position794, tokenIndex794, depth794
 					if buffer[position] != rune('R') {
-						goto l729
+						goto l774
 					}
 					position++
 				}
-			l737:
+			l794:
 				{
-					position739, tokenIndex739, depth739 := position, tokenIndex, depth
+					position796, tokenIndex796, depth796 := position, tokenIndex, depth
 					if buffer[position] != rune('y') {
-						goto l740
+						goto l797
 					}
 					position++
-					goto l739
-				l740:
-					position, tokenIndex, depth = position739, tokenIndex739, depth739
+					goto l796
+				l797:
+					position, tokenIndex, depth = position796, tokenIndex796, depth796
 					if buffer[position] != rune('Y') {
-						goto l729
+						goto l774
 					}
 					position++
 				}
-			l739:
+			l796:
 				if !_rules[rulesp]() {
-					goto l729
+					goto l774
 				}
 				{
-					position741, tokenIndex741, depth741 := position, tokenIndex, depth
-					if !_rules[ruleFloatLiteral]() {
-						goto l742
+					position798, tokenIndex798, depth798 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l799
 					}
-					goto l741
-				l742:
-					position, tokenIndex, depth = position741, tokenIndex741, depth741
-					if !_rules[ruleNumericLiteral]() {
-						goto l729
+					position++
+					goto l798
+				l799:
+					position, tokenIndex, depth = position798, tokenIndex798, depth798
+					if buffer[position] != rune('P') {
+						goto l774
 					}
+					position++
 				}
-			l741:
-				if !_rules[rulesp]() {
-					goto l729
+			l798:
+				{
+					position800, tokenIndex800, depth800 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l801
+					}
+					position++
+					goto l800
+				l801:
+					position, tokenIndex, depth = position800, tokenIndex800, depth800
+					if buffer[position] != rune('O') {
+						goto l774
+					}
+					position++
 				}
+			l800:
 				{
-					position743, tokenIndex743, depth743 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l744
+					position802, tokenIndex802, depth802 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l803
 					}
 					position++
-					goto l743
-				l744:
-					position, tokenIndex, depth = position743, tokenIndex743, depth743
-					if buffer[position] != rune('S') {
-						goto l729
+					goto l802
+				l803:
+					position, tokenIndex, depth = position802, tokenIndex802, depth802
+					if buffer[position] != rune('L') {
+						goto l774
 					}
 					position++
 				}
-			l743:
+			l802:
 				{
-					position745, tokenIndex745, depth745 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l746
+					position804, tokenIndex804, depth804 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l805
 					}
 					position++
-					goto l745
-				l746:
-					position, tokenIndex, depth = position745, tokenIndex745, depth745
-					if buffer[position] != rune('E') {
-						goto l729
+					goto l804
+				l805:
+					position, tokenIndex, depth = position804, tokenIndex804, depth804
+					if buffer[position] != rune('I') {
+						goto l774
 					}
 					position++
 				}
-			l745:
+			l804:
 				{
-					position747, tokenIndex747, depth747 := position, tokenIndex, depth
+					position806, tokenIndex806, depth806 := position, tokenIndex, depth
 					if buffer[position] != rune('c') {
-						goto l748
+						goto l807
 					}
 					position++
-					goto l747
-				l748:
-					position, tokenIndex, depth = position747, tokenIndex747, depth747
+					goto l806
+				l807:
+					position, tokenIndex, depth = position806, tokenIndex806, depth806
 					if buffer[position] != rune('C') {
-						goto l729
+						goto l774
 					}
 					position++
 				}
-			l747:
+			l806:
 				{
-					position749, tokenIndex749, depth749 := position, tokenIndex, depth
-					if buffer[position] != rune('o') {
-						goto l750
+					position808, tokenIndex808, depth808 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l809
 					}
 					position++
-					goto l749
-				l750:
-					position, tokenIndex, depth = position749, tokenIndex749, depth749
-					if buffer[position] != rune('O') {
-						goto l729
+					goto l808
+				l809:
+					position, tokenIndex, depth = position808, tokenIndex808, depth808
+					if buffer[position] != rune('Y') {
+						goto l774
 					}
 					position++
 				}
-			l749:
+			l808:
+				if !_rules[rulesp]() {
+					goto l774
+				}
+				if !_rules[ruleIdentifier]() {
+					goto l774
+				}
+				if !_rules[rulesp]() {
+					goto l774
+				}
 				{
-					position751, tokenIndex751, depth751 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l752
+					position810, tokenIndex810, depth810 := position, tokenIndex, depth
+					if buffer[position] != rune('f') {
+						goto l811
 					}
 					position++
-					goto l751
-				l752:
-					position, tokenIndex, depth = position751, tokenIndex751, depth751
-					if buffer[position] != rune('N') {
-						goto l729
+					goto l810
+				l811:
+					position, tokenIndex, depth = position810, tokenIndex810, depth810
+					if buffer[position] != rune('F') {
+						goto l774
 					}
 					position++
 				}
-			l751:
+			l810:
 				{
-					position753, tokenIndex753, depth753 := position, tokenIndex, depth
-					if buffer[position] != rune('d') {
-						goto l754
+					position812, tokenIndex812, depth812 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l813
 					}
 					position++
-					goto l753
-				l754:
-					position, tokenIndex, depth = position753, tokenIndex753, depth753
-					if buffer[position] != rune('D') {
-						goto l729
+					goto l812
+				l813:
+					position, tokenIndex, depth = position812, tokenIndex812, depth812
+					if buffer[position] != rune('O') {
+						goto l774
 					}
 					position++
 				}
-			l753:
+			l812:
 				{
-					position755, tokenIndex755, depth755 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l756
+					position814, tokenIndex814, depth814 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l815
 					}
 					position++
-					goto l755
-				l756:
-					position, tokenIndex, depth = position755, tokenIndex755, depth755
-					if buffer[position] != rune('S') {
-						goto l729
+					goto l814
+				l815:
+					position, tokenIndex, depth = position814, tokenIndex814, depth814
+					if buffer[position] != rune('R') {
+						goto l774
 					}
 					position++
 				}
-			l755:
-				if !_rules[ruleAction29]() {
-					goto l729
+			l814:
+				if !_rules[rulesp]() {
+					goto l774
+				}
+				if !_rules[ruleSettingTarget]() {
+					goto l774
+				}
+				if !_rules[ruleSourceSinkSpecs]() {
+					goto l774
+				}
+				if !_rules[ruleAction28]() {
+					goto l774
 				}
 				depth--
-				add(ruleTimeBasedSamplingSeconds, position730)
+				add(ruleSetRecoveryPolicyStmt, position775)
 			}
 			return true
-		l729:
-			position, tokenIndex, depth = position729, tokenIndex729, depth729
+		l774:
+			position, tokenIndex, depth = position774, tokenIndex774, depth774
 			return false
 		},
-		/* 39 TimeBasedSamplingMilliseconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action30)> */
+		/* 38 SettingTarget <- <((NodeTarget / TopologyTarget) sp StreamIdentifier)> */
 		func() bool {
-			position757, tokenIndex757, depth757 := position, tokenIndex, depth
+			position816, tokenIndex816, depth816 := position, tokenIndex, depth
 			{
-				position758 := position
+				position817 := position
 				depth++
 				{
-					position759, tokenIndex759, depth759 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l760
+					position818, tokenIndex818, depth818 := position, tokenIndex, depth
+					if !_rules[ruleNodeTarget]() {
+						goto l819
 					}
-					position++
-					goto l759
-				l760:
-					position, tokenIndex, depth = position759, tokenIndex759, depth759
-					if buffer[position] != rune('E') {
-						goto l757
+					goto l818
+				l819:
+					position, tokenIndex, depth = position818, tokenIndex818, depth818
+					if !_rules[ruleTopologyTarget]() {
+						goto l816
 					}
-					position++
 				}
-			l759:
+			l818:
+				if !_rules[rulesp]() {
+					goto l816
+				}
+				if !_rules[ruleStreamIdentifier]() {
+					goto l816
+				}
+				depth--
+				add(ruleSettingTarget, position817)
+			}
+			return true
+		l816:
+			position, tokenIndex, depth = position816, tokenIndex816, depth816
+			return false
+		},
+		/* 39 EvalStmt <- <(('e' / 'E') ('v' / 'V') ('a' / 'A') ('l' / 'L') sp Expression <(sp (('o' / 'O') ('n' / 'N')) sp MapExpr)?> Action29)> */
+		func() bool {
+			position820, tokenIndex820, depth820 := position, tokenIndex, depth
+			{
+				position821 := position
+				depth++
 				{
-					position761, tokenIndex761, depth761 := position, tokenIndex, depth
-					if buffer[position] != rune('v') {
-						goto l762
+					position822, tokenIndex822, depth822 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l823
 					}
 					position++
-					goto l761
-				l762:
-					position, tokenIndex, depth = position761, tokenIndex761, depth761
-					if buffer[position] != rune('V') {
-						goto l757
+					goto l822
+				l823:
+					position, tokenIndex, depth = position822, tokenIndex822, depth822
+					if buffer[position] != rune('E') {
+						goto l820
 					}
 					position++
 				}
-			l761:
+			l822:
 				{
-					position763, tokenIndex763, depth763 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l764
+					position824, tokenIndex824, depth824 := position, tokenIndex, depth
+					if buffer[position] != rune('v') {
+						goto l825
 					}
 					position++
-					goto l763
-				l764:
-					position, tokenIndex, depth = position763, tokenIndex763, depth763
-					if buffer[position] != rune('E') {
-						goto l757
+					goto l824
+				l825:
+					position, tokenIndex, depth = position824, tokenIndex824, depth824
+					if buffer[position] != rune('V') {
+						goto l820
 					}
 					position++
 				}
-			l763:
+			l824:
 				{
-					position765, tokenIndex765, depth765 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l766
+					position826, tokenIndex826, depth826 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l827
 					}
 					position++
-					goto l765
-				l766:
-					position, tokenIndex, depth = position765, tokenIndex765, depth765
-					if buffer[position] != rune('R') {
-						goto l757
+					goto l826
+				l827:
+					position, tokenIndex, depth = position826, tokenIndex826, depth826
+					if buffer[position] != rune('A') {
+						goto l820
 					}
 					position++
 				}
-			l765:
+			l826:
 				{
-					position767, tokenIndex767, depth767 := position, tokenIndex, depth
-					if buffer[position] != rune('y') {
-						goto l768
+					position828, tokenIndex828, depth828 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l829
 					}
 					position++
-					goto l767
-				l768:
-					position, tokenIndex, depth = position767, tokenIndex767, depth767
-					if buffer[position] != rune('Y') {
-						goto l757
+					goto l828
+				l829:
+					position, tokenIndex, depth = position828, tokenIndex828, depth828
+					if buffer[position] != rune('L') {
+						goto l820
 					}
 					position++
 				}
-			l767:
+			l828:
 				if !_rules[rulesp]() {
-					goto l757
+					goto l820
 				}
-				{
-					position769, tokenIndex769, depth769 := position, tokenIndex, depth
-					if !_rules[ruleFloatLiteral]() {
-						goto l770
-					}
-					goto l769
-				l770:
-					position, tokenIndex, depth = position769, tokenIndex769, depth769
-					if !_rules[ruleNumericLiteral]() {
-						goto l757
-					}
-				}
-			l769:
-				if !_rules[rulesp]() {
-					goto l757
-				}
-				{
-					position771, tokenIndex771, depth771 := position, tokenIndex, depth
-					if buffer[position] != rune('m') {
-						goto l772
-					}
-					position++
-					goto l771
-				l772:
-					position, tokenIndex, depth = position771, tokenIndex771, depth771
-					if buffer[position] != rune('M') {
-						goto l757
-					}
-					position++
-				}
-			l771:
-				{
-					position773, tokenIndex773, depth773 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l774
-					}
-					position++
-					goto l773
-				l774:
-					position, tokenIndex, depth = position773, tokenIndex773, depth773
-					if buffer[position] != rune('I') {
-						goto l757
-					}
-					position++
-				}
-			l773:
-				{
-					position775, tokenIndex775, depth775 := position, tokenIndex, depth
-					if buffer[position] != rune('l') {
-						goto l776
-					}
-					position++
-					goto l775
-				l776:
-					position, tokenIndex, depth = position775, tokenIndex775, depth775
-					if buffer[position] != rune('L') {
-						goto l757
-					}
-					position++
+				if !_rules[ruleExpression]() {
+					goto l820
 				}
-			l775:
 				{
-					position777, tokenIndex777, depth777 := position, tokenIndex, depth
-					if buffer[position] != rune('l') {
-						goto l778
-					}
-					position++
-					goto l777
-				l778:
-					position, tokenIndex, depth = position777, tokenIndex777, depth777
-					if buffer[position] != rune('L') {
-						goto l757
+					position830 := position
+					depth++
+					{
+						position831, tokenIndex831, depth831 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l831
+						}
+						{
+							position833, tokenIndex833, depth833 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l834
+							}
+							position++
+							goto l833
+						l834:
+							position, tokenIndex, depth = position833, tokenIndex833, depth833
+							if buffer[position] != rune('O') {
+								goto l831
+							}
+							position++
+						}
+					l833:
+						{
+							position835, tokenIndex835, depth835 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l836
+							}
+							position++
+							goto l835
+						l836:
+							position, tokenIndex, depth = position835, tokenIndex835, depth835
+							if buffer[position] != rune('N') {
+								goto l831
+							}
+							position++
+						}
+					l835:
+						if !_rules[rulesp]() {
+							goto l831
+						}
+						if !_rules[ruleMapExpr]() {
+							goto l831
+						}
+						goto l832
+					l831:
+						position, tokenIndex, depth = position831, tokenIndex831, depth831
 					}
-					position++
+				l832:
+					depth--
+					add(rulePegText, position830)
 				}
-			l777:
-				{
-					position779, tokenIndex779, depth779 := position, tokenIndex, depth
-					if buffer[position] != rune('i') {
-						goto l780
-					}
-					position++
-					goto l779
-				l780:
-					position, tokenIndex, depth = position779, tokenIndex779, depth779
-					if buffer[position] != rune('I') {
-						goto l757
-					}
-					position++
+				if !_rules[ruleAction29]() {
+					goto l820
 				}
-			l779:
+				depth--
+				add(ruleEvalStmt, position821)
+			}
+			return true
+		l820:
+			position, tokenIndex, depth = position820, tokenIndex820, depth820
+			return false
+		},
+		/* 40 ShowStmt <- <(('s' / 'S') ('h' / 'H') ('o' / 'O') ('w' / 'W') sp ShowTarget <(sp (('i' / 'I') ('n' / 'N')) sp StreamIdentifier)?> Action30)> */
+		func() bool {
+			position837, tokenIndex837, depth837 := position, tokenIndex, depth
+			{
+				position838 := position
+				depth++
 				{
-					position781, tokenIndex781, depth781 := position, tokenIndex, depth
+					position839, tokenIndex839, depth839 := position, tokenIndex, depth
 					if buffer[position] != rune('s') {
-						goto l782
+						goto l840
 					}
 					position++
-					goto l781
-				l782:
-					position, tokenIndex, depth = position781, tokenIndex781, depth781
+					goto l839
+				l840:
+					position, tokenIndex, depth = position839, tokenIndex839, depth839
 					if buffer[position] != rune('S') {
-						goto l757
-					}
-					position++
-				}
-			l781:
-				{
-					position783, tokenIndex783, depth783 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l784
-					}
-					position++
-					goto l783
-				l784:
-					position, tokenIndex, depth = position783, tokenIndex783, depth783
-					if buffer[position] != rune('E') {
-						goto l757
+						goto l837
 					}
 					position++
 				}
-			l783:
+			l839:
 				{
-					position785, tokenIndex785, depth785 := position, tokenIndex, depth
-					if buffer[position] != rune('c') {
-						goto l786
+					position841, tokenIndex841, depth841 := position, tokenIndex, depth
+					if buffer[position] != rune('h') {
+						goto l842
 					}
 					position++
-					goto l785
-				l786:
-					position, tokenIndex, depth = position785, tokenIndex785, depth785
-					if buffer[position] != rune('C') {
-						goto l757
+					goto l841
+				l842:
+					position, tokenIndex, depth = position841, tokenIndex841, depth841
+					if buffer[position] != rune('H') {
+						goto l837
 					}
 					position++
 				}
-			l785:
+			l841:
 				{
-					position787, tokenIndex787, depth787 := position, tokenIndex, depth
+					position843, tokenIndex843, depth843 := position, tokenIndex, depth
 					if buffer[position] != rune('o') {
-						goto l788
+						goto l844
 					}
 					position++
-					goto l787
-				l788:
-					position, tokenIndex, depth = position787, tokenIndex787, depth787
+					goto l843
+				l844:
+					position, tokenIndex, depth = position843, tokenIndex843, depth843
 					if buffer[position] != rune('O') {
-						goto l757
-					}
-					position++
-				}
-			l787:
-				{
-					position789, tokenIndex789, depth789 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l790
-					}
-					position++
-					goto l789
-				l790:
-					position, tokenIndex, depth = position789, tokenIndex789, depth789
-					if buffer[position] != rune('N') {
-						goto l757
+						goto l837
 					}
 					position++
 				}
-			l789:
+			l843:
 				{
-					position791, tokenIndex791, depth791 := position, tokenIndex, depth
-					if buffer[position] != rune('d') {
-						goto l792
+					position845, tokenIndex845, depth845 := position, tokenIndex, depth
+					if buffer[position] != rune('w') {
+						goto l846
 					}
 					position++
-					goto l791
-				l792:
-					position, tokenIndex, depth = position791, tokenIndex791, depth791
-					if buffer[position] != rune('D') {
-						goto l757
+					goto l845
+				l846:
+					position, tokenIndex, depth = position845, tokenIndex845, depth845
+					if buffer[position] != rune('W') {
+						goto l837
 					}
 					position++
 				}
-			l791:
-				{
-					position793, tokenIndex793, depth793 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l794
-					}
-					position++
-					goto l793
-				l794:
-					position, tokenIndex, depth = position793, tokenIndex793, depth793
-					if buffer[position] != rune('S') {
-						goto l757
-					}
-					position++
+			l845:
+				if !_rules[rulesp]() {
+					goto l837
 				}
-			l793:
-				if !_rules[ruleAction30]() {
-					goto l757
+				if !_rules[ruleShowTarget]() {
+					goto l837
 				}
-				depth--
-				add(ruleTimeBasedSamplingMilliseconds, position758)
-			}
-			return true
-		l757:
-			position, tokenIndex, depth = position757, tokenIndex757, depth757
-			return false
-		},
-		/* 40 Projections <- <(<(sp Projection (spOpt ',' spOpt Projection)*)> Action31)> */
-		func() bool {
-			position795, tokenIndex795, depth795 := position, tokenIndex, depth
-			{
-				position796 := position
-				depth++
 				{
-					position797 := position
+					position847 := position
 					depth++
-					if !_rules[rulesp]() {
-						goto l795
-					}
-					if !_rules[ruleProjection]() {
-						goto l795
-					}
-				l798:
 					{
-						position799, tokenIndex799, depth799 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l799
+						position848, tokenIndex848, depth848 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l848
 						}
-						if buffer[position] != rune(',') {
-							goto l799
+						{
+							position850, tokenIndex850, depth850 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l851
+							}
+							position++
+							goto l850
+						l851:
+							position, tokenIndex, depth = position850, tokenIndex850, depth850
+							if buffer[position] != rune('I') {
+								goto l848
+							}
+							position++
 						}
-						position++
-						if !_rules[rulespOpt]() {
-							goto l799
+					l850:
+						{
+							position852, tokenIndex852, depth852 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l853
+							}
+							position++
+							goto l852
+						l853:
+							position, tokenIndex, depth = position852, tokenIndex852, depth852
+							if buffer[position] != rune('N') {
+								goto l848
+							}
+							position++
 						}
-						if !_rules[ruleProjection]() {
-							goto l799
+					l852:
+						if !_rules[rulesp]() {
+							goto l848
+						}
+						if !_rules[ruleStreamIdentifier]() {
+							goto l848
 						}
-						goto l798
-					l799:
-						position, tokenIndex, depth = position799, tokenIndex799, depth799
+						goto l849
+					l848:
+						position, tokenIndex, depth = position848, tokenIndex848, depth848
 					}
+				l849:
 					depth--
-					add(rulePegText, position797)
+					add(rulePegText, position847)
 				}
-				if !_rules[ruleAction31]() {
-					goto l795
+				if !_rules[ruleAction30]() {
+					goto l837
 				}
 				depth--
-				add(ruleProjections, position796)
+				add(ruleShowStmt, position838)
 			}
 			return true
-		l795:
-			position, tokenIndex, depth = position795, tokenIndex795, depth795
+		l837:
+			position, tokenIndex, depth = position837, tokenIndex837, depth837
 			return false
 		},
-		/* 41 Projection <- <(AliasExpression / ExpressionOrWildcard)> */
+		/* 41 ShowTarget <- <(SourcesTarget / StreamsTarget / SinksTarget / StatesTarget / UDFsTarget)> */
 		func() bool {
-			position800, tokenIndex800, depth800 := position, tokenIndex, depth
+			position854, tokenIndex854, depth854 := position, tokenIndex, depth
 			{
-				position801 := position
+				position855 := position
 				depth++
 				{
-					position802, tokenIndex802, depth802 := position, tokenIndex, depth
-					if !_rules[ruleAliasExpression]() {
-						goto l803
+					position856, tokenIndex856, depth856 := position, tokenIndex, depth
+					if !_rules[ruleSourcesTarget]() {
+						goto l857
 					}
-					goto l802
-				l803:
-					position, tokenIndex, depth = position802, tokenIndex802, depth802
-					if !_rules[ruleExpressionOrWildcard]() {
-						goto l800
+					goto l856
+				l857:
+					position, tokenIndex, depth = position856, tokenIndex856, depth856
+					if !_rules[ruleStreamsTarget]() {
+						goto l858
+					}
+					goto l856
+				l858:
+					position, tokenIndex, depth = position856, tokenIndex856, depth856
+					if !_rules[ruleSinksTarget]() {
+						goto l859
+					}
+					goto l856
+				l859:
+					position, tokenIndex, depth = position856, tokenIndex856, depth856
+					if !_rules[ruleStatesTarget]() {
+						goto l860
+					}
+					goto l856
+				l860:
+					position, tokenIndex, depth = position856, tokenIndex856, depth856
+					if !_rules[ruleUDFsTarget]() {
+						goto l854
 					}
 				}
-			l802:
+			l856:
 				depth--
-				add(ruleProjection, position801)
+				add(ruleShowTarget, position855)
 			}
 			return true
-		l800:
-			position, tokenIndex, depth = position800, tokenIndex800, depth800
+		l854:
+			position, tokenIndex, depth = position854, tokenIndex854, depth854
 			return false
 		},
-		/* 42 AliasExpression <- <(ExpressionOrWildcard sp (('a' / 'A') ('s' / 'S')) sp TargetIdentifier Action32)> */
+		/* 42 Emitter <- <(sp (ISTREAM / DSTREAM / RSTREAM) EmitterOptions Action31)> */
 		func() bool {
-			position804, tokenIndex804, depth804 := position, tokenIndex, depth
+			position861, tokenIndex861, depth861 := position, tokenIndex, depth
 			{
-				position805 := position
+				position862 := position
 				depth++
-				if !_rules[ruleExpressionOrWildcard]() {
-					goto l804
-				}
 				if !_rules[rulesp]() {
-					goto l804
+					goto l861
 				}
 				{
-					position806, tokenIndex806, depth806 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l807
-					}
-					position++
-					goto l806
-				l807:
-					position, tokenIndex, depth = position806, tokenIndex806, depth806
-					if buffer[position] != rune('A') {
-						goto l804
+					position863, tokenIndex863, depth863 := position, tokenIndex, depth
+					if !_rules[ruleISTREAM]() {
+						goto l864
 					}
-					position++
-				}
-			l806:
-				{
-					position808, tokenIndex808, depth808 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l809
+					goto l863
+				l864:
+					position, tokenIndex, depth = position863, tokenIndex863, depth863
+					if !_rules[ruleDSTREAM]() {
+						goto l865
 					}
-					position++
-					goto l808
-				l809:
-					position, tokenIndex, depth = position808, tokenIndex808, depth808
-					if buffer[position] != rune('S') {
-						goto l804
+					goto l863
+				l865:
+					position, tokenIndex, depth = position863, tokenIndex863, depth863
+					if !_rules[ruleRSTREAM]() {
+						goto l861
 					}
-					position++
-				}
-			l808:
-				if !_rules[rulesp]() {
-					goto l804
 				}
-				if !_rules[ruleTargetIdentifier]() {
-					goto l804
+			l863:
+				if !_rules[ruleEmitterOptions]() {
+					goto l861
 				}
-				if !_rules[ruleAction32]() {
-					goto l804
+				if !_rules[ruleAction31]() {
+					goto l861
 				}
 				depth--
-				add(ruleAliasExpression, position805)
+				add(ruleEmitter, position862)
 			}
 			return true
-		l804:
-			position, tokenIndex, depth = position804, tokenIndex804, depth804
+		l861:
+			position, tokenIndex, depth = position861, tokenIndex861, depth861
 			return false
 		},
-		/* 43 WindowedFrom <- <(<(sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp Relations)?> Action33)> */
+		/* 43 EmitterOptions <- <(<(spOpt '[' spOpt EmitterOptionCombinations spOpt ']')?> Action32)> */
 		func() bool {
-			position810, tokenIndex810, depth810 := position, tokenIndex, depth
+			position866, tokenIndex866, depth866 := position, tokenIndex, depth
 			{
-				position811 := position
+				position867 := position
 				depth++
 				{
-					position812 := position
+					position868 := position
 					depth++
 					{
-						position813, tokenIndex813, depth813 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l813
+						position869, tokenIndex869, depth869 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l869
 						}
-						{
-							position815, tokenIndex815, depth815 := position, tokenIndex, depth
-							if buffer[position] != rune('f') {
-								goto l816
-							}
-							position++
-							goto l815
-						l816:
-							position, tokenIndex, depth = position815, tokenIndex815, depth815
-							if buffer[position] != rune('F') {
-								goto l813
-							}
-							position++
+						if buffer[position] != rune('[') {
+							goto l869
 						}
-					l815:
-						{
-							position817, tokenIndex817, depth817 := position, tokenIndex, depth
-							if buffer[position] != rune('r') {
-								goto l818
-							}
-							position++
-							goto l817
-						l818:
-							position, tokenIndex, depth = position817, tokenIndex817, depth817
-							if buffer[position] != rune('R') {
-								goto l813
-							}
-							position++
-						}
-					l817:
-						{
-							position819, tokenIndex819, depth819 := position, tokenIndex, depth
-							if buffer[position] != rune('o') {
-								goto l820
-							}
-							position++
-							goto l819
-						l820:
-							position, tokenIndex, depth = position819, tokenIndex819, depth819
-							if buffer[position] != rune('O') {
-								goto l813
-							}
-							position++
+						position++
+						if !_rules[rulespOpt]() {
+							goto l869
 						}
-					l819:
-						{
-							position821, tokenIndex821, depth821 := position, tokenIndex, depth
-							if buffer[position] != rune('m') {
-								goto l822
-							}
-							position++
-							goto l821
-						l822:
-							position, tokenIndex, depth = position821, tokenIndex821, depth821
-							if buffer[position] != rune('M') {
-								goto l813
-							}
-							position++
+						if !_rules[ruleEmitterOptionCombinations]() {
+							goto l869
 						}
-					l821:
-						if !_rules[rulesp]() {
-							goto l813
+						if !_rules[rulespOpt]() {
+							goto l869
 						}
-						if !_rules[ruleRelations]() {
-							goto l813
+						if buffer[position] != rune(']') {
+							goto l869
 						}
-						goto l814
-					l813:
-						position, tokenIndex, depth = position813, tokenIndex813, depth813
+						position++
+						goto l870
+					l869:
+						position, tokenIndex, depth = position869, tokenIndex869, depth869
 					}
-				l814:
+				l870:
 					depth--
-					add(rulePegText, position812)
+					add(rulePegText, position868)
 				}
-				if !_rules[ruleAction33]() {
-					goto l810
+				if !_rules[ruleAction32]() {
+					goto l866
 				}
 				depth--
-				add(ruleWindowedFrom, position811)
+				add(ruleEmitterOptions, position867)
 			}
 			return true
-		l810:
-			position, tokenIndex, depth = position810, tokenIndex810, depth810
+		l866:
+			position, tokenIndex, depth = position866, tokenIndex866, depth866
 			return false
 		},
-		/* 44 Interval <- <(TimeInterval / TuplesInterval)> */
+		/* 44 EmitterOptionCombinations <- <(EmitterLimit / (EmitterSample sp EmitterLimit) / EmitterSample / (EmitterChange (sp EmitterLimit)?))> */
 		func() bool {
-			position823, tokenIndex823, depth823 := position, tokenIndex, depth
+			position871, tokenIndex871, depth871 := position, tokenIndex, depth
 			{
-				position824 := position
+				position872 := position
 				depth++
 				{
-					position825, tokenIndex825, depth825 := position, tokenIndex, depth
-					if !_rules[ruleTimeInterval]() {
-						goto l826
+					position873, tokenIndex873, depth873 := position, tokenIndex, depth
+					if !_rules[ruleEmitterLimit]() {
+						goto l874
 					}
-					goto l825
-				l826:
-					position, tokenIndex, depth = position825, tokenIndex825, depth825
-					if !_rules[ruleTuplesInterval]() {
-						goto l823
+					goto l873
+				l874:
+					position, tokenIndex, depth = position873, tokenIndex873, depth873
+					if !_rules[ruleEmitterSample]() {
+						goto l875
+					}
+					if !_rules[rulesp]() {
+						goto l875
+					}
+					if !_rules[ruleEmitterLimit]() {
+						goto l875
+					}
+					goto l873
+				l875:
+					position, tokenIndex, depth = position873, tokenIndex873, depth873
+					if !_rules[ruleEmitterSample]() {
+						goto l876
 					}
+					goto l873
+				l876:
+					position, tokenIndex, depth = position873, tokenIndex873, depth873
+					if !_rules[ruleEmitterChange]() {
+						goto l871
+					}
+					{
+						position877, tokenIndex877, depth877 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l877
+						}
+						if !_rules[ruleEmitterLimit]() {
+							goto l877
+						}
+						goto l878
+					l877:
+						position, tokenIndex, depth = position877, tokenIndex877, depth877
+					}
+				l878:
 				}
-			l825:
+			l873:
 				depth--
-				add(ruleInterval, position824)
+				add(ruleEmitterOptionCombinations, position872)
 			}
 			return true
-		l823:
-			position, tokenIndex, depth = position823, tokenIndex823, depth823
+		l871:
+			position, tokenIndex, depth = position871, tokenIndex871, depth871
 			return false
 		},
-		/* 45 TimeInterval <- <((FloatLiteral / NumericLiteral) sp (SECONDS / MILLISECONDS) Action34)> */
+		/* 45 EmitterLimit <- <(('l' / 'L') ('i' / 'I') ('m' / 'M') ('i' / 'I') ('t' / 'T') sp NumericLiteral Action33)> */
 		func() bool {
-			position827, tokenIndex827, depth827 := position, tokenIndex, depth
+			position879, tokenIndex879, depth879 := position, tokenIndex, depth
 			{
-				position828 := position
+				position880 := position
 				depth++
 				{
-					position829, tokenIndex829, depth829 := position, tokenIndex, depth
-					if !_rules[ruleFloatLiteral]() {
-						goto l830
+					position881, tokenIndex881, depth881 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l882
 					}
-					goto l829
-				l830:
-					position, tokenIndex, depth = position829, tokenIndex829, depth829
-					if !_rules[ruleNumericLiteral]() {
-						goto l827
+					position++
+					goto l881
+				l882:
+					position, tokenIndex, depth = position881, tokenIndex881, depth881
+					if buffer[position] != rune('L') {
+						goto l879
 					}
+					position++
 				}
-			l829:
-				if !_rules[rulesp]() {
-					goto l827
+			l881:
+				{
+					position883, tokenIndex883, depth883 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l884
+					}
+					position++
+					goto l883
+				l884:
+					position, tokenIndex, depth = position883, tokenIndex883, depth883
+					if buffer[position] != rune('I') {
+						goto l879
+					}
+					position++
 				}
+			l883:
 				{
-					position831, tokenIndex831, depth831 := position, tokenIndex, depth
-					if !_rules[ruleSECONDS]() {
-						goto l832
+					position885, tokenIndex885, depth885 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l886
 					}
-					goto l831
-				l832:
-					position, tokenIndex, depth = position831, tokenIndex831, depth831
-					if !_rules[ruleMILLISECONDS]() {
-						goto l827
+					position++
+					goto l885
+				l886:
+					position, tokenIndex, depth = position885, tokenIndex885, depth885
+					if buffer[position] != rune('M') {
+						goto l879
 					}
+					position++
 				}
-			l831:
-				if !_rules[ruleAction34]() {
-					goto l827
+			l885:
+				{
+					position887, tokenIndex887, depth887 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l888
+					}
+					position++
+					goto l887
+				l888:
+					position, tokenIndex, depth = position887, tokenIndex887, depth887
+					if buffer[position] != rune('I') {
+						goto l879
+					}
+					position++
 				}
-				depth--
-				add(ruleTimeInterval, position828)
-			}
-			return true
-		l827:
-			position, tokenIndex, depth = position827, tokenIndex827, depth827
-			return false
-		},
-		/* 46 TuplesInterval <- <(NumericLiteral sp TUPLES Action35)> */
-		func() bool {
-			position833, tokenIndex833, depth833 := position, tokenIndex, depth
-			{
-				position834 := position
-				depth++
-				if !_rules[ruleNumericLiteral]() {
-					goto l833
+			l887:
+				{
+					position889, tokenIndex889, depth889 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l890
+					}
+					position++
+					goto l889
+				l890:
+					position, tokenIndex, depth = position889, tokenIndex889, depth889
+					if buffer[position] != rune('T') {
+						goto l879
+					}
+					position++
 				}
+			l889:
 				if !_rules[rulesp]() {
-					goto l833
+					goto l879
 				}
-				if !_rules[ruleTUPLES]() {
-					goto l833
+				if !_rules[ruleNumericLiteral]() {
+					goto l879
 				}
-				if !_rules[ruleAction35]() {
-					goto l833
+				if !_rules[ruleAction33]() {
+					goto l879
 				}
 				depth--
-				add(ruleTuplesInterval, position834)
+				add(ruleEmitterLimit, position880)
 			}
 			return true
-		l833:
-			position, tokenIndex, depth = position833, tokenIndex833, depth833
+		l879:
+			position, tokenIndex, depth = position879, tokenIndex879, depth879
 			return false
 		},
-		/* 47 Relations <- <(RelationLike (spOpt ',' spOpt RelationLike)*)> */
+		/* 46 EmitterSample <- <(CountBasedSampling / RandomizedSampling / TimeBasedSampling)> */
 		func() bool {
-			position835, tokenIndex835, depth835 := position, tokenIndex, depth
+			position891, tokenIndex891, depth891 := position, tokenIndex, depth
 			{
-				position836 := position
+				position892 := position
 				depth++
-				if !_rules[ruleRelationLike]() {
-					goto l835
-				}
-			l837:
 				{
-					position838, tokenIndex838, depth838 := position, tokenIndex, depth
-					if !_rules[rulespOpt]() {
-						goto l838
-					}
-					if buffer[position] != rune(',') {
-						goto l838
+					position893, tokenIndex893, depth893 := position, tokenIndex, depth
+					if !_rules[ruleCountBasedSampling]() {
+						goto l894
 					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l838
+					goto l893
+				l894:
+					position, tokenIndex, depth = position893, tokenIndex893, depth893
+					if !_rules[ruleRandomizedSampling]() {
+						goto l895
 					}
-					if !_rules[ruleRelationLike]() {
-						goto l838
+					goto l893
+				l895:
+					position, tokenIndex, depth = position893, tokenIndex893, depth893
+					if !_rules[ruleTimeBasedSampling]() {
+						goto l891
 					}
-					goto l837
-				l838:
-					position, tokenIndex, depth = position838, tokenIndex838, depth838
 				}
+			l893:
 				depth--
-				add(ruleRelations, position836)
+				add(ruleEmitterSample, position892)
 			}
 			return true
-		l835:
-			position, tokenIndex, depth = position835, tokenIndex835, depth835
+		l891:
+			position, tokenIndex, depth = position891, tokenIndex891, depth891
 			return false
 		},
-		/* 48 Filter <- <(<(sp (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) sp Expression)?> Action36)> */
+		/* 47 CountBasedSampling <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp NumericLiteral spOpt '-'? spOpt ((('s' / 'S') ('t' / 'T')) / (('n' / 'N') ('d' / 'D')) / (('r' / 'R') ('d' / 'D')) / (('t' / 'T') ('h' / 'H'))) sp (('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E')) Action34)> */
 		func() bool {
-			position839, tokenIndex839, depth839 := position, tokenIndex, depth
+			position896, tokenIndex896, depth896 := position, tokenIndex, depth
 			{
-				position840 := position
+				position897 := position
 				depth++
 				{
-					position841 := position
-					depth++
+					position898, tokenIndex898, depth898 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l899
+					}
+					position++
+					goto l898
+				l899:
+					position, tokenIndex, depth = position898, tokenIndex898, depth898
+					if buffer[position] != rune('E') {
+						goto l896
+					}
+					position++
+				}
+			l898:
+				{
+					position900, tokenIndex900, depth900 := position, tokenIndex, depth
+					if buffer[position] != rune('v') {
+						goto l901
+					}
+					position++
+					goto l900
+				l901:
+					position, tokenIndex, depth = position900, tokenIndex900, depth900
+					if buffer[position] != rune('V') {
+						goto l896
+					}
+					position++
+				}
+			l900:
+				{
+					position902, tokenIndex902, depth902 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l903
+					}
+					position++
+					goto l902
+				l903:
+					position, tokenIndex, depth = position902, tokenIndex902, depth902
+					if buffer[position] != rune('E') {
+						goto l896
+					}
+					position++
+				}
+			l902:
+				{
+					position904, tokenIndex904, depth904 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l905
+					}
+					position++
+					goto l904
+				l905:
+					position, tokenIndex, depth = position904, tokenIndex904, depth904
+					if buffer[position] != rune('R') {
+						goto l896
+					}
+					position++
+				}
+			l904:
+				{
+					position906, tokenIndex906, depth906 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l907
+					}
+					position++
+					goto l906
+				l907:
+					position, tokenIndex, depth = position906, tokenIndex906, depth906
+					if buffer[position] != rune('Y') {
+						goto l896
+					}
+					position++
+				}
+			l906:
+				if !_rules[rulesp]() {
+					goto l896
+				}
+				if !_rules[ruleNumericLiteral]() {
+					goto l896
+				}
+				if !_rules[rulespOpt]() {
+					goto l896
+				}
+				{
+					position908, tokenIndex908, depth908 := position, tokenIndex, depth
+					if buffer[position] != rune('-') {
+						goto l908
+					}
+					position++
+					goto l909
+				l908:
+					position, tokenIndex, depth = position908, tokenIndex908, depth908
+				}
+			l909:
+				if !_rules[rulespOpt]() {
+					goto l896
+				}
+				{
+					position910, tokenIndex910, depth910 := position, tokenIndex, depth
 					{
-						position842, tokenIndex842, depth842 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l842
+						position912, tokenIndex912, depth912 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l913
 						}
-						{
-							position844, tokenIndex844, depth844 := position, tokenIndex, depth
-							if buffer[position] != rune('w') {
-								goto l845
-							}
-							position++
-							goto l844
-						l845:
-							position, tokenIndex, depth = position844, tokenIndex844, depth844
-							if buffer[position] != rune('W') {
-								goto l842
-							}
-							position++
+						position++
+						goto l912
+					l913:
+						position, tokenIndex, depth = position912, tokenIndex912, depth912
+						if buffer[position] != rune('S') {
+							goto l911
 						}
-					l844:
-						{
-							position846, tokenIndex846, depth846 := position, tokenIndex, depth
-							if buffer[position] != rune('h') {
-								goto l847
-							}
-							position++
-							goto l846
-						l847:
-							position, tokenIndex, depth = position846, tokenIndex846, depth846
-							if buffer[position] != rune('H') {
-								goto l842
-							}
-							position++
+						position++
+					}
+				l912:
+					{
+						position914, tokenIndex914, depth914 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l915
 						}
-					l846:
-						{
-							position848, tokenIndex848, depth848 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l849
-							}
-							position++
-							goto l848
-						l849:
-							position, tokenIndex, depth = position848, tokenIndex848, depth848
-							if buffer[position] != rune('E') {
-								goto l842
-							}
-							position++
+						position++
+						goto l914
+					l915:
+						position, tokenIndex, depth = position914, tokenIndex914, depth914
+						if buffer[position] != rune('T') {
+							goto l911
 						}
-					l848:
-						{
-							position850, tokenIndex850, depth850 := position, tokenIndex, depth
-							if buffer[position] != rune('r') {
-								goto l851
-							}
-							position++
-							goto l850
-						l851:
-							position, tokenIndex, depth = position850, tokenIndex850, depth850
-							if buffer[position] != rune('R') {
-								goto l842
-							}
-							position++
+						position++
+					}
+				l914:
+					goto l910
+				l911:
+					position, tokenIndex, depth = position910, tokenIndex910, depth910
+					{
+						position917, tokenIndex917, depth917 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l918
 						}
-					l850:
-						{
-							position852, tokenIndex852, depth852 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l853
-							}
-							position++
-							goto l852
-						l853:
-							position, tokenIndex, depth = position852, tokenIndex852, depth852
-							if buffer[position] != rune('E') {
-								goto l842
-							}
-							position++
-						}
-					l852:
-						if !_rules[rulesp]() {
-							goto l842
-						}
-						if !_rules[ruleExpression]() {
-							goto l842
+						position++
+						goto l917
+					l918:
+						position, tokenIndex, depth = position917, tokenIndex917, depth917
+						if buffer[position] != rune('N') {
+							goto l916
 						}
-						goto l843
-					l842:
-						position, tokenIndex, depth = position842, tokenIndex842, depth842
+						position++
 					}
-				l843:
-					depth--
-					add(rulePegText, position841)
-				}
-				if !_rules[ruleAction36]() {
-					goto l839
-				}
-				depth--
-				add(ruleFilter, position840)
-			}
-			return true
-		l839:
-			position, tokenIndex, depth = position839, tokenIndex839, depth839
-			return false
-		},
-		/* 49 Grouping <- <(<(sp (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P')) sp (('b' / 'B') ('y' / 'Y')) sp GroupList)?> Action37)> */
-		func() bool {
-			position854, tokenIndex854, depth854 := position, tokenIndex, depth
-			{
-				position855 := position
-				depth++
-				{
-					position856 := position
-					depth++
+				l917:
 					{
-						position857, tokenIndex857, depth857 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l857
-						}
-						{
-							position859, tokenIndex859, depth859 := position, tokenIndex, depth
-							if buffer[position] != rune('g') {
-								goto l860
-							}
-							position++
-							goto l859
-						l860:
-							position, tokenIndex, depth = position859, tokenIndex859, depth859
-							if buffer[position] != rune('G') {
-								goto l857
-							}
-							position++
+						position919, tokenIndex919, depth919 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l920
 						}
-					l859:
-						{
-							position861, tokenIndex861, depth861 := position, tokenIndex, depth
-							if buffer[position] != rune('r') {
-								goto l862
-							}
-							position++
-							goto l861
-						l862:
-							position, tokenIndex, depth = position861, tokenIndex861, depth861
-							if buffer[position] != rune('R') {
-								goto l857
-							}
-							position++
+						position++
+						goto l919
+					l920:
+						position, tokenIndex, depth = position919, tokenIndex919, depth919
+						if buffer[position] != rune('D') {
+							goto l916
 						}
-					l861:
-						{
-							position863, tokenIndex863, depth863 := position, tokenIndex, depth
-							if buffer[position] != rune('o') {
-								goto l864
-							}
-							position++
-							goto l863
-						l864:
-							position, tokenIndex, depth = position863, tokenIndex863, depth863
-							if buffer[position] != rune('O') {
-								goto l857
-							}
-							position++
+						position++
+					}
+				l919:
+					goto l910
+				l916:
+					position, tokenIndex, depth = position910, tokenIndex910, depth910
+					{
+						position922, tokenIndex922, depth922 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l923
 						}
-					l863:
-						{
-							position865, tokenIndex865, depth865 := position, tokenIndex, depth
-							if buffer[position] != rune('u') {
-								goto l866
-							}
-							position++
-							goto l865
-						l866:
-							position, tokenIndex, depth = position865, tokenIndex865, depth865
-							if buffer[position] != rune('U') {
-								goto l857
-							}
-							position++
+						position++
+						goto l922
+					l923:
+						position, tokenIndex, depth = position922, tokenIndex922, depth922
+						if buffer[position] != rune('R') {
+							goto l921
 						}
-					l865:
-						{
-							position867, tokenIndex867, depth867 := position, tokenIndex, depth
-							if buffer[position] != rune('p') {
-								goto l868
-							}
-							position++
-							goto l867
-						l868:
-							position, tokenIndex, depth = position867, tokenIndex867, depth867
-							if buffer[position] != rune('P') {
-								goto l857
-							}
-							position++
+						position++
+					}
+				l922:
+					{
+						position924, tokenIndex924, depth924 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l925
 						}
-					l867:
-						if !_rules[rulesp]() {
-							goto l857
+						position++
+						goto l924
+					l925:
+						position, tokenIndex, depth = position924, tokenIndex924, depth924
+						if buffer[position] != rune('D') {
+							goto l921
 						}
-						{
-							position869, tokenIndex869, depth869 := position, tokenIndex, depth
-							if buffer[position] != rune('b') {
-								goto l870
-							}
-							position++
-							goto l869
-						l870:
-							position, tokenIndex, depth = position869, tokenIndex869, depth869
-							if buffer[position] != rune('B') {
-								goto l857
-							}
-							position++
+						position++
+					}
+				l924:
+					goto l910
+				l921:
+					position, tokenIndex, depth = position910, tokenIndex910, depth910
+					{
+						position926, tokenIndex926, depth926 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l927
 						}
-					l869:
-						{
-							position871, tokenIndex871, depth871 := position, tokenIndex, depth
-							if buffer[position] != rune('y') {
-								goto l872
-							}
-							position++
-							goto l871
-						l872:
-							position, tokenIndex, depth = position871, tokenIndex871, depth871
-							if buffer[position] != rune('Y') {
-								goto l857
-							}
-							position++
+						position++
+						goto l926
+					l927:
+						position, tokenIndex, depth = position926, tokenIndex926, depth926
+						if buffer[position] != rune('T') {
+							goto l896
 						}
-					l871:
-						if !_rules[rulesp]() {
-							goto l857
+						position++
+					}
+				l926:
+					{
+						position928, tokenIndex928, depth928 := position, tokenIndex, depth
+						if buffer[position] != rune('h') {
+							goto l929
 						}
-						if !_rules[ruleGroupList]() {
-							goto l857
+						position++
+						goto l928
+					l929:
+						position, tokenIndex, depth = position928, tokenIndex928, depth928
+						if buffer[position] != rune('H') {
+							goto l896
 						}
-						goto l858
-					l857:
-						position, tokenIndex, depth = position857, tokenIndex857, depth857
+						position++
 					}
-				l858:
-					depth--
-					add(rulePegText, position856)
+				l928:
 				}
-				if !_rules[ruleAction37]() {
-					goto l854
+			l910:
+				if !_rules[rulesp]() {
+					goto l896
+				}
+				{
+					position930, tokenIndex930, depth930 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l931
+					}
+					position++
+					goto l930
+				l931:
+					position, tokenIndex, depth = position930, tokenIndex930, depth930
+					if buffer[position] != rune('T') {
+						goto l896
+					}
+					position++
+				}
+			l930:
+				{
+					position932, tokenIndex932, depth932 := position, tokenIndex, depth
+					if buffer[position] != rune('u') {
+						goto l933
+					}
+					position++
+					goto l932
+				l933:
+					position, tokenIndex, depth = position932, tokenIndex932, depth932
+					if buffer[position] != rune('U') {
+						goto l896
+					}
+					position++
+				}
+			l932:
+				{
+					position934, tokenIndex934, depth934 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l935
+					}
+					position++
+					goto l934
+				l935:
+					position, tokenIndex, depth = position934, tokenIndex934, depth934
+					if buffer[position] != rune('P') {
+						goto l896
+					}
+					position++
+				}
+			l934:
+				{
+					position936, tokenIndex936, depth936 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l937
+					}
+					position++
+					goto l936
+				l937:
+					position, tokenIndex, depth = position936, tokenIndex936, depth936
+					if buffer[position] != rune('L') {
+						goto l896
+					}
+					position++
+				}
+			l936:
+				{
+					position938, tokenIndex938, depth938 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l939
+					}
+					position++
+					goto l938
+				l939:
+					position, tokenIndex, depth = position938, tokenIndex938, depth938
+					if buffer[position] != rune('E') {
+						goto l896
+					}
+					position++
+				}
+			l938:
+				if !_rules[ruleAction34]() {
+					goto l896
 				}
 				depth--
-				add(ruleGrouping, position855)
+				add(ruleCountBasedSampling, position897)
 			}
 			return true
-		l854:
-			position, tokenIndex, depth = position854, tokenIndex854, depth854
+		l896:
+			position, tokenIndex, depth = position896, tokenIndex896, depth896
 			return false
 		},
-		/* 50 GroupList <- <(Expression (spOpt ',' spOpt Expression)*)> */
+		/* 48 RandomizedSampling <- <(('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E') sp (FloatLiteral / NumericLiteral) spOpt '%' Action35)> */
 		func() bool {
-			position873, tokenIndex873, depth873 := position, tokenIndex, depth
+			position940, tokenIndex940, depth940 := position, tokenIndex, depth
 			{
-				position874 := position
+				position941 := position
 				depth++
-				if !_rules[ruleExpression]() {
-					goto l873
+				{
+					position942, tokenIndex942, depth942 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l943
+					}
+					position++
+					goto l942
+				l943:
+					position, tokenIndex, depth = position942, tokenIndex942, depth942
+					if buffer[position] != rune('S') {
+						goto l940
+					}
+					position++
 				}
-			l875:
+			l942:
 				{
-					position876, tokenIndex876, depth876 := position, tokenIndex, depth
-					if !_rules[rulespOpt]() {
-						goto l876
+					position944, tokenIndex944, depth944 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l945
 					}
-					if buffer[position] != rune(',') {
-						goto l876
+					position++
+					goto l944
+				l945:
+					position, tokenIndex, depth = position944, tokenIndex944, depth944
+					if buffer[position] != rune('A') {
+						goto l940
 					}
 					position++
-					if !_rules[rulespOpt]() {
-						goto l876
+				}
+			l944:
+				{
+					position946, tokenIndex946, depth946 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l947
 					}
-					if !_rules[ruleExpression]() {
-						goto l876
+					position++
+					goto l946
+				l947:
+					position, tokenIndex, depth = position946, tokenIndex946, depth946
+					if buffer[position] != rune('M') {
+						goto l940
 					}
-					goto l875
-				l876:
-					position, tokenIndex, depth = position876, tokenIndex876, depth876
+					position++
+				}
+			l946:
+				{
+					position948, tokenIndex948, depth948 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l949
+					}
+					position++
+					goto l948
+				l949:
+					position, tokenIndex, depth = position948, tokenIndex948, depth948
+					if buffer[position] != rune('P') {
+						goto l940
+					}
+					position++
+				}
+			l948:
+				{
+					position950, tokenIndex950, depth950 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l951
+					}
+					position++
+					goto l950
+				l951:
+					position, tokenIndex, depth = position950, tokenIndex950, depth950
+					if buffer[position] != rune('L') {
+						goto l940
+					}
+					position++
+				}
+			l950:
+				{
+					position952, tokenIndex952, depth952 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l953
+					}
+					position++
+					goto l952
+				l953:
+					position, tokenIndex, depth = position952, tokenIndex952, depth952
+					if buffer[position] != rune('E') {
+						goto l940
+					}
+					position++
+				}
+			l952:
+				if !_rules[rulesp]() {
+					goto l940
+				}
+				{
+					position954, tokenIndex954, depth954 := position, tokenIndex, depth
+					if !_rules[ruleFloatLiteral]() {
+						goto l955
+					}
+					goto l954
+				l955:
+					position, tokenIndex, depth = position954, tokenIndex954, depth954
+					if !_rules[ruleNumericLiteral]() {
+						goto l940
+					}
+				}
+			l954:
+				if !_rules[rulespOpt]() {
+					goto l940
+				}
+				if buffer[position] != rune('%') {
+					goto l940
+				}
+				position++
+				if !_rules[ruleAction35]() {
+					goto l940
 				}
 				depth--
-				add(ruleGroupList, position874)
+				add(ruleRandomizedSampling, position941)
 			}
 			return true
-		l873:
-			position, tokenIndex, depth = position873, tokenIndex873, depth873
+		l940:
+			position, tokenIndex, depth = position940, tokenIndex940, depth940
 			return false
 		},
-		/* 51 Having <- <(<(sp (('h' / 'H') ('a' / 'A') ('v' / 'V') ('i' / 'I') ('n' / 'N') ('g' / 'G')) sp Expression)?> Action38)> */
+		/* 49 TimeBasedSampling <- <(TimeBasedSamplingSeconds / TimeBasedSamplingMilliseconds)> */
 		func() bool {
-			position877, tokenIndex877, depth877 := position, tokenIndex, depth
+			position956, tokenIndex956, depth956 := position, tokenIndex, depth
 			{
-				position878 := position
+				position957 := position
 				depth++
 				{
-					position879 := position
-					depth++
-					{
-						position880, tokenIndex880, depth880 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l880
-						}
-						{
-							position882, tokenIndex882, depth882 := position, tokenIndex, depth
-							if buffer[position] != rune('h') {
-								goto l883
-							}
-							position++
-							goto l882
-						l883:
-							position, tokenIndex, depth = position882, tokenIndex882, depth882
-							if buffer[position] != rune('H') {
-								goto l880
-							}
-							position++
-						}
-					l882:
-						{
-							position884, tokenIndex884, depth884 := position, tokenIndex, depth
-							if buffer[position] != rune('a') {
-								goto l885
-							}
-							position++
-							goto l884
-						l885:
-							position, tokenIndex, depth = position884, tokenIndex884, depth884
-							if buffer[position] != rune('A') {
-								goto l880
-							}
-							position++
-						}
-					l884:
-						{
-							position886, tokenIndex886, depth886 := position, tokenIndex, depth
-							if buffer[position] != rune('v') {
-								goto l887
-							}
-							position++
-							goto l886
-						l887:
-							position, tokenIndex, depth = position886, tokenIndex886, depth886
-							if buffer[position] != rune('V') {
-								goto l880
-							}
-							position++
-						}
-					l886:
-						{
-							position888, tokenIndex888, depth888 := position, tokenIndex, depth
-							if buffer[position] != rune('i') {
-								goto l889
-							}
-							position++
-							goto l888
-						l889:
-							position, tokenIndex, depth = position888, tokenIndex888, depth888
-							if buffer[position] != rune('I') {
-								goto l880
-							}
-							position++
-						}
-					l888:
-						{
-							position890, tokenIndex890, depth890 := position, tokenIndex, depth
-							if buffer[position] != rune('n') {
-								goto l891
-							}
-							position++
-							goto l890
-						l891:
-							position, tokenIndex, depth = position890, tokenIndex890, depth890
-							if buffer[position] != rune('N') {
-								goto l880
-							}
-							position++
-						}
-					l890:
-						{
-							position892, tokenIndex892, depth892 := position, tokenIndex, depth
-							if buffer[position] != rune('g') {
-								goto l893
-							}
-							position++
-							goto l892
-						l893:
-							position, tokenIndex, depth = position892, tokenIndex892, depth892
-							if buffer[position] != rune('G') {
-								goto l880
-							}
-							position++
-						}
-					l892:
-						if !_rules[rulesp]() {
-							goto l880
-						}
-						if !_rules[ruleExpression]() {
-							goto l880
-						}
-						goto l881
-					l880:
-						position, tokenIndex, depth = position880, tokenIndex880, depth880
+					position958, tokenIndex958, depth958 := position, tokenIndex, depth
+					if !_rules[ruleTimeBasedSamplingSeconds]() {
+						goto l959
+					}
+					goto l958
+				l959:
+					position, tokenIndex, depth = position958, tokenIndex958, depth958
+					if !_rules[ruleTimeBasedSamplingMilliseconds]() {
+						goto l956
 					}
-				l881:
-					depth--
-					add(rulePegText, position879)
-				}
-				if !_rules[ruleAction38]() {
-					goto l877
 				}
+			l958:
 				depth--
-				add(ruleHaving, position878)
+				add(ruleTimeBasedSampling, position957)
 			}
 			return true
-		l877:
-			position, tokenIndex, depth = position877, tokenIndex877, depth877
+		l956:
+			position, tokenIndex, depth = position956, tokenIndex956, depth956
 			return false
 		},
-		/* 52 RelationLike <- <(AliasedStreamWindow / (StreamWindow Action39))> */
+		/* 50 TimeBasedSamplingSeconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action36)> */
 		func() bool {
-			position894, tokenIndex894, depth894 := position, tokenIndex, depth
+			position960, tokenIndex960, depth960 := position, tokenIndex, depth
 			{
-				position895 := position
+				position961 := position
 				depth++
 				{
-					position896, tokenIndex896, depth896 := position, tokenIndex, depth
-					if !_rules[ruleAliasedStreamWindow]() {
-						goto l897
-					}
-					goto l896
-				l897:
-					position, tokenIndex, depth = position896, tokenIndex896, depth896
-					if !_rules[ruleStreamWindow]() {
-						goto l894
+					position962, tokenIndex962, depth962 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l963
 					}
-					if !_rules[ruleAction39]() {
-						goto l894
+					position++
+					goto l962
+				l963:
+					position, tokenIndex, depth = position962, tokenIndex962, depth962
+					if buffer[position] != rune('E') {
+						goto l960
 					}
+					position++
 				}
-			l896:
-				depth--
-				add(ruleRelationLike, position895)
-			}
-			return true
-		l894:
-			position, tokenIndex, depth = position894, tokenIndex894, depth894
-			return false
-		},
-		/* 53 AliasedStreamWindow <- <(StreamWindow sp (('a' / 'A') ('s' / 'S')) sp Identifier Action40)> */
-		func() bool {
-			position898, tokenIndex898, depth898 := position, tokenIndex, depth
-			{
-				position899 := position
-				depth++
-				if !_rules[ruleStreamWindow]() {
-					goto l898
+			l962:
+				{
+					position964, tokenIndex964, depth964 := position, tokenIndex, depth
+					if buffer[position] != rune('v') {
+						goto l965
+					}
+					position++
+					goto l964
+				l965:
+					position, tokenIndex, depth = position964, tokenIndex964, depth964
+					if buffer[position] != rune('V') {
+						goto l960
+					}
+					position++
 				}
-				if !_rules[rulesp]() {
-					goto l898
+			l964:
+				{
+					position966, tokenIndex966, depth966 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l967
+					}
+					position++
+					goto l966
+				l967:
+					position, tokenIndex, depth = position966, tokenIndex966, depth966
+					if buffer[position] != rune('E') {
+						goto l960
+					}
+					position++
 				}
+			l966:
 				{
-					position900, tokenIndex900, depth900 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l901
+					position968, tokenIndex968, depth968 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l969
 					}
 					position++
-					goto l900
-				l901:
-					position, tokenIndex, depth = position900, tokenIndex900, depth900
-					if buffer[position] != rune('A') {
-						goto l898
+					goto l968
+				l969:
+					position, tokenIndex, depth = position968, tokenIndex968, depth968
+					if buffer[position] != rune('R') {
+						goto l960
 					}
 					position++
 				}
-			l900:
+			l968:
 				{
-					position902, tokenIndex902, depth902 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l903
+					position970, tokenIndex970, depth970 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l971
 					}
 					position++
-					goto l902
-				l903:
-					position, tokenIndex, depth = position902, tokenIndex902, depth902
-					if buffer[position] != rune('S') {
-						goto l898
+					goto l970
+				l971:
+					position, tokenIndex, depth = position970, tokenIndex970, depth970
+					if buffer[position] != rune('Y') {
+						goto l960
 					}
 					position++
 				}
-			l902:
+			l970:
 				if !_rules[rulesp]() {
-					goto l898
-				}
-				if !_rules[ruleIdentifier]() {
-					goto l898
-				}
-				if !_rules[ruleAction40]() {
-					goto l898
+					goto l960
 				}
-				depth--
-				add(ruleAliasedStreamWindow, position899)
-			}
-			return true
-		l898:
-			position, tokenIndex, depth = position898, tokenIndex898, depth898
-			return false
-		},
-		/* 54 StreamWindow <- <(StreamLike spOpt '[' spOpt (('r' / 'R') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E')) sp Interval CapacitySpecOpt SheddingSpecOpt spOpt ']' Action41)> */
-		func() bool {
-			position904, tokenIndex904, depth904 := position, tokenIndex, depth
-			{
-				position905 := position
-				depth++
-				if !_rules[ruleStreamLike]() {
-					goto l904
+				{
+					position972, tokenIndex972, depth972 := position, tokenIndex, depth
+					if !_rules[ruleFloatLiteral]() {
+						goto l973
+					}
+					goto l972
+				l973:
+					position, tokenIndex, depth = position972, tokenIndex972, depth972
+					if !_rules[ruleNumericLiteral]() {
+						goto l960
+					}
 				}
-				if !_rules[rulespOpt]() {
-					goto l904
+			l972:
+				if !_rules[rulesp]() {
+					goto l960
 				}
-				if buffer[position] != rune('[') {
-					goto l904
+				{
+					position974, tokenIndex974, depth974 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l975
+					}
+					position++
+					goto l974
+				l975:
+					position, tokenIndex, depth = position974, tokenIndex974, depth974
+					if buffer[position] != rune('S') {
+						goto l960
+					}
+					position++
 				}
-				position++
-				if !_rules[rulespOpt]() {
-					goto l904
+			l974:
+				{
+					position976, tokenIndex976, depth976 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l977
+					}
+					position++
+					goto l976
+				l977:
+					position, tokenIndex, depth = position976, tokenIndex976, depth976
+					if buffer[position] != rune('E') {
+						goto l960
+					}
+					position++
 				}
+			l976:
 				{
-					position906, tokenIndex906, depth906 := position, tokenIndex, depth
-					if buffer[position] != rune('r') {
-						goto l907
+					position978, tokenIndex978, depth978 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l979
 					}
 					position++
-					goto l906
-				l907:
-					position, tokenIndex, depth = position906, tokenIndex906, depth906
-					if buffer[position] != rune('R') {
-						goto l904
+					goto l978
+				l979:
+					position, tokenIndex, depth = position978, tokenIndex978, depth978
+					if buffer[position] != rune('C') {
+						goto l960
 					}
 					position++
 				}
-			l906:
+			l978:
 				{
-					position908, tokenIndex908, depth908 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l909
+					position980, tokenIndex980, depth980 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l981
 					}
 					position++
-					goto l908
-				l909:
-					position, tokenIndex, depth = position908, tokenIndex908, depth908
-					if buffer[position] != rune('A') {
-						goto l904
+					goto l980
+				l981:
+					position, tokenIndex, depth = position980, tokenIndex980, depth980
+					if buffer[position] != rune('O') {
+						goto l960
 					}
 					position++
 				}
-			l908:
+			l980:
 				{
-					position910, tokenIndex910, depth910 := position, tokenIndex, depth
+					position982, tokenIndex982, depth982 := position, tokenIndex, depth
 					if buffer[position] != rune('n') {
-						goto l911
+						goto l983
 					}
 					position++
-					goto l910
-				l911:
-					position, tokenIndex, depth = position910, tokenIndex910, depth910
+					goto l982
+				l983:
+					position, tokenIndex, depth = position982, tokenIndex982, depth982
 					if buffer[position] != rune('N') {
-						goto l904
+						goto l960
 					}
 					position++
 				}
-			l910:
+			l982:
 				{
-					position912, tokenIndex912, depth912 := position, tokenIndex, depth
-					if buffer[position] != rune('g') {
-						goto l913
+					position984, tokenIndex984, depth984 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l985
 					}
 					position++
-					goto l912
-				l913:
-					position, tokenIndex, depth = position912, tokenIndex912, depth912
-					if buffer[position] != rune('G') {
-						goto l904
+					goto l984
+				l985:
+					position, tokenIndex, depth = position984, tokenIndex984, depth984
+					if buffer[position] != rune('D') {
+						goto l960
 					}
 					position++
 				}
-			l912:
+			l984:
 				{
-					position914, tokenIndex914, depth914 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l915
+					position986, tokenIndex986, depth986 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l987
 					}
 					position++
-					goto l914
-				l915:
-					position, tokenIndex, depth = position914, tokenIndex914, depth914
-					if buffer[position] != rune('E') {
-						goto l904
+					goto l986
+				l987:
+					position, tokenIndex, depth = position986, tokenIndex986, depth986
+					if buffer[position] != rune('S') {
+						goto l960
 					}
 					position++
 				}
-			l914:
-				if !_rules[rulesp]() {
-					goto l904
-				}
-				if !_rules[ruleInterval]() {
-					goto l904
-				}
-				if !_rules[ruleCapacitySpecOpt]() {
-					goto l904
-				}
-				if !_rules[ruleSheddingSpecOpt]() {
-					goto l904
-				}
-				if !_rules[rulespOpt]() {
-					goto l904
-				}
-				if buffer[position] != rune(']') {
-					goto l904
-				}
-				position++
-				if !_rules[ruleAction41]() {
-					goto l904
+			l986:
+				if !_rules[ruleAction36]() {
+					goto l960
 				}
 				depth--
-				add(ruleStreamWindow, position905)
+				add(ruleTimeBasedSamplingSeconds, position961)
 			}
 			return true
-		l904:
-			position, tokenIndex, depth = position904, tokenIndex904, depth904
+		l960:
+			position, tokenIndex, depth = position960, tokenIndex960, depth960
 			return false
 		},
-		/* 55 StreamLike <- <(UDSFFuncApp / Stream)> */
+		/* 51 TimeBasedSamplingMilliseconds <- <(('e' / 'E') ('v' / 'V') ('e' / 'E') ('r' / 'R') ('y' / 'Y') sp (FloatLiteral / NumericLiteral) sp (('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S')) Action37)> */
 		func() bool {
-			position916, tokenIndex916, depth916 := position, tokenIndex, depth
+			position988, tokenIndex988, depth988 := position, tokenIndex, depth
 			{
-				position917 := position
+				position989 := position
 				depth++
 				{
-					position918, tokenIndex918, depth918 := position, tokenIndex, depth
-					if !_rules[ruleUDSFFuncApp]() {
-						goto l919
+					position990, tokenIndex990, depth990 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l991
 					}
-					goto l918
-				l919:
-					position, tokenIndex, depth = position918, tokenIndex918, depth918
-					if !_rules[ruleStream]() {
-						goto l916
+					position++
+					goto l990
+				l991:
+					position, tokenIndex, depth = position990, tokenIndex990, depth990
+					if buffer[position] != rune('E') {
+						goto l988
 					}
+					position++
 				}
-			l918:
-				depth--
-				add(ruleStreamLike, position917)
-			}
-			return true
-		l916:
-			position, tokenIndex, depth = position916, tokenIndex916, depth916
-			return false
-		},
-		/* 56 UDSFFuncApp <- <(FuncAppWithoutOrderBy Action42)> */
-		func() bool {
-			position920, tokenIndex920, depth920 := position, tokenIndex, depth
-			{
-				position921 := position
-				depth++
-				if !_rules[ruleFuncAppWithoutOrderBy]() {
-					goto l920
-				}
-				if !_rules[ruleAction42]() {
-					goto l920
-				}
-				depth--
-				add(ruleUDSFFuncApp, position921)
-			}
-			return true
-		l920:
-			position, tokenIndex, depth = position920, tokenIndex920, depth920
-			return false
-		},
-		/* 57 CapacitySpecOpt <- <(<(spOpt ',' spOpt (('b' / 'B') ('u' / 'U') ('f' / 'F') ('f' / 'F') ('e' / 'E') ('r' / 'R')) sp (('s' / 'S') ('i' / 'I') ('z' / 'Z') ('e' / 'E')) sp NonNegativeNumericLiteral)?> Action43)> */
-		func() bool {
-			position922, tokenIndex922, depth922 := position, tokenIndex, depth
-			{
-				position923 := position
-				depth++
+			l990:
 				{
-					position924 := position
-					depth++
-					{
-						position925, tokenIndex925, depth925 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l925
+					position992, tokenIndex992, depth992 := position, tokenIndex, depth
+					if buffer[position] != rune('v') {
+						goto l993
+					}
+					position++
+					goto l992
+				l993:
+					position, tokenIndex, depth = position992, tokenIndex992, depth992
+					if buffer[position] != rune('V') {
+						goto l988
+					}
+					position++
+				}
+			l992:
+				{
+					position994, tokenIndex994, depth994 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l995
+					}
+					position++
+					goto l994
+				l995:
+					position, tokenIndex, depth = position994, tokenIndex994, depth994
+					if buffer[position] != rune('E') {
+						goto l988
+					}
+					position++
+				}
+			l994:
+				{
+					position996, tokenIndex996, depth996 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l997
+					}
+					position++
+					goto l996
+				l997:
+					position, tokenIndex, depth = position996, tokenIndex996, depth996
+					if buffer[position] != rune('R') {
+						goto l988
+					}
+					position++
+				}
+			l996:
+				{
+					position998, tokenIndex998, depth998 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l999
+					}
+					position++
+					goto l998
+				l999:
+					position, tokenIndex, depth = position998, tokenIndex998, depth998
+					if buffer[position] != rune('Y') {
+						goto l988
+					}
+					position++
+				}
+			l998:
+				if !_rules[rulesp]() {
+					goto l988
+				}
+				{
+					position1000, tokenIndex1000, depth1000 := position, tokenIndex, depth
+					if !_rules[ruleFloatLiteral]() {
+						goto l1001
+					}
+					goto l1000
+				l1001:
+					position, tokenIndex, depth = position1000, tokenIndex1000, depth1000
+					if !_rules[ruleNumericLiteral]() {
+						goto l988
+					}
+				}
+			l1000:
+				if !_rules[rulesp]() {
+					goto l988
+				}
+				{
+					position1002, tokenIndex1002, depth1002 := position, tokenIndex, depth
+					if buffer[position] != rune('m') {
+						goto l1003
+					}
+					position++
+					goto l1002
+				l1003:
+					position, tokenIndex, depth = position1002, tokenIndex1002, depth1002
+					if buffer[position] != rune('M') {
+						goto l988
+					}
+					position++
+				}
+			l1002:
+				{
+					position1004, tokenIndex1004, depth1004 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l1005
+					}
+					position++
+					goto l1004
+				l1005:
+					position, tokenIndex, depth = position1004, tokenIndex1004, depth1004
+					if buffer[position] != rune('I') {
+						goto l988
+					}
+					position++
+				}
+			l1004:
+				{
+					position1006, tokenIndex1006, depth1006 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l1007
+					}
+					position++
+					goto l1006
+				l1007:
+					position, tokenIndex, depth = position1006, tokenIndex1006, depth1006
+					if buffer[position] != rune('L') {
+						goto l988
+					}
+					position++
+				}
+			l1006:
+				{
+					position1008, tokenIndex1008, depth1008 := position, tokenIndex, depth
+					if buffer[position] != rune('l') {
+						goto l1009
+					}
+					position++
+					goto l1008
+				l1009:
+					position, tokenIndex, depth = position1008, tokenIndex1008, depth1008
+					if buffer[position] != rune('L') {
+						goto l988
+					}
+					position++
+				}
+			l1008:
+				{
+					position1010, tokenIndex1010, depth1010 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l1011
+					}
+					position++
+					goto l1010
+				l1011:
+					position, tokenIndex, depth = position1010, tokenIndex1010, depth1010
+					if buffer[position] != rune('I') {
+						goto l988
+					}
+					position++
+				}
+			l1010:
+				{
+					position1012, tokenIndex1012, depth1012 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1013
+					}
+					position++
+					goto l1012
+				l1013:
+					position, tokenIndex, depth = position1012, tokenIndex1012, depth1012
+					if buffer[position] != rune('S') {
+						goto l988
+					}
+					position++
+				}
+			l1012:
+				{
+					position1014, tokenIndex1014, depth1014 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1015
+					}
+					position++
+					goto l1014
+				l1015:
+					position, tokenIndex, depth = position1014, tokenIndex1014, depth1014
+					if buffer[position] != rune('E') {
+						goto l988
+					}
+					position++
+				}
+			l1014:
+				{
+					position1016, tokenIndex1016, depth1016 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l1017
+					}
+					position++
+					goto l1016
+				l1017:
+					position, tokenIndex, depth = position1016, tokenIndex1016, depth1016
+					if buffer[position] != rune('C') {
+						goto l988
+					}
+					position++
+				}
+			l1016:
+				{
+					position1018, tokenIndex1018, depth1018 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l1019
+					}
+					position++
+					goto l1018
+				l1019:
+					position, tokenIndex, depth = position1018, tokenIndex1018, depth1018
+					if buffer[position] != rune('O') {
+						goto l988
+					}
+					position++
+				}
+			l1018:
+				{
+					position1020, tokenIndex1020, depth1020 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1021
+					}
+					position++
+					goto l1020
+				l1021:
+					position, tokenIndex, depth = position1020, tokenIndex1020, depth1020
+					if buffer[position] != rune('N') {
+						goto l988
+					}
+					position++
+				}
+			l1020:
+				{
+					position1022, tokenIndex1022, depth1022 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l1023
+					}
+					position++
+					goto l1022
+				l1023:
+					position, tokenIndex, depth = position1022, tokenIndex1022, depth1022
+					if buffer[position] != rune('D') {
+						goto l988
+					}
+					position++
+				}
+			l1022:
+				{
+					position1024, tokenIndex1024, depth1024 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1025
+					}
+					position++
+					goto l1024
+				l1025:
+					position, tokenIndex, depth = position1024, tokenIndex1024, depth1024
+					if buffer[position] != rune('S') {
+						goto l988
+					}
+					position++
+				}
+			l1024:
+				if !_rules[ruleAction37]() {
+					goto l988
+				}
+				depth--
+				add(ruleTimeBasedSamplingMilliseconds, position989)
+			}
+			return true
+		l988:
+			position, tokenIndex, depth = position988, tokenIndex988, depth988
+			return false
+		},
+		/* 52 EmitterChange <- <(('w' / 'W') ('h' / 'H') ('e' / 'E') ('n' / 'N') sp (('c' / 'C') ('h' / 'H') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E') ('d' / 'D')) <(sp (('b' / 'B') ('y' / 'Y')) sp EmitterChangeKey (spOpt ',' spOpt EmitterChangeKey)*)?> Action38)> */
+		func() bool {
+			position1026, tokenIndex1026, depth1026 := position, tokenIndex, depth
+			{
+				position1027 := position
+				depth++
+				{
+					position1028, tokenIndex1028, depth1028 := position, tokenIndex, depth
+					if buffer[position] != rune('w') {
+						goto l1029
+					}
+					position++
+					goto l1028
+				l1029:
+					position, tokenIndex, depth = position1028, tokenIndex1028, depth1028
+					if buffer[position] != rune('W') {
+						goto l1026
+					}
+					position++
+				}
+			l1028:
+				{
+					position1030, tokenIndex1030, depth1030 := position, tokenIndex, depth
+					if buffer[position] != rune('h') {
+						goto l1031
+					}
+					position++
+					goto l1030
+				l1031:
+					position, tokenIndex, depth = position1030, tokenIndex1030, depth1030
+					if buffer[position] != rune('H') {
+						goto l1026
+					}
+					position++
+				}
+			l1030:
+				{
+					position1032, tokenIndex1032, depth1032 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1033
+					}
+					position++
+					goto l1032
+				l1033:
+					position, tokenIndex, depth = position1032, tokenIndex1032, depth1032
+					if buffer[position] != rune('E') {
+						goto l1026
+					}
+					position++
+				}
+			l1032:
+				{
+					position1034, tokenIndex1034, depth1034 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1035
+					}
+					position++
+					goto l1034
+				l1035:
+					position, tokenIndex, depth = position1034, tokenIndex1034, depth1034
+					if buffer[position] != rune('N') {
+						goto l1026
+					}
+					position++
+				}
+			l1034:
+				if !_rules[rulesp]() {
+					goto l1026
+				}
+				{
+					position1036, tokenIndex1036, depth1036 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l1037
+					}
+					position++
+					goto l1036
+				l1037:
+					position, tokenIndex, depth = position1036, tokenIndex1036, depth1036
+					if buffer[position] != rune('C') {
+						goto l1026
+					}
+					position++
+				}
+			l1036:
+				{
+					position1038, tokenIndex1038, depth1038 := position, tokenIndex, depth
+					if buffer[position] != rune('h') {
+						goto l1039
+					}
+					position++
+					goto l1038
+				l1039:
+					position, tokenIndex, depth = position1038, tokenIndex1038, depth1038
+					if buffer[position] != rune('H') {
+						goto l1026
+					}
+					position++
+				}
+			l1038:
+				{
+					position1040, tokenIndex1040, depth1040 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1041
+					}
+					position++
+					goto l1040
+				l1041:
+					position, tokenIndex, depth = position1040, tokenIndex1040, depth1040
+					if buffer[position] != rune('A') {
+						goto l1026
+					}
+					position++
+				}
+			l1040:
+				{
+					position1042, tokenIndex1042, depth1042 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1043
+					}
+					position++
+					goto l1042
+				l1043:
+					position, tokenIndex, depth = position1042, tokenIndex1042, depth1042
+					if buffer[position] != rune('N') {
+						goto l1026
+					}
+					position++
+				}
+			l1042:
+				{
+					position1044, tokenIndex1044, depth1044 := position, tokenIndex, depth
+					if buffer[position] != rune('g') {
+						goto l1045
+					}
+					position++
+					goto l1044
+				l1045:
+					position, tokenIndex, depth = position1044, tokenIndex1044, depth1044
+					if buffer[position] != rune('G') {
+						goto l1026
+					}
+					position++
+				}
+			l1044:
+				{
+					position1046, tokenIndex1046, depth1046 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1047
+					}
+					position++
+					goto l1046
+				l1047:
+					position, tokenIndex, depth = position1046, tokenIndex1046, depth1046
+					if buffer[position] != rune('E') {
+						goto l1026
+					}
+					position++
+				}
+			l1046:
+				{
+					position1048, tokenIndex1048, depth1048 := position, tokenIndex, depth
+					if buffer[position] != rune('d') {
+						goto l1049
+					}
+					position++
+					goto l1048
+				l1049:
+					position, tokenIndex, depth = position1048, tokenIndex1048, depth1048
+					if buffer[position] != rune('D') {
+						goto l1026
+					}
+					position++
+				}
+			l1048:
+				{
+					position1050 := position
+					depth++
+					{
+						position1051, tokenIndex1051, depth1051 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1051
+						}
+						{
+							position1053, tokenIndex1053, depth1053 := position, tokenIndex, depth
+							if buffer[position] != rune('b') {
+								goto l1054
+							}
+							position++
+							goto l1053
+						l1054:
+							position, tokenIndex, depth = position1053, tokenIndex1053, depth1053
+							if buffer[position] != rune('B') {
+								goto l1051
+							}
+							position++
+						}
+					l1053:
+						{
+							position1055, tokenIndex1055, depth1055 := position, tokenIndex, depth
+							if buffer[position] != rune('y') {
+								goto l1056
+							}
+							position++
+							goto l1055
+						l1056:
+							position, tokenIndex, depth = position1055, tokenIndex1055, depth1055
+							if buffer[position] != rune('Y') {
+								goto l1051
+							}
+							position++
+						}
+					l1055:
+						if !_rules[rulesp]() {
+							goto l1051
+						}
+						if !_rules[ruleEmitterChangeKey]() {
+							goto l1051
+						}
+					l1057:
+						{
+							position1058, tokenIndex1058, depth1058 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1058
+							}
+							if buffer[position] != rune(',') {
+								goto l1058
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1058
+							}
+							if !_rules[ruleEmitterChangeKey]() {
+								goto l1058
+							}
+							goto l1057
+						l1058:
+							position, tokenIndex, depth = position1058, tokenIndex1058, depth1058
+						}
+						goto l1052
+					l1051:
+						position, tokenIndex, depth = position1051, tokenIndex1051, depth1051
+					}
+				l1052:
+					depth--
+					add(rulePegText, position1050)
+				}
+				if !_rules[ruleAction38]() {
+					goto l1026
+				}
+				depth--
+				add(ruleEmitterChange, position1027)
+			}
+			return true
+		l1026:
+			position, tokenIndex, depth = position1026, tokenIndex1026, depth1026
+			return false
+		},
+		/* 53 EmitterChangeKey <- <(<jsonGetPath> Action39)> */
+		func() bool {
+			position1059, tokenIndex1059, depth1059 := position, tokenIndex, depth
+			{
+				position1060 := position
+				depth++
+				{
+					position1061 := position
+					depth++
+					if !_rules[rulejsonGetPath]() {
+						goto l1059
+					}
+					depth--
+					add(rulePegText, position1061)
+				}
+				if !_rules[ruleAction39]() {
+					goto l1059
+				}
+				depth--
+				add(ruleEmitterChangeKey, position1060)
+			}
+			return true
+		l1059:
+			position, tokenIndex, depth = position1059, tokenIndex1059, depth1059
+			return false
+		},
+		/* 54 Projections <- <(<(sp Projection (spOpt ',' spOpt Projection)*)> Action40)> */
+		func() bool {
+			position1062, tokenIndex1062, depth1062 := position, tokenIndex, depth
+			{
+				position1063 := position
+				depth++
+				{
+					position1064 := position
+					depth++
+					if !_rules[rulesp]() {
+						goto l1062
+					}
+					if !_rules[ruleProjection]() {
+						goto l1062
+					}
+				l1065:
+					{
+						position1066, tokenIndex1066, depth1066 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1066
+						}
+						if buffer[position] != rune(',') {
+							goto l1066
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1066
+						}
+						if !_rules[ruleProjection]() {
+							goto l1066
+						}
+						goto l1065
+					l1066:
+						position, tokenIndex, depth = position1066, tokenIndex1066, depth1066
+					}
+					depth--
+					add(rulePegText, position1064)
+				}
+				if !_rules[ruleAction40]() {
+					goto l1062
+				}
+				depth--
+				add(ruleProjections, position1063)
+			}
+			return true
+		l1062:
+			position, tokenIndex, depth = position1062, tokenIndex1062, depth1062
+			return false
+		},
+		/* 55 Projection <- <(AliasExpression / ExpressionOrWildcard)> */
+		func() bool {
+			position1067, tokenIndex1067, depth1067 := position, tokenIndex, depth
+			{
+				position1068 := position
+				depth++
+				{
+					position1069, tokenIndex1069, depth1069 := position, tokenIndex, depth
+					if !_rules[ruleAliasExpression]() {
+						goto l1070
+					}
+					goto l1069
+				l1070:
+					position, tokenIndex, depth = position1069, tokenIndex1069, depth1069
+					if !_rules[ruleExpressionOrWildcard]() {
+						goto l1067
+					}
+				}
+			l1069:
+				depth--
+				add(ruleProjection, position1068)
+			}
+			return true
+		l1067:
+			position, tokenIndex, depth = position1067, tokenIndex1067, depth1067
+			return false
+		},
+		/* 56 AliasExpression <- <(ExpressionOrWildcard sp (('a' / 'A') ('s' / 'S')) sp TargetIdentifier Action41)> */
+		func() bool {
+			position1071, tokenIndex1071, depth1071 := position, tokenIndex, depth
+			{
+				position1072 := position
+				depth++
+				if !_rules[ruleExpressionOrWildcard]() {
+					goto l1071
+				}
+				if !_rules[rulesp]() {
+					goto l1071
+				}
+				{
+					position1073, tokenIndex1073, depth1073 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1074
+					}
+					position++
+					goto l1073
+				l1074:
+					position, tokenIndex, depth = position1073, tokenIndex1073, depth1073
+					if buffer[position] != rune('A') {
+						goto l1071
+					}
+					position++
+				}
+			l1073:
+				{
+					position1075, tokenIndex1075, depth1075 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1076
+					}
+					position++
+					goto l1075
+				l1076:
+					position, tokenIndex, depth = position1075, tokenIndex1075, depth1075
+					if buffer[position] != rune('S') {
+						goto l1071
+					}
+					position++
+				}
+			l1075:
+				if !_rules[rulesp]() {
+					goto l1071
+				}
+				if !_rules[ruleTargetIdentifier]() {
+					goto l1071
+				}
+				if !_rules[ruleAction41]() {
+					goto l1071
+				}
+				depth--
+				add(ruleAliasExpression, position1072)
+			}
+			return true
+		l1071:
+			position, tokenIndex, depth = position1071, tokenIndex1071, depth1071
+			return false
+		},
+		/* 57 WindowedFrom <- <(<(sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')) sp Relations)?> Action42)> */
+		func() bool {
+			position1077, tokenIndex1077, depth1077 := position, tokenIndex, depth
+			{
+				position1078 := position
+				depth++
+				{
+					position1079 := position
+					depth++
+					{
+						position1080, tokenIndex1080, depth1080 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1080
+						}
+						{
+							position1082, tokenIndex1082, depth1082 := position, tokenIndex, depth
+							if buffer[position] != rune('f') {
+								goto l1083
+							}
+							position++
+							goto l1082
+						l1083:
+							position, tokenIndex, depth = position1082, tokenIndex1082, depth1082
+							if buffer[position] != rune('F') {
+								goto l1080
+							}
+							position++
+						}
+					l1082:
+						{
+							position1084, tokenIndex1084, depth1084 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1085
+							}
+							position++
+							goto l1084
+						l1085:
+							position, tokenIndex, depth = position1084, tokenIndex1084, depth1084
+							if buffer[position] != rune('R') {
+								goto l1080
+							}
+							position++
+						}
+					l1084:
+						{
+							position1086, tokenIndex1086, depth1086 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l1087
+							}
+							position++
+							goto l1086
+						l1087:
+							position, tokenIndex, depth = position1086, tokenIndex1086, depth1086
+							if buffer[position] != rune('O') {
+								goto l1080
+							}
+							position++
+						}
+					l1086:
+						{
+							position1088, tokenIndex1088, depth1088 := position, tokenIndex, depth
+							if buffer[position] != rune('m') {
+								goto l1089
+							}
+							position++
+							goto l1088
+						l1089:
+							position, tokenIndex, depth = position1088, tokenIndex1088, depth1088
+							if buffer[position] != rune('M') {
+								goto l1080
+							}
+							position++
+						}
+					l1088:
+						if !_rules[rulesp]() {
+							goto l1080
+						}
+						if !_rules[ruleRelations]() {
+							goto l1080
+						}
+						goto l1081
+					l1080:
+						position, tokenIndex, depth = position1080, tokenIndex1080, depth1080
+					}
+				l1081:
+					depth--
+					add(rulePegText, position1079)
+				}
+				if !_rules[ruleAction42]() {
+					goto l1077
+				}
+				depth--
+				add(ruleWindowedFrom, position1078)
+			}
+			return true
+		l1077:
+			position, tokenIndex, depth = position1077, tokenIndex1077, depth1077
+			return false
+		},
+		/* 58 Interval <- <(TimeInterval / TuplesInterval)> */
+		func() bool {
+			position1090, tokenIndex1090, depth1090 := position, tokenIndex, depth
+			{
+				position1091 := position
+				depth++
+				{
+					position1092, tokenIndex1092, depth1092 := position, tokenIndex, depth
+					if !_rules[ruleTimeInterval]() {
+						goto l1093
+					}
+					goto l1092
+				l1093:
+					position, tokenIndex, depth = position1092, tokenIndex1092, depth1092
+					if !_rules[ruleTuplesInterval]() {
+						goto l1090
+					}
+				}
+			l1092:
+				depth--
+				add(ruleInterval, position1091)
+			}
+			return true
+		l1090:
+			position, tokenIndex, depth = position1090, tokenIndex1090, depth1090
+			return false
+		},
+		/* 59 TimeInterval <- <((FloatLiteral / NumericLiteral) sp (SECONDS / MILLISECONDS) Action43)> */
+		func() bool {
+			position1094, tokenIndex1094, depth1094 := position, tokenIndex, depth
+			{
+				position1095 := position
+				depth++
+				{
+					position1096, tokenIndex1096, depth1096 := position, tokenIndex, depth
+					if !_rules[ruleFloatLiteral]() {
+						goto l1097
+					}
+					goto l1096
+				l1097:
+					position, tokenIndex, depth = position1096, tokenIndex1096, depth1096
+					if !_rules[ruleNumericLiteral]() {
+						goto l1094
+					}
+				}
+			l1096:
+				if !_rules[rulesp]() {
+					goto l1094
+				}
+				{
+					position1098, tokenIndex1098, depth1098 := position, tokenIndex, depth
+					if !_rules[ruleSECONDS]() {
+						goto l1099
+					}
+					goto l1098
+				l1099:
+					position, tokenIndex, depth = position1098, tokenIndex1098, depth1098
+					if !_rules[ruleMILLISECONDS]() {
+						goto l1094
+					}
+				}
+			l1098:
+				if !_rules[ruleAction43]() {
+					goto l1094
+				}
+				depth--
+				add(ruleTimeInterval, position1095)
+			}
+			return true
+		l1094:
+			position, tokenIndex, depth = position1094, tokenIndex1094, depth1094
+			return false
+		},
+		/* 60 TuplesInterval <- <(NumericLiteral sp TUPLES Action44)> */
+		func() bool {
+			position1100, tokenIndex1100, depth1100 := position, tokenIndex, depth
+			{
+				position1101 := position
+				depth++
+				if !_rules[ruleNumericLiteral]() {
+					goto l1100
+				}
+				if !_rules[rulesp]() {
+					goto l1100
+				}
+				if !_rules[ruleTUPLES]() {
+					goto l1100
+				}
+				if !_rules[ruleAction44]() {
+					goto l1100
+				}
+				depth--
+				add(ruleTuplesInterval, position1101)
+			}
+			return true
+		l1100:
+			position, tokenIndex, depth = position1100, tokenIndex1100, depth1100
+			return false
+		},
+		/* 61 Relations <- <(RelationLike (spOpt ',' spOpt RelationLike)*)> */
+		func() bool {
+			position1102, tokenIndex1102, depth1102 := position, tokenIndex, depth
+			{
+				position1103 := position
+				depth++
+				if !_rules[ruleRelationLike]() {
+					goto l1102
+				}
+			l1104:
+				{
+					position1105, tokenIndex1105, depth1105 := position, tokenIndex, depth
+					if !_rules[rulespOpt]() {
+						goto l1105
+					}
+					if buffer[position] != rune(',') {
+						goto l1105
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1105
+					}
+					if !_rules[ruleRelationLike]() {
+						goto l1105
+					}
+					goto l1104
+				l1105:
+					position, tokenIndex, depth = position1105, tokenIndex1105, depth1105
+				}
+				depth--
+				add(ruleRelations, position1103)
+			}
+			return true
+		l1102:
+			position, tokenIndex, depth = position1102, tokenIndex1102, depth1102
+			return false
+		},
+		/* 62 Filter <- <(<(sp (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) sp Expression)?> Action45)> */
+		func() bool {
+			position1106, tokenIndex1106, depth1106 := position, tokenIndex, depth
+			{
+				position1107 := position
+				depth++
+				{
+					position1108 := position
+					depth++
+					{
+						position1109, tokenIndex1109, depth1109 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1109
+						}
+						{
+							position1111, tokenIndex1111, depth1111 := position, tokenIndex, depth
+							if buffer[position] != rune('w') {
+								goto l1112
+							}
+							position++
+							goto l1111
+						l1112:
+							position, tokenIndex, depth = position1111, tokenIndex1111, depth1111
+							if buffer[position] != rune('W') {
+								goto l1109
+							}
+							position++
+						}
+					l1111:
+						{
+							position1113, tokenIndex1113, depth1113 := position, tokenIndex, depth
+							if buffer[position] != rune('h') {
+								goto l1114
+							}
+							position++
+							goto l1113
+						l1114:
+							position, tokenIndex, depth = position1113, tokenIndex1113, depth1113
+							if buffer[position] != rune('H') {
+								goto l1109
+							}
+							position++
+						}
+					l1113:
+						{
+							position1115, tokenIndex1115, depth1115 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1116
+							}
+							position++
+							goto l1115
+						l1116:
+							position, tokenIndex, depth = position1115, tokenIndex1115, depth1115
+							if buffer[position] != rune('E') {
+								goto l1109
+							}
+							position++
+						}
+					l1115:
+						{
+							position1117, tokenIndex1117, depth1117 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1118
+							}
+							position++
+							goto l1117
+						l1118:
+							position, tokenIndex, depth = position1117, tokenIndex1117, depth1117
+							if buffer[position] != rune('R') {
+								goto l1109
+							}
+							position++
+						}
+					l1117:
+						{
+							position1119, tokenIndex1119, depth1119 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1120
+							}
+							position++
+							goto l1119
+						l1120:
+							position, tokenIndex, depth = position1119, tokenIndex1119, depth1119
+							if buffer[position] != rune('E') {
+								goto l1109
+							}
+							position++
+						}
+					l1119:
+						if !_rules[rulesp]() {
+							goto l1109
+						}
+						if !_rules[ruleExpression]() {
+							goto l1109
+						}
+						goto l1110
+					l1109:
+						position, tokenIndex, depth = position1109, tokenIndex1109, depth1109
+					}
+				l1110:
+					depth--
+					add(rulePegText, position1108)
+				}
+				if !_rules[ruleAction45]() {
+					goto l1106
+				}
+				depth--
+				add(ruleFilter, position1107)
+			}
+			return true
+		l1106:
+			position, tokenIndex, depth = position1106, tokenIndex1106, depth1106
+			return false
+		},
+		/* 63 Grouping <- <(<(sp (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P')) sp (('b' / 'B') ('y' / 'Y')) sp GroupList)?> Action46)> */
+		func() bool {
+			position1121, tokenIndex1121, depth1121 := position, tokenIndex, depth
+			{
+				position1122 := position
+				depth++
+				{
+					position1123 := position
+					depth++
+					{
+						position1124, tokenIndex1124, depth1124 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1124
+						}
+						{
+							position1126, tokenIndex1126, depth1126 := position, tokenIndex, depth
+							if buffer[position] != rune('g') {
+								goto l1127
+							}
+							position++
+							goto l1126
+						l1127:
+							position, tokenIndex, depth = position1126, tokenIndex1126, depth1126
+							if buffer[position] != rune('G') {
+								goto l1124
+							}
+							position++
+						}
+					l1126:
+						{
+							position1128, tokenIndex1128, depth1128 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1129
+							}
+							position++
+							goto l1128
+						l1129:
+							position, tokenIndex, depth = position1128, tokenIndex1128, depth1128
+							if buffer[position] != rune('R') {
+								goto l1124
+							}
+							position++
+						}
+					l1128:
+						{
+							position1130, tokenIndex1130, depth1130 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l1131
+							}
+							position++
+							goto l1130
+						l1131:
+							position, tokenIndex, depth = position1130, tokenIndex1130, depth1130
+							if buffer[position] != rune('O') {
+								goto l1124
+							}
+							position++
+						}
+					l1130:
+						{
+							position1132, tokenIndex1132, depth1132 := position, tokenIndex, depth
+							if buffer[position] != rune('u') {
+								goto l1133
+							}
+							position++
+							goto l1132
+						l1133:
+							position, tokenIndex, depth = position1132, tokenIndex1132, depth1132
+							if buffer[position] != rune('U') {
+								goto l1124
+							}
+							position++
+						}
+					l1132:
+						{
+							position1134, tokenIndex1134, depth1134 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1135
+							}
+							position++
+							goto l1134
+						l1135:
+							position, tokenIndex, depth = position1134, tokenIndex1134, depth1134
+							if buffer[position] != rune('P') {
+								goto l1124
+							}
+							position++
+						}
+					l1134:
+						if !_rules[rulesp]() {
+							goto l1124
+						}
+						{
+							position1136, tokenIndex1136, depth1136 := position, tokenIndex, depth
+							if buffer[position] != rune('b') {
+								goto l1137
+							}
+							position++
+							goto l1136
+						l1137:
+							position, tokenIndex, depth = position1136, tokenIndex1136, depth1136
+							if buffer[position] != rune('B') {
+								goto l1124
+							}
+							position++
+						}
+					l1136:
+						{
+							position1138, tokenIndex1138, depth1138 := position, tokenIndex, depth
+							if buffer[position] != rune('y') {
+								goto l1139
+							}
+							position++
+							goto l1138
+						l1139:
+							position, tokenIndex, depth = position1138, tokenIndex1138, depth1138
+							if buffer[position] != rune('Y') {
+								goto l1124
+							}
+							position++
+						}
+					l1138:
+						if !_rules[rulesp]() {
+							goto l1124
+						}
+						if !_rules[ruleGroupList]() {
+							goto l1124
+						}
+						goto l1125
+					l1124:
+						position, tokenIndex, depth = position1124, tokenIndex1124, depth1124
+					}
+				l1125:
+					depth--
+					add(rulePegText, position1123)
+				}
+				if !_rules[ruleAction46]() {
+					goto l1121
+				}
+				depth--
+				add(ruleGrouping, position1122)
+			}
+			return true
+		l1121:
+			position, tokenIndex, depth = position1121, tokenIndex1121, depth1121
+			return false
+		},
+		/* 64 GroupList <- <(Expression (spOpt ',' spOpt Expression)*)> */
+		func() bool {
+			position1140, tokenIndex1140, depth1140 := position, tokenIndex, depth
+			{
+				position1141 := position
+				depth++
+				if !_rules[ruleExpression]() {
+					goto l1140
+				}
+			l1142:
+				{
+					position1143, tokenIndex1143, depth1143 := position, tokenIndex, depth
+					if !_rules[rulespOpt]() {
+						goto l1143
+					}
+					if buffer[position] != rune(',') {
+						goto l1143
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1143
+					}
+					if !_rules[ruleExpression]() {
+						goto l1143
+					}
+					goto l1142
+				l1143:
+					position, tokenIndex, depth = position1143, tokenIndex1143, depth1143
+				}
+				depth--
+				add(ruleGroupList, position1141)
+			}
+			return true
+		l1140:
+			position, tokenIndex, depth = position1140, tokenIndex1140, depth1140
+			return false
+		},
+		/* 65 Having <- <(<(sp (('h' / 'H') ('a' / 'A') ('v' / 'V') ('i' / 'I') ('n' / 'N') ('g' / 'G')) sp Expression)?> Action47)> */
+		func() bool {
+			position1144, tokenIndex1144, depth1144 := position, tokenIndex, depth
+			{
+				position1145 := position
+				depth++
+				{
+					position1146 := position
+					depth++
+					{
+						position1147, tokenIndex1147, depth1147 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1147
+						}
+						{
+							position1149, tokenIndex1149, depth1149 := position, tokenIndex, depth
+							if buffer[position] != rune('h') {
+								goto l1150
+							}
+							position++
+							goto l1149
+						l1150:
+							position, tokenIndex, depth = position1149, tokenIndex1149, depth1149
+							if buffer[position] != rune('H') {
+								goto l1147
+							}
+							position++
+						}
+					l1149:
+						{
+							position1151, tokenIndex1151, depth1151 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l1152
+							}
+							position++
+							goto l1151
+						l1152:
+							position, tokenIndex, depth = position1151, tokenIndex1151, depth1151
+							if buffer[position] != rune('A') {
+								goto l1147
+							}
+							position++
+						}
+					l1151:
+						{
+							position1153, tokenIndex1153, depth1153 := position, tokenIndex, depth
+							if buffer[position] != rune('v') {
+								goto l1154
+							}
+							position++
+							goto l1153
+						l1154:
+							position, tokenIndex, depth = position1153, tokenIndex1153, depth1153
+							if buffer[position] != rune('V') {
+								goto l1147
+							}
+							position++
+						}
+					l1153:
+						{
+							position1155, tokenIndex1155, depth1155 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1156
+							}
+							position++
+							goto l1155
+						l1156:
+							position, tokenIndex, depth = position1155, tokenIndex1155, depth1155
+							if buffer[position] != rune('I') {
+								goto l1147
+							}
+							position++
+						}
+					l1155:
+						{
+							position1157, tokenIndex1157, depth1157 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l1158
+							}
+							position++
+							goto l1157
+						l1158:
+							position, tokenIndex, depth = position1157, tokenIndex1157, depth1157
+							if buffer[position] != rune('N') {
+								goto l1147
+							}
+							position++
+						}
+					l1157:
+						{
+							position1159, tokenIndex1159, depth1159 := position, tokenIndex, depth
+							if buffer[position] != rune('g') {
+								goto l1160
+							}
+							position++
+							goto l1159
+						l1160:
+							position, tokenIndex, depth = position1159, tokenIndex1159, depth1159
+							if buffer[position] != rune('G') {
+								goto l1147
+							}
+							position++
+						}
+					l1159:
+						if !_rules[rulesp]() {
+							goto l1147
+						}
+						if !_rules[ruleExpression]() {
+							goto l1147
+						}
+						goto l1148
+					l1147:
+						position, tokenIndex, depth = position1147, tokenIndex1147, depth1147
+					}
+				l1148:
+					depth--
+					add(rulePegText, position1146)
+				}
+				if !_rules[ruleAction47]() {
+					goto l1144
+				}
+				depth--
+				add(ruleHaving, position1145)
+			}
+			return true
+		l1144:
+			position, tokenIndex, depth = position1144, tokenIndex1144, depth1144
+			return false
+		},
+		/* 66 InputSampling <- <(<(sp (('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E')) sp (FloatLiteral / NumericLiteral) sp (('p' / 'P') ('e' / 'E') ('r' / 'R') ('c' / 'C') ('e' / 'E') ('n' / 'N') ('t' / 'T')))?> Action48)> */
+		func() bool {
+			position1161, tokenIndex1161, depth1161 := position, tokenIndex, depth
+			{
+				position1162 := position
+				depth++
+				{
+					position1163 := position
+					depth++
+					{
+						position1164, tokenIndex1164, depth1164 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1164
+						}
+						{
+							position1166, tokenIndex1166, depth1166 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l1167
+							}
+							position++
+							goto l1166
+						l1167:
+							position, tokenIndex, depth = position1166, tokenIndex1166, depth1166
+							if buffer[position] != rune('S') {
+								goto l1164
+							}
+							position++
+						}
+					l1166:
+						{
+							position1168, tokenIndex1168, depth1168 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l1169
+							}
+							position++
+							goto l1168
+						l1169:
+							position, tokenIndex, depth = position1168, tokenIndex1168, depth1168
+							if buffer[position] != rune('A') {
+								goto l1164
+							}
+							position++
+						}
+					l1168:
+						{
+							position1170, tokenIndex1170, depth1170 := position, tokenIndex, depth
+							if buffer[position] != rune('m') {
+								goto l1171
+							}
+							position++
+							goto l1170
+						l1171:
+							position, tokenIndex, depth = position1170, tokenIndex1170, depth1170
+							if buffer[position] != rune('M') {
+								goto l1164
+							}
+							position++
+						}
+					l1170:
+						{
+							position1172, tokenIndex1172, depth1172 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1173
+							}
+							position++
+							goto l1172
+						l1173:
+							position, tokenIndex, depth = position1172, tokenIndex1172, depth1172
+							if buffer[position] != rune('P') {
+								goto l1164
+							}
+							position++
+						}
+					l1172:
+						{
+							position1174, tokenIndex1174, depth1174 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1175
+							}
+							position++
+							goto l1174
+						l1175:
+							position, tokenIndex, depth = position1174, tokenIndex1174, depth1174
+							if buffer[position] != rune('L') {
+								goto l1164
+							}
+							position++
+						}
+					l1174:
+						{
+							position1176, tokenIndex1176, depth1176 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1177
+							}
+							position++
+							goto l1176
+						l1177:
+							position, tokenIndex, depth = position1176, tokenIndex1176, depth1176
+							if buffer[position] != rune('E') {
+								goto l1164
+							}
+							position++
+						}
+					l1176:
+						if !_rules[rulesp]() {
+							goto l1164
+						}
+						{
+							position1178, tokenIndex1178, depth1178 := position, tokenIndex, depth
+							if !_rules[ruleFloatLiteral]() {
+								goto l1179
+							}
+							goto l1178
+						l1179:
+							position, tokenIndex, depth = position1178, tokenIndex1178, depth1178
+							if !_rules[ruleNumericLiteral]() {
+								goto l1164
+							}
+						}
+					l1178:
+						if !_rules[rulesp]() {
+							goto l1164
+						}
+						{
+							position1180, tokenIndex1180, depth1180 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1181
+							}
+							position++
+							goto l1180
+						l1181:
+							position, tokenIndex, depth = position1180, tokenIndex1180, depth1180
+							if buffer[position] != rune('P') {
+								goto l1164
+							}
+							position++
+						}
+					l1180:
+						{
+							position1182, tokenIndex1182, depth1182 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1183
+							}
+							position++
+							goto l1182
+						l1183:
+							position, tokenIndex, depth = position1182, tokenIndex1182, depth1182
+							if buffer[position] != rune('E') {
+								goto l1164
+							}
+							position++
+						}
+					l1182:
+						{
+							position1184, tokenIndex1184, depth1184 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1185
+							}
+							position++
+							goto l1184
+						l1185:
+							position, tokenIndex, depth = position1184, tokenIndex1184, depth1184
+							if buffer[position] != rune('R') {
+								goto l1164
+							}
+							position++
+						}
+					l1184:
+						{
+							position1186, tokenIndex1186, depth1186 := position, tokenIndex, depth
+							if buffer[position] != rune('c') {
+								goto l1187
+							}
+							position++
+							goto l1186
+						l1187:
+							position, tokenIndex, depth = position1186, tokenIndex1186, depth1186
+							if buffer[position] != rune('C') {
+								goto l1164
+							}
+							position++
+						}
+					l1186:
+						{
+							position1188, tokenIndex1188, depth1188 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1189
+							}
+							position++
+							goto l1188
+						l1189:
+							position, tokenIndex, depth = position1188, tokenIndex1188, depth1188
+							if buffer[position] != rune('E') {
+								goto l1164
+							}
+							position++
+						}
+					l1188:
+						{
+							position1190, tokenIndex1190, depth1190 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l1191
+							}
+							position++
+							goto l1190
+						l1191:
+							position, tokenIndex, depth = position1190, tokenIndex1190, depth1190
+							if buffer[position] != rune('N') {
+								goto l1164
+							}
+							position++
+						}
+					l1190:
+						{
+							position1192, tokenIndex1192, depth1192 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l1193
+							}
+							position++
+							goto l1192
+						l1193:
+							position, tokenIndex, depth = position1192, tokenIndex1192, depth1192
+							if buffer[position] != rune('T') {
+								goto l1164
+							}
+							position++
+						}
+					l1192:
+						goto l1165
+					l1164:
+						position, tokenIndex, depth = position1164, tokenIndex1164, depth1164
+					}
+				l1165:
+					depth--
+					add(rulePegText, position1163)
+				}
+				if !_rules[ruleAction48]() {
+					goto l1161
+				}
+				depth--
+				add(ruleInputSampling, position1162)
+			}
+			return true
+		l1161:
+			position, tokenIndex, depth = position1161, tokenIndex1161, depth1161
+			return false
+		},
+		/* 67 RelationLike <- <(AliasedStreamWindow / (StreamWindow Action49))> */
+		func() bool {
+			position1194, tokenIndex1194, depth1194 := position, tokenIndex, depth
+			{
+				position1195 := position
+				depth++
+				{
+					position1196, tokenIndex1196, depth1196 := position, tokenIndex, depth
+					if !_rules[ruleAliasedStreamWindow]() {
+						goto l1197
+					}
+					goto l1196
+				l1197:
+					position, tokenIndex, depth = position1196, tokenIndex1196, depth1196
+					if !_rules[ruleStreamWindow]() {
+						goto l1194
+					}
+					if !_rules[ruleAction49]() {
+						goto l1194
+					}
+				}
+			l1196:
+				depth--
+				add(ruleRelationLike, position1195)
+			}
+			return true
+		l1194:
+			position, tokenIndex, depth = position1194, tokenIndex1194, depth1194
+			return false
+		},
+		/* 68 AliasedStreamWindow <- <(StreamWindow sp (('a' / 'A') ('s' / 'S')) sp Identifier Action50)> */
+		func() bool {
+			position1198, tokenIndex1198, depth1198 := position, tokenIndex, depth
+			{
+				position1199 := position
+				depth++
+				if !_rules[ruleStreamWindow]() {
+					goto l1198
+				}
+				if !_rules[rulesp]() {
+					goto l1198
+				}
+				{
+					position1200, tokenIndex1200, depth1200 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1201
+					}
+					position++
+					goto l1200
+				l1201:
+					position, tokenIndex, depth = position1200, tokenIndex1200, depth1200
+					if buffer[position] != rune('A') {
+						goto l1198
+					}
+					position++
+				}
+			l1200:
+				{
+					position1202, tokenIndex1202, depth1202 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1203
+					}
+					position++
+					goto l1202
+				l1203:
+					position, tokenIndex, depth = position1202, tokenIndex1202, depth1202
+					if buffer[position] != rune('S') {
+						goto l1198
+					}
+					position++
+				}
+			l1202:
+				if !_rules[rulesp]() {
+					goto l1198
+				}
+				if !_rules[ruleIdentifier]() {
+					goto l1198
+				}
+				if !_rules[ruleAction50]() {
+					goto l1198
+				}
+				depth--
+				add(ruleAliasedStreamWindow, position1199)
+			}
+			return true
+		l1198:
+			position, tokenIndex, depth = position1198, tokenIndex1198, depth1198
+			return false
+		},
+		/* 69 StreamWindow <- <(StreamLike spOpt '[' spOpt (('r' / 'R') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E')) sp Interval CapacitySpecOpt SheddingSpecOpt PipeSpecOpt PartitionSpecOpt spOpt ']' Action51)> */
+		func() bool {
+			position1204, tokenIndex1204, depth1204 := position, tokenIndex, depth
+			{
+				position1205 := position
+				depth++
+				if !_rules[ruleStreamLike]() {
+					goto l1204
+				}
+				if !_rules[rulespOpt]() {
+					goto l1204
+				}
+				if buffer[position] != rune('[') {
+					goto l1204
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1204
+				}
+				{
+					position1206, tokenIndex1206, depth1206 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l1207
+					}
+					position++
+					goto l1206
+				l1207:
+					position, tokenIndex, depth = position1206, tokenIndex1206, depth1206
+					if buffer[position] != rune('R') {
+						goto l1204
+					}
+					position++
+				}
+			l1206:
+				{
+					position1208, tokenIndex1208, depth1208 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1209
+					}
+					position++
+					goto l1208
+				l1209:
+					position, tokenIndex, depth = position1208, tokenIndex1208, depth1208
+					if buffer[position] != rune('A') {
+						goto l1204
+					}
+					position++
+				}
+			l1208:
+				{
+					position1210, tokenIndex1210, depth1210 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1211
+					}
+					position++
+					goto l1210
+				l1211:
+					position, tokenIndex, depth = position1210, tokenIndex1210, depth1210
+					if buffer[position] != rune('N') {
+						goto l1204
+					}
+					position++
+				}
+			l1210:
+				{
+					position1212, tokenIndex1212, depth1212 := position, tokenIndex, depth
+					if buffer[position] != rune('g') {
+						goto l1213
+					}
+					position++
+					goto l1212
+				l1213:
+					position, tokenIndex, depth = position1212, tokenIndex1212, depth1212
+					if buffer[position] != rune('G') {
+						goto l1204
+					}
+					position++
+				}
+			l1212:
+				{
+					position1214, tokenIndex1214, depth1214 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1215
+					}
+					position++
+					goto l1214
+				l1215:
+					position, tokenIndex, depth = position1214, tokenIndex1214, depth1214
+					if buffer[position] != rune('E') {
+						goto l1204
+					}
+					position++
+				}
+			l1214:
+				if !_rules[rulesp]() {
+					goto l1204
+				}
+				if !_rules[ruleInterval]() {
+					goto l1204
+				}
+				if !_rules[ruleCapacitySpecOpt]() {
+					goto l1204
+				}
+				if !_rules[ruleSheddingSpecOpt]() {
+					goto l1204
+				}
+				if !_rules[rulePipeSpecOpt]() {
+					goto l1204
+				}
+				if !_rules[rulePartitionSpecOpt]() {
+					goto l1204
+				}
+				if !_rules[rulespOpt]() {
+					goto l1204
+				}
+				if buffer[position] != rune(']') {
+					goto l1204
+				}
+				position++
+				if !_rules[ruleAction51]() {
+					goto l1204
+				}
+				depth--
+				add(ruleStreamWindow, position1205)
+			}
+			return true
+		l1204:
+			position, tokenIndex, depth = position1204, tokenIndex1204, depth1204
+			return false
+		},
+		/* 70 StreamLike <- <(UDSFFuncApp / Stream)> */
+		func() bool {
+			position1216, tokenIndex1216, depth1216 := position, tokenIndex, depth
+			{
+				position1217 := position
+				depth++
+				{
+					position1218, tokenIndex1218, depth1218 := position, tokenIndex, depth
+					if !_rules[ruleUDSFFuncApp]() {
+						goto l1219
+					}
+					goto l1218
+				l1219:
+					position, tokenIndex, depth = position1218, tokenIndex1218, depth1218
+					if !_rules[ruleStream]() {
+						goto l1216
+					}
+				}
+			l1218:
+				depth--
+				add(ruleStreamLike, position1217)
+			}
+			return true
+		l1216:
+			position, tokenIndex, depth = position1216, tokenIndex1216, depth1216
+			return false
+		},
+		/* 71 UDSFFuncApp <- <(FuncAppWithoutOrderBy Action52)> */
+		func() bool {
+			position1220, tokenIndex1220, depth1220 := position, tokenIndex, depth
+			{
+				position1221 := position
+				depth++
+				if !_rules[ruleFuncAppWithoutOrderBy]() {
+					goto l1220
+				}
+				if !_rules[ruleAction52]() {
+					goto l1220
+				}
+				depth--
+				add(ruleUDSFFuncApp, position1221)
+			}
+			return true
+		l1220:
+			position, tokenIndex, depth = position1220, tokenIndex1220, depth1220
+			return false
+		},
+		/* 72 CapacitySpecOpt <- <(<(spOpt ',' spOpt (('b' / 'B') ('u' / 'U') ('f' / 'F') ('f' / 'F') ('e' / 'E') ('r' / 'R')) sp (('s' / 'S') ('i' / 'I') ('z' / 'Z') ('e' / 'E')) sp NonNegativeNumericLiteral)?> Action53)> */
+		func() bool {
+			position1222, tokenIndex1222, depth1222 := position, tokenIndex, depth
+			{
+				position1223 := position
+				depth++
+				{
+					position1224 := position
+					depth++
+					{
+						position1225, tokenIndex1225, depth1225 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1225
+						}
+						if buffer[position] != rune(',') {
+							goto l1225
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1225
+						}
+						{
+							position1227, tokenIndex1227, depth1227 := position, tokenIndex, depth
+							if buffer[position] != rune('b') {
+								goto l1228
+							}
+							position++
+							goto l1227
+						l1228:
+							position, tokenIndex, depth = position1227, tokenIndex1227, depth1227
+							if buffer[position] != rune('B') {
+								goto l1225
+							}
+							position++
+						}
+					l1227:
+						{
+							position1229, tokenIndex1229, depth1229 := position, tokenIndex, depth
+							if buffer[position] != rune('u') {
+								goto l1230
+							}
+							position++
+							goto l1229
+						l1230:
+							position, tokenIndex, depth = position1229, tokenIndex1229, depth1229
+							if buffer[position] != rune('U') {
+								goto l1225
+							}
+							position++
+						}
+					l1229:
+						{
+							position1231, tokenIndex1231, depth1231 := position, tokenIndex, depth
+							if buffer[position] != rune('f') {
+								goto l1232
+							}
+							position++
+							goto l1231
+						l1232:
+							position, tokenIndex, depth = position1231, tokenIndex1231, depth1231
+							if buffer[position] != rune('F') {
+								goto l1225
+							}
+							position++
+						}
+					l1231:
+						{
+							position1233, tokenIndex1233, depth1233 := position, tokenIndex, depth
+							if buffer[position] != rune('f') {
+								goto l1234
+							}
+							position++
+							goto l1233
+						l1234:
+							position, tokenIndex, depth = position1233, tokenIndex1233, depth1233
+							if buffer[position] != rune('F') {
+								goto l1225
+							}
+							position++
+						}
+					l1233:
+						{
+							position1235, tokenIndex1235, depth1235 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1236
+							}
+							position++
+							goto l1235
+						l1236:
+							position, tokenIndex, depth = position1235, tokenIndex1235, depth1235
+							if buffer[position] != rune('E') {
+								goto l1225
+							}
+							position++
+						}
+					l1235:
+						{
+							position1237, tokenIndex1237, depth1237 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1238
+							}
+							position++
+							goto l1237
+						l1238:
+							position, tokenIndex, depth = position1237, tokenIndex1237, depth1237
+							if buffer[position] != rune('R') {
+								goto l1225
+							}
+							position++
+						}
+					l1237:
+						if !_rules[rulesp]() {
+							goto l1225
+						}
+						{
+							position1239, tokenIndex1239, depth1239 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l1240
+							}
+							position++
+							goto l1239
+						l1240:
+							position, tokenIndex, depth = position1239, tokenIndex1239, depth1239
+							if buffer[position] != rune('S') {
+								goto l1225
+							}
+							position++
+						}
+					l1239:
+						{
+							position1241, tokenIndex1241, depth1241 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1242
+							}
+							position++
+							goto l1241
+						l1242:
+							position, tokenIndex, depth = position1241, tokenIndex1241, depth1241
+							if buffer[position] != rune('I') {
+								goto l1225
+							}
+							position++
+						}
+					l1241:
+						{
+							position1243, tokenIndex1243, depth1243 := position, tokenIndex, depth
+							if buffer[position] != rune('z') {
+								goto l1244
+							}
+							position++
+							goto l1243
+						l1244:
+							position, tokenIndex, depth = position1243, tokenIndex1243, depth1243
+							if buffer[position] != rune('Z') {
+								goto l1225
+							}
+							position++
+						}
+					l1243:
+						{
+							position1245, tokenIndex1245, depth1245 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1246
+							}
+							position++
+							goto l1245
+						l1246:
+							position, tokenIndex, depth = position1245, tokenIndex1245, depth1245
+							if buffer[position] != rune('E') {
+								goto l1225
+							}
+							position++
+						}
+					l1245:
+						if !_rules[rulesp]() {
+							goto l1225
+						}
+						if !_rules[ruleNonNegativeNumericLiteral]() {
+							goto l1225
+						}
+						goto l1226
+					l1225:
+						position, tokenIndex, depth = position1225, tokenIndex1225, depth1225
+					}
+				l1226:
+					depth--
+					add(rulePegText, position1224)
+				}
+				if !_rules[ruleAction53]() {
+					goto l1222
+				}
+				depth--
+				add(ruleCapacitySpecOpt, position1223)
+			}
+			return true
+		l1222:
+			position, tokenIndex, depth = position1222, tokenIndex1222, depth1222
+			return false
+		},
+		/* 73 SheddingSpecOpt <- <(<(spOpt ',' spOpt SheddingOption sp (('i' / 'I') ('f' / 'F')) sp (('f' / 'F') ('u' / 'U') ('l' / 'L') ('l' / 'L')))?> Action54)> */
+		func() bool {
+			position1247, tokenIndex1247, depth1247 := position, tokenIndex, depth
+			{
+				position1248 := position
+				depth++
+				{
+					position1249 := position
+					depth++
+					{
+						position1250, tokenIndex1250, depth1250 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1250
+						}
+						if buffer[position] != rune(',') {
+							goto l1250
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1250
+						}
+						if !_rules[ruleSheddingOption]() {
+							goto l1250
+						}
+						if !_rules[rulesp]() {
+							goto l1250
+						}
+						{
+							position1252, tokenIndex1252, depth1252 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1253
+							}
+							position++
+							goto l1252
+						l1253:
+							position, tokenIndex, depth = position1252, tokenIndex1252, depth1252
+							if buffer[position] != rune('I') {
+								goto l1250
+							}
+							position++
+						}
+					l1252:
+						{
+							position1254, tokenIndex1254, depth1254 := position, tokenIndex, depth
+							if buffer[position] != rune('f') {
+								goto l1255
+							}
+							position++
+							goto l1254
+						l1255:
+							position, tokenIndex, depth = position1254, tokenIndex1254, depth1254
+							if buffer[position] != rune('F') {
+								goto l1250
+							}
+							position++
+						}
+					l1254:
+						if !_rules[rulesp]() {
+							goto l1250
+						}
+						{
+							position1256, tokenIndex1256, depth1256 := position, tokenIndex, depth
+							if buffer[position] != rune('f') {
+								goto l1257
+							}
+							position++
+							goto l1256
+						l1257:
+							position, tokenIndex, depth = position1256, tokenIndex1256, depth1256
+							if buffer[position] != rune('F') {
+								goto l1250
+							}
+							position++
+						}
+					l1256:
+						{
+							position1258, tokenIndex1258, depth1258 := position, tokenIndex, depth
+							if buffer[position] != rune('u') {
+								goto l1259
+							}
+							position++
+							goto l1258
+						l1259:
+							position, tokenIndex, depth = position1258, tokenIndex1258, depth1258
+							if buffer[position] != rune('U') {
+								goto l1250
+							}
+							position++
+						}
+					l1258:
+						{
+							position1260, tokenIndex1260, depth1260 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1261
+							}
+							position++
+							goto l1260
+						l1261:
+							position, tokenIndex, depth = position1260, tokenIndex1260, depth1260
+							if buffer[position] != rune('L') {
+								goto l1250
+							}
+							position++
+						}
+					l1260:
+						{
+							position1262, tokenIndex1262, depth1262 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1263
+							}
+							position++
+							goto l1262
+						l1263:
+							position, tokenIndex, depth = position1262, tokenIndex1262, depth1262
+							if buffer[position] != rune('L') {
+								goto l1250
+							}
+							position++
+						}
+					l1262:
+						goto l1251
+					l1250:
+						position, tokenIndex, depth = position1250, tokenIndex1250, depth1250
+					}
+				l1251:
+					depth--
+					add(rulePegText, position1249)
+				}
+				if !_rules[ruleAction54]() {
+					goto l1247
+				}
+				depth--
+				add(ruleSheddingSpecOpt, position1248)
+			}
+			return true
+		l1247:
+			position, tokenIndex, depth = position1247, tokenIndex1247, depth1247
+			return false
+		},
+		/* 74 SheddingOption <- <(Wait / DropOldest / DropNewest)> */
+		func() bool {
+			position1264, tokenIndex1264, depth1264 := position, tokenIndex, depth
+			{
+				position1265 := position
+				depth++
+				{
+					position1266, tokenIndex1266, depth1266 := position, tokenIndex, depth
+					if !_rules[ruleWait]() {
+						goto l1267
+					}
+					goto l1266
+				l1267:
+					position, tokenIndex, depth = position1266, tokenIndex1266, depth1266
+					if !_rules[ruleDropOldest]() {
+						goto l1268
+					}
+					goto l1266
+				l1268:
+					position, tokenIndex, depth = position1266, tokenIndex1266, depth1266
+					if !_rules[ruleDropNewest]() {
+						goto l1264
+					}
+				}
+			l1266:
+				depth--
+				add(ruleSheddingOption, position1265)
+			}
+			return true
+		l1264:
+			position, tokenIndex, depth = position1264, tokenIndex1264, depth1264
+			return false
+		},
+		/* 75 PipeSpecOpt <- <(<(spOpt ',' spOpt PipeOption sp (('p' / 'P') ('i' / 'I') ('p' / 'P') ('e' / 'E')))?> Action55)> */
+		func() bool {
+			position1269, tokenIndex1269, depth1269 := position, tokenIndex, depth
+			{
+				position1270 := position
+				depth++
+				{
+					position1271 := position
+					depth++
+					{
+						position1272, tokenIndex1272, depth1272 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1272
+						}
+						if buffer[position] != rune(',') {
+							goto l1272
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1272
+						}
+						if !_rules[rulePipeOption]() {
+							goto l1272
+						}
+						if !_rules[rulesp]() {
+							goto l1272
+						}
+						{
+							position1274, tokenIndex1274, depth1274 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1275
+							}
+							position++
+							goto l1274
+						l1275:
+							position, tokenIndex, depth = position1274, tokenIndex1274, depth1274
+							if buffer[position] != rune('P') {
+								goto l1272
+							}
+							position++
+						}
+					l1274:
+						{
+							position1276, tokenIndex1276, depth1276 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1277
+							}
+							position++
+							goto l1276
+						l1277:
+							position, tokenIndex, depth = position1276, tokenIndex1276, depth1276
+							if buffer[position] != rune('I') {
+								goto l1272
+							}
+							position++
+						}
+					l1276:
+						{
+							position1278, tokenIndex1278, depth1278 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1279
+							}
+							position++
+							goto l1278
+						l1279:
+							position, tokenIndex, depth = position1278, tokenIndex1278, depth1278
+							if buffer[position] != rune('P') {
+								goto l1272
+							}
+							position++
+						}
+					l1278:
+						{
+							position1280, tokenIndex1280, depth1280 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1281
+							}
+							position++
+							goto l1280
+						l1281:
+							position, tokenIndex, depth = position1280, tokenIndex1280, depth1280
+							if buffer[position] != rune('E') {
+								goto l1272
+							}
+							position++
+						}
+					l1280:
+						goto l1273
+					l1272:
+						position, tokenIndex, depth = position1272, tokenIndex1272, depth1272
+					}
+				l1273:
+					depth--
+					add(rulePegText, position1271)
+				}
+				if !_rules[ruleAction55]() {
+					goto l1269
+				}
+				depth--
+				add(rulePipeSpecOpt, position1270)
+			}
+			return true
+		l1269:
+			position, tokenIndex, depth = position1269, tokenIndex1269, depth1269
+			return false
+		},
+		/* 76 PipeOption <- <(ChannelPipe / RingPipe)> */
+		func() bool {
+			position1282, tokenIndex1282, depth1282 := position, tokenIndex, depth
+			{
+				position1283 := position
+				depth++
+				{
+					position1284, tokenIndex1284, depth1284 := position, tokenIndex, depth
+					if !_rules[ruleChannelPipe]() {
+						goto l1285
+					}
+					goto l1284
+				l1285:
+					position, tokenIndex, depth = position1284, tokenIndex1284, depth1284
+					if !_rules[ruleRingPipe]() {
+						goto l1282
+					}
+				}
+			l1284:
+				depth--
+				add(rulePipeOption, position1283)
+			}
+			return true
+		l1282:
+			position, tokenIndex, depth = position1282, tokenIndex1282, depth1282
+			return false
+		},
+		/* 77 PartitionSpecOpt <- <(<(spOpt ',' spOpt PartitionSpec)?> Action56)> */
+		func() bool {
+			position1286, tokenIndex1286, depth1286 := position, tokenIndex, depth
+			{
+				position1287 := position
+				depth++
+				{
+					position1288 := position
+					depth++
+					{
+						position1289, tokenIndex1289, depth1289 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1289
+						}
+						if buffer[position] != rune(',') {
+							goto l1289
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1289
+						}
+						if !_rules[rulePartitionSpec]() {
+							goto l1289
+						}
+						goto l1290
+					l1289:
+						position, tokenIndex, depth = position1289, tokenIndex1289, depth1289
+					}
+				l1290:
+					depth--
+					add(rulePegText, position1288)
+				}
+				if !_rules[ruleAction56]() {
+					goto l1286
+				}
+				depth--
+				add(rulePartitionSpecOpt, position1287)
+			}
+			return true
+		l1286:
+			position, tokenIndex, depth = position1286, tokenIndex1286, depth1286
+			return false
+		},
+		/* 78 PartitionSpec <- <(('p' / 'P') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('i' / 'I') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N') sp (('b' / 'B') ('y' / 'Y')) sp Expression sp (('i' / 'I') ('n' / 'N') ('s' / 'S') ('t' / 'T') ('a' / 'A') ('n' / 'N') ('c' / 'C') ('e' / 'E')) sp NonNegativeNumericLiteral sp (('o' / 'O') ('f' / 'F')) sp NonNegativeNumericLiteral Action57)> */
+		func() bool {
+			position1291, tokenIndex1291, depth1291 := position, tokenIndex, depth
+			{
+				position1292 := position
+				depth++
+				{
+					position1293, tokenIndex1293, depth1293 := position, tokenIndex, depth
+					if buffer[position] != rune('p') {
+						goto l1294
+					}
+					position++
+					goto l1293
+				l1294:
+					position, tokenIndex, depth = position1293, tokenIndex1293, depth1293
+					if buffer[position] != rune('P') {
+						goto l1291
+					}
+					position++
+				}
+			l1293:
+				{
+					position1295, tokenIndex1295, depth1295 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1296
+					}
+					position++
+					goto l1295
+				l1296:
+					position, tokenIndex, depth = position1295, tokenIndex1295, depth1295
+					if buffer[position] != rune('A') {
+						goto l1291
+					}
+					position++
+				}
+			l1295:
+				{
+					position1297, tokenIndex1297, depth1297 := position, tokenIndex, depth
+					if buffer[position] != rune('r') {
+						goto l1298
+					}
+					position++
+					goto l1297
+				l1298:
+					position, tokenIndex, depth = position1297, tokenIndex1297, depth1297
+					if buffer[position] != rune('R') {
+						goto l1291
+					}
+					position++
+				}
+			l1297:
+				{
+					position1299, tokenIndex1299, depth1299 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l1300
+					}
+					position++
+					goto l1299
+				l1300:
+					position, tokenIndex, depth = position1299, tokenIndex1299, depth1299
+					if buffer[position] != rune('T') {
+						goto l1291
+					}
+					position++
+				}
+			l1299:
+				{
+					position1301, tokenIndex1301, depth1301 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l1302
+					}
+					position++
+					goto l1301
+				l1302:
+					position, tokenIndex, depth = position1301, tokenIndex1301, depth1301
+					if buffer[position] != rune('I') {
+						goto l1291
+					}
+					position++
+				}
+			l1301:
+				{
+					position1303, tokenIndex1303, depth1303 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l1304
+					}
+					position++
+					goto l1303
+				l1304:
+					position, tokenIndex, depth = position1303, tokenIndex1303, depth1303
+					if buffer[position] != rune('T') {
+						goto l1291
+					}
+					position++
+				}
+			l1303:
+				{
+					position1305, tokenIndex1305, depth1305 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l1306
+					}
+					position++
+					goto l1305
+				l1306:
+					position, tokenIndex, depth = position1305, tokenIndex1305, depth1305
+					if buffer[position] != rune('I') {
+						goto l1291
+					}
+					position++
+				}
+			l1305:
+				{
+					position1307, tokenIndex1307, depth1307 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l1308
+					}
+					position++
+					goto l1307
+				l1308:
+					position, tokenIndex, depth = position1307, tokenIndex1307, depth1307
+					if buffer[position] != rune('O') {
+						goto l1291
+					}
+					position++
+				}
+			l1307:
+				{
+					position1309, tokenIndex1309, depth1309 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1310
+					}
+					position++
+					goto l1309
+				l1310:
+					position, tokenIndex, depth = position1309, tokenIndex1309, depth1309
+					if buffer[position] != rune('N') {
+						goto l1291
+					}
+					position++
+				}
+			l1309:
+				if !_rules[rulesp]() {
+					goto l1291
+				}
+				{
+					position1311, tokenIndex1311, depth1311 := position, tokenIndex, depth
+					if buffer[position] != rune('b') {
+						goto l1312
+					}
+					position++
+					goto l1311
+				l1312:
+					position, tokenIndex, depth = position1311, tokenIndex1311, depth1311
+					if buffer[position] != rune('B') {
+						goto l1291
+					}
+					position++
+				}
+			l1311:
+				{
+					position1313, tokenIndex1313, depth1313 := position, tokenIndex, depth
+					if buffer[position] != rune('y') {
+						goto l1314
+					}
+					position++
+					goto l1313
+				l1314:
+					position, tokenIndex, depth = position1313, tokenIndex1313, depth1313
+					if buffer[position] != rune('Y') {
+						goto l1291
+					}
+					position++
+				}
+			l1313:
+				if !_rules[rulesp]() {
+					goto l1291
+				}
+				if !_rules[ruleExpression]() {
+					goto l1291
+				}
+				if !_rules[rulesp]() {
+					goto l1291
+				}
+				{
+					position1315, tokenIndex1315, depth1315 := position, tokenIndex, depth
+					if buffer[position] != rune('i') {
+						goto l1316
+					}
+					position++
+					goto l1315
+				l1316:
+					position, tokenIndex, depth = position1315, tokenIndex1315, depth1315
+					if buffer[position] != rune('I') {
+						goto l1291
+					}
+					position++
+				}
+			l1315:
+				{
+					position1317, tokenIndex1317, depth1317 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1318
+					}
+					position++
+					goto l1317
+				l1318:
+					position, tokenIndex, depth = position1317, tokenIndex1317, depth1317
+					if buffer[position] != rune('N') {
+						goto l1291
+					}
+					position++
+				}
+			l1317:
+				{
+					position1319, tokenIndex1319, depth1319 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1320
+					}
+					position++
+					goto l1319
+				l1320:
+					position, tokenIndex, depth = position1319, tokenIndex1319, depth1319
+					if buffer[position] != rune('S') {
+						goto l1291
+					}
+					position++
+				}
+			l1319:
+				{
+					position1321, tokenIndex1321, depth1321 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l1322
+					}
+					position++
+					goto l1321
+				l1322:
+					position, tokenIndex, depth = position1321, tokenIndex1321, depth1321
+					if buffer[position] != rune('T') {
+						goto l1291
+					}
+					position++
+				}
+			l1321:
+				{
+					position1323, tokenIndex1323, depth1323 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1324
+					}
+					position++
+					goto l1323
+				l1324:
+					position, tokenIndex, depth = position1323, tokenIndex1323, depth1323
+					if buffer[position] != rune('A') {
+						goto l1291
+					}
+					position++
+				}
+			l1323:
+				{
+					position1325, tokenIndex1325, depth1325 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1326
+					}
+					position++
+					goto l1325
+				l1326:
+					position, tokenIndex, depth = position1325, tokenIndex1325, depth1325
+					if buffer[position] != rune('N') {
+						goto l1291
+					}
+					position++
+				}
+			l1325:
+				{
+					position1327, tokenIndex1327, depth1327 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l1328
+					}
+					position++
+					goto l1327
+				l1328:
+					position, tokenIndex, depth = position1327, tokenIndex1327, depth1327
+					if buffer[position] != rune('C') {
+						goto l1291
+					}
+					position++
+				}
+			l1327:
+				{
+					position1329, tokenIndex1329, depth1329 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1330
+					}
+					position++
+					goto l1329
+				l1330:
+					position, tokenIndex, depth = position1329, tokenIndex1329, depth1329
+					if buffer[position] != rune('E') {
+						goto l1291
+					}
+					position++
+				}
+			l1329:
+				if !_rules[rulesp]() {
+					goto l1291
+				}
+				if !_rules[ruleNonNegativeNumericLiteral]() {
+					goto l1291
+				}
+				if !_rules[rulesp]() {
+					goto l1291
+				}
+				{
+					position1331, tokenIndex1331, depth1331 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l1332
+					}
+					position++
+					goto l1331
+				l1332:
+					position, tokenIndex, depth = position1331, tokenIndex1331, depth1331
+					if buffer[position] != rune('O') {
+						goto l1291
+					}
+					position++
+				}
+			l1331:
+				{
+					position1333, tokenIndex1333, depth1333 := position, tokenIndex, depth
+					if buffer[position] != rune('f') {
+						goto l1334
+					}
+					position++
+					goto l1333
+				l1334:
+					position, tokenIndex, depth = position1333, tokenIndex1333, depth1333
+					if buffer[position] != rune('F') {
+						goto l1291
+					}
+					position++
+				}
+			l1333:
+				if !_rules[rulesp]() {
+					goto l1291
+				}
+				if !_rules[ruleNonNegativeNumericLiteral]() {
+					goto l1291
+				}
+				if !_rules[ruleAction57]() {
+					goto l1291
+				}
+				depth--
+				add(rulePartitionSpec, position1292)
+			}
+			return true
+		l1291:
+			position, tokenIndex, depth = position1291, tokenIndex1291, depth1291
+			return false
+		},
+		/* 79 SourceSinkSpecs <- <(<(sp (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action58)> */
+		func() bool {
+			position1335, tokenIndex1335, depth1335 := position, tokenIndex, depth
+			{
+				position1336 := position
+				depth++
+				{
+					position1337 := position
+					depth++
+					{
+						position1338, tokenIndex1338, depth1338 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1338
+						}
+						{
+							position1340, tokenIndex1340, depth1340 := position, tokenIndex, depth
+							if buffer[position] != rune('w') {
+								goto l1341
+							}
+							position++
+							goto l1340
+						l1341:
+							position, tokenIndex, depth = position1340, tokenIndex1340, depth1340
+							if buffer[position] != rune('W') {
+								goto l1338
+							}
+							position++
+						}
+					l1340:
+						{
+							position1342, tokenIndex1342, depth1342 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1343
+							}
+							position++
+							goto l1342
+						l1343:
+							position, tokenIndex, depth = position1342, tokenIndex1342, depth1342
+							if buffer[position] != rune('I') {
+								goto l1338
+							}
+							position++
+						}
+					l1342:
+						{
+							position1344, tokenIndex1344, depth1344 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l1345
+							}
+							position++
+							goto l1344
+						l1345:
+							position, tokenIndex, depth = position1344, tokenIndex1344, depth1344
+							if buffer[position] != rune('T') {
+								goto l1338
+							}
+							position++
+						}
+					l1344:
+						{
+							position1346, tokenIndex1346, depth1346 := position, tokenIndex, depth
+							if buffer[position] != rune('h') {
+								goto l1347
+							}
+							position++
+							goto l1346
+						l1347:
+							position, tokenIndex, depth = position1346, tokenIndex1346, depth1346
+							if buffer[position] != rune('H') {
+								goto l1338
+							}
+							position++
+						}
+					l1346:
+						if !_rules[rulesp]() {
+							goto l1338
+						}
+						if !_rules[ruleSourceSinkParam]() {
+							goto l1338
+						}
+					l1348:
+						{
+							position1349, tokenIndex1349, depth1349 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1349
+							}
+							if buffer[position] != rune(',') {
+								goto l1349
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1349
+							}
+							if !_rules[ruleSourceSinkParam]() {
+								goto l1349
+							}
+							goto l1348
+						l1349:
+							position, tokenIndex, depth = position1349, tokenIndex1349, depth1349
+						}
+						goto l1339
+					l1338:
+						position, tokenIndex, depth = position1338, tokenIndex1338, depth1338
+					}
+				l1339:
+					depth--
+					add(rulePegText, position1337)
+				}
+				if !_rules[ruleAction58]() {
+					goto l1335
+				}
+				depth--
+				add(ruleSourceSinkSpecs, position1336)
+			}
+			return true
+		l1335:
+			position, tokenIndex, depth = position1335, tokenIndex1335, depth1335
+			return false
+		},
+		/* 80 UpdateSourceSinkSpecs <- <(<(sp UpdateSpecsKeyword sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)> Action59)> */
+		func() bool {
+			position1350, tokenIndex1350, depth1350 := position, tokenIndex, depth
+			{
+				position1351 := position
+				depth++
+				{
+					position1352 := position
+					depth++
+					if !_rules[rulesp]() {
+						goto l1350
+					}
+					if !_rules[ruleUpdateSpecsKeyword]() {
+						goto l1350
+					}
+					if !_rules[rulesp]() {
+						goto l1350
+					}
+					if !_rules[ruleSourceSinkParam]() {
+						goto l1350
+					}
+				l1353:
+					{
+						position1354, tokenIndex1354, depth1354 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1354
+						}
+						if buffer[position] != rune(',') {
+							goto l1354
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1354
+						}
+						if !_rules[ruleSourceSinkParam]() {
+							goto l1354
+						}
+						goto l1353
+					l1354:
+						position, tokenIndex, depth = position1354, tokenIndex1354, depth1354
+					}
+					depth--
+					add(rulePegText, position1352)
+				}
+				if !_rules[ruleAction59]() {
+					goto l1350
+				}
+				depth--
+				add(ruleUpdateSourceSinkSpecs, position1351)
+			}
+			return true
+		l1350:
+			position, tokenIndex, depth = position1350, tokenIndex1350, depth1350
+			return false
+		},
+		/* 81 UpdateSpecsKeyword <- <((('s' / 'S') ('e' / 'E') ('t' / 'T')) / (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H')))> */
+		func() bool {
+			position1355, tokenIndex1355, depth1355 := position, tokenIndex, depth
+			{
+				position1356 := position
+				depth++
+				{
+					position1357, tokenIndex1357, depth1357 := position, tokenIndex, depth
+					{
+						position1359, tokenIndex1359, depth1359 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1360
+						}
+						position++
+						goto l1359
+					l1360:
+						position, tokenIndex, depth = position1359, tokenIndex1359, depth1359
+						if buffer[position] != rune('S') {
+							goto l1358
+						}
+						position++
+					}
+				l1359:
+					{
+						position1361, tokenIndex1361, depth1361 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1362
+						}
+						position++
+						goto l1361
+					l1362:
+						position, tokenIndex, depth = position1361, tokenIndex1361, depth1361
+						if buffer[position] != rune('E') {
+							goto l1358
+						}
+						position++
+					}
+				l1361:
+					{
+						position1363, tokenIndex1363, depth1363 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1364
+						}
+						position++
+						goto l1363
+					l1364:
+						position, tokenIndex, depth = position1363, tokenIndex1363, depth1363
+						if buffer[position] != rune('T') {
+							goto l1358
+						}
+						position++
+					}
+				l1363:
+					goto l1357
+				l1358:
+					position, tokenIndex, depth = position1357, tokenIndex1357, depth1357
+					{
+						position1365, tokenIndex1365, depth1365 := position, tokenIndex, depth
+						if buffer[position] != rune('w') {
+							goto l1366
+						}
+						position++
+						goto l1365
+					l1366:
+						position, tokenIndex, depth = position1365, tokenIndex1365, depth1365
+						if buffer[position] != rune('W') {
+							goto l1355
+						}
+						position++
+					}
+				l1365:
+					{
+						position1367, tokenIndex1367, depth1367 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l1368
+						}
+						position++
+						goto l1367
+					l1368:
+						position, tokenIndex, depth = position1367, tokenIndex1367, depth1367
+						if buffer[position] != rune('I') {
+							goto l1355
+						}
+						position++
+					}
+				l1367:
+					{
+						position1369, tokenIndex1369, depth1369 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1370
+						}
+						position++
+						goto l1369
+					l1370:
+						position, tokenIndex, depth = position1369, tokenIndex1369, depth1369
+						if buffer[position] != rune('T') {
+							goto l1355
+						}
+						position++
+					}
+				l1369:
+					{
+						position1371, tokenIndex1371, depth1371 := position, tokenIndex, depth
+						if buffer[position] != rune('h') {
+							goto l1372
+						}
+						position++
+						goto l1371
+					l1372:
+						position, tokenIndex, depth = position1371, tokenIndex1371, depth1371
+						if buffer[position] != rune('H') {
+							goto l1355
+						}
+						position++
+					}
+				l1371:
+				}
+			l1357:
+				depth--
+				add(ruleUpdateSpecsKeyword, position1356)
+			}
+			return true
+		l1355:
+			position, tokenIndex, depth = position1355, tokenIndex1355, depth1355
+			return false
+		},
+		/* 82 SetOptSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action60)> */
+		func() bool {
+			position1373, tokenIndex1373, depth1373 := position, tokenIndex, depth
+			{
+				position1374 := position
+				depth++
+				{
+					position1375 := position
+					depth++
+					{
+						position1376, tokenIndex1376, depth1376 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1376
+						}
+						{
+							position1378, tokenIndex1378, depth1378 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l1379
+							}
+							position++
+							goto l1378
+						l1379:
+							position, tokenIndex, depth = position1378, tokenIndex1378, depth1378
+							if buffer[position] != rune('S') {
+								goto l1376
+							}
+							position++
+						}
+					l1378:
+						{
+							position1380, tokenIndex1380, depth1380 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1381
+							}
+							position++
+							goto l1380
+						l1381:
+							position, tokenIndex, depth = position1380, tokenIndex1380, depth1380
+							if buffer[position] != rune('E') {
+								goto l1376
+							}
+							position++
+						}
+					l1380:
+						{
+							position1382, tokenIndex1382, depth1382 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l1383
+							}
+							position++
+							goto l1382
+						l1383:
+							position, tokenIndex, depth = position1382, tokenIndex1382, depth1382
+							if buffer[position] != rune('T') {
+								goto l1376
+							}
+							position++
+						}
+					l1382:
+						if !_rules[rulesp]() {
+							goto l1376
+						}
+						if !_rules[ruleSourceSinkParam]() {
+							goto l1376
+						}
+					l1384:
+						{
+							position1385, tokenIndex1385, depth1385 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1385
+							}
+							if buffer[position] != rune(',') {
+								goto l1385
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1385
+							}
+							if !_rules[ruleSourceSinkParam]() {
+								goto l1385
+							}
+							goto l1384
+						l1385:
+							position, tokenIndex, depth = position1385, tokenIndex1385, depth1385
+						}
+						goto l1377
+					l1376:
+						position, tokenIndex, depth = position1376, tokenIndex1376, depth1376
+					}
+				l1377:
+					depth--
+					add(rulePegText, position1375)
+				}
+				if !_rules[ruleAction60]() {
+					goto l1373
+				}
+				depth--
+				add(ruleSetOptSpecs, position1374)
+			}
+			return true
+		l1373:
+			position, tokenIndex, depth = position1373, tokenIndex1373, depth1373
+			return false
+		},
+		/* 83 StateTagOpt <- <(<(sp (('t' / 'T') ('a' / 'A') ('g' / 'G')) sp Identifier)?> Action61)> */
+		func() bool {
+			position1386, tokenIndex1386, depth1386 := position, tokenIndex, depth
+			{
+				position1387 := position
+				depth++
+				{
+					position1388 := position
+					depth++
+					{
+						position1389, tokenIndex1389, depth1389 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1389
+						}
+						{
+							position1391, tokenIndex1391, depth1391 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l1392
+							}
+							position++
+							goto l1391
+						l1392:
+							position, tokenIndex, depth = position1391, tokenIndex1391, depth1391
+							if buffer[position] != rune('T') {
+								goto l1389
+							}
+							position++
+						}
+					l1391:
+						{
+							position1393, tokenIndex1393, depth1393 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l1394
+							}
+							position++
+							goto l1393
+						l1394:
+							position, tokenIndex, depth = position1393, tokenIndex1393, depth1393
+							if buffer[position] != rune('A') {
+								goto l1389
+							}
+							position++
+						}
+					l1393:
+						{
+							position1395, tokenIndex1395, depth1395 := position, tokenIndex, depth
+							if buffer[position] != rune('g') {
+								goto l1396
+							}
+							position++
+							goto l1395
+						l1396:
+							position, tokenIndex, depth = position1395, tokenIndex1395, depth1395
+							if buffer[position] != rune('G') {
+								goto l1389
+							}
+							position++
+						}
+					l1395:
+						if !_rules[rulesp]() {
+							goto l1389
+						}
+						if !_rules[ruleIdentifier]() {
+							goto l1389
+						}
+						goto l1390
+					l1389:
+						position, tokenIndex, depth = position1389, tokenIndex1389, depth1389
+					}
+				l1390:
+					depth--
+					add(rulePegText, position1388)
+				}
+				if !_rules[ruleAction61]() {
+					goto l1386
+				}
+				depth--
+				add(ruleStateTagOpt, position1387)
+			}
+			return true
+		l1386:
+			position, tokenIndex, depth = position1386, tokenIndex1386, depth1386
+			return false
+		},
+		/* 84 SourceSinkParam <- <(SourceSinkParamKey spOpt '=' spOpt SourceSinkParamVal Action62)> */
+		func() bool {
+			position1397, tokenIndex1397, depth1397 := position, tokenIndex, depth
+			{
+				position1398 := position
+				depth++
+				if !_rules[ruleSourceSinkParamKey]() {
+					goto l1397
+				}
+				if !_rules[rulespOpt]() {
+					goto l1397
+				}
+				if buffer[position] != rune('=') {
+					goto l1397
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1397
+				}
+				if !_rules[ruleSourceSinkParamVal]() {
+					goto l1397
+				}
+				if !_rules[ruleAction62]() {
+					goto l1397
+				}
+				depth--
+				add(ruleSourceSinkParam, position1398)
+			}
+			return true
+		l1397:
+			position, tokenIndex, depth = position1397, tokenIndex1397, depth1397
+			return false
+		},
+		/* 85 SourceSinkParamVal <- <(ParamLiteral / ParamArrayExpr / ParamMapExpr)> */
+		func() bool {
+			position1399, tokenIndex1399, depth1399 := position, tokenIndex, depth
+			{
+				position1400 := position
+				depth++
+				{
+					position1401, tokenIndex1401, depth1401 := position, tokenIndex, depth
+					if !_rules[ruleParamLiteral]() {
+						goto l1402
+					}
+					goto l1401
+				l1402:
+					position, tokenIndex, depth = position1401, tokenIndex1401, depth1401
+					if !_rules[ruleParamArrayExpr]() {
+						goto l1403
+					}
+					goto l1401
+				l1403:
+					position, tokenIndex, depth = position1401, tokenIndex1401, depth1401
+					if !_rules[ruleParamMapExpr]() {
+						goto l1399
+					}
+				}
+			l1401:
+				depth--
+				add(ruleSourceSinkParamVal, position1400)
+			}
+			return true
+		l1399:
+			position, tokenIndex, depth = position1399, tokenIndex1399, depth1399
+			return false
+		},
+		/* 86 ParamLiteral <- <(BooleanLiteral / Literal)> */
+		func() bool {
+			position1404, tokenIndex1404, depth1404 := position, tokenIndex, depth
+			{
+				position1405 := position
+				depth++
+				{
+					position1406, tokenIndex1406, depth1406 := position, tokenIndex, depth
+					if !_rules[ruleBooleanLiteral]() {
+						goto l1407
+					}
+					goto l1406
+				l1407:
+					position, tokenIndex, depth = position1406, tokenIndex1406, depth1406
+					if !_rules[ruleLiteral]() {
+						goto l1404
+					}
+				}
+			l1406:
+				depth--
+				add(ruleParamLiteral, position1405)
+			}
+			return true
+		l1404:
+			position, tokenIndex, depth = position1404, tokenIndex1404, depth1404
+			return false
+		},
+		/* 87 ParamArrayExpr <- <(<('[' spOpt (SourceSinkParamVal (',' spOpt SourceSinkParamVal)*)? spOpt ','? spOpt ']')> Action63)> */
+		func() bool {
+			position1408, tokenIndex1408, depth1408 := position, tokenIndex, depth
+			{
+				position1409 := position
+				depth++
+				{
+					position1410 := position
+					depth++
+					if buffer[position] != rune('[') {
+						goto l1408
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1408
+					}
+					{
+						position1411, tokenIndex1411, depth1411 := position, tokenIndex, depth
+						if !_rules[ruleSourceSinkParamVal]() {
+							goto l1411
+						}
+					l1413:
+						{
+							position1414, tokenIndex1414, depth1414 := position, tokenIndex, depth
+							if buffer[position] != rune(',') {
+								goto l1414
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1414
+							}
+							if !_rules[ruleSourceSinkParamVal]() {
+								goto l1414
+							}
+							goto l1413
+						l1414:
+							position, tokenIndex, depth = position1414, tokenIndex1414, depth1414
+						}
+						goto l1412
+					l1411:
+						position, tokenIndex, depth = position1411, tokenIndex1411, depth1411
+					}
+				l1412:
+					if !_rules[rulespOpt]() {
+						goto l1408
+					}
+					{
+						position1415, tokenIndex1415, depth1415 := position, tokenIndex, depth
+						if buffer[position] != rune(',') {
+							goto l1415
+						}
+						position++
+						goto l1416
+					l1415:
+						position, tokenIndex, depth = position1415, tokenIndex1415, depth1415
+					}
+				l1416:
+					if !_rules[rulespOpt]() {
+						goto l1408
+					}
+					if buffer[position] != rune(']') {
+						goto l1408
+					}
+					position++
+					depth--
+					add(rulePegText, position1410)
+				}
+				if !_rules[ruleAction63]() {
+					goto l1408
+				}
+				depth--
+				add(ruleParamArrayExpr, position1409)
+			}
+			return true
+		l1408:
+			position, tokenIndex, depth = position1408, tokenIndex1408, depth1408
+			return false
+		},
+		/* 88 ParamMapExpr <- <(<('{' spOpt (ParamKeyValuePair (spOpt ',' spOpt ParamKeyValuePair)*)? spOpt '}')> Action64)> */
+		func() bool {
+			position1417, tokenIndex1417, depth1417 := position, tokenIndex, depth
+			{
+				position1418 := position
+				depth++
+				{
+					position1419 := position
+					depth++
+					if buffer[position] != rune('{') {
+						goto l1417
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1417
+					}
+					{
+						position1420, tokenIndex1420, depth1420 := position, tokenIndex, depth
+						if !_rules[ruleParamKeyValuePair]() {
+							goto l1420
+						}
+					l1422:
+						{
+							position1423, tokenIndex1423, depth1423 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1423
+							}
+							if buffer[position] != rune(',') {
+								goto l1423
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1423
+							}
+							if !_rules[ruleParamKeyValuePair]() {
+								goto l1423
+							}
+							goto l1422
+						l1423:
+							position, tokenIndex, depth = position1423, tokenIndex1423, depth1423
+						}
+						goto l1421
+					l1420:
+						position, tokenIndex, depth = position1420, tokenIndex1420, depth1420
+					}
+				l1421:
+					if !_rules[rulespOpt]() {
+						goto l1417
+					}
+					if buffer[position] != rune('}') {
+						goto l1417
+					}
+					position++
+					depth--
+					add(rulePegText, position1419)
+				}
+				if !_rules[ruleAction64]() {
+					goto l1417
+				}
+				depth--
+				add(ruleParamMapExpr, position1418)
+			}
+			return true
+		l1417:
+			position, tokenIndex, depth = position1417, tokenIndex1417, depth1417
+			return false
+		},
+		/* 89 ParamKeyValuePair <- <(<(StringLiteral spOpt ':' spOpt SourceSinkParamVal)> Action65)> */
+		func() bool {
+			position1424, tokenIndex1424, depth1424 := position, tokenIndex, depth
+			{
+				position1425 := position
+				depth++
+				{
+					position1426 := position
+					depth++
+					if !_rules[ruleStringLiteral]() {
+						goto l1424
+					}
+					if !_rules[rulespOpt]() {
+						goto l1424
+					}
+					if buffer[position] != rune(':') {
+						goto l1424
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1424
+					}
+					if !_rules[ruleSourceSinkParamVal]() {
+						goto l1424
+					}
+					depth--
+					add(rulePegText, position1426)
+				}
+				if !_rules[ruleAction65]() {
+					goto l1424
+				}
+				depth--
+				add(ruleParamKeyValuePair, position1425)
+			}
+			return true
+		l1424:
+			position, tokenIndex, depth = position1424, tokenIndex1424, depth1424
+			return false
+		},
+		/* 90 PausedOpt <- <(<(sp (Paused / Unpaused))?> Action66)> */
+		func() bool {
+			position1427, tokenIndex1427, depth1427 := position, tokenIndex, depth
+			{
+				position1428 := position
+				depth++
+				{
+					position1429 := position
+					depth++
+					{
+						position1430, tokenIndex1430, depth1430 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1430
+						}
+						{
+							position1432, tokenIndex1432, depth1432 := position, tokenIndex, depth
+							if !_rules[rulePaused]() {
+								goto l1433
+							}
+							goto l1432
+						l1433:
+							position, tokenIndex, depth = position1432, tokenIndex1432, depth1432
+							if !_rules[ruleUnpaused]() {
+								goto l1430
+							}
+						}
+					l1432:
+						goto l1431
+					l1430:
+						position, tokenIndex, depth = position1430, tokenIndex1430, depth1430
+					}
+				l1431:
+					depth--
+					add(rulePegText, position1429)
+				}
+				if !_rules[ruleAction66]() {
+					goto l1427
+				}
+				depth--
+				add(rulePausedOpt, position1428)
+			}
+			return true
+		l1427:
+			position, tokenIndex, depth = position1427, tokenIndex1427, depth1427
+			return false
+		},
+		/* 91 OrReplaceOpt <- <(<(sp OrReplace)?> Action67)> */
+		func() bool {
+			position1434, tokenIndex1434, depth1434 := position, tokenIndex, depth
+			{
+				position1435 := position
+				depth++
+				{
+					position1436 := position
+					depth++
+					{
+						position1437, tokenIndex1437, depth1437 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1437
+						}
+						if !_rules[ruleOrReplace]() {
+							goto l1437
+						}
+						goto l1438
+					l1437:
+						position, tokenIndex, depth = position1437, tokenIndex1437, depth1437
+					}
+				l1438:
+					depth--
+					add(rulePegText, position1436)
+				}
+				if !_rules[ruleAction67]() {
+					goto l1434
+				}
+				depth--
+				add(ruleOrReplaceOpt, position1435)
+			}
+			return true
+		l1434:
+			position, tokenIndex, depth = position1434, tokenIndex1434, depth1434
+			return false
+		},
+		/* 92 IfExistsOpt <- <(<(sp IfExists)?> Action68)> */
+		func() bool {
+			position1439, tokenIndex1439, depth1439 := position, tokenIndex, depth
+			{
+				position1440 := position
+				depth++
+				{
+					position1441 := position
+					depth++
+					{
+						position1442, tokenIndex1442, depth1442 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1442
+						}
+						if !_rules[ruleIfExists]() {
+							goto l1442
+						}
+						goto l1443
+					l1442:
+						position, tokenIndex, depth = position1442, tokenIndex1442, depth1442
+					}
+				l1443:
+					depth--
+					add(rulePegText, position1441)
+				}
+				if !_rules[ruleAction68]() {
+					goto l1439
+				}
+				depth--
+				add(ruleIfExistsOpt, position1440)
+			}
+			return true
+		l1439:
+			position, tokenIndex, depth = position1439, tokenIndex1439, depth1439
+			return false
+		},
+		/* 93 CascadeOpt <- <(<(sp Cascade)?> Action69)> */
+		func() bool {
+			position1444, tokenIndex1444, depth1444 := position, tokenIndex, depth
+			{
+				position1445 := position
+				depth++
+				{
+					position1446 := position
+					depth++
+					{
+						position1447, tokenIndex1447, depth1447 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1447
+						}
+						if !_rules[ruleCascade]() {
+							goto l1447
+						}
+						goto l1448
+					l1447:
+						position, tokenIndex, depth = position1447, tokenIndex1447, depth1447
+					}
+				l1448:
+					depth--
+					add(rulePegText, position1446)
+				}
+				if !_rules[ruleAction69]() {
+					goto l1444
+				}
+				depth--
+				add(ruleCascadeOpt, position1445)
+			}
+			return true
+		l1444:
+			position, tokenIndex, depth = position1444, tokenIndex1444, depth1444
+			return false
+		},
+		/* 94 ExpressionOrWildcard <- <(Wildcard / Expression)> */
+		func() bool {
+			position1449, tokenIndex1449, depth1449 := position, tokenIndex, depth
+			{
+				position1450 := position
+				depth++
+				{
+					position1451, tokenIndex1451, depth1451 := position, tokenIndex, depth
+					if !_rules[ruleWildcard]() {
+						goto l1452
+					}
+					goto l1451
+				l1452:
+					position, tokenIndex, depth = position1451, tokenIndex1451, depth1451
+					if !_rules[ruleExpression]() {
+						goto l1449
+					}
+				}
+			l1451:
+				depth--
+				add(ruleExpressionOrWildcard, position1450)
+			}
+			return true
+		l1449:
+			position, tokenIndex, depth = position1449, tokenIndex1449, depth1449
+			return false
+		},
+		/* 95 Expression <- <orExpr> */
+		func() bool {
+			position1453, tokenIndex1453, depth1453 := position, tokenIndex, depth
+			{
+				position1454 := position
+				depth++
+				if !_rules[ruleorExpr]() {
+					goto l1453
+				}
+				depth--
+				add(ruleExpression, position1454)
+			}
+			return true
+		l1453:
+			position, tokenIndex, depth = position1453, tokenIndex1453, depth1453
+			return false
+		},
+		/* 96 orExpr <- <(<(andExpr (sp Or sp andExpr)*)> Action70)> */
+		func() bool {
+			position1455, tokenIndex1455, depth1455 := position, tokenIndex, depth
+			{
+				position1456 := position
+				depth++
+				{
+					position1457 := position
+					depth++
+					if !_rules[ruleandExpr]() {
+						goto l1455
+					}
+				l1458:
+					{
+						position1459, tokenIndex1459, depth1459 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1459
+						}
+						if !_rules[ruleOr]() {
+							goto l1459
+						}
+						if !_rules[rulesp]() {
+							goto l1459
+						}
+						if !_rules[ruleandExpr]() {
+							goto l1459
+						}
+						goto l1458
+					l1459:
+						position, tokenIndex, depth = position1459, tokenIndex1459, depth1459
+					}
+					depth--
+					add(rulePegText, position1457)
+				}
+				if !_rules[ruleAction70]() {
+					goto l1455
+				}
+				depth--
+				add(ruleorExpr, position1456)
+			}
+			return true
+		l1455:
+			position, tokenIndex, depth = position1455, tokenIndex1455, depth1455
+			return false
+		},
+		/* 97 andExpr <- <(<(notExpr (sp And sp notExpr)*)> Action71)> */
+		func() bool {
+			position1460, tokenIndex1460, depth1460 := position, tokenIndex, depth
+			{
+				position1461 := position
+				depth++
+				{
+					position1462 := position
+					depth++
+					if !_rules[rulenotExpr]() {
+						goto l1460
+					}
+				l1463:
+					{
+						position1464, tokenIndex1464, depth1464 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1464
+						}
+						if !_rules[ruleAnd]() {
+							goto l1464
+						}
+						if !_rules[rulesp]() {
+							goto l1464
+						}
+						if !_rules[rulenotExpr]() {
+							goto l1464
+						}
+						goto l1463
+					l1464:
+						position, tokenIndex, depth = position1464, tokenIndex1464, depth1464
+					}
+					depth--
+					add(rulePegText, position1462)
+				}
+				if !_rules[ruleAction71]() {
+					goto l1460
+				}
+				depth--
+				add(ruleandExpr, position1461)
+			}
+			return true
+		l1460:
+			position, tokenIndex, depth = position1460, tokenIndex1460, depth1460
+			return false
+		},
+		/* 98 notExpr <- <(<((Not sp)? comparisonExpr)> Action72)> */
+		func() bool {
+			position1465, tokenIndex1465, depth1465 := position, tokenIndex, depth
+			{
+				position1466 := position
+				depth++
+				{
+					position1467 := position
+					depth++
+					{
+						position1468, tokenIndex1468, depth1468 := position, tokenIndex, depth
+						if !_rules[ruleNot]() {
+							goto l1468
+						}
+						if !_rules[rulesp]() {
+							goto l1468
+						}
+						goto l1469
+					l1468:
+						position, tokenIndex, depth = position1468, tokenIndex1468, depth1468
+					}
+				l1469:
+					if !_rules[rulecomparisonExpr]() {
+						goto l1465
+					}
+					depth--
+					add(rulePegText, position1467)
+				}
+				if !_rules[ruleAction72]() {
+					goto l1465
+				}
+				depth--
+				add(rulenotExpr, position1466)
+			}
+			return true
+		l1465:
+			position, tokenIndex, depth = position1465, tokenIndex1465, depth1465
+			return false
+		},
+		/* 99 comparisonExpr <- <(<(otherOpExpr (((spOpt ComparisonOp spOpt) / (sp DistinctOp sp)) otherOpExpr)?)> Action73)> */
+		func() bool {
+			position1470, tokenIndex1470, depth1470 := position, tokenIndex, depth
+			{
+				position1471 := position
+				depth++
+				{
+					position1472 := position
+					depth++
+					if !_rules[ruleotherOpExpr]() {
+						goto l1470
+					}
+					{
+						position1473, tokenIndex1473, depth1473 := position, tokenIndex, depth
+						{
+							position1475, tokenIndex1475, depth1475 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1476
+							}
+							if !_rules[ruleComparisonOp]() {
+								goto l1476
+							}
+							if !_rules[rulespOpt]() {
+								goto l1476
+							}
+							goto l1475
+						l1476:
+							position, tokenIndex, depth = position1475, tokenIndex1475, depth1475
+							if !_rules[rulesp]() {
+								goto l1473
+							}
+							if !_rules[ruleDistinctOp]() {
+								goto l1473
+							}
+							if !_rules[rulesp]() {
+								goto l1473
+							}
+						}
+					l1475:
+						if !_rules[ruleotherOpExpr]() {
+							goto l1473
+						}
+						goto l1474
+					l1473:
+						position, tokenIndex, depth = position1473, tokenIndex1473, depth1473
+					}
+				l1474:
+					depth--
+					add(rulePegText, position1472)
+				}
+				if !_rules[ruleAction73]() {
+					goto l1470
+				}
+				depth--
+				add(rulecomparisonExpr, position1471)
+			}
+			return true
+		l1470:
+			position, tokenIndex, depth = position1470, tokenIndex1470, depth1470
+			return false
+		},
+		/* 100 otherOpExpr <- <(<(isExpr (spOpt OtherOp spOpt isExpr)*)> Action74)> */
+		func() bool {
+			position1477, tokenIndex1477, depth1477 := position, tokenIndex, depth
+			{
+				position1478 := position
+				depth++
+				{
+					position1479 := position
+					depth++
+					if !_rules[ruleisExpr]() {
+						goto l1477
+					}
+				l1480:
+					{
+						position1481, tokenIndex1481, depth1481 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1481
+						}
+						if !_rules[ruleOtherOp]() {
+							goto l1481
+						}
+						if !_rules[rulespOpt]() {
+							goto l1481
+						}
+						if !_rules[ruleisExpr]() {
+							goto l1481
+						}
+						goto l1480
+					l1481:
+						position, tokenIndex, depth = position1481, tokenIndex1481, depth1481
+					}
+					depth--
+					add(rulePegText, position1479)
+				}
+				if !_rules[ruleAction74]() {
+					goto l1477
+				}
+				depth--
+				add(ruleotherOpExpr, position1478)
+			}
+			return true
+		l1477:
+			position, tokenIndex, depth = position1477, tokenIndex1477, depth1477
+			return false
+		},
+		/* 101 isExpr <- <(<((RowValue sp IsOp sp Missing) / (termExpr (sp IsOp sp NullLiteral)?))> Action75)> */
+		func() bool {
+			position1482, tokenIndex1482, depth1482 := position, tokenIndex, depth
+			{
+				position1483 := position
+				depth++
+				{
+					position1484 := position
+					depth++
+					{
+						position1485, tokenIndex1485, depth1485 := position, tokenIndex, depth
+						if !_rules[ruleRowValue]() {
+							goto l1486
+						}
+						if !_rules[rulesp]() {
+							goto l1486
+						}
+						if !_rules[ruleIsOp]() {
+							goto l1486
+						}
+						if !_rules[rulesp]() {
+							goto l1486
+						}
+						if !_rules[ruleMissing]() {
+							goto l1486
+						}
+						goto l1485
+					l1486:
+						position, tokenIndex, depth = position1485, tokenIndex1485, depth1485
+						if !_rules[ruletermExpr]() {
+							goto l1482
+						}
+						{
+							position1487, tokenIndex1487, depth1487 := position, tokenIndex, depth
+							if !_rules[rulesp]() {
+								goto l1487
+							}
+							if !_rules[ruleIsOp]() {
+								goto l1487
+							}
+							if !_rules[rulesp]() {
+								goto l1487
+							}
+							if !_rules[ruleNullLiteral]() {
+								goto l1487
+							}
+							goto l1488
+						l1487:
+							position, tokenIndex, depth = position1487, tokenIndex1487, depth1487
+						}
+					l1488:
+					}
+				l1485:
+					depth--
+					add(rulePegText, position1484)
+				}
+				if !_rules[ruleAction75]() {
+					goto l1482
+				}
+				depth--
+				add(ruleisExpr, position1483)
+			}
+			return true
+		l1482:
+			position, tokenIndex, depth = position1482, tokenIndex1482, depth1482
+			return false
+		},
+		/* 102 termExpr <- <(<(productExpr (spOpt PlusMinusOp spOpt productExpr)*)> Action76)> */
+		func() bool {
+			position1489, tokenIndex1489, depth1489 := position, tokenIndex, depth
+			{
+				position1490 := position
+				depth++
+				{
+					position1491 := position
+					depth++
+					if !_rules[ruleproductExpr]() {
+						goto l1489
+					}
+				l1492:
+					{
+						position1493, tokenIndex1493, depth1493 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1493
+						}
+						if !_rules[rulePlusMinusOp]() {
+							goto l1493
+						}
+						if !_rules[rulespOpt]() {
+							goto l1493
+						}
+						if !_rules[ruleproductExpr]() {
+							goto l1493
+						}
+						goto l1492
+					l1493:
+						position, tokenIndex, depth = position1493, tokenIndex1493, depth1493
+					}
+					depth--
+					add(rulePegText, position1491)
+				}
+				if !_rules[ruleAction76]() {
+					goto l1489
+				}
+				depth--
+				add(ruletermExpr, position1490)
+			}
+			return true
+		l1489:
+			position, tokenIndex, depth = position1489, tokenIndex1489, depth1489
+			return false
+		},
+		/* 103 productExpr <- <(<(minusExpr (spOpt MultDivOp spOpt minusExpr)*)> Action77)> */
+		func() bool {
+			position1494, tokenIndex1494, depth1494 := position, tokenIndex, depth
+			{
+				position1495 := position
+				depth++
+				{
+					position1496 := position
+					depth++
+					if !_rules[ruleminusExpr]() {
+						goto l1494
+					}
+				l1497:
+					{
+						position1498, tokenIndex1498, depth1498 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1498
+						}
+						if !_rules[ruleMultDivOp]() {
+							goto l1498
+						}
+						if !_rules[rulespOpt]() {
+							goto l1498
+						}
+						if !_rules[ruleminusExpr]() {
+							goto l1498
+						}
+						goto l1497
+					l1498:
+						position, tokenIndex, depth = position1498, tokenIndex1498, depth1498
+					}
+					depth--
+					add(rulePegText, position1496)
+				}
+				if !_rules[ruleAction77]() {
+					goto l1494
+				}
+				depth--
+				add(ruleproductExpr, position1495)
+			}
+			return true
+		l1494:
+			position, tokenIndex, depth = position1494, tokenIndex1494, depth1494
+			return false
+		},
+		/* 104 minusExpr <- <(<((UnaryMinus spOpt)? castExpr)> Action78)> */
+		func() bool {
+			position1499, tokenIndex1499, depth1499 := position, tokenIndex, depth
+			{
+				position1500 := position
+				depth++
+				{
+					position1501 := position
+					depth++
+					{
+						position1502, tokenIndex1502, depth1502 := position, tokenIndex, depth
+						if !_rules[ruleUnaryMinus]() {
+							goto l1502
+						}
+						if !_rules[rulespOpt]() {
+							goto l1502
+						}
+						goto l1503
+					l1502:
+						position, tokenIndex, depth = position1502, tokenIndex1502, depth1502
+					}
+				l1503:
+					if !_rules[rulecastExpr]() {
+						goto l1499
+					}
+					depth--
+					add(rulePegText, position1501)
+				}
+				if !_rules[ruleAction78]() {
+					goto l1499
+				}
+				depth--
+				add(ruleminusExpr, position1500)
+			}
+			return true
+		l1499:
+			position, tokenIndex, depth = position1499, tokenIndex1499, depth1499
+			return false
+		},
+		/* 105 castExpr <- <(<(baseExpr (spOpt (':' ':') spOpt Type)?)> Action79)> */
+		func() bool {
+			position1504, tokenIndex1504, depth1504 := position, tokenIndex, depth
+			{
+				position1505 := position
+				depth++
+				{
+					position1506 := position
+					depth++
+					if !_rules[rulebaseExpr]() {
+						goto l1504
+					}
+					{
+						position1507, tokenIndex1507, depth1507 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1507
+						}
+						if buffer[position] != rune(':') {
+							goto l1507
+						}
+						position++
+						if buffer[position] != rune(':') {
+							goto l1507
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1507
+						}
+						if !_rules[ruleType]() {
+							goto l1507
+						}
+						goto l1508
+					l1507:
+						position, tokenIndex, depth = position1507, tokenIndex1507, depth1507
+					}
+				l1508:
+					depth--
+					add(rulePegText, position1506)
+				}
+				if !_rules[ruleAction79]() {
+					goto l1504
+				}
+				depth--
+				add(rulecastExpr, position1505)
+			}
+			return true
+		l1504:
+			position, tokenIndex, depth = position1504, tokenIndex1504, depth1504
+			return false
+		},
+		/* 106 baseExpr <- <(('(' spOpt Expression spOpt ')') / MapExpr / BooleanLiteral / NullLiteral / Case / RowMeta / FuncTypeCast / FuncApp / RowValue / ArrayExpr / Literal)> */
+		func() bool {
+			position1509, tokenIndex1509, depth1509 := position, tokenIndex, depth
+			{
+				position1510 := position
+				depth++
+				{
+					position1511, tokenIndex1511, depth1511 := position, tokenIndex, depth
+					if buffer[position] != rune('(') {
+						goto l1512
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1512
+					}
+					if !_rules[ruleExpression]() {
+						goto l1512
+					}
+					if !_rules[rulespOpt]() {
+						goto l1512
+					}
+					if buffer[position] != rune(')') {
+						goto l1512
+					}
+					position++
+					goto l1511
+				l1512:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleMapExpr]() {
+						goto l1513
+					}
+					goto l1511
+				l1513:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleBooleanLiteral]() {
+						goto l1514
+					}
+					goto l1511
+				l1514:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleNullLiteral]() {
+						goto l1515
+					}
+					goto l1511
+				l1515:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleCase]() {
+						goto l1516
+					}
+					goto l1511
+				l1516:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleRowMeta]() {
+						goto l1517
+					}
+					goto l1511
+				l1517:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleFuncTypeCast]() {
+						goto l1518
+					}
+					goto l1511
+				l1518:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleFuncApp]() {
+						goto l1519
+					}
+					goto l1511
+				l1519:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleRowValue]() {
+						goto l1520
+					}
+					goto l1511
+				l1520:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleArrayExpr]() {
+						goto l1521
+					}
+					goto l1511
+				l1521:
+					position, tokenIndex, depth = position1511, tokenIndex1511, depth1511
+					if !_rules[ruleLiteral]() {
+						goto l1509
+					}
+				}
+			l1511:
+				depth--
+				add(rulebaseExpr, position1510)
+			}
+			return true
+		l1509:
+			position, tokenIndex, depth = position1509, tokenIndex1509, depth1509
+			return false
+		},
+		/* 107 FuncTypeCast <- <(<(('c' / 'C') ('a' / 'A') ('s' / 'S') ('t' / 'T') spOpt '(' spOpt Expression sp (('a' / 'A') ('s' / 'S')) sp Type CastFormatOpt spOpt ')')> Action80)> */
+		func() bool {
+			position1522, tokenIndex1522, depth1522 := position, tokenIndex, depth
+			{
+				position1523 := position
+				depth++
+				{
+					position1524 := position
+					depth++
+					{
+						position1525, tokenIndex1525, depth1525 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l1526
+						}
+						position++
+						goto l1525
+					l1526:
+						position, tokenIndex, depth = position1525, tokenIndex1525, depth1525
+						if buffer[position] != rune('C') {
+							goto l1522
+						}
+						position++
+					}
+				l1525:
+					{
+						position1527, tokenIndex1527, depth1527 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1528
+						}
+						position++
+						goto l1527
+					l1528:
+						position, tokenIndex, depth = position1527, tokenIndex1527, depth1527
+						if buffer[position] != rune('A') {
+							goto l1522
+						}
+						position++
+					}
+				l1527:
+					{
+						position1529, tokenIndex1529, depth1529 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1530
+						}
+						position++
+						goto l1529
+					l1530:
+						position, tokenIndex, depth = position1529, tokenIndex1529, depth1529
+						if buffer[position] != rune('S') {
+							goto l1522
+						}
+						position++
+					}
+				l1529:
+					{
+						position1531, tokenIndex1531, depth1531 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1532
+						}
+						position++
+						goto l1531
+					l1532:
+						position, tokenIndex, depth = position1531, tokenIndex1531, depth1531
+						if buffer[position] != rune('T') {
+							goto l1522
+						}
+						position++
+					}
+				l1531:
+					if !_rules[rulespOpt]() {
+						goto l1522
+					}
+					if buffer[position] != rune('(') {
+						goto l1522
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1522
+					}
+					if !_rules[ruleExpression]() {
+						goto l1522
+					}
+					if !_rules[rulesp]() {
+						goto l1522
+					}
+					{
+						position1533, tokenIndex1533, depth1533 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1534
+						}
+						position++
+						goto l1533
+					l1534:
+						position, tokenIndex, depth = position1533, tokenIndex1533, depth1533
+						if buffer[position] != rune('A') {
+							goto l1522
+						}
+						position++
+					}
+				l1533:
+					{
+						position1535, tokenIndex1535, depth1535 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1536
+						}
+						position++
+						goto l1535
+					l1536:
+						position, tokenIndex, depth = position1535, tokenIndex1535, depth1535
+						if buffer[position] != rune('S') {
+							goto l1522
+						}
+						position++
+					}
+				l1535:
+					if !_rules[rulesp]() {
+						goto l1522
+					}
+					if !_rules[ruleType]() {
+						goto l1522
+					}
+					if !_rules[ruleCastFormatOpt]() {
+						goto l1522
+					}
+					if !_rules[rulespOpt]() {
+						goto l1522
+					}
+					if buffer[position] != rune(')') {
+						goto l1522
+					}
+					position++
+					depth--
+					add(rulePegText, position1524)
+				}
+				if !_rules[ruleAction80]() {
+					goto l1522
+				}
+				depth--
+				add(ruleFuncTypeCast, position1523)
+			}
+			return true
+		l1522:
+			position, tokenIndex, depth = position1522, tokenIndex1522, depth1522
+			return false
+		},
+		/* 108 CastFormatOpt <- <(sp (('f' / 'F') ('o' / 'O') ('r' / 'R') ('m' / 'M') ('a' / 'A') ('t' / 'T')) sp StringLiteral)?> */
+		func() bool {
+			{
+				position1538 := position
+				depth++
+				{
+					position1539, tokenIndex1539, depth1539 := position, tokenIndex, depth
+					if !_rules[rulesp]() {
+						goto l1539
+					}
+					{
+						position1541, tokenIndex1541, depth1541 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l1542
+						}
+						position++
+						goto l1541
+					l1542:
+						position, tokenIndex, depth = position1541, tokenIndex1541, depth1541
+						if buffer[position] != rune('F') {
+							goto l1539
+						}
+						position++
+					}
+				l1541:
+					{
+						position1543, tokenIndex1543, depth1543 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l1544
+						}
+						position++
+						goto l1543
+					l1544:
+						position, tokenIndex, depth = position1543, tokenIndex1543, depth1543
+						if buffer[position] != rune('O') {
+							goto l1539
+						}
+						position++
+					}
+				l1543:
+					{
+						position1545, tokenIndex1545, depth1545 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l1546
+						}
+						position++
+						goto l1545
+					l1546:
+						position, tokenIndex, depth = position1545, tokenIndex1545, depth1545
+						if buffer[position] != rune('R') {
+							goto l1539
+						}
+						position++
+					}
+				l1545:
+					{
+						position1547, tokenIndex1547, depth1547 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l1548
+						}
+						position++
+						goto l1547
+					l1548:
+						position, tokenIndex, depth = position1547, tokenIndex1547, depth1547
+						if buffer[position] != rune('M') {
+							goto l1539
+						}
+						position++
+					}
+				l1547:
+					{
+						position1549, tokenIndex1549, depth1549 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1550
+						}
+						position++
+						goto l1549
+					l1550:
+						position, tokenIndex, depth = position1549, tokenIndex1549, depth1549
+						if buffer[position] != rune('A') {
+							goto l1539
+						}
+						position++
+					}
+				l1549:
+					{
+						position1551, tokenIndex1551, depth1551 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1552
+						}
+						position++
+						goto l1551
+					l1552:
+						position, tokenIndex, depth = position1551, tokenIndex1551, depth1551
+						if buffer[position] != rune('T') {
+							goto l1539
+						}
+						position++
+					}
+				l1551:
+					if !_rules[rulesp]() {
+						goto l1539
+					}
+					if !_rules[ruleStringLiteral]() {
+						goto l1539
+					}
+					goto l1540
+				l1539:
+					position, tokenIndex, depth = position1539, tokenIndex1539, depth1539
+				}
+			l1540:
+				depth--
+				add(ruleCastFormatOpt, position1538)
+			}
+			return true
+		},
+		/* 109 FuncApp <- <((FuncAppWithOrderBy / FuncAppWithoutOrderBy) WindowClauseOpt)> */
+		func() bool {
+			position1553, tokenIndex1553, depth1553 := position, tokenIndex, depth
+			{
+				position1554 := position
+				depth++
+				{
+					position1555, tokenIndex1555, depth1555 := position, tokenIndex, depth
+					if !_rules[ruleFuncAppWithOrderBy]() {
+						goto l1556
+					}
+					goto l1555
+				l1556:
+					position, tokenIndex, depth = position1555, tokenIndex1555, depth1555
+					if !_rules[ruleFuncAppWithoutOrderBy]() {
+						goto l1553
+					}
+				}
+			l1555:
+				if !_rules[ruleWindowClauseOpt]() {
+					goto l1553
+				}
+				depth--
+				add(ruleFuncApp, position1554)
+			}
+			return true
+		l1553:
+			position, tokenIndex, depth = position1553, tokenIndex1553, depth1553
+			return false
+		},
+		/* 110 WindowClauseOpt <- <(<(sp (('o' / 'O') ('v' / 'V') ('e' / 'E') ('r' / 'R')) spOpt '(' spOpt WindowPartition spOpt WindowOrder spOpt ')')?> Action81)> */
+		func() bool {
+			position1557, tokenIndex1557, depth1557 := position, tokenIndex, depth
+			{
+				position1558 := position
+				depth++
+				{
+					position1559 := position
+					depth++
+					{
+						position1560, tokenIndex1560, depth1560 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1560
+						}
+						{
+							position1562, tokenIndex1562, depth1562 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l1563
+							}
+							position++
+							goto l1562
+						l1563:
+							position, tokenIndex, depth = position1562, tokenIndex1562, depth1562
+							if buffer[position] != rune('O') {
+								goto l1560
+							}
+							position++
+						}
+					l1562:
+						{
+							position1564, tokenIndex1564, depth1564 := position, tokenIndex, depth
+							if buffer[position] != rune('v') {
+								goto l1565
+							}
+							position++
+							goto l1564
+						l1565:
+							position, tokenIndex, depth = position1564, tokenIndex1564, depth1564
+							if buffer[position] != rune('V') {
+								goto l1560
+							}
+							position++
+						}
+					l1564:
+						{
+							position1566, tokenIndex1566, depth1566 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1567
+							}
+							position++
+							goto l1566
+						l1567:
+							position, tokenIndex, depth = position1566, tokenIndex1566, depth1566
+							if buffer[position] != rune('E') {
+								goto l1560
+							}
+							position++
+						}
+					l1566:
+						{
+							position1568, tokenIndex1568, depth1568 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1569
+							}
+							position++
+							goto l1568
+						l1569:
+							position, tokenIndex, depth = position1568, tokenIndex1568, depth1568
+							if buffer[position] != rune('R') {
+								goto l1560
+							}
+							position++
+						}
+					l1568:
+						if !_rules[rulespOpt]() {
+							goto l1560
+						}
+						if buffer[position] != rune('(') {
+							goto l1560
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1560
+						}
+						if !_rules[ruleWindowPartition]() {
+							goto l1560
+						}
+						if !_rules[rulespOpt]() {
+							goto l1560
+						}
+						if !_rules[ruleWindowOrder]() {
+							goto l1560
+						}
+						if !_rules[rulespOpt]() {
+							goto l1560
+						}
+						if buffer[position] != rune(')') {
+							goto l1560
+						}
+						position++
+						goto l1561
+					l1560:
+						position, tokenIndex, depth = position1560, tokenIndex1560, depth1560
+					}
+				l1561:
+					depth--
+					add(rulePegText, position1559)
+				}
+				if !_rules[ruleAction81]() {
+					goto l1557
+				}
+				depth--
+				add(ruleWindowClauseOpt, position1558)
+			}
+			return true
+		l1557:
+			position, tokenIndex, depth = position1557, tokenIndex1557, depth1557
+			return false
+		},
+		/* 111 WindowPartition <- <(<(('p' / 'P') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('i' / 'I') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N') sp (('b' / 'B') ('y' / 'Y')) sp Expression (spOpt ',' spOpt Expression)*)?> Action82)> */
+		func() bool {
+			position1570, tokenIndex1570, depth1570 := position, tokenIndex, depth
+			{
+				position1571 := position
+				depth++
+				{
+					position1572 := position
+					depth++
+					{
+						position1573, tokenIndex1573, depth1573 := position, tokenIndex, depth
+						{
+							position1575, tokenIndex1575, depth1575 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1576
+							}
+							position++
+							goto l1575
+						l1576:
+							position, tokenIndex, depth = position1575, tokenIndex1575, depth1575
+							if buffer[position] != rune('P') {
+								goto l1573
+							}
+							position++
+						}
+					l1575:
+						{
+							position1577, tokenIndex1577, depth1577 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l1578
+							}
+							position++
+							goto l1577
+						l1578:
+							position, tokenIndex, depth = position1577, tokenIndex1577, depth1577
+							if buffer[position] != rune('A') {
+								goto l1573
+							}
+							position++
+						}
+					l1577:
+						{
+							position1579, tokenIndex1579, depth1579 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1580
+							}
+							position++
+							goto l1579
+						l1580:
+							position, tokenIndex, depth = position1579, tokenIndex1579, depth1579
+							if buffer[position] != rune('R') {
+								goto l1573
+							}
+							position++
+						}
+					l1579:
+						{
+							position1581, tokenIndex1581, depth1581 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l1582
+							}
+							position++
+							goto l1581
+						l1582:
+							position, tokenIndex, depth = position1581, tokenIndex1581, depth1581
+							if buffer[position] != rune('T') {
+								goto l1573
+							}
+							position++
+						}
+					l1581:
+						{
+							position1583, tokenIndex1583, depth1583 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1584
+							}
+							position++
+							goto l1583
+						l1584:
+							position, tokenIndex, depth = position1583, tokenIndex1583, depth1583
+							if buffer[position] != rune('I') {
+								goto l1573
+							}
+							position++
+						}
+					l1583:
+						{
+							position1585, tokenIndex1585, depth1585 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l1586
+							}
+							position++
+							goto l1585
+						l1586:
+							position, tokenIndex, depth = position1585, tokenIndex1585, depth1585
+							if buffer[position] != rune('T') {
+								goto l1573
+							}
+							position++
+						}
+					l1585:
+						{
+							position1587, tokenIndex1587, depth1587 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1588
+							}
+							position++
+							goto l1587
+						l1588:
+							position, tokenIndex, depth = position1587, tokenIndex1587, depth1587
+							if buffer[position] != rune('I') {
+								goto l1573
+							}
+							position++
+						}
+					l1587:
+						{
+							position1589, tokenIndex1589, depth1589 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l1590
+							}
+							position++
+							goto l1589
+						l1590:
+							position, tokenIndex, depth = position1589, tokenIndex1589, depth1589
+							if buffer[position] != rune('O') {
+								goto l1573
+							}
+							position++
+						}
+					l1589:
+						{
+							position1591, tokenIndex1591, depth1591 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l1592
+							}
+							position++
+							goto l1591
+						l1592:
+							position, tokenIndex, depth = position1591, tokenIndex1591, depth1591
+							if buffer[position] != rune('N') {
+								goto l1573
+							}
+							position++
+						}
+					l1591:
+						if !_rules[rulesp]() {
+							goto l1573
+						}
+						{
+							position1593, tokenIndex1593, depth1593 := position, tokenIndex, depth
+							if buffer[position] != rune('b') {
+								goto l1594
+							}
+							position++
+							goto l1593
+						l1594:
+							position, tokenIndex, depth = position1593, tokenIndex1593, depth1593
+							if buffer[position] != rune('B') {
+								goto l1573
+							}
+							position++
+						}
+					l1593:
+						{
+							position1595, tokenIndex1595, depth1595 := position, tokenIndex, depth
+							if buffer[position] != rune('y') {
+								goto l1596
+							}
+							position++
+							goto l1595
+						l1596:
+							position, tokenIndex, depth = position1595, tokenIndex1595, depth1595
+							if buffer[position] != rune('Y') {
+								goto l1573
+							}
+							position++
+						}
+					l1595:
+						if !_rules[rulesp]() {
+							goto l1573
+						}
+						if !_rules[ruleExpression]() {
+							goto l1573
+						}
+					l1597:
+						{
+							position1598, tokenIndex1598, depth1598 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1598
+							}
+							if buffer[position] != rune(',') {
+								goto l1598
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1598
+							}
+							if !_rules[ruleExpression]() {
+								goto l1598
+							}
+							goto l1597
+						l1598:
+							position, tokenIndex, depth = position1598, tokenIndex1598, depth1598
+						}
+						goto l1574
+					l1573:
+						position, tokenIndex, depth = position1573, tokenIndex1573, depth1573
+					}
+				l1574:
+					depth--
+					add(rulePegText, position1572)
+				}
+				if !_rules[ruleAction82]() {
+					goto l1570
+				}
+				depth--
+				add(ruleWindowPartition, position1571)
+			}
+			return true
+		l1570:
+			position, tokenIndex, depth = position1570, tokenIndex1570, depth1570
+			return false
+		},
+		/* 112 WindowOrder <- <(<(('o' / 'O') ('r' / 'R') ('d' / 'D') ('e' / 'E') ('r' / 'R') sp (('b' / 'B') ('y' / 'Y')) sp SortedExpression (spOpt ',' spOpt SortedExpression)*)?> Action83)> */
+		func() bool {
+			position1599, tokenIndex1599, depth1599 := position, tokenIndex, depth
+			{
+				position1600 := position
+				depth++
+				{
+					position1601 := position
+					depth++
+					{
+						position1602, tokenIndex1602, depth1602 := position, tokenIndex, depth
+						{
+							position1604, tokenIndex1604, depth1604 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l1605
+							}
+							position++
+							goto l1604
+						l1605:
+							position, tokenIndex, depth = position1604, tokenIndex1604, depth1604
+							if buffer[position] != rune('O') {
+								goto l1602
+							}
+							position++
+						}
+					l1604:
+						{
+							position1606, tokenIndex1606, depth1606 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1607
+							}
+							position++
+							goto l1606
+						l1607:
+							position, tokenIndex, depth = position1606, tokenIndex1606, depth1606
+							if buffer[position] != rune('R') {
+								goto l1602
+							}
+							position++
+						}
+					l1606:
+						{
+							position1608, tokenIndex1608, depth1608 := position, tokenIndex, depth
+							if buffer[position] != rune('d') {
+								goto l1609
+							}
+							position++
+							goto l1608
+						l1609:
+							position, tokenIndex, depth = position1608, tokenIndex1608, depth1608
+							if buffer[position] != rune('D') {
+								goto l1602
+							}
+							position++
+						}
+					l1608:
+						{
+							position1610, tokenIndex1610, depth1610 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1611
+							}
+							position++
+							goto l1610
+						l1611:
+							position, tokenIndex, depth = position1610, tokenIndex1610, depth1610
+							if buffer[position] != rune('E') {
+								goto l1602
+							}
+							position++
+						}
+					l1610:
+						{
+							position1612, tokenIndex1612, depth1612 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l1613
+							}
+							position++
+							goto l1612
+						l1613:
+							position, tokenIndex, depth = position1612, tokenIndex1612, depth1612
+							if buffer[position] != rune('R') {
+								goto l1602
+							}
+							position++
+						}
+					l1612:
+						if !_rules[rulesp]() {
+							goto l1602
+						}
+						{
+							position1614, tokenIndex1614, depth1614 := position, tokenIndex, depth
+							if buffer[position] != rune('b') {
+								goto l1615
+							}
+							position++
+							goto l1614
+						l1615:
+							position, tokenIndex, depth = position1614, tokenIndex1614, depth1614
+							if buffer[position] != rune('B') {
+								goto l1602
+							}
+							position++
+						}
+					l1614:
+						{
+							position1616, tokenIndex1616, depth1616 := position, tokenIndex, depth
+							if buffer[position] != rune('y') {
+								goto l1617
+							}
+							position++
+							goto l1616
+						l1617:
+							position, tokenIndex, depth = position1616, tokenIndex1616, depth1616
+							if buffer[position] != rune('Y') {
+								goto l1602
+							}
+							position++
+						}
+					l1616:
+						if !_rules[rulesp]() {
+							goto l1602
+						}
+						if !_rules[ruleSortedExpression]() {
+							goto l1602
+						}
+					l1618:
+						{
+							position1619, tokenIndex1619, depth1619 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1619
+							}
+							if buffer[position] != rune(',') {
+								goto l1619
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1619
+							}
+							if !_rules[ruleSortedExpression]() {
+								goto l1619
+							}
+							goto l1618
+						l1619:
+							position, tokenIndex, depth = position1619, tokenIndex1619, depth1619
+						}
+						goto l1603
+					l1602:
+						position, tokenIndex, depth = position1602, tokenIndex1602, depth1602
+					}
+				l1603:
+					depth--
+					add(rulePegText, position1601)
+				}
+				if !_rules[ruleAction83]() {
+					goto l1599
+				}
+				depth--
+				add(ruleWindowOrder, position1600)
+			}
+			return true
+		l1599:
+			position, tokenIndex, depth = position1599, tokenIndex1599, depth1599
+			return false
+		},
+		/* 113 FuncAppWithOrderBy <- <(Function spOpt '(' spOpt FuncParams sp ParamsOrder spOpt ')' Action84)> */
+		func() bool {
+			position1620, tokenIndex1620, depth1620 := position, tokenIndex, depth
+			{
+				position1621 := position
+				depth++
+				if !_rules[ruleFunction]() {
+					goto l1620
+				}
+				if !_rules[rulespOpt]() {
+					goto l1620
+				}
+				if buffer[position] != rune('(') {
+					goto l1620
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1620
+				}
+				if !_rules[ruleFuncParams]() {
+					goto l1620
+				}
+				if !_rules[rulesp]() {
+					goto l1620
+				}
+				if !_rules[ruleParamsOrder]() {
+					goto l1620
+				}
+				if !_rules[rulespOpt]() {
+					goto l1620
+				}
+				if buffer[position] != rune(')') {
+					goto l1620
+				}
+				position++
+				if !_rules[ruleAction84]() {
+					goto l1620
+				}
+				depth--
+				add(ruleFuncAppWithOrderBy, position1621)
+			}
+			return true
+		l1620:
+			position, tokenIndex, depth = position1620, tokenIndex1620, depth1620
+			return false
+		},
+		/* 114 FuncAppWithoutOrderBy <- <(Function spOpt '(' spOpt FuncParams <spOpt> ')' Action85)> */
+		func() bool {
+			position1622, tokenIndex1622, depth1622 := position, tokenIndex, depth
+			{
+				position1623 := position
+				depth++
+				if !_rules[ruleFunction]() {
+					goto l1622
+				}
+				if !_rules[rulespOpt]() {
+					goto l1622
+				}
+				if buffer[position] != rune('(') {
+					goto l1622
+				}
+				position++
+				if !_rules[rulespOpt]() {
+					goto l1622
+				}
+				if !_rules[ruleFuncParams]() {
+					goto l1622
+				}
+				{
+					position1624 := position
+					depth++
+					if !_rules[rulespOpt]() {
+						goto l1622
+					}
+					depth--
+					add(rulePegText, position1624)
+				}
+				if buffer[position] != rune(')') {
+					goto l1622
+				}
+				position++
+				if !_rules[ruleAction85]() {
+					goto l1622
+				}
+				depth--
+				add(ruleFuncAppWithoutOrderBy, position1623)
+			}
+			return true
+		l1622:
+			position, tokenIndex, depth = position1622, tokenIndex1622, depth1622
+			return false
+		},
+		/* 115 FuncParams <- <(<(ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)?> Action86)> */
+		func() bool {
+			position1625, tokenIndex1625, depth1625 := position, tokenIndex, depth
+			{
+				position1626 := position
+				depth++
+				{
+					position1627 := position
+					depth++
+					{
+						position1628, tokenIndex1628, depth1628 := position, tokenIndex, depth
+						if !_rules[ruleExpressionOrWildcard]() {
+							goto l1628
+						}
+					l1630:
+						{
+							position1631, tokenIndex1631, depth1631 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1631
+							}
+							if buffer[position] != rune(',') {
+								goto l1631
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1631
+							}
+							if !_rules[ruleExpressionOrWildcard]() {
+								goto l1631
+							}
+							goto l1630
+						l1631:
+							position, tokenIndex, depth = position1631, tokenIndex1631, depth1631
+						}
+						goto l1629
+					l1628:
+						position, tokenIndex, depth = position1628, tokenIndex1628, depth1628
+					}
+				l1629:
+					depth--
+					add(rulePegText, position1627)
+				}
+				if !_rules[ruleAction86]() {
+					goto l1625
+				}
+				depth--
+				add(ruleFuncParams, position1626)
+			}
+			return true
+		l1625:
+			position, tokenIndex, depth = position1625, tokenIndex1625, depth1625
+			return false
+		},
+		/* 116 ParamsOrder <- <(<(('o' / 'O') ('r' / 'R') ('d' / 'D') ('e' / 'E') ('r' / 'R') sp (('b' / 'B') ('y' / 'Y')) sp SortedExpression (spOpt ',' spOpt SortedExpression)*)> Action87)> */
+		func() bool {
+			position1632, tokenIndex1632, depth1632 := position, tokenIndex, depth
+			{
+				position1633 := position
+				depth++
+				{
+					position1634 := position
+					depth++
+					{
+						position1635, tokenIndex1635, depth1635 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l1636
+						}
+						position++
+						goto l1635
+					l1636:
+						position, tokenIndex, depth = position1635, tokenIndex1635, depth1635
+						if buffer[position] != rune('O') {
+							goto l1632
+						}
+						position++
+					}
+				l1635:
+					{
+						position1637, tokenIndex1637, depth1637 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l1638
+						}
+						position++
+						goto l1637
+					l1638:
+						position, tokenIndex, depth = position1637, tokenIndex1637, depth1637
+						if buffer[position] != rune('R') {
+							goto l1632
+						}
+						position++
+					}
+				l1637:
+					{
+						position1639, tokenIndex1639, depth1639 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l1640
+						}
+						position++
+						goto l1639
+					l1640:
+						position, tokenIndex, depth = position1639, tokenIndex1639, depth1639
+						if buffer[position] != rune('D') {
+							goto l1632
+						}
+						position++
+					}
+				l1639:
+					{
+						position1641, tokenIndex1641, depth1641 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1642
+						}
+						position++
+						goto l1641
+					l1642:
+						position, tokenIndex, depth = position1641, tokenIndex1641, depth1641
+						if buffer[position] != rune('E') {
+							goto l1632
+						}
+						position++
+					}
+				l1641:
+					{
+						position1643, tokenIndex1643, depth1643 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l1644
+						}
+						position++
+						goto l1643
+					l1644:
+						position, tokenIndex, depth = position1643, tokenIndex1643, depth1643
+						if buffer[position] != rune('R') {
+							goto l1632
+						}
+						position++
+					}
+				l1643:
+					if !_rules[rulesp]() {
+						goto l1632
+					}
+					{
+						position1645, tokenIndex1645, depth1645 := position, tokenIndex, depth
+						if buffer[position] != rune('b') {
+							goto l1646
+						}
+						position++
+						goto l1645
+					l1646:
+						position, tokenIndex, depth = position1645, tokenIndex1645, depth1645
+						if buffer[position] != rune('B') {
+							goto l1632
+						}
+						position++
+					}
+				l1645:
+					{
+						position1647, tokenIndex1647, depth1647 := position, tokenIndex, depth
+						if buffer[position] != rune('y') {
+							goto l1648
+						}
+						position++
+						goto l1647
+					l1648:
+						position, tokenIndex, depth = position1647, tokenIndex1647, depth1647
+						if buffer[position] != rune('Y') {
+							goto l1632
+						}
+						position++
+					}
+				l1647:
+					if !_rules[rulesp]() {
+						goto l1632
+					}
+					if !_rules[ruleSortedExpression]() {
+						goto l1632
+					}
+				l1649:
+					{
+						position1650, tokenIndex1650, depth1650 := position, tokenIndex, depth
+						if !_rules[rulespOpt]() {
+							goto l1650
+						}
+						if buffer[position] != rune(',') {
+							goto l1650
+						}
+						position++
+						if !_rules[rulespOpt]() {
+							goto l1650
+						}
+						if !_rules[ruleSortedExpression]() {
+							goto l1650
+						}
+						goto l1649
+					l1650:
+						position, tokenIndex, depth = position1650, tokenIndex1650, depth1650
+					}
+					depth--
+					add(rulePegText, position1634)
+				}
+				if !_rules[ruleAction87]() {
+					goto l1632
+				}
+				depth--
+				add(ruleParamsOrder, position1633)
+			}
+			return true
+		l1632:
+			position, tokenIndex, depth = position1632, tokenIndex1632, depth1632
+			return false
+		},
+		/* 117 SortedExpression <- <(Expression OrderDirectionOpt NullsOrderOpt Action88)> */
+		func() bool {
+			position1651, tokenIndex1651, depth1651 := position, tokenIndex, depth
+			{
+				position1652 := position
+				depth++
+				if !_rules[ruleExpression]() {
+					goto l1651
+				}
+				if !_rules[ruleOrderDirectionOpt]() {
+					goto l1651
+				}
+				if !_rules[ruleNullsOrderOpt]() {
+					goto l1651
+				}
+				if !_rules[ruleAction88]() {
+					goto l1651
+				}
+				depth--
+				add(ruleSortedExpression, position1652)
+			}
+			return true
+		l1651:
+			position, tokenIndex, depth = position1651, tokenIndex1651, depth1651
+			return false
+		},
+		/* 118 OrderDirectionOpt <- <(<(sp (Ascending / Descending))?> Action89)> */
+		func() bool {
+			position1653, tokenIndex1653, depth1653 := position, tokenIndex, depth
+			{
+				position1654 := position
+				depth++
+				{
+					position1655 := position
+					depth++
+					{
+						position1656, tokenIndex1656, depth1656 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1656
+						}
+						{
+							position1658, tokenIndex1658, depth1658 := position, tokenIndex, depth
+							if !_rules[ruleAscending]() {
+								goto l1659
+							}
+							goto l1658
+						l1659:
+							position, tokenIndex, depth = position1658, tokenIndex1658, depth1658
+							if !_rules[ruleDescending]() {
+								goto l1656
+							}
+						}
+					l1658:
+						goto l1657
+					l1656:
+						position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
+					}
+				l1657:
+					depth--
+					add(rulePegText, position1655)
+				}
+				if !_rules[ruleAction89]() {
+					goto l1653
+				}
+				depth--
+				add(ruleOrderDirectionOpt, position1654)
+			}
+			return true
+		l1653:
+			position, tokenIndex, depth = position1653, tokenIndex1653, depth1653
+			return false
+		},
+		/* 119 NullsOrderOpt <- <(<(sp (('n' / 'N') ('u' / 'U') ('l' / 'L') ('l' / 'L') ('s' / 'S')) sp (NullsFirst / NullsLast))?> Action90)> */
+		func() bool {
+			position1660, tokenIndex1660, depth1660 := position, tokenIndex, depth
+			{
+				position1661 := position
+				depth++
+				{
+					position1662 := position
+					depth++
+					{
+						position1663, tokenIndex1663, depth1663 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1663
+						}
+						{
+							position1665, tokenIndex1665, depth1665 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l1666
+							}
+							position++
+							goto l1665
+						l1666:
+							position, tokenIndex, depth = position1665, tokenIndex1665, depth1665
+							if buffer[position] != rune('N') {
+								goto l1663
+							}
+							position++
+						}
+					l1665:
+						{
+							position1667, tokenIndex1667, depth1667 := position, tokenIndex, depth
+							if buffer[position] != rune('u') {
+								goto l1668
+							}
+							position++
+							goto l1667
+						l1668:
+							position, tokenIndex, depth = position1667, tokenIndex1667, depth1667
+							if buffer[position] != rune('U') {
+								goto l1663
+							}
+							position++
+						}
+					l1667:
+						{
+							position1669, tokenIndex1669, depth1669 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1670
+							}
+							position++
+							goto l1669
+						l1670:
+							position, tokenIndex, depth = position1669, tokenIndex1669, depth1669
+							if buffer[position] != rune('L') {
+								goto l1663
+							}
+							position++
+						}
+					l1669:
+						{
+							position1671, tokenIndex1671, depth1671 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1672
+							}
+							position++
+							goto l1671
+						l1672:
+							position, tokenIndex, depth = position1671, tokenIndex1671, depth1671
+							if buffer[position] != rune('L') {
+								goto l1663
+							}
+							position++
+						}
+					l1671:
+						{
+							position1673, tokenIndex1673, depth1673 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l1674
+							}
+							position++
+							goto l1673
+						l1674:
+							position, tokenIndex, depth = position1673, tokenIndex1673, depth1673
+							if buffer[position] != rune('S') {
+								goto l1663
+							}
+							position++
+						}
+					l1673:
+						if !_rules[rulesp]() {
+							goto l1663
+						}
+						{
+							position1675, tokenIndex1675, depth1675 := position, tokenIndex, depth
+							if !_rules[ruleNullsFirst]() {
+								goto l1676
+							}
+							goto l1675
+						l1676:
+							position, tokenIndex, depth = position1675, tokenIndex1675, depth1675
+							if !_rules[ruleNullsLast]() {
+								goto l1663
+							}
+						}
+					l1675:
+						goto l1664
+					l1663:
+						position, tokenIndex, depth = position1663, tokenIndex1663, depth1663
+					}
+				l1664:
+					depth--
+					add(rulePegText, position1662)
+				}
+				if !_rules[ruleAction90]() {
+					goto l1660
+				}
+				depth--
+				add(ruleNullsOrderOpt, position1661)
+			}
+			return true
+		l1660:
+			position, tokenIndex, depth = position1660, tokenIndex1660, depth1660
+			return false
+		},
+		/* 120 ArrayExpr <- <(<('[' spOpt (ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)? spOpt ','? spOpt ']')> Action91)> */
+		func() bool {
+			position1677, tokenIndex1677, depth1677 := position, tokenIndex, depth
+			{
+				position1678 := position
+				depth++
+				{
+					position1679 := position
+					depth++
+					if buffer[position] != rune('[') {
+						goto l1677
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1677
+					}
+					{
+						position1680, tokenIndex1680, depth1680 := position, tokenIndex, depth
+						if !_rules[ruleExpressionOrWildcard]() {
+							goto l1680
+						}
+					l1682:
+						{
+							position1683, tokenIndex1683, depth1683 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1683
+							}
+							if buffer[position] != rune(',') {
+								goto l1683
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1683
+							}
+							if !_rules[ruleExpressionOrWildcard]() {
+								goto l1683
+							}
+							goto l1682
+						l1683:
+							position, tokenIndex, depth = position1683, tokenIndex1683, depth1683
+						}
+						goto l1681
+					l1680:
+						position, tokenIndex, depth = position1680, tokenIndex1680, depth1680
+					}
+				l1681:
+					if !_rules[rulespOpt]() {
+						goto l1677
+					}
+					{
+						position1684, tokenIndex1684, depth1684 := position, tokenIndex, depth
+						if buffer[position] != rune(',') {
+							goto l1684
+						}
+						position++
+						goto l1685
+					l1684:
+						position, tokenIndex, depth = position1684, tokenIndex1684, depth1684
+					}
+				l1685:
+					if !_rules[rulespOpt]() {
+						goto l1677
+					}
+					if buffer[position] != rune(']') {
+						goto l1677
+					}
+					position++
+					depth--
+					add(rulePegText, position1679)
+				}
+				if !_rules[ruleAction91]() {
+					goto l1677
+				}
+				depth--
+				add(ruleArrayExpr, position1678)
+			}
+			return true
+		l1677:
+			position, tokenIndex, depth = position1677, tokenIndex1677, depth1677
+			return false
+		},
+		/* 121 MapExpr <- <(<('{' spOpt (KeyValuePair (spOpt ',' spOpt KeyValuePair)*)? spOpt '}')> Action92)> */
+		func() bool {
+			position1686, tokenIndex1686, depth1686 := position, tokenIndex, depth
+			{
+				position1687 := position
+				depth++
+				{
+					position1688 := position
+					depth++
+					if buffer[position] != rune('{') {
+						goto l1686
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1686
+					}
+					{
+						position1689, tokenIndex1689, depth1689 := position, tokenIndex, depth
+						if !_rules[ruleKeyValuePair]() {
+							goto l1689
+						}
+					l1691:
+						{
+							position1692, tokenIndex1692, depth1692 := position, tokenIndex, depth
+							if !_rules[rulespOpt]() {
+								goto l1692
+							}
+							if buffer[position] != rune(',') {
+								goto l1692
+							}
+							position++
+							if !_rules[rulespOpt]() {
+								goto l1692
+							}
+							if !_rules[ruleKeyValuePair]() {
+								goto l1692
+							}
+							goto l1691
+						l1692:
+							position, tokenIndex, depth = position1692, tokenIndex1692, depth1692
+						}
+						goto l1690
+					l1689:
+						position, tokenIndex, depth = position1689, tokenIndex1689, depth1689
+					}
+				l1690:
+					if !_rules[rulespOpt]() {
+						goto l1686
+					}
+					if buffer[position] != rune('}') {
+						goto l1686
+					}
+					position++
+					depth--
+					add(rulePegText, position1688)
+				}
+				if !_rules[ruleAction92]() {
+					goto l1686
+				}
+				depth--
+				add(ruleMapExpr, position1687)
+			}
+			return true
+		l1686:
+			position, tokenIndex, depth = position1686, tokenIndex1686, depth1686
+			return false
+		},
+		/* 122 KeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ExpressionOrWildcard)> Action93)> */
+		func() bool {
+			position1693, tokenIndex1693, depth1693 := position, tokenIndex, depth
+			{
+				position1694 := position
+				depth++
+				{
+					position1695 := position
+					depth++
+					if !_rules[ruleStringLiteral]() {
+						goto l1693
+					}
+					if !_rules[rulespOpt]() {
+						goto l1693
+					}
+					if buffer[position] != rune(':') {
+						goto l1693
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1693
+					}
+					if !_rules[ruleExpressionOrWildcard]() {
+						goto l1693
+					}
+					depth--
+					add(rulePegText, position1695)
+				}
+				if !_rules[ruleAction93]() {
+					goto l1693
+				}
+				depth--
+				add(ruleKeyValuePair, position1694)
+			}
+			return true
+		l1693:
+			position, tokenIndex, depth = position1693, tokenIndex1693, depth1693
+			return false
+		},
+		/* 123 Case <- <(ConditionCase / ExpressionCase)> */
+		func() bool {
+			position1696, tokenIndex1696, depth1696 := position, tokenIndex, depth
+			{
+				position1697 := position
+				depth++
+				{
+					position1698, tokenIndex1698, depth1698 := position, tokenIndex, depth
+					if !_rules[ruleConditionCase]() {
+						goto l1699
+					}
+					goto l1698
+				l1699:
+					position, tokenIndex, depth = position1698, tokenIndex1698, depth1698
+					if !_rules[ruleExpressionCase]() {
+						goto l1696
+					}
+				}
+			l1698:
+				depth--
+				add(ruleCase, position1697)
+			}
+			return true
+		l1696:
+			position, tokenIndex, depth = position1696, tokenIndex1696, depth1696
+			return false
+		},
+		/* 124 ConditionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action94)> */
+		func() bool {
+			position1700, tokenIndex1700, depth1700 := position, tokenIndex, depth
+			{
+				position1701 := position
+				depth++
+				{
+					position1702, tokenIndex1702, depth1702 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l1703
+					}
+					position++
+					goto l1702
+				l1703:
+					position, tokenIndex, depth = position1702, tokenIndex1702, depth1702
+					if buffer[position] != rune('C') {
+						goto l1700
+					}
+					position++
+				}
+			l1702:
+				{
+					position1704, tokenIndex1704, depth1704 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1705
+					}
+					position++
+					goto l1704
+				l1705:
+					position, tokenIndex, depth = position1704, tokenIndex1704, depth1704
+					if buffer[position] != rune('A') {
+						goto l1700
+					}
+					position++
+				}
+			l1704:
+				{
+					position1706, tokenIndex1706, depth1706 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1707
+					}
+					position++
+					goto l1706
+				l1707:
+					position, tokenIndex, depth = position1706, tokenIndex1706, depth1706
+					if buffer[position] != rune('S') {
+						goto l1700
+					}
+					position++
+				}
+			l1706:
+				{
+					position1708, tokenIndex1708, depth1708 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1709
+					}
+					position++
+					goto l1708
+				l1709:
+					position, tokenIndex, depth = position1708, tokenIndex1708, depth1708
+					if buffer[position] != rune('E') {
+						goto l1700
+					}
+					position++
+				}
+			l1708:
+				{
+					position1710 := position
+					depth++
+					if !_rules[rulesp]() {
+						goto l1700
+					}
+					if !_rules[ruleWhenThenPair]() {
+						goto l1700
+					}
+				l1711:
+					{
+						position1712, tokenIndex1712, depth1712 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1712
+						}
+						if !_rules[ruleWhenThenPair]() {
+							goto l1712
+						}
+						goto l1711
+					l1712:
+						position, tokenIndex, depth = position1712, tokenIndex1712, depth1712
+					}
+					{
+						position1713, tokenIndex1713, depth1713 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1713
+						}
+						{
+							position1715, tokenIndex1715, depth1715 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1716
+							}
+							position++
+							goto l1715
+						l1716:
+							position, tokenIndex, depth = position1715, tokenIndex1715, depth1715
+							if buffer[position] != rune('E') {
+								goto l1713
+							}
+							position++
+						}
+					l1715:
+						{
+							position1717, tokenIndex1717, depth1717 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1718
+							}
+							position++
+							goto l1717
+						l1718:
+							position, tokenIndex, depth = position1717, tokenIndex1717, depth1717
+							if buffer[position] != rune('L') {
+								goto l1713
+							}
+							position++
+						}
+					l1717:
+						{
+							position1719, tokenIndex1719, depth1719 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l1720
+							}
+							position++
+							goto l1719
+						l1720:
+							position, tokenIndex, depth = position1719, tokenIndex1719, depth1719
+							if buffer[position] != rune('S') {
+								goto l1713
+							}
+							position++
+						}
+					l1719:
+						{
+							position1721, tokenIndex1721, depth1721 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1722
+							}
+							position++
+							goto l1721
+						l1722:
+							position, tokenIndex, depth = position1721, tokenIndex1721, depth1721
+							if buffer[position] != rune('E') {
+								goto l1713
+							}
+							position++
+						}
+					l1721:
+						if !_rules[rulesp]() {
+							goto l1713
+						}
+						if !_rules[ruleExpression]() {
+							goto l1713
+						}
+						goto l1714
+					l1713:
+						position, tokenIndex, depth = position1713, tokenIndex1713, depth1713
+					}
+				l1714:
+					if !_rules[rulesp]() {
+						goto l1700
+					}
+					{
+						position1723, tokenIndex1723, depth1723 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1724
+						}
+						position++
+						goto l1723
+					l1724:
+						position, tokenIndex, depth = position1723, tokenIndex1723, depth1723
+						if buffer[position] != rune('E') {
+							goto l1700
+						}
+						position++
+					}
+				l1723:
+					{
+						position1725, tokenIndex1725, depth1725 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l1726
+						}
+						position++
+						goto l1725
+					l1726:
+						position, tokenIndex, depth = position1725, tokenIndex1725, depth1725
+						if buffer[position] != rune('N') {
+							goto l1700
+						}
+						position++
+					}
+				l1725:
+					{
+						position1727, tokenIndex1727, depth1727 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l1728
+						}
+						position++
+						goto l1727
+					l1728:
+						position, tokenIndex, depth = position1727, tokenIndex1727, depth1727
+						if buffer[position] != rune('D') {
+							goto l1700
+						}
+						position++
+					}
+				l1727:
+					depth--
+					add(rulePegText, position1710)
+				}
+				if !_rules[ruleAction94]() {
+					goto l1700
+				}
+				depth--
+				add(ruleConditionCase, position1701)
+			}
+			return true
+		l1700:
+			position, tokenIndex, depth = position1700, tokenIndex1700, depth1700
+			return false
+		},
+		/* 125 ExpressionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') sp Expression <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action95)> */
+		func() bool {
+			position1729, tokenIndex1729, depth1729 := position, tokenIndex, depth
+			{
+				position1730 := position
+				depth++
+				{
+					position1731, tokenIndex1731, depth1731 := position, tokenIndex, depth
+					if buffer[position] != rune('c') {
+						goto l1732
+					}
+					position++
+					goto l1731
+				l1732:
+					position, tokenIndex, depth = position1731, tokenIndex1731, depth1731
+					if buffer[position] != rune('C') {
+						goto l1729
+					}
+					position++
+				}
+			l1731:
+				{
+					position1733, tokenIndex1733, depth1733 := position, tokenIndex, depth
+					if buffer[position] != rune('a') {
+						goto l1734
+					}
+					position++
+					goto l1733
+				l1734:
+					position, tokenIndex, depth = position1733, tokenIndex1733, depth1733
+					if buffer[position] != rune('A') {
+						goto l1729
+					}
+					position++
+				}
+			l1733:
+				{
+					position1735, tokenIndex1735, depth1735 := position, tokenIndex, depth
+					if buffer[position] != rune('s') {
+						goto l1736
+					}
+					position++
+					goto l1735
+				l1736:
+					position, tokenIndex, depth = position1735, tokenIndex1735, depth1735
+					if buffer[position] != rune('S') {
+						goto l1729
+					}
+					position++
+				}
+			l1735:
+				{
+					position1737, tokenIndex1737, depth1737 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1738
+					}
+					position++
+					goto l1737
+				l1738:
+					position, tokenIndex, depth = position1737, tokenIndex1737, depth1737
+					if buffer[position] != rune('E') {
+						goto l1729
+					}
+					position++
+				}
+			l1737:
+				if !_rules[rulesp]() {
+					goto l1729
+				}
+				if !_rules[ruleExpression]() {
+					goto l1729
+				}
+				{
+					position1739 := position
+					depth++
+					if !_rules[rulesp]() {
+						goto l1729
+					}
+					if !_rules[ruleWhenThenPair]() {
+						goto l1729
+					}
+				l1740:
+					{
+						position1741, tokenIndex1741, depth1741 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1741
+						}
+						if !_rules[ruleWhenThenPair]() {
+							goto l1741
+						}
+						goto l1740
+					l1741:
+						position, tokenIndex, depth = position1741, tokenIndex1741, depth1741
+					}
+					{
+						position1742, tokenIndex1742, depth1742 := position, tokenIndex, depth
+						if !_rules[rulesp]() {
+							goto l1742
+						}
+						{
+							position1744, tokenIndex1744, depth1744 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1745
+							}
+							position++
+							goto l1744
+						l1745:
+							position, tokenIndex, depth = position1744, tokenIndex1744, depth1744
+							if buffer[position] != rune('E') {
+								goto l1742
+							}
+							position++
+						}
+					l1744:
+						{
+							position1746, tokenIndex1746, depth1746 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1747
+							}
+							position++
+							goto l1746
+						l1747:
+							position, tokenIndex, depth = position1746, tokenIndex1746, depth1746
+							if buffer[position] != rune('L') {
+								goto l1742
+							}
+							position++
+						}
+					l1746:
+						{
+							position1748, tokenIndex1748, depth1748 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l1749
+							}
+							position++
+							goto l1748
+						l1749:
+							position, tokenIndex, depth = position1748, tokenIndex1748, depth1748
+							if buffer[position] != rune('S') {
+								goto l1742
+							}
+							position++
+						}
+					l1748:
+						{
+							position1750, tokenIndex1750, depth1750 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1751
+							}
+							position++
+							goto l1750
+						l1751:
+							position, tokenIndex, depth = position1750, tokenIndex1750, depth1750
+							if buffer[position] != rune('E') {
+								goto l1742
+							}
+							position++
+						}
+					l1750:
+						if !_rules[rulesp]() {
+							goto l1742
+						}
+						if !_rules[ruleExpression]() {
+							goto l1742
+						}
+						goto l1743
+					l1742:
+						position, tokenIndex, depth = position1742, tokenIndex1742, depth1742
+					}
+				l1743:
+					if !_rules[rulesp]() {
+						goto l1729
+					}
+					{
+						position1752, tokenIndex1752, depth1752 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1753
+						}
+						position++
+						goto l1752
+					l1753:
+						position, tokenIndex, depth = position1752, tokenIndex1752, depth1752
+						if buffer[position] != rune('E') {
+							goto l1729
+						}
+						position++
+					}
+				l1752:
+					{
+						position1754, tokenIndex1754, depth1754 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l1755
+						}
+						position++
+						goto l1754
+					l1755:
+						position, tokenIndex, depth = position1754, tokenIndex1754, depth1754
+						if buffer[position] != rune('N') {
+							goto l1729
+						}
+						position++
+					}
+				l1754:
+					{
+						position1756, tokenIndex1756, depth1756 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l1757
+						}
+						position++
+						goto l1756
+					l1757:
+						position, tokenIndex, depth = position1756, tokenIndex1756, depth1756
+						if buffer[position] != rune('D') {
+							goto l1729
+						}
+						position++
+					}
+				l1756:
+					depth--
+					add(rulePegText, position1739)
+				}
+				if !_rules[ruleAction95]() {
+					goto l1729
+				}
+				depth--
+				add(ruleExpressionCase, position1730)
+			}
+			return true
+		l1729:
+			position, tokenIndex, depth = position1729, tokenIndex1729, depth1729
+			return false
+		},
+		/* 126 WhenThenPair <- <(('w' / 'W') ('h' / 'H') ('e' / 'E') ('n' / 'N') sp Expression sp (('t' / 'T') ('h' / 'H') ('e' / 'E') ('n' / 'N')) sp ExpressionOrWildcard Action96)> */
+		func() bool {
+			position1758, tokenIndex1758, depth1758 := position, tokenIndex, depth
+			{
+				position1759 := position
+				depth++
+				{
+					position1760, tokenIndex1760, depth1760 := position, tokenIndex, depth
+					if buffer[position] != rune('w') {
+						goto l1761
+					}
+					position++
+					goto l1760
+				l1761:
+					position, tokenIndex, depth = position1760, tokenIndex1760, depth1760
+					if buffer[position] != rune('W') {
+						goto l1758
+					}
+					position++
+				}
+			l1760:
+				{
+					position1762, tokenIndex1762, depth1762 := position, tokenIndex, depth
+					if buffer[position] != rune('h') {
+						goto l1763
+					}
+					position++
+					goto l1762
+				l1763:
+					position, tokenIndex, depth = position1762, tokenIndex1762, depth1762
+					if buffer[position] != rune('H') {
+						goto l1758
+					}
+					position++
+				}
+			l1762:
+				{
+					position1764, tokenIndex1764, depth1764 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1765
+					}
+					position++
+					goto l1764
+				l1765:
+					position, tokenIndex, depth = position1764, tokenIndex1764, depth1764
+					if buffer[position] != rune('E') {
+						goto l1758
+					}
+					position++
+				}
+			l1764:
+				{
+					position1766, tokenIndex1766, depth1766 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1767
+					}
+					position++
+					goto l1766
+				l1767:
+					position, tokenIndex, depth = position1766, tokenIndex1766, depth1766
+					if buffer[position] != rune('N') {
+						goto l1758
+					}
+					position++
+				}
+			l1766:
+				if !_rules[rulesp]() {
+					goto l1758
+				}
+				if !_rules[ruleExpression]() {
+					goto l1758
+				}
+				if !_rules[rulesp]() {
+					goto l1758
+				}
+				{
+					position1768, tokenIndex1768, depth1768 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l1769
+					}
+					position++
+					goto l1768
+				l1769:
+					position, tokenIndex, depth = position1768, tokenIndex1768, depth1768
+					if buffer[position] != rune('T') {
+						goto l1758
+					}
+					position++
+				}
+			l1768:
+				{
+					position1770, tokenIndex1770, depth1770 := position, tokenIndex, depth
+					if buffer[position] != rune('h') {
+						goto l1771
+					}
+					position++
+					goto l1770
+				l1771:
+					position, tokenIndex, depth = position1770, tokenIndex1770, depth1770
+					if buffer[position] != rune('H') {
+						goto l1758
+					}
+					position++
+				}
+			l1770:
+				{
+					position1772, tokenIndex1772, depth1772 := position, tokenIndex, depth
+					if buffer[position] != rune('e') {
+						goto l1773
+					}
+					position++
+					goto l1772
+				l1773:
+					position, tokenIndex, depth = position1772, tokenIndex1772, depth1772
+					if buffer[position] != rune('E') {
+						goto l1758
+					}
+					position++
+				}
+			l1772:
+				{
+					position1774, tokenIndex1774, depth1774 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l1775
+					}
+					position++
+					goto l1774
+				l1775:
+					position, tokenIndex, depth = position1774, tokenIndex1774, depth1774
+					if buffer[position] != rune('N') {
+						goto l1758
+					}
+					position++
+				}
+			l1774:
+				if !_rules[rulesp]() {
+					goto l1758
+				}
+				if !_rules[ruleExpressionOrWildcard]() {
+					goto l1758
+				}
+				if !_rules[ruleAction96]() {
+					goto l1758
+				}
+				depth--
+				add(ruleWhenThenPair, position1759)
+			}
+			return true
+		l1758:
+			position, tokenIndex, depth = position1758, tokenIndex1758, depth1758
+			return false
+		},
+		/* 127 Literal <- <(FloatLiteral / NumericLiteral / StringLiteral / Placeholder)> */
+		func() bool {
+			position1776, tokenIndex1776, depth1776 := position, tokenIndex, depth
+			{
+				position1777 := position
+				depth++
+				{
+					position1778, tokenIndex1778, depth1778 := position, tokenIndex, depth
+					if !_rules[ruleFloatLiteral]() {
+						goto l1779
+					}
+					goto l1778
+				l1779:
+					position, tokenIndex, depth = position1778, tokenIndex1778, depth1778
+					if !_rules[ruleNumericLiteral]() {
+						goto l1780
+					}
+					goto l1778
+				l1780:
+					position, tokenIndex, depth = position1778, tokenIndex1778, depth1778
+					if !_rules[ruleStringLiteral]() {
+						goto l1781
+					}
+					goto l1778
+				l1781:
+					position, tokenIndex, depth = position1778, tokenIndex1778, depth1778
+					if !_rules[rulePlaceholder]() {
+						goto l1776
+					}
+				}
+			l1778:
+				depth--
+				add(ruleLiteral, position1777)
+			}
+			return true
+		l1776:
+			position, tokenIndex, depth = position1776, tokenIndex1776, depth1776
+			return false
+		},
+		/* 128 ComparisonOp <- <(Equal / NotEqual / LessOrEqual / Less / GreaterOrEqual / Greater / NotEqual)> */
+		func() bool {
+			position1782, tokenIndex1782, depth1782 := position, tokenIndex, depth
+			{
+				position1783 := position
+				depth++
+				{
+					position1784, tokenIndex1784, depth1784 := position, tokenIndex, depth
+					if !_rules[ruleEqual]() {
+						goto l1785
+					}
+					goto l1784
+				l1785:
+					position, tokenIndex, depth = position1784, tokenIndex1784, depth1784
+					if !_rules[ruleNotEqual]() {
+						goto l1786
+					}
+					goto l1784
+				l1786:
+					position, tokenIndex, depth = position1784, tokenIndex1784, depth1784
+					if !_rules[ruleLessOrEqual]() {
+						goto l1787
+					}
+					goto l1784
+				l1787:
+					position, tokenIndex, depth = position1784, tokenIndex1784, depth1784
+					if !_rules[ruleLess]() {
+						goto l1788
+					}
+					goto l1784
+				l1788:
+					position, tokenIndex, depth = position1784, tokenIndex1784, depth1784
+					if !_rules[ruleGreaterOrEqual]() {
+						goto l1789
+					}
+					goto l1784
+				l1789:
+					position, tokenIndex, depth = position1784, tokenIndex1784, depth1784
+					if !_rules[ruleGreater]() {
+						goto l1790
+					}
+					goto l1784
+				l1790:
+					position, tokenIndex, depth = position1784, tokenIndex1784, depth1784
+					if !_rules[ruleNotEqual]() {
+						goto l1782
+					}
+				}
+			l1784:
+				depth--
+				add(ruleComparisonOp, position1783)
+			}
+			return true
+		l1782:
+			position, tokenIndex, depth = position1782, tokenIndex1782, depth1782
+			return false
+		},
+		/* 129 DistinctOp <- <(IsNotDistinctFrom / IsDistinctFrom)> */
+		func() bool {
+			position1791, tokenIndex1791, depth1791 := position, tokenIndex, depth
+			{
+				position1792 := position
+				depth++
+				{
+					position1793, tokenIndex1793, depth1793 := position, tokenIndex, depth
+					if !_rules[ruleIsNotDistinctFrom]() {
+						goto l1794
+					}
+					goto l1793
+				l1794:
+					position, tokenIndex, depth = position1793, tokenIndex1793, depth1793
+					if !_rules[ruleIsDistinctFrom]() {
+						goto l1791
+					}
+				}
+			l1793:
+				depth--
+				add(ruleDistinctOp, position1792)
+			}
+			return true
+		l1791:
+			position, tokenIndex, depth = position1791, tokenIndex1791, depth1791
+			return false
+		},
+		/* 130 OtherOp <- <Concat> */
+		func() bool {
+			position1795, tokenIndex1795, depth1795 := position, tokenIndex, depth
+			{
+				position1796 := position
+				depth++
+				if !_rules[ruleConcat]() {
+					goto l1795
+				}
+				depth--
+				add(ruleOtherOp, position1796)
+			}
+			return true
+		l1795:
+			position, tokenIndex, depth = position1795, tokenIndex1795, depth1795
+			return false
+		},
+		/* 131 IsOp <- <(IsNot / Is)> */
+		func() bool {
+			position1797, tokenIndex1797, depth1797 := position, tokenIndex, depth
+			{
+				position1798 := position
+				depth++
+				{
+					position1799, tokenIndex1799, depth1799 := position, tokenIndex, depth
+					if !_rules[ruleIsNot]() {
+						goto l1800
+					}
+					goto l1799
+				l1800:
+					position, tokenIndex, depth = position1799, tokenIndex1799, depth1799
+					if !_rules[ruleIs]() {
+						goto l1797
+					}
+				}
+			l1799:
+				depth--
+				add(ruleIsOp, position1798)
+			}
+			return true
+		l1797:
+			position, tokenIndex, depth = position1797, tokenIndex1797, depth1797
+			return false
+		},
+		/* 132 PlusMinusOp <- <(Plus / Minus)> */
+		func() bool {
+			position1801, tokenIndex1801, depth1801 := position, tokenIndex, depth
+			{
+				position1802 := position
+				depth++
+				{
+					position1803, tokenIndex1803, depth1803 := position, tokenIndex, depth
+					if !_rules[rulePlus]() {
+						goto l1804
+					}
+					goto l1803
+				l1804:
+					position, tokenIndex, depth = position1803, tokenIndex1803, depth1803
+					if !_rules[ruleMinus]() {
+						goto l1801
+					}
+				}
+			l1803:
+				depth--
+				add(rulePlusMinusOp, position1802)
+			}
+			return true
+		l1801:
+			position, tokenIndex, depth = position1801, tokenIndex1801, depth1801
+			return false
+		},
+		/* 133 MultDivOp <- <(Multiply / Divide / Modulo)> */
+		func() bool {
+			position1805, tokenIndex1805, depth1805 := position, tokenIndex, depth
+			{
+				position1806 := position
+				depth++
+				{
+					position1807, tokenIndex1807, depth1807 := position, tokenIndex, depth
+					if !_rules[ruleMultiply]() {
+						goto l1808
+					}
+					goto l1807
+				l1808:
+					position, tokenIndex, depth = position1807, tokenIndex1807, depth1807
+					if !_rules[ruleDivide]() {
+						goto l1809
+					}
+					goto l1807
+				l1809:
+					position, tokenIndex, depth = position1807, tokenIndex1807, depth1807
+					if !_rules[ruleModulo]() {
+						goto l1805
+					}
+				}
+			l1807:
+				depth--
+				add(ruleMultDivOp, position1806)
+			}
+			return true
+		l1805:
+			position, tokenIndex, depth = position1805, tokenIndex1805, depth1805
+			return false
+		},
+		/* 134 Stream <- <(<ident> Action97)> */
+		func() bool {
+			position1810, tokenIndex1810, depth1810 := position, tokenIndex, depth
+			{
+				position1811 := position
+				depth++
+				{
+					position1812 := position
+					depth++
+					if !_rules[ruleident]() {
+						goto l1810
+					}
+					depth--
+					add(rulePegText, position1812)
+				}
+				if !_rules[ruleAction97]() {
+					goto l1810
+				}
+				depth--
+				add(ruleStream, position1811)
+			}
+			return true
+		l1810:
+			position, tokenIndex, depth = position1810, tokenIndex1810, depth1810
+			return false
+		},
+		/* 135 RowMeta <- <(RowTimestamp / RowProcTimestamp / RowInputName / RowTrace / RowMetadata)> */
+		func() bool {
+			position1813, tokenIndex1813, depth1813 := position, tokenIndex, depth
+			{
+				position1814 := position
+				depth++
+				{
+					position1815, tokenIndex1815, depth1815 := position, tokenIndex, depth
+					if !_rules[ruleRowTimestamp]() {
+						goto l1816
+					}
+					goto l1815
+				l1816:
+					position, tokenIndex, depth = position1815, tokenIndex1815, depth1815
+					if !_rules[ruleRowProcTimestamp]() {
+						goto l1817
+					}
+					goto l1815
+				l1817:
+					position, tokenIndex, depth = position1815, tokenIndex1815, depth1815
+					if !_rules[ruleRowInputName]() {
+						goto l1818
+					}
+					goto l1815
+				l1818:
+					position, tokenIndex, depth = position1815, tokenIndex1815, depth1815
+					if !_rules[ruleRowTrace]() {
+						goto l1819
+					}
+					goto l1815
+				l1819:
+					position, tokenIndex, depth = position1815, tokenIndex1815, depth1815
+					if !_rules[ruleRowMetadata]() {
+						goto l1813
+					}
+				}
+			l1815:
+				depth--
+				add(ruleRowMeta, position1814)
+			}
+			return true
+		l1813:
+			position, tokenIndex, depth = position1813, tokenIndex1813, depth1813
+			return false
+		},
+		/* 136 RowTimestamp <- <(<((ident ':')? ('t' 's' '(' ')'))> Action98)> */
+		func() bool {
+			position1820, tokenIndex1820, depth1820 := position, tokenIndex, depth
+			{
+				position1821 := position
+				depth++
+				{
+					position1822 := position
+					depth++
+					{
+						position1823, tokenIndex1823, depth1823 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1823
 						}
-						if buffer[position] != rune(',') {
-							goto l925
+						if buffer[position] != rune(':') {
+							goto l1823
 						}
 						position++
-						if !_rules[rulespOpt]() {
-							goto l925
-						}
-						{
-							position927, tokenIndex927, depth927 := position, tokenIndex, depth
-							if buffer[position] != rune('b') {
-								goto l928
-							}
-							position++
-							goto l927
-						l928:
-							position, tokenIndex, depth = position927, tokenIndex927, depth927
-							if buffer[position] != rune('B') {
-								goto l925
-							}
-							position++
-						}
-					l927:
-						{
-							position929, tokenIndex929, depth929 := position, tokenIndex, depth
-							if buffer[position] != rune('u') {
-								goto l930
-							}
-							position++
-							goto l929
-						l930:
-							position, tokenIndex, depth = position929, tokenIndex929, depth929
-							if buffer[position] != rune('U') {
-								goto l925
-							}
-							position++
-						}
-					l929:
-						{
-							position931, tokenIndex931, depth931 := position, tokenIndex, depth
-							if buffer[position] != rune('f') {
-								goto l932
-							}
-							position++
-							goto l931
-						l932:
-							position, tokenIndex, depth = position931, tokenIndex931, depth931
-							if buffer[position] != rune('F') {
-								goto l925
-							}
-							position++
-						}
-					l931:
-						{
-							position933, tokenIndex933, depth933 := position, tokenIndex, depth
-							if buffer[position] != rune('f') {
-								goto l934
-							}
-							position++
-							goto l933
-						l934:
-							position, tokenIndex, depth = position933, tokenIndex933, depth933
-							if buffer[position] != rune('F') {
-								goto l925
-							}
-							position++
-						}
-					l933:
-						{
-							position935, tokenIndex935, depth935 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l936
-							}
-							position++
-							goto l935
-						l936:
-							position, tokenIndex, depth = position935, tokenIndex935, depth935
-							if buffer[position] != rune('E') {
-								goto l925
-							}
-							position++
-						}
-					l935:
-						{
-							position937, tokenIndex937, depth937 := position, tokenIndex, depth
-							if buffer[position] != rune('r') {
-								goto l938
-							}
-							position++
-							goto l937
-						l938:
-							position, tokenIndex, depth = position937, tokenIndex937, depth937
-							if buffer[position] != rune('R') {
-								goto l925
-							}
-							position++
-						}
-					l937:
-						if !_rules[rulesp]() {
-							goto l925
-						}
-						{
-							position939, tokenIndex939, depth939 := position, tokenIndex, depth
-							if buffer[position] != rune('s') {
-								goto l940
-							}
-							position++
-							goto l939
-						l940:
-							position, tokenIndex, depth = position939, tokenIndex939, depth939
-							if buffer[position] != rune('S') {
-								goto l925
-							}
-							position++
-						}
-					l939:
-						{
-							position941, tokenIndex941, depth941 := position, tokenIndex, depth
-							if buffer[position] != rune('i') {
-								goto l942
-							}
-							position++
-							goto l941
-						l942:
-							position, tokenIndex, depth = position941, tokenIndex941, depth941
-							if buffer[position] != rune('I') {
-								goto l925
-							}
-							position++
-						}
-					l941:
-						{
-							position943, tokenIndex943, depth943 := position, tokenIndex, depth
-							if buffer[position] != rune('z') {
-								goto l944
-							}
-							position++
-							goto l943
-						l944:
-							position, tokenIndex, depth = position943, tokenIndex943, depth943
-							if buffer[position] != rune('Z') {
-								goto l925
-							}
-							position++
-						}
-					l943:
-						{
-							position945, tokenIndex945, depth945 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l946
-							}
-							position++
-							goto l945
-						l946:
-							position, tokenIndex, depth = position945, tokenIndex945, depth945
-							if buffer[position] != rune('E') {
-								goto l925
-							}
-							position++
-						}
-					l945:
-						if !_rules[rulesp]() {
-							goto l925
-						}
-						if !_rules[ruleNonNegativeNumericLiteral]() {
-							goto l925
-						}
-						goto l926
-					l925:
-						position, tokenIndex, depth = position925, tokenIndex925, depth925
+						goto l1824
+					l1823:
+						position, tokenIndex, depth = position1823, tokenIndex1823, depth1823
 					}
-				l926:
+				l1824:
+					if buffer[position] != rune('t') {
+						goto l1820
+					}
+					position++
+					if buffer[position] != rune('s') {
+						goto l1820
+					}
+					position++
+					if buffer[position] != rune('(') {
+						goto l1820
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l1820
+					}
+					position++
 					depth--
-					add(rulePegText, position924)
+					add(rulePegText, position1822)
 				}
-				if !_rules[ruleAction43]() {
-					goto l922
+				if !_rules[ruleAction98]() {
+					goto l1820
 				}
 				depth--
-				add(ruleCapacitySpecOpt, position923)
+				add(ruleRowTimestamp, position1821)
 			}
 			return true
-		l922:
-			position, tokenIndex, depth = position922, tokenIndex922, depth922
+		l1820:
+			position, tokenIndex, depth = position1820, tokenIndex1820, depth1820
 			return false
 		},
-		/* 58 SheddingSpecOpt <- <(<(spOpt ',' spOpt SheddingOption sp (('i' / 'I') ('f' / 'F')) sp (('f' / 'F') ('u' / 'U') ('l' / 'L') ('l' / 'L')))?> Action44)> */
+		/* 137 RowProcTimestamp <- <(<((ident ':')? (('p' / 'P') ('r' / 'R') ('o' / 'O') ('c' / 'C') '_' ('t' / 'T') ('s' / 'S')) ('(' ')'))> Action99)> */
 		func() bool {
-			position947, tokenIndex947, depth947 := position, tokenIndex, depth
+			position1825, tokenIndex1825, depth1825 := position, tokenIndex, depth
 			{
-				position948 := position
+				position1826 := position
 				depth++
 				{
-					position949 := position
+					position1827 := position
 					depth++
 					{
-						position950, tokenIndex950, depth950 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l950
+						position1828, tokenIndex1828, depth1828 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1828
 						}
-						if buffer[position] != rune(',') {
-							goto l950
+						if buffer[position] != rune(':') {
+							goto l1828
 						}
 						position++
-						if !_rules[rulespOpt]() {
-							goto l950
+						goto l1829
+					l1828:
+						position, tokenIndex, depth = position1828, tokenIndex1828, depth1828
+					}
+				l1829:
+					{
+						position1830, tokenIndex1830, depth1830 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l1831
 						}
-						if !_rules[ruleSheddingOption]() {
-							goto l950
+						position++
+						goto l1830
+					l1831:
+						position, tokenIndex, depth = position1830, tokenIndex1830, depth1830
+						if buffer[position] != rune('P') {
+							goto l1825
 						}
-						if !_rules[rulesp]() {
-							goto l950
+						position++
+					}
+				l1830:
+					{
+						position1832, tokenIndex1832, depth1832 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l1833
 						}
-						{
-							position952, tokenIndex952, depth952 := position, tokenIndex, depth
-							if buffer[position] != rune('i') {
-								goto l953
-							}
-							position++
-							goto l952
-						l953:
-							position, tokenIndex, depth = position952, tokenIndex952, depth952
-							if buffer[position] != rune('I') {
-								goto l950
-							}
-							position++
+						position++
+						goto l1832
+					l1833:
+						position, tokenIndex, depth = position1832, tokenIndex1832, depth1832
+						if buffer[position] != rune('R') {
+							goto l1825
 						}
-					l952:
-						{
-							position954, tokenIndex954, depth954 := position, tokenIndex, depth
-							if buffer[position] != rune('f') {
-								goto l955
-							}
-							position++
-							goto l954
-						l955:
-							position, tokenIndex, depth = position954, tokenIndex954, depth954
-							if buffer[position] != rune('F') {
-								goto l950
-							}
-							position++
+						position++
+					}
+				l1832:
+					{
+						position1834, tokenIndex1834, depth1834 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l1835
 						}
-					l954:
-						if !_rules[rulesp]() {
-							goto l950
+						position++
+						goto l1834
+					l1835:
+						position, tokenIndex, depth = position1834, tokenIndex1834, depth1834
+						if buffer[position] != rune('O') {
+							goto l1825
 						}
-						{
-							position956, tokenIndex956, depth956 := position, tokenIndex, depth
-							if buffer[position] != rune('f') {
-								goto l957
-							}
-							position++
-							goto l956
-						l957:
-							position, tokenIndex, depth = position956, tokenIndex956, depth956
-							if buffer[position] != rune('F') {
-								goto l950
-							}
-							position++
+						position++
+					}
+				l1834:
+					{
+						position1836, tokenIndex1836, depth1836 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l1837
 						}
-					l956:
-						{
-							position958, tokenIndex958, depth958 := position, tokenIndex, depth
-							if buffer[position] != rune('u') {
-								goto l959
-							}
-							position++
-							goto l958
-						l959:
-							position, tokenIndex, depth = position958, tokenIndex958, depth958
-							if buffer[position] != rune('U') {
-								goto l950
-							}
-							position++
+						position++
+						goto l1836
+					l1837:
+						position, tokenIndex, depth = position1836, tokenIndex1836, depth1836
+						if buffer[position] != rune('C') {
+							goto l1825
 						}
-					l958:
-						{
-							position960, tokenIndex960, depth960 := position, tokenIndex, depth
-							if buffer[position] != rune('l') {
-								goto l961
-							}
-							position++
-							goto l960
-						l961:
-							position, tokenIndex, depth = position960, tokenIndex960, depth960
-							if buffer[position] != rune('L') {
-								goto l950
-							}
-							position++
+						position++
+					}
+				l1836:
+					if buffer[position] != rune('_') {
+						goto l1825
+					}
+					position++
+					{
+						position1838, tokenIndex1838, depth1838 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1839
 						}
-					l960:
-						{
-							position962, tokenIndex962, depth962 := position, tokenIndex, depth
-							if buffer[position] != rune('l') {
-								goto l963
-							}
-							position++
-							goto l962
-						l963:
-							position, tokenIndex, depth = position962, tokenIndex962, depth962
-							if buffer[position] != rune('L') {
-								goto l950
-							}
-							position++
+						position++
+						goto l1838
+					l1839:
+						position, tokenIndex, depth = position1838, tokenIndex1838, depth1838
+						if buffer[position] != rune('T') {
+							goto l1825
 						}
-					l962:
-						goto l951
-					l950:
-						position, tokenIndex, depth = position950, tokenIndex950, depth950
+						position++
+					}
+				l1838:
+					{
+						position1840, tokenIndex1840, depth1840 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1841
+						}
+						position++
+						goto l1840
+					l1841:
+						position, tokenIndex, depth = position1840, tokenIndex1840, depth1840
+						if buffer[position] != rune('S') {
+							goto l1825
+						}
+						position++
+					}
+				l1840:
+					if buffer[position] != rune('(') {
+						goto l1825
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l1825
 					}
-				l951:
+					position++
 					depth--
-					add(rulePegText, position949)
+					add(rulePegText, position1827)
 				}
-				if !_rules[ruleAction44]() {
-					goto l947
+				if !_rules[ruleAction99]() {
+					goto l1825
 				}
 				depth--
-				add(ruleSheddingSpecOpt, position948)
+				add(ruleRowProcTimestamp, position1826)
 			}
 			return true
-		l947:
-			position, tokenIndex, depth = position947, tokenIndex947, depth947
+		l1825:
+			position, tokenIndex, depth = position1825, tokenIndex1825, depth1825
 			return false
 		},
-		/* 59 SheddingOption <- <(Wait / DropOldest / DropNewest)> */
+		/* 138 RowInputName <- <(<((ident ':')? (('i' / 'I') ('n' / 'N') ('p' / 'P') ('u' / 'U') ('t' / 'T') '_' ('n' / 'N') ('a' / 'A') ('m' / 'M') ('e' / 'E')) ('(' ')'))> Action100)> */
 		func() bool {
-			position964, tokenIndex964, depth964 := position, tokenIndex, depth
+			position1842, tokenIndex1842, depth1842 := position, tokenIndex, depth
 			{
-				position965 := position
+				position1843 := position
 				depth++
 				{
-					position966, tokenIndex966, depth966 := position, tokenIndex, depth
-					if !_rules[ruleWait]() {
-						goto l967
+					position1844 := position
+					depth++
+					{
+						position1845, tokenIndex1845, depth1845 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1845
+						}
+						if buffer[position] != rune(':') {
+							goto l1845
+						}
+						position++
+						goto l1846
+					l1845:
+						position, tokenIndex, depth = position1845, tokenIndex1845, depth1845
 					}
-					goto l966
-				l967:
-					position, tokenIndex, depth = position966, tokenIndex966, depth966
-					if !_rules[ruleDropOldest]() {
-						goto l968
+				l1846:
+					{
+						position1847, tokenIndex1847, depth1847 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l1848
+						}
+						position++
+						goto l1847
+					l1848:
+						position, tokenIndex, depth = position1847, tokenIndex1847, depth1847
+						if buffer[position] != rune('I') {
+							goto l1842
+						}
+						position++
 					}
-					goto l966
-				l968:
-					position, tokenIndex, depth = position966, tokenIndex966, depth966
-					if !_rules[ruleDropNewest]() {
-						goto l964
+				l1847:
+					{
+						position1849, tokenIndex1849, depth1849 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l1850
+						}
+						position++
+						goto l1849
+					l1850:
+						position, tokenIndex, depth = position1849, tokenIndex1849, depth1849
+						if buffer[position] != rune('N') {
+							goto l1842
+						}
+						position++
 					}
-				}
-			l966:
-				depth--
-				add(ruleSheddingOption, position965)
-			}
-			return true
-		l964:
-			position, tokenIndex, depth = position964, tokenIndex964, depth964
-			return false
-		},
-		/* 60 SourceSinkSpecs <- <(<(sp (('w' / 'W') ('i' / 'I') ('t' / 'T') ('h' / 'H')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action45)> */
-		func() bool {
-			position969, tokenIndex969, depth969 := position, tokenIndex, depth
-			{
-				position970 := position
-				depth++
-				{
-					position971 := position
-					depth++
+				l1849:
 					{
-						position972, tokenIndex972, depth972 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l972
+						position1851, tokenIndex1851, depth1851 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l1852
 						}
-						{
-							position974, tokenIndex974, depth974 := position, tokenIndex, depth
-							if buffer[position] != rune('w') {
-								goto l975
-							}
-							position++
-							goto l974
-						l975:
-							position, tokenIndex, depth = position974, tokenIndex974, depth974
-							if buffer[position] != rune('W') {
-								goto l972
-							}
-							position++
+						position++
+						goto l1851
+					l1852:
+						position, tokenIndex, depth = position1851, tokenIndex1851, depth1851
+						if buffer[position] != rune('P') {
+							goto l1842
 						}
-					l974:
-						{
-							position976, tokenIndex976, depth976 := position, tokenIndex, depth
-							if buffer[position] != rune('i') {
-								goto l977
-							}
-							position++
-							goto l976
-						l977:
-							position, tokenIndex, depth = position976, tokenIndex976, depth976
-							if buffer[position] != rune('I') {
-								goto l972
-							}
-							position++
+						position++
+					}
+				l1851:
+					{
+						position1853, tokenIndex1853, depth1853 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l1854
 						}
-					l976:
-						{
-							position978, tokenIndex978, depth978 := position, tokenIndex, depth
-							if buffer[position] != rune('t') {
-								goto l979
-							}
-							position++
-							goto l978
-						l979:
-							position, tokenIndex, depth = position978, tokenIndex978, depth978
-							if buffer[position] != rune('T') {
-								goto l972
-							}
-							position++
+						position++
+						goto l1853
+					l1854:
+						position, tokenIndex, depth = position1853, tokenIndex1853, depth1853
+						if buffer[position] != rune('U') {
+							goto l1842
 						}
-					l978:
-						{
-							position980, tokenIndex980, depth980 := position, tokenIndex, depth
-							if buffer[position] != rune('h') {
-								goto l981
-							}
-							position++
-							goto l980
-						l981:
-							position, tokenIndex, depth = position980, tokenIndex980, depth980
-							if buffer[position] != rune('H') {
-								goto l972
-							}
-							position++
+						position++
+					}
+				l1853:
+					{
+						position1855, tokenIndex1855, depth1855 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1856
 						}
-					l980:
-						if !_rules[rulesp]() {
-							goto l972
+						position++
+						goto l1855
+					l1856:
+						position, tokenIndex, depth = position1855, tokenIndex1855, depth1855
+						if buffer[position] != rune('T') {
+							goto l1842
 						}
-						if !_rules[ruleSourceSinkParam]() {
-							goto l972
+						position++
+					}
+				l1855:
+					if buffer[position] != rune('_') {
+						goto l1842
+					}
+					position++
+					{
+						position1857, tokenIndex1857, depth1857 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l1858
 						}
-					l982:
-						{
-							position983, tokenIndex983, depth983 := position, tokenIndex, depth
-							if !_rules[rulespOpt]() {
-								goto l983
-							}
-							if buffer[position] != rune(',') {
-								goto l983
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l983
-							}
-							if !_rules[ruleSourceSinkParam]() {
-								goto l983
-							}
-							goto l982
-						l983:
-							position, tokenIndex, depth = position983, tokenIndex983, depth983
+						position++
+						goto l1857
+					l1858:
+						position, tokenIndex, depth = position1857, tokenIndex1857, depth1857
+						if buffer[position] != rune('N') {
+							goto l1842
 						}
-						goto l973
-					l972:
-						position, tokenIndex, depth = position972, tokenIndex972, depth972
+						position++
 					}
-				l973:
+				l1857:
+					{
+						position1859, tokenIndex1859, depth1859 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1860
+						}
+						position++
+						goto l1859
+					l1860:
+						position, tokenIndex, depth = position1859, tokenIndex1859, depth1859
+						if buffer[position] != rune('A') {
+							goto l1842
+						}
+						position++
+					}
+				l1859:
+					{
+						position1861, tokenIndex1861, depth1861 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l1862
+						}
+						position++
+						goto l1861
+					l1862:
+						position, tokenIndex, depth = position1861, tokenIndex1861, depth1861
+						if buffer[position] != rune('M') {
+							goto l1842
+						}
+						position++
+					}
+				l1861:
+					{
+						position1863, tokenIndex1863, depth1863 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1864
+						}
+						position++
+						goto l1863
+					l1864:
+						position, tokenIndex, depth = position1863, tokenIndex1863, depth1863
+						if buffer[position] != rune('E') {
+							goto l1842
+						}
+						position++
+					}
+				l1863:
+					if buffer[position] != rune('(') {
+						goto l1842
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l1842
+					}
+					position++
 					depth--
-					add(rulePegText, position971)
+					add(rulePegText, position1844)
 				}
-				if !_rules[ruleAction45]() {
-					goto l969
+				if !_rules[ruleAction100]() {
+					goto l1842
 				}
 				depth--
-				add(ruleSourceSinkSpecs, position970)
+				add(ruleRowInputName, position1843)
 			}
 			return true
-		l969:
-			position, tokenIndex, depth = position969, tokenIndex969, depth969
+		l1842:
+			position, tokenIndex, depth = position1842, tokenIndex1842, depth1842
 			return false
 		},
-		/* 61 UpdateSourceSinkSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)> Action46)> */
+		/* 139 RowTrace <- <(<((ident ':')? (('t' / 'T') ('r' / 'R') ('a' / 'A') ('c' / 'C') ('e' / 'E')) ('(' ')'))> Action101)> */
 		func() bool {
-			position984, tokenIndex984, depth984 := position, tokenIndex, depth
+			position1865, tokenIndex1865, depth1865 := position, tokenIndex, depth
 			{
-				position985 := position
+				position1866 := position
 				depth++
 				{
-					position986 := position
+					position1867 := position
 					depth++
-					if !_rules[rulesp]() {
-						goto l984
+					{
+						position1868, tokenIndex1868, depth1868 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1868
+						}
+						if buffer[position] != rune(':') {
+							goto l1868
+						}
+						position++
+						goto l1869
+					l1868:
+						position, tokenIndex, depth = position1868, tokenIndex1868, depth1868
 					}
+				l1869:
 					{
-						position987, tokenIndex987, depth987 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l988
+						position1870, tokenIndex1870, depth1870 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1871
 						}
 						position++
-						goto l987
-					l988:
-						position, tokenIndex, depth = position987, tokenIndex987, depth987
-						if buffer[position] != rune('S') {
-							goto l984
+						goto l1870
+					l1871:
+						position, tokenIndex, depth = position1870, tokenIndex1870, depth1870
+						if buffer[position] != rune('T') {
+							goto l1865
 						}
 						position++
 					}
-				l987:
+				l1870:
 					{
-						position989, tokenIndex989, depth989 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l990
+						position1872, tokenIndex1872, depth1872 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l1873
 						}
 						position++
-						goto l989
-					l990:
-						position, tokenIndex, depth = position989, tokenIndex989, depth989
-						if buffer[position] != rune('E') {
-							goto l984
+						goto l1872
+					l1873:
+						position, tokenIndex, depth = position1872, tokenIndex1872, depth1872
+						if buffer[position] != rune('R') {
+							goto l1865
 						}
 						position++
 					}
-				l989:
+				l1872:
 					{
-						position991, tokenIndex991, depth991 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l992
+						position1874, tokenIndex1874, depth1874 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1875
 						}
 						position++
-						goto l991
-					l992:
-						position, tokenIndex, depth = position991, tokenIndex991, depth991
-						if buffer[position] != rune('T') {
-							goto l984
+						goto l1874
+					l1875:
+						position, tokenIndex, depth = position1874, tokenIndex1874, depth1874
+						if buffer[position] != rune('A') {
+							goto l1865
 						}
 						position++
 					}
-				l991:
-					if !_rules[rulesp]() {
-						goto l984
-					}
-					if !_rules[ruleSourceSinkParam]() {
-						goto l984
-					}
-				l993:
+				l1874:
 					{
-						position994, tokenIndex994, depth994 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l994
+						position1876, tokenIndex1876, depth1876 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l1877
 						}
-						if buffer[position] != rune(',') {
-							goto l994
+						position++
+						goto l1876
+					l1877:
+						position, tokenIndex, depth = position1876, tokenIndex1876, depth1876
+						if buffer[position] != rune('C') {
+							goto l1865
 						}
 						position++
-						if !_rules[rulespOpt]() {
-							goto l994
+					}
+				l1876:
+					{
+						position1878, tokenIndex1878, depth1878 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1879
 						}
-						if !_rules[ruleSourceSinkParam]() {
-							goto l994
+						position++
+						goto l1878
+					l1879:
+						position, tokenIndex, depth = position1878, tokenIndex1878, depth1878
+						if buffer[position] != rune('E') {
+							goto l1865
 						}
-						goto l993
-					l994:
-						position, tokenIndex, depth = position994, tokenIndex994, depth994
+						position++
+					}
+				l1878:
+					if buffer[position] != rune('(') {
+						goto l1865
+					}
+					position++
+					if buffer[position] != rune(')') {
+						goto l1865
 					}
+					position++
 					depth--
-					add(rulePegText, position986)
+					add(rulePegText, position1867)
 				}
-				if !_rules[ruleAction46]() {
-					goto l984
+				if !_rules[ruleAction101]() {
+					goto l1865
 				}
 				depth--
-				add(ruleUpdateSourceSinkSpecs, position985)
+				add(ruleRowTrace, position1866)
 			}
 			return true
-		l984:
-			position, tokenIndex, depth = position984, tokenIndex984, depth984
+		l1865:
+			position, tokenIndex, depth = position1865, tokenIndex1865, depth1865
 			return false
 		},
-		/* 62 SetOptSpecs <- <(<(sp (('s' / 'S') ('e' / 'E') ('t' / 'T')) sp SourceSinkParam (spOpt ',' spOpt SourceSinkParam)*)?> Action47)> */
+		/* 140 RowMetadata <- <(<((ident ':')? (('m' / 'M') ('e' / 'E') ('t' / 'T') ('a' / 'A')) spOpt '(' spOpt StringLiteral spOpt ')')> Action102)> */
 		func() bool {
-			position995, tokenIndex995, depth995 := position, tokenIndex, depth
+			position1880, tokenIndex1880, depth1880 := position, tokenIndex, depth
 			{
-				position996 := position
+				position1881 := position
 				depth++
 				{
-					position997 := position
+					position1882 := position
 					depth++
 					{
-						position998, tokenIndex998, depth998 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l998
+						position1883, tokenIndex1883, depth1883 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1883
 						}
-						{
-							position1000, tokenIndex1000, depth1000 := position, tokenIndex, depth
-							if buffer[position] != rune('s') {
-								goto l1001
-							}
-							position++
-							goto l1000
-						l1001:
-							position, tokenIndex, depth = position1000, tokenIndex1000, depth1000
-							if buffer[position] != rune('S') {
-								goto l998
-							}
-							position++
+						if buffer[position] != rune(':') {
+							goto l1883
 						}
-					l1000:
-						{
-							position1002, tokenIndex1002, depth1002 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l1003
-							}
-							position++
-							goto l1002
-						l1003:
-							position, tokenIndex, depth = position1002, tokenIndex1002, depth1002
-							if buffer[position] != rune('E') {
-								goto l998
-							}
-							position++
+						position++
+						goto l1884
+					l1883:
+						position, tokenIndex, depth = position1883, tokenIndex1883, depth1883
+					}
+				l1884:
+					{
+						position1885, tokenIndex1885, depth1885 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l1886
 						}
-					l1002:
-						{
-							position1004, tokenIndex1004, depth1004 := position, tokenIndex, depth
-							if buffer[position] != rune('t') {
-								goto l1005
-							}
-							position++
-							goto l1004
-						l1005:
-							position, tokenIndex, depth = position1004, tokenIndex1004, depth1004
-							if buffer[position] != rune('T') {
-								goto l998
-							}
-							position++
+						position++
+						goto l1885
+					l1886:
+						position, tokenIndex, depth = position1885, tokenIndex1885, depth1885
+						if buffer[position] != rune('M') {
+							goto l1880
+						}
+						position++
+					}
+				l1885:
+					{
+						position1887, tokenIndex1887, depth1887 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1888
+						}
+						position++
+						goto l1887
+					l1888:
+						position, tokenIndex, depth = position1887, tokenIndex1887, depth1887
+						if buffer[position] != rune('E') {
+							goto l1880
+						}
+						position++
+					}
+				l1887:
+					{
+						position1889, tokenIndex1889, depth1889 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1890
 						}
-					l1004:
-						if !_rules[rulesp]() {
-							goto l998
+						position++
+						goto l1889
+					l1890:
+						position, tokenIndex, depth = position1889, tokenIndex1889, depth1889
+						if buffer[position] != rune('T') {
+							goto l1880
 						}
-						if !_rules[ruleSourceSinkParam]() {
-							goto l998
+						position++
+					}
+				l1889:
+					{
+						position1891, tokenIndex1891, depth1891 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1892
 						}
-					l1006:
-						{
-							position1007, tokenIndex1007, depth1007 := position, tokenIndex, depth
-							if !_rules[rulespOpt]() {
-								goto l1007
-							}
-							if buffer[position] != rune(',') {
-								goto l1007
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l1007
-							}
-							if !_rules[ruleSourceSinkParam]() {
-								goto l1007
-							}
-							goto l1006
-						l1007:
-							position, tokenIndex, depth = position1007, tokenIndex1007, depth1007
+						position++
+						goto l1891
+					l1892:
+						position, tokenIndex, depth = position1891, tokenIndex1891, depth1891
+						if buffer[position] != rune('A') {
+							goto l1880
 						}
-						goto l999
-					l998:
-						position, tokenIndex, depth = position998, tokenIndex998, depth998
+						position++
 					}
-				l999:
+				l1891:
+					if !_rules[rulespOpt]() {
+						goto l1880
+					}
+					if buffer[position] != rune('(') {
+						goto l1880
+					}
+					position++
+					if !_rules[rulespOpt]() {
+						goto l1880
+					}
+					if !_rules[ruleStringLiteral]() {
+						goto l1880
+					}
+					if !_rules[rulespOpt]() {
+						goto l1880
+					}
+					if buffer[position] != rune(')') {
+						goto l1880
+					}
+					position++
 					depth--
-					add(rulePegText, position997)
+					add(rulePegText, position1882)
 				}
-				if !_rules[ruleAction47]() {
-					goto l995
+				if !_rules[ruleAction102]() {
+					goto l1880
 				}
 				depth--
-				add(ruleSetOptSpecs, position996)
+				add(ruleRowMetadata, position1881)
 			}
 			return true
-		l995:
-			position, tokenIndex, depth = position995, tokenIndex995, depth995
+		l1880:
+			position, tokenIndex, depth = position1880, tokenIndex1880, depth1880
 			return false
 		},
-		/* 63 StateTagOpt <- <(<(sp (('t' / 'T') ('a' / 'A') ('g' / 'G')) sp Identifier)?> Action48)> */
+		/* 141 RowValue <- <(<((ident ':' !':')? jsonGetPath)> Action103)> */
 		func() bool {
-			position1008, tokenIndex1008, depth1008 := position, tokenIndex, depth
+			position1893, tokenIndex1893, depth1893 := position, tokenIndex, depth
 			{
-				position1009 := position
+				position1894 := position
 				depth++
 				{
-					position1010 := position
+					position1895 := position
 					depth++
 					{
-						position1011, tokenIndex1011, depth1011 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1011
-						}
-						{
-							position1013, tokenIndex1013, depth1013 := position, tokenIndex, depth
-							if buffer[position] != rune('t') {
-								goto l1014
-							}
-							position++
-							goto l1013
-						l1014:
-							position, tokenIndex, depth = position1013, tokenIndex1013, depth1013
-							if buffer[position] != rune('T') {
-								goto l1011
-							}
-							position++
+						position1896, tokenIndex1896, depth1896 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1896
 						}
-					l1013:
-						{
-							position1015, tokenIndex1015, depth1015 := position, tokenIndex, depth
-							if buffer[position] != rune('a') {
-								goto l1016
-							}
-							position++
-							goto l1015
-						l1016:
-							position, tokenIndex, depth = position1015, tokenIndex1015, depth1015
-							if buffer[position] != rune('A') {
-								goto l1011
-							}
-							position++
+						if buffer[position] != rune(':') {
+							goto l1896
 						}
-					l1015:
+						position++
 						{
-							position1017, tokenIndex1017, depth1017 := position, tokenIndex, depth
-							if buffer[position] != rune('g') {
-								goto l1018
-							}
-							position++
-							goto l1017
-						l1018:
-							position, tokenIndex, depth = position1017, tokenIndex1017, depth1017
-							if buffer[position] != rune('G') {
-								goto l1011
+							position1898, tokenIndex1898, depth1898 := position, tokenIndex, depth
+							if buffer[position] != rune(':') {
+								goto l1898
 							}
 							position++
+							goto l1896
+						l1898:
+							position, tokenIndex, depth = position1898, tokenIndex1898, depth1898
 						}
-					l1017:
-						if !_rules[rulesp]() {
-							goto l1011
-						}
-						if !_rules[ruleIdentifier]() {
-							goto l1011
-						}
-						goto l1012
-					l1011:
-						position, tokenIndex, depth = position1011, tokenIndex1011, depth1011
+						goto l1897
+					l1896:
+						position, tokenIndex, depth = position1896, tokenIndex1896, depth1896
+					}
+				l1897:
+					if !_rules[rulejsonGetPath]() {
+						goto l1893
 					}
-				l1012:
 					depth--
-					add(rulePegText, position1010)
-				}
-				if !_rules[ruleAction48]() {
-					goto l1008
-				}
-				depth--
-				add(ruleStateTagOpt, position1009)
-			}
-			return true
-		l1008:
-			position, tokenIndex, depth = position1008, tokenIndex1008, depth1008
-			return false
-		},
-		/* 64 SourceSinkParam <- <(SourceSinkParamKey spOpt '=' spOpt SourceSinkParamVal Action49)> */
-		func() bool {
-			position1019, tokenIndex1019, depth1019 := position, tokenIndex, depth
-			{
-				position1020 := position
-				depth++
-				if !_rules[ruleSourceSinkParamKey]() {
-					goto l1019
-				}
-				if !_rules[rulespOpt]() {
-					goto l1019
-				}
-				if buffer[position] != rune('=') {
-					goto l1019
-				}
-				position++
-				if !_rules[rulespOpt]() {
-					goto l1019
-				}
-				if !_rules[ruleSourceSinkParamVal]() {
-					goto l1019
+					add(rulePegText, position1895)
 				}
-				if !_rules[ruleAction49]() {
-					goto l1019
+				if !_rules[ruleAction103]() {
+					goto l1893
 				}
 				depth--
-				add(ruleSourceSinkParam, position1020)
+				add(ruleRowValue, position1894)
 			}
 			return true
-		l1019:
-			position, tokenIndex, depth = position1019, tokenIndex1019, depth1019
+		l1893:
+			position, tokenIndex, depth = position1893, tokenIndex1893, depth1893
 			return false
 		},
-		/* 65 SourceSinkParamVal <- <(ParamLiteral / ParamArrayExpr / ParamMapExpr)> */
+		/* 142 NumericLiteral <- <(<('-'? [0-9]+)> Action104)> */
 		func() bool {
-			position1021, tokenIndex1021, depth1021 := position, tokenIndex, depth
+			position1899, tokenIndex1899, depth1899 := position, tokenIndex, depth
 			{
-				position1022 := position
+				position1900 := position
 				depth++
 				{
-					position1023, tokenIndex1023, depth1023 := position, tokenIndex, depth
-					if !_rules[ruleParamLiteral]() {
-						goto l1024
+					position1901 := position
+					depth++
+					{
+						position1902, tokenIndex1902, depth1902 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l1902
+						}
+						position++
+						goto l1903
+					l1902:
+						position, tokenIndex, depth = position1902, tokenIndex1902, depth1902
 					}
-					goto l1023
-				l1024:
-					position, tokenIndex, depth = position1023, tokenIndex1023, depth1023
-					if !_rules[ruleParamArrayExpr]() {
-						goto l1025
+				l1903:
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1899
 					}
-					goto l1023
-				l1025:
-					position, tokenIndex, depth = position1023, tokenIndex1023, depth1023
-					if !_rules[ruleParamMapExpr]() {
-						goto l1021
+					position++
+				l1904:
+					{
+						position1905, tokenIndex1905, depth1905 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1905
+						}
+						position++
+						goto l1904
+					l1905:
+						position, tokenIndex, depth = position1905, tokenIndex1905, depth1905
 					}
+					depth--
+					add(rulePegText, position1901)
+				}
+				if !_rules[ruleAction104]() {
+					goto l1899
 				}
-			l1023:
 				depth--
-				add(ruleSourceSinkParamVal, position1022)
+				add(ruleNumericLiteral, position1900)
 			}
 			return true
-		l1021:
-			position, tokenIndex, depth = position1021, tokenIndex1021, depth1021
+		l1899:
+			position, tokenIndex, depth = position1899, tokenIndex1899, depth1899
 			return false
 		},
-		/* 66 ParamLiteral <- <(BooleanLiteral / Literal)> */
+		/* 143 NonNegativeNumericLiteral <- <(<[0-9]+> Action105)> */
 		func() bool {
-			position1026, tokenIndex1026, depth1026 := position, tokenIndex, depth
+			position1906, tokenIndex1906, depth1906 := position, tokenIndex, depth
 			{
-				position1027 := position
+				position1907 := position
 				depth++
 				{
-					position1028, tokenIndex1028, depth1028 := position, tokenIndex, depth
-					if !_rules[ruleBooleanLiteral]() {
-						goto l1029
+					position1908 := position
+					depth++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1906
 					}
-					goto l1028
-				l1029:
-					position, tokenIndex, depth = position1028, tokenIndex1028, depth1028
-					if !_rules[ruleLiteral]() {
-						goto l1026
+					position++
+				l1909:
+					{
+						position1910, tokenIndex1910, depth1910 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1910
+						}
+						position++
+						goto l1909
+					l1910:
+						position, tokenIndex, depth = position1910, tokenIndex1910, depth1910
 					}
+					depth--
+					add(rulePegText, position1908)
+				}
+				if !_rules[ruleAction105]() {
+					goto l1906
 				}
-			l1028:
 				depth--
-				add(ruleParamLiteral, position1027)
+				add(ruleNonNegativeNumericLiteral, position1907)
 			}
 			return true
-		l1026:
-			position, tokenIndex, depth = position1026, tokenIndex1026, depth1026
+		l1906:
+			position, tokenIndex, depth = position1906, tokenIndex1906, depth1906
 			return false
 		},
-		/* 67 ParamArrayExpr <- <(<('[' spOpt (ParamLiteral (',' spOpt ParamLiteral)*)? spOpt ','? spOpt ']')> Action50)> */
+		/* 144 FloatLiteral <- <(<('-'? [0-9]+ '.' [0-9]+)> Action106)> */
 		func() bool {
-			position1030, tokenIndex1030, depth1030 := position, tokenIndex, depth
+			position1911, tokenIndex1911, depth1911 := position, tokenIndex, depth
 			{
-				position1031 := position
+				position1912 := position
 				depth++
 				{
-					position1032 := position
+					position1913 := position
 					depth++
-					if buffer[position] != rune('[') {
-						goto l1030
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1030
-					}
 					{
-						position1033, tokenIndex1033, depth1033 := position, tokenIndex, depth
-						if !_rules[ruleParamLiteral]() {
-							goto l1033
-						}
-					l1035:
-						{
-							position1036, tokenIndex1036, depth1036 := position, tokenIndex, depth
-							if buffer[position] != rune(',') {
-								goto l1036
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l1036
-							}
-							if !_rules[ruleParamLiteral]() {
-								goto l1036
-							}
-							goto l1035
-						l1036:
-							position, tokenIndex, depth = position1036, tokenIndex1036, depth1036
+						position1914, tokenIndex1914, depth1914 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l1914
 						}
-						goto l1034
-					l1033:
-						position, tokenIndex, depth = position1033, tokenIndex1033, depth1033
+						position++
+						goto l1915
+					l1914:
+						position, tokenIndex, depth = position1914, tokenIndex1914, depth1914
 					}
-				l1034:
-					if !_rules[rulespOpt]() {
-						goto l1030
+				l1915:
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1911
 					}
+					position++
+				l1916:
 					{
-						position1037, tokenIndex1037, depth1037 := position, tokenIndex, depth
-						if buffer[position] != rune(',') {
-							goto l1037
+						position1917, tokenIndex1917, depth1917 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1917
 						}
 						position++
-						goto l1038
-					l1037:
-						position, tokenIndex, depth = position1037, tokenIndex1037, depth1037
+						goto l1916
+					l1917:
+						position, tokenIndex, depth = position1917, tokenIndex1917, depth1917
 					}
-				l1038:
-					if !_rules[rulespOpt]() {
-						goto l1030
+					if buffer[position] != rune('.') {
+						goto l1911
 					}
-					if buffer[position] != rune(']') {
-						goto l1030
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l1911
 					}
 					position++
+				l1918:
+					{
+						position1919, tokenIndex1919, depth1919 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l1919
+						}
+						position++
+						goto l1918
+					l1919:
+						position, tokenIndex, depth = position1919, tokenIndex1919, depth1919
+					}
 					depth--
-					add(rulePegText, position1032)
+					add(rulePegText, position1913)
 				}
-				if !_rules[ruleAction50]() {
-					goto l1030
+				if !_rules[ruleAction106]() {
+					goto l1911
 				}
 				depth--
-				add(ruleParamArrayExpr, position1031)
+				add(ruleFloatLiteral, position1912)
 			}
 			return true
-		l1030:
-			position, tokenIndex, depth = position1030, tokenIndex1030, depth1030
+		l1911:
+			position, tokenIndex, depth = position1911, tokenIndex1911, depth1911
 			return false
 		},
-		/* 68 ParamMapExpr <- <(<('{' spOpt (ParamKeyValuePair (spOpt ',' spOpt ParamKeyValuePair)*)? spOpt '}')> Action51)> */
+		/* 145 Function <- <(<ident> Action107)> */
 		func() bool {
-			position1039, tokenIndex1039, depth1039 := position, tokenIndex, depth
+			position1920, tokenIndex1920, depth1920 := position, tokenIndex, depth
 			{
-				position1040 := position
+				position1921 := position
 				depth++
 				{
-					position1041 := position
+					position1922 := position
 					depth++
-					if buffer[position] != rune('{') {
-						goto l1039
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1039
-					}
-					{
-						position1042, tokenIndex1042, depth1042 := position, tokenIndex, depth
-						if !_rules[ruleParamKeyValuePair]() {
-							goto l1042
-						}
-					l1044:
-						{
-							position1045, tokenIndex1045, depth1045 := position, tokenIndex, depth
-							if !_rules[rulespOpt]() {
-								goto l1045
-							}
-							if buffer[position] != rune(',') {
-								goto l1045
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l1045
-							}
-							if !_rules[ruleParamKeyValuePair]() {
-								goto l1045
-							}
-							goto l1044
-						l1045:
-							position, tokenIndex, depth = position1045, tokenIndex1045, depth1045
-						}
-						goto l1043
-					l1042:
-						position, tokenIndex, depth = position1042, tokenIndex1042, depth1042
-					}
-				l1043:
-					if !_rules[rulespOpt]() {
-						goto l1039
-					}
-					if buffer[position] != rune('}') {
-						goto l1039
+					if !_rules[ruleident]() {
+						goto l1920
 					}
-					position++
 					depth--
-					add(rulePegText, position1041)
+					add(rulePegText, position1922)
 				}
-				if !_rules[ruleAction51]() {
-					goto l1039
+				if !_rules[ruleAction107]() {
+					goto l1920
 				}
 				depth--
-				add(ruleParamMapExpr, position1040)
+				add(ruleFunction, position1921)
 			}
 			return true
-		l1039:
-			position, tokenIndex, depth = position1039, tokenIndex1039, depth1039
+		l1920:
+			position, tokenIndex, depth = position1920, tokenIndex1920, depth1920
 			return false
 		},
-		/* 69 ParamKeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ParamLiteral)> Action52)> */
+		/* 146 NullLiteral <- <(<(('n' / 'N') ('u' / 'U') ('l' / 'L') ('l' / 'L'))> Action108)> */
 		func() bool {
-			position1046, tokenIndex1046, depth1046 := position, tokenIndex, depth
+			position1923, tokenIndex1923, depth1923 := position, tokenIndex, depth
 			{
-				position1047 := position
+				position1924 := position
 				depth++
 				{
-					position1048 := position
+					position1925 := position
 					depth++
-					if !_rules[ruleStringLiteral]() {
-						goto l1046
-					}
-					if !_rules[rulespOpt]() {
-						goto l1046
+					{
+						position1926, tokenIndex1926, depth1926 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l1927
+						}
+						position++
+						goto l1926
+					l1927:
+						position, tokenIndex, depth = position1926, tokenIndex1926, depth1926
+						if buffer[position] != rune('N') {
+							goto l1923
+						}
+						position++
 					}
-					if buffer[position] != rune(':') {
-						goto l1046
+				l1926:
+					{
+						position1928, tokenIndex1928, depth1928 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l1929
+						}
+						position++
+						goto l1928
+					l1929:
+						position, tokenIndex, depth = position1928, tokenIndex1928, depth1928
+						if buffer[position] != rune('U') {
+							goto l1923
+						}
+						position++
 					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1046
+				l1928:
+					{
+						position1930, tokenIndex1930, depth1930 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l1931
+						}
+						position++
+						goto l1930
+					l1931:
+						position, tokenIndex, depth = position1930, tokenIndex1930, depth1930
+						if buffer[position] != rune('L') {
+							goto l1923
+						}
+						position++
 					}
-					if !_rules[ruleParamLiteral]() {
-						goto l1046
+				l1930:
+					{
+						position1932, tokenIndex1932, depth1932 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l1933
+						}
+						position++
+						goto l1932
+					l1933:
+						position, tokenIndex, depth = position1932, tokenIndex1932, depth1932
+						if buffer[position] != rune('L') {
+							goto l1923
+						}
+						position++
 					}
+				l1932:
 					depth--
-					add(rulePegText, position1048)
+					add(rulePegText, position1925)
 				}
-				if !_rules[ruleAction52]() {
-					goto l1046
+				if !_rules[ruleAction108]() {
+					goto l1923
 				}
 				depth--
-				add(ruleParamKeyValuePair, position1047)
+				add(ruleNullLiteral, position1924)
 			}
 			return true
-		l1046:
-			position, tokenIndex, depth = position1046, tokenIndex1046, depth1046
+		l1923:
+			position, tokenIndex, depth = position1923, tokenIndex1923, depth1923
 			return false
 		},
-		/* 70 PausedOpt <- <(<(sp (Paused / Unpaused))?> Action53)> */
+		/* 147 Missing <- <(<(('m' / 'M') ('i' / 'I') ('s' / 'S') ('s' / 'S') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action109)> */
 		func() bool {
-			position1049, tokenIndex1049, depth1049 := position, tokenIndex, depth
+			position1934, tokenIndex1934, depth1934 := position, tokenIndex, depth
 			{
-				position1050 := position
+				position1935 := position
 				depth++
 				{
-					position1051 := position
+					position1936 := position
 					depth++
 					{
-						position1052, tokenIndex1052, depth1052 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1052
+						position1937, tokenIndex1937, depth1937 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l1938
 						}
-						{
-							position1054, tokenIndex1054, depth1054 := position, tokenIndex, depth
-							if !_rules[rulePaused]() {
-								goto l1055
-							}
-							goto l1054
-						l1055:
-							position, tokenIndex, depth = position1054, tokenIndex1054, depth1054
-							if !_rules[ruleUnpaused]() {
-								goto l1052
-							}
+						position++
+						goto l1937
+					l1938:
+						position, tokenIndex, depth = position1937, tokenIndex1937, depth1937
+						if buffer[position] != rune('M') {
+							goto l1934
+						}
+						position++
+					}
+				l1937:
+					{
+						position1939, tokenIndex1939, depth1939 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l1940
+						}
+						position++
+						goto l1939
+					l1940:
+						position, tokenIndex, depth = position1939, tokenIndex1939, depth1939
+						if buffer[position] != rune('I') {
+							goto l1934
+						}
+						position++
+					}
+				l1939:
+					{
+						position1941, tokenIndex1941, depth1941 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1942
+						}
+						position++
+						goto l1941
+					l1942:
+						position, tokenIndex, depth = position1941, tokenIndex1941, depth1941
+						if buffer[position] != rune('S') {
+							goto l1934
+						}
+						position++
+					}
+				l1941:
+					{
+						position1943, tokenIndex1943, depth1943 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1944
+						}
+						position++
+						goto l1943
+					l1944:
+						position, tokenIndex, depth = position1943, tokenIndex1943, depth1943
+						if buffer[position] != rune('S') {
+							goto l1934
+						}
+						position++
+					}
+				l1943:
+					{
+						position1945, tokenIndex1945, depth1945 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l1946
+						}
+						position++
+						goto l1945
+					l1946:
+						position, tokenIndex, depth = position1945, tokenIndex1945, depth1945
+						if buffer[position] != rune('I') {
+							goto l1934
+						}
+						position++
+					}
+				l1945:
+					{
+						position1947, tokenIndex1947, depth1947 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l1948
+						}
+						position++
+						goto l1947
+					l1948:
+						position, tokenIndex, depth = position1947, tokenIndex1947, depth1947
+						if buffer[position] != rune('N') {
+							goto l1934
+						}
+						position++
+					}
+				l1947:
+					{
+						position1949, tokenIndex1949, depth1949 := position, tokenIndex, depth
+						if buffer[position] != rune('g') {
+							goto l1950
+						}
+						position++
+						goto l1949
+					l1950:
+						position, tokenIndex, depth = position1949, tokenIndex1949, depth1949
+						if buffer[position] != rune('G') {
+							goto l1934
 						}
-					l1054:
-						goto l1053
-					l1052:
-						position, tokenIndex, depth = position1052, tokenIndex1052, depth1052
+						position++
 					}
-				l1053:
+				l1949:
 					depth--
-					add(rulePegText, position1051)
+					add(rulePegText, position1936)
 				}
-				if !_rules[ruleAction53]() {
-					goto l1049
+				if !_rules[ruleAction109]() {
+					goto l1934
 				}
 				depth--
-				add(rulePausedOpt, position1050)
+				add(ruleMissing, position1935)
 			}
 			return true
-		l1049:
-			position, tokenIndex, depth = position1049, tokenIndex1049, depth1049
+		l1934:
+			position, tokenIndex, depth = position1934, tokenIndex1934, depth1934
 			return false
 		},
-		/* 71 ExpressionOrWildcard <- <(Wildcard / Expression)> */
+		/* 148 BooleanLiteral <- <(TRUE / FALSE)> */
 		func() bool {
-			position1056, tokenIndex1056, depth1056 := position, tokenIndex, depth
+			position1951, tokenIndex1951, depth1951 := position, tokenIndex, depth
 			{
-				position1057 := position
+				position1952 := position
 				depth++
 				{
-					position1058, tokenIndex1058, depth1058 := position, tokenIndex, depth
-					if !_rules[ruleWildcard]() {
-						goto l1059
+					position1953, tokenIndex1953, depth1953 := position, tokenIndex, depth
+					if !_rules[ruleTRUE]() {
+						goto l1954
 					}
-					goto l1058
-				l1059:
-					position, tokenIndex, depth = position1058, tokenIndex1058, depth1058
-					if !_rules[ruleExpression]() {
-						goto l1056
+					goto l1953
+				l1954:
+					position, tokenIndex, depth = position1953, tokenIndex1953, depth1953
+					if !_rules[ruleFALSE]() {
+						goto l1951
 					}
 				}
-			l1058:
-				depth--
-				add(ruleExpressionOrWildcard, position1057)
-			}
-			return true
-		l1056:
-			position, tokenIndex, depth = position1056, tokenIndex1056, depth1056
-			return false
-		},
-		/* 72 Expression <- <orExpr> */
-		func() bool {
-			position1060, tokenIndex1060, depth1060 := position, tokenIndex, depth
-			{
-				position1061 := position
-				depth++
-				if !_rules[ruleorExpr]() {
-					goto l1060
-				}
+			l1953:
 				depth--
-				add(ruleExpression, position1061)
+				add(ruleBooleanLiteral, position1952)
 			}
 			return true
-		l1060:
-			position, tokenIndex, depth = position1060, tokenIndex1060, depth1060
+		l1951:
+			position, tokenIndex, depth = position1951, tokenIndex1951, depth1951
 			return false
 		},
-		/* 73 orExpr <- <(<(andExpr (sp Or sp andExpr)*)> Action54)> */
+		/* 149 TRUE <- <(<(('t' / 'T') ('r' / 'R') ('u' / 'U') ('e' / 'E'))> Action110)> */
 		func() bool {
-			position1062, tokenIndex1062, depth1062 := position, tokenIndex, depth
+			position1955, tokenIndex1955, depth1955 := position, tokenIndex, depth
 			{
-				position1063 := position
+				position1956 := position
 				depth++
 				{
-					position1064 := position
+					position1957 := position
 					depth++
-					if !_rules[ruleandExpr]() {
-						goto l1062
-					}
-				l1065:
 					{
-						position1066, tokenIndex1066, depth1066 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1066
+						position1958, tokenIndex1958, depth1958 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l1959
 						}
-						if !_rules[ruleOr]() {
-							goto l1066
+						position++
+						goto l1958
+					l1959:
+						position, tokenIndex, depth = position1958, tokenIndex1958, depth1958
+						if buffer[position] != rune('T') {
+							goto l1955
 						}
-						if !_rules[rulesp]() {
-							goto l1066
+						position++
+					}
+				l1958:
+					{
+						position1960, tokenIndex1960, depth1960 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l1961
 						}
-						if !_rules[ruleandExpr]() {
-							goto l1066
+						position++
+						goto l1960
+					l1961:
+						position, tokenIndex, depth = position1960, tokenIndex1960, depth1960
+						if buffer[position] != rune('R') {
+							goto l1955
 						}
-						goto l1065
-					l1066:
-						position, tokenIndex, depth = position1066, tokenIndex1066, depth1066
-					}
-					depth--
-					add(rulePegText, position1064)
-				}
-				if !_rules[ruleAction54]() {
-					goto l1062
-				}
-				depth--
-				add(ruleorExpr, position1063)
-			}
-			return true
-		l1062:
-			position, tokenIndex, depth = position1062, tokenIndex1062, depth1062
-			return false
-		},
-		/* 74 andExpr <- <(<(notExpr (sp And sp notExpr)*)> Action55)> */
-		func() bool {
-			position1067, tokenIndex1067, depth1067 := position, tokenIndex, depth
-			{
-				position1068 := position
-				depth++
-				{
-					position1069 := position
-					depth++
-					if !_rules[rulenotExpr]() {
-						goto l1067
+						position++
 					}
-				l1070:
+				l1960:
 					{
-						position1071, tokenIndex1071, depth1071 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1071
+						position1962, tokenIndex1962, depth1962 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l1963
 						}
-						if !_rules[ruleAnd]() {
-							goto l1071
+						position++
+						goto l1962
+					l1963:
+						position, tokenIndex, depth = position1962, tokenIndex1962, depth1962
+						if buffer[position] != rune('U') {
+							goto l1955
 						}
-						if !_rules[rulesp]() {
-							goto l1071
+						position++
+					}
+				l1962:
+					{
+						position1964, tokenIndex1964, depth1964 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1965
 						}
-						if !_rules[rulenotExpr]() {
-							goto l1071
+						position++
+						goto l1964
+					l1965:
+						position, tokenIndex, depth = position1964, tokenIndex1964, depth1964
+						if buffer[position] != rune('E') {
+							goto l1955
 						}
-						goto l1070
-					l1071:
-						position, tokenIndex, depth = position1071, tokenIndex1071, depth1071
+						position++
 					}
+				l1964:
 					depth--
-					add(rulePegText, position1069)
+					add(rulePegText, position1957)
 				}
-				if !_rules[ruleAction55]() {
-					goto l1067
+				if !_rules[ruleAction110]() {
+					goto l1955
 				}
 				depth--
-				add(ruleandExpr, position1068)
+				add(ruleTRUE, position1956)
 			}
 			return true
-		l1067:
-			position, tokenIndex, depth = position1067, tokenIndex1067, depth1067
+		l1955:
+			position, tokenIndex, depth = position1955, tokenIndex1955, depth1955
 			return false
 		},
-		/* 75 notExpr <- <(<((Not sp)? comparisonExpr)> Action56)> */
+		/* 150 FALSE <- <(<(('f' / 'F') ('a' / 'A') ('l' / 'L') ('s' / 'S') ('e' / 'E'))> Action111)> */
 		func() bool {
-			position1072, tokenIndex1072, depth1072 := position, tokenIndex, depth
+			position1966, tokenIndex1966, depth1966 := position, tokenIndex, depth
 			{
-				position1073 := position
+				position1967 := position
 				depth++
 				{
-					position1074 := position
+					position1968 := position
 					depth++
 					{
-						position1075, tokenIndex1075, depth1075 := position, tokenIndex, depth
-						if !_rules[ruleNot]() {
-							goto l1075
+						position1969, tokenIndex1969, depth1969 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l1970
 						}
-						if !_rules[rulesp]() {
-							goto l1075
+						position++
+						goto l1969
+					l1970:
+						position, tokenIndex, depth = position1969, tokenIndex1969, depth1969
+						if buffer[position] != rune('F') {
+							goto l1966
 						}
-						goto l1076
-					l1075:
-						position, tokenIndex, depth = position1075, tokenIndex1075, depth1075
+						position++
 					}
-				l1076:
-					if !_rules[rulecomparisonExpr]() {
-						goto l1072
+				l1969:
+					{
+						position1971, tokenIndex1971, depth1971 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l1972
+						}
+						position++
+						goto l1971
+					l1972:
+						position, tokenIndex, depth = position1971, tokenIndex1971, depth1971
+						if buffer[position] != rune('A') {
+							goto l1966
+						}
+						position++
 					}
-					depth--
-					add(rulePegText, position1074)
-				}
-				if !_rules[ruleAction56]() {
-					goto l1072
-				}
-				depth--
-				add(rulenotExpr, position1073)
-			}
-			return true
-		l1072:
-			position, tokenIndex, depth = position1072, tokenIndex1072, depth1072
-			return false
-		},
-		/* 76 comparisonExpr <- <(<(otherOpExpr (spOpt ComparisonOp spOpt otherOpExpr)?)> Action57)> */
-		func() bool {
-			position1077, tokenIndex1077, depth1077 := position, tokenIndex, depth
-			{
-				position1078 := position
-				depth++
-				{
-					position1079 := position
-					depth++
-					if !_rules[ruleotherOpExpr]() {
-						goto l1077
+				l1971:
+					{
+						position1973, tokenIndex1973, depth1973 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l1974
+						}
+						position++
+						goto l1973
+					l1974:
+						position, tokenIndex, depth = position1973, tokenIndex1973, depth1973
+						if buffer[position] != rune('L') {
+							goto l1966
+						}
+						position++
 					}
+				l1973:
 					{
-						position1080, tokenIndex1080, depth1080 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l1080
+						position1975, tokenIndex1975, depth1975 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l1976
 						}
-						if !_rules[ruleComparisonOp]() {
-							goto l1080
+						position++
+						goto l1975
+					l1976:
+						position, tokenIndex, depth = position1975, tokenIndex1975, depth1975
+						if buffer[position] != rune('S') {
+							goto l1966
 						}
-						if !_rules[rulespOpt]() {
-							goto l1080
+						position++
+					}
+				l1975:
+					{
+						position1977, tokenIndex1977, depth1977 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l1978
 						}
-						if !_rules[ruleotherOpExpr]() {
-							goto l1080
+						position++
+						goto l1977
+					l1978:
+						position, tokenIndex, depth = position1977, tokenIndex1977, depth1977
+						if buffer[position] != rune('E') {
+							goto l1966
 						}
-						goto l1081
-					l1080:
-						position, tokenIndex, depth = position1080, tokenIndex1080, depth1080
+						position++
 					}
-				l1081:
+				l1977:
 					depth--
-					add(rulePegText, position1079)
+					add(rulePegText, position1968)
 				}
-				if !_rules[ruleAction57]() {
-					goto l1077
+				if !_rules[ruleAction111]() {
+					goto l1966
 				}
 				depth--
-				add(rulecomparisonExpr, position1078)
+				add(ruleFALSE, position1967)
 			}
 			return true
-		l1077:
-			position, tokenIndex, depth = position1077, tokenIndex1077, depth1077
+		l1966:
+			position, tokenIndex, depth = position1966, tokenIndex1966, depth1966
 			return false
 		},
-		/* 77 otherOpExpr <- <(<(isExpr (spOpt OtherOp spOpt isExpr)*)> Action58)> */
+		/* 151 Wildcard <- <(<((ident ':' !':')? '*')> Action112)> */
 		func() bool {
-			position1082, tokenIndex1082, depth1082 := position, tokenIndex, depth
+			position1979, tokenIndex1979, depth1979 := position, tokenIndex, depth
 			{
-				position1083 := position
+				position1980 := position
 				depth++
 				{
-					position1084 := position
+					position1981 := position
 					depth++
-					if !_rules[ruleisExpr]() {
-						goto l1082
-					}
-				l1085:
 					{
-						position1086, tokenIndex1086, depth1086 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l1086
-						}
-						if !_rules[ruleOtherOp]() {
-							goto l1086
+						position1982, tokenIndex1982, depth1982 := position, tokenIndex, depth
+						if !_rules[ruleident]() {
+							goto l1982
 						}
-						if !_rules[rulespOpt]() {
-							goto l1086
+						if buffer[position] != rune(':') {
+							goto l1982
 						}
-						if !_rules[ruleisExpr]() {
-							goto l1086
+						position++
+						{
+							position1984, tokenIndex1984, depth1984 := position, tokenIndex, depth
+							if buffer[position] != rune(':') {
+								goto l1984
+							}
+							position++
+							goto l1982
+						l1984:
+							position, tokenIndex, depth = position1984, tokenIndex1984, depth1984
 						}
-						goto l1085
-					l1086:
-						position, tokenIndex, depth = position1086, tokenIndex1086, depth1086
+						goto l1983
+					l1982:
+						position, tokenIndex, depth = position1982, tokenIndex1982, depth1982
 					}
+				l1983:
+					if buffer[position] != rune('*') {
+						goto l1979
+					}
+					position++
 					depth--
-					add(rulePegText, position1084)
+					add(rulePegText, position1981)
 				}
-				if !_rules[ruleAction58]() {
-					goto l1082
+				if !_rules[ruleAction112]() {
+					goto l1979
 				}
 				depth--
-				add(ruleotherOpExpr, position1083)
+				add(ruleWildcard, position1980)
 			}
 			return true
-		l1082:
-			position, tokenIndex, depth = position1082, tokenIndex1082, depth1082
+		l1979:
+			position, tokenIndex, depth = position1979, tokenIndex1979, depth1979
 			return false
 		},
-		/* 78 isExpr <- <(<((RowValue sp IsOp sp Missing) / (termExpr (sp IsOp sp NullLiteral)?))> Action59)> */
+		/* 152 StringLiteral <- <(<('"' (('"' '"') / (!'"' .))* '"')> Action113)> */
 		func() bool {
-			position1087, tokenIndex1087, depth1087 := position, tokenIndex, depth
+			position1985, tokenIndex1985, depth1985 := position, tokenIndex, depth
 			{
-				position1088 := position
+				position1986 := position
 				depth++
 				{
-					position1089 := position
+					position1987 := position
 					depth++
+					if buffer[position] != rune('"') {
+						goto l1985
+					}
+					position++
+				l1988:
 					{
-						position1090, tokenIndex1090, depth1090 := position, tokenIndex, depth
-						if !_rules[ruleRowValue]() {
-							goto l1091
-						}
-						if !_rules[rulesp]() {
-							goto l1091
-						}
-						if !_rules[ruleIsOp]() {
-							goto l1091
-						}
-						if !_rules[rulesp]() {
-							goto l1091
-						}
-						if !_rules[ruleMissing]() {
-							goto l1091
-						}
-						goto l1090
-					l1091:
-						position, tokenIndex, depth = position1090, tokenIndex1090, depth1090
-						if !_rules[ruletermExpr]() {
-							goto l1087
-						}
+						position1989, tokenIndex1989, depth1989 := position, tokenIndex, depth
 						{
-							position1092, tokenIndex1092, depth1092 := position, tokenIndex, depth
-							if !_rules[rulesp]() {
-								goto l1092
+							position1990, tokenIndex1990, depth1990 := position, tokenIndex, depth
+							if buffer[position] != rune('"') {
+								goto l1991
 							}
-							if !_rules[ruleIsOp]() {
-								goto l1092
+							position++
+							if buffer[position] != rune('"') {
+								goto l1991
 							}
-							if !_rules[rulesp]() {
-								goto l1092
+							position++
+							goto l1990
+						l1991:
+							position, tokenIndex, depth = position1990, tokenIndex1990, depth1990
+							{
+								position1992, tokenIndex1992, depth1992 := position, tokenIndex, depth
+								if buffer[position] != rune('"') {
+									goto l1992
+								}
+								position++
+								goto l1989
+							l1992:
+								position, tokenIndex, depth = position1992, tokenIndex1992, depth1992
 							}
-							if !_rules[ruleNullLiteral]() {
-								goto l1092
+							if !matchDot() {
+								goto l1989
 							}
-							goto l1093
-						l1092:
-							position, tokenIndex, depth = position1092, tokenIndex1092, depth1092
 						}
-					l1093:
+					l1990:
+						goto l1988
+					l1989:
+						position, tokenIndex, depth = position1989, tokenIndex1989, depth1989
 					}
-				l1090:
+					if buffer[position] != rune('"') {
+						goto l1985
+					}
+					position++
 					depth--
-					add(rulePegText, position1089)
+					add(rulePegText, position1987)
 				}
-				if !_rules[ruleAction59]() {
-					goto l1087
+				if !_rules[ruleAction113]() {
+					goto l1985
 				}
 				depth--
-				add(ruleisExpr, position1088)
+				add(ruleStringLiteral, position1986)
 			}
 			return true
-		l1087:
-			position, tokenIndex, depth = position1087, tokenIndex1087, depth1087
+		l1985:
+			position, tokenIndex, depth = position1985, tokenIndex1985, depth1985
 			return false
 		},
-		/* 79 termExpr <- <(<(productExpr (spOpt PlusMinusOp spOpt productExpr)*)> Action60)> */
+		/* 153 Placeholder <- <(<('?' / (':' ident))> Action114)> */
 		func() bool {
-			position1094, tokenIndex1094, depth1094 := position, tokenIndex, depth
+			position1993, tokenIndex1993, depth1993 := position, tokenIndex, depth
 			{
-				position1095 := position
+				position1994 := position
 				depth++
 				{
-					position1096 := position
+					position1995 := position
 					depth++
-					if !_rules[ruleproductExpr]() {
-						goto l1094
-					}
-				l1097:
 					{
-						position1098, tokenIndex1098, depth1098 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l1098
-						}
-						if !_rules[rulePlusMinusOp]() {
-							goto l1098
+						position1996, tokenIndex1996, depth1996 := position, tokenIndex, depth
+						if buffer[position] != rune('?') {
+							goto l1997
 						}
-						if !_rules[rulespOpt]() {
-							goto l1098
+						position++
+						goto l1996
+					l1997:
+						position, tokenIndex, depth = position1996, tokenIndex1996, depth1996
+						if buffer[position] != rune(':') {
+							goto l1993
 						}
-						if !_rules[ruleproductExpr]() {
-							goto l1098
+						position++
+						if !_rules[ruleident]() {
+							goto l1993
 						}
-						goto l1097
-					l1098:
-						position, tokenIndex, depth = position1098, tokenIndex1098, depth1098
 					}
+				l1996:
 					depth--
-					add(rulePegText, position1096)
+					add(rulePegText, position1995)
 				}
-				if !_rules[ruleAction60]() {
-					goto l1094
+				if !_rules[ruleAction114]() {
+					goto l1993
 				}
 				depth--
-				add(ruletermExpr, position1095)
+				add(rulePlaceholder, position1994)
 			}
 			return true
-		l1094:
-			position, tokenIndex, depth = position1094, tokenIndex1094, depth1094
+		l1993:
+			position, tokenIndex, depth = position1993, tokenIndex1993, depth1993
 			return false
 		},
-		/* 80 productExpr <- <(<(minusExpr (spOpt MultDivOp spOpt minusExpr)*)> Action61)> */
+		/* 154 ISTREAM <- <(<(('i' / 'I') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action115)> */
 		func() bool {
-			position1099, tokenIndex1099, depth1099 := position, tokenIndex, depth
+			position1998, tokenIndex1998, depth1998 := position, tokenIndex, depth
 			{
-				position1100 := position
+				position1999 := position
 				depth++
 				{
-					position1101 := position
+					position2000 := position
 					depth++
-					if !_rules[ruleminusExpr]() {
-						goto l1099
-					}
-				l1102:
 					{
-						position1103, tokenIndex1103, depth1103 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l1103
+						position2001, tokenIndex2001, depth2001 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2002
 						}
-						if !_rules[ruleMultDivOp]() {
-							goto l1103
+						position++
+						goto l2001
+					l2002:
+						position, tokenIndex, depth = position2001, tokenIndex2001, depth2001
+						if buffer[position] != rune('I') {
+							goto l1998
 						}
-						if !_rules[rulespOpt]() {
-							goto l1103
+						position++
+					}
+				l2001:
+					{
+						position2003, tokenIndex2003, depth2003 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2004
 						}
-						if !_rules[ruleminusExpr]() {
-							goto l1103
+						position++
+						goto l2003
+					l2004:
+						position, tokenIndex, depth = position2003, tokenIndex2003, depth2003
+						if buffer[position] != rune('S') {
+							goto l1998
 						}
-						goto l1102
-					l1103:
-						position, tokenIndex, depth = position1103, tokenIndex1103, depth1103
+						position++
 					}
-					depth--
-					add(rulePegText, position1101)
-				}
-				if !_rules[ruleAction61]() {
-					goto l1099
-				}
-				depth--
-				add(ruleproductExpr, position1100)
-			}
-			return true
-		l1099:
-			position, tokenIndex, depth = position1099, tokenIndex1099, depth1099
-			return false
-		},
-		/* 81 minusExpr <- <(<((UnaryMinus spOpt)? castExpr)> Action62)> */
-		func() bool {
-			position1104, tokenIndex1104, depth1104 := position, tokenIndex, depth
-			{
-				position1105 := position
-				depth++
-				{
-					position1106 := position
-					depth++
+				l2003:
 					{
-						position1107, tokenIndex1107, depth1107 := position, tokenIndex, depth
-						if !_rules[ruleUnaryMinus]() {
-							goto l1107
+						position2005, tokenIndex2005, depth2005 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2006
 						}
-						if !_rules[rulespOpt]() {
-							goto l1107
+						position++
+						goto l2005
+					l2006:
+						position, tokenIndex, depth = position2005, tokenIndex2005, depth2005
+						if buffer[position] != rune('T') {
+							goto l1998
 						}
-						goto l1108
-					l1107:
-						position, tokenIndex, depth = position1107, tokenIndex1107, depth1107
-					}
-				l1108:
-					if !_rules[rulecastExpr]() {
-						goto l1104
+						position++
 					}
-					depth--
-					add(rulePegText, position1106)
-				}
-				if !_rules[ruleAction62]() {
-					goto l1104
-				}
-				depth--
-				add(ruleminusExpr, position1105)
-			}
-			return true
-		l1104:
-			position, tokenIndex, depth = position1104, tokenIndex1104, depth1104
-			return false
-		},
-		/* 82 castExpr <- <(<(baseExpr (spOpt (':' ':') spOpt Type)?)> Action63)> */
-		func() bool {
-			position1109, tokenIndex1109, depth1109 := position, tokenIndex, depth
-			{
-				position1110 := position
-				depth++
-				{
-					position1111 := position
-					depth++
-					if !_rules[rulebaseExpr]() {
-						goto l1109
+				l2005:
+					{
+						position2007, tokenIndex2007, depth2007 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2008
+						}
+						position++
+						goto l2007
+					l2008:
+						position, tokenIndex, depth = position2007, tokenIndex2007, depth2007
+						if buffer[position] != rune('R') {
+							goto l1998
+						}
+						position++
 					}
+				l2007:
 					{
-						position1112, tokenIndex1112, depth1112 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l1112
+						position2009, tokenIndex2009, depth2009 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2010
 						}
-						if buffer[position] != rune(':') {
-							goto l1112
+						position++
+						goto l2009
+					l2010:
+						position, tokenIndex, depth = position2009, tokenIndex2009, depth2009
+						if buffer[position] != rune('E') {
+							goto l1998
 						}
 						position++
-						if buffer[position] != rune(':') {
-							goto l1112
+					}
+				l2009:
+					{
+						position2011, tokenIndex2011, depth2011 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2012
 						}
 						position++
-						if !_rules[rulespOpt]() {
-							goto l1112
+						goto l2011
+					l2012:
+						position, tokenIndex, depth = position2011, tokenIndex2011, depth2011
+						if buffer[position] != rune('A') {
+							goto l1998
 						}
-						if !_rules[ruleType]() {
-							goto l1112
+						position++
+					}
+				l2011:
+					{
+						position2013, tokenIndex2013, depth2013 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2014
+						}
+						position++
+						goto l2013
+					l2014:
+						position, tokenIndex, depth = position2013, tokenIndex2013, depth2013
+						if buffer[position] != rune('M') {
+							goto l1998
 						}
-						goto l1113
-					l1112:
-						position, tokenIndex, depth = position1112, tokenIndex1112, depth1112
+						position++
 					}
-				l1113:
+				l2013:
 					depth--
-					add(rulePegText, position1111)
+					add(rulePegText, position2000)
 				}
-				if !_rules[ruleAction63]() {
-					goto l1109
+				if !_rules[ruleAction115]() {
+					goto l1998
 				}
 				depth--
-				add(rulecastExpr, position1110)
+				add(ruleISTREAM, position1999)
 			}
 			return true
-		l1109:
-			position, tokenIndex, depth = position1109, tokenIndex1109, depth1109
+		l1998:
+			position, tokenIndex, depth = position1998, tokenIndex1998, depth1998
 			return false
 		},
-		/* 83 baseExpr <- <(('(' spOpt Expression spOpt ')') / MapExpr / BooleanLiteral / NullLiteral / Case / RowMeta / FuncTypeCast / FuncApp / RowValue / ArrayExpr / Literal)> */
+		/* 155 DSTREAM <- <(<(('d' / 'D') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action116)> */
 		func() bool {
-			position1114, tokenIndex1114, depth1114 := position, tokenIndex, depth
+			position2015, tokenIndex2015, depth2015 := position, tokenIndex, depth
 			{
-				position1115 := position
+				position2016 := position
 				depth++
 				{
-					position1116, tokenIndex1116, depth1116 := position, tokenIndex, depth
-					if buffer[position] != rune('(') {
-						goto l1117
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1117
-					}
-					if !_rules[ruleExpression]() {
-						goto l1117
-					}
-					if !_rules[rulespOpt]() {
-						goto l1117
-					}
-					if buffer[position] != rune(')') {
-						goto l1117
-					}
-					position++
-					goto l1116
-				l1117:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleMapExpr]() {
-						goto l1118
-					}
-					goto l1116
-				l1118:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleBooleanLiteral]() {
-						goto l1119
-					}
-					goto l1116
-				l1119:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleNullLiteral]() {
-						goto l1120
-					}
-					goto l1116
-				l1120:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleCase]() {
-						goto l1121
+					position2017 := position
+					depth++
+					{
+						position2018, tokenIndex2018, depth2018 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2019
+						}
+						position++
+						goto l2018
+					l2019:
+						position, tokenIndex, depth = position2018, tokenIndex2018, depth2018
+						if buffer[position] != rune('D') {
+							goto l2015
+						}
+						position++
 					}
-					goto l1116
-				l1121:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleRowMeta]() {
-						goto l1122
+				l2018:
+					{
+						position2020, tokenIndex2020, depth2020 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2021
+						}
+						position++
+						goto l2020
+					l2021:
+						position, tokenIndex, depth = position2020, tokenIndex2020, depth2020
+						if buffer[position] != rune('S') {
+							goto l2015
+						}
+						position++
 					}
-					goto l1116
-				l1122:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleFuncTypeCast]() {
-						goto l1123
+				l2020:
+					{
+						position2022, tokenIndex2022, depth2022 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2023
+						}
+						position++
+						goto l2022
+					l2023:
+						position, tokenIndex, depth = position2022, tokenIndex2022, depth2022
+						if buffer[position] != rune('T') {
+							goto l2015
+						}
+						position++
 					}
-					goto l1116
-				l1123:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleFuncApp]() {
-						goto l1124
+				l2022:
+					{
+						position2024, tokenIndex2024, depth2024 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2025
+						}
+						position++
+						goto l2024
+					l2025:
+						position, tokenIndex, depth = position2024, tokenIndex2024, depth2024
+						if buffer[position] != rune('R') {
+							goto l2015
+						}
+						position++
 					}
-					goto l1116
-				l1124:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleRowValue]() {
-						goto l1125
+				l2024:
+					{
+						position2026, tokenIndex2026, depth2026 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2027
+						}
+						position++
+						goto l2026
+					l2027:
+						position, tokenIndex, depth = position2026, tokenIndex2026, depth2026
+						if buffer[position] != rune('E') {
+							goto l2015
+						}
+						position++
 					}
-					goto l1116
-				l1125:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleArrayExpr]() {
-						goto l1126
+				l2026:
+					{
+						position2028, tokenIndex2028, depth2028 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2029
+						}
+						position++
+						goto l2028
+					l2029:
+						position, tokenIndex, depth = position2028, tokenIndex2028, depth2028
+						if buffer[position] != rune('A') {
+							goto l2015
+						}
+						position++
 					}
-					goto l1116
-				l1126:
-					position, tokenIndex, depth = position1116, tokenIndex1116, depth1116
-					if !_rules[ruleLiteral]() {
-						goto l1114
+				l2028:
+					{
+						position2030, tokenIndex2030, depth2030 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2031
+						}
+						position++
+						goto l2030
+					l2031:
+						position, tokenIndex, depth = position2030, tokenIndex2030, depth2030
+						if buffer[position] != rune('M') {
+							goto l2015
+						}
+						position++
 					}
+				l2030:
+					depth--
+					add(rulePegText, position2017)
+				}
+				if !_rules[ruleAction116]() {
+					goto l2015
 				}
-			l1116:
 				depth--
-				add(rulebaseExpr, position1115)
+				add(ruleDSTREAM, position2016)
 			}
 			return true
-		l1114:
-			position, tokenIndex, depth = position1114, tokenIndex1114, depth1114
+		l2015:
+			position, tokenIndex, depth = position2015, tokenIndex2015, depth2015
 			return false
 		},
-		/* 84 FuncTypeCast <- <(<(('c' / 'C') ('a' / 'A') ('s' / 'S') ('t' / 'T') spOpt '(' spOpt Expression sp (('a' / 'A') ('s' / 'S')) sp Type spOpt ')')> Action64)> */
+		/* 156 RSTREAM <- <(<(('r' / 'R') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action117)> */
 		func() bool {
-			position1127, tokenIndex1127, depth1127 := position, tokenIndex, depth
+			position2032, tokenIndex2032, depth2032 := position, tokenIndex, depth
 			{
-				position1128 := position
+				position2033 := position
 				depth++
 				{
-					position1129 := position
+					position2034 := position
 					depth++
 					{
-						position1130, tokenIndex1130, depth1130 := position, tokenIndex, depth
-						if buffer[position] != rune('c') {
-							goto l1131
+						position2035, tokenIndex2035, depth2035 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2036
 						}
 						position++
-						goto l1130
-					l1131:
-						position, tokenIndex, depth = position1130, tokenIndex1130, depth1130
-						if buffer[position] != rune('C') {
-							goto l1127
+						goto l2035
+					l2036:
+						position, tokenIndex, depth = position2035, tokenIndex2035, depth2035
+						if buffer[position] != rune('R') {
+							goto l2032
 						}
 						position++
 					}
-				l1130:
+				l2035:
 					{
-						position1132, tokenIndex1132, depth1132 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1133
+						position2037, tokenIndex2037, depth2037 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2038
 						}
 						position++
-						goto l1132
-					l1133:
-						position, tokenIndex, depth = position1132, tokenIndex1132, depth1132
-						if buffer[position] != rune('A') {
-							goto l1127
+						goto l2037
+					l2038:
+						position, tokenIndex, depth = position2037, tokenIndex2037, depth2037
+						if buffer[position] != rune('S') {
+							goto l2032
 						}
 						position++
 					}
-				l1132:
+				l2037:
 					{
-						position1134, tokenIndex1134, depth1134 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1135
+						position2039, tokenIndex2039, depth2039 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2040
 						}
 						position++
-						goto l1134
-					l1135:
-						position, tokenIndex, depth = position1134, tokenIndex1134, depth1134
-						if buffer[position] != rune('S') {
-							goto l1127
+						goto l2039
+					l2040:
+						position, tokenIndex, depth = position2039, tokenIndex2039, depth2039
+						if buffer[position] != rune('T') {
+							goto l2032
 						}
 						position++
 					}
-				l1134:
+				l2039:
 					{
-						position1136, tokenIndex1136, depth1136 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1137
+						position2041, tokenIndex2041, depth2041 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2042
 						}
 						position++
-						goto l1136
-					l1137:
-						position, tokenIndex, depth = position1136, tokenIndex1136, depth1136
-						if buffer[position] != rune('T') {
-							goto l1127
+						goto l2041
+					l2042:
+						position, tokenIndex, depth = position2041, tokenIndex2041, depth2041
+						if buffer[position] != rune('R') {
+							goto l2032
 						}
 						position++
 					}
-				l1136:
-					if !_rules[rulespOpt]() {
-						goto l1127
-					}
-					if buffer[position] != rune('(') {
-						goto l1127
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1127
-					}
-					if !_rules[ruleExpression]() {
-						goto l1127
-					}
-					if !_rules[rulesp]() {
-						goto l1127
+				l2041:
+					{
+						position2043, tokenIndex2043, depth2043 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2044
+						}
+						position++
+						goto l2043
+					l2044:
+						position, tokenIndex, depth = position2043, tokenIndex2043, depth2043
+						if buffer[position] != rune('E') {
+							goto l2032
+						}
+						position++
 					}
+				l2043:
 					{
-						position1138, tokenIndex1138, depth1138 := position, tokenIndex, depth
+						position2045, tokenIndex2045, depth2045 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l1139
+							goto l2046
 						}
 						position++
-						goto l1138
-					l1139:
-						position, tokenIndex, depth = position1138, tokenIndex1138, depth1138
+						goto l2045
+					l2046:
+						position, tokenIndex, depth = position2045, tokenIndex2045, depth2045
 						if buffer[position] != rune('A') {
-							goto l1127
+							goto l2032
 						}
 						position++
 					}
-				l1138:
+				l2045:
 					{
-						position1140, tokenIndex1140, depth1140 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1141
+						position2047, tokenIndex2047, depth2047 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2048
 						}
 						position++
-						goto l1140
-					l1141:
-						position, tokenIndex, depth = position1140, tokenIndex1140, depth1140
-						if buffer[position] != rune('S') {
-							goto l1127
+						goto l2047
+					l2048:
+						position, tokenIndex, depth = position2047, tokenIndex2047, depth2047
+						if buffer[position] != rune('M') {
+							goto l2032
 						}
 						position++
 					}
-				l1140:
-					if !_rules[rulesp]() {
-						goto l1127
-					}
-					if !_rules[ruleType]() {
-						goto l1127
-					}
-					if !_rules[rulespOpt]() {
-						goto l1127
-					}
-					if buffer[position] != rune(')') {
-						goto l1127
-					}
-					position++
+				l2047:
 					depth--
-					add(rulePegText, position1129)
+					add(rulePegText, position2034)
 				}
-				if !_rules[ruleAction64]() {
-					goto l1127
+				if !_rules[ruleAction117]() {
+					goto l2032
 				}
 				depth--
-				add(ruleFuncTypeCast, position1128)
+				add(ruleRSTREAM, position2033)
 			}
 			return true
-		l1127:
-			position, tokenIndex, depth = position1127, tokenIndex1127, depth1127
+		l2032:
+			position, tokenIndex, depth = position2032, tokenIndex2032, depth2032
 			return false
 		},
-		/* 85 FuncApp <- <(FuncAppWithOrderBy / FuncAppWithoutOrderBy)> */
+		/* 157 TUPLES <- <(<(('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E') ('s' / 'S'))> Action118)> */
 		func() bool {
-			position1142, tokenIndex1142, depth1142 := position, tokenIndex, depth
+			position2049, tokenIndex2049, depth2049 := position, tokenIndex, depth
 			{
-				position1143 := position
+				position2050 := position
 				depth++
 				{
-					position1144, tokenIndex1144, depth1144 := position, tokenIndex, depth
-					if !_rules[ruleFuncAppWithOrderBy]() {
-						goto l1145
+					position2051 := position
+					depth++
+					{
+						position2052, tokenIndex2052, depth2052 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2053
+						}
+						position++
+						goto l2052
+					l2053:
+						position, tokenIndex, depth = position2052, tokenIndex2052, depth2052
+						if buffer[position] != rune('T') {
+							goto l2049
+						}
+						position++
 					}
-					goto l1144
-				l1145:
-					position, tokenIndex, depth = position1144, tokenIndex1144, depth1144
-					if !_rules[ruleFuncAppWithoutOrderBy]() {
-						goto l1142
+				l2052:
+					{
+						position2054, tokenIndex2054, depth2054 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l2055
+						}
+						position++
+						goto l2054
+					l2055:
+						position, tokenIndex, depth = position2054, tokenIndex2054, depth2054
+						if buffer[position] != rune('U') {
+							goto l2049
+						}
+						position++
 					}
-				}
-			l1144:
-				depth--
-				add(ruleFuncApp, position1143)
-			}
-			return true
-		l1142:
-			position, tokenIndex, depth = position1142, tokenIndex1142, depth1142
-			return false
-		},
-		/* 86 FuncAppWithOrderBy <- <(Function spOpt '(' spOpt FuncParams sp ParamsOrder spOpt ')' Action65)> */
-		func() bool {
-			position1146, tokenIndex1146, depth1146 := position, tokenIndex, depth
-			{
-				position1147 := position
-				depth++
-				if !_rules[ruleFunction]() {
-					goto l1146
-				}
-				if !_rules[rulespOpt]() {
-					goto l1146
-				}
-				if buffer[position] != rune('(') {
-					goto l1146
-				}
-				position++
-				if !_rules[rulespOpt]() {
-					goto l1146
-				}
-				if !_rules[ruleFuncParams]() {
-					goto l1146
-				}
-				if !_rules[rulesp]() {
-					goto l1146
-				}
-				if !_rules[ruleParamsOrder]() {
-					goto l1146
-				}
-				if !_rules[rulespOpt]() {
-					goto l1146
-				}
-				if buffer[position] != rune(')') {
-					goto l1146
-				}
-				position++
-				if !_rules[ruleAction65]() {
-					goto l1146
-				}
-				depth--
-				add(ruleFuncAppWithOrderBy, position1147)
-			}
-			return true
-		l1146:
-			position, tokenIndex, depth = position1146, tokenIndex1146, depth1146
-			return false
-		},
-		/* 87 FuncAppWithoutOrderBy <- <(Function spOpt '(' spOpt FuncParams <spOpt> ')' Action66)> */
-		func() bool {
-			position1148, tokenIndex1148, depth1148 := position, tokenIndex, depth
-			{
-				position1149 := position
-				depth++
-				if !_rules[ruleFunction]() {
-					goto l1148
-				}
-				if !_rules[rulespOpt]() {
-					goto l1148
-				}
-				if buffer[position] != rune('(') {
-					goto l1148
-				}
-				position++
-				if !_rules[rulespOpt]() {
-					goto l1148
-				}
-				if !_rules[ruleFuncParams]() {
-					goto l1148
-				}
-				{
-					position1150 := position
-					depth++
-					if !_rules[rulespOpt]() {
-						goto l1148
+				l2054:
+					{
+						position2056, tokenIndex2056, depth2056 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2057
+						}
+						position++
+						goto l2056
+					l2057:
+						position, tokenIndex, depth = position2056, tokenIndex2056, depth2056
+						if buffer[position] != rune('P') {
+							goto l2049
+						}
+						position++
+					}
+				l2056:
+					{
+						position2058, tokenIndex2058, depth2058 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2059
+						}
+						position++
+						goto l2058
+					l2059:
+						position, tokenIndex, depth = position2058, tokenIndex2058, depth2058
+						if buffer[position] != rune('L') {
+							goto l2049
+						}
+						position++
+					}
+				l2058:
+					{
+						position2060, tokenIndex2060, depth2060 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2061
+						}
+						position++
+						goto l2060
+					l2061:
+						position, tokenIndex, depth = position2060, tokenIndex2060, depth2060
+						if buffer[position] != rune('E') {
+							goto l2049
+						}
+						position++
+					}
+				l2060:
+					{
+						position2062, tokenIndex2062, depth2062 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2063
+						}
+						position++
+						goto l2062
+					l2063:
+						position, tokenIndex, depth = position2062, tokenIndex2062, depth2062
+						if buffer[position] != rune('S') {
+							goto l2049
+						}
+						position++
 					}
+				l2062:
 					depth--
-					add(rulePegText, position1150)
-				}
-				if buffer[position] != rune(')') {
-					goto l1148
+					add(rulePegText, position2051)
 				}
-				position++
-				if !_rules[ruleAction66]() {
-					goto l1148
+				if !_rules[ruleAction118]() {
+					goto l2049
 				}
 				depth--
-				add(ruleFuncAppWithoutOrderBy, position1149)
+				add(ruleTUPLES, position2050)
 			}
 			return true
-		l1148:
-			position, tokenIndex, depth = position1148, tokenIndex1148, depth1148
+		l2049:
+			position, tokenIndex, depth = position2049, tokenIndex2049, depth2049
 			return false
 		},
-		/* 88 FuncParams <- <(<(ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)?> Action67)> */
+		/* 158 SECONDS <- <(<(('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action119)> */
 		func() bool {
-			position1151, tokenIndex1151, depth1151 := position, tokenIndex, depth
+			position2064, tokenIndex2064, depth2064 := position, tokenIndex, depth
 			{
-				position1152 := position
+				position2065 := position
 				depth++
 				{
-					position1153 := position
+					position2066 := position
 					depth++
 					{
-						position1154, tokenIndex1154, depth1154 := position, tokenIndex, depth
-						if !_rules[ruleExpressionOrWildcard]() {
-							goto l1154
+						position2067, tokenIndex2067, depth2067 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2068
 						}
-					l1156:
-						{
-							position1157, tokenIndex1157, depth1157 := position, tokenIndex, depth
-							if !_rules[rulespOpt]() {
-								goto l1157
-							}
-							if buffer[position] != rune(',') {
-								goto l1157
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l1157
-							}
-							if !_rules[ruleExpressionOrWildcard]() {
-								goto l1157
-							}
-							goto l1156
-						l1157:
-							position, tokenIndex, depth = position1157, tokenIndex1157, depth1157
+						position++
+						goto l2067
+					l2068:
+						position, tokenIndex, depth = position2067, tokenIndex2067, depth2067
+						if buffer[position] != rune('S') {
+							goto l2064
+						}
+						position++
+					}
+				l2067:
+					{
+						position2069, tokenIndex2069, depth2069 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2070
+						}
+						position++
+						goto l2069
+					l2070:
+						position, tokenIndex, depth = position2069, tokenIndex2069, depth2069
+						if buffer[position] != rune('E') {
+							goto l2064
+						}
+						position++
+					}
+				l2069:
+					{
+						position2071, tokenIndex2071, depth2071 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2072
+						}
+						position++
+						goto l2071
+					l2072:
+						position, tokenIndex, depth = position2071, tokenIndex2071, depth2071
+						if buffer[position] != rune('C') {
+							goto l2064
+						}
+						position++
+					}
+				l2071:
+					{
+						position2073, tokenIndex2073, depth2073 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2074
+						}
+						position++
+						goto l2073
+					l2074:
+						position, tokenIndex, depth = position2073, tokenIndex2073, depth2073
+						if buffer[position] != rune('O') {
+							goto l2064
+						}
+						position++
+					}
+				l2073:
+					{
+						position2075, tokenIndex2075, depth2075 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2076
+						}
+						position++
+						goto l2075
+					l2076:
+						position, tokenIndex, depth = position2075, tokenIndex2075, depth2075
+						if buffer[position] != rune('N') {
+							goto l2064
+						}
+						position++
+					}
+				l2075:
+					{
+						position2077, tokenIndex2077, depth2077 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2078
+						}
+						position++
+						goto l2077
+					l2078:
+						position, tokenIndex, depth = position2077, tokenIndex2077, depth2077
+						if buffer[position] != rune('D') {
+							goto l2064
+						}
+						position++
+					}
+				l2077:
+					{
+						position2079, tokenIndex2079, depth2079 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2080
 						}
-						goto l1155
-					l1154:
-						position, tokenIndex, depth = position1154, tokenIndex1154, depth1154
+						position++
+						goto l2079
+					l2080:
+						position, tokenIndex, depth = position2079, tokenIndex2079, depth2079
+						if buffer[position] != rune('S') {
+							goto l2064
+						}
+						position++
 					}
-				l1155:
+				l2079:
 					depth--
-					add(rulePegText, position1153)
+					add(rulePegText, position2066)
 				}
-				if !_rules[ruleAction67]() {
-					goto l1151
+				if !_rules[ruleAction119]() {
+					goto l2064
 				}
 				depth--
-				add(ruleFuncParams, position1152)
+				add(ruleSECONDS, position2065)
 			}
 			return true
-		l1151:
-			position, tokenIndex, depth = position1151, tokenIndex1151, depth1151
+		l2064:
+			position, tokenIndex, depth = position2064, tokenIndex2064, depth2064
 			return false
 		},
-		/* 89 ParamsOrder <- <(<(('o' / 'O') ('r' / 'R') ('d' / 'D') ('e' / 'E') ('r' / 'R') sp (('b' / 'B') ('y' / 'Y')) sp SortedExpression (spOpt ',' spOpt SortedExpression)*)> Action68)> */
+		/* 159 MILLISECONDS <- <(<(('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action120)> */
 		func() bool {
-			position1158, tokenIndex1158, depth1158 := position, tokenIndex, depth
+			position2081, tokenIndex2081, depth2081 := position, tokenIndex, depth
 			{
-				position1159 := position
+				position2082 := position
 				depth++
 				{
-					position1160 := position
+					position2083 := position
 					depth++
 					{
-						position1161, tokenIndex1161, depth1161 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1162
+						position2084, tokenIndex2084, depth2084 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2085
 						}
 						position++
-						goto l1161
-					l1162:
-						position, tokenIndex, depth = position1161, tokenIndex1161, depth1161
-						if buffer[position] != rune('O') {
-							goto l1158
+						goto l2084
+					l2085:
+						position, tokenIndex, depth = position2084, tokenIndex2084, depth2084
+						if buffer[position] != rune('M') {
+							goto l2081
 						}
 						position++
 					}
-				l1161:
+				l2084:
 					{
-						position1163, tokenIndex1163, depth1163 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1164
+						position2086, tokenIndex2086, depth2086 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2087
+						}
+						position++
+						goto l2086
+					l2087:
+						position, tokenIndex, depth = position2086, tokenIndex2086, depth2086
+						if buffer[position] != rune('I') {
+							goto l2081
+						}
+						position++
+					}
+				l2086:
+					{
+						position2088, tokenIndex2088, depth2088 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2089
+						}
+						position++
+						goto l2088
+					l2089:
+						position, tokenIndex, depth = position2088, tokenIndex2088, depth2088
+						if buffer[position] != rune('L') {
+							goto l2081
+						}
+						position++
+					}
+				l2088:
+					{
+						position2090, tokenIndex2090, depth2090 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2091
+						}
+						position++
+						goto l2090
+					l2091:
+						position, tokenIndex, depth = position2090, tokenIndex2090, depth2090
+						if buffer[position] != rune('L') {
+							goto l2081
+						}
+						position++
+					}
+				l2090:
+					{
+						position2092, tokenIndex2092, depth2092 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2093
 						}
 						position++
-						goto l1163
-					l1164:
-						position, tokenIndex, depth = position1163, tokenIndex1163, depth1163
-						if buffer[position] != rune('R') {
-							goto l1158
+						goto l2092
+					l2093:
+						position, tokenIndex, depth = position2092, tokenIndex2092, depth2092
+						if buffer[position] != rune('I') {
+							goto l2081
 						}
 						position++
 					}
-				l1163:
+				l2092:
 					{
-						position1165, tokenIndex1165, depth1165 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1166
+						position2094, tokenIndex2094, depth2094 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2095
 						}
 						position++
-						goto l1165
-					l1166:
-						position, tokenIndex, depth = position1165, tokenIndex1165, depth1165
-						if buffer[position] != rune('D') {
-							goto l1158
+						goto l2094
+					l2095:
+						position, tokenIndex, depth = position2094, tokenIndex2094, depth2094
+						if buffer[position] != rune('S') {
+							goto l2081
 						}
 						position++
 					}
-				l1165:
+				l2094:
 					{
-						position1167, tokenIndex1167, depth1167 := position, tokenIndex, depth
+						position2096, tokenIndex2096, depth2096 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l1168
+							goto l2097
 						}
 						position++
-						goto l1167
-					l1168:
-						position, tokenIndex, depth = position1167, tokenIndex1167, depth1167
+						goto l2096
+					l2097:
+						position, tokenIndex, depth = position2096, tokenIndex2096, depth2096
 						if buffer[position] != rune('E') {
-							goto l1158
+							goto l2081
 						}
 						position++
 					}
-				l1167:
+				l2096:
 					{
-						position1169, tokenIndex1169, depth1169 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1170
+						position2098, tokenIndex2098, depth2098 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2099
 						}
 						position++
-						goto l1169
-					l1170:
-						position, tokenIndex, depth = position1169, tokenIndex1169, depth1169
-						if buffer[position] != rune('R') {
-							goto l1158
+						goto l2098
+					l2099:
+						position, tokenIndex, depth = position2098, tokenIndex2098, depth2098
+						if buffer[position] != rune('C') {
+							goto l2081
 						}
 						position++
 					}
-				l1169:
-					if !_rules[rulesp]() {
-						goto l1158
-					}
+				l2098:
 					{
-						position1171, tokenIndex1171, depth1171 := position, tokenIndex, depth
-						if buffer[position] != rune('b') {
-							goto l1172
+						position2100, tokenIndex2100, depth2100 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2101
 						}
 						position++
-						goto l1171
-					l1172:
-						position, tokenIndex, depth = position1171, tokenIndex1171, depth1171
-						if buffer[position] != rune('B') {
-							goto l1158
+						goto l2100
+					l2101:
+						position, tokenIndex, depth = position2100, tokenIndex2100, depth2100
+						if buffer[position] != rune('O') {
+							goto l2081
 						}
 						position++
 					}
-				l1171:
+				l2100:
 					{
-						position1173, tokenIndex1173, depth1173 := position, tokenIndex, depth
-						if buffer[position] != rune('y') {
-							goto l1174
+						position2102, tokenIndex2102, depth2102 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2103
 						}
 						position++
-						goto l1173
-					l1174:
-						position, tokenIndex, depth = position1173, tokenIndex1173, depth1173
-						if buffer[position] != rune('Y') {
-							goto l1158
+						goto l2102
+					l2103:
+						position, tokenIndex, depth = position2102, tokenIndex2102, depth2102
+						if buffer[position] != rune('N') {
+							goto l2081
 						}
 						position++
 					}
-				l1173:
-					if !_rules[rulesp]() {
-						goto l1158
-					}
-					if !_rules[ruleSortedExpression]() {
-						goto l1158
-					}
-				l1175:
+				l2102:
 					{
-						position1176, tokenIndex1176, depth1176 := position, tokenIndex, depth
-						if !_rules[rulespOpt]() {
-							goto l1176
-						}
-						if buffer[position] != rune(',') {
-							goto l1176
+						position2104, tokenIndex2104, depth2104 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2105
 						}
 						position++
-						if !_rules[rulespOpt]() {
-							goto l1176
-						}
-						if !_rules[ruleSortedExpression]() {
-							goto l1176
+						goto l2104
+					l2105:
+						position, tokenIndex, depth = position2104, tokenIndex2104, depth2104
+						if buffer[position] != rune('D') {
+							goto l2081
 						}
-						goto l1175
-					l1176:
-						position, tokenIndex, depth = position1176, tokenIndex1176, depth1176
+						position++
 					}
-					depth--
-					add(rulePegText, position1160)
-				}
-				if !_rules[ruleAction68]() {
-					goto l1158
-				}
-				depth--
-				add(ruleParamsOrder, position1159)
-			}
-			return true
-		l1158:
-			position, tokenIndex, depth = position1158, tokenIndex1158, depth1158
-			return false
-		},
-		/* 90 SortedExpression <- <(Expression OrderDirectionOpt Action69)> */
-		func() bool {
-			position1177, tokenIndex1177, depth1177 := position, tokenIndex, depth
-			{
-				position1178 := position
-				depth++
-				if !_rules[ruleExpression]() {
-					goto l1177
-				}
-				if !_rules[ruleOrderDirectionOpt]() {
-					goto l1177
-				}
-				if !_rules[ruleAction69]() {
-					goto l1177
-				}
-				depth--
-				add(ruleSortedExpression, position1178)
-			}
-			return true
-		l1177:
-			position, tokenIndex, depth = position1177, tokenIndex1177, depth1177
-			return false
-		},
-		/* 91 OrderDirectionOpt <- <(<(sp (Ascending / Descending))?> Action70)> */
-		func() bool {
-			position1179, tokenIndex1179, depth1179 := position, tokenIndex, depth
-			{
-				position1180 := position
-				depth++
-				{
-					position1181 := position
-					depth++
+				l2104:
 					{
-						position1182, tokenIndex1182, depth1182 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1182
+						position2106, tokenIndex2106, depth2106 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2107
 						}
-						{
-							position1184, tokenIndex1184, depth1184 := position, tokenIndex, depth
-							if !_rules[ruleAscending]() {
-								goto l1185
-							}
-							goto l1184
-						l1185:
-							position, tokenIndex, depth = position1184, tokenIndex1184, depth1184
-							if !_rules[ruleDescending]() {
-								goto l1182
-							}
+						position++
+						goto l2106
+					l2107:
+						position, tokenIndex, depth = position2106, tokenIndex2106, depth2106
+						if buffer[position] != rune('S') {
+							goto l2081
 						}
-					l1184:
-						goto l1183
-					l1182:
-						position, tokenIndex, depth = position1182, tokenIndex1182, depth1182
+						position++
 					}
-				l1183:
+				l2106:
 					depth--
-					add(rulePegText, position1181)
+					add(rulePegText, position2083)
 				}
-				if !_rules[ruleAction70]() {
-					goto l1179
+				if !_rules[ruleAction120]() {
+					goto l2081
 				}
 				depth--
-				add(ruleOrderDirectionOpt, position1180)
+				add(ruleMILLISECONDS, position2082)
 			}
 			return true
-		l1179:
-			position, tokenIndex, depth = position1179, tokenIndex1179, depth1179
+		l2081:
+			position, tokenIndex, depth = position2081, tokenIndex2081, depth2081
 			return false
 		},
-		/* 92 ArrayExpr <- <(<('[' spOpt (ExpressionOrWildcard (spOpt ',' spOpt ExpressionOrWildcard)*)? spOpt ','? spOpt ']')> Action71)> */
+		/* 160 Wait <- <(<(('w' / 'W') ('a' / 'A') ('i' / 'I') ('t' / 'T'))> Action121)> */
 		func() bool {
-			position1186, tokenIndex1186, depth1186 := position, tokenIndex, depth
+			position2108, tokenIndex2108, depth2108 := position, tokenIndex, depth
 			{
-				position1187 := position
+				position2109 := position
 				depth++
 				{
-					position1188 := position
+					position2110 := position
 					depth++
-					if buffer[position] != rune('[') {
-						goto l1186
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1186
-					}
 					{
-						position1189, tokenIndex1189, depth1189 := position, tokenIndex, depth
-						if !_rules[ruleExpressionOrWildcard]() {
-							goto l1189
+						position2111, tokenIndex2111, depth2111 := position, tokenIndex, depth
+						if buffer[position] != rune('w') {
+							goto l2112
 						}
-					l1191:
-						{
-							position1192, tokenIndex1192, depth1192 := position, tokenIndex, depth
-							if !_rules[rulespOpt]() {
-								goto l1192
-							}
-							if buffer[position] != rune(',') {
-								goto l1192
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l1192
-							}
-							if !_rules[ruleExpressionOrWildcard]() {
-								goto l1192
-							}
-							goto l1191
-						l1192:
-							position, tokenIndex, depth = position1192, tokenIndex1192, depth1192
+						position++
+						goto l2111
+					l2112:
+						position, tokenIndex, depth = position2111, tokenIndex2111, depth2111
+						if buffer[position] != rune('W') {
+							goto l2108
 						}
-						goto l1190
-					l1189:
-						position, tokenIndex, depth = position1189, tokenIndex1189, depth1189
-					}
-				l1190:
-					if !_rules[rulespOpt]() {
-						goto l1186
+						position++
 					}
+				l2111:
 					{
-						position1193, tokenIndex1193, depth1193 := position, tokenIndex, depth
-						if buffer[position] != rune(',') {
-							goto l1193
+						position2113, tokenIndex2113, depth2113 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2114
+						}
+						position++
+						goto l2113
+					l2114:
+						position, tokenIndex, depth = position2113, tokenIndex2113, depth2113
+						if buffer[position] != rune('A') {
+							goto l2108
 						}
 						position++
-						goto l1194
-					l1193:
-						position, tokenIndex, depth = position1193, tokenIndex1193, depth1193
-					}
-				l1194:
-					if !_rules[rulespOpt]() {
-						goto l1186
-					}
-					if buffer[position] != rune(']') {
-						goto l1186
-					}
-					position++
-					depth--
-					add(rulePegText, position1188)
-				}
-				if !_rules[ruleAction71]() {
-					goto l1186
-				}
-				depth--
-				add(ruleArrayExpr, position1187)
-			}
-			return true
-		l1186:
-			position, tokenIndex, depth = position1186, tokenIndex1186, depth1186
-			return false
-		},
-		/* 93 MapExpr <- <(<('{' spOpt (KeyValuePair (spOpt ',' spOpt KeyValuePair)*)? spOpt '}')> Action72)> */
-		func() bool {
-			position1195, tokenIndex1195, depth1195 := position, tokenIndex, depth
-			{
-				position1196 := position
-				depth++
-				{
-					position1197 := position
-					depth++
-					if buffer[position] != rune('{') {
-						goto l1195
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1195
 					}
+				l2113:
 					{
-						position1198, tokenIndex1198, depth1198 := position, tokenIndex, depth
-						if !_rules[ruleKeyValuePair]() {
-							goto l1198
+						position2115, tokenIndex2115, depth2115 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2116
 						}
-					l1200:
-						{
-							position1201, tokenIndex1201, depth1201 := position, tokenIndex, depth
-							if !_rules[rulespOpt]() {
-								goto l1201
-							}
-							if buffer[position] != rune(',') {
-								goto l1201
-							}
-							position++
-							if !_rules[rulespOpt]() {
-								goto l1201
-							}
-							if !_rules[ruleKeyValuePair]() {
-								goto l1201
-							}
-							goto l1200
-						l1201:
-							position, tokenIndex, depth = position1201, tokenIndex1201, depth1201
+						position++
+						goto l2115
+					l2116:
+						position, tokenIndex, depth = position2115, tokenIndex2115, depth2115
+						if buffer[position] != rune('I') {
+							goto l2108
 						}
-						goto l1199
-					l1198:
-						position, tokenIndex, depth = position1198, tokenIndex1198, depth1198
-					}
-				l1199:
-					if !_rules[rulespOpt]() {
-						goto l1195
-					}
-					if buffer[position] != rune('}') {
-						goto l1195
-					}
-					position++
-					depth--
-					add(rulePegText, position1197)
-				}
-				if !_rules[ruleAction72]() {
-					goto l1195
-				}
-				depth--
-				add(ruleMapExpr, position1196)
-			}
-			return true
-		l1195:
-			position, tokenIndex, depth = position1195, tokenIndex1195, depth1195
-			return false
-		},
-		/* 94 KeyValuePair <- <(<(StringLiteral spOpt ':' spOpt ExpressionOrWildcard)> Action73)> */
-		func() bool {
-			position1202, tokenIndex1202, depth1202 := position, tokenIndex, depth
-			{
-				position1203 := position
-				depth++
-				{
-					position1204 := position
-					depth++
-					if !_rules[ruleStringLiteral]() {
-						goto l1202
-					}
-					if !_rules[rulespOpt]() {
-						goto l1202
-					}
-					if buffer[position] != rune(':') {
-						goto l1202
-					}
-					position++
-					if !_rules[rulespOpt]() {
-						goto l1202
-					}
-					if !_rules[ruleExpressionOrWildcard]() {
-						goto l1202
-					}
-					depth--
-					add(rulePegText, position1204)
-				}
-				if !_rules[ruleAction73]() {
-					goto l1202
-				}
-				depth--
-				add(ruleKeyValuePair, position1203)
-			}
-			return true
-		l1202:
-			position, tokenIndex, depth = position1202, tokenIndex1202, depth1202
-			return false
-		},
-		/* 95 Case <- <(ConditionCase / ExpressionCase)> */
-		func() bool {
-			position1205, tokenIndex1205, depth1205 := position, tokenIndex, depth
-			{
-				position1206 := position
-				depth++
-				{
-					position1207, tokenIndex1207, depth1207 := position, tokenIndex, depth
-					if !_rules[ruleConditionCase]() {
-						goto l1208
-					}
-					goto l1207
-				l1208:
-					position, tokenIndex, depth = position1207, tokenIndex1207, depth1207
-					if !_rules[ruleExpressionCase]() {
-						goto l1205
-					}
-				}
-			l1207:
-				depth--
-				add(ruleCase, position1206)
-			}
-			return true
-		l1205:
-			position, tokenIndex, depth = position1205, tokenIndex1205, depth1205
-			return false
-		},
-		/* 96 ConditionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action74)> */
-		func() bool {
-			position1209, tokenIndex1209, depth1209 := position, tokenIndex, depth
-			{
-				position1210 := position
-				depth++
-				{
-					position1211, tokenIndex1211, depth1211 := position, tokenIndex, depth
-					if buffer[position] != rune('c') {
-						goto l1212
-					}
-					position++
-					goto l1211
-				l1212:
-					position, tokenIndex, depth = position1211, tokenIndex1211, depth1211
-					if buffer[position] != rune('C') {
-						goto l1209
-					}
-					position++
-				}
-			l1211:
-				{
-					position1213, tokenIndex1213, depth1213 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l1214
-					}
-					position++
-					goto l1213
-				l1214:
-					position, tokenIndex, depth = position1213, tokenIndex1213, depth1213
-					if buffer[position] != rune('A') {
-						goto l1209
-					}
-					position++
-				}
-			l1213:
-				{
-					position1215, tokenIndex1215, depth1215 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l1216
-					}
-					position++
-					goto l1215
-				l1216:
-					position, tokenIndex, depth = position1215, tokenIndex1215, depth1215
-					if buffer[position] != rune('S') {
-						goto l1209
-					}
-					position++
-				}
-			l1215:
-				{
-					position1217, tokenIndex1217, depth1217 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l1218
+						position++
 					}
-					position++
-					goto l1217
-				l1218:
-					position, tokenIndex, depth = position1217, tokenIndex1217, depth1217
-					if buffer[position] != rune('E') {
-						goto l1209
+				l2115:
+					{
+						position2117, tokenIndex2117, depth2117 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2118
+						}
+						position++
+						goto l2117
+					l2118:
+						position, tokenIndex, depth = position2117, tokenIndex2117, depth2117
+						if buffer[position] != rune('T') {
+							goto l2108
+						}
+						position++
 					}
-					position++
+				l2117:
+					depth--
+					add(rulePegText, position2110)
+				}
+				if !_rules[ruleAction121]() {
+					goto l2108
 				}
-			l1217:
+				depth--
+				add(ruleWait, position2109)
+			}
+			return true
+		l2108:
+			position, tokenIndex, depth = position2108, tokenIndex2108, depth2108
+			return false
+		},
+		/* 161 DropOldest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('o' / 'O') ('l' / 'L') ('d' / 'D') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action122)> */
+		func() bool {
+			position2119, tokenIndex2119, depth2119 := position, tokenIndex, depth
+			{
+				position2120 := position
+				depth++
 				{
-					position1219 := position
+					position2121 := position
 					depth++
-					if !_rules[rulesp]() {
-						goto l1209
-					}
-					if !_rules[ruleWhenThenPair]() {
-						goto l1209
+					{
+						position2122, tokenIndex2122, depth2122 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2123
+						}
+						position++
+						goto l2122
+					l2123:
+						position, tokenIndex, depth = position2122, tokenIndex2122, depth2122
+						if buffer[position] != rune('D') {
+							goto l2119
+						}
+						position++
 					}
-				l1220:
+				l2122:
 					{
-						position1221, tokenIndex1221, depth1221 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1221
+						position2124, tokenIndex2124, depth2124 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2125
 						}
-						if !_rules[ruleWhenThenPair]() {
-							goto l1221
+						position++
+						goto l2124
+					l2125:
+						position, tokenIndex, depth = position2124, tokenIndex2124, depth2124
+						if buffer[position] != rune('R') {
+							goto l2119
 						}
-						goto l1220
-					l1221:
-						position, tokenIndex, depth = position1221, tokenIndex1221, depth1221
+						position++
 					}
+				l2124:
 					{
-						position1222, tokenIndex1222, depth1222 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1222
+						position2126, tokenIndex2126, depth2126 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2127
 						}
-						{
-							position1224, tokenIndex1224, depth1224 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l1225
-							}
-							position++
-							goto l1224
-						l1225:
-							position, tokenIndex, depth = position1224, tokenIndex1224, depth1224
-							if buffer[position] != rune('E') {
-								goto l1222
-							}
-							position++
+						position++
+						goto l2126
+					l2127:
+						position, tokenIndex, depth = position2126, tokenIndex2126, depth2126
+						if buffer[position] != rune('O') {
+							goto l2119
 						}
-					l1224:
-						{
-							position1226, tokenIndex1226, depth1226 := position, tokenIndex, depth
-							if buffer[position] != rune('l') {
-								goto l1227
-							}
-							position++
-							goto l1226
-						l1227:
-							position, tokenIndex, depth = position1226, tokenIndex1226, depth1226
-							if buffer[position] != rune('L') {
-								goto l1222
-							}
-							position++
+						position++
+					}
+				l2126:
+					{
+						position2128, tokenIndex2128, depth2128 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2129
 						}
-					l1226:
-						{
-							position1228, tokenIndex1228, depth1228 := position, tokenIndex, depth
-							if buffer[position] != rune('s') {
-								goto l1229
-							}
-							position++
-							goto l1228
-						l1229:
-							position, tokenIndex, depth = position1228, tokenIndex1228, depth1228
-							if buffer[position] != rune('S') {
-								goto l1222
-							}
-							position++
+						position++
+						goto l2128
+					l2129:
+						position, tokenIndex, depth = position2128, tokenIndex2128, depth2128
+						if buffer[position] != rune('P') {
+							goto l2119
 						}
-					l1228:
-						{
-							position1230, tokenIndex1230, depth1230 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l1231
-							}
-							position++
-							goto l1230
-						l1231:
-							position, tokenIndex, depth = position1230, tokenIndex1230, depth1230
-							if buffer[position] != rune('E') {
-								goto l1222
-							}
-							position++
+						position++
+					}
+				l2128:
+					if !_rules[rulesp]() {
+						goto l2119
+					}
+					{
+						position2130, tokenIndex2130, depth2130 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2131
 						}
-					l1230:
-						if !_rules[rulesp]() {
-							goto l1222
+						position++
+						goto l2130
+					l2131:
+						position, tokenIndex, depth = position2130, tokenIndex2130, depth2130
+						if buffer[position] != rune('O') {
+							goto l2119
 						}
-						if !_rules[ruleExpression]() {
-							goto l1222
+						position++
+					}
+				l2130:
+					{
+						position2132, tokenIndex2132, depth2132 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2133
+						}
+						position++
+						goto l2132
+					l2133:
+						position, tokenIndex, depth = position2132, tokenIndex2132, depth2132
+						if buffer[position] != rune('L') {
+							goto l2119
 						}
-						goto l1223
-					l1222:
-						position, tokenIndex, depth = position1222, tokenIndex1222, depth1222
+						position++
 					}
-				l1223:
-					if !_rules[rulesp]() {
-						goto l1209
+				l2132:
+					{
+						position2134, tokenIndex2134, depth2134 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2135
+						}
+						position++
+						goto l2134
+					l2135:
+						position, tokenIndex, depth = position2134, tokenIndex2134, depth2134
+						if buffer[position] != rune('D') {
+							goto l2119
+						}
+						position++
 					}
+				l2134:
 					{
-						position1232, tokenIndex1232, depth1232 := position, tokenIndex, depth
+						position2136, tokenIndex2136, depth2136 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l1233
+							goto l2137
 						}
 						position++
-						goto l1232
-					l1233:
-						position, tokenIndex, depth = position1232, tokenIndex1232, depth1232
+						goto l2136
+					l2137:
+						position, tokenIndex, depth = position2136, tokenIndex2136, depth2136
 						if buffer[position] != rune('E') {
-							goto l1209
+							goto l2119
 						}
 						position++
 					}
-				l1232:
+				l2136:
 					{
-						position1234, tokenIndex1234, depth1234 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1235
+						position2138, tokenIndex2138, depth2138 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2139
 						}
 						position++
-						goto l1234
-					l1235:
-						position, tokenIndex, depth = position1234, tokenIndex1234, depth1234
-						if buffer[position] != rune('N') {
-							goto l1209
+						goto l2138
+					l2139:
+						position, tokenIndex, depth = position2138, tokenIndex2138, depth2138
+						if buffer[position] != rune('S') {
+							goto l2119
 						}
 						position++
 					}
-				l1234:
+				l2138:
 					{
-						position1236, tokenIndex1236, depth1236 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1237
+						position2140, tokenIndex2140, depth2140 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2141
 						}
 						position++
-						goto l1236
-					l1237:
-						position, tokenIndex, depth = position1236, tokenIndex1236, depth1236
-						if buffer[position] != rune('D') {
-							goto l1209
+						goto l2140
+					l2141:
+						position, tokenIndex, depth = position2140, tokenIndex2140, depth2140
+						if buffer[position] != rune('T') {
+							goto l2119
 						}
 						position++
 					}
-				l1236:
+				l2140:
 					depth--
-					add(rulePegText, position1219)
+					add(rulePegText, position2121)
 				}
-				if !_rules[ruleAction74]() {
-					goto l1209
+				if !_rules[ruleAction122]() {
+					goto l2119
 				}
 				depth--
-				add(ruleConditionCase, position1210)
+				add(ruleDropOldest, position2120)
 			}
 			return true
-		l1209:
-			position, tokenIndex, depth = position1209, tokenIndex1209, depth1209
+		l2119:
+			position, tokenIndex, depth = position2119, tokenIndex2119, depth2119
 			return false
 		},
-		/* 97 ExpressionCase <- <(('c' / 'C') ('a' / 'A') ('s' / 'S') ('e' / 'E') sp Expression <((sp WhenThenPair)+ (sp (('e' / 'E') ('l' / 'L') ('s' / 'S') ('e' / 'E')) sp Expression)? sp (('e' / 'E') ('n' / 'N') ('d' / 'D')))> Action75)> */
+		/* 162 DropNewest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('n' / 'N') ('e' / 'E') ('w' / 'W') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action123)> */
 		func() bool {
-			position1238, tokenIndex1238, depth1238 := position, tokenIndex, depth
+			position2142, tokenIndex2142, depth2142 := position, tokenIndex, depth
 			{
-				position1239 := position
+				position2143 := position
 				depth++
 				{
-					position1240, tokenIndex1240, depth1240 := position, tokenIndex, depth
-					if buffer[position] != rune('c') {
-						goto l1241
-					}
-					position++
-					goto l1240
-				l1241:
-					position, tokenIndex, depth = position1240, tokenIndex1240, depth1240
-					if buffer[position] != rune('C') {
-						goto l1238
-					}
-					position++
-				}
-			l1240:
-				{
-					position1242, tokenIndex1242, depth1242 := position, tokenIndex, depth
-					if buffer[position] != rune('a') {
-						goto l1243
+					position2144 := position
+					depth++
+					{
+						position2145, tokenIndex2145, depth2145 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2146
+						}
+						position++
+						goto l2145
+					l2146:
+						position, tokenIndex, depth = position2145, tokenIndex2145, depth2145
+						if buffer[position] != rune('D') {
+							goto l2142
+						}
+						position++
 					}
-					position++
-					goto l1242
-				l1243:
-					position, tokenIndex, depth = position1242, tokenIndex1242, depth1242
-					if buffer[position] != rune('A') {
-						goto l1238
+				l2145:
+					{
+						position2147, tokenIndex2147, depth2147 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2148
+						}
+						position++
+						goto l2147
+					l2148:
+						position, tokenIndex, depth = position2147, tokenIndex2147, depth2147
+						if buffer[position] != rune('R') {
+							goto l2142
+						}
+						position++
 					}
-					position++
-				}
-			l1242:
-				{
-					position1244, tokenIndex1244, depth1244 := position, tokenIndex, depth
-					if buffer[position] != rune('s') {
-						goto l1245
+				l2147:
+					{
+						position2149, tokenIndex2149, depth2149 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2150
+						}
+						position++
+						goto l2149
+					l2150:
+						position, tokenIndex, depth = position2149, tokenIndex2149, depth2149
+						if buffer[position] != rune('O') {
+							goto l2142
+						}
+						position++
 					}
-					position++
-					goto l1244
-				l1245:
-					position, tokenIndex, depth = position1244, tokenIndex1244, depth1244
-					if buffer[position] != rune('S') {
-						goto l1238
+				l2149:
+					{
+						position2151, tokenIndex2151, depth2151 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2152
+						}
+						position++
+						goto l2151
+					l2152:
+						position, tokenIndex, depth = position2151, tokenIndex2151, depth2151
+						if buffer[position] != rune('P') {
+							goto l2142
+						}
+						position++
 					}
-					position++
-				}
-			l1244:
-				{
-					position1246, tokenIndex1246, depth1246 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l1247
+				l2151:
+					if !_rules[rulesp]() {
+						goto l2142
 					}
-					position++
-					goto l1246
-				l1247:
-					position, tokenIndex, depth = position1246, tokenIndex1246, depth1246
-					if buffer[position] != rune('E') {
-						goto l1238
+					{
+						position2153, tokenIndex2153, depth2153 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2154
+						}
+						position++
+						goto l2153
+					l2154:
+						position, tokenIndex, depth = position2153, tokenIndex2153, depth2153
+						if buffer[position] != rune('N') {
+							goto l2142
+						}
+						position++
 					}
-					position++
-				}
-			l1246:
-				if !_rules[rulesp]() {
-					goto l1238
-				}
-				if !_rules[ruleExpression]() {
-					goto l1238
-				}
-				{
-					position1248 := position
-					depth++
-					if !_rules[rulesp]() {
-						goto l1238
+				l2153:
+					{
+						position2155, tokenIndex2155, depth2155 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2156
+						}
+						position++
+						goto l2155
+					l2156:
+						position, tokenIndex, depth = position2155, tokenIndex2155, depth2155
+						if buffer[position] != rune('E') {
+							goto l2142
+						}
+						position++
 					}
-					if !_rules[ruleWhenThenPair]() {
-						goto l1238
+				l2155:
+					{
+						position2157, tokenIndex2157, depth2157 := position, tokenIndex, depth
+						if buffer[position] != rune('w') {
+							goto l2158
+						}
+						position++
+						goto l2157
+					l2158:
+						position, tokenIndex, depth = position2157, tokenIndex2157, depth2157
+						if buffer[position] != rune('W') {
+							goto l2142
+						}
+						position++
 					}
-				l1249:
+				l2157:
 					{
-						position1250, tokenIndex1250, depth1250 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1250
+						position2159, tokenIndex2159, depth2159 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2160
 						}
-						if !_rules[ruleWhenThenPair]() {
-							goto l1250
+						position++
+						goto l2159
+					l2160:
+						position, tokenIndex, depth = position2159, tokenIndex2159, depth2159
+						if buffer[position] != rune('E') {
+							goto l2142
 						}
-						goto l1249
-					l1250:
-						position, tokenIndex, depth = position1250, tokenIndex1250, depth1250
+						position++
 					}
+				l2159:
 					{
-						position1251, tokenIndex1251, depth1251 := position, tokenIndex, depth
-						if !_rules[rulesp]() {
-							goto l1251
+						position2161, tokenIndex2161, depth2161 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2162
 						}
-						{
-							position1253, tokenIndex1253, depth1253 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l1254
-							}
-							position++
-							goto l1253
-						l1254:
-							position, tokenIndex, depth = position1253, tokenIndex1253, depth1253
-							if buffer[position] != rune('E') {
-								goto l1251
-							}
-							position++
+						position++
+						goto l2161
+					l2162:
+						position, tokenIndex, depth = position2161, tokenIndex2161, depth2161
+						if buffer[position] != rune('S') {
+							goto l2142
 						}
-					l1253:
-						{
-							position1255, tokenIndex1255, depth1255 := position, tokenIndex, depth
-							if buffer[position] != rune('l') {
-								goto l1256
-							}
-							position++
-							goto l1255
-						l1256:
-							position, tokenIndex, depth = position1255, tokenIndex1255, depth1255
-							if buffer[position] != rune('L') {
-								goto l1251
-							}
-							position++
+						position++
+					}
+				l2161:
+					{
+						position2163, tokenIndex2163, depth2163 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2164
 						}
-					l1255:
-						{
-							position1257, tokenIndex1257, depth1257 := position, tokenIndex, depth
-							if buffer[position] != rune('s') {
-								goto l1258
-							}
-							position++
-							goto l1257
-						l1258:
-							position, tokenIndex, depth = position1257, tokenIndex1257, depth1257
-							if buffer[position] != rune('S') {
-								goto l1251
-							}
-							position++
+						position++
+						goto l2163
+					l2164:
+						position, tokenIndex, depth = position2163, tokenIndex2163, depth2163
+						if buffer[position] != rune('T') {
+							goto l2142
+						}
+						position++
+					}
+				l2163:
+					depth--
+					add(rulePegText, position2144)
+				}
+				if !_rules[ruleAction123]() {
+					goto l2142
+				}
+				depth--
+				add(ruleDropNewest, position2143)
+			}
+			return true
+		l2142:
+			position, tokenIndex, depth = position2142, tokenIndex2142, depth2142
+			return false
+		},
+		/* 163 ChannelPipe <- <(<(('c' / 'C') ('h' / 'H') ('a' / 'A') ('n' / 'N') ('n' / 'N') ('e' / 'E') ('l' / 'L'))> Action124)> */
+		func() bool {
+			position2165, tokenIndex2165, depth2165 := position, tokenIndex, depth
+			{
+				position2166 := position
+				depth++
+				{
+					position2167 := position
+					depth++
+					{
+						position2168, tokenIndex2168, depth2168 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2169
 						}
-					l1257:
-						{
-							position1259, tokenIndex1259, depth1259 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l1260
-							}
-							position++
-							goto l1259
-						l1260:
-							position, tokenIndex, depth = position1259, tokenIndex1259, depth1259
-							if buffer[position] != rune('E') {
-								goto l1251
-							}
-							position++
+						position++
+						goto l2168
+					l2169:
+						position, tokenIndex, depth = position2168, tokenIndex2168, depth2168
+						if buffer[position] != rune('C') {
+							goto l2165
 						}
-					l1259:
-						if !_rules[rulesp]() {
-							goto l1251
+						position++
+					}
+				l2168:
+					{
+						position2170, tokenIndex2170, depth2170 := position, tokenIndex, depth
+						if buffer[position] != rune('h') {
+							goto l2171
 						}
-						if !_rules[ruleExpression]() {
-							goto l1251
+						position++
+						goto l2170
+					l2171:
+						position, tokenIndex, depth = position2170, tokenIndex2170, depth2170
+						if buffer[position] != rune('H') {
+							goto l2165
 						}
-						goto l1252
-					l1251:
-						position, tokenIndex, depth = position1251, tokenIndex1251, depth1251
+						position++
 					}
-				l1252:
-					if !_rules[rulesp]() {
-						goto l1238
+				l2170:
+					{
+						position2172, tokenIndex2172, depth2172 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2173
+						}
+						position++
+						goto l2172
+					l2173:
+						position, tokenIndex, depth = position2172, tokenIndex2172, depth2172
+						if buffer[position] != rune('A') {
+							goto l2165
+						}
+						position++
 					}
+				l2172:
 					{
-						position1261, tokenIndex1261, depth1261 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1262
+						position2174, tokenIndex2174, depth2174 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2175
 						}
 						position++
-						goto l1261
-					l1262:
-						position, tokenIndex, depth = position1261, tokenIndex1261, depth1261
-						if buffer[position] != rune('E') {
-							goto l1238
+						goto l2174
+					l2175:
+						position, tokenIndex, depth = position2174, tokenIndex2174, depth2174
+						if buffer[position] != rune('N') {
+							goto l2165
 						}
 						position++
 					}
-				l1261:
+				l2174:
 					{
-						position1263, tokenIndex1263, depth1263 := position, tokenIndex, depth
+						position2176, tokenIndex2176, depth2176 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l1264
+							goto l2177
 						}
 						position++
-						goto l1263
-					l1264:
-						position, tokenIndex, depth = position1263, tokenIndex1263, depth1263
+						goto l2176
+					l2177:
+						position, tokenIndex, depth = position2176, tokenIndex2176, depth2176
 						if buffer[position] != rune('N') {
-							goto l1238
+							goto l2165
 						}
 						position++
 					}
-				l1263:
+				l2176:
 					{
-						position1265, tokenIndex1265, depth1265 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1266
+						position2178, tokenIndex2178, depth2178 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2179
 						}
 						position++
-						goto l1265
-					l1266:
-						position, tokenIndex, depth = position1265, tokenIndex1265, depth1265
-						if buffer[position] != rune('D') {
-							goto l1238
+						goto l2178
+					l2179:
+						position, tokenIndex, depth = position2178, tokenIndex2178, depth2178
+						if buffer[position] != rune('E') {
+							goto l2165
 						}
 						position++
 					}
-				l1265:
+				l2178:
+					{
+						position2180, tokenIndex2180, depth2180 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2181
+						}
+						position++
+						goto l2180
+					l2181:
+						position, tokenIndex, depth = position2180, tokenIndex2180, depth2180
+						if buffer[position] != rune('L') {
+							goto l2165
+						}
+						position++
+					}
+				l2180:
 					depth--
-					add(rulePegText, position1248)
+					add(rulePegText, position2167)
 				}
-				if !_rules[ruleAction75]() {
-					goto l1238
+				if !_rules[ruleAction124]() {
+					goto l2165
 				}
 				depth--
-				add(ruleExpressionCase, position1239)
+				add(ruleChannelPipe, position2166)
 			}
 			return true
-		l1238:
-			position, tokenIndex, depth = position1238, tokenIndex1238, depth1238
+		l2165:
+			position, tokenIndex, depth = position2165, tokenIndex2165, depth2165
 			return false
 		},
-		/* 98 WhenThenPair <- <(('w' / 'W') ('h' / 'H') ('e' / 'E') ('n' / 'N') sp Expression sp (('t' / 'T') ('h' / 'H') ('e' / 'E') ('n' / 'N')) sp ExpressionOrWildcard Action76)> */
+		/* 164 RingPipe <- <(<(('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action125)> */
 		func() bool {
-			position1267, tokenIndex1267, depth1267 := position, tokenIndex, depth
+			position2182, tokenIndex2182, depth2182 := position, tokenIndex, depth
 			{
-				position1268 := position
+				position2183 := position
 				depth++
 				{
-					position1269, tokenIndex1269, depth1269 := position, tokenIndex, depth
-					if buffer[position] != rune('w') {
-						goto l1270
-					}
-					position++
-					goto l1269
-				l1270:
-					position, tokenIndex, depth = position1269, tokenIndex1269, depth1269
-					if buffer[position] != rune('W') {
-						goto l1267
-					}
-					position++
-				}
-			l1269:
-				{
-					position1271, tokenIndex1271, depth1271 := position, tokenIndex, depth
-					if buffer[position] != rune('h') {
-						goto l1272
-					}
-					position++
-					goto l1271
-				l1272:
-					position, tokenIndex, depth = position1271, tokenIndex1271, depth1271
-					if buffer[position] != rune('H') {
-						goto l1267
-					}
-					position++
-				}
-			l1271:
-				{
-					position1273, tokenIndex1273, depth1273 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l1274
-					}
-					position++
-					goto l1273
-				l1274:
-					position, tokenIndex, depth = position1273, tokenIndex1273, depth1273
-					if buffer[position] != rune('E') {
-						goto l1267
-					}
-					position++
-				}
-			l1273:
-				{
-					position1275, tokenIndex1275, depth1275 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l1276
-					}
-					position++
-					goto l1275
-				l1276:
-					position, tokenIndex, depth = position1275, tokenIndex1275, depth1275
-					if buffer[position] != rune('N') {
-						goto l1267
-					}
-					position++
-				}
-			l1275:
-				if !_rules[rulesp]() {
-					goto l1267
-				}
-				if !_rules[ruleExpression]() {
-					goto l1267
-				}
-				if !_rules[rulesp]() {
-					goto l1267
-				}
-				{
-					position1277, tokenIndex1277, depth1277 := position, tokenIndex, depth
-					if buffer[position] != rune('t') {
-						goto l1278
-					}
-					position++
-					goto l1277
-				l1278:
-					position, tokenIndex, depth = position1277, tokenIndex1277, depth1277
-					if buffer[position] != rune('T') {
-						goto l1267
-					}
-					position++
-				}
-			l1277:
-				{
-					position1279, tokenIndex1279, depth1279 := position, tokenIndex, depth
-					if buffer[position] != rune('h') {
-						goto l1280
-					}
-					position++
-					goto l1279
-				l1280:
-					position, tokenIndex, depth = position1279, tokenIndex1279, depth1279
-					if buffer[position] != rune('H') {
-						goto l1267
-					}
-					position++
-				}
-			l1279:
-				{
-					position1281, tokenIndex1281, depth1281 := position, tokenIndex, depth
-					if buffer[position] != rune('e') {
-						goto l1282
+					position2184 := position
+					depth++
+					{
+						position2185, tokenIndex2185, depth2185 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2186
+						}
+						position++
+						goto l2185
+					l2186:
+						position, tokenIndex, depth = position2185, tokenIndex2185, depth2185
+						if buffer[position] != rune('R') {
+							goto l2182
+						}
+						position++
 					}
-					position++
-					goto l1281
-				l1282:
-					position, tokenIndex, depth = position1281, tokenIndex1281, depth1281
-					if buffer[position] != rune('E') {
-						goto l1267
+				l2185:
+					{
+						position2187, tokenIndex2187, depth2187 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2188
+						}
+						position++
+						goto l2187
+					l2188:
+						position, tokenIndex, depth = position2187, tokenIndex2187, depth2187
+						if buffer[position] != rune('I') {
+							goto l2182
+						}
+						position++
 					}
-					position++
-				}
-			l1281:
-				{
-					position1283, tokenIndex1283, depth1283 := position, tokenIndex, depth
-					if buffer[position] != rune('n') {
-						goto l1284
+				l2187:
+					{
+						position2189, tokenIndex2189, depth2189 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2190
+						}
+						position++
+						goto l2189
+					l2190:
+						position, tokenIndex, depth = position2189, tokenIndex2189, depth2189
+						if buffer[position] != rune('N') {
+							goto l2182
+						}
+						position++
 					}
-					position++
-					goto l1283
-				l1284:
-					position, tokenIndex, depth = position1283, tokenIndex1283, depth1283
-					if buffer[position] != rune('N') {
-						goto l1267
+				l2189:
+					{
+						position2191, tokenIndex2191, depth2191 := position, tokenIndex, depth
+						if buffer[position] != rune('g') {
+							goto l2192
+						}
+						position++
+						goto l2191
+					l2192:
+						position, tokenIndex, depth = position2191, tokenIndex2191, depth2191
+						if buffer[position] != rune('G') {
+							goto l2182
+						}
+						position++
 					}
-					position++
-				}
-			l1283:
-				if !_rules[rulesp]() {
-					goto l1267
-				}
-				if !_rules[ruleExpressionOrWildcard]() {
-					goto l1267
+				l2191:
+					depth--
+					add(rulePegText, position2184)
 				}
-				if !_rules[ruleAction76]() {
-					goto l1267
+				if !_rules[ruleAction125]() {
+					goto l2182
 				}
 				depth--
-				add(ruleWhenThenPair, position1268)
+				add(ruleRingPipe, position2183)
 			}
 			return true
-		l1267:
-			position, tokenIndex, depth = position1267, tokenIndex1267, depth1267
+		l2182:
+			position, tokenIndex, depth = position2182, tokenIndex2182, depth2182
 			return false
 		},
-		/* 99 Literal <- <(FloatLiteral / NumericLiteral / StringLiteral)> */
+		/* 165 StreamIdentifier <- <(<ident> Action126)> */
 		func() bool {
-			position1285, tokenIndex1285, depth1285 := position, tokenIndex, depth
+			position2193, tokenIndex2193, depth2193 := position, tokenIndex, depth
 			{
-				position1286 := position
+				position2194 := position
 				depth++
 				{
-					position1287, tokenIndex1287, depth1287 := position, tokenIndex, depth
-					if !_rules[ruleFloatLiteral]() {
-						goto l1288
-					}
-					goto l1287
-				l1288:
-					position, tokenIndex, depth = position1287, tokenIndex1287, depth1287
-					if !_rules[ruleNumericLiteral]() {
-						goto l1289
-					}
-					goto l1287
-				l1289:
-					position, tokenIndex, depth = position1287, tokenIndex1287, depth1287
-					if !_rules[ruleStringLiteral]() {
-						goto l1285
+					position2195 := position
+					depth++
+					if !_rules[ruleident]() {
+						goto l2193
 					}
+					depth--
+					add(rulePegText, position2195)
+				}
+				if !_rules[ruleAction126]() {
+					goto l2193
 				}
-			l1287:
 				depth--
-				add(ruleLiteral, position1286)
+				add(ruleStreamIdentifier, position2194)
 			}
 			return true
-		l1285:
-			position, tokenIndex, depth = position1285, tokenIndex1285, depth1285
+		l2193:
+			position, tokenIndex, depth = position2193, tokenIndex2193, depth2193
 			return false
 		},
-		/* 100 ComparisonOp <- <(Equal / NotEqual / LessOrEqual / Less / GreaterOrEqual / Greater / NotEqual)> */
+		/* 166 SourceSinkType <- <(<ident> Action127)> */
 		func() bool {
-			position1290, tokenIndex1290, depth1290 := position, tokenIndex, depth
+			position2196, tokenIndex2196, depth2196 := position, tokenIndex, depth
 			{
-				position1291 := position
+				position2197 := position
 				depth++
 				{
-					position1292, tokenIndex1292, depth1292 := position, tokenIndex, depth
-					if !_rules[ruleEqual]() {
-						goto l1293
-					}
-					goto l1292
-				l1293:
-					position, tokenIndex, depth = position1292, tokenIndex1292, depth1292
-					if !_rules[ruleNotEqual]() {
-						goto l1294
-					}
-					goto l1292
-				l1294:
-					position, tokenIndex, depth = position1292, tokenIndex1292, depth1292
-					if !_rules[ruleLessOrEqual]() {
-						goto l1295
-					}
-					goto l1292
-				l1295:
-					position, tokenIndex, depth = position1292, tokenIndex1292, depth1292
-					if !_rules[ruleLess]() {
-						goto l1296
-					}
-					goto l1292
-				l1296:
-					position, tokenIndex, depth = position1292, tokenIndex1292, depth1292
-					if !_rules[ruleGreaterOrEqual]() {
-						goto l1297
-					}
-					goto l1292
-				l1297:
-					position, tokenIndex, depth = position1292, tokenIndex1292, depth1292
-					if !_rules[ruleGreater]() {
-						goto l1298
-					}
-					goto l1292
-				l1298:
-					position, tokenIndex, depth = position1292, tokenIndex1292, depth1292
-					if !_rules[ruleNotEqual]() {
-						goto l1290
+					position2198 := position
+					depth++
+					if !_rules[ruleident]() {
+						goto l2196
 					}
+					depth--
+					add(rulePegText, position2198)
+				}
+				if !_rules[ruleAction127]() {
+					goto l2196
 				}
-			l1292:
 				depth--
-				add(ruleComparisonOp, position1291)
+				add(ruleSourceSinkType, position2197)
 			}
 			return true
-		l1290:
-			position, tokenIndex, depth = position1290, tokenIndex1290, depth1290
+		l2196:
+			position, tokenIndex, depth = position2196, tokenIndex2196, depth2196
 			return false
 		},
-		/* 101 OtherOp <- <Concat> */
+		/* 167 SourceSinkParamKey <- <(<ident> Action128)> */
 		func() bool {
-			position1299, tokenIndex1299, depth1299 := position, tokenIndex, depth
+			position2199, tokenIndex2199, depth2199 := position, tokenIndex, depth
 			{
-				position1300 := position
+				position2200 := position
 				depth++
-				if !_rules[ruleConcat]() {
-					goto l1299
+				{
+					position2201 := position
+					depth++
+					if !_rules[ruleident]() {
+						goto l2199
+					}
+					depth--
+					add(rulePegText, position2201)
+				}
+				if !_rules[ruleAction128]() {
+					goto l2199
 				}
 				depth--
-				add(ruleOtherOp, position1300)
+				add(ruleSourceSinkParamKey, position2200)
 			}
 			return true
-		l1299:
-			position, tokenIndex, depth = position1299, tokenIndex1299, depth1299
+		l2199:
+			position, tokenIndex, depth = position2199, tokenIndex2199, depth2199
 			return false
 		},
-		/* 102 IsOp <- <(IsNot / Is)> */
+		/* 168 Paused <- <(<(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action129)> */
 		func() bool {
-			position1301, tokenIndex1301, depth1301 := position, tokenIndex, depth
+			position2202, tokenIndex2202, depth2202 := position, tokenIndex, depth
 			{
-				position1302 := position
+				position2203 := position
 				depth++
 				{
-					position1303, tokenIndex1303, depth1303 := position, tokenIndex, depth
-					if !_rules[ruleIsNot]() {
-						goto l1304
+					position2204 := position
+					depth++
+					{
+						position2205, tokenIndex2205, depth2205 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2206
+						}
+						position++
+						goto l2205
+					l2206:
+						position, tokenIndex, depth = position2205, tokenIndex2205, depth2205
+						if buffer[position] != rune('P') {
+							goto l2202
+						}
+						position++
+					}
+				l2205:
+					{
+						position2207, tokenIndex2207, depth2207 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2208
+						}
+						position++
+						goto l2207
+					l2208:
+						position, tokenIndex, depth = position2207, tokenIndex2207, depth2207
+						if buffer[position] != rune('A') {
+							goto l2202
+						}
+						position++
+					}
+				l2207:
+					{
+						position2209, tokenIndex2209, depth2209 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l2210
+						}
+						position++
+						goto l2209
+					l2210:
+						position, tokenIndex, depth = position2209, tokenIndex2209, depth2209
+						if buffer[position] != rune('U') {
+							goto l2202
+						}
+						position++
+					}
+				l2209:
+					{
+						position2211, tokenIndex2211, depth2211 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2212
+						}
+						position++
+						goto l2211
+					l2212:
+						position, tokenIndex, depth = position2211, tokenIndex2211, depth2211
+						if buffer[position] != rune('S') {
+							goto l2202
+						}
+						position++
+					}
+				l2211:
+					{
+						position2213, tokenIndex2213, depth2213 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2214
+						}
+						position++
+						goto l2213
+					l2214:
+						position, tokenIndex, depth = position2213, tokenIndex2213, depth2213
+						if buffer[position] != rune('E') {
+							goto l2202
+						}
+						position++
 					}
-					goto l1303
-				l1304:
-					position, tokenIndex, depth = position1303, tokenIndex1303, depth1303
-					if !_rules[ruleIs]() {
-						goto l1301
+				l2213:
+					{
+						position2215, tokenIndex2215, depth2215 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2216
+						}
+						position++
+						goto l2215
+					l2216:
+						position, tokenIndex, depth = position2215, tokenIndex2215, depth2215
+						if buffer[position] != rune('D') {
+							goto l2202
+						}
+						position++
 					}
+				l2215:
+					depth--
+					add(rulePegText, position2204)
+				}
+				if !_rules[ruleAction129]() {
+					goto l2202
 				}
-			l1303:
 				depth--
-				add(ruleIsOp, position1302)
+				add(rulePaused, position2203)
 			}
 			return true
-		l1301:
-			position, tokenIndex, depth = position1301, tokenIndex1301, depth1301
+		l2202:
+			position, tokenIndex, depth = position2202, tokenIndex2202, depth2202
 			return false
 		},
-		/* 103 PlusMinusOp <- <(Plus / Minus)> */
+		/* 169 Unpaused <- <(<(('u' / 'U') ('n' / 'N') ('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action130)> */
 		func() bool {
-			position1305, tokenIndex1305, depth1305 := position, tokenIndex, depth
+			position2217, tokenIndex2217, depth2217 := position, tokenIndex, depth
 			{
-				position1306 := position
+				position2218 := position
 				depth++
 				{
-					position1307, tokenIndex1307, depth1307 := position, tokenIndex, depth
-					if !_rules[rulePlus]() {
-						goto l1308
+					position2219 := position
+					depth++
+					{
+						position2220, tokenIndex2220, depth2220 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l2221
+						}
+						position++
+						goto l2220
+					l2221:
+						position, tokenIndex, depth = position2220, tokenIndex2220, depth2220
+						if buffer[position] != rune('U') {
+							goto l2217
+						}
+						position++
 					}
-					goto l1307
-				l1308:
-					position, tokenIndex, depth = position1307, tokenIndex1307, depth1307
-					if !_rules[ruleMinus]() {
-						goto l1305
+				l2220:
+					{
+						position2222, tokenIndex2222, depth2222 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2223
+						}
+						position++
+						goto l2222
+					l2223:
+						position, tokenIndex, depth = position2222, tokenIndex2222, depth2222
+						if buffer[position] != rune('N') {
+							goto l2217
+						}
+						position++
 					}
-				}
-			l1307:
-				depth--
-				add(rulePlusMinusOp, position1306)
-			}
-			return true
-		l1305:
-			position, tokenIndex, depth = position1305, tokenIndex1305, depth1305
-			return false
-		},
-		/* 104 MultDivOp <- <(Multiply / Divide / Modulo)> */
-		func() bool {
-			position1309, tokenIndex1309, depth1309 := position, tokenIndex, depth
-			{
-				position1310 := position
-				depth++
-				{
-					position1311, tokenIndex1311, depth1311 := position, tokenIndex, depth
-					if !_rules[ruleMultiply]() {
-						goto l1312
+				l2222:
+					{
+						position2224, tokenIndex2224, depth2224 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2225
+						}
+						position++
+						goto l2224
+					l2225:
+						position, tokenIndex, depth = position2224, tokenIndex2224, depth2224
+						if buffer[position] != rune('P') {
+							goto l2217
+						}
+						position++
 					}
-					goto l1311
-				l1312:
-					position, tokenIndex, depth = position1311, tokenIndex1311, depth1311
-					if !_rules[ruleDivide]() {
-						goto l1313
+				l2224:
+					{
+						position2226, tokenIndex2226, depth2226 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2227
+						}
+						position++
+						goto l2226
+					l2227:
+						position, tokenIndex, depth = position2226, tokenIndex2226, depth2226
+						if buffer[position] != rune('A') {
+							goto l2217
+						}
+						position++
 					}
-					goto l1311
-				l1313:
-					position, tokenIndex, depth = position1311, tokenIndex1311, depth1311
-					if !_rules[ruleModulo]() {
-						goto l1309
+				l2226:
+					{
+						position2228, tokenIndex2228, depth2228 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l2229
+						}
+						position++
+						goto l2228
+					l2229:
+						position, tokenIndex, depth = position2228, tokenIndex2228, depth2228
+						if buffer[position] != rune('U') {
+							goto l2217
+						}
+						position++
+					}
+				l2228:
+					{
+						position2230, tokenIndex2230, depth2230 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2231
+						}
+						position++
+						goto l2230
+					l2231:
+						position, tokenIndex, depth = position2230, tokenIndex2230, depth2230
+						if buffer[position] != rune('S') {
+							goto l2217
+						}
+						position++
+					}
+				l2230:
+					{
+						position2232, tokenIndex2232, depth2232 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2233
+						}
+						position++
+						goto l2232
+					l2233:
+						position, tokenIndex, depth = position2232, tokenIndex2232, depth2232
+						if buffer[position] != rune('E') {
+							goto l2217
+						}
+						position++
+					}
+				l2232:
+					{
+						position2234, tokenIndex2234, depth2234 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2235
+						}
+						position++
+						goto l2234
+					l2235:
+						position, tokenIndex, depth = position2234, tokenIndex2234, depth2234
+						if buffer[position] != rune('D') {
+							goto l2217
+						}
+						position++
 					}
+				l2234:
+					depth--
+					add(rulePegText, position2219)
+				}
+				if !_rules[ruleAction130]() {
+					goto l2217
 				}
-			l1311:
 				depth--
-				add(ruleMultDivOp, position1310)
+				add(ruleUnpaused, position2218)
 			}
 			return true
-		l1309:
-			position, tokenIndex, depth = position1309, tokenIndex1309, depth1309
+		l2217:
+			position, tokenIndex, depth = position2217, tokenIndex2217, depth2217
 			return false
 		},
-		/* 105 Stream <- <(<ident> Action77)> */
+		/* 170 OrReplace <- <(<(('o' / 'O') ('r' / 'R') sp (('r' / 'R') ('e' / 'E') ('p' / 'P') ('l' / 'L') ('a' / 'A') ('c' / 'C') ('e' / 'E')))> Action131)> */
 		func() bool {
-			position1314, tokenIndex1314, depth1314 := position, tokenIndex, depth
+			position2236, tokenIndex2236, depth2236 := position, tokenIndex, depth
 			{
-				position1315 := position
+				position2237 := position
 				depth++
 				{
-					position1316 := position
+					position2238 := position
 					depth++
-					if !_rules[ruleident]() {
-						goto l1314
+					{
+						position2239, tokenIndex2239, depth2239 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2240
+						}
+						position++
+						goto l2239
+					l2240:
+						position, tokenIndex, depth = position2239, tokenIndex2239, depth2239
+						if buffer[position] != rune('O') {
+							goto l2236
+						}
+						position++
+					}
+				l2239:
+					{
+						position2241, tokenIndex2241, depth2241 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2242
+						}
+						position++
+						goto l2241
+					l2242:
+						position, tokenIndex, depth = position2241, tokenIndex2241, depth2241
+						if buffer[position] != rune('R') {
+							goto l2236
+						}
+						position++
+					}
+				l2241:
+					if !_rules[rulesp]() {
+						goto l2236
+					}
+					{
+						position2243, tokenIndex2243, depth2243 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2244
+						}
+						position++
+						goto l2243
+					l2244:
+						position, tokenIndex, depth = position2243, tokenIndex2243, depth2243
+						if buffer[position] != rune('R') {
+							goto l2236
+						}
+						position++
+					}
+				l2243:
+					{
+						position2245, tokenIndex2245, depth2245 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2246
+						}
+						position++
+						goto l2245
+					l2246:
+						position, tokenIndex, depth = position2245, tokenIndex2245, depth2245
+						if buffer[position] != rune('E') {
+							goto l2236
+						}
+						position++
+					}
+				l2245:
+					{
+						position2247, tokenIndex2247, depth2247 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2248
+						}
+						position++
+						goto l2247
+					l2248:
+						position, tokenIndex, depth = position2247, tokenIndex2247, depth2247
+						if buffer[position] != rune('P') {
+							goto l2236
+						}
+						position++
+					}
+				l2247:
+					{
+						position2249, tokenIndex2249, depth2249 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2250
+						}
+						position++
+						goto l2249
+					l2250:
+						position, tokenIndex, depth = position2249, tokenIndex2249, depth2249
+						if buffer[position] != rune('L') {
+							goto l2236
+						}
+						position++
+					}
+				l2249:
+					{
+						position2251, tokenIndex2251, depth2251 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2252
+						}
+						position++
+						goto l2251
+					l2252:
+						position, tokenIndex, depth = position2251, tokenIndex2251, depth2251
+						if buffer[position] != rune('A') {
+							goto l2236
+						}
+						position++
+					}
+				l2251:
+					{
+						position2253, tokenIndex2253, depth2253 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2254
+						}
+						position++
+						goto l2253
+					l2254:
+						position, tokenIndex, depth = position2253, tokenIndex2253, depth2253
+						if buffer[position] != rune('C') {
+							goto l2236
+						}
+						position++
+					}
+				l2253:
+					{
+						position2255, tokenIndex2255, depth2255 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2256
+						}
+						position++
+						goto l2255
+					l2256:
+						position, tokenIndex, depth = position2255, tokenIndex2255, depth2255
+						if buffer[position] != rune('E') {
+							goto l2236
+						}
+						position++
 					}
+				l2255:
 					depth--
-					add(rulePegText, position1316)
-				}
-				if !_rules[ruleAction77]() {
-					goto l1314
+					add(rulePegText, position2238)
 				}
-				depth--
-				add(ruleStream, position1315)
-			}
-			return true
-		l1314:
-			position, tokenIndex, depth = position1314, tokenIndex1314, depth1314
-			return false
-		},
-		/* 106 RowMeta <- <RowTimestamp> */
-		func() bool {
-			position1317, tokenIndex1317, depth1317 := position, tokenIndex, depth
-			{
-				position1318 := position
-				depth++
-				if !_rules[ruleRowTimestamp]() {
-					goto l1317
+				if !_rules[ruleAction131]() {
+					goto l2236
 				}
 				depth--
-				add(ruleRowMeta, position1318)
+				add(ruleOrReplace, position2237)
 			}
 			return true
-		l1317:
-			position, tokenIndex, depth = position1317, tokenIndex1317, depth1317
+		l2236:
+			position, tokenIndex, depth = position2236, tokenIndex2236, depth2236
 			return false
 		},
-		/* 107 RowTimestamp <- <(<((ident ':')? ('t' 's' '(' ')'))> Action78)> */
+		/* 171 IfExists <- <(<(('i' / 'I') ('f' / 'F') sp (('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S')))> Action132)> */
 		func() bool {
-			position1319, tokenIndex1319, depth1319 := position, tokenIndex, depth
+			position2257, tokenIndex2257, depth2257 := position, tokenIndex, depth
 			{
-				position1320 := position
+				position2258 := position
 				depth++
 				{
-					position1321 := position
+					position2259 := position
 					depth++
 					{
-						position1322, tokenIndex1322, depth1322 := position, tokenIndex, depth
-						if !_rules[ruleident]() {
-							goto l1322
+						position2260, tokenIndex2260, depth2260 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2261
 						}
-						if buffer[position] != rune(':') {
-							goto l1322
+						position++
+						goto l2260
+					l2261:
+						position, tokenIndex, depth = position2260, tokenIndex2260, depth2260
+						if buffer[position] != rune('I') {
+							goto l2257
 						}
 						position++
-						goto l1323
-					l1322:
-						position, tokenIndex, depth = position1322, tokenIndex1322, depth1322
 					}
-				l1323:
-					if buffer[position] != rune('t') {
-						goto l1319
+				l2260:
+					{
+						position2262, tokenIndex2262, depth2262 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2263
+						}
+						position++
+						goto l2262
+					l2263:
+						position, tokenIndex, depth = position2262, tokenIndex2262, depth2262
+						if buffer[position] != rune('F') {
+							goto l2257
+						}
+						position++
 					}
-					position++
-					if buffer[position] != rune('s') {
-						goto l1319
+				l2262:
+					if !_rules[rulesp]() {
+						goto l2257
 					}
-					position++
-					if buffer[position] != rune('(') {
-						goto l1319
+					{
+						position2264, tokenIndex2264, depth2264 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2265
+						}
+						position++
+						goto l2264
+					l2265:
+						position, tokenIndex, depth = position2264, tokenIndex2264, depth2264
+						if buffer[position] != rune('E') {
+							goto l2257
+						}
+						position++
 					}
-					position++
-					if buffer[position] != rune(')') {
-						goto l1319
+				l2264:
+					{
+						position2266, tokenIndex2266, depth2266 := position, tokenIndex, depth
+						if buffer[position] != rune('x') {
+							goto l2267
+						}
+						position++
+						goto l2266
+					l2267:
+						position, tokenIndex, depth = position2266, tokenIndex2266, depth2266
+						if buffer[position] != rune('X') {
+							goto l2257
+						}
+						position++
+					}
+				l2266:
+					{
+						position2268, tokenIndex2268, depth2268 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2269
+						}
+						position++
+						goto l2268
+					l2269:
+						position, tokenIndex, depth = position2268, tokenIndex2268, depth2268
+						if buffer[position] != rune('I') {
+							goto l2257
+						}
+						position++
+					}
+				l2268:
+					{
+						position2270, tokenIndex2270, depth2270 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2271
+						}
+						position++
+						goto l2270
+					l2271:
+						position, tokenIndex, depth = position2270, tokenIndex2270, depth2270
+						if buffer[position] != rune('S') {
+							goto l2257
+						}
+						position++
+					}
+				l2270:
+					{
+						position2272, tokenIndex2272, depth2272 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2273
+						}
+						position++
+						goto l2272
+					l2273:
+						position, tokenIndex, depth = position2272, tokenIndex2272, depth2272
+						if buffer[position] != rune('T') {
+							goto l2257
+						}
+						position++
+					}
+				l2272:
+					{
+						position2274, tokenIndex2274, depth2274 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2275
+						}
+						position++
+						goto l2274
+					l2275:
+						position, tokenIndex, depth = position2274, tokenIndex2274, depth2274
+						if buffer[position] != rune('S') {
+							goto l2257
+						}
+						position++
 					}
-					position++
+				l2274:
 					depth--
-					add(rulePegText, position1321)
+					add(rulePegText, position2259)
 				}
-				if !_rules[ruleAction78]() {
-					goto l1319
+				if !_rules[ruleAction132]() {
+					goto l2257
 				}
 				depth--
-				add(ruleRowTimestamp, position1320)
+				add(ruleIfExists, position2258)
 			}
 			return true
-		l1319:
-			position, tokenIndex, depth = position1319, tokenIndex1319, depth1319
+		l2257:
+			position, tokenIndex, depth = position2257, tokenIndex2257, depth2257
 			return false
 		},
-		/* 108 RowValue <- <(<((ident ':' !':')? jsonGetPath)> Action79)> */
+		/* 172 Cascade <- <(<(('c' / 'C') ('a' / 'A') ('s' / 'S') ('c' / 'C') ('a' / 'A') ('d' / 'D') ('e' / 'E'))> Action133)> */
 		func() bool {
-			position1324, tokenIndex1324, depth1324 := position, tokenIndex, depth
+			position2276, tokenIndex2276, depth2276 := position, tokenIndex, depth
 			{
-				position1325 := position
+				position2277 := position
 				depth++
 				{
-					position1326 := position
+					position2278 := position
 					depth++
 					{
-						position1327, tokenIndex1327, depth1327 := position, tokenIndex, depth
-						if !_rules[ruleident]() {
-							goto l1327
+						position2279, tokenIndex2279, depth2279 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2280
 						}
-						if buffer[position] != rune(':') {
-							goto l1327
+						position++
+						goto l2279
+					l2280:
+						position, tokenIndex, depth = position2279, tokenIndex2279, depth2279
+						if buffer[position] != rune('C') {
+							goto l2276
 						}
 						position++
-						{
-							position1329, tokenIndex1329, depth1329 := position, tokenIndex, depth
-							if buffer[position] != rune(':') {
-								goto l1329
-							}
-							position++
-							goto l1327
-						l1329:
-							position, tokenIndex, depth = position1329, tokenIndex1329, depth1329
+					}
+				l2279:
+					{
+						position2281, tokenIndex2281, depth2281 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2282
 						}
-						goto l1328
-					l1327:
-						position, tokenIndex, depth = position1327, tokenIndex1327, depth1327
+						position++
+						goto l2281
+					l2282:
+						position, tokenIndex, depth = position2281, tokenIndex2281, depth2281
+						if buffer[position] != rune('A') {
+							goto l2276
+						}
+						position++
 					}
-				l1328:
-					if !_rules[rulejsonGetPath]() {
-						goto l1324
+				l2281:
+					{
+						position2283, tokenIndex2283, depth2283 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2284
+						}
+						position++
+						goto l2283
+					l2284:
+						position, tokenIndex, depth = position2283, tokenIndex2283, depth2283
+						if buffer[position] != rune('S') {
+							goto l2276
+						}
+						position++
 					}
-					depth--
-					add(rulePegText, position1326)
-				}
-				if !_rules[ruleAction79]() {
-					goto l1324
-				}
-				depth--
-				add(ruleRowValue, position1325)
-			}
-			return true
-		l1324:
-			position, tokenIndex, depth = position1324, tokenIndex1324, depth1324
-			return false
-		},
-		/* 109 NumericLiteral <- <(<('-'? [0-9]+)> Action80)> */
-		func() bool {
-			position1330, tokenIndex1330, depth1330 := position, tokenIndex, depth
-			{
-				position1331 := position
-				depth++
-				{
-					position1332 := position
-					depth++
+				l2283:
 					{
-						position1333, tokenIndex1333, depth1333 := position, tokenIndex, depth
-						if buffer[position] != rune('-') {
-							goto l1333
+						position2285, tokenIndex2285, depth2285 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2286
+						}
+						position++
+						goto l2285
+					l2286:
+						position, tokenIndex, depth = position2285, tokenIndex2285, depth2285
+						if buffer[position] != rune('C') {
+							goto l2276
 						}
 						position++
-						goto l1334
-					l1333:
-						position, tokenIndex, depth = position1333, tokenIndex1333, depth1333
 					}
-				l1334:
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1330
+				l2285:
+					{
+						position2287, tokenIndex2287, depth2287 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2288
+						}
+						position++
+						goto l2287
+					l2288:
+						position, tokenIndex, depth = position2287, tokenIndex2287, depth2287
+						if buffer[position] != rune('A') {
+							goto l2276
+						}
+						position++
 					}
-					position++
-				l1335:
+				l2287:
 					{
-						position1336, tokenIndex1336, depth1336 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1336
+						position2289, tokenIndex2289, depth2289 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2290
+						}
+						position++
+						goto l2289
+					l2290:
+						position, tokenIndex, depth = position2289, tokenIndex2289, depth2289
+						if buffer[position] != rune('D') {
+							goto l2276
+						}
+						position++
+					}
+				l2289:
+					{
+						position2291, tokenIndex2291, depth2291 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2292
+						}
+						position++
+						goto l2291
+					l2292:
+						position, tokenIndex, depth = position2291, tokenIndex2291, depth2291
+						if buffer[position] != rune('E') {
+							goto l2276
 						}
 						position++
-						goto l1335
-					l1336:
-						position, tokenIndex, depth = position1336, tokenIndex1336, depth1336
 					}
+				l2291:
 					depth--
-					add(rulePegText, position1332)
+					add(rulePegText, position2278)
 				}
-				if !_rules[ruleAction80]() {
-					goto l1330
+				if !_rules[ruleAction133]() {
+					goto l2276
 				}
 				depth--
-				add(ruleNumericLiteral, position1331)
+				add(ruleCascade, position2277)
 			}
 			return true
-		l1330:
-			position, tokenIndex, depth = position1330, tokenIndex1330, depth1330
+		l2276:
+			position, tokenIndex, depth = position2276, tokenIndex2276, depth2276
 			return false
 		},
-		/* 110 NonNegativeNumericLiteral <- <(<[0-9]+> Action81)> */
+		/* 173 On <- <(<(('o' / 'O') ('n' / 'N'))> Action134)> */
 		func() bool {
-			position1337, tokenIndex1337, depth1337 := position, tokenIndex, depth
+			position2293, tokenIndex2293, depth2293 := position, tokenIndex, depth
 			{
-				position1338 := position
+				position2294 := position
 				depth++
 				{
-					position1339 := position
+					position2295 := position
 					depth++
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1337
+					{
+						position2296, tokenIndex2296, depth2296 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2297
+						}
+						position++
+						goto l2296
+					l2297:
+						position, tokenIndex, depth = position2296, tokenIndex2296, depth2296
+						if buffer[position] != rune('O') {
+							goto l2293
+						}
+						position++
 					}
-					position++
-				l1340:
+				l2296:
 					{
-						position1341, tokenIndex1341, depth1341 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1341
+						position2298, tokenIndex2298, depth2298 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2299
+						}
+						position++
+						goto l2298
+					l2299:
+						position, tokenIndex, depth = position2298, tokenIndex2298, depth2298
+						if buffer[position] != rune('N') {
+							goto l2293
 						}
 						position++
-						goto l1340
-					l1341:
-						position, tokenIndex, depth = position1341, tokenIndex1341, depth1341
 					}
+				l2298:
 					depth--
-					add(rulePegText, position1339)
+					add(rulePegText, position2295)
 				}
-				if !_rules[ruleAction81]() {
-					goto l1337
+				if !_rules[ruleAction134]() {
+					goto l2293
 				}
 				depth--
-				add(ruleNonNegativeNumericLiteral, position1338)
+				add(ruleOn, position2294)
 			}
 			return true
-		l1337:
-			position, tokenIndex, depth = position1337, tokenIndex1337, depth1337
+		l2293:
+			position, tokenIndex, depth = position2293, tokenIndex2293, depth2293
 			return false
 		},
-		/* 111 FloatLiteral <- <(<('-'? [0-9]+ '.' [0-9]+)> Action82)> */
+		/* 174 Off <- <(<(('o' / 'O') ('f' / 'F') ('f' / 'F'))> Action135)> */
 		func() bool {
-			position1342, tokenIndex1342, depth1342 := position, tokenIndex, depth
+			position2300, tokenIndex2300, depth2300 := position, tokenIndex, depth
 			{
-				position1343 := position
+				position2301 := position
 				depth++
 				{
-					position1344 := position
+					position2302 := position
 					depth++
 					{
-						position1345, tokenIndex1345, depth1345 := position, tokenIndex, depth
-						if buffer[position] != rune('-') {
-							goto l1345
+						position2303, tokenIndex2303, depth2303 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2304
+						}
+						position++
+						goto l2303
+					l2304:
+						position, tokenIndex, depth = position2303, tokenIndex2303, depth2303
+						if buffer[position] != rune('O') {
+							goto l2300
 						}
 						position++
-						goto l1346
-					l1345:
-						position, tokenIndex, depth = position1345, tokenIndex1345, depth1345
-					}
-				l1346:
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1342
 					}
-					position++
-				l1347:
+				l2303:
 					{
-						position1348, tokenIndex1348, depth1348 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1348
+						position2305, tokenIndex2305, depth2305 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2306
+						}
+						position++
+						goto l2305
+					l2306:
+						position, tokenIndex, depth = position2305, tokenIndex2305, depth2305
+						if buffer[position] != rune('F') {
+							goto l2300
 						}
 						position++
-						goto l1347
-					l1348:
-						position, tokenIndex, depth = position1348, tokenIndex1348, depth1348
-					}
-					if buffer[position] != rune('.') {
-						goto l1342
-					}
-					position++
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1342
 					}
-					position++
-				l1349:
+				l2305:
 					{
-						position1350, tokenIndex1350, depth1350 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1350
+						position2307, tokenIndex2307, depth2307 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2308
+						}
+						position++
+						goto l2307
+					l2308:
+						position, tokenIndex, depth = position2307, tokenIndex2307, depth2307
+						if buffer[position] != rune('F') {
+							goto l2300
 						}
 						position++
-						goto l1349
-					l1350:
-						position, tokenIndex, depth = position1350, tokenIndex1350, depth1350
-					}
-					depth--
-					add(rulePegText, position1344)
-				}
-				if !_rules[ruleAction82]() {
-					goto l1342
-				}
-				depth--
-				add(ruleFloatLiteral, position1343)
-			}
-			return true
-		l1342:
-			position, tokenIndex, depth = position1342, tokenIndex1342, depth1342
-			return false
-		},
-		/* 112 Function <- <(<ident> Action83)> */
-		func() bool {
-			position1351, tokenIndex1351, depth1351 := position, tokenIndex, depth
-			{
-				position1352 := position
-				depth++
-				{
-					position1353 := position
-					depth++
-					if !_rules[ruleident]() {
-						goto l1351
 					}
+				l2307:
 					depth--
-					add(rulePegText, position1353)
+					add(rulePegText, position2302)
 				}
-				if !_rules[ruleAction83]() {
-					goto l1351
+				if !_rules[ruleAction135]() {
+					goto l2300
 				}
 				depth--
-				add(ruleFunction, position1352)
+				add(ruleOff, position2301)
 			}
 			return true
-		l1351:
-			position, tokenIndex, depth = position1351, tokenIndex1351, depth1351
+		l2300:
+			position, tokenIndex, depth = position2300, tokenIndex2300, depth2300
 			return false
 		},
-		/* 113 NullLiteral <- <(<(('n' / 'N') ('u' / 'U') ('l' / 'L') ('l' / 'L'))> Action84)> */
+		/* 175 NodeTarget <- <(<(('n' / 'N') ('o' / 'O') ('d' / 'D') ('e' / 'E'))> Action136)> */
 		func() bool {
-			position1354, tokenIndex1354, depth1354 := position, tokenIndex, depth
+			position2309, tokenIndex2309, depth2309 := position, tokenIndex, depth
 			{
-				position1355 := position
+				position2310 := position
 				depth++
 				{
-					position1356 := position
+					position2311 := position
 					depth++
 					{
-						position1357, tokenIndex1357, depth1357 := position, tokenIndex, depth
+						position2312, tokenIndex2312, depth2312 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l1358
+							goto l2313
 						}
 						position++
-						goto l1357
-					l1358:
-						position, tokenIndex, depth = position1357, tokenIndex1357, depth1357
+						goto l2312
+					l2313:
+						position, tokenIndex, depth = position2312, tokenIndex2312, depth2312
 						if buffer[position] != rune('N') {
-							goto l1354
+							goto l2309
 						}
 						position++
 					}
-				l1357:
+				l2312:
 					{
-						position1359, tokenIndex1359, depth1359 := position, tokenIndex, depth
-						if buffer[position] != rune('u') {
-							goto l1360
+						position2314, tokenIndex2314, depth2314 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2315
 						}
 						position++
-						goto l1359
-					l1360:
-						position, tokenIndex, depth = position1359, tokenIndex1359, depth1359
-						if buffer[position] != rune('U') {
-							goto l1354
+						goto l2314
+					l2315:
+						position, tokenIndex, depth = position2314, tokenIndex2314, depth2314
+						if buffer[position] != rune('O') {
+							goto l2309
 						}
 						position++
 					}
-				l1359:
+				l2314:
 					{
-						position1361, tokenIndex1361, depth1361 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1362
+						position2316, tokenIndex2316, depth2316 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2317
 						}
 						position++
-						goto l1361
-					l1362:
-						position, tokenIndex, depth = position1361, tokenIndex1361, depth1361
-						if buffer[position] != rune('L') {
-							goto l1354
+						goto l2316
+					l2317:
+						position, tokenIndex, depth = position2316, tokenIndex2316, depth2316
+						if buffer[position] != rune('D') {
+							goto l2309
 						}
 						position++
 					}
-				l1361:
+				l2316:
 					{
-						position1363, tokenIndex1363, depth1363 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1364
+						position2318, tokenIndex2318, depth2318 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2319
 						}
 						position++
-						goto l1363
-					l1364:
-						position, tokenIndex, depth = position1363, tokenIndex1363, depth1363
-						if buffer[position] != rune('L') {
-							goto l1354
+						goto l2318
+					l2319:
+						position, tokenIndex, depth = position2318, tokenIndex2318, depth2318
+						if buffer[position] != rune('E') {
+							goto l2309
 						}
 						position++
 					}
-				l1363:
+				l2318:
 					depth--
-					add(rulePegText, position1356)
+					add(rulePegText, position2311)
 				}
-				if !_rules[ruleAction84]() {
-					goto l1354
+				if !_rules[ruleAction136]() {
+					goto l2309
 				}
 				depth--
-				add(ruleNullLiteral, position1355)
+				add(ruleNodeTarget, position2310)
 			}
 			return true
-		l1354:
-			position, tokenIndex, depth = position1354, tokenIndex1354, depth1354
+		l2309:
+			position, tokenIndex, depth = position2309, tokenIndex2309, depth2309
 			return false
 		},
-		/* 114 Missing <- <(<(('m' / 'M') ('i' / 'I') ('s' / 'S') ('s' / 'S') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action85)> */
+		/* 176 TopologyTarget <- <(<(('t' / 'T') ('o' / 'O') ('p' / 'P') ('o' / 'O') ('l' / 'L') ('o' / 'O') ('g' / 'G') ('y' / 'Y'))> Action137)> */
 		func() bool {
-			position1365, tokenIndex1365, depth1365 := position, tokenIndex, depth
+			position2320, tokenIndex2320, depth2320 := position, tokenIndex, depth
 			{
-				position1366 := position
+				position2321 := position
 				depth++
 				{
-					position1367 := position
+					position2322 := position
 					depth++
 					{
-						position1368, tokenIndex1368, depth1368 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1369
-						}
-						position++
-						goto l1368
-					l1369:
-						position, tokenIndex, depth = position1368, tokenIndex1368, depth1368
-						if buffer[position] != rune('M') {
-							goto l1365
-						}
-						position++
-					}
-				l1368:
-					{
-						position1370, tokenIndex1370, depth1370 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1371
-						}
-						position++
-						goto l1370
-					l1371:
-						position, tokenIndex, depth = position1370, tokenIndex1370, depth1370
-						if buffer[position] != rune('I') {
-							goto l1365
-						}
-						position++
-					}
-				l1370:
-					{
-						position1372, tokenIndex1372, depth1372 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1373
-						}
-						position++
-						goto l1372
-					l1373:
-						position, tokenIndex, depth = position1372, tokenIndex1372, depth1372
-						if buffer[position] != rune('S') {
-							goto l1365
-						}
-						position++
-					}
-				l1372:
-					{
-						position1374, tokenIndex1374, depth1374 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1375
+						position2323, tokenIndex2323, depth2323 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2324
 						}
 						position++
-						goto l1374
-					l1375:
-						position, tokenIndex, depth = position1374, tokenIndex1374, depth1374
-						if buffer[position] != rune('S') {
-							goto l1365
+						goto l2323
+					l2324:
+						position, tokenIndex, depth = position2323, tokenIndex2323, depth2323
+						if buffer[position] != rune('T') {
+							goto l2320
 						}
 						position++
 					}
-				l1374:
+				l2323:
 					{
-						position1376, tokenIndex1376, depth1376 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1377
+						position2325, tokenIndex2325, depth2325 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2326
 						}
 						position++
-						goto l1376
-					l1377:
-						position, tokenIndex, depth = position1376, tokenIndex1376, depth1376
-						if buffer[position] != rune('I') {
-							goto l1365
+						goto l2325
+					l2326:
+						position, tokenIndex, depth = position2325, tokenIndex2325, depth2325
+						if buffer[position] != rune('O') {
+							goto l2320
 						}
 						position++
 					}
-				l1376:
+				l2325:
 					{
-						position1378, tokenIndex1378, depth1378 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1379
+						position2327, tokenIndex2327, depth2327 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2328
 						}
 						position++
-						goto l1378
-					l1379:
-						position, tokenIndex, depth = position1378, tokenIndex1378, depth1378
-						if buffer[position] != rune('N') {
-							goto l1365
+						goto l2327
+					l2328:
+						position, tokenIndex, depth = position2327, tokenIndex2327, depth2327
+						if buffer[position] != rune('P') {
+							goto l2320
 						}
 						position++
 					}
-				l1378:
+				l2327:
 					{
-						position1380, tokenIndex1380, depth1380 := position, tokenIndex, depth
-						if buffer[position] != rune('g') {
-							goto l1381
+						position2329, tokenIndex2329, depth2329 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2330
 						}
 						position++
-						goto l1380
-					l1381:
-						position, tokenIndex, depth = position1380, tokenIndex1380, depth1380
-						if buffer[position] != rune('G') {
-							goto l1365
+						goto l2329
+					l2330:
+						position, tokenIndex, depth = position2329, tokenIndex2329, depth2329
+						if buffer[position] != rune('O') {
+							goto l2320
 						}
 						position++
 					}
-				l1380:
-					depth--
-					add(rulePegText, position1367)
-				}
-				if !_rules[ruleAction85]() {
-					goto l1365
-				}
-				depth--
-				add(ruleMissing, position1366)
-			}
-			return true
-		l1365:
-			position, tokenIndex, depth = position1365, tokenIndex1365, depth1365
-			return false
-		},
-		/* 115 BooleanLiteral <- <(TRUE / FALSE)> */
-		func() bool {
-			position1382, tokenIndex1382, depth1382 := position, tokenIndex, depth
-			{
-				position1383 := position
-				depth++
-				{
-					position1384, tokenIndex1384, depth1384 := position, tokenIndex, depth
-					if !_rules[ruleTRUE]() {
-						goto l1385
-					}
-					goto l1384
-				l1385:
-					position, tokenIndex, depth = position1384, tokenIndex1384, depth1384
-					if !_rules[ruleFALSE]() {
-						goto l1382
-					}
-				}
-			l1384:
-				depth--
-				add(ruleBooleanLiteral, position1383)
-			}
-			return true
-		l1382:
-			position, tokenIndex, depth = position1382, tokenIndex1382, depth1382
-			return false
-		},
-		/* 116 TRUE <- <(<(('t' / 'T') ('r' / 'R') ('u' / 'U') ('e' / 'E'))> Action86)> */
-		func() bool {
-			position1386, tokenIndex1386, depth1386 := position, tokenIndex, depth
-			{
-				position1387 := position
-				depth++
-				{
-					position1388 := position
-					depth++
+				l2329:
 					{
-						position1389, tokenIndex1389, depth1389 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1390
+						position2331, tokenIndex2331, depth2331 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2332
 						}
 						position++
-						goto l1389
-					l1390:
-						position, tokenIndex, depth = position1389, tokenIndex1389, depth1389
-						if buffer[position] != rune('T') {
-							goto l1386
+						goto l2331
+					l2332:
+						position, tokenIndex, depth = position2331, tokenIndex2331, depth2331
+						if buffer[position] != rune('L') {
+							goto l2320
 						}
 						position++
 					}
-				l1389:
+				l2331:
 					{
-						position1391, tokenIndex1391, depth1391 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1392
+						position2333, tokenIndex2333, depth2333 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2334
 						}
 						position++
-						goto l1391
-					l1392:
-						position, tokenIndex, depth = position1391, tokenIndex1391, depth1391
-						if buffer[position] != rune('R') {
-							goto l1386
+						goto l2333
+					l2334:
+						position, tokenIndex, depth = position2333, tokenIndex2333, depth2333
+						if buffer[position] != rune('O') {
+							goto l2320
 						}
 						position++
 					}
-				l1391:
+				l2333:
 					{
-						position1393, tokenIndex1393, depth1393 := position, tokenIndex, depth
-						if buffer[position] != rune('u') {
-							goto l1394
+						position2335, tokenIndex2335, depth2335 := position, tokenIndex, depth
+						if buffer[position] != rune('g') {
+							goto l2336
 						}
 						position++
-						goto l1393
-					l1394:
-						position, tokenIndex, depth = position1393, tokenIndex1393, depth1393
-						if buffer[position] != rune('U') {
-							goto l1386
+						goto l2335
+					l2336:
+						position, tokenIndex, depth = position2335, tokenIndex2335, depth2335
+						if buffer[position] != rune('G') {
+							goto l2320
 						}
 						position++
 					}
-				l1393:
+				l2335:
 					{
-						position1395, tokenIndex1395, depth1395 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1396
+						position2337, tokenIndex2337, depth2337 := position, tokenIndex, depth
+						if buffer[position] != rune('y') {
+							goto l2338
 						}
 						position++
-						goto l1395
-					l1396:
-						position, tokenIndex, depth = position1395, tokenIndex1395, depth1395
-						if buffer[position] != rune('E') {
-							goto l1386
+						goto l2337
+					l2338:
+						position, tokenIndex, depth = position2337, tokenIndex2337, depth2337
+						if buffer[position] != rune('Y') {
+							goto l2320
 						}
 						position++
 					}
-				l1395:
+				l2337:
 					depth--
-					add(rulePegText, position1388)
+					add(rulePegText, position2322)
 				}
-				if !_rules[ruleAction86]() {
-					goto l1386
+				if !_rules[ruleAction137]() {
+					goto l2320
 				}
 				depth--
-				add(ruleTRUE, position1387)
+				add(ruleTopologyTarget, position2321)
 			}
 			return true
-		l1386:
-			position, tokenIndex, depth = position1386, tokenIndex1386, depth1386
+		l2320:
+			position, tokenIndex, depth = position2320, tokenIndex2320, depth2320
 			return false
 		},
-		/* 117 FALSE <- <(<(('f' / 'F') ('a' / 'A') ('l' / 'L') ('s' / 'S') ('e' / 'E'))> Action87)> */
+		/* 177 SourcesTarget <- <(<(('s' / 'S') ('o' / 'O') ('u' / 'U') ('r' / 'R') ('c' / 'C') ('e' / 'E') ('s' / 'S'))> Action138)> */
 		func() bool {
-			position1397, tokenIndex1397, depth1397 := position, tokenIndex, depth
+			position2339, tokenIndex2339, depth2339 := position, tokenIndex, depth
 			{
-				position1398 := position
+				position2340 := position
 				depth++
 				{
-					position1399 := position
+					position2341 := position
 					depth++
 					{
-						position1400, tokenIndex1400, depth1400 := position, tokenIndex, depth
-						if buffer[position] != rune('f') {
-							goto l1401
+						position2342, tokenIndex2342, depth2342 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2343
 						}
 						position++
-						goto l1400
-					l1401:
-						position, tokenIndex, depth = position1400, tokenIndex1400, depth1400
-						if buffer[position] != rune('F') {
-							goto l1397
+						goto l2342
+					l2343:
+						position, tokenIndex, depth = position2342, tokenIndex2342, depth2342
+						if buffer[position] != rune('S') {
+							goto l2339
 						}
 						position++
 					}
-				l1400:
+				l2342:
 					{
-						position1402, tokenIndex1402, depth1402 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1403
+						position2344, tokenIndex2344, depth2344 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2345
 						}
 						position++
-						goto l1402
-					l1403:
-						position, tokenIndex, depth = position1402, tokenIndex1402, depth1402
-						if buffer[position] != rune('A') {
-							goto l1397
+						goto l2344
+					l2345:
+						position, tokenIndex, depth = position2344, tokenIndex2344, depth2344
+						if buffer[position] != rune('O') {
+							goto l2339
 						}
 						position++
 					}
-				l1402:
+				l2344:
 					{
-						position1404, tokenIndex1404, depth1404 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1405
+						position2346, tokenIndex2346, depth2346 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l2347
 						}
 						position++
-						goto l1404
-					l1405:
-						position, tokenIndex, depth = position1404, tokenIndex1404, depth1404
-						if buffer[position] != rune('L') {
-							goto l1397
+						goto l2346
+					l2347:
+						position, tokenIndex, depth = position2346, tokenIndex2346, depth2346
+						if buffer[position] != rune('U') {
+							goto l2339
 						}
 						position++
 					}
-				l1404:
+				l2346:
 					{
-						position1406, tokenIndex1406, depth1406 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1407
+						position2348, tokenIndex2348, depth2348 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2349
 						}
 						position++
-						goto l1406
-					l1407:
-						position, tokenIndex, depth = position1406, tokenIndex1406, depth1406
-						if buffer[position] != rune('S') {
-							goto l1397
+						goto l2348
+					l2349:
+						position, tokenIndex, depth = position2348, tokenIndex2348, depth2348
+						if buffer[position] != rune('R') {
+							goto l2339
 						}
 						position++
 					}
-				l1406:
+				l2348:
 					{
-						position1408, tokenIndex1408, depth1408 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1409
+						position2350, tokenIndex2350, depth2350 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2351
 						}
 						position++
-						goto l1408
-					l1409:
-						position, tokenIndex, depth = position1408, tokenIndex1408, depth1408
-						if buffer[position] != rune('E') {
-							goto l1397
+						goto l2350
+					l2351:
+						position, tokenIndex, depth = position2350, tokenIndex2350, depth2350
+						if buffer[position] != rune('C') {
+							goto l2339
 						}
 						position++
 					}
-				l1408:
-					depth--
-					add(rulePegText, position1399)
-				}
-				if !_rules[ruleAction87]() {
-					goto l1397
-				}
-				depth--
-				add(ruleFALSE, position1398)
-			}
-			return true
-		l1397:
-			position, tokenIndex, depth = position1397, tokenIndex1397, depth1397
-			return false
-		},
-		/* 118 Wildcard <- <(<((ident ':' !':')? '*')> Action88)> */
-		func() bool {
-			position1410, tokenIndex1410, depth1410 := position, tokenIndex, depth
-			{
-				position1411 := position
-				depth++
-				{
-					position1412 := position
-					depth++
+				l2350:
 					{
-						position1413, tokenIndex1413, depth1413 := position, tokenIndex, depth
-						if !_rules[ruleident]() {
-							goto l1413
-						}
-						if buffer[position] != rune(':') {
-							goto l1413
+						position2352, tokenIndex2352, depth2352 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2353
 						}
 						position++
-						{
-							position1415, tokenIndex1415, depth1415 := position, tokenIndex, depth
-							if buffer[position] != rune(':') {
-								goto l1415
-							}
-							position++
-							goto l1413
-						l1415:
-							position, tokenIndex, depth = position1415, tokenIndex1415, depth1415
+						goto l2352
+					l2353:
+						position, tokenIndex, depth = position2352, tokenIndex2352, depth2352
+						if buffer[position] != rune('E') {
+							goto l2339
 						}
-						goto l1414
-					l1413:
-						position, tokenIndex, depth = position1413, tokenIndex1413, depth1413
-					}
-				l1414:
-					if buffer[position] != rune('*') {
-						goto l1410
-					}
-					position++
-					depth--
-					add(rulePegText, position1412)
-				}
-				if !_rules[ruleAction88]() {
-					goto l1410
-				}
-				depth--
-				add(ruleWildcard, position1411)
-			}
-			return true
-		l1410:
-			position, tokenIndex, depth = position1410, tokenIndex1410, depth1410
-			return false
-		},
-		/* 119 StringLiteral <- <(<('"' (('"' '"') / (!'"' .))* '"')> Action89)> */
-		func() bool {
-			position1416, tokenIndex1416, depth1416 := position, tokenIndex, depth
-			{
-				position1417 := position
-				depth++
-				{
-					position1418 := position
-					depth++
-					if buffer[position] != rune('"') {
-						goto l1416
+						position++
 					}
-					position++
-				l1419:
+				l2352:
 					{
-						position1420, tokenIndex1420, depth1420 := position, tokenIndex, depth
-						{
-							position1421, tokenIndex1421, depth1421 := position, tokenIndex, depth
-							if buffer[position] != rune('"') {
-								goto l1422
-							}
-							position++
-							if buffer[position] != rune('"') {
-								goto l1422
-							}
-							position++
-							goto l1421
-						l1422:
-							position, tokenIndex, depth = position1421, tokenIndex1421, depth1421
-							{
-								position1423, tokenIndex1423, depth1423 := position, tokenIndex, depth
-								if buffer[position] != rune('"') {
-									goto l1423
-								}
-								position++
-								goto l1420
-							l1423:
-								position, tokenIndex, depth = position1423, tokenIndex1423, depth1423
-							}
-							if !matchDot() {
-								goto l1420
-							}
+						position2354, tokenIndex2354, depth2354 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2355
 						}
-					l1421:
-						goto l1419
-					l1420:
-						position, tokenIndex, depth = position1420, tokenIndex1420, depth1420
-					}
-					if buffer[position] != rune('"') {
-						goto l1416
+						position++
+						goto l2354
+					l2355:
+						position, tokenIndex, depth = position2354, tokenIndex2354, depth2354
+						if buffer[position] != rune('S') {
+							goto l2339
+						}
+						position++
 					}
-					position++
+				l2354:
 					depth--
-					add(rulePegText, position1418)
+					add(rulePegText, position2341)
 				}
-				if !_rules[ruleAction89]() {
-					goto l1416
+				if !_rules[ruleAction138]() {
+					goto l2339
 				}
 				depth--
-				add(ruleStringLiteral, position1417)
+				add(ruleSourcesTarget, position2340)
 			}
 			return true
-		l1416:
-			position, tokenIndex, depth = position1416, tokenIndex1416, depth1416
+		l2339:
+			position, tokenIndex, depth = position2339, tokenIndex2339, depth2339
 			return false
 		},
-		/* 120 ISTREAM <- <(<(('i' / 'I') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action90)> */
+		/* 178 StreamsTarget <- <(<(('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M') ('s' / 'S'))> Action139)> */
 		func() bool {
-			position1424, tokenIndex1424, depth1424 := position, tokenIndex, depth
+			position2356, tokenIndex2356, depth2356 := position, tokenIndex, depth
 			{
-				position1425 := position
+				position2357 := position
 				depth++
 				{
-					position1426 := position
+					position2358 := position
 					depth++
 					{
-						position1427, tokenIndex1427, depth1427 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1428
-						}
-						position++
-						goto l1427
-					l1428:
-						position, tokenIndex, depth = position1427, tokenIndex1427, depth1427
-						if buffer[position] != rune('I') {
-							goto l1424
-						}
-						position++
-					}
-				l1427:
-					{
-						position1429, tokenIndex1429, depth1429 := position, tokenIndex, depth
+						position2359, tokenIndex2359, depth2359 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l1430
+							goto l2360
 						}
 						position++
-						goto l1429
-					l1430:
-						position, tokenIndex, depth = position1429, tokenIndex1429, depth1429
+						goto l2359
+					l2360:
+						position, tokenIndex, depth = position2359, tokenIndex2359, depth2359
 						if buffer[position] != rune('S') {
-							goto l1424
+							goto l2356
 						}
 						position++
 					}
-				l1429:
+				l2359:
 					{
-						position1431, tokenIndex1431, depth1431 := position, tokenIndex, depth
+						position2361, tokenIndex2361, depth2361 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l1432
+							goto l2362
 						}
 						position++
-						goto l1431
-					l1432:
-						position, tokenIndex, depth = position1431, tokenIndex1431, depth1431
+						goto l2361
+					l2362:
+						position, tokenIndex, depth = position2361, tokenIndex2361, depth2361
 						if buffer[position] != rune('T') {
-							goto l1424
+							goto l2356
 						}
 						position++
 					}
-				l1431:
+				l2361:
 					{
-						position1433, tokenIndex1433, depth1433 := position, tokenIndex, depth
+						position2363, tokenIndex2363, depth2363 := position, tokenIndex, depth
 						if buffer[position] != rune('r') {
-							goto l1434
+							goto l2364
 						}
 						position++
-						goto l1433
-					l1434:
-						position, tokenIndex, depth = position1433, tokenIndex1433, depth1433
+						goto l2363
+					l2364:
+						position, tokenIndex, depth = position2363, tokenIndex2363, depth2363
 						if buffer[position] != rune('R') {
-							goto l1424
+							goto l2356
 						}
 						position++
 					}
-				l1433:
+				l2363:
 					{
-						position1435, tokenIndex1435, depth1435 := position, tokenIndex, depth
+						position2365, tokenIndex2365, depth2365 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l1436
+							goto l2366
 						}
 						position++
-						goto l1435
-					l1436:
-						position, tokenIndex, depth = position1435, tokenIndex1435, depth1435
+						goto l2365
+					l2366:
+						position, tokenIndex, depth = position2365, tokenIndex2365, depth2365
 						if buffer[position] != rune('E') {
-							goto l1424
+							goto l2356
 						}
 						position++
 					}
-				l1435:
+				l2365:
 					{
-						position1437, tokenIndex1437, depth1437 := position, tokenIndex, depth
+						position2367, tokenIndex2367, depth2367 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l1438
+							goto l2368
 						}
 						position++
-						goto l1437
-					l1438:
-						position, tokenIndex, depth = position1437, tokenIndex1437, depth1437
+						goto l2367
+					l2368:
+						position, tokenIndex, depth = position2367, tokenIndex2367, depth2367
 						if buffer[position] != rune('A') {
-							goto l1424
+							goto l2356
 						}
 						position++
 					}
-				l1437:
+				l2367:
 					{
-						position1439, tokenIndex1439, depth1439 := position, tokenIndex, depth
+						position2369, tokenIndex2369, depth2369 := position, tokenIndex, depth
 						if buffer[position] != rune('m') {
-							goto l1440
+							goto l2370
 						}
 						position++
-						goto l1439
-					l1440:
-						position, tokenIndex, depth = position1439, tokenIndex1439, depth1439
+						goto l2369
+					l2370:
+						position, tokenIndex, depth = position2369, tokenIndex2369, depth2369
 						if buffer[position] != rune('M') {
-							goto l1424
+							goto l2356
+						}
+						position++
+					}
+				l2369:
+					{
+						position2371, tokenIndex2371, depth2371 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2372
+						}
+						position++
+						goto l2371
+					l2372:
+						position, tokenIndex, depth = position2371, tokenIndex2371, depth2371
+						if buffer[position] != rune('S') {
+							goto l2356
 						}
 						position++
 					}
-				l1439:
+				l2371:
 					depth--
-					add(rulePegText, position1426)
+					add(rulePegText, position2358)
 				}
-				if !_rules[ruleAction90]() {
-					goto l1424
+				if !_rules[ruleAction139]() {
+					goto l2356
 				}
 				depth--
-				add(ruleISTREAM, position1425)
+				add(ruleStreamsTarget, position2357)
 			}
 			return true
-		l1424:
-			position, tokenIndex, depth = position1424, tokenIndex1424, depth1424
+		l2356:
+			position, tokenIndex, depth = position2356, tokenIndex2356, depth2356
 			return false
 		},
-		/* 121 DSTREAM <- <(<(('d' / 'D') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action91)> */
+		/* 179 SinksTarget <- <(<(('s' / 'S') ('i' / 'I') ('n' / 'N') ('k' / 'K') ('s' / 'S'))> Action140)> */
 		func() bool {
-			position1441, tokenIndex1441, depth1441 := position, tokenIndex, depth
+			position2373, tokenIndex2373, depth2373 := position, tokenIndex, depth
 			{
-				position1442 := position
+				position2374 := position
 				depth++
 				{
-					position1443 := position
+					position2375 := position
 					depth++
 					{
-						position1444, tokenIndex1444, depth1444 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1445
-						}
-						position++
-						goto l1444
-					l1445:
-						position, tokenIndex, depth = position1444, tokenIndex1444, depth1444
-						if buffer[position] != rune('D') {
-							goto l1441
-						}
-						position++
-					}
-				l1444:
-					{
-						position1446, tokenIndex1446, depth1446 := position, tokenIndex, depth
+						position2376, tokenIndex2376, depth2376 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l1447
+							goto l2377
 						}
 						position++
-						goto l1446
-					l1447:
-						position, tokenIndex, depth = position1446, tokenIndex1446, depth1446
+						goto l2376
+					l2377:
+						position, tokenIndex, depth = position2376, tokenIndex2376, depth2376
 						if buffer[position] != rune('S') {
-							goto l1441
-						}
-						position++
-					}
-				l1446:
-					{
-						position1448, tokenIndex1448, depth1448 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1449
-						}
-						position++
-						goto l1448
-					l1449:
-						position, tokenIndex, depth = position1448, tokenIndex1448, depth1448
-						if buffer[position] != rune('T') {
-							goto l1441
+							goto l2373
 						}
 						position++
 					}
-				l1448:
+				l2376:
 					{
-						position1450, tokenIndex1450, depth1450 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1451
+						position2378, tokenIndex2378, depth2378 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2379
 						}
 						position++
-						goto l1450
-					l1451:
-						position, tokenIndex, depth = position1450, tokenIndex1450, depth1450
-						if buffer[position] != rune('R') {
-							goto l1441
+						goto l2378
+					l2379:
+						position, tokenIndex, depth = position2378, tokenIndex2378, depth2378
+						if buffer[position] != rune('I') {
+							goto l2373
 						}
 						position++
 					}
-				l1450:
+				l2378:
 					{
-						position1452, tokenIndex1452, depth1452 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1453
+						position2380, tokenIndex2380, depth2380 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2381
 						}
 						position++
-						goto l1452
-					l1453:
-						position, tokenIndex, depth = position1452, tokenIndex1452, depth1452
-						if buffer[position] != rune('E') {
-							goto l1441
+						goto l2380
+					l2381:
+						position, tokenIndex, depth = position2380, tokenIndex2380, depth2380
+						if buffer[position] != rune('N') {
+							goto l2373
 						}
 						position++
 					}
-				l1452:
+				l2380:
 					{
-						position1454, tokenIndex1454, depth1454 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1455
+						position2382, tokenIndex2382, depth2382 := position, tokenIndex, depth
+						if buffer[position] != rune('k') {
+							goto l2383
 						}
 						position++
-						goto l1454
-					l1455:
-						position, tokenIndex, depth = position1454, tokenIndex1454, depth1454
-						if buffer[position] != rune('A') {
-							goto l1441
+						goto l2382
+					l2383:
+						position, tokenIndex, depth = position2382, tokenIndex2382, depth2382
+						if buffer[position] != rune('K') {
+							goto l2373
 						}
 						position++
 					}
-				l1454:
+				l2382:
 					{
-						position1456, tokenIndex1456, depth1456 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1457
+						position2384, tokenIndex2384, depth2384 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2385
 						}
 						position++
-						goto l1456
-					l1457:
-						position, tokenIndex, depth = position1456, tokenIndex1456, depth1456
-						if buffer[position] != rune('M') {
-							goto l1441
+						goto l2384
+					l2385:
+						position, tokenIndex, depth = position2384, tokenIndex2384, depth2384
+						if buffer[position] != rune('S') {
+							goto l2373
 						}
 						position++
 					}
-				l1456:
+				l2384:
 					depth--
-					add(rulePegText, position1443)
+					add(rulePegText, position2375)
 				}
-				if !_rules[ruleAction91]() {
-					goto l1441
+				if !_rules[ruleAction140]() {
+					goto l2373
 				}
 				depth--
-				add(ruleDSTREAM, position1442)
+				add(ruleSinksTarget, position2374)
 			}
 			return true
-		l1441:
-			position, tokenIndex, depth = position1441, tokenIndex1441, depth1441
+		l2373:
+			position, tokenIndex, depth = position2373, tokenIndex2373, depth2373
 			return false
 		},
-		/* 122 RSTREAM <- <(<(('r' / 'R') ('s' / 'S') ('t' / 'T') ('r' / 'R') ('e' / 'E') ('a' / 'A') ('m' / 'M'))> Action92)> */
+		/* 180 StatesTarget <- <(<(('s' / 'S') ('t' / 'T') ('a' / 'A') ('t' / 'T') ('e' / 'E') ('s' / 'S'))> Action141)> */
 		func() bool {
-			position1458, tokenIndex1458, depth1458 := position, tokenIndex, depth
+			position2386, tokenIndex2386, depth2386 := position, tokenIndex, depth
 			{
-				position1459 := position
+				position2387 := position
 				depth++
 				{
-					position1460 := position
+					position2388 := position
 					depth++
 					{
-						position1461, tokenIndex1461, depth1461 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1462
+						position2389, tokenIndex2389, depth2389 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2390
 						}
 						position++
-						goto l1461
-					l1462:
-						position, tokenIndex, depth = position1461, tokenIndex1461, depth1461
-						if buffer[position] != rune('R') {
-							goto l1458
+						goto l2389
+					l2390:
+						position, tokenIndex, depth = position2389, tokenIndex2389, depth2389
+						if buffer[position] != rune('S') {
+							goto l2386
 						}
 						position++
 					}
-				l1461:
+				l2389:
 					{
-						position1463, tokenIndex1463, depth1463 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1464
+						position2391, tokenIndex2391, depth2391 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2392
 						}
 						position++
-						goto l1463
-					l1464:
-						position, tokenIndex, depth = position1463, tokenIndex1463, depth1463
-						if buffer[position] != rune('S') {
-							goto l1458
+						goto l2391
+					l2392:
+						position, tokenIndex, depth = position2391, tokenIndex2391, depth2391
+						if buffer[position] != rune('T') {
+							goto l2386
 						}
 						position++
 					}
-				l1463:
+				l2391:
 					{
-						position1465, tokenIndex1465, depth1465 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1466
+						position2393, tokenIndex2393, depth2393 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2394
 						}
 						position++
-						goto l1465
-					l1466:
-						position, tokenIndex, depth = position1465, tokenIndex1465, depth1465
-						if buffer[position] != rune('T') {
-							goto l1458
+						goto l2393
+					l2394:
+						position, tokenIndex, depth = position2393, tokenIndex2393, depth2393
+						if buffer[position] != rune('A') {
+							goto l2386
 						}
 						position++
 					}
-				l1465:
+				l2393:
 					{
-						position1467, tokenIndex1467, depth1467 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1468
+						position2395, tokenIndex2395, depth2395 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2396
 						}
 						position++
-						goto l1467
-					l1468:
-						position, tokenIndex, depth = position1467, tokenIndex1467, depth1467
-						if buffer[position] != rune('R') {
-							goto l1458
+						goto l2395
+					l2396:
+						position, tokenIndex, depth = position2395, tokenIndex2395, depth2395
+						if buffer[position] != rune('T') {
+							goto l2386
 						}
 						position++
 					}
-				l1467:
+				l2395:
 					{
-						position1469, tokenIndex1469, depth1469 := position, tokenIndex, depth
+						position2397, tokenIndex2397, depth2397 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l1470
+							goto l2398
 						}
 						position++
-						goto l1469
-					l1470:
-						position, tokenIndex, depth = position1469, tokenIndex1469, depth1469
+						goto l2397
+					l2398:
+						position, tokenIndex, depth = position2397, tokenIndex2397, depth2397
 						if buffer[position] != rune('E') {
-							goto l1458
+							goto l2386
 						}
 						position++
 					}
-				l1469:
+				l2397:
 					{
-						position1471, tokenIndex1471, depth1471 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1472
+						position2399, tokenIndex2399, depth2399 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2400
 						}
 						position++
-						goto l1471
-					l1472:
-						position, tokenIndex, depth = position1471, tokenIndex1471, depth1471
-						if buffer[position] != rune('A') {
-							goto l1458
+						goto l2399
+					l2400:
+						position, tokenIndex, depth = position2399, tokenIndex2399, depth2399
+						if buffer[position] != rune('S') {
+							goto l2386
+						}
+						position++
+					}
+				l2399:
+					depth--
+					add(rulePegText, position2388)
+				}
+				if !_rules[ruleAction141]() {
+					goto l2386
+				}
+				depth--
+				add(ruleStatesTarget, position2387)
+			}
+			return true
+		l2386:
+			position, tokenIndex, depth = position2386, tokenIndex2386, depth2386
+			return false
+		},
+		/* 181 UDFsTarget <- <(<(('u' / 'U') ('d' / 'D') ('f' / 'F') ('s' / 'S'))> Action142)> */
+		func() bool {
+			position2401, tokenIndex2401, depth2401 := position, tokenIndex, depth
+			{
+				position2402 := position
+				depth++
+				{
+					position2403 := position
+					depth++
+					{
+						position2404, tokenIndex2404, depth2404 := position, tokenIndex, depth
+						if buffer[position] != rune('u') {
+							goto l2405
+						}
+						position++
+						goto l2404
+					l2405:
+						position, tokenIndex, depth = position2404, tokenIndex2404, depth2404
+						if buffer[position] != rune('U') {
+							goto l2401
 						}
 						position++
 					}
-				l1471:
+				l2404:
 					{
-						position1473, tokenIndex1473, depth1473 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1474
+						position2406, tokenIndex2406, depth2406 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2407
+						}
+						position++
+						goto l2406
+					l2407:
+						position, tokenIndex, depth = position2406, tokenIndex2406, depth2406
+						if buffer[position] != rune('D') {
+							goto l2401
+						}
+						position++
+					}
+				l2406:
+					{
+						position2408, tokenIndex2408, depth2408 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2409
+						}
+						position++
+						goto l2408
+					l2409:
+						position, tokenIndex, depth = position2408, tokenIndex2408, depth2408
+						if buffer[position] != rune('F') {
+							goto l2401
+						}
+						position++
+					}
+				l2408:
+					{
+						position2410, tokenIndex2410, depth2410 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2411
 						}
 						position++
-						goto l1473
-					l1474:
-						position, tokenIndex, depth = position1473, tokenIndex1473, depth1473
-						if buffer[position] != rune('M') {
-							goto l1458
+						goto l2410
+					l2411:
+						position, tokenIndex, depth = position2410, tokenIndex2410, depth2410
+						if buffer[position] != rune('S') {
+							goto l2401
 						}
 						position++
 					}
-				l1473:
+				l2410:
 					depth--
-					add(rulePegText, position1460)
+					add(rulePegText, position2403)
 				}
-				if !_rules[ruleAction92]() {
-					goto l1458
+				if !_rules[ruleAction142]() {
+					goto l2401
 				}
 				depth--
-				add(ruleRSTREAM, position1459)
+				add(ruleUDFsTarget, position2402)
 			}
 			return true
-		l1458:
-			position, tokenIndex, depth = position1458, tokenIndex1458, depth1458
+		l2401:
+			position, tokenIndex, depth = position2401, tokenIndex2401, depth2401
 			return false
 		},
-		/* 123 TUPLES <- <(<(('t' / 'T') ('u' / 'U') ('p' / 'P') ('l' / 'L') ('e' / 'E') ('s' / 'S'))> Action93)> */
+		/* 182 Ascending <- <(<(('a' / 'A') ('s' / 'S') ('c' / 'C'))> Action143)> */
 		func() bool {
-			position1475, tokenIndex1475, depth1475 := position, tokenIndex, depth
+			position2412, tokenIndex2412, depth2412 := position, tokenIndex, depth
 			{
-				position1476 := position
+				position2413 := position
 				depth++
 				{
-					position1477 := position
+					position2414 := position
 					depth++
 					{
-						position1478, tokenIndex1478, depth1478 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1479
+						position2415, tokenIndex2415, depth2415 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2416
 						}
 						position++
-						goto l1478
-					l1479:
-						position, tokenIndex, depth = position1478, tokenIndex1478, depth1478
-						if buffer[position] != rune('T') {
-							goto l1475
+						goto l2415
+					l2416:
+						position, tokenIndex, depth = position2415, tokenIndex2415, depth2415
+						if buffer[position] != rune('A') {
+							goto l2412
 						}
 						position++
 					}
-				l1478:
+				l2415:
 					{
-						position1480, tokenIndex1480, depth1480 := position, tokenIndex, depth
-						if buffer[position] != rune('u') {
-							goto l1481
+						position2417, tokenIndex2417, depth2417 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2418
 						}
 						position++
-						goto l1480
-					l1481:
-						position, tokenIndex, depth = position1480, tokenIndex1480, depth1480
-						if buffer[position] != rune('U') {
-							goto l1475
+						goto l2417
+					l2418:
+						position, tokenIndex, depth = position2417, tokenIndex2417, depth2417
+						if buffer[position] != rune('S') {
+							goto l2412
 						}
 						position++
 					}
-				l1480:
+				l2417:
 					{
-						position1482, tokenIndex1482, depth1482 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1483
+						position2419, tokenIndex2419, depth2419 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2420
 						}
 						position++
-						goto l1482
-					l1483:
-						position, tokenIndex, depth = position1482, tokenIndex1482, depth1482
-						if buffer[position] != rune('P') {
-							goto l1475
+						goto l2419
+					l2420:
+						position, tokenIndex, depth = position2419, tokenIndex2419, depth2419
+						if buffer[position] != rune('C') {
+							goto l2412
 						}
 						position++
 					}
-				l1482:
+				l2419:
+					depth--
+					add(rulePegText, position2414)
+				}
+				if !_rules[ruleAction143]() {
+					goto l2412
+				}
+				depth--
+				add(ruleAscending, position2413)
+			}
+			return true
+		l2412:
+			position, tokenIndex, depth = position2412, tokenIndex2412, depth2412
+			return false
+		},
+		/* 183 Descending <- <(<(('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C'))> Action144)> */
+		func() bool {
+			position2421, tokenIndex2421, depth2421 := position, tokenIndex, depth
+			{
+				position2422 := position
+				depth++
+				{
+					position2423 := position
+					depth++
 					{
-						position1484, tokenIndex1484, depth1484 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1485
+						position2424, tokenIndex2424, depth2424 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2425
 						}
 						position++
-						goto l1484
-					l1485:
-						position, tokenIndex, depth = position1484, tokenIndex1484, depth1484
-						if buffer[position] != rune('L') {
-							goto l1475
+						goto l2424
+					l2425:
+						position, tokenIndex, depth = position2424, tokenIndex2424, depth2424
+						if buffer[position] != rune('D') {
+							goto l2421
 						}
 						position++
 					}
-				l1484:
+				l2424:
 					{
-						position1486, tokenIndex1486, depth1486 := position, tokenIndex, depth
+						position2426, tokenIndex2426, depth2426 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l1487
+							goto l2427
 						}
 						position++
-						goto l1486
-					l1487:
-						position, tokenIndex, depth = position1486, tokenIndex1486, depth1486
+						goto l2426
+					l2427:
+						position, tokenIndex, depth = position2426, tokenIndex2426, depth2426
 						if buffer[position] != rune('E') {
-							goto l1475
+							goto l2421
 						}
 						position++
 					}
-				l1486:
+				l2426:
 					{
-						position1488, tokenIndex1488, depth1488 := position, tokenIndex, depth
+						position2428, tokenIndex2428, depth2428 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l1489
+							goto l2429
 						}
 						position++
-						goto l1488
-					l1489:
-						position, tokenIndex, depth = position1488, tokenIndex1488, depth1488
+						goto l2428
+					l2429:
+						position, tokenIndex, depth = position2428, tokenIndex2428, depth2428
 						if buffer[position] != rune('S') {
-							goto l1475
+							goto l2421
+						}
+						position++
+					}
+				l2428:
+					{
+						position2430, tokenIndex2430, depth2430 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2431
+						}
+						position++
+						goto l2430
+					l2431:
+						position, tokenIndex, depth = position2430, tokenIndex2430, depth2430
+						if buffer[position] != rune('C') {
+							goto l2421
 						}
 						position++
 					}
-				l1488:
+				l2430:
 					depth--
-					add(rulePegText, position1477)
+					add(rulePegText, position2423)
 				}
-				if !_rules[ruleAction93]() {
-					goto l1475
+				if !_rules[ruleAction144]() {
+					goto l2421
 				}
 				depth--
-				add(ruleTUPLES, position1476)
+				add(ruleDescending, position2422)
 			}
 			return true
-		l1475:
-			position, tokenIndex, depth = position1475, tokenIndex1475, depth1475
+		l2421:
+			position, tokenIndex, depth = position2421, tokenIndex2421, depth2421
 			return false
 		},
-		/* 124 SECONDS <- <(<(('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action94)> */
+		/* 184 NullsFirst <- <(<(('f' / 'F') ('i' / 'I') ('r' / 'R') ('s' / 'S') ('t' / 'T'))> Action145)> */
 		func() bool {
-			position1490, tokenIndex1490, depth1490 := position, tokenIndex, depth
+			position2432, tokenIndex2432, depth2432 := position, tokenIndex, depth
 			{
-				position1491 := position
+				position2433 := position
 				depth++
 				{
-					position1492 := position
+					position2434 := position
 					depth++
 					{
-						position1493, tokenIndex1493, depth1493 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1494
-						}
-						position++
-						goto l1493
-					l1494:
-						position, tokenIndex, depth = position1493, tokenIndex1493, depth1493
-						if buffer[position] != rune('S') {
-							goto l1490
-						}
-						position++
-					}
-				l1493:
-					{
-						position1495, tokenIndex1495, depth1495 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1496
-						}
-						position++
-						goto l1495
-					l1496:
-						position, tokenIndex, depth = position1495, tokenIndex1495, depth1495
-						if buffer[position] != rune('E') {
-							goto l1490
-						}
-						position++
-					}
-				l1495:
-					{
-						position1497, tokenIndex1497, depth1497 := position, tokenIndex, depth
-						if buffer[position] != rune('c') {
-							goto l1498
+						position2435, tokenIndex2435, depth2435 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2436
 						}
 						position++
-						goto l1497
-					l1498:
-						position, tokenIndex, depth = position1497, tokenIndex1497, depth1497
-						if buffer[position] != rune('C') {
-							goto l1490
+						goto l2435
+					l2436:
+						position, tokenIndex, depth = position2435, tokenIndex2435, depth2435
+						if buffer[position] != rune('F') {
+							goto l2432
 						}
 						position++
 					}
-				l1497:
+				l2435:
 					{
-						position1499, tokenIndex1499, depth1499 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1500
+						position2437, tokenIndex2437, depth2437 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2438
 						}
 						position++
-						goto l1499
-					l1500:
-						position, tokenIndex, depth = position1499, tokenIndex1499, depth1499
-						if buffer[position] != rune('O') {
-							goto l1490
+						goto l2437
+					l2438:
+						position, tokenIndex, depth = position2437, tokenIndex2437, depth2437
+						if buffer[position] != rune('I') {
+							goto l2432
 						}
 						position++
 					}
-				l1499:
+				l2437:
 					{
-						position1501, tokenIndex1501, depth1501 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1502
+						position2439, tokenIndex2439, depth2439 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2440
 						}
 						position++
-						goto l1501
-					l1502:
-						position, tokenIndex, depth = position1501, tokenIndex1501, depth1501
-						if buffer[position] != rune('N') {
-							goto l1490
+						goto l2439
+					l2440:
+						position, tokenIndex, depth = position2439, tokenIndex2439, depth2439
+						if buffer[position] != rune('R') {
+							goto l2432
 						}
 						position++
 					}
-				l1501:
+				l2439:
 					{
-						position1503, tokenIndex1503, depth1503 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1504
+						position2441, tokenIndex2441, depth2441 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2442
 						}
 						position++
-						goto l1503
-					l1504:
-						position, tokenIndex, depth = position1503, tokenIndex1503, depth1503
-						if buffer[position] != rune('D') {
-							goto l1490
+						goto l2441
+					l2442:
+						position, tokenIndex, depth = position2441, tokenIndex2441, depth2441
+						if buffer[position] != rune('S') {
+							goto l2432
 						}
 						position++
 					}
-				l1503:
+				l2441:
 					{
-						position1505, tokenIndex1505, depth1505 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1506
+						position2443, tokenIndex2443, depth2443 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2444
 						}
 						position++
-						goto l1505
-					l1506:
-						position, tokenIndex, depth = position1505, tokenIndex1505, depth1505
-						if buffer[position] != rune('S') {
-							goto l1490
+						goto l2443
+					l2444:
+						position, tokenIndex, depth = position2443, tokenIndex2443, depth2443
+						if buffer[position] != rune('T') {
+							goto l2432
 						}
 						position++
 					}
-				l1505:
+				l2443:
 					depth--
-					add(rulePegText, position1492)
+					add(rulePegText, position2434)
 				}
-				if !_rules[ruleAction94]() {
-					goto l1490
+				if !_rules[ruleAction145]() {
+					goto l2432
 				}
 				depth--
-				add(ruleSECONDS, position1491)
+				add(ruleNullsFirst, position2433)
 			}
 			return true
-		l1490:
-			position, tokenIndex, depth = position1490, tokenIndex1490, depth1490
+		l2432:
+			position, tokenIndex, depth = position2432, tokenIndex2432, depth2432
 			return false
 		},
-		/* 125 MILLISECONDS <- <(<(('m' / 'M') ('i' / 'I') ('l' / 'L') ('l' / 'L') ('i' / 'I') ('s' / 'S') ('e' / 'E') ('c' / 'C') ('o' / 'O') ('n' / 'N') ('d' / 'D') ('s' / 'S'))> Action95)> */
+		/* 185 NullsLast <- <(<(('l' / 'L') ('a' / 'A') ('s' / 'S') ('t' / 'T'))> Action146)> */
 		func() bool {
-			position1507, tokenIndex1507, depth1507 := position, tokenIndex, depth
+			position2445, tokenIndex2445, depth2445 := position, tokenIndex, depth
 			{
-				position1508 := position
+				position2446 := position
 				depth++
 				{
-					position1509 := position
+					position2447 := position
 					depth++
 					{
-						position1510, tokenIndex1510, depth1510 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1511
+						position2448, tokenIndex2448, depth2448 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2449
 						}
 						position++
-						goto l1510
-					l1511:
-						position, tokenIndex, depth = position1510, tokenIndex1510, depth1510
-						if buffer[position] != rune('M') {
-							goto l1507
+						goto l2448
+					l2449:
+						position, tokenIndex, depth = position2448, tokenIndex2448, depth2448
+						if buffer[position] != rune('L') {
+							goto l2445
 						}
 						position++
 					}
-				l1510:
+				l2448:
 					{
-						position1512, tokenIndex1512, depth1512 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1513
+						position2450, tokenIndex2450, depth2450 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2451
 						}
 						position++
-						goto l1512
-					l1513:
-						position, tokenIndex, depth = position1512, tokenIndex1512, depth1512
-						if buffer[position] != rune('I') {
-							goto l1507
+						goto l2450
+					l2451:
+						position, tokenIndex, depth = position2450, tokenIndex2450, depth2450
+						if buffer[position] != rune('A') {
+							goto l2445
 						}
 						position++
 					}
-				l1512:
+				l2450:
 					{
-						position1514, tokenIndex1514, depth1514 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1515
+						position2452, tokenIndex2452, depth2452 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2453
 						}
 						position++
-						goto l1514
-					l1515:
-						position, tokenIndex, depth = position1514, tokenIndex1514, depth1514
-						if buffer[position] != rune('L') {
-							goto l1507
+						goto l2452
+					l2453:
+						position, tokenIndex, depth = position2452, tokenIndex2452, depth2452
+						if buffer[position] != rune('S') {
+							goto l2445
 						}
 						position++
 					}
-				l1514:
+				l2452:
 					{
-						position1516, tokenIndex1516, depth1516 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1517
+						position2454, tokenIndex2454, depth2454 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2455
 						}
 						position++
-						goto l1516
-					l1517:
-						position, tokenIndex, depth = position1516, tokenIndex1516, depth1516
-						if buffer[position] != rune('L') {
-							goto l1507
+						goto l2454
+					l2455:
+						position, tokenIndex, depth = position2454, tokenIndex2454, depth2454
+						if buffer[position] != rune('T') {
+							goto l2445
 						}
 						position++
 					}
-				l1516:
+				l2454:
+					depth--
+					add(rulePegText, position2447)
+				}
+				if !_rules[ruleAction146]() {
+					goto l2445
+				}
+				depth--
+				add(ruleNullsLast, position2446)
+			}
+			return true
+		l2445:
+			position, tokenIndex, depth = position2445, tokenIndex2445, depth2445
+			return false
+		},
+		/* 186 Type <- <(Bool / Int / Float / String / Blob / Timestamp / Array / Map / Vector)> */
+		func() bool {
+			position2456, tokenIndex2456, depth2456 := position, tokenIndex, depth
+			{
+				position2457 := position
+				depth++
+				{
+					position2458, tokenIndex2458, depth2458 := position, tokenIndex, depth
+					if !_rules[ruleBool]() {
+						goto l2459
+					}
+					goto l2458
+				l2459:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleInt]() {
+						goto l2460
+					}
+					goto l2458
+				l2460:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleFloat]() {
+						goto l2461
+					}
+					goto l2458
+				l2461:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleString]() {
+						goto l2462
+					}
+					goto l2458
+				l2462:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleBlob]() {
+						goto l2463
+					}
+					goto l2458
+				l2463:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleTimestamp]() {
+						goto l2464
+					}
+					goto l2458
+				l2464:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleArray]() {
+						goto l2465
+					}
+					goto l2458
+				l2465:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleMap]() {
+						goto l2466
+					}
+					goto l2458
+				l2466:
+					position, tokenIndex, depth = position2458, tokenIndex2458, depth2458
+					if !_rules[ruleVector]() {
+						goto l2456
+					}
+				}
+			l2458:
+				depth--
+				add(ruleType, position2457)
+			}
+			return true
+		l2456:
+			position, tokenIndex, depth = position2456, tokenIndex2456, depth2456
+			return false
+		},
+		/* 187 Bool <- <(<(('b' / 'B') ('o' / 'O') ('o' / 'O') ('l' / 'L'))> Action147)> */
+		func() bool {
+			position2467, tokenIndex2467, depth2467 := position, tokenIndex, depth
+			{
+				position2468 := position
+				depth++
+				{
+					position2469 := position
+					depth++
 					{
-						position1518, tokenIndex1518, depth1518 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1519
+						position2470, tokenIndex2470, depth2470 := position, tokenIndex, depth
+						if buffer[position] != rune('b') {
+							goto l2471
 						}
 						position++
-						goto l1518
-					l1519:
-						position, tokenIndex, depth = position1518, tokenIndex1518, depth1518
-						if buffer[position] != rune('I') {
-							goto l1507
+						goto l2470
+					l2471:
+						position, tokenIndex, depth = position2470, tokenIndex2470, depth2470
+						if buffer[position] != rune('B') {
+							goto l2467
 						}
 						position++
 					}
-				l1518:
+				l2470:
 					{
-						position1520, tokenIndex1520, depth1520 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1521
+						position2472, tokenIndex2472, depth2472 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2473
 						}
 						position++
-						goto l1520
-					l1521:
-						position, tokenIndex, depth = position1520, tokenIndex1520, depth1520
-						if buffer[position] != rune('S') {
-							goto l1507
+						goto l2472
+					l2473:
+						position, tokenIndex, depth = position2472, tokenIndex2472, depth2472
+						if buffer[position] != rune('O') {
+							goto l2467
 						}
 						position++
 					}
-				l1520:
+				l2472:
 					{
-						position1522, tokenIndex1522, depth1522 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1523
+						position2474, tokenIndex2474, depth2474 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2475
 						}
 						position++
-						goto l1522
-					l1523:
-						position, tokenIndex, depth = position1522, tokenIndex1522, depth1522
-						if buffer[position] != rune('E') {
-							goto l1507
+						goto l2474
+					l2475:
+						position, tokenIndex, depth = position2474, tokenIndex2474, depth2474
+						if buffer[position] != rune('O') {
+							goto l2467
 						}
 						position++
 					}
-				l1522:
+				l2474:
 					{
-						position1524, tokenIndex1524, depth1524 := position, tokenIndex, depth
-						if buffer[position] != rune('c') {
-							goto l1525
+						position2476, tokenIndex2476, depth2476 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2477
 						}
 						position++
-						goto l1524
-					l1525:
-						position, tokenIndex, depth = position1524, tokenIndex1524, depth1524
-						if buffer[position] != rune('C') {
-							goto l1507
+						goto l2476
+					l2477:
+						position, tokenIndex, depth = position2476, tokenIndex2476, depth2476
+						if buffer[position] != rune('L') {
+							goto l2467
 						}
 						position++
 					}
-				l1524:
+				l2476:
+					depth--
+					add(rulePegText, position2469)
+				}
+				if !_rules[ruleAction147]() {
+					goto l2467
+				}
+				depth--
+				add(ruleBool, position2468)
+			}
+			return true
+		l2467:
+			position, tokenIndex, depth = position2467, tokenIndex2467, depth2467
+			return false
+		},
+		/* 188 Int <- <(<(('i' / 'I') ('n' / 'N') ('t' / 'T'))> Action148)> */
+		func() bool {
+			position2478, tokenIndex2478, depth2478 := position, tokenIndex, depth
+			{
+				position2479 := position
+				depth++
+				{
+					position2480 := position
+					depth++
 					{
-						position1526, tokenIndex1526, depth1526 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1527
+						position2481, tokenIndex2481, depth2481 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2482
 						}
 						position++
-						goto l1526
-					l1527:
-						position, tokenIndex, depth = position1526, tokenIndex1526, depth1526
-						if buffer[position] != rune('O') {
-							goto l1507
+						goto l2481
+					l2482:
+						position, tokenIndex, depth = position2481, tokenIndex2481, depth2481
+						if buffer[position] != rune('I') {
+							goto l2478
 						}
 						position++
 					}
-				l1526:
+				l2481:
 					{
-						position1528, tokenIndex1528, depth1528 := position, tokenIndex, depth
+						position2483, tokenIndex2483, depth2483 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l1529
+							goto l2484
 						}
 						position++
-						goto l1528
-					l1529:
-						position, tokenIndex, depth = position1528, tokenIndex1528, depth1528
+						goto l2483
+					l2484:
+						position, tokenIndex, depth = position2483, tokenIndex2483, depth2483
 						if buffer[position] != rune('N') {
-							goto l1507
-						}
-						position++
-					}
-				l1528:
-					{
-						position1530, tokenIndex1530, depth1530 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1531
-						}
-						position++
-						goto l1530
-					l1531:
-						position, tokenIndex, depth = position1530, tokenIndex1530, depth1530
-						if buffer[position] != rune('D') {
-							goto l1507
+							goto l2478
 						}
 						position++
 					}
-				l1530:
+				l2483:
 					{
-						position1532, tokenIndex1532, depth1532 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1533
+						position2485, tokenIndex2485, depth2485 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2486
 						}
 						position++
-						goto l1532
-					l1533:
-						position, tokenIndex, depth = position1532, tokenIndex1532, depth1532
-						if buffer[position] != rune('S') {
-							goto l1507
+						goto l2485
+					l2486:
+						position, tokenIndex, depth = position2485, tokenIndex2485, depth2485
+						if buffer[position] != rune('T') {
+							goto l2478
 						}
 						position++
 					}
-				l1532:
+				l2485:
 					depth--
-					add(rulePegText, position1509)
+					add(rulePegText, position2480)
 				}
-				if !_rules[ruleAction95]() {
-					goto l1507
+				if !_rules[ruleAction148]() {
+					goto l2478
 				}
 				depth--
-				add(ruleMILLISECONDS, position1508)
+				add(ruleInt, position2479)
 			}
 			return true
-		l1507:
-			position, tokenIndex, depth = position1507, tokenIndex1507, depth1507
+		l2478:
+			position, tokenIndex, depth = position2478, tokenIndex2478, depth2478
 			return false
 		},
-		/* 126 Wait <- <(<(('w' / 'W') ('a' / 'A') ('i' / 'I') ('t' / 'T'))> Action96)> */
+		/* 189 Float <- <(<(('f' / 'F') ('l' / 'L') ('o' / 'O') ('a' / 'A') ('t' / 'T'))> Action149)> */
 		func() bool {
-			position1534, tokenIndex1534, depth1534 := position, tokenIndex, depth
+			position2487, tokenIndex2487, depth2487 := position, tokenIndex, depth
 			{
-				position1535 := position
+				position2488 := position
 				depth++
 				{
-					position1536 := position
+					position2489 := position
 					depth++
 					{
-						position1537, tokenIndex1537, depth1537 := position, tokenIndex, depth
-						if buffer[position] != rune('w') {
-							goto l1538
+						position2490, tokenIndex2490, depth2490 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2491
 						}
 						position++
-						goto l1537
-					l1538:
-						position, tokenIndex, depth = position1537, tokenIndex1537, depth1537
-						if buffer[position] != rune('W') {
-							goto l1534
+						goto l2490
+					l2491:
+						position, tokenIndex, depth = position2490, tokenIndex2490, depth2490
+						if buffer[position] != rune('F') {
+							goto l2487
 						}
 						position++
 					}
-				l1537:
+				l2490:
 					{
-						position1539, tokenIndex1539, depth1539 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1540
+						position2492, tokenIndex2492, depth2492 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2493
 						}
 						position++
-						goto l1539
-					l1540:
-						position, tokenIndex, depth = position1539, tokenIndex1539, depth1539
-						if buffer[position] != rune('A') {
-							goto l1534
+						goto l2492
+					l2493:
+						position, tokenIndex, depth = position2492, tokenIndex2492, depth2492
+						if buffer[position] != rune('L') {
+							goto l2487
 						}
 						position++
 					}
-				l1539:
+				l2492:
 					{
-						position1541, tokenIndex1541, depth1541 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1542
+						position2494, tokenIndex2494, depth2494 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2495
 						}
 						position++
-						goto l1541
-					l1542:
-						position, tokenIndex, depth = position1541, tokenIndex1541, depth1541
-						if buffer[position] != rune('I') {
-							goto l1534
+						goto l2494
+					l2495:
+						position, tokenIndex, depth = position2494, tokenIndex2494, depth2494
+						if buffer[position] != rune('O') {
+							goto l2487
+						}
+						position++
+					}
+				l2494:
+					{
+						position2496, tokenIndex2496, depth2496 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2497
+						}
+						position++
+						goto l2496
+					l2497:
+						position, tokenIndex, depth = position2496, tokenIndex2496, depth2496
+						if buffer[position] != rune('A') {
+							goto l2487
 						}
 						position++
 					}
-				l1541:
+				l2496:
 					{
-						position1543, tokenIndex1543, depth1543 := position, tokenIndex, depth
+						position2498, tokenIndex2498, depth2498 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l1544
+							goto l2499
 						}
 						position++
-						goto l1543
-					l1544:
-						position, tokenIndex, depth = position1543, tokenIndex1543, depth1543
+						goto l2498
+					l2499:
+						position, tokenIndex, depth = position2498, tokenIndex2498, depth2498
 						if buffer[position] != rune('T') {
-							goto l1534
+							goto l2487
 						}
 						position++
 					}
-				l1543:
+				l2498:
 					depth--
-					add(rulePegText, position1536)
+					add(rulePegText, position2489)
 				}
-				if !_rules[ruleAction96]() {
-					goto l1534
+				if !_rules[ruleAction149]() {
+					goto l2487
 				}
 				depth--
-				add(ruleWait, position1535)
+				add(ruleFloat, position2488)
 			}
 			return true
-		l1534:
-			position, tokenIndex, depth = position1534, tokenIndex1534, depth1534
+		l2487:
+			position, tokenIndex, depth = position2487, tokenIndex2487, depth2487
 			return false
 		},
-		/* 127 DropOldest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('o' / 'O') ('l' / 'L') ('d' / 'D') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action97)> */
+		/* 190 String <- <(<(('s' / 'S') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action150)> */
 		func() bool {
-			position1545, tokenIndex1545, depth1545 := position, tokenIndex, depth
+			position2500, tokenIndex2500, depth2500 := position, tokenIndex, depth
 			{
-				position1546 := position
+				position2501 := position
 				depth++
 				{
-					position1547 := position
+					position2502 := position
 					depth++
 					{
-						position1548, tokenIndex1548, depth1548 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1549
+						position2503, tokenIndex2503, depth2503 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2504
 						}
 						position++
-						goto l1548
-					l1549:
-						position, tokenIndex, depth = position1548, tokenIndex1548, depth1548
-						if buffer[position] != rune('D') {
-							goto l1545
+						goto l2503
+					l2504:
+						position, tokenIndex, depth = position2503, tokenIndex2503, depth2503
+						if buffer[position] != rune('S') {
+							goto l2500
 						}
 						position++
 					}
-				l1548:
+				l2503:
 					{
-						position1550, tokenIndex1550, depth1550 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1551
+						position2505, tokenIndex2505, depth2505 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2506
 						}
 						position++
-						goto l1550
-					l1551:
-						position, tokenIndex, depth = position1550, tokenIndex1550, depth1550
-						if buffer[position] != rune('R') {
-							goto l1545
+						goto l2505
+					l2506:
+						position, tokenIndex, depth = position2505, tokenIndex2505, depth2505
+						if buffer[position] != rune('T') {
+							goto l2500
 						}
 						position++
 					}
-				l1550:
+				l2505:
 					{
-						position1552, tokenIndex1552, depth1552 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1553
+						position2507, tokenIndex2507, depth2507 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2508
 						}
 						position++
-						goto l1552
-					l1553:
-						position, tokenIndex, depth = position1552, tokenIndex1552, depth1552
-						if buffer[position] != rune('O') {
-							goto l1545
+						goto l2507
+					l2508:
+						position, tokenIndex, depth = position2507, tokenIndex2507, depth2507
+						if buffer[position] != rune('R') {
+							goto l2500
 						}
 						position++
 					}
-				l1552:
+				l2507:
 					{
-						position1554, tokenIndex1554, depth1554 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1555
+						position2509, tokenIndex2509, depth2509 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2510
 						}
 						position++
-						goto l1554
-					l1555:
-						position, tokenIndex, depth = position1554, tokenIndex1554, depth1554
-						if buffer[position] != rune('P') {
-							goto l1545
+						goto l2509
+					l2510:
+						position, tokenIndex, depth = position2509, tokenIndex2509, depth2509
+						if buffer[position] != rune('I') {
+							goto l2500
 						}
 						position++
 					}
-				l1554:
-					if !_rules[rulesp]() {
-						goto l1545
-					}
+				l2509:
 					{
-						position1556, tokenIndex1556, depth1556 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1557
+						position2511, tokenIndex2511, depth2511 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2512
 						}
 						position++
-						goto l1556
-					l1557:
-						position, tokenIndex, depth = position1556, tokenIndex1556, depth1556
-						if buffer[position] != rune('O') {
-							goto l1545
+						goto l2511
+					l2512:
+						position, tokenIndex, depth = position2511, tokenIndex2511, depth2511
+						if buffer[position] != rune('N') {
+							goto l2500
 						}
 						position++
 					}
-				l1556:
+				l2511:
 					{
-						position1558, tokenIndex1558, depth1558 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1559
+						position2513, tokenIndex2513, depth2513 := position, tokenIndex, depth
+						if buffer[position] != rune('g') {
+							goto l2514
 						}
 						position++
-						goto l1558
-					l1559:
-						position, tokenIndex, depth = position1558, tokenIndex1558, depth1558
-						if buffer[position] != rune('L') {
-							goto l1545
+						goto l2513
+					l2514:
+						position, tokenIndex, depth = position2513, tokenIndex2513, depth2513
+						if buffer[position] != rune('G') {
+							goto l2500
 						}
 						position++
 					}
-				l1558:
+				l2513:
+					depth--
+					add(rulePegText, position2502)
+				}
+				if !_rules[ruleAction150]() {
+					goto l2500
+				}
+				depth--
+				add(ruleString, position2501)
+			}
+			return true
+		l2500:
+			position, tokenIndex, depth = position2500, tokenIndex2500, depth2500
+			return false
+		},
+		/* 191 Blob <- <(<(('b' / 'B') ('l' / 'L') ('o' / 'O') ('b' / 'B'))> Action151)> */
+		func() bool {
+			position2515, tokenIndex2515, depth2515 := position, tokenIndex, depth
+			{
+				position2516 := position
+				depth++
+				{
+					position2517 := position
+					depth++
 					{
-						position1560, tokenIndex1560, depth1560 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1561
+						position2518, tokenIndex2518, depth2518 := position, tokenIndex, depth
+						if buffer[position] != rune('b') {
+							goto l2519
 						}
 						position++
-						goto l1560
-					l1561:
-						position, tokenIndex, depth = position1560, tokenIndex1560, depth1560
-						if buffer[position] != rune('D') {
-							goto l1545
+						goto l2518
+					l2519:
+						position, tokenIndex, depth = position2518, tokenIndex2518, depth2518
+						if buffer[position] != rune('B') {
+							goto l2515
 						}
 						position++
 					}
-				l1560:
+				l2518:
 					{
-						position1562, tokenIndex1562, depth1562 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1563
+						position2520, tokenIndex2520, depth2520 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l2521
 						}
 						position++
-						goto l1562
-					l1563:
-						position, tokenIndex, depth = position1562, tokenIndex1562, depth1562
-						if buffer[position] != rune('E') {
-							goto l1545
+						goto l2520
+					l2521:
+						position, tokenIndex, depth = position2520, tokenIndex2520, depth2520
+						if buffer[position] != rune('L') {
+							goto l2515
 						}
 						position++
 					}
-				l1562:
+				l2520:
 					{
-						position1564, tokenIndex1564, depth1564 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1565
+						position2522, tokenIndex2522, depth2522 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2523
 						}
 						position++
-						goto l1564
-					l1565:
-						position, tokenIndex, depth = position1564, tokenIndex1564, depth1564
-						if buffer[position] != rune('S') {
-							goto l1545
+						goto l2522
+					l2523:
+						position, tokenIndex, depth = position2522, tokenIndex2522, depth2522
+						if buffer[position] != rune('O') {
+							goto l2515
 						}
 						position++
 					}
-				l1564:
+				l2522:
 					{
-						position1566, tokenIndex1566, depth1566 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1567
+						position2524, tokenIndex2524, depth2524 := position, tokenIndex, depth
+						if buffer[position] != rune('b') {
+							goto l2525
 						}
 						position++
-						goto l1566
-					l1567:
-						position, tokenIndex, depth = position1566, tokenIndex1566, depth1566
-						if buffer[position] != rune('T') {
-							goto l1545
+						goto l2524
+					l2525:
+						position, tokenIndex, depth = position2524, tokenIndex2524, depth2524
+						if buffer[position] != rune('B') {
+							goto l2515
 						}
 						position++
 					}
-				l1566:
+				l2524:
 					depth--
-					add(rulePegText, position1547)
+					add(rulePegText, position2517)
 				}
-				if !_rules[ruleAction97]() {
-					goto l1545
+				if !_rules[ruleAction151]() {
+					goto l2515
 				}
 				depth--
-				add(ruleDropOldest, position1546)
+				add(ruleBlob, position2516)
 			}
 			return true
-		l1545:
-			position, tokenIndex, depth = position1545, tokenIndex1545, depth1545
+		l2515:
+			position, tokenIndex, depth = position2515, tokenIndex2515, depth2515
 			return false
 		},
-		/* 128 DropNewest <- <(<(('d' / 'D') ('r' / 'R') ('o' / 'O') ('p' / 'P') sp (('n' / 'N') ('e' / 'E') ('w' / 'W') ('e' / 'E') ('s' / 'S') ('t' / 'T')))> Action98)> */
+		/* 192 Timestamp <- <(<(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ('s' / 'S') ('t' / 'T') ('a' / 'A') ('m' / 'M') ('p' / 'P'))> Action152)> */
 		func() bool {
-			position1568, tokenIndex1568, depth1568 := position, tokenIndex, depth
+			position2526, tokenIndex2526, depth2526 := position, tokenIndex, depth
 			{
-				position1569 := position
+				position2527 := position
 				depth++
 				{
-					position1570 := position
+					position2528 := position
 					depth++
 					{
-						position1571, tokenIndex1571, depth1571 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1572
-						}
-						position++
-						goto l1571
-					l1572:
-						position, tokenIndex, depth = position1571, tokenIndex1571, depth1571
-						if buffer[position] != rune('D') {
-							goto l1568
-						}
-						position++
-					}
-				l1571:
-					{
-						position1573, tokenIndex1573, depth1573 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1574
+						position2529, tokenIndex2529, depth2529 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2530
 						}
 						position++
-						goto l1573
-					l1574:
-						position, tokenIndex, depth = position1573, tokenIndex1573, depth1573
-						if buffer[position] != rune('R') {
-							goto l1568
+						goto l2529
+					l2530:
+						position, tokenIndex, depth = position2529, tokenIndex2529, depth2529
+						if buffer[position] != rune('T') {
+							goto l2526
 						}
 						position++
 					}
-				l1573:
+				l2529:
 					{
-						position1575, tokenIndex1575, depth1575 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1576
+						position2531, tokenIndex2531, depth2531 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2532
 						}
 						position++
-						goto l1575
-					l1576:
-						position, tokenIndex, depth = position1575, tokenIndex1575, depth1575
-						if buffer[position] != rune('O') {
-							goto l1568
+						goto l2531
+					l2532:
+						position, tokenIndex, depth = position2531, tokenIndex2531, depth2531
+						if buffer[position] != rune('I') {
+							goto l2526
 						}
 						position++
 					}
-				l1575:
+				l2531:
 					{
-						position1577, tokenIndex1577, depth1577 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1578
+						position2533, tokenIndex2533, depth2533 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2534
 						}
 						position++
-						goto l1577
-					l1578:
-						position, tokenIndex, depth = position1577, tokenIndex1577, depth1577
-						if buffer[position] != rune('P') {
-							goto l1568
+						goto l2533
+					l2534:
+						position, tokenIndex, depth = position2533, tokenIndex2533, depth2533
+						if buffer[position] != rune('M') {
+							goto l2526
 						}
 						position++
 					}
-				l1577:
-					if !_rules[rulesp]() {
-						goto l1568
-					}
+				l2533:
 					{
-						position1579, tokenIndex1579, depth1579 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1580
+						position2535, tokenIndex2535, depth2535 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2536
 						}
 						position++
-						goto l1579
-					l1580:
-						position, tokenIndex, depth = position1579, tokenIndex1579, depth1579
-						if buffer[position] != rune('N') {
-							goto l1568
+						goto l2535
+					l2536:
+						position, tokenIndex, depth = position2535, tokenIndex2535, depth2535
+						if buffer[position] != rune('E') {
+							goto l2526
 						}
 						position++
 					}
-				l1579:
+				l2535:
 					{
-						position1581, tokenIndex1581, depth1581 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1582
+						position2537, tokenIndex2537, depth2537 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2538
 						}
 						position++
-						goto l1581
-					l1582:
-						position, tokenIndex, depth = position1581, tokenIndex1581, depth1581
-						if buffer[position] != rune('E') {
-							goto l1568
+						goto l2537
+					l2538:
+						position, tokenIndex, depth = position2537, tokenIndex2537, depth2537
+						if buffer[position] != rune('S') {
+							goto l2526
 						}
 						position++
 					}
-				l1581:
+				l2537:
 					{
-						position1583, tokenIndex1583, depth1583 := position, tokenIndex, depth
-						if buffer[position] != rune('w') {
-							goto l1584
+						position2539, tokenIndex2539, depth2539 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2540
 						}
 						position++
-						goto l1583
-					l1584:
-						position, tokenIndex, depth = position1583, tokenIndex1583, depth1583
-						if buffer[position] != rune('W') {
-							goto l1568
+						goto l2539
+					l2540:
+						position, tokenIndex, depth = position2539, tokenIndex2539, depth2539
+						if buffer[position] != rune('T') {
+							goto l2526
 						}
 						position++
 					}
-				l1583:
+				l2539:
 					{
-						position1585, tokenIndex1585, depth1585 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1586
+						position2541, tokenIndex2541, depth2541 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2542
 						}
 						position++
-						goto l1585
-					l1586:
-						position, tokenIndex, depth = position1585, tokenIndex1585, depth1585
-						if buffer[position] != rune('E') {
-							goto l1568
+						goto l2541
+					l2542:
+						position, tokenIndex, depth = position2541, tokenIndex2541, depth2541
+						if buffer[position] != rune('A') {
+							goto l2526
 						}
 						position++
 					}
-				l1585:
+				l2541:
 					{
-						position1587, tokenIndex1587, depth1587 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1588
+						position2543, tokenIndex2543, depth2543 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2544
 						}
 						position++
-						goto l1587
-					l1588:
-						position, tokenIndex, depth = position1587, tokenIndex1587, depth1587
-						if buffer[position] != rune('S') {
-							goto l1568
+						goto l2543
+					l2544:
+						position, tokenIndex, depth = position2543, tokenIndex2543, depth2543
+						if buffer[position] != rune('M') {
+							goto l2526
 						}
 						position++
 					}
-				l1587:
+				l2543:
 					{
-						position1589, tokenIndex1589, depth1589 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1590
+						position2545, tokenIndex2545, depth2545 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2546
 						}
 						position++
-						goto l1589
-					l1590:
-						position, tokenIndex, depth = position1589, tokenIndex1589, depth1589
-						if buffer[position] != rune('T') {
-							goto l1568
+						goto l2545
+					l2546:
+						position, tokenIndex, depth = position2545, tokenIndex2545, depth2545
+						if buffer[position] != rune('P') {
+							goto l2526
 						}
 						position++
 					}
-				l1589:
-					depth--
-					add(rulePegText, position1570)
-				}
-				if !_rules[ruleAction98]() {
-					goto l1568
-				}
-				depth--
-				add(ruleDropNewest, position1569)
-			}
-			return true
-		l1568:
-			position, tokenIndex, depth = position1568, tokenIndex1568, depth1568
-			return false
-		},
-		/* 129 StreamIdentifier <- <(<ident> Action99)> */
-		func() bool {
-			position1591, tokenIndex1591, depth1591 := position, tokenIndex, depth
-			{
-				position1592 := position
-				depth++
-				{
-					position1593 := position
-					depth++
-					if !_rules[ruleident]() {
-						goto l1591
-					}
+				l2545:
 					depth--
-					add(rulePegText, position1593)
+					add(rulePegText, position2528)
 				}
-				if !_rules[ruleAction99]() {
-					goto l1591
+				if !_rules[ruleAction152]() {
+					goto l2526
 				}
 				depth--
-				add(ruleStreamIdentifier, position1592)
+				add(ruleTimestamp, position2527)
 			}
 			return true
-		l1591:
-			position, tokenIndex, depth = position1591, tokenIndex1591, depth1591
+		l2526:
+			position, tokenIndex, depth = position2526, tokenIndex2526, depth2526
 			return false
 		},
-		/* 130 SourceSinkType <- <(<ident> Action100)> */
+		/* 193 Array <- <(<(('a' / 'A') ('r' / 'R') ('r' / 'R') ('a' / 'A') ('y' / 'Y'))> Action153)> */
 		func() bool {
-			position1594, tokenIndex1594, depth1594 := position, tokenIndex, depth
+			position2547, tokenIndex2547, depth2547 := position, tokenIndex, depth
 			{
-				position1595 := position
+				position2548 := position
 				depth++
 				{
-					position1596 := position
+					position2549 := position
 					depth++
-					if !_rules[ruleident]() {
-						goto l1594
+					{
+						position2550, tokenIndex2550, depth2550 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2551
+						}
+						position++
+						goto l2550
+					l2551:
+						position, tokenIndex, depth = position2550, tokenIndex2550, depth2550
+						if buffer[position] != rune('A') {
+							goto l2547
+						}
+						position++
 					}
-					depth--
-					add(rulePegText, position1596)
-				}
-				if !_rules[ruleAction100]() {
-					goto l1594
-				}
-				depth--
-				add(ruleSourceSinkType, position1595)
-			}
-			return true
-		l1594:
-			position, tokenIndex, depth = position1594, tokenIndex1594, depth1594
-			return false
-		},
-		/* 131 SourceSinkParamKey <- <(<ident> Action101)> */
-		func() bool {
-			position1597, tokenIndex1597, depth1597 := position, tokenIndex, depth
-			{
-				position1598 := position
-				depth++
-				{
-					position1599 := position
-					depth++
-					if !_rules[ruleident]() {
-						goto l1597
+				l2550:
+					{
+						position2552, tokenIndex2552, depth2552 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2553
+						}
+						position++
+						goto l2552
+					l2553:
+						position, tokenIndex, depth = position2552, tokenIndex2552, depth2552
+						if buffer[position] != rune('R') {
+							goto l2547
+						}
+						position++
 					}
-					depth--
-					add(rulePegText, position1599)
-				}
-				if !_rules[ruleAction101]() {
-					goto l1597
-				}
-				depth--
-				add(ruleSourceSinkParamKey, position1598)
-			}
-			return true
-		l1597:
-			position, tokenIndex, depth = position1597, tokenIndex1597, depth1597
-			return false
-		},
-		/* 132 Paused <- <(<(('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action102)> */
-		func() bool {
-			position1600, tokenIndex1600, depth1600 := position, tokenIndex, depth
-			{
-				position1601 := position
-				depth++
-				{
-					position1602 := position
-					depth++
+				l2552:
 					{
-						position1603, tokenIndex1603, depth1603 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1604
+						position2554, tokenIndex2554, depth2554 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2555
 						}
 						position++
-						goto l1603
-					l1604:
-						position, tokenIndex, depth = position1603, tokenIndex1603, depth1603
-						if buffer[position] != rune('P') {
-							goto l1600
+						goto l2554
+					l2555:
+						position, tokenIndex, depth = position2554, tokenIndex2554, depth2554
+						if buffer[position] != rune('R') {
+							goto l2547
 						}
 						position++
 					}
-				l1603:
+				l2554:
 					{
-						position1605, tokenIndex1605, depth1605 := position, tokenIndex, depth
+						position2556, tokenIndex2556, depth2556 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l1606
+							goto l2557
 						}
 						position++
-						goto l1605
-					l1606:
-						position, tokenIndex, depth = position1605, tokenIndex1605, depth1605
+						goto l2556
+					l2557:
+						position, tokenIndex, depth = position2556, tokenIndex2556, depth2556
 						if buffer[position] != rune('A') {
-							goto l1600
+							goto l2547
 						}
 						position++
 					}
-				l1605:
+				l2556:
 					{
-						position1607, tokenIndex1607, depth1607 := position, tokenIndex, depth
-						if buffer[position] != rune('u') {
-							goto l1608
+						position2558, tokenIndex2558, depth2558 := position, tokenIndex, depth
+						if buffer[position] != rune('y') {
+							goto l2559
 						}
 						position++
-						goto l1607
-					l1608:
-						position, tokenIndex, depth = position1607, tokenIndex1607, depth1607
-						if buffer[position] != rune('U') {
-							goto l1600
+						goto l2558
+					l2559:
+						position, tokenIndex, depth = position2558, tokenIndex2558, depth2558
+						if buffer[position] != rune('Y') {
+							goto l2547
 						}
 						position++
 					}
-				l1607:
+				l2558:
+					depth--
+					add(rulePegText, position2549)
+				}
+				if !_rules[ruleAction153]() {
+					goto l2547
+				}
+				depth--
+				add(ruleArray, position2548)
+			}
+			return true
+		l2547:
+			position, tokenIndex, depth = position2547, tokenIndex2547, depth2547
+			return false
+		},
+		/* 194 Map <- <(<(('m' / 'M') ('a' / 'A') ('p' / 'P'))> Action154)> */
+		func() bool {
+			position2560, tokenIndex2560, depth2560 := position, tokenIndex, depth
+			{
+				position2561 := position
+				depth++
+				{
+					position2562 := position
+					depth++
 					{
-						position1609, tokenIndex1609, depth1609 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1610
+						position2563, tokenIndex2563, depth2563 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2564
 						}
 						position++
-						goto l1609
-					l1610:
-						position, tokenIndex, depth = position1609, tokenIndex1609, depth1609
-						if buffer[position] != rune('S') {
-							goto l1600
+						goto l2563
+					l2564:
+						position, tokenIndex, depth = position2563, tokenIndex2563, depth2563
+						if buffer[position] != rune('M') {
+							goto l2560
 						}
 						position++
 					}
-				l1609:
+				l2563:
 					{
-						position1611, tokenIndex1611, depth1611 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1612
+						position2565, tokenIndex2565, depth2565 := position, tokenIndex, depth
+						if buffer[position] != rune('a') {
+							goto l2566
 						}
 						position++
-						goto l1611
-					l1612:
-						position, tokenIndex, depth = position1611, tokenIndex1611, depth1611
-						if buffer[position] != rune('E') {
-							goto l1600
+						goto l2565
+					l2566:
+						position, tokenIndex, depth = position2565, tokenIndex2565, depth2565
+						if buffer[position] != rune('A') {
+							goto l2560
 						}
 						position++
 					}
-				l1611:
+				l2565:
 					{
-						position1613, tokenIndex1613, depth1613 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1614
+						position2567, tokenIndex2567, depth2567 := position, tokenIndex, depth
+						if buffer[position] != rune('p') {
+							goto l2568
 						}
 						position++
-						goto l1613
-					l1614:
-						position, tokenIndex, depth = position1613, tokenIndex1613, depth1613
-						if buffer[position] != rune('D') {
-							goto l1600
+						goto l2567
+					l2568:
+						position, tokenIndex, depth = position2567, tokenIndex2567, depth2567
+						if buffer[position] != rune('P') {
+							goto l2560
 						}
 						position++
 					}
-				l1613:
+				l2567:
 					depth--
-					add(rulePegText, position1602)
+					add(rulePegText, position2562)
 				}
-				if !_rules[ruleAction102]() {
-					goto l1600
+				if !_rules[ruleAction154]() {
+					goto l2560
 				}
 				depth--
-				add(rulePaused, position1601)
+				add(ruleMap, position2561)
 			}
 			return true
-		l1600:
-			position, tokenIndex, depth = position1600, tokenIndex1600, depth1600
+		l2560:
+			position, tokenIndex, depth = position2560, tokenIndex2560, depth2560
 			return false
 		},
-		/* 133 Unpaused <- <(<(('u' / 'U') ('n' / 'N') ('p' / 'P') ('a' / 'A') ('u' / 'U') ('s' / 'S') ('e' / 'E') ('d' / 'D'))> Action103)> */
+		/* 195 Vector <- <(<(('v' / 'V') ('e' / 'E') ('c' / 'C') ('t' / 'T') ('o' / 'O') ('r' / 'R'))> Action155)> */
 		func() bool {
-			position1615, tokenIndex1615, depth1615 := position, tokenIndex, depth
+			position2569, tokenIndex2569, depth2569 := position, tokenIndex, depth
 			{
-				position1616 := position
+				position2570 := position
 				depth++
 				{
-					position1617 := position
+					position2571 := position
 					depth++
 					{
-						position1618, tokenIndex1618, depth1618 := position, tokenIndex, depth
-						if buffer[position] != rune('u') {
-							goto l1619
+						position2572, tokenIndex2572, depth2572 := position, tokenIndex, depth
+						if buffer[position] != rune('v') {
+							goto l2573
 						}
 						position++
-						goto l1618
-					l1619:
-						position, tokenIndex, depth = position1618, tokenIndex1618, depth1618
-						if buffer[position] != rune('U') {
-							goto l1615
+						goto l2572
+					l2573:
+						position, tokenIndex, depth = position2572, tokenIndex2572, depth2572
+						if buffer[position] != rune('V') {
+							goto l2569
 						}
 						position++
 					}
-				l1618:
+				l2572:
 					{
-						position1620, tokenIndex1620, depth1620 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1621
+						position2574, tokenIndex2574, depth2574 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l2575
 						}
 						position++
-						goto l1620
-					l1621:
-						position, tokenIndex, depth = position1620, tokenIndex1620, depth1620
-						if buffer[position] != rune('N') {
-							goto l1615
+						goto l2574
+					l2575:
+						position, tokenIndex, depth = position2574, tokenIndex2574, depth2574
+						if buffer[position] != rune('E') {
+							goto l2569
 						}
 						position++
 					}
-				l1620:
+				l2574:
 					{
-						position1622, tokenIndex1622, depth1622 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1623
+						position2576, tokenIndex2576, depth2576 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2577
 						}
 						position++
-						goto l1622
-					l1623:
-						position, tokenIndex, depth = position1622, tokenIndex1622, depth1622
-						if buffer[position] != rune('P') {
-							goto l1615
+						goto l2576
+					l2577:
+						position, tokenIndex, depth = position2576, tokenIndex2576, depth2576
+						if buffer[position] != rune('C') {
+							goto l2569
 						}
 						position++
 					}
-				l1622:
+				l2576:
 					{
-						position1624, tokenIndex1624, depth1624 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1625
+						position2578, tokenIndex2578, depth2578 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2579
 						}
 						position++
-						goto l1624
-					l1625:
-						position, tokenIndex, depth = position1624, tokenIndex1624, depth1624
-						if buffer[position] != rune('A') {
-							goto l1615
+						goto l2578
+					l2579:
+						position, tokenIndex, depth = position2578, tokenIndex2578, depth2578
+						if buffer[position] != rune('T') {
+							goto l2569
 						}
 						position++
 					}
-				l1624:
+				l2578:
 					{
-						position1626, tokenIndex1626, depth1626 := position, tokenIndex, depth
-						if buffer[position] != rune('u') {
-							goto l1627
+						position2580, tokenIndex2580, depth2580 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2581
 						}
 						position++
-						goto l1626
-					l1627:
-						position, tokenIndex, depth = position1626, tokenIndex1626, depth1626
-						if buffer[position] != rune('U') {
-							goto l1615
+						goto l2580
+					l2581:
+						position, tokenIndex, depth = position2580, tokenIndex2580, depth2580
+						if buffer[position] != rune('O') {
+							goto l2569
 						}
 						position++
 					}
-				l1626:
+				l2580:
 					{
-						position1628, tokenIndex1628, depth1628 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1629
+						position2582, tokenIndex2582, depth2582 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2583
 						}
 						position++
-						goto l1628
-					l1629:
-						position, tokenIndex, depth = position1628, tokenIndex1628, depth1628
-						if buffer[position] != rune('S') {
-							goto l1615
+						goto l2582
+					l2583:
+						position, tokenIndex, depth = position2582, tokenIndex2582, depth2582
+						if buffer[position] != rune('R') {
+							goto l2569
 						}
 						position++
 					}
-				l1628:
+				l2582:
+					depth--
+					add(rulePegText, position2571)
+				}
+				if !_rules[ruleAction155]() {
+					goto l2569
+				}
+				depth--
+				add(ruleVector, position2570)
+			}
+			return true
+		l2569:
+			position, tokenIndex, depth = position2569, tokenIndex2569, depth2569
+			return false
+		},
+		/* 196 Or <- <(<(('o' / 'O') ('r' / 'R'))> Action156)> */
+		func() bool {
+			position2584, tokenIndex2584, depth2584 := position, tokenIndex, depth
+			{
+				position2585 := position
+				depth++
+				{
+					position2586 := position
+					depth++
 					{
-						position1630, tokenIndex1630, depth1630 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1631
+						position2587, tokenIndex2587, depth2587 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2588
 						}
 						position++
-						goto l1630
-					l1631:
-						position, tokenIndex, depth = position1630, tokenIndex1630, depth1630
-						if buffer[position] != rune('E') {
-							goto l1615
+						goto l2587
+					l2588:
+						position, tokenIndex, depth = position2587, tokenIndex2587, depth2587
+						if buffer[position] != rune('O') {
+							goto l2584
 						}
 						position++
 					}
-				l1630:
+				l2587:
 					{
-						position1632, tokenIndex1632, depth1632 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1633
+						position2589, tokenIndex2589, depth2589 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2590
 						}
 						position++
-						goto l1632
-					l1633:
-						position, tokenIndex, depth = position1632, tokenIndex1632, depth1632
-						if buffer[position] != rune('D') {
-							goto l1615
+						goto l2589
+					l2590:
+						position, tokenIndex, depth = position2589, tokenIndex2589, depth2589
+						if buffer[position] != rune('R') {
+							goto l2584
 						}
 						position++
 					}
-				l1632:
+				l2589:
 					depth--
-					add(rulePegText, position1617)
+					add(rulePegText, position2586)
 				}
-				if !_rules[ruleAction103]() {
-					goto l1615
+				if !_rules[ruleAction156]() {
+					goto l2584
 				}
 				depth--
-				add(ruleUnpaused, position1616)
+				add(ruleOr, position2585)
 			}
 			return true
-		l1615:
-			position, tokenIndex, depth = position1615, tokenIndex1615, depth1615
+		l2584:
+			position, tokenIndex, depth = position2584, tokenIndex2584, depth2584
 			return false
 		},
-		/* 134 Ascending <- <(<(('a' / 'A') ('s' / 'S') ('c' / 'C'))> Action104)> */
+		/* 197 And <- <(<(('a' / 'A') ('n' / 'N') ('d' / 'D'))> Action157)> */
 		func() bool {
-			position1634, tokenIndex1634, depth1634 := position, tokenIndex, depth
+			position2591, tokenIndex2591, depth2591 := position, tokenIndex, depth
 			{
-				position1635 := position
+				position2592 := position
 				depth++
 				{
-					position1636 := position
+					position2593 := position
 					depth++
 					{
-						position1637, tokenIndex1637, depth1637 := position, tokenIndex, depth
+						position2594, tokenIndex2594, depth2594 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l1638
+							goto l2595
 						}
 						position++
-						goto l1637
-					l1638:
-						position, tokenIndex, depth = position1637, tokenIndex1637, depth1637
+						goto l2594
+					l2595:
+						position, tokenIndex, depth = position2594, tokenIndex2594, depth2594
 						if buffer[position] != rune('A') {
-							goto l1634
+							goto l2591
 						}
 						position++
 					}
-				l1637:
+				l2594:
 					{
-						position1639, tokenIndex1639, depth1639 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1640
+						position2596, tokenIndex2596, depth2596 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2597
 						}
 						position++
-						goto l1639
-					l1640:
-						position, tokenIndex, depth = position1639, tokenIndex1639, depth1639
-						if buffer[position] != rune('S') {
-							goto l1634
+						goto l2596
+					l2597:
+						position, tokenIndex, depth = position2596, tokenIndex2596, depth2596
+						if buffer[position] != rune('N') {
+							goto l2591
 						}
 						position++
 					}
-				l1639:
+				l2596:
 					{
-						position1641, tokenIndex1641, depth1641 := position, tokenIndex, depth
-						if buffer[position] != rune('c') {
-							goto l1642
+						position2598, tokenIndex2598, depth2598 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2599
 						}
 						position++
-						goto l1641
-					l1642:
-						position, tokenIndex, depth = position1641, tokenIndex1641, depth1641
-						if buffer[position] != rune('C') {
-							goto l1634
+						goto l2598
+					l2599:
+						position, tokenIndex, depth = position2598, tokenIndex2598, depth2598
+						if buffer[position] != rune('D') {
+							goto l2591
 						}
 						position++
 					}
-				l1641:
+				l2598:
 					depth--
-					add(rulePegText, position1636)
+					add(rulePegText, position2593)
 				}
-				if !_rules[ruleAction104]() {
-					goto l1634
+				if !_rules[ruleAction157]() {
+					goto l2591
 				}
 				depth--
-				add(ruleAscending, position1635)
+				add(ruleAnd, position2592)
 			}
 			return true
-		l1634:
-			position, tokenIndex, depth = position1634, tokenIndex1634, depth1634
+		l2591:
+			position, tokenIndex, depth = position2591, tokenIndex2591, depth2591
 			return false
 		},
-		/* 135 Descending <- <(<(('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C'))> Action105)> */
+		/* 198 Not <- <(<(('n' / 'N') ('o' / 'O') ('t' / 'T'))> Action158)> */
 		func() bool {
-			position1643, tokenIndex1643, depth1643 := position, tokenIndex, depth
+			position2600, tokenIndex2600, depth2600 := position, tokenIndex, depth
 			{
-				position1644 := position
+				position2601 := position
 				depth++
 				{
-					position1645 := position
+					position2602 := position
 					depth++
 					{
-						position1646, tokenIndex1646, depth1646 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1647
-						}
-						position++
-						goto l1646
-					l1647:
-						position, tokenIndex, depth = position1646, tokenIndex1646, depth1646
-						if buffer[position] != rune('D') {
-							goto l1643
-						}
-						position++
-					}
-				l1646:
-					{
-						position1648, tokenIndex1648, depth1648 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1649
+						position2603, tokenIndex2603, depth2603 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2604
 						}
 						position++
-						goto l1648
-					l1649:
-						position, tokenIndex, depth = position1648, tokenIndex1648, depth1648
-						if buffer[position] != rune('E') {
-							goto l1643
+						goto l2603
+					l2604:
+						position, tokenIndex, depth = position2603, tokenIndex2603, depth2603
+						if buffer[position] != rune('N') {
+							goto l2600
 						}
 						position++
 					}
-				l1648:
+				l2603:
 					{
-						position1650, tokenIndex1650, depth1650 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1651
+						position2605, tokenIndex2605, depth2605 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2606
 						}
 						position++
-						goto l1650
-					l1651:
-						position, tokenIndex, depth = position1650, tokenIndex1650, depth1650
-						if buffer[position] != rune('S') {
-							goto l1643
+						goto l2605
+					l2606:
+						position, tokenIndex, depth = position2605, tokenIndex2605, depth2605
+						if buffer[position] != rune('O') {
+							goto l2600
 						}
 						position++
 					}
-				l1650:
+				l2605:
 					{
-						position1652, tokenIndex1652, depth1652 := position, tokenIndex, depth
-						if buffer[position] != rune('c') {
-							goto l1653
+						position2607, tokenIndex2607, depth2607 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2608
 						}
 						position++
-						goto l1652
-					l1653:
-						position, tokenIndex, depth = position1652, tokenIndex1652, depth1652
-						if buffer[position] != rune('C') {
-							goto l1643
+						goto l2607
+					l2608:
+						position, tokenIndex, depth = position2607, tokenIndex2607, depth2607
+						if buffer[position] != rune('T') {
+							goto l2600
 						}
 						position++
 					}
-				l1652:
+				l2607:
 					depth--
-					add(rulePegText, position1645)
+					add(rulePegText, position2602)
 				}
-				if !_rules[ruleAction105]() {
-					goto l1643
+				if !_rules[ruleAction158]() {
+					goto l2600
 				}
 				depth--
-				add(ruleDescending, position1644)
+				add(ruleNot, position2601)
 			}
 			return true
-		l1643:
-			position, tokenIndex, depth = position1643, tokenIndex1643, depth1643
+		l2600:
+			position, tokenIndex, depth = position2600, tokenIndex2600, depth2600
 			return false
 		},
-		/* 136 Type <- <(Bool / Int / Float / String / Blob / Timestamp / Array / Map)> */
+		/* 199 Equal <- <(<'='> Action159)> */
 		func() bool {
-			position1654, tokenIndex1654, depth1654 := position, tokenIndex, depth
+			position2609, tokenIndex2609, depth2609 := position, tokenIndex, depth
 			{
-				position1655 := position
+				position2610 := position
 				depth++
 				{
-					position1656, tokenIndex1656, depth1656 := position, tokenIndex, depth
-					if !_rules[ruleBool]() {
-						goto l1657
-					}
-					goto l1656
-				l1657:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleInt]() {
-						goto l1658
+					position2611 := position
+					depth++
+					if buffer[position] != rune('=') {
+						goto l2609
 					}
-					goto l1656
-				l1658:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleFloat]() {
-						goto l1659
+					position++
+					depth--
+					add(rulePegText, position2611)
+				}
+				if !_rules[ruleAction159]() {
+					goto l2609
+				}
+				depth--
+				add(ruleEqual, position2610)
+			}
+			return true
+		l2609:
+			position, tokenIndex, depth = position2609, tokenIndex2609, depth2609
+			return false
+		},
+		/* 200 Less <- <(<'<'> Action160)> */
+		func() bool {
+			position2612, tokenIndex2612, depth2612 := position, tokenIndex, depth
+			{
+				position2613 := position
+				depth++
+				{
+					position2614 := position
+					depth++
+					if buffer[position] != rune('<') {
+						goto l2612
 					}
-					goto l1656
-				l1659:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleString]() {
-						goto l1660
+					position++
+					depth--
+					add(rulePegText, position2614)
+				}
+				if !_rules[ruleAction160]() {
+					goto l2612
+				}
+				depth--
+				add(ruleLess, position2613)
+			}
+			return true
+		l2612:
+			position, tokenIndex, depth = position2612, tokenIndex2612, depth2612
+			return false
+		},
+		/* 201 LessOrEqual <- <(<('<' '=')> Action161)> */
+		func() bool {
+			position2615, tokenIndex2615, depth2615 := position, tokenIndex, depth
+			{
+				position2616 := position
+				depth++
+				{
+					position2617 := position
+					depth++
+					if buffer[position] != rune('<') {
+						goto l2615
 					}
-					goto l1656
-				l1660:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleBlob]() {
-						goto l1661
+					position++
+					if buffer[position] != rune('=') {
+						goto l2615
 					}
-					goto l1656
-				l1661:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleTimestamp]() {
-						goto l1662
+					position++
+					depth--
+					add(rulePegText, position2617)
+				}
+				if !_rules[ruleAction161]() {
+					goto l2615
+				}
+				depth--
+				add(ruleLessOrEqual, position2616)
+			}
+			return true
+		l2615:
+			position, tokenIndex, depth = position2615, tokenIndex2615, depth2615
+			return false
+		},
+		/* 202 Greater <- <(<'>'> Action162)> */
+		func() bool {
+			position2618, tokenIndex2618, depth2618 := position, tokenIndex, depth
+			{
+				position2619 := position
+				depth++
+				{
+					position2620 := position
+					depth++
+					if buffer[position] != rune('>') {
+						goto l2618
 					}
-					goto l1656
-				l1662:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleArray]() {
-						goto l1663
+					position++
+					depth--
+					add(rulePegText, position2620)
+				}
+				if !_rules[ruleAction162]() {
+					goto l2618
+				}
+				depth--
+				add(ruleGreater, position2619)
+			}
+			return true
+		l2618:
+			position, tokenIndex, depth = position2618, tokenIndex2618, depth2618
+			return false
+		},
+		/* 203 GreaterOrEqual <- <(<('>' '=')> Action163)> */
+		func() bool {
+			position2621, tokenIndex2621, depth2621 := position, tokenIndex, depth
+			{
+				position2622 := position
+				depth++
+				{
+					position2623 := position
+					depth++
+					if buffer[position] != rune('>') {
+						goto l2621
 					}
-					goto l1656
-				l1663:
-					position, tokenIndex, depth = position1656, tokenIndex1656, depth1656
-					if !_rules[ruleMap]() {
-						goto l1654
+					position++
+					if buffer[position] != rune('=') {
+						goto l2621
 					}
+					position++
+					depth--
+					add(rulePegText, position2623)
+				}
+				if !_rules[ruleAction163]() {
+					goto l2621
 				}
-			l1656:
 				depth--
-				add(ruleType, position1655)
+				add(ruleGreaterOrEqual, position2622)
 			}
 			return true
-		l1654:
-			position, tokenIndex, depth = position1654, tokenIndex1654, depth1654
+		l2621:
+			position, tokenIndex, depth = position2621, tokenIndex2621, depth2621
 			return false
 		},
-		/* 137 Bool <- <(<(('b' / 'B') ('o' / 'O') ('o' / 'O') ('l' / 'L'))> Action106)> */
+		/* 204 NotEqual <- <(<(('!' '=') / ('<' '>'))> Action164)> */
 		func() bool {
-			position1664, tokenIndex1664, depth1664 := position, tokenIndex, depth
+			position2624, tokenIndex2624, depth2624 := position, tokenIndex, depth
 			{
-				position1665 := position
+				position2625 := position
 				depth++
 				{
-					position1666 := position
+					position2626 := position
 					depth++
 					{
-						position1667, tokenIndex1667, depth1667 := position, tokenIndex, depth
-						if buffer[position] != rune('b') {
-							goto l1668
+						position2627, tokenIndex2627, depth2627 := position, tokenIndex, depth
+						if buffer[position] != rune('!') {
+							goto l2628
 						}
 						position++
-						goto l1667
-					l1668:
-						position, tokenIndex, depth = position1667, tokenIndex1667, depth1667
-						if buffer[position] != rune('B') {
-							goto l1664
+						if buffer[position] != rune('=') {
+							goto l2628
 						}
 						position++
-					}
-				l1667:
-					{
-						position1669, tokenIndex1669, depth1669 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1670
+						goto l2627
+					l2628:
+						position, tokenIndex, depth = position2627, tokenIndex2627, depth2627
+						if buffer[position] != rune('<') {
+							goto l2624
 						}
 						position++
-						goto l1669
-					l1670:
-						position, tokenIndex, depth = position1669, tokenIndex1669, depth1669
-						if buffer[position] != rune('O') {
-							goto l1664
+						if buffer[position] != rune('>') {
+							goto l2624
 						}
 						position++
 					}
-				l1669:
+				l2627:
+					depth--
+					add(rulePegText, position2626)
+				}
+				if !_rules[ruleAction164]() {
+					goto l2624
+				}
+				depth--
+				add(ruleNotEqual, position2625)
+			}
+			return true
+		l2624:
+			position, tokenIndex, depth = position2624, tokenIndex2624, depth2624
+			return false
+		},
+		/* 205 Concat <- <(<('|' '|')> Action165)> */
+		func() bool {
+			position2629, tokenIndex2629, depth2629 := position, tokenIndex, depth
+			{
+				position2630 := position
+				depth++
+				{
+					position2631 := position
+					depth++
+					if buffer[position] != rune('|') {
+						goto l2629
+					}
+					position++
+					if buffer[position] != rune('|') {
+						goto l2629
+					}
+					position++
+					depth--
+					add(rulePegText, position2631)
+				}
+				if !_rules[ruleAction165]() {
+					goto l2629
+				}
+				depth--
+				add(ruleConcat, position2630)
+			}
+			return true
+		l2629:
+			position, tokenIndex, depth = position2629, tokenIndex2629, depth2629
+			return false
+		},
+		/* 206 Is <- <(<(('i' / 'I') ('s' / 'S'))> Action166)> */
+		func() bool {
+			position2632, tokenIndex2632, depth2632 := position, tokenIndex, depth
+			{
+				position2633 := position
+				depth++
+				{
+					position2634 := position
+					depth++
 					{
-						position1671, tokenIndex1671, depth1671 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1672
+						position2635, tokenIndex2635, depth2635 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2636
 						}
 						position++
-						goto l1671
-					l1672:
-						position, tokenIndex, depth = position1671, tokenIndex1671, depth1671
-						if buffer[position] != rune('O') {
-							goto l1664
+						goto l2635
+					l2636:
+						position, tokenIndex, depth = position2635, tokenIndex2635, depth2635
+						if buffer[position] != rune('I') {
+							goto l2632
 						}
 						position++
 					}
-				l1671:
+				l2635:
 					{
-						position1673, tokenIndex1673, depth1673 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1674
+						position2637, tokenIndex2637, depth2637 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2638
 						}
 						position++
-						goto l1673
-					l1674:
-						position, tokenIndex, depth = position1673, tokenIndex1673, depth1673
-						if buffer[position] != rune('L') {
-							goto l1664
+						goto l2637
+					l2638:
+						position, tokenIndex, depth = position2637, tokenIndex2637, depth2637
+						if buffer[position] != rune('S') {
+							goto l2632
 						}
 						position++
 					}
-				l1673:
+				l2637:
 					depth--
-					add(rulePegText, position1666)
+					add(rulePegText, position2634)
 				}
-				if !_rules[ruleAction106]() {
-					goto l1664
+				if !_rules[ruleAction166]() {
+					goto l2632
 				}
 				depth--
-				add(ruleBool, position1665)
+				add(ruleIs, position2633)
 			}
 			return true
-		l1664:
-			position, tokenIndex, depth = position1664, tokenIndex1664, depth1664
+		l2632:
+			position, tokenIndex, depth = position2632, tokenIndex2632, depth2632
 			return false
 		},
-		/* 138 Int <- <(<(('i' / 'I') ('n' / 'N') ('t' / 'T'))> Action107)> */
+		/* 207 IsNot <- <(<(('i' / 'I') ('s' / 'S') sp (('n' / 'N') ('o' / 'O') ('t' / 'T')))> Action167)> */
 		func() bool {
-			position1675, tokenIndex1675, depth1675 := position, tokenIndex, depth
+			position2639, tokenIndex2639, depth2639 := position, tokenIndex, depth
 			{
-				position1676 := position
+				position2640 := position
 				depth++
 				{
-					position1677 := position
+					position2641 := position
 					depth++
 					{
-						position1678, tokenIndex1678, depth1678 := position, tokenIndex, depth
+						position2642, tokenIndex2642, depth2642 := position, tokenIndex, depth
 						if buffer[position] != rune('i') {
-							goto l1679
+							goto l2643
 						}
 						position++
-						goto l1678
-					l1679:
-						position, tokenIndex, depth = position1678, tokenIndex1678, depth1678
+						goto l2642
+					l2643:
+						position, tokenIndex, depth = position2642, tokenIndex2642, depth2642
 						if buffer[position] != rune('I') {
-							goto l1675
+							goto l2639
 						}
 						position++
 					}
-				l1678:
+				l2642:
 					{
-						position1680, tokenIndex1680, depth1680 := position, tokenIndex, depth
+						position2644, tokenIndex2644, depth2644 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2645
+						}
+						position++
+						goto l2644
+					l2645:
+						position, tokenIndex, depth = position2644, tokenIndex2644, depth2644
+						if buffer[position] != rune('S') {
+							goto l2639
+						}
+						position++
+					}
+				l2644:
+					if !_rules[rulesp]() {
+						goto l2639
+					}
+					{
+						position2646, tokenIndex2646, depth2646 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l1681
+							goto l2647
 						}
 						position++
-						goto l1680
-					l1681:
-						position, tokenIndex, depth = position1680, tokenIndex1680, depth1680
+						goto l2646
+					l2647:
+						position, tokenIndex, depth = position2646, tokenIndex2646, depth2646
 						if buffer[position] != rune('N') {
-							goto l1675
+							goto l2639
+						}
+						position++
+					}
+				l2646:
+					{
+						position2648, tokenIndex2648, depth2648 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2649
+						}
+						position++
+						goto l2648
+					l2649:
+						position, tokenIndex, depth = position2648, tokenIndex2648, depth2648
+						if buffer[position] != rune('O') {
+							goto l2639
 						}
 						position++
 					}
-				l1680:
+				l2648:
 					{
-						position1682, tokenIndex1682, depth1682 := position, tokenIndex, depth
+						position2650, tokenIndex2650, depth2650 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l1683
+							goto l2651
 						}
 						position++
-						goto l1682
-					l1683:
-						position, tokenIndex, depth = position1682, tokenIndex1682, depth1682
+						goto l2650
+					l2651:
+						position, tokenIndex, depth = position2650, tokenIndex2650, depth2650
 						if buffer[position] != rune('T') {
-							goto l1675
+							goto l2639
 						}
 						position++
 					}
-				l1682:
+				l2650:
 					depth--
-					add(rulePegText, position1677)
+					add(rulePegText, position2641)
 				}
-				if !_rules[ruleAction107]() {
-					goto l1675
+				if !_rules[ruleAction167]() {
+					goto l2639
 				}
 				depth--
-				add(ruleInt, position1676)
+				add(ruleIsNot, position2640)
 			}
 			return true
-		l1675:
-			position, tokenIndex, depth = position1675, tokenIndex1675, depth1675
+		l2639:
+			position, tokenIndex, depth = position2639, tokenIndex2639, depth2639
 			return false
 		},
-		/* 139 Float <- <(<(('f' / 'F') ('l' / 'L') ('o' / 'O') ('a' / 'A') ('t' / 'T'))> Action108)> */
+		/* 208 IsDistinctFrom <- <(<(('i' / 'I') ('s' / 'S') sp (('d' / 'D') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('i' / 'I') ('n' / 'N') ('c' / 'C') ('t' / 'T')) sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')))> Action168)> */
 		func() bool {
-			position1684, tokenIndex1684, depth1684 := position, tokenIndex, depth
+			position2652, tokenIndex2652, depth2652 := position, tokenIndex, depth
 			{
-				position1685 := position
+				position2653 := position
 				depth++
 				{
-					position1686 := position
+					position2654 := position
 					depth++
 					{
-						position1687, tokenIndex1687, depth1687 := position, tokenIndex, depth
-						if buffer[position] != rune('f') {
-							goto l1688
+						position2655, tokenIndex2655, depth2655 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2656
+						}
+						position++
+						goto l2655
+					l2656:
+						position, tokenIndex, depth = position2655, tokenIndex2655, depth2655
+						if buffer[position] != rune('I') {
+							goto l2652
+						}
+						position++
+					}
+				l2655:
+					{
+						position2657, tokenIndex2657, depth2657 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2658
+						}
+						position++
+						goto l2657
+					l2658:
+						position, tokenIndex, depth = position2657, tokenIndex2657, depth2657
+						if buffer[position] != rune('S') {
+							goto l2652
+						}
+						position++
+					}
+				l2657:
+					if !_rules[rulesp]() {
+						goto l2652
+					}
+					{
+						position2659, tokenIndex2659, depth2659 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2660
+						}
+						position++
+						goto l2659
+					l2660:
+						position, tokenIndex, depth = position2659, tokenIndex2659, depth2659
+						if buffer[position] != rune('D') {
+							goto l2652
+						}
+						position++
+					}
+				l2659:
+					{
+						position2661, tokenIndex2661, depth2661 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2662
 						}
 						position++
-						goto l1687
-					l1688:
-						position, tokenIndex, depth = position1687, tokenIndex1687, depth1687
-						if buffer[position] != rune('F') {
-							goto l1684
+						goto l2661
+					l2662:
+						position, tokenIndex, depth = position2661, tokenIndex2661, depth2661
+						if buffer[position] != rune('I') {
+							goto l2652
 						}
 						position++
 					}
-				l1687:
+				l2661:
 					{
-						position1689, tokenIndex1689, depth1689 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1690
+						position2663, tokenIndex2663, depth2663 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2664
 						}
 						position++
-						goto l1689
-					l1690:
-						position, tokenIndex, depth = position1689, tokenIndex1689, depth1689
-						if buffer[position] != rune('L') {
-							goto l1684
+						goto l2663
+					l2664:
+						position, tokenIndex, depth = position2663, tokenIndex2663, depth2663
+						if buffer[position] != rune('S') {
+							goto l2652
 						}
 						position++
 					}
-				l1689:
+				l2663:
 					{
-						position1691, tokenIndex1691, depth1691 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1692
+						position2665, tokenIndex2665, depth2665 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2666
 						}
 						position++
-						goto l1691
-					l1692:
-						position, tokenIndex, depth = position1691, tokenIndex1691, depth1691
-						if buffer[position] != rune('O') {
-							goto l1684
+						goto l2665
+					l2666:
+						position, tokenIndex, depth = position2665, tokenIndex2665, depth2665
+						if buffer[position] != rune('T') {
+							goto l2652
 						}
 						position++
 					}
-				l1691:
+				l2665:
 					{
-						position1693, tokenIndex1693, depth1693 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1694
+						position2667, tokenIndex2667, depth2667 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2668
 						}
 						position++
-						goto l1693
-					l1694:
-						position, tokenIndex, depth = position1693, tokenIndex1693, depth1693
-						if buffer[position] != rune('A') {
-							goto l1684
+						goto l2667
+					l2668:
+						position, tokenIndex, depth = position2667, tokenIndex2667, depth2667
+						if buffer[position] != rune('I') {
+							goto l2652
 						}
 						position++
 					}
-				l1693:
+				l2667:
 					{
-						position1695, tokenIndex1695, depth1695 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1696
+						position2669, tokenIndex2669, depth2669 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2670
 						}
 						position++
-						goto l1695
-					l1696:
-						position, tokenIndex, depth = position1695, tokenIndex1695, depth1695
-						if buffer[position] != rune('T') {
-							goto l1684
+						goto l2669
+					l2670:
+						position, tokenIndex, depth = position2669, tokenIndex2669, depth2669
+						if buffer[position] != rune('N') {
+							goto l2652
 						}
 						position++
 					}
-				l1695:
-					depth--
-					add(rulePegText, position1686)
-				}
-				if !_rules[ruleAction108]() {
-					goto l1684
-				}
-				depth--
-				add(ruleFloat, position1685)
-			}
-			return true
-		l1684:
-			position, tokenIndex, depth = position1684, tokenIndex1684, depth1684
-			return false
-		},
-		/* 140 String <- <(<(('s' / 'S') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('n' / 'N') ('g' / 'G'))> Action109)> */
-		func() bool {
-			position1697, tokenIndex1697, depth1697 := position, tokenIndex, depth
-			{
-				position1698 := position
-				depth++
-				{
-					position1699 := position
-					depth++
+				l2669:
 					{
-						position1700, tokenIndex1700, depth1700 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1701
+						position2671, tokenIndex2671, depth2671 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2672
 						}
 						position++
-						goto l1700
-					l1701:
-						position, tokenIndex, depth = position1700, tokenIndex1700, depth1700
-						if buffer[position] != rune('S') {
-							goto l1697
+						goto l2671
+					l2672:
+						position, tokenIndex, depth = position2671, tokenIndex2671, depth2671
+						if buffer[position] != rune('C') {
+							goto l2652
 						}
 						position++
 					}
-				l1700:
+				l2671:
 					{
-						position1702, tokenIndex1702, depth1702 := position, tokenIndex, depth
+						position2673, tokenIndex2673, depth2673 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l1703
+							goto l2674
 						}
 						position++
-						goto l1702
-					l1703:
-						position, tokenIndex, depth = position1702, tokenIndex1702, depth1702
+						goto l2673
+					l2674:
+						position, tokenIndex, depth = position2673, tokenIndex2673, depth2673
 						if buffer[position] != rune('T') {
-							goto l1697
+							goto l2652
 						}
 						position++
 					}
-				l1702:
+				l2673:
+					if !_rules[rulesp]() {
+						goto l2652
+					}
 					{
-						position1704, tokenIndex1704, depth1704 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1705
+						position2675, tokenIndex2675, depth2675 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2676
 						}
 						position++
-						goto l1704
-					l1705:
-						position, tokenIndex, depth = position1704, tokenIndex1704, depth1704
-						if buffer[position] != rune('R') {
-							goto l1697
+						goto l2675
+					l2676:
+						position, tokenIndex, depth = position2675, tokenIndex2675, depth2675
+						if buffer[position] != rune('F') {
+							goto l2652
 						}
 						position++
 					}
-				l1704:
+				l2675:
 					{
-						position1706, tokenIndex1706, depth1706 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1707
+						position2677, tokenIndex2677, depth2677 := position, tokenIndex, depth
+						if buffer[position] != rune('r') {
+							goto l2678
 						}
 						position++
-						goto l1706
-					l1707:
-						position, tokenIndex, depth = position1706, tokenIndex1706, depth1706
-						if buffer[position] != rune('I') {
-							goto l1697
+						goto l2677
+					l2678:
+						position, tokenIndex, depth = position2677, tokenIndex2677, depth2677
+						if buffer[position] != rune('R') {
+							goto l2652
 						}
 						position++
 					}
-				l1706:
+				l2677:
 					{
-						position1708, tokenIndex1708, depth1708 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1709
+						position2679, tokenIndex2679, depth2679 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2680
 						}
 						position++
-						goto l1708
-					l1709:
-						position, tokenIndex, depth = position1708, tokenIndex1708, depth1708
-						if buffer[position] != rune('N') {
-							goto l1697
+						goto l2679
+					l2680:
+						position, tokenIndex, depth = position2679, tokenIndex2679, depth2679
+						if buffer[position] != rune('O') {
+							goto l2652
 						}
 						position++
 					}
-				l1708:
+				l2679:
 					{
-						position1710, tokenIndex1710, depth1710 := position, tokenIndex, depth
-						if buffer[position] != rune('g') {
-							goto l1711
+						position2681, tokenIndex2681, depth2681 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2682
 						}
 						position++
-						goto l1710
-					l1711:
-						position, tokenIndex, depth = position1710, tokenIndex1710, depth1710
-						if buffer[position] != rune('G') {
-							goto l1697
+						goto l2681
+					l2682:
+						position, tokenIndex, depth = position2681, tokenIndex2681, depth2681
+						if buffer[position] != rune('M') {
+							goto l2652
 						}
 						position++
 					}
-				l1710:
+				l2681:
 					depth--
-					add(rulePegText, position1699)
+					add(rulePegText, position2654)
 				}
-				if !_rules[ruleAction109]() {
-					goto l1697
+				if !_rules[ruleAction168]() {
+					goto l2652
 				}
 				depth--
-				add(ruleString, position1698)
+				add(ruleIsDistinctFrom, position2653)
 			}
 			return true
-		l1697:
-			position, tokenIndex, depth = position1697, tokenIndex1697, depth1697
+		l2652:
+			position, tokenIndex, depth = position2652, tokenIndex2652, depth2652
 			return false
 		},
-		/* 141 Blob <- <(<(('b' / 'B') ('l' / 'L') ('o' / 'O') ('b' / 'B'))> Action110)> */
+		/* 209 IsNotDistinctFrom <- <(<(('i' / 'I') ('s' / 'S') sp (('n' / 'N') ('o' / 'O') ('t' / 'T')) sp (('d' / 'D') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('i' / 'I') ('n' / 'N') ('c' / 'C') ('t' / 'T')) sp (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M')))> Action169)> */
 		func() bool {
-			position1712, tokenIndex1712, depth1712 := position, tokenIndex, depth
+			position2683, tokenIndex2683, depth2683 := position, tokenIndex, depth
 			{
-				position1713 := position
+				position2684 := position
 				depth++
 				{
-					position1714 := position
+					position2685 := position
 					depth++
 					{
-						position1715, tokenIndex1715, depth1715 := position, tokenIndex, depth
-						if buffer[position] != rune('b') {
-							goto l1716
+						position2686, tokenIndex2686, depth2686 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2687
 						}
 						position++
-						goto l1715
-					l1716:
-						position, tokenIndex, depth = position1715, tokenIndex1715, depth1715
-						if buffer[position] != rune('B') {
-							goto l1712
+						goto l2686
+					l2687:
+						position, tokenIndex, depth = position2686, tokenIndex2686, depth2686
+						if buffer[position] != rune('I') {
+							goto l2683
 						}
 						position++
 					}
-				l1715:
+				l2686:
 					{
-						position1717, tokenIndex1717, depth1717 := position, tokenIndex, depth
-						if buffer[position] != rune('l') {
-							goto l1718
+						position2688, tokenIndex2688, depth2688 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l2689
 						}
 						position++
-						goto l1717
-					l1718:
-						position, tokenIndex, depth = position1717, tokenIndex1717, depth1717
-						if buffer[position] != rune('L') {
-							goto l1712
+						goto l2688
+					l2689:
+						position, tokenIndex, depth = position2688, tokenIndex2688, depth2688
+						if buffer[position] != rune('S') {
+							goto l2683
 						}
 						position++
 					}
-				l1717:
+				l2688:
+					if !_rules[rulesp]() {
+						goto l2683
+					}
 					{
-						position1719, tokenIndex1719, depth1719 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1720
+						position2690, tokenIndex2690, depth2690 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2691
 						}
 						position++
-						goto l1719
-					l1720:
-						position, tokenIndex, depth = position1719, tokenIndex1719, depth1719
-						if buffer[position] != rune('O') {
-							goto l1712
+						goto l2690
+					l2691:
+						position, tokenIndex, depth = position2690, tokenIndex2690, depth2690
+						if buffer[position] != rune('N') {
+							goto l2683
 						}
 						position++
 					}
-				l1719:
+				l2690:
 					{
-						position1721, tokenIndex1721, depth1721 := position, tokenIndex, depth
-						if buffer[position] != rune('b') {
-							goto l1722
+						position2692, tokenIndex2692, depth2692 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2693
 						}
 						position++
-						goto l1721
-					l1722:
-						position, tokenIndex, depth = position1721, tokenIndex1721, depth1721
-						if buffer[position] != rune('B') {
-							goto l1712
+						goto l2692
+					l2693:
+						position, tokenIndex, depth = position2692, tokenIndex2692, depth2692
+						if buffer[position] != rune('O') {
+							goto l2683
 						}
 						position++
 					}
-				l1721:
-					depth--
-					add(rulePegText, position1714)
-				}
-				if !_rules[ruleAction110]() {
-					goto l1712
-				}
-				depth--
-				add(ruleBlob, position1713)
-			}
-			return true
-		l1712:
-			position, tokenIndex, depth = position1712, tokenIndex1712, depth1712
-			return false
-		},
-		/* 142 Timestamp <- <(<(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ('s' / 'S') ('t' / 'T') ('a' / 'A') ('m' / 'M') ('p' / 'P'))> Action111)> */
-		func() bool {
-			position1723, tokenIndex1723, depth1723 := position, tokenIndex, depth
-			{
-				position1724 := position
-				depth++
-				{
-					position1725 := position
-					depth++
+				l2692:
 					{
-						position1726, tokenIndex1726, depth1726 := position, tokenIndex, depth
+						position2694, tokenIndex2694, depth2694 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l1727
+							goto l2695
 						}
 						position++
-						goto l1726
-					l1727:
-						position, tokenIndex, depth = position1726, tokenIndex1726, depth1726
+						goto l2694
+					l2695:
+						position, tokenIndex, depth = position2694, tokenIndex2694, depth2694
 						if buffer[position] != rune('T') {
-							goto l1723
+							goto l2683
 						}
 						position++
 					}
-				l1726:
-					{
-						position1728, tokenIndex1728, depth1728 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1729
-						}
-						position++
-						goto l1728
-					l1729:
-						position, tokenIndex, depth = position1728, tokenIndex1728, depth1728
-						if buffer[position] != rune('I') {
-							goto l1723
-						}
-						position++
+				l2694:
+					if !_rules[rulesp]() {
+						goto l2683
 					}
-				l1728:
 					{
-						position1730, tokenIndex1730, depth1730 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1731
+						position2696, tokenIndex2696, depth2696 := position, tokenIndex, depth
+						if buffer[position] != rune('d') {
+							goto l2697
 						}
 						position++
-						goto l1730
-					l1731:
-						position, tokenIndex, depth = position1730, tokenIndex1730, depth1730
-						if buffer[position] != rune('M') {
-							goto l1723
+						goto l2696
+					l2697:
+						position, tokenIndex, depth = position2696, tokenIndex2696, depth2696
+						if buffer[position] != rune('D') {
+							goto l2683
 						}
 						position++
 					}
-				l1730:
+				l2696:
 					{
-						position1732, tokenIndex1732, depth1732 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l1733
+						position2698, tokenIndex2698, depth2698 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2699
 						}
 						position++
-						goto l1732
-					l1733:
-						position, tokenIndex, depth = position1732, tokenIndex1732, depth1732
-						if buffer[position] != rune('E') {
-							goto l1723
+						goto l2698
+					l2699:
+						position, tokenIndex, depth = position2698, tokenIndex2698, depth2698
+						if buffer[position] != rune('I') {
+							goto l2683
 						}
 						position++
 					}
-				l1732:
+				l2698:
 					{
-						position1734, tokenIndex1734, depth1734 := position, tokenIndex, depth
+						position2700, tokenIndex2700, depth2700 := position, tokenIndex, depth
 						if buffer[position] != rune('s') {
-							goto l1735
+							goto l2701
 						}
 						position++
-						goto l1734
-					l1735:
-						position, tokenIndex, depth = position1734, tokenIndex1734, depth1734
+						goto l2700
+					l2701:
+						position, tokenIndex, depth = position2700, tokenIndex2700, depth2700
 						if buffer[position] != rune('S') {
-							goto l1723
+							goto l2683
 						}
 						position++
 					}
-				l1734:
+				l2700:
 					{
-						position1736, tokenIndex1736, depth1736 := position, tokenIndex, depth
+						position2702, tokenIndex2702, depth2702 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l1737
+							goto l2703
 						}
 						position++
-						goto l1736
-					l1737:
-						position, tokenIndex, depth = position1736, tokenIndex1736, depth1736
+						goto l2702
+					l2703:
+						position, tokenIndex, depth = position2702, tokenIndex2702, depth2702
 						if buffer[position] != rune('T') {
-							goto l1723
+							goto l2683
 						}
 						position++
 					}
-				l1736:
+				l2702:
 					{
-						position1738, tokenIndex1738, depth1738 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1739
+						position2704, tokenIndex2704, depth2704 := position, tokenIndex, depth
+						if buffer[position] != rune('i') {
+							goto l2705
 						}
 						position++
-						goto l1738
-					l1739:
-						position, tokenIndex, depth = position1738, tokenIndex1738, depth1738
-						if buffer[position] != rune('A') {
-							goto l1723
+						goto l2704
+					l2705:
+						position, tokenIndex, depth = position2704, tokenIndex2704, depth2704
+						if buffer[position] != rune('I') {
+							goto l2683
 						}
 						position++
 					}
-				l1738:
+				l2704:
 					{
-						position1740, tokenIndex1740, depth1740 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1741
+						position2706, tokenIndex2706, depth2706 := position, tokenIndex, depth
+						if buffer[position] != rune('n') {
+							goto l2707
 						}
 						position++
-						goto l1740
-					l1741:
-						position, tokenIndex, depth = position1740, tokenIndex1740, depth1740
-						if buffer[position] != rune('M') {
-							goto l1723
+						goto l2706
+					l2707:
+						position, tokenIndex, depth = position2706, tokenIndex2706, depth2706
+						if buffer[position] != rune('N') {
+							goto l2683
 						}
 						position++
 					}
-				l1740:
+				l2706:
 					{
-						position1742, tokenIndex1742, depth1742 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1743
+						position2708, tokenIndex2708, depth2708 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l2709
 						}
 						position++
-						goto l1742
-					l1743:
-						position, tokenIndex, depth = position1742, tokenIndex1742, depth1742
-						if buffer[position] != rune('P') {
-							goto l1723
+						goto l2708
+					l2709:
+						position, tokenIndex, depth = position2708, tokenIndex2708, depth2708
+						if buffer[position] != rune('C') {
+							goto l2683
 						}
 						position++
 					}
-				l1742:
-					depth--
-					add(rulePegText, position1725)
-				}
-				if !_rules[ruleAction111]() {
-					goto l1723
-				}
-				depth--
-				add(ruleTimestamp, position1724)
-			}
-			return true
-		l1723:
-			position, tokenIndex, depth = position1723, tokenIndex1723, depth1723
-			return false
-		},
-		/* 143 Array <- <(<(('a' / 'A') ('r' / 'R') ('r' / 'R') ('a' / 'A') ('y' / 'Y'))> Action112)> */
-		func() bool {
-			position1744, tokenIndex1744, depth1744 := position, tokenIndex, depth
-			{
-				position1745 := position
-				depth++
-				{
-					position1746 := position
-					depth++
+				l2708:
 					{
-						position1747, tokenIndex1747, depth1747 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1748
+						position2710, tokenIndex2710, depth2710 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l2711
 						}
 						position++
-						goto l1747
-					l1748:
-						position, tokenIndex, depth = position1747, tokenIndex1747, depth1747
-						if buffer[position] != rune('A') {
-							goto l1744
+						goto l2710
+					l2711:
+						position, tokenIndex, depth = position2710, tokenIndex2710, depth2710
+						if buffer[position] != rune('T') {
+							goto l2683
 						}
 						position++
 					}
-				l1747:
+				l2710:
+					if !_rules[rulesp]() {
+						goto l2683
+					}
 					{
-						position1749, tokenIndex1749, depth1749 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1750
+						position2712, tokenIndex2712, depth2712 := position, tokenIndex, depth
+						if buffer[position] != rune('f') {
+							goto l2713
 						}
 						position++
-						goto l1749
-					l1750:
-						position, tokenIndex, depth = position1749, tokenIndex1749, depth1749
-						if buffer[position] != rune('R') {
-							goto l1744
+						goto l2712
+					l2713:
+						position, tokenIndex, depth = position2712, tokenIndex2712, depth2712
+						if buffer[position] != rune('F') {
+							goto l2683
 						}
 						position++
 					}
-				l1749:
+				l2712:
 					{
-						position1751, tokenIndex1751, depth1751 := position, tokenIndex, depth
+						position2714, tokenIndex2714, depth2714 := position, tokenIndex, depth
 						if buffer[position] != rune('r') {
-							goto l1752
+							goto l2715
 						}
 						position++
-						goto l1751
-					l1752:
-						position, tokenIndex, depth = position1751, tokenIndex1751, depth1751
+						goto l2714
+					l2715:
+						position, tokenIndex, depth = position2714, tokenIndex2714, depth2714
 						if buffer[position] != rune('R') {
-							goto l1744
+							goto l2683
 						}
 						position++
 					}
-				l1751:
+				l2714:
 					{
-						position1753, tokenIndex1753, depth1753 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1754
+						position2716, tokenIndex2716, depth2716 := position, tokenIndex, depth
+						if buffer[position] != rune('o') {
+							goto l2717
 						}
 						position++
-						goto l1753
-					l1754:
-						position, tokenIndex, depth = position1753, tokenIndex1753, depth1753
-						if buffer[position] != rune('A') {
-							goto l1744
+						goto l2716
+					l2717:
+						position, tokenIndex, depth = position2716, tokenIndex2716, depth2716
+						if buffer[position] != rune('O') {
+							goto l2683
 						}
 						position++
 					}
-				l1753:
+				l2716:
 					{
-						position1755, tokenIndex1755, depth1755 := position, tokenIndex, depth
-						if buffer[position] != rune('y') {
-							goto l1756
+						position2718, tokenIndex2718, depth2718 := position, tokenIndex, depth
+						if buffer[position] != rune('m') {
+							goto l2719
 						}
 						position++
-						goto l1755
-					l1756:
-						position, tokenIndex, depth = position1755, tokenIndex1755, depth1755
-						if buffer[position] != rune('Y') {
-							goto l1744
+						goto l2718
+					l2719:
+						position, tokenIndex, depth = position2718, tokenIndex2718, depth2718
+						if buffer[position] != rune('M') {
+							goto l2683
 						}
 						position++
 					}
-				l1755:
+				l2718:
+					depth--
+					add(rulePegText, position2685)
+				}
+				if !_rules[ruleAction169]() {
+					goto l2683
+				}
+				depth--
+				add(ruleIsNotDistinctFrom, position2684)
+			}
+			return true
+		l2683:
+			position, tokenIndex, depth = position2683, tokenIndex2683, depth2683
+			return false
+		},
+		/* 210 Plus <- <(<'+'> Action170)> */
+		func() bool {
+			position2720, tokenIndex2720, depth2720 := position, tokenIndex, depth
+			{
+				position2721 := position
+				depth++
+				{
+					position2722 := position
+					depth++
+					if buffer[position] != rune('+') {
+						goto l2720
+					}
+					position++
+					depth--
+					add(rulePegText, position2722)
+				}
+				if !_rules[ruleAction170]() {
+					goto l2720
+				}
+				depth--
+				add(rulePlus, position2721)
+			}
+			return true
+		l2720:
+			position, tokenIndex, depth = position2720, tokenIndex2720, depth2720
+			return false
+		},
+		/* 211 Minus <- <(<'-'> Action171)> */
+		func() bool {
+			position2723, tokenIndex2723, depth2723 := position, tokenIndex, depth
+			{
+				position2724 := position
+				depth++
+				{
+					position2725 := position
+					depth++
+					if buffer[position] != rune('-') {
+						goto l2723
+					}
+					position++
+					depth--
+					add(rulePegText, position2725)
+				}
+				if !_rules[ruleAction171]() {
+					goto l2723
+				}
+				depth--
+				add(ruleMinus, position2724)
+			}
+			return true
+		l2723:
+			position, tokenIndex, depth = position2723, tokenIndex2723, depth2723
+			return false
+		},
+		/* 212 Multiply <- <(<'*'> Action172)> */
+		func() bool {
+			position2726, tokenIndex2726, depth2726 := position, tokenIndex, depth
+			{
+				position2727 := position
+				depth++
+				{
+					position2728 := position
+					depth++
+					if buffer[position] != rune('*') {
+						goto l2726
+					}
+					position++
+					depth--
+					add(rulePegText, position2728)
+				}
+				if !_rules[ruleAction172]() {
+					goto l2726
+				}
+				depth--
+				add(ruleMultiply, position2727)
+			}
+			return true
+		l2726:
+			position, tokenIndex, depth = position2726, tokenIndex2726, depth2726
+			return false
+		},
+		/* 213 Divide <- <(<'/'> Action173)> */
+		func() bool {
+			position2729, tokenIndex2729, depth2729 := position, tokenIndex, depth
+			{
+				position2730 := position
+				depth++
+				{
+					position2731 := position
+					depth++
+					if buffer[position] != rune('/') {
+						goto l2729
+					}
+					position++
 					depth--
-					add(rulePegText, position1746)
+					add(rulePegText, position2731)
 				}
-				if !_rules[ruleAction112]() {
-					goto l1744
+				if !_rules[ruleAction173]() {
+					goto l2729
 				}
 				depth--
-				add(ruleArray, position1745)
+				add(ruleDivide, position2730)
 			}
 			return true
-		l1744:
-			position, tokenIndex, depth = position1744, tokenIndex1744, depth1744
+		l2729:
+			position, tokenIndex, depth = position2729, tokenIndex2729, depth2729
 			return false
 		},
-		/* 144 Map <- <(<(('m' / 'M') ('a' / 'A') ('p' / 'P'))> Action113)> */
+		/* 214 Modulo <- <(<'%'> Action174)> */
 		func() bool {
-			position1757, tokenIndex1757, depth1757 := position, tokenIndex, depth
+			position2732, tokenIndex2732, depth2732 := position, tokenIndex, depth
 			{
-				position1758 := position
+				position2733 := position
 				depth++
 				{
-					position1759 := position
+					position2734 := position
 					depth++
-					{
-						position1760, tokenIndex1760, depth1760 := position, tokenIndex, depth
-						if buffer[position] != rune('m') {
-							goto l1761
-						}
-						position++
-						goto l1760
-					l1761:
-						position, tokenIndex, depth = position1760, tokenIndex1760, depth1760
-						if buffer[position] != rune('M') {
-							goto l1757
-						}
-						position++
-					}
-				l1760:
-					{
-						position1762, tokenIndex1762, depth1762 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1763
-						}
-						position++
-						goto l1762
-					l1763:
-						position, tokenIndex, depth = position1762, tokenIndex1762, depth1762
-						if buffer[position] != rune('A') {
-							goto l1757
-						}
-						position++
-					}
-				l1762:
-					{
-						position1764, tokenIndex1764, depth1764 := position, tokenIndex, depth
-						if buffer[position] != rune('p') {
-							goto l1765
-						}
-						position++
-						goto l1764
-					l1765:
-						position, tokenIndex, depth = position1764, tokenIndex1764, depth1764
-						if buffer[position] != rune('P') {
-							goto l1757
-						}
-						position++
+					if buffer[position] != rune('%') {
+						goto l2732
 					}
-				l1764:
+					position++
 					depth--
-					add(rulePegText, position1759)
+					add(rulePegText, position2734)
 				}
-				if !_rules[ruleAction113]() {
-					goto l1757
+				if !_rules[ruleAction174]() {
+					goto l2732
 				}
 				depth--
-				add(ruleMap, position1758)
+				add(ruleModulo, position2733)
 			}
 			return true
-		l1757:
-			position, tokenIndex, depth = position1757, tokenIndex1757, depth1757
+		l2732:
+			position, tokenIndex, depth = position2732, tokenIndex2732, depth2732
 			return false
 		},
-		/* 145 Or <- <(<(('o' / 'O') ('r' / 'R'))> Action114)> */
+		/* 215 UnaryMinus <- <(<'-'> Action175)> */
 		func() bool {
-			position1766, tokenIndex1766, depth1766 := position, tokenIndex, depth
+			position2735, tokenIndex2735, depth2735 := position, tokenIndex, depth
 			{
-				position1767 := position
+				position2736 := position
 				depth++
 				{
-					position1768 := position
+					position2737 := position
 					depth++
-					{
-						position1769, tokenIndex1769, depth1769 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1770
-						}
-						position++
-						goto l1769
-					l1770:
-						position, tokenIndex, depth = position1769, tokenIndex1769, depth1769
-						if buffer[position] != rune('O') {
-							goto l1766
-						}
-						position++
-					}
-				l1769:
-					{
-						position1771, tokenIndex1771, depth1771 := position, tokenIndex, depth
-						if buffer[position] != rune('r') {
-							goto l1772
-						}
-						position++
-						goto l1771
-					l1772:
-						position, tokenIndex, depth = position1771, tokenIndex1771, depth1771
-						if buffer[position] != rune('R') {
-							goto l1766
-						}
-						position++
+					if buffer[position] != rune('-') {
+						goto l2735
 					}
-				l1771:
+					position++
 					depth--
-					add(rulePegText, position1768)
+					add(rulePegText, position2737)
 				}
-				if !_rules[ruleAction114]() {
-					goto l1766
+				if !_rules[ruleAction175]() {
+					goto l2735
 				}
 				depth--
-				add(ruleOr, position1767)
+				add(ruleUnaryMinus, position2736)
 			}
 			return true
-		l1766:
-			position, tokenIndex, depth = position1766, tokenIndex1766, depth1766
+		l2735:
+			position, tokenIndex, depth = position2735, tokenIndex2735, depth2735
 			return false
 		},
-		/* 146 And <- <(<(('a' / 'A') ('n' / 'N') ('d' / 'D'))> Action115)> */
+		/* 216 Identifier <- <(<ident> Action176)> */
 		func() bool {
-			position1773, tokenIndex1773, depth1773 := position, tokenIndex, depth
+			position2738, tokenIndex2738, depth2738 := position, tokenIndex, depth
 			{
-				position1774 := position
+				position2739 := position
 				depth++
 				{
-					position1775 := position
+					position2740 := position
 					depth++
-					{
-						position1776, tokenIndex1776, depth1776 := position, tokenIndex, depth
-						if buffer[position] != rune('a') {
-							goto l1777
-						}
-						position++
-						goto l1776
-					l1777:
-						position, tokenIndex, depth = position1776, tokenIndex1776, depth1776
-						if buffer[position] != rune('A') {
-							goto l1773
-						}
-						position++
-					}
-				l1776:
-					{
-						position1778, tokenIndex1778, depth1778 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1779
-						}
-						position++
-						goto l1778
-					l1779:
-						position, tokenIndex, depth = position1778, tokenIndex1778, depth1778
-						if buffer[position] != rune('N') {
-							goto l1773
-						}
-						position++
-					}
-				l1778:
-					{
-						position1780, tokenIndex1780, depth1780 := position, tokenIndex, depth
-						if buffer[position] != rune('d') {
-							goto l1781
-						}
-						position++
-						goto l1780
-					l1781:
-						position, tokenIndex, depth = position1780, tokenIndex1780, depth1780
-						if buffer[position] != rune('D') {
-							goto l1773
-						}
-						position++
+					if !_rules[ruleident]() {
+						goto l2738
 					}
-				l1780:
 					depth--
-					add(rulePegText, position1775)
+					add(rulePegText, position2740)
 				}
-				if !_rules[ruleAction115]() {
-					goto l1773
+				if !_rules[ruleAction176]() {
+					goto l2738
 				}
 				depth--
-				add(ruleAnd, position1774)
+				add(ruleIdentifier, position2739)
 			}
 			return true
-		l1773:
-			position, tokenIndex, depth = position1773, tokenIndex1773, depth1773
+		l2738:
+			position, tokenIndex, depth = position2738, tokenIndex2738, depth2738
 			return false
 		},
-		/* 147 Not <- <(<(('n' / 'N') ('o' / 'O') ('t' / 'T'))> Action116)> */
+		/* 217 TargetIdentifier <- <(<('*' / jsonSetPath)> Action177)> */
 		func() bool {
-			position1782, tokenIndex1782, depth1782 := position, tokenIndex, depth
+			position2741, tokenIndex2741, depth2741 := position, tokenIndex, depth
 			{
-				position1783 := position
+				position2742 := position
 				depth++
 				{
-					position1784 := position
+					position2743 := position
 					depth++
 					{
-						position1785, tokenIndex1785, depth1785 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1786
+						position2744, tokenIndex2744, depth2744 := position, tokenIndex, depth
+						if buffer[position] != rune('*') {
+							goto l2745
 						}
 						position++
-						goto l1785
-					l1786:
-						position, tokenIndex, depth = position1785, tokenIndex1785, depth1785
-						if buffer[position] != rune('N') {
-							goto l1782
+						goto l2744
+					l2745:
+						position, tokenIndex, depth = position2744, tokenIndex2744, depth2744
+						if !_rules[rulejsonSetPath]() {
+							goto l2741
 						}
-						position++
 					}
-				l1785:
+				l2744:
+					depth--
+					add(rulePegText, position2743)
+				}
+				if !_rules[ruleAction177]() {
+					goto l2741
+				}
+				depth--
+				add(ruleTargetIdentifier, position2742)
+			}
+			return true
+		l2741:
+			position, tokenIndex, depth = position2741, tokenIndex2741, depth2741
+			return false
+		},
+		/* 218 ident <- <(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)> */
+		func() bool {
+			position2746, tokenIndex2746, depth2746 := position, tokenIndex, depth
+			{
+				position2747 := position
+				depth++
+				{
+					position2748, tokenIndex2748, depth2748 := position, tokenIndex, depth
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l2749
+					}
+					position++
+					goto l2748
+				l2749:
+					position, tokenIndex, depth = position2748, tokenIndex2748, depth2748
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l2746
+					}
+					position++
+				}
+			l2748:
+			l2750:
+				{
+					position2751, tokenIndex2751, depth2751 := position, tokenIndex, depth
 					{
-						position1787, tokenIndex1787, depth1787 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1788
+						position2752, tokenIndex2752, depth2752 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l2753
 						}
 						position++
-						goto l1787
-					l1788:
-						position, tokenIndex, depth = position1787, tokenIndex1787, depth1787
-						if buffer[position] != rune('O') {
-							goto l1782
+						goto l2752
+					l2753:
+						position, tokenIndex, depth = position2752, tokenIndex2752, depth2752
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l2754
 						}
 						position++
-					}
-				l1787:
-					{
-						position1789, tokenIndex1789, depth1789 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1790
+						goto l2752
+					l2754:
+						position, tokenIndex, depth = position2752, tokenIndex2752, depth2752
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2755
 						}
 						position++
-						goto l1789
-					l1790:
-						position, tokenIndex, depth = position1789, tokenIndex1789, depth1789
-						if buffer[position] != rune('T') {
-							goto l1782
+						goto l2752
+					l2755:
+						position, tokenIndex, depth = position2752, tokenIndex2752, depth2752
+						if buffer[position] != rune('_') {
+							goto l2751
 						}
 						position++
 					}
-				l1789:
-					depth--
-					add(rulePegText, position1784)
+				l2752:
+					goto l2750
+				l2751:
+					position, tokenIndex, depth = position2751, tokenIndex2751, depth2751
 				}
-				if !_rules[ruleAction116]() {
-					goto l1782
+				depth--
+				add(ruleident, position2747)
+			}
+			return true
+		l2746:
+			position, tokenIndex, depth = position2746, tokenIndex2746, depth2746
+			return false
+		},
+		/* 219 jsonGetPath <- <(jsonPathHead jsonGetPathNonHead*)> */
+		func() bool {
+			position2756, tokenIndex2756, depth2756 := position, tokenIndex, depth
+			{
+				position2757 := position
+				depth++
+				if !_rules[rulejsonPathHead]() {
+					goto l2756
+				}
+			l2758:
+				{
+					position2759, tokenIndex2759, depth2759 := position, tokenIndex, depth
+					if !_rules[rulejsonGetPathNonHead]() {
+						goto l2759
+					}
+					goto l2758
+				l2759:
+					position, tokenIndex, depth = position2759, tokenIndex2759, depth2759
 				}
 				depth--
-				add(ruleNot, position1783)
+				add(rulejsonGetPath, position2757)
 			}
 			return true
-		l1782:
-			position, tokenIndex, depth = position1782, tokenIndex1782, depth1782
+		l2756:
+			position, tokenIndex, depth = position2756, tokenIndex2756, depth2756
 			return false
 		},
-		/* 148 Equal <- <(<'='> Action117)> */
+		/* 220 jsonSetPath <- <(jsonPathHead jsonSetPathNonHead*)> */
 		func() bool {
-			position1791, tokenIndex1791, depth1791 := position, tokenIndex, depth
+			position2760, tokenIndex2760, depth2760 := position, tokenIndex, depth
 			{
-				position1792 := position
+				position2761 := position
 				depth++
+				if !_rules[rulejsonPathHead]() {
+					goto l2760
+				}
+			l2762:
 				{
-					position1793 := position
-					depth++
-					if buffer[position] != rune('=') {
-						goto l1791
+					position2763, tokenIndex2763, depth2763 := position, tokenIndex, depth
+					if !_rules[rulejsonSetPathNonHead]() {
+						goto l2763
 					}
-					position++
-					depth--
-					add(rulePegText, position1793)
+					goto l2762
+				l2763:
+					position, tokenIndex, depth = position2763, tokenIndex2763, depth2763
 				}
-				if !_rules[ruleAction117]() {
-					goto l1791
+				depth--
+				add(rulejsonSetPath, position2761)
+			}
+			return true
+		l2760:
+			position, tokenIndex, depth = position2760, tokenIndex2760, depth2760
+			return false
+		},
+		/* 221 jsonPathHead <- <(jsonMapAccessString / jsonMapAccessBracket)> */
+		func() bool {
+			position2764, tokenIndex2764, depth2764 := position, tokenIndex, depth
+			{
+				position2765 := position
+				depth++
+				{
+					position2766, tokenIndex2766, depth2766 := position, tokenIndex, depth
+					if !_rules[rulejsonMapAccessString]() {
+						goto l2767
+					}
+					goto l2766
+				l2767:
+					position, tokenIndex, depth = position2766, tokenIndex2766, depth2766
+					if !_rules[rulejsonMapAccessBracket]() {
+						goto l2764
+					}
 				}
+			l2766:
 				depth--
-				add(ruleEqual, position1792)
+				add(rulejsonPathHead, position2765)
 			}
 			return true
-		l1791:
-			position, tokenIndex, depth = position1791, tokenIndex1791, depth1791
+		l2764:
+			position, tokenIndex, depth = position2764, tokenIndex2764, depth2764
 			return false
 		},
-		/* 149 Less <- <(<'<'> Action118)> */
+		/* 222 jsonGetPathNonHead <- <(jsonMapMultipleLevel / jsonMapSingleLevel / jsonArrayFullSlice / jsonArrayPartialSlice / jsonArraySlice / jsonArrayAccess)> */
 		func() bool {
-			position1794, tokenIndex1794, depth1794 := position, tokenIndex, depth
+			position2768, tokenIndex2768, depth2768 := position, tokenIndex, depth
 			{
-				position1795 := position
+				position2769 := position
 				depth++
 				{
-					position1796 := position
-					depth++
-					if buffer[position] != rune('<') {
-						goto l1794
+					position2770, tokenIndex2770, depth2770 := position, tokenIndex, depth
+					if !_rules[rulejsonMapMultipleLevel]() {
+						goto l2771
+					}
+					goto l2770
+				l2771:
+					position, tokenIndex, depth = position2770, tokenIndex2770, depth2770
+					if !_rules[rulejsonMapSingleLevel]() {
+						goto l2772
+					}
+					goto l2770
+				l2772:
+					position, tokenIndex, depth = position2770, tokenIndex2770, depth2770
+					if !_rules[rulejsonArrayFullSlice]() {
+						goto l2773
+					}
+					goto l2770
+				l2773:
+					position, tokenIndex, depth = position2770, tokenIndex2770, depth2770
+					if !_rules[rulejsonArrayPartialSlice]() {
+						goto l2774
+					}
+					goto l2770
+				l2774:
+					position, tokenIndex, depth = position2770, tokenIndex2770, depth2770
+					if !_rules[rulejsonArraySlice]() {
+						goto l2775
+					}
+					goto l2770
+				l2775:
+					position, tokenIndex, depth = position2770, tokenIndex2770, depth2770
+					if !_rules[rulejsonArrayAccess]() {
+						goto l2768
 					}
-					position++
-					depth--
-					add(rulePegText, position1796)
 				}
-				if !_rules[ruleAction118]() {
-					goto l1794
+			l2770:
+				depth--
+				add(rulejsonGetPathNonHead, position2769)
+			}
+			return true
+		l2768:
+			position, tokenIndex, depth = position2768, tokenIndex2768, depth2768
+			return false
+		},
+		/* 223 jsonSetPathNonHead <- <(jsonMapSingleLevel / jsonNonNegativeArrayAccess)> */
+		func() bool {
+			position2776, tokenIndex2776, depth2776 := position, tokenIndex, depth
+			{
+				position2777 := position
+				depth++
+				{
+					position2778, tokenIndex2778, depth2778 := position, tokenIndex, depth
+					if !_rules[rulejsonMapSingleLevel]() {
+						goto l2779
+					}
+					goto l2778
+				l2779:
+					position, tokenIndex, depth = position2778, tokenIndex2778, depth2778
+					if !_rules[rulejsonNonNegativeArrayAccess]() {
+						goto l2776
+					}
 				}
+			l2778:
 				depth--
-				add(ruleLess, position1795)
+				add(rulejsonSetPathNonHead, position2777)
 			}
 			return true
-		l1794:
-			position, tokenIndex, depth = position1794, tokenIndex1794, depth1794
+		l2776:
+			position, tokenIndex, depth = position2776, tokenIndex2776, depth2776
 			return false
 		},
-		/* 150 LessOrEqual <- <(<('<' '=')> Action119)> */
+		/* 224 jsonMapSingleLevel <- <(('.' jsonMapAccessString) / jsonMapAccessBracket)> */
 		func() bool {
-			position1797, tokenIndex1797, depth1797 := position, tokenIndex, depth
+			position2780, tokenIndex2780, depth2780 := position, tokenIndex, depth
 			{
-				position1798 := position
+				position2781 := position
 				depth++
 				{
-					position1799 := position
-					depth++
-					if buffer[position] != rune('<') {
-						goto l1797
+					position2782, tokenIndex2782, depth2782 := position, tokenIndex, depth
+					if buffer[position] != rune('.') {
+						goto l2783
 					}
 					position++
-					if buffer[position] != rune('=') {
-						goto l1797
+					if !_rules[rulejsonMapAccessString]() {
+						goto l2783
+					}
+					goto l2782
+				l2783:
+					position, tokenIndex, depth = position2782, tokenIndex2782, depth2782
+					if !_rules[rulejsonMapAccessBracket]() {
+						goto l2780
 					}
-					position++
-					depth--
-					add(rulePegText, position1799)
 				}
-				if !_rules[ruleAction119]() {
-					goto l1797
+			l2782:
+				depth--
+				add(rulejsonMapSingleLevel, position2781)
+			}
+			return true
+		l2780:
+			position, tokenIndex, depth = position2780, tokenIndex2780, depth2780
+			return false
+		},
+		/* 225 jsonMapMultipleLevel <- <('.' '.' (jsonMapAccessString / jsonMapAccessBracket))> */
+		func() bool {
+			position2784, tokenIndex2784, depth2784 := position, tokenIndex, depth
+			{
+				position2785 := position
+				depth++
+				if buffer[position] != rune('.') {
+					goto l2784
+				}
+				position++
+				if buffer[position] != rune('.') {
+					goto l2784
 				}
+				position++
+				{
+					position2786, tokenIndex2786, depth2786 := position, tokenIndex, depth
+					if !_rules[rulejsonMapAccessString]() {
+						goto l2787
+					}
+					goto l2786
+				l2787:
+					position, tokenIndex, depth = position2786, tokenIndex2786, depth2786
+					if !_rules[rulejsonMapAccessBracket]() {
+						goto l2784
+					}
+				}
+			l2786:
 				depth--
-				add(ruleLessOrEqual, position1798)
+				add(rulejsonMapMultipleLevel, position2785)
 			}
 			return true
-		l1797:
-			position, tokenIndex, depth = position1797, tokenIndex1797, depth1797
+		l2784:
+			position, tokenIndex, depth = position2784, tokenIndex2784, depth2784
 			return false
 		},
-		/* 151 Greater <- <(<'>'> Action120)> */
+		/* 226 jsonMapAccessString <- <<(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)>> */
 		func() bool {
-			position1800, tokenIndex1800, depth1800 := position, tokenIndex, depth
+			position2788, tokenIndex2788, depth2788 := position, tokenIndex, depth
 			{
-				position1801 := position
+				position2789 := position
 				depth++
 				{
-					position1802 := position
+					position2790 := position
 					depth++
-					if buffer[position] != rune('>') {
-						goto l1800
+					{
+						position2791, tokenIndex2791, depth2791 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l2792
+						}
+						position++
+						goto l2791
+					l2792:
+						position, tokenIndex, depth = position2791, tokenIndex2791, depth2791
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l2788
+						}
+						position++
+					}
+				l2791:
+				l2793:
+					{
+						position2794, tokenIndex2794, depth2794 := position, tokenIndex, depth
+						{
+							position2795, tokenIndex2795, depth2795 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l2796
+							}
+							position++
+							goto l2795
+						l2796:
+							position, tokenIndex, depth = position2795, tokenIndex2795, depth2795
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l2797
+							}
+							position++
+							goto l2795
+						l2797:
+							position, tokenIndex, depth = position2795, tokenIndex2795, depth2795
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l2798
+							}
+							position++
+							goto l2795
+						l2798:
+							position, tokenIndex, depth = position2795, tokenIndex2795, depth2795
+							if buffer[position] != rune('_') {
+								goto l2794
+							}
+							position++
+						}
+					l2795:
+						goto l2793
+					l2794:
+						position, tokenIndex, depth = position2794, tokenIndex2794, depth2794
 					}
-					position++
 					depth--
-					add(rulePegText, position1802)
-				}
-				if !_rules[ruleAction120]() {
-					goto l1800
+					add(rulePegText, position2790)
 				}
 				depth--
-				add(ruleGreater, position1801)
+				add(rulejsonMapAccessString, position2789)
 			}
 			return true
-		l1800:
-			position, tokenIndex, depth = position1800, tokenIndex1800, depth1800
+		l2788:
+			position, tokenIndex, depth = position2788, tokenIndex2788, depth2788
 			return false
 		},
-		/* 152 GreaterOrEqual <- <(<('>' '=')> Action121)> */
+		/* 227 jsonMapAccessBracket <- <('[' doubleQuotedString ']')> */
 		func() bool {
-			position1803, tokenIndex1803, depth1803 := position, tokenIndex, depth
+			position2799, tokenIndex2799, depth2799 := position, tokenIndex, depth
 			{
-				position1804 := position
+				position2800 := position
 				depth++
-				{
-					position1805 := position
-					depth++
-					if buffer[position] != rune('>') {
-						goto l1803
-					}
-					position++
-					if buffer[position] != rune('=') {
-						goto l1803
-					}
-					position++
-					depth--
-					add(rulePegText, position1805)
+				if buffer[position] != rune('[') {
+					goto l2799
 				}
-				if !_rules[ruleAction121]() {
-					goto l1803
+				position++
+				if !_rules[ruledoubleQuotedString]() {
+					goto l2799
+				}
+				if buffer[position] != rune(']') {
+					goto l2799
 				}
+				position++
 				depth--
-				add(ruleGreaterOrEqual, position1804)
+				add(rulejsonMapAccessBracket, position2800)
 			}
 			return true
-		l1803:
-			position, tokenIndex, depth = position1803, tokenIndex1803, depth1803
+		l2799:
+			position, tokenIndex, depth = position2799, tokenIndex2799, depth2799
 			return false
 		},
-		/* 153 NotEqual <- <(<(('!' '=') / ('<' '>'))> Action122)> */
+		/* 228 doubleQuotedString <- <('"' <(('"' '"') / (!'"' .))*> '"')> */
 		func() bool {
-			position1806, tokenIndex1806, depth1806 := position, tokenIndex, depth
+			position2801, tokenIndex2801, depth2801 := position, tokenIndex, depth
 			{
-				position1807 := position
+				position2802 := position
 				depth++
+				if buffer[position] != rune('"') {
+					goto l2801
+				}
+				position++
 				{
-					position1808 := position
+					position2803 := position
 					depth++
+				l2804:
 					{
-						position1809, tokenIndex1809, depth1809 := position, tokenIndex, depth
-						if buffer[position] != rune('!') {
-							goto l1810
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l1810
-						}
-						position++
-						goto l1809
-					l1810:
-						position, tokenIndex, depth = position1809, tokenIndex1809, depth1809
-						if buffer[position] != rune('<') {
-							goto l1806
-						}
-						position++
-						if buffer[position] != rune('>') {
-							goto l1806
+						position2805, tokenIndex2805, depth2805 := position, tokenIndex, depth
+						{
+							position2806, tokenIndex2806, depth2806 := position, tokenIndex, depth
+							if buffer[position] != rune('"') {
+								goto l2807
+							}
+							position++
+							if buffer[position] != rune('"') {
+								goto l2807
+							}
+							position++
+							goto l2806
+						l2807:
+							position, tokenIndex, depth = position2806, tokenIndex2806, depth2806
+							{
+								position2808, tokenIndex2808, depth2808 := position, tokenIndex, depth
+								if buffer[position] != rune('"') {
+									goto l2808
+								}
+								position++
+								goto l2805
+							l2808:
+								position, tokenIndex, depth = position2808, tokenIndex2808, depth2808
+							}
+							if !matchDot() {
+								goto l2805
+							}
 						}
-						position++
+					l2806:
+						goto l2804
+					l2805:
+						position, tokenIndex, depth = position2805, tokenIndex2805, depth2805
 					}
-				l1809:
 					depth--
-					add(rulePegText, position1808)
+					add(rulePegText, position2803)
 				}
-				if !_rules[ruleAction122]() {
-					goto l1806
+				if buffer[position] != rune('"') {
+					goto l2801
 				}
+				position++
 				depth--
-				add(ruleNotEqual, position1807)
+				add(ruledoubleQuotedString, position2802)
 			}
 			return true
-		l1806:
-			position, tokenIndex, depth = position1806, tokenIndex1806, depth1806
+		l2801:
+			position, tokenIndex, depth = position2801, tokenIndex2801, depth2801
 			return false
 		},
-		/* 154 Concat <- <(<('|' '|')> Action123)> */
+		/* 229 jsonArrayAccess <- <('[' <('-'? [0-9]+)> ']')> */
 		func() bool {
-			position1811, tokenIndex1811, depth1811 := position, tokenIndex, depth
+			position2809, tokenIndex2809, depth2809 := position, tokenIndex, depth
 			{
-				position1812 := position
+				position2810 := position
 				depth++
+				if buffer[position] != rune('[') {
+					goto l2809
+				}
+				position++
 				{
-					position1813 := position
+					position2811 := position
 					depth++
-					if buffer[position] != rune('|') {
-						goto l1811
+					{
+						position2812, tokenIndex2812, depth2812 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l2812
+						}
+						position++
+						goto l2813
+					l2812:
+						position, tokenIndex, depth = position2812, tokenIndex2812, depth2812
 					}
-					position++
-					if buffer[position] != rune('|') {
-						goto l1811
+				l2813:
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l2809
 					}
 					position++
+				l2814:
+					{
+						position2815, tokenIndex2815, depth2815 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2815
+						}
+						position++
+						goto l2814
+					l2815:
+						position, tokenIndex, depth = position2815, tokenIndex2815, depth2815
+					}
 					depth--
-					add(rulePegText, position1813)
+					add(rulePegText, position2811)
 				}
-				if !_rules[ruleAction123]() {
-					goto l1811
+				if buffer[position] != rune(']') {
+					goto l2809
 				}
+				position++
 				depth--
-				add(ruleConcat, position1812)
+				add(rulejsonArrayAccess, position2810)
 			}
 			return true
-		l1811:
-			position, tokenIndex, depth = position1811, tokenIndex1811, depth1811
+		l2809:
+			position, tokenIndex, depth = position2809, tokenIndex2809, depth2809
 			return false
 		},
-		/* 155 Is <- <(<(('i' / 'I') ('s' / 'S'))> Action124)> */
+		/* 230 jsonNonNegativeArrayAccess <- <('[' <[0-9]+> ']')> */
 		func() bool {
-			position1814, tokenIndex1814, depth1814 := position, tokenIndex, depth
+			position2816, tokenIndex2816, depth2816 := position, tokenIndex, depth
 			{
-				position1815 := position
+				position2817 := position
 				depth++
+				if buffer[position] != rune('[') {
+					goto l2816
+				}
+				position++
 				{
-					position1816 := position
+					position2818 := position
 					depth++
-					{
-						position1817, tokenIndex1817, depth1817 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1818
-						}
-						position++
-						goto l1817
-					l1818:
-						position, tokenIndex, depth = position1817, tokenIndex1817, depth1817
-						if buffer[position] != rune('I') {
-							goto l1814
-						}
-						position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l2816
 					}
-				l1817:
+					position++
+				l2819:
 					{
-						position1819, tokenIndex1819, depth1819 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1820
-						}
-						position++
-						goto l1819
-					l1820:
-						position, tokenIndex, depth = position1819, tokenIndex1819, depth1819
-						if buffer[position] != rune('S') {
-							goto l1814
+						position2820, tokenIndex2820, depth2820 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2820
 						}
 						position++
+						goto l2819
+					l2820:
+						position, tokenIndex, depth = position2820, tokenIndex2820, depth2820
 					}
-				l1819:
 					depth--
-					add(rulePegText, position1816)
+					add(rulePegText, position2818)
 				}
-				if !_rules[ruleAction124]() {
-					goto l1814
+				if buffer[position] != rune(']') {
+					goto l2816
 				}
+				position++
 				depth--
-				add(ruleIs, position1815)
+				add(rulejsonNonNegativeArrayAccess, position2817)
 			}
 			return true
-		l1814:
-			position, tokenIndex, depth = position1814, tokenIndex1814, depth1814
+		l2816:
+			position, tokenIndex, depth = position2816, tokenIndex2816, depth2816
 			return false
 		},
-		/* 156 IsNot <- <(<(('i' / 'I') ('s' / 'S') sp (('n' / 'N') ('o' / 'O') ('t' / 'T')))> Action125)> */
+		/* 231 jsonArraySlice <- <('[' <('-'? [0-9]+ ':' '-'? [0-9]+ (':' '-'? [0-9]+)?)> ']')> */
 		func() bool {
-			position1821, tokenIndex1821, depth1821 := position, tokenIndex, depth
+			position2821, tokenIndex2821, depth2821 := position, tokenIndex, depth
 			{
-				position1822 := position
+				position2822 := position
 				depth++
+				if buffer[position] != rune('[') {
+					goto l2821
+				}
+				position++
 				{
-					position1823 := position
+					position2823 := position
 					depth++
 					{
-						position1824, tokenIndex1824, depth1824 := position, tokenIndex, depth
-						if buffer[position] != rune('i') {
-							goto l1825
-						}
-						position++
-						goto l1824
-					l1825:
-						position, tokenIndex, depth = position1824, tokenIndex1824, depth1824
-						if buffer[position] != rune('I') {
-							goto l1821
+						position2824, tokenIndex2824, depth2824 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l2824
 						}
 						position++
+						goto l2825
+					l2824:
+						position, tokenIndex, depth = position2824, tokenIndex2824, depth2824
 					}
-				l1824:
+				l2825:
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l2821
+					}
+					position++
+				l2826:
 					{
-						position1826, tokenIndex1826, depth1826 := position, tokenIndex, depth
-						if buffer[position] != rune('s') {
-							goto l1827
-						}
-						position++
-						goto l1826
-					l1827:
-						position, tokenIndex, depth = position1826, tokenIndex1826, depth1826
-						if buffer[position] != rune('S') {
-							goto l1821
+						position2827, tokenIndex2827, depth2827 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2827
 						}
 						position++
+						goto l2826
+					l2827:
+						position, tokenIndex, depth = position2827, tokenIndex2827, depth2827
 					}
-				l1826:
-					if !_rules[rulesp]() {
-						goto l1821
+					if buffer[position] != rune(':') {
+						goto l2821
 					}
+					position++
 					{
-						position1828, tokenIndex1828, depth1828 := position, tokenIndex, depth
-						if buffer[position] != rune('n') {
-							goto l1829
-						}
-						position++
-						goto l1828
-					l1829:
-						position, tokenIndex, depth = position1828, tokenIndex1828, depth1828
-						if buffer[position] != rune('N') {
-							goto l1821
+						position2828, tokenIndex2828, depth2828 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l2828
 						}
 						position++
+						goto l2829
+					l2828:
+						position, tokenIndex, depth = position2828, tokenIndex2828, depth2828
+					}
+				l2829:
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l2821
 					}
-				l1828:
+					position++
+				l2830:
 					{
-						position1830, tokenIndex1830, depth1830 := position, tokenIndex, depth
-						if buffer[position] != rune('o') {
-							goto l1831
-						}
-						position++
-						goto l1830
-					l1831:
-						position, tokenIndex, depth = position1830, tokenIndex1830, depth1830
-						if buffer[position] != rune('O') {
-							goto l1821
+						position2831, tokenIndex2831, depth2831 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2831
 						}
 						position++
+						goto l2830
+					l2831:
+						position, tokenIndex, depth = position2831, tokenIndex2831, depth2831
 					}
-				l1830:
 					{
-						position1832, tokenIndex1832, depth1832 := position, tokenIndex, depth
-						if buffer[position] != rune('t') {
-							goto l1833
+						position2832, tokenIndex2832, depth2832 := position, tokenIndex, depth
+						if buffer[position] != rune(':') {
+							goto l2832
 						}
 						position++
-						goto l1832
-					l1833:
-						position, tokenIndex, depth = position1832, tokenIndex1832, depth1832
-						if buffer[position] != rune('T') {
-							goto l1821
+						{
+							position2834, tokenIndex2834, depth2834 := position, tokenIndex, depth
+							if buffer[position] != rune('-') {
+								goto l2834
+							}
+							position++
+							goto l2835
+						l2834:
+							position, tokenIndex, depth = position2834, tokenIndex2834, depth2834
+						}
+					l2835:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2832
 						}
 						position++
+					l2836:
+						{
+							position2837, tokenIndex2837, depth2837 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l2837
+							}
+							position++
+							goto l2836
+						l2837:
+							position, tokenIndex, depth = position2837, tokenIndex2837, depth2837
+						}
+						goto l2833
+					l2832:
+						position, tokenIndex, depth = position2832, tokenIndex2832, depth2832
 					}
-				l1832:
+				l2833:
 					depth--
-					add(rulePegText, position1823)
+					add(rulePegText, position2823)
 				}
-				if !_rules[ruleAction125]() {
-					goto l1821
+				if buffer[position] != rune(']') {
+					goto l2821
 				}
+				position++
 				depth--
-				add(ruleIsNot, position1822)
+				add(rulejsonArraySlice, position2822)
 			}
 			return true
-		l1821:
-			position, tokenIndex, depth = position1821, tokenIndex1821, depth1821
+		l2821:
+			position, tokenIndex, depth = position2821, tokenIndex2821, depth2821
 			return false
 		},
-		/* 157 Plus <- <(<'+'> Action126)> */
+		/* 232 jsonArrayPartialSlice <- <('[' <((':' '-'? [0-9]+) / ('-'? [0-9]+ ':'))> ']')> */
 		func() bool {
-			position1834, tokenIndex1834, depth1834 := position, tokenIndex, depth
+			position2838, tokenIndex2838, depth2838 := position, tokenIndex, depth
 			{
-				position1835 := position
+				position2839 := position
 				depth++
+				if buffer[position] != rune('[') {
+					goto l2838
+				}
+				position++
 				{
-					position1836 := position
+					position2840 := position
 					depth++
-					if buffer[position] != rune('+') {
-						goto l1834
+					{
+						position2841, tokenIndex2841, depth2841 := position, tokenIndex, depth
+						if buffer[position] != rune(':') {
+							goto l2842
+						}
+						position++
+						{
+							position2843, tokenIndex2843, depth2843 := position, tokenIndex, depth
+							if buffer[position] != rune('-') {
+								goto l2843
+							}
+							position++
+							goto l2844
+						l2843:
+							position, tokenIndex, depth = position2843, tokenIndex2843, depth2843
+						}
+					l2844:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2842
+						}
+						position++
+					l2845:
+						{
+							position2846, tokenIndex2846, depth2846 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l2846
+							}
+							position++
+							goto l2845
+						l2846:
+							position, tokenIndex, depth = position2846, tokenIndex2846, depth2846
+						}
+						goto l2841
+					l2842:
+						position, tokenIndex, depth = position2841, tokenIndex2841, depth2841
+						{
+							position2847, tokenIndex2847, depth2847 := position, tokenIndex, depth
+							if buffer[position] != rune('-') {
+								goto l2847
+							}
+							position++
+							goto l2848
+						l2847:
+							position, tokenIndex, depth = position2847, tokenIndex2847, depth2847
+						}
+					l2848:
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l2838
+						}
+						position++
+					l2849:
+						{
+							position2850, tokenIndex2850, depth2850 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l2850
+							}
+							position++
+							goto l2849
+						l2850:
+							position, tokenIndex, depth = position2850, tokenIndex2850, depth2850
+						}
+						if buffer[position] != rune(':') {
+							goto l2838
+						}
+						position++
 					}
-					position++
+				l2841:
 					depth--
-					add(rulePegText, position1836)
+					add(rulePegText, position2840)
 				}
-				if !_rules[ruleAction126]() {
-					goto l1834
+				if buffer[position] != rune(']') {
+					goto l2838
 				}
+				position++
 				depth--
-				add(rulePlus, position1835)
+				add(rulejsonArrayPartialSlice, position2839)
 			}
 			return true
-		l1834:
-			position, tokenIndex, depth = position1834, tokenIndex1834, depth1834
+		l2838:
+			position, tokenIndex, depth = position2838, tokenIndex2838, depth2838
 			return false
 		},
-		/* 158 Minus <- <(<'-'> Action127)> */
+		/* 233 jsonArrayFullSlice <- <('[' ':' ']')> */
 		func() bool {
-			position1837, tokenIndex1837, depth1837 := position, tokenIndex, depth
+			position2851, tokenIndex2851, depth2851 := position, tokenIndex, depth
 			{
-				position1838 := position
+				position2852 := position
 				depth++
-				{
-					position1839 := position
-					depth++
-					if buffer[position] != rune('-') {
-						goto l1837
-					}
-					position++
-					depth--
-					add(rulePegText, position1839)
+				if buffer[position] != rune('[') {
+					goto l2851
 				}
-				if !_rules[ruleAction127]() {
-					goto l1837
+				position++
+				if buffer[position] != rune(':') {
+					goto l2851
 				}
+				position++
+				if buffer[position] != rune(']') {
+					goto l2851
+				}
+				position++
 				depth--
-				add(ruleMinus, position1838)
+				add(rulejsonArrayFullSlice, position2852)
 			}
 			return true
-		l1837:
-			position, tokenIndex, depth = position1837, tokenIndex1837, depth1837
+		l2851:
+			position, tokenIndex, depth = position2851, tokenIndex2851, depth2851
 			return false
 		},
-		/* 159 Multiply <- <(<'*'> Action128)> */
+		/* 234 spElem <- <(' ' / '\t' / '\n' / '\r' / comment / finalComment)> */
 		func() bool {
-			position1840, tokenIndex1840, depth1840 := position, tokenIndex, depth
+			position2853, tokenIndex2853, depth2853 := position, tokenIndex, depth
 			{
-				position1841 := position
+				position2854 := position
 				depth++
 				{
-					position1842 := position
-					depth++
-					if buffer[position] != rune('*') {
-						goto l1840
+					position2855, tokenIndex2855, depth2855 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l2856
 					}
 					position++
-					depth--
-					add(rulePegText, position1842)
-				}
-				if !_rules[ruleAction128]() {
-					goto l1840
-				}
-				depth--
-				add(ruleMultiply, position1841)
-			}
-			return true
-		l1840:
-			position, tokenIndex, depth = position1840, tokenIndex1840, depth1840
-			return false
-		},
-		/* 160 Divide <- <(<'/'> Action129)> */
-		func() bool {
-			position1843, tokenIndex1843, depth1843 := position, tokenIndex, depth
-			{
-				position1844 := position
-				depth++
-				{
-					position1845 := position
-					depth++
-					if buffer[position] != rune('/') {
-						goto l1843
+					goto l2855
+				l2856:
+					position, tokenIndex, depth = position2855, tokenIndex2855, depth2855
+					if buffer[position] != rune('\t') {
+						goto l2857
 					}
 					position++
-					depth--
-					add(rulePegText, position1845)
-				}
-				if !_rules[ruleAction129]() {
-					goto l1843
-				}
-				depth--
-				add(ruleDivide, position1844)
-			}
-			return true
-		l1843:
-			position, tokenIndex, depth = position1843, tokenIndex1843, depth1843
-			return false
-		},
-		/* 161 Modulo <- <(<'%'> Action130)> */
-		func() bool {
-			position1846, tokenIndex1846, depth1846 := position, tokenIndex, depth
-			{
-				position1847 := position
-				depth++
-				{
-					position1848 := position
-					depth++
-					if buffer[position] != rune('%') {
-						goto l1846
+					goto l2855
+				l2857:
+					position, tokenIndex, depth = position2855, tokenIndex2855, depth2855
+					if buffer[position] != rune('\n') {
+						goto l2858
 					}
 					position++
-					depth--
-					add(rulePegText, position1848)
-				}
-				if !_rules[ruleAction130]() {
-					goto l1846
+					goto l2855
+				l2858:
+					position, tokenIndex, depth = position2855, tokenIndex2855, depth2855
+					if buffer[position] != rune('\r') {
+						goto l2859
+					}
+					position++
+					goto l2855
+				l2859:
+					position, tokenIndex, depth = position2855, tokenIndex2855, depth2855
+					if !_rules[rulecomment]() {
+						goto l2860
+					}
+					goto l2855
+				l2860:
+					position, tokenIndex, depth = position2855, tokenIndex2855, depth2855
+					if !_rules[rulefinalComment]() {
+						goto l2853
+					}
 				}
+			l2855:
 				depth--
-				add(ruleModulo, position1847)
+				add(rulespElem, position2854)
 			}
 			return true
-		l1846:
-			position, tokenIndex, depth = position1846, tokenIndex1846, depth1846
+		l2853:
+			position, tokenIndex, depth = position2853, tokenIndex2853, depth2853
 			return false
 		},
-		/* 162 UnaryMinus <- <(<'-'> Action131)> */
+		/* 235 sp <- <spElem+> */
 		func() bool {
-			position1849, tokenIndex1849, depth1849 := position, tokenIndex, depth
+			position2861, tokenIndex2861, depth2861 := position, tokenIndex, depth
 			{
-				position1850 := position
+				position2862 := position
 				depth++
+				if !_rules[rulespElem]() {
+					goto l2861
+				}
+			l2863:
 				{
-					position1851 := position
-					depth++
-					if buffer[position] != rune('-') {
-						goto l1849
+					position2864, tokenIndex2864, depth2864 := position, tokenIndex, depth
+					if !_rules[rulespElem]() {
+						goto l2864
 					}
-					position++
-					depth--
-					add(rulePegText, position1851)
-				}
-				if !_rules[ruleAction131]() {
-					goto l1849
+					goto l2863
+				l2864:
+					position, tokenIndex, depth = position2864, tokenIndex2864, depth2864
 				}
 				depth--
-				add(ruleUnaryMinus, position1850)
+				add(rulesp, position2862)
 			}
 			return true
-		l1849:
-			position, tokenIndex, depth = position1849, tokenIndex1849, depth1849
+		l2861:
+			position, tokenIndex, depth = position2861, tokenIndex2861, depth2861
 			return false
 		},
-		/* 163 Identifier <- <(<ident> Action132)> */
+		/* 236 spOpt <- <spElem*> */
 		func() bool {
-			position1852, tokenIndex1852, depth1852 := position, tokenIndex, depth
 			{
-				position1853 := position
+				position2866 := position
 				depth++
+			l2867:
 				{
-					position1854 := position
-					depth++
-					if !_rules[ruleident]() {
-						goto l1852
+					position2868, tokenIndex2868, depth2868 := position, tokenIndex, depth
+					if !_rules[rulespElem]() {
+						goto l2868
 					}
-					depth--
-					add(rulePegText, position1854)
-				}
-				if !_rules[ruleAction132]() {
-					goto l1852
+					goto l2867
+				l2868:
+					position, tokenIndex, depth = position2868, tokenIndex2868, depth2868
 				}
 				depth--
-				add(ruleIdentifier, position1853)
+				add(rulespOpt, position2866)
 			}
 			return true
-		l1852:
-			position, tokenIndex, depth = position1852, tokenIndex1852, depth1852
-			return false
 		},
-		/* 164 TargetIdentifier <- <(<('*' / jsonSetPath)> Action133)> */
+		/* 237 comment <- <('-' '-' (!('\r' / '\n') .)* ('\r' / '\n'))> */
 		func() bool {
-			position1855, tokenIndex1855, depth1855 := position, tokenIndex, depth
+			position2869, tokenIndex2869, depth2869 := position, tokenIndex, depth
 			{
-				position1856 := position
+				position2870 := position
 				depth++
+				if buffer[position] != rune('-') {
+					goto l2869
+				}
+				position++
+				if buffer[position] != rune('-') {
+					goto l2869
+				}
+				position++
+			l2871:
 				{
-					position1857 := position
-					depth++
+					position2872, tokenIndex2872, depth2872 := position, tokenIndex, depth
 					{
-						position1858, tokenIndex1858, depth1858 := position, tokenIndex, depth
-						if buffer[position] != rune('*') {
-							goto l1859
-						}
-						position++
-						goto l1858
-					l1859:
-						position, tokenIndex, depth = position1858, tokenIndex1858, depth1858
-						if !_rules[rulejsonSetPath]() {
-							goto l1855
+						position2873, tokenIndex2873, depth2873 := position, tokenIndex, depth
+						{
+							position2874, tokenIndex2874, depth2874 := position, tokenIndex, depth
+							if buffer[position] != rune('\r') {
+								goto l2875
+							}
+							position++
+							goto l2874
+						l2875:
+							position, tokenIndex, depth = position2874, tokenIndex2874, depth2874
+							if buffer[position] != rune('\n') {
+								goto l2873
+							}
+							position++
 						}
+					l2874:
+						goto l2872
+					l2873:
+						position, tokenIndex, depth = position2873, tokenIndex2873, depth2873
 					}
-				l1858:
-					depth--
-					add(rulePegText, position1857)
+					if !matchDot() {
+						goto l2872
+					}
+					goto l2871
+				l2872:
+					position, tokenIndex, depth = position2872, tokenIndex2872, depth2872
 				}
-				if !_rules[ruleAction133]() {
-					goto l1855
+				{
+					position2876, tokenIndex2876, depth2876 := position, tokenIndex, depth
+					if buffer[position] != rune('\r') {
+						goto l2877
+					}
+					position++
+					goto l2876
+				l2877:
+					position, tokenIndex, depth = position2876, tokenIndex2876, depth2876
+					if buffer[position] != rune('\n') {
+						goto l2869
+					}
+					position++
 				}
+			l2876:
 				depth--
-				add(ruleTargetIdentifier, position1856)
+				add(rulecomment, position2870)
 			}
 			return true
-		l1855:
-			position, tokenIndex, depth = position1855, tokenIndex1855, depth1855
+		l2869:
+			position, tokenIndex, depth = position2869, tokenIndex2869, depth2869
 			return false
 		},
-		/* 165 ident <- <(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)> */
+		/* 238 finalComment <- <('-' '-' (!('\r' / '\n') .)* !.)> */
 		func() bool {
-			position1860, tokenIndex1860, depth1860 := position, tokenIndex, depth
+			position2878, tokenIndex2878, depth2878 := position, tokenIndex, depth
 			{
-				position1861 := position
+				position2879 := position
 				depth++
+				if buffer[position] != rune('-') {
+					goto l2878
+				}
+				position++
+				if buffer[position] != rune('-') {
+					goto l2878
+				}
+				position++
+			l2880:
 				{
-					position1862, tokenIndex1862, depth1862 := position, tokenIndex, depth
-					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l1863
+					position2881, tokenIndex2881, depth2881 := position, tokenIndex, depth
+					{
+						position2882, tokenIndex2882, depth2882 := position, tokenIndex, depth
+						{
+							position2883, tokenIndex2883, depth2883 := position, tokenIndex, depth
+							if buffer[position] != rune('\r') {
+								goto l2884
+							}
+							position++
+							goto l2883
+						l2884:
+							position, tokenIndex, depth = position2883, tokenIndex2883, depth2883
+							if buffer[position] != rune('\n') {
+								goto l2882
+							}
+							position++
+						}
+					l2883:
+						goto l2881
+					l2882:
+						position, tokenIndex, depth = position2882, tokenIndex2882, depth2882
 					}
-					position++
-					goto l1862
-				l1863:
-					position, tokenIndex, depth = position1862, tokenIndex1862, depth1862
-					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l1860
+					if !matchDot() {
+						goto l2881
 					}
-					position++
+					goto l2880
+				l2881:
+					position, tokenIndex, depth = position2881, tokenIndex2881, depth2881
 				}
-			l1862:
-			l1864:
 				{
-					position1865, tokenIndex1865, depth1865 := position, tokenIndex, depth
-					{
-						position1866, tokenIndex1866, depth1866 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l1867
-						}
-						position++
-						goto l1866
-					l1867:
-						position, tokenIndex, depth = position1866, tokenIndex1866, depth1866
-						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l1868
-						}
-						position++
-						goto l1866
-					l1868:
-						position, tokenIndex, depth = position1866, tokenIndex1866, depth1866
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1869
-						}
-						position++
-						goto l1866
-					l1869:
-						position, tokenIndex, depth = position1866, tokenIndex1866, depth1866
-						if buffer[position] != rune('_') {
-							goto l1865
-						}
-						position++
+					position2885, tokenIndex2885, depth2885 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l2885
 					}
-				l1866:
-					goto l1864
-				l1865:
-					position, tokenIndex, depth = position1865, tokenIndex1865, depth1865
+					goto l2878
+				l2885:
+					position, tokenIndex, depth = position2885, tokenIndex2885, depth2885
 				}
 				depth--
-				add(ruleident, position1861)
+				add(rulefinalComment, position2879)
 			}
 			return true
-		l1860:
-			position, tokenIndex, depth = position1860, tokenIndex1860, depth1860
+		l2878:
+			position, tokenIndex, depth = position2878, tokenIndex2878, depth2878
 			return false
 		},
-		/* 166 jsonGetPath <- <(jsonPathHead jsonGetPathNonHead*)> */
+		nil,
+		/* 241 Action0 <- <{
+		    p.IncludeTrailingWhitespace(begin, end)
+		}> */
 		func() bool {
-			position1870, tokenIndex1870, depth1870 := position, tokenIndex, depth
 			{
-				position1871 := position
-				depth++
-				if !_rules[rulejsonPathHead]() {
-					goto l1870
-				}
-			l1872:
-				{
-					position1873, tokenIndex1873, depth1873 := position, tokenIndex, depth
-					if !_rules[rulejsonGetPathNonHead]() {
-						goto l1873
-					}
-					goto l1872
-				l1873:
-					position, tokenIndex, depth = position1873, tokenIndex1873, depth1873
-				}
-				depth--
-				add(rulejsonGetPath, position1871)
+				add(ruleAction0, position)
+			}
+			return true
+		},
+		/* 242 Action1 <- <{
+		    p.IncludeTrailingWhitespace(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction1, position)
+			}
+			return true
+		},
+		/* 243 Action2 <- <{
+		    p.AssembleSelect()
+		}> */
+		func() bool {
+			{
+				add(ruleAction2, position)
+			}
+			return true
+		},
+		/* 244 Action3 <- <{
+		    p.AssembleSelectUnion(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction3, position)
+			}
+			return true
+		},
+		/* 245 Action4 <- <{
+		    p.AssembleCreateStreamAsSelect()
+		}> */
+		func() bool {
+			{
+				add(ruleAction4, position)
+			}
+			return true
+		},
+		/* 246 Action5 <- <{
+		    p.EnsureEvictionTarget(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction5, position)
+			}
+			return true
+		},
+		/* 247 Action6 <- <{
+		    p.AssembleCreateStreamAsSelectUnion()
+		}> */
+		func() bool {
+			{
+				add(ruleAction6, position)
+			}
+			return true
+		},
+		/* 248 Action7 <- <{
+		    p.AssembleCreateSource()
+		}> */
+		func() bool {
+			{
+				add(ruleAction7, position)
+			}
+			return true
+		},
+		/* 249 Action8 <- <{
+		    p.AssembleCreateSink()
+		}> */
+		func() bool {
+			{
+				add(ruleAction8, position)
+			}
+			return true
+		},
+		/* 250 Action9 <- <{
+		    p.AssembleCreateBox()
+		}> */
+		func() bool {
+			{
+				add(ruleAction9, position)
+			}
+			return true
+		},
+		/* 251 Action10 <- <{
+		    p.AssembleCreateState()
+		}> */
+		func() bool {
+			{
+				add(ruleAction10, position)
+			}
+			return true
+		},
+		/* 252 Action11 <- <{
+		    p.AssembleUpdateState()
+		}> */
+		func() bool {
+			{
+				add(ruleAction11, position)
+			}
+			return true
+		},
+		/* 253 Action12 <- <{
+		    p.AssembleUpdateSource()
+		}> */
+		func() bool {
+			{
+				add(ruleAction12, position)
+			}
+			return true
+		},
+		/* 254 Action13 <- <{
+		    p.AssembleUpdateSink()
+		}> */
+		func() bool {
+			{
+				add(ruleAction13, position)
+			}
+			return true
+		},
+		/* 255 Action14 <- <{
+		    p.AssembleInsertIntoFrom()
+		}> */
+		func() bool {
+			{
+				add(ruleAction14, position)
+			}
+			return true
+		},
+		/* 256 Action15 <- <{
+		    p.AssemblePauseSource()
+		}> */
+		func() bool {
+			{
+				add(ruleAction15, position)
+			}
+			return true
+		},
+		/* 257 Action16 <- <{
+		    p.AssembleResumeSource()
+		}> */
+		func() bool {
+			{
+				add(ruleAction16, position)
+			}
+			return true
+		},
+		/* 258 Action17 <- <{
+		    p.AssembleRewindSource()
+		}> */
+		func() bool {
+			{
+				add(ruleAction17, position)
+			}
+			return true
+		},
+		/* 259 Action18 <- <{
+		    p.AssembleDropSource()
+		}> */
+		func() bool {
+			{
+				add(ruleAction18, position)
+			}
+			return true
+		},
+		/* 260 Action19 <- <{
+		    p.AssembleDropStream()
+		}> */
+		func() bool {
+			{
+				add(ruleAction19, position)
 			}
 			return true
-		l1870:
-			position, tokenIndex, depth = position1870, tokenIndex1870, depth1870
-			return false
 		},
-		/* 167 jsonSetPath <- <(jsonPathHead jsonSetPathNonHead*)> */
+		/* 261 Action20 <- <{
+		    p.AssembleDropSink()
+		}> */
 		func() bool {
-			position1874, tokenIndex1874, depth1874 := position, tokenIndex, depth
 			{
-				position1875 := position
-				depth++
-				if !_rules[rulejsonPathHead]() {
-					goto l1874
-				}
-			l1876:
-				{
-					position1877, tokenIndex1877, depth1877 := position, tokenIndex, depth
-					if !_rules[rulejsonSetPathNonHead]() {
-						goto l1877
-					}
-					goto l1876
-				l1877:
-					position, tokenIndex, depth = position1877, tokenIndex1877, depth1877
-				}
-				depth--
-				add(rulejsonSetPath, position1875)
+				add(ruleAction20, position)
 			}
 			return true
-		l1874:
-			position, tokenIndex, depth = position1874, tokenIndex1874, depth1874
-			return false
 		},
-		/* 168 jsonPathHead <- <(jsonMapAccessString / jsonMapAccessBracket)> */
+		/* 262 Action21 <- <{
+		    p.AssembleDropState()
+		}> */
 		func() bool {
-			position1878, tokenIndex1878, depth1878 := position, tokenIndex, depth
 			{
-				position1879 := position
-				depth++
-				{
-					position1880, tokenIndex1880, depth1880 := position, tokenIndex, depth
-					if !_rules[rulejsonMapAccessString]() {
-						goto l1881
-					}
-					goto l1880
-				l1881:
-					position, tokenIndex, depth = position1880, tokenIndex1880, depth1880
-					if !_rules[rulejsonMapAccessBracket]() {
-						goto l1878
-					}
-				}
-			l1880:
-				depth--
-				add(rulejsonPathHead, position1879)
+				add(ruleAction21, position)
 			}
 			return true
-		l1878:
-			position, tokenIndex, depth = position1878, tokenIndex1878, depth1878
-			return false
 		},
-		/* 169 jsonGetPathNonHead <- <(jsonMapMultipleLevel / jsonMapSingleLevel / jsonArrayFullSlice / jsonArrayPartialSlice / jsonArraySlice / jsonArrayAccess)> */
+		/* 263 Action22 <- <{
+		    p.AssembleLoadState()
+		}> */
 		func() bool {
-			position1882, tokenIndex1882, depth1882 := position, tokenIndex, depth
 			{
-				position1883 := position
-				depth++
-				{
-					position1884, tokenIndex1884, depth1884 := position, tokenIndex, depth
-					if !_rules[rulejsonMapMultipleLevel]() {
-						goto l1885
-					}
-					goto l1884
-				l1885:
-					position, tokenIndex, depth = position1884, tokenIndex1884, depth1884
-					if !_rules[rulejsonMapSingleLevel]() {
-						goto l1886
-					}
-					goto l1884
-				l1886:
-					position, tokenIndex, depth = position1884, tokenIndex1884, depth1884
-					if !_rules[rulejsonArrayFullSlice]() {
-						goto l1887
-					}
-					goto l1884
-				l1887:
-					position, tokenIndex, depth = position1884, tokenIndex1884, depth1884
-					if !_rules[rulejsonArrayPartialSlice]() {
-						goto l1888
-					}
-					goto l1884
-				l1888:
-					position, tokenIndex, depth = position1884, tokenIndex1884, depth1884
-					if !_rules[rulejsonArraySlice]() {
-						goto l1889
-					}
-					goto l1884
-				l1889:
-					position, tokenIndex, depth = position1884, tokenIndex1884, depth1884
-					if !_rules[rulejsonArrayAccess]() {
-						goto l1882
-					}
-				}
-			l1884:
-				depth--
-				add(rulejsonGetPathNonHead, position1883)
+				add(ruleAction22, position)
 			}
 			return true
-		l1882:
-			position, tokenIndex, depth = position1882, tokenIndex1882, depth1882
-			return false
 		},
-		/* 170 jsonSetPathNonHead <- <(jsonMapSingleLevel / jsonNonNegativeArrayAccess)> */
+		/* 264 Action23 <- <{
+		    p.AssembleLoadStateOrCreate()
+		}> */
 		func() bool {
-			position1890, tokenIndex1890, depth1890 := position, tokenIndex, depth
 			{
-				position1891 := position
-				depth++
-				{
-					position1892, tokenIndex1892, depth1892 := position, tokenIndex, depth
-					if !_rules[rulejsonMapSingleLevel]() {
-						goto l1893
-					}
-					goto l1892
-				l1893:
-					position, tokenIndex, depth = position1892, tokenIndex1892, depth1892
-					if !_rules[rulejsonNonNegativeArrayAccess]() {
-						goto l1890
-					}
-				}
-			l1892:
-				depth--
-				add(rulejsonSetPathNonHead, position1891)
+				add(ruleAction23, position)
 			}
 			return true
-		l1890:
-			position, tokenIndex, depth = position1890, tokenIndex1890, depth1890
-			return false
 		},
-		/* 171 jsonMapSingleLevel <- <(('.' jsonMapAccessString) / jsonMapAccessBracket)> */
+		/* 265 Action24 <- <{
+		    p.AssembleSaveState()
+		}> */
 		func() bool {
-			position1894, tokenIndex1894, depth1894 := position, tokenIndex, depth
 			{
-				position1895 := position
-				depth++
-				{
-					position1896, tokenIndex1896, depth1896 := position, tokenIndex, depth
-					if buffer[position] != rune('.') {
-						goto l1897
-					}
-					position++
-					if !_rules[rulejsonMapAccessString]() {
-						goto l1897
-					}
-					goto l1896
-				l1897:
-					position, tokenIndex, depth = position1896, tokenIndex1896, depth1896
-					if !_rules[rulejsonMapAccessBracket]() {
-						goto l1894
-					}
-				}
-			l1896:
-				depth--
-				add(rulejsonMapSingleLevel, position1895)
+				add(ruleAction24, position)
 			}
 			return true
-		l1894:
-			position, tokenIndex, depth = position1894, tokenIndex1894, depth1894
-			return false
 		},
-		/* 172 jsonMapMultipleLevel <- <('.' '.' (jsonMapAccessString / jsonMapAccessBracket))> */
+		/* 266 Action25 <- <{
+		    p.AssembleLoadPlugin()
+		}> */
 		func() bool {
-			position1898, tokenIndex1898, depth1898 := position, tokenIndex, depth
 			{
-				position1899 := position
-				depth++
-				if buffer[position] != rune('.') {
-					goto l1898
-				}
-				position++
-				if buffer[position] != rune('.') {
-					goto l1898
-				}
-				position++
-				{
-					position1900, tokenIndex1900, depth1900 := position, tokenIndex, depth
-					if !_rules[rulejsonMapAccessString]() {
-						goto l1901
-					}
-					goto l1900
-				l1901:
-					position, tokenIndex, depth = position1900, tokenIndex1900, depth1900
-					if !_rules[rulejsonMapAccessBracket]() {
-						goto l1898
-					}
-				}
-			l1900:
-				depth--
-				add(rulejsonMapMultipleLevel, position1899)
+				add(ruleAction25, position)
 			}
 			return true
-		l1898:
-			position, tokenIndex, depth = position1898, tokenIndex1898, depth1898
-			return false
 		},
-		/* 173 jsonMapAccessString <- <<(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9] / '_')*)>> */
+		/* 267 Action26 <- <{
+		    p.AssembleSetLogLevel()
+		}> */
 		func() bool {
-			position1902, tokenIndex1902, depth1902 := position, tokenIndex, depth
 			{
-				position1903 := position
-				depth++
-				{
-					position1904 := position
-					depth++
-					{
-						position1905, tokenIndex1905, depth1905 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l1906
-						}
-						position++
-						goto l1905
-					l1906:
-						position, tokenIndex, depth = position1905, tokenIndex1905, depth1905
-						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l1902
-						}
-						position++
-					}
-				l1905:
-				l1907:
-					{
-						position1908, tokenIndex1908, depth1908 := position, tokenIndex, depth
-						{
-							position1909, tokenIndex1909, depth1909 := position, tokenIndex, depth
-							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l1910
-							}
-							position++
-							goto l1909
-						l1910:
-							position, tokenIndex, depth = position1909, tokenIndex1909, depth1909
-							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l1911
-							}
-							position++
-							goto l1909
-						l1911:
-							position, tokenIndex, depth = position1909, tokenIndex1909, depth1909
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1912
-							}
-							position++
-							goto l1909
-						l1912:
-							position, tokenIndex, depth = position1909, tokenIndex1909, depth1909
-							if buffer[position] != rune('_') {
-								goto l1908
-							}
-							position++
-						}
-					l1909:
-						goto l1907
-					l1908:
-						position, tokenIndex, depth = position1908, tokenIndex1908, depth1908
-					}
-					depth--
-					add(rulePegText, position1904)
-				}
-				depth--
-				add(rulejsonMapAccessString, position1903)
+				add(ruleAction26, position)
 			}
 			return true
-		l1902:
-			position, tokenIndex, depth = position1902, tokenIndex1902, depth1902
-			return false
 		},
-		/* 174 jsonMapAccessBracket <- <('[' doubleQuotedString ']')> */
+		/* 268 Action27 <- <{
+		    p.AssembleSetTrace()
+		}> */
 		func() bool {
-			position1913, tokenIndex1913, depth1913 := position, tokenIndex, depth
 			{
-				position1914 := position
-				depth++
-				if buffer[position] != rune('[') {
-					goto l1913
-				}
-				position++
-				if !_rules[ruledoubleQuotedString]() {
-					goto l1913
-				}
-				if buffer[position] != rune(']') {
-					goto l1913
-				}
-				position++
-				depth--
-				add(rulejsonMapAccessBracket, position1914)
+				add(ruleAction27, position)
 			}
 			return true
-		l1913:
-			position, tokenIndex, depth = position1913, tokenIndex1913, depth1913
-			return false
 		},
-		/* 175 doubleQuotedString <- <('"' <(('"' '"') / (!'"' .))*> '"')> */
+		/* 269 Action28 <- <{
+		    p.AssembleSetRecoveryPolicy()
+		}> */
 		func() bool {
-			position1915, tokenIndex1915, depth1915 := position, tokenIndex, depth
 			{
-				position1916 := position
-				depth++
-				if buffer[position] != rune('"') {
-					goto l1915
-				}
-				position++
-				{
-					position1917 := position
-					depth++
-				l1918:
-					{
-						position1919, tokenIndex1919, depth1919 := position, tokenIndex, depth
-						{
-							position1920, tokenIndex1920, depth1920 := position, tokenIndex, depth
-							if buffer[position] != rune('"') {
-								goto l1921
-							}
-							position++
-							if buffer[position] != rune('"') {
-								goto l1921
-							}
-							position++
-							goto l1920
-						l1921:
-							position, tokenIndex, depth = position1920, tokenIndex1920, depth1920
-							{
-								position1922, tokenIndex1922, depth1922 := position, tokenIndex, depth
-								if buffer[position] != rune('"') {
-									goto l1922
-								}
-								position++
-								goto l1919
-							l1922:
-								position, tokenIndex, depth = position1922, tokenIndex1922, depth1922
-							}
-							if !matchDot() {
-								goto l1919
-							}
-						}
-					l1920:
-						goto l1918
-					l1919:
-						position, tokenIndex, depth = position1919, tokenIndex1919, depth1919
-					}
-					depth--
-					add(rulePegText, position1917)
-				}
-				if buffer[position] != rune('"') {
-					goto l1915
-				}
-				position++
-				depth--
-				add(ruledoubleQuotedString, position1916)
+				add(ruleAction28, position)
 			}
 			return true
-		l1915:
-			position, tokenIndex, depth = position1915, tokenIndex1915, depth1915
-			return false
 		},
-		/* 176 jsonArrayAccess <- <('[' <('-'? [0-9]+)> ']')> */
+		/* 270 Action29 <- <{
+		    p.AssembleEval(begin, end)
+		}> */
 		func() bool {
-			position1923, tokenIndex1923, depth1923 := position, tokenIndex, depth
 			{
-				position1924 := position
-				depth++
-				if buffer[position] != rune('[') {
-					goto l1923
-				}
-				position++
-				{
-					position1925 := position
-					depth++
-					{
-						position1926, tokenIndex1926, depth1926 := position, tokenIndex, depth
-						if buffer[position] != rune('-') {
-							goto l1926
-						}
-						position++
-						goto l1927
-					l1926:
-						position, tokenIndex, depth = position1926, tokenIndex1926, depth1926
-					}
-				l1927:
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1923
-					}
-					position++
-				l1928:
-					{
-						position1929, tokenIndex1929, depth1929 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1929
-						}
-						position++
-						goto l1928
-					l1929:
-						position, tokenIndex, depth = position1929, tokenIndex1929, depth1929
-					}
-					depth--
-					add(rulePegText, position1925)
-				}
-				if buffer[position] != rune(']') {
-					goto l1923
-				}
-				position++
-				depth--
-				add(rulejsonArrayAccess, position1924)
+				add(ruleAction29, position)
 			}
 			return true
-		l1923:
-			position, tokenIndex, depth = position1923, tokenIndex1923, depth1923
-			return false
 		},
-		/* 177 jsonNonNegativeArrayAccess <- <('[' <[0-9]+> ']')> */
-		func() bool {
-			position1930, tokenIndex1930, depth1930 := position, tokenIndex, depth
-			{
-				position1931 := position
-				depth++
-				if buffer[position] != rune('[') {
-					goto l1930
-				}
-				position++
-				{
-					position1932 := position
-					depth++
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1930
-					}
-					position++
-				l1933:
-					{
-						position1934, tokenIndex1934, depth1934 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1934
-						}
-						position++
-						goto l1933
-					l1934:
-						position, tokenIndex, depth = position1934, tokenIndex1934, depth1934
-					}
-					depth--
-					add(rulePegText, position1932)
-				}
-				if buffer[position] != rune(']') {
-					goto l1930
-				}
-				position++
-				depth--
-				add(rulejsonNonNegativeArrayAccess, position1931)
+		/* 271 Action30 <- <{
+		    p.AssembleShow(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction30, position)
 			}
 			return true
-		l1930:
-			position, tokenIndex, depth = position1930, tokenIndex1930, depth1930
-			return false
 		},
-		/* 178 jsonArraySlice <- <('[' <('-'? [0-9]+ ':' '-'? [0-9]+ (':' '-'? [0-9]+)?)> ']')> */
+		/* 272 Action31 <- <{
+		    p.AssembleEmitter()
+		}> */
 		func() bool {
-			position1935, tokenIndex1935, depth1935 := position, tokenIndex, depth
 			{
-				position1936 := position
-				depth++
-				if buffer[position] != rune('[') {
-					goto l1935
-				}
-				position++
-				{
-					position1937 := position
-					depth++
-					{
-						position1938, tokenIndex1938, depth1938 := position, tokenIndex, depth
-						if buffer[position] != rune('-') {
-							goto l1938
-						}
-						position++
-						goto l1939
-					l1938:
-						position, tokenIndex, depth = position1938, tokenIndex1938, depth1938
-					}
-				l1939:
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1935
-					}
-					position++
-				l1940:
-					{
-						position1941, tokenIndex1941, depth1941 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1941
-						}
-						position++
-						goto l1940
-					l1941:
-						position, tokenIndex, depth = position1941, tokenIndex1941, depth1941
-					}
-					if buffer[position] != rune(':') {
-						goto l1935
-					}
-					position++
-					{
-						position1942, tokenIndex1942, depth1942 := position, tokenIndex, depth
-						if buffer[position] != rune('-') {
-							goto l1942
-						}
-						position++
-						goto l1943
-					l1942:
-						position, tokenIndex, depth = position1942, tokenIndex1942, depth1942
-					}
-				l1943:
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l1935
-					}
-					position++
-				l1944:
-					{
-						position1945, tokenIndex1945, depth1945 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1945
-						}
-						position++
-						goto l1944
-					l1945:
-						position, tokenIndex, depth = position1945, tokenIndex1945, depth1945
-					}
-					{
-						position1946, tokenIndex1946, depth1946 := position, tokenIndex, depth
-						if buffer[position] != rune(':') {
-							goto l1946
-						}
-						position++
-						{
-							position1948, tokenIndex1948, depth1948 := position, tokenIndex, depth
-							if buffer[position] != rune('-') {
-								goto l1948
-							}
-							position++
-							goto l1949
-						l1948:
-							position, tokenIndex, depth = position1948, tokenIndex1948, depth1948
-						}
-					l1949:
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1946
-						}
-						position++
-					l1950:
-						{
-							position1951, tokenIndex1951, depth1951 := position, tokenIndex, depth
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1951
-							}
-							position++
-							goto l1950
-						l1951:
-							position, tokenIndex, depth = position1951, tokenIndex1951, depth1951
-						}
-						goto l1947
-					l1946:
-						position, tokenIndex, depth = position1946, tokenIndex1946, depth1946
-					}
-				l1947:
-					depth--
-					add(rulePegText, position1937)
-				}
-				if buffer[position] != rune(']') {
-					goto l1935
-				}
-				position++
-				depth--
-				add(rulejsonArraySlice, position1936)
+				add(ruleAction31, position)
 			}
 			return true
-		l1935:
-			position, tokenIndex, depth = position1935, tokenIndex1935, depth1935
-			return false
 		},
-		/* 179 jsonArrayPartialSlice <- <('[' <((':' '-'? [0-9]+) / ('-'? [0-9]+ ':'))> ']')> */
+		/* 273 Action32 <- <{
+		    p.AssembleEmitterOptions(begin, end)
+		}> */
 		func() bool {
-			position1952, tokenIndex1952, depth1952 := position, tokenIndex, depth
 			{
-				position1953 := position
-				depth++
-				if buffer[position] != rune('[') {
-					goto l1952
-				}
-				position++
-				{
-					position1954 := position
-					depth++
-					{
-						position1955, tokenIndex1955, depth1955 := position, tokenIndex, depth
-						if buffer[position] != rune(':') {
-							goto l1956
-						}
-						position++
-						{
-							position1957, tokenIndex1957, depth1957 := position, tokenIndex, depth
-							if buffer[position] != rune('-') {
-								goto l1957
-							}
-							position++
-							goto l1958
-						l1957:
-							position, tokenIndex, depth = position1957, tokenIndex1957, depth1957
-						}
-					l1958:
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1956
-						}
-						position++
-					l1959:
-						{
-							position1960, tokenIndex1960, depth1960 := position, tokenIndex, depth
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1960
-							}
-							position++
-							goto l1959
-						l1960:
-							position, tokenIndex, depth = position1960, tokenIndex1960, depth1960
-						}
-						goto l1955
-					l1956:
-						position, tokenIndex, depth = position1955, tokenIndex1955, depth1955
-						{
-							position1961, tokenIndex1961, depth1961 := position, tokenIndex, depth
-							if buffer[position] != rune('-') {
-								goto l1961
-							}
-							position++
-							goto l1962
-						l1961:
-							position, tokenIndex, depth = position1961, tokenIndex1961, depth1961
-						}
-					l1962:
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l1952
-						}
-						position++
-					l1963:
-						{
-							position1964, tokenIndex1964, depth1964 := position, tokenIndex, depth
-							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l1964
-							}
-							position++
-							goto l1963
-						l1964:
-							position, tokenIndex, depth = position1964, tokenIndex1964, depth1964
-						}
-						if buffer[position] != rune(':') {
-							goto l1952
-						}
-						position++
-					}
-				l1955:
-					depth--
-					add(rulePegText, position1954)
-				}
-				if buffer[position] != rune(']') {
-					goto l1952
-				}
-				position++
-				depth--
-				add(rulejsonArrayPartialSlice, position1953)
+				add(ruleAction32, position)
 			}
 			return true
-		l1952:
-			position, tokenIndex, depth = position1952, tokenIndex1952, depth1952
-			return false
 		},
-		/* 180 jsonArrayFullSlice <- <('[' ':' ']')> */
+		/* 274 Action33 <- <{
+		    p.AssembleEmitterLimit()
+		}> */
 		func() bool {
-			position1965, tokenIndex1965, depth1965 := position, tokenIndex, depth
 			{
-				position1966 := position
-				depth++
-				if buffer[position] != rune('[') {
-					goto l1965
-				}
-				position++
-				if buffer[position] != rune(':') {
-					goto l1965
-				}
-				position++
-				if buffer[position] != rune(']') {
-					goto l1965
-				}
-				position++
-				depth--
-				add(rulejsonArrayFullSlice, position1966)
+				add(ruleAction33, position)
 			}
 			return true
-		l1965:
-			position, tokenIndex, depth = position1965, tokenIndex1965, depth1965
-			return false
 		},
-		/* 181 spElem <- <(' ' / '\t' / '\n' / '\r' / comment / finalComment)> */
+		/* 275 Action34 <- <{
+		    p.AssembleEmitterSampling(CountBasedSampling, 1)
+		}> */
 		func() bool {
-			position1967, tokenIndex1967, depth1967 := position, tokenIndex, depth
 			{
-				position1968 := position
-				depth++
-				{
-					position1969, tokenIndex1969, depth1969 := position, tokenIndex, depth
-					if buffer[position] != rune(' ') {
-						goto l1970
-					}
-					position++
-					goto l1969
-				l1970:
-					position, tokenIndex, depth = position1969, tokenIndex1969, depth1969
-					if buffer[position] != rune('\t') {
-						goto l1971
-					}
-					position++
-					goto l1969
-				l1971:
-					position, tokenIndex, depth = position1969, tokenIndex1969, depth1969
-					if buffer[position] != rune('\n') {
-						goto l1972
-					}
-					position++
-					goto l1969
-				l1972:
-					position, tokenIndex, depth = position1969, tokenIndex1969, depth1969
-					if buffer[position] != rune('\r') {
-						goto l1973
-					}
-					position++
-					goto l1969
-				l1973:
-					position, tokenIndex, depth = position1969, tokenIndex1969, depth1969
-					if !_rules[rulecomment]() {
-						goto l1974
-					}
-					goto l1969
-				l1974:
-					position, tokenIndex, depth = position1969, tokenIndex1969, depth1969
-					if !_rules[rulefinalComment]() {
-						goto l1967
-					}
-				}
-			l1969:
-				depth--
-				add(rulespElem, position1968)
+				add(ruleAction34, position)
 			}
 			return true
-		l1967:
-			position, tokenIndex, depth = position1967, tokenIndex1967, depth1967
-			return false
 		},
-		/* 182 sp <- <spElem+> */
+		/* 276 Action35 <- <{
+		    p.AssembleEmitterSampling(RandomizedSampling, 1)
+		}> */
 		func() bool {
-			position1975, tokenIndex1975, depth1975 := position, tokenIndex, depth
 			{
-				position1976 := position
-				depth++
-				if !_rules[rulespElem]() {
-					goto l1975
-				}
-			l1977:
-				{
-					position1978, tokenIndex1978, depth1978 := position, tokenIndex, depth
-					if !_rules[rulespElem]() {
-						goto l1978
-					}
-					goto l1977
-				l1978:
-					position, tokenIndex, depth = position1978, tokenIndex1978, depth1978
-				}
-				depth--
-				add(rulesp, position1976)
+				add(ruleAction35, position)
 			}
 			return true
-		l1975:
-			position, tokenIndex, depth = position1975, tokenIndex1975, depth1975
-			return false
 		},
-		/* 183 spOpt <- <spElem*> */
+		/* 277 Action36 <- <{
+		    p.AssembleEmitterSampling(TimeBasedSampling, 1)
+		}> */
 		func() bool {
 			{
-				position1980 := position
-				depth++
-			l1981:
-				{
-					position1982, tokenIndex1982, depth1982 := position, tokenIndex, depth
-					if !_rules[rulespElem]() {
-						goto l1982
-					}
-					goto l1981
-				l1982:
-					position, tokenIndex, depth = position1982, tokenIndex1982, depth1982
-				}
-				depth--
-				add(rulespOpt, position1980)
+				add(ruleAction36, position)
 			}
 			return true
 		},
-		/* 184 comment <- <('-' '-' (!('\r' / '\n') .)* ('\r' / '\n'))> */
+		/* 278 Action37 <- <{
+		    p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
+		}> */
 		func() bool {
-			position1983, tokenIndex1983, depth1983 := position, tokenIndex, depth
 			{
-				position1984 := position
-				depth++
-				if buffer[position] != rune('-') {
-					goto l1983
-				}
-				position++
-				if buffer[position] != rune('-') {
-					goto l1983
-				}
-				position++
-			l1985:
-				{
-					position1986, tokenIndex1986, depth1986 := position, tokenIndex, depth
-					{
-						position1987, tokenIndex1987, depth1987 := position, tokenIndex, depth
-						{
-							position1988, tokenIndex1988, depth1988 := position, tokenIndex, depth
-							if buffer[position] != rune('\r') {
-								goto l1989
-							}
-							position++
-							goto l1988
-						l1989:
-							position, tokenIndex, depth = position1988, tokenIndex1988, depth1988
-							if buffer[position] != rune('\n') {
-								goto l1987
-							}
-							position++
-						}
-					l1988:
-						goto l1986
-					l1987:
-						position, tokenIndex, depth = position1987, tokenIndex1987, depth1987
-					}
-					if !matchDot() {
-						goto l1986
-					}
-					goto l1985
-				l1986:
-					position, tokenIndex, depth = position1986, tokenIndex1986, depth1986
-				}
-				{
-					position1990, tokenIndex1990, depth1990 := position, tokenIndex, depth
-					if buffer[position] != rune('\r') {
-						goto l1991
-					}
-					position++
-					goto l1990
-				l1991:
-					position, tokenIndex, depth = position1990, tokenIndex1990, depth1990
-					if buffer[position] != rune('\n') {
-						goto l1983
-					}
-					position++
-				}
-			l1990:
-				depth--
-				add(rulecomment, position1984)
+				add(ruleAction37, position)
+			}
+			return true
+		},
+		/* 279 Action38 <- <{
+		    p.AssembleEmitterChange(begin, end)
+		}> */
+		func() bool {
+			{
+				add(ruleAction38, position)
+			}
+			return true
+		},
+		/* 280 Action39 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, Identifier(substr))
+		}> */
+		func() bool {
+			{
+				add(ruleAction39, position)
 			}
 			return true
-		l1983:
-			position, tokenIndex, depth = position1983, tokenIndex1983, depth1983
-			return false
 		},
-		/* 185 finalComment <- <('-' '-' (!('\r' / '\n') .)* !.)> */
+		/* 281 Action40 <- <{
+		    p.AssembleProjections(begin, end)
+		}> */
 		func() bool {
-			position1992, tokenIndex1992, depth1992 := position, tokenIndex, depth
 			{
-				position1993 := position
-				depth++
-				if buffer[position] != rune('-') {
-					goto l1992
-				}
-				position++
-				if buffer[position] != rune('-') {
-					goto l1992
-				}
-				position++
-			l1994:
-				{
-					position1995, tokenIndex1995, depth1995 := position, tokenIndex, depth
-					{
-						position1996, tokenIndex1996, depth1996 := position, tokenIndex, depth
-						{
-							position1997, tokenIndex1997, depth1997 := position, tokenIndex, depth
-							if buffer[position] != rune('\r') {
-								goto l1998
-							}
-							position++
-							goto l1997
-						l1998:
-							position, tokenIndex, depth = position1997, tokenIndex1997, depth1997
-							if buffer[position] != rune('\n') {
-								goto l1996
-							}
-							position++
-						}
-					l1997:
-						goto l1995
-					l1996:
-						position, tokenIndex, depth = position1996, tokenIndex1996, depth1996
-					}
-					if !matchDot() {
-						goto l1995
-					}
-					goto l1994
-				l1995:
-					position, tokenIndex, depth = position1995, tokenIndex1995, depth1995
-				}
-				{
-					position1999, tokenIndex1999, depth1999 := position, tokenIndex, depth
-					if !matchDot() {
-						goto l1999
-					}
-					goto l1992
-				l1999:
-					position, tokenIndex, depth = position1999, tokenIndex1999, depth1999
-				}
-				depth--
-				add(rulefinalComment, position1993)
+				add(ruleAction40, position)
 			}
 			return true
-		l1992:
-			position, tokenIndex, depth = position1992, tokenIndex1992, depth1992
-			return false
 		},
-		nil,
-		/* 188 Action0 <- <{
-		    p.IncludeTrailingWhitespace(begin, end)
+		/* 282 Action41 <- <{
+		    p.AssembleAlias()
 		}> */
 		func() bool {
 			{
-				add(ruleAction0, position)
+				add(ruleAction41, position)
 			}
 			return true
 		},
-		/* 189 Action1 <- <{
-		    p.IncludeTrailingWhitespace(begin, end)
+		/* 283 Action42 <- <{
+		    // This is *always* executed, even if there is no
+		    // FROM clause present in the statement.
+		    p.AssembleWindowedFrom(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction1, position)
+				add(ruleAction42, position)
 			}
 			return true
 		},
-		/* 190 Action2 <- <{
-		    p.AssembleSelect()
+		/* 284 Action43 <- <{
+		    p.AssembleInterval()
 		}> */
 		func() bool {
 			{
-				add(ruleAction2, position)
+				add(ruleAction43, position)
 			}
 			return true
 		},
-		/* 191 Action3 <- <{
-		    p.AssembleSelectUnion(begin, end)
+		/* 285 Action44 <- <{
+		    p.AssembleInterval()
 		}> */
 		func() bool {
 			{
-				add(ruleAction3, position)
+				add(ruleAction44, position)
 			}
 			return true
 		},
-		/* 192 Action4 <- <{
-		    p.AssembleCreateStreamAsSelect()
+		/* 286 Action45 <- <{
+		    // This is *always* executed, even if there is no
+		    // WHERE clause present in the statement.
+		    p.AssembleFilter(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction4, position)
+				add(ruleAction45, position)
 			}
 			return true
 		},
-		/* 193 Action5 <- <{
-		    p.AssembleCreateStreamAsSelectUnion()
+		/* 287 Action46 <- <{
+		    // This is *always* executed, even if there is no
+		    // GROUP BY clause present in the statement.
+		    p.AssembleGrouping(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction5, position)
+				add(ruleAction46, position)
 			}
 			return true
 		},
-		/* 194 Action6 <- <{
-		    p.AssembleCreateSource()
+		/* 288 Action47 <- <{
+		    // This is *always* executed, even if there is no
+		    // HAVING clause present in the statement.
+		    p.AssembleHaving(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction6, position)
+				add(ruleAction47, position)
 			}
 			return true
 		},
-		/* 195 Action7 <- <{
-		    p.AssembleCreateSink()
+		/* 289 Action48 <- <{
+		    // This is *always* executed, even if there is no
+		    // SAMPLE clause present in the statement.
+		    p.AssembleInputSampling(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction7, position)
+				add(ruleAction48, position)
 			}
 			return true
 		},
-		/* 196 Action8 <- <{
-		    p.AssembleCreateState()
+		/* 290 Action49 <- <{
+		    p.EnsureAliasedStreamWindow()
 		}> */
 		func() bool {
 			{
-				add(ruleAction8, position)
+				add(ruleAction49, position)
 			}
 			return true
 		},
-		/* 197 Action9 <- <{
-		    p.AssembleUpdateState()
+		/* 291 Action50 <- <{
+		    p.AssembleAliasedStreamWindow()
 		}> */
 		func() bool {
 			{
-				add(ruleAction9, position)
+				add(ruleAction50, position)
 			}
 			return true
 		},
-		/* 198 Action10 <- <{
-		    p.AssembleUpdateSource()
+		/* 292 Action51 <- <{
+		    p.AssembleStreamWindow()
 		}> */
 		func() bool {
 			{
-				add(ruleAction10, position)
+				add(ruleAction51, position)
 			}
 			return true
 		},
-		/* 199 Action11 <- <{
-		    p.AssembleUpdateSink()
+		/* 293 Action52 <- <{
+		    p.AssembleUDSFFuncApp()
 		}> */
 		func() bool {
 			{
-				add(ruleAction11, position)
+				add(ruleAction52, position)
 			}
 			return true
 		},
-		/* 200 Action12 <- <{
-		    p.AssembleInsertIntoFrom()
+		/* 294 Action53 <- <{
+		    p.EnsureCapacitySpec(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction12, position)
+				add(ruleAction53, position)
 			}
 			return true
 		},
-		/* 201 Action13 <- <{
-		    p.AssemblePauseSource()
+		/* 295 Action54 <- <{
+		    p.EnsureSheddingSpec(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction13, position)
+				add(ruleAction54, position)
 			}
 			return true
 		},
-		/* 202 Action14 <- <{
-		    p.AssembleResumeSource()
+		/* 296 Action55 <- <{
+		    p.EnsurePipeSpec(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction14, position)
+				add(ruleAction55, position)
 			}
 			return true
 		},
-		/* 203 Action15 <- <{
-		    p.AssembleRewindSource()
+		/* 297 Action56 <- <{
+		    p.EnsurePartitionSpec(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction15, position)
+				add(ruleAction56, position)
 			}
 			return true
 		},
-		/* 204 Action16 <- <{
-		    p.AssembleDropSource()
+		/* 298 Action57 <- <{
+		    p.AssemblePartitionSpec()
 		}> */
 		func() bool {
 			{
-				add(ruleAction16, position)
+				add(ruleAction57, position)
 			}
 			return true
 		},
-		/* 205 Action17 <- <{
-		    p.AssembleDropStream()
+		/* 299 Action58 <- <{
+		    p.AssembleSourceSinkSpecs(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction17, position)
+				add(ruleAction58, position)
 			}
 			return true
 		},
-		/* 206 Action18 <- <{
-		    p.AssembleDropSink()
+		/* 300 Action59 <- <{
+		    p.AssembleSourceSinkSpecs(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction18, position)
+				add(ruleAction59, position)
 			}
 			return true
 		},
-		/* 207 Action19 <- <{
-		    p.AssembleDropState()
+		/* 301 Action60 <- <{
+		    p.AssembleSourceSinkSpecs(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction19, position)
+				add(ruleAction60, position)
 			}
 			return true
 		},
-		/* 208 Action20 <- <{
-		    p.AssembleLoadState()
+		/* 302 Action61 <- <{
+		    p.EnsureIdentifier(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction20, position)
+				add(ruleAction61, position)
 			}
 			return true
 		},
-		/* 209 Action21 <- <{
-		    p.AssembleLoadStateOrCreate()
+		/* 303 Action62 <- <{
+		    p.AssembleSourceSinkParam()
 		}> */
 		func() bool {
 			{
-				add(ruleAction21, position)
+				add(ruleAction62, position)
 			}
 			return true
 		},
-		/* 210 Action22 <- <{
-		    p.AssembleSaveState()
+		/* 304 Action63 <- <{
+		    p.AssembleExpressions(begin, end)
+		    p.AssembleArray()
 		}> */
 		func() bool {
 			{
-				add(ruleAction22, position)
+				add(ruleAction63, position)
 			}
 			return true
 		},
-		/* 211 Action23 <- <{
-		    p.AssembleEval(begin, end)
+		/* 305 Action64 <- <{
+		    p.AssembleMap(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction23, position)
+				add(ruleAction64, position)
 			}
 			return true
 		},
-		/* 212 Action24 <- <{
-		    p.AssembleEmitter()
+		/* 306 Action65 <- <{
+		    p.AssembleKeyValuePair()
 		}> */
 		func() bool {
 			{
-				add(ruleAction24, position)
+				add(ruleAction65, position)
 			}
 			return true
 		},
-		/* 213 Action25 <- <{
-		    p.AssembleEmitterOptions(begin, end)
+		/* 307 Action66 <- <{
+		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction25, position)
+				add(ruleAction66, position)
 			}
 			return true
 		},
-		/* 214 Action26 <- <{
-		    p.AssembleEmitterLimit()
+		/* 308 Action67 <- <{
+		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction26, position)
+				add(ruleAction67, position)
 			}
 			return true
 		},
-		/* 215 Action27 <- <{
-		    p.AssembleEmitterSampling(CountBasedSampling, 1)
+		/* 309 Action68 <- <{
+		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction27, position)
+				add(ruleAction68, position)
 			}
 			return true
 		},
-		/* 216 Action28 <- <{
-		    p.AssembleEmitterSampling(RandomizedSampling, 1)
+		/* 310 Action69 <- <{
+		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction28, position)
+				add(ruleAction69, position)
 			}
 			return true
 		},
-		/* 217 Action29 <- <{
-		    p.AssembleEmitterSampling(TimeBasedSampling, 1)
+		/* 311 Action70 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction29, position)
+				add(ruleAction70, position)
 			}
 			return true
 		},
-		/* 218 Action30 <- <{
-		    p.AssembleEmitterSampling(TimeBasedSampling, 0.001)
+		/* 312 Action71 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction30, position)
+				add(ruleAction71, position)
 			}
 			return true
 		},
-		/* 219 Action31 <- <{
-		    p.AssembleProjections(begin, end)
+		/* 313 Action72 <- <{
+		    p.AssembleUnaryPrefixOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction31, position)
+				add(ruleAction72, position)
 			}
 			return true
 		},
-		/* 220 Action32 <- <{
-		    p.AssembleAlias()
+		/* 314 Action73 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction32, position)
+				add(ruleAction73, position)
 			}
 			return true
 		},
-		/* 221 Action33 <- <{
-		    // This is *always* executed, even if there is no
-		    // FROM clause present in the statement.
-		    p.AssembleWindowedFrom(begin, end)
+		/* 315 Action74 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction33, position)
+				add(ruleAction74, position)
 			}
 			return true
 		},
-		/* 222 Action34 <- <{
-		    p.AssembleInterval()
+		/* 316 Action75 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction34, position)
+				add(ruleAction75, position)
 			}
 			return true
 		},
-		/* 223 Action35 <- <{
-		    p.AssembleInterval()
+		/* 317 Action76 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction35, position)
+				add(ruleAction76, position)
 			}
 			return true
 		},
-		/* 224 Action36 <- <{
-		    // This is *always* executed, even if there is no
-		    // WHERE clause present in the statement.
-		    p.AssembleFilter(begin, end)
+		/* 318 Action77 <- <{
+		    p.AssembleBinaryOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction36, position)
+				add(ruleAction77, position)
 			}
 			return true
 		},
-		/* 225 Action37 <- <{
-		    // This is *always* executed, even if there is no
-		    // GROUP BY clause present in the statement.
-		    p.AssembleGrouping(begin, end)
+		/* 319 Action78 <- <{
+		    p.AssembleUnaryPrefixOperation(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction37, position)
+				add(ruleAction78, position)
 			}
 			return true
 		},
-		/* 226 Action38 <- <{
-		    // This is *always* executed, even if there is no
-		    // HAVING clause present in the statement.
-		    p.AssembleHaving(begin, end)
+		/* 320 Action79 <- <{
+		    p.AssembleTypeCast(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction38, position)
+				add(ruleAction79, position)
 			}
 			return true
 		},
-		/* 227 Action39 <- <{
-		    p.EnsureAliasedStreamWindow()
+		/* 321 Action80 <- <{
+		    p.AssembleTypeCast(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction39, position)
+				add(ruleAction80, position)
 			}
 			return true
 		},
-		/* 228 Action40 <- <{
-		    p.AssembleAliasedStreamWindow()
+		/* 322 Action81 <- <{
+		    p.AssembleWindowFuncApp(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction40, position)
+				add(ruleAction81, position)
 			}
 			return true
 		},
-		/* 229 Action41 <- <{
-		    p.AssembleStreamWindow()
+		/* 323 Action82 <- <{
+		    p.AssembleExpressions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction41, position)
+				add(ruleAction82, position)
 			}
 			return true
 		},
-		/* 230 Action42 <- <{
-		    p.AssembleUDSFFuncApp()
+		/* 324 Action83 <- <{
+		    p.AssembleExpressions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction42, position)
+				add(ruleAction83, position)
 			}
 			return true
 		},
-		/* 231 Action43 <- <{
-		    p.EnsureCapacitySpec(begin, end)
+		/* 325 Action84 <- <{
+		    p.AssembleFuncApp()
 		}> */
 		func() bool {
 			{
-				add(ruleAction43, position)
+				add(ruleAction84, position)
 			}
 			return true
 		},
-		/* 232 Action44 <- <{
-		    p.EnsureSheddingSpec(begin, end)
+		/* 326 Action85 <- <{
+		    p.AssembleExpressions(begin, end)
+		    p.AssembleFuncApp()
 		}> */
 		func() bool {
 			{
-				add(ruleAction44, position)
+				add(ruleAction85, position)
 			}
 			return true
 		},
-		/* 233 Action45 <- <{
-		    p.AssembleSourceSinkSpecs(begin, end)
+		/* 327 Action86 <- <{
+		    p.AssembleExpressions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction45, position)
+				add(ruleAction86, position)
 			}
 			return true
 		},
-		/* 234 Action46 <- <{
-		    p.AssembleSourceSinkSpecs(begin, end)
+		/* 328 Action87 <- <{
+		    p.AssembleExpressions(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction46, position)
+				add(ruleAction87, position)
 			}
 			return true
 		},
-		/* 235 Action47 <- <{
-		    p.AssembleSourceSinkSpecs(begin, end)
+		/* 329 Action88 <- <{
+		    p.AssembleSortedExpression()
 		}> */
 		func() bool {
 			{
-				add(ruleAction47, position)
+				add(ruleAction88, position)
 			}
 			return true
 		},
-		/* 236 Action48 <- <{
-		    p.EnsureIdentifier(begin, end)
+		/* 330 Action89 <- <{
+		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction48, position)
+				add(ruleAction89, position)
 			}
 			return true
 		},
-		/* 237 Action49 <- <{
-		    p.AssembleSourceSinkParam()
+		/* 331 Action90 <- <{
+		    p.EnsureKeywordPresent(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction49, position)
+				add(ruleAction90, position)
 			}
 			return true
 		},
-		/* 238 Action50 <- <{
+		/* 332 Action91 <- <{
 		    p.AssembleExpressions(begin, end)
 		    p.AssembleArray()
 		}> */
 		func() bool {
 			{
-				add(ruleAction50, position)
+				add(ruleAction91, position)
 			}
 			return true
 		},
-		/* 239 Action51 <- <{
+		/* 333 Action92 <- <{
 		    p.AssembleMap(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction51, position)
+				add(ruleAction92, position)
 			}
 			return true
 		},
-		/* 240 Action52 <- <{
+		/* 334 Action93 <- <{
 		    p.AssembleKeyValuePair()
 		}> */
 		func() bool {
 			{
-				add(ruleAction52, position)
+				add(ruleAction93, position)
 			}
 			return true
 		},
-		/* 241 Action53 <- <{
-		    p.EnsureKeywordPresent(begin, end)
+		/* 335 Action94 <- <{
+		    p.AssembleConditionCase(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction53, position)
+				add(ruleAction94, position)
 			}
 			return true
 		},
-		/* 242 Action54 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 336 Action95 <- <{
+		    p.AssembleExpressionCase(begin, end)
 		}> */
 		func() bool {
 			{
-				add(ruleAction54, position)
+				add(ruleAction95, position)
 			}
 			return true
 		},
-		/* 243 Action55 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 337 Action96 <- <{
+		    p.AssembleWhenThenPair()
 		}> */
 		func() bool {
 			{
-				add(ruleAction55, position)
+				add(ruleAction96, position)
 			}
 			return true
 		},
-		/* 244 Action56 <- <{
-		    p.AssembleUnaryPrefixOperation(begin, end)
+		/* 338 Action97 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewStream(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction56, position)
+				add(ruleAction97, position)
 			}
 			return true
 		},
-		/* 245 Action57 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 339 Action98 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
 		}> */
 		func() bool {
 			{
-				add(ruleAction57, position)
+				add(ruleAction98, position)
 			}
 			return true
 		},
-		/* 246 Action58 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 340 Action99 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowMeta(substr, ProcTimestampMeta))
 		}> */
 		func() bool {
 			{
-				add(ruleAction58, position)
+				add(ruleAction99, position)
 			}
 			return true
 		},
-		/* 247 Action59 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 341 Action100 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowMeta(substr, InputNameMeta))
 		}> */
 		func() bool {
 			{
-				add(ruleAction59, position)
+				add(ruleAction100, position)
 			}
 			return true
 		},
-		/* 248 Action60 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 342 Action101 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowMeta(substr, TraceMeta))
 		}> */
 		func() bool {
 			{
-				add(ruleAction60, position)
+				add(ruleAction101, position)
 			}
 			return true
 		},
-		/* 249 Action61 <- <{
-		    p.AssembleBinaryOperation(begin, end)
+		/* 343 Action102 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.AssembleRowMetadata(begin, end, substr)
 		}> */
 		func() bool {
 			{
-				add(ruleAction61, position)
+				add(ruleAction102, position)
 			}
 			return true
 		},
-		/* 250 Action62 <- <{
-		    p.AssembleUnaryPrefixOperation(begin, end)
+		/* 344 Action103 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewRowValue(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction62, position)
+				add(ruleAction103, position)
 			}
 			return true
 		},
-		/* 251 Action63 <- <{
-		    p.AssembleTypeCast(begin, end)
+		/* 345 Action104 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewNumericLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction63, position)
+				add(ruleAction104, position)
 			}
 			return true
 		},
-		/* 252 Action64 <- <{
-		    p.AssembleTypeCast(begin, end)
+		/* 346 Action105 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewNumericLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction64, position)
+				add(ruleAction105, position)
 			}
 			return true
 		},
-		/* 253 Action65 <- <{
-		    p.AssembleFuncApp()
+		/* 347 Action106 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewFloatLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction65, position)
+				add(ruleAction106, position)
 			}
 			return true
 		},
-		/* 254 Action66 <- <{
-		    p.AssembleExpressions(begin, end)
-		    p.AssembleFuncApp()
+		/* 348 Action107 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, FuncName(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction66, position)
+				add(ruleAction107, position)
 			}
 			return true
 		},
-		/* 255 Action67 <- <{
-		    p.AssembleExpressions(begin, end)
+		/* 349 Action108 <- <{
+		    p.PushComponent(begin, end, NewNullLiteral())
 		}> */
 		func() bool {
 			{
-				add(ruleAction67, position)
+				add(ruleAction108, position)
 			}
 			return true
 		},
-		/* 256 Action68 <- <{
-		    p.AssembleExpressions(begin, end)
+		/* 350 Action109 <- <{
+		    p.PushComponent(begin, end, NewMissing())
 		}> */
 		func() bool {
 			{
-				add(ruleAction68, position)
+				add(ruleAction109, position)
 			}
 			return true
 		},
-		/* 257 Action69 <- <{
-		    p.AssembleSortedExpression()
+		/* 351 Action110 <- <{
+		    p.PushComponent(begin, end, NewBoolLiteral(true))
 		}> */
 		func() bool {
 			{
-				add(ruleAction69, position)
+				add(ruleAction110, position)
 			}
 			return true
 		},
-		/* 258 Action70 <- <{
-		    p.EnsureKeywordPresent(begin, end)
+		/* 352 Action111 <- <{
+		    p.PushComponent(begin, end, NewBoolLiteral(false))
 		}> */
 		func() bool {
 			{
-				add(ruleAction70, position)
+				add(ruleAction111, position)
 			}
 			return true
 		},
-		/* 259 Action71 <- <{
-		    p.AssembleExpressions(begin, end)
-		    p.AssembleArray()
+		/* 353 Action112 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewWildcard(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction71, position)
+				add(ruleAction112, position)
 			}
 			return true
 		},
-		/* 260 Action72 <- <{
-		    p.AssembleMap(begin, end)
+		/* 354 Action113 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, NewStringLiteral(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction72, position)
+				add(ruleAction113, position)
 			}
 			return true
 		},
-		/* 261 Action73 <- <{
-		    p.AssembleKeyValuePair()
+		/* 355 Action114 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.AssemblePlaceholder(begin, end, substr)
 		}> */
 		func() bool {
 			{
-				add(ruleAction73, position)
+				add(ruleAction114, position)
 			}
 			return true
 		},
-		/* 262 Action74 <- <{
-		    p.AssembleConditionCase(begin, end)
+		/* 356 Action115 <- <{
+		    p.PushComponent(begin, end, Istream)
 		}> */
 		func() bool {
 			{
-				add(ruleAction74, position)
+				add(ruleAction115, position)
 			}
 			return true
 		},
-		/* 263 Action75 <- <{
-		    p.AssembleExpressionCase(begin, end)
+		/* 357 Action116 <- <{
+		    p.PushComponent(begin, end, Dstream)
 		}> */
 		func() bool {
 			{
-				add(ruleAction75, position)
+				add(ruleAction116, position)
 			}
 			return true
 		},
-		/* 264 Action76 <- <{
-		    p.AssembleWhenThenPair()
+		/* 358 Action117 <- <{
+		    p.PushComponent(begin, end, Rstream)
 		}> */
 		func() bool {
 			{
-				add(ruleAction76, position)
+				add(ruleAction117, position)
 			}
 			return true
 		},
-		/* 265 Action77 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewStream(substr))
+		/* 359 Action118 <- <{
+		    p.PushComponent(begin, end, Tuples)
 		}> */
 		func() bool {
 			{
-				add(ruleAction77, position)
+				add(ruleAction118, position)
 			}
 			return true
 		},
-		/* 266 Action78 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewRowMeta(substr, TimestampMeta))
+		/* 360 Action119 <- <{
+		    p.PushComponent(begin, end, Seconds)
 		}> */
 		func() bool {
 			{
-				add(ruleAction78, position)
+				add(ruleAction119, position)
 			}
 			return true
 		},
-		/* 267 Action79 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewRowValue(substr))
+		/* 361 Action120 <- <{
+		    p.PushComponent(begin, end, Milliseconds)
 		}> */
 		func() bool {
 			{
-				add(ruleAction79, position)
+				add(ruleAction120, position)
 			}
 			return true
 		},
-		/* 268 Action80 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewNumericLiteral(substr))
+		/* 362 Action121 <- <{
+		    p.PushComponent(begin, end, Wait)
 		}> */
 		func() bool {
 			{
-				add(ruleAction80, position)
+				add(ruleAction121, position)
 			}
 			return true
 		},
-		/* 269 Action81 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewNumericLiteral(substr))
+		/* 363 Action122 <- <{
+		    p.PushComponent(begin, end, DropOldest)
 		}> */
 		func() bool {
 			{
-				add(ruleAction81, position)
+				add(ruleAction122, position)
 			}
 			return true
 		},
-		/* 270 Action82 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewFloatLiteral(substr))
+		/* 364 Action123 <- <{
+		    p.PushComponent(begin, end, DropNewest)
 		}> */
 		func() bool {
 			{
-				add(ruleAction82, position)
+				add(ruleAction123, position)
 			}
 			return true
 		},
-		/* 271 Action83 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, FuncName(substr))
+		/* 365 Action124 <- <{
+		    p.PushComponent(begin, end, ChannelPipe)
 		}> */
 		func() bool {
 			{
-				add(ruleAction83, position)
+				add(ruleAction124, position)
 			}
 			return true
 		},
-		/* 272 Action84 <- <{
-		    p.PushComponent(begin, end, NewNullLiteral())
+		/* 366 Action125 <- <{
+		    p.PushComponent(begin, end, RingPipe)
 		}> */
 		func() bool {
 			{
-				add(ruleAction84, position)
+				add(ruleAction125, position)
 			}
 			return true
 		},
-		/* 273 Action85 <- <{
-		    p.PushComponent(begin, end, NewMissing())
+		/* 367 Action126 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, StreamIdentifier(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction85, position)
+				add(ruleAction126, position)
 			}
 			return true
 		},
-		/* 274 Action86 <- <{
-		    p.PushComponent(begin, end, NewBoolLiteral(true))
+		/* 368 Action127 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, SourceSinkType(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction86, position)
+				add(ruleAction127, position)
 			}
 			return true
 		},
-		/* 275 Action87 <- <{
-		    p.PushComponent(begin, end, NewBoolLiteral(false))
+		/* 369 Action128 <- <{
+		    substr := string([]rune(buffer)[begin:end])
+		    p.PushComponent(begin, end, SourceSinkParamKey(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction87, position)
+				add(ruleAction128, position)
 			}
 			return true
 		},
-		/* 276 Action88 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewWildcard(substr))
+		/* 370 Action129 <- <{
+		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction88, position)
+				add(ruleAction129, position)
 			}
 			return true
 		},
-		/* 277 Action89 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, NewStringLiteral(substr))
+		/* 371 Action130 <- <{
+		    p.PushComponent(begin, end, No)
 		}> */
 		func() bool {
 			{
-				add(ruleAction89, position)
+				add(ruleAction130, position)
 			}
 			return true
 		},
-		/* 278 Action90 <- <{
-		    p.PushComponent(begin, end, Istream)
+		/* 372 Action131 <- <{
+		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction90, position)
+				add(ruleAction131, position)
 			}
 			return true
 		},
-		/* 279 Action91 <- <{
-		    p.PushComponent(begin, end, Dstream)
+		/* 373 Action132 <- <{
+		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction91, position)
+				add(ruleAction132, position)
 			}
 			return true
 		},
-		/* 280 Action92 <- <{
-		    p.PushComponent(begin, end, Rstream)
+		/* 374 Action133 <- <{
+		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction92, position)
+				add(ruleAction133, position)
 			}
 			return true
 		},
-		/* 281 Action93 <- <{
-		    p.PushComponent(begin, end, Tuples)
+		/* 375 Action134 <- <{
+		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction93, position)
+				add(ruleAction134, position)
 			}
 			return true
 		},
-		/* 282 Action94 <- <{
-		    p.PushComponent(begin, end, Seconds)
+		/* 376 Action135 <- <{
+		    p.PushComponent(begin, end, No)
 		}> */
 		func() bool {
 			{
-				add(ruleAction94, position)
+				add(ruleAction135, position)
 			}
 			return true
 		},
-		/* 283 Action95 <- <{
-		    p.PushComponent(begin, end, Milliseconds)
+		/* 377 Action136 <- <{
+		    p.PushComponent(begin, end, NodeTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction95, position)
+				add(ruleAction136, position)
 			}
 			return true
 		},
-		/* 284 Action96 <- <{
-		    p.PushComponent(begin, end, Wait)
+		/* 378 Action137 <- <{
+		    p.PushComponent(begin, end, TopologyTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction96, position)
+				add(ruleAction137, position)
 			}
 			return true
 		},
-		/* 285 Action97 <- <{
-		    p.PushComponent(begin, end, DropOldest)
+		/* 379 Action138 <- <{
+		    p.PushComponent(begin, end, SourcesTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction97, position)
+				add(ruleAction138, position)
 			}
 			return true
 		},
-		/* 286 Action98 <- <{
-		    p.PushComponent(begin, end, DropNewest)
+		/* 380 Action139 <- <{
+		    p.PushComponent(begin, end, StreamsTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction98, position)
+				add(ruleAction139, position)
 			}
 			return true
 		},
-		/* 287 Action99 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, StreamIdentifier(substr))
+		/* 381 Action140 <- <{
+		    p.PushComponent(begin, end, SinksTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction99, position)
+				add(ruleAction140, position)
 			}
 			return true
 		},
-		/* 288 Action100 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, SourceSinkType(substr))
+		/* 382 Action141 <- <{
+		    p.PushComponent(begin, end, StatesTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction100, position)
+				add(ruleAction141, position)
 			}
 			return true
 		},
-		/* 289 Action101 <- <{
-		    substr := string([]rune(buffer)[begin:end])
-		    p.PushComponent(begin, end, SourceSinkParamKey(substr))
+		/* 383 Action142 <- <{
+		    p.PushComponent(begin, end, UDFsTarget)
 		}> */
 		func() bool {
 			{
-				add(ruleAction101, position)
+				add(ruleAction142, position)
 			}
 			return true
 		},
-		/* 290 Action102 <- <{
+		/* 384 Action143 <- <{
 		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction102, position)
+				add(ruleAction143, position)
 			}
 			return true
 		},
-		/* 291 Action103 <- <{
+		/* 385 Action144 <- <{
 		    p.PushComponent(begin, end, No)
 		}> */
 		func() bool {
 			{
-				add(ruleAction103, position)
+				add(ruleAction144, position)
 			}
 			return true
 		},
-		/* 292 Action104 <- <{
+		/* 386 Action145 <- <{
 		    p.PushComponent(begin, end, Yes)
 		}> */
 		func() bool {
 			{
-				add(ruleAction104, position)
+				add(ruleAction145, position)
 			}
 			return true
 		},
-		/* 293 Action105 <- <{
+		/* 387 Action146 <- <{
 		    p.PushComponent(begin, end, No)
 		}> */
 		func() bool {
 			{
-				add(ruleAction105, position)
+				add(ruleAction146, position)
 			}
 			return true
 		},
-		/* 294 Action106 <- <{
+		/* 388 Action147 <- <{
 		    p.PushComponent(begin, end, Bool)
 		}> */
 		func() bool {
 			{
-				add(ruleAction106, position)
+				add(ruleAction147, position)
 			}
 			return true
 		},
-		/* 295 Action107 <- <{
+		/* 389 Action148 <- <{
 		    p.PushComponent(begin, end, Int)
 		}> */
 		func() bool {
 			{
-				add(ruleAction107, position)
+				add(ruleAction148, position)
 			}
 			return true
 		},
-		/* 296 Action108 <- <{
+		/* 390 Action149 <- <{
 		    p.PushComponent(begin, end, Float)
 		}> */
 		func() bool {
 			{
-				add(ruleAction108, position)
+				add(ruleAction149, position)
 			}
 			return true
 		},
-		/* 297 Action109 <- <{
+		/* 391 Action150 <- <{
 		    p.PushComponent(begin, end, String)
 		}> */
 		func() bool {
 			{
-				add(ruleAction109, position)
+				add(ruleAction150, position)
 			}
 			return true
 		},
-		/* 298 Action110 <- <{
+		/* 392 Action151 <- <{
 		    p.PushComponent(begin, end, Blob)
 		}> */
 		func() bool {
 			{
-				add(ruleAction110, position)
+				add(ruleAction151, position)
 			}
 			return true
 		},
-		/* 299 Action111 <- <{
+		/* 393 Action152 <- <{
 		    p.PushComponent(begin, end, Timestamp)
 		}> */
 		func() bool {
 			{
-				add(ruleAction111, position)
+				add(ruleAction152, position)
 			}
 			return true
 		},
-		/* 300 Action112 <- <{
+		/* 394 Action153 <- <{
 		    p.PushComponent(begin, end, Array)
 		}> */
 		func() bool {
 			{
-				add(ruleAction112, position)
+				add(ruleAction153, position)
 			}
 			return true
 		},
-		/* 301 Action113 <- <{
+		/* 395 Action154 <- <{
 		    p.PushComponent(begin, end, Map)
 		}> */
 		func() bool {
 			{
-				add(ruleAction113, position)
+				add(ruleAction154, position)
+			}
+			return true
+		},
+		/* 396 Action155 <- <{
+		    p.PushComponent(begin, end, Vector)
+		}> */
+		func() bool {
+			{
+				add(ruleAction155, position)
 			}
 			return true
 		},
-		/* 302 Action114 <- <{
+		/* 397 Action156 <- <{
 		    p.PushComponent(begin, end, Or)
 		}> */
 		func() bool {
 			{
-				add(ruleAction114, position)
+				add(ruleAction156, position)
 			}
 			return true
 		},
-		/* 303 Action115 <- <{
+		/* 398 Action157 <- <{
 		    p.PushComponent(begin, end, And)
 		}> */
 		func() bool {
 			{
-				add(ruleAction115, position)
+				add(ruleAction157, position)
 			}
 			return true
 		},
-		/* 304 Action116 <- <{
+		/* 399 Action158 <- <{
 		    p.PushComponent(begin, end, Not)
 		}> */
 		func() bool {
 			{
-				add(ruleAction116, position)
+				add(ruleAction158, position)
 			}
 			return true
 		},
-		/* 305 Action117 <- <{
+		/* 400 Action159 <- <{
 		    p.PushComponent(begin, end, Equal)
 		}> */
 		func() bool {
 			{
-				add(ruleAction117, position)
+				add(ruleAction159, position)
 			}
 			return true
 		},
-		/* 306 Action118 <- <{
+		/* 401 Action160 <- <{
 		    p.PushComponent(begin, end, Less)
 		}> */
 		func() bool {
 			{
-				add(ruleAction118, position)
+				add(ruleAction160, position)
 			}
 			return true
 		},
-		/* 307 Action119 <- <{
+		/* 402 Action161 <- <{
 		    p.PushComponent(begin, end, LessOrEqual)
 		}> */
 		func() bool {
 			{
-				add(ruleAction119, position)
+				add(ruleAction161, position)
 			}
 			return true
 		},
-		/* 308 Action120 <- <{
+		/* 403 Action162 <- <{
 		    p.PushComponent(begin, end, Greater)
 		}> */
 		func() bool {
 			{
-				add(ruleAction120, position)
+				add(ruleAction162, position)
 			}
 			return true
 		},
-		/* 309 Action121 <- <{
+		/* 404 Action163 <- <{
 		    p.PushComponent(begin, end, GreaterOrEqual)
 		}> */
 		func() bool {
 			{
-				add(ruleAction121, position)
+				add(ruleAction163, position)
 			}
 			return true
 		},
-		/* 310 Action122 <- <{
+		/* 405 Action164 <- <{
 		    p.PushComponent(begin, end, NotEqual)
 		}> */
 		func() bool {
 			{
-				add(ruleAction122, position)
+				add(ruleAction164, position)
 			}
 			return true
 		},
-		/* 311 Action123 <- <{
+		/* 406 Action165 <- <{
 		    p.PushComponent(begin, end, Concat)
 		}> */
 		func() bool {
 			{
-				add(ruleAction123, position)
+				add(ruleAction165, position)
 			}
 			return true
 		},
-		/* 312 Action124 <- <{
+		/* 407 Action166 <- <{
 		    p.PushComponent(begin, end, Is)
 		}> */
 		func() bool {
 			{
-				add(ruleAction124, position)
+				add(ruleAction166, position)
 			}
 			return true
 		},
-		/* 313 Action125 <- <{
+		/* 408 Action167 <- <{
 		    p.PushComponent(begin, end, IsNot)
 		}> */
 		func() bool {
 			{
-				add(ruleAction125, position)
+				add(ruleAction167, position)
+			}
+			return true
+		},
+		/* 409 Action168 <- <{
+		    p.PushComponent(begin, end, IsDistinctFrom)
+		}> */
+		func() bool {
+			{
+				add(ruleAction168, position)
+			}
+			return true
+		},
+		/* 410 Action169 <- <{
+		    p.PushComponent(begin, end, IsNotDistinctFrom)
+		}> */
+		func() bool {
+			{
+				add(ruleAction169, position)
 			}
 			return true
 		},
-		/* 314 Action126 <- <{
+		/* 411 Action170 <- <{
 		    p.PushComponent(begin, end, Plus)
 		}> */
 		func() bool {
 			{
-				add(ruleAction126, position)
+				add(ruleAction170, position)
 			}
 			return true
 		},
-		/* 315 Action127 <- <{
+		/* 412 Action171 <- <{
 		    p.PushComponent(begin, end, Minus)
 		}> */
 		func() bool {
 			{
-				add(ruleAction127, position)
+				add(ruleAction171, position)
 			}
 			return true
 		},
-		/* 316 Action128 <- <{
+		/* 413 Action172 <- <{
 		    p.PushComponent(begin, end, Multiply)
 		}> */
 		func() bool {
 			{
-				add(ruleAction128, position)
+				add(ruleAction172, position)
 			}
 			return true
 		},
-		/* 317 Action129 <- <{
+		/* 414 Action173 <- <{
 		    p.PushComponent(begin, end, Divide)
 		}> */
 		func() bool {
 			{
-				add(ruleAction129, position)
+				add(ruleAction173, position)
 			}
 			return true
 		},
-		/* 318 Action130 <- <{
+		/* 415 Action174 <- <{
 		    p.PushComponent(begin, end, Modulo)
 		}> */
 		func() bool {
 			{
-				add(ruleAction130, position)
+				add(ruleAction174, position)
 			}
 			return true
 		},
-		/* 319 Action131 <- <{
+		/* 416 Action175 <- <{
 		    p.PushComponent(begin, end, UnaryMinus)
 		}> */
 		func() bool {
 			{
-				add(ruleAction131, position)
+				add(ruleAction175, position)
 			}
 			return true
 		},
-		/* 320 Action132 <- <{
+		/* 417 Action176 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, Identifier(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction132, position)
+				add(ruleAction176, position)
 			}
 			return true
 		},
-		/* 321 Action133 <- <{
+		/* 418 Action177 <- <{
 		    substr := string([]rune(buffer)[begin:end])
 		    p.PushComponent(begin, end, Identifier(substr))
 		}> */
 		func() bool {
 			{
-				add(ruleAction133, position)
+				add(ruleAction177, position)
 			}
 			return true
 		},
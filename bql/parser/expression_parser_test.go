@@ -31,39 +31,68 @@ func TestExpressionParser(t *testing.T) {
 			ExpressionsAST{[]Expression{Wildcard{"x"}}}, nil}}, "f(x:*)"},
 		"f(x:* ORDER BY a)": {[]Expression{FuncAppAST{FuncName("f"),
 			ExpressionsAST{[]Expression{Wildcard{"x"}}},
-			[]SortedExpressionAST{{RowValue{"", "a"}, UnspecifiedKeyword}}}}, "f(x:* ORDER BY a)"},
+			[]SortedExpressionAST{{RowValue{"", "a"}, UnspecifiedKeyword, UnspecifiedKeyword}}}}, "f(x:* ORDER BY a)"},
 		"f(a ORDER BY a DESC, b, c ASC)": {[]Expression{FuncAppAST{FuncName("f"),
 			ExpressionsAST{[]Expression{RowValue{"", "a"}}},
-			[]SortedExpressionAST{{RowValue{"", "a"}, No}, {RowValue{"", "b"}, UnspecifiedKeyword}, {RowValue{"", "c"}, Yes}}}}, "f(a ORDER BY a DESC, b, c ASC)"},
+			[]SortedExpressionAST{{RowValue{"", "a"}, No, UnspecifiedKeyword},
+				{RowValue{"", "b"}, UnspecifiedKeyword, UnspecifiedKeyword}, {RowValue{"", "c"}, Yes, UnspecifiedKeyword}}}}, "f(a ORDER BY a DESC, b, c ASC)"},
+		"f(a ORDER BY a DESC NULLS FIRST, b NULLS LAST)": {[]Expression{FuncAppAST{FuncName("f"),
+			ExpressionsAST{[]Expression{RowValue{"", "a"}}},
+			[]SortedExpressionAST{{RowValue{"", "a"}, No, Yes},
+				{RowValue{"", "b"}, UnspecifiedKeyword, No}}}}, "f(a ORDER BY a DESC NULLS FIRST, b NULLS LAST)"},
 		"count(a ORDER BY count(b))": {[]Expression{FuncAppAST{FuncName("count"),
 			ExpressionsAST{[]Expression{RowValue{"", "a"}}},
 			[]SortedExpressionAST{{FuncAppAST{FuncName("count"),
-				ExpressionsAST{[]Expression{RowValue{"", "b"}}}, nil}, UnspecifiedKeyword}}}}, "count(a ORDER BY count(b))"},
+				ExpressionsAST{[]Expression{RowValue{"", "b"}}}, nil}, UnspecifiedKeyword, UnspecifiedKeyword}}}}, "count(a ORDER BY count(b))"},
 		`f(2.1, "a")`: {[]Expression{FuncAppAST{FuncName("f"),
 			ExpressionsAST{[]Expression{FloatLiteral{2.1}, StringLiteral{"a"}}}, nil}}, `f(2.1, "a")`},
+		// Window Function Application
+		"row_number() OVER ()": {[]Expression{WindowFuncAppAST{FuncAppAST{FuncName("row_number"),
+			ExpressionsAST{[]Expression{}}, nil}, nil, nil}}, "row_number() OVER ()"},
+		"lag(a, 2) over (partition by k, j order by ts DESC)": {[]Expression{WindowFuncAppAST{FuncAppAST{FuncName("lag"),
+			ExpressionsAST{[]Expression{RowValue{"", "a"}, NumericLiteral{2}}}, nil},
+			[]Expression{RowValue{"", "k"}, RowValue{"", "j"}},
+			[]SortedExpressionAST{{RowValue{"", "ts"}, No, UnspecifiedKeyword}}}},
+			"lag(a, 2) OVER (PARTITION BY k, j ORDER BY ts DESC)"},
+		"sum(a) OVER(ORDER BY ts)+1": {[]Expression{BinaryOpAST{Plus, WindowFuncAppAST{FuncAppAST{FuncName("sum"),
+			ExpressionsAST{[]Expression{RowValue{"", "a"}}}, nil},
+			nil, []SortedExpressionAST{{RowValue{"", "ts"}, UnspecifiedKeyword, UnspecifiedKeyword}}}, NumericLiteral{1}}},
+			"sum(a) OVER (ORDER BY ts) + 1"},
 		// Type Cast
-		"CAST(2.1 AS BOOL)":    {[]Expression{TypeCastAST{FloatLiteral{2.1}, Bool}}, "CAST(2.1 AS BOOL)"},
-		"CAST(2.1 AS INT)":     {[]Expression{TypeCastAST{FloatLiteral{2.1}, Int}}, "CAST(2.1 AS INT)"},
-		"CAST(a*2 AS FLOAT)":   {[]Expression{TypeCastAST{BinaryOpAST{Multiply, RowValue{"", "a"}, NumericLiteral{2}}, Float}}, "CAST(a * 2 AS FLOAT)"},
-		"CAST(2.1 AS STRING)":  {[]Expression{TypeCastAST{FloatLiteral{2.1}, String}}, "CAST(2.1 AS STRING)"},
-		`CAST("hoge" AS BLOB)`: {[]Expression{TypeCastAST{StringLiteral{"hoge"}, Blob}}, `CAST("hoge" AS BLOB)`},
-		"CAST(0 AS TIMESTAMP)": {[]Expression{TypeCastAST{NumericLiteral{0}, Timestamp}}, "CAST(0 AS TIMESTAMP)"},
-		"CAST(2.1 AS ARRAY)":   {[]Expression{TypeCastAST{FloatLiteral{2.1}, Array}}, "CAST(2.1 AS ARRAY)"},
-		`CAST("a" AS MAP)`:     {[]Expression{TypeCastAST{StringLiteral{"a"}, Map}}, `CAST("a" AS MAP)`},
-		"2.1::INT":             {[]Expression{TypeCastAST{FloatLiteral{2.1}, Int}}, "CAST(2.1 AS INT)"},
-		"int::STRING":          {[]Expression{TypeCastAST{RowValue{"", "int"}, String}}, "int::STRING"},
-		"x:int::STRING":        {[]Expression{TypeCastAST{RowValue{"x", "int"}, String}}, "x:int::STRING"},
-		"ts()::STRING":         {[]Expression{TypeCastAST{RowMeta{"", TimestampMeta}, String}}, "ts()::STRING"},
-		"tab:ts()::STRING":     {[]Expression{TypeCastAST{RowMeta{"tab", TimestampMeta}, String}}, "tab:ts()::STRING"},
+		"CAST(2.1 AS BOOL)":    {[]Expression{TypeCastAST{FloatLiteral{2.1}, Bool, ""}}, "CAST(2.1 AS BOOL)"},
+		"CAST(2.1 AS INT)":     {[]Expression{TypeCastAST{FloatLiteral{2.1}, Int, ""}}, "CAST(2.1 AS INT)"},
+		"CAST(a*2 AS FLOAT)":   {[]Expression{TypeCastAST{BinaryOpAST{Multiply, RowValue{"", "a"}, NumericLiteral{2}}, Float, ""}}, "CAST(a * 2 AS FLOAT)"},
+		"CAST(2.1 AS STRING)":  {[]Expression{TypeCastAST{FloatLiteral{2.1}, String, ""}}, "CAST(2.1 AS STRING)"},
+		`CAST("hoge" AS BLOB)`: {[]Expression{TypeCastAST{StringLiteral{"hoge"}, Blob, ""}}, `CAST("hoge" AS BLOB)`},
+		"CAST(0 AS TIMESTAMP)": {[]Expression{TypeCastAST{NumericLiteral{0}, Timestamp, ""}}, "CAST(0 AS TIMESTAMP)"},
+		"CAST(2.1 AS ARRAY)":   {[]Expression{TypeCastAST{FloatLiteral{2.1}, Array, ""}}, "CAST(2.1 AS ARRAY)"},
+		`CAST("a" AS MAP)`:     {[]Expression{TypeCastAST{StringLiteral{"a"}, Map, ""}}, `CAST("a" AS MAP)`},
+		`CAST("a" AS VECTOR)`:  {[]Expression{TypeCastAST{StringLiteral{"a"}, Vector, ""}}, `CAST("a" AS VECTOR)`},
+		"2.1::INT":             {[]Expression{TypeCastAST{FloatLiteral{2.1}, Int, ""}}, "CAST(2.1 AS INT)"},
+		"int::STRING":          {[]Expression{TypeCastAST{RowValue{"", "int"}, String, ""}}, "int::STRING"},
+		"x:int::STRING":        {[]Expression{TypeCastAST{RowValue{"x", "int"}, String, ""}}, "x:int::STRING"},
+		"ts()::STRING":         {[]Expression{TypeCastAST{RowMeta{"", TimestampMeta}, String, ""}}, "ts()::STRING"},
+		"tab:ts()::STRING":     {[]Expression{TypeCastAST{RowMeta{"tab", TimestampMeta}, String, ""}}, "tab:ts()::STRING"},
+		`CAST(a AS TIMESTAMP FORMAT "2006-01-02")`: {[]Expression{TypeCastAST{RowValue{"", "a"}, Timestamp, "2006-01-02"}},
+			`CAST(a AS TIMESTAMP FORMAT "2006-01-02")`},
+		`cast(a as float format "de")`: {[]Expression{TypeCastAST{RowValue{"", "a"}, Float, "de"}},
+			`CAST(a AS FLOAT FORMAT "de")`},
+		`CAST(a AS FLOAT FORMAT de)`: {nil, ""},
 		// RowValue
-		"a":         {[]Expression{RowValue{"", "a"}}, "a"},
-		"-a":        {[]Expression{UnaryOpAST{UnaryMinus, RowValue{"", "a"}}}, "-a"},
-		"tab:a":     {[]Expression{RowValue{"tab", "a"}}, "tab:a"},
-		"ts()":      {[]Expression{RowMeta{"", TimestampMeta}}, "ts()"},
-		"tab:ts()":  {[]Expression{RowMeta{"tab", TimestampMeta}}, "tab:ts()"},
-		"a, b":      {[]Expression{RowValue{"", "a"}, RowValue{"", "b"}}, "a, b"},
-		"A":         {[]Expression{RowValue{"", "A"}}, "A"},
-		"my_mem_27": {[]Expression{RowValue{"", "my_mem_27"}}, "my_mem_27"},
+		"a":                   {[]Expression{RowValue{"", "a"}}, "a"},
+		"-a":                  {[]Expression{UnaryOpAST{UnaryMinus, RowValue{"", "a"}}}, "-a"},
+		"tab:a":               {[]Expression{RowValue{"tab", "a"}}, "tab:a"},
+		"ts()":                {[]Expression{RowMeta{"", TimestampMeta}}, "ts()"},
+		"tab:ts()":            {[]Expression{RowMeta{"tab", TimestampMeta}}, "tab:ts()"},
+		"proc_ts()":           {[]Expression{RowMeta{"", ProcTimestampMeta}}, "proc_ts()"},
+		"tab:input_name()":    {[]Expression{RowMeta{"tab", InputNameMeta}}, "tab:input_name()"},
+		"trace()":             {[]Expression{RowMeta{"", TraceMeta}}, "trace()"},
+		`meta("partition")`:   {[]Expression{RowMetadata{"", "partition"}}, `meta("partition")`},
+		`tab:META ( "a:b" )`:  {[]Expression{RowMetadata{"tab", "a:b"}}, `tab:meta("a:b")`},
+		`meta("tenant")::INT`: {[]Expression{TypeCastAST{RowMetadata{"", "tenant"}, Int, ""}}, `meta("tenant")::INT`},
+		"a, b":                {[]Expression{RowValue{"", "a"}, RowValue{"", "b"}}, "a, b"},
+		"A":                   {[]Expression{RowValue{"", "A"}}, "A"},
+		"my_mem_27":           {[]Expression{RowValue{"", "my_mem_27"}}, "my_mem_27"},
 		/// JSON Path
 		`["hoge"]`:       {[]Expression{RowValue{"", `["hoge"]`}}, `["hoge"]`},
 		`["hoge"][0]..y`: {[]Expression{RowValue{"", `["hoge"][0]..y`}}, `["hoge"][0]..y`},
@@ -157,6 +186,11 @@ func TestExpressionParser(t *testing.T) {
 		"a IS MISSING":     {[]Expression{BinaryOpAST{Is, RowValue{"", "a"}, Missing{}}}, "a IS MISSING"},
 		"a IS NOT MISSING": {[]Expression{BinaryOpAST{IsNot, RowValue{"", "a"}, Missing{}}}, "a IS NOT MISSING"},
 		"a + 2 IS MISSING": {nil, ""}, // this is invalid, as opposed to IS NULL
+		"a IS DISTINCT FROM b": {[]Expression{BinaryOpAST{IsDistinctFrom,
+			RowValue{"", "a"}, RowValue{"", "b"}}}, "a IS DISTINCT FROM b"},
+		"a is not distinct from NULL": {[]Expression{BinaryOpAST{IsNotDistinctFrom,
+			RowValue{"", "a"}, NullLiteral{}}}, "a IS NOT DISTINCT FROM NULL"},
+		"a IS DISTINCTFROM b": {nil, ""},
 		// Plus/Minus Terms
 		"a + 2": {[]Expression{BinaryOpAST{Plus, RowValue{"", "a"}, NumericLiteral{2}}}, "a + 2"},
 		"a - 2": {[]Expression{BinaryOpAST{Minus, RowValue{"", "a"}, NumericLiteral{2}}}, "a - 2"},
@@ -199,8 +233,13 @@ func TestExpressionParser(t *testing.T) {
 		"2 || a = 4": {[]Expression{BinaryOpAST{Equal,
 			BinaryOpAST{Concat, NumericLiteral{2}, RowValue{"", "a"}},
 			NumericLiteral{4}}}, "2 || a = 4"},
+		"a + 1 IS NOT DISTINCT FROM b IS NULL": {[]Expression{BinaryOpAST{IsNotDistinctFrom,
+			BinaryOpAST{Plus, RowValue{"", "a"}, NumericLiteral{1}},
+			BinaryOpAST{Is, RowValue{"", "b"}, NullLiteral{}}}}, "a + 1 IS NOT DISTINCT FROM b IS NULL"},
+		"NOT a IS DISTINCT FROM b": {[]Expression{UnaryOpAST{Not,
+			BinaryOpAST{IsDistinctFrom, RowValue{"", "a"}, RowValue{"", "b"}}}}, "NOT (a IS DISTINCT FROM b)"},
 		"-2.1::INT": {[]Expression{UnaryOpAST{UnaryMinus,
-			TypeCastAST{FloatLiteral{2.1}, Int}}}, "-CAST(2.1 AS INT)"},
+			TypeCastAST{FloatLiteral{2.1}, Int, ""}}}, "-CAST(2.1 AS INT)"},
 		/// Left-Associativity
 		`a || "2" || b`: {[]Expression{BinaryOpAST{Concat,
 			BinaryOpAST{Concat, RowValue{"", "a"}, StringLiteral{"2"}}, RowValue{"", "b"}}}, `(a || "2") || b`},
@@ -240,7 +279,7 @@ func TestExpressionParser(t *testing.T) {
 			RowValue{"", "b"}}}, "(2 - a) * b"},
 		"(2 - a)::STRING": {[]Expression{TypeCastAST{
 			BinaryOpAST{Minus, NumericLiteral{2}, RowValue{"", "a"}},
-			String}}, "CAST(2 - a AS STRING)"},
+			String, ""}}, "CAST(2 - a AS STRING)"},
 		"a * (2 = b)": {[]Expression{BinaryOpAST{Multiply,
 			RowValue{"", "a"}, BinaryOpAST{Equal, NumericLiteral{2}, RowValue{"", "b"}}}}, "a * (2 = b)"},
 		"a - (2 - b)": {[]Expression{BinaryOpAST{Minus,
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format returns the canonical representation of a statement returned from
+// the parser. Keywords are written in upper case and each statement is
+// terminated by a semicolon. Clauses of SELECT statements and parameters of
+// WITH or SET having more than one parameter are written on separate lines:
+//
+//	CREATE STREAM s AS
+//	  SELECT ISTREAM a, b
+//	  FROM src [RANGE 1 TUPLES]
+//	  WHERE a > 1;
+//
+// Parsing the result gives the same statement as the given one, so the
+// result can be used to compare or normalize statements.
+func Format(stmt interface{}) (string, error) {
+	var s string
+	switch stmt := stmt.(type) {
+	case SelectStmt:
+		s = strings.Join(formatSelect(&stmt), "\n")
+	case SelectUnionStmt:
+		s = strings.Join(formatSelectUnion(&stmt), "\n")
+	case CreateStreamAsSelectStmt:
+		s = fmt.Sprintf("%v STREAM %v AS\n  %v", createKeyword(stmt.OrReplace), stmt.Name,
+			strings.Join(formatSelect(&stmt.Select), "\n  "))
+		if stmt.EvictTo != "" {
+			s += fmt.Sprintf("\n  ON EVICT EMIT TO %v", stmt.EvictTo)
+		}
+	case CreateStreamAsSelectUnionStmt:
+		s = fmt.Sprintf("%v STREAM %v AS\n  %v", createKeyword(stmt.OrReplace), stmt.Name,
+			strings.Join(formatSelectUnion(&stmt.SelectUnionStmt), "\n  "))
+	case CreateSourceStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "WITH")
+	case CreateSinkStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "WITH")
+	case CreateBoxStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "WITH")
+	case CreateStateStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "WITH")
+	case UpdateSourceStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "SET")
+	case UpdateSinkStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "SET")
+	case UpdateStateStmt:
+		specs := stmt.SourceSinkSpecsAST
+		stmt.SourceSinkSpecsAST = SourceSinkSpecsAST{}
+		s = stmt.String() + formatSpecs(specs, "SET")
+	case fmt.Stringer:
+		s = stmt.String()
+	default:
+		return "", fmt.Errorf("statement of type %T cannot be formatted", stmt)
+	}
+	return s + ";", nil
+}
+
+// formatSelect returns lines of the statement. A line can have newlines in
+// string literals, so lines must not be indented by replacing newlines.
+func formatSelect(s *SelectStmt) []string {
+	lines := []string{"SELECT " + s.EmitterAST.string()}
+	if prj := s.ProjectionsAST.string(); prj != "" {
+		lines[0] += " " + prj
+	}
+	for _, c := range []string{
+		s.WindowedFromAST.string(),
+		s.FilterAST.string(),
+		s.GroupingAST.string(),
+		s.HavingAST.string(),
+		s.InputSamplingAST.string(),
+	} {
+		if c != "" {
+			lines = append(lines, c)
+		}
+	}
+	return lines
+}
+
+func formatSelectUnion(s *SelectUnionStmt) []string {
+	lines := []string{}
+	for i := range s.Selects {
+		if i > 0 {
+			lines = append(lines, "UNION ALL")
+		}
+		lines = append(lines, formatSelect(&s.Selects[i])...)
+	}
+	return lines
+}
+
+// formatSpecs returns parameters following the keyword. Parameters are
+// written on separate lines when there're more than one parameter.
+func formatSpecs(specs SourceSinkSpecsAST, keyword string) string {
+	switch len(specs.Params) {
+	case 0:
+		return ""
+	case 1:
+		return " " + specs.string(keyword)
+	}
+	ps := make([]string, len(specs.Params))
+	for i, p := range specs.Params {
+		ps[i] = "  " + p.string()
+	}
+	return " " + keyword + "\n" + strings.Join(ps, ",\n")
+}
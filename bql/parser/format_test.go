@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := map[string]string{
+		`select istream a,b from s [range 1 tuples] where a>1`: `SELECT ISTREAM a, b
+FROM s [RANGE 1 TUPLES]
+WHERE a > 1;`,
+		`CREATE STREAM x AS SELECT RSTREAM count(*) AS c FROM s [RANGE 2 SECONDS] GROUP BY a HAVING c > 2`: `CREATE STREAM x AS
+  SELECT RSTREAM count(*) AS c
+  FROM s [RANGE 2 SECONDS]
+  GROUP BY a
+  HAVING c > 2;`,
+		`select istream * from s [range 1 tuples] where a > 1 sample 0.5 percent`: `SELECT ISTREAM *
+FROM s [RANGE 1 TUPLES]
+WHERE a > 1
+SAMPLE 0.5 PERCENT;`,
+		`create or replace stream x as select istream * from a [range 1 tuples] union all select istream * from b [range 1 tuples]`: `CREATE OR REPLACE STREAM x AS
+  SELECT ISTREAM *
+  FROM a [RANGE 1 TUPLES]
+  UNION ALL
+  SELECT ISTREAM *
+  FROM b [RANGE 1 TUPLES];`,
+		`CREATE STREAM x AS SELECT RSTREAM * FROM s [RANGE 2 SECONDS] ON EVICT EMIT TO y`: `CREATE STREAM x AS
+  SELECT RSTREAM *
+  FROM s [RANGE 2 SECONDS]
+  ON EVICT EMIT TO y;`,
+		// newlines in string literals must be kept
+		"CREATE STREAM x AS SELECT ISTREAM \"a\nb\" AS c FROM s [RANGE 1 TUPLES]": "CREATE STREAM x AS\n  SELECT ISTREAM \"a\nb\" AS c\n  FROM s [RANGE 1 TUPLES];",
+		`CREATE PAUSED SOURCE s TYPE t WITH a=1, b="x"`: `CREATE PAUSED SOURCE s TYPE t WITH
+  a=1,
+  b="x";`,
+		`CREATE SINK k TYPE t WITH a=1`: `CREATE SINK k TYPE t WITH a=1;`,
+		`UPDATE STATE st SET a=[1, 2], b=3`: `UPDATE STATE st SET
+  a=[1,2],
+  b=3;`,
+		`insert into k from s`:            `INSERT INTO k FROM s;`,
+		`DROP STREAM IF EXISTS s CASCADE`: `DROP STREAM IF EXISTS s CASCADE;`,
+	}
+
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		for stmt, expected := range testCases {
+			stmt, expected := stmt, expected
+
+			Convey(fmt.Sprintf("When formatting %s", stmt), func() {
+				s, _, err := p.ParseStmt(stmt)
+				So(err, ShouldBeNil)
+				f, err := Format(s)
+				So(err, ShouldBeNil)
+
+				Convey("Then it should be canonical", func() {
+					So(f, ShouldEqual, expected)
+				})
+
+				Convey("Then parsing it should give the same statement", func() {
+					s2, rest, err := p.ParseStmt(f)
+					So(err, ShouldBeNil)
+					So(rest, ShouldBeEmpty)
+					So(s2, ShouldResemble, s)
+				})
+			})
+		}
+
+		Convey("When formatting a value which isn't a statement", func() {
+			_, err := Format(1)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -180,10 +180,10 @@ func TestComment(t *testing.T) {
 		// non-select statements as well
 		("-- do some setup\nCREATE STATE hoge TYPE test;\nSELECT ISTREAM\n  --cols\n" +
 			"  a,b;\nDROP STATE hoge;\n--done"): []interface{}{
-			CreateStateStmt{StreamIdentifier("hoge"), SourceSinkType("test"), SourceSinkSpecsAST{nil}},
+			CreateStateStmt{UnspecifiedKeyword, StreamIdentifier("hoge"), SourceSinkType("test"), SourceSinkSpecsAST{nil}},
 			SelectStmt{EmitterAST: EmitterAST{Istream, nil},
 				ProjectionsAST: ProjectionsAST{[]Expression{RowValue{"", "a"}, RowValue{"", "b"}}}},
-			DropStateStmt{StreamIdentifier("hoge")},
+			DropStateStmt{UnspecifiedKeyword, StreamIdentifier("hoge")},
 		},
 	}
 
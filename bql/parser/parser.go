@@ -18,6 +18,15 @@ func New() *bqlParser {
 }
 
 func (p *bqlParser) ParseStmt(s string) (result interface{}, rest string, err error) {
+	result, rest, _, err = p.parseStmt(s, false)
+	return
+}
+
+// parseStmt parses the first statement in s. When allowPlaceholders is true,
+// the statement can have placeholders and they're returned in the order of
+// their appearance.
+func (p *bqlParser) parseStmt(s string, allowPlaceholders bool) (result interface{}, rest string,
+	placeholders []ParsedComponent, err error) {
 	// catch any parser errors
 	defer func() {
 		if r := recover(); r != nil {
@@ -29,13 +38,14 @@ func (p *bqlParser) ParseStmt(s string) (result interface{}, rest string, err er
 	b.Buffer = s
 	b.Init()
 	if err := b.Parse(); err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
+	b.parseStack.allowPlaceholders = allowPlaceholders
 	b.Execute()
 	if b.parseStack.Peek() == nil {
 		// the statement was parsed ok, but not put on the stack?
 		// this should never occur.
-		return nil, "", fmt.Errorf("no valid BQL statement could be parsed")
+		return nil, "", nil, fmt.Errorf("no valid BQL statement could be parsed")
 	}
 	stackElem := b.parseStack.Pop()
 	// we look at the part of the string right of the parsed
@@ -48,7 +58,7 @@ func (p *bqlParser) ParseStmt(s string) (result interface{}, rest string, err er
 	}
 	rest = strings.TrimLeftFunc(string([]rune(s)[stackElem.end:]), isSpaceOrSemicolon)
 	// pop it from the parse stack
-	return stackElem.comp, rest, nil
+	return stackElem.comp, rest, b.parseStack.placeholders, nil
 }
 
 func (p *bqlParser) ParseStmts(s string) ([]interface{}, error) {
@@ -90,68 +100,94 @@ type bqlParseError struct {
 func (e *bqlParseError) Error() string {
 	error := "failed to parse string as BQL statement\n"
 	stmt := []rune(e.p.Buffer)
-	tokens := e.p.tokenTree.Error()
-	// collect the current stack of tokens and translate their
-	// string indexes into line/symbol pairs
-	positions, p := make([]int, 2*len(tokens)), 0
-	for _, token := range tokens {
-		positions[p], p = int(token.begin), p+1
-		positions[p], p = int(token.end), p+1
-	}
-	translations := translatePositions(e.p.buffer, positions)
-	// now find the offensive line
-	foundError := false
-	for _, token := range tokens {
-		begin, end := int(token.begin), int(token.end)
-		if end == 0 {
-			// these are '' matches we cannot exploit for a useful error message
-			continue
-		} else if foundError {
-			// if we found an error, the next tokens may give some additional
-			// information about what kind of statement we have here. the first
-			// rule that starts at 0 is (often?) the description we want.
-			ruleName := rul3s[token.pegRule]
-			if begin == 0 && end > 0 {
-				error += fmt.Sprintf("\nconsider to look up the documentation for %s",
-					ruleName)
-				break
-			}
-		} else if end > 0 {
-			error += fmt.Sprintf("statement has a syntax error near line %v, symbol %v:\n",
-				translations[end].line, translations[end].symbol)
-			// we want some output like:
-			//
-			//   ... FROM x [RANGE 7 UPLES] WHERE ...
-			//                       ^
-			//
-			snipStartIdx := end - 20
-			snipStart := "..."
-			if snipStartIdx < 0 {
-				snipStartIdx = 0
-				snipStart = ""
-			}
-			snipEndIdx := end + 30
-			snipEnd := "..."
-			if snipEndIdx > len(stmt) {
-				snipEndIdx = len(stmt)
-				snipEnd = ""
-			}
-			// first line: an excerpt from the statement
-			error += "  " + snipStart
-			snipBeforeErr := strings.Replace(string(stmt[snipStartIdx:end]), "\n", " ", -1)
-			snipAfterInclErr := strings.Replace(string(stmt[end:snipEndIdx]), "\n", " ", -1)
-			error += snipBeforeErr + snipAfterInclErr
-			error += snipEnd + "\n"
-			// second line: a ^ marker at the correct position
-			error += strings.Repeat(" ", len(snipStart)+2)
-			error += strings.Repeat(" ", runewidth.StringWidth(snipBeforeErr))
-			error += "^"
-			foundError = true
+	// the parser stopped at the end of the longest match
+	pos := int(e.max.end)
+	// if we found an error, the tokens may give some additional
+	// information about what kind of statement we have here. the first
+	// rule that starts at 0 is (often?) the description we want.
+	ruleName := ""
+	for _, token := range e.p.tokenTree.Error() {
+		if token.begin == 0 && token.end > 0 {
+			ruleName = rul3s[token.pegRule]
+			break
+		}
+	}
+	// the statement may fail at whitespace before the unexpected token
+	for pos < len(stmt) && unicode.IsSpace(stmt[pos]) {
+		pos++
+	}
+
+	line, column := 1, pos+1
+	lineStart := 0
+	for i, r := range stmt[:pos] {
+		if r == '\n' {
+			line, column = line+1, pos-i
+			lineStart = i + 1
 		}
 	}
-	if !foundError {
-		error += "statement has an unlocatable syntax error"
+	lineEnd := pos
+	for lineEnd < len(stmt) && stmt[lineEnd] != '\n' {
+		lineEnd++
 	}
+	error += fmt.Sprintf("statement has a syntax error at line %v, column %v:\n", line, column)
 
+	// we want some output like:
+	//
+	//   ... FROM x [RANGE 7 UPLES] WHERE ...
+	//                       ^
+	//
+	snipStartIdx := pos - 20
+	snipStart := "..."
+	if snipStartIdx <= lineStart {
+		snipStartIdx = lineStart
+		snipStart = ""
+	}
+	snipEndIdx := pos + 30
+	snipEnd := "..."
+	if snipEndIdx >= lineEnd {
+		snipEndIdx = lineEnd
+		snipEnd = ""
+	}
+	// first line: an excerpt from the line having the error
+	snipBeforeErr := string(stmt[snipStartIdx:pos])
+	error += "  " + snipStart + snipBeforeErr + string(stmt[pos:snipEndIdx]) + snipEnd + "\n"
+	// second line: a ^ marker at the correct position
+	error += strings.Repeat(" ", len(snipStart)+2)
+	error += strings.Repeat(" ", runewidth.StringWidth(snipBeforeErr))
+	error += "^"
+
+	// third line: the unexpected token and a suggestion
+	token := unexpectedToken(stmt[pos:])
+	if token == "" {
+		error += "\nunexpected end of input"
+	} else {
+		error += fmt.Sprintf("\nunexpected %q", token)
+		if k := suggestKeyword(token); k != "" {
+			error += fmt.Sprintf(", did you mean %q?", k)
+		}
+	}
+
+	if ruleName != "" {
+		error += fmt.Sprintf("\nconsider to look up the documentation for %s", ruleName)
+	}
 	return error
 }
+
+// unexpectedToken returns the token at the beginning of s. It returns an
+// empty string when s is empty.
+func unexpectedToken(s []rune) string {
+	if len(s) == 0 {
+		return ""
+	}
+	isIdent := func(r rune) bool {
+		return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	if !isIdent(s[0]) {
+		return string(s[:1])
+	}
+	i := 1
+	for i < len(s) && isIdent(s[i]) {
+		i++
+	}
+	return string(s[:i])
+}
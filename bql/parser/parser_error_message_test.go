@@ -10,33 +10,61 @@ func TestParserErrorMessage(t *testing.T) {
 	testCases := map[string]string{
 		// rubbish
 		`HELLO`: `failed to parse string as BQL statement
-statement has an unlocatable syntax error`,
+statement has a syntax error at line 1, column 1:
+  HELLO
+  ^
+unexpected "HELLO"`,
 		// wrong keyword -> unrecognizable statement
 		`CREATE STRAEM x AS SELECT ISTREAM x`: `failed to parse string as BQL statement
-statement has a syntax error near line 1, symbol 8:
+statement has a syntax error at line 1, column 8:
   CREATE STRAEM x AS SELECT ISTREAM x
-         ^`,
-		`REWIND SSOURCE ab`: `statement has a syntax error near line 1, symbol 8:
+         ^
+unexpected "STRAEM", did you mean "STREAM"?`,
+		`REWIND SSOURCE ab`: `statement has a syntax error at line 1, column 8:
   REWIND SSOURCE ab
-         ^`,
+         ^
+unexpected "SSOURCE", did you mean "SOURCE"?`,
+		`SELCT ISTREAM a FROM b [RANGE 1 TUPLES]`: `statement has a syntax error at line 1, column 1:
+  SELCT ISTREAM a FROM b [RANGE ...
+  ^
+unexpected "SELCT", did you mean "SELECT"?`,
 		// some remainder after the statement is complete
-		`REWIND SOURCE ab cd`: `statement has a syntax error near line 1, symbol 17:
+		`REWIND SOURCE ab cd`: `statement has a syntax error at line 1, column 18:
   REWIND SOURCE ab cd
-                  ^
+                   ^
+unexpected "cd"
 consider to look up the documentation for RewindSourceStmt`,
 		// unicode characters
-		// TODO this should be "look up the documentation for SelectStmt"!
-		`SELECT ISTREAM "日本語", b FROM c [RANGE 3 UPLES]`: `failed to parse string as BQL statement
-statement has a syntax error near line 1, symbol 24:
-  ...ECT ISTREAM "日本語", b FROM c [RANGE 3 UPLES]
+		`SELECT ISTREAM "日本語" FORM c`: `failed to parse string as BQL statement
+statement has a syntax error at line 1, column 22:
+  ...ELECT ISTREAM "日本語" FORM c
                             ^
+unexpected "FORM", did you mean "FROM"?
+consider to look up the documentation for StatementWithoutRest`,
+		// lower case keywords
+		`select istream a from b [range 1 tupels]`: `statement has a syntax error at line 1, column 34:
+  ...m a from b [range 1 tupels]
+                         ^
+unexpected "tupels", did you mean "tuples"?
+consider to look up the documentation for StatementWithoutRest`,
+		// multiple lines
+		"SELECT ISTREAM a\nFROM b [RANGE 1 UPLES]\nWHERE c": `statement has a syntax error at line 2, column 17:
+  FROM b [RANGE 1 UPLES]
+                  ^
+unexpected "UPLES", did you mean "TUPLES"?
 consider to look up the documentation for StatementWithoutRest`,
 		// wrong expression
 		`CREATE STREAM x AS SELECT ISTREAM 2 + x:*`: `failed to parse string as BQL statement
-statement has a syntax error near line 1, symbol 36:
-  ... AS SELECT ISTREAM 2 + x:*
+statement has a syntax error at line 1, column 40:
+  ...SELECT ISTREAM 2 + x:*
                          ^
+unexpected ":"
 consider to look up the documentation for CreateStreamAsSelectStmt`,
+		`EVAL 1 +`: `statement has a syntax error at line 1, column 9:
+  EVAL 1 +
+          ^
+unexpected end of input
+consider to look up the documentation for StatementWithoutRest`,
 	}
 
 	Convey("Given a BQL parser", t, func() {
@@ -61,4 +89,26 @@ consider to look up the documentation for CreateStreamAsSelectStmt`,
 
 	})
 
+	Convey("Given a name and candidates", t, func() {
+		candidates := []string{"count", "concat", "coalesce"}
+
+		Convey("When the name is misspelled", func() {
+			Convey("Then the most similar candidate should be suggested", func() {
+				So(SuggestName("cuont", candidates), ShouldEqual, "count")
+				So(SuggestName("CONCT", candidates), ShouldEqual, "concat")
+			})
+		})
+
+		Convey("When the name is one of the candidates", func() {
+			Convey("Then nothing should be suggested", func() {
+				So(SuggestName("Count", candidates), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When no candidate is similar to the name", func() {
+			Convey("Then nothing should be suggested", func() {
+				So(SuggestName("sum", candidates), ShouldBeEmpty)
+			})
+		})
+	})
 }
@@ -0,0 +1,230 @@
+package parser
+
+import (
+	"encoding/base64"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PreparedStmt is a BQL statement having placeholders which are replaced
+// with values when the statement is bound. A positional placeholder is
+// written as `?` and a named placeholder is written as `:name`. A statement
+// cannot have both kinds of placeholders. Placeholders can be used wherever
+// a literal can be written, including parameters of a WITH clause.
+//
+// Bound values are embedded into the statement as literals. Therefore, they
+// are never interpreted as a part of BQL syntax and their types are kept
+// as they are, unlike statements built by string interpolation.
+type PreparedStmt struct {
+	stmt         []rune
+	placeholders []ParsedComponent
+	named        bool
+}
+
+// Prepare parses a single BQL statement having placeholders.
+func (p *bqlParser) Prepare(s string) (*PreparedStmt, error) {
+	_, rest, phs, err := p.parseStmt(s, true)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("a prepared statement must consist of a single statement")
+	}
+	sort.Sort(componentsByPosition(phs))
+
+	ps := &PreparedStmt{
+		stmt:         []rune(s),
+		placeholders: phs,
+	}
+	for i, c := range phs {
+		named := c.comp.(Placeholder).Name != ""
+		if i > 0 && named != ps.named {
+			return nil, fmt.Errorf("positional and named placeholders cannot be used together")
+		}
+		ps.named = named
+	}
+	return ps, nil
+}
+
+// String returns the statement having placeholders.
+func (s *PreparedStmt) String() string {
+	return string(s.stmt)
+}
+
+// NumPlaceholders returns the number of placeholders in the statement. A
+// named placeholder appearing more than once is counted for each occurrence.
+func (s *PreparedStmt) NumPlaceholders() int {
+	return len(s.placeholders)
+}
+
+// Names returns the names of named placeholders in the statement without
+// duplicates. It returns an empty slice when the statement only has
+// positional placeholders.
+func (s *PreparedStmt) Names() []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, c := range s.placeholders {
+		n := c.comp.(Placeholder).Name
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		names = append(names, n)
+	}
+	return names
+}
+
+// Bind returns a statement in which positional placeholders are replaced
+// with the given values in order. The number of values must be the same as
+// the number of placeholders.
+func (s *PreparedStmt) Bind(args ...data.Value) (string, error) {
+	if s.named {
+		return "", fmt.Errorf("the statement has named placeholders")
+	}
+	if len(args) != len(s.placeholders) {
+		return "", fmt.Errorf("the statement has %v placeholders but %v values are given",
+			len(s.placeholders), len(args))
+	}
+	return s.bind(func(i int, p Placeholder) (data.Value, error) {
+		return args[i], nil
+	})
+}
+
+// BindNamed returns a statement in which named placeholders are replaced
+// with values having the same names in args. args must have a value for
+// each name and must not have a value which isn't used by the statement.
+func (s *PreparedStmt) BindNamed(args data.Map) (string, error) {
+	if !s.named && len(s.placeholders) > 0 {
+		return "", fmt.Errorf("the statement has positional placeholders")
+	}
+	names := map[string]bool{}
+	for _, n := range s.Names() {
+		names[n] = true
+	}
+	for n := range args {
+		if !names[n] {
+			return "", fmt.Errorf("the statement doesn't have the placeholder ':%v'", n)
+		}
+	}
+	return s.bind(func(i int, p Placeholder) (data.Value, error) {
+		v, ok := args[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("no value is given to the placeholder ':%v'", p.Name)
+		}
+		return v, nil
+	})
+}
+
+func (s *PreparedStmt) bind(value func(i int, p Placeholder) (data.Value, error)) (string, error) {
+	var b []rune
+	prev := 0
+	for i, c := range s.placeholders {
+		v, err := value(i, c.comp.(Placeholder))
+		if err != nil {
+			return "", err
+		}
+		lit, err := literal(v)
+		if err != nil {
+			return "", fmt.Errorf("cannot bind a value to the placeholder %v: %v",
+				c.comp.(Placeholder), err)
+		}
+
+		b = append(b, s.stmt[prev:c.begin]...)
+		// a negative number following '-' would start a comment
+		if len(b) > 0 && b[len(b)-1] == '-' {
+			b = append(b, ' ')
+		}
+		b = append(b, []rune(lit)...)
+		prev = c.end
+	}
+	b = append(b, s.stmt[prev:]...)
+	return string(b), nil
+}
+
+// literal returns a BQL expression which is evaluated to the given value.
+func literal(v data.Value) (string, error) {
+	if v == nil {
+		return NullLiteral{}.String(), nil
+	}
+	switch v.Type() {
+	case data.TypeNull:
+		return NullLiteral{}.String(), nil
+	case data.TypeBool:
+		b, _ := data.AsBool(v)
+		return BoolLiteral{b}.String(), nil
+	case data.TypeInt:
+		i, _ := data.AsInt(v)
+		return NumericLiteral{i}.String(), nil
+	case data.TypeFloat:
+		f, _ := data.AsFloat(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return castLiteral(strconv.FormatFloat(f, 'g', -1, 64), Float), nil
+		}
+		// the literal must have a decimal point and no exponent to be
+		// parsed as a float
+		s := strconv.FormatFloat(f, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s, nil
+	case data.TypeString:
+		s, _ := data.AsString(v)
+		return StringLiteral{s}.String(), nil
+	case data.TypeBlob:
+		b, _ := data.AsBlob(v)
+		return castLiteral(base64.StdEncoding.EncodeToString(b), Blob), nil
+	case data.TypeTimestamp:
+		t, _ := data.AsTimestamp(v)
+		return castLiteral(t.Format(time.RFC3339Nano), Timestamp), nil
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		elems := make([]string, len(a))
+		for i, e := range a {
+			l, err := literal(e)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = l
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			l, err := literal(m[k])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = StringLiteral{k}.String() + ":" + l
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	case data.TypeVector:
+		a, _ := data.AsArray(v)
+		l, err := literal(a)
+		if err != nil {
+			return "", err
+		}
+		return "CAST(" + l + " AS " + Vector.String() + ")", nil
+	}
+	return "", fmt.Errorf("unsupported type: %v", v.Type())
+}
+
+func castLiteral(s string, t Type) string {
+	return TypeCastAST{StringLiteral{s}, t, ""}.String()
+}
+
+type componentsByPosition []ParsedComponent
+
+func (c componentsByPosition) Len() int           { return len(c) }
+func (c componentsByPosition) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c componentsByPosition) Less(i, j int) bool { return c[i].begin < c[j].begin }
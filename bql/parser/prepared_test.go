@@ -0,0 +1,144 @@
+package parser
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPreparedStmt(t *testing.T) {
+	Convey("Given a BQL parser", t, func() {
+		p := New()
+
+		Convey("When parsing a statement having a placeholder without preparing it", func() {
+			_, _, err := p.ParseStmt("EVAL ?")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "prepared statement")
+			})
+		})
+
+		Convey("When preparing a statement having positional placeholders", func() {
+			ps, err := p.Prepare(`SELECT ISTREAM a, ? AS b FROM s [RANGE 1 TUPLES] WHERE c = ? AND d = "?"`)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the placeholders", func() {
+				So(ps.NumPlaceholders(), ShouldEqual, 2)
+				So(ps.Names(), ShouldBeEmpty)
+			})
+
+			Convey("Then values should be bound in order", func() {
+				s, err := ps.Bind(data.Int(1), data.String(`x" OR TRUE`))
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, `SELECT ISTREAM a, 1 AS b FROM s [RANGE 1 TUPLES] WHERE c = "x"" OR TRUE" AND d = "?"`)
+
+				Convey("And the bound statement should be parsed as a string comparison", func() {
+					stmt, _, err := p.ParseStmt(s)
+					So(err, ShouldBeNil)
+					sel := stmt.(SelectStmt)
+					bin := sel.Filter.(BinaryOpAST)
+					So(bin.Op, ShouldEqual, And)
+					So(bin.Left.(BinaryOpAST).Right, ShouldResemble, StringLiteral{`x" OR TRUE`})
+				})
+			})
+
+			Convey("Then binding the wrong number of values should fail", func() {
+				_, err := ps.Bind(data.Int(1))
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then binding named values should fail", func() {
+				_, err := ps.BindNamed(data.Map{"a": data.Int(1)})
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When preparing a statement having named placeholders", func() {
+			ps, err := p.Prepare(`CREATE SOURCE s TYPE dummy WITH a=:x, b=[:y, :x]`)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the names", func() {
+				So(ps.NumPlaceholders(), ShouldEqual, 3)
+				So(ps.Names(), ShouldResemble, []string{"x", "y"})
+			})
+
+			Convey("Then values should be bound by names", func() {
+				s, err := ps.BindNamed(data.Map{"x": data.Int(1), "y": data.String("z")})
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, `CREATE SOURCE s TYPE dummy WITH a=1, b=["z", 1]`)
+			})
+
+			Convey("Then binding a missing name should fail", func() {
+				_, err := ps.BindNamed(data.Map{"x": data.Int(1)})
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then binding an unknown name should fail", func() {
+				_, err := ps.BindNamed(data.Map{"x": data.Int(1), "y": data.Int(2), "z": data.Int(3)})
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When preparing a statement having both kinds of placeholders", func() {
+			_, err := p.Prepare(`EVAL ? + :a`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When preparing multiple statements", func() {
+			_, err := p.Prepare(`EVAL ?; EVAL ?;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When binding a negative number after a minus sign", func() {
+			ps, err := p.Prepare(`EVAL 1 -?`)
+			So(err, ShouldBeNil)
+			s, err := ps.Bind(data.Int(-2))
+			So(err, ShouldBeNil)
+
+			Convey("Then it shouldn't become a comment", func() {
+				So(s, ShouldEqual, `EVAL 1 - -2`)
+				_, _, err := p.ParseStmt(s)
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When binding values of various types", func() {
+			ps, err := p.Prepare(`EVAL ?`)
+			So(err, ShouldBeNil)
+			now := time.Date(2015, 4, 1, 12, 34, 56, 789, time.UTC)
+
+			cases := []struct {
+				v        data.Value
+				expected string
+			}{
+				{data.Null{}, "EVAL NULL"},
+				{nil, "EVAL NULL"},
+				{data.Bool(true), "EVAL TRUE"},
+				{data.Int(-3), "EVAL -3"},
+				{data.Float(2), "EVAL 2.0"},
+				{data.Float(1e21), "EVAL 1000000000000000000000.0"},
+				{data.Float(math.Inf(1)), `EVAL CAST("+Inf" AS FLOAT)`},
+				{data.Blob("abc"), `EVAL CAST("YWJj" AS BLOB)`},
+				{data.Timestamp(now), `EVAL CAST("2015-04-01T12:34:56.000000789Z" AS TIMESTAMP)`},
+				{data.Array{data.Int(1), data.String("a")}, `EVAL [1, "a"]`},
+				{data.Map{"b": data.Int(1), `a"`: data.Bool(false)}, `EVAL {"a""":FALSE, "b":1}`},
+			}
+			for _, c := range cases {
+				s, err := ps.Bind(c.v)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, c.expected)
+				_, _, err = p.ParseStmt(s)
+				So(err, ShouldBeNil)
+			}
+		})
+	})
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
 )
 
 // parseStack is a standard stack implementation, but also holds
@@ -10,6 +11,11 @@ import (
 type parseStack struct {
 	top  *stackElement
 	size int
+
+	// allowPlaceholders is true when the statement is parsed as a prepared
+	// statement. placeholders has all placeholders in the statement.
+	allowPlaceholders bool
+	placeholders      []ParsedComponent
 }
 
 // stackElement is a stack-internal data structure that is used
@@ -73,14 +79,17 @@ func (ps *parseStack) Peek() (value *ParsedComponent) {
 //  FilterAST
 //  WindowedFromAST
 //  ProjectionsAST
+//  InputSamplingAST
 //   =>
-//  SelectStmt{EmitterAST, ProjectionsAST, WindowedFromAST, FilterAST, GroupingAST, HavingAST}
+//  SelectStmt{EmitterAST, ProjectionsAST, WindowedFromAST, FilterAST, GroupingAST, HavingAST, InputSamplingAST}
 func (ps *parseStack) AssembleSelect() {
 	// pop the components from the stack in reverse order
+	_sampling := ps.Pop()
 	_having, _grouping, _filter, _from, _projections, _emitter := ps.pop6()
 
 	// extract and convert the contained structure
 	// (if this fails, this is a fundamental parser bug => panic ok)
+	sampling := _sampling.comp.(InputSamplingAST)
 	having := _having.comp.(HavingAST)
 	grouping := _grouping.comp.(GroupingAST)
 	filter := _filter.comp.(FilterAST)
@@ -89,8 +98,8 @@ func (ps *parseStack) AssembleSelect() {
 	emitter := _emitter.comp.(EmitterAST)
 
 	// assemble the SelectStmt and push it back
-	s := SelectStmt{emitter, projections, from, filter, grouping, having}
-	se := ParsedComponent{_emitter.begin, _having.end, s}
+	s := SelectStmt{emitter, projections, from, filter, grouping, having, sampling}
+	se := ParsedComponent{_emitter.begin, _sampling.end, s}
 	ps.Push(&se)
 }
 
@@ -117,45 +126,73 @@ func (ps *parseStack) AssembleSelectUnion(begin int, end int) {
 // assuming they are components of a CREATE STREAM statement, and
 // replaces them by a single CreateStreamAsSelectStmt element.
 //
+//  StreamIdentifier
 //  SelectStmt
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  CreateStreamAsSelectStmt{StreamIdentifier, SelectStmt}
+//  CreateStreamAsSelectStmt{BinaryKeyword, StreamIdentifier, SelectStmt, StreamIdentifier}
 func (ps *parseStack) AssembleCreateStreamAsSelect() {
 	// now pop the components from the stack in reverse order
-	_select, _name := ps.pop2()
+	_evictTo, _select, _name, _orReplace := ps.pop4()
 
 	// extract and convert the contained structure
 	// (if this fails, this is a fundamental parser bug => panic ok)
+	evictTo := _evictTo.comp.(StreamIdentifier)
 	s := _select.comp.(SelectStmt)
 	name := _name.comp.(StreamIdentifier)
+	orReplace := _orReplace.comp.(BinaryKeyword)
 
 	// assemble the SelectStmt and push it back
-	css := CreateStreamAsSelectStmt{name, s}
-	se := ParsedComponent{_name.begin, _select.end, css}
+	css := CreateStreamAsSelectStmt{orReplace, name, s, evictTo}
+	end := _select.end
+	if evictTo != "" {
+		end = _evictTo.end
+	}
+	se := ParsedComponent{_orReplace.begin, end, css}
 	ps.Push(&se)
 }
 
+// EnsureEvictionTarget makes sure that the top element of the stack is a
+// StreamIdentifier given by ON EVICT EMIT TO. An empty StreamIdentifier is
+// pushed when it isn't specified.
+func (ps *parseStack) EnsureEvictionTarget(begin int, end int) {
+	top := ps.Peek()
+	if top == nil || top.end <= begin {
+		// there is no item in the given range
+		ps.PushComponent(begin, end, StreamIdentifier(""))
+	} else {
+		// there is an item in the given range
+		_, ok := top.comp.(StreamIdentifier)
+		if !ok {
+			panic(fmt.Sprintf("begin (%d) != end (%d), but there "+
+				"was a %T on the stack", begin, end, top.comp))
+		}
+	}
+}
+
 // AssembleCreateStreamAsSelectUnion takes the topmost elements from the
 // stack, assuming they are components of a CREATE STREAM statement, and
 // replaces them by a single CreateStreamAsSelectUnionStmt element.
 //
 //  SelectUnionStmt
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  CreateStreamAsSelectUnionStmt{StreamIdentifier, SelectUnionStmt}
+//  CreateStreamAsSelectUnionStmt{BinaryKeyword, StreamIdentifier, SelectUnionStmt}
 func (ps *parseStack) AssembleCreateStreamAsSelectUnion() {
 	// now pop the components from the stack in reverse order
-	_selectUnion, _name := ps.pop2()
+	_selectUnion, _name, _orReplace := ps.pop3()
 
 	// extract and convert the contained structure
 	// (if this fails, this is a fundamental parser bug => panic ok)
 	selectUnion := _selectUnion.comp.(SelectUnionStmt)
 	name := _name.comp.(StreamIdentifier)
+	orReplace := _orReplace.comp.(BinaryKeyword)
 
 	// assemble the SelectUnionStmt and push it back
-	css := CreateStreamAsSelectUnionStmt{name, selectUnion}
-	se := ParsedComponent{_name.begin, _selectUnion.end, css}
+	css := CreateStreamAsSelectUnionStmt{orReplace, name, selectUnion}
+	se := ParsedComponent{_orReplace.begin, _selectUnion.end, css}
 	ps.Push(&se)
 }
 
@@ -163,16 +200,17 @@ func (ps *parseStack) AssembleCreateStreamAsSelectUnion() {
 // assuming they are components of a CREATE SOURCE statement, and
 // replaces them by a single CreateSourceStmt element.
 //
-//  BinaryKeyword
 //  SourceSinkSpecsAST
 //  SourceSinkType
 //  StreamIdentifier
+//  BinaryKeyword
+//  BinaryKeyword
 //   =>
-//  CreateSourceStmt{BinaryKeyword, StreamIdentifier, SourceSinkType,
-//    SourceSinkSpecsAST}
+//  CreateSourceStmt{BinaryKeyword, BinaryKeyword, StreamIdentifier,
+//    SourceSinkType, SourceSinkSpecsAST}
 func (ps *parseStack) AssembleCreateSource() {
 	// pop the components from the stack in reverse order
-	_specs, _sourceType, _name, _paused := ps.pop4()
+	_specs, _sourceType, _name, _paused, _orReplace := ps.pop5()
 
 	// extract and convert the contained structure
 	// (if this fails, this is a fundamental parser bug => panic ok)
@@ -180,10 +218,11 @@ func (ps *parseStack) AssembleCreateSource() {
 	sourceType := _sourceType.comp.(SourceSinkType)
 	name := _name.comp.(StreamIdentifier)
 	paused := _paused.comp.(BinaryKeyword)
+	orReplace := _orReplace.comp.(BinaryKeyword)
 
 	// assemble the CreateSourceStmt and push it back
-	s := CreateSourceStmt{paused, name, sourceType, specs}
-	se := ParsedComponent{_paused.begin, _specs.end, s}
+	s := CreateSourceStmt{orReplace, paused, name, sourceType, specs}
+	se := ParsedComponent{_orReplace.begin, _specs.end, s}
 	ps.Push(&se)
 }
 
@@ -194,16 +233,42 @@ func (ps *parseStack) AssembleCreateSource() {
 //  SourceSinkSpecsAST
 //  SourceSinkType
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  CreateSinkStmt{StreamIdentifier, SourceSinkType, SourceSinkSpecsAST}
+//  CreateSinkStmt{BinaryKeyword, StreamIdentifier, SourceSinkType,
+//    SourceSinkSpecsAST}
 func (ps *parseStack) AssembleCreateSink() {
-	_specs, _sinkType, _name := ps.pop3()
+	_specs, _sinkType, _name, _orReplace := ps.pop4()
 
 	specs := _specs.comp.(SourceSinkSpecsAST)
 	sinkType := _sinkType.comp.(SourceSinkType)
 	name := _name.comp.(StreamIdentifier)
+	orReplace := _orReplace.comp.(BinaryKeyword)
 
-	s := CreateSinkStmt{name, sinkType, specs}
+	s := CreateSinkStmt{orReplace, name, sinkType, specs}
+	se := ParsedComponent{_orReplace.begin, _specs.end, s}
+	ps.Push(&se)
+}
+
+// AssembleCreateBox takes the topmost elements from the stack,
+// assuming they are components of a CREATE BOX statement, and
+// replaces them by a single CreateBoxStmt element.
+//
+//  SourceSinkSpecsAST
+//  StreamIdentifier
+//  SourceSinkType
+//  StreamIdentifier
+//   =>
+//  CreateBoxStmt{StreamIdentifier, SourceSinkType, StreamIdentifier, SourceSinkSpecsAST}
+func (ps *parseStack) AssembleCreateBox() {
+	_specs, _input, _boxType, _name := ps.pop4()
+
+	specs := _specs.comp.(SourceSinkSpecsAST)
+	input := _input.comp.(StreamIdentifier)
+	boxType := _boxType.comp.(SourceSinkType)
+	name := _name.comp.(StreamIdentifier)
+
+	s := CreateBoxStmt{name, boxType, input, specs}
 	se := ParsedComponent{_name.begin, _specs.end, s}
 	ps.Push(&se)
 }
@@ -215,17 +280,20 @@ func (ps *parseStack) AssembleCreateSink() {
 //  SourceSinkSpecsAST
 //  SourceSinkType
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  CreateStateStmt{StreamIdentifier, SourceSinkType, SourceSinkSpecsAST}
+//  CreateStateStmt{BinaryKeyword, StreamIdentifier, SourceSinkType,
+//    SourceSinkSpecsAST}
 func (ps *parseStack) AssembleCreateState() {
-	_specs, _sinkType, _name := ps.pop3()
+	_specs, _sinkType, _name, _orReplace := ps.pop4()
 
 	specs := _specs.comp.(SourceSinkSpecsAST)
 	sinkType := _sinkType.comp.(SourceSinkType)
 	name := _name.comp.(StreamIdentifier)
+	orReplace := _orReplace.comp.(BinaryKeyword)
 
-	s := CreateStateStmt{name, sinkType, specs}
-	se := ParsedComponent{_name.begin, _specs.end, s}
+	s := CreateStateStmt{orReplace, name, sinkType, specs}
+	se := ParsedComponent{_orReplace.begin, _specs.end, s}
 	ps.Push(&se)
 }
 
@@ -360,16 +428,20 @@ func (ps *parseStack) AssembleRewindSource() {
 // assuming they are components of a DROP SOURCE statement, and
 // replaces them by a single DropSourceStmt element.
 //
+//  BinaryKeyword
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  DropSourceStmt{StreamIdentifier}
+//  DropSourceStmt{BinaryKeyword, StreamIdentifier, BinaryKeyword}
 func (ps *parseStack) AssembleDropSource() {
 	// pop the components from the stack in reverse order
-	_name := ps.Pop()
+	_cascade, _name, _ifExists := ps.pop3()
 
+	cascade := _cascade.comp.(BinaryKeyword)
 	name := _name.comp.(StreamIdentifier)
+	ifExists := _ifExists.comp.(BinaryKeyword)
 
-	se := ParsedComponent{_name.begin, _name.end, DropSourceStmt{name}}
+	se := ParsedComponent{_ifExists.begin, _cascade.end, DropSourceStmt{ifExists, name, cascade}}
 	ps.Push(&se)
 }
 
@@ -377,16 +449,20 @@ func (ps *parseStack) AssembleDropSource() {
 // assuming they are components of a DROP STREAM statement, and
 // replaces them by a single DropStreamStmt element.
 //
+//  BinaryKeyword
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  DropStreamStmt{StreamIdentifier}
+//  DropStreamStmt{BinaryKeyword, StreamIdentifier, BinaryKeyword}
 func (ps *parseStack) AssembleDropStream() {
 	// pop the components from the stack in reverse order
-	_name := ps.Pop()
+	_cascade, _name, _ifExists := ps.pop3()
 
+	cascade := _cascade.comp.(BinaryKeyword)
 	name := _name.comp.(StreamIdentifier)
+	ifExists := _ifExists.comp.(BinaryKeyword)
 
-	se := ParsedComponent{_name.begin, _name.end, DropStreamStmt{name}}
+	se := ParsedComponent{_ifExists.begin, _cascade.end, DropStreamStmt{ifExists, name, cascade}}
 	ps.Push(&se)
 }
 
@@ -395,15 +471,17 @@ func (ps *parseStack) AssembleDropStream() {
 // replaces them by a single DropSinkStmt element.
 //
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  DropSinkStmt{StreamIdentifier}
+//  DropSinkStmt{BinaryKeyword, StreamIdentifier}
 func (ps *parseStack) AssembleDropSink() {
 	// pop the components from the stack in reverse order
-	_name := ps.Pop()
+	_name, _ifExists := ps.pop2()
 
 	name := _name.comp.(StreamIdentifier)
+	ifExists := _ifExists.comp.(BinaryKeyword)
 
-	se := ParsedComponent{_name.begin, _name.end, DropSinkStmt{name}}
+	se := ParsedComponent{_ifExists.begin, _name.end, DropSinkStmt{ifExists, name}}
 	ps.Push(&se)
 }
 
@@ -412,15 +490,17 @@ func (ps *parseStack) AssembleDropSink() {
 // replaces them by a single DropStateStmt element.
 //
 //  StreamIdentifier
+//  BinaryKeyword
 //   =>
-//  DropStateStmt{StreamIdentifier}
+//  DropStateStmt{BinaryKeyword, StreamIdentifier}
 func (ps *parseStack) AssembleDropState() {
 	// pop the components from the stack in reverse order
-	_name := ps.Pop()
+	_name, _ifExists := ps.pop2()
 
 	name := _name.comp.(StreamIdentifier)
+	ifExists := _ifExists.comp.(BinaryKeyword)
 
-	se := ParsedComponent{_name.begin, _name.end, DropStateStmt{name}}
+	se := ParsedComponent{_ifExists.begin, _name.end, DropStateStmt{ifExists, name}}
 	ps.Push(&se)
 }
 
@@ -493,6 +573,90 @@ func (ps *parseStack) AssembleSaveState() {
 	ps.Push(&se)
 }
 
+// AssembleLoadPlugin takes the topmost element from the stack,
+// assuming it is a component of a LOAD PLUGIN statement, and
+// replaces it by a single LoadPluginStmt element.
+//
+//  StringLiteral
+//   =>
+//  LoadPluginStmt{string}
+func (ps *parseStack) AssembleLoadPlugin() {
+	_path := ps.Pop()
+
+	path := _path.comp.(StringLiteral)
+
+	se := ParsedComponent{_path.begin, _path.end, LoadPluginStmt{path.Value}}
+	ps.Push(&se)
+}
+
+// AssembleSetLogLevel takes the topmost elements from the stack,
+// assuming they are components of a SET LOG LEVEL statement, and
+// replaces them by a single SetLogLevelStmt element.
+//
+//  StreamIdentifier
+//  SettingTargetType
+//  Identifier
+//   =>
+//  SetLogLevelStmt{string, SettingTarget}
+func (ps *parseStack) AssembleSetLogLevel() {
+	// pop the components from the stack in reverse order
+	_name, _type, _level := ps.pop3()
+
+	name := _name.comp.(StreamIdentifier)
+	targetType := _type.comp.(SettingTargetType)
+	level := _level.comp.(Identifier)
+
+	se := ParsedComponent{_level.begin, _name.end, SetLogLevelStmt{
+		string(level), SettingTarget{targetType, name}}}
+	ps.Push(&se)
+}
+
+// AssembleSetTrace takes the topmost elements from the stack,
+// assuming they are components of a SET TRACE statement, and
+// replaces them by a single SetTraceStmt element.
+//
+//  StreamIdentifier
+//  SettingTargetType
+//  BinaryKeyword
+//   =>
+//  SetTraceStmt{BinaryKeyword, SettingTarget}
+func (ps *parseStack) AssembleSetTrace() {
+	// pop the components from the stack in reverse order
+	_name, _type, _enabled := ps.pop3()
+
+	name := _name.comp.(StreamIdentifier)
+	targetType := _type.comp.(SettingTargetType)
+	enabled := _enabled.comp.(BinaryKeyword)
+
+	se := ParsedComponent{_enabled.begin, _name.end, SetTraceStmt{
+		enabled, SettingTarget{targetType, name}}}
+	ps.Push(&se)
+}
+
+// AssembleSetRecoveryPolicy takes the topmost elements from the stack,
+// assuming they are components of a SET RECOVERY POLICY statement, and
+// replaces them by a single SetRecoveryPolicyStmt element.
+//
+//  SourceSinkSpecsAST
+//  StreamIdentifier
+//  SettingTargetType
+//  Identifier
+//   =>
+//  SetRecoveryPolicyStmt{Identifier, SettingTarget, SourceSinkSpecsAST}
+func (ps *parseStack) AssembleSetRecoveryPolicy() {
+	// pop the components from the stack in reverse order
+	_specs, _name, _type, _policy := ps.pop4()
+
+	specs := _specs.comp.(SourceSinkSpecsAST)
+	name := _name.comp.(StreamIdentifier)
+	targetType := _type.comp.(SettingTargetType)
+	policy := _policy.comp.(Identifier)
+
+	se := ParsedComponent{_policy.begin, _specs.end, SetRecoveryPolicyStmt{
+		policy, SettingTarget{targetType, name}, specs}}
+	ps.Push(&se)
+}
+
 // AssembleEval takes the topmost one or two elements from the
 // stack, assuming they are components of an EVAL statement, and
 // replaces them by a single EvalStmt element.
@@ -529,6 +693,33 @@ func (ps *parseStack) AssembleEval(begin, end int) {
 	ps.Push(&se)
 }
 
+// AssembleShow takes the topmost elements from the stack, assuming
+// they are components of a SHOW statement, and replaces them by
+// a single ShowStmt element.
+//
+//  ShowTargetType
+//  StreamIdentifier (optional)
+//   =>
+//  ShowStmt{ShowTargetType, StreamIdentifier}
+func (ps *parseStack) AssembleShow(begin, end int) {
+	var _target *ParsedComponent
+	var topology StreamIdentifier
+
+	// pop a different number of items depending on whether we have the
+	// optional IN clause or not
+	if begin == end {
+		_target = ps.Pop()
+	} else {
+		var _topology *ParsedComponent
+		_topology, _target = ps.pop2()
+		topology = _topology.comp.(StreamIdentifier)
+	}
+	target := _target.comp.(ShowTargetType)
+
+	se := ParsedComponent{_target.begin, end, ShowStmt{target, topology}}
+	ps.Push(&se)
+}
+
 /* Projections/Columns */
 
 // AssembleEmitter takes the topmost elements from the stack, assuming
@@ -605,6 +796,27 @@ func (ps *parseStack) AssembleEmitterSampling(samplingType EmitterSamplingType,
 	ps.PushComponent(_value.begin, _value.end, EmitterSampling{value * factor, samplingType})
 }
 
+// AssembleEmitterChange takes the elements from the stack that
+// correspond to the input[begin:end] string, assuming they are keys of
+// a emitter WHEN CHANGED option, and replaces them by a single
+// EmitterChange element.
+//
+//  Identifier
+//  Identifier
+//   =>
+//  EmitterChange{[Identifier, Identifier]}
+func (ps *parseStack) AssembleEmitterChange(begin int, end int) {
+	elems := ps.collectElements(begin, end)
+	var keys []string
+	if len(elems) > 0 {
+		keys = make([]string, len(elems))
+	}
+	for i := range elems {
+		keys[i] = string(elems[i].(Identifier))
+	}
+	ps.PushComponent(begin, end, EmitterChange{keys})
+}
+
 // AssembleProjections takes the elements from the stack that
 // correspond to the input[begin:end] string and wraps a
 // ProjectionsAST struct around them.
@@ -778,6 +990,29 @@ func (ps *parseStack) AssembleHaving(begin int, end int) {
 	}
 }
 
+// AssembleInputSampling takes the literal on top of the stack
+// (if there is a SAMPLE clause) and wraps an InputSamplingAST struct
+// around it. If there is no SAMPLE clause, an empty InputSamplingAST
+// struct is used.
+//
+//  NumericLiteral/FloatLiteral
+//   =>
+//  InputSamplingAST{NumericLiteral/FloatLiteral}
+func (ps *parseStack) AssembleInputSampling(begin int, end int) {
+	if begin == end {
+		// push an empty sampling clause
+		ps.PushComponent(begin, end, InputSamplingAST{})
+	} else {
+		// if the stack is empty at this point, this is
+		// a serious parser bug
+		v := ps.Pop()
+		if begin > v.begin || end < v.end {
+			panic("the item on top of the stack is not within given range")
+		}
+		ps.PushComponent(begin, end, InputSamplingAST{v.comp.(Expression)})
+	}
+}
+
 // AssembleAliasedStreamWindow takes the topmost elements from the stack, assuming
 // they are components of an AS clause, and replaces them by
 // a single AliasedStreamWindowAST element.
@@ -825,15 +1060,17 @@ func (ps *parseStack) EnsureAliasedStreamWindow() {
 //  StreamWindowAST{Stream, IntervalAST}
 func (ps *parseStack) AssembleStreamWindow() {
 	// pop the components from the stack in reverse order
-	_shedding, _capacity, _range, _rel := ps.pop4()
+	_partition, _pipe, _shedding, _capacity, _range, _rel := ps.pop6()
 
 	rel := _rel.comp.(Stream)
 	rangeAst := _range.comp.(IntervalAST)
 	capacity := _capacity.comp.(NumericLiteral)
 	shedding := _shedding.comp.(SheddingOption)
+	pipe := _pipe.comp.(PipeOption)
+	partition := _partition.comp.(*PartitionAST)
 
-	ps.PushComponent(_rel.begin, _shedding.end, StreamWindowAST{rel, rangeAst,
-		capacity.Value, shedding})
+	ps.PushComponent(_rel.begin, _partition.end, StreamWindowAST{rel, rangeAst,
+		capacity.Value, shedding, pipe, partition})
 }
 
 // AssembleUDSFFuncApp takes the topmost elements from the stack,
@@ -887,6 +1124,59 @@ func (ps *parseStack) EnsureSheddingSpec(begin int, end int) {
 	}
 }
 
+// EnsurePipeSpec makes sure that the top element of the stack
+// is a PipeOption element.
+func (ps *parseStack) EnsurePipeSpec(begin int, end int) {
+	top := ps.Peek()
+	if top == nil || top.end <= begin {
+		// there is no item in the given range
+		ps.PushComponent(begin, end, UnspecifiedPipeOption)
+	} else {
+		// there is an item in the given range
+		_, ok := top.comp.(PipeOption)
+		if !ok {
+			panic(fmt.Sprintf("begin (%d) != end (%d), but there "+
+				"was a %T on the stack", begin, end, top.comp))
+		}
+	}
+}
+
+// EnsurePartitionSpec makes sure that the top element of the stack
+// is a *PartitionAST element. It's nil when there's no PARTITION BY clause.
+func (ps *parseStack) EnsurePartitionSpec(begin int, end int) {
+	top := ps.Peek()
+	if top == nil || top.end <= begin {
+		// there is no item in the given range
+		ps.PushComponent(begin, end, (*PartitionAST)(nil))
+	} else {
+		// there is an item in the given range
+		_, ok := top.comp.(*PartitionAST)
+		if !ok {
+			panic(fmt.Sprintf("begin (%d) != end (%d), but there "+
+				"was a %T on the stack", begin, end, top.comp))
+		}
+	}
+}
+
+// AssemblePartitionSpec takes the topmost elements from the stack, assuming
+// they are components of a PARTITION BY clause, and replaces them by
+// a single *PartitionAST element.
+//
+//  NumericLiteral
+//  NumericLiteral
+//  Any
+//   =>
+//  *PartitionAST{Any, NumericLiteral, NumericLiteral}
+func (ps *parseStack) AssemblePartitionSpec() {
+	_count, _index, _expr := ps.pop3()
+
+	expr := _expr.comp.(Expression)
+	index := _index.comp.(NumericLiteral)
+	count := _count.comp.(NumericLiteral)
+
+	ps.PushComponent(_expr.begin, _count.end, &PartitionAST{expr, index.Value, count.Value})
+}
+
 // AssembleSourceSinkSpecs takes the elements from the stack that
 // correspond to the input[begin:end] string, makes sure
 // they are all SourceSinkParamAST elements and wraps a SourceSinkSpecsAST
@@ -940,6 +1230,9 @@ func (ps *parseStack) AssembleSourceSinkParam() {
 			value = data.Int(lit.Value)
 		case FloatLiteral:
 			value = data.Float(lit.Value)
+		case Placeholder:
+			// the actual value is given when the statement is executed
+			value = data.Null{}
 		case ArrayAST:
 			arr := make(data.Array, len(lit.Expressions))
 			for i, item := range lit.Expressions {
@@ -1074,7 +1367,7 @@ func (ps *parseStack) AssembleUnaryPrefixOperation(begin int, end int) {
 	}
 }
 
-// AssembleTypeCast takes the two elements from the stack that
+// AssembleTypeCast takes the two or three elements from the stack that
 // correspond to the input[begin:end] string and replaces them by
 // a single TypeCastAST element. If there is just one element, push
 // it back unmodified.
@@ -1087,6 +1380,12 @@ func (ps *parseStack) AssembleUnaryPrefixOperation(begin int, end int) {
 //  Type
 //   =>
 //  AssembleTypeCastAST{Any, Type}
+// or
+//  Any
+//  Type
+//  StringLiteral
+//   =>
+//  AssembleTypeCastAST{Any, Type, StringLiteral.Value}
 func (ps *parseStack) AssembleTypeCast(begin int, end int) {
 
 	elems := ps.collectElements(begin, end)
@@ -1096,12 +1395,28 @@ func (ps *parseStack) AssembleTypeCast(begin int, end int) {
 	} else if len(elems) == 2 {
 		target := elems[1].(Type)
 		// connect the expression with the given operator
-		ps.PushComponent(begin, end, TypeCastAST{elems[0].(Expression), target})
+		ps.PushComponent(begin, end, TypeCastAST{elems[0].(Expression), target, ""})
+	} else if len(elems) == 3 {
+		target := elems[1].(Type)
+		format := elems[2].(StringLiteral)
+		ps.PushComponent(begin, end, TypeCastAST{elems[0].(Expression), target, format.Value})
 	} else {
 		panic(fmt.Sprintf("cannot turn %+v into a type cast", elems))
 	}
 }
 
+// AssembleRowMetadata takes the topmost element from the stack, assuming
+// it is the key of a meta function, and replaces it by a RowMetadata element.
+// s is the whole text of the function application.
+//
+//  StringLiteral
+//   =>
+//  RowMetadata{Relation, StringLiteral.Value}
+func (ps *parseStack) AssembleRowMetadata(begin int, end int, s string) {
+	key := ps.Pop().comp.(StringLiteral)
+	ps.PushComponent(begin, end, NewRowMetadata(s, key.Value))
+}
+
 // AssembleFuncApp takes the topmost elements from the stack, assuming
 // they are components of a function application clause, and replaces
 // them by a single FuncAppAST element.
@@ -1132,21 +1447,61 @@ func (ps *parseStack) AssembleFuncApp() {
 	ps.PushComponent(_funcName.begin, _exprs.end, FuncAppAST{funcName, exprs, orderExprs})
 }
 
+// AssembleWindowFuncApp takes the topmost elements from the stack,
+// assuming they are components of a function application having an
+// OVER clause, and replaces them by a single WindowFuncAppAST element.
+// When the range is empty, i.e., the function application doesn't
+// have an OVER clause, the stack isn't modified.
+//
+//  FuncAppAST
+//  ExpressionsAST (PARTITION BY)
+//  ExpressionsAST (ORDER BY)
+//   =>
+//  WindowFuncAppAST{FuncAppAST, []Expression, []SortedExpressionAST}
+func (ps *parseStack) AssembleWindowFuncApp(begin int, end int) {
+	if begin == end {
+		return
+	}
+	_ordering, _partition, _funcApp := ps.pop3()
+
+	// extract and convert the contained structure
+	// (if this fails, this is a fundamental parser bug => panic ok)
+	ordering := _ordering.comp.(ExpressionsAST)
+	partition := _partition.comp.(ExpressionsAST)
+	funcApp := _funcApp.comp.(FuncAppAST)
+
+	var orderExprs []SortedExpressionAST
+	if len(ordering.Expressions) > 0 {
+		orderExprs = make([]SortedExpressionAST, len(ordering.Expressions))
+		for i, e := range ordering.Expressions {
+			orderExprs[i] = e.(SortedExpressionAST)
+		}
+	}
+	var partitionExprs []Expression
+	if len(partition.Expressions) > 0 {
+		partitionExprs = partition.Expressions
+	}
+
+	ps.PushComponent(_funcApp.begin, end, WindowFuncAppAST{funcApp, partitionExprs, orderExprs})
+}
+
 // AssembleSortedExpression takes the topmost elements from the stack,
 // assuming they are components of an ORDER BY clause, and replaces
 // them by a single SortedExpressionAST element.
 //
-//  BinaryKeyword
+//  BinaryKeyword (NULLS FIRST/LAST)
+//  BinaryKeyword (ASC/DESC)
 //  Expression
 //   =>
-//  SortedExpressionAST{Expression, BinaryKeyword}
+//  SortedExpressionAST{Expression, BinaryKeyword, BinaryKeyword}
 func (ps *parseStack) AssembleSortedExpression() {
-	_sortOrder, _expr := ps.pop2()
+	_nullsOrder, _sortOrder, _expr := ps.pop3()
 
 	expr := _expr.comp.(Expression)
 	sortOrder := _sortOrder.comp.(BinaryKeyword)
+	nullsOrder := _nullsOrder.comp.(BinaryKeyword)
 
-	ps.PushComponent(_expr.begin, _sortOrder.end, SortedExpressionAST{expr, sortOrder})
+	ps.PushComponent(_expr.begin, _nullsOrder.end, SortedExpressionAST{expr, sortOrder, nullsOrder})
 }
 
 // AssembleArray takes the topmost elements from the stack, assuming
@@ -1323,6 +1678,21 @@ func (ps *parseStack) PushComponent(begin int, end int, comp interface{}) {
 	ps.Push(&se)
 }
 
+// AssemblePlaceholder pushes a Placeholder created from the given string
+// to the top of the stack and records it. It panics when the statement
+// isn't parsed as a prepared statement.
+func (ps *parseStack) AssemblePlaceholder(begin int, end int, s string) {
+	if !ps.allowPlaceholders {
+		panic(fmt.Sprintf("placeholder '%v' can only be used in a prepared statement", s))
+	}
+	p := Placeholder{}
+	if strings.HasPrefix(s, ":") {
+		p.Name = s[1:]
+	}
+	ps.PushComponent(begin, end, p)
+	ps.placeholders = append(ps.placeholders, ParsedComponent{begin, end, p})
+}
+
 // IncludeTrailingWhitespace updates the `end` value of the top of
 // the stack to match the given `end` parameter. This is required
 // so that we "eat" trailing comments and do not try to parse them
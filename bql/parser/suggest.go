@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"strings"
+)
+
+// keywords has reserved words of BQL used to suggest a correct keyword for
+// a misspelled one.
+var keywords = []string{
+	"ALL", "AND", "ARRAY", "AS", "ASC", "BLOB", "BOOL", "BOX", "BUFFER", "BY",
+	"CASCADE", "CASE", "CAST", "CHANGED", "CHANNEL", "CREATE", "DESC",
+	"DISTINCT", "DROP", "DSTREAM", "ELSE", "EMIT", "END", "EVAL", "EVERY",
+	"EVICT", "EXISTS", "FALSE", "FIRST", "FLOAT", "FOR", "FORMAT", "FROM",
+	"FULL", "GROUP", "HAVING", "IF", "IN", "INSERT", "INSTANCE", "INT", "INTO",
+	"IS", "ISTREAM", "LAST", "LEVEL", "LIMIT", "LOAD", "LOG", "MAP",
+	"MILLISECONDS", "MISSING", "NEWEST", "NODE", "NOT", "NULL", "NULLS", "OF",
+	"OFF", "OLDEST", "ON", "OR", "ORDER", "OVER", "PARTITION", "PAUSE",
+	"PAUSED", "PERCENT", "PIPE", "PLUGIN", "POLICY", "RANGE", "RECOVERY",
+	"REPLACE", "RESUME", "REWIND", "RING", "RSTREAM", "SAMPLE", "SAVE", "SAVED",
+	"SECONDS", "SELECT", "SET", "SHOW", "SINK", "SINKS", "SIZE", "SOURCE",
+	"SOURCES", "STATE", "STATES", "STREAM", "STREAMS", "STRING", "TAG", "THEN",
+	"TIMESTAMP", "TOPOLOGY", "TRACE", "TRUE", "TUPLE", "TUPLES", "TYPE", "UDFS",
+	"UNION", "UNPAUSED", "UPDATE", "VECTOR", "WAIT", "WHEN", "WHERE", "WITH",
+}
+
+// SuggestName returns the candidate which is the most similar to the given
+// name, e.g. "STREAM" for "STRAEM". Names are compared case-insensitively.
+// It returns an empty string when the name is one of the candidates or no
+// candidate is similar enough. When candidates have the same similarity, the
+// one whose length is the closest to the name's is returned.
+func SuggestName(name string, candidates []string) string {
+	lowerName := strings.ToLower(name)
+	n := len([]rune(name))
+	maxDist := 1
+	if n > 4 {
+		maxDist = 2
+	}
+
+	abs := func(x int) int {
+		if x < 0 {
+			return -x
+		}
+		return x
+	}
+	best, bestDist, bestLenDiff := "", maxDist+1, 0
+	for _, c := range candidates {
+		d := editDistance(lowerName, strings.ToLower(c))
+		if d == 0 {
+			return ""
+		}
+		lenDiff := abs(len([]rune(c)) - n)
+		if d < bestDist || (d == bestDist && lenDiff < bestLenDiff) {
+			best, bestDist, bestLenDiff = c, d, lenDiff
+		}
+	}
+	return best
+}
+
+// suggestKeyword returns the keyword similar to the given word. The
+// keyword is returned in lower case when the word is in lower case.
+func suggestKeyword(word string) string {
+	k := SuggestName(word, keywords)
+	if word == strings.ToLower(word) {
+		return strings.ToLower(k)
+	}
+	return k
+}
+
+// editDistance returns the number of insertions, deletions, substitutions,
+// and transpositions of adjacent characters needed to change a into b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	min := func(a, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(min(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
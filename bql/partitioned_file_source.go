@@ -0,0 +1,165 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// partitionedFileSource is a core.PartitionedSource reading files matching a
+// glob pattern. It's created by the file source having 'readers' parameter,
+// which is the initial number of readers. Each file is a partition and the
+// number of readers can be changed by updating 'readers' parameter.
+//
+// Each reader reports the number of bytes of its files which haven't been
+// read yet as its lag. Files are read only once, so 'repeat' parameter isn't
+// supported. 'rewindable' isn't supported, either.
+type partitionedFileSource struct {
+	pattern string
+
+	// base is the template of sources reading each file.
+	base readerSource
+
+	m        sync.Mutex
+	progress map[string]*fileProgress
+}
+
+// fileProgress is the position of a file read by a partitionedFileSource.
+// Fields are accessed atomically.
+type fileProgress struct {
+	// records is the number of records written.
+	records int64
+
+	// bytes is the number of bytes read by the current reader.
+	bytes int64
+
+	// done is 1 when all records in the file have been written.
+	done int32
+}
+
+func newPartitionedFileSource(pattern string, base *readerSource, readers int, rewindable bool) (core.Source, error) {
+	if rewindable {
+		return nil, errors.New("'rewindable' parameter cannot be true when 'readers' is given")
+	}
+	if base.repeat != 0 {
+		return nil, errors.New("'repeat' parameter cannot be used when 'readers' is given")
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("'path' parameter must be a valid glob pattern: %v", err)
+	}
+	s := &partitionedFileSource{
+		pattern:  pattern,
+		base:     *base,
+		progress: map[string]*fileProgress{},
+	}
+	return core.NewParallelSource(s, readers)
+}
+
+func (s *partitionedFileSource) Partitions(ctx *core.Context) ([]string, error) {
+	return filepath.Glob(s.pattern)
+}
+
+func (s *partitionedFileSource) NewReader(ctx *core.Context, partitions []string) (core.Source, error) {
+	r := &partitionedFileReader{
+		stopCh: make(chan struct{}),
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, p := range partitions {
+		prog, ok := s.progress[p]
+		if !ok {
+			prog = &fileProgress{}
+			s.progress[p] = prog
+		}
+		rs := s.base
+		rs.filename = p
+		rs.stopCh = r.stopCh
+		rs.progress = prog
+		r.files = append(r.files, &rs)
+	}
+	return r, nil
+}
+
+// partitionedFileReader reads files assigned by a partitionedFileSource one
+// by one.
+type partitionedFileReader struct {
+	files  []*readerSource
+	stopCh chan struct{}
+
+	// m is held while writing a tuple so that no tuple is written after Stop
+	// returns.
+	m       sync.RWMutex
+	stopped bool
+}
+
+func (r *partitionedFileReader) GenerateStream(ctx *core.Context, w core.Writer) error {
+	sw := core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+		r.m.RLock()
+		defer r.m.RUnlock()
+		if r.stopped {
+			return core.ErrSourceStopped
+		}
+		return w.Write(ctx, t)
+	})
+
+	for _, f := range r.files {
+		if atomic.LoadInt32(&f.progress.done) != 0 {
+			continue
+		}
+		if err := f.generateStream(ctx, sw); err != nil {
+			if err == core.ErrSourceStopped {
+				return nil
+			}
+			return fmt.Errorf("cannot read '%v': %v", f.filename, err)
+		}
+		atomic.StoreInt32(&f.progress.done, 1)
+	}
+	return nil
+}
+
+func (r *partitionedFileReader) Stop(ctx *core.Context) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.stopCh)
+	}
+	return nil
+}
+
+// Lag returns the number of bytes of files which haven't been read yet.
+func (r *partitionedFileReader) Lag(ctx *core.Context) (int64, error) {
+	var lag int64
+	for _, f := range r.files {
+		if atomic.LoadInt32(&f.progress.done) != 0 {
+			continue
+		}
+		st, err := os.Stat(f.filename)
+		if err != nil {
+			return 0, err
+		}
+		if l := st.Size() - atomic.LoadInt64(&f.progress.bytes); l > 0 {
+			lag += l
+		}
+	}
+	return lag, nil
+}
+
+// progressReadCloser records the number of bytes read from the file.
+type progressReadCloser struct {
+	io.ReadCloser
+	p    *fileProgress
+	read int64
+}
+
+func (r *progressReadCloser) Read(b []byte) (int, error) {
+	n, err := r.ReadCloser.Read(b)
+	r.read += int64(n)
+	atomic.StoreInt64(&r.p.bytes, r.read)
+	return n, err
+}
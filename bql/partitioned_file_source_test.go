@@ -0,0 +1,131 @@
+package bql
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestPartitionedFileSource(t *testing.T) {
+	Convey("Given files matching a glob pattern", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_bql_partitioned_file_source")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		for i := 0; i < 3; i++ {
+			So(ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("in%v.jsonl", i)),
+				[]byte(fmt.Sprintf("{\"file\":%v,\"int\":1}\n{\"file\":%v,\"int\":2}\n", i, i)), 0600), ShouldBeNil)
+		}
+		So(ioutil.WriteFile(filepath.Join(dir, "other.txt"), []byte("{}\n"), 0600), ShouldBeNil)
+		pattern := filepath.Join(dir, "*.jsonl")
+		w := &testFileWriter{}
+		w.c = sync.NewCond(&w.m)
+
+		Convey("When reading them by a file source having readers", func() {
+			s, err := createFileSource(ctx, &IOParams{}, data.Map{
+				"path":    data.String(pattern),
+				"readers": data.Int(2),
+			})
+			So(err, ShouldBeNil)
+			err = s.GenerateStream(ctx, w)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should emit all tuples in the files", func() {
+				So(w.cnt, ShouldEqual, 6)
+			})
+
+			Convey("Then it should be a parallel source", func() {
+				ps, ok := s.(core.ParallelSource)
+				So(ok, ShouldBeTrue)
+				So(ps.Readers(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a reader of a file is stopped in the middle of the file", func() {
+			ps := &partitionedFileSource{
+				pattern:  pattern,
+				base:     readerSource{ioParams: &IOParams{}},
+				progress: map[string]*fileProgress{},
+			}
+			parts, err := ps.Partitions(ctx)
+			So(err, ShouldBeNil)
+			So(parts, ShouldHaveLength, 3)
+
+			r, err := ps.NewReader(ctx, parts[:1])
+			So(err, ShouldBeNil)
+			So(r.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				if w.cnt == 1 {
+					return core.ErrSourceStopped
+				}
+				return w.Write(ctx, t)
+			})), ShouldBeNil)
+			So(w.cnt, ShouldEqual, 1)
+
+			Convey("Then a reader of a file not read yet should report its size as the lag", func() {
+				r, err := ps.NewReader(ctx, parts[1:2])
+				So(err, ShouldBeNil)
+				st, err := os.Stat(parts[1])
+				So(err, ShouldBeNil)
+				lag, err := r.(core.LagReporter).Lag(ctx)
+				So(err, ShouldBeNil)
+				So(lag, ShouldEqual, st.Size())
+			})
+
+			Convey("Then a new reader should resume reading the file", func() {
+				r, err := ps.NewReader(ctx, parts[:1])
+				So(err, ShouldBeNil)
+				So(r.GenerateStream(ctx, w), ShouldBeNil)
+				So(w.cnt, ShouldEqual, 2)
+
+				lag, err := r.(core.LagReporter).Lag(ctx)
+				So(err, ShouldBeNil)
+				So(lag, ShouldEqual, 0)
+
+				Convey("And the file shouldn't be read again", func() {
+					r, err := ps.NewReader(ctx, parts[:1])
+					So(err, ShouldBeNil)
+					So(r.GenerateStream(ctx, w), ShouldBeNil)
+					So(w.cnt, ShouldEqual, 2)
+				})
+			})
+		})
+
+		Convey("When creating a file source having invalid parameters", func() {
+			cases := map[string]data.Map{
+				"zero readers": data.Map{
+					"readers": data.Int(0),
+				},
+				"rewindable": data.Map{
+					"readers":    data.Int(2),
+					"rewindable": data.True,
+				},
+				"repeat": data.Map{
+					"readers": data.Int(2),
+					"repeat":  data.Int(1),
+				},
+				"a malformed pattern": data.Map{
+					"readers": data.Int(2),
+					"path":    data.String(filepath.Join(dir, "[")),
+				},
+			}
+			for title, params := range cases {
+				if _, ok := params["path"]; !ok {
+					params["path"] = data.String(pattern)
+				}
+				_, err := createFileSource(ctx, &IOParams{}, params)
+
+				Convey("Then it should fail with "+title, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,295 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"path/filepath"
+	"plugin"
+	"sort"
+	"sync"
+)
+
+// PluginSymbol is the name of the symbol which a plugin must export.
+const PluginSymbol = "SensorBeePlugin"
+
+// Plugin is an extension of SensorBee loaded at runtime by LOAD PLUGIN
+// statements. It provides sources, sinks, boxes, UDSFs, UDSs, and UDFs without
+// recompiling the server.
+//
+// A plugin is a Go package built with `go build -buildmode=plugin`. It must
+// export a variable or a function returning a Plugin named PluginSymbol:
+//
+//	package main
+//
+//	var SensorBeePlugin bql.Plugin = &myPlugin{}
+//
+// The plugin must be built with the same version of Go and SensorBee as the
+// server. A plugin file is loaded only once in a process and shared by all
+// topologies, but its components are registered separately to each topology
+// loading it.
+//
+// A file having the .wasm extension is loaded as a sandboxed WebAssembly
+// module instead, which doesn't have the restriction above. See
+// SetWASMRuntime.
+type Plugin interface {
+	// Register registers components of the plugin through the registrar.
+	// It's called every time a topology loads the plugin.
+	Register(r *PluginRegistrar) error
+}
+
+// PluginUnloader is implemented by a Plugin which needs to clean up when it's
+// unloaded from a topology.
+type PluginUnloader interface {
+	// Unload is called after components registered by the plugin are
+	// removed from the topology.
+	Unload(ctx *core.Context) error
+}
+
+// PluginRegistrar registers components of a plugin to a TopologyBuilder and
+// records them so that they can be removed when the plugin is unloaded.
+type PluginRegistrar struct {
+	tb      *TopologyBuilder
+	sources []string
+	sinks   []string
+	boxes   []string
+	udsfs   []string
+	udss    []string
+	udfs    []string
+}
+
+// Context returns the context of the topology loading the plugin.
+func (r *PluginRegistrar) Context() *core.Context {
+	return r.tb.topology.Context()
+}
+
+// RegisterSourceCreator registers a SourceCreator.
+func (r *PluginRegistrar) RegisterSourceCreator(typeName string, c SourceCreator) error {
+	if err := r.tb.SourceCreators.Register(typeName, c); err != nil {
+		return err
+	}
+	r.sources = append(r.sources, typeName)
+	return nil
+}
+
+// RegisterSinkCreator registers a SinkCreator.
+func (r *PluginRegistrar) RegisterSinkCreator(typeName string, c SinkCreator) error {
+	if err := r.tb.SinkCreators.Register(typeName, c); err != nil {
+		return err
+	}
+	r.sinks = append(r.sinks, typeName)
+	return nil
+}
+
+// RegisterBoxCreator registers a BoxCreator.
+func (r *PluginRegistrar) RegisterBoxCreator(typeName string, c BoxCreator) error {
+	if err := r.tb.BoxCreators.Register(typeName, c); err != nil {
+		return err
+	}
+	r.boxes = append(r.boxes, typeName)
+	return nil
+}
+
+// RegisterUDSFCreator registers a UDSFCreator.
+func (r *PluginRegistrar) RegisterUDSFCreator(typeName string, c udf.UDSFCreator) error {
+	if err := r.tb.UDSFCreators.Register(typeName, c); err != nil {
+		return err
+	}
+	r.udsfs = append(r.udsfs, typeName)
+	return nil
+}
+
+// RegisterUDSCreator registers a UDSCreator.
+func (r *PluginRegistrar) RegisterUDSCreator(typeName string, c udf.UDSCreator) error {
+	if err := r.tb.UDSCreators.Register(typeName, c); err != nil {
+		return err
+	}
+	r.udss = append(r.udss, typeName)
+	return nil
+}
+
+// RegisterUDF registers a UDF.
+func (r *PluginRegistrar) RegisterUDF(name string, f udf.UDF) error {
+	if err := r.tb.Reg.Register(name, f); err != nil {
+		return err
+	}
+	r.udfs = append(r.udfs, name)
+	return nil
+}
+
+// unregister removes all components registered through the registrar. It
+// continues even if some of them cannot be removed and returns the first
+// error.
+func (r *PluginRegistrar) unregister() error {
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, n := range r.sources {
+		keep(r.tb.SourceCreators.Unregister(n))
+	}
+	for _, n := range r.sinks {
+		keep(r.tb.SinkCreators.Unregister(n))
+	}
+	for _, n := range r.boxes {
+		keep(r.tb.BoxCreators.Unregister(n))
+	}
+	for _, n := range r.udsfs {
+		keep(r.tb.UDSFCreators.Unregister(n))
+	}
+	for _, n := range r.udss {
+		keep(r.tb.UDSCreators.Unregister(n))
+	}
+	if u, ok := r.tb.Reg.(interface {
+		Unregister(name string) error
+	}); ok {
+		for _, n := range r.udfs {
+			keep(u.Unregister(n))
+		}
+	} else if len(r.udfs) > 0 {
+		keep(errors.New("UDFs registered by the plugin cannot be removed from the function registry"))
+	}
+	return firstErr
+}
+
+var (
+	pluginsMutex sync.Mutex
+
+	// plugins has plugins already opened in this process keyed by their
+	// absolute paths. Go plugins cannot be closed once they're opened.
+	plugins = map[string]Plugin{}
+
+	// openPlugin opens a plugin file. It can be replaced in tests.
+	openPlugin = openGoPlugin
+)
+
+func openGoPlugin(path string) (Plugin, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	switch s := sym.(type) {
+	case *Plugin:
+		return *s, nil
+	case Plugin:
+		return s, nil
+	case func() Plugin:
+		return s(), nil
+	default:
+		return nil, fmt.Errorf("%v has an unsupported type: %T", PluginSymbol, sym)
+	}
+}
+
+// OpenPlugin opens a plugin file and returns the Plugin it exports. A file
+// is opened only once and the same Plugin is returned for the same path.
+func OpenPlugin(path string) (Plugin, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pluginsMutex.Lock()
+	defer pluginsMutex.Unlock()
+	if p, ok := plugins[abs]; ok {
+		return p, nil
+	}
+	var p Plugin
+	if isWASMPlugin(abs) {
+		p, err = openWASMPlugin(abs)
+	} else {
+		p, err = openPlugin(abs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the plugin %v: %v", path, err)
+	}
+	if p == nil {
+		return nil, fmt.Errorf("the plugin %v doesn't provide %v", path, PluginSymbol)
+	}
+	plugins[abs] = p
+	return p, nil
+}
+
+type loadedPlugin struct {
+	plugin    Plugin
+	registrar *PluginRegistrar
+}
+
+// LoadPlugin opens a plugin file and registers its components to the
+// builder. It does nothing when the plugin is already loaded by the builder.
+func (tb *TopologyBuilder) LoadPlugin(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	p, err := OpenPlugin(abs)
+	if err != nil {
+		return err
+	}
+
+	tb.pluginsMutex.Lock()
+	defer tb.pluginsMutex.Unlock()
+	if _, ok := tb.plugins[abs]; ok {
+		return nil
+	}
+	r := &PluginRegistrar{tb: tb}
+	if err := p.Register(r); err != nil {
+		if uerr := r.unregister(); uerr != nil {
+			tb.topology.Context().ErrLog(uerr).WithField("plugin", abs).
+				Error("Cannot remove components of the plugin which failed to register")
+		}
+		return fmt.Errorf("cannot register components of the plugin %v: %v", path, err)
+	}
+	if tb.plugins == nil {
+		tb.plugins = map[string]*loadedPlugin{}
+	}
+	tb.plugins[abs] = &loadedPlugin{
+		plugin:    p,
+		registrar: r,
+	}
+	return nil
+}
+
+// UnloadPlugin removes components registered by the plugin from the builder.
+// Nodes and states already created by the plugin aren't affected. The plugin
+// file itself stays in the process and can be loaded again.
+func (tb *TopologyBuilder) UnloadPlugin(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	tb.pluginsMutex.Lock()
+	defer tb.pluginsMutex.Unlock()
+	lp, ok := tb.plugins[abs]
+	if !ok {
+		return core.NotExistError(fmt.Errorf("the plugin %v isn't loaded", path))
+	}
+	delete(tb.plugins, abs)
+	if err := lp.registrar.unregister(); err != nil {
+		return err
+	}
+	if u, ok := lp.plugin.(PluginUnloader); ok {
+		return u.Unload(tb.topology.Context())
+	}
+	return nil
+}
+
+// Plugins returns absolute paths of plugins loaded by the builder in sorted
+// order.
+func (tb *TopologyBuilder) Plugins() []string {
+	tb.pluginsMutex.Lock()
+	defer tb.pluginsMutex.Unlock()
+	ps := make([]string, 0, len(tb.plugins))
+	for p := range tb.plugins {
+		ps = append(ps, p)
+	}
+	sort.Strings(ps)
+	return ps
+}
@@ -0,0 +1,166 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"path/filepath"
+	"testing"
+)
+
+type testPlugin struct {
+	fail     bool
+	unloaded int
+}
+
+func (p *testPlugin) Register(r *PluginRegistrar) error {
+	if err := r.RegisterSourceCreator("plugin_source", SourceCreatorFunc(createDummySource)); err != nil {
+		return err
+	}
+	if err := r.RegisterSinkCreator("plugin_sink", SinkCreatorFunc(createCollectorSink)); err != nil {
+		return err
+	}
+	if err := r.RegisterUDF("plugin_func", udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+		return v, nil
+	})); err != nil {
+		return err
+	}
+	if p.fail {
+		return errors.New("failure")
+	}
+	return nil
+}
+
+func (p *testPlugin) Unload(ctx *core.Context) error {
+	p.unloaded++
+	return nil
+}
+
+func TestPlugin(t *testing.T) {
+	Convey("Given a topology builder and plugin files", t, func() {
+		opened := map[string]int{}
+		files := map[string]Plugin{
+			"/plugins/good.so": &testPlugin{},
+			"/plugins/bad.so":  &testPlugin{fail: true},
+		}
+		openPlugin = func(path string) (Plugin, error) {
+			p, ok := files[path]
+			if !ok {
+				return nil, errors.New("no such file")
+			}
+			opened[path]++
+			return p, nil
+		}
+		Reset(func() {
+			openPlugin = openGoPlugin
+			pluginsMutex.Lock()
+			plugins = map[string]Plugin{}
+			pluginsMutex.Unlock()
+		})
+
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+
+		Convey("When loading a plugin by LOAD PLUGIN statement", func() {
+			So(addBQLToTopology(tb, `LOAD PLUGIN "/plugins/good.so";`), ShouldBeNil)
+
+			Convey("Then its components should be available", func() {
+				So(addBQLToTopology(tb, `
+					CREATE PAUSED SOURCE s TYPE plugin_source;
+					CREATE SINK k TYPE plugin_sink;
+					CREATE STREAM t AS SELECT RSTREAM plugin_func(int) AS i FROM s [RANGE 1 TUPLES];`), ShouldBeNil)
+			})
+
+			Convey("Then the builder should list the plugin", func() {
+				So(tb.Plugins(), ShouldResemble, []string{filepath.FromSlash("/plugins/good.so")})
+			})
+
+			Convey("Then loading it again should do nothing", func() {
+				So(addBQLToTopology(tb, `LOAD PLUGIN "/plugins/good.so";`), ShouldBeNil)
+				So(tb.Plugins(), ShouldHaveLength, 1)
+			})
+
+			Convey("Then another builder loading it should share the opened plugin", func() {
+				tp2 := newTestTopology()
+				defer tp2.Stop()
+				tb2, err := NewTopologyBuilder(tp2)
+				So(err, ShouldBeNil)
+				So(tb2.LoadPlugin("/plugins/good.so"), ShouldBeNil)
+				So(opened["/plugins/good.so"], ShouldEqual, 1)
+				_, err = tb2.SourceCreators.Lookup("plugin_source")
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then other builders shouldn't have its components", func() {
+				tp2 := newTestTopology()
+				defer tp2.Stop()
+				tb2, err := NewTopologyBuilder(tp2)
+				So(err, ShouldBeNil)
+				_, err = tb2.SourceCreators.Lookup("plugin_source")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("And unloading it", func() {
+				So(tb.UnloadPlugin("/plugins/good.so"), ShouldBeNil)
+
+				Convey("Then its components should be removed", func() {
+					_, err := tb.SourceCreators.Lookup("plugin_source")
+					So(core.IsNotExist(err), ShouldBeTrue)
+					_, err = tb.SinkCreators.Lookup("plugin_sink")
+					So(core.IsNotExist(err), ShouldBeTrue)
+					_, err = tb.Reg.Lookup("plugin_func", 1)
+					So(core.IsNotExist(err), ShouldBeTrue)
+					So(tb.Plugins(), ShouldBeEmpty)
+				})
+
+				Convey("Then the plugin should be notified", func() {
+					So(files["/plugins/good.so"].(*testPlugin).unloaded, ShouldEqual, 1)
+				})
+
+				Convey("Then it can be loaded again", func() {
+					So(tb.LoadPlugin("/plugins/good.so"), ShouldBeNil)
+					_, err := tb.SourceCreators.Lookup("plugin_source")
+					So(err, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("When loading a plugin failing to register", func() {
+			err := addBQLToTopology(tb, `LOAD PLUGIN "/plugins/bad.so";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then components registered before the failure should be removed", func() {
+				_, err := tb.SourceCreators.Lookup("plugin_source")
+				So(core.IsNotExist(err), ShouldBeTrue)
+				_, err = tb.Reg.Lookup("plugin_func", 1)
+				So(core.IsNotExist(err), ShouldBeTrue)
+				So(tb.Plugins(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When loading a missing plugin", func() {
+			err := tb.LoadPlugin("/plugins/missing.so")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When unloading a plugin which isn't loaded", func() {
+			err := tb.UnloadPlugin("/plugins/good.so")
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
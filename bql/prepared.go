@@ -0,0 +1,68 @@
+package bql
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// PreparedStatement is a BQL statement having positional (`?`) or named
+// (`:name`) placeholders. It's parsed once and can be bound to different
+// values many times, e.g. to update a sink for each device:
+//
+//	ps, err := bql.PrepareStatement(`UPDATE SINK alert SET device_id = :id, threshold = :threshold;`)
+//	...
+//	stmt, err := ps.BindNamed(data.Map{"id": data.String(id), "threshold": data.Float(t)})
+//	...
+//	_, err = tb.AddStmt(stmt)
+//
+// Placeholders can only be used where a literal can be written, so names of
+// streams or types cannot be placeholders. Bound values are embedded as
+// literals so that they can't change the structure of the statement.
+type PreparedStatement struct {
+	stmt *parser.PreparedStmt
+}
+
+// PrepareStatement parses a single BQL statement having placeholders.
+func PrepareStatement(s string) (*PreparedStatement, error) {
+	stmt, err := parser.New().Prepare(s)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedStatement{
+		stmt: stmt,
+	}, nil
+}
+
+// String returns the statement having placeholders.
+func (p *PreparedStatement) String() string {
+	return p.stmt.String()
+}
+
+// Bind binds the given values to positional placeholders in order and
+// returns the parsed statement which can be passed to
+// TopologyBuilder.AddStmt.
+func (p *PreparedStatement) Bind(args ...data.Value) (interface{}, error) {
+	s, err := p.stmt.Bind(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseBoundStmt(s)
+}
+
+// BindNamed binds values in args to named placeholders having the same
+// names and returns the parsed statement.
+func (p *PreparedStatement) BindNamed(args data.Map) (interface{}, error) {
+	s, err := p.stmt.BindNamed(args)
+	if err != nil {
+		return nil, err
+	}
+	return parseBoundStmt(s)
+}
+
+func parseBoundStmt(s string) (interface{}, error) {
+	stmt, _, err := parser.New().ParseStmt(s)
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
@@ -0,0 +1,66 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestPreparedStatement(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When preparing a CREATE STATE statement with a positional placeholder", func() {
+			ps, err := PrepareStatement(`CREATE STATE hoge TYPE dummy_uds WITH num=?;`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the bound statement should create a state having the value", func() {
+				stmt, err := ps.Bind(data.Int(7))
+				So(err, ShouldBeNil)
+				So(stmt, ShouldHaveSameTypeAs, parser.CreateStateStmt{})
+				_, err = tb.AddStmt(stmt)
+				So(err, ShouldBeNil)
+
+				s, err := dt.Context().SharedStates.Get("hoge")
+				So(err, ShouldBeNil)
+				So(s.(*dummyUDS).num, ShouldEqual, 7)
+			})
+
+			Convey("Then binding a value which cannot be a parameter should fail", func() {
+				_, err := ps.Bind(data.Null{})
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When preparing an EVAL statement with named placeholders", func() {
+			ps, err := PrepareStatement(`EVAL :a || :b`)
+			So(err, ShouldBeNil)
+
+			Convey("Then values shouldn't be interpreted as BQL", func() {
+				stmt, err := ps.BindNamed(data.Map{
+					"a": data.String("x"),
+					"b": data.String(`" || "y`),
+				})
+				So(err, ShouldBeNil)
+				eval := stmt.(parser.EvalStmt)
+				v, err := tb.RunEvalStmt(&eval)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String(`x" || "y`))
+			})
+		})
+
+		Convey("When preparing a statement with a syntax error", func() {
+			_, err := PrepareStatement(`EVAL ? +`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
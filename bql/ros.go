@@ -0,0 +1,562 @@
+package bql
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"golang.org/x/net/websocket"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultROSBridgeURL         = "ws://localhost:9090"
+	defaultROSReconnectInterval = 5 * time.Second
+	rosBridgeDialTimeout        = 10 * time.Second
+	rosBridgeMaxMessageSize     = 64 * 1024 * 1024
+)
+
+// rosBridge is a connection setting of a rosbridge server, which relays
+// messages of ROS topics as JSON over WebSocket with the rosbridge v2
+// protocol.
+type rosBridge struct {
+	url       string
+	origin    string
+	tlsConfig *tls.Config
+
+	// name is url without a password, which can be written to logs.
+	name string
+}
+
+// extractROSBridgeParameters retrieves 'url' parameter having a ws or wss
+// URL of a rosbridge server and 'tls' parameter described in
+// LookupTLSConfig.
+func extractROSBridgeParameters(params data.Map) (*rosBridge, error) {
+	b := &rosBridge{url: defaultROSBridgeURL}
+	if v, ok := params["url"]; ok {
+		var err error
+		if b.url, err = data.AsString(v); err != nil {
+			return nil, fmt.Errorf("'url' parameter must be a string: %v", err)
+		}
+	}
+	u, err := url.Parse(b.url)
+	if err != nil {
+		return nil, fmt.Errorf("'url' parameter must be a valid URL: %v", err)
+	}
+	switch u.Scheme {
+	case "ws":
+		b.origin = "http://" + u.Host + "/"
+	case "wss":
+		b.origin = "https://" + u.Host + "/"
+	default:
+		return nil, fmt.Errorf("'url' parameter must be a ws or wss URL: %v", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("'url' parameter must have a host: %v", u.Redacted())
+	}
+	b.name = u.Redacted()
+
+	if tc, err := LookupTLSConfig(params); err != nil {
+		return nil, err
+	} else if tc != nil {
+		if b.tlsConfig, err = tc.ClientConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// dial connects to the server.
+func (b *rosBridge) dial() (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(b.url, b.origin)
+	if err != nil {
+		return nil, err
+	}
+	config.TlsConfig = b.tlsConfig
+	config.Dialer = &net.Dialer{Timeout: rosBridgeDialTimeout}
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	conn.MaxPayloadBytes = rosBridgeMaxMessageSize
+	return conn, nil
+}
+
+// send sends an operation of the rosbridge protocol. It's sent as a text
+// frame because rosbridge decodes binary frames as BSON.
+func (b *rosBridge) send(conn *websocket.Conn, op data.Map) error {
+	js, err := data.MarshalJSON(op)
+	if err != nil {
+		return err
+	}
+	return websocket.Message.Send(conn, string(js))
+}
+
+// extractROSTopicsParameter retrieves 'topics' parameter and returns
+// subscribe operations of them. The parameter is a topic name, or an array
+// of topic names or maps having following keys:
+//
+//	topic: the name of the topic such as "/odom" (required)
+//	type: the type of messages such as "nav_msgs/Odometry", which rosbridge
+//	    infers when it's omitted
+//	throttle_rate: the minimum interval between messages of the topic
+//	queue_length: the number of messages rosbridge buffers for throttling
+func extractROSTopicsParameter(params data.Map) ([]data.Map, error) {
+	v, ok := params["topics"]
+	if !ok {
+		return nil, errors.New("'topics' parameter is missing")
+	}
+	var ts data.Array
+	if v.Type() == data.TypeArray {
+		ts, _ = data.AsArray(v)
+	} else {
+		ts = data.Array{v}
+	}
+	if len(ts) == 0 {
+		return nil, errors.New("'topics' parameter must not be empty")
+	}
+
+	ops := make([]data.Map, 0, len(ts))
+	seen := map[string]bool{}
+	for i, t := range ts {
+		op := data.Map{"op": data.String("subscribe")}
+		var m data.Map
+		if t.Type() == data.TypeMap {
+			m, _ = data.AsMap(t)
+			for k := range m {
+				switch k {
+				case "topic", "type", "throttle_rate", "queue_length":
+				default:
+					return nil, fmt.Errorf("topics[%v] has an unknown key: %v", i, k)
+				}
+			}
+			var ok bool
+			if t, ok = m["topic"]; !ok {
+				return nil, fmt.Errorf("topics[%v] doesn't have 'topic'", i)
+			}
+		}
+		topic, err := data.AsString(t)
+		if err != nil {
+			return nil, fmt.Errorf("the name of topics[%v] must be a string: %v", i, err)
+		}
+		if topic == "" {
+			return nil, fmt.Errorf("the name of topics[%v] must not be empty", i)
+		}
+		if seen[topic] {
+			return nil, fmt.Errorf("'topics' parameter has a duplicated topic: %v", topic)
+		}
+		seen[topic] = true
+		op["topic"] = data.String(topic)
+
+		if v, ok := m["type"]; ok {
+			typ, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("the type of topics[%v] must be a string: %v", i, err)
+			}
+			op["type"] = data.String(typ)
+		}
+		if v, ok := m["throttle_rate"]; ok {
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("the throttle_rate of topics[%v] should have a duration: %v", i, err)
+			}
+			if d < 0 {
+				return nil, fmt.Errorf("the throttle_rate of topics[%v] must not be negative: %v", i, v)
+			}
+			op["throttle_rate"] = data.Int(d / time.Millisecond)
+		}
+		if v, ok := m["queue_length"]; ok {
+			n, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("the queue_length of topics[%v] must be an integer: %v", i, err)
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("the queue_length of topics[%v] must not be negative: %v", i, n)
+			}
+			op["queue_length"] = data.Int(n)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// rosStampPaths are paths of seconds and nanoseconds of header.stamp in
+// ROS 1 and ROS 2 messages.
+var rosStampPaths = [][2]data.Path{
+	{data.MustCompilePath("header.stamp.secs"), data.MustCompilePath("header.stamp.nsecs")},
+	{data.MustCompilePath("header.stamp.sec"), data.MustCompilePath("header.stamp.nanosec")},
+}
+
+// rosHeaderStamp returns the time of header.stamp of a ROS message.
+func rosHeaderStamp(msg data.Map) (time.Time, bool) {
+	for _, paths := range rosStampPaths {
+		s, err := msg.Get(paths[0])
+		if err != nil {
+			continue
+		}
+		ns, err := msg.Get(paths[1])
+		if err != nil {
+			continue
+		}
+		sec, err := data.ToInt(s)
+		if err != nil {
+			continue
+		}
+		nsec, err := data.ToInt(ns)
+		if err != nil {
+			continue
+		}
+		return time.Unix(sec, nsec), true
+	}
+	return time.Time{}, false
+}
+
+// rosSource subscribes ROS topics through rosbridge. It emits a tuple
+// having "topic" and "msg" for each message, where msg is the message
+// mapped from JSON as it is. Since JSON doesn't distinguish integers, all
+// numbers in messages are Float.
+//
+// The timestamp of a tuple is the time when it's received. When
+// useHeaderStamp is true, header.stamp of the message is used instead if
+// the message has it.
+//
+// When the connection fails, GenerateStream returns an error and the source
+// is reconnected by the supervisor, which can be configured by reconnect_
+// parameters of CREATE SOURCE. The topics are subscribed again on each
+// reconnect.
+type rosSource struct {
+	bridge         *rosBridge
+	subscriptions  []data.Map
+	useHeaderStamp bool
+	ioParams       *IOParams
+	stopCh         chan struct{}
+
+	m       sync.Mutex
+	stopped bool
+
+	// conn is the connection being read. It's closed by Stop to abort
+	// reading.
+	conn *websocket.Conn
+}
+
+func (s *rosSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	conn, err := s.connect()
+	if err == nil {
+		var writeErr error
+		writeErr, err = s.read(ctx, w, conn)
+		s.close()
+		if writeErr != nil {
+			return writeErr
+		}
+	}
+
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	return fmt.Errorf("cannot receive messages from rosbridge %v: %v", s.bridge.name, err)
+}
+
+// connect connects to the server and subscribes the topics.
+func (s *rosSource) connect() (*websocket.Conn, error) {
+	conn, err := s.bridge.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		conn.Close()
+		return nil, errors.New("the source has already been stopped")
+	}
+	s.conn = conn
+	s.m.Unlock()
+
+	for _, op := range s.subscriptions {
+		if err := s.bridge.send(conn, op); err != nil {
+			s.close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (s *rosSource) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// read emits tuples until the connection fails. It returns an error from w
+// as writeErr and an error from the connection as readErr.
+func (s *rosSource) read(ctx *core.Context, w core.Writer, conn *websocket.Conn) (writeErr, readErr error) {
+	for {
+		var b []byte
+		if err := websocket.Message.Receive(conn, &b); err != nil {
+			return nil, err
+		}
+		m, err := data.UnmarshalJSON(b)
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("url", s.bridge.name).
+				Warning("Ignoring a malformed message from rosbridge")
+			continue
+		}
+
+		op, _ := data.AsString(m["op"])
+		switch op {
+		case "publish":
+		case "status":
+			level, _ := data.AsString(m["level"])
+			msg, _ := data.AsString(m["msg"])
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("url", s.bridge.name).
+				WithField("level", level).
+				Warning("rosbridge reported a status: ", msg)
+			continue
+		default:
+			continue
+		}
+
+		topic, err := data.AsString(m["topic"])
+		if err != nil {
+			continue
+		}
+		msg, err := data.AsMap(m["msg"])
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("url", s.bridge.name).
+				WithField("topic", topic).
+				Warning("Ignoring a message without a valid msg")
+			continue
+		}
+
+		t := core.NewTuple(data.Map{
+			"topic": data.String(topic),
+			"msg":   msg,
+		})
+		t.ProcTimestamp = ctx.Clock().Now()
+		t.Timestamp = t.ProcTimestamp
+		if s.useHeaderStamp {
+			if ts, ok := rosHeaderStamp(msg); ok {
+				t.Timestamp = ts
+			}
+		}
+		if err := w.Write(ctx, t); err != nil {
+			return err, nil
+		}
+	}
+}
+
+func (s *rosSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	close(s.stopCh)
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	return nil
+}
+
+func (s *rosSource) reconnectsBySupervisor() {}
+
+// createROSSource creates a source subscribing ROS topics through rosbridge.
+// It accepts following parameters:
+//
+//	url: the URL of the rosbridge server (default: "ws://localhost:9090")
+//	topics: the topics described in extractROSTopicsParameter (required)
+//	use_header_stamp: whether header.stamp of messages is used as the
+//	    timestamp of tuples (default: false)
+//	tls: TLS parameters described in LookupTLSConfig for wss URLs
+func createROSSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &rosSource{
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+	}
+	var err error
+	if s.bridge, err = extractROSBridgeParameters(params); err != nil {
+		return nil, err
+	}
+	if s.subscriptions, err = extractROSTopicsParameter(params); err != nil {
+		return nil, err
+	}
+	if v, ok := params["use_header_stamp"]; ok {
+		if s.useHeaderStamp, err = data.AsBool(v); err != nil {
+			return nil, fmt.Errorf("'use_header_stamp' parameter must be a bool: %v", err)
+		}
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("ros", SourceCreatorFunc(createROSSource))
+}
+
+// rosSink publishes tuples to a ROS topic through rosbridge. The Map at
+// field, or the whole tuple when field is nil, is published as a message.
+//
+// The sink connects to the server and advertises the topic on the first
+// write, and reconnects after a write fails. Writes fail without
+// reconnecting until reconnectInterval passes after the last failure.
+type rosSink struct {
+	bridge            *rosBridge
+	topic             string
+	typ               string
+	field             data.Path
+	reconnectInterval time.Duration
+	ioParams          *IOParams
+
+	m      sync.Mutex
+	closed bool
+
+	// conn is the connection to the server. It's nil while disconnected.
+	conn *websocket.Conn
+
+	// failedAt is the time when the last write or connect failed.
+	failedAt time.Time
+}
+
+func (s *rosSink) Write(ctx *core.Context, t *core.Tuple) error {
+	msg := t.Data
+	if s.field != nil {
+		v, err := t.Data.Get(s.field)
+		if err != nil {
+			return err
+		}
+		if msg, err = data.AsMap(v); err != nil {
+			return fmt.Errorf("the message must be a map: %v", err)
+		}
+	}
+	op := data.Map{
+		"op":    data.String("publish"),
+		"topic": data.String(s.topic),
+		"msg":   msg,
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	now := ctx.Clock().Now()
+	if s.conn == nil {
+		if !s.failedAt.IsZero() && now.Sub(s.failedAt) < s.reconnectInterval {
+			return errors.New("rosbridge isn't available")
+		}
+		conn, err := s.bridge.dial()
+		if err == nil {
+			if err = s.bridge.send(conn, s.advertisement("advertise")); err != nil {
+				conn.Close()
+			}
+		}
+		if err != nil {
+			s.failedAt = now
+			return err
+		}
+		s.conn = conn
+		if !s.failedAt.IsZero() {
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("url", s.bridge.name).Info("Reconnected to rosbridge")
+		}
+	}
+	if err := s.bridge.send(s.conn, op); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		s.failedAt = now
+		return err
+	}
+	return nil
+}
+
+// advertisement returns an advertise or unadvertise operation of the topic.
+func (s *rosSink) advertisement(op string) data.Map {
+	m := data.Map{
+		"op":    data.String(op),
+		"topic": data.String(s.topic),
+	}
+	if op == "advertise" {
+		m["type"] = data.String(s.typ)
+	}
+	return m
+}
+
+func (s *rosSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.conn == nil {
+		return nil
+	}
+	// The topic is unadvertised as a courtesy. rosbridge also unadvertises
+	// topics of closed connections.
+	s.bridge.send(s.conn, s.advertisement("unadvertise"))
+	return s.conn.Close()
+}
+
+// createROSSink creates a sink publishing tuples to a ROS topic through
+// rosbridge. It accepts following parameters:
+//
+//	url, tls: same as the ros source
+//	reconnect_interval: the minimum interval between reconnects after
+//	    writing fails (default: 5s)
+//	topic: the name of the topic such as "/cmd_vel" (required)
+//	type: the type of messages such as "geometry_msgs/Twist" (required)
+//	field: the path of the Map published as a message (default: the whole
+//	    tuple)
+func createROSSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	s := &rosSink{
+		ioParams: ioParams,
+	}
+	var err error
+	if s.bridge, err = extractROSBridgeParameters(params); err != nil {
+		return nil, err
+	}
+	for _, p := range []struct {
+		name string
+		v    *string
+	}{{"topic", &s.topic}, {"type", &s.typ}} {
+		v, ok := params[p.name]
+		if !ok {
+			return nil, fmt.Errorf("'%v' parameter is missing", p.name)
+		}
+		if *p.v, err = data.AsString(v); err != nil {
+			return nil, fmt.Errorf("'%v' parameter must be a string: %v", p.name, err)
+		}
+		if *p.v == "" {
+			return nil, fmt.Errorf("'%v' parameter must not be empty", p.name)
+		}
+	}
+	if v, ok := params["field"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'field' parameter must be a string: %v", err)
+		}
+		if s.field, err = data.CompilePath(f); err != nil {
+			return nil, fmt.Errorf("'field' parameter doesn't have a valid path: %v", err)
+		}
+	}
+	if s.reconnectInterval, err = extractReconnectIntervalParameter(params, defaultROSReconnectInterval); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSinkCreator("ros", SinkCreatorFunc(createROSSink))
+}
@@ -0,0 +1,330 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/websocket"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeROSBridge is a rosbridge server recording operations it receives.
+type fakeROSBridge struct {
+	*httptest.Server
+
+	m     sync.Mutex
+	conns int
+	ops   []data.Map
+	opCh  chan data.Map
+}
+
+// newFakeROSBridge creates a server calling handle for each connection. The
+// connection is closed when handle returns.
+func newFakeROSBridge(handle func(b *fakeROSBridge, conn *websocket.Conn, n int)) *fakeROSBridge {
+	b := &fakeROSBridge{
+		opCh: make(chan data.Map, 100),
+	}
+	b.Server = httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		b.m.Lock()
+		b.conns++
+		n := b.conns
+		b.m.Unlock()
+		handle(b, conn, n)
+	}))
+	return b
+}
+
+func (b *fakeROSBridge) url() string {
+	return "ws" + strings.TrimPrefix(b.URL, "http")
+}
+
+// receive receives an operation and records it.
+func (b *fakeROSBridge) receive(conn *websocket.Conn) (data.Map, error) {
+	var s string
+	if err := websocket.Message.Receive(conn, &s); err != nil {
+		return nil, err
+	}
+	m, err := data.UnmarshalJSON([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	b.m.Lock()
+	b.ops = append(b.ops, m)
+	b.m.Unlock()
+	b.opCh <- m
+	return m, nil
+}
+
+func TestROSSource(t *testing.T) {
+	Convey("Given a rosbridge server publishing messages", t, func() {
+		ctx := core.NewContext(nil)
+		b := newFakeROSBridge(func(b *fakeROSBridge, conn *websocket.Conn, n int) {
+			for i := 0; i < 2; i++ {
+				if _, err := b.receive(conn); err != nil {
+					return
+				}
+			}
+			for _, msg := range []string{
+				`{"op":"publish","topic":"/odom","msg":{"header":{"stamp":{"secs":1500000000,"nsecs":500000000}},"x":1.5}}`,
+				`{"op":"status","level":"error","msg":"unknown topic"}`,
+				`{"op":"publish","topic":`,
+				`{"op":"publish","topic":"/chatter","msg":"not a map"}`,
+				`{"op":"publish","topic":"/chatter","msg":{"data":"hello"}}`,
+			} {
+				websocket.Message.Send(conn, msg)
+			}
+			if n > 1 {
+				// Keep the connection until the source is stopped.
+				b.receive(conn)
+			}
+		})
+		Reset(b.Close)
+
+		Convey("When subscribing topics", func() {
+			src, err := createROSSource(ctx, &IOParams{}, data.Map{
+				"url": data.String(b.url()),
+				"topics": data.Array{
+					data.String("/chatter"),
+					data.Map{
+						"topic":         data.String("/odom"),
+						"type":          data.String("nav_msgs/Odometry"),
+						"throttle_rate": data.String("100ms"),
+						"queue_length":  data.Int(1),
+					},
+				},
+				"use_header_stamp": data.True,
+			})
+			So(err, ShouldBeNil)
+			s := core.NewSupervisedSource(src, &core.SupervisorConfig{
+				InitialBackoff: time.Millisecond,
+			})
+
+			ch := make(chan *core.Tuple, 3)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					select {
+					case ch <- t:
+					default:
+					}
+					return nil
+				}))
+			}()
+			ts := make([]*core.Tuple, 3)
+			for i := range ts {
+				ts[i] = <-ch
+			}
+			So(s.Stop(ctx), ShouldBeNil)
+			So(<-done, ShouldBeNil)
+			So(s.Stop(ctx), ShouldBeNil)
+
+			Convey("Then the topics should be subscribed", func() {
+				b.m.Lock()
+				defer b.m.Unlock()
+				So(len(b.ops), ShouldBeGreaterThanOrEqualTo, 2)
+				So(b.ops[0], ShouldResemble, data.Map{
+					"op":    data.String("subscribe"),
+					"topic": data.String("/chatter"),
+				})
+				So(b.ops[1], ShouldResemble, data.Map{
+					"op":            data.String("subscribe"),
+					"topic":         data.String("/odom"),
+					"type":          data.String("nav_msgs/Odometry"),
+					"throttle_rate": data.Float(100),
+					"queue_length":  data.Float(1),
+				})
+			})
+
+			Convey("Then messages should be emitted", func() {
+				So(ts[0].Data, ShouldResemble, data.Map{
+					"topic": data.String("/odom"),
+					"msg": data.Map{
+						"header": data.Map{
+							"stamp": data.Map{
+								"secs":  data.Float(1500000000),
+								"nsecs": data.Float(500000000),
+							},
+						},
+						"x": data.Float(1.5),
+					},
+				})
+				So(ts[0].Timestamp, ShouldResemble, time.Unix(1500000000, 500000000))
+				So(ts[1].Data, ShouldResemble, data.Map{
+					"topic": data.String("/chatter"),
+					"msg":   data.Map{"data": data.String("hello")},
+				})
+				So(ts[1].Timestamp, ShouldResemble, ts[1].ProcTimestamp)
+			})
+
+			Convey("Then the source should resubscribe after reconnecting", func() {
+				So(ts[2].Data["topic"], ShouldEqual, data.String("/odom"))
+				b.m.Lock()
+				defer b.m.Unlock()
+				So(b.conns, ShouldBeGreaterThanOrEqualTo, 2)
+			})
+		})
+
+		Convey("When subscribing topics without the supervisor", func() {
+			s, err := createROSSource(ctx, &IOParams{}, data.Map{
+				"url":    data.String(b.url()),
+				"topics": data.Array{data.String("/chatter"), data.String("/odom")},
+			})
+			So(err, ShouldBeNil)
+			err = s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				return nil
+			}))
+
+			Convey("Then GenerateStream should fail when the connection is closed", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given ROS messages with header stamps", t, func() {
+		Convey("When getting the stamps", func() {
+			Convey("Then ROS 1 and ROS 2 stamps should be supported", func() {
+				ts, ok := rosHeaderStamp(data.Map{"header": data.Map{"stamp": data.Map{
+					"sec": data.Float(10), "nanosec": data.Float(20)}}})
+				So(ok, ShouldBeTrue)
+				So(ts, ShouldResemble, time.Unix(10, 20))
+
+				_, ok = rosHeaderStamp(data.Map{"header": data.Map{"frame_id": data.String("map")}})
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		ctx := core.NewContext(nil)
+
+		Convey("When creating sources", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{},
+					{"topics": data.Array{}},
+					{"topics": data.Int(1)},
+					{"topics": data.String("")},
+					{"topics": data.Array{data.String("/a"), data.String("/a")}},
+					{"topics": data.Array{data.Map{"type": data.String("std_msgs/String")}}},
+					{"topics": data.Array{data.Map{"topic": data.String("/a"), "rate": data.Int(1)}}},
+					{"topics": data.Array{data.Map{"topic": data.String("/a"), "type": data.Int(1)}}},
+					{"topics": data.Array{data.Map{"topic": data.String("/a"), "throttle_rate": data.String("-1s")}}},
+					{"topics": data.Array{data.Map{"topic": data.String("/a"), "queue_length": data.Int(-1)}}},
+					{"topics": data.String("/a"), "url": data.String("http://localhost:9090")},
+					{"topics": data.String("/a"), "url": data.String("ws://")},
+					{"topics": data.String("/a"), "url": data.Int(1)},
+					{"topics": data.String("/a"), "use_header_stamp": data.String("yes")},
+				} {
+					_, err := createROSSource(ctx, &IOParams{}, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestROSSink(t *testing.T) {
+	Convey("Given a rosbridge server", t, func() {
+		ctx := core.NewContext(nil)
+		b := newFakeROSBridge(func(b *fakeROSBridge, conn *websocket.Conn, n int) {
+			for {
+				if _, err := b.receive(conn); err != nil {
+					return
+				}
+			}
+		})
+		Reset(b.Close)
+
+		Convey("When publishing tuples", func() {
+			s, err := createROSSink(ctx, &IOParams{}, data.Map{
+				"url":   data.String(b.url()),
+				"topic": data.String("/cmd_vel"),
+				"type":  data.String("geometry_msgs/Twist"),
+				"field": data.String("twist"),
+			})
+			So(err, ShouldBeNil)
+			twist := data.Map{"linear": data.Map{"x": data.Float(0.5)}}
+			So(s.Write(ctx, core.NewTuple(data.Map{"twist": twist})), ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"twist": data.Map{}})), ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"twist": data.Int(1)})), ShouldNotBeNil)
+			So(s.Close(ctx), ShouldBeNil)
+			So(s.Close(ctx), ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"twist": twist})), ShouldNotBeNil)
+
+			Convey("Then the topic should be advertised and published", func() {
+				ops := make([]data.Map, 4)
+				for i := range ops {
+					ops[i] = <-b.opCh
+				}
+				So(ops[0], ShouldResemble, data.Map{
+					"op":    data.String("advertise"),
+					"topic": data.String("/cmd_vel"),
+					"type":  data.String("geometry_msgs/Twist"),
+				})
+				So(ops[1], ShouldResemble, data.Map{
+					"op":    data.String("publish"),
+					"topic": data.String("/cmd_vel"),
+					"msg":   twist,
+				})
+				So(ops[2]["msg"], ShouldResemble, data.Map{})
+				So(ops[3], ShouldResemble, data.Map{
+					"op":    data.String("unadvertise"),
+					"topic": data.String("/cmd_vel"),
+				})
+			})
+		})
+	})
+
+	Convey("Given an unavailable rosbridge server", t, func() {
+		ctx := core.NewContext(nil)
+		b := newFakeROSBridge(func(b *fakeROSBridge, conn *websocket.Conn, n int) {})
+		b.Close()
+
+		Convey("When publishing tuples", func() {
+			s, err := createROSSink(ctx, &IOParams{}, data.Map{
+				"url":                data.String(b.url()),
+				"topic":              data.String("/chatter"),
+				"type":               data.String("std_msgs/String"),
+				"reconnect_interval": data.String("1h"),
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Close(ctx)
+			})
+			err1 := s.Write(ctx, core.NewTuple(data.Map{"data": data.String("a")}))
+			err2 := s.Write(ctx, core.NewTuple(data.Map{"data": data.String("b")}))
+
+			Convey("Then writes should fail without reconnecting", func() {
+				So(err1, ShouldNotBeNil)
+				So(err2, ShouldNotBeNil)
+				So(err2.Error(), ShouldContainSubstring, "isn't available")
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		ctx := core.NewContext(nil)
+
+		Convey("When creating sinks", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{},
+					{"topic": data.String("/a")},
+					{"type": data.String("std_msgs/String")},
+					{"topic": data.String(""), "type": data.String("std_msgs/String")},
+					{"topic": data.Int(1), "type": data.String("std_msgs/String")},
+					{"topic": data.String("/a"), "type": data.String("std_msgs/String"), "field": data.String("[")},
+					{"topic": data.String("/a"), "type": data.String("std_msgs/String"), "url": data.String("tcp://ros")},
+					{"topic": data.String("/a"), "type": data.String("std_msgs/String"), "reconnect_interval": data.Int(-1)},
+				} {
+					_, err := createROSSink(ctx, &IOParams{}, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
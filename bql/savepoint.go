@@ -0,0 +1,492 @@
+package bql
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Savepoint is a consistent snapshot of a topology which can be restored in
+// another process, e.g. on another machine, to move the topology without
+// losing data. It's taken by TopologyBuilder.TakeSavepoint and restored by
+// TopologyBuilder.RestoreSavepoint. It can be encoded in JSON.
+type Savepoint struct {
+	// Topology is the name of the topology from which the savepoint was
+	// taken.
+	Topology string `json:"topology"`
+
+	// CreatedAt is the time when the savepoint was taken.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Statements has BQL statements defining the topology in the order
+	// they can be applied. Sources are created in the paused state.
+	// Savable states are restored from States instead of statements.
+	Statements []string `json:"statements"`
+
+	// States has data of savable states keyed by their names.
+	States map[string]*SavepointState `json:"states"`
+
+	// Offsets has the largest offset emitted by each source. Sources are
+	// restored so that they start from the tuple next to the offset.
+	Offsets map[string]int64 `json:"offsets"`
+
+	// Windows has tuples in windows of streams created by SELECT
+	// statements keyed by names of the streams.
+	Windows map[string]*SavepointWindow `json:"windows"`
+
+	// RunningSources has names of sources which were running when the
+	// savepoint was taken. They're resumed after the savepoint is restored.
+	RunningSources []string `json:"running_sources"`
+}
+
+// SavepointState is data of a savable state in a Savepoint.
+type SavepointState struct {
+	// Type is the type name of the state.
+	Type string `json:"type"`
+
+	// Data has the data written by core.SavableSharedState.Save. It's
+	// encoded in base64 in JSON.
+	Data []byte `json:"data"`
+}
+
+// SavepointWindow has tuples in windows of a stream in a Savepoint.
+type SavepointWindow struct {
+	// Fields has names in the data.FieldDictionary used to encode data of
+	// tuples.
+	Fields []string `json:"fields"`
+
+	// Tuples has tuples in the order of their timestamps.
+	Tuples []*SavepointTuple `json:"tuples"`
+}
+
+// SavepointTuple is a tuple in a SavepointWindow.
+type SavepointTuple struct {
+	InputName     string    `json:"input_name"`
+	Timestamp     time.Time `json:"timestamp"`
+	ProcTimestamp time.Time `json:"proc_timestamp"`
+
+	// Data has the data of the tuple encoded by data.AppendBinary.
+	Data []byte `json:"data"`
+}
+
+// savepointPollInterval is the interval at which TakeSavepoint checks if
+// all tuples emitted by sources have been processed.
+const savepointPollInterval = 10 * time.Millisecond
+
+// TakeSavepoint takes a savepoint of the topology. It pauses all running
+// sources and waits until tuples in pipes are processed by boxes and sinks
+// so that windows, states, and offsets are consistent with each other. Sinks
+// implementing core.Flushable are then flushed so that tuples written before
+// the savepoint are persisted. It returns an error when the topology doesn't
+// become idle within the timeout or a sink cannot be flushed.
+//
+// Sources are left paused so that the topology doesn't process tuples which
+// will also be processed by the topology restored from the savepoint. They
+// can be resumed by RESUME SOURCE when the savepoint isn't used. Windows of
+// streams created by UNION ALL and states which are neither savable nor
+// created by CREATE STATE aren't included.
+func (tb *TopologyBuilder) TakeSavepoint(timeout time.Duration) (*Savepoint, error) {
+	var running []string
+	for name, s := range tb.topology.Sources() {
+		if tb.definition(nodeDefinitionPrefix, name) == nil || s.State().Get() != core.TSRunning {
+			continue
+		}
+		if err := s.Pause(); err != nil {
+			tb.resumeSources(running)
+			return nil, err
+		}
+		running = append(running, s.Name())
+	}
+	sort.Strings(running)
+
+	sp, err := tb.takeSavepoint(timeout)
+	if err != nil {
+		tb.resumeSources(running)
+		return nil, err
+	}
+	sp.RunningSources = running
+	return sp, nil
+}
+
+func (tb *TopologyBuilder) takeSavepoint(timeout time.Duration) (*Savepoint, error) {
+	if err := tb.waitForIdle(timeout); err != nil {
+		return nil, err
+	}
+	for name, s := range tb.topology.Sinks() {
+		if err := s.Flush(); err != nil {
+			return nil, fmt.Errorf("cannot flush the sink '%v': %v", name, err)
+		}
+	}
+
+	ctx := tb.topology.Context()
+	sp := &Savepoint{
+		Topology:  tb.topology.Name(),
+		CreatedAt: time.Now(),
+		States:    map[string]*SavepointState{},
+		Offsets:   map[string]int64{},
+		Windows:   map[string]*SavepointWindow{},
+	}
+
+	states, err := ctx.SharedStates.List()
+	if err != nil {
+		return nil, err
+	}
+	for name, s := range states {
+		sv, ok := s.(core.SavableSharedState)
+		if !ok {
+			continue
+		}
+		typeName, err := ctx.SharedStates.Type(name)
+		if err != nil {
+			if core.IsNotExist(err) {
+				continue // the state was removed after listing states
+			}
+			return nil, err
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := sv.Save(ctx, buf, data.Map{}); err != nil {
+			return nil, err
+		}
+		sp.States[name] = &SavepointState{
+			Type: typeName,
+			Data: buf.Bytes(),
+		}
+	}
+
+	for name := range tb.topology.Sources() {
+		if off, ok := ctx.Offsets.Emitted(name); ok {
+			sp.Offsets[name] = off
+		}
+	}
+
+	for name, b := range tb.topology.Boxes() {
+		bb, ok := b.Box().(*bqlBox)
+		if !ok || isTemporaryNode(name) {
+			continue
+		}
+		tuples, ok, err := bb.windowTuples()
+		if err != nil {
+			return nil, fmt.Errorf("cannot take windows of stream '%v': %v", name, err)
+		}
+		if !ok {
+			continue
+		}
+		d := data.NewFieldDictionary()
+		w := &SavepointWindow{
+			Tuples: make([]*SavepointTuple, len(tuples)),
+		}
+		for i, t := range tuples {
+			w.Tuples[i] = &SavepointTuple{
+				InputName:     t.InputName,
+				Timestamp:     t.Timestamp,
+				ProcTimestamp: t.ProcTimestamp,
+				Data:          data.AppendBinary(nil, t.Data, d),
+			}
+		}
+		w.Fields = d.Names()
+		sp.Windows[b.Name()] = w
+	}
+
+	stmts, err := tb.savepointStatements(sp.States)
+	if err != nil {
+		return nil, err
+	}
+	sp.Statements = stmts
+	return sp, nil
+}
+
+// waitForIdle waits until pipes of the topology don't have queued tuples,
+// no box or sink is in the middle of writing a tuple, and the numbers of
+// tuples received by nodes stop changing. The last condition covers tuples
+// which have just been taken from queues but not counted as in flight yet.
+func (tb *TopologyBuilder) waitForIdle(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	prev := int64(-1)
+	for {
+		var queued, inFlight, received int64
+		for _, e := range tb.topology.Graph().Edges {
+			if v, ok := e.Stats["num_queued"]; ok {
+				q, _ := data.AsInt(v)
+				queued += q
+			}
+		}
+		var nodes []core.Node
+		for _, b := range tb.topology.Boxes() {
+			nodes = append(nodes, b)
+		}
+		for _, s := range tb.topology.Sinks() {
+			nodes = append(nodes, s)
+		}
+		for _, n := range nodes {
+			st, _ := data.AsMap(n.Status()["input_stats"])
+			if v, ok := st["num_in_flight"]; ok {
+				f, _ := data.AsInt(v)
+				inFlight += f
+			}
+			if v, ok := st["num_received_total"]; ok {
+				r, _ := data.AsInt(v)
+				received += r
+			}
+		}
+		if queued == 0 && inFlight == 0 && received == prev {
+			return nil
+		}
+		prev = received
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the topology didn't become idle in %v", timeout)
+		}
+		time.Sleep(savepointPollInterval)
+	}
+}
+
+func (tb *TopologyBuilder) resumeSources(names []string) {
+	for _, name := range names {
+		s, err := tb.topology.Source(name)
+		if err != nil {
+			continue // removed while taking a savepoint
+		}
+		if err := s.Resume(); err != nil {
+			tb.topology.Context().ErrLog(err).WithField("node_name", name).
+				Error("Cannot resume the source paused for a savepoint")
+		}
+	}
+}
+
+// savepointStatements returns statements which define the topology. States
+// in saved don't have statements because they're restored from their data.
+func (tb *TopologyBuilder) savepointStatements(saved map[string]*SavepointState) ([]string, error) {
+	ctx := tb.topology.Context()
+	var stmts []string
+
+	states, err := ctx.SharedStates.List()
+	if err != nil {
+		return nil, err
+	}
+	stateNames := make([]string, 0, len(states))
+	for name := range states {
+		stateNames = append(stateNames, name)
+	}
+	sort.Strings(stateNames)
+	for _, name := range stateNames {
+		if _, ok := saved[name]; ok {
+			continue
+		}
+		typeName, err := ctx.SharedStates.Type(name)
+		if err != nil {
+			if core.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if def := tb.definition(stateDefinitionPrefix, name); def != nil && def.typeName == typeName {
+			stmts = append(stmts, def.stmt)
+		}
+	}
+
+	g := tb.topology.Graph()
+	inputs := map[string][]string{}
+	for _, e := range g.Edges {
+		r := strings.ToLower(e.Receiver)
+		inputs[r] = append(inputs[r], e.Sender)
+	}
+
+	var (
+		boxes []string
+		sinks []string
+		// evictedBy maps names of streams given to EVICT TO to names of
+		// streams evicting tuples to them.
+		evictedBy = map[string]string{}
+	)
+	for _, n := range g.Nodes {
+		def := tb.definition(nodeDefinitionPrefix, n.Name)
+		if def == nil {
+			continue
+		}
+		switch n.Type {
+		case core.NTSource:
+			stmt, _, err := parser.New().ParseStmt(def.stmt)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := stmt.(parser.CreateSourceStmt)
+			if !ok {
+				return nil, fmt.Errorf("source '%v' has an unexpected definition: %v", n.Name, def.stmt)
+			}
+			s.Paused = parser.Yes
+			f, err := Format(s)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, f)
+		case core.NTBox:
+			boxes = append(boxes, n.Name)
+			if stmt, _, err := parser.New().ParseStmt(def.stmt); err == nil {
+				if s, ok := stmt.(parser.CreateStreamAsSelectStmt); ok && s.EvictTo != "" {
+					evictedBy[strings.ToLower(string(s.EvictTo))] = n.Name
+				}
+			}
+		case core.NTSink:
+			sinks = append(sinks, n.Name)
+		}
+	}
+
+	// Streams are created after streams they read from.
+	done := map[string]bool{}
+	deps := func(name string) []string {
+		var ds []string
+		for _, in := range connectedNodes(name, inputs) {
+			n, _ := data.AsString(in)
+			if b, ok := evictedBy[strings.ToLower(n)]; ok {
+				n = b
+			}
+			ds = append(ds, strings.ToLower(n))
+		}
+		return ds
+	}
+	isBox := map[string]bool{}
+	for _, b := range boxes {
+		isBox[strings.ToLower(b)] = true
+	}
+	for len(done) < len(boxes) {
+		next := ""
+		for _, b := range boxes {
+			if done[strings.ToLower(b)] {
+				continue
+			}
+			ready := true
+			for _, d := range deps(b) {
+				if isBox[d] && !done[d] && d != strings.ToLower(b) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = b
+				break
+			}
+		}
+		if next == "" {
+			// Streams have a cycle. Break it at the first remaining one.
+			for _, b := range boxes {
+				if !done[strings.ToLower(b)] {
+					next = b
+					break
+				}
+			}
+		}
+		done[strings.ToLower(next)] = true
+		stmts = append(stmts, tb.definition(nodeDefinitionPrefix, next).stmt)
+	}
+
+	for _, s := range sinks {
+		stmts = append(stmts, tb.definition(nodeDefinitionPrefix, s).stmt)
+	}
+	for _, s := range sinks {
+		for _, in := range inputs[strings.ToLower(s)] {
+			if isTemporaryNode(in) {
+				continue
+			}
+			f, err := Format(parser.InsertIntoFromStmt{
+				Sink:  parser.StreamIdentifier(s),
+				Input: parser.StreamIdentifier(in),
+			})
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, f)
+		}
+	}
+	return stmts, nil
+}
+
+// RestoreSavepoint restores the topology from the savepoint. The topology
+// should be empty. States are restored first, then entities are created by
+// statements in the savepoint with AddStmts, and windows are filled with the
+// saved tuples. Sources which were running when the savepoint was taken are
+// resumed at last. Each source starts from the tuple next to its saved
+// offset, which requires the source to generate tuples having offsets from
+// the beginning of its stream, e.g. by re-reading them.
+func (tb *TopologyBuilder) RestoreSavepoint(sp *Savepoint) error {
+	bp := parser.New()
+	stmts := make([]interface{}, len(sp.Statements))
+	for i, s := range sp.Statements {
+		stmt, _, err := bp.ParseStmt(s)
+		if err != nil {
+			return fmt.Errorf("cannot parse the statement '%v': %v", s, err)
+		}
+		stmts[i] = stmt
+	}
+
+	if len(sp.Offsets) > 0 && tb.SourceOffsets == nil {
+		tb.SourceOffsets = map[string]int64{}
+	}
+	for name, off := range sp.Offsets {
+		tb.SourceOffsets[strings.ToLower(name)] = off + 1
+	}
+
+	names := make([]string, 0, len(sp.States))
+	for name := range sp.States {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		st := sp.States[name]
+		if err := tb.loadStateFrom(st.Type, name, bytes.NewReader(st.Data), data.Map{}); err != nil {
+			if err == errStateNotLoadable {
+				err = fmt.Errorf("the state '%v' cannot be loaded", name)
+			}
+			return err
+		}
+	}
+
+	if err := tb.AddStmts(stmts); err != nil {
+		return err
+	}
+
+	for name, w := range sp.Windows {
+		b, err := tb.topology.Box(name)
+		if err != nil {
+			return err
+		}
+		bb, ok := b.Box().(*bqlBox)
+		if !ok {
+			return fmt.Errorf("stream '%v' isn't created by a SELECT statement", name)
+		}
+		d := data.NewFieldDictionary(w.Fields...)
+		tuples := make([]*core.Tuple, len(w.Tuples))
+		for i, st := range w.Tuples {
+			v, err := data.UnmarshalBinary(st.Data, d)
+			if err != nil {
+				return fmt.Errorf("cannot decode a tuple of stream '%v': %v", name, err)
+			}
+			m, err := data.AsMap(v)
+			if err != nil {
+				return fmt.Errorf("cannot decode a tuple of stream '%v': %v", name, err)
+			}
+			t := core.NewTuple(m)
+			t.InputName = st.InputName
+			t.Timestamp = st.Timestamp
+			t.ProcTimestamp = st.ProcTimestamp
+			tuples[i] = t
+		}
+		if err := bb.restoreWindows(tuples); err != nil {
+			return fmt.Errorf("cannot restore windows of stream '%v': %v", name, err)
+		}
+	}
+
+	for _, name := range sp.RunningSources {
+		s, err := tb.topology.Source(name)
+		if err != nil {
+			return err
+		}
+		if err := s.Resume(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
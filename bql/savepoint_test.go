@@ -0,0 +1,207 @@
+package bql
+
+import (
+	"encoding/json"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSavepoint(t *testing.T) {
+	f, err := ioutil.TempFile("", "sbtest_savepoint")
+	if err != nil {
+		t.Fatal("Cannot create a temp file:", err)
+	}
+	name := f.Name()
+	defer os.Remove(name)
+	for i := 1; i <= 6; i++ {
+		fmt.Fprintf(f, `{"int":%v}`+"\n", i)
+	}
+	f.Close()
+
+	Convey("Given a running topology having windows, states, and offsets", t, func() {
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, fmt.Sprintf(`
+			CREATE STATE saved TYPE dummy_updatable_uds WITH num=5;
+			CREATE STATE plain TYPE dummy_uds WITH num=1;
+			CREATE PAUSED SOURCE src TYPE file WITH path="%v", rewindable=true;
+			CREATE STREAM s AS SELECT RSTREAM count(*) AS c FROM src [RANGE 3 TUPLES];
+			CREATE SINK snk TYPE collector;
+			INSERT INTO snk FROM s;
+			RESUME SOURCE src;`, name)), ShouldBeNil)
+		sn, err := tp.Sink("snk")
+		So(err, ShouldBeNil)
+		sn.Sink().(*tupleCollectorSink).Wait(6)
+
+		Convey("When taking a savepoint", func() {
+			sp, err := tb.TakeSavepoint(time.Second)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have statements defining the topology", func() {
+				So(sp.Statements, ShouldResemble, []string{
+					"CREATE STATE plain TYPE dummy_uds WITH num=1;",
+					fmt.Sprintf("CREATE PAUSED SOURCE src TYPE file WITH\n  path=\"%v\",\n  rewindable=true;", name),
+					"CREATE STREAM s AS\n  SELECT RSTREAM count(1) AS c\n  FROM src [RANGE 3 TUPLES];",
+					"CREATE SINK snk TYPE collector;",
+					"INSERT INTO snk FROM s;",
+				})
+			})
+
+			Convey("Then it should have savable states, offsets, and windows", func() {
+				So(sp.States, ShouldContainKey, "saved")
+				So(sp.States["saved"].Type, ShouldEqual, "dummy_updatable_uds")
+				So(sp.Offsets, ShouldResemble, map[string]int64{"src": 6})
+				So(sp.Windows, ShouldContainKey, "s")
+				So(sp.Windows["s"].Tuples, ShouldHaveLength, 3)
+				So(sp.RunningSources, ShouldResemble, []string{"src"})
+			})
+
+			Convey("Then the source should be paused", func() {
+				so, err := tp.Source("src")
+				So(err, ShouldBeNil)
+				So(so.State().Get(), ShouldEqual, core.TSPaused)
+			})
+
+			Convey("Then it should be restored in another topology", func() {
+				f, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0644)
+				So(err, ShouldBeNil)
+				fmt.Fprintf(f, `{"int":7}`+"\n"+`{"int":8}`+"\n")
+				f.Close()
+
+				b, err := json.Marshal(sp)
+				So(err, ShouldBeNil)
+				restored := &Savepoint{}
+				So(json.Unmarshal(b, restored), ShouldBeNil)
+
+				tp2, err := core.NewDefaultTopology(core.NewContext(nil), "testTopology2")
+				So(err, ShouldBeNil)
+				defer tp2.Stop()
+				tb2, err := NewTopologyBuilder(tp2)
+				So(err, ShouldBeNil)
+				So(tb2.RestoreSavepoint(restored), ShouldBeNil)
+
+				sn2, err := tp2.Sink("snk")
+				So(err, ShouldBeNil)
+				si := sn2.Sink().(*tupleCollectorSink)
+				si.Wait(2)
+				So(si.len(), ShouldEqual, 2)
+				for i := 0; i < 2; i++ {
+					So(si.get(i).Data["c"], ShouldEqual, data.Int(3))
+				}
+
+				st, err := tp2.Context().SharedStates.Get("saved")
+				So(err, ShouldBeNil)
+				So(st.(*dummyUpdatableUDS).num, ShouldEqual, 5)
+				_, err = tp2.Context().SharedStates.Get("plain")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When the topology doesn't become idle", func() {
+			_, err := tb.TakeSavepoint(0)
+
+			Convey("Then it should fail and resume the source", func() {
+				So(err, ShouldNotBeNil)
+				so, err := tp.Source("src")
+				So(err, ShouldBeNil)
+				So(so.State().Get(), ShouldEqual, core.TSRunning)
+			})
+		})
+	})
+
+	Convey("Given a running topology having a sink in the middle of a write", t, func() {
+		tp := newTestTopology()
+		// The source only has one tuple so that no tuple is queued while
+		// the sink is writing it.
+		f, err := ioutil.TempFile("", "sbtest_savepoint_write")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.Remove(f.Name())
+		})
+		fmt.Fprintln(f, `{"int":1}`)
+		f.Close()
+
+		sink := &blockingSink{
+			writing: make(chan struct{}),
+			release: make(chan struct{}),
+		}
+		Reset(func() {
+			sink.unblock()
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(tb.SinkCreators.Register("blocking", SinkCreatorFunc(
+			func(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+				return sink, nil
+			})), ShouldBeNil)
+		So(addBQLToTopology(tb, fmt.Sprintf(`
+			CREATE PAUSED SOURCE src TYPE file WITH path="%v", rewindable=true;
+			CREATE SINK snk TYPE blocking;
+			INSERT INTO snk FROM src;
+			RESUME SOURCE src;`, f.Name())), ShouldBeNil)
+		<-sink.writing
+
+		Convey("When taking a savepoint", func() {
+			done := make(chan error, 1)
+			go func() {
+				_, err := tb.TakeSavepoint(time.Second)
+				done <- err
+			}()
+
+			Convey("Then it should wait for the write to finish", func() {
+				taken := false
+				select {
+				case <-done:
+					taken = true
+				case <-time.After(5 * savepointPollInterval):
+				}
+				So(taken, ShouldBeFalse)
+				sink.unblock()
+				So(<-done, ShouldBeNil)
+			})
+		})
+	})
+}
+
+// blockingSink blocks the first write until unblock is called.
+type blockingSink struct {
+	writing chan struct{}
+	release chan struct{}
+	once    sync.Once
+	m       sync.Mutex
+	n       int
+}
+
+func (s *blockingSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	s.n++
+	first := s.n == 1
+	s.m.Unlock()
+	if first {
+		close(s.writing)
+		<-s.release
+	}
+	return nil
+}
+
+func (s *blockingSink) unblock() {
+	s.once.Do(func() {
+		close(s.release)
+	})
+}
+
+func (s *blockingSink) Close(ctx *core.Context) error {
+	return nil
+}
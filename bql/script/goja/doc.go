@@ -0,0 +1,21 @@
+// Package goja provides a ScriptEngine running JavaScript with goja, a pure
+// Go implementation of ECMAScript 5.1. Since it adds a dependency, the engine
+// is only built with the goja build tag:
+//
+//	go build -tags goja
+//
+// Import this package for side effects to register the engine as the
+// "javascript" language of script boxes:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/script/goja"
+//
+// A script must define a function named process. It receives the data of a
+// tuple as an object and returns an object, an array of objects, or null:
+//
+//	CREATE BOX b TYPE script FROM s WITH code="
+//	    function process(d) {
+//	        if (d.value < 0) return null;
+//	        d.value = d.value * 2;
+//	        return d;
+//	    }";
+package goja
@@ -0,0 +1,65 @@
+//go:build goja
+// +build goja
+
+package goja
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dop251/goja"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// ProcessFunc is the name of the function called for each tuple.
+const ProcessFunc = "process"
+
+// Engine is a bql.ScriptEngine for JavaScript.
+type Engine struct{}
+
+// Compile runs the code in a new runtime and looks up the process function
+// defined by it.
+func (Engine) Compile(ctx *core.Context, code string, params data.Map) (bql.Script, error) {
+	vm := goja.New()
+	if _, err := vm.RunString(code); err != nil {
+		return nil, err
+	}
+	f, ok := goja.AssertFunction(vm.Get(ProcessFunc))
+	if !ok {
+		return nil, fmt.Errorf("the script doesn't define %v function", ProcessFunc)
+	}
+	return &script{
+		vm:      vm,
+		process: f,
+	}, nil
+}
+
+type script struct {
+	vm      *goja.Runtime
+	process goja.Callable
+}
+
+func (s *script) Process(ctx *core.Context, d data.Map) (data.Value, error) {
+	res, err := s.process(goja.Undefined(), s.vm.ToValue(data.NewIMap(d)))
+	if err != nil {
+		return nil, err
+	}
+	if goja.IsUndefined(res) || goja.IsNull(res) {
+		return data.Null{}, nil
+	}
+	v, err := data.NewValue(res.Export())
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert the value returned from the script: %v", err)
+	}
+	return v, nil
+}
+
+func (s *script) Terminate(ctx *core.Context) error {
+	s.vm.Interrupt(errors.New("the script box is terminated"))
+	return nil
+}
+
+func init() {
+	bql.MustRegisterScriptEngine(bql.DefaultScriptLanguage, Engine{})
+}
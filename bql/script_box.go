@@ -0,0 +1,211 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Script is a compiled script used by a script box.
+type Script interface {
+	// Process is called with the data of each tuple the box receives. The
+	// script may modify the given map. It returns a data.Map for a single
+	// output tuple, a data.Array of data.Map for multiple output tuples, or
+	// data.Null when it doesn't emit anything.
+	Process(ctx *core.Context, d data.Map) (data.Value, error)
+}
+
+// ScriptTerminator is implemented by a Script which has to release resources
+// when the box using it is removed from a topology.
+type ScriptTerminator interface {
+	Terminate(ctx *core.Context) error
+}
+
+// ScriptEngine compiles scripts written in a language. Engines are registered
+// by RegisterScriptEngine, typically from an init function of a package
+// embedding an interpreter.
+type ScriptEngine interface {
+	// Compile compiles the code and returns a Script. params has all
+	// parameters given to the box so that engines can accept their own
+	// options. A Script returned from this method is only used by one box
+	// and isn't called concurrently.
+	Compile(ctx *core.Context, code string, params data.Map) (Script, error)
+}
+
+var (
+	scriptEnginesMutex sync.RWMutex
+	scriptEngines      = map[string]ScriptEngine{}
+)
+
+// RegisterScriptEngine registers a ScriptEngine for the language. The name of
+// the language is case-insensitive.
+func RegisterScriptEngine(lang string, e ScriptEngine) error {
+	scriptEnginesMutex.Lock()
+	defer scriptEnginesMutex.Unlock()
+	l := strings.ToLower(lang)
+	if _, ok := scriptEngines[l]; ok {
+		return fmt.Errorf("script engine for '%v' is already registered", lang)
+	}
+	scriptEngines[l] = e
+	return nil
+}
+
+// MustRegisterScriptEngine is like RegisterScriptEngine but panics if an
+// error occurred.
+func MustRegisterScriptEngine(lang string, e ScriptEngine) {
+	if err := RegisterScriptEngine(lang, e); err != nil {
+		panic(fmt.Errorf("bql.MustRegisterScriptEngine: cannot register '%v': %v", lang, err))
+	}
+}
+
+// LookupScriptEngine returns the ScriptEngine for the language. It returns
+// core.NotExistError when no engine is registered for the language.
+func LookupScriptEngine(lang string) (ScriptEngine, error) {
+	scriptEnginesMutex.RLock()
+	defer scriptEnginesMutex.RUnlock()
+	e, ok := scriptEngines[strings.ToLower(lang)]
+	if !ok {
+		return nil, core.NotExistError(fmt.Errorf("script engine for '%v' is not registered", lang))
+	}
+	return e, nil
+}
+
+// ScriptLanguages returns names of languages having registered engines in
+// sorted order.
+func ScriptLanguages() []string {
+	scriptEnginesMutex.RLock()
+	defer scriptEnginesMutex.RUnlock()
+	ls := make([]string, 0, len(scriptEngines))
+	for l := range scriptEngines {
+		ls = append(ls, l)
+	}
+	sort.Strings(ls)
+	return ls
+}
+
+// DefaultScriptLanguage is the language of a script box when its lang
+// parameter is omitted.
+const DefaultScriptLanguage = "javascript"
+
+type scriptBox struct {
+	m      sync.Mutex
+	lang   string
+	script Script
+}
+
+var (
+	_ core.StatefulBox = &scriptBox{}
+)
+
+// createScriptBox creates a box running a script for each tuple:
+//
+//	CREATE BOX b TYPE script FROM s WITH lang="javascript", code="...";
+//
+// It has following parameters:
+//
+//	code: the source code of the script (required)
+//	lang: the language of the script (default: "javascript")
+//
+// Other parameters are passed to the ScriptEngine.
+func createScriptBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	v, ok := params["code"]
+	if !ok {
+		return nil, fmt.Errorf("code parameter is missing")
+	}
+	code, err := data.AsString(v)
+	if err != nil {
+		return nil, fmt.Errorf("code parameter must be a string: %v", err)
+	}
+
+	lang := DefaultScriptLanguage
+	if v, ok := params["lang"]; ok {
+		l, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("lang parameter must be a string: %v", err)
+		}
+		lang = l
+	}
+
+	e, err := LookupScriptEngine(lang)
+	if err != nil {
+		return nil, err
+	}
+	s, err := e.Compile(ctx, code, params)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compile the %v script of the box '%v': %v", lang, ioParams.Name, err)
+	}
+	return &scriptBox{
+		lang:   strings.ToLower(lang),
+		script: s,
+	}, nil
+}
+
+func (b *scriptBox) Init(ctx *core.Context) error {
+	return nil
+}
+
+func (b *scriptBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	d := t.Data
+	if t.Flags.IsSet(core.TFSharedData) {
+		d = d.Copy()
+	}
+
+	b.m.Lock()
+	res, err := b.script.Process(ctx, d)
+	b.m.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var outs []data.Map
+	switch res.Type() {
+	case data.TypeNull:
+		return nil
+	case data.TypeMap:
+		m, _ := data.AsMap(res)
+		outs = []data.Map{m}
+	case data.TypeArray:
+		a, _ := data.AsArray(res)
+		outs = make([]data.Map, 0, len(a))
+		for i, e := range a {
+			m, err := data.AsMap(e)
+			if err != nil {
+				return fmt.Errorf("the %v-th element returned from the script isn't a map: %v", i, err)
+			}
+			outs = append(outs, m)
+		}
+	default:
+		return fmt.Errorf("the script must return a map, an array of maps, or null: %v", res.Type())
+	}
+
+	for _, m := range outs {
+		out := t.ShallowCopy()
+		out.Data = m
+		out.Flags.Clear(core.TFSharedData)
+		if err := w.Write(ctx, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *scriptBox) Terminate(ctx *core.Context) error {
+	if t, ok := b.script.(ScriptTerminator); ok {
+		return t.Terminate(ctx)
+	}
+	return nil
+}
+
+// Status returns the status of the box.
+func (b *scriptBox) Status() data.Map {
+	return data.Map{
+		"lang": data.String(b.lang),
+	}
+}
+
+func init() {
+	MustRegisterGlobalBoxCreator("script", BoxCreatorFunc(createScriptBox))
+}
@@ -0,0 +1,199 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+// testScriptEngine compiles "scripts" which are names of predefined
+// behaviors.
+type testScriptEngine struct{}
+
+func (testScriptEngine) Compile(ctx *core.Context, code string, params data.Map) (Script, error) {
+	switch code {
+	case "identity", "split", "drop", "invalid":
+		return &testScript{code: code}, nil
+	default:
+		return nil, errors.New("syntax error")
+	}
+}
+
+type testScript struct {
+	code       string
+	terminated bool
+}
+
+func (s *testScript) Process(ctx *core.Context, d data.Map) (data.Value, error) {
+	switch s.code {
+	case "identity":
+		return d, nil
+	case "split":
+		i, _ := data.AsInt(d["int"])
+		return data.Array{
+			data.Map{"int": data.Int(i), "half": data.Int(1)},
+			data.Map{"int": data.Int(i), "half": data.Int(2)},
+		}, nil
+	case "drop":
+		return data.Null{}, nil
+	default:
+		return data.Int(1), nil
+	}
+}
+
+func (s *testScript) Terminate(ctx *core.Context) error {
+	s.terminated = true
+	return nil
+}
+
+func init() {
+	MustRegisterScriptEngine("test_script", testScriptEngine{})
+}
+
+func TestScriptEngineRegistry(t *testing.T) {
+	Convey("Given the script engine registry", t, func() {
+		Convey("When looking up a registered engine", func() {
+			_, err := LookupScriptEngine("TEST_SCRIPT")
+
+			Convey("Then it should be found case-insensitively", func() {
+				So(err, ShouldBeNil)
+				So(ScriptLanguages(), ShouldContain, "test_script")
+			})
+		})
+
+		Convey("When looking up a missing engine", func() {
+			_, err := LookupScriptEngine("no_such_lang")
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When registering the same language again", func() {
+			err := RegisterScriptEngine("Test_Script", testScriptEngine{})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestScriptBox(t *testing.T) {
+	Convey("Given a topology builder", t, func() {
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE source TYPE dummy WITH num=4, resumable=false;`), ShouldBeNil)
+
+		run := func(code string) *tupleCollectorSink {
+			So(addBQLToTopology(tb, `
+				CREATE BOX b TYPE script FROM source WITH lang="test_script", code="`+code+`";
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM b;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sn, err := tp.Sink("snk")
+			So(err, ShouldBeNil)
+			return sn.Sink().(*tupleCollectorSink)
+		}
+
+		Convey("When the script returns a map", func() {
+			si := run("identity")
+
+			Convey("Then the sink should receive the same tuples", func() {
+				si.Wait(4)
+				So(si.len(), ShouldEqual, 4)
+				t := si.get(0)
+				So(t.Data, ShouldResemble, data.Map{"int": data.Int(1)})
+				So(t.Timestamp, ShouldResemble, time.Date(2015, time.April, 10, 10, 23, 0, 0, time.UTC))
+			})
+		})
+
+		Convey("When the script returns an array of maps", func() {
+			si := run("split")
+
+			Convey("Then the sink should receive a tuple for each map", func() {
+				si.Wait(8)
+				So(si.len(), ShouldEqual, 8)
+				So(si.get(0).Data, ShouldResemble, data.Map{"int": data.Int(1), "half": data.Int(1)})
+				So(si.get(1).Data, ShouldResemble, data.Map{"int": data.Int(1), "half": data.Int(2)})
+				So(si.get(1).Timestamp, ShouldResemble, si.get(0).Timestamp)
+			})
+		})
+
+		Convey("When the script returns null", func() {
+			si := run("drop")
+			sn, err := tp.Source("source")
+			So(err, ShouldBeNil)
+
+			Convey("Then the sink shouldn't receive anything", func() {
+				So(sn.State().Wait(core.TSStopped), ShouldEqual, core.TSStopped)
+				So(si.len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the script returns an invalid value", func() {
+			si := run("invalid")
+			sn, err := tp.Source("source")
+			So(err, ShouldBeNil)
+
+			Convey("Then the sink shouldn't receive anything", func() {
+				So(sn.State().Wait(core.TSStopped), ShouldEqual, core.TSStopped)
+				So(si.len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the box is removed", func() {
+			So(addBQLToTopology(tb, `CREATE BOX b TYPE script FROM source WITH lang="test_script", code="drop";`), ShouldBeNil)
+			bn, err := tp.Box("b")
+			So(err, ShouldBeNil)
+			s := bn.Box().(*scriptBox).script.(*testScript)
+			So(tp.Remove("b"), ShouldBeNil)
+
+			Convey("Then the script should be terminated", func() {
+				So(s.terminated, ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating a script box with invalid parameters", func() {
+			Convey("Then it should fail without code", func() {
+				So(addBQLToTopology(tb, `CREATE BOX b TYPE script FROM source WITH lang="test_script";`), ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail with an unknown language", func() {
+				So(addBQLToTopology(tb, `CREATE BOX b TYPE script FROM source WITH lang="cobol", code="identity";`), ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail with a script failing to compile", func() {
+				So(addBQLToTopology(tb, `CREATE BOX b TYPE script FROM source WITH lang="test_script", code="broken";`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a box from a missing input", func() {
+			err := addBQLToTopology(tb, `CREATE BOX b TYPE script FROM no_such_stream WITH lang="test_script", code="identity";`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then the box shouldn't be added", func() {
+				_, err := tp.Box("b")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a box of an unknown type", func() {
+			err := addBQLToTopology(tb, `CREATE BOX b TYPE no_such_box FROM source;`)
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
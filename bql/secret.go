@@ -0,0 +1,245 @@
+package bql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrSecretNotFound is returned from SecretProvider.Secret when the provider
+// doesn't have the secret.
+var ErrSecretNotFound = errors.New("the secret is not found")
+
+// SecretProvider provides secrets such as passwords and tokens used by
+// sources, sinks, and states. Secrets are referred from parameters of BQL
+// statements like `${secret:name}` and substituted when a node or a state is
+// created, so that secret values don't have to be written in BQL.
+type SecretProvider interface {
+	// Secret returns the value of the secret having the name. It returns
+	// ErrSecretNotFound when the provider doesn't have the secret.
+	Secret(name string) (string, error)
+}
+
+type multiSecretProvider []SecretProvider
+
+// NewMultiSecretProvider returns a SecretProvider looking up secrets in the
+// given providers in order.
+func NewMultiSecretProvider(ps ...SecretProvider) SecretProvider {
+	return multiSecretProvider(ps)
+}
+
+func (m multiSecretProvider) Secret(name string) (string, error) {
+	for _, p := range m {
+		s, err := p.Secret(name)
+		if err == ErrSecretNotFound {
+			continue
+		}
+		return s, err
+	}
+	return "", ErrSecretNotFound
+}
+
+type envSecretProvider struct {
+	prefix string
+}
+
+// NewEnvSecretProvider returns a SecretProvider reading secrets from
+// environment variables. The name of the variable is the prefix followed by
+// the upper-cased name of the secret. For example, `${secret:db_password}`
+// is read from SENSORBEE_SECRET_DB_PASSWORD when the prefix is
+// "SENSORBEE_SECRET_".
+func NewEnvSecretProvider(prefix string) SecretProvider {
+	return &envSecretProvider{
+		prefix: prefix,
+	}
+}
+
+func (e *envSecretProvider) Secret(name string) (string, error) {
+	s, ok := os.LookupEnv(e.prefix + strings.ToUpper(name))
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return s, nil
+}
+
+type fileSecretProvider struct {
+	dir string
+}
+
+// NewFileSecretProvider returns a SecretProvider reading secrets from files
+// in the directory. Each file has one secret and its name is the name of the
+// secret. A trailing newline of a file is removed. This works well with
+// secrets mounted as files by container orchestrators.
+func NewFileSecretProvider(dir string) SecretProvider {
+	return &fileSecretProvider{
+		dir: dir,
+	}
+}
+
+func (f *fileSecretProvider) Secret(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid secret name for files: %v", name)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(f.dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	s := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(s, "\r"), nil
+}
+
+type vaultSecretProvider struct {
+	cli   *http.Client
+	addr  string
+	token string
+	mount string
+}
+
+// NewVaultSecretProvider returns a SecretProvider reading secrets from the
+// KV secrets engine version 2 of HashiCorp Vault. addr is the address of the
+// Vault server like "https://vault.example.com:8200" and mount is the path
+// where the engine is mounted like "secret". When cli is nil,
+// http.DefaultClient is used.
+//
+// The name of a secret has the form "path#key". The key is "value" when it's
+// omitted. For example, `${secret:sensorbee/db#password}` refers to the key
+// "password" of the secret "sensorbee/db".
+func NewVaultSecretProvider(cli *http.Client, addr, token, mount string) SecretProvider {
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	return &vaultSecretProvider{
+		cli:   cli,
+		addr:  strings.TrimSuffix(addr, "/"),
+		token: token,
+		mount: strings.Trim(mount, "/"),
+	}
+}
+
+func (v *vaultSecretProvider) Secret(name string) (string, error) {
+	path, key := name, "value"
+	if i := strings.LastIndex(name, "#"); i >= 0 {
+		path, key = name[:i], name[i+1:]
+	}
+	if path == "" || key == "" {
+		return "", fmt.Errorf("invalid secret name for Vault: %v", name)
+	}
+
+	u := fmt.Sprintf("%v/v1/%v/data/%v", v.addr, v.mount, (&url.URL{Path: path}).EscapedPath())
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	res, err := v.cli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot read the secret from Vault: %v", err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return "", ErrSecretNotFound
+	case res.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("cannot read the secret from Vault: status %v", res.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("cannot decode the response from Vault: %v", err)
+	}
+	s, ok := body.Data.Data[key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	str, ok := s.(string)
+	if !ok {
+		return "", fmt.Errorf("the secret %v isn't a string", name)
+	}
+	return str, nil
+}
+
+var secretReference = regexp.MustCompile(`\$\{secret:([^}]+)\}`)
+
+// SubstituteSecrets returns a copy of params in which all references to
+// secrets in strings like `${secret:name}` are replaced with values provided
+// by p. A reference can be a part of a string like
+// "postgres://user:${secret:db_password}@localhost/db". It also returns
+// the values of secrets substituted so that the caller can redact them.
+func SubstituteSecrets(p SecretProvider, params data.Map) (data.Map, []string, error) {
+	var secrets []string
+	var subst func(v data.Value) (data.Value, error)
+	subst = func(v data.Value) (data.Value, error) {
+		switch v := v.(type) {
+		case data.String:
+			var err error
+			s := secretReference.ReplaceAllStringFunc(string(v), func(ref string) string {
+				if err != nil {
+					return ref
+				}
+				name := secretReference.FindStringSubmatch(ref)[1]
+				s, e := p.Secret(name)
+				if e != nil {
+					if e == ErrSecretNotFound {
+						err = fmt.Errorf("the secret '%v' is not found", name)
+					} else {
+						err = fmt.Errorf("cannot get the secret '%v': %v", name, e)
+					}
+					return ref
+				}
+				secrets = append(secrets, s)
+				return s
+			})
+			if err != nil {
+				return nil, err
+			}
+			return data.String(s), nil
+
+		case data.Map:
+			m := make(data.Map, len(v))
+			for k, e := range v {
+				s, err := subst(e)
+				if err != nil {
+					return nil, err
+				}
+				m[k] = s
+			}
+			return m, nil
+
+		case data.Array:
+			a := make(data.Array, len(v))
+			for i, e := range v {
+				s, err := subst(e)
+				if err != nil {
+					return nil, err
+				}
+				a[i] = s
+			}
+			return a, nil
+
+		default:
+			return v, nil
+		}
+	}
+
+	m, err := subst(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m.(data.Map), secrets, nil
+}
@@ -0,0 +1,557 @@
+package bql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/frame"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSerialReconnectInterval = 5 * time.Second
+	serialMaxLineLength            = 64 * 1024
+)
+
+// serialConfig is a configuration of a serial port.
+type serialConfig struct {
+	baudRate int
+	dataBits int
+	parity   string
+	stopBits int
+}
+
+// extractSerialConfig returns the configuration of a serial port from
+// following parameters:
+//
+//	baud_rate: the baud rate such as 9600 or 115200
+//	data_bits: 5, 6, 7, or 8 (default: 8)
+//	parity: "none", "odd", or "even" (default: "none")
+//	stop_bits: 1 or 2 (default: 1)
+//
+// It returns nil when none of them is given, in which case the current
+// configuration of the port is used. baud_rate is required when any of the
+// others is given. Serial ports can only be configured on Linux.
+func extractSerialConfig(params data.Map) (*serialConfig, error) {
+	given := false
+	for _, key := range []string{"baud_rate", "data_bits", "parity", "stop_bits"} {
+		if _, ok := params[key]; ok {
+			given = true
+		}
+	}
+	if !given {
+		return nil, nil
+	}
+
+	c := &serialConfig{
+		dataBits: 8,
+		parity:   "none",
+		stopBits: 1,
+	}
+	v, ok := params["baud_rate"]
+	if !ok {
+		return nil, errors.New("'baud_rate' parameter is required to configure the serial port")
+	}
+	b, err := data.AsInt(v)
+	if err != nil {
+		return nil, fmt.Errorf("'baud_rate' parameter must be an integer: %v", err)
+	}
+	c.baudRate = int(b)
+
+	if v, ok := params["data_bits"]; ok {
+		b, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'data_bits' parameter must be an integer: %v", err)
+		}
+		if b < 5 || b > 8 {
+			return nil, fmt.Errorf("'data_bits' parameter must be in [5, 8]: %v", b)
+		}
+		c.dataBits = int(b)
+	}
+	if v, ok := params["parity"]; ok {
+		p, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'parity' parameter must be a string: %v", err)
+		}
+		switch c.parity = strings.ToLower(p); c.parity {
+		case "none", "odd", "even":
+		default:
+			return nil, fmt.Errorf("'parity' parameter must be none, odd, or even: %v", p)
+		}
+	}
+	if v, ok := params["stop_bits"]; ok {
+		b, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'stop_bits' parameter must be an integer: %v", err)
+		}
+		if b != 1 && b != 2 {
+			return nil, fmt.Errorf("'stop_bits' parameter must be 1 or 2: %v", b)
+		}
+		c.stopBits = int(b)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// resolveSerialDevice returns the path of a device. When pattern has
+// wildcards such as "/dev/ttyUSB*", it returns the first path matching it so
+// that a device can be reopened after it's re-enumerated with another name.
+func resolveSerialDevice(pattern string) (string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return pattern, nil
+	}
+	ms, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(ms) == 0 {
+		return "", fmt.Errorf("no device matches %v", pattern)
+	}
+	sort.Strings(ms)
+	return ms[0], nil
+}
+
+// extractSerialDeviceParameter retrieves 'device' parameter. It validates
+// wildcards in the parameter.
+func extractSerialDeviceParameter(params data.Map) (string, error) {
+	v, ok := params["device"]
+	if !ok {
+		return "", errors.New("'device' parameter is missing")
+	}
+	d, err := data.AsString(v)
+	if err != nil {
+		return "", fmt.Errorf("'device' parameter must be a string: %v", err)
+	}
+	if d == "" {
+		return "", errors.New("'device' parameter must not be empty")
+	}
+	if _, err := filepath.Match(d, ""); err != nil {
+		return "", fmt.Errorf("'device' parameter has an invalid pattern: %v", err)
+	}
+	return d, nil
+}
+
+func extractReconnectIntervalParameter(params data.Map, def time.Duration) (time.Duration, error) {
+	v, ok := params["reconnect_interval"]
+	if !ok {
+		return def, nil
+	}
+	d, err := data.ToDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("'reconnect_interval' parameter should have a duration: %v", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("'reconnect_interval' parameter must be positive: %v", v)
+	}
+	return d, nil
+}
+
+// extractSerialFramingParameter retrieves 'framing' parameter, which is
+// "line" or "binary". It returns "line" when the parameter is missing.
+func extractSerialFramingParameter(params data.Map) (string, error) {
+	v, ok := params["framing"]
+	if !ok {
+		return "line", nil
+	}
+	f, err := data.AsString(v)
+	if err != nil {
+		return "", fmt.Errorf("'framing' parameter must be a string: %v", err)
+	}
+	switch f = strings.ToLower(f); f {
+	case "line", "binary":
+		return f, nil
+	}
+	return "", fmt.Errorf("'framing' parameter must be line or binary: %v", f)
+}
+
+// serialSource reads a serial port. With the line framing, it emits a tuple
+// having "line" for each line without line terminators. With the binary
+// framing, it decodes fixed-size frames with layout. When header isn't
+// empty, each frame starts with it and bytes before it are skipped so that
+// the source can synchronize with frames after noise or a restart.
+//
+// The timestamp of a tuple is the time when it's read.
+//
+// When opening or reading the device fails, for example because the device
+// is unplugged, GenerateStream returns an error and the source is reopened
+// by the supervisor, which can be configured by reconnect_ parameters of
+// CREATE SOURCE. The device is resolved again on each reopen. When the
+// device is a regular file, the source stops at the end of the file.
+type serialSource struct {
+	device   string
+	config   *serialConfig
+	layout   *frame.Layout
+	header   []byte
+	ioParams *IOParams
+	stopCh   chan struct{}
+
+	m       sync.Mutex
+	stopped bool
+
+	// f is the device being read. It's closed by Stop to abort reading.
+	f *os.File
+}
+
+func (s *serialSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	f, path, regular, err := s.open()
+	if err == nil {
+		var writeErr error
+		writeErr, err = s.read(ctx, w, f, path)
+		s.close()
+		if writeErr != nil {
+			return writeErr
+		}
+		if err == nil && regular {
+			return nil
+		}
+	}
+
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	return fmt.Errorf("cannot read the serial port %v: %v", s.device, err)
+}
+
+// open opens the device. It also returns the resolved path of the device and
+// whether it's a regular file.
+func (s *serialSource) open() (*os.File, string, bool, error) {
+	path, err := resolveSerialDevice(s.device)
+	if err != nil {
+		return nil, "", false, err
+	}
+	f, err := openSerialPort(path, os.O_RDONLY, s.config)
+	if err != nil {
+		return nil, "", false, err
+	}
+	regular := false
+	if fi, err := f.Stat(); err == nil {
+		regular = fi.Mode().IsRegular()
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		f.Close()
+		return nil, "", false, errors.New("the source has already been stopped")
+	}
+	s.f = f
+	return f, path, regular, nil
+}
+
+func (s *serialSource) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.f != nil {
+		s.f.Close()
+		s.f = nil
+	}
+}
+
+// read emits tuples until the device reaches EOF or fails. It returns an
+// error from w as writeErr and an error from the device as readErr.
+func (s *serialSource) read(ctx *core.Context, w core.Writer, f io.Reader, path string) (writeErr, readErr error) {
+	write := func(m data.Map) error {
+		t := core.NewTuple(m)
+		t.Timestamp = ctx.Clock().Now()
+		t.ProcTimestamp = t.Timestamp
+		return w.Write(ctx, t)
+	}
+
+	if s.layout == nil {
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 4096), serialMaxLineLength)
+		for sc.Scan() {
+			line := strings.TrimRight(sc.Text(), "\r")
+			if err := write(data.Map{"line": data.String(line)}); err != nil {
+				return err, nil
+			}
+		}
+		return nil, sc.Err()
+	}
+
+	r := bufio.NewReader(f)
+	buf := make([]byte, s.layout.Size)
+	for {
+		skipped := 0
+		for len(s.header) > 0 {
+			b, err := r.Peek(len(s.header))
+			if err == io.EOF {
+				return nil, nil
+			} else if err != nil {
+				return nil, err
+			}
+			if bytes.Equal(b, s.header) {
+				break
+			}
+			r.Discard(1)
+			skipped++
+		}
+		if skipped > 0 {
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("device", path).WithField("skipped_bytes", skipped).
+				Warning("Skipped bytes before the frame header")
+		}
+
+		if n, err := io.ReadFull(r, buf); err == io.EOF {
+			return nil, nil
+		} else if err == io.ErrUnexpectedEOF {
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("device", path).
+				WithField("body", hex.EncodeToString(buf[:n])).
+				Warning("Ignoring a partial frame at the end of the input")
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		m, err := s.layout.Decode(buf)
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("device", path).
+				WithField("body", hex.EncodeToString(buf)).
+				Warning("Ignoring a malformed frame")
+			continue
+		}
+		if err := write(m); err != nil {
+			return err, nil
+		}
+	}
+}
+
+func (s *serialSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	close(s.stopCh)
+	if s.f != nil {
+		s.f.Close()
+	}
+	return nil
+}
+
+// createSerialSource creates a source reading a serial port. It accepts
+// following parameters:
+//
+//	device: the path of the device such as "/dev/ttyUSB0". It can have
+//	    wildcards such as "/dev/ttyUSB*", or be a stable path such as
+//	    "/dev/serial/by-id/...", to follow re-enumerated devices (required)
+//	baud_rate, data_bits, parity, stop_bits: the configuration of the port
+//	    described in extractSerialConfig
+//	framing: "line" or "binary" (default: "line")
+//	header: a hex string of bytes starting each frame of the binary framing
+//
+// The binary framing also accepts parameters of frame.NewLayout.
+func createSerialSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &serialSource{
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+	}
+	var err error
+	if s.device, err = extractSerialDeviceParameter(params); err != nil {
+		return nil, err
+	}
+	if s.config, err = extractSerialConfig(params); err != nil {
+		return nil, err
+	}
+
+	framing, err := extractSerialFramingParameter(params)
+	if err != nil {
+		return nil, err
+	}
+	if framing == "binary" {
+		if s.layout, err = frame.NewLayout(params); err != nil {
+			return nil, fmt.Errorf("parameters of the binary framing are invalid: %v", err)
+		}
+		if v, ok := params["header"]; ok {
+			h, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("'header' parameter must be a string: %v", err)
+			}
+			if s.header, err = hex.DecodeString(h); err != nil {
+				return nil, fmt.Errorf("'header' parameter must be a hex string: %v", err)
+			}
+			if len(s.header) > s.layout.Size {
+				return nil, fmt.Errorf("'header' parameter must not be longer than frames: %v", h)
+			}
+		}
+	} else if _, ok := params["header"]; ok {
+		return nil, errors.New("'header' parameter requires the binary framing")
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("serial", SourceCreatorFunc(createSerialSource))
+}
+
+// serialSink writes tuples to a serial port. With the line framing, tuples
+// are formatted by a TupleFormatter and terminated by lineEnding. With the
+// binary framing, the Blob at field is written as it is.
+//
+// The device is opened on the first write and reopened after a write fails.
+// Writes fail without reopening the device until reconnectInterval passes
+// after the last failure.
+type serialSink struct {
+	device            string
+	config            *serialConfig
+	f                 TupleFormatter
+	lineEnding        string
+	field             data.Path
+	reconnectInterval time.Duration
+	ioParams          *IOParams
+
+	m      sync.Mutex
+	closed bool
+
+	// w is the device being written. It's nil while the device is closed.
+	w *os.File
+
+	// failedAt is the time when the last write or open failed.
+	failedAt time.Time
+}
+
+func (s *serialSink) Write(ctx *core.Context, t *core.Tuple) error {
+	var b []byte
+	if s.field != nil {
+		v, err := t.Data.Get(s.field)
+		if err != nil {
+			return err
+		}
+		if b, err = data.AsBlob(v); err != nil {
+			return fmt.Errorf("the frame must be a blob: %v", err)
+		}
+	} else {
+		line, err := s.f.FormatTuple(t)
+		if err != nil {
+			return err
+		}
+		b = []byte(line + s.lineEnding)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	now := ctx.Clock().Now()
+	if s.w == nil {
+		if !s.failedAt.IsZero() && now.Sub(s.failedAt) < s.reconnectInterval {
+			return errors.New("the serial port isn't available")
+		}
+		path, err := resolveSerialDevice(s.device)
+		if err == nil {
+			s.w, err = openSerialPort(path, os.O_WRONLY, s.config)
+		}
+		if err != nil {
+			s.failedAt = now
+			return err
+		}
+		if !s.failedAt.IsZero() {
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("device", path).Info("Reopened the serial port")
+		}
+	}
+	if _, err := s.w.Write(b); err != nil {
+		s.w.Close()
+		s.w = nil
+		s.failedAt = now
+		return err
+	}
+	return nil
+}
+
+func (s *serialSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.w != nil {
+		return s.w.Close()
+	}
+	return nil
+}
+
+// createSerialSink creates a sink writing a serial port. It accepts
+// following parameters:
+//
+//	device, baud_rate, data_bits, parity, stop_bits: same as the serial
+//	    source
+//	reconnect_interval: the minimum interval between reopens of the device
+//	    after writing fails (default: 5s)
+//	framing: "line" or "binary" (default: "line")
+//	line_ending: the terminator of lines (default: "\n")
+//	field: the path of the Blob written with the binary framing (required
+//	    for the binary framing)
+//
+// The line framing also accepts parameters of NewTupleFormatter and writes
+// JSON by default.
+func createSerialSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	s := &serialSink{
+		lineEnding: "\n",
+		ioParams:   ioParams,
+	}
+	var err error
+	if s.device, err = extractSerialDeviceParameter(params); err != nil {
+		return nil, err
+	}
+	if s.config, err = extractSerialConfig(params); err != nil {
+		return nil, err
+	}
+	if s.reconnectInterval, err = extractReconnectIntervalParameter(params, defaultSerialReconnectInterval); err != nil {
+		return nil, err
+	}
+
+	framing, err := extractSerialFramingParameter(params)
+	if err != nil {
+		return nil, err
+	}
+	if framing == "binary" {
+		v, ok := params["field"]
+		if !ok {
+			return nil, errors.New("'field' parameter is required for the binary framing")
+		}
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'field' parameter must be a string: %v", err)
+		}
+		if s.field, err = data.CompilePath(f); err != nil {
+			return nil, fmt.Errorf("'field' parameter doesn't have a valid path: %v", err)
+		}
+		return s, nil
+	}
+
+	if s.f, err = NewTupleFormatter(params); err != nil {
+		return nil, err
+	}
+	if v, ok := params["line_ending"]; ok {
+		if s.lineEnding, err = data.AsString(v); err != nil {
+			return nil, fmt.Errorf("'line_ending' parameter must be a string: %v", err)
+		}
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSinkCreator("serial", SinkCreatorFunc(createSerialSink))
+}
@@ -0,0 +1,98 @@
+//go:build linux
+// +build linux
+
+package bql
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var serialBaudRates = map[int]uint32{
+	1200:   syscall.B1200,
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+	230400: syscall.B230400,
+	460800: syscall.B460800,
+	921600: syscall.B921600,
+}
+
+var serialDataBits = map[int]uint32{
+	5: syscall.CS5,
+	6: syscall.CS6,
+	7: syscall.CS7,
+	8: syscall.CS8,
+}
+
+func (c *serialConfig) validate() error {
+	if _, ok := serialBaudRates[c.baudRate]; !ok {
+		return fmt.Errorf("'baud_rate' parameter has an unsupported baud rate: %v", c.baudRate)
+	}
+	return nil
+}
+
+// openSerialPort opens a serial port with flag such as os.O_RDONLY. When c
+// isn't nil, the port is configured to be raw with c. Otherwise, the current
+// configuration, which can be set by stty, is used as it is.
+//
+// The port is opened in the non-blocking mode so that opening it doesn't
+// wait for the carrier. Reads and writes are still blocking because the
+// runtime polls the port.
+func openSerialPort(path string, flag int, c *serialConfig) (*os.File, error) {
+	f, err := os.OpenFile(path, flag|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return f, nil
+	}
+
+	rc, err := f.SyscallConn()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	var errno syscall.Errno
+	err = rc.Control(func(fd uintptr) {
+		t := syscall.Termios{}
+		if _, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS,
+			uintptr(unsafe.Pointer(&t))); errno != 0 {
+			return
+		}
+		speed := serialBaudRates[c.baudRate]
+		t.Iflag = 0
+		t.Oflag = 0
+		t.Lflag = 0
+		t.Cflag = speed | serialDataBits[c.dataBits] | syscall.CREAD | syscall.CLOCAL
+		switch c.parity {
+		case "odd":
+			t.Cflag |= syscall.PARENB | syscall.PARODD
+			t.Iflag |= syscall.INPCK
+		case "even":
+			t.Cflag |= syscall.PARENB
+			t.Iflag |= syscall.INPCK
+		}
+		if c.stopBits == 2 {
+			t.Cflag |= syscall.CSTOPB
+		}
+		t.Cc[syscall.VMIN] = 1
+		t.Cc[syscall.VTIME] = 0
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCSETS,
+			uintptr(unsafe.Pointer(&t)))
+	})
+	if err == nil && errno != 0 {
+		err = errno
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("cannot configure the serial port: %v", err)
+	}
+	return f, nil
+}
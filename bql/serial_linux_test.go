@@ -0,0 +1,87 @@
+package bql
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"os"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// openPTY opens a pseudo terminal and returns its master and the path of its
+// slave, which works as a serial port.
+func openPTY() (*os.File, string, error) {
+	m, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	var n, unlock uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, m.Fd(), syscall.TIOCSPTLCK,
+		uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		m.Close()
+		return nil, "", errno
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, m.Fd(), syscall.TIOCGPTN,
+		uintptr(unsafe.Pointer(&n))); errno != 0 {
+		m.Close()
+		return nil, "", errno
+	}
+	return m, fmt.Sprintf("/dev/pts/%d", n), nil
+}
+
+func TestSerialSourceOnPTY(t *testing.T) {
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Skipf("pseudo terminals aren't available: %v", err)
+	}
+	defer master.Close()
+
+	Convey("Given a serial source reading a pseudo terminal", t, func() {
+		ctx := core.NewContext(nil)
+		s, err := createSerialSource(ctx, &IOParams{}, data.Map{
+			"device":    data.String(slave),
+			"baud_rate": data.Int(9600),
+			"data_bits": data.Int(7),
+			"parity":    data.String("even"),
+			"stop_bits": data.Int(2),
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When the terminal receives lines", func() {
+			ch := make(chan *core.Tuple, 1)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					ch <- t
+					return nil
+				}))
+			}()
+			Reset(func() {
+				s.Stop(ctx)
+				<-done
+			})
+			_, err := master.Write([]byte("hello\r\n"))
+			So(err, ShouldBeNil)
+			t := <-ch
+
+			Convey("Then the port should be configured and the line should be emitted", func() {
+				So(t.Data["line"], ShouldEqual, data.String("hello"))
+
+				f, err := os.OpenFile(slave, os.O_RDONLY|syscall.O_NOCTTY, 0)
+				So(err, ShouldBeNil)
+				defer f.Close()
+				tio := syscall.Termios{}
+				_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS,
+					uintptr(unsafe.Pointer(&tio)))
+				So(errno, ShouldEqual, 0)
+				// Pseudo terminals ignore data bits and parity.
+				So(tio.Cflag&syscall.CSTOPB, ShouldNotEqual, 0)
+				So(tio.Cflag&syscall.CLOCAL, ShouldNotEqual, 0)
+				So(tio.Lflag&syscall.ICANON, ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+// +build !linux
+
+package bql
+
+import (
+	"errors"
+	"os"
+)
+
+func (c *serialConfig) validate() error {
+	return errors.New("serial ports cannot be configured on this platform")
+}
+
+// openSerialPort opens a serial port with flag such as os.O_RDONLY. Since
+// configuring serial ports is only supported on Linux, c must be nil and the
+// port should be configured by stty in advance.
+func openSerialPort(path string, flag int, c *serialConfig) (*os.File, error) {
+	if c != nil {
+		return nil, c.validate()
+	}
+	return os.OpenFile(path, flag, 0)
+}
@@ -0,0 +1,237 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSerialSource(t *testing.T) {
+	Convey("Given a directory for devices", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_serial")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		dev := filepath.Join(dir, "ttyUSB0")
+
+		read := func(params data.Map) []*core.Tuple {
+			s, err := createSerialSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			w := &tupleCollectorSink{}
+			So(s.GenerateStream(ctx, w), ShouldBeNil)
+			return w.Tuples
+		}
+
+		Convey("When reading lines", func() {
+			So(ioutil.WriteFile(dev, []byte("T=21.5\r\n\nT=21.6"), 0644), ShouldBeNil)
+			ts := read(data.Map{"device": data.String(dev)})
+
+			Convey("Then each line should be emitted without terminators", func() {
+				So(len(ts), ShouldEqual, 3)
+				So(ts[0].Data, ShouldResemble, data.Map{"line": data.String("T=21.5")})
+				So(ts[1].Data, ShouldResemble, data.Map{"line": data.String("")})
+				So(ts[2].Data, ShouldResemble, data.Map{"line": data.String("T=21.6")})
+			})
+		})
+
+		Convey("When reading binary frames having headers", func() {
+			So(ioutil.WriteFile(dev, []byte{
+				0x01, 0xaa, // noise
+				0xaa, 0x55, 0x00, 0x10,
+				0xaa, 0x55, 0x01, 0x00,
+				0x00,
+				0xaa, 0x55, 0xff, 0xff,
+				0xaa, 0x55, 0x00, // partial
+			}, 0644), ShouldBeNil)
+			ts := read(data.Map{
+				"device":  data.String(dev),
+				"framing": data.String("binary"),
+				"header":  data.String("AA55"),
+				"fields": data.Array{
+					data.Map{"name": data.String("v"), "offset": data.Int(2), "type": data.String("uint16")},
+				},
+			})
+
+			Convey("Then frames should be synchronized with headers", func() {
+				So(len(ts), ShouldEqual, 3)
+				So(ts[0].Data, ShouldResemble, data.Map{"v": data.Int(16)})
+				So(ts[1].Data, ShouldResemble, data.Map{"v": data.Int(256)})
+				So(ts[2].Data, ShouldResemble, data.Map{"v": data.Int(65535)})
+			})
+		})
+
+		Convey("When the device is missing without the supervisor", func() {
+			s, err := createSerialSource(ctx, &IOParams{}, data.Map{
+				"device": data.String(dev),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then GenerateStream should fail", func() {
+				So(s.GenerateStream(ctx, &tupleCollectorSink{}), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the device appears after the source starts", func() {
+			src, err := createSerialSource(ctx, &IOParams{}, data.Map{
+				"device": data.String(filepath.Join(dir, "ttyUSB*")),
+			})
+			So(err, ShouldBeNil)
+			s := core.NewSupervisedSource(src, &core.SupervisorConfig{
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+			})
+			w := &tupleCollectorSink{}
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, w)
+			}()
+			time.Sleep(10 * time.Millisecond)
+			tmp := filepath.Join(dir, "tmp")
+			So(ioutil.WriteFile(tmp, []byte("hello\n"), 0644), ShouldBeNil)
+			So(os.Rename(tmp, filepath.Join(dir, "ttyUSB3")), ShouldBeNil)
+
+			Convey("Then it should be opened", func() {
+				So(<-done, ShouldBeNil)
+				So(len(w.Tuples), ShouldEqual, 1)
+				So(w.Tuples[0].Data["line"], ShouldEqual, data.String("hello"))
+			})
+		})
+
+		Convey("When stopping the source waiting for the device", func() {
+			src, err := createSerialSource(ctx, &IOParams{}, data.Map{
+				"device": data.String(dev),
+			})
+			So(err, ShouldBeNil)
+			s := core.NewSupervisedSource(src, nil)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, &tupleCollectorSink{})
+			}()
+			So(s.Stop(ctx), ShouldBeNil)
+
+			Convey("Then it should stop", func() {
+				So(<-done, ShouldBeNil)
+				So(s.Stop(ctx), ShouldBeNil)
+			})
+		})
+
+		Convey("When creating sources with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{},
+					{"device": data.String("")},
+					{"device": data.String("/dev/ttyUSB[")},
+					{"device": data.String(dev), "parity": data.String("even")},
+					{"device": data.String(dev), "baud_rate": data.Int(12345)},
+					{"device": data.String(dev), "baud_rate": data.Int(9600), "data_bits": data.Int(9)},
+					{"device": data.String(dev), "baud_rate": data.Int(9600), "parity": data.String("mark")},
+					{"device": data.String(dev), "baud_rate": data.Int(9600), "stop_bits": data.Int(3)},
+					{"device": data.String(dev), "framing": data.String("json")},
+					{"device": data.String(dev), "header": data.String("aa")},
+					{"device": data.String(dev), "framing": data.String("binary")},
+					{"device": data.String(dev), "framing": data.String("binary"), "header": data.String("xx"),
+						"fields": data.Array{data.Map{"name": data.String("v"), "type": data.String("uint8")}}},
+					{"device": data.String(dev), "framing": data.String("binary"), "header": data.String("aa55"),
+						"fields": data.Array{data.Map{"name": data.String("v"), "type": data.String("uint8")}}},
+				} {
+					_, err := createSerialSource(ctx, &IOParams{}, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestSerialSink(t *testing.T) {
+	Convey("Given a device", t, func() {
+		ctx := core.NewContext(nil)
+		dir, err := ioutil.TempDir("", "sbtest_serial")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		dev := filepath.Join(dir, "ttyS0")
+		So(ioutil.WriteFile(dev, nil, 0644), ShouldBeNil)
+
+		Convey("When writing lines", func() {
+			s, err := createSerialSink(ctx, &IOParams{}, data.Map{
+				"device":      data.String(dev),
+				"line_ending": data.String("\r\n"),
+			})
+			So(err, ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"a": data.Int(1)})), ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"a": data.Int(2)})), ShouldBeNil)
+			So(s.Close(ctx), ShouldBeNil)
+
+			Convey("Then they should be written as JSON", func() {
+				b, err := ioutil.ReadFile(dev)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "{\"a\":1}\r\n{\"a\":2}\r\n")
+				So(s.Write(ctx, core.NewTuple(data.Map{"a": data.Int(3)})), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When writing binary frames", func() {
+			s, err := createSerialSink(ctx, &IOParams{}, data.Map{
+				"device":  data.String(dev),
+				"framing": data.String("binary"),
+				"field":   data.String("frame"),
+			})
+			So(err, ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"frame": data.Blob{0xaa, 0x55, 0x00}})), ShouldBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{"frame": data.Int(1)})), ShouldNotBeNil)
+			So(s.Write(ctx, core.NewTuple(data.Map{})), ShouldNotBeNil)
+			So(s.Close(ctx), ShouldBeNil)
+
+			Convey("Then blobs should be written as they are", func() {
+				b, err := ioutil.ReadFile(dev)
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, []byte{0xaa, 0x55, 0x00})
+			})
+		})
+
+		Convey("When the device is missing", func() {
+			s, err := createSerialSink(ctx, &IOParams{}, data.Map{
+				"device":             data.String(filepath.Join(dir, "ttyUSB*")),
+				"reconnect_interval": data.String("50ms"),
+			})
+			So(err, ShouldBeNil)
+			t := core.NewTuple(data.Map{"a": data.Int(1)})
+			So(s.Write(ctx, t), ShouldNotBeNil)
+
+			Convey("Then it should be opened after the interval", func() {
+				So(ioutil.WriteFile(filepath.Join(dir, "ttyUSB1"), nil, 0644), ShouldBeNil)
+				So(s.Write(ctx, t), ShouldNotBeNil)
+				time.Sleep(60 * time.Millisecond)
+				So(s.Write(ctx, t), ShouldBeNil)
+				So(s.Close(ctx), ShouldBeNil)
+				b, err := ioutil.ReadFile(filepath.Join(dir, "ttyUSB1"))
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "{\"a\":1}\n")
+			})
+		})
+
+		Convey("When creating sinks with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{},
+					{"device": data.String(dev), "framing": data.String("binary")},
+					{"device": data.String(dev), "framing": data.String("binary"), "field": data.String("a[")},
+					{"device": data.String(dev), "line_ending": data.Int(1)},
+					{"device": data.String(dev), "codec": data.String("xml")},
+					{"device": data.String(dev), "stop_bits": data.Int(2)},
+				} {
+					_, err := createSerialSink(ctx, &IOParams{}, p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
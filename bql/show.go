@@ -0,0 +1,275 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+)
+
+// entityDefinition has the type name and parameters given to a CREATE
+// statement and the statement itself formatted by Format. Parameters are
+// kept as written in the statement so that references to secrets aren't
+// resolved.
+type entityDefinition struct {
+	typeName string
+	params   data.Map
+	stmt     string
+}
+
+const (
+	nodeDefinitionPrefix  = "node:"
+	stateDefinitionPrefix = "state:"
+)
+
+func (tb *TopologyBuilder) define(key, name, typeName string, params data.Map, stmt interface{}) {
+	if isTemporaryNode(name) {
+		return
+	}
+	f, _ := Format(stmt)
+	tb.defsMutex.Lock()
+	defer tb.defsMutex.Unlock()
+	if tb.defs == nil {
+		tb.defs = map[string]*entityDefinition{}
+	}
+	tb.defs[key+strings.ToLower(name)] = &entityDefinition{
+		typeName: typeName,
+		params:   params,
+		stmt:     f,
+	}
+}
+
+func (tb *TopologyBuilder) undefine(key, name string) {
+	tb.defsMutex.Lock()
+	defer tb.defsMutex.Unlock()
+	delete(tb.defs, key+strings.ToLower(name))
+}
+
+func (tb *TopologyBuilder) definition(key, name string) *entityDefinition {
+	tb.defsMutex.RLock()
+	defer tb.defsMutex.RUnlock()
+	return tb.defs[key+strings.ToLower(name)]
+}
+
+// defineStmt records the definition of the entity created by the statement.
+// Statements which don't create an entity are ignored.
+func (tb *TopologyBuilder) defineStmt(stmt interface{}) {
+	params := specParams
+	switch stmt := stmt.(type) {
+	case parser.CreateSourceStmt:
+		tb.define(nodeDefinitionPrefix, string(stmt.Name), string(stmt.Type), params(stmt.SourceSinkSpecsAST), stmt)
+	case parser.CreateSinkStmt:
+		tb.define(nodeDefinitionPrefix, string(stmt.Name), string(stmt.Type), params(stmt.SourceSinkSpecsAST), stmt)
+	case parser.CreateBoxStmt:
+		tb.define(nodeDefinitionPrefix, string(stmt.Name), string(stmt.Type), params(stmt.SourceSinkSpecsAST), stmt)
+	case parser.CreateStreamAsSelectStmt:
+		tb.define(nodeDefinitionPrefix, string(stmt.Name), "select", selectParams(stmt.Select), stmt)
+	case parser.CreateStreamAsSelectUnionStmt:
+		tb.define(nodeDefinitionPrefix, string(stmt.Name), "select_union", selectParams(stmt.SelectUnionStmt), stmt)
+	case parser.CreateStateStmt:
+		tb.define(stateDefinitionPrefix, string(stmt.Name), string(stmt.Type), params(stmt.SourceSinkSpecsAST), stmt)
+	}
+}
+
+// specParams returns parameters given to a WITH clause as written in the
+// statement. They're also set to the meta information of the node so that
+// they're shown in the graph of the topology.
+func specParams(specs parser.SourceSinkSpecsAST) data.Map {
+	m := make(data.Map, len(specs.Params))
+	for _, kv := range specs.Params {
+		m[string(kv.Key)] = kv.Value
+	}
+	return m
+}
+
+// selectParams returns parameters of a stream created by a SELECT statement.
+func selectParams(query fmt.Stringer) data.Map {
+	return data.Map{
+		"query": data.String(query.String()),
+	}
+}
+
+// RunShowStmt returns information of entities listed by the given ShowStmt.
+// Each row has "name", "type", "status", "params", "connections", and
+// "statement" and rows are sorted by their names. "statement" is the
+// statement which defined the entity formatted by Format. Secrets in
+// parameters and statements are redacted.
+//
+// "connections" has "inputs" and "outputs" of a node. Nodes internally
+// created by the TopologyBuilder aren't listed and connections are looked
+// through them. "status" and "connections" are null and an empty map for
+// states and UDFs, respectively. "statement" is null when the entity wasn't
+// defined by a statement, e.g. UDFs.
+func (tb *TopologyBuilder) RunShowStmt(stmt *parser.ShowStmt) ([]data.Map, error) {
+	if stmt.Topology != "" && string(stmt.Topology) != tb.topology.Name() {
+		return nil, fmt.Errorf("the statement refers to the topology '%v' but is run on '%v'",
+			stmt.Topology, tb.topology.Name())
+	}
+
+	var (
+		rows []data.Map
+		err  error
+	)
+	switch stmt.Target {
+	case parser.SourcesTarget:
+		rows = tb.showNodes(core.NTSource)
+	case parser.StreamsTarget:
+		rows = tb.showNodes(core.NTBox)
+	case parser.SinksTarget:
+		rows = tb.showNodes(core.NTSink)
+	case parser.StatesTarget:
+		rows, err = tb.showStates()
+	case parser.UDFsTarget:
+		rows, err = tb.showUDFs()
+	default:
+		return nil, fmt.Errorf("SHOW %v is unsupported", stmt.Target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(showRowsByName(rows))
+	return rows, nil
+}
+
+func (tb *TopologyBuilder) showNodes(nodeType core.NodeType) []data.Map {
+	g := tb.topology.Graph()
+	inputs := map[string][]string{}
+	outputs := map[string][]string{}
+	for _, e := range g.Edges {
+		r, s := strings.ToLower(e.Receiver), strings.ToLower(e.Sender)
+		inputs[r] = append(inputs[r], e.Sender)
+		outputs[s] = append(outputs[s], e.Receiver)
+	}
+
+	redactor := tb.topology.Context().Secrets
+	rows := []data.Map{}
+	for _, n := range g.Nodes {
+		if n.Type != nodeType || isTemporaryNode(n.Name) {
+			continue
+		}
+		row := data.Map{
+			"name":   data.String(n.Name),
+			"type":   data.Null{},
+			"status": data.String(n.State.String()),
+			"params": data.Map{},
+			"connections": data.Map{
+				"inputs":  connectedNodes(n.Name, inputs),
+				"outputs": connectedNodes(n.Name, outputs),
+			},
+			"statement": data.Null{},
+		}
+		if def := tb.definition(nodeDefinitionPrefix, n.Name); def != nil {
+			row["type"] = data.String(def.typeName)
+			row["params"] = redactor.RedactMap(def.params)
+			row["statement"] = redactor.Redact(data.String(def.stmt))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// connectedNodes returns names of nodes adjacent to the node having the
+// name. Temporary nodes are looked through.
+func connectedNodes(name string, adj map[string][]string) data.Array {
+	names := []string{}
+	visited := map[string]bool{strings.ToLower(name): true}
+	var visit func(string)
+	visit = func(name string) {
+		for _, n := range adj[strings.ToLower(name)] {
+			if visited[strings.ToLower(n)] {
+				continue
+			}
+			visited[strings.ToLower(n)] = true
+			if isTemporaryNode(n) {
+				visit(n)
+				continue
+			}
+			names = append(names, n)
+		}
+	}
+	visit(name)
+
+	sort.Strings(names)
+	a := make(data.Array, len(names))
+	for i, n := range names {
+		a[i] = data.String(n)
+	}
+	return a
+}
+
+func (tb *TopologyBuilder) showStates() ([]data.Map, error) {
+	ctx := tb.topology.Context()
+	states, err := ctx.SharedStates.List()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]data.Map, 0, len(states))
+	for name := range states {
+		typeName, err := ctx.SharedStates.Type(name)
+		if err != nil {
+			if core.IsNotExist(err) {
+				continue // removed after List was called
+			}
+			return nil, err
+		}
+		params := data.Map{}
+		var stmt data.Value = data.Null{}
+		if def := tb.definition(stateDefinitionPrefix, name); def != nil && def.typeName == typeName {
+			params = ctx.Secrets.RedactMap(def.params)
+			stmt = ctx.Secrets.Redact(data.String(def.stmt))
+		}
+		rows = append(rows, data.Map{
+			"name":        data.String(name),
+			"type":        data.String(typeName),
+			"status":      data.Null{},
+			"params":      params,
+			"connections": data.Map{},
+			"statement":   stmt,
+		})
+	}
+	return rows, nil
+}
+
+func (tb *TopologyBuilder) showUDFs() ([]data.Map, error) {
+	l, ok := tb.Reg.(interface {
+		List() (map[string]udf.UDF, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("the function registry doesn't support listing UDFs")
+	}
+	fs, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]data.Map, 0, len(fs))
+	for name := range fs {
+		rows = append(rows, data.Map{
+			"name":        data.String(name),
+			"type":        data.String("function"),
+			"status":      data.Null{},
+			"params":      data.Map{},
+			"connections": data.Map{},
+			"statement":   data.Null{},
+		})
+	}
+	return rows, nil
+}
+
+type showRowsByName []data.Map
+
+func (r showRowsByName) Len() int      { return len(r) }
+func (r showRowsByName) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r showRowsByName) Less(i, j int) bool {
+	return strings.ToLower(showRowName(r[i])) < strings.ToLower(showRowName(r[j]))
+}
+
+func showRowName(m data.Map) string {
+	n, _ := data.AsString(m["name"])
+	return n
+}
@@ -0,0 +1,190 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestShowStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(tb.SourceCreators.Register("any_params", SourceCreatorFunc(
+			func(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+				return createDummySource(ctx, ioParams, data.Map{})
+			})), ShouldBeNil)
+		dt.Context().Secrets.Add("hunter2")
+
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE s1 TYPE dummy WITH num=4;
+			CREATE PAUSED SOURCE s2 TYPE any_params WITH password="hunter2";
+			CREATE STREAM b1 AS SELECT ISTREAM * FROM s1 [RANGE 1 TUPLES];
+			CREATE STREAM u AS SELECT ISTREAM * FROM s1 [RANGE 1 TUPLES]
+				UNION ALL SELECT ISTREAM * FROM s2 [RANGE 1 TUPLES];
+			CREATE SINK k TYPE collector;
+			INSERT INTO k FROM u;
+			CREATE STATE st TYPE dummy_uds WITH num=5;
+		`), ShouldBeNil)
+
+		show := func(s string) ([]data.Map, error) {
+			stmt, _, err := parser.New().ParseStmt(s)
+			So(err, ShouldBeNil)
+			showStmt := stmt.(parser.ShowStmt)
+			return tb.RunShowStmt(&showStmt)
+		}
+
+		Convey("When running SHOW SOURCES", func() {
+			rows, err := show("SHOW SOURCES")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should list all sources", func() {
+				So(len(rows), ShouldEqual, 2)
+				So(rows[0], ShouldResemble, data.Map{
+					"name":   data.String("s1"),
+					"type":   data.String("dummy"),
+					"status": data.String("paused"),
+					"params": data.Map{"num": data.Int(4)},
+					"connections": data.Map{
+						"inputs":  data.Array{},
+						"outputs": data.Array{data.String("b1"), data.String("u")},
+					},
+					"statement": data.String("CREATE PAUSED SOURCE s1 TYPE dummy WITH num=4;"),
+				})
+			})
+
+			Convey("Then secrets in parameters should be redacted", func() {
+				So(rows[1]["name"], ShouldEqual, data.String("s2"))
+				So(rows[1]["params"], ShouldResemble, data.Map{
+					"password": data.String(core.RedactedValue),
+				})
+				So(rows[1]["statement"], ShouldEqual,
+					data.String(`CREATE PAUSED SOURCE s2 TYPE any_params WITH password="`+core.RedactedValue+`";`))
+			})
+		})
+
+		Convey("When getting the graph of the topology", func() {
+			params := map[string]data.Map{}
+			for _, n := range dt.Graph().Nodes {
+				params[n.Name] = n.Params
+			}
+
+			Convey("Then nodes should have parameters of their statements", func() {
+				So(params["s1"], ShouldResemble, data.Map{"num": data.Int(4)})
+				So(params["k"], ShouldBeEmpty)
+				So(params["b1"], ShouldResemble, data.Map{
+					"query": data.String("SELECT ISTREAM * FROM s1 [RANGE 1 TUPLES]"),
+				})
+				So(params["u"]["query"], ShouldNotBeNil)
+			})
+
+			Convey("Then secrets in parameters should be redacted", func() {
+				So(params["s2"], ShouldResemble, data.Map{
+					"password": data.String(core.RedactedValue),
+				})
+			})
+		})
+
+		Convey("When running SHOW STREAMS", func() {
+			rows, err := show("SHOW STREAMS")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should list streams without temporary ones", func() {
+				So(len(rows), ShouldEqual, 2)
+				So(rows[0]["name"], ShouldEqual, data.String("b1"))
+				So(rows[0]["type"], ShouldEqual, data.String("select"))
+				So(rows[1]["name"], ShouldEqual, data.String("u"))
+				So(rows[1]["type"], ShouldEqual, data.String("select_union"))
+			})
+
+			Convey("Then each stream should have its defining statement", func() {
+				So(rows[0]["statement"], ShouldEqual, data.String(`CREATE STREAM b1 AS
+  SELECT ISTREAM *
+  FROM s1 [RANGE 1 TUPLES];`))
+			})
+
+			Convey("Then connections should look through temporary nodes", func() {
+				So(rows[1]["connections"], ShouldResemble, data.Map{
+					"inputs":  data.Array{data.String("s1"), data.String("s2")},
+					"outputs": data.Array{data.String("k")},
+				})
+			})
+		})
+
+		Convey("When running SHOW SINKS", func() {
+			rows, err := show("SHOW SINKS")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should list all sinks", func() {
+				So(len(rows), ShouldEqual, 1)
+				So(rows[0]["name"], ShouldEqual, data.String("k"))
+				So(rows[0]["type"], ShouldEqual, data.String("collector"))
+			})
+		})
+
+		Convey("When running SHOW STATES", func() {
+			rows, err := show("SHOW STATES")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should list all states", func() {
+				So(len(rows), ShouldEqual, 1)
+				So(rows[0], ShouldResemble, data.Map{
+					"name":        data.String("st"),
+					"type":        data.String("dummy_uds"),
+					"status":      data.Null{},
+					"params":      data.Map{"num": data.Int(5)},
+					"connections": data.Map{},
+					"statement":   data.String("CREATE STATE st TYPE dummy_uds WITH num=5;"),
+				})
+			})
+		})
+
+		Convey("When running SHOW UDFS", func() {
+			rows, err := show("SHOW UDFS")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should list registered UDFs", func() {
+				So(len(rows), ShouldBeGreaterThan, 0)
+				names := map[string]bool{}
+				for _, r := range rows {
+					names[showRowName(r)] = true
+				}
+				So(names["str"], ShouldBeTrue)
+			})
+		})
+
+		Convey("When a source is dropped", func() {
+			So(addBQLToTopology(tb, `DROP SOURCE s1 CASCADE;`), ShouldBeNil)
+
+			Convey("Then SHOW SOURCES shouldn't list it", func() {
+				rows, err := show("SHOW SOURCES")
+				So(err, ShouldBeNil)
+				So(len(rows), ShouldEqual, 1)
+				So(rows[0]["name"], ShouldEqual, data.String("s2"))
+			})
+		})
+
+		Convey("When running SHOW with the name of the topology", func() {
+			rows, err := show("SHOW SINKS IN testTopology")
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+				So(len(rows), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When running SHOW with the name of another topology", func() {
+			_, err := show("SHOW SINKS IN another")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
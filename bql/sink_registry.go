@@ -130,8 +130,14 @@ func MustRegisterGlobalSinkCreator(typeName string, c SinkCreator) {
 // CopyGlobalSinkCreatorRegistry creates a new independent copy of the global
 // SinkCreatorRegistry.
 func CopyGlobalSinkCreatorRegistry() (SinkCreatorRegistry, error) {
+	return CopySinkCreatorRegistry(globalSinkCreatorRegistry)
+}
+
+// CopySinkCreatorRegistry creates a new independent copy of the given
+// SinkCreatorRegistry.
+func CopySinkCreatorRegistry(src SinkCreatorRegistry) (SinkCreatorRegistry, error) {
 	r := NewDefaultSinkCreatorRegistry()
-	m, err := globalSinkCreatorRegistry.List()
+	m, err := src.List()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,384 @@
+package bql
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const (
+	defaultSMTPSinkTimeout       = 30 * time.Second
+	defaultSMTPSinkMaxDigestSize = 1000
+)
+
+// smtpSink sends tuples by email. Each tuple is sent as an email unless
+// digest_interval is given. With digest_interval, tuples are sent together
+// as a digest email per interval.
+type smtpSink struct {
+	addr      string
+	host      string
+	from      string
+	to        []string
+	auth      smtp.Auth
+	security  string
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	subject   *template.Template
+	body      TupleFormatter
+
+	digest        bool
+	maxDigestSize int
+
+	// sendMutex serializes sending so that digests are sent in order.
+	sendMutex sync.Mutex
+
+	m      sync.Mutex
+	buf    []*core.Tuple
+	closed bool
+
+	stop        chan struct{}
+	flusherDone chan struct{}
+}
+
+func (s *smtpSink) Write(ctx *core.Context, t *core.Tuple) error {
+	if !s.digest {
+		s.sendMutex.Lock()
+		defer s.sendMutex.Unlock()
+		return s.send(ctx, []*core.Tuple{t})
+	}
+
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		return errors.New("the sink is already closed")
+	}
+	s.buf = append(s.buf, t)
+	full := len(s.buf) >= s.maxDigestSize
+	s.m.Unlock()
+
+	if full {
+		s.flush(ctx)
+	}
+	return nil
+}
+
+// Flush sends tuples in the buffer as a digest. It implements core.Flushable.
+func (s *smtpSink) Flush(ctx *core.Context) error {
+	return s.flush(ctx)
+}
+
+// flush sends tuples in the buffer as a digest.
+func (s *smtpSink) flush(ctx *core.Context) error {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	s.m.Lock()
+	ts := s.buf
+	s.buf = nil
+	s.m.Unlock()
+	if len(ts) == 0 {
+		return nil
+	}
+	if err := s.send(ctx, ts); err != nil {
+		ctx.ErrLog(err).WithField("num_tuples", len(ts)).Error("Cannot send a digest email")
+		return err
+	}
+	return nil
+}
+
+func (s *smtpSink) flusher(ctx *core.Context, interval time.Duration) {
+	defer close(s.flusherDone)
+	ticker := ctx.Clock().NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C():
+			s.flush(ctx)
+		}
+	}
+}
+
+// send sends tuples as an email. The subject is rendered from the first
+// tuple. The caller must hold sendMutex.
+func (s *smtpSink) send(ctx *core.Context, ts []*core.Tuple) error {
+	subj := bytes.NewBuffer(nil)
+	if err := s.subject.Execute(subj, toNative(ts[0].Data)); err != nil {
+		return err
+	}
+	lines := make([]string, 0, len(ts))
+	for _, t := range ts {
+		l, err := s.body.FormatTuple(t)
+		if err != nil {
+			if !s.digest {
+				return err
+			}
+			ctx.ErrLog(err).Error("Cannot format a tuple of a digest email")
+			continue
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) == 0 {
+		return errors.New("no tuple could be formatted")
+	}
+
+	msg, err := s.message(ctx.Clock().Now(), subj.String(), strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		return err
+	}
+	return s.deliver(msg)
+}
+
+func (s *smtpSink) message(now time.Time, subj, body string) ([]byte, error) {
+	b := bytes.NewBuffer(nil)
+	for _, h := range [][2]string{
+		{"From", s.from},
+		{"To", strings.Join(s.to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subj)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", `text/plain; charset="utf-8"`},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	} {
+		fmt.Fprintf(b, "%v: %v\r\n", h[0], h[1])
+	}
+	b.WriteString("\r\n")
+	w := quotedprintable.NewWriter(b)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (s *smtpSink) deliver(msg []byte) error {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.security == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.addr, s.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", s.addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if s.security == "starttls" {
+		ok, _ := c.Extension("STARTTLS")
+		if !ok {
+			return errors.New("the server doesn't support STARTTLS")
+		}
+		if err := c.StartTLS(s.tlsConfig); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if err := c.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	for _, to := range s.to {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (s *smtpSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.m.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		<-s.flusherDone
+	}
+	s.flush(ctx)
+	return nil
+}
+
+// createSMTPSink creates a sink sending tuples by email. It accepts the
+// following parameters:
+//
+//   - addr: the address of the SMTP server as "host:port", which is required.
+//   - from: the sender address, which is required.
+//   - to: a recipient address or an array of them, which is required.
+//   - subject: a Go text/template of the subject executed with the tuple's
+//     data like the "template" parameter of NewTupleFormatter. The subject of
+//     a digest is rendered from the first tuple. The default value is
+//     "SensorBee notification".
+//   - template, format: the body of each tuple as NewTupleFormatter accepts.
+//     A tuple is written as a JSON object by default.
+//   - digest_interval: the interval of sending a digest email having all
+//     tuples received in the interval. Each tuple is sent as an email when
+//     it's omitted.
+//   - max_digest_size: the maximum number of tuples in a digest. A digest is
+//     sent immediately when it becomes full. The default value is 1000.
+//   - username, password: credentials of PLAIN authentication.
+//   - security: "starttls", "tls", or "none". "tls" connects to the server
+//     over TLS, which is usually done on port 465. "starttls" requires the
+//     server to support STARTTLS. The default value is "starttls".
+//   - tls: TLS parameters described in TLSConfig.
+//   - timeout: the timeout of sending an email. The default value is 30
+//     seconds.
+func createSMTPSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	s := &smtpSink{
+		security: "starttls",
+	}
+
+	strParam := func(name string) (string, error) {
+		v, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("'%v' parameter is missing", name)
+		}
+		str, err := data.AsString(v)
+		if err != nil {
+			return "", fmt.Errorf("'%v' parameter must be a string: %v", name, err)
+		}
+		return str, nil
+	}
+
+	var err error
+	if s.addr, err = strParam("addr"); err != nil {
+		return nil, err
+	}
+	if s.host, _, err = net.SplitHostPort(s.addr); err != nil {
+		return nil, fmt.Errorf("'addr' parameter must be host:port: %v", err)
+	}
+	if s.from, err = strParam("from"); err != nil {
+		return nil, err
+	}
+	switch v := params["to"].(type) {
+	case nil:
+		return nil, errors.New("'to' parameter is missing")
+	case data.Array:
+		for _, e := range v {
+			to, err := data.AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("'to' parameter must be a string or an array of strings: %v", err)
+			}
+			s.to = append(s.to, to)
+		}
+		if len(s.to) == 0 {
+			return nil, errors.New("'to' parameter must have at least one address")
+		}
+	default:
+		to, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'to' parameter must be a string or an array of strings: %v", err)
+		}
+		s.to = []string{to}
+	}
+
+	subj := "SensorBee notification"
+	if _, ok := params["subject"]; ok {
+		if subj, err = strParam("subject"); err != nil {
+			return nil, err
+		}
+	}
+	if s.subject, err = template.New("subject").Option("missingkey=error").Parse(subj); err != nil {
+		return nil, fmt.Errorf("'subject' parameter has an invalid template: %v", err)
+	}
+	if s.body, err = NewTupleFormatter(params); err != nil {
+		return nil, err
+	}
+
+	if _, ok := params["security"]; ok {
+		if s.security, err = strParam("security"); err != nil {
+			return nil, err
+		}
+		switch s.security {
+		case "starttls", "tls", "none":
+		default:
+			return nil, fmt.Errorf("'security' parameter must be one of starttls, tls, and none: %v", s.security)
+		}
+	}
+	s.tlsConfig = &tls.Config{}
+	if tc, err := LookupTLSConfig(params); err != nil {
+		return nil, err
+	} else if tc != nil {
+		if s.tlsConfig, err = tc.ClientConfig(); err != nil {
+			return nil, err
+		}
+	}
+	if s.tlsConfig.ServerName == "" {
+		s.tlsConfig.ServerName = s.host
+	}
+
+	if _, ok := params["username"]; ok {
+		user, err := strParam("username")
+		if err != nil {
+			return nil, err
+		}
+		pass, err := strParam("password")
+		if err != nil {
+			return nil, err
+		}
+		s.auth = smtp.PlainAuth("", user, pass, s.host)
+	}
+
+	if s.timeout, err = extractDurationParameter(params, "timeout", defaultSMTPSinkTimeout); err != nil {
+		return nil, err
+	}
+	if s.maxDigestSize, err = extractPositiveIntParameter(params, "max_digest_size", defaultSMTPSinkMaxDigestSize); err != nil {
+		return nil, err
+	}
+	if _, ok := params["digest_interval"]; ok {
+		interval, err := extractDurationParameter(params, "digest_interval", 0)
+		if err != nil {
+			return nil, err
+		}
+		s.digest = true
+		s.stop = make(chan struct{})
+		s.flusherDone = make(chan struct{})
+		go s.flusher(ctx, interval)
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSinkCreator("smtp", SinkCreatorFunc(createSMTPSink))
+}
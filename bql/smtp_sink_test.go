@@ -0,0 +1,286 @@
+package bql
+
+import (
+	"bufio"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"mime/quotedprintable"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type smtpSinkTestMail struct {
+	from string
+	to   []string
+	auth string
+	data string
+}
+
+// body returns the decoded body of the mail.
+func (m *smtpSinkTestMail) body() string {
+	i := strings.Index(m.data, "\r\n\r\n")
+	if i < 0 {
+		return ""
+	}
+	b, _ := ioutil.ReadAll(quotedprintable.NewReader(strings.NewReader(m.data[i+4:])))
+	return strings.Replace(string(b), "\r\n", "\n", -1)
+}
+
+// smtpSinkTestServer is a minimal SMTP server accepting PLAIN authentication
+// without TLS.
+type smtpSinkTestServer struct {
+	l     net.Listener
+	m     sync.Mutex
+	mails []*smtpSinkTestMail
+}
+
+func newSMTPSinkTestServer() (*smtpSinkTestServer, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &smtpSinkTestServer{l: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s, nil
+}
+
+func (s *smtpSinkTestServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...interface{}) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+	m := &smtpSinkTestMail{}
+	reply("220 localhost ESMTP")
+	for {
+		l, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		l = strings.TrimRight(l, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(l, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			m.auth = l
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			m.from = l
+			reply("250 OK")
+		case "RCPT":
+			m.to = append(m.to, l)
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			lines := []string{}
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				lines = append(lines, l)
+			}
+			m.data = strings.Join(lines, "")
+			s.m.Lock()
+			s.mails = append(s.mails, m)
+			s.m.Unlock()
+			m = &smtpSinkTestMail{}
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Unsupported")
+		}
+	}
+}
+
+func (s *smtpSinkTestServer) addr() string {
+	return s.l.Addr().String()
+}
+
+func (s *smtpSinkTestServer) received() []*smtpSinkTestMail {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]*smtpSinkTestMail{}, s.mails...)
+}
+
+func (s *smtpSinkTestServer) Close() {
+	s.l.Close()
+}
+
+func TestSMTPSink(t *testing.T) {
+	newTuple := func(host string, v int) *core.Tuple {
+		return core.NewTuple(data.Map{
+			"host":  data.String(host),
+			"value": data.Int(v),
+		})
+	}
+
+	Convey("Given an SMTP server", t, func() {
+		srv, err := newSMTPSinkTestServer()
+		So(err, ShouldBeNil)
+		Reset(srv.Close)
+		clock := core.NewFakeClock(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+		ctx := core.NewContext(&core.ContextConfig{
+			Clock: clock,
+		})
+		params := data.Map{
+			"addr":     data.String(srv.addr()),
+			"from":     data.String("sensorbee@example.com"),
+			"to":       data.Array{data.String("a@example.com"), data.String("b@example.com")},
+			"subject":  data.String("Report of {{.host}}"),
+			"format":   data.String("{host}: {value}"),
+			"security": data.String("none"),
+		}
+
+		Convey("When sending tuples one by one", func() {
+			params["username"] = data.String("user")
+			params["password"] = data.String("pass")
+			si, err := createSMTPSink(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			So(si.Write(ctx, newTuple("h1", 1)), ShouldBeNil)
+			So(si.Write(ctx, newTuple("h2", 2)), ShouldBeNil)
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then each tuple should be sent as an email", func() {
+				ms := srv.received()
+				So(len(ms), ShouldEqual, 2)
+				So(ms[0].from, ShouldContainSubstring, "<sensorbee@example.com>")
+				So(ms[0].to, ShouldResemble, []string{"RCPT TO:<a@example.com>", "RCPT TO:<b@example.com>"})
+				So(ms[0].auth, ShouldStartWith, "AUTH PLAIN")
+				So(ms[0].data, ShouldContainSubstring, "Subject: Report of h1\r\n")
+				So(ms[0].data, ShouldContainSubstring, "To: a@example.com, b@example.com\r\n")
+				So(ms[0].body(), ShouldEqual, "h1: 1\n")
+				So(ms[1].body(), ShouldEqual, "h2: 2\n")
+			})
+		})
+
+		Convey("When sending tuples as a digest", func() {
+			params["digest_interval"] = data.String("1m")
+			si, err := createSMTPSink(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+			clock.BlockUntil(1)
+
+			So(si.Write(ctx, newTuple("h1", 1)), ShouldBeNil)
+			So(si.Write(ctx, newTuple("h2", 2)), ShouldBeNil)
+
+			Convey("Then no email should be sent before the interval", func() {
+				So(srv.received(), ShouldBeEmpty)
+			})
+
+			Convey("Then a digest should be sent after the interval", func() {
+				clock.Advance(time.Minute)
+				var ms []*smtpSinkTestMail
+				for i := 0; i < 100; i++ {
+					if ms = srv.received(); len(ms) > 0 {
+						break
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				So(len(ms), ShouldEqual, 1)
+				So(ms[0].data, ShouldContainSubstring, "Subject: Report of h1\r\n")
+				So(ms[0].body(), ShouldEqual, "h1: 1\nh2: 2\n")
+			})
+
+			Convey("Then the remaining tuples should be sent on close", func() {
+				So(si.Close(ctx), ShouldBeNil)
+				ms := srv.received()
+				So(len(ms), ShouldEqual, 1)
+				So(ms[0].body(), ShouldEqual, "h1: 1\nh2: 2\n")
+			})
+		})
+
+		Convey("When sending tuples as a digest with a maximum size", func() {
+			params["digest_interval"] = data.String("1h")
+			params["max_digest_size"] = data.Int(2)
+			si, err := createSMTPSink(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			for i := 0; i < 3; i++ {
+				So(si.Write(ctx, newTuple("h", i)), ShouldBeNil)
+			}
+
+			Convey("Then a full digest should be sent immediately", func() {
+				ms := srv.received()
+				So(len(ms), ShouldEqual, 1)
+				So(ms[0].body(), ShouldEqual, "h: 0\nh: 1\n")
+				So(si.Close(ctx), ShouldBeNil)
+				So(len(srv.received()), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When sending a tuple with STARTTLS to a server not supporting it", func() {
+			delete(params, "security")
+			si, err := createSMTPSink(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			err = si.Write(ctx, newTuple("h1", 1))
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(srv.received(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		base := func(k string, v data.Value) data.Map {
+			m := data.Map{
+				"addr": data.String("localhost:25"),
+				"from": data.String("a@example.com"),
+				"to":   data.String("b@example.com"),
+			}
+			if v == nil {
+				delete(m, k)
+			} else {
+				m[k] = v
+			}
+			return m
+		}
+		cases := map[string]data.Map{
+			"no addr":                    base("addr", nil),
+			"an addr without a port":     base("addr", data.String("localhost")),
+			"no from":                    base("from", nil),
+			"no to":                      base("to", nil),
+			"an empty to":                base("to", data.Array{}),
+			"a non-string to":            base("to", data.Array{data.Int(1)}),
+			"an invalid subject":         base("subject", data.String("{{.a")),
+			"an unknown security":        base("security", data.String("ssl")),
+			"a username only":            base("username", data.String("u")),
+			"a zero max digest size":     base("max_digest_size", data.Int(0)),
+			"a negative digest interval": base("digest_interval", data.Int(-1)),
+		}
+		for title, params := range cases {
+			params := params
+			Convey("When creating an SMTP sink with "+title, func() {
+				_, err := createSMTPSink(core.NewContext(nil), &IOParams{}, params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
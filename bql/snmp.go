@@ -0,0 +1,653 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/snmp"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultSNMPPort      = "161"
+	defaultSNMPCommunity = "public"
+	defaultSNMPInterval  = time.Minute
+	defaultSNMPTimeout   = 3 * time.Second
+	defaultSNMPRetries   = 1
+
+	// snmpGetBatchSize is the maximum number of OIDs in a GetRequest.
+	snmpGetBatchSize = 16
+
+	// snmpMaxRepetitions is max-repetitions of GetBulkRequests sent to walk
+	// subtrees.
+	snmpMaxRepetitions = 10
+
+	// maxSNMPWalkVarBinds is the maximum number of variables read from a
+	// subtree so that a broken agent doesn't make the walk endless.
+	maxSNMPWalkVarBinds = 10000
+)
+
+var (
+	snmpSysUpTime   = snmp.OID{1, 3, 6, 1, 2, 1, 1, 3, 0}
+	snmpTrapOID     = snmp.OID{1, 3, 6, 1, 6, 3, 1, 1, 4, 1, 0}
+	snmpGenericTrap = snmp.OID{1, 3, 6, 1, 6, 3, 1, 1, 5}
+)
+
+// snmpTarget is an agent polled by snmpSource.
+type snmpTarget struct {
+	address   string
+	community string
+	version   snmp.Version
+}
+
+// snmpSource polls agents and receives traps. Values are stored in a map
+// whose keys are names of OIDs resolved by the MIB, e.g. "ifDescr.1". OCTET
+// STRING values are String when they're printable UTF-8 strings and Blob
+// otherwise.
+//
+// Agents are polled concurrently at the interval and a tuple is emitted for
+// each agent responding to all requests:
+//
+//	type: "poll"
+//	agent: the address of the agent
+//	values: a map of values got or walked
+//
+// A tuple is emitted for each trap or inform received:
+//
+//	type: "trap"
+//	agent: the IP address of the sender
+//	version: "1" or "2c"
+//	community: the community of the trap
+//	trap: the name of the trap OID
+//	trap_oid: the trap OID in the dotted notation
+//	uptime: sysUpTime of the agent in hundredths of a second
+//	values: a map of variables of the trap
+//
+// SNMPv1 traps are converted to SNMPv2 trap OIDs as defined in RFC 3584.
+// Informs are acknowledged after they're written.
+type snmpSource struct {
+	targets       []*snmpTarget
+	get           []snmp.OID
+	walk          []snmp.OID
+	interval      time.Duration
+	timeout       time.Duration
+	retries       int
+	trapAddress   string
+	trapCommunity string
+	mib           *snmp.MIB
+	ioParams      *IOParams
+	stopCh        chan struct{}
+
+	requestID int32
+
+	// writeMutex serializes writes from the poller and the trap receiver.
+	writeMutex sync.Mutex
+}
+
+func (s *snmpSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		trapErr error
+		failed  = make(chan struct{})
+	)
+	write := func(t *core.Tuple) error {
+		s.writeMutex.Lock()
+		defer s.writeMutex.Unlock()
+		return w.Write(ctx, t)
+	}
+
+	if s.trapAddress != "" {
+		conn, err := net.ListenPacket("udp", s.trapAddress)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			conn.Close()
+			wg.Wait()
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.receiveTraps(ctx, conn, write); err != nil {
+				errOnce.Do(func() {
+					trapErr = err
+					close(failed)
+				})
+			}
+		}()
+	}
+
+	if len(s.targets) == 0 {
+		select {
+		case <-s.stopCh:
+			return nil
+		case <-failed:
+			return trapErr
+		}
+	}
+
+	clock := ctx.Clock()
+	next := clock.Now()
+	for {
+		for _, t := range s.poll(ctx, next) {
+			if err := write(t); err != nil {
+				return err
+			}
+		}
+
+		now := clock.Now()
+		next = next.Add(s.interval)
+		if next.Before(now) {
+			// delayed too much and should be rescheduled.
+			next = now.Add(s.interval)
+		}
+		timer := clock.NewTimer(next.Sub(now))
+		select {
+		case <-s.stopCh:
+			timer.Stop()
+			return nil
+		case <-failed:
+			timer.Stop()
+			return trapErr
+		case <-timer.C():
+		}
+	}
+}
+
+// poll polls all targets concurrently and returns tuples of targets which
+// responded.
+func (s *snmpSource) poll(ctx *core.Context, ts time.Time) []*core.Tuple {
+	tuples := make([]*core.Tuple, len(s.targets))
+	var wg sync.WaitGroup
+	for i, t := range s.targets {
+		wg.Add(1)
+		go func(i int, t *snmpTarget) {
+			defer wg.Done()
+			values, err := s.pollTarget(t)
+			if err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField("agent", t.address).Warning("Cannot poll the SNMP agent")
+				return
+			}
+			tuple := core.NewTuple(data.Map{
+				"type":   data.String("poll"),
+				"agent":  data.String(t.address),
+				"values": values,
+			})
+			tuple.Timestamp = ts
+			tuples[i] = tuple
+		}(i, t)
+	}
+	wg.Wait()
+
+	res := tuples[:0]
+	for _, t := range tuples {
+		if t != nil {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func (s *snmpSource) pollTarget(t *snmpTarget) (data.Map, error) {
+	conn, err := net.Dial("udp", t.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	values := data.Map{}
+	for i := 0; i < len(s.get); i += snmpGetBatchSize {
+		end := i + snmpGetBatchSize
+		if end > len(s.get) {
+			end = len(s.get)
+		}
+		pdu := &snmp.PDU{Type: snmp.GetRequest}
+		for _, o := range s.get[i:end] {
+			pdu.VarBinds = append(pdu.VarBinds, snmp.VarBind{OID: o})
+		}
+		res, err := s.request(conn, t, pdu)
+		if err != nil {
+			return nil, err
+		}
+		if res.ErrorStatus != 0 {
+			return nil, fmt.Errorf("the agent returned error status %v for %v",
+				res.ErrorStatus, s.errorOID(pdu, res))
+		}
+		for _, vb := range res.VarBinds {
+			s.setValue(values, vb)
+		}
+	}
+
+	for _, root := range s.walk {
+		if err := s.walkSubtree(conn, t, root, values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// walkSubtree reads all variables in the subtree by GetBulkRequest, or by
+// GetNextRequest in SNMPv1.
+func (s *snmpSource) walkSubtree(conn net.Conn, t *snmpTarget, root snmp.OID, values data.Map) error {
+	cur := root
+	for n := 0; n < maxSNMPWalkVarBinds; {
+		pdu := &snmp.PDU{
+			Type:     snmp.GetNextRequest,
+			VarBinds: []snmp.VarBind{{OID: cur}},
+		}
+		if t.version != snmp.Version1 {
+			pdu.Type = snmp.GetBulkRequest
+			pdu.ErrorIndex = snmpMaxRepetitions
+		}
+		res, err := s.request(conn, t, pdu)
+		if err != nil {
+			return err
+		}
+		if res.ErrorStatus == 2 && t.version == snmp.Version1 {
+			// noSuchName means the end of the MIB view in SNMPv1.
+			return nil
+		} else if res.ErrorStatus != 0 {
+			return fmt.Errorf("the agent returned error status %v while walking %v",
+				res.ErrorStatus, s.mib.Name(root))
+		}
+		if len(res.VarBinds) == 0 {
+			return nil
+		}
+		for _, vb := range res.VarBinds {
+			if vb.Type == snmp.EndOfMIBView || !vb.OID.HasPrefix(root) {
+				return nil
+			}
+			if vb.OID.Compare(cur) <= 0 {
+				return fmt.Errorf("the agent returned %v which doesn't follow %v", vb.OID, cur)
+			}
+			s.setValue(values, vb)
+			cur = vb.OID
+			n++
+		}
+	}
+	return fmt.Errorf("%v has more than %v variables", s.mib.Name(root), maxSNMPWalkVarBinds)
+}
+
+// request sends the request and waits for the response. It resends the
+// request when the agent doesn't respond in time.
+func (s *snmpSource) request(conn net.Conn, t *snmpTarget, pdu *snmp.PDU) (*snmp.PDU, error) {
+	pdu.RequestID = atomic.AddInt32(&s.requestID, 1) & 0x7fffffff
+	b, err := (&snmp.Message{
+		Version:   t.version,
+		Community: t.community,
+		PDU:       pdu,
+	}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 65535)
+	for i := 0; i <= s.retries; i++ {
+		if _, err := conn.Write(b); err != nil {
+			return nil, err
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(s.timeout)); err != nil {
+			return nil, err
+		}
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				if e, ok := err.(net.Error); ok && e.Timeout() {
+					break
+				}
+				return nil, err
+			}
+			m, err := snmp.Unmarshal(buf[:n])
+			if err != nil || m.PDU.Type != snmp.GetResponse || m.PDU.RequestID != pdu.RequestID {
+				// ignore broken and stale responses
+				continue
+			}
+			return m.PDU, nil
+		}
+	}
+	return nil, fmt.Errorf("the agent didn't respond in %v", s.timeout)
+}
+
+func (s *snmpSource) errorOID(req, res *snmp.PDU) string {
+	if i := res.ErrorIndex - 1; i >= 0 && i < len(req.VarBinds) {
+		return s.mib.Name(req.VarBinds[i].OID)
+	}
+	return "the request"
+}
+
+// setValue sets the value of the variable binding to values. Exceptions are
+// ignored.
+func (s *snmpSource) setValue(values data.Map, vb snmp.VarBind) {
+	if vb.Type.IsException() {
+		return
+	}
+	values[s.mib.Name(vb.OID)] = snmpValue(vb)
+}
+
+func snmpValue(vb snmp.VarBind) data.Value {
+	if vb.Type != snmp.OctetString {
+		return vb.Value
+	}
+	b, _ := data.AsBlob(vb.Value)
+	if !utf8.Valid(b) {
+		return vb.Value
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return vb.Value
+		}
+	}
+	return data.String(b)
+}
+
+// receiveTraps receives traps until the connection is closed.
+func (s *snmpSource) receiveTraps(ctx *core.Context, conn net.PacketConn, write func(t *core.Tuple) error) error {
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-s.stopCh:
+				return nil
+			default:
+			}
+			return err
+		}
+
+		m, err := snmp.Unmarshal(buf[:n])
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("sender", addr.String()).Warning("Ignoring a malformed SNMP message")
+			continue
+		}
+		switch m.PDU.Type {
+		case snmp.TrapV1, snmp.TrapV2, snmp.InformRequest:
+		default:
+			continue
+		}
+		if s.trapCommunity != "" && m.Community != s.trapCommunity {
+			ctx.Log().WithField("node_name", s.ioParams.Name).
+				WithField("sender", addr.String()).Warning("Ignoring a trap having a wrong community")
+			continue
+		}
+
+		t, err := s.trapTuple(addr, m)
+		if err != nil {
+			ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+				WithField("sender", addr.String()).Warning("Ignoring a malformed SNMP trap")
+			continue
+		}
+		if err := write(t); err != nil {
+			return err
+		}
+
+		if m.PDU.Type == snmp.InformRequest {
+			m.PDU.Type = snmp.GetResponse
+			b, err := m.Marshal()
+			if err == nil {
+				_, err = conn.WriteTo(b, addr)
+			}
+			if err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField("sender", addr.String()).Warning("Cannot acknowledge the inform")
+			}
+		}
+	}
+}
+
+func (s *snmpSource) trapTuple(addr net.Addr, m *snmp.Message) (*core.Tuple, error) {
+	var (
+		trapOID snmp.OID
+		uptime  data.Value
+		vbs     = m.PDU.VarBinds
+	)
+	if m.PDU.Type == snmp.TrapV1 {
+		p := m.PDU
+		if p.GenericTrap == 6 {
+			trapOID = append(append(snmp.OID{}, p.Enterprise...), 0, uint32(p.SpecificTrap))
+		} else if p.GenericTrap >= 0 && p.GenericTrap < 6 {
+			trapOID = append(append(snmp.OID{}, snmpGenericTrap...), uint32(p.GenericTrap+1))
+		} else {
+			return nil, fmt.Errorf("invalid generic trap: %v", p.GenericTrap)
+		}
+		uptime = data.Int(p.Timestamp)
+	} else {
+		if len(vbs) < 2 || !vbs[0].OID.Equal(snmpSysUpTime) || !vbs[1].OID.Equal(snmpTrapOID) ||
+			vbs[1].Type != snmp.ObjectIdentifier {
+			return nil, errors.New("the trap doesn't start with sysUpTime.0 and snmpTrapOID.0")
+		}
+		uptime = vbs[0].Value
+		str, _ := data.AsString(vbs[1].Value)
+		o, err := snmp.ParseOID(str)
+		if err != nil {
+			return nil, err
+		}
+		trapOID = o
+		vbs = vbs[2:]
+	}
+
+	values := data.Map{}
+	for _, vb := range vbs {
+		s.setValue(values, vb)
+	}
+	agent := addr.String()
+	if a, ok := addr.(*net.UDPAddr); ok {
+		agent = a.IP.String()
+	}
+	return core.NewTuple(data.Map{
+		"type":      data.String("trap"),
+		"agent":     data.String(agent),
+		"version":   data.String(m.Version.String()),
+		"community": data.String(m.Community),
+		"trap":      data.String(s.mib.Name(trapOID)),
+		"trap_oid":  data.String(trapOID.String()),
+		"uptime":    uptime,
+		"values":    values,
+	}), nil
+}
+
+func (s *snmpSource) Stop(ctx *core.Context) error {
+	close(s.stopCh)
+	return nil
+}
+
+// createSNMPSource creates an SNMP source. It accepts following parameters:
+//
+//	targets: an array of addresses of agents such as "router1:161", or maps
+//	    having address, community, and version
+//	community: the default community of targets (default: "public")
+//	version: the default version of targets, "1" or "2c" (default: "2c")
+//	get: an array of names or OIDs got from targets, e.g. "sysUpTime.0"
+//	walk: an array of names or OIDs of subtrees walked, e.g. "ifTable"
+//	interval: the interval between polls (default: 1m)
+//	timeout: the timeout of each request (default: 3s)
+//	retries: the number of retries of each request (default: 1)
+//	trap_address: the address receiving traps such as ":162"
+//	trap_community: the community of traps accepted (default: any)
+//	mibs: an array of MIB files or directories loaded to resolve names
+//
+// Either targets or trap_address is required. Targets require get or walk.
+func createSNMPSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &snmpSource{
+		interval: defaultSNMPInterval,
+		timeout:  defaultSNMPTimeout,
+		retries:  defaultSNMPRetries,
+		mib:      snmp.NewMIB(),
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+	}
+	getString := func(m data.Map, key string) (string, bool, error) {
+		v, ok := m[key]
+		if !ok {
+			return "", false, nil
+		}
+		str, err := data.AsString(v)
+		if err != nil {
+			return "", false, fmt.Errorf("'%v' parameter must be a string: %v", key, err)
+		}
+		return str, true, nil
+	}
+	getStrings := func(key string) ([]string, error) {
+		v, ok := params[key]
+		if !ok {
+			return nil, nil
+		}
+		if v.Type() == data.TypeString {
+			str, _ := data.AsString(v)
+			return []string{str}, nil
+		}
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'%v' parameter must be an array of strings: %v", key, err)
+		}
+		ss := make([]string, len(a))
+		for i, e := range a {
+			if ss[i], err = data.AsString(e); err != nil {
+				return nil, fmt.Errorf("'%v' parameter must be an array of strings: %v", key, err)
+			}
+		}
+		return ss, nil
+	}
+
+	// MIBs are loaded first to resolve names in get and walk.
+	mibs, err := getStrings("mibs")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range mibs {
+		if err := s.mib.Load(p); err != nil {
+			return nil, fmt.Errorf("'mibs' parameter has a MIB which cannot be loaded: %v", err)
+		}
+	}
+	if ns := s.mib.Unresolved(); len(ns) > 0 {
+		ctx.Log().WithField("node_name", ioParams.Name).WithField("names", ns).
+			Warning("Some names in MIBs cannot be resolved because their parents aren't loaded")
+	}
+
+	community := defaultSNMPCommunity
+	if c, ok, err := getString(params, "community"); err != nil {
+		return nil, err
+	} else if ok {
+		community = c
+	}
+	version := snmp.Version2c
+	if v, ok, err := getString(params, "version"); err != nil {
+		return nil, err
+	} else if ok {
+		if version, err = snmp.ParseVersion(v); err != nil {
+			return nil, fmt.Errorf("'version' parameter has an invalid version: %v", err)
+		}
+	}
+
+	if v, ok := params["targets"]; ok {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'targets' parameter must be an array: %v", err)
+		}
+		for i, e := range a {
+			t := &snmpTarget{
+				community: community,
+				version:   version,
+			}
+			if e.Type() == data.TypeString {
+				t.address, _ = data.AsString(e)
+			} else {
+				m, err := data.AsMap(e)
+				if err != nil {
+					return nil, fmt.Errorf("'targets' parameter has a target which is neither a string nor a map at %v", i)
+				}
+				if t.address, ok, err = getString(m, "address"); err != nil {
+					return nil, err
+				} else if !ok {
+					return nil, fmt.Errorf("'targets' parameter has a target without an address at %v", i)
+				}
+				if c, ok, err := getString(m, "community"); err != nil {
+					return nil, err
+				} else if ok {
+					t.community = c
+				}
+				if v, ok, err := getString(m, "version"); err != nil {
+					return nil, err
+				} else if ok {
+					if t.version, err = snmp.ParseVersion(v); err != nil {
+						return nil, fmt.Errorf("'targets' parameter has an invalid version at %v: %v", i, err)
+					}
+				}
+			}
+			if _, _, err := net.SplitHostPort(t.address); err != nil {
+				t.address = net.JoinHostPort(t.address, defaultSNMPPort)
+			}
+			s.targets = append(s.targets, t)
+		}
+	}
+
+	for key, dst := range map[string]*[]snmp.OID{
+		"get":  &s.get,
+		"walk": &s.walk,
+	} {
+		names, err := getStrings(key)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range names {
+			o, err := s.mib.Resolve(n)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter has an invalid OID: %v", key, err)
+			}
+			*dst = append(*dst, o)
+		}
+	}
+
+	for key, dst := range map[string]*time.Duration{
+		"interval": &s.interval,
+		"timeout":  &s.timeout,
+	} {
+		if v, ok := params[key]; ok {
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter should have a duration: %v", key, err)
+			}
+			if d <= 0 {
+				return nil, fmt.Errorf("'%v' parameter must be positive: %v", key, v)
+			}
+			*dst = d
+		}
+	}
+	if v, ok := params["retries"]; ok {
+		r, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'retries' parameter must be an integer: %v", err)
+		}
+		if r < 0 {
+			return nil, fmt.Errorf("'retries' parameter must not be negative: %v", r)
+		}
+		s.retries = int(r)
+	}
+
+	if s.trapAddress, _, err = getString(params, "trap_address"); err != nil {
+		return nil, err
+	}
+	if s.trapCommunity, _, err = getString(params, "trap_community"); err != nil {
+		return nil, err
+	}
+
+	if len(s.targets) == 0 && s.trapAddress == "" {
+		return nil, errors.New("either 'targets' or 'trap_address' parameter is required")
+	}
+	if len(s.targets) > 0 && len(s.get) == 0 && len(s.walk) == 0 {
+		return nil, errors.New("either 'get' or 'walk' parameter is required to poll targets")
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("snmp", SourceCreatorFunc(createSNMPSource))
+}
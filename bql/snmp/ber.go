@@ -0,0 +1,235 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errTruncated is returned when a message ends in the middle of a value.
+var errTruncated = errors.New("the message is truncated")
+
+// encoder builds BER encoded values. Values are written from the head and
+// constructed values are encoded by encoding their contents first.
+type encoder struct {
+	b []byte
+}
+
+func (e *encoder) tlv(tag byte, content []byte) {
+	e.b = append(e.b, tag)
+	e.b = appendLength(e.b, len(content))
+	e.b = append(e.b, content...)
+}
+
+// constructed encodes a constructed value whose contents are written by f.
+func (e *encoder) constructed(tag byte, f func(e *encoder) error) error {
+	c := &encoder{}
+	if err := f(c); err != nil {
+		return err
+	}
+	e.tlv(tag, c.b)
+	return nil
+}
+
+func (e *encoder) integer(tag byte, i int64) {
+	e.tlv(tag, encodeInteger(i))
+}
+
+func (e *encoder) unsigned(tag byte, u uint64) {
+	e.tlv(tag, encodeUnsigned(u))
+}
+
+func appendLength(b []byte, l int) []byte {
+	if l < 0x80 {
+		return append(b, byte(l))
+	}
+	var lb []byte
+	for ; l > 0; l >>= 8 {
+		lb = append([]byte{byte(l)}, lb...)
+	}
+	b = append(b, 0x80|byte(len(lb)))
+	return append(b, lb...)
+}
+
+// encodeInteger encodes a two's complement integer in the minimum number of
+// bytes.
+func encodeInteger(i int64) []byte {
+	n := 1
+	for v := i; v > 127 || v < -128; v >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for j := n - 1; j >= 0; j-- {
+		b[j] = byte(i)
+		i >>= 8
+	}
+	return b
+}
+
+// encodeUnsigned encodes an unsigned integer. A leading zero byte is added
+// when the most significant bit is set so that it isn't negative.
+func encodeUnsigned(u uint64) []byte {
+	var b []byte
+	for v := u; ; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+		if v < 0x100 {
+			break
+		}
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+func encodeOID(o OID) ([]byte, error) {
+	if len(o) < 2 {
+		return nil, fmt.Errorf("an OID must have at least two components: %v", o)
+	}
+	if o[0] > 2 || (o[0] < 2 && o[1] >= 40) {
+		return nil, fmt.Errorf("invalid OID: %v", o)
+	}
+	b := appendBase128(nil, o[0]*40+o[1])
+	for _, c := range o[2:] {
+		b = appendBase128(b, c)
+	}
+	return b, nil
+}
+
+func appendBase128(b []byte, c uint32) []byte {
+	var tmp [5]byte
+	n := 0
+	for {
+		tmp[n] = byte(c & 0x7f)
+		n++
+		c >>= 7
+		if c == 0 {
+			break
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := tmp[i]
+		if i > 0 {
+			v |= 0x80
+		}
+		b = append(b, v)
+	}
+	return b
+}
+
+// decoder reads BER encoded values.
+type decoder struct {
+	b []byte
+}
+
+func (d *decoder) empty() bool {
+	return len(d.b) == 0
+}
+
+// next reads the next value and returns its tag and contents.
+func (d *decoder) next() (byte, []byte, error) {
+	if len(d.b) < 2 {
+		return 0, nil, errTruncated
+	}
+	tag := d.b[0]
+	l := int(d.b[1])
+	p := 2
+	if l&0x80 != 0 {
+		n := l & 0x7f
+		if n == 0 || n > 4 {
+			return 0, nil, fmt.Errorf("unsupported length of %v bytes", n)
+		}
+		if len(d.b) < p+n {
+			return 0, nil, errTruncated
+		}
+		l = 0
+		for _, c := range d.b[p : p+n] {
+			l = l<<8 | int(c)
+		}
+		p += n
+	}
+	if l < 0 || len(d.b)-p < l {
+		return 0, nil, errTruncated
+	}
+	c := d.b[p : p+l]
+	d.b = d.b[p+l:]
+	return tag, c, nil
+}
+
+// expect reads the next value and checks its tag.
+func (d *decoder) expect(tag byte) ([]byte, error) {
+	t, c, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	if t != tag {
+		return nil, fmt.Errorf("unexpected tag 0x%02x where 0x%02x is expected", t, tag)
+	}
+	return c, nil
+}
+
+func (d *decoder) integer() (int64, error) {
+	c, err := d.expect(tagInteger)
+	if err != nil {
+		return 0, err
+	}
+	return decodeInteger(c)
+}
+
+func decodeInteger(c []byte) (int64, error) {
+	if len(c) == 0 || len(c) > 8 {
+		return 0, fmt.Errorf("invalid length of an integer: %v", len(c))
+	}
+	i := int64(int8(c[0]))
+	for _, b := range c[1:] {
+		i = i<<8 | int64(b)
+	}
+	return i, nil
+}
+
+func decodeUnsigned(c []byte) (uint64, error) {
+	if len(c) > 0 && c[0] == 0 {
+		c = c[1:]
+	}
+	if len(c) > 8 {
+		return 0, fmt.Errorf("invalid length of an unsigned integer: %v", len(c))
+	}
+	var u uint64
+	for _, b := range c {
+		u = u<<8 | uint64(b)
+	}
+	return u, nil
+}
+
+func decodeOID(c []byte) (OID, error) {
+	if len(c) == 0 {
+		return nil, errors.New("an OID cannot be empty")
+	}
+	var o OID
+	var v uint64
+	for i, b := range c {
+		v = v<<7 | uint64(b&0x7f)
+		if v > 0xffffffff {
+			return nil, errors.New("a component of an OID is too large")
+		}
+		if b&0x80 != 0 {
+			if i == len(c)-1 {
+				return nil, errTruncated
+			}
+			continue
+		}
+		if o == nil {
+			switch {
+			case v < 40:
+				o = OID{0, uint32(v)}
+			case v < 80:
+				o = OID{1, uint32(v - 40)}
+			default:
+				o = OID{2, uint32(v - 80)}
+			}
+		} else {
+			o = append(o, uint32(v))
+		}
+		v = 0
+	}
+	return o, nil
+}
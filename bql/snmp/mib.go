@@ -0,0 +1,429 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// builtinMIB has well-known nodes so that common OIDs can be resolved
+// without loading MIB files.
+const builtinMIB = `
+SENSORBEE-BUILTIN-MIB DEFINITIONS ::= BEGIN
+org OBJECT IDENTIFIER ::= { iso 3 }
+dod OBJECT IDENTIFIER ::= { org 6 }
+internet OBJECT IDENTIFIER ::= { dod 1 }
+directory OBJECT IDENTIFIER ::= { internet 1 }
+mgmt OBJECT IDENTIFIER ::= { internet 2 }
+mib-2 OBJECT IDENTIFIER ::= { mgmt 1 }
+transmission OBJECT IDENTIFIER ::= { mib-2 10 }
+experimental OBJECT IDENTIFIER ::= { internet 3 }
+private OBJECT IDENTIFIER ::= { internet 4 }
+enterprises OBJECT IDENTIFIER ::= { private 1 }
+security OBJECT IDENTIFIER ::= { internet 5 }
+snmpV2 OBJECT IDENTIFIER ::= { internet 6 }
+snmpDomains OBJECT IDENTIFIER ::= { snmpV2 1 }
+snmpProxys OBJECT IDENTIFIER ::= { snmpV2 2 }
+snmpModules OBJECT IDENTIFIER ::= { snmpV2 3 }
+
+system OBJECT IDENTIFIER ::= { mib-2 1 }
+sysDescr OBJECT IDENTIFIER ::= { system 1 }
+sysObjectID OBJECT IDENTIFIER ::= { system 2 }
+sysUpTime OBJECT IDENTIFIER ::= { system 3 }
+sysContact OBJECT IDENTIFIER ::= { system 4 }
+sysName OBJECT IDENTIFIER ::= { system 5 }
+sysLocation OBJECT IDENTIFIER ::= { system 6 }
+sysServices OBJECT IDENTIFIER ::= { system 7 }
+
+interfaces OBJECT IDENTIFIER ::= { mib-2 2 }
+ifNumber OBJECT IDENTIFIER ::= { interfaces 1 }
+ifTable OBJECT IDENTIFIER ::= { interfaces 2 }
+ifEntry OBJECT IDENTIFIER ::= { ifTable 1 }
+ifIndex OBJECT IDENTIFIER ::= { ifEntry 1 }
+ifDescr OBJECT IDENTIFIER ::= { ifEntry 2 }
+ifType OBJECT IDENTIFIER ::= { ifEntry 3 }
+ifMtu OBJECT IDENTIFIER ::= { ifEntry 4 }
+ifSpeed OBJECT IDENTIFIER ::= { ifEntry 5 }
+ifPhysAddress OBJECT IDENTIFIER ::= { ifEntry 6 }
+ifAdminStatus OBJECT IDENTIFIER ::= { ifEntry 7 }
+ifOperStatus OBJECT IDENTIFIER ::= { ifEntry 8 }
+ifLastChange OBJECT IDENTIFIER ::= { ifEntry 9 }
+ifInOctets OBJECT IDENTIFIER ::= { ifEntry 10 }
+ifInUcastPkts OBJECT IDENTIFIER ::= { ifEntry 11 }
+ifInNUcastPkts OBJECT IDENTIFIER ::= { ifEntry 12 }
+ifInDiscards OBJECT IDENTIFIER ::= { ifEntry 13 }
+ifInErrors OBJECT IDENTIFIER ::= { ifEntry 14 }
+ifInUnknownProtos OBJECT IDENTIFIER ::= { ifEntry 15 }
+ifOutOctets OBJECT IDENTIFIER ::= { ifEntry 16 }
+ifOutUcastPkts OBJECT IDENTIFIER ::= { ifEntry 17 }
+ifOutNUcastPkts OBJECT IDENTIFIER ::= { ifEntry 18 }
+ifOutDiscards OBJECT IDENTIFIER ::= { ifEntry 19 }
+ifOutErrors OBJECT IDENTIFIER ::= { ifEntry 20 }
+ifOutQLen OBJECT IDENTIFIER ::= { ifEntry 21 }
+ifSpecific OBJECT IDENTIFIER ::= { ifEntry 22 }
+
+snmpMIB OBJECT IDENTIFIER ::= { snmpModules 1 }
+snmpMIBObjects OBJECT IDENTIFIER ::= { snmpMIB 1 }
+snmpTrap OBJECT IDENTIFIER ::= { snmpMIBObjects 4 }
+snmpTrapOID OBJECT IDENTIFIER ::= { snmpTrap 1 }
+snmpTrapEnterprise OBJECT IDENTIFIER ::= { snmpTrap 3 }
+snmpTraps OBJECT IDENTIFIER ::= { snmpMIBObjects 5 }
+coldStart OBJECT IDENTIFIER ::= { snmpTraps 1 }
+warmStart OBJECT IDENTIFIER ::= { snmpTraps 2 }
+linkDown OBJECT IDENTIFIER ::= { snmpTraps 3 }
+linkUp OBJECT IDENTIFIER ::= { snmpTraps 4 }
+authenticationFailure OBJECT IDENTIFIER ::= { snmpTraps 5 }
+END
+`
+
+// mibMacros are macros of SMI defining OIDs. Definitions using other macros
+// such as TEXTUAL-CONVENTION don't have OIDs.
+var mibMacros = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"OBJECT-IDENTITY":    true,
+	"MODULE-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+	"TRAP-TYPE":          true,
+}
+
+// MIB has names of OIDs defined in MIB modules. Names are resolved without
+// module names, so names defined in multiple modules are resolved to the
+// one loaded last.
+//
+// A MIB isn't safe for concurrent use while loading modules.
+type MIB struct {
+	names map[string]OID
+	oids  map[string]string
+
+	// pending has definitions whose parents aren't defined yet. They're
+	// resolved when their parents are loaded.
+	pending []*mibDef
+}
+
+type mibDef struct {
+	module string
+	name   string
+	parent string
+	subIDs []uint32
+}
+
+// NewMIB creates a MIB having well-known nodes such as system, interfaces,
+// and standard traps.
+func NewMIB() *MIB {
+	m := &MIB{
+		names: map[string]OID{
+			"ccitt":           {0},
+			"iso":             {1},
+			"joint-iso-ccitt": {2},
+		},
+		oids: map[string]string{
+			"0": "ccitt",
+			"1": "iso",
+			"2": "joint-iso-ccitt",
+		},
+	}
+	if err := m.Parse([]byte(builtinMIB)); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Load loads a MIB file or all files in a directory.
+func (m *MIB) Load(path string) error {
+	st, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !st.IsDir() {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := m.Parse(b); err != nil {
+			return fmt.Errorf("cannot parse %v: %v", path, err)
+		}
+		return nil
+	}
+
+	fs, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, f := range fs {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		if err := m.Load(filepath.Join(path, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse parses MIB modules in SMIv1 or SMIv2 and adds OIDs defined in them.
+// It only reads definitions of OIDs and ignores other parts of modules.
+func (m *MIB) Parse(b []byte) error {
+	ts, err := tokenizeMIB(string(b))
+	if err != nil {
+		return err
+	}
+
+	module := ""
+	for i := 0; i < len(ts); i++ {
+		t := ts[i]
+		if i+1 < len(ts) && ts[i+1] == "DEFINITIONS" {
+			module = t
+			continue
+		}
+		if !isMIBValueName(t) || i+1 >= len(ts) {
+			continue
+		}
+
+		var def *mibDef
+		next := ts[i+1]
+		switch {
+		case next == "OBJECT" && i+3 < len(ts) && ts[i+2] == "IDENTIFIER" && ts[i+3] == "::=":
+			def, i, err = parseMIBOIDValue(ts, i+4)
+		case next == "TRAP-TYPE":
+			def, i, err = parseMIBTrapType(ts, i+2)
+		case mibMacros[next]:
+			j := i + 2
+			for j < len(ts) && ts[j] != "::=" {
+				j++
+			}
+			def, i, err = parseMIBOIDValue(ts, j+1)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("invalid definition of %v: %v", t, err)
+		}
+		def.module, def.name = module, t
+		m.pending = append(m.pending, def)
+	}
+	m.resolve()
+	return nil
+}
+
+// parseMIBOIDValue parses a value like { parent 1 2 } or { iso(1) org(3) }
+// starting at ts[i]. It returns the index of the closing brace.
+func parseMIBOIDValue(ts []string, i int) (*mibDef, int, error) {
+	if i >= len(ts) || ts[i] != "{" {
+		return nil, i, errors.New("the value must be an OID in braces")
+	}
+	def := &mibDef{}
+	first := true
+	for i++; i < len(ts); i++ {
+		t := ts[i]
+		switch {
+		case t == "}":
+			if first {
+				return nil, i, errors.New("the OID is empty")
+			}
+			return def, i, nil
+
+		case i+3 < len(ts) && ts[i+1] == "(" && ts[i+3] == ")":
+			// name(number)
+			v, err := strconv.ParseUint(ts[i+2], 10, 32)
+			if err != nil {
+				return nil, i, fmt.Errorf("invalid sub-identifier: %v", ts[i+2])
+			}
+			def.subIDs = append(def.subIDs, uint32(v))
+			i += 3
+
+		case first && isMIBValueName(t):
+			def.parent = t
+
+		default:
+			v, err := strconv.ParseUint(t, 10, 32)
+			if err != nil {
+				return nil, i, fmt.Errorf("invalid sub-identifier: %v", t)
+			}
+			def.subIDs = append(def.subIDs, uint32(v))
+		}
+		first = false
+	}
+	return nil, i, errors.New("the OID isn't closed")
+}
+
+// parseMIBTrapType parses the body of TRAP-TYPE of SMIv1 starting at ts[i].
+// A trap is mapped to enterprise.0.number as defined in RFC 3584.
+func parseMIBTrapType(ts []string, i int) (*mibDef, int, error) {
+	def := &mibDef{}
+	for ; i < len(ts) && ts[i] != "::="; i++ {
+		if ts[i] == "ENTERPRISE" && i+1 < len(ts) {
+			def.parent = ts[i+1]
+		}
+	}
+	if def.parent == "" {
+		return nil, i, errors.New("ENTERPRISE is missing")
+	}
+	if i+1 >= len(ts) {
+		return nil, i, errors.New("the trap number is missing")
+	}
+	v, err := strconv.ParseUint(ts[i+1], 10, 32)
+	if err != nil {
+		return nil, i, fmt.Errorf("invalid trap number: %v", ts[i+1])
+	}
+	def.subIDs = []uint32{0, uint32(v)}
+	return def, i + 1, nil
+}
+
+// resolve assigns OIDs to pending definitions whose parents are known.
+func (m *MIB) resolve() {
+	for {
+		var rest []*mibDef
+		for _, d := range m.pending {
+			var o OID
+			if d.parent != "" {
+				p, ok := m.names[d.parent]
+				if !ok {
+					rest = append(rest, d)
+					continue
+				}
+				o = append(o, p...)
+			}
+			o = append(o, d.subIDs...)
+			m.names[d.name] = o
+			if d.module != "" {
+				m.names[d.module+"::"+d.name] = o
+			}
+			m.oids[o.String()] = d.name
+		}
+		if len(rest) == len(m.pending) {
+			return
+		}
+		m.pending = rest
+	}
+}
+
+// Unresolved returns names of definitions whose parents aren't loaded.
+func (m *MIB) Unresolved() []string {
+	var ns []string
+	for _, d := range m.pending {
+		ns = append(ns, d.name)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+// Name returns the name of the OID. When the OID itself isn't defined, the
+// name of its nearest ancestor followed by the remaining sub-identifiers is
+// returned, e.g. "ifDescr.3". It returns the OID in the dotted notation when
+// no ancestor is defined.
+func (m *MIB) Name(o OID) string {
+	for l := len(o); l > 0; l-- {
+		n, ok := m.oids[o[:l].String()]
+		if !ok {
+			continue
+		}
+		if l == len(o) {
+			return n
+		}
+		return n + "." + o[l:].String()
+	}
+	return o.String()
+}
+
+// Resolve returns the OID of a name such as "ifDescr", "IF-MIB::ifDescr.1",
+// or "1.3.6.1.2.1.2.2.1.2.1". A name can be followed by sub-identifiers.
+func (m *MIB) Resolve(s string) (OID, error) {
+	if s == "" {
+		return nil, errors.New("a name cannot be empty")
+	}
+	if c := s[0]; c == '.' || ('0' <= c && c <= '9') {
+		return ParseOID(s)
+	}
+
+	name, suffix := s, ""
+	if i := strings.Index(s, "::"); i >= 0 {
+		if j := strings.Index(s[i:], "."); j >= 0 {
+			name, suffix = s[:i+j], s[i+j+1:]
+		}
+	} else if i := strings.Index(s, "."); i >= 0 {
+		name, suffix = s[:i], s[i+1:]
+	}
+	o, ok := m.names[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown name: %v", name)
+	}
+	o = append(OID{}, o...)
+	if suffix != "" {
+		sub, err := ParseOID(suffix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sub-identifiers of %v: %v", s, err)
+		}
+		o = append(o, sub...)
+	}
+	return o, nil
+}
+
+// isMIBValueName returns true when the token can be the name of a value,
+// which starts with a lowercase letter.
+func isMIBValueName(t string) bool {
+	return t != "" && unicode.IsLower(rune(t[0]))
+}
+
+// tokenizeMIB splits a MIB module into tokens. Comments are removed and
+// quoted strings are replaced with `""`.
+func tokenizeMIB(s string) ([]string, error) {
+	var ts []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+
+		case strings.HasPrefix(s[i:], "--"):
+			// A comment ends at the end of the line or the next "--".
+			j := i + 2
+			for j < len(s) && s[j] != '\n' && !strings.HasPrefix(s[j:], "--") {
+				j++
+			}
+			if strings.HasPrefix(s[j:], "--") {
+				j += 2
+			}
+			i = j
+
+		case c == '"':
+			j := strings.IndexByte(s[i+1:], '"')
+			if j < 0 {
+				return nil, errors.New("a string isn't closed")
+			}
+			ts = append(ts, `""`)
+			i += j + 2
+
+		case strings.HasPrefix(s[i:], "::="):
+			ts = append(ts, "::=")
+			i += 3
+
+		case isMIBIdentChar(c):
+			j := i
+			for j < len(s) && isMIBIdentChar(s[j]) {
+				j++
+			}
+			ts = append(ts, s[i:j])
+			i = j
+
+		default:
+			ts = append(ts, string(c))
+			i++
+		}
+	}
+	return ts, nil
+}
+
+func isMIBIdentChar(c byte) bool {
+	return c == '-' || c == '_' || ('0' <= c && c <= '9') ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,157 @@
+package snmp
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMIB = `
+ACME-SENSOR-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, NOTIFICATION-TYPE, Integer32
+        FROM SNMPv2-SMI
+    acme
+        FROM ACME-ROOT-MIB;
+
+acmeSensorMIB MODULE-IDENTITY
+    LAST-UPDATED "201601020000Z"
+    ORGANIZATION "ACME"
+    CONTACT-INFO "::= { not an OID }"
+    DESCRIPTION  "Sensors -- not a comment"
+    ::= { acme 1 }
+
+sensorTable OBJECT-TYPE
+    SYNTAX      SEQUENCE OF SensorEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "A table of sensors."
+    ::= { acmeSensorMIB 1 }
+
+sensorEntry OBJECT-TYPE
+    SYNTAX      SensorEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "A sensor."
+    INDEX       { sensorIndex }
+    ::= { sensorTable 1 }
+
+SensorEntry ::= SEQUENCE {
+    sensorIndex Integer32,
+    sensorValue Integer32
+}
+
+sensorIndex OBJECT-TYPE
+    SYNTAX      Integer32 (1..255) -- the index --
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "The index."
+    ::= { sensorEntry 1 }
+
+sensorValue OBJECT-TYPE
+    SYNTAX      INTEGER { low(1), high(2) }
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "The value."
+    ::= { sensorEntry 2 }
+
+sensorAlarm NOTIFICATION-TYPE
+    OBJECTS     { sensorValue }
+    STATUS      current
+    DESCRIPTION "An alarm."
+    ::= { acmeSensorMIB 0 1 }
+
+END
+`
+
+const testRootMIB = `
+ACME-ROOT-MIB DEFINITIONS ::= BEGIN
+acme OBJECT IDENTIFIER ::= { iso(1) org(3) dod(6) internet(1) private(4) enterprises(1) 99999 }
+
+acmeHot TRAP-TYPE
+    ENTERPRISE acme
+    VARIABLES  { sensorValue }
+    ::= 3
+END
+`
+
+func TestMIB(t *testing.T) {
+	Convey("Given a MIB", t, func() {
+		m := NewMIB()
+
+		Convey("When resolving built-in names", func() {
+			Convey("Then they should be resolved", func() {
+				o, err := m.Resolve("sysUpTime.0")
+				So(err, ShouldBeNil)
+				So(o, ShouldResemble, OID{1, 3, 6, 1, 2, 1, 1, 3, 0})
+				So(m.Name(OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 2, 3}), ShouldEqual, "ifDescr.3")
+				So(m.Name(OID{1, 3, 6, 1, 6, 3, 1, 1, 5, 3}), ShouldEqual, "linkDown")
+			})
+		})
+
+		Convey("When loading a module whose parent isn't loaded", func() {
+			So(m.Parse([]byte(testMIB)), ShouldBeNil)
+
+			Convey("Then its names should be pending", func() {
+				So(m.Unresolved(), ShouldContain, "sensorValue")
+				_, err := m.Resolve("sensorValue")
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("And loading the parent module", func() {
+				So(m.Parse([]byte(testRootMIB)), ShouldBeNil)
+
+				Convey("Then names should be resolved", func() {
+					So(m.Unresolved(), ShouldBeEmpty)
+					o, err := m.Resolve("ACME-SENSOR-MIB::sensorValue.7")
+					So(err, ShouldBeNil)
+					So(o, ShouldResemble, OID{1, 3, 6, 1, 4, 1, 99999, 1, 1, 1, 2, 7})
+					So(m.Name(o), ShouldEqual, "sensorValue.7")
+					So(m.Name(OID{1, 3, 6, 1, 4, 1, 99999, 1, 0, 1}), ShouldEqual, "sensorAlarm")
+					So(m.Name(OID{1, 3, 6, 1, 4, 1, 99999, 0, 3}), ShouldEqual, "acmeHot")
+				})
+			})
+		})
+
+		Convey("When loading a directory", func() {
+			dir, err := ioutil.TempDir("", "sbtest_snmp_mib")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				os.RemoveAll(dir)
+			})
+			So(ioutil.WriteFile(filepath.Join(dir, "ACME-SENSOR-MIB.txt"), []byte(testMIB), 0644), ShouldBeNil)
+			So(ioutil.WriteFile(filepath.Join(dir, "ACME-ROOT-MIB.txt"), []byte(testRootMIB), 0644), ShouldBeNil)
+			So(m.Load(dir), ShouldBeNil)
+
+			Convey("Then all modules should be loaded", func() {
+				So(m.Unresolved(), ShouldBeEmpty)
+				So(m.Name(OID{1, 3, 6, 1, 4, 1, 99999, 1, 1, 1, 1}), ShouldEqual, "sensorIndex")
+			})
+		})
+
+		Convey("When resolving unknown names or OIDs", func() {
+			Convey("Then names should fail and OIDs should be numeric", func() {
+				_, err := m.Resolve("noSuchName")
+				So(err, ShouldNotBeNil)
+				_, err = m.Resolve("sysUpTime.x")
+				So(err, ShouldNotBeNil)
+				So(m.Name(OID{2, 5}), ShouldEqual, "joint-iso-ccitt.5")
+			})
+		})
+
+		Convey("When parsing broken modules", func() {
+			Convey("Then it should fail", func() {
+				for _, s := range []string{
+					`a OBJECT IDENTIFIER ::= { iso 3`,
+					`a OBJECT IDENTIFIER ::= { iso x }`,
+					`a OBJECT-TYPE DESCRIPTION "x`,
+				} {
+					So(m.Parse([]byte(s)), ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
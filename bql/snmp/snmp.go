@@ -0,0 +1,513 @@
+// Package snmp encodes and decodes messages of SNMPv1 and SNMPv2c and
+// resolves names of OIDs defined in MIB modules. It only has what sources of
+// BQL need to poll agents and to receive traps, and doesn't support SNMPv3.
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of SNMP.
+type Version int
+
+const (
+	// Version1 is SNMPv1.
+	Version1 Version = 0
+
+	// Version2c is community-based SNMPv2.
+	Version2c Version = 1
+)
+
+func (v Version) String() string {
+	switch v {
+	case Version1:
+		return "1"
+	case Version2c:
+		return "2c"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseVersion parses "1", "2c", or "2".
+func ParseVersion(s string) (Version, error) {
+	switch strings.ToLower(s) {
+	case "1", "v1":
+		return Version1, nil
+	case "2", "2c", "v2c":
+		return Version2c, nil
+	}
+	return 0, fmt.Errorf("unsupported SNMP version: %v", s)
+}
+
+// PDUType is the type of a PDU.
+type PDUType byte
+
+// Types of PDUs.
+const (
+	GetRequest     PDUType = 0xa0
+	GetNextRequest PDUType = 0xa1
+	GetResponse    PDUType = 0xa2
+	SetRequest     PDUType = 0xa3
+	TrapV1         PDUType = 0xa4
+	GetBulkRequest PDUType = 0xa5
+	InformRequest  PDUType = 0xa6
+	TrapV2         PDUType = 0xa7
+	Report         PDUType = 0xa8
+)
+
+// Type is the type of a value of a variable binding.
+type Type byte
+
+// Types of values.
+const (
+	Integer          Type = 0x02
+	OctetString      Type = 0x04
+	Null             Type = 0x05
+	ObjectIdentifier Type = 0x06
+	IPAddress        Type = 0x40
+	Counter32        Type = 0x41
+	Gauge32          Type = 0x42
+	TimeTicks        Type = 0x43
+	Opaque           Type = 0x44
+	Counter64        Type = 0x46
+	NoSuchObject     Type = 0x80
+	NoSuchInstance   Type = 0x81
+	EndOfMIBView     Type = 0x82
+)
+
+const (
+	tagInteger  = byte(Integer)
+	tagOctets   = byte(OctetString)
+	tagOID      = byte(ObjectIdentifier)
+	tagSequence = 0x30
+)
+
+func (t Type) String() string {
+	switch t {
+	case Integer:
+		return "integer"
+	case OctetString:
+		return "octet_string"
+	case Null:
+		return "null"
+	case ObjectIdentifier:
+		return "oid"
+	case IPAddress:
+		return "ip_address"
+	case Counter32:
+		return "counter32"
+	case Gauge32:
+		return "gauge32"
+	case TimeTicks:
+		return "timeticks"
+	case Opaque:
+		return "opaque"
+	case Counter64:
+		return "counter64"
+	case NoSuchObject:
+		return "no_such_object"
+	case NoSuchInstance:
+		return "no_such_instance"
+	case EndOfMIBView:
+		return "end_of_mib_view"
+	default:
+		return fmt.Sprintf("unknown(0x%02x)", byte(t))
+	}
+}
+
+// IsException returns true when the type is an exception of SNMPv2, which
+// means the variable doesn't have a value.
+func (t Type) IsException() bool {
+	return t == NoSuchObject || t == NoSuchInstance || t == EndOfMIBView
+}
+
+// OID is an object identifier.
+type OID []uint32
+
+// ParseOID parses an OID in the dotted notation such as "1.3.6.1.2.1.1.3.0".
+// A leading dot is allowed.
+func ParseOID(s string) (OID, error) {
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return nil, errors.New("an OID cannot be empty")
+	}
+	cs := strings.Split(s, ".")
+	o := make(OID, len(cs))
+	for i, c := range cs {
+		v, err := strconv.ParseUint(c, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID: %v", s)
+		}
+		o[i] = uint32(v)
+	}
+	return o, nil
+}
+
+func (o OID) String() string {
+	cs := make([]string, len(o))
+	for i, c := range o {
+		cs[i] = strconv.FormatUint(uint64(c), 10)
+	}
+	return strings.Join(cs, ".")
+}
+
+// HasPrefix returns true when o is p or a descendant of p.
+func (o OID) HasPrefix(p OID) bool {
+	if len(o) < len(p) {
+		return false
+	}
+	for i, c := range p {
+		if o[i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true when o and p are the same OID.
+func (o OID) Equal(p OID) bool {
+	return len(o) == len(p) && o.HasPrefix(p)
+}
+
+// Compare returns -1, 0, or 1 when o is before, the same as, or after p in
+// the lexicographical order.
+func (o OID) Compare(p OID) int {
+	for i := 0; i < len(o) && i < len(p); i++ {
+		switch {
+		case o[i] < p[i]:
+			return -1
+		case o[i] > p[i]:
+			return 1
+		}
+	}
+	switch {
+	case len(o) < len(p):
+		return -1
+	case len(o) > len(p):
+		return 1
+	}
+	return 0
+}
+
+// VarBind is a variable binding.
+//
+// Value is Int for Integer, Counter32, Gauge32, TimeTicks, and Counter64,
+// Blob for OctetString and Opaque, String for ObjectIdentifier and
+// IPAddress, and Null for Null and exceptions. Counter64 values which don't
+// fit in Int are Float.
+type VarBind struct {
+	OID   OID
+	Type  Type
+	Value data.Value
+}
+
+// PDU is a protocol data unit.
+type PDU struct {
+	Type      PDUType
+	RequestID int32
+
+	// ErrorStatus and ErrorIndex are non-repeaters and max-repetitions in
+	// GetBulkRequest.
+	ErrorStatus int
+	ErrorIndex  int
+
+	VarBinds []VarBind
+
+	// Enterprise, AgentAddress, GenericTrap, SpecificTrap, and Timestamp
+	// are only used by TrapV1.
+	Enterprise   OID
+	AgentAddress net.IP
+	GenericTrap  int
+	SpecificTrap int
+	Timestamp    uint32
+}
+
+// Message is an SNMP message.
+type Message struct {
+	Version   Version
+	Community string
+	PDU       *PDU
+}
+
+// Marshal encodes the message.
+func (m *Message) Marshal() ([]byte, error) {
+	e := &encoder{}
+	err := e.constructed(tagSequence, func(e *encoder) error {
+		e.integer(tagInteger, int64(m.Version))
+		e.tlv(tagOctets, []byte(m.Community))
+		return m.PDU.marshal(e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.b, nil
+}
+
+func (p *PDU) marshal(e *encoder) error {
+	return e.constructed(byte(p.Type), func(e *encoder) error {
+		if p.Type == TrapV1 {
+			o, err := encodeOID(p.Enterprise)
+			if err != nil {
+				return err
+			}
+			e.tlv(tagOID, o)
+			ip := p.AgentAddress.To4()
+			if ip == nil {
+				ip = net.IPv4zero.To4()
+			}
+			e.tlv(byte(IPAddress), ip)
+			e.integer(tagInteger, int64(p.GenericTrap))
+			e.integer(tagInteger, int64(p.SpecificTrap))
+			e.unsigned(byte(TimeTicks), uint64(p.Timestamp))
+		} else {
+			e.integer(tagInteger, int64(p.RequestID))
+			e.integer(tagInteger, int64(p.ErrorStatus))
+			e.integer(tagInteger, int64(p.ErrorIndex))
+		}
+		return e.constructed(tagSequence, func(e *encoder) error {
+			for _, vb := range p.VarBinds {
+				if err := vb.marshal(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func (vb *VarBind) marshal(e *encoder) error {
+	return e.constructed(tagSequence, func(e *encoder) error {
+		o, err := encodeOID(vb.OID)
+		if err != nil {
+			return err
+		}
+		e.tlv(tagOID, o)
+
+		t := vb.Type
+		if t == 0 {
+			t = Null
+		}
+		switch t {
+		case Null, NoSuchObject, NoSuchInstance, EndOfMIBView:
+			e.tlv(byte(t), nil)
+		case Integer:
+			i, err := data.AsInt(vb.Value)
+			if err != nil {
+				return err
+			}
+			e.integer(byte(t), i)
+		case Counter32, Gauge32, TimeTicks, Counter64:
+			i, err := data.AsInt(vb.Value)
+			if err != nil {
+				return err
+			}
+			if i < 0 {
+				return fmt.Errorf("%v cannot be negative: %v", t, i)
+			}
+			e.unsigned(byte(t), uint64(i))
+		case OctetString, Opaque:
+			b, err := data.ToBlob(vb.Value)
+			if err != nil {
+				return err
+			}
+			e.tlv(byte(t), b)
+		case ObjectIdentifier:
+			s, err := data.AsString(vb.Value)
+			if err != nil {
+				return err
+			}
+			v, err := ParseOID(s)
+			if err != nil {
+				return err
+			}
+			b, err := encodeOID(v)
+			if err != nil {
+				return err
+			}
+			e.tlv(byte(t), b)
+		case IPAddress:
+			s, err := data.AsString(vb.Value)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(s).To4()
+			if ip == nil {
+				return fmt.Errorf("invalid IPv4 address: %v", s)
+			}
+			e.tlv(byte(t), ip)
+		default:
+			return fmt.Errorf("unsupported type: %v", t)
+		}
+		return nil
+	})
+}
+
+// Unmarshal decodes a message.
+func Unmarshal(b []byte) (*Message, error) {
+	d := &decoder{b: b}
+	c, err := d.expect(tagSequence)
+	if err != nil {
+		return nil, err
+	}
+	d = &decoder{b: c}
+
+	m := &Message{}
+	v, err := d.integer()
+	if err != nil {
+		return nil, fmt.Errorf("invalid version: %v", err)
+	}
+	if v != int64(Version1) && v != int64(Version2c) {
+		return nil, fmt.Errorf("unsupported version: %v", v)
+	}
+	m.Version = Version(v)
+	community, err := d.expect(tagOctets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid community: %v", err)
+	}
+	m.Community = string(community)
+
+	tag, c, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	if tag < byte(GetRequest) || tag > byte(Report) {
+		return nil, fmt.Errorf("unsupported PDU type: 0x%02x", tag)
+	}
+	if m.PDU, err = unmarshalPDU(PDUType(tag), c); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func unmarshalPDU(t PDUType, c []byte) (*PDU, error) {
+	p := &PDU{Type: t}
+	d := &decoder{b: c}
+	if t == TrapV1 {
+		o, err := d.expect(tagOID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid enterprise: %v", err)
+		}
+		if p.Enterprise, err = decodeOID(o); err != nil {
+			return nil, err
+		}
+		ip, err := d.expect(byte(IPAddress))
+		if err != nil {
+			return nil, fmt.Errorf("invalid agent address: %v", err)
+		}
+		if len(ip) != 4 {
+			return nil, fmt.Errorf("invalid agent address: %v", ip)
+		}
+		p.AgentAddress = net.IP(ip)
+		g, err := d.integer()
+		if err != nil {
+			return nil, fmt.Errorf("invalid generic trap: %v", err)
+		}
+		s, err := d.integer()
+		if err != nil {
+			return nil, fmt.Errorf("invalid specific trap: %v", err)
+		}
+		p.GenericTrap, p.SpecificTrap = int(g), int(s)
+		ts, err := d.expect(byte(TimeTicks))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %v", err)
+		}
+		u, err := decodeUnsigned(ts)
+		if err != nil {
+			return nil, err
+		}
+		p.Timestamp = uint32(u)
+	} else {
+		id, err := d.integer()
+		if err != nil {
+			return nil, fmt.Errorf("invalid request ID: %v", err)
+		}
+		es, err := d.integer()
+		if err != nil {
+			return nil, fmt.Errorf("invalid error status: %v", err)
+		}
+		ei, err := d.integer()
+		if err != nil {
+			return nil, fmt.Errorf("invalid error index: %v", err)
+		}
+		p.RequestID, p.ErrorStatus, p.ErrorIndex = int32(id), int(es), int(ei)
+	}
+
+	c, err := d.expect(tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("invalid variable bindings: %v", err)
+	}
+	d = &decoder{b: c}
+	for !d.empty() {
+		c, err := d.expect(tagSequence)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variable binding: %v", err)
+		}
+		vb, err := unmarshalVarBind(c)
+		if err != nil {
+			return nil, err
+		}
+		p.VarBinds = append(p.VarBinds, vb)
+	}
+	return p, nil
+}
+
+func unmarshalVarBind(c []byte) (VarBind, error) {
+	vb := VarBind{}
+	d := &decoder{b: c}
+	o, err := d.expect(tagOID)
+	if err != nil {
+		return vb, fmt.Errorf("invalid OID of a variable binding: %v", err)
+	}
+	if vb.OID, err = decodeOID(o); err != nil {
+		return vb, err
+	}
+	tag, v, err := d.next()
+	if err != nil {
+		return vb, fmt.Errorf("invalid value of %v: %v", vb.OID, err)
+	}
+	vb.Type = Type(tag)
+
+	switch vb.Type {
+	case Null, NoSuchObject, NoSuchInstance, EndOfMIBView:
+		vb.Value = data.Null{}
+	case Integer:
+		i, err := decodeInteger(v)
+		if err != nil {
+			return vb, err
+		}
+		vb.Value = data.Int(i)
+	case Counter32, Gauge32, TimeTicks, Counter64:
+		u, err := decodeUnsigned(v)
+		if err != nil {
+			return vb, err
+		}
+		if u > math.MaxInt64 {
+			vb.Value = data.Float(u)
+		} else {
+			vb.Value = data.Int(u)
+		}
+	case OctetString, Opaque:
+		vb.Value = data.Blob(append([]byte{}, v...))
+	case ObjectIdentifier:
+		x, err := decodeOID(v)
+		if err != nil {
+			return vb, err
+		}
+		vb.Value = data.String(x.String())
+	case IPAddress:
+		if len(v) != 4 {
+			return vb, fmt.Errorf("invalid IP address of %v", vb.OID)
+		}
+		vb.Value = data.String(net.IP(v).String())
+	default:
+		return vb, fmt.Errorf("unsupported type of %v: %v", vb.OID, vb.Type)
+	}
+	return vb, nil
+}
@@ -0,0 +1,160 @@
+package snmp
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	Convey("Given a GetRequest message", t, func() {
+		m := &Message{
+			Version:   Version2c,
+			Community: "public",
+			PDU: &PDU{
+				Type:      GetRequest,
+				RequestID: 1,
+				VarBinds:  []VarBind{{OID: OID{1, 3, 6, 1, 2, 1, 1, 1, 0}}},
+			},
+		}
+		encoded := []byte{
+			0x30, 0x26, 0x02, 0x01, 0x01, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+			0xa0, 0x19, 0x02, 0x01, 0x01, 0x02, 0x01, 0x00, 0x02, 0x01, 0x00,
+			0x30, 0x0e, 0x30, 0x0c, 0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x01, 0x00,
+			0x05, 0x00,
+		}
+
+		Convey("When marshaling it", func() {
+			b, err := m.Marshal()
+
+			Convey("Then it should be encoded in BER", func() {
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, encoded)
+			})
+		})
+
+		Convey("When unmarshaling the encoded message", func() {
+			d, err := Unmarshal(encoded)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be the same message", func() {
+				m.PDU.VarBinds[0].Type = Null
+				m.PDU.VarBinds[0].Value = data.Null{}
+				So(d, ShouldResemble, m)
+			})
+		})
+
+		Convey("When unmarshaling truncated messages", func() {
+			Convey("Then it should fail", func() {
+				for i := 0; i < len(encoded); i++ {
+					_, err := Unmarshal(encoded[:i])
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+
+	Convey("Given a response having values of all types", t, func() {
+		m := &Message{
+			Version:   Version2c,
+			Community: "private",
+			PDU: &PDU{
+				Type:      GetResponse,
+				RequestID: -12345678,
+				VarBinds: []VarBind{
+					{OID{1, 3, 6, 1, 4, 1, 1}, Integer, data.Int(-300)},
+					{OID{1, 3, 6, 1, 4, 1, 2}, OctetString, data.Blob("eth0")},
+					{OID{1, 3, 6, 1, 4, 1, 3}, ObjectIdentifier, data.String("1.3.6.1.4.1.99999")},
+					{OID{1, 3, 6, 1, 4, 1, 4}, IPAddress, data.String("192.168.0.1")},
+					{OID{1, 3, 6, 1, 4, 1, 5}, Counter32, data.Int(4294967295)},
+					{OID{1, 3, 6, 1, 4, 1, 6}, Gauge32, data.Int(128)},
+					{OID{1, 3, 6, 1, 4, 1, 7}, TimeTicks, data.Int(0)},
+					{OID{1, 3, 6, 1, 4, 1, 8}, Counter64, data.Int(1 << 40)},
+					{OID{1, 3, 6, 1, 4, 1, 9, 200000}, NoSuchInstance, data.Null{}},
+					{OID{1, 3, 6, 1, 4, 1, 10}, EndOfMIBView, data.Null{}},
+				},
+			},
+		}
+
+		Convey("When marshaling and unmarshaling it", func() {
+			b, err := m.Marshal()
+			So(err, ShouldBeNil)
+			d, err := Unmarshal(b)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be the same message", func() {
+				So(d, ShouldResemble, m)
+			})
+		})
+	})
+
+	Convey("Given an SNMPv1 trap", t, func() {
+		m := &Message{
+			Version:   Version1,
+			Community: "public",
+			PDU: &PDU{
+				Type:         TrapV1,
+				Enterprise:   OID{1, 3, 6, 1, 4, 1, 8072},
+				AgentAddress: net.IPv4(10, 0, 0, 1).To4(),
+				GenericTrap:  6,
+				SpecificTrap: 17,
+				Timestamp:    123456,
+				VarBinds: []VarBind{
+					{OID{1, 3, 6, 1, 4, 1, 8072, 1}, OctetString, data.Blob("hot")},
+				},
+			},
+		}
+
+		Convey("When marshaling and unmarshaling it", func() {
+			b, err := m.Marshal()
+			So(err, ShouldBeNil)
+			d, err := Unmarshal(b)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be the same message", func() {
+				So(d, ShouldResemble, m)
+			})
+		})
+	})
+}
+
+func TestOID(t *testing.T) {
+	Convey("Given OIDs", t, func() {
+		Convey("When parsing them", func() {
+			o, err := ParseOID(".1.3.6.1.2.1")
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be compared", func() {
+				So(o, ShouldResemble, OID{1, 3, 6, 1, 2, 1})
+				So(o.String(), ShouldEqual, "1.3.6.1.2.1")
+				So(OID{1, 3, 6, 1, 2, 1, 1}.HasPrefix(o), ShouldBeTrue)
+				So(OID{1, 3, 6, 1, 2, 2}.HasPrefix(o), ShouldBeFalse)
+				So(OID{1, 3, 6, 1, 2, 1, 1}.Compare(o), ShouldEqual, 1)
+				So(OID{1, 3, 6, 1, 1}.Compare(o), ShouldEqual, -1)
+				So(o.Compare(o), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When parsing invalid OIDs", func() {
+			Convey("Then it should fail", func() {
+				for _, s := range []string{"", ".", "1..2", "1.a", "1.4294967296"} {
+					_, err := ParseOID(s)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When encoding large sub-identifiers", func() {
+			o := OID{2, 999, 4294967295}
+			b, err := encodeOID(o)
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be decoded", func() {
+				d, err := decodeOID(b)
+				So(err, ShouldBeNil)
+				So(d, ShouldResemble, o)
+			})
+		})
+	})
+}
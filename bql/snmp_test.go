@@ -0,0 +1,361 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/snmp"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fakeSNMPAgent responds to requests having the community "public".
+type fakeSNMPAgent struct {
+	conn net.PacketConn
+	vars []snmp.VarBind
+}
+
+func newFakeSNMPAgent() *fakeSNMPAgent {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	oid := func(s string) snmp.OID {
+		o, err := snmp.ParseOID(s)
+		So(err, ShouldBeNil)
+		return o
+	}
+	a := &fakeSNMPAgent{
+		conn: conn,
+		vars: []snmp.VarBind{
+			{oid("1.3.6.1.2.1.1.1.0"), snmp.OctetString, data.Blob("Linux box")},
+			{oid("1.3.6.1.2.1.1.3.0"), snmp.TimeTicks, data.Int(12345)},
+			{oid("1.3.6.1.2.1.2.2.1.2.1"), snmp.OctetString, data.Blob("eth0")},
+			{oid("1.3.6.1.2.1.2.2.1.2.2"), snmp.OctetString, data.Blob("eth1")},
+			{oid("1.3.6.1.2.1.2.2.1.6.1"), snmp.OctetString, data.Blob{0, 1, 2, 3, 4, 0xff}},
+			{oid("1.3.6.1.2.1.2.2.1.10.1"), snmp.Counter32, data.Int(100)},
+			{oid("1.3.6.1.2.1.2.2.1.10.2"), snmp.Counter32, data.Int(200)},
+			{oid("1.3.6.1.2.1.31.1.1.1.1.1"), snmp.OctetString, data.Blob("eth0")},
+		},
+	}
+	sort.Slice(a.vars, func(i, j int) bool {
+		return a.vars[i].OID.Compare(a.vars[j].OID) < 0
+	})
+	go a.serve()
+	return a
+}
+
+func (a *fakeSNMPAgent) serve() {
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := a.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		m, err := snmp.Unmarshal(buf[:n])
+		if err != nil || m.Community != "public" {
+			continue
+		}
+		req := m.PDU
+		res := &snmp.PDU{Type: snmp.GetResponse, RequestID: req.RequestID}
+		switch req.Type {
+		case snmp.GetRequest:
+			for _, vb := range req.VarBinds {
+				res.VarBinds = append(res.VarBinds, a.get(vb.OID))
+			}
+		case snmp.GetNextRequest:
+			vb, ok := a.next(req.VarBinds[0].OID)
+			if !ok && m.Version == snmp.Version1 {
+				res.ErrorStatus, res.ErrorIndex = 2, 1
+				vb = req.VarBinds[0]
+			}
+			res.VarBinds = []snmp.VarBind{vb}
+		case snmp.GetBulkRequest:
+			cur := req.VarBinds[0].OID
+			for i := 0; i < req.ErrorIndex; i++ {
+				vb, ok := a.next(cur)
+				res.VarBinds = append(res.VarBinds, vb)
+				if !ok {
+					break
+				}
+				cur = vb.OID
+			}
+		}
+		m.PDU = res
+		b, err := m.Marshal()
+		if err != nil {
+			continue
+		}
+		a.conn.WriteTo(b, addr)
+	}
+}
+
+func (a *fakeSNMPAgent) get(o snmp.OID) snmp.VarBind {
+	for _, vb := range a.vars {
+		if vb.OID.Equal(o) {
+			return vb
+		}
+	}
+	return snmp.VarBind{OID: o, Type: snmp.NoSuchObject, Value: data.Null{}}
+}
+
+func (a *fakeSNMPAgent) next(o snmp.OID) (snmp.VarBind, bool) {
+	for _, vb := range a.vars {
+		if vb.OID.Compare(o) > 0 {
+			return vb, true
+		}
+	}
+	return snmp.VarBind{OID: o, Type: snmp.EndOfMIBView, Value: data.Null{}}, false
+}
+
+func TestSNMPSource(t *testing.T) {
+	Convey("Given an SNMP agent", t, func() {
+		ctx := core.NewContext(nil)
+		agent := newFakeSNMPAgent()
+		Reset(func() {
+			agent.conn.Close()
+		})
+		params := data.Map{
+			"targets":  data.Array{data.String(agent.conn.LocalAddr().String())},
+			"get":      data.Array{data.String("sysDescr.0"), data.String("sysUpTime.0"), data.String("sysName.0")},
+			"walk":     data.Array{data.String("ifTable")},
+			"interval": data.String("1ms"),
+			"timeout":  data.String("100ms"),
+		}
+		expected := data.Map{
+			"sysDescr.0":      data.String("Linux box"),
+			"sysUpTime.0":     data.Int(12345),
+			"ifDescr.1":       data.String("eth0"),
+			"ifDescr.2":       data.String("eth1"),
+			"ifPhysAddress.1": data.Blob{0, 1, 2, 3, 4, 0xff},
+			"ifInOctets.1":    data.Int(100),
+			"ifInOctets.2":    data.Int(200),
+		}
+
+		read := func(n int) []*core.Tuple {
+			s, err := createSNMPSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			ch := make(chan *core.Tuple, n)
+			done := make(chan error)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+					select {
+					case ch <- t:
+					default:
+					}
+					return nil
+				}))
+			}()
+			var ts []*core.Tuple
+			for i := 0; i < n; i++ {
+				ts = append(ts, <-ch)
+			}
+			So(s.Stop(ctx), ShouldBeNil)
+			So(<-done, ShouldBeNil)
+			return ts
+		}
+
+		Convey("When polling it in SNMPv2c", func() {
+			ts := read(2)
+
+			Convey("Then each tuple should have values got and walked", func() {
+				for _, t := range ts {
+					So(t.Data, ShouldResemble, data.Map{
+						"type":   data.String("poll"),
+						"agent":  data.String(agent.conn.LocalAddr().String()),
+						"values": expected,
+					})
+				}
+			})
+		})
+
+		Convey("When polling it in SNMPv1", func() {
+			params["version"] = data.String("1")
+			params["get"] = data.Array{data.String("sysDescr.0")}
+			params["walk"] = data.Array{data.String("1.3.6.1.2.1.31")}
+			ts := read(1)
+
+			Convey("Then the walk should end at the end of the MIB view", func() {
+				So(ts[0].Data["values"], ShouldResemble, data.Map{
+					"sysDescr.0":         data.String("Linux box"),
+					"mib-2.31.1.1.1.1.1": data.String("eth0"),
+				})
+			})
+		})
+
+		Convey("When the agent doesn't respond", func() {
+			params["targets"] = data.Array{
+				data.Map{
+					"address":   data.String(agent.conn.LocalAddr().String()),
+					"community": data.String("private"),
+				},
+			}
+			s, err := createSNMPSource(ctx, &IOParams{}, params)
+			So(err, ShouldBeNil)
+			ts := s.(*snmpSource).poll(ctx, time.Now())
+
+			Convey("Then no tuple should be emitted", func() {
+				So(ts, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When creating sources with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []data.Map{
+					{"targets": data.Array{}},
+					{"targets": data.Array{data.Int(1)}},
+					{"targets": data.Array{data.Map{"community": data.String("a")}}},
+					{"version": data.String("3")},
+					{"get": data.Array{data.String("noSuchName.0")}},
+					{"get": data.Array{}, "walk": data.Array{}},
+					{"interval": data.Int(0)},
+					{"retries": data.Int(-1)},
+					{"mibs": data.String("/no/such/mib")},
+				} {
+					ps := params.Copy()
+					for k, v := range p {
+						ps[k] = v
+					}
+					_, err := createSNMPSource(ctx, &IOParams{}, ps)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+
+	Convey("Given an SNMP source receiving traps", t, func() {
+		ctx := core.NewContext(nil)
+		l, err := net.ListenPacket("udp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		addr := l.LocalAddr().String()
+		l.Close()
+
+		s, err := createSNMPSource(ctx, &IOParams{}, data.Map{
+			"trap_address":   data.String(addr),
+			"trap_community": data.String("public"),
+		})
+		So(err, ShouldBeNil)
+		ch := make(chan *core.Tuple, 10)
+		done := make(chan error)
+		go func() {
+			done <- s.GenerateStream(ctx, core.WriterFunc(func(ctx *core.Context, t *core.Tuple) error {
+				ch <- t
+				return nil
+			}))
+		}()
+		Reset(func() {
+			s.Stop(ctx)
+			<-done
+		})
+
+		sender, err := net.ListenPacket("udp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			sender.Close()
+		})
+		dst, err := net.ResolveUDPAddr("udp", addr)
+		So(err, ShouldBeNil)
+		send := func(m *snmp.Message) {
+			b, err := m.Marshal()
+			So(err, ShouldBeNil)
+			// The source might not be listening yet.
+			for i := 0; i < 100; i++ {
+				_, err := sender.WriteTo(b, dst)
+				So(err, ShouldBeNil)
+				select {
+				case t := <-ch:
+					ch <- t
+					return
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+		}
+		trapV2 := func(t snmp.PDUType, community string) *snmp.Message {
+			return &snmp.Message{
+				Version:   snmp.Version2c,
+				Community: community,
+				PDU: &snmp.PDU{
+					Type:      t,
+					RequestID: 42,
+					VarBinds: []snmp.VarBind{
+						{snmpSysUpTime, snmp.TimeTicks, data.Int(500)},
+						{snmpTrapOID, snmp.ObjectIdentifier, data.String("1.3.6.1.6.3.1.1.5.3")},
+						{snmp.OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 1, 2}, snmp.Integer, data.Int(2)},
+					},
+				},
+			}
+		}
+
+		Convey("When receiving an SNMPv2c trap", func() {
+			send(trapV2(snmp.TrapV2, "public"))
+			t := <-ch
+
+			Convey("Then it should be emitted with resolved names", func() {
+				So(t.Data, ShouldResemble, data.Map{
+					"type":      data.String("trap"),
+					"agent":     data.String("127.0.0.1"),
+					"version":   data.String("2c"),
+					"community": data.String("public"),
+					"trap":      data.String("linkDown"),
+					"trap_oid":  data.String("1.3.6.1.6.3.1.1.5.3"),
+					"uptime":    data.Int(500),
+					"values":    data.Map{"ifIndex.2": data.Int(2)},
+				})
+			})
+		})
+
+		Convey("When receiving an SNMPv1 trap", func() {
+			send(&snmp.Message{
+				Version:   snmp.Version1,
+				Community: "public",
+				PDU: &snmp.PDU{
+					Type:         snmp.TrapV1,
+					Enterprise:   snmp.OID{1, 3, 6, 1, 4, 1, 99999},
+					AgentAddress: net.IPv4(127, 0, 0, 1),
+					GenericTrap:  6,
+					SpecificTrap: 3,
+					Timestamp:    700,
+				},
+			})
+			t := <-ch
+
+			Convey("Then the trap OID should be converted", func() {
+				So(t.Data["version"], ShouldEqual, data.String("1"))
+				So(t.Data["trap"], ShouldEqual, data.String("enterprises.99999.0.3"))
+				So(t.Data["uptime"], ShouldEqual, data.Int(700))
+			})
+		})
+
+		Convey("When receiving an inform", func() {
+			send(trapV2(snmp.InformRequest, "public"))
+			t := <-ch
+
+			Convey("Then it should be emitted and acknowledged", func() {
+				So(t.Data["trap"], ShouldEqual, data.String("linkDown"))
+				buf := make([]byte, 65535)
+				sender.SetReadDeadline(time.Now().Add(time.Second))
+				n, _, err := sender.ReadFrom(buf)
+				So(err, ShouldBeNil)
+				m, err := snmp.Unmarshal(buf[:n])
+				So(err, ShouldBeNil)
+				So(m.PDU.Type, ShouldEqual, snmp.GetResponse)
+				So(m.PDU.RequestID, ShouldEqual, 42)
+			})
+		})
+
+		Convey("When receiving a trap having a wrong community", func() {
+			b, err := trapV2(snmp.TrapV2, "private").Marshal()
+			So(err, ShouldBeNil)
+			_, err = sender.WriteTo(b, dst)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be ignored", func() {
+				select {
+				case <-ch:
+					So("a tuple was emitted", ShouldBeEmpty)
+				case <-time.After(50 * time.Millisecond):
+				}
+			})
+		})
+	})
+}
@@ -139,8 +139,14 @@ func MustRegisterGlobalSourceCreator(typeName string, c SourceCreator) {
 // CopyGlobalSourceCreatorRegistry creates a new independent copy of the global
 // SourceCreatorRegistry.
 func CopyGlobalSourceCreatorRegistry() (SourceCreatorRegistry, error) {
+	return CopySourceCreatorRegistry(globalSourceCreatorRegistry)
+}
+
+// CopySourceCreatorRegistry creates a new independent copy of the given
+// SourceCreatorRegistry.
+func CopySourceCreatorRegistry(src SourceCreatorRegistry) (SourceCreatorRegistry, error) {
 	r := NewDefaultSourceCreatorRegistry()
-	m, err := globalSourceCreatorRegistry.List()
+	m, err := src.List()
 	if err != nil {
 		return nil, err
 	}
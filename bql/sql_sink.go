@@ -0,0 +1,286 @@
+package bql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSQLSinkTimeout     = 10 * time.Second
+	defaultSQLSinkOffsetTable = "sensorbee_offsets"
+)
+
+// sqlIdentifierPattern matches table and column names which can be embedded
+// in statements without quoting. A table name can be qualified by a schema.
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// sqlSink inserts tuples into a table of a database through database/sql.
+// Drivers aren't bundled, so a driver must be registered with sql.Register
+// by a plugin before the sink is created.
+//
+// In the outbox mode, the sink writes a row having the name of the sink, the
+// name of the source, and the offset of each tuple to the offset table in the
+// same transaction as the tuple. A tuple whose row already exists is
+// discarded, so tuples written again after the source is rewound or the
+// server is restarted are written exactly once as long as the source emits
+// the same tuples at the same offsets. Since each offset is checked on its
+// own, tuples can arrive in any order, for example, from parallel boxes or
+// partitioned inputs. The offset table should have a unique constraint on
+// the three columns so that concurrent writers of the same tuple fail
+// instead of writing it twice.
+type sqlSink struct {
+	db      *sql.DB
+	name    string
+	insert  string
+	columns []string
+	timeout time.Duration
+
+	outbox       bool
+	countOffset  string
+	insertOffset string
+
+	m      sync.Mutex
+	closed bool
+}
+
+var _ core.ContextSink = &sqlSink{}
+
+func (s *sqlSink) Write(ctx *core.Context, t *core.Tuple) error {
+	return s.WriteContext(context.Background(), ctx, t)
+}
+
+// WriteContext inserts the tuple. The statement is canceled when c is done.
+// It implements core.ContextSink.
+func (s *sqlSink) WriteContext(c context.Context, ctx *core.Context, t *core.Tuple) error {
+	args := make([]interface{}, len(s.columns))
+	for i, col := range s.columns {
+		v, err := sqlValue(t.Data[col])
+		if err != nil {
+			return fmt.Errorf("cannot convert the value of the column %v: %v", col, err)
+		}
+		args[i] = v
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+
+	c, cancel := context.WithTimeout(c, s.timeout)
+	defer cancel()
+	if !s.outbox || t.Offset <= 0 || t.OffsetSource == "" {
+		_, err := s.db.ExecContext(c, s.insert, args...)
+		return err
+	}
+
+	return s.writeWithOffset(c, args, strings.ToLower(t.OffsetSource), t.Offset)
+}
+
+// writeWithOffset inserts a tuple and its offset in a transaction unless the
+// offset has already been written. The caller must hold s.m.
+func (s *sqlSink) writeWithOffset(c context.Context, args []interface{}, source string, offset int64) (err error) {
+	tx, err := s.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var n int64
+	if err := tx.QueryRowContext(c, s.countOffset, s.name, source, offset).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		// The tuple has already been written before a rewind or a restart.
+		tx.Rollback()
+		return nil
+	}
+	if _, err := tx.ExecContext(c, s.insertOffset, s.name, source, offset); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(c, s.insert, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqlSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.db.Close()
+}
+
+// sqlValue converts a value to a value which drivers accept. Arrays and maps
+// are written as JSON strings.
+func sqlValue(v data.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, data.Null:
+		return nil, nil
+	case data.Array, data.Map:
+		return v.String(), nil
+	case *data.SharedBlob:
+		return data.AsBlob(v)
+	case data.String, data.Int, data.Float, data.Bool, data.Blob, data.Timestamp:
+		return toNative(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", v.Type())
+	}
+}
+
+// createSQLSink creates a sink inserting tuples into a table through
+// database/sql. Its type name is "sqldb" because "sql" is a reserved word
+// of BQL. It accepts the following parameters:
+//
+//   - driver: the name of the driver registered with sql.Register, which is
+//     required.
+//   - dsn: the data source name passed to the driver, which is required.
+//   - table: the name of the table, which is required.
+//   - columns: an array of names of columns, which is required. The value of
+//     each column is the field of the tuple having the same name. A missing
+//     field is written as NULL, and arrays and maps are written as JSON.
+//   - placeholder: "?" or "$", which is the style of placeholders the driver
+//     accepts. "$" means "$1", "$2", and so on. The default value is "?".
+//   - outbox: true to write the offset of each tuple to the offset table in
+//     the same transaction as the tuple. The default value is false.
+//   - offset_table: the name of the offset table used in the outbox mode. It
+//     must have the columns "sink", "source", and "source_offset", which
+//     have the name of the sink, the name of the source, and the offset as
+//     an integer, and should have a unique constraint on them. It has a row
+//     for each tuple written, and rows can be deleted once sources are no
+//     longer rewound to their offsets. The default value is
+//     "sensorbee_offsets".
+//   - timeout: the timeout of writing a tuple. The default value is 10
+//     seconds.
+func createSQLSink(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Sink, error) {
+	strParam := func(name string) (string, error) {
+		v, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("'%v' parameter is missing", name)
+		}
+		str, err := data.AsString(v)
+		if err != nil {
+			return "", fmt.Errorf("'%v' parameter must be a string: %v", name, err)
+		}
+		return str, nil
+	}
+	identParam := func(name string) (string, error) {
+		str, err := strParam(name)
+		if err != nil {
+			return "", err
+		}
+		if !sqlIdentifierPattern.MatchString(str) {
+			return "", fmt.Errorf("'%v' parameter must be a name of a table: %v", name, str)
+		}
+		return str, nil
+	}
+
+	driver, err := strParam("driver")
+	if err != nil {
+		return nil, err
+	}
+	dsn, err := strParam("dsn")
+	if err != nil {
+		return nil, err
+	}
+	table, err := identParam("table")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &sqlSink{
+		name: strings.ToLower(ioParams.Name),
+	}
+	v, ok := params["columns"]
+	if !ok {
+		return nil, errors.New("'columns' parameter is missing")
+	}
+	cols, err := data.AsArray(v)
+	if err != nil {
+		return nil, fmt.Errorf("'columns' parameter must be an array of strings: %v", err)
+	}
+	for _, e := range cols {
+		col, err := data.AsString(e)
+		if err != nil {
+			return nil, fmt.Errorf("'columns' parameter must be an array of strings: %v", err)
+		}
+		if !sqlIdentifierPattern.MatchString(col) || strings.Contains(col, ".") {
+			return nil, fmt.Errorf("'columns' parameter has an invalid column name: %v", col)
+		}
+		s.columns = append(s.columns, col)
+	}
+	if len(s.columns) == 0 {
+		return nil, errors.New("'columns' parameter must have at least one column")
+	}
+
+	placeholder := func(i int) string { return "?" }
+	if _, ok := params["placeholder"]; ok {
+		p, err := strParam("placeholder")
+		if err != nil {
+			return nil, err
+		}
+		switch p {
+		case "?":
+		case "$":
+			placeholder = func(i int) string { return fmt.Sprintf("$%v", i) }
+		default:
+			return nil, fmt.Errorf("'placeholder' parameter must be ? or $: %v", p)
+		}
+	}
+	phs := make([]string, len(s.columns))
+	for i := range phs {
+		phs[i] = placeholder(i + 1)
+	}
+	s.insert = fmt.Sprintf("INSERT INTO %v (%v) VALUES (%v)", table,
+		strings.Join(s.columns, ", "), strings.Join(phs, ", "))
+
+	if v, ok := params["outbox"]; ok {
+		if s.outbox, err = data.AsBool(v); err != nil {
+			return nil, fmt.Errorf("'outbox' parameter must be a bool: %v", err)
+		}
+	}
+	offsetTable := defaultSQLSinkOffsetTable
+	if _, ok := params["offset_table"]; ok {
+		if offsetTable, err = identParam("offset_table"); err != nil {
+			return nil, err
+		}
+	}
+	s.countOffset = fmt.Sprintf("SELECT COUNT(*) FROM %v WHERE sink = %v AND source = %v AND source_offset = %v",
+		offsetTable, placeholder(1), placeholder(2), placeholder(3))
+	s.insertOffset = fmt.Sprintf("INSERT INTO %v (sink, source, source_offset) VALUES (%v, %v, %v)",
+		offsetTable, placeholder(1), placeholder(2), placeholder(3))
+
+	if s.timeout, err = extractDurationParameter(params, "timeout", defaultSQLSinkTimeout); err != nil {
+		return nil, err
+	}
+
+	if s.db, err = sql.Open(driver, dsn); err != nil {
+		return nil, err
+	}
+	c, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.db.PingContext(c); err != nil {
+		s.db.Close()
+		return nil, fmt.Errorf("cannot connect to the database: %v", err)
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSinkCreator("sqldb", SinkCreatorFunc(createSQLSink))
+}
@@ -0,0 +1,471 @@
+package bql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// sqlSinkTestDriver is a database/sql driver keeping tables in memory. It
+// only understands statements issued by the SQL sink.
+type sqlSinkTestDriver struct {
+	m   sync.Mutex
+	dbs map[string]*sqlSinkTestDB
+}
+
+var sqlSinkTestDrv = &sqlSinkTestDriver{
+	dbs: map[string]*sqlSinkTestDB{},
+}
+
+func init() {
+	sql.Register("sqlsinktest", sqlSinkTestDrv)
+}
+
+// newDB creates a database whose data source name is dsn.
+func (d *sqlSinkTestDriver) newDB(dsn string) *sqlSinkTestDB {
+	d.m.Lock()
+	defer d.m.Unlock()
+	db := &sqlSinkTestDB{
+		state: newSQLSinkTestState(),
+	}
+	d.dbs[dsn] = db
+	return db
+}
+
+func (d *sqlSinkTestDriver) Open(dsn string) (driver.Conn, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	db, ok := d.dbs[dsn]
+	if !ok {
+		return nil, fmt.Errorf("database %v doesn't exist", dsn)
+	}
+	return &sqlSinkTestConn{db: db}, nil
+}
+
+type sqlSinkTestDB struct {
+	m     sync.Mutex
+	state *sqlSinkTestState
+
+	// queries has all statements executed including rolled back ones.
+	queries []string
+
+	// fail makes statements having the prefix fail.
+	fail string
+}
+
+func (db *sqlSinkTestDB) rows(table string) [][]driver.Value {
+	db.m.Lock()
+	defer db.m.Unlock()
+	return db.state.rows[table]
+}
+
+// offsets returns sorted offsets of each source written by the sink.
+func (db *sqlSinkTestDB) offsets(sink string) map[string][]int64 {
+	db.m.Lock()
+	defer db.m.Unlock()
+	res := map[string][]int64{}
+	for k := range db.state.offsets {
+		if k.sink != sink {
+			continue
+		}
+		res[k.source] = append(res[k.source], k.offset)
+	}
+	for _, offs := range res {
+		sort.Sort(int64Slice(offs))
+	}
+	return res
+}
+
+type int64Slice []int64
+
+func (s int64Slice) Len() int           { return len(s) }
+func (s int64Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s int64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+type sqlSinkTestOffsetKey struct {
+	sink   string
+	source string
+	offset int64
+}
+
+type sqlSinkTestState struct {
+	rows map[string][][]driver.Value
+
+	// offsets has rows of the offset table, which has a unique constraint
+	// on all columns.
+	offsets map[sqlSinkTestOffsetKey]bool
+}
+
+func newSQLSinkTestState() *sqlSinkTestState {
+	return &sqlSinkTestState{
+		rows:    map[string][][]driver.Value{},
+		offsets: map[sqlSinkTestOffsetKey]bool{},
+	}
+}
+
+func (s *sqlSinkTestState) clone() *sqlSinkTestState {
+	c := newSQLSinkTestState()
+	for t, rows := range s.rows {
+		c.rows[t] = append([][]driver.Value{}, rows...)
+	}
+	for k := range s.offsets {
+		c.offsets[k] = true
+	}
+	return c
+}
+
+var (
+	sqlSinkTestInsertPattern = regexp.MustCompile(`^INSERT INTO (\S+) \(`)
+	sqlSinkTestCountPattern  = regexp.MustCompile(`^SELECT COUNT\(\*\) FROM \S+ WHERE sink = `)
+)
+
+func sqlSinkTestOffsetKeyOf(args []driver.Value) sqlSinkTestOffsetKey {
+	return sqlSinkTestOffsetKey{
+		sink:   args[0].(string),
+		source: args[1].(string),
+		offset: args[2].(int64),
+	}
+}
+
+// exec executes a statement on the state. The caller must hold db.m.
+func (db *sqlSinkTestDB) exec(s *sqlSinkTestState, query string, args []driver.Value) (int64, error) {
+	db.queries = append(db.queries, query)
+	if db.fail != "" && strings.HasPrefix(query, db.fail) {
+		return 0, errors.New("the statement failed")
+	}
+	switch {
+	case strings.Contains(query, "(sink, source, source_offset)"):
+		k := sqlSinkTestOffsetKeyOf(args)
+		if s.offsets[k] {
+			return 0, errors.New("duplicate key violates the unique constraint")
+		}
+		s.offsets[k] = true
+		return 1, nil
+	default:
+		m := sqlSinkTestInsertPattern.FindStringSubmatch(query)
+		if m == nil {
+			return 0, fmt.Errorf("unsupported statement: %v", query)
+		}
+		s.rows[m[1]] = append(s.rows[m[1]], args)
+		return 1, nil
+	}
+}
+
+type sqlSinkTestConn struct {
+	db *sqlSinkTestDB
+
+	// tx has the state modified by the current transaction.
+	tx *sqlSinkTestState
+}
+
+func (c *sqlSinkTestConn) Prepare(query string) (driver.Stmt, error) {
+	return &sqlSinkTestStmt{c: c, query: query}, nil
+}
+
+func (c *sqlSinkTestConn) Close() error {
+	return nil
+}
+
+func (c *sqlSinkTestConn) Begin() (driver.Tx, error) {
+	c.db.m.Lock()
+	defer c.db.m.Unlock()
+	c.tx = c.db.state.clone()
+	return c, nil
+}
+
+func (c *sqlSinkTestConn) Commit() error {
+	c.db.m.Lock()
+	defer c.db.m.Unlock()
+	c.db.state = c.tx
+	c.tx = nil
+	return nil
+}
+
+func (c *sqlSinkTestConn) Rollback() error {
+	c.tx = nil
+	return nil
+}
+
+type sqlSinkTestStmt struct {
+	c     *sqlSinkTestConn
+	query string
+}
+
+func (s *sqlSinkTestStmt) Close() error {
+	return nil
+}
+
+func (s *sqlSinkTestStmt) NumInput() int {
+	return -1
+}
+
+func (s *sqlSinkTestStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := s.c.db
+	db.m.Lock()
+	defer db.m.Unlock()
+	state := db.state
+	if s.c.tx != nil {
+		state = s.c.tx
+	}
+	n, err := db.exec(state, s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(n), nil
+}
+
+func (s *sqlSinkTestStmt) Query(args []driver.Value) (driver.Rows, error) {
+	db := s.c.db
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.queries = append(db.queries, s.query)
+	if !sqlSinkTestCountPattern.MatchString(s.query) {
+		return nil, fmt.Errorf("unsupported query: %v", s.query)
+	}
+	state := db.state
+	if s.c.tx != nil {
+		state = s.c.tx
+	}
+	n := int64(0)
+	if state.offsets[sqlSinkTestOffsetKeyOf(args)] {
+		n = 1
+	}
+	return &sqlSinkTestRows{values: [][]driver.Value{{n}}}, nil
+}
+
+type sqlSinkTestRows struct {
+	values [][]driver.Value
+}
+
+func (r *sqlSinkTestRows) Columns() []string {
+	return []string{"count"}
+}
+
+func (r *sqlSinkTestRows) Close() error {
+	return nil
+}
+
+func (r *sqlSinkTestRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestSQLSink(t *testing.T) {
+	ctx := core.NewContext(nil)
+	ioParams := &IOParams{TypeName: "sqldb", Name: "SQL_SINK"}
+
+	Convey("Given a database", t, func() {
+		db := sqlSinkTestDrv.newDB("test")
+		params := data.Map{
+			"driver":  data.String("sqlsinktest"),
+			"dsn":     data.String("test"),
+			"table":   data.String("events"),
+			"columns": data.Array{data.String("id"), data.String("payload")},
+		}
+
+		Convey("When creating a sink with invalid parameters", func() {
+			for name, ps := range map[string]data.Map{
+				"driver is missing":        {"driver": nil},
+				"driver isn't registered":  {"driver": data.String("nosuchdriver")},
+				"dsn is missing":           {"dsn": nil},
+				"the database is missing":  {"dsn": data.String("nosuchdb")},
+				"table is missing":         {"table": nil},
+				"table has invalid name":   {"table": data.String("events; DROP TABLE events")},
+				"columns is missing":       {"columns": nil},
+				"columns is empty":         {"columns": data.Array{}},
+				"columns isn't strings":    {"columns": data.Array{data.Int(1)}},
+				"columns has invalid name": {"columns": data.Array{data.String("a.b")}},
+				"placeholder is invalid":   {"placeholder": data.String(":")},
+				"outbox isn't a bool":      {"outbox": data.String("yes")},
+				"offset_table is invalid":  {"offset_table": data.String("")},
+				"timeout isn't a duration": {"timeout": data.String("soon")},
+				"timeout is negative":      {"timeout": data.Int(-1)},
+			} {
+				p := params.Copy()
+				for k, v := range ps {
+					if v == nil {
+						delete(p, k)
+					} else {
+						p[k] = v
+					}
+				}
+
+				Convey("Then it should fail when "+name, func() {
+					_, err := createSQLSink(ctx, ioParams, p)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When writing tuples to a sink", func() {
+			params["placeholder"] = data.String("$")
+			si, err := createSQLSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			So(si.Write(ctx, &core.Tuple{Data: data.Map{
+				"id":      data.Int(1),
+				"payload": data.Map{"a": data.String("b")},
+			}}), ShouldBeNil)
+			So(si.Write(ctx, &core.Tuple{Data: data.Map{
+				"id": data.Int(2),
+			}}), ShouldBeNil)
+
+			Convey("Then rows should be inserted", func() {
+				So(db.rows("events"), ShouldResemble, [][]driver.Value{
+					{int64(1), `{"a":"b"}`},
+					{int64(2), nil},
+				})
+			})
+
+			Convey("Then placeholders should be numbered", func() {
+				So(db.queries, ShouldContain, "INSERT INTO events (id, payload) VALUES ($1, $2)")
+			})
+		})
+
+		Convey("When writing tuples having offsets without the outbox mode", func() {
+			si, err := createSQLSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			for i := 1; i <= 2; i++ {
+				So(si.Write(ctx, &core.Tuple{
+					Data:         data.Map{"id": data.Int(i)},
+					Offset:       1,
+					OffsetSource: "src",
+				}), ShouldBeNil)
+			}
+
+			Convey("Then all tuples should be inserted without offsets", func() {
+				So(db.rows("events"), ShouldHaveLength, 2)
+				So(db.offsets("sql_sink"), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When writing tuples to a sink in the outbox mode", func() {
+			params["outbox"] = data.True
+			si, err := createSQLSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+
+			write := func(s core.Sink, source string, offset int64) error {
+				return s.Write(ctx, &core.Tuple{
+					Data:         data.Map{"id": data.Int(offset)},
+					Offset:       offset,
+					OffsetSource: source,
+				})
+			}
+			for i := int64(1); i <= 3; i++ {
+				So(write(si, "SRC", i), ShouldBeNil)
+			}
+			So(write(si, "src2", 1), ShouldBeNil)
+			So(si.Write(ctx, &core.Tuple{Data: data.Map{"id": data.Int(100)}}), ShouldBeNil)
+
+			Convey("Then rows and offsets should be written", func() {
+				So(db.rows("events"), ShouldHaveLength, 5)
+				So(db.offsets("sql_sink"), ShouldResemble, map[string][]int64{
+					"src":  {1, 2, 3},
+					"src2": {1},
+				})
+			})
+
+			Convey("Then tuples having offsets already written should be discarded", func() {
+				So(write(si, "src", 2), ShouldBeNil)
+				So(write(si, "src", 3), ShouldBeNil)
+				So(db.rows("events"), ShouldHaveLength, 5)
+			})
+
+			Convey("Then tuples arriving out of order should all be written", func() {
+				for _, off := range []int64{6, 4, 5} {
+					So(write(si, "src", off), ShouldBeNil)
+				}
+				So(write(si, "src", 4), ShouldBeNil)
+				So(db.rows("events"), ShouldHaveLength, 8)
+				So(db.offsets("sql_sink")["src"], ShouldResemble, []int64{1, 2, 3, 4, 5, 6})
+			})
+
+			Convey("And writing the row fails", func() {
+				db.fail = "INSERT INTO events"
+				err := write(si, "src", 4)
+
+				Convey("Then the write should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+
+				Convey("Then the offset should be rolled back", func() {
+					So(db.rows("events"), ShouldHaveLength, 5)
+					So(db.offsets("sql_sink")["src"], ShouldResemble, []int64{1, 2, 3})
+				})
+
+				Convey("Then the tuple should be written again after recovery", func() {
+					db.fail = ""
+					So(write(si, "src", 4), ShouldBeNil)
+					So(db.rows("events"), ShouldHaveLength, 6)
+					So(db.offsets("sql_sink")["src"], ShouldResemble, []int64{1, 2, 3, 4})
+				})
+			})
+
+			Convey("And the sink is created again after closing it", func() {
+				So(si.Close(ctx), ShouldBeNil)
+				si2, err := createSQLSink(ctx, ioParams, params)
+				So(err, ShouldBeNil)
+				Reset(func() {
+					si2.Close(ctx)
+				})
+
+				Convey("Then it should only write tuples which haven't been written", func() {
+					for _, off := range []int64{5, 1, 2, 3, 4} {
+						So(write(si2, "src", off), ShouldBeNil)
+					}
+					So(db.rows("events"), ShouldHaveLength, 7)
+					So(db.offsets("sql_sink")["src"], ShouldResemble, []int64{1, 2, 3, 4, 5})
+				})
+			})
+
+			Convey("Then the closed sink should fail to write", func() {
+				So(si.Close(ctx), ShouldBeNil)
+				So(write(si, "src", 4), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a sink in the outbox mode with a custom offset table", func() {
+			params["outbox"] = data.True
+			params["offset_table"] = data.String("public.offsets")
+			si, err := createSQLSink(ctx, ioParams, params)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				si.Close(ctx)
+			})
+			So(si.Write(ctx, &core.Tuple{
+				Data:         data.Map{"id": data.Int(1)},
+				Offset:       1,
+				OffsetSource: "src",
+			}), ShouldBeNil)
+
+			Convey("Then offsets should be written to the table", func() {
+				So(db.queries, ShouldContain, "SELECT COUNT(*) FROM public.offsets WHERE sink = ? AND source = ? AND source_offset = ?")
+				So(db.queries, ShouldContain, "INSERT INTO public.offsets (sink, source, source_offset) VALUES (?, ?, ?)")
+			})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"strings"
+	"sync"
+)
+
+// TenantSeparator separates the name of a tenant and the rest of the name of
+// a topology owned by the tenant. For example, a topology "team_a__sensors"
+// is owned by the tenant "team_a".
+const TenantSeparator = "__"
+
+// Tenant is a group of topologies owned by the same user or team. Each
+// tenant has its own registries of UDFs, UDSF creators, UDS creators, source
+// creators, and sink creators, which are copied from the global registries
+// when the tenant is created. Components registered to a tenant are only
+// visible to topologies created by the tenant's NewTopologyBuilder.
+//
+// All topologies of a tenant share one core.ResourceController so that the
+// total usage of them is limited by the tenant's quota. Shared states are
+// kept in each topology's core.Context as usual, so a topology can never see
+// states of topologies owned by other tenants.
+//
+// A topology owned by a tenant must have a name prefixed by the tenant's name
+// followed by TenantSeparator.
+type Tenant struct {
+	name string
+
+	m    sync.RWMutex
+	reg  udf.FunctionManager
+	udfs map[string]udf.UDF
+
+	UDSFCreators   udf.UDSFCreatorRegistry
+	UDSCreators    udf.UDSCreatorRegistry
+	SourceCreators SourceCreatorRegistry
+	SinkCreators   SinkCreatorRegistry
+	BoxCreators    BoxCreatorRegistry
+
+	// Resources is shared by all topologies of the tenant. Set it to
+	// core.ContextConfig.Resources when creating a topology.
+	Resources *core.ResourceController
+}
+
+// NewTenant creates a new tenant. If limits is nil, resources used by
+// topologies of the tenant aren't limited.
+func NewTenant(name string, limits *core.ResourceLimits) (*Tenant, error) {
+	if err := ValidateTenantName(name); err != nil {
+		return nil, err
+	}
+
+	udsfs, err := udf.CopyGlobalUDSFCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+	udss, err := udf.CopyGlobalUDSCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+	srcs, err := CopyGlobalSourceCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+	sinks, err := CopyGlobalSinkCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+	boxes, err := CopyGlobalBoxCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tenant{
+		name:           name,
+		reg:            udf.CopyGlobalUDFRegistry(nil),
+		udfs:           map[string]udf.UDF{},
+		UDSFCreators:   udsfs,
+		UDSCreators:    udss,
+		SourceCreators: srcs,
+		SinkCreators:   sinks,
+		BoxCreators:    boxes,
+		Resources:      core.NewResourceController(limits),
+	}, nil
+}
+
+// ValidateTenantName validates the name of a tenant. In addition to the rules
+// of core.ValidateSymbol, the name cannot contain TenantSeparator nor end with
+// "_" so that the owner of a topology can be determined from its name.
+func ValidateTenantName(name string) error {
+	if err := core.ValidateSymbol(name); err != nil {
+		return err
+	}
+	if strings.Contains(name, TenantSeparator) || strings.HasSuffix(name, "_") {
+		return fmt.Errorf("the name of a tenant cannot contain '%v' nor end with '_': %v",
+			TenantSeparator, name)
+	}
+	return nil
+}
+
+// TenantNameOf returns the name of the tenant owning the topology. It returns
+// an empty string when the name of the topology isn't prefixed by a tenant.
+func TenantNameOf(topologyName string) string {
+	i := strings.Index(topologyName, TenantSeparator)
+	if i <= 0 {
+		return ""
+	}
+	return topologyName[:i]
+}
+
+// Name returns the name of the tenant.
+func (t *Tenant) Name() string {
+	return t.name
+}
+
+// Owns returns true when the topology having the given name is owned by the
+// tenant.
+func (t *Tenant) Owns(topologyName string) bool {
+	return TenantNameOf(topologyName) == t.name
+}
+
+// RegisterUDF adds a UDF which is only visible to topologies of the tenant.
+// UDFs registered after creating a topology aren't seen by the topology.
+func (t *Tenant) RegisterUDF(name string, f udf.UDF) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if err := t.reg.Register(name, f); err != nil {
+		return err
+	}
+	t.udfs[name] = f
+	return nil
+}
+
+// NewTopologyBuilder creates a new TopologyBuilder having copies of the
+// tenant's registries. The topology must be owned by the tenant and its
+// context should be created with the tenant's Resources.
+func (t *Tenant) NewTopologyBuilder(tp core.Topology) (*TopologyBuilder, error) {
+	if !t.Owns(tp.Name()) {
+		return nil, fmt.Errorf("the topology '%v' isn't owned by the tenant '%v'", tp.Name(), t.name)
+	}
+
+	udsfs, err := udf.CopyUDSFCreatorRegistry(t.UDSFCreators)
+	if err != nil {
+		return nil, err
+	}
+	udss, err := udf.CopyUDSCreatorRegistry(t.UDSCreators)
+	if err != nil {
+		return nil, err
+	}
+	srcs, err := CopySourceCreatorRegistry(t.SourceCreators)
+	if err != nil {
+		return nil, err
+	}
+	sinks, err := CopySinkCreatorRegistry(t.SinkCreators)
+	if err != nil {
+		return nil, err
+	}
+	boxes, err := CopyBoxCreatorRegistry(t.BoxCreators)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := udf.CopyGlobalUDFRegistry(tp.Context())
+	t.m.RLock()
+	defer t.m.RUnlock()
+	for name, f := range t.udfs {
+		if err := reg.Register(name, f); err != nil {
+			return nil, err
+		}
+	}
+	return newTopologyBuilder(tp, reg, udsfs, udss, srcs, sinks, boxes)
+}
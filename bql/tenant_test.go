@@ -0,0 +1,106 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestTenantName(t *testing.T) {
+	Convey("Given tenant names", t, func() {
+		Convey("Then valid names should be accepted", func() {
+			for _, n := range []string{"a", "team_a", "TeamA1"} {
+				So(ValidateTenantName(n), ShouldBeNil)
+			}
+		})
+
+		Convey("Then invalid names should be rejected", func() {
+			for _, n := range []string{"", "1a", "team__a", "team_", "select"} {
+				So(ValidateTenantName(n), ShouldNotBeNil)
+			}
+		})
+
+		Convey("Then the owner of a topology should be determined by its name", func() {
+			So(TenantNameOf("team_a__test"), ShouldEqual, "team_a")
+			So(TenantNameOf("team_a__test__x"), ShouldEqual, "team_a")
+			So(TenantNameOf("team_a_test"), ShouldEqual, "")
+			So(TenantNameOf("__test"), ShouldEqual, "")
+		})
+	})
+}
+
+func TestTenant(t *testing.T) {
+	Convey("Given a tenant", t, func() {
+		tn, err := NewTenant("team_a", &core.ResourceLimits{MaxTuples: 10})
+		So(err, ShouldBeNil)
+		So(tn.RegisterUDF("tenant_func", udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			return v, nil
+		})), ShouldBeNil)
+		So(tn.SourceCreators.Register("tenant_source", SourceCreatorFunc(createDummySource)), ShouldBeNil)
+
+		newTopology := func(name string, resources *core.ResourceController) core.Topology {
+			tp, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+				Resources: resources,
+			}), name)
+			So(err, ShouldBeNil)
+			Reset(func() {
+				tp.Stop()
+			})
+			return tp
+		}
+
+		Convey("When creating a topology builder of the tenant", func() {
+			tp := newTopology("team_a__test", tn.Resources)
+			tb, err := tn.NewTopologyBuilder(tp)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have components registered to the tenant", func() {
+				_, err := tb.Reg.Lookup("tenant_func", 1)
+				So(err, ShouldBeNil)
+				So(addBQLToTopology(tb, `CREATE SOURCE s TYPE tenant_source`), ShouldBeNil)
+			})
+
+			Convey("Then it should also have global components", func() {
+				_, err := tb.Reg.Lookup("str", 1)
+				So(err, ShouldBeNil)
+				So(addBQLToTopology(tb, `CREATE SOURCE s TYPE dummy`), ShouldBeNil)
+			})
+
+			Convey("Then the topology should share the tenant's resources", func() {
+				So(tp.Context().Resources, ShouldPointTo, tn.Resources)
+				So(tp.Context().Resources.Limits().MaxTuples, ShouldEqual, 10)
+			})
+		})
+
+		Convey("When creating a topology builder without the tenant", func() {
+			tb, err := NewTopologyBuilder(newTopology("team_b__test", nil))
+			So(err, ShouldBeNil)
+
+			Convey("Then it shouldn't have components registered to the tenant", func() {
+				_, err := tb.Reg.Lookup("tenant_func", 1)
+				So(err, ShouldNotBeNil)
+				So(addBQLToTopology(tb, `CREATE SOURCE s TYPE tenant_source`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When creating a topology builder of a topology owned by another tenant", func() {
+			_, err := tn.NewTopologyBuilder(newTopology("team_b__test", nil))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When registering a UDF having the same name as a global one", func() {
+			err := tn.RegisterUDF("str", udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+				return v, nil
+			}))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
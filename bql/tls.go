@@ -0,0 +1,256 @@
+package bql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+)
+
+// TLSConfig has TLS parameters shared by networked sources, sinks, and the
+// API server. It can be created from a parameter map of the WITH clause of
+// CREATE SOURCE or CREATE SINK statements by LookupTLSConfig:
+//
+//	CREATE SOURCE s TYPE some_network_source WITH
+//	    tls = {"cert_file": "client.crt", "key_file": "client.key", "ca_file": "ca.crt"};
+//
+// The map can have the following parameters:
+//
+//	cert_file, cert: a certificate in a PEM file or in an inline PEM string
+//	key_file, key: a private key in a PEM file or in an inline PEM string
+//	ca_file, ca: CA certificates to verify peers in a PEM file or in an inline
+//	             PEM string. System CAs are used by clients when omitted.
+//	client_auth: the policy of servers for client certificates, which is one
+//	             of "none", "request", "require", "verify_if_given", and
+//	             "require_and_verify". The default value is
+//	             "require_and_verify" when a CA is given, or "none" otherwise.
+//	server_name: the name of the server which clients verify
+//	insecure_skip_verify: true to disable verification of server certificates
+//	min_version: the minimum TLS version, which is one of "1.0", "1.1", "1.2",
+//	             and "1.3". The default value is "1.2".
+type TLSConfig struct {
+	CertFile           string
+	Cert               string
+	KeyFile            string
+	Key                string
+	CAFile             string
+	CA                 string
+	ClientAuth         string
+	ServerName         string
+	InsecureSkipVerify bool
+	MinVersion         string
+}
+
+// TLSParam is the name of the parameter having TLS parameters.
+const TLSParam = "tls"
+
+// LookupTLSConfig creates a TLSConfig from the TLSParam parameter in params.
+// It returns nil when params doesn't have the parameter, which means TLS
+// isn't used.
+func LookupTLSConfig(params data.Map) (*TLSConfig, error) {
+	v, ok := params[TLSParam]
+	if !ok {
+		return nil, nil
+	}
+	m, err := data.AsMap(v)
+	if err != nil {
+		return nil, fmt.Errorf("'%v' parameter must be a map: %v", TLSParam, err)
+	}
+	return NewTLSConfig(m)
+}
+
+// NewTLSConfig creates a TLSConfig from a parameter map.
+func NewTLSConfig(params data.Map) (*TLSConfig, error) {
+	c := &TLSConfig{}
+	for k, v := range params {
+		var dst *string
+		switch k {
+		case "cert_file":
+			dst = &c.CertFile
+		case "cert":
+			dst = &c.Cert
+		case "key_file":
+			dst = &c.KeyFile
+		case "key":
+			dst = &c.Key
+		case "ca_file":
+			dst = &c.CAFile
+		case "ca":
+			dst = &c.CA
+		case "client_auth":
+			dst = &c.ClientAuth
+		case "server_name":
+			dst = &c.ServerName
+		case "min_version":
+			dst = &c.MinVersion
+		case "insecure_skip_verify":
+			b, err := data.AsBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter must be bool: %v", k, err)
+			}
+			c.InsecureSkipVerify = b
+			continue
+		default:
+			return nil, fmt.Errorf("unknown TLS parameter: %v", k)
+		}
+		s, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'%v' parameter must be a string: %v", k, err)
+		}
+		*dst = s
+	}
+
+	if c.CertFile != "" && c.Cert != "" {
+		return nil, errors.New("'cert_file' and 'cert' cannot be given at the same time")
+	}
+	if c.KeyFile != "" && c.Key != "" {
+		return nil, errors.New("'key_file' and 'key' cannot be given at the same time")
+	}
+	if c.CAFile != "" && c.CA != "" {
+		return nil, errors.New("'ca_file' and 'ca' cannot be given at the same time")
+	}
+	if c.hasCert() != c.hasKey() {
+		return nil, errors.New("a certificate and a private key must be given together")
+	}
+	if _, err := c.clientAuth(); err != nil {
+		return nil, err
+	}
+	if _, err := c.minVersion(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *TLSConfig) hasCert() bool {
+	return c.CertFile != "" || c.Cert != ""
+}
+
+func (c *TLSConfig) hasKey() bool {
+	return c.KeyFile != "" || c.Key != ""
+}
+
+func (c *TLSConfig) hasCA() bool {
+	return c.CAFile != "" || c.CA != ""
+}
+
+func (c *TLSConfig) clientAuth() (tls.ClientAuthType, error) {
+	switch c.ClientAuth {
+	case "":
+		if c.hasCA() {
+			return tls.RequireAndVerifyClientCert, nil
+		}
+		return tls.NoClientCert, nil
+	case "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require_and_verify":
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return 0, fmt.Errorf("unknown client_auth: %v", c.ClientAuth)
+	}
+}
+
+func (c *TLSConfig) minVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported min_version: %v", c.MinVersion)
+	}
+}
+
+func readPEM(file, inline string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	return ioutil.ReadFile(file)
+}
+
+func (c *TLSConfig) baseConfig() (*tls.Config, error) {
+	v, err := c.minVersion()
+	if err != nil {
+		return nil, err
+	}
+	conf := &tls.Config{
+		MinVersion: v,
+	}
+
+	if c.hasCert() {
+		cert, err := readPEM(c.CertFile, c.Cert)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the certificate: %v", err)
+		}
+		key, err := readPEM(c.KeyFile, c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the private key: %v", err)
+		}
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate or private key: %v", err)
+		}
+		conf.Certificates = []tls.Certificate{pair}
+	}
+	return conf, nil
+}
+
+func (c *TLSConfig) certPool() (*x509.CertPool, error) {
+	if !c.hasCA() {
+		return nil, nil
+	}
+	ca, err := readPEM(c.CAFile, c.CA)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CA certificates: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("no valid CA certificate is found")
+	}
+	return pool, nil
+}
+
+// ServerConfig returns a tls.Config for servers. A certificate and a private
+// key are required.
+func (c *TLSConfig) ServerConfig() (*tls.Config, error) {
+	if !c.hasCert() {
+		return nil, errors.New("a server requires a certificate and a private key")
+	}
+	conf, err := c.baseConfig()
+	if err != nil {
+		return nil, err
+	}
+	if conf.ClientAuth, err = c.clientAuth(); err != nil {
+		return nil, err
+	}
+	if conf.ClientCAs, err = c.certPool(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// ClientConfig returns a tls.Config for clients. A certificate and a private
+// key are optional and sent to servers requiring client certificates.
+func (c *TLSConfig) ClientConfig() (*tls.Config, error) {
+	conf, err := c.baseConfig()
+	if err != nil {
+		return nil, err
+	}
+	if conf.RootCAs, err = c.certPool(); err != nil {
+		return nil, err
+	}
+	conf.ServerName = c.ServerName
+	conf.InsecureSkipVerify = c.InsecureSkipVerify
+	return conf, nil
+}
@@ -0,0 +1,224 @@
+package bql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+func newTestCert(cn string, serial int64, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	So(err, ShouldBeNil)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{cn},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	So(err, ShouldBeNil)
+	cert, err := x509.ParseCertificate(der)
+	So(err, ShouldBeNil)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	So(err, ShouldBeNil)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+// handshake performs a TLS handshake and returns errors of the server and
+// the client.
+func handshake(server, client *tls.Config) (error, error) {
+	l, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	So(err, ShouldBeNil)
+	defer l.Close()
+
+	ch := make(chan error, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			ch <- err
+			return
+		}
+		defer conn.Close()
+		ch <- conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", l.Addr().String(), client)
+	if err == nil {
+		// The client may complete its handshake before the server rejects
+		// its certificate.
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	return <-ch, err
+}
+
+func TestTLSConfig(t *testing.T) {
+	Convey("Given certificates signed by a CA", t, func() {
+		ca := newTestCert("ca", 1, nil)
+		serverCert := newTestCert("localhost", 2, ca)
+		clientCert := newTestCert("client", 3, ca)
+
+		dir, err := ioutil.TempDir("", "sensorbee_tls_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		write := func(name, content string) string {
+			p := filepath.Join(dir, name)
+			So(ioutil.WriteFile(p, []byte(content), 0600), ShouldBeNil)
+			return p
+		}
+
+		serverParams := data.Map{
+			"cert_file": data.String(write("server.crt", serverCert.certPEM)),
+			"key_file":  data.String(write("server.key", serverCert.keyPEM)),
+			"ca_file":   data.String(write("ca.crt", ca.certPEM)),
+		}
+		clientParams := data.Map{
+			"cert":        data.String(clientCert.certPEM),
+			"key":         data.String(clientCert.keyPEM),
+			"ca":          data.String(ca.certPEM),
+			"server_name": data.String("localhost"),
+		}
+
+		Convey("When creating configs with mutual TLS", func() {
+			s, err := LookupTLSConfig(data.Map{"tls": serverParams})
+			So(err, ShouldBeNil)
+			serverConf, err := s.ServerConfig()
+			So(err, ShouldBeNil)
+			c, err := NewTLSConfig(clientParams)
+			So(err, ShouldBeNil)
+			clientConf, err := c.ClientConfig()
+			So(err, ShouldBeNil)
+
+			Convey("Then the server should require client certificates by default", func() {
+				So(serverConf.ClientAuth, ShouldEqual, tls.RequireAndVerifyClientCert)
+				So(serverConf.MinVersion, ShouldEqual, tls.VersionTLS12)
+			})
+
+			Convey("Then a handshake should succeed", func() {
+				serr, _ := handshake(serverConf, clientConf)
+				So(serr, ShouldBeNil)
+			})
+
+			Convey("Then a client without a certificate should be rejected", func() {
+				delete(clientParams, "cert")
+				delete(clientParams, "key")
+				c, err := NewTLSConfig(clientParams)
+				So(err, ShouldBeNil)
+				clientConf, err := c.ClientConfig()
+				So(err, ShouldBeNil)
+
+				serr, _ := handshake(serverConf, clientConf)
+				So(serr, ShouldNotBeNil)
+			})
+
+			Convey("Then a client not trusting the CA should fail", func() {
+				delete(clientParams, "ca")
+				c, err := NewTLSConfig(clientParams)
+				So(err, ShouldBeNil)
+				clientConf, err := c.ClientConfig()
+				So(err, ShouldBeNil)
+
+				_, cerr := handshake(serverConf, clientConf)
+				So(cerr, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When client_auth is none", func() {
+			serverParams["client_auth"] = data.String("none")
+			s, err := NewTLSConfig(serverParams)
+			So(err, ShouldBeNil)
+			serverConf, err := s.ServerConfig()
+			So(err, ShouldBeNil)
+
+			Convey("Then a client without a certificate should be accepted", func() {
+				c, err := NewTLSConfig(data.Map{
+					"ca":          data.String(ca.certPEM),
+					"server_name": data.String("localhost"),
+				})
+				So(err, ShouldBeNil)
+				clientConf, err := c.ClientConfig()
+				So(err, ShouldBeNil)
+
+				serr, _ := handshake(serverConf, clientConf)
+				So(serr, ShouldBeNil)
+			})
+		})
+
+		Convey("When the params don't have tls parameter", func() {
+			c, err := LookupTLSConfig(data.Map{})
+
+			Convey("Then TLS shouldn't be used", func() {
+				So(err, ShouldBeNil)
+				So(c, ShouldBeNil)
+			})
+		})
+
+		Convey("When a server config doesn't have a certificate", func() {
+			c, err := NewTLSConfig(data.Map{"ca": data.String(ca.certPEM)})
+			So(err, ShouldBeNil)
+			_, err = c.ServerConfig()
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating parameters", func() {
+			for _, p := range []data.Map{
+				{"cert": data.String("a")},
+				{"cert": data.String("a"), "cert_file": data.String("b"), "key": data.String("c")},
+				{"client_auth": data.String("always")},
+				{"min_version": data.String("1.4")},
+				{"insecure_skip_verify": data.String("yes")},
+				{"ca_file": data.Int(1)},
+				{"certificate": data.String("a")},
+			} {
+				Convey("Then it should reject "+p.String(), func() {
+					_, err := NewTLSConfig(p)
+					So(err, ShouldNotBeNil)
+				})
+			}
+
+			Convey("Then it should reject a non-map tls parameter", func() {
+				_, err := LookupTLSConfig(data.Map{"tls": data.String("on")})
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
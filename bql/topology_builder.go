@@ -3,14 +3,18 @@ package bql
 import (
 	"errors"
 	"fmt"
+	"github.com/Sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
 	"math"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type TopologyBuilder struct {
@@ -20,11 +24,105 @@ type TopologyBuilder struct {
 	UDSCreators    udf.UDSCreatorRegistry
 	SourceCreators SourceCreatorRegistry
 	SinkCreators   SinkCreatorRegistry
+	BoxCreators    BoxCreatorRegistry
 	UDSStorage     udf.UDSStorage
-}
 
-// TODO: Provide AtomicTopologyBuilder which support building multiple nodes
-// in an atomic manner (kind of transactionally)
+	// WindowSpill is the configuration to spill old contents of large
+	// windows of SELECT statements to disk. Spilling is disabled when it's
+	// nil. Changing this field only affects statements added after that.
+	WindowSpill *execution.SpillConfig
+
+	// WindowArena makes SELECT statements with GROUP BY reuse temporary
+	// structures used to compute results from their windows, which reduces
+	// GC pauses when windows have many groups. Changing this field only
+	// affects statements added after that.
+	WindowArena bool
+
+	// ShareWindows makes SELECT statements reading the same stream with
+	// identical windows and aliases keep the same tuples in their windows
+	// instead of wrapping and accounting each tuple per statement (see
+	// execution.WindowBufferPool).
+	// Windows aren't shared when spilling is enabled. Changing this field
+	// only affects statements added after that.
+	ShareWindows bool
+
+	// ParallelAggregation makes SELECT statements with GROUP BY evaluate
+	// groups in parallel when their windows have many groups. Groups are
+	// evaluated sequentially when it's nil. Changing this field only affects
+	// statements added after that.
+	ParallelAggregation *execution.ParallelAggregation
+
+	// KeyTTL is how long SELECT statements keep state of each key, such as
+	// the last row emitted for a key of WHEN CHANGED BY, after the key was
+	// seen last time. Expired state is removed by a background sweeper so
+	// that memory doesn't leak when the number of distinct keys is
+	// unbounded. State is kept forever when it's 0. Changing this field
+	// only affects statements added after that.
+	KeyTTL time.Duration
+
+	// Secrets provides secrets referred from parameters of statements like
+	// `${secret:name}`. References aren't substituted when it's nil.
+	Secrets SecretProvider
+
+	// SourceStaleness and SinkStaleness discard stale tuples at sources and
+	// before sinks created from CREATE SOURCE and CREATE SINK statements,
+	// respectively. Tuples aren't filtered when they're nil. Changing these
+	// fields only affects statements added after that.
+	SourceStaleness *core.StalenessFilter
+	SinkStaleness   *core.StalenessFilter
+
+	// BoxParallelism and BoxNice are core.BoxConfig.Parallelism and
+	// core.BoxConfig.Nice of boxes created from CREATE STREAM statements
+	// having FROM clauses with box types. Boxes created from SELECT
+	// statements always run with one goroutine since their results depend
+	// on the order of input tuples. Changing these fields only affects
+	// statements added after that.
+	BoxParallelism int
+	BoxNice        int
+
+	// NodeNice has core.BoxConfig.Nice of each box created from a CREATE
+	// STREAM statement having a FROM clause with a box type. Names must be in
+	// lower case. Boxes listed in it run with core.ParallelismAuto regardless
+	// of BoxParallelism and their values override BoxNice, so that CPUs can
+	// be shared between boxes of the topology. Changing this field only
+	// affects statements added after that.
+	NodeNice map[string]int
+
+	// DedicatedThreadSinks has names of sinks created from CREATE SINK
+	// statements which get dedicated OS threads (see
+	// core.SinkConfig.LockOSThread). Names must be in lower case.
+	DedicatedThreadSinks map[string]bool
+
+	// SinkFlushInterval is core.SinkConfig.FlushInterval of sinks created
+	// from CREATE SINK statements. Sinks implementing core.Flushable are
+	// flushed periodically at the interval when it's positive. Changing this
+	// field only affects statements added after that.
+	SinkFlushInterval time.Duration
+
+	// SinkReadinessTimeout is how long AddStmts and AddStartupStmts wait for
+	// sinks of the topology to become ready (see core.SinkNode.Connect)
+	// before they start sources created by statements. Sources start
+	// regardless of sinks when it's 0 or when the timeout expires.
+	SinkReadinessTimeout time.Duration
+
+	// SourceOffsets has the offset from which each source created from a
+	// CREATE SOURCE statement starts a stream. Keys are names of sources in
+	// lower case. Tuples having smaller offsets are discarded until the
+	// source is rewound. It's used to resume processing from offsets
+	// committed by another process, such as a primary server taken over by a
+	// standby.
+	SourceOffsets map[string]int64
+
+	// windowPool has window buffers shared by statements added by this
+	// builder when ShareWindows is true.
+	windowPool *execution.WindowBufferPool
+
+	pluginsMutex sync.Mutex
+	plugins      map[string]*loadedPlugin
+
+	defsMutex sync.RWMutex
+	defs      map[string]*entityDefinition
+}
 
 // NewTopologyBuilder creates a new TopologyBuilder which dynamically creates
 // nodes from BQL statements. The target Topology can be shared by
@@ -34,6 +132,8 @@ type TopologyBuilder struct {
 // when a user wants to add three statement and the second statement fails,
 // only the node created from the first statement is registered to the topology
 // and it starts to generate tuples. Others won't be registered.
+// Use AddStmts to apply multiple statements atomically, and AddStartupStmts
+// to start sources after sinks are ready.
 func NewTopologyBuilder(t core.Topology) (*TopologyBuilder, error) {
 	udsfs, err := udf.CopyGlobalUDSFCreatorRegistry()
 	if err != nil {
@@ -49,6 +149,24 @@ func NewTopologyBuilder(t core.Topology) (*TopologyBuilder, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	sinks, err := CopyGlobalSinkCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	boxes, err := CopyGlobalBoxCreatorRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return newTopologyBuilder(t, udf.CopyGlobalUDFRegistry(t.Context()), udsfs, udss, srcs, sinks, boxes)
+}
+
+// newTopologyBuilder creates a new TopologyBuilder having the given
+// registries. The registries must not be shared with other builders.
+func newTopologyBuilder(t core.Topology, reg udf.FunctionManager, udsfs udf.UDSFCreatorRegistry,
+	udss udf.UDSCreatorRegistry, srcs SourceCreatorRegistry, sinks SinkCreatorRegistry,
+	boxes BoxCreatorRegistry) (*TopologyBuilder, error) {
 	// node_statuses builtin source can only be registered here because it
 	// requires a topology.
 	if err := srcs.Register("node_statuses", createNodeStatusSourceCreator(t)); err != nil {
@@ -57,20 +175,22 @@ func NewTopologyBuilder(t core.Topology) (*TopologyBuilder, error) {
 	if err := srcs.Register("edge_statuses", createEdgeStatusSourceCreator(t)); err != nil {
 		return nil, err
 	}
-
-	sinks, err := CopyGlobalSinkCreatorRegistry()
-	if err != nil {
-		return nil, err
+	for name, f := range windowStateFuncs(t) {
+		if err := reg.Register(name, f); err != nil {
+			return nil, err
+		}
 	}
 
 	tb := &TopologyBuilder{
 		topology:       t,
-		Reg:            udf.CopyGlobalUDFRegistry(t.Context()),
+		Reg:            reg,
 		UDSFCreators:   udsfs,
 		UDSCreators:    udss,
 		SourceCreators: srcs,
 		SinkCreators:   sinks,
+		BoxCreators:    boxes,
 		UDSStorage:     udf.NewInMemoryUDSStorage(),
+		windowPool:     execution.NewWindowBufferPool(),
 	}
 	return tb, nil
 }
@@ -94,13 +214,30 @@ func (tb *TopologyBuilder) Topology() core.Topology {
 // AddStmt add a node created from a statement to the topology. It returns
 // a created node. It returns a nil node when the statement is CREATE STATE.
 func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
+	node, err := tb.addStmt(stmt)
+	if err != nil {
+		return node, err
+	}
+	tb.defineStmt(stmt)
+	return node, nil
+}
+
+func (tb *TopologyBuilder) addStmt(stmt interface{}) (core.Node, error) {
 	// TODO: Enable StopOnDisconnect properly
 
 	// check the type of statement
 	switch stmt := stmt.(type) {
 	case parser.CreateSourceStmt:
 		// load params into map for faster access
-		paramsMap := tb.mkParamsMap(stmt.Params)
+		paramsMap, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		supervisor, err := extractSupervisorConfig(paramsMap)
+		if err != nil {
+			return nil, err
+		}
 
 		// check if we know this type of source
 		creator, err := tb.SourceCreators.Lookup(string(stmt.Type))
@@ -116,14 +253,40 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-		return tb.topology.AddSource(string(stmt.Name), source, &core.SourceConfig{
-			PausedOnStartup: stmt.Paused == parser.Yes,
-		})
+		if _, ok := source.(reconnectingSource); ok && supervisor == nil {
+			supervisor = &core.SupervisorConfig{}
+		}
+		if supervisor != nil {
+			source = core.NewSupervisedSource(source, supervisor)
+		}
+		add := func() (core.Node, error) {
+			return tb.topology.AddSource(string(stmt.Name), source, &core.SourceConfig{
+				PausedOnStartup: stmt.Paused == parser.Yes,
+				Staleness:       tb.SourceStaleness,
+				StartOffset:     tb.SourceOffsets[strings.ToLower(string(stmt.Name))],
+				Meta:            specParams(stmt.SourceSinkSpecsAST),
+			})
+		}
+		if stmt.OrReplace == parser.Yes {
+			return tb.replaceNode(string(stmt.Name), core.NTSource, add)
+		}
+		return add()
 
 	case parser.CreateStreamAsSelectStmt:
+		if stmt.OrReplace == parser.Yes {
+			return tb.replaceNode(string(stmt.Name), core.NTBox, func() (core.Node, error) {
+				return tb.createStreamAsSelectStmt(&stmt)
+			})
+		}
 		return tb.createStreamAsSelectStmt(&stmt)
 
 	case parser.CreateStreamAsSelectUnionStmt:
+		if stmt.OrReplace == parser.Yes {
+			stmt.OrReplace = parser.No
+			return tb.replaceNode(string(stmt.Name), core.NTBox, func() (core.Node, error) {
+				return tb.AddStmt(stmt)
+			})
+		}
 		// idea: create an intermediate box for each SELECT substatement,
 		// then connect them with a simple forwarder box
 		names := make([]string, 0, len(stmt.Selects))
@@ -137,8 +300,8 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 			// create a stream with a generated name and recurse
 			tmpName := fmt.Sprintf("sensorbee_tmp_%v", topologyBuilderNextTemporaryID())
 			tmpStmt := parser.CreateStreamAsSelectStmt{
-				parser.StreamIdentifier(tmpName),
-				selStmt,
+				Name:   parser.StreamIdentifier(tmpName),
+				Select: selStmt,
 			}
 			box, err := tb.AddStmt(tmpStmt)
 			if err != nil {
@@ -152,7 +315,9 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		forwardBox := core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
 			return w.Write(ctx, t)
 		})
-		node, err := tb.topology.AddBox(string(stmt.Name), forwardBox, nil)
+		node, err := tb.topology.AddBox(string(stmt.Name), forwardBox, &core.BoxConfig{
+			Meta: selectParams(stmt.SelectUnionStmt),
+		})
 		if err != nil {
 			removeTmpNodes()
 			return nil, err
@@ -174,7 +339,25 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 
 	case parser.CreateSinkStmt:
 		// load params into map for faster access
-		paramsMap := tb.mkParamsMap(stmt.Params)
+		paramsMap, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		recovery, err := extractRecoveryPolicy(paramsMap)
+		if err != nil {
+			return nil, err
+		}
+
+		idempotency, err := tb.extractIdempotencyConfig(paramsMap)
+		if err != nil {
+			return nil, err
+		}
+
+		batching, err := extractAdaptiveBatching(paramsMap)
+		if err != nil {
+			return nil, err
+		}
 
 		// check if we know this type of sink
 		creator, err := tb.SinkCreators.Lookup(string(stmt.Type))
@@ -190,10 +373,86 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if err != nil {
 			return nil, err
 		}
+		if idempotency != nil {
+			sink = core.NewIdempotentSink(sink, idempotency.key, idempotency.state)
+		}
 		// we insert a sink, but cannot connect it to
 		// any streams yet, therefore we have to keep track
 		// of the SinkDeclarer
-		return tb.topology.AddSink(string(stmt.Name), sink, nil)
+		add := func() (core.Node, error) {
+			node, err := tb.topology.AddSink(string(stmt.Name), sink, &core.SinkConfig{
+				Staleness:        tb.SinkStaleness,
+				LockOSThread:     tb.DedicatedThreadSinks[strings.ToLower(string(stmt.Name))],
+				FlushInterval:    tb.SinkFlushInterval,
+				AdaptiveBatching: batching,
+				Meta:             specParams(stmt.SourceSinkSpecsAST),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := tb.setNodeRecoveryPolicy(node, recovery); err != nil {
+				tb.topology.Remove(string(stmt.Name))
+				return nil, err
+			}
+			return node, nil
+		}
+		if stmt.OrReplace == parser.Yes {
+			return tb.replaceNode(string(stmt.Name), core.NTSink, add)
+		}
+		return add()
+
+	case parser.CreateBoxStmt:
+		paramsMap, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		recovery, err := extractRecoveryPolicy(paramsMap)
+		if err != nil {
+			return nil, err
+		}
+
+		creator, err := tb.BoxCreators.Lookup(string(stmt.Type))
+		if err != nil {
+			return nil, err
+		}
+
+		ioParams := &IOParams{
+			TypeName: string(stmt.Type),
+			Name:     string(stmt.Name),
+		}
+		// The creator might modify parameters, so the box is recreated
+		// from a copy of the original ones.
+		origParams := paramsMap.Copy()
+		box, err := creator.CreateBox(tb.topology.Context(), ioParams, paramsMap)
+		if err != nil {
+			return nil, err
+		}
+		parallelism, nice := tb.BoxParallelism, tb.BoxNice
+		if n, ok := tb.NodeNice[strings.ToLower(string(stmt.Name))]; ok {
+			parallelism, nice = core.ParallelismAuto, n
+		}
+		node, err := tb.topology.AddBox(string(stmt.Name), box, &core.BoxConfig{
+			Parallelism: parallelism,
+			Nice:        nice,
+			Meta:        specParams(stmt.SourceSinkSpecsAST),
+			Recreate: func(ctx *core.Context) (core.Box, error) {
+				return creator.CreateBox(ctx, ioParams, origParams.Copy())
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := node.Input(string(stmt.Input), nil); err != nil {
+			tb.topology.Remove(string(stmt.Name))
+			return nil, err
+		}
+		if err := tb.setNodeRecoveryPolicy(node, recovery); err != nil {
+			tb.topology.Remove(string(stmt.Name))
+			return nil, err
+		}
+		node.StopOnDisconnect(core.Inbound)
+		return node, nil
 
 	case parser.CreateStateStmt:
 		c, err := tb.UDSCreators.Lookup(string(stmt.Type))
@@ -201,38 +460,50 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 			return nil, err
 		}
 
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
 		ctx := tb.topology.Context()
-		s, err := c.CreateState(ctx, tb.mkParamsMap(stmt.Params))
+		s, err := c.CreateState(ctx, params)
 		if err != nil {
 			return nil, err
 		}
+		if stmt.OrReplace == parser.Yes {
+			return nil, tb.replaceState(string(stmt.Name), string(stmt.Type), s)
+		}
 		if err := ctx.SharedStates.Add(string(stmt.Name), string(stmt.Type), s); err != nil {
 			return nil, err
 		}
 		return nil, nil
 
 	case parser.UpdateStateStmt:
-		ctx := tb.topology.Context()
-		state, err := ctx.SharedStates.Get(string(stmt.Name))
+		params, err := tb.mkParamsMap(stmt.Params)
 		if err != nil {
 			return nil, err
 		}
+		return nil, tb.topology.Context().SharedStates.Update(string(stmt.Name), params)
 
-		u, ok := state.(core.Updater)
-		if !ok {
-			return nil, fmt.Errorf("%s cannot be updated", string(stmt.Name))
-		}
-		return nil, u.Update(ctx, tb.mkParamsMap(stmt.Params))
+	case parser.LoadPluginStmt:
+		return nil, tb.LoadPlugin(stmt.Path)
 
 	case parser.SaveStateStmt:
 		return nil, tb.saveState(string(stmt.Name), stmt.Tag)
 
 	case parser.LoadStateStmt:
-		_, err := tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, tb.mkParamsMap(stmt.Params))
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, params)
 		return nil, err
 
 	case parser.LoadStateOrCreateStmt:
-		shouldCreate, err := tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, tb.mkParamsMap(stmt.LoadSpecs.Params))
+		params, err := tb.mkParamsMap(stmt.LoadSpecs.Params)
+		if err != nil {
+			return nil, err
+		}
+		shouldCreate, err := tb.loadState(string(stmt.Type), string(stmt.Name), stmt.Tag, params)
 		if shouldCreate {
 			c := parser.CreateStateStmt{}
 			c.Type = stmt.Type
@@ -247,58 +518,56 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-
-		u, ok := src.Source().(core.Updater)
-		if !ok {
-			return nil, fmt.Errorf("%s cannot be updated", string(stmt.Name))
+		params, err := tb.mkParamsMap(stmt.Params)
+		if err != nil {
+			return nil, err
 		}
-		return nil, u.Update(tb.topology.Context(), tb.mkParamsMap(stmt.Params))
+		return nil, src.Update(params)
 
 	case parser.UpdateSinkStmt:
 		sink, err := tb.topology.Sink(string(stmt.Name))
 		if err != nil {
 			return nil, err
 		}
-
-		u, ok := sink.Sink().(core.Updater)
-		if !ok {
-			return nil, fmt.Errorf("%s cannot be updated", string(stmt.Name))
-		}
-		return nil, u.Update(tb.topology.Context(), tb.mkParamsMap(stmt.Params))
-
-	case parser.DropSourceStmt:
-		_, err := tb.topology.Source(string(stmt.Source))
+		params, err := tb.mkParamsMap(stmt.Params)
 		if err != nil {
 			return nil, err
 		}
+		return nil, sink.Update(params)
 
-		return nil, tb.topology.Remove(string(stmt.Source))
+	case parser.DropSourceStmt:
+		return nil, tb.drop(string(stmt.Source), stmt.IfExists, stmt.Cascade, func(name string) error {
+			_, err := tb.topology.Source(name)
+			return err
+		})
 
 	case parser.DropStreamStmt:
-		_, err := tb.topology.Box(string(stmt.Stream))
-		if err != nil {
-			return nil, err
-		}
-
-		return nil, tb.topology.Remove(string(stmt.Stream))
+		return nil, tb.drop(string(stmt.Stream), stmt.IfExists, stmt.Cascade, func(name string) error {
+			_, err := tb.topology.Box(name)
+			return err
+		})
 
 	case parser.DropSinkStmt:
-		_, err := tb.topology.Sink(string(stmt.Sink))
-		if err != nil {
-			return nil, err
-		}
-
-		return nil, tb.topology.Remove(string(stmt.Sink))
+		return nil, tb.drop(string(stmt.Sink), stmt.IfExists, parser.No, func(name string) error {
+			_, err := tb.topology.Sink(name)
+			return err
+		})
 
 	case parser.DropStateStmt:
 		ctx := tb.topology.Context()
 		_, err := ctx.SharedStates.Get(string(stmt.State))
 		if err != nil {
+			if stmt.IfExists == parser.Yes && core.IsNotExist(err) {
+				return nil, nil
+			}
 			return nil, err
 		}
 
-		_, err = ctx.SharedStates.Remove(string(stmt.State))
-		return nil, err
+		if _, err := ctx.SharedStates.Remove(string(stmt.State)); err != nil {
+			return nil, err
+		}
+		tb.undefine(stateDefinitionPrefix, string(stmt.State))
+		return nil, nil
 
 	case parser.InsertIntoFromStmt:
 		// get the sink to add an input to
@@ -341,11 +610,208 @@ func (tb *TopologyBuilder) AddStmt(stmt interface{}) (core.Node, error) {
 			return nil, err
 		}
 		return src, nil
+
+	case parser.SetLogLevelStmt:
+		return nil, tb.setLogLevel(stmt)
+
+	case parser.SetTraceStmt:
+		return nil, tb.setTrace(stmt)
+
+	case parser.SetRecoveryPolicyStmt:
+		return nil, tb.setRecoveryPolicy(stmt)
 	}
 
 	return nil, fmt.Errorf("statement of type %T is unimplemented", stmt)
 }
 
+// setLogLevel changes the log level of the topology or a node at runtime.
+func (tb *TopologyBuilder) setLogLevel(stmt parser.SetLogLevelStmt) error {
+	lvl, err := logrus.ParseLevel(stmt.Level)
+	if err != nil {
+		return err
+	}
+	ctx := tb.topology.Context()
+	switch stmt.Target.Type {
+	case parser.NodeTarget:
+		if _, err := tb.topology.Node(string(stmt.Target.Name)); err != nil {
+			return err
+		}
+		ctx.LogLevels.SetNodeLevel(string(stmt.Target.Name), lvl)
+	case parser.TopologyTarget:
+		if err := tb.checkTopologyTarget(stmt.Target); err != nil {
+			return err
+		}
+		ctx.LogLevels.SetLevel(lvl)
+	default:
+		return fmt.Errorf("unknown target type: %v", stmt.Target.Type)
+	}
+	return nil
+}
+
+// setTrace turns on or off tuple tracing of the topology or a node at
+// runtime.
+func (tb *TopologyBuilder) setTrace(stmt parser.SetTraceStmt) error {
+	ctx := tb.topology.Context()
+	enabled := stmt.Enabled == parser.Yes
+	switch stmt.Target.Type {
+	case parser.NodeTarget:
+		node, err := tb.topology.Node(string(stmt.Target.Name))
+		if err != nil {
+			return err
+		}
+		ns, _ := ctx.Settings.Node(node.Name())
+		ns.Trace = &enabled
+		return ctx.Settings.SetNode(node.Name(), &ns)
+	case parser.TopologyTarget:
+		if err := tb.checkTopologyTarget(stmt.Target); err != nil {
+			return err
+		}
+		// The setting of the topology overrides the default given by the
+		// server, so it's set in addition to the flag.
+		ns := ctx.Settings.Topology()
+		ns.Trace = &enabled
+		if err := ctx.Settings.SetTopology(&ns); err != nil {
+			return err
+		}
+		ctx.Flags.TupleTrace.Set(enabled)
+		return nil
+	default:
+		return fmt.Errorf("unknown target type: %v", stmt.Target.Type)
+	}
+}
+
+// setRecoveryPolicy changes the recovery policy of a box, a sink, or the
+// topology. The policy of the topology is applied to boxes and sinks which
+// don't have their own policies.
+func (tb *TopologyBuilder) setRecoveryPolicy(stmt parser.SetRecoveryPolicyStmt) error {
+	params, err := tb.mkParamsMap(stmt.Params)
+	if err != nil {
+		return err
+	}
+	if _, ok := params["policy"]; ok {
+		return fmt.Errorf("the policy cannot be specified as a parameter")
+	}
+	params["policy"] = data.String(stmt.Policy)
+	p, err := core.NewRecoveryPolicy(params)
+	if err != nil {
+		return err
+	}
+
+	switch stmt.Target.Type {
+	case parser.NodeTarget:
+		node, err := tb.topology.Node(string(stmt.Target.Name))
+		if err != nil {
+			return err
+		}
+		return tb.setNodeRecoveryPolicy(node, p)
+	case parser.TopologyTarget:
+		if err := tb.checkTopologyTarget(stmt.Target); err != nil {
+			return err
+		}
+		ctx := tb.topology.Context()
+		ns := ctx.Settings.Topology()
+		ns.Recovery = p
+		return ctx.Settings.SetTopology(&ns)
+	default:
+		return fmt.Errorf("unknown target type: %v", stmt.Target.Type)
+	}
+}
+
+// recoveryParamPrefix is the prefix of parameters of CREATE BOX and CREATE
+// SINK statements configuring the recovery policy of the node. For example,
+// recovery_policy and recovery_max_restarts correspond to policy and
+// max_restarts of core.NewRecoveryPolicy, respectively.
+const recoveryParamPrefix = "recovery_"
+
+// extractRecoveryPolicy removes parameters of the recovery policy from params
+// and creates a policy from them. It returns nil when params doesn't have
+// any parameter of the recovery policy.
+func extractRecoveryPolicy(params data.Map) (*core.RecoveryPolicy, error) {
+	rp := extractPrefixedParams(params, recoveryParamPrefix)
+	if len(rp) == 0 {
+		return nil, nil
+	}
+	return core.NewRecoveryPolicy(rp)
+}
+
+// adaptiveBatchingParamPrefix is the prefix of parameters of CREATE SINK
+// statements configuring adaptive batching of the sink. For example,
+// adaptive_latency_target and adaptive_max_batch_size correspond to
+// latency_target and max_batch_size of core.NewAdaptiveBatching,
+// respectively. Adaptive batching of a sink replaces SinkFlushInterval.
+const adaptiveBatchingParamPrefix = "adaptive_"
+
+// extractAdaptiveBatching removes parameters of adaptive batching from params
+// and creates a config from them. It returns nil when params doesn't have any
+// parameter of adaptive batching.
+func extractAdaptiveBatching(params data.Map) (*core.AdaptiveBatching, error) {
+	bp := extractPrefixedParams(params, adaptiveBatchingParamPrefix)
+	if len(bp) == 0 {
+		return nil, nil
+	}
+	return core.NewAdaptiveBatching(bp)
+}
+
+// reconnectParamPrefix is the prefix of parameters of CREATE SOURCE
+// statements configuring the supervisor of the source. For example,
+// reconnect_max_retries corresponds to max_retries of
+// core.NewSupervisorConfig. A source is supervised when it has at least one
+// of the parameters or it's a reconnectingSource.
+const reconnectParamPrefix = "reconnect_"
+
+// reconnectingSource is a source connecting to an external system which
+// returns an error from GenerateStream when the connection is lost. It
+// relies on the supervisor to reconnect, so it's always supervised. The
+// supervisor has the default config when CREATE SOURCE doesn't have
+// reconnect parameters.
+type reconnectingSource interface {
+	core.Source
+
+	// reconnectsBySupervisor is only used to mark the source.
+	reconnectsBySupervisor()
+}
+
+// extractSupervisorConfig removes parameters of the supervisor from params
+// and creates a config from them. It returns nil when params doesn't have
+// any parameter of the supervisor.
+func extractSupervisorConfig(params data.Map) (*core.SupervisorConfig, error) {
+	sp := extractPrefixedParams(params, reconnectParamPrefix)
+	if len(sp) == 0 {
+		return nil, nil
+	}
+	return core.NewSupervisorConfig(sp)
+}
+
+// extractPrefixedParams removes parameters having the prefix from params and
+// returns them without the prefix.
+func extractPrefixedParams(params data.Map, prefix string) data.Map {
+	m := data.Map{}
+	for k, v := range params {
+		if strings.HasPrefix(k, prefix) {
+			m[strings.TrimPrefix(k, prefix)] = v
+			delete(params, k)
+		}
+	}
+	return m
+}
+
+func (tb *TopologyBuilder) setNodeRecoveryPolicy(node core.Node, p *core.RecoveryPolicy) error {
+	if p == nil {
+		return nil
+	}
+	if node.Type() == core.NTSource {
+		return fmt.Errorf("a recovery policy cannot be set for a source")
+	}
+	return tb.topology.Context().Recovery.Set(node.Name(), p)
+}
+
+func (tb *TopologyBuilder) checkTopologyTarget(t parser.SettingTarget) error {
+	if name := tb.topology.Name(); string(t.Name) != name {
+		return fmt.Errorf("the target topology '%v' is different from the current topology '%v'", t.Name, name)
+	}
+	return nil
+}
+
 // udsfBox is a core.Box which runs a UDSF in the stream mode.
 type udsfBox struct {
 	f udf.UDSF
@@ -410,9 +876,33 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 	// insert a bqlBox that executes the SELECT statement
 	outName := string(stmt.Name)
 	box := NewBQLBox(&stmt.Select, tb.Reg)
+	box.spill = tb.WindowSpill
+	box.name = outName
+	box.arena = tb.WindowArena
+	if tb.ShareWindows {
+		box.windowPool = tb.windowPool
+	}
+	box.parallelAggregation = tb.ParallelAggregation
+	box.keyTTL = tb.KeyTTL
+	box.triggers = tb.timerTriggers(&stmt.Select)
+	evictTo := string(stmt.EvictTo)
+	evictToCreated := false
+	if evictTo != "" {
+		src, created, err := tb.addEvictionTarget(outName, evictTo)
+		if err != nil {
+			return nil, err
+		}
+		box.evictionHook = src.hook
+		evictToCreated = created
+	}
 	// add all the referenced relations as named inputs
-	dbox, err := tb.topology.AddBox(outName, box, nil)
+	dbox, err := tb.topology.AddBox(outName, box, &core.BoxConfig{
+		Meta: selectParams(stmt.Select),
+	})
 	if err != nil {
+		if evictToCreated {
+			tb.topology.Remove(evictTo)
+		}
 		return nil, err
 	}
 	// provide a function to the BQL box to remove itself from the topology
@@ -429,13 +919,26 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 			tb.topology.Remove(n)
 		}
 		tb.topology.Remove(outName)
+		if evictToCreated {
+			tb.topology.Remove(evictTo)
+		}
 	}()
 
 	connected := map[string]bool{}
+	numRefs := map[string]int{}
+	for _, rel := range stmt.Select.Relations {
+		if rel.Type == parser.ActualStream {
+			numRefs[rel.Name]++
+		}
+	}
 	var pausedSources []core.SourceNode
 	for _, rel := range stmt.Select.Relations {
 		switch rel.Type {
 		case parser.ActualStream:
+			if rel.Partition != nil && numRefs[rel.Name] > 1 {
+				// All relations in a self-join share the same input.
+				return nil, fmt.Errorf("PARTITION BY cannot be used for '%v' referred more than once", rel.Name)
+			}
 			if connected[rel.Name] {
 				// this is a self-join (FROM x [RANGE ...] AS a, x [RANGE ...] AS b)
 				// and we already have connected x to this box before
@@ -471,6 +974,10 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 			} else if rel.Shedding == parser.Wait {
 				conf.DropMode = core.DropNone
 			}
+			conf.PipeType = pipeType(rel.Pipe)
+			if conf.Partition, err = tb.partitionConfig(&rel); err != nil {
+				return nil, err
+			}
 			if err := dbox.Input(rel.Name, conf); err != nil {
 				return nil, err
 			}
@@ -500,9 +1007,47 @@ func (tb *TopologyBuilder) createStreamAsSelectStmt(stmt *parser.CreateStreamAsS
 		}
 	}
 	removeNodes = false
+
+	// Remove the previous target when the stream is replaced with a new
+	// target.
+	for _, n := range tb.evictionTargets(outName) {
+		if !strings.EqualFold(n, evictTo) {
+			tb.topology.Remove(n)
+		}
+	}
 	return dbox, nil
 }
 
+// timerTriggers returns names of streams in the FROM clause which are created
+// by timer sources. When the statement also has other streams, the timer
+// streams become triggers of the statement: tuples from other streams only
+// update windows and results are emitted only when the timer ticks. For
+// example, the following statement emits the number of events in the last
+// five minutes every five minutes:
+//
+//	CREATE SOURCE every_5min TYPE timer WITH interval = "5m";
+//	CREATE STREAM report AS SELECT RSTREAM count(*) AS n
+//	    FROM events [RANGE 300 SECONDS], every_5min [RANGE 1 TUPLES];
+func (tb *TopologyBuilder) timerTriggers(stmt *parser.SelectStmt) []string {
+	var triggers []string
+	hasOthers := false
+	for _, rel := range stmt.Relations {
+		if rel.Type == parser.ActualStream {
+			if sn, err := tb.topology.Source(rel.Name); err == nil {
+				if _, ok := sn.Source().(*timerSource); ok {
+					triggers = append(triggers, rel.Name)
+					continue
+				}
+			}
+		}
+		hasOthers = true
+	}
+	if !hasOthers {
+		return nil
+	}
+	return triggers
+}
+
 // setUpUDSFStream creates a Source or a Box from a UDSF. When it creates a
 // Source, it will return the corresponding core.SourceNode of it. Otherwise,
 // it returns nil for core.SourceNode. It also returns the temporary name of
@@ -583,6 +1128,12 @@ func (tb *TopologyBuilder) setUpUDSFStream(subsequentBox core.BoxNode, rel *pars
 		} else if rel.Shedding == parser.Wait {
 			conf.DropMode = core.DropNone
 		}
+		conf.PipeType = pipeType(rel.Pipe)
+		partition, err := tb.partitionConfig(rel)
+		if err != nil {
+			return err
+		}
+		conf.Partition = partition
 		return subsequentBox.Input(temporaryName, conf)
 	}
 
@@ -623,12 +1174,76 @@ func (tb *TopologyBuilder) setUpUDSFStream(subsequentBox core.BoxNode, rel *pars
 	return nil, temporaryName, nil
 }
 
-func (tb *TopologyBuilder) mkParamsMap(params []parser.SourceSinkParamAST) data.Map {
+// pipeType returns the type of the input pipe specified by a CHANNEL PIPE or
+// RING PIPE clause. It's core.ChannelPipe when the clause isn't given.
+func pipeType(p parser.PipeOption) core.PipeType {
+	if p == parser.RingPipe {
+		return core.RingPipe
+	}
+	return core.ChannelPipe
+}
+
+// partitionConfig creates a config of hash partitioning from the PARTITION BY
+// clause of the relation. It returns nil when the relation doesn't have the
+// clause. The key expression can refer to columns of the relation with or
+// without its name or alias.
+func (tb *TopologyBuilder) partitionConfig(rel *parser.AliasedStreamWindowAST) (*core.PartitionConfig, error) {
+	p := rel.Partition
+	if p == nil {
+		return nil, nil
+	}
+	if p.Count > math.MaxInt32 {
+		return nil, fmt.Errorf("the number of partitions %d is too large", p.Count)
+	}
+
+	expr := p.Expr
+	for r := range expr.ReferencedRelations() {
+		if r != "" && r != rel.Name && r != rel.Alias {
+			return nil, fmt.Errorf("PARTITION BY cannot refer to other streams: %v", r)
+		}
+		expr = expr.RenameReferencedRelation(r, "input")
+	}
+	flatExpr, err := execution.ParserExprToFlatExpr(expr, tb.Reg)
+	if err != nil {
+		return nil, err
+	}
+	eval, err := execution.ExpressionToEvaluator(flatExpr, tb.Reg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &core.PartitionConfig{
+		Key: func(t *core.Tuple) (data.Value, error) {
+			// nest the data so that access via JSON path works properly
+			return eval.Eval(data.Map{"input": t.Data})
+		},
+		Count: int(p.Count),
+		Index: int(p.Index),
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// mkParamsMap creates a parameter map from the AST. References to secrets in
+// parameters are substituted when tb.Secrets is set. Substituted values are
+// registered to the context of the topology so that they're redacted from
+// statuses of nodes.
+func (tb *TopologyBuilder) mkParamsMap(params []parser.SourceSinkParamAST) (data.Map, error) {
 	paramsMap := make(data.Map, len(params))
 	for _, kv := range params {
 		paramsMap[string(kv.Key)] = kv.Value
 	}
-	return paramsMap
+	if tb.Secrets == nil {
+		return paramsMap, nil
+	}
+	m, secrets, err := SubstituteSecrets(tb.Secrets, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	tb.topology.Context().Secrets.Add(secrets...)
+	return m, nil
 }
 
 type chanSink struct {
@@ -718,14 +1333,15 @@ func (tb *TopologyBuilder) AddSelectUnionStmt(stmts *parser.SelectUnionStmt) (co
 			//  + a connection (random_string -> sink)
 			tmpName := fmt.Sprintf("sensorbee_tmp_%v", topologyBuilderNextTemporaryID())
 			tmpStmt := parser.CreateStreamAsSelectStmt{
-				parser.StreamIdentifier(tmpName),
-				parser.SelectStmt{
+				Name: parser.StreamIdentifier(tmpName),
+				Select: parser.SelectStmt{
 					stmt.EmitterAST,
 					stmt.ProjectionsAST,
 					stmt.WindowedFromAST,
 					stmt.FilterAST,
 					stmt.GroupingAST,
 					stmt.HavingAST,
+					stmt.InputSamplingAST,
 				},
 			}
 			box, err := tb.AddStmt(tmpStmt)
@@ -820,7 +1436,14 @@ func (tb *TopologyBuilder) saveState(name, tag string) error {
 		return err
 	}
 	shouldAbort = false
-	return w.Commit()
+	if err := w.Commit(); err != nil {
+		return err
+	}
+	tb.topology.Context().ReportSystemEvent(core.SETStateSaved, data.Map{
+		"state_name": data.String(name),
+		"state_tag":  data.String(tag),
+	})
+	return nil
 }
 
 // loadState loads a state from the storage. It returns true when the state was
@@ -832,13 +1455,28 @@ func (tb *TopologyBuilder) loadState(typeName, name, tag string, params data.Map
 	}
 	defer r.Close()
 
+	if err := tb.loadStateFrom(typeName, name, r, params); err != nil {
+		if err == errStateNotLoadable {
+			err = fmt.Errorf("the state '%v-%v' cannot be loaded", name, tag)
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+var errStateNotLoadable = errors.New("the state cannot be loaded")
+
+// loadStateFrom loads a state from the reader having data written by
+// core.SavableSharedState.Save. It returns errStateNotLoadable when the type
+// of the state doesn't support loading.
+func (tb *TopologyBuilder) loadStateFrom(typeName, name string, r io.Reader, params data.Map) error {
 	c, err := tb.UDSCreators.Lookup(typeName)
 	if err != nil {
-		return false, err
+		return err
 	}
 	loader, ok := c.(udf.UDSLoader)
 	if !ok {
-		return false, fmt.Errorf("the state '%v-%v' cannot be loaded", name, tag)
+		return errStateNotLoadable
 	}
 
 	// If the state is loaded and it provides Load method, Load method will be
@@ -848,22 +1486,22 @@ func (tb *TopologyBuilder) loadState(typeName, name, tag string, params data.Map
 	if err != nil {
 		// TODO: check if the error is "not found". Return only on other errors.
 	} else if t, err := reg.Type(name); err != nil {
-		return false, err
+		return err
 	} else if t != typeName {
-		return false, fmt.Errorf("type name doesn't much to the current state's type")
+		return fmt.Errorf("type name doesn't much to the current state's type")
 	}
 
 	if l, ok := s.(core.LoadableSharedState); ok {
-		return false, l.Load(tb.topology.Context(), r, params)
+		return l.Load(tb.topology.Context(), r, params)
 	}
 
 	newState, err := loader.LoadState(tb.topology.Context(), r, params)
 	if err != nil {
-		return false, err
+		return err
 	}
 	prev, err := reg.Replace(name, typeName, newState)
 	if err != nil {
-		return false, err
+		return err
 	}
 	if prev != nil {
 		if err := prev.Terminate(tb.topology.Context()); err != nil {
@@ -871,5 +1509,5 @@ func (tb *TopologyBuilder) loadState(typeName, name, tag string, params data.Map
 				Error("Cannot terminate the previous instance of the loaded state")
 		}
 	}
-	return false, nil
+	return nil
 }
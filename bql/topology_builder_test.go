@@ -1,6 +1,7 @@
 package bql
 
 import (
+	"github.com/Sirupsen/logrus"
 	. "github.com/smartystreets/goconvey/convey"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
@@ -51,6 +52,46 @@ func TestCreateSourceStmt(t *testing.T) {
 			})
 		})
 
+		Convey("When running CREATE SOURCE with reconnect parameters", func() {
+			err := addBQLToTopology(tb, `CREATE PAUSED SOURCE hoge TYPE dummy
+				WITH num=4, reconnect_max_retries=3, reconnect_jitter=0.1`)
+
+			Convey("Then the source should be supervised", func() {
+				So(err, ShouldBeNil)
+				sn, err := dt.Source("hoge")
+				So(err, ShouldBeNil)
+				v, err := sn.Status().Get(data.MustCompilePath("source.supervisor.config.max_retries"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When running CREATE SOURCE of a source reconnecting by the supervisor", func() {
+			SetOPCUAClient(&fakeOPCUAClient{})
+			Reset(func() {
+				SetOPCUAClient(nil)
+			})
+			err := addBQLToTopology(tb, `CREATE PAUSED SOURCE hoge TYPE opcua
+				WITH endpoint="opc.tcp://localhost:4840", nodes=["i=2258"]`)
+
+			Convey("Then the source should be supervised by default", func() {
+				So(err, ShouldBeNil)
+				sn, err := dt.Source("hoge")
+				So(err, ShouldBeNil)
+				v, err := sn.Status().Get(data.MustCompilePath("source.supervisor.config.max_retries"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When running CREATE SOURCE with invalid reconnect parameters", func() {
+			err := addBQLToTopology(tb, `CREATE SOURCE hoge TYPE dummy WITH reconnect_jitter=2.0`)
+
+			Convey("Then an error should be returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
 		Convey("When running CREATE SOURCE with invalid parameters", func() {
 			err := addBQLToTopology(tb, `CREATE SOURCE hoge TYPE dummy WITH num="bar"`)
 
@@ -91,6 +132,44 @@ func TestCreateStreamAsSelectStmt(t *testing.T) {
 		err = addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy`)
 		So(err, ShouldBeNil)
 
+		Convey("When running CREATE STREAM AS SELECT with a ring pipe", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM int FROM
+                s [RANGE 2 TUPLES, BUFFER SIZE 16, DROP NEWEST IF FULL, RING PIPE]`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the input of the stream should be a ring pipe", func() {
+				b, err := dt.Box("t")
+				So(err, ShouldBeNil)
+				c := b.Inputs()["s"]
+				So(c, ShouldNotBeNil)
+				So(c.PipeType, ShouldEqual, core.RingPipe)
+				So(c.Capacity, ShouldEqual, 16)
+				So(c.DropMode, ShouldEqual, core.DropLatest)
+			})
+		})
+
+		Convey("When running CREATE STREAM AS SELECT with a channel pipe", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM int FROM
+                s [RANGE 2 TUPLES, CHANNEL PIPE]`)
+			So(err, ShouldBeNil)
+
+			Convey("Then the input of the stream should be a channel pipe", func() {
+				b, err := dt.Box("t")
+				So(err, ShouldBeNil)
+				So(b.Inputs()["s"].PipeType, ShouldEqual, core.ChannelPipe)
+			})
+		})
+
+		Convey("When running CREATE STREAM AS SELECT with a ring pipe dropping the oldest tuple", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM int FROM
+                s [RANGE 2 TUPLES, DROP OLDEST IF FULL, RING PIPE]`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "ring pipe")
+			})
+		})
+
 		Convey("When running CREATE STREAM AS SELECT on an existing stream", func() {
 			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM int FROM
                 s [RANGE 2 SECONDS, BUFFER SIZE 2, WAIT IF FULL] WHERE int=2`)
@@ -257,6 +336,86 @@ func TestCreateStreamAsSelectStmt(t *testing.T) {
 	})
 }
 
+func TestCreateStreamAsSelectStmtWithTimer(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source and a timer", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy`), ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE tick TYPE timer WITH interval=1`), ShouldBeNil)
+
+		Convey("When selecting from both of them", func() {
+			s, _, err := parser.New().ParseStmt(`SELECT RSTREAM count(*) FROM s [RANGE 2 TUPLES], tick [RANGE 1 TUPLES]`)
+			So(err, ShouldBeNil)
+			stmt := s.(parser.SelectStmt)
+
+			Convey("Then the timer should be a trigger", func() {
+				So(tb.timerTriggers(&stmt), ShouldResemble, []string{"tick"})
+			})
+		})
+
+		Convey("When selecting only from the timer", func() {
+			s, _, err := parser.New().ParseStmt(`SELECT RSTREAM * FROM tick [RANGE 1 TUPLES]`)
+			So(err, ShouldBeNil)
+			stmt := s.(parser.SelectStmt)
+
+			Convey("Then it shouldn't have any trigger", func() {
+				So(tb.timerTriggers(&stmt), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When creating a stream driven by the timer", func() {
+			err := addBQLToTopology(tb, `CREATE STREAM t AS SELECT RSTREAM count(*) AS n
+                FROM s [RANGE 2 TUPLES], tick [RANGE 1 TUPLES]`)
+
+			Convey("Then there should be no error", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateStreamAsSelectStmtWithSharedWindows(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy;`), ShouldBeNil)
+		stmts := `CREATE STREAM a AS SELECT RSTREAM int FROM s [RANGE 2 TUPLES];
+			CREATE STREAM b AS SELECT RSTREAM int * 2 AS x FROM s [RANGE 2 TUPLES];`
+
+		Convey("When creating streams having identical windows with shared windows", func() {
+			tb.ShareWindows = true
+			So(addBQLToTopology(tb, stmts), ShouldBeNil)
+
+			Convey("Then they should share a window buffer", func() {
+				So(tb.windowPool.NumWindows(), ShouldEqual, 1)
+			})
+
+			Convey("Then the buffer should be removed after dropping both streams", func() {
+				So(addBQLToTopology(tb, `DROP STREAM a;`), ShouldBeNil)
+				So(tb.windowPool.NumWindows(), ShouldEqual, 1)
+				So(addBQLToTopology(tb, `DROP STREAM b;`), ShouldBeNil)
+				So(tb.windowPool.NumWindows(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When creating streams having identical windows without shared windows", func() {
+			So(addBQLToTopology(tb, stmts), ShouldBeNil)
+
+			Convey("Then they shouldn't share a window buffer", func() {
+				So(tb.windowPool.NumWindows(), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
 func TestCreateStreamAsSelectUnionStmt(t *testing.T) {
 	Convey("Given a BQL TopologyBuilder with source and stream", t, func() {
 		dt := newTestTopology()
@@ -755,6 +914,14 @@ func TestUpdateSourceStmt(t *testing.T) {
 					So(err, ShouldBeNil)
 				})
 			})
+
+			Convey("When updating the updatable source with WITH", func() {
+				err := addBQLToTopology(tb, `UPDATE SOURCE hoge WITH num=5;`)
+
+				Convey("There should be no error", func() {
+					So(err, ShouldBeNil)
+				})
+			})
 		})
 	})
 }
@@ -1046,6 +1213,10 @@ func TestDropSourceStmt(t *testing.T) {
 			Convey("Then dropping should fail", func() {
 				So(addBQLToTopology(tb, `DROP SOURCE hoge;`), ShouldNotBeNil)
 			})
+
+			Convey("Then dropping with IF EXISTS should succeed", func() {
+				So(addBQLToTopology(tb, `DROP SOURCE IF EXISTS hoge;`), ShouldBeNil)
+			})
 		})
 
 		Convey("When adding a source", func() {
@@ -1055,6 +1226,33 @@ func TestDropSourceStmt(t *testing.T) {
 				So(addBQLToTopology(tb, `DROP SOURCE hoge;`), ShouldBeNil)
 			})
 		})
+
+		Convey("When adding a source and a stream reading from it", func() {
+			So(addBQLToTopology(tb, `CREATE PAUSED SOURCE hoge TYPE dummy;
+				CREATE STREAM t AS SELECT ISTREAM * FROM hoge [RANGE 1 TUPLES];`), ShouldBeNil)
+
+			Convey("Then dropping the source should fail", func() {
+				err := addBQLToTopology(tb, `DROP SOURCE hoge;`)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "used by t")
+
+				Convey("And the source should still exist", func() {
+					_, err := dt.Source("hoge")
+					So(err, ShouldBeNil)
+				})
+			})
+
+			Convey("Then dropping the source with CASCADE should succeed", func() {
+				So(addBQLToTopology(tb, `DROP SOURCE hoge CASCADE;`), ShouldBeNil)
+
+				Convey("And the stream should also be dropped", func() {
+					_, err := dt.Source("hoge")
+					So(core.IsNotExist(err), ShouldBeTrue)
+					_, err = dt.Box("t")
+					So(core.IsNotExist(err), ShouldBeTrue)
+				})
+			})
+		})
 	})
 }
 
@@ -1073,6 +1271,27 @@ func TestDropStreamStmt(t *testing.T) {
 			Convey("Then dropping should fail", func() {
 				So(addBQLToTopology(tb, `DROP STREAM t;`), ShouldNotBeNil)
 			})
+
+			Convey("Then dropping with IF EXISTS should succeed", func() {
+				So(addBQLToTopology(tb, `DROP STREAM IF EXISTS t;`), ShouldBeNil)
+			})
+		})
+
+		Convey("When a UNION ALL stream reads from a stream", func() {
+			So(addBQLToTopology(tb, `CREATE STREAM t AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE STREAM u AS SELECT ISTREAM * FROM t [RANGE 1 TUPLES]
+				UNION ALL SELECT ISTREAM * FROM s [RANGE 1 TUPLES];`), ShouldBeNil)
+
+			Convey("Then dropping the stream should fail", func() {
+				err := addBQLToTopology(tb, `DROP STREAM t;`)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "used by u")
+			})
+
+			Convey("Then dropping the UNION ALL stream first should succeed", func() {
+				So(addBQLToTopology(tb, `DROP STREAM u;`), ShouldBeNil)
+				So(addBQLToTopology(tb, `DROP STREAM t;`), ShouldBeNil)
+			})
 		})
 
 		Convey("When running CREATE STREAM AS SELECT on an existing stream", func() {
@@ -1103,6 +1322,10 @@ func TestDropSinkStmt(t *testing.T) {
 			Convey("Then dropping should fail", func() {
 				So(addBQLToTopology(tb, `DROP SINK hoge;`), ShouldNotBeNil)
 			})
+
+			Convey("Then dropping with IF EXISTS should succeed", func() {
+				So(addBQLToTopology(tb, `DROP SINK IF EXISTS hoge;`), ShouldBeNil)
+			})
 		})
 
 		Convey("When adding a sink", func() {
@@ -1129,6 +1352,10 @@ func TestDropStateStmt(t *testing.T) {
 			Convey("Then dropping should fail", func() {
 				So(addBQLToTopology(tb, `DROP STATE hoge;`), ShouldNotBeNil)
 			})
+
+			Convey("Then dropping with IF EXISTS should succeed", func() {
+				So(addBQLToTopology(tb, `DROP STATE IF EXISTS hoge;`), ShouldBeNil)
+			})
 		})
 
 		Convey("When adding an UDS", func() {
@@ -1141,6 +1368,416 @@ func TestDropStateStmt(t *testing.T) {
 	})
 }
 
+func TestCreateOrReplaceStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source, a stream, and a sink", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE s TYPE dummy;
+			CREATE STREAM t AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+			CREATE SINK k TYPE collector;
+			INSERT INTO k FROM t;`), ShouldBeNil)
+		prev, err := dt.Box("t")
+		So(err, ShouldBeNil)
+
+		Convey("When replacing the stream", func() {
+			So(addBQLToTopology(tb, `CREATE OR REPLACE STREAM t AS
+				SELECT ISTREAM int FROM s [RANGE 1 TUPLES];`), ShouldBeNil)
+
+			Convey("Then the stream should be a new node", func() {
+				b, err := dt.Box("t")
+				So(err, ShouldBeNil)
+				So(b, ShouldNotEqual, prev)
+				So(b.Inputs(), ShouldContainKey, "s")
+			})
+
+			Convey("Then the sink should be reconnected to the new stream", func() {
+				k, err := dt.Sink("k")
+				So(err, ShouldBeNil)
+				So(k.Inputs(), ShouldContainKey, "t")
+				So(len(k.Inputs()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When replacing the source", func() {
+			So(addBQLToTopology(tb, `CREATE OR REPLACE PAUSED SOURCE s TYPE dummy;`), ShouldBeNil)
+
+			Convey("Then the stream should be reconnected to the new source", func() {
+				So(prev.State().Get(), ShouldEqual, core.TSRunning)
+				So(prev.Inputs(), ShouldContainKey, "s")
+				So(len(prev.Inputs()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When replacing a node with a node having a different type", func() {
+			err := addBQLToTopology(tb, `CREATE OR REPLACE SINK s TYPE collector;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then the original node should remain", func() {
+				_, err := dt.Source("s")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When replacing a nonexistent sink", func() {
+			So(addBQLToTopology(tb, `CREATE OR REPLACE SINK k2 TYPE collector;`), ShouldBeNil)
+
+			Convey("Then the sink should be created", func() {
+				_, err := dt.Sink("k2")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When replacing a state", func() {
+			So(addBQLToTopology(tb, `CREATE STATE hoge TYPE dummy_uds WITH num=5;`), ShouldBeNil)
+			So(addBQLToTopology(tb, `CREATE OR REPLACE STATE hoge TYPE dummy_uds WITH num=6;`), ShouldBeNil)
+
+			Convey("Then the state should have new parameters", func() {
+				s, err := dt.Context().SharedStates.Get("hoge")
+				So(err, ShouldBeNil)
+				So(s.(*dummyUDS).num, ShouldEqual, 6)
+			})
+		})
+	})
+}
+
+func TestSetLogLevelStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE hoge TYPE dummy;`), ShouldBeNil)
+		levels := dt.Context().LogLevels
+
+		Convey("When setting the log level of the source", func() {
+			So(addBQLToTopology(tb, `SET LOG LEVEL debug FOR NODE hoge;`), ShouldBeNil)
+
+			Convey("Then the source should have the level", func() {
+				lvl, ok := levels.NodeLevel("hoge")
+				So(ok, ShouldBeTrue)
+				So(lvl, ShouldEqual, logrus.DebugLevel)
+			})
+
+			Convey("Then the level of the topology shouldn't change", func() {
+				So(levels.Level(), ShouldNotEqual, logrus.DebugLevel)
+			})
+		})
+
+		Convey("When setting the log level of the topology", func() {
+			So(addBQLToTopology(tb, `SET LOG LEVEL error FOR TOPOLOGY testTopology;`), ShouldBeNil)
+
+			Convey("Then the topology should have the level", func() {
+				So(levels.Level(), ShouldEqual, logrus.ErrorLevel)
+			})
+		})
+
+		Convey("When setting the log level of a missing node", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET LOG LEVEL debug FOR NODE no_such_node;`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When setting the log level of another topology", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET LOG LEVEL debug FOR TOPOLOGY another;`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When setting an invalid log level", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET LOG LEVEL verbose FOR NODE hoge;`), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSetTraceStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE hoge TYPE dummy;`), ShouldBeNil)
+		flag := &dt.Context().Flags.TupleTrace
+
+		Convey("When turning on tracing of the topology", func() {
+			So(addBQLToTopology(tb, `SET TRACE ON FOR TOPOLOGY testTopology;`), ShouldBeNil)
+
+			Convey("Then tuples should be traced", func() {
+				So(flag.Enabled(), ShouldBeTrue)
+			})
+
+			Convey("And turning it off", func() {
+				So(addBQLToTopology(tb, `SET TRACE OFF FOR TOPOLOGY testTopology;`), ShouldBeNil)
+
+				Convey("Then tuples shouldn't be traced", func() {
+					So(flag.Enabled(), ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("When turning on tracing of a node", func() {
+			So(addBQLToTopology(tb, `SET TRACE ON FOR NODE hoge;`), ShouldBeNil)
+
+			Convey("Then only tuples passing the node should be traced", func() {
+				So(dt.Context().Traced("hoge"), ShouldBeTrue)
+				So(dt.Context().Traced(""), ShouldBeFalse)
+			})
+
+			Convey("And turning off tracing of the topology", func() {
+				So(addBQLToTopology(tb, `SET TRACE OFF FOR TOPOLOGY testTopology;`), ShouldBeNil)
+
+				Convey("Then the node's setting should be kept", func() {
+					So(dt.Context().Traced("hoge"), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When turning on tracing of a missing node", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET TRACE ON FOR NODE fuga;`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When turning off tracing of the topology enabled by default", func() {
+			on := true
+			So(dt.Context().Settings.SetDefaults(&core.NodeSettings{Trace: &on}), ShouldBeNil)
+			So(addBQLToTopology(tb, `SET TRACE OFF FOR TOPOLOGY testTopology;`), ShouldBeNil)
+
+			Convey("Then tuples shouldn't be traced", func() {
+				So(dt.Context().Traced("hoge"), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestCreateSinkWithAdaptiveBatching(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+
+		Convey("When creating a sink with adaptive batching", func() {
+			err := addBQLToTopology(tb, `CREATE SINK snk TYPE collector
+				WITH adaptive_latency_target="100ms", adaptive_max_batch_size=100;`)
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+				_, err := dt.Sink("snk")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When creating a sink with invalid adaptive batching", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `CREATE SINK snk TYPE collector
+					WITH adaptive_max_batch_size=100;`), ShouldNotBeNil)
+				So(addBQLToTopology(tb, `CREATE SINK snk TYPE collector
+					WITH adaptive_latency_target=1, adaptive_batch_size=1;`), ShouldNotBeNil)
+				_, err := dt.Sink("snk")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSetRecoveryPolicyStmt(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a source", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `CREATE PAUSED SOURCE hoge TYPE dummy;`), ShouldBeNil)
+		policies := dt.Context().Recovery
+
+		Convey("When creating a sink with a recovery policy", func() {
+			So(addBQLToTopology(tb, `CREATE SINK snk TYPE collector
+				WITH recovery_policy="restart_node", recovery_max_restarts=3;`), ShouldBeNil)
+
+			Convey("Then the sink should have the policy", func() {
+				p, ok := policies.Get("snk")
+				So(ok, ShouldBeTrue)
+				So(p.Type, ShouldEqual, core.RPRestartNode)
+				So(p.MaxRestarts, ShouldEqual, 3)
+			})
+
+			Convey("And changing the policy", func() {
+				So(addBQLToTopology(tb, `SET RECOVERY POLICY halt FOR NODE snk;`), ShouldBeNil)
+
+				Convey("Then the sink should have the new policy", func() {
+					p, ok := policies.Get("snk")
+					So(ok, ShouldBeTrue)
+					So(p.Type, ShouldEqual, core.RPHalt)
+				})
+			})
+		})
+
+		Convey("When creating a sink with an invalid recovery policy", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `CREATE SINK snk TYPE collector
+					WITH recovery_policy="retry";`), ShouldNotBeNil)
+				_, err := dt.Sink("snk")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When setting the recovery policy of the source", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET RECOVERY POLICY restart_node FOR NODE hoge;`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When setting the recovery policy of the topology", func() {
+			So(addBQLToTopology(tb, `SET RECOVERY POLICY restart_node FOR TOPOLOGY testTopology
+				WITH max_restarts=2;`), ShouldBeNil)
+
+			Convey("Then the topology's settings should have the policy", func() {
+				p := dt.Context().Settings.Resolve("snk").Recovery
+				So(p, ShouldNotBeNil)
+				So(p.Type, ShouldEqual, core.RPRestartNode)
+				So(p.MaxRestarts, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When setting the recovery policy of another topology", func() {
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET RECOVERY POLICY restart_node FOR TOPOLOGY hoge;`), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When setting the recovery policy with the policy parameter", func() {
+			So(addBQLToTopology(tb, `CREATE SINK snk TYPE collector;`), ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(addBQLToTopology(tb, `SET RECOVERY POLICY halt FOR NODE snk
+					WITH policy="restart_node";`), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+// breakingBox panics on the tuple having int=2 and keeps panicking after that
+// because the panic breaks its state.
+type breakingBox struct {
+	broken bool
+}
+
+func (b *breakingBox) Process(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+	if b.broken || t.Data["int"] == data.Int(2) {
+		b.broken = true
+		panic("the box is broken")
+	}
+	return w.Write(ctx, t)
+}
+
+func TestRecreateBox(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a box type breaking on panics", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		var params []data.Map
+		So(tb.BoxCreators.Register("breaking", BoxCreatorFunc(func(ctx *core.Context, ioParams *IOParams, p data.Map) (core.Box, error) {
+			params = append(params, p.Copy())
+			delete(p, "a")
+			return &breakingBox{}, nil
+		})), ShouldBeNil)
+
+		Convey("When the box panics with a recovery policy recreating it", func() {
+			So(addBQLToTopology(tb, `
+				CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+				CREATE BOX b TYPE breaking FROM source WITH a=1,
+					recovery_policy="restart_node", recovery_initial_backoff="1ns",
+					recovery_recreate=true;
+				CREATE SINK snk TYPE collector;
+				INSERT INTO snk FROM b;
+				RESUME SOURCE source;`), ShouldBeNil)
+			sn, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			si := sn.Sink().(*tupleCollectorSink)
+			si.Wait(3)
+
+			Convey("Then the box should be recreated and process subsequent tuples", func() {
+				So(si.len(), ShouldEqual, 3)
+				So(si.get(2).Data["int"], ShouldEqual, data.Int(4))
+				bn, err := dt.Box("b")
+				So(err, ShouldBeNil)
+				So(bn.Box().(*breakingBox).broken, ShouldBeFalse)
+			})
+
+			Convey("Then the box should be recreated from the original parameters", func() {
+				So(params, ShouldResemble, []data.Map{{"a": data.Int(1)}, {"a": data.Int(1)}})
+			})
+		})
+	})
+}
+
+// boxConfigTopology records configs of boxes added to the topology.
+type boxConfigTopology struct {
+	core.Topology
+	configs map[string]*core.BoxConfig
+}
+
+func (t *boxConfigTopology) AddBox(name string, b core.Box, config *core.BoxConfig) (core.BoxNode, error) {
+	t.configs[name] = config
+	return t.Topology.AddBox(name, b, config)
+}
+
+func TestBoxNice(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder having nice of a box", t, func() {
+		dt := &boxConfigTopology{
+			Topology: newTestTopology(),
+			configs:  map[string]*core.BoxConfig{},
+		}
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(tb.BoxCreators.Register("breaking", BoxCreatorFunc(func(ctx *core.Context, ioParams *IOParams, p data.Map) (core.Box, error) {
+			return &breakingBox{}, nil
+		})), ShouldBeNil)
+		tb.BoxParallelism = 2
+		tb.BoxNice = 5
+		tb.NodeNice = map[string]int{"b1": 19}
+
+		Convey("When creating boxes", func() {
+			So(addBQLToTopology(tb, `
+				CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+				CREATE BOX B1 TYPE breaking FROM source;
+				CREATE BOX b2 TYPE breaking FROM source;`), ShouldBeNil)
+
+			Convey("Then the box should have an automatically sized pool with its nice", func() {
+				So(dt.configs["B1"].Parallelism, ShouldEqual, core.ParallelismAuto)
+				So(dt.configs["B1"].Nice, ShouldEqual, 19)
+			})
+
+			Convey("Then other boxes should have the topology-wide values", func() {
+				So(dt.configs["b2"].Parallelism, ShouldEqual, 2)
+				So(dt.configs["b2"].Nice, ShouldEqual, 5)
+			})
+		})
+	})
+}
+
 func waitForExpectedCondition(f func() bool) {
 	for !f() {
 		time.Sleep(time.Nanosecond)
@@ -0,0 +1,256 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"strings"
+)
+
+// isTemporaryNode returns true when the node is created internally by the
+// TopologyBuilder (e.g. for a UNION ALL or a SELECT statement issued by a
+// client). Such nodes are automatically removed when their inputs are
+// disconnected.
+func isTemporaryNode(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), "sensorbee_tmp_")
+}
+
+// consumerInput is an input connection from a node to one of its consumers.
+// Exactly one of box and sink is set.
+type consumerInput struct {
+	box     core.BoxNode
+	boxConf *core.BoxInputConfig
+
+	sink     core.SinkNode
+	sinkConf *core.SinkInputConfig
+}
+
+func (c *consumerInput) name() string {
+	if c.box != nil {
+		return c.box.Name()
+	}
+	return c.sink.Name()
+}
+
+// connect connects the consumer to the node having the name with the same
+// config as the one originally used.
+func (c *consumerInput) connect(name string) error {
+	if c.box != nil {
+		return c.box.Input(name, c.boxConf)
+	}
+	return c.sink.Input(name, c.sinkConf)
+}
+
+// consumers returns all boxes and sinks directly receiving tuples from the
+// node having the name. The node itself isn't included even if it receives
+// its own output.
+func (tb *TopologyBuilder) consumers(name string) []*consumerInput {
+	lowerName := strings.ToLower(name)
+	var cs []*consumerInput
+	for n, b := range tb.topology.Boxes() {
+		if n == lowerName {
+			continue
+		}
+		for in, conf := range b.Inputs() {
+			if strings.ToLower(in) == lowerName {
+				cs = append(cs, &consumerInput{box: b, boxConf: conf})
+			}
+		}
+	}
+	for _, s := range tb.topology.Sinks() {
+		for in, conf := range s.Inputs() {
+			if strings.ToLower(in) == lowerName {
+				cs = append(cs, &consumerInput{sink: s, sinkConf: conf})
+			}
+		}
+	}
+	return cs
+}
+
+// dependents returns names of nodes which were explicitly created by users
+// and consume the output of the node having the name. Temporary nodes are
+// looked through so that, for example, a stream defined by UNION ALL is
+// reported as a dependent of all streams in the statement.
+func (tb *TopologyBuilder) dependents(name string) []string {
+	var names []string
+	visited := map[string]bool{strings.ToLower(name): true}
+	var visit func(string)
+	visit = func(name string) {
+		for _, c := range tb.consumers(name) {
+			n := c.name()
+			if visited[strings.ToLower(n)] {
+				continue
+			}
+			visited[strings.ToLower(n)] = true
+			if isTemporaryNode(n) {
+				visit(n)
+				continue
+			}
+			names = append(names, n)
+		}
+	}
+	visit(name)
+	return names
+}
+
+// dropNode removes the node having the name. It fails when other nodes
+// depend on the node unless cascade is true, in which case all dependents
+// are dropped first. dropping has names of nodes being dropped by the
+// current statement so that cyclic dependencies terminate.
+func (tb *TopologyBuilder) dropNode(name string, cascade bool, dropping map[string]bool) error {
+	// streams receiving evicted tuples are dropped with the owner
+	owned := tb.evictionTargets(name)
+	dropping[strings.ToLower(name)] = true
+	for _, n := range owned {
+		dropping[strings.ToLower(n)] = true
+	}
+	var deps []string
+	for _, n := range append([]string{name}, owned...) {
+		for _, d := range tb.dependents(n) {
+			if !dropping[strings.ToLower(d)] {
+				deps = append(deps, d)
+			}
+		}
+	}
+	if len(deps) > 0 {
+		if !cascade {
+			return fmt.Errorf("'%v' cannot be dropped because it's used by %v (use CASCADE to drop them as well)",
+				name, strings.Join(deps, ", "))
+		}
+		for _, d := range deps {
+			if err := tb.dropNode(d, true, dropping); err != nil && !core.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	if err := tb.topology.Remove(name); err != nil {
+		return err
+	}
+	for _, n := range owned {
+		if err := tb.topology.Remove(n); err != nil && !core.IsNotExist(err) {
+			return err
+		}
+	}
+	tb.undefine(nodeDefinitionPrefix, name)
+	return nil
+}
+
+// drop implements DROP SOURCE/STREAM/SINK. lookup checks that the node has
+// the expected type.
+func (tb *TopologyBuilder) drop(name string, ifExists, cascade parser.BinaryKeyword,
+	lookup func(string) error) error {
+	if err := lookup(name); err != nil {
+		if ifExists == parser.Yes && core.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return tb.dropNode(name, cascade == parser.Yes, map[string]bool{})
+}
+
+// placeholderSource is a source which never emits a tuple. It keeps consumers
+// of a node being replaced from stopping due to the disconnection of their
+// last input.
+type placeholderSource struct {
+	stop chan struct{}
+}
+
+func newPlaceholderSource() *placeholderSource {
+	return &placeholderSource{
+		stop: make(chan struct{}),
+	}
+}
+
+func (s *placeholderSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	<-s.stop
+	return nil
+}
+
+func (s *placeholderSource) Stop(ctx *core.Context) error {
+	close(s.stop)
+	return nil
+}
+
+// replaceNode replaces the node having the name with a new node created by
+// the given function. When there's no such node, it just calls create.
+// Consumers of the previous node are reconnected to the new node with the
+// same input configs. Inputs of the previous node itself aren't restored
+// and the new node has to connect its own inputs in create.
+func (tb *TopologyBuilder) replaceNode(name string, nodeType core.NodeType,
+	create func() (core.Node, error)) (core.Node, error) {
+	prev, err := tb.topology.Node(name)
+	if err != nil {
+		if !core.IsNotExist(err) {
+			return nil, err
+		}
+		return create()
+	}
+	if prev.Type() != nodeType {
+		return nil, fmt.Errorf("'%v' is a %v and cannot be replaced with a %v",
+			name, prev.Type(), nodeType)
+	}
+
+	cs := tb.consumers(name)
+	if len(cs) > 0 {
+		tmpName := fmt.Sprintf("sensorbee_tmp_placeholder_%v", topologyBuilderNextTemporaryID())
+		if _, err := tb.topology.AddSource(tmpName, newPlaceholderSource(), nil); err != nil {
+			return nil, err
+		}
+		defer tb.topology.Remove(tmpName)
+		for _, c := range cs {
+			if err := c.connect(tmpName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tb.topology.Remove(name); err != nil {
+		return nil, err
+	}
+	node, err := create()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		if err := c.connect(name); err != nil {
+			return nil, fmt.Errorf("cannot reconnect '%v' to '%v': %v", c.name(), name, err)
+		}
+	}
+	return node, nil
+}
+
+// replaceState replaces the state having the name with the new state. When
+// the previous state has the same type, it's atomically replaced in the
+// registry. Otherwise, the previous state is removed before the new state is
+// added.
+func (tb *TopologyBuilder) replaceState(name, typeName string, s core.SharedState) error {
+	ctx := tb.topology.Context()
+	reg := ctx.SharedStates
+	prevType, err := reg.Type(name)
+	if err != nil {
+		if !core.IsNotExist(err) {
+			return err
+		}
+		return reg.Add(name, typeName, s)
+	}
+
+	if prevType != typeName {
+		if _, err := reg.Remove(name); err != nil && !core.IsNotExist(err) {
+			ctx.ErrLog(err).WithField("state_name", name).
+				Error("Cannot terminate the previous instance of the replaced state")
+		}
+		return reg.Add(name, typeName, s)
+	}
+
+	prev, err := reg.Replace(name, typeName, s)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		if err := prev.Terminate(ctx); err != nil {
+			ctx.ErrLog(err).WithField("state_name", name).
+				Error("Cannot terminate the previous instance of the replaced state")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StmtError is returned from AddStmts when one of the statements cannot be
+// applied. Index is the position of the statement in the given slice.
+type StmtError struct {
+	Index int
+	Stmt  interface{}
+	Err   error
+}
+
+func (e *StmtError) Error() string {
+	return fmt.Sprintf("cannot apply the statement at index %v (%v): %v", e.Index, e.Stmt, e.Err)
+}
+
+// ApplyBQL parses all statements in the given BQL script and applies them
+// with AddStmts. It returns the parsed statements.
+func (tb *TopologyBuilder) ApplyBQL(bql string) ([]interface{}, error) {
+	stmts, err := parser.New().ParseStmts(bql)
+	if err != nil {
+		return nil, err
+	}
+	if err := tb.AddStmts(stmts); err != nil {
+		return nil, err
+	}
+	return stmts, nil
+}
+
+// AddStmts applies all statements to the topology atomically. Statements
+// and references between them are validated before any of them is applied.
+// When a statement fails, all changes made by preceding statements are
+// rolled back so that the topology doesn't have a partially built flow.
+// Sources created by the statements don't generate tuples until all
+// statements are applied.
+//
+// Only statements which can be rolled back are supported: CREATE SOURCE,
+// CREATE STREAM, CREATE SINK, CREATE BOX, and CREATE STATE without
+// OR REPLACE, INSERT INTO, PAUSE SOURCE, and RESUME SOURCE. An error
+// returned from this method is a *StmtError unless the TopologyBuilder
+// fails to roll back changes.
+func (tb *TopologyBuilder) AddStmts(stmts []interface{}) error {
+	if err := tb.validateStmts(stmts); err != nil {
+		return err
+	}
+
+	var (
+		undos []func() error
+		// resumes has sources created by the statements. They're created
+		// in the paused state and resumed after all statements are applied
+		// unless they're paused by a statement.
+		resumes []string
+	)
+	rollback := func() {
+		for i := len(undos) - 1; i >= 0; i-- {
+			if err := undos[i](); err != nil {
+				tb.topology.Context().ErrLog(err).WithField("topology", tb.topology.Name()).
+					Error("Cannot roll back a statement")
+			}
+		}
+	}
+
+	for i, stmt := range stmts {
+		var undo func() error
+		modified := false
+		switch s := stmt.(type) {
+		case parser.CreateSourceStmt:
+			if s.Paused != parser.Yes {
+				s.Paused = parser.Yes
+				stmt, modified = s, true
+				resumes = append(resumes, string(s.Name))
+			}
+			undo = tb.undoCreateNode(string(s.Name))
+		case parser.CreateStreamAsSelectStmt:
+			undo = tb.undoCreateNode(string(s.Name), string(s.EvictTo))
+		case parser.CreateStreamAsSelectUnionStmt:
+			undo = tb.undoCreateNode(string(s.Name))
+		case parser.CreateSinkStmt:
+			undo = tb.undoCreateNode(string(s.Name))
+		case parser.CreateBoxStmt:
+			undo = tb.undoCreateNode(string(s.Name))
+		case parser.CreateStateStmt:
+			undo = func() error {
+				if _, err := tb.topology.Context().SharedStates.Remove(string(s.Name)); err != nil {
+					return err
+				}
+				tb.undefine(stateDefinitionPrefix, string(s.Name))
+				return nil
+			}
+		case parser.InsertIntoFromStmt:
+			undo = func() error {
+				sink, err := tb.topology.Sink(string(s.Sink))
+				if err != nil {
+					return err
+				}
+				if err := sink.RemoveInput(string(s.Input)); err != nil && !core.IsNotExist(err) {
+					return err
+				}
+				return nil
+			}
+		case parser.PauseSourceStmt:
+			resumes = removeName(resumes, string(s.Source))
+			undo = tb.undoSourceState(string(s.Source))
+		case parser.ResumeSourceStmt:
+			resumes = removeName(resumes, string(s.Source))
+			undo = tb.undoSourceState(string(s.Source))
+		}
+
+		if _, err := tb.AddStmt(stmt); err != nil {
+			rollback()
+			return &StmtError{
+				Index: i,
+				Stmt:  stmts[i],
+				Err:   err,
+			}
+		}
+		if modified {
+			// record the statement as given rather than the modified one
+			tb.defineStmt(stmts[i])
+		}
+		undos = append(undos, undo)
+	}
+
+	tb.startSources(resumes)
+	return nil
+}
+
+// AddStartupStmts applies statements building a topology on startup, such
+// as ones read from a BQL file, in dependency order. Sources created by the
+// statements are created in the paused state and resumed after all
+// statements are applied and sinks become ready (see SinkReadinessTimeout)
+// so that tuples don't flow into sinks which are still connecting.
+//
+// Unlike AddStmts, it accepts any statement and doesn't roll back changes
+// when a statement fails. Sources created by preceding statements are left
+// paused in that case. An error returned from this method is a *StmtError.
+func (tb *TopologyBuilder) AddStartupStmts(stmts []interface{}) error {
+	var resumes []string
+	for i, stmt := range stmts {
+		modified := false
+		switch s := stmt.(type) {
+		case parser.CreateSourceStmt:
+			if s.Paused != parser.Yes {
+				s.Paused = parser.Yes
+				stmt, modified = s, true
+				resumes = append(resumes, string(s.Name))
+			}
+		case parser.PauseSourceStmt:
+			resumes = removeName(resumes, string(s.Source))
+		case parser.ResumeSourceStmt:
+			resumes = removeName(resumes, string(s.Source))
+		case parser.DropSourceStmt:
+			resumes = removeName(resumes, string(s.Source))
+		}
+
+		if _, err := tb.AddStmt(stmt); err != nil {
+			return &StmtError{
+				Index: i,
+				Stmt:  stmts[i],
+				Err:   err,
+			}
+		}
+		if modified {
+			tb.defineStmt(stmts[i])
+		}
+	}
+	tb.startSources(resumes)
+	return nil
+}
+
+// startSources resumes sources having the given names after waiting for
+// sinks of the topology to become ready.
+func (tb *TopologyBuilder) startSources(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	tb.waitSinksReady()
+	for _, name := range names {
+		src, err := tb.topology.Source(name)
+		if err == nil {
+			err = src.Resume()
+		}
+		if err != nil {
+			tb.topology.Context().ErrLog(err).WithField("node_type", core.NTSource).
+				WithField("node_name", name).Error("Cannot resume the source")
+		}
+	}
+}
+
+// sinkReadinessRetryInterval is the interval between attempts to connect a
+// sink which isn't ready yet.
+const sinkReadinessRetryInterval = 100 * time.Millisecond
+
+// waitSinksReady waits until all sinks of the topology become ready or
+// SinkReadinessTimeout expires. Sinks which aren't ready are logged and
+// sources start anyway since they may become ready later.
+func (tb *TopologyBuilder) waitSinksReady() {
+	if tb.SinkReadinessTimeout <= 0 {
+		return
+	}
+	deadline := time.Now().Add(tb.SinkReadinessTimeout)
+	ctx := tb.topology.Context()
+
+	var wg sync.WaitGroup
+	for name, sn := range tb.topology.Sinks() {
+		name, sn := name, sn
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				err := sn.Connect()
+				if err == nil {
+					return
+				}
+				if !time.Now().Add(sinkReadinessRetryInterval).Before(deadline) {
+					ctx.ErrLog(err).WithField("node_type", core.NTSink).
+						WithField("node_name", name).
+						Warn("The sink didn't become ready before sources started")
+					return
+				}
+				time.Sleep(sinkReadinessRetryInterval)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (tb *TopologyBuilder) undoCreateNode(name string, owned ...string) func() error {
+	return func() error {
+		if err := tb.topology.Remove(name); err != nil {
+			return err
+		}
+		for _, n := range owned {
+			if n == "" {
+				continue
+			}
+			if err := tb.topology.Remove(n); err != nil && !core.IsNotExist(err) {
+				return err
+			}
+		}
+		tb.undefine(nodeDefinitionPrefix, name)
+		return nil
+	}
+}
+
+// undoSourceState returns a function restoring the current state of the
+// source. The source must exist when this method is called unless it's
+// created by a preceding statement, in which case the function does nothing
+// because the source is removed anyway.
+func (tb *TopologyBuilder) undoSourceState(name string) func() error {
+	src, err := tb.topology.Source(name)
+	if err != nil {
+		return func() error { return nil }
+	}
+	paused := src.State().Get() == core.TSPaused
+	return func() error {
+		if paused {
+			return src.Pause()
+		}
+		return src.Resume()
+	}
+}
+
+func removeName(names []string, name string) []string {
+	res := names[:0]
+	for _, n := range names {
+		if !strings.EqualFold(n, name) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// validateStmts checks that all statements can be rolled back, all types
+// are registered, and all entities referred from statements exist in the
+// topology or are created by preceding statements.
+func (tb *TopologyBuilder) validateStmts(stmts []interface{}) error {
+	ctx := tb.topology.Context()
+
+	// nodes and states have entities created by preceding statements.
+	// Keys are names in lower case.
+	nodes := map[string]core.NodeType{}
+	states := map[string]bool{}
+
+	nodeType := func(name string) (core.NodeType, bool) {
+		if t, ok := nodes[strings.ToLower(name)]; ok {
+			return t, true
+		}
+		n, err := tb.topology.Node(name)
+		if err != nil {
+			return 0, false
+		}
+		return n.Type(), true
+	}
+	createNode := func(name string, t core.NodeType) error {
+		if _, ok := nodeType(name); ok {
+			return fmt.Errorf("node '%v' already exists", name)
+		}
+		nodes[strings.ToLower(name)] = t
+		return nil
+	}
+	createState := func(name string) error {
+		if states[strings.ToLower(name)] {
+			return fmt.Errorf("state '%v' already exists", name)
+		}
+		if _, err := ctx.SharedStates.Get(name); err == nil {
+			return fmt.Errorf("state '%v' already exists", name)
+		}
+		states[strings.ToLower(name)] = true
+		return nil
+	}
+	checkInput := func(name string) error {
+		t, ok := nodeType(name)
+		if !ok {
+			return fmt.Errorf("'%v' doesn't exist", name)
+		}
+		if t != core.NTSource && t != core.NTBox {
+			return fmt.Errorf("'%v' is a %v and cannot be an input", name, t)
+		}
+		return nil
+	}
+	checkSelect := func(s *parser.SelectStmt) error {
+		for _, rel := range s.Relations {
+			if rel.Type != parser.ActualStream {
+				continue
+			}
+			if err := checkInput(rel.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	checkParams := func(specs parser.SourceSinkSpecsAST) error {
+		_, err := tb.mkParamsMap(specs.Params)
+		return err
+	}
+
+	for i, stmt := range stmts {
+		var err error
+		switch s := stmt.(type) {
+		case parser.CreateSourceStmt:
+			if s.OrReplace == parser.Yes {
+				err = fmt.Errorf("OR REPLACE cannot be rolled back")
+				break
+			}
+			if _, err = tb.SourceCreators.Lookup(string(s.Type)); err != nil {
+				break
+			}
+			if err = checkParams(s.SourceSinkSpecsAST); err != nil {
+				break
+			}
+			err = createNode(string(s.Name), core.NTSource)
+
+		case parser.CreateStreamAsSelectStmt:
+			if s.OrReplace == parser.Yes {
+				err = fmt.Errorf("OR REPLACE cannot be rolled back")
+				break
+			}
+			if err = checkSelect(&s.Select); err != nil {
+				break
+			}
+			if err = createNode(string(s.Name), core.NTBox); err != nil {
+				break
+			}
+			if s.EvictTo != "" {
+				err = createNode(string(s.EvictTo), core.NTSource)
+			}
+
+		case parser.CreateStreamAsSelectUnionStmt:
+			if s.OrReplace == parser.Yes {
+				err = fmt.Errorf("OR REPLACE cannot be rolled back")
+				break
+			}
+			for j := range s.Selects {
+				if err = checkSelect(&s.Selects[j]); err != nil {
+					break
+				}
+			}
+			if err != nil {
+				break
+			}
+			err = createNode(string(s.Name), core.NTBox)
+
+		case parser.CreateSinkStmt:
+			if s.OrReplace == parser.Yes {
+				err = fmt.Errorf("OR REPLACE cannot be rolled back")
+				break
+			}
+			if _, err = tb.SinkCreators.Lookup(string(s.Type)); err != nil {
+				break
+			}
+			if err = checkParams(s.SourceSinkSpecsAST); err != nil {
+				break
+			}
+			err = createNode(string(s.Name), core.NTSink)
+
+		case parser.CreateBoxStmt:
+			if _, err = tb.BoxCreators.Lookup(string(s.Type)); err != nil {
+				break
+			}
+			if err = checkParams(s.SourceSinkSpecsAST); err != nil {
+				break
+			}
+			if err = checkInput(string(s.Input)); err != nil {
+				break
+			}
+			err = createNode(string(s.Name), core.NTBox)
+
+		case parser.CreateStateStmt:
+			if s.OrReplace == parser.Yes {
+				err = fmt.Errorf("OR REPLACE cannot be rolled back")
+				break
+			}
+			if _, err = tb.UDSCreators.Lookup(string(s.Type)); err != nil {
+				break
+			}
+			if err = checkParams(s.SourceSinkSpecsAST); err != nil {
+				break
+			}
+			err = createState(string(s.Name))
+
+		case parser.InsertIntoFromStmt:
+			if t, ok := nodeType(string(s.Sink)); !ok || t != core.NTSink {
+				err = fmt.Errorf("sink '%v' doesn't exist", s.Sink)
+				break
+			}
+			err = checkInput(string(s.Input))
+
+		case parser.PauseSourceStmt:
+			if t, ok := nodeType(string(s.Source)); !ok || t != core.NTSource {
+				err = fmt.Errorf("source '%v' doesn't exist", s.Source)
+			}
+
+		case parser.ResumeSourceStmt:
+			if t, ok := nodeType(string(s.Source)); !ok || t != core.NTSource {
+				err = fmt.Errorf("source '%v' doesn't exist", s.Source)
+			}
+
+		default:
+			err = fmt.Errorf("statement of type %T cannot be rolled back", stmt)
+		}
+		if err != nil {
+			return &StmtError{
+				Index: i,
+				Stmt:  stmt,
+				Err:   err,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAddStmts(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE existing TYPE dummy;
+			CREATE SINK existing_sink TYPE collector;
+		`), ShouldBeNil)
+
+		nodeNames := func() []string {
+			names := []string{}
+			for name := range dt.Nodes() {
+				names = append(names, name)
+			}
+			return names
+		}
+
+		Convey("When applying a valid script", func() {
+			stmts, err := tb.ApplyBQL(`
+				CREATE SOURCE s TYPE dummy WITH num=4;
+				CREATE STREAM b AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE SINK k TYPE collector;
+				INSERT INTO k FROM b;
+				INSERT INTO existing_sink FROM existing;
+				CREATE STATE st TYPE dummy_uds WITH num=5;
+				RESUME SOURCE existing;
+			`)
+			So(err, ShouldBeNil)
+
+			Convey("Then all statements should be applied", func() {
+				So(len(stmts), ShouldEqual, 7)
+				for _, name := range []string{"s", "b", "k"} {
+					_, err := dt.Node(name)
+					So(err, ShouldBeNil)
+				}
+				_, err := dt.Context().SharedStates.Get("st")
+				So(err, ShouldBeNil)
+
+				sink, err := dt.Sink("existing_sink")
+				So(err, ShouldBeNil)
+				So(sink.Inputs(), ShouldContainKey, "existing")
+			})
+
+			Convey("Then the created source should be running", func() {
+				s, err := dt.Source("s")
+				So(err, ShouldBeNil)
+				So(s.State().Get(), ShouldNotEqual, core.TSPaused)
+			})
+
+			Convey("Then the existing source should be resumed", func() {
+				s, err := dt.Source("existing")
+				So(err, ShouldBeNil)
+				So(s.State().Get(), ShouldNotEqual, core.TSPaused)
+			})
+		})
+
+		Convey("When applying a script whose statement fails after others are applied", func() {
+			_, err := tb.ApplyBQL(`
+				CREATE SOURCE s TYPE dummy WITH num=4;
+				CREATE STREAM b AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				INSERT INTO existing_sink FROM b;
+				RESUME SOURCE existing;
+				CREATE STATE st TYPE dummy_uds WITH num=5;
+				CREATE STATE st2 TYPE dummy_uds WITH num="not a number";
+			`)
+
+			Convey("Then it should fail with the failed statement", func() {
+				So(err, ShouldNotBeNil)
+				stmtErr, ok := err.(*StmtError)
+				So(ok, ShouldBeTrue)
+				So(stmtErr.Index, ShouldEqual, 5)
+			})
+
+			Convey("Then all changes should be rolled back", func() {
+				So(nodeNames(), ShouldHaveLength, 2)
+				_, err := dt.Context().SharedStates.Get("st")
+				So(core.IsNotExist(err), ShouldBeTrue)
+
+				sink, err := dt.Sink("existing_sink")
+				So(err, ShouldBeNil)
+				So(sink.Inputs(), ShouldBeEmpty)
+
+				s, err := dt.Source("existing")
+				So(err, ShouldBeNil)
+				So(s.State().Get(), ShouldEqual, core.TSPaused)
+			})
+		})
+
+		Convey("When applying a script referring to a nonexistent stream", func() {
+			_, err := tb.ApplyBQL(`
+				CREATE SOURCE s TYPE dummy;
+				CREATE STREAM b AS SELECT ISTREAM * FROM no_such_stream [RANGE 1 TUPLES];
+			`)
+
+			Convey("Then it should fail without applying any statement", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*StmtError).Index, ShouldEqual, 1)
+				So(nodeNames(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When applying a script referring to a stream created later", func() {
+			_, err := tb.ApplyBQL(`
+				CREATE STREAM b AS SELECT ISTREAM * FROM s [RANGE 1 TUPLES];
+				CREATE SOURCE s TYPE dummy;
+			`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*StmtError).Index, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When applying a script creating the same node twice", func() {
+			_, err := tb.ApplyBQL(`
+				CREATE SOURCE s TYPE dummy;
+				CREATE SINK S TYPE collector;
+			`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*StmtError).Index, ShouldEqual, 1)
+				So(nodeNames(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When applying a script creating an existing node", func() {
+			_, err := tb.ApplyBQL(`CREATE SOURCE existing TYPE dummy;`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When applying a script having an unregistered type", func() {
+			_, err := tb.ApplyBQL(`
+				CREATE SOURCE s TYPE dummy;
+				CREATE SINK k TYPE no_such_type;
+			`)
+
+			Convey("Then it should fail without applying any statement", func() {
+				So(err, ShouldNotBeNil)
+				So(nodeNames(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When applying a script having a statement which cannot be rolled back", func() {
+			_, err := tb.ApplyBQL(`
+				CREATE SOURCE s TYPE dummy;
+				DROP SOURCE existing;
+			`)
+
+			Convey("Then it should fail without applying any statement", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot be rolled back")
+				So(nodeNames(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When applying a script having a syntax error", func() {
+			_, err := tb.ApplyBQL(`CREATE SOURCE s TYPE dummy; CREATE SINK`)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(nodeNames(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
+
+// connectingSink becomes connected after Connect fails failures times. It
+// counts tuples written before it's connected.
+type connectingSink struct {
+	m           sync.Mutex
+	failures    int
+	attempts    int
+	connected   bool
+	writes      int
+	earlyWrites int
+}
+
+func (s *connectingSink) Connect(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.attempts++
+	if s.failures >= 0 && s.attempts > s.failures {
+		s.connected = true
+	}
+	if !s.connected {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (s *connectingSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.writes++
+	if !s.connected {
+		s.earlyWrites++
+	}
+	return nil
+}
+
+func (s *connectingSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func (s *connectingSink) stats() (attempts, writes, earlyWrites int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.attempts, s.writes, s.earlyWrites
+}
+
+// waitWrites waits until the sink receives n tuples.
+func (s *connectingSink) waitWrites(n int) {
+	for i := 0; i < 1000; i++ {
+		if _, w, _ := s.stats(); w >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAddStartupStmts(t *testing.T) {
+	Convey("Given a BQL TopologyBuilder with a sink type connecting slowly", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		tb.SinkReadinessTimeout = 5 * time.Second
+
+		var sink *connectingSink
+		So(tb.SinkCreators.Register("connecting", SinkCreatorFunc(func(ctx *core.Context, ioParams *IOParams, p data.Map) (core.Sink, error) {
+			return sink, nil
+		})), ShouldBeNil)
+		addStartupBQL := func(bql string) error {
+			stmts, err := parser.New().ParseStmts(bql)
+			So(err, ShouldBeNil)
+			return tb.AddStartupStmts(stmts)
+		}
+		sourceState := func(name string) core.TopologyState {
+			s, err := dt.Source(name)
+			So(err, ShouldBeNil)
+			return s.State().Get()
+		}
+
+		Convey("When applying statements with a sink which becomes ready", func() {
+			sink = &connectingSink{failures: 2}
+			So(addStartupBQL(`
+				CREATE SOURCE s TYPE dummy WITH num=4;
+				CREATE SINK k TYPE connecting;
+				INSERT INTO k FROM s;
+				CREATE PAUSED SOURCE p TYPE dummy;
+			`), ShouldBeNil)
+			sink.waitWrites(4)
+
+			Convey("Then the source should start after the sink is connected", func() {
+				attempts, writes, earlyWrites := sink.stats()
+				So(attempts, ShouldEqual, 3)
+				So(writes, ShouldEqual, 4)
+				So(earlyWrites, ShouldEqual, 0)
+			})
+
+			Convey("Then the paused source should stay paused", func() {
+				So(sourceState("p"), ShouldEqual, core.TSPaused)
+			})
+
+			Convey("Then the statement should be recorded as given", func() {
+				def := tb.definition(nodeDefinitionPrefix, "s")
+				So(def, ShouldNotBeNil)
+				So(def.stmt, ShouldNotContainSubstring, "PAUSED")
+			})
+		})
+
+		Convey("When applying statements with a sink which never becomes ready", func() {
+			sink = &connectingSink{failures: -1}
+			tb.SinkReadinessTimeout = 300 * time.Millisecond
+			So(addStartupBQL(`
+				CREATE SOURCE s TYPE dummy WITH num=4;
+				CREATE SINK k TYPE connecting;
+				INSERT INTO k FROM s;
+			`), ShouldBeNil)
+
+			Convey("Then the source should start after the timeout", func() {
+				sink.waitWrites(4)
+				attempts, writes, earlyWrites := sink.stats()
+				So(attempts, ShouldBeGreaterThan, 1)
+				So(writes, ShouldEqual, 4)
+				So(earlyWrites, ShouldEqual, 4)
+			})
+		})
+
+		Convey("When applying statements one of which fails", func() {
+			sink = &connectingSink{}
+			err := addStartupBQL(`
+				CREATE SOURCE s TYPE dummy WITH num=4;
+				CREATE SINK k TYPE connecting;
+				INSERT INTO k FROM no_such_stream;
+			`)
+
+			Convey("Then it should fail with the failed statement", func() {
+				So(err, ShouldNotBeNil)
+				e, ok := err.(*StmtError)
+				So(ok, ShouldBeTrue)
+				So(e.Index, ShouldEqual, 2)
+			})
+
+			Convey("Then the created source shouldn't start", func() {
+				So(sourceState("s"), ShouldEqual, core.TSPaused)
+			})
+		})
+	})
+}
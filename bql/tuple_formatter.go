@@ -0,0 +1,219 @@
+package bql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TupleFormatter converts a tuple to a line written by a sink.
+type TupleFormatter interface {
+	// FormatTuple returns the representation of the tuple. The result
+	// doesn't have a trailing newline.
+	FormatTuple(t *core.Tuple) (string, error)
+}
+
+// headerTupleFormatter is a TupleFormatter writing a header before the first
+// tuple.
+type headerTupleFormatter interface {
+	TupleFormatter
+
+	// FormatHeader returns the header. t is the first tuple written after
+	// the header. It returns an empty string when there's no header.
+	FormatHeader(t *core.Tuple) (string, error)
+}
+
+// NewTupleFormatter creates a TupleFormatter from parameters of a sink so
+// that sinks writing lines can share the same output options. It accepts one
+// of the following parameters:
+//
+//   - template: a Go text/template executed with the tuple's data. Values in
+//     the data are converted to Go's native types, e.g. {{.name}} is written
+//     without quotes. The "json" function returns the JSON representation of
+//     a value, e.g. {{json .payload}}.
+//   - format: a string having placeholders of JSON paths in braces, e.g.
+//     "{device.id} temp={temp}". Strings are written without quotes and other
+//     values are written as ToString does. "{{" and "}}" are written as "{"
+//     and "}".
+//   - codec: "json" or "csv" and "tsv" writing records of CSV. Other
+//     parameters of the CSV codec such as 'columns' are also accepted. See
+//     extractCSVConfig for details. Sinks writing files or stdout write the
+//     header before the first tuple.
+//
+// The returned formatter writes a tuple as a JSON object when params has
+// neither of them. Referring to a missing field is an error.
+func NewTupleFormatter(params data.Map) (TupleFormatter, error) {
+	tmpl, hasTmpl := params["template"]
+	format, hasFormat := params["format"]
+	csv, err := extractCSVConfig(params)
+	if err != nil {
+		return nil, err
+	}
+	if c, _ := extractCodecParameter(params); c == "xml" || c == "binary" {
+		return nil, fmt.Errorf("'codec' parameter doesn't support %v for writing tuples", c)
+	}
+	switch {
+	case hasTmpl && hasFormat:
+		return nil, errors.New("'template' and 'format' parameters cannot be specified at once")
+	case csv != nil && (hasTmpl || hasFormat):
+		return nil, errors.New("'codec' parameter cannot be specified with 'template' or 'format' parameter")
+	case csv != nil:
+		return newCSVTupleFormatter(csv)
+	case hasTmpl:
+		s, err := data.AsString(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("'template' parameter must be a string: %v", err)
+		}
+		return newTemplateTupleFormatter(s)
+	case hasFormat:
+		s, err := data.AsString(format)
+		if err != nil {
+			return nil, fmt.Errorf("'format' parameter must be a string: %v", err)
+		}
+		f, err := newPlaceholderTupleFormatter(s)
+		if err != nil {
+			return nil, fmt.Errorf("'format' parameter is invalid: %v", err)
+		}
+		return f, nil
+	}
+	return jsonTupleFormatter{}, nil
+}
+
+type jsonTupleFormatter struct{}
+
+func (jsonTupleFormatter) FormatTuple(t *core.Tuple) (string, error) {
+	return t.Data.String(), nil
+}
+
+type templateTupleFormatter struct {
+	tmpl *template.Template
+}
+
+func newTemplateTupleFormatter(s string) (TupleFormatter, error) {
+	tmpl, err := template.New("tuple").Option("missingkey=error").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("'template' parameter has an invalid template: %v", err)
+	}
+	return &templateTupleFormatter{
+		tmpl: tmpl,
+	}, nil
+}
+
+func (f *templateTupleFormatter) FormatTuple(t *core.Tuple) (string, error) {
+	b := bytes.NewBuffer(nil)
+	if err := f.tmpl.Execute(b, toNative(t.Data)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// toNative converts a value to a Go's native value so that templates can
+// write it without quotes.
+func toNative(v data.Value) interface{} {
+	switch v := v.(type) {
+	case data.Map:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = toNative(e)
+		}
+		return m
+	case data.Array:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = toNative(e)
+		}
+		return a
+	case data.String:
+		return string(v)
+	case data.Int:
+		return int64(v)
+	case data.Float:
+		return float64(v)
+	case data.Bool:
+		return bool(v)
+	case data.Blob:
+		return []byte(v)
+	case *data.SharedBlob:
+		b, _ := data.AsBlob(v)
+		return b
+	case data.Timestamp:
+		return time.Time(v)
+	case data.Null:
+		return nil
+	default:
+		return v
+	}
+}
+
+// placeholderTupleFormatter writes literals and values of paths in turn.
+// paths[i] is written after literals[i], so literals has one more element
+// than paths. When escape isn't nil, values are written after being escaped
+// by it.
+type placeholderTupleFormatter struct {
+	literals []string
+	paths    []data.Path
+	escape   func(string) string
+}
+
+func newPlaceholderTupleFormatter(s string) (*placeholderTupleFormatter, error) {
+	f := &placeholderTupleFormatter{}
+	lit := []byte{}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case strings.HasPrefix(s[i:], "{{"), strings.HasPrefix(s[i:], "}}"):
+			lit = append(lit, c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder at %v", i)
+			}
+			p, err := data.CompilePath(s[i+1 : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path '%v': %v", s[i+1:i+end], err)
+			}
+			f.literals = append(f.literals, string(lit))
+			f.paths = append(f.paths, p)
+			lit = lit[:0]
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("unmatched '}' at %v", i)
+		default:
+			lit = append(lit, c)
+		}
+	}
+	f.literals = append(f.literals, string(lit))
+	return f, nil
+}
+
+func (f *placeholderTupleFormatter) FormatTuple(t *core.Tuple) (string, error) {
+	b := bytes.NewBuffer(nil)
+	for i, p := range f.paths {
+		b.WriteString(f.literals[i])
+		v, err := t.Data.Get(p)
+		if err != nil {
+			return "", err
+		}
+		s, err := data.ToString(v)
+		if err != nil {
+			return "", err
+		}
+		if f.escape != nil {
+			s = f.escape(s)
+		}
+		b.WriteString(s)
+	}
+	b.WriteString(f.literals[len(f.literals)-1])
+	return b.String(), nil
+}
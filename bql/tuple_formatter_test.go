@@ -0,0 +1,154 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTupleFormatter(t *testing.T) {
+	tuple := core.NewTuple(data.Map{
+		"name": data.String("sensor1"),
+		"temp": data.Float(21.5),
+		"tags": data.Array{data.String("a"), data.Int(1)},
+		"device": data.Map{
+			"id": data.Int(3),
+		},
+	})
+
+	Convey("Given a tuple", t, func() {
+		Convey("When formatting it without parameters", func() {
+			f, err := NewTupleFormatter(data.Map{})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be written as JSON", func() {
+				s, err := f.FormatTuple(tuple)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, tuple.Data.String())
+			})
+		})
+
+		Convey("When formatting it with a template", func() {
+			f, err := NewTupleFormatter(data.Map{
+				"template": data.String(`{{.name}},{{.device.id}},{{.temp}} {{json .tags}}`),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then values should be written without quotes", func() {
+				s, err := f.FormatTuple(tuple)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, `sensor1,3,21.5 ["a",1]`)
+			})
+		})
+
+		Convey("When formatting it with a template referring to a missing field", func() {
+			f, err := NewTupleFormatter(data.Map{
+				"template": data.String(`{{.no_such_field}}`),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				_, err := f.FormatTuple(tuple)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When formatting it with a format string", func() {
+			f, err := NewTupleFormatter(data.Map{
+				"format": data.String(`{name} id={device.id} temp={temp} tag={tags[0]} {{}}`),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then placeholders should be replaced with values", func() {
+				s, err := f.FormatTuple(tuple)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, `sensor1 id=3 temp=21.5 tag=a {}`)
+			})
+		})
+
+		Convey("When formatting it with a format string referring to a missing field", func() {
+			f, err := NewTupleFormatter(data.Map{
+				"format": data.String(`{no_such_field}`),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				_, err := f.FormatTuple(tuple)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		cases := map[string]data.Map{
+			"both template and format": data.Map{
+				"template": data.String("{{.a}}"),
+				"format":   data.String("{a}"),
+			},
+			"a broken template":       data.Map{"template": data.String("{{.a")},
+			"a non-string template":   data.Map{"template": data.Int(1)},
+			"an unclosed placeholder": data.Map{"format": data.String("{a")},
+			"an unmatched brace":      data.Map{"format": data.String("a}")},
+			"an invalid path":         data.Map{"format": data.String("{a[}")},
+			"a non-string format":     data.Map{"format": data.Int(1)},
+		}
+		for title, params := range cases {
+			params := params
+			Convey("When creating a formatter with "+title, func() {
+				_, err := NewTupleFormatter(params)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestFileSinkWithFormat(t *testing.T) {
+	ctx := core.NewContext(nil)
+
+	Convey("Given a file sink having a format", t, func() {
+		dir, err := ioutil.TempDir("", "sensorbee_file_sink_format_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		fpath := filepath.Join(dir, "out.txt")
+
+		si, err := createFileSink(ctx, &IOParams{}, data.Map{
+			"path":   data.String(fpath),
+			"format": data.String("{name}={value}"),
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When writing tuples", func() {
+			for i := 0; i < 2; i++ {
+				So(si.Write(ctx, core.NewTuple(data.Map{
+					"name":  data.String("x"),
+					"value": data.Int(i),
+				})), ShouldBeNil)
+			}
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then the file should have formatted lines", func() {
+				b, err := ioutil.ReadFile(fpath)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "x=0\nx=1\n")
+			})
+		})
+
+		Convey("When writing a tuple missing a field", func() {
+			err := si.Write(ctx, core.NewTuple(data.Map{"name": data.String("x")}))
+			So(si.Close(ctx), ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
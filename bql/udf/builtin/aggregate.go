@@ -7,7 +7,9 @@ import (
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"math"
+	"math/rand"
 	"sort"
+	"time"
 )
 
 // singleParamAggFunc is a template for aggregate functions that
@@ -102,17 +104,26 @@ var arrayAggFunc udf.UDF = &singleParamAggFunc{
 
 // avgFunc is an aggregate function that computes the average
 // of all input values. Null values are ignored, non-numeric
-// values lead to an error.
+// values lead to an error. When the first non-null value is a
+// Timestamp, all values must be Timestamps and the average
+// time is returned.
 //
 // It can be used in BQL as `avg`.
 //
-//  Input: Int or Float (aggregated)
-//  Return Type: Float (Null on empty input)
+//  Input: Int or Float, or Timestamp (aggregated)
+//  Return Type: Float or Timestamp (Null on empty input)
 var avgFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
 		if len(arr) == 0 {
 			return data.Null{}, nil
 		}
+		for _, item := range arr {
+			if item.Type() == data.TypeTimestamp {
+				return avgTimestamp(arr)
+			} else if item.Type() != data.TypeNull {
+				break
+			}
+		}
 		sum := float64(0.0)
 		count := int64(0)
 		for _, item := range arr {
@@ -139,6 +150,32 @@ var avgFunc udf.UDF = &singleParamAggFunc{
 	},
 }
 
+// avgTimestamp computes the average of Timestamp values. Null
+// values are ignored. Differences from the first value are
+// summed up so that large timestamps don't overflow.
+func avgTimestamp(arr []data.Value) (data.Value, error) {
+	var (
+		base  time.Time
+		sum   float64
+		count int64
+	)
+	for _, item := range arr {
+		if item.Type() == data.TypeNull {
+			continue
+		} else if item.Type() != data.TypeTimestamp {
+			return nil, fmt.Errorf("cannot interpret %s (%T) as a timestamp",
+				item, item)
+		}
+		t, _ := data.AsTimestamp(item)
+		if count == 0 {
+			base = t
+		}
+		sum += float64(t.Sub(base))
+		count++
+	}
+	return data.Timestamp(base.Add(time.Duration(sum / float64(count)))), nil
+}
+
 // medianFunc is an aggregate function that computes the median
 // of all input values. Null values are ignored, non-numeric
 // values lead to an error.
@@ -316,11 +353,12 @@ var jsonObjectAggFunc udf.UDF = &twoParamAggFunc{
 
 // maxFunc is an aggregate function that computes the maximum
 // value of all input values. Null values are ignored, non-numeric
-// values lead to an error.
+// values lead to an error. When the first non-null value is a
+// Timestamp, all values must be Timestamps.
 //
 // It can be used in BQL as `max`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float, or Timestamp (aggregated)
 //  Return Type: same as maximal input value (Null on empty input)
 var maxFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -386,11 +424,12 @@ var maxFunc udf.UDF = &singleParamAggFunc{
 
 // minFunc is an aggregate function that computes the minimum
 // value of all input values. Null values are ignored, non-numeric
-// values lead to an error.
+// values lead to an error. When the first non-null value is a
+// Timestamp, all values must be Timestamps.
 //
 // It can be used in BQL as `min`.
 //
-//  Input: Int or Float (aggregated)
+//  Input: Int or Float, or Timestamp (aggregated)
 //  Return Type: same as minimal input value (Null on empty input)
 var minFunc udf.UDF = &singleParamAggFunc{
 	aggFun: func(arr []data.Value) (data.Value, error) {
@@ -454,6 +493,70 @@ var minFunc udf.UDF = &singleParamAggFunc{
 	},
 }
 
+// firstValueFunc is an aggregate function that returns the value
+// having the oldest timestamp given as the second parameter. Values
+// whose timestamps are Null are ignored. When several values have
+// the same timestamp, the one appearing first is returned.
+//
+// It can be used in BQL as `first_value`.
+//
+//  Input: any (aggregated), Timestamp (aggregated)
+//  Return Type: same as the input value (Null on empty input)
+var firstValueFunc udf.UDF = &twoParamAggFunc{
+	aggFun: func(values []data.Value, times []data.Value) (data.Value, error) {
+		return valueOrderedByTime(values, times, func(t, cur time.Time) bool {
+			return t.Before(cur)
+		})
+	},
+}
+
+// lastValueFunc is an aggregate function that returns the value
+// having the newest timestamp given as the second parameter. Values
+// whose timestamps are Null are ignored. When several values have
+// the same timestamp, the one appearing last is returned.
+//
+// It can be used in BQL as `last_value`.
+//
+//  Input: any (aggregated), Timestamp (aggregated)
+//  Return Type: same as the input value (Null on empty input)
+var lastValueFunc udf.UDF = &twoParamAggFunc{
+	aggFun: func(values []data.Value, times []data.Value) (data.Value, error) {
+		return valueOrderedByTime(values, times, func(t, cur time.Time) bool {
+			return !t.Before(cur)
+		})
+	},
+}
+
+// valueOrderedByTime returns the value whose timestamp is preferred
+// to all other timestamps by prefer. prefer returns true when t
+// should replace the current choice cur.
+func valueOrderedByTime(values []data.Value, times []data.Value,
+	prefer func(t, cur time.Time) bool) (data.Value, error) {
+	if len(values) != len(times) {
+		return nil, fmt.Errorf("values and timestamps have different lengths: %d != %d",
+			len(values), len(times))
+	}
+	var (
+		res     data.Value = data.Null{}
+		resTime time.Time
+		found   bool
+	)
+	for i, item := range times {
+		if item.Type() == data.TypeNull {
+			continue
+		}
+		t, err := data.ToTimestamp(item)
+		if err != nil {
+			return nil, fmt.Errorf("cannot interpret %s (%T) as a timestamp",
+				item, item)
+		}
+		if !found || prefer(t, resTime) {
+			res, resTime, found = values[i], t, true
+		}
+	}
+	return res, nil
+}
+
 type stringAggFuncTmpl struct {
 }
 
@@ -504,6 +607,122 @@ func (f *stringAggFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Va
 // lead to an error.
 var stringAggFunc udf.UDF = &stringAggFuncTmpl{}
 
+type reservoirSampleFuncTmpl struct {
+}
+
+func (f *reservoirSampleFuncTmpl) Accept(arity int) bool {
+	return arity == 2 || arity == 3
+}
+
+func (f *reservoirSampleFuncTmpl) IsAggregationParameter(k int) bool {
+	return k == 0 || k == 2
+}
+
+func (f *reservoirSampleFuncTmpl) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("function takes two or three arguments")
+	}
+	arr, err := data.AsArray(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("function needs array input, not %T", args[0])
+	}
+	n, err := data.AsInt(args[1])
+	if err != nil {
+		return nil, fmt.Errorf("function needs int input, not %T", args[1])
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("the size of the sample must be positive: %v", n)
+	}
+	if len(args) == 2 {
+		if len(arr) == 0 {
+			return data.Null{}, nil
+		}
+		return data.Array(reservoirSample(arr, int(n))), nil
+	}
+
+	strata, err := data.AsArray(args[2])
+	if err != nil {
+		return nil, fmt.Errorf("function needs array input, not %T", args[2])
+	}
+	if len(arr) != len(strata) {
+		return nil, fmt.Errorf("the numbers of values and strata differ: %v != %v",
+			len(arr), len(strata))
+	}
+	if len(arr) == 0 {
+		return data.Null{}, nil
+	}
+
+	// group values by strata in order of their first appearance
+	type stratum struct {
+		key    data.Value
+		values []data.Value
+	}
+	var groups []*stratum
+	index := map[data.HashValue][]*stratum{}
+	for i, v := range arr {
+		k := strata[i]
+		h := data.Hash(k)
+		var g *stratum
+		for _, c := range index[h] {
+			if data.Equal(c.key, k) {
+				g = c
+				break
+			}
+		}
+		if g == nil {
+			g = &stratum{key: k}
+			index[h] = append(index[h], g)
+			groups = append(groups, g)
+		}
+		g.values = append(g.values, v)
+	}
+
+	res := data.Array{}
+	for _, g := range groups {
+		res = append(res, reservoirSample(g.values, int(n))...)
+	}
+	return res, nil
+}
+
+// reservoirSample returns at most n values uniformly chosen from the given
+// values. The order of chosen values is kept.
+func reservoirSample(values []data.Value, n int) []data.Value {
+	if len(values) <= n {
+		return values
+	}
+
+	// Algorithm R keeps indices so that the order can be restored
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	for i := n; i < len(values); i++ {
+		if j := rand.Intn(i + 1); j < n {
+			idx[j] = i
+		}
+	}
+	sort.Ints(idx)
+
+	res := make([]data.Value, n)
+	for i, j := range idx {
+		res[i] = values[j]
+	}
+	return res
+}
+
+// reservoirSampleFunc(expr, n[, stratum]) is an aggregate function that
+// returns an array of at most n values uniformly sampled from its input
+// values. When stratum is given, at most n values are sampled for each
+// distinct value of stratum, and the samples are concatenated in order of
+// the first appearance of their strata so that rare strata are represented
+// as well as frequent ones. Null values are sampled like other values.
+//
+// It can be used in BQL as `reservoir_sample`.
+//
+//  Input: any (aggregated), Int, and any (aggregated)
+//  Return Type: Array (Null on empty input)
+var reservoirSampleFunc udf.UDF = &reservoirSampleFuncTmpl{}
+
 // sumFunc is an aggregate function that computes the sum
 // of all input values. Null values are ignored, non-numeric
 // values lead to an error.
@@ -56,8 +56,15 @@ func TestUnaryAggregateFuncs(t *testing.T) {
 			// normal inputs
 			{data.Array{data.Int(7), data.Int(3)}, data.Float(5.0)},
 			{data.Array{data.Int(7), data.Null{}, data.Float(3.0)}, data.Float(5.0)},
+			// timestamps
+			{data.Array{data.Timestamp(someTime)}, data.Timestamp(someTime)},
+			{data.Array{data.Timestamp(someTime), data.Null{}, data.Timestamp(someTimeLater)},
+				data.Timestamp(someTime.Add(30 * time.Second))},
+			{data.Array{data.Null{}, data.Timestamp(someTimeLater), data.Timestamp(someTime)},
+				data.Timestamp(someTime.Add(30 * time.Second))},
 			// incompatible data
 			{data.Array{data.Int(7), data.Timestamp(someTime)}, nil},
+			{data.Array{data.Timestamp(someTime), data.Int(7)}, nil},
 		}},
 		{"bool_and", boolAndFunc, []udfUnaryTestCaseInput{
 			// empty array: Null
@@ -266,6 +273,18 @@ func TestUnaryAggregateFuncs(t *testing.T) {
 
 func TestBinaryAggregateFuncs(t *testing.T) {
 	someTime := time.Date(2015, time.May, 1, 14, 27, 0, 0, time.UTC)
+	someTimeLater := time.Date(2015, time.May, 1, 14, 28, 0, 0, time.UTC)
+	ts := func(ts ...time.Time) data.Array {
+		a := make(data.Array, len(ts))
+		for i, t := range ts {
+			if t.IsZero() {
+				a[i] = data.Null{}
+			} else {
+				a[i] = data.Timestamp(t)
+			}
+		}
+		return a
+	}
 
 	invalidInputs := []udfBinaryTestCaseInput{
 		{data.Null{}, data.Int(1), nil},
@@ -307,6 +326,36 @@ func TestBinaryAggregateFuncs(t *testing.T) {
 			{data.Array{data.String("foo"), data.Int(17)},
 				data.Array{data.Int(7), data.Int(3)}, nil},
 		}},
+		{"first_value", firstValueFunc, []udfBinaryTestCaseInput{
+			{data.Array{}, data.Array{}, data.Null{}},
+			// normal cases
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTime, someTimeLater), data.Int(1)},
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTimeLater, someTime), data.Int(2)},
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTime, someTime), data.Int(1)},
+			{data.Array{data.Null{}, data.Int(2)}, ts(someTime, someTimeLater), data.Null{}},
+			// null timestamps are ignored
+			{data.Array{data.Int(1), data.Int(2)}, ts(time.Time{}, someTimeLater), data.Int(2)},
+			{data.Array{data.Int(1)}, ts(time.Time{}), data.Null{}},
+			/// fail cases
+			// different length
+			{data.Array{data.Int(1)}, ts(someTime, someTimeLater), nil},
+			// timestamp isn't convertible
+			{data.Array{data.Int(1)}, data.Array{data.Bool(true)}, nil},
+		}},
+		{"last_value", lastValueFunc, []udfBinaryTestCaseInput{
+			{data.Array{}, data.Array{}, data.Null{}},
+			// normal cases
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTime, someTimeLater), data.Int(2)},
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTimeLater, someTime), data.Int(1)},
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTime, someTime), data.Int(2)},
+			// null timestamps are ignored
+			{data.Array{data.Int(1), data.Int(2)}, ts(someTime, time.Time{}), data.Int(1)},
+			/// fail cases
+			// different length
+			{data.Array{data.Int(1)}, ts(someTime, someTimeLater), nil},
+			// timestamp isn't convertible
+			{data.Array{data.Int(1)}, data.Array{data.Bool(true)}, nil},
+		}},
 		{"string_agg", stringAggFunc, []udfBinaryTestCaseInput{
 			{data.Array{}, data.String(", "), data.Null{}},
 			// normal cases
@@ -374,3 +423,98 @@ func TestBinaryAggregateFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestReservoirSampleFunc(t *testing.T) {
+	Convey("Given the reservoir_sample function", t, func() {
+		f := reservoirSampleFunc
+		values := data.Array{}
+		strata := data.Array{}
+		for i := 0; i < 100; i++ {
+			values = append(values, data.Int(i))
+			if i < 90 {
+				strata = append(strata, data.String("frequent"))
+			} else {
+				strata = append(strata, data.String("rare"))
+			}
+		}
+
+		Convey("When sampling fewer values than the input", func() {
+			v, err := f.Call(nil, values, data.Int(10))
+			So(err, ShouldBeNil)
+			arr, err := data.AsArray(v)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return distinct input values in order", func() {
+				So(len(arr), ShouldEqual, 10)
+				prev := int64(-1)
+				for _, e := range arr {
+					i, err := data.AsInt(e)
+					So(err, ShouldBeNil)
+					So(i, ShouldBeGreaterThan, prev)
+					So(i, ShouldBeLessThan, 100)
+					prev = i
+				}
+			})
+		})
+
+		Convey("When sampling more values than the input", func() {
+			v, err := f.Call(nil, values[:3], data.Int(10))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return all values", func() {
+				So(v, ShouldResemble, values[:3])
+			})
+		})
+
+		Convey("When sampling values stratified by an expression", func() {
+			v, err := f.Call(nil, values, data.Int(5), strata)
+			So(err, ShouldBeNil)
+			arr, err := data.AsArray(v)
+			So(err, ShouldBeNil)
+
+			Convey("Then each stratum should have its own sample", func() {
+				So(len(arr), ShouldEqual, 10)
+				for i, e := range arr {
+					n, err := data.AsInt(e)
+					So(err, ShouldBeNil)
+					if i < 5 {
+						So(n, ShouldBeLessThan, 90)
+					} else {
+						So(n, ShouldBeGreaterThanOrEqualTo, 90)
+					}
+				}
+			})
+		})
+
+		Convey("When sampling an empty input", func() {
+			v, err := f.Call(nil, data.Array{}, data.Int(5))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return null", func() {
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When giving invalid arguments", func() {
+			Convey("Then it should fail", func() {
+				for _, args := range [][]data.Value{
+					{values},
+					{data.Int(1), data.Int(5)},
+					{values, data.String("5")},
+					{values, data.Int(0)},
+					{values, data.Int(5), data.Int(1)},
+					{values, data.Int(5), strata[:10]},
+				} {
+					_, err := f.Call(nil, args...)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("Then it should equal the one in the default registry", func() {
+			regFun, err := udf.CopyGlobalUDFRegistry(nil).Lookup("reservoir_sample", 3)
+			So(err, ShouldBeNil)
+			So(regFun, ShouldEqual, f)
+		})
+	})
+}
@@ -0,0 +1,214 @@
+package builtin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/compress"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"image"
+	"net/http"
+	"strings"
+
+	// register decoders used by imageSizeFunc
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// singleParamBytesFunc is a template for functions that
+// have a single string or blob parameter as input.
+type singleParamBytesFunc struct {
+	singleParamFunc
+	bytesFun func([]byte) data.Value
+}
+
+func (f *singleParamBytesFunc) Call(ctx *core.Context, args ...data.Value) (val data.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	if len(args) != 1 {
+		return nil, fmt.Errorf("function takes exactly one argument")
+	}
+	arg := args[0]
+	switch arg.Type() {
+	case data.TypeNull:
+		return data.Null{}, nil
+	case data.TypeString:
+		s, _ := data.AsString(arg)
+		return f.bytesFun([]byte(s)), nil
+	case data.TypeBlob:
+		b, _ := data.AsBlob(arg)
+		return f.bytesFun(b), nil
+	}
+	return nil, fmt.Errorf("cannot interpret %s as a string or a blob", arg)
+}
+
+// encodeFunc encodes a blob into a textual representation. Supported
+// formats are "base64" and "hex".
+//
+// It can be used in BQL as `encode`.
+//
+//	Input: Blob, String (format)
+//	Return Type: String
+var encodeFunc = udf.BinaryFunc(func(ctx *core.Context, blob, format data.Value) (data.Value, error) {
+	if blob.Type() == data.TypeNull || format.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	b, err := data.AsBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	f, err := data.AsString(format)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(f) {
+	case "base64":
+		return data.String(base64.StdEncoding.EncodeToString(b)), nil
+	case "hex":
+		return data.String(hex.EncodeToString(b)), nil
+	}
+	return nil, fmt.Errorf("unsupported encoding format: %v", f)
+})
+
+// decodeFunc decodes a blob from a textual representation created by
+// `encode`. Supported formats are "base64" and "hex".
+//
+// It can be used in BQL as `decode`.
+//
+//	Input: String, String (format)
+//	Return Type: Blob
+var decodeFunc = udf.BinaryFunc(func(ctx *core.Context, str, format data.Value) (data.Value, error) {
+	if str.Type() == data.TypeNull || format.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	s, err := data.AsString(str)
+	if err != nil {
+		return nil, err
+	}
+	f, err := data.AsString(format)
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	switch strings.ToLower(f) {
+	case "base64":
+		b, err = base64.StdEncoding.DecodeString(s)
+	case "hex":
+		b, err = hex.DecodeString(s)
+	default:
+		return nil, fmt.Errorf("unsupported encoding format: %v", f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data.Blob(b), nil
+})
+
+// mimeTypeFunc determines the MIME type of a blob from its first bytes
+// by the algorithm described at https://mimesniff.spec.whatwg.org/.
+// It returns "application/octet-stream" when the type is unknown.
+// See also: net/http.DetectContentType
+//
+// It can be used in BQL as `mime_type`.
+//
+//	Input: Blob
+//	Return Type: String
+var mimeTypeFunc = udf.UnaryFunc(func(ctx *core.Context, blob data.Value) (data.Value, error) {
+	if blob.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	b, err := data.AsBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	return data.String(http.DetectContentType(b)), nil
+})
+
+// imageSizeFunc extracts the format and the dimensions of a JPEG, PNG, or
+// GIF image without decoding the whole image. It returns a map having
+// "format", "width", and "height", and fails when the blob isn't an image
+// in the supported formats.
+//
+// It can be used in BQL as `image_size`.
+//
+//	Input: Blob
+//	Return Type: Map
+var imageSizeFunc = udf.UnaryFunc(func(ctx *core.Context, blob data.Value) (data.Value, error) {
+	if blob.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	b, err := data.AsBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+	c, format, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the image: %v", err)
+	}
+	return data.Map{
+		"format": data.String(format),
+		"width":  data.Int(c.Width),
+		"height": data.Int(c.Height),
+	}, nil
+})
+
+// compressFunc compresses a blob or a string with a codec registered to the
+// compress package such as "gzip".
+//
+// It can be used in BQL as `compress`.
+//
+//	Input: Blob or String, String (codec)
+//	Return Type: Blob
+var compressFunc = udf.BinaryFunc(func(ctx *core.Context, v, codec data.Value) (data.Value, error) {
+	return applyCodec(v, codec, compress.Compress)
+})
+
+// decompressFunc decompresses a blob compressed by `compress` or other
+// tools using the same format.
+//
+// It can be used in BQL as `decompress`.
+//
+//	Input: Blob, String (codec)
+//	Return Type: Blob
+var decompressFunc = udf.BinaryFunc(func(ctx *core.Context, v, codec data.Value) (data.Value, error) {
+	if v.Type() == data.TypeString {
+		return nil, fmt.Errorf("cannot decompress a string")
+	}
+	return applyCodec(v, codec, compress.Decompress)
+})
+
+func applyCodec(v, codec data.Value, f func(compress.Codec, []byte) ([]byte, error)) (data.Value, error) {
+	if v.Type() == data.TypeNull || codec.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	var b []byte
+	switch v.Type() {
+	case data.TypeBlob:
+		b, _ = data.AsBlob(v)
+	case data.TypeString:
+		s, _ := data.AsString(v)
+		b = []byte(s)
+	default:
+		return nil, fmt.Errorf("cannot interpret %s as a string or a blob", v)
+	}
+	name, err := data.AsString(codec)
+	if err != nil {
+		return nil, fmt.Errorf("the name of a codec must be a string: %v", err)
+	}
+	c, err := compress.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	res, err := f(c, b)
+	if err != nil {
+		return nil, err
+	}
+	return data.Blob(res), nil
+}
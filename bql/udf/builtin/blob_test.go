@@ -0,0 +1,253 @@
+package builtin
+
+import (
+	"bytes"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestUnaryBytesFuncs(t *testing.T) {
+	someTime := time.Date(2015, time.May, 1, 14, 27, 0, 0, time.UTC)
+
+	invalidInputs := []udfUnaryTestCaseInput{
+		// NULL input -> NULL output
+		{data.Null{}, data.Null{}},
+		// cannot process the following
+		{data.Array{}, nil},
+		{data.Bool(true), nil},
+		{data.Float(2.3), nil},
+		{data.Int(7), nil},
+		{data.Map{}, nil},
+		{data.Timestamp(someTime), nil},
+	}
+
+	udfUnaryTestCases := []udfUnaryTestCase{
+		{"octet_length", octetLengthFunc, []udfUnaryTestCaseInput{
+			{data.String(""), data.Int(0)},
+			{data.String("jose"), data.Int(4)},
+			{data.String("日本語"), data.Int(9)},
+			{data.Blob{}, data.Int(0)},
+			{data.Blob("\x00\x01\x02"), data.Int(3)},
+		}},
+		{"md5", md5Func, []udfUnaryTestCaseInput{
+			{data.String("abc"), data.String("900150983cd24fb0d6963f7d28e17f72")},
+			{data.String("日本語\n"), data.String("2123035863e00ab6633d0f429fd9aefa")},
+			{data.Blob("abc"), data.String("900150983cd24fb0d6963f7d28e17f72")},
+		}},
+		{"sha1", sha1Func, []udfUnaryTestCaseInput{
+			{data.String("abc\n"), data.String("03cfd743661f07975fa2f1220c5194cbaff48451")},
+			{data.String("日本語\n"), data.String("eb2ac8ca4ec0f3913058ccd239dd984bda31a821")},
+			{data.Blob("abc\n"), data.String("03cfd743661f07975fa2f1220c5194cbaff48451")},
+		}},
+		{"sha256", sha256Func, []udfUnaryTestCaseInput{
+			{data.String("abc\n"), data.String("edeaaff3f1774ad2888673770c6d64097e391bc362d7d6fb34982ddf0efd18cb")},
+			{data.String("日本語\n"), data.String("a43d56ae90ff2daebd847bf06f9c0a7b416f48f89b6e9dbefe7886540c94b550")},
+			{data.Blob("abc\n"), data.String("edeaaff3f1774ad2888673770c6d64097e391bc362d7d6fb34982ddf0efd18cb")},
+		}},
+	}
+
+	for _, testCase := range udfUnaryTestCases {
+		f := testCase.f
+		allInputs := append(testCase.inputs, invalidInputs...)
+
+		Convey(fmt.Sprintf("Given the %s function", testCase.name), t, func() {
+			for _, tc := range allInputs {
+				tc := tc
+
+				Convey(fmt.Sprintf("When evaluating it on %s (%T)", tc.input, tc.input), func() {
+					val, err := f.Call(nil, tc.input)
+
+					if tc.expected == nil {
+						Convey("Then evaluation should fail", func() {
+							So(err, ShouldNotBeNil)
+						})
+					} else {
+						Convey(fmt.Sprintf("Then the result should be %s", tc.expected), func() {
+							So(err, ShouldBeNil)
+							So(val, ShouldResemble, tc.expected)
+						})
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	Convey("Given a blob", t, func() {
+		b := data.Blob("\x00\xffabc")
+
+		for _, c := range []struct {
+			format  string
+			encoded string
+		}{
+			{"base64", "AP9hYmM="},
+			{"HEX", "00ff616263"},
+		} {
+			c := c
+			Convey(fmt.Sprintf("When encoding it in %v", c.format), func() {
+				s, err := encodeFunc.Call(nil, b, data.String(c.format))
+				So(err, ShouldBeNil)
+
+				Convey("Then it should be encoded", func() {
+					So(s, ShouldEqual, data.String(c.encoded))
+				})
+
+				Convey("Then it should be decoded to the original blob", func() {
+					d, err := decodeFunc.Call(nil, s, data.String(c.format))
+					So(err, ShouldBeNil)
+					So(d, ShouldResemble, b)
+				})
+			})
+		}
+
+		Convey("When using an unsupported format", func() {
+			_, err := encodeFunc.Call(nil, b, data.String("escape"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When decoding an invalid string", func() {
+			_, err := decodeFunc.Call(nil, data.String("zz"), data.String("hex"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When passing null", func() {
+			v, err := encodeFunc.Call(nil, data.Null{}, data.String("hex"))
+
+			Convey("Then it should return null", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+	})
+}
+
+func TestCompressFuncs(t *testing.T) {
+	Convey("Given compress and decompress functions", t, func() {
+		in := data.Blob(bytes.Repeat([]byte("abc"), 100))
+
+		Convey("When compressing a blob with gzip", func() {
+			c, err := compressFunc.Call(nil, in, data.String("gzip"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be compressed", func() {
+				b, err := data.AsBlob(c)
+				So(err, ShouldBeNil)
+				So(len(b), ShouldBeLessThan, len(in))
+			})
+
+			Convey("Then it should be decompressed", func() {
+				d, err := decompressFunc.Call(nil, c, data.String("gzip"))
+				So(err, ShouldBeNil)
+				So(d, ShouldResemble, in)
+			})
+
+			Convey("Then decompressing it with another codec should fail", func() {
+				_, err := decompressFunc.Call(nil, c, data.String("zlib"))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When compressing a string", func() {
+			c, err := compressFunc.Call(nil, data.String("abc"), data.String("zlib"))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be decompressed to a blob", func() {
+				d, err := decompressFunc.Call(nil, c, data.String("zlib"))
+				So(err, ShouldBeNil)
+				So(d, ShouldResemble, data.Blob("abc"))
+			})
+		})
+
+		Convey("When giving invalid arguments", func() {
+			Convey("Then they should fail", func() {
+				_, err := compressFunc.Call(nil, in, data.String("no_such_codec"))
+				So(err, ShouldNotBeNil)
+				_, err = compressFunc.Call(nil, data.Int(1), data.String("gzip"))
+				So(err, ShouldNotBeNil)
+				_, err = decompressFunc.Call(nil, data.String("abc"), data.String("gzip"))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When passing null", func() {
+			v, err := compressFunc.Call(nil, data.Null{}, data.String("gzip"))
+
+			Convey("Then it should return null", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+	})
+}
+
+func TestImageFuncs(t *testing.T) {
+	Convey("Given images", t, func() {
+		img := image.NewGray(image.Rect(0, 0, 12, 7))
+		pngBuf := &bytes.Buffer{}
+		So(png.Encode(pngBuf, img), ShouldBeNil)
+		jpegBuf := &bytes.Buffer{}
+		So(jpeg.Encode(jpegBuf, img, nil), ShouldBeNil)
+
+		Convey("When detecting their MIME types", func() {
+			Convey("Then they should be detected from their content", func() {
+				v, err := mimeTypeFunc.Call(nil, data.Blob(pngBuf.Bytes()))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("image/png"))
+				v, err = mimeTypeFunc.Call(nil, data.Blob(jpegBuf.Bytes()))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("image/jpeg"))
+				v, err = mimeTypeFunc.Call(nil, data.Blob("\x00\x01\x02"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String("application/octet-stream"))
+			})
+		})
+
+		Convey("When extracting their sizes", func() {
+			Convey("Then they should have the dimensions", func() {
+				v, err := imageSizeFunc.Call(nil, data.Blob(pngBuf.Bytes()))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{
+					"format": data.String("png"),
+					"width":  data.Int(12),
+					"height": data.Int(7),
+				})
+				v, err = imageSizeFunc.Call(nil, data.Blob(jpegBuf.Bytes()))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{
+					"format": data.String("jpeg"),
+					"width":  data.Int(12),
+					"height": data.Int(7),
+				})
+			})
+		})
+
+		Convey("When extracting the size of a non-image blob", func() {
+			_, err := imageSizeFunc.Call(nil, data.Blob("not an image"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When passing a string", func() {
+			_, err := imageSizeFunc.Call(nil, data.String("abc"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
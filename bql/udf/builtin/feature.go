@@ -0,0 +1,381 @@
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// hashFeature returns the index and the sign of a feature in a hashed vector
+// having the given width.
+func hashFeature(name string, width int64) (int64, float64) {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	s := h.Sum64()
+	sign := 1.0
+	if s>>63 == 1 {
+		sign = -1
+	}
+	return int64((s & math.MaxInt64) % uint64(width)), sign
+}
+
+// featureHashFunc converts features into a fixed-width vector by the hashing
+// trick. Features can be given in following forms:
+//
+//	String: a single categorical feature
+//	Array of String: multiple categorical features like a bag of words
+//	Map: named features. A number is used as the value of the feature. A
+//	    string is converted to a categorical feature named "key=value". A
+//	    bool is converted to 1 or 0.
+//
+// A categorical feature has the value of 1. To reduce the bias of collisions,
+// the sign of a value is also determined by the hash.
+//
+// It can be used in BQL as `feature_hash`.
+//
+//	Input: String, Array, or Map (features), Int (width)
+//	Return Type: Array of Float
+var featureHashFunc = udf.BinaryFunc(func(ctx *core.Context, features, width data.Value) (data.Value, error) {
+	w, err := data.AsInt(width)
+	if err != nil {
+		return nil, fmt.Errorf("the width must be an integer: %v", err)
+	}
+	if w <= 0 {
+		return nil, fmt.Errorf("the width must be positive: %v", w)
+	}
+	vec := make([]float64, w)
+	add := func(name string, v float64) {
+		i, sign := hashFeature(name, w)
+		vec[i] += sign * v
+	}
+
+	switch features.Type() {
+	case data.TypeNull:
+		return data.Null{}, nil
+	case data.TypeString:
+		s, _ := data.AsString(features)
+		add(s, 1)
+	case data.TypeArray:
+		a, _ := data.AsArray(features)
+		for i, e := range a {
+			s, err := data.AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("the %v-th feature must be a string: %v", i, err)
+			}
+			add(s, 1)
+		}
+	case data.TypeMap:
+		m, _ := data.AsMap(features)
+		for k, v := range m {
+			switch v.Type() {
+			case data.TypeNull:
+			case data.TypeString:
+				s, _ := data.AsString(v)
+				add(k+"="+s, 1)
+			case data.TypeBool:
+				if b, _ := data.AsBool(v); b {
+					add(k, 1)
+				}
+			default:
+				f, err := data.ToFloat(v)
+				if err != nil {
+					return nil, fmt.Errorf("the feature '%v' has an unsupported value: %v", k, err)
+				}
+				add(k, f)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("features must be a string, an array, or a map: %v", features.Type())
+	}
+
+	a := make(data.Array, w)
+	for i, f := range vec {
+		a[i] = data.Float(f)
+	}
+	return a, nil
+})
+
+// vocabularyState assigns indexes to categorical values for one-hot encoding:
+//
+//	CREATE STATE v TYPE vocabulary WITH values=["red", "green", "blue"];
+//
+// It has following parameters:
+//
+//	values: initial values of the vocabulary (default: [])
+//	max_size: the maximum number of values (default: the number of values)
+//	field: the path to the value in a tuple written to the state
+//	    (default: "value")
+//
+// When max_size is larger than the number of initial values, values written
+// to the state through a uds sink are added to the vocabulary until it has
+// max_size values. The width of vectors created by one_hot is always
+// max_size so that it doesn't change while the vocabulary grows.
+type vocabularyState struct {
+	maxSize int
+	field   data.Path
+
+	m       sync.RWMutex
+	indexes map[string]int
+}
+
+var (
+	_ core.SharedState = &vocabularyState{}
+	_ core.Writer      = &vocabularyState{}
+)
+
+func createVocabularyState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	s := &vocabularyState{
+		maxSize: -1,
+		indexes: map[string]int{},
+	}
+	if v, ok := params["values"]; ok {
+		a, err := data.AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("'values' parameter must be an array: %v", err)
+		}
+		for i, e := range a {
+			str, err := data.ToString(e)
+			if err != nil {
+				return nil, fmt.Errorf("the %v-th value cannot be converted to a string: %v", i, err)
+			}
+			if _, ok := s.indexes[str]; !ok {
+				s.indexes[str] = len(s.indexes)
+			}
+		}
+	}
+	if v, ok := params["max_size"]; ok {
+		n, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'max_size' parameter must be an integer: %v", err)
+		}
+		if n < int64(len(s.indexes)) || n <= 0 {
+			return nil, fmt.Errorf("'max_size' parameter must be positive and at least the number of values: %v", n)
+		}
+		s.maxSize = int(n)
+	} else if len(s.indexes) == 0 {
+		return nil, errors.New("'max_size' parameter is required when 'values' parameter is empty")
+	} else {
+		s.maxSize = len(s.indexes)
+	}
+
+	field := "value"
+	if v, ok := params["field"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'field' parameter must be a string: %v", err)
+		}
+		field = f
+	}
+	p, err := data.CompilePath(field)
+	if err != nil {
+		return nil, fmt.Errorf("'field' parameter doesn't have a valid path: %v", err)
+	}
+	s.field = p
+	return s, nil
+}
+
+// Write adds the value of the tuple to the vocabulary. Values are ignored
+// after the vocabulary becomes full.
+func (s *vocabularyState) Write(ctx *core.Context, t *core.Tuple) error {
+	v, err := t.Data.Get(s.field)
+	if err != nil {
+		return fmt.Errorf("the tuple doesn't have the value: %v", err)
+	}
+	str, err := data.ToString(v)
+	if err != nil {
+		return err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if _, ok := s.indexes[str]; !ok && len(s.indexes) < s.maxSize {
+		s.indexes[str] = len(s.indexes)
+	}
+	return nil
+}
+
+// Index returns the index of the value. It returns -1 when the value isn't in
+// the vocabulary.
+func (s *vocabularyState) Index(v data.Value) (int, error) {
+	str, err := data.ToString(v)
+	if err != nil {
+		return 0, err
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if i, ok := s.indexes[str]; ok {
+		return i, nil
+	}
+	return -1, nil
+}
+
+// Contains returns true when the value is in the vocabulary. It allows
+// assertion boxes to check values against the vocabulary.
+func (s *vocabularyState) Contains(v data.Value) (bool, error) {
+	i, err := s.Index(v)
+	if err != nil {
+		return false, err
+	}
+	return i >= 0, nil
+}
+
+func (s *vocabularyState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// oneHotFunc converts a categorical value to a one-hot vector using a
+// vocabulary state. The vector only has zeros when the value isn't in the
+// vocabulary.
+//
+// It can be used in BQL as `one_hot`.
+//
+//	Input: String (name of the state), Any (value)
+//	Return Type: Array of Int
+var oneHotFunc = udf.BinaryFunc(func(ctx *core.Context, name, value data.Value) (data.Value, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of a state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*vocabularyState)
+	if !ok {
+		return nil, fmt.Errorf("the state '%v' isn't a vocabulary", n)
+	}
+
+	i, err := s.Index(value)
+	if err != nil {
+		return nil, err
+	}
+	a := make(data.Array, s.maxSize)
+	for j := range a {
+		a[j] = data.Int(0)
+	}
+	if i >= 0 {
+		a[i] = data.Int(1)
+	}
+	return a, nil
+})
+
+// runningStatsState computes the running mean and standard deviation of
+// values written to the state through a uds sink by Welford's algorithm:
+//
+//	CREATE STATE s TYPE running_stats WITH field="temperature";
+//
+// It has following parameters:
+//
+//	field: the path to the value in a tuple (default: "value")
+type runningStatsState struct {
+	field data.Path
+
+	m    sync.RWMutex
+	n    int64
+	mean float64
+	m2   float64
+}
+
+var (
+	_ core.SharedState = &runningStatsState{}
+	_ core.Writer      = &runningStatsState{}
+)
+
+func createRunningStatsState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	field := "value"
+	if v, ok := params["field"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'field' parameter must be a string: %v", err)
+		}
+		field = f
+	}
+	p, err := data.CompilePath(field)
+	if err != nil {
+		return nil, fmt.Errorf("'field' parameter doesn't have a valid path: %v", err)
+	}
+	return &runningStatsState{field: p}, nil
+}
+
+func (s *runningStatsState) Write(ctx *core.Context, t *core.Tuple) error {
+	v, err := t.Data.Get(s.field)
+	if err != nil {
+		return fmt.Errorf("the tuple doesn't have the value: %v", err)
+	}
+	if v.Type() == data.TypeNull {
+		return nil
+	}
+	x, err := data.ToFloat(v)
+	if err != nil {
+		return fmt.Errorf("the value must be a number: %v", err)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.n++
+	d := x - s.mean
+	s.mean += d / float64(s.n)
+	s.m2 += d * (x - s.mean)
+	return nil
+}
+
+// Stats returns the number of values, the mean, and the population standard
+// deviation.
+func (s *runningStatsState) Stats() (int64, float64, float64) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.n == 0 {
+		return 0, 0, 0
+	}
+	return s.n, s.mean, math.Sqrt(s.m2 / float64(s.n))
+}
+
+func (s *runningStatsState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// standardizeFunc standardizes a number with the running mean and standard
+// deviation computed by a running_stats state, that is (x - mean) / std. It
+// returns 0 while the standard deviation is 0.
+//
+// It can be used in BQL as `standardize`.
+//
+//	Input: String (name of the state), Int or Float
+//	Return Type: Float
+var standardizeFunc = udf.BinaryFunc(func(ctx *core.Context, name, value data.Value) (data.Value, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of a state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*runningStatsState)
+	if !ok {
+		return nil, fmt.Errorf("the state '%v' isn't a running_stats", n)
+	}
+	if value.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	x, err := data.ToFloat(value)
+	if err != nil {
+		return nil, err
+	}
+
+	_, mean, std := s.Stats()
+	if std == 0 {
+		return data.Float(0), nil
+	}
+	return data.Float((x - mean) / std), nil
+})
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("vocabulary", udf.UDSCreatorFunc(createVocabularyState))
+	udf.MustRegisterGlobalUDSCreator("running_stats", udf.UDSCreatorFunc(createRunningStatsState))
+}
@@ -0,0 +1,189 @@
+package builtin
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"testing"
+)
+
+func TestFeatureHash(t *testing.T) {
+	Convey("Given feature_hash function", t, func() {
+		f := featureHashFunc
+
+		Convey("When hashing named features", func() {
+			v, err := f.Call(nil, data.Map{
+				"temperature": data.Float(2.5),
+				"color":       data.String("red"),
+				"on":          data.True,
+			}, data.Int(1024))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return a vector having the width", func() {
+				a, err := data.AsArray(v)
+				So(err, ShouldBeNil)
+				So(a, ShouldHaveLength, 1024)
+
+				Convey("And each feature should be in its hashed position", func() {
+					i, sign := hashFeature("temperature", 1024)
+					So(a[i], ShouldEqual, data.Float(sign*2.5))
+					i, sign = hashFeature("color=red", 1024)
+					So(a[i], ShouldEqual, data.Float(sign))
+					i, sign = hashFeature("on", 1024)
+					So(a[i], ShouldEqual, data.Float(sign))
+
+					sum := 0.0
+					for _, e := range a {
+						x, _ := data.AsFloat(e)
+						sum += math.Abs(x)
+					}
+					So(sum, ShouldEqual, 4.5)
+				})
+			})
+
+			Convey("Then it should return the same vector for the same features", func() {
+				v2, err := f.Call(nil, data.Map{
+					"temperature": data.Float(2.5),
+					"color":       data.String("red"),
+					"on":          data.True,
+				}, data.Int(1024))
+				So(err, ShouldBeNil)
+				So(v2, ShouldResemble, v)
+			})
+		})
+
+		Convey("When hashing a bag of words", func() {
+			v, err := f.Call(nil, data.Array{data.String("a"), data.String("a")}, data.Int(8))
+			So(err, ShouldBeNil)
+
+			Convey("Then values of the same token should be summed", func() {
+				a, _ := data.AsArray(v)
+				i, sign := hashFeature("a", 8)
+				So(a[i], ShouldEqual, data.Float(2*sign))
+			})
+		})
+
+		Convey("When giving invalid arguments", func() {
+			Convey("Then it should fail with a non-positive width", func() {
+				_, err := f.Call(nil, data.String("a"), data.Int(0))
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail with an array of non-strings", func() {
+				_, err := f.Call(nil, data.Array{data.Int(1)}, data.Int(8))
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail with a number", func() {
+				_, err := f.Call(nil, data.Int(1), data.Int(8))
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestOneHot(t *testing.T) {
+	Convey("Given a vocabulary state", t, func() {
+		ctx := core.NewContext(nil)
+		s, err := createVocabularyState(ctx, data.Map{
+			"values":   data.Array{data.String("red"), data.String("green")},
+			"max_size": data.Int(3),
+			"field":    data.String("color"),
+		})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("v", "vocabulary", s), ShouldBeNil)
+
+		Convey("When encoding a value in the vocabulary", func() {
+			v, err := oneHotFunc.Call(ctx, data.String("v"), data.String("green"))
+
+			Convey("Then it should return a one-hot vector", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{data.Int(0), data.Int(1), data.Int(0)})
+			})
+		})
+
+		Convey("When encoding an unknown value", func() {
+			v, err := oneHotFunc.Call(ctx, data.String("v"), data.String("blue"))
+
+			Convey("Then it should return a zero vector", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{data.Int(0), data.Int(0), data.Int(0)})
+			})
+		})
+
+		Convey("When writing new values to the state", func() {
+			w := s.(core.Writer)
+			So(w.Write(ctx, core.NewTuple(data.Map{"color": data.String("blue")})), ShouldBeNil)
+			So(w.Write(ctx, core.NewTuple(data.Map{"color": data.String("white")})), ShouldBeNil)
+
+			Convey("Then values should be added until the vocabulary is full", func() {
+				v, err := oneHotFunc.Call(ctx, data.String("v"), data.String("blue"))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{data.Int(0), data.Int(0), data.Int(1)})
+				v, err = oneHotFunc.Call(ctx, data.String("v"), data.String("white"))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{data.Int(0), data.Int(0), data.Int(0)})
+			})
+		})
+	})
+
+	Convey("Given invalid parameters for a vocabulary", t, func() {
+		for _, params := range []data.Map{
+			{},
+			{"values": data.Array{data.String("a"), data.String("b")}, "max_size": data.Int(1)},
+			{"values": data.String("a")},
+		} {
+			_, err := createVocabularyState(core.NewContext(nil), params)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestStandardize(t *testing.T) {
+	Convey("Given a running_stats state", t, func() {
+		ctx := core.NewContext(nil)
+		s, err := createRunningStatsState(ctx, data.Map{})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("s", "running_stats", s), ShouldBeNil)
+
+		Convey("When no value is written", func() {
+			v, err := standardizeFunc.Call(ctx, data.String("s"), data.Int(3))
+
+			Convey("Then standardize should return 0", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Float(0))
+			})
+		})
+
+		Convey("When values are written", func() {
+			for _, x := range []int{2, 4, 4, 4, 5, 5, 7, 9} {
+				So(s.(core.Writer).Write(ctx, core.NewTuple(data.Map{"value": data.Int(x)})), ShouldBeNil)
+			}
+
+			Convey("Then it should have the mean and the standard deviation", func() {
+				n, mean, std := s.(*runningStatsState).Stats()
+				So(n, ShouldEqual, 8)
+				So(mean, ShouldAlmostEqual, 5)
+				So(std, ShouldAlmostEqual, 2)
+			})
+
+			Convey("Then standardize should use them", func() {
+				v, err := standardizeFunc.Call(ctx, data.String("s"), data.Int(9))
+				So(err, ShouldBeNil)
+				So(v, ShouldAlmostEqual, data.Float(2))
+			})
+		})
+
+		Convey("When using a state of another type", func() {
+			v, err := createVocabularyState(ctx, data.Map{"max_size": data.Int(1)})
+			So(err, ShouldBeNil)
+			So(ctx.SharedStates.Add("v", "vocabulary", v), ShouldBeNil)
+			_, err = standardizeFunc.Call(ctx, data.String("v"), data.Int(1))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
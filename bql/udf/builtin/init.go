@@ -59,6 +59,22 @@ func init() {
 	udf.RegisterGlobalUDF("substring", &arityDispatcher{
 		binary: substringFunc, ternary: substringFunc})
 	udf.RegisterGlobalUDF("upper", upperFunc)
+	// blob functions
+	udf.RegisterGlobalUDF("compress", compressFunc)
+	udf.RegisterGlobalUDF("decompress", decompressFunc)
+	udf.RegisterGlobalUDF("decode", decodeFunc)
+	udf.RegisterGlobalUDF("encode", encodeFunc)
+	udf.RegisterGlobalUDF("image_size", imageSizeFunc)
+	udf.RegisterGlobalUDF("mime_type", mimeTypeFunc)
+	// parsing functions
+	udf.RegisterGlobalUDF("decode_frame", decodeFrameFunc)
+	udf.RegisterGlobalUDF("grok", grokFunc)
+	udf.RegisterGlobalUDF("parse_access_log", parseAccessLogFunc)
+	udf.RegisterGlobalUDF("parse_influx", &arityDispatcher{
+		unary: parseInfluxNsFunc, binary: parseInfluxFunc})
+	udf.RegisterGlobalUDF("parse_logfmt", parseLogfmtFunc)
+	udf.RegisterGlobalUDF("parse_xml", &arityDispatcher{
+		unary: parseXMLDefaultFunc, binary: parseXMLFunc})
 	// time functions
 	udf.RegisterGlobalUDF("distance_us", diffUsFunc)
 	udf.RegisterGlobalUDF("clock_timestamp", clockTimestampFunc)
@@ -68,12 +84,25 @@ func init() {
 	udf.RegisterGlobalUDF("count", countFunc)
 	udf.RegisterGlobalUDF("bool_and", boolAndFunc)
 	udf.RegisterGlobalUDF("bool_or", boolOrFunc)
+	udf.RegisterGlobalUDF("first_value", firstValueFunc)
 	udf.RegisterGlobalUDF("json_object_agg", jsonObjectAggFunc)
+	udf.RegisterGlobalUDF("last_value", lastValueFunc)
 	udf.RegisterGlobalUDF("max", maxFunc)
 	udf.RegisterGlobalUDF("median", medianFunc)
 	udf.RegisterGlobalUDF("min", minFunc)
+	udf.RegisterGlobalUDF("reservoir_sample", reservoirSampleFunc)
 	udf.RegisterGlobalUDF("string_agg", stringAggFunc)
 	udf.RegisterGlobalUDF("sum", sumFunc)
+	// machine learning functions
+	udf.RegisterGlobalUDF("feature_hash", featureHashFunc)
+	udf.RegisterGlobalUDF("one_hot", oneHotFunc)
+	udf.RegisterGlobalUDF("standardize", standardizeFunc)
+	udf.RegisterGlobalUDF("dot", dotFunc)
+	udf.RegisterGlobalUDF("cosine_similarity", cosineSimilarityFunc)
+	udf.RegisterGlobalUDF("l2_distance", l2DistanceFunc)
+	udf.RegisterGlobalUDF("vector_nearest", vectorNearestFunc)
+	udf.RegisterGlobalUDF("regression_predict", regressionPredictFunc)
+	udf.RegisterGlobalUDF("regression_weights", regressionWeightsFunc)
 	// other functions
 	udf.RegisterGlobalUDF("coalesce", coalesceFunc)
 }
@@ -0,0 +1,628 @@
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/frame"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// parseLogfmtFunc parses a line of logfmt such as
+// `level=info msg="user logged in" user=alice admin` into a map. Values are
+// strings and keys without values are true. Quoted values can have escape
+// sequences of Go's string literals.
+//
+// It can be used in BQL as `parse_logfmt`.
+//
+//	Input: String
+//	Return Type: Map
+var parseLogfmtFunc = udf.UnaryFunc(func(ctx *core.Context, line data.Value) (data.Value, error) {
+	if line.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	s, err := data.AsString(line)
+	if err != nil {
+		return nil, err
+	}
+
+	m := data.Map{}
+	for i := 0; i < len(s); {
+		if s[i] == ' ' || s[i] == '\t' {
+			i++
+			continue
+		}
+		end := i + strings.IndexAny(s[i:]+" ", " \t=\"")
+		if end == i {
+			return nil, fmt.Errorf("logfmt has an invalid key at %v", i)
+		}
+		key := s[i:end]
+		i = end
+		if i >= len(s) || s[i] != '=' {
+			if i < len(s) && s[i] == '"' {
+				return nil, fmt.Errorf("logfmt has an invalid key at %v", i)
+			}
+			m[key] = data.True
+			continue
+		}
+		i++
+
+		if i < len(s) && s[i] == '"' {
+			end, err := findQuoteEnd(s, i)
+			if err != nil {
+				return nil, fmt.Errorf("logfmt has an invalid value of %v: %v", key, err)
+			}
+			v, err := strconv.Unquote(s[i:end])
+			if err != nil {
+				return nil, fmt.Errorf("logfmt has an invalid value of %v: %v", key, err)
+			}
+			m[key] = data.String(v)
+			i = end
+			continue
+		}
+		end = i + strings.IndexAny(s[i:]+" ", " \t")
+		m[key] = data.String(s[i:end])
+		i = end
+	}
+	return m, nil
+})
+
+// findQuoteEnd returns the index next to the closing quote of the string
+// starting at s[start].
+func findQuoteEnd(s string, start int) (int, error) {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, errors.New("the quoted string isn't closed")
+}
+
+// influxPrecisions are units of timestamps of Influx line protocol.
+var influxPrecisions = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+}
+
+// parseInfluxFunc parses a line of Influx line protocol such as
+// `weather,location=us-midwest temperature=82,ok=true 1465839830100400200`
+// into a map having "measurement", "tags", "fields", and "timestamp".
+// "timestamp" is NULL when the line doesn't have it. Integers having the
+// suffix "i" or "u" are converted to Int and other numbers are converted to
+// Float. The second argument is the precision of the timestamp, which is one
+// of "ns", "us", "ms", and "s". The default precision is "ns".
+//
+// It can be used in BQL as `parse_influx`.
+//
+//	Input: String, [String (precision)]
+//	Return Type: Map
+var parseInfluxFunc = udf.BinaryFunc(func(ctx *core.Context, line, precision data.Value) (data.Value, error) {
+	if line.Type() == data.TypeNull || precision.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	s, err := data.AsString(line)
+	if err != nil {
+		return nil, err
+	}
+	p, err := data.AsString(precision)
+	if err != nil {
+		return nil, err
+	}
+	unit, ok := influxPrecisions[strings.ToLower(p)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported precision: %v", p)
+	}
+	m, err := parseInfluxLine(strings.TrimSpace(s), unit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the line protocol: %v", err)
+	}
+	return m, nil
+})
+
+// parseInfluxNsFunc is parseInfluxFunc having the precision of "ns".
+//
+// It can be used in BQL as `parse_influx`.
+//
+//	Input: String
+//	Return Type: Map
+var parseInfluxNsFunc = udf.UnaryFunc(func(ctx *core.Context, line data.Value) (data.Value, error) {
+	return parseInfluxFunc.Call(ctx, line, data.String("ns"))
+})
+
+// scanInfluxToken returns the token starting at s[i] and the index of the
+// character terminating it. The token ends at an unescaped character in
+// stops. A backslash escapes a following comma, equal sign, or space.
+func scanInfluxToken(s string, i int, stops string) (string, int) {
+	var b []byte
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) && strings.IndexByte(", =", s[i+1]) >= 0 {
+			b = append(b, s[i+1])
+			i++
+			continue
+		}
+		if strings.IndexByte(stops, c) >= 0 {
+			break
+		}
+		b = append(b, c)
+	}
+	return string(b), i
+}
+
+func parseInfluxLine(s string, unit time.Duration) (data.Map, error) {
+	if s == "" || s[0] == '#' {
+		return nil, errors.New("the line has no point")
+	}
+	name, i := scanInfluxToken(s, 0, ", ")
+	if name == "" {
+		return nil, errors.New("the measurement is empty")
+	}
+
+	tags := data.Map{}
+	for i < len(s) && s[i] == ',' {
+		k, next := scanInfluxToken(s, i+1, ",= ")
+		if next >= len(s) || s[next] != '=' || k == "" {
+			return nil, fmt.Errorf("invalid tag at %v", i+1)
+		}
+		v, next := scanInfluxToken(s, next+1, ", ")
+		if v == "" {
+			return nil, fmt.Errorf("tag %v has no value", k)
+		}
+		tags[k] = data.String(v)
+		i = next
+	}
+	if i >= len(s) {
+		return nil, errors.New("the line has no field")
+	}
+
+	fields := data.Map{}
+	for {
+		k, next := scanInfluxToken(s, i+1, ",= ")
+		if next >= len(s) || s[next] != '=' || k == "" {
+			return nil, fmt.Errorf("invalid field at %v", i+1)
+		}
+		i = next + 1
+		var raw string
+		if i < len(s) && s[i] == '"' {
+			end, err := findQuoteEnd(s, i)
+			if err != nil {
+				return nil, fmt.Errorf("field %v has an invalid string: %v", k, err)
+			}
+			r := strings.NewReplacer(`\"`, `"`, `\\`, `\`)
+			fields[k] = data.String(r.Replace(s[i+1 : end-1]))
+			i = end
+		} else {
+			raw, i = scanInfluxToken(s, i, ", ")
+			v, err := parseInfluxFieldValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("field %v has an invalid value: %v", k, err)
+			}
+			fields[k] = v
+		}
+		if i >= len(s) || s[i] != ',' {
+			break
+		}
+	}
+
+	var ts data.Value = data.Null{}
+	if rest := strings.TrimSpace(s[i:]); rest != "" {
+		if i < len(s) && s[i] != ' ' {
+			return nil, fmt.Errorf("unexpected character at %v", i)
+		}
+		n, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %v", rest)
+		}
+		ts = data.Timestamp(time.Unix(0, n*int64(unit)))
+	}
+	return data.Map{
+		"measurement": data.String(name),
+		"tags":        tags,
+		"fields":      fields,
+		"timestamp":   ts,
+	}, nil
+}
+
+func parseInfluxFieldValue(s string) (data.Value, error) {
+	switch s {
+	case "t", "T", "true", "True", "TRUE":
+		return data.True, nil
+	case "f", "F", "false", "False", "FALSE":
+		return data.False, nil
+	case "":
+		return nil, errors.New("the value is empty")
+	}
+	switch s[len(s)-1] {
+	case 'i':
+		i, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return data.Int(i), nil
+	case 'u':
+		u, err := strconv.ParseUint(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("%v overflows Int", s)
+		}
+		return data.Int(u), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return data.Float(f), nil
+}
+
+var accessLogPattern = regexp.MustCompile(`^(\S+) (\S+) (\S+) \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\d{3}) (\d+|-)(?: "((?:[^"\\]|\\.)*)" "((?:[^"\\]|\\.)*)")?`)
+
+// parseAccessLogFunc parses a line of Apache's or Nginx's access log in the
+// common or combined log format into a map having "remote_addr", "ident",
+// "user", "time", "request", "method", "path", "protocol", "status",
+// "bytes", "referer", and "user_agent". Fields written as "-" are NULL.
+// "method", "path", and "protocol" are NULL when the request cannot be split
+// into them. "referer" and "user_agent" are NULL in the common log format.
+//
+// It can be used in BQL as `parse_access_log`.
+//
+//	Input: String
+//	Return Type: Map
+var parseAccessLogFunc = udf.UnaryFunc(func(ctx *core.Context, line data.Value) (data.Value, error) {
+	if line.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	s, err := data.AsString(line)
+	if err != nil {
+		return nil, err
+	}
+	ms := accessLogPattern.FindStringSubmatch(s)
+	if ms == nil {
+		return nil, errors.New("the line isn't in the common or combined log format")
+	}
+	ts, err := time.Parse("02/Jan/2006:15:04:05 -0700", ms[4])
+	if err != nil {
+		return nil, fmt.Errorf("the line has an invalid time: %v", err)
+	}
+	status, _ := strconv.ParseInt(ms[6], 10, 64)
+
+	str := func(s string) data.Value {
+		if s == "-" || s == "" {
+			return data.Null{}
+		}
+		return data.String(s)
+	}
+	m := data.Map{
+		"remote_addr": data.String(ms[1]),
+		"ident":       str(ms[2]),
+		"user":        str(ms[3]),
+		"time":        data.Timestamp(ts),
+		"request":     data.String(ms[5]),
+		"method":      data.Null{},
+		"path":        data.Null{},
+		"protocol":    data.Null{},
+		"status":      data.Int(status),
+		"bytes":       data.Null{},
+		"referer":     str(ms[8]),
+		"user_agent":  str(ms[9]),
+	}
+	if req := strings.Fields(ms[5]); len(req) == 3 {
+		m["method"] = data.String(req[0])
+		m["path"] = data.String(req[1])
+		m["protocol"] = data.String(req[2])
+	}
+	if ms[7] != "-" {
+		b, err := strconv.ParseInt(ms[7], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the line has invalid bytes: %v", err)
+		}
+		m["bytes"] = data.Int(b)
+	}
+	return m, nil
+})
+
+// grokPatterns are named patterns which can be referred to from patterns of
+// grok. They must not have capturing groups.
+var grokPatterns = map[string]string{
+	"USERNAME":          `[a-zA-Z0-9._-]+`,
+	"USER":              `%{USERNAME}`,
+	"INT":               `[+-]?[0-9]+`,
+	"POSINT":            `\b[1-9][0-9]*\b`,
+	"NONNEGINT":         `\b[0-9]+\b`,
+	"BASE10NUM":         `[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)`,
+	"NUMBER":            `%{BASE10NUM}`,
+	"WORD":              `\b\w+\b`,
+	"NOTSPACE":          `\S+`,
+	"SPACE":             `\s*`,
+	"DATA":              `.*?`,
+	"GREEDYDATA":        `.*`,
+	"QUOTEDSTRING":      `"(?:[^"\\]|\\.)*"`,
+	"UUID":              `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"MAC":               `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}`,
+	"IPV4":              `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`,
+	"IPV6":              `(?:[0-9A-Fa-f]{0,4}:){2,7}(?:[0-9A-Fa-f]{1,4}|%{IPV4})?`,
+	"IP":                `(?:%{IPV6}|%{IPV4})`,
+	"HOSTNAME":          `\b[0-9A-Za-z][0-9A-Za-z-]{0,62}(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?`,
+	"IPORHOST":          `(?:%{IP}|%{HOSTNAME})`,
+	"HOSTPORT":          `%{IPORHOST}:%{POSINT}`,
+	"URIPATH":           `(?:/[A-Za-z0-9$.+!*'(){},~:;=@#%&_\-]*)+`,
+	"URIPARAM":          `\?[A-Za-z0-9$.+!*'|(){},~@#%&/=:;_?\-\[\]<>]*`,
+	"URIPATHPARAM":      `%{URIPATH}(?:%{URIPARAM})?`,
+	"LOGLEVEL":          `(?i:trace|debug|info|notice|warn(?:ing)?|err(?:or)?|crit(?:ical)?|alert|fatal|severe|emerg(?:ency)?)`,
+	"TIMESTAMP_ISO8601": `\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(?::\d{2}(?:[.,]\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?`,
+	"HTTPDATE":          `\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`,
+}
+
+var grokReference = regexp.MustCompile(`%\{(\w+)(?::(\w+))?(?::(\w+))?\}`)
+
+// grokPattern is a compiled pattern of grok. types has types of captured
+// values which are converted from strings.
+type grokPattern struct {
+	re    *regexp.Regexp
+	types map[string]string
+}
+
+// maxGrokDepth is the maximum depth of nested references of named patterns.
+const maxGrokDepth = 16
+
+func compileGrokPattern(p string) (*grokPattern, error) {
+	g := &grokPattern{
+		types: map[string]string{},
+	}
+	var expand func(p string, depth int) (string, error)
+	expand = func(p string, depth int) (string, error) {
+		if depth > maxGrokDepth {
+			return "", errors.New("named patterns are nested too deeply")
+		}
+		var err error
+		res := grokReference.ReplaceAllStringFunc(p, func(ref string) string {
+			if err != nil {
+				return ""
+			}
+			ms := grokReference.FindStringSubmatch(ref)
+			def, ok := grokPatterns[ms[1]]
+			if !ok {
+				err = fmt.Errorf("unknown pattern: %v", ms[1])
+				return ""
+			}
+			var e string
+			if e, err = expand(def, depth+1); err != nil {
+				return ""
+			}
+			if ms[2] == "" {
+				return "(?:" + e + ")"
+			}
+			if depth > 0 {
+				err = fmt.Errorf("named pattern %v cannot capture values", ms[1])
+				return ""
+			}
+			switch ms[3] {
+			case "", "string":
+			case "int", "float":
+				g.types[ms[2]] = ms[3]
+			default:
+				err = fmt.Errorf("unsupported type of %v: %v", ms[2], ms[3])
+				return ""
+			}
+			return "(?P<" + ms[2] + ">" + e + ")"
+		})
+		return res, err
+	}
+	e, err := expand(p, 0)
+	if err != nil {
+		return nil, err
+	}
+	if g.re, err = regexp.Compile(e); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// match returns a map of named captures. Captures not participating in the
+// match are NULL. It returns nil when s doesn't match the pattern.
+func (g *grokPattern) match(s string) (data.Map, error) {
+	idx := g.re.FindStringSubmatchIndex(s)
+	if idx == nil {
+		return nil, nil
+	}
+	m := data.Map{}
+	for i, name := range g.re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		if idx[2*i] < 0 {
+			m[name] = data.Null{}
+			continue
+		}
+		v := s[idx[2*i]:idx[2*i+1]]
+		switch g.types[name] {
+		case "int":
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert %v of %v to int", v, name)
+			}
+			m[name] = data.Int(i)
+		case "float":
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert %v of %v to float", v, name)
+			}
+			m[name] = data.Float(f)
+		default:
+			m[name] = data.String(v)
+		}
+	}
+	return m, nil
+}
+
+// grokCache has compiled patterns of grok so that a pattern given to the
+// function as a constant isn't compiled for every tuple. It's cleared when
+// it gets full.
+type grokCache struct {
+	m        sync.RWMutex
+	patterns map[string]*grokPattern
+	capacity int
+}
+
+func (c *grokCache) get(p string) (*grokPattern, error) {
+	c.m.RLock()
+	g, ok := c.patterns[p]
+	c.m.RUnlock()
+	if ok {
+		return g, nil
+	}
+
+	g, err := compileGrokPattern(p)
+	if err != nil {
+		return nil, err
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	if len(c.patterns) >= c.capacity {
+		c.patterns = map[string]*grokPattern{}
+	}
+	c.patterns[p] = g
+	return g, nil
+}
+
+var defaultGrokCache = &grokCache{
+	patterns: map[string]*grokPattern{},
+	capacity: 256,
+}
+
+// grokFunc extracts values from a string by a pattern of grok. A pattern is
+// a regular expression which can refer to named patterns such as
+// %{IPV4:client} and %{NUMBER:took:float}. The first part is the name of the
+// pattern, the second part is the key of the captured value, and the third
+// part is the type of the value, which is one of "string", "int", and
+// "float". Named groups of the regular expression like (?P<key>...) are also
+// captured as strings. It returns NULL when the string doesn't match the
+// pattern. Compiled patterns are cached and shared by all topologies.
+//
+// Supported named patterns are USERNAME, USER, INT, POSINT, NONNEGINT,
+// BASE10NUM, NUMBER, WORD, NOTSPACE, SPACE, DATA, GREEDYDATA, QUOTEDSTRING,
+// UUID, MAC, IPV4, IPV6, IP, HOSTNAME, IPORHOST, HOSTPORT, URIPATH, URIPARAM,
+// URIPATHPARAM, LOGLEVEL, TIMESTAMP_ISO8601, and HTTPDATE.
+//
+// It can be used in BQL as `grok`.
+//
+//	Input: String, String (pattern)
+//	Return Type: Map
+var grokFunc = udf.BinaryFunc(func(ctx *core.Context, str, pattern data.Value) (data.Value, error) {
+	if str.Type() == data.TypeNull || pattern.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	s, err := data.AsString(str)
+	if err != nil {
+		return nil, err
+	}
+	p, err := data.AsString(pattern)
+	if err != nil {
+		return nil, err
+	}
+	g, err := defaultGrokCache.get(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grok pattern: %v", err)
+	}
+	m, err := g.match(s)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return data.Null{}, nil
+	}
+	return m, nil
+})
+
+// parseXMLFunc converts an XML document to a map having the root element as
+// its only key. The second argument is a map of options accepted by
+// data.NewXMLOptions, e.g. {"namespaces": "uri", "force_array": ["item"]}.
+//
+// It can be used in BQL as `parse_xml`.
+//
+//	Input: String or Blob, [Map (options)]
+//	Return Type: Map
+var parseXMLFunc = udf.BinaryFunc(func(ctx *core.Context, doc, options data.Value) (data.Value, error) {
+	if doc.Type() == data.TypeNull || options.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	var b []byte
+	switch doc.Type() {
+	case data.TypeString:
+		s, _ := data.AsString(doc)
+		b = []byte(s)
+	case data.TypeBlob:
+		b, _ = data.AsBlob(doc)
+	default:
+		return nil, fmt.Errorf("cannot interpret %s as a string or a blob", doc)
+	}
+	params, err := data.AsMap(options)
+	if err != nil {
+		return nil, fmt.Errorf("options must be a map: %v", err)
+	}
+	opts, err := data.NewXMLOptions(params)
+	if err != nil {
+		return nil, err
+	}
+	m, err := data.UnmarshalXML(b, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse XML: %v", err)
+	}
+	return m, nil
+})
+
+// parseXMLDefaultFunc is parseXMLFunc having the default options.
+//
+// It can be used in BQL as `parse_xml`.
+//
+//	Input: String or Blob
+//	Return Type: Map
+var parseXMLDefaultFunc = udf.UnaryFunc(func(ctx *core.Context, doc data.Value) (data.Value, error) {
+	return parseXMLFunc.Call(ctx, doc, data.Map{})
+})
+
+// decodeFrameFunc decodes a fixed-format binary frame into a map. The second
+// argument is a map of parameters accepted by frame.NewLayout, e.g.
+// {"fields": [{"name": "temp", "offset": 0, "type": "int16", "scale": 0.1}]}.
+// It's an error when the blob is shorter than the frame, but extra bytes are
+// ignored.
+//
+// It can be used in BQL as `decode_frame`.
+//
+//	Input: Blob, Map (layout)
+//	Return Type: Map
+var decodeFrameFunc = udf.BinaryFunc(func(ctx *core.Context, b, layout data.Value) (data.Value, error) {
+	if b.Type() == data.TypeNull || layout.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	blob, err := data.AsBlob(b)
+	if err != nil {
+		return nil, err
+	}
+	params, err := data.AsMap(layout)
+	if err != nil {
+		return nil, fmt.Errorf("layout must be a map: %v", err)
+	}
+	l, err := frame.NewLayout(params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid layout: %v", err)
+	}
+	m, err := l.Decode(blob)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+})
@@ -0,0 +1,314 @@
+package builtin
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestParseLogfmt(t *testing.T) {
+	Convey("Given the parse_logfmt function", t, func() {
+		Convey("When parsing a line of logfmt", func() {
+			v, err := parseLogfmtFunc.Call(nil,
+				data.String(`level=info msg="user \"a\" logged in" took=12ms  admin empty=`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have all keys", func() {
+				So(v, ShouldResemble, data.Map{
+					"level": data.String("info"),
+					"msg":   data.String(`user "a" logged in`),
+					"took":  data.String("12ms"),
+					"admin": data.True,
+					"empty": data.String(""),
+				})
+			})
+		})
+
+		Convey("When parsing invalid lines", func() {
+			Convey("Then it should fail", func() {
+				for _, l := range []string{`=a`, `a="b`, `a"b=c`} {
+					_, err := parseLogfmtFunc.Call(nil, data.String(l))
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When passing null", func() {
+			v, err := parseLogfmtFunc.Call(nil, data.Null{})
+
+			Convey("Then it should return null", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+	})
+}
+
+func TestParseInflux(t *testing.T) {
+	Convey("Given the parse_influx function", t, func() {
+		Convey("When parsing a line with tags and a timestamp", func() {
+			v, err := parseInfluxNsFunc.Call(nil, data.String(
+				`weather\ report,location=us\,midwest,host=a temp=82.5,count=3i,ok=t,note="say \"hi\", ok" 1465839830100400200`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have all parts", func() {
+				So(v, ShouldResemble, data.Map{
+					"measurement": data.String("weather report"),
+					"tags": data.Map{
+						"location": data.String("us,midwest"),
+						"host":     data.String("a"),
+					},
+					"fields": data.Map{
+						"temp":  data.Float(82.5),
+						"count": data.Int(3),
+						"ok":    data.True,
+						"note":  data.String(`say "hi", ok`),
+					},
+					"timestamp": data.Timestamp(time.Unix(0, 1465839830100400200)),
+				})
+			})
+		})
+
+		Convey("When parsing a line without tags and a timestamp", func() {
+			v, err := parseInfluxNsFunc.Call(nil, data.String(`cpu usage=1u`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have empty tags and null timestamp", func() {
+				So(v, ShouldResemble, data.Map{
+					"measurement": data.String("cpu"),
+					"tags":        data.Map{},
+					"fields":      data.Map{"usage": data.Int(1)},
+					"timestamp":   data.Null{},
+				})
+			})
+		})
+
+		Convey("When parsing a line with a precision", func() {
+			v, err := parseInfluxFunc.Call(nil, data.String(`cpu usage=1 1465839830`), data.String("s"))
+			So(err, ShouldBeNil)
+
+			Convey("Then the timestamp should be in the precision", func() {
+				m, _ := data.AsMap(v)
+				So(m["timestamp"], ShouldResemble, data.Timestamp(time.Unix(1465839830, 0)))
+			})
+		})
+
+		Convey("When parsing invalid lines", func() {
+			Convey("Then it should fail", func() {
+				for _, l := range []string{``, `# comment`, `cpu`, `cpu,host usage=1`,
+					`cpu usage=`, `cpu usage=abc`, `cpu usage=1 abc`, `cpu usage="a`} {
+					_, err := parseInfluxNsFunc.Call(nil, data.String(l))
+					So(err, ShouldNotBeNil)
+				}
+				_, err := parseInfluxFunc.Call(nil, data.String(`cpu usage=1`), data.String("m"))
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestParseAccessLog(t *testing.T) {
+	Convey("Given the parse_access_log function", t, func() {
+		Convey("When parsing a line in the combined log format", func() {
+			v, err := parseAccessLogFunc.Call(nil, data.String(
+				`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)"`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have all fields", func() {
+				So(v, ShouldResemble, data.Map{
+					"remote_addr": data.String("127.0.0.1"),
+					"ident":       data.Null{},
+					"user":        data.String("frank"),
+					"time":        data.Timestamp(time.Date(2000, 10, 10, 20, 55, 36, 0, time.UTC).In(time.FixedZone("", -7*3600))),
+					"request":     data.String("GET /apache_pb.gif HTTP/1.0"),
+					"method":      data.String("GET"),
+					"path":        data.String("/apache_pb.gif"),
+					"protocol":    data.String("HTTP/1.0"),
+					"status":      data.Int(200),
+					"bytes":       data.Int(2326),
+					"referer":     data.String("http://www.example.com/start.html"),
+					"user_agent":  data.String("Mozilla/4.08 [en] (Win98; I ;Nav)"),
+				})
+			})
+		})
+
+		Convey("When parsing a line in the common log format", func() {
+			v, err := parseAccessLogFunc.Call(nil, data.String(
+				`::1 - - [10/Oct/2000:13:55:36 +0000] "-" 400 -`))
+			So(err, ShouldBeNil)
+
+			Convey("Then missing fields should be null", func() {
+				m, _ := data.AsMap(v)
+				So(m["user"], ShouldResemble, data.Null{})
+				So(m["method"], ShouldResemble, data.Null{})
+				So(m["bytes"], ShouldResemble, data.Null{})
+				So(m["referer"], ShouldResemble, data.Null{})
+				So(m["status"], ShouldEqual, data.Int(400))
+			})
+		})
+
+		Convey("When parsing a line in another format", func() {
+			_, err := parseAccessLogFunc.Call(nil, data.String(`level=info msg=hello`))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestGrok(t *testing.T) {
+	Convey("Given the grok function", t, func() {
+		Convey("When matching a pattern with named patterns", func() {
+			v, err := grokFunc.Call(nil,
+				data.String("2016-01-02T03:04:05Z WARN 10.0.0.1 took 1.5s code=42 disk full"),
+				data.String(`^%{TIMESTAMP_ISO8601:ts} %{LOGLEVEL:level} %{IP:client} took %{NUMBER:took:float}s code=%{INT:code:int} (?P<msg>.*)$`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have captured values", func() {
+				So(v, ShouldResemble, data.Map{
+					"ts":     data.String("2016-01-02T03:04:05Z"),
+					"level":  data.String("WARN"),
+					"client": data.String("10.0.0.1"),
+					"took":   data.Float(1.5),
+					"code":   data.Int(42),
+					"msg":    data.String("disk full"),
+				})
+			})
+
+			Convey("Then the pattern should be cached", func() {
+				defaultGrokCache.m.RLock()
+				defer defaultGrokCache.m.RUnlock()
+				So(defaultGrokCache.patterns, ShouldContainKey,
+					`^%{TIMESTAMP_ISO8601:ts} %{LOGLEVEL:level} %{IP:client} took %{NUMBER:took:float}s code=%{INT:code:int} (?P<msg>.*)$`)
+			})
+		})
+
+		Convey("When a string doesn't match the pattern", func() {
+			v, err := grokFunc.Call(nil, data.String("abc"), data.String(`^%{INT:n}$`))
+
+			Convey("Then it should return null", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When an optional capture doesn't participate", func() {
+			v, err := grokFunc.Call(nil, data.String("a"), data.String(`^%{WORD:w}(?: %{INT:n})?$`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be null", func() {
+				So(v, ShouldResemble, data.Map{"w": data.String("a"), "n": data.Null{}})
+			})
+		})
+
+		Convey("When giving invalid patterns", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range []string{`%{NO_SUCH_PATTERN:a}`, `%{INT:a:date}`, `(`} {
+					_, err := grokFunc.Call(nil, data.String("1"), data.String(p))
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When the cache gets full", func() {
+			c := &grokCache{
+				patterns: map[string]*grokPattern{},
+				capacity: 2,
+			}
+			for _, p := range []string{"a", "b", "c"} {
+				_, err := c.get(p)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then it should be cleared", func() {
+				So(c.patterns, ShouldHaveLength, 1)
+				So(c.patterns, ShouldContainKey, "c")
+			})
+		})
+	})
+}
+
+func TestParseXML(t *testing.T) {
+	Convey("Given the parse_xml function", t, func() {
+		doc := `<ns:reading xmlns:ns="urn:x" unit="C"><value>21.5</value></ns:reading>`
+
+		Convey("When parsing a document with default options", func() {
+			v, err := parseXMLDefaultFunc.Call(nil, data.String(doc))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be converted to a map", func() {
+				So(v, ShouldResemble, data.Map{
+					"reading": data.Map{
+						"@unit": data.String("C"),
+						"value": data.String("21.5"),
+					},
+				})
+			})
+		})
+
+		Convey("When parsing a blob with options", func() {
+			v, err := parseXMLFunc.Call(nil, data.Blob(doc), data.Map{
+				"namespaces":  data.String("uri"),
+				"infer_types": data.True,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the options should be applied", func() {
+				So(v, ShouldResemble, data.Map{
+					"{urn:x}reading": data.Map{
+						"@unit": data.String("C"),
+						"value": data.Float(21.5),
+					},
+				})
+			})
+		})
+
+		Convey("When giving invalid arguments", func() {
+			Convey("Then it should fail", func() {
+				_, err := parseXMLDefaultFunc.Call(nil, data.String("<a>"))
+				So(err, ShouldNotBeNil)
+				_, err = parseXMLDefaultFunc.Call(nil, data.Int(1))
+				So(err, ShouldNotBeNil)
+				_, err = parseXMLFunc.Call(nil, data.String(doc), data.Map{"namespaces": data.String("x")})
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestDecodeFrame(t *testing.T) {
+	Convey("Given the decode_frame function", t, func() {
+		layout := data.Map{
+			"fields": data.Array{
+				data.Map{"name": data.String("unit"), "offset": data.Int(0), "type": data.String("uint8")},
+				data.Map{"name": data.String("value"), "offset": data.Int(1), "type": data.String("uint16"),
+					"endian": data.String("little")},
+			},
+		}
+
+		Convey("When decoding a frame", func() {
+			v, err := decodeFrameFunc.Call(nil, data.Blob{0x03, 0x10, 0x27, 0xff}, layout)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be converted to a map", func() {
+				So(v, ShouldResemble, data.Map{
+					"unit":  data.Int(3),
+					"value": data.Int(10000),
+				})
+			})
+		})
+
+		Convey("When giving invalid arguments", func() {
+			Convey("Then it should fail", func() {
+				_, err := decodeFrameFunc.Call(nil, data.Blob{0x03}, layout)
+				So(err, ShouldNotBeNil)
+				_, err = decodeFrameFunc.Call(nil, data.String("abc"), layout)
+				So(err, ShouldNotBeNil)
+				_, err = decodeFrameFunc.Call(nil, data.Blob{0x03}, data.Map{})
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
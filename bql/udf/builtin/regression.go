@@ -0,0 +1,384 @@
+package builtin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// regressionState is a linear or logistic regression model trained online by
+// stochastic gradient descent. Each tuple written to the state, typically
+// through a uds sink, is used as a training example:
+//
+//	CREATE STATE m TYPE logistic_regression WITH learning_rate=0.1;
+//	CREATE SINK trainer TYPE uds WITH name="m";
+//	INSERT INTO trainer FROM labeled_stream;
+//
+// The state has following parameters:
+//
+//	learning_rate: the step size of SGD (default: 0.01)
+//	regularization: the coefficient of L2 regularization (default: 0)
+//	label_field: the path to the label in a tuple (default: "label")
+//	features_field: the path to the features in a tuple (default: "features")
+//
+// Features are a map from feature names to numbers. An array of numbers can
+// also be given and its elements are named by their indexes. Labels of a
+// logistic regression are 0, 1, or bool. To keep updates proportional to the
+// number of features in an example, regularization is only applied to weights
+// of features the example has.
+//
+// Predictions made by regression_predict see a consistent snapshot of the
+// model because updates are done while holding the write lock.
+type regressionState struct {
+	logistic      bool
+	learningRate  float64
+	l2            float64
+	labelField    string
+	featuresField string
+	labelPath     data.Path
+	featuresPath  data.Path
+
+	m          sync.RWMutex
+	weights    map[string]float64
+	bias       float64
+	numTrained int64
+	terminated bool
+}
+
+var (
+	_ core.LoadableSharedState = &regressionState{}
+	_ core.Writer              = &regressionState{}
+)
+
+type regressionCreator struct {
+	logistic bool
+}
+
+var (
+	_ udf.UDSLoader = &regressionCreator{}
+)
+
+func (c *regressionCreator) CreateState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	s := &regressionState{
+		logistic:      c.logistic,
+		learningRate:  0.01,
+		labelField:    "label",
+		featuresField: "features",
+		weights:       map[string]float64{},
+	}
+	if err := s.setParams(params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (c *regressionCreator) LoadState(ctx *core.Context, r io.Reader, params data.Map) (core.SharedState, error) {
+	s := &regressionState{logistic: c.logistic}
+	if err := s.Load(ctx, r, params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *regressionState) setParams(params data.Map) error {
+	if v, ok := params["learning_rate"]; ok {
+		f, err := data.ToFloat(v)
+		if err != nil {
+			return fmt.Errorf("'learning_rate' parameter must be a number: %v", err)
+		}
+		if f <= 0 {
+			return fmt.Errorf("'learning_rate' parameter must be positive: %v", f)
+		}
+		s.learningRate = f
+	}
+	if v, ok := params["regularization"]; ok {
+		f, err := data.ToFloat(v)
+		if err != nil {
+			return fmt.Errorf("'regularization' parameter must be a number: %v", err)
+		}
+		if f < 0 {
+			return fmt.Errorf("'regularization' parameter must not be negative: %v", f)
+		}
+		s.l2 = f
+	}
+	if v, ok := params["label_field"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return fmt.Errorf("'label_field' parameter must be a string: %v", err)
+		}
+		s.labelField = f
+	}
+	if v, ok := params["features_field"]; ok {
+		f, err := data.AsString(v)
+		if err != nil {
+			return fmt.Errorf("'features_field' parameter must be a string: %v", err)
+		}
+		s.featuresField = f
+	}
+	return s.compilePaths()
+}
+
+func (s *regressionState) compilePaths() error {
+	p, err := data.CompilePath(s.labelField)
+	if err != nil {
+		return fmt.Errorf("'label_field' parameter doesn't have a valid path: %v", err)
+	}
+	s.labelPath = p
+	if p, err = data.CompilePath(s.featuresField); err != nil {
+		return fmt.Errorf("'features_field' parameter doesn't have a valid path: %v", err)
+	}
+	s.featuresPath = p
+	return nil
+}
+
+// toFeatures converts a map or an array of numbers to features.
+func toFeatures(v data.Value) (map[string]float64, error) {
+	fs := map[string]float64{}
+	switch v.Type() {
+	case data.TypeMap:
+		m, _ := data.AsMap(v)
+		for k, e := range m {
+			f, err := data.ToFloat(e)
+			if err != nil {
+				return nil, fmt.Errorf("the feature '%v' isn't a number: %v", k, err)
+			}
+			fs[k] = f
+		}
+	case data.TypeArray:
+		a, _ := data.AsArray(v)
+		for i, e := range a {
+			f, err := data.ToFloat(e)
+			if err != nil {
+				return nil, fmt.Errorf("the %v-th feature isn't a number: %v", i, err)
+			}
+			fs[strconv.Itoa(i)] = f
+		}
+	default:
+		return nil, fmt.Errorf("features must be a map or an array: %v", v.Type())
+	}
+	return fs, nil
+}
+
+// predict computes the prediction. The caller must hold the lock.
+func (s *regressionState) predict(fs map[string]float64) float64 {
+	y := s.bias
+	for k, x := range fs {
+		y += s.weights[k] * x
+	}
+	if s.logistic {
+		return 1 / (1 + math.Exp(-y))
+	}
+	return y
+}
+
+func (s *regressionState) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.terminated {
+		return errors.New("the state is already terminated")
+	}
+
+	lv, err := t.Data.Get(s.labelPath)
+	if err != nil {
+		return fmt.Errorf("the tuple doesn't have the label: %v", err)
+	}
+	var label float64
+	if lv.Type() == data.TypeBool {
+		b, _ := data.AsBool(lv)
+		if b {
+			label = 1
+		}
+	} else if label, err = data.ToFloat(lv); err != nil {
+		return fmt.Errorf("the label must be a number: %v", err)
+	}
+	if s.logistic && label != 0 && label != 1 {
+		return fmt.Errorf("the label of a logistic regression must be 0 or 1: %v", label)
+	}
+
+	fv, err := t.Data.Get(s.featuresPath)
+	if err != nil {
+		return fmt.Errorf("the tuple doesn't have the features: %v", err)
+	}
+	fs, err := toFeatures(fv)
+	if err != nil {
+		return err
+	}
+
+	// The gradient of both the squared error and the log loss is
+	// (prediction - label) * x.
+	g := s.predict(fs) - label
+	for k, x := range fs {
+		w := s.weights[k]
+		s.weights[k] = w - s.learningRate*(g*x+s.l2*w)
+	}
+	s.bias -= s.learningRate * g
+	s.numTrained++
+	return nil
+}
+
+// Predict returns the prediction for the features. It's the probability of
+// the label being 1 for a logistic regression.
+func (s *regressionState) Predict(features data.Value) (float64, error) {
+	fs, err := toFeatures(features)
+	if err != nil {
+		return 0, err
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.predict(fs), nil
+}
+
+// Weights returns a copy of the current weights and the bias.
+func (s *regressionState) Weights() (map[string]float64, float64) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	ws := make(map[string]float64, len(s.weights))
+	for k, w := range s.weights {
+		ws[k] = w
+	}
+	return ws, s.bias
+}
+
+func (s *regressionState) Terminate(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.terminated = true
+	return nil
+}
+
+type savedRegressionState struct {
+	Logistic      bool               `json:"logistic"`
+	LearningRate  float64            `json:"learning_rate"`
+	L2            float64            `json:"regularization"`
+	LabelField    string             `json:"label_field"`
+	FeaturesField string             `json:"features_field"`
+	Weights       map[string]float64 `json:"weights"`
+	Bias          float64            `json:"bias"`
+	NumTrained    int64              `json:"num_trained"`
+}
+
+func (s *regressionState) Save(ctx *core.Context, w io.Writer, params data.Map) error {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	saved := &savedRegressionState{
+		Logistic:      s.logistic,
+		LearningRate:  s.learningRate,
+		L2:            s.l2,
+		LabelField:    s.labelField,
+		FeaturesField: s.featuresField,
+		Weights:       s.weights,
+		Bias:          s.bias,
+		NumTrained:    s.numTrained,
+	}
+	return json.NewEncoder(w).Encode(saved)
+}
+
+// Load overwrites the model with saved data. params can override the
+// parameters saved with the model.
+func (s *regressionState) Load(ctx *core.Context, r io.Reader, params data.Map) error {
+	saved := &savedRegressionState{}
+	if err := json.NewDecoder(r).Decode(saved); err != nil {
+		return fmt.Errorf("cannot decode the saved model: %v", err)
+	}
+	if saved.Logistic != s.logistic {
+		return errors.New("the saved model has a different type")
+	}
+	if saved.Weights == nil {
+		saved.Weights = map[string]float64{}
+	}
+
+	n := &regressionState{
+		logistic:      saved.Logistic,
+		learningRate:  saved.LearningRate,
+		l2:            saved.L2,
+		labelField:    saved.LabelField,
+		featuresField: saved.FeaturesField,
+	}
+	if err := n.setParams(params); err != nil {
+		return err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.learningRate = n.learningRate
+	s.l2 = n.l2
+	s.labelField = n.labelField
+	s.featuresField = n.featuresField
+	s.labelPath = n.labelPath
+	s.featuresPath = n.featuresPath
+	s.weights = saved.Weights
+	s.bias = saved.Bias
+	s.numTrained = saved.NumTrained
+	return nil
+}
+
+func lookupRegressionState(ctx *core.Context, name data.Value) (*regressionState, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of a state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*regressionState)
+	if !ok {
+		return nil, fmt.Errorf("the state '%v' isn't a regression model", n)
+	}
+	return s, nil
+}
+
+// regressionPredictFunc predicts a value with a linear or logistic regression
+// state. It returns the probability of the label being 1 for a logistic
+// regression.
+//
+// It can be used in BQL as `regression_predict`.
+//
+//	Input: String (name of the state), Map or Array (features)
+//	Return Type: Float
+var regressionPredictFunc = udf.BinaryFunc(func(ctx *core.Context, name, features data.Value) (data.Value, error) {
+	s, err := lookupRegressionState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	y, err := s.Predict(features)
+	if err != nil {
+		return nil, err
+	}
+	return data.Float(y), nil
+})
+
+// regressionWeightsFunc returns the current weights of a linear or logistic
+// regression state as a map having "weights" and "bias".
+//
+// It can be used in BQL as `regression_weights`.
+//
+//	Input: String (name of the state)
+//	Return Type: Map
+var regressionWeightsFunc = udf.UnaryFunc(func(ctx *core.Context, name data.Value) (data.Value, error) {
+	s, err := lookupRegressionState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ws, b := s.Weights()
+	m := make(data.Map, len(ws))
+	for k, w := range ws {
+		m[k] = data.Float(w)
+	}
+	return data.Map{
+		"weights": m,
+		"bias":    data.Float(b),
+	}, nil
+})
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("linear_regression", &regressionCreator{})
+	udf.MustRegisterGlobalUDSCreator("logistic_regression", &regressionCreator{logistic: true})
+}
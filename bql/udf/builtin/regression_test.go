@@ -0,0 +1,156 @@
+package builtin
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math/rand"
+	"testing"
+)
+
+func TestRegressionState(t *testing.T) {
+	Convey("Given a linear regression state", t, func() {
+		ctx := core.NewContext(nil)
+		c, err := udf.CopyGlobalUDSCreatorRegistry()
+		So(err, ShouldBeNil)
+		creator, err := c.Lookup("linear_regression")
+		So(err, ShouldBeNil)
+		st, err := creator.CreateState(ctx, data.Map{"learning_rate": data.Float(0.05)})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("m", "linear_regression", st), ShouldBeNil)
+		w := st.(core.Writer)
+
+		Convey("When training it with y = 2 * x1 - x2 + 1", func() {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 5000; i++ {
+				x1, x2 := r.Float64()*2-1, r.Float64()*2-1
+				So(w.Write(ctx, core.NewTuple(data.Map{
+					"features": data.Map{"x1": data.Float(x1), "x2": data.Float(x2)},
+					"label":    data.Float(2*x1 - x2 + 1),
+				})), ShouldBeNil)
+			}
+
+			Convey("Then regression_predict should predict the value", func() {
+				v, err := regressionPredictFunc.Call(ctx, data.String("m"),
+					data.Map{"x1": data.Float(0.5), "x2": data.Int(1)})
+				So(err, ShouldBeNil)
+				So(v, ShouldAlmostEqual, data.Float(1), 0.01)
+			})
+
+			Convey("Then regression_weights should return the learned weights", func() {
+				v, err := regressionWeightsFunc.Call(ctx, data.String("m"))
+				So(err, ShouldBeNil)
+				m, err := data.AsMap(v)
+				So(err, ShouldBeNil)
+				So(m["bias"], ShouldAlmostEqual, data.Float(1), 0.01)
+				x1, err := m.Get(data.MustCompilePath("weights.x1"))
+				So(err, ShouldBeNil)
+				So(x1, ShouldAlmostEqual, data.Float(2), 0.01)
+			})
+
+			Convey("Then it can be saved and loaded", func() {
+				b := bytes.NewBuffer(nil)
+				So(st.(core.SavableSharedState).Save(ctx, b, data.Map{}), ShouldBeNil)
+				loaded, err := creator.(udf.UDSLoader).LoadState(ctx, b, data.Map{})
+				So(err, ShouldBeNil)
+				y, err := loaded.(*regressionState).Predict(data.Map{"x1": data.Float(0.5), "x2": data.Int(1)})
+				So(err, ShouldBeNil)
+				So(y, ShouldAlmostEqual, 1, 0.01)
+			})
+		})
+
+		Convey("When writing invalid tuples", func() {
+			Convey("Then it should fail without a label", func() {
+				So(w.Write(ctx, core.NewTuple(data.Map{"features": data.Array{data.Int(1)}})), ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail with non-numeric features", func() {
+				So(w.Write(ctx, core.NewTuple(data.Map{
+					"features": data.Map{"x": data.String("a")},
+					"label":    data.Int(1),
+				})), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the state is terminated", func() {
+			So(st.Terminate(ctx), ShouldBeNil)
+
+			Convey("Then writing a tuple should fail", func() {
+				So(w.Write(ctx, core.NewTuple(data.Map{
+					"features": data.Array{data.Int(1)},
+					"label":    data.Int(1),
+				})), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When predicting with a missing state", func() {
+			_, err := regressionPredictFunc.Call(ctx, data.String("no_such_state"), data.Array{})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a logistic regression state", t, func() {
+		ctx := core.NewContext(nil)
+		st, err := (&regressionCreator{logistic: true}).CreateState(ctx, data.Map{
+			"learning_rate":  data.Float(0.5),
+			"features_field": data.String("x"),
+			"label_field":    data.String("y"),
+		})
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("m", "logistic_regression", st), ShouldBeNil)
+		w := st.(core.Writer)
+
+		Convey("When training it with separable data", func() {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 2000; i++ {
+				x := r.Float64()*2 - 1
+				So(w.Write(ctx, core.NewTuple(data.Map{
+					"x": data.Array{data.Float(x)},
+					"y": data.Bool(x > 0),
+				})), ShouldBeNil)
+			}
+
+			Convey("Then it should predict probabilities", func() {
+				p, err := regressionPredictFunc.Call(ctx, data.String("m"), data.Array{data.Float(0.8)})
+				So(err, ShouldBeNil)
+				So(p, ShouldBeGreaterThan, data.Float(0.9))
+				p, err = regressionPredictFunc.Call(ctx, data.String("m"), data.Array{data.Float(-0.8)})
+				So(err, ShouldBeNil)
+				So(p, ShouldBeLessThan, data.Float(0.1))
+			})
+		})
+
+		Convey("When writing a label other than 0 or 1", func() {
+			err := w.Write(ctx, core.NewTuple(data.Map{"x": data.Array{data.Int(1)}, "y": data.Int(2)}))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When loading a saved linear regression", func() {
+			b := bytes.NewBufferString(`{"logistic":false,"learning_rate":0.1,"label_field":"label","features_field":"features"}`)
+			err := st.(core.LoadableSharedState).Load(ctx, b, data.Map{})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters", t, func() {
+		for _, params := range []data.Map{
+			{"learning_rate": data.Int(0)},
+			{"regularization": data.Float(-1)},
+			{"label_field": data.Int(1)},
+		} {
+			_, err := (&regressionCreator{}).CreateState(core.NewContext(nil), params)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
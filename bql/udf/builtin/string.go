@@ -105,15 +105,15 @@ var lowerFunc udf.UDF = &singleParamStringFunc{
 	},
 }
 
-// byteLengthFunc computes the number of bytes in a string.
+// byteLengthFunc computes the number of bytes in a string or a blob.
 //
 // It can be used in BQL as `octet_length`.
 //
-//  Input: String
+//  Input: String or Blob
 //  Return Type: Int
-var octetLengthFunc udf.UDF = &singleParamStringFunc{
-	strFun: func(s string) data.Value {
-		return data.Int(len(s))
+var octetLengthFunc udf.UDF = &singleParamBytesFunc{
+	bytesFun: func(b []byte) data.Value {
+		return data.Int(len(b))
 	},
 }
 
@@ -540,47 +540,47 @@ var formatFunc udf.UDF = &variadicFunc{
 	},
 }
 
-// md5Func computes the MD5 checksum of a string in hexadecimal
-// format.
+// md5Func computes the MD5 checksum of a string or a blob in
+// hexadecimal format.
 // See also: crypto/md5.Sum
 //
 // It can be used in BQL as `md5`.
 //
-//  Input: String
+//  Input: String or Blob
 //  Return Type: String
-var md5Func udf.UDF = &singleParamStringFunc{
-	strFun: func(s string) data.Value {
-		sum := md5.Sum([]byte(s))
+var md5Func udf.UDF = &singleParamBytesFunc{
+	bytesFun: func(b []byte) data.Value {
+		sum := md5.Sum(b)
 		return data.String(fmt.Sprintf("%x", sum))
 	},
 }
 
-// sha1Func computes the SHA-1 checksum of a string in hexadecimal
-// format.
+// sha1Func computes the SHA-1 checksum of a string or a blob in
+// hexadecimal format.
 // See also: crypto/sha1.Sum
 //
 // It can be used in BQL as `sha1`.
 //
-//  Input: String
+//  Input: String or Blob
 //  Return Type: String
-var sha1Func udf.UDF = &singleParamStringFunc{
-	strFun: func(s string) data.Value {
-		sum := sha1.Sum([]byte(s))
+var sha1Func udf.UDF = &singleParamBytesFunc{
+	bytesFun: func(b []byte) data.Value {
+		sum := sha1.Sum(b)
 		return data.String(fmt.Sprintf("%x", sum))
 	},
 }
 
-// sha256Func computes the SHA-256 checksum of a string in hexadecimal
-// format.
+// sha256Func computes the SHA-256 checksum of a string or a blob in
+// hexadecimal format.
 // See also: crypto/sha256.Sum
 //
 // It can be used in BQL as `sha256`.
 //
-//  Input: String
+//  Input: String or Blob
 //  Return Type: String
-var sha256Func udf.UDF = &singleParamStringFunc{
-	strFun: func(s string) data.Value {
-		sum := sha256.Sum256([]byte(s))
+var sha256Func udf.UDF = &singleParamBytesFunc{
+	bytesFun: func(b []byte) data.Value {
+		sum := sha256.Sum256(b)
 		return data.String(fmt.Sprintf("%x", sum))
 	},
 }
@@ -47,11 +47,6 @@ func TestUnaryStringFuncs(t *testing.T) {
 			{data.String("José"), data.String("JOSÉ")},
 			{data.String("日本語"), data.String("日本語")},
 		}},
-		{"octet_length", octetLengthFunc, []udfUnaryTestCaseInput{
-			{data.String(""), data.Int(0)},
-			{data.String("jose"), data.Int(4)},
-			{data.String("日本語"), data.Int(9)},
-		}},
 		{"ltrim", ltrimSpaceFunc, []udfUnaryTestCaseInput{
 			{data.String("  trim"), data.String("trim")},
 			{data.String(" \n trim "), data.String("trim ")},
@@ -63,18 +58,6 @@ func TestUnaryStringFuncs(t *testing.T) {
 		{"btrim", btrimSpaceFunc, []udfUnaryTestCaseInput{
 			{data.String(" \t trim \n "), data.String("trim")},
 		}},
-		{"md5", md5Func, []udfUnaryTestCaseInput{
-			{data.String("abc"), data.String("900150983cd24fb0d6963f7d28e17f72")},
-			{data.String("日本語\n"), data.String("2123035863e00ab6633d0f429fd9aefa")},
-		}},
-		{"sha1", sha1Func, []udfUnaryTestCaseInput{
-			{data.String("abc\n"), data.String("03cfd743661f07975fa2f1220c5194cbaff48451")},
-			{data.String("日本語\n"), data.String("eb2ac8ca4ec0f3913058ccd239dd984bda31a821")},
-		}},
-		{"sha256", sha256Func, []udfUnaryTestCaseInput{
-			{data.String("abc\n"), data.String("edeaaff3f1774ad2888673770c6d64097e391bc362d7d6fb34982ddf0efd18cb")},
-			{data.String("日本語\n"), data.String("a43d56ae90ff2daebd847bf06f9c0a7b416f48f89b6e9dbefe7886540c94b550")},
-		}},
 	}
 
 	for _, testCase := range udfUnaryTestCases {
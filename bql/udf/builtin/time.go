@@ -45,12 +45,13 @@ func (f *diffUsFuncTmpl) Call(ctx *core.Context, args ...data.Value) (val data.V
 var diffUsFunc udf.UDF = &diffUsFuncTmpl{}
 
 // clockTimestampFunc returns the local time (in UTC) as a Timestamp.
+// The time is obtained from the Clock of the context.
 // See also: time.Now
 //
 // It can be used in BQL as `clock_timestamp`.
 //
 //  Input: None
 //  Return Type: Timestamp
-var clockTimestampFunc = udf.MustConvertGeneric(func() time.Time {
-	return time.Now().In(time.UTC)
+var clockTimestampFunc = udf.MustConvertGeneric(func(ctx *core.Context) time.Time {
+	return ctx.Clock().Now().In(time.UTC)
 })
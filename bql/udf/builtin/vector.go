@@ -0,0 +1,417 @@
+package builtin
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// toVectorPair converts arguments of a similarity function to vectors
+// having the same dimension. It returns nil vectors when either one of
+// arguments is Null.
+func toVectorPair(a, b data.Value) (data.Vector, data.Vector, error) {
+	if a.Type() == data.TypeNull || b.Type() == data.TypeNull {
+		return nil, nil, nil
+	}
+	x, err := data.ToVector(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := data.ToVector(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(x) != len(y) {
+		return nil, nil, fmt.Errorf("vectors have different dimensions: %v and %v", len(x), len(y))
+	}
+	return x, y, nil
+}
+
+func vectorDot(x, y data.Vector) float64 {
+	s := 0.0
+	for i, e := range x {
+		s += float64(e) * float64(y[i])
+	}
+	return s
+}
+
+func vectorCosineSimilarity(x, y data.Vector) (float64, error) {
+	nx, ny := math.Sqrt(vectorDot(x, x)), math.Sqrt(vectorDot(y, y))
+	if nx == 0 || ny == 0 {
+		return 0, errors.New("cosine similarity isn't defined for a zero vector")
+	}
+	return vectorDot(x, y) / (nx * ny), nil
+}
+
+func vectorL2Distance(x, y data.Vector) float64 {
+	s := 0.0
+	for i, e := range x {
+		d := float64(e) - float64(y[i])
+		s += d * d
+	}
+	return math.Sqrt(s)
+}
+
+// dotFunc computes the dot product of two vectors. Arrays of numbers and
+// Blobs created by data.Vector.MarshalBinary are also accepted as vectors.
+//
+// It can be used in BQL as `dot`.
+//
+//	Input: 2 * Vector, Array, or Blob
+//	Return Type: Float
+var dotFunc = udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+	x, y, err := toVectorPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x == nil {
+		return data.Null{}, nil
+	}
+	return data.Float(vectorDot(x, y)), nil
+})
+
+// cosineSimilarityFunc computes the cosine similarity of two vectors. It
+// fails when either one of the vectors is a zero vector.
+//
+// It can be used in BQL as `cosine_similarity`.
+//
+//	Input: 2 * Vector, Array, or Blob
+//	Return Type: Float
+var cosineSimilarityFunc = udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+	x, y, err := toVectorPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x == nil {
+		return data.Null{}, nil
+	}
+	s, err := vectorCosineSimilarity(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return data.Float(s), nil
+})
+
+// l2DistanceFunc computes the Euclidean distance between two vectors.
+//
+// It can be used in BQL as `l2_distance`.
+//
+//	Input: 2 * Vector, Array, or Blob
+//	Return Type: Float
+var l2DistanceFunc = udf.BinaryFunc(func(ctx *core.Context, a, b data.Value) (data.Value, error) {
+	x, y, err := toVectorPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if x == nil {
+		return data.Null{}, nil
+	}
+	return data.Float(vectorL2Distance(x, y)), nil
+})
+
+// vectorIndexState stores vectors with their IDs and finds the nearest
+// neighbors of a query vector by exhaustive search:
+//
+//	CREATE STATE idx TYPE vector_index WITH metric="cosine", max_size=10000;
+//	CREATE SINK indexer TYPE uds WITH name="idx";
+//	INSERT INTO indexer FROM embeddings;
+//
+// It has following parameters:
+//
+//	dimension: the dimension of vectors (default: the dimension of the
+//	    first vector written to the state)
+//	metric: "cosine", "dot", or "l2" (default: "cosine")
+//	max_size: the maximum number of vectors. The oldest vector is removed
+//	    when a new vector is added to a full index (default: unlimited)
+//	id_field: the path to the ID in a tuple (default: "id")
+//	vector_field: the path to the vector in a tuple (default: "vector")
+//
+// Writing a vector having an existing ID replaces the old vector. Vectors
+// which cannot be used with the metric, such as zero vectors for "cosine",
+// are rejected.
+type vectorIndexState struct {
+	metric     string
+	maxSize    int
+	idPath     data.Path
+	vectorPath data.Path
+
+	m       sync.RWMutex
+	dim     int
+	ids     []data.Value
+	vectors []data.Vector
+	norms   []float64
+	// positions maps the hash value of an ID to the indexes of entries
+	// having the hash value.
+	positions map[data.HashValue][]int
+}
+
+var (
+	_ core.SharedState = &vectorIndexState{}
+	_ core.Writer      = &vectorIndexState{}
+)
+
+func createVectorIndexState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	s := &vectorIndexState{
+		metric:    "cosine",
+		positions: map[data.HashValue][]int{},
+	}
+	if v, ok := params["dimension"]; ok {
+		d, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'dimension' parameter must be an integer: %v", err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("'dimension' parameter must be positive: %v", d)
+		}
+		s.dim = int(d)
+	}
+	if v, ok := params["metric"]; ok {
+		m, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("'metric' parameter must be a string: %v", err)
+		}
+		s.metric = strings.ToLower(m)
+		switch s.metric {
+		case "cosine", "dot", "l2":
+		default:
+			return nil, fmt.Errorf("unsupported metric: %v", m)
+		}
+	}
+	if v, ok := params["max_size"]; ok {
+		n, err := data.AsInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("'max_size' parameter must be an integer: %v", err)
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("'max_size' parameter must be positive: %v", n)
+		}
+		s.maxSize = int(n)
+	}
+
+	paths := []struct {
+		name string
+		def  string
+		p    *data.Path
+	}{
+		{"id_field", "id", &s.idPath},
+		{"vector_field", "vector", &s.vectorPath},
+	}
+	for _, f := range paths {
+		field := f.def
+		if v, ok := params[f.name]; ok {
+			str, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%v' parameter must be a string: %v", f.name, err)
+			}
+			field = str
+		}
+		p, err := data.CompilePath(field)
+		if err != nil {
+			return nil, fmt.Errorf("'%v' parameter doesn't have a valid path: %v", f.name, err)
+		}
+		*f.p = p
+	}
+	return s, nil
+}
+
+// checkVector validates the dimension of a vector. The caller must hold the
+// lock.
+func (s *vectorIndexState) checkVector(vec data.Vector) error {
+	if s.dim != 0 && len(vec) != s.dim {
+		return fmt.Errorf("the vector must have %v dimensions: %v", s.dim, len(vec))
+	}
+	if len(vec) == 0 {
+		return errors.New("the vector must not be empty")
+	}
+	return nil
+}
+
+// find returns the position of the entry having the ID. It returns -1 when
+// the index doesn't have the ID. The caller must hold the lock.
+func (s *vectorIndexState) find(h data.HashValue, id data.Value) int {
+	for _, i := range s.positions[h] {
+		if data.Equal(s.ids[i], id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Write adds the vector of the tuple to the index.
+func (s *vectorIndexState) Write(ctx *core.Context, t *core.Tuple) error {
+	id, err := t.Data.Get(s.idPath)
+	if err != nil {
+		return fmt.Errorf("the tuple doesn't have the ID: %v", err)
+	}
+	v, err := t.Data.Get(s.vectorPath)
+	if err != nil {
+		return fmt.Errorf("the tuple doesn't have the vector: %v", err)
+	}
+	vec, err := data.ToVector(v)
+	if err != nil {
+		return err
+	}
+	norm := math.Sqrt(vectorDot(vec, vec))
+	if s.metric == "cosine" && norm == 0 {
+		return errors.New("a zero vector cannot be added to an index using cosine similarity")
+	}
+	return s.add(id, vec, norm)
+}
+
+func (s *vectorIndexState) add(id data.Value, vec data.Vector, norm float64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if err := s.checkVector(vec); err != nil {
+		return err
+	}
+	if s.dim == 0 {
+		s.dim = len(vec)
+	}
+
+	h := data.Hash(id)
+	if i := s.find(h, id); i >= 0 {
+		s.vectors[i] = vec
+		s.norms[i] = norm
+		return nil
+	}
+	if s.maxSize > 0 && len(s.ids) >= s.maxSize {
+		s.removeOldest()
+	}
+	s.positions[h] = append(s.positions[h], len(s.ids))
+	s.ids = append(s.ids, id)
+	s.vectors = append(s.vectors, vec)
+	s.norms = append(s.norms, norm)
+	return nil
+}
+
+// removeOldest removes the first entry and rebuilds positions. The caller
+// must hold the lock.
+func (s *vectorIndexState) removeOldest() {
+	s.ids = s.ids[1:]
+	s.vectors = s.vectors[1:]
+	s.norms = s.norms[1:]
+	s.positions = make(map[data.HashValue][]int, len(s.ids))
+	for i, id := range s.ids {
+		h := data.Hash(id)
+		s.positions[h] = append(s.positions[h], i)
+	}
+}
+
+// Len returns the number of vectors in the index.
+func (s *vectorIndexState) Len() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return len(s.ids)
+}
+
+type vectorSearchResult struct {
+	id    data.Value
+	score float64
+}
+
+// Search returns at most k nearest neighbors of the query vector. Results
+// are sorted from the nearest one. A score is a similarity for "cosine" and
+// "dot", and a distance for "l2".
+func (s *vectorIndexState) Search(q data.Vector, k int) ([]vectorSearchResult, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("the number of neighbors must be positive: %v", k)
+	}
+	qn := math.Sqrt(vectorDot(q, q))
+	if s.metric == "cosine" && qn == 0 {
+		return nil, errors.New("cosine similarity isn't defined for a zero vector")
+	}
+
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if len(s.ids) == 0 {
+		return nil, nil
+	}
+	if err := s.checkVector(q); err != nil {
+		return nil, err
+	}
+
+	res := make([]vectorSearchResult, len(s.ids))
+	for i, vec := range s.vectors {
+		var score float64
+		switch s.metric {
+		case "cosine":
+			score = vectorDot(q, vec) / (qn * s.norms[i])
+		case "dot":
+			score = vectorDot(q, vec)
+		case "l2":
+			score = vectorL2Distance(q, vec)
+		}
+		res[i] = vectorSearchResult{id: s.ids[i], score: score}
+	}
+	if s.metric == "l2" {
+		sort.SliceStable(res, func(i, j int) bool { return res[i].score < res[j].score })
+	} else {
+		sort.SliceStable(res, func(i, j int) bool { return res[i].score > res[j].score })
+	}
+	if len(res) > k {
+		res = res[:k]
+	}
+	return res, nil
+}
+
+func (s *vectorIndexState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+// vectorNearestFunc finds the k nearest neighbors of a vector in a
+// vector_index state. It returns an array of maps having "id" and "score"
+// sorted from the nearest neighbor. The score is a similarity for "cosine"
+// and "dot" metrics, and a distance for "l2" metric.
+//
+// It can be used in BQL as `vector_nearest`.
+//
+//	Input: String (name of the state), Vector, Array, or Blob, Int (k)
+//	Return Type: Array of Map
+var vectorNearestFunc = udf.TernaryFunc(func(ctx *core.Context, name, vector, k data.Value) (data.Value, error) {
+	n, err := data.AsString(name)
+	if err != nil {
+		return nil, fmt.Errorf("the name of a state must be a string: %v", err)
+	}
+	st, err := ctx.SharedStates.Get(n)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := st.(*vectorIndexState)
+	if !ok {
+		return nil, fmt.Errorf("the state '%v' isn't a vector_index", n)
+	}
+	if vector.Type() == data.TypeNull {
+		return data.Null{}, nil
+	}
+	q, err := data.ToVector(vector)
+	if err != nil {
+		return nil, err
+	}
+	num, err := data.ToInt(k)
+	if err != nil {
+		return nil, fmt.Errorf("the number of neighbors must be an integer: %v", err)
+	}
+
+	rs, err := s.Search(q, int(num))
+	if err != nil {
+		return nil, err
+	}
+	a := make(data.Array, len(rs))
+	for i, r := range rs {
+		a[i] = data.Map{
+			"id":    r.id,
+			"score": data.Float(r.score),
+		}
+	}
+	return a, nil
+})
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("vector_index", udf.UDSCreatorFunc(createVectorIndexState))
+}
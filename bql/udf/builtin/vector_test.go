@@ -0,0 +1,201 @@
+package builtin
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math"
+	"testing"
+)
+
+func TestVectorSimilarityFuncs(t *testing.T) {
+	Convey("Given vector similarity functions", t, func() {
+		x := data.Vector{1, 0, 1}
+		y := data.Array{data.Int(0), data.Int(2), data.Int(2)}
+
+		Convey("When computing the dot product", func() {
+			v, err := dotFunc.Call(nil, x, y)
+
+			Convey("Then it should be correct", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Float(2))
+			})
+		})
+
+		Convey("When computing the cosine similarity", func() {
+			v, err := cosineSimilarityFunc.Call(nil, x, y)
+
+			Convey("Then it should be correct", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldAlmostEqual, data.Float(0.5))
+			})
+
+			Convey("Then it should fail with a zero vector", func() {
+				_, err := cosineSimilarityFunc.Call(nil, x, data.Vector{0, 0, 0})
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When computing the L2 distance", func() {
+			v, err := l2DistanceFunc.Call(nil, x, y)
+
+			Convey("Then it should be correct", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldAlmostEqual, data.Float(math.Sqrt(6)))
+			})
+		})
+
+		Convey("When passing an encoded vector", func() {
+			b, err := x.MarshalBinary()
+			So(err, ShouldBeNil)
+			v, err := dotFunc.Call(nil, data.Blob(b), x)
+
+			Convey("Then it should be decoded", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Float(2))
+			})
+		})
+
+		Convey("When passing null", func() {
+			Convey("Then it should return null", func() {
+				for _, f := range []interface {
+					Call(*core.Context, ...data.Value) (data.Value, error)
+				}{dotFunc, cosineSimilarityFunc, l2DistanceFunc} {
+					v, err := f.Call(nil, data.Null{}, x)
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, data.Null{})
+				}
+			})
+		})
+
+		Convey("When passing vectors having different dimensions", func() {
+			_, err := dotFunc.Call(nil, x, data.Vector{1, 2})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestVectorIndex(t *testing.T) {
+	Convey("Given a vector_index state", t, func() {
+		ctx := core.NewContext(nil)
+		create := func(params data.Map) *vectorIndexState {
+			s, err := createVectorIndexState(ctx, params)
+			So(err, ShouldBeNil)
+			So(ctx.SharedStates.Add("idx", "vector_index", s), ShouldBeNil)
+			return s.(*vectorIndexState)
+		}
+		write := func(s *vectorIndexState, id string, vec data.Value) error {
+			return s.Write(ctx, core.NewTuple(data.Map{"id": data.String(id), "vector": vec}))
+		}
+		nearest := func(q data.Value, k int) (data.Value, error) {
+			return vectorNearestFunc.Call(ctx, data.String("idx"), q, data.Int(k))
+		}
+
+		Convey("When searching with cosine similarity", func() {
+			s := create(data.Map{})
+			So(write(s, "x", data.Vector{1, 0}), ShouldBeNil)
+			So(write(s, "y", data.Vector{0, 1}), ShouldBeNil)
+			So(write(s, "xy", data.Array{data.Int(1), data.Int(1)}), ShouldBeNil)
+			v, err := nearest(data.Vector{2, 1}, 2)
+
+			Convey("Then it should return the most similar vectors", func() {
+				So(err, ShouldBeNil)
+				a, _ := data.AsArray(v)
+				So(a, ShouldHaveLength, 2)
+				So(a[0].(data.Map)["id"], ShouldEqual, data.String("xy"))
+				So(a[1].(data.Map)["id"], ShouldEqual, data.String("x"))
+				So(a[1].(data.Map)["score"], ShouldAlmostEqual, data.Float(2/math.Sqrt(5)))
+			})
+
+			Convey("Then a vector having a different dimension should be rejected", func() {
+				So(write(s, "z", data.Vector{1, 2, 3}), ShouldNotBeNil)
+				_, err := nearest(data.Vector{1, 2, 3}, 1)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then a zero vector should be rejected", func() {
+				So(write(s, "z", data.Vector{0, 0}), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When searching with L2 distance", func() {
+			s := create(data.Map{"metric": data.String("L2")})
+			So(write(s, "a", data.Vector{0, 0}), ShouldBeNil)
+			So(write(s, "b", data.Vector{3, 4}), ShouldBeNil)
+			v, err := nearest(data.Vector{3, 3}, 5)
+
+			Convey("Then it should return vectors from the nearest one", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{
+					data.Map{"id": data.String("b"), "score": data.Float(1)},
+					data.Map{"id": data.String("a"), "score": data.Float(math.Sqrt(18))},
+				})
+			})
+		})
+
+		Convey("When writing a vector having an existing ID", func() {
+			s := create(data.Map{"metric": data.String("dot")})
+			So(write(s, "a", data.Vector{1, 0}), ShouldBeNil)
+			So(write(s, "a", data.Vector{0, 1}), ShouldBeNil)
+
+			Convey("Then it should be replaced", func() {
+				So(s.Len(), ShouldEqual, 1)
+				v, err := nearest(data.Vector{0, 1}, 1)
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{data.Map{"id": data.String("a"), "score": data.Float(1)}})
+			})
+		})
+
+		Convey("When the index is full", func() {
+			s := create(data.Map{"max_size": data.Int(2)})
+			So(write(s, "a", data.Vector{1, 0}), ShouldBeNil)
+			So(write(s, "b", data.Vector{0, 1}), ShouldBeNil)
+			So(write(s, "c", data.Vector{1, 1}), ShouldBeNil)
+
+			Convey("Then the oldest vector should be removed", func() {
+				So(s.Len(), ShouldEqual, 2)
+				So(write(s, "b", data.Vector{1, 2}), ShouldBeNil)
+				So(s.Len(), ShouldEqual, 2)
+				v, err := nearest(data.Vector{1, 0}, 3)
+				So(err, ShouldBeNil)
+				a, _ := data.AsArray(v)
+				So(a, ShouldHaveLength, 2)
+				So(a[0].(data.Map)["id"], ShouldEqual, data.String("c"))
+			})
+		})
+
+		Convey("When searching an empty index", func() {
+			create(data.Map{})
+			v, err := nearest(data.Vector{1, 0}, 1)
+
+			Convey("Then it should return an empty array", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Array{})
+			})
+		})
+
+		Convey("When searching with invalid k", func() {
+			create(data.Map{})
+			_, err := nearest(data.Vector{1, 0}, 0)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid parameters for a vector_index", t, func() {
+		for _, params := range []data.Map{
+			{"dimension": data.Int(0)},
+			{"metric": data.String("manhattan")},
+			{"max_size": data.Int(-1)},
+			{"id_field": data.Int(1)},
+		} {
+			_, err := createVectorIndexState(core.NewContext(nil), params)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
@@ -2,8 +2,10 @@ package udf
 
 import (
 	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -164,6 +166,15 @@ func (fr *defaultFunctionRegistry) Lookup(name string, arity int) (UDF, error) {
 		}
 		return nil, fmt.Errorf("function '%s' is not %d-ary", name, arity)
 	}
+
+	names := make([]string, 0, len(fr.funcs))
+	for n := range fr.funcs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	if s := parser.SuggestName(name, names); s != "" {
+		return nil, core.NotExistError(fmt.Errorf("function '%s' is unknown, did you mean '%s'?", name, s))
+	}
 	return nil, core.NotExistError(fmt.Errorf("function '%s' is unknown", name))
 }
 
@@ -191,6 +202,32 @@ func (fr *defaultFunctionRegistry) Register(name string, f UDF) error {
 	return nil
 }
 
+// Unregister removes a function from the registry. It returns
+// core.NotExistError when the registry doesn't have the function.
+func (fr *defaultFunctionRegistry) Unregister(name string) error {
+	fr.m.Lock()
+	defer fr.m.Unlock()
+	lowerName := strings.ToLower(name)
+	if _, exists := fr.funcs[lowerName]; !exists {
+		return core.NotExistError(fmt.Errorf("function '%s' is unknown", name))
+	}
+	delete(fr.funcs, lowerName)
+	return nil
+}
+
+// List returns all functions registered to the registry. Keys of the map are
+// names of functions in lower case.
+func (fr *defaultFunctionRegistry) List() (map[string]UDF, error) {
+	fr.m.RLock()
+	defer fr.m.RUnlock()
+
+	m := make(map[string]UDF, len(fr.funcs))
+	for n, f := range fr.funcs {
+		m[n] = f
+	}
+	return m, nil
+}
+
 var (
 	// globalUDFRegistry has UDFs visible to all topologies. Do NOT use this
 	// instance in running topologies because it doesn't have an actual
@@ -29,11 +29,37 @@ func TestDefaultFunctionRegistry(t *testing.T) {
 				So(err, ShouldBeNil)
 			})
 
+			Convey("And a misspelled name should be corrected in the error", func() {
+				_, err := fr.Lookup("tset", 1)
+				So(core.IsNotExist(err), ShouldBeTrue)
+				So(err.Error(), ShouldContainSubstring, "did you mean 'test'?")
+			})
+
 			Convey("And it won't be found as binary", func() {
 				_, err := fr.Lookup("test", 2)
 				So(err, ShouldNotBeNil)
 				So(core.IsNotExist(err), ShouldBeFalse) // the function was found but its arity was wrong
 			})
+
+			Convey("And it can be unregistered", func() {
+				u := fr.(interface {
+					Unregister(name string) error
+				})
+				So(u.Unregister("test"), ShouldBeNil)
+				_, err := fr.Lookup("test", 1)
+				So(core.IsNotExist(err), ShouldBeTrue)
+				So(core.IsNotExist(u.Unregister("test")), ShouldBeTrue)
+			})
+
+			Convey("And it can be listed", func() {
+				l := fr.(interface {
+					List() (map[string]UDF, error)
+				})
+				m, err := l.List()
+				So(err, ShouldBeNil)
+				So(m, ShouldContainKey, "test")
+				So(m, ShouldContainKey, "str")
+			})
 		})
 
 		Convey("When adding a variadic function via VariadicFunc", func() {
@@ -14,8 +14,10 @@ import (
 // of the function don't have to be tuple types, but some standard types are
 // allowed. The UDF returned provide a weak type conversion, that is it uses
 // data.To{Type} function to convert values. Therefore, a string may be
-// passed as an integer or vice versa. If the function wants to provide
-// strict type conversion, generate UDF by Func function.
+// passed as an integer or vice versa. When the strict conversion policy is
+// enabled by core.ContextFlags.StrictConversion, data.StrictConversion is
+// used instead. If the function wants to provide its own strict type
+// conversion, generate UDF by Func function.
 //
 // Acceptable types:
 //	- bool
@@ -24,7 +26,7 @@ import (
 //	- string
 //	- time.Time
 //	- data.Bool, data.Int, data.Float, data.String, data.Blob,
-//	  data.Timestamp, data.Array, data.Map, data.Value
+//	  data.Timestamp, data.Array, data.Map, data.Vector, data.Value
 //	- a slice of types above
 func ConvertGeneric(function interface{}) (UDF, error) {
 	t := reflect.TypeOf(function)
@@ -192,19 +194,21 @@ func createGenericConverters(t reflect.Type, argStart int) ([]argumentConverter,
 	return convs, nil
 }
 
-type argumentConverter func(data.Value) (interface{}, error)
+// argumentConverter converts a value passed to a UDF to the type of the
+// corresponding parameter under the given conversion policy.
+type argumentConverter func(data.ConversionPolicy, data.Value) (interface{}, error)
 
 func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 	// TODO: this function is too long.
 	switch t.Kind() {
 	case reflect.Bool:
-		return func(v data.Value) (interface{}, error) {
-			return data.ToBool(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			return p.ToBool(v)
 		}, nil
 
 	case reflect.Int:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -218,8 +222,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Int8:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -233,8 +237,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Int16:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -248,8 +252,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Int32:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -263,13 +267,13 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Int64:
-		return func(v data.Value) (interface{}, error) {
-			return data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			return p.ToInt(v)
 		}, nil
 
 	case reflect.Uint:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -283,8 +287,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Uint8:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -298,8 +302,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Uint16:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -313,8 +317,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Uint32:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -328,8 +332,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Uint64:
-		return func(v data.Value) (interface{}, error) {
-			i, err := data.ToInt(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			i, err := p.ToInt(v)
 			if err != nil {
 				return nil, err
 			}
@@ -341,8 +345,8 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Float32:
-		return func(v data.Value) (interface{}, error) {
-			f, err := data.ToFloat(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			f, err := p.ToFloat(v)
 			if err != nil {
 				return nil, err
 			}
@@ -350,21 +354,31 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		}, nil
 
 	case reflect.Float64:
-		return func(v data.Value) (interface{}, error) {
-			return data.ToFloat(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			return p.ToFloat(v)
 		}, nil
 
 	case reflect.String:
-		return func(v data.Value) (interface{}, error) {
-			return data.ToString(v)
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+			return p.ToString(v)
 		}, nil
 
 	case reflect.Slice:
 		elemType := t.Elem()
 		if elemType.Kind() == reflect.Uint8 {
 			// process this as a blob
-			return func(v data.Value) (interface{}, error) {
-				return data.ToBlob(v)
+			return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+				return p.ToBlob(v)
+			}, nil
+		}
+		if elemType.Kind() == reflect.Float32 {
+			// process this as a vector
+			return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+				vec, err := p.ToVector(v)
+				if err != nil {
+					return nil, err
+				}
+				return reflect.ValueOf(vec).Convert(t).Interface(), nil
 			}, nil
 		}
 
@@ -372,14 +386,14 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 		if err != nil {
 			return nil, err
 		}
-		return func(v data.Value) (interface{}, error) {
+		return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
 			a, err := data.AsArray(v)
 			if err != nil {
 				return nil, err
 			}
 			res := reflect.Zero(t)
 			for _, elem := range a {
-				e, err := c(elem)
+				e, err := c(p, elem)
 				if err != nil {
 					return nil, err
 				}
@@ -391,19 +405,19 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 	default:
 		switch reflect.Zero(t).Interface().(type) {
 		case data.Map:
-			return func(v data.Value) (interface{}, error) {
+			return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
 				return data.AsMap(v)
 			}, nil
 
 		case time.Time:
-			return func(v data.Value) (interface{}, error) {
-				return data.ToTimestamp(v)
+			return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
+				return p.ToTimestamp(v)
 			}, nil
 
 		default:
 			if t.Implements(reflect.TypeOf(data.NewValue).Out(0)) { // data.Value
 				// Zero(interface) returns nil and type assertion doesn't work for it.
-				return func(v data.Value) (interface{}, error) {
+				return func(p data.ConversionPolicy, v data.Value) (interface{}, error) {
 					return v, nil
 				}, nil
 			}
@@ -413,6 +427,15 @@ func genericFuncArgumentConverter(t reflect.Type) (argumentConverter, error) {
 	}
 }
 
+// conversionPolicy returns the conversion policy applied to arguments of
+// UDFs called with the given context.
+func conversionPolicy(ctx *core.Context) data.ConversionPolicy {
+	if ctx != nil && ctx.Flags.StrictConversion.Enabled() {
+		return data.StrictConversion
+	}
+	return data.PermissiveConversion
+}
+
 type genericFunc struct {
 	function reflect.Value
 
@@ -466,20 +489,21 @@ func (g *genericFunc) call(ctx *core.Context, args ...data.Value) ([]reflect.Val
 		in = append(in, reflect.ValueOf(ctx))
 	}
 
+	policy := conversionPolicy(ctx)
 	variadicBegin := g.arity
 	if g.variadic {
 		variadicBegin--
 	}
 
 	for i := 0; i < variadicBegin; i++ {
-		v, err := g.converters[i](args[i])
+		v, err := g.converters[i](policy, args[i])
 		if err != nil {
 			return nil, err
 		}
 		in = append(in, reflect.ValueOf(v))
 	}
 	for i := variadicBegin; i < len(args); i++ {
-		v, err := g.converters[len(g.converters)-1](args[i])
+		v, err := g.converters[len(g.converters)-1](policy, args[i])
 		if err != nil {
 			return nil, err
 		}
@@ -745,6 +745,59 @@ func TestGenericIntAndFloatFunc(t *testing.T) {
 	})
 }
 
+func TestGenericFuncStrictConversion(t *testing.T) {
+	Convey("Given a function receiving int with the strict conversion policy", t, func() {
+		ctx := &core.Context{}
+		ctx.Flags.StrictConversion.Set(true)
+		f := MustConvertGeneric(func(i int, fs ...float64) int {
+			return i * 2
+		})
+
+		Convey("When passing values which can be converted without loss", func() {
+			v, err := f.Call(ctx, data.Float(2), data.Int(1))
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(4))
+			})
+		})
+
+		Convey("When passing a float having a fractional part", func() {
+			_, err := f.Call(ctx, data.Float(2.7))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When passing a string", func() {
+			_, err := f.Call(ctx, data.String("1"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When passing a string as a variadic argument", func() {
+			_, err := f.Call(ctx, data.Int(1), data.String("1.5"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the policy is switched back to the permissive one", func() {
+			ctx.Flags.StrictConversion.Set(false)
+			v, err := f.Call(ctx, data.Float(2.7))
+
+			Convey("Then the float should be truncated", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(4))
+			})
+		})
+	})
+}
+
 func TestGenericBoolFunc(t *testing.T) {
 	ctx := &core.Context{} // not used in this test
 
@@ -789,6 +842,38 @@ func TestGenericBlobFunc(t *testing.T) {
 	})
 }
 
+func TestGenericVectorFunc(t *testing.T) {
+	ctx := &core.Context{} // not used in this test
+
+	Convey("Given a function receiving a vector", t, func() {
+		f, err := ConvertGeneric(func(v []float32) []float32 {
+			for i := range v {
+				v[i] *= 2
+			}
+			return v
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When passing an array", func() {
+			v, err := f.Call(ctx, data.Array{data.Int(1), data.Float(1.5)})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return a vector", func() {
+				So(v, ShouldResemble, data.Vector{2, 3})
+			})
+		})
+
+		Convey("When passing a vector", func() {
+			v, err := f.Call(ctx, data.Vector{1, 2})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should return a vector", func() {
+				So(v, ShouldResemble, data.Vector{2, 4})
+			})
+		})
+	})
+}
+
 func TestGenericTimeFunc(t *testing.T) {
 	ctx := &core.Context{} // not used in this test
 
@@ -156,8 +156,14 @@ func MustRegisterGlobalUDSCreator(typeName string, c UDSCreator) {
 // CopyGlobalUDSCreatorRegistry creates a new independent copy of the global
 // UDSCreatorRegistry.
 func CopyGlobalUDSCreatorRegistry() (UDSCreatorRegistry, error) {
+	return CopyUDSCreatorRegistry(globalUDSCreatorRegistry)
+}
+
+// CopyUDSCreatorRegistry creates a new independent copy of the given
+// UDSCreatorRegistry.
+func CopyUDSCreatorRegistry(src UDSCreatorRegistry) (UDSCreatorRegistry, error) {
 	r := NewDefaultUDSCreatorRegistry()
-	m, err := globalUDSCreatorRegistry.List()
+	m, err := src.List()
 	if err != nil {
 		return nil, err
 	}
@@ -139,20 +139,21 @@ func (g *genericUDSFCreator) CreateUDSF(ctx *core.Context, decl UDSFDeclarer, ar
 	}
 	in = append(in, reflect.ValueOf(decl))
 
+	policy := conversionPolicy(ctx)
 	variadicBegin := g.arity
 	if g.variadic {
 		variadicBegin--
 	}
 
 	for i := 0; i < variadicBegin; i++ {
-		v, err := g.converters[i](args[i])
+		v, err := g.converters[i](policy, args[i])
 		if err != nil {
 			return nil, err
 		}
 		in = append(in, reflect.ValueOf(v))
 	}
 	for i := variadicBegin; i < len(args); i++ {
-		v, err := g.converters[len(g.converters)-1](args[i])
+		v, err := g.converters[len(g.converters)-1](policy, args[i])
 		if err != nil {
 			return nil, err
 		}
@@ -116,8 +116,14 @@ func MustRegisterGlobalUDSFCreator(typeName string, c UDSFCreator) {
 // CopyGlobalUDSFCreatorRegistry creates a new independent copy of the global
 // UDSFCreatorRegistry.
 func CopyGlobalUDSFCreatorRegistry() (UDSFCreatorRegistry, error) {
+	return CopyUDSFCreatorRegistry(globalUDSFCreatorRegistry)
+}
+
+// CopyUDSFCreatorRegistry creates a new independent copy of the given
+// UDSFCreatorRegistry.
+func CopyUDSFCreatorRegistry(src UDSFCreatorRegistry) (UDSFCreatorRegistry, error) {
 	r := NewDefaultUDSFCreatorRegistry()
-	m, err := globalUDSFCreatorRegistry.List()
+	m, err := src.List()
 	if err != nil {
 		return nil, err
 	}
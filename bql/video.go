@@ -0,0 +1,476 @@
+package bql
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/video"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"image/jpeg"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultVideoQuality = 90
+
+	// defaultVideoPollInterval is the interval of polling HTTP endpoints
+	// serving a single image when fps isn't specified.
+	defaultVideoPollInterval = time.Second
+)
+
+// videoFrame is a JPEG frame. width and height are 0 when the stream doesn't
+// know them.
+type videoFrame struct {
+	data   []byte
+	width  int
+	height int
+}
+
+// videoStream is a stream of frames.
+type videoStream interface {
+	// ReadFrame returns the next frame. Closing the stream aborts it.
+	ReadFrame() (*videoFrame, error)
+	io.Closer
+}
+
+// jpegVideoStream reads JPEG images concatenated in a stream such as Motion
+// JPEG over HTTP.
+type jpegVideoStream struct {
+	r io.ReadCloser
+	j *video.Reader
+}
+
+func newJPEGVideoStream(r io.ReadCloser) *jpegVideoStream {
+	return &jpegVideoStream{
+		r: r,
+		j: video.NewReader(r),
+	}
+}
+
+func (s *jpegVideoStream) ReadFrame() (*videoFrame, error) {
+	b, err := s.j.Next()
+	if err != nil {
+		return nil, err
+	}
+	return &videoFrame{data: b}, nil
+}
+
+func (s *jpegVideoStream) Close() error {
+	return s.r.Close()
+}
+
+// httpImageStream is a response of an HTTP endpoint serving a single JPEG
+// image, such as a snapshot of a camera. It returns io.EOF after the image,
+// and videoSource polls the endpoint again for the next frame.
+type httpImageStream struct {
+	*jpegVideoStream
+}
+
+// openHTTPVideoStream requests a Motion JPEG stream, which is a multipart
+// response having a JPEG image in each part. A response whose content type
+// is an image is returned as httpImageStream.
+func openHTTPVideoStream(cli *http.Client, u string) (videoStream, error) {
+	res, err := cli.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("the server returned %v", res.Status)
+	}
+	if t, _, err := mime.ParseMediaType(res.Header.Get("Content-Type")); err == nil && strings.HasPrefix(t, "image/") {
+		return &httpImageStream{newJPEGVideoStream(res.Body)}, nil
+	}
+	return newJPEGVideoStream(res.Body), nil
+}
+
+// ffmpegVideoStream reads frames decoded by an ffmpeg process.
+type ffmpegVideoStream struct {
+	*jpegVideoStream
+	p *ffmpegProcess
+}
+
+// ffmpegVideoArgs returns arguments of ffmpeg decoding the stream at u into
+// JPEG images written to the standard output.
+func ffmpegVideoArgs(u, transport string, width, height int, fps float64) []string {
+	var args []string
+	if transport != "" {
+		args = append(args, "-rtsp_transport", transport)
+	}
+	args = append(args, "-i", u, "-an")
+	var filters []string
+	if fps > 0 {
+		filters = append(filters, "fps="+strconv.FormatFloat(fps, 'g', -1, 64))
+	}
+	if width > 0 && height > 0 {
+		filters = append(filters, fmt.Sprintf("scale=%v:%v", width, height))
+	}
+	if len(filters) > 0 {
+		args = append(args, "-vf", strings.Join(filters, ","))
+	}
+	return append(args, "-f", "image2pipe", "-c:v", "mjpeg", "-q:v", "2", "-")
+}
+
+// openFFmpegVideoStream starts ffmpeg decoding the stream.
+func openFFmpegVideoStream(path string, args []string) (videoStream, error) {
+	p, err := startFFmpeg(path, args)
+	if err != nil {
+		return nil, err
+	}
+	return &ffmpegVideoStream{
+		jpegVideoStream: newJPEGVideoStream(p.stdout),
+		p:               p,
+	}, nil
+}
+
+func (s *ffmpegVideoStream) ReadFrame() (*videoFrame, error) {
+	f, err := s.jpegVideoStream.ReadFrame()
+	if err != nil {
+		return nil, s.p.exitError(err)
+	}
+	return f, nil
+}
+
+func (s *ffmpegVideoStream) Close() error {
+	return s.p.Close()
+}
+
+// videoRateLimiter drops frames arriving faster than a frame rate.
+type videoRateLimiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// allow returns true when a frame arriving at t should be emitted.
+func (l *videoRateLimiter) allow(t time.Time) bool {
+	if l.interval <= 0 {
+		return true
+	}
+	if t.Before(l.next) {
+		return false
+	}
+	// The next frame is expected at the next interval rather than at
+	// t+interval, so that jitters of frames don't lower the frame rate.
+	// When frames have been delayed more than an interval, it restarts
+	// from t.
+	l.next = l.next.Add(l.interval)
+	if l.next.Before(t) {
+		l.next = t.Add(l.interval)
+	}
+	return true
+}
+
+// videoSource captures frames from a video device, an HTTP Motion JPEG
+// stream, or an RTSP stream, and emits a tuple for each frame. A tuple has
+// following fields:
+//
+//	frame: the frame encoded in JPEG
+//	format: "jpeg"
+//	width: the width of the frame in pixels
+//	height: the height of the frame in pixels
+//	sequence: the number of frames emitted before the frame
+//	captured_at: the time when the frame is captured
+//
+// The timestamp of a tuple is the time when the frame is captured. Frames
+// arriving faster than fps are dropped. HTTP endpoints serving a single image
+// rather than a Motion JPEG stream are polled at fps, or every second when
+// fps isn't specified.
+//
+// When the stream fails or ends, except after a single image has been
+// polled, GenerateStream returns an error and the stream is reopened by the
+// supervisor, which can be configured by reconnect_ parameters of CREATE
+// SOURCE. The sequence number and the frame
+// rate are kept across reopens.
+type videoSource struct {
+	name     string
+	open     func() (videoStream, error)
+	fps      float64
+	ioParams *IOParams
+	stopCh   chan struct{}
+
+	// limiter and seq are only used by GenerateStream, which isn't called
+	// concurrently.
+	limiter videoRateLimiter
+	seq     int64
+
+	m       sync.Mutex
+	stopped bool
+
+	// conn is the stream being read. It's closed by Stop to abort reading.
+	conn videoStream
+}
+
+func (s *videoSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	for {
+		start := ctx.Clock().Now()
+		var writeErr error
+		conn, err := s.start()
+		if err == nil {
+			writeErr, err = s.read(ctx, w, conn)
+		}
+		s.close()
+		if writeErr != nil {
+			return writeErr
+		}
+
+		select {
+		case <-s.stopCh:
+			return nil
+		default:
+		}
+		if _, ok := conn.(*httpImageStream); !ok || err != io.EOF {
+			return fmt.Errorf("cannot capture video frames from %v: %v", s.name, err)
+		}
+		if !s.waitForPoll(ctx, start) {
+			return nil
+		}
+	}
+}
+
+// waitForPoll waits until the next frame of an HTTP endpoint serving a single
+// image should be requested after the last request made at start. It returns
+// false when the source is stopped while waiting.
+func (s *videoSource) waitForPoll(ctx *core.Context, start time.Time) bool {
+	interval := s.limiter.interval
+	if interval <= 0 {
+		interval = defaultVideoPollInterval
+	}
+	next := start.Add(interval)
+	if next.Before(s.limiter.next) {
+		// Waiting for the limiter prevents the next frame from being
+		// dropped when it's returned sooner than the last one.
+		next = s.limiter.next
+	}
+	t := time.NewTimer(next.Sub(ctx.Clock().Now()))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-s.stopCh:
+		return false
+	}
+}
+
+func (s *videoSource) start() (videoStream, error) {
+	conn, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		conn.Close()
+		return nil, errors.New("the source has already been stopped")
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+// read emits frames until reading the stream fails. It returns an error from
+// w as writeErr and an error from the stream as readErr.
+func (s *videoSource) read(ctx *core.Context, w core.Writer, conn videoStream) (writeErr, readErr error) {
+	for {
+		f, err := conn.ReadFrame()
+		if err != nil {
+			return nil, err
+		}
+		now := ctx.Clock().Now()
+		if !s.limiter.allow(now) {
+			continue
+		}
+		if f.width == 0 || f.height == 0 {
+			c, err := jpeg.DecodeConfig(bytes.NewReader(f.data))
+			if err != nil {
+				ctx.ErrLog(err).WithField("node_name", s.ioParams.Name).
+					WithField("stream", s.name).
+					WithField("size", len(f.data)).
+					Warning("Ignoring a malformed frame")
+				continue
+			}
+			f.width, f.height = c.Width, c.Height
+		}
+
+		t := core.NewTuple(data.Map{
+			"frame":       data.Blob(f.data),
+			"format":      data.String("jpeg"),
+			"width":       data.Int(f.width),
+			"height":      data.Int(f.height),
+			"sequence":    data.Int(s.seq),
+			"captured_at": data.Timestamp(now),
+		})
+		t.Timestamp = now
+		t.ProcTimestamp = now
+		if err := w.Write(ctx, t); err != nil {
+			return err, nil
+		}
+		s.seq++
+	}
+}
+
+func (s *videoSource) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *videoSource) Stop(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	close(s.stopCh)
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return nil
+}
+
+func (s *videoSource) reconnectsBySupervisor() {}
+
+// createVideoSource creates a source capturing frames from a camera. It
+// accepts following parameters:
+//
+//	device: the path of a V4L2 device such as "/dev/video0", which can have
+//	    wildcards
+//	url: the URL of an HTTP Motion JPEG stream, an HTTP endpoint serving a
+//	    single JPEG image, or an RTSP stream
+//	fps: the maximum number of frames emitted per second (default: all
+//	    frames)
+//	width, height: the size of frames requested to the device or to which
+//	    RTSP streams are scaled
+//	quality: the quality in [1, 100] of JPEG encoding frames of devices not
+//	    supporting Motion JPEG (default: 90)
+//	ffmpeg: the path of ffmpeg decoding RTSP streams (default: "ffmpeg")
+//	rtsp_transport: "tcp" or "udp" (default: "tcp")
+//	tls: TLS parameters of HTTPS streams
+//
+// Either device or url is required. Devices are only supported on Linux and
+// must support Motion JPEG or YUYV. RTSP streams are decoded by ffmpeg,
+// which must be installed. User names and passwords can be given in URLs.
+func createVideoSource(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Source, error) {
+	s := &videoSource{
+		ioParams: ioParams,
+		stopCh:   make(chan struct{}),
+	}
+
+	if v, ok := params["fps"]; ok {
+		f, err := data.ToFloat(v)
+		if err != nil || f <= 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("'fps' parameter must be a positive number: %v", v)
+		}
+		s.fps = f
+		s.limiter.interval = time.Duration(float64(time.Second) / f)
+	}
+
+	var width, height int
+	for key, dst := range map[string]*int{"width": &width, "height": &height} {
+		if v, ok := params[key]; ok {
+			n, err := data.AsInt(v)
+			if err != nil || n <= 0 || n > math.MaxInt32 {
+				return nil, fmt.Errorf("'%v' parameter must be a positive integer: %v", key, v)
+			}
+			*dst = int(n)
+		}
+	}
+	if (width == 0) != (height == 0) {
+		return nil, errors.New("'width' and 'height' parameters must be specified at once")
+	}
+
+	quality := defaultVideoQuality
+	if v, ok := params["quality"]; ok {
+		q, err := data.AsInt(v)
+		if err != nil || q < 1 || q > 100 {
+			return nil, fmt.Errorf("'quality' parameter must be an integer in [1, 100]: %v", v)
+		}
+		quality = int(q)
+	}
+
+	dv, hasDevice := params["device"]
+	uv, hasURL := params["url"]
+	switch {
+	case hasDevice && hasURL:
+		return nil, errors.New("'device' and 'url' parameters cannot be specified at once")
+	case hasDevice:
+		d, err := extractSerialDeviceParameter(data.Map{"device": dv})
+		if err != nil {
+			return nil, err
+		}
+		s.name = d
+		fps := s.fps
+		s.open = func() (videoStream, error) {
+			p, err := resolveSerialDevice(d)
+			if err != nil {
+				return nil, err
+			}
+			return openVideoDevice(p, width, height, fps, quality)
+		}
+
+	case hasURL:
+		str, err := data.AsString(uv)
+		if err != nil {
+			return nil, fmt.Errorf("'url' parameter must be a string: %v", err)
+		}
+		u, err := url.Parse(str)
+		if err != nil {
+			return nil, fmt.Errorf("'url' parameter must be a URL: %v", err)
+		}
+		s.name = u.Redacted()
+
+		switch strings.ToLower(u.Scheme) {
+		case "http", "https":
+			if width != 0 {
+				return nil, errors.New("'width' and 'height' parameters cannot be specified for HTTP streams")
+			}
+			transport := &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+			}
+			if tc, err := LookupTLSConfig(params); err != nil {
+				return nil, err
+			} else if tc != nil {
+				if transport.TLSClientConfig, err = tc.ClientConfig(); err != nil {
+					return nil, err
+				}
+			}
+			cli := &http.Client{Transport: transport}
+			s.open = func() (videoStream, error) {
+				return openHTTPVideoStream(cli, str)
+			}
+
+		case "rtsp", "rtsps":
+			path, transport, err := extractFFmpegParameters(params)
+			if err != nil {
+				return nil, err
+			}
+			args := ffmpegVideoArgs(str, transport, width, height, s.fps)
+			s.open = func() (videoStream, error) {
+				return openFFmpegVideoStream(path, args)
+			}
+
+		default:
+			return nil, fmt.Errorf("'url' parameter must have http, https, rtsp, or rtsps scheme: %v", s.name)
+		}
+
+	default:
+		return nil, errors.New("'device' or 'url' parameter is required")
+	}
+	return s, nil
+}
+
+func init() {
+	MustRegisterGlobalSourceCreator("video", SourceCreatorFunc(createVideoSource))
+}
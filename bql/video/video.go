@@ -0,0 +1,222 @@
+// Package video reads JPEG frames from byte streams such as Motion JPEG over
+// HTTP and outputs of video decoders, and encodes raw frames captured by
+// cameras into JPEG.
+package video
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+)
+
+// DefaultMaxFrameSize is the default maximum size of a frame read by Reader.
+const DefaultMaxFrameSize = 32 * 1024 * 1024
+
+// JPEG markers.
+const (
+	markerSOI = 0xd8
+	markerEOI = 0xd9
+	markerSOS = 0xda
+	markerTEM = 0x01
+	markerRST = 0xd0 // RST0 to RST7 are 0xd0 to 0xd7.
+)
+
+// Reader reads JPEG images concatenated in a stream. Bytes between images,
+// such as headers of multipart messages, are skipped.
+type Reader struct {
+	r *bufio.Reader
+
+	// MaxFrameSize is the maximum size of a frame. Next returns an error
+	// when a frame is larger than it.
+	MaxFrameSize int
+
+	// Skipped is the number of bytes skipped before the last frame.
+	Skipped int
+}
+
+// NewReader creates a Reader reading r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r:            bufio.NewReaderSize(r, 64*1024),
+		MaxFrameSize: DefaultMaxFrameSize,
+	}
+}
+
+// Next returns the next JPEG image. It returns io.EOF when the stream ends
+// before the start of an image, and io.ErrUnexpectedEOF when the stream ends
+// in the middle of an image. The returned slice isn't reused by Reader.
+//
+// The end of an image is found by parsing markers rather than searching for
+// the end of image marker, so that images having thumbnails in their
+// metadata are read correctly.
+func (r *Reader) Next() ([]byte, error) {
+	if err := r.skipToSOI(); err != nil {
+		return nil, err
+	}
+	buf := []byte{0xff, markerSOI}
+
+	m, err := r.readMarker(&buf)
+	for err == nil {
+		switch {
+		case m == markerEOI:
+			return buf, nil
+		case m == markerTEM || (m >= markerRST && m <= markerRST+7):
+			m, err = r.readMarker(&buf)
+		case m == markerSOS:
+			if err = r.readSegment(&buf); err == nil {
+				m, err = r.readEntropyCodedData(&buf)
+			}
+		default:
+			if err = r.readSegment(&buf); err == nil {
+				m, err = r.readMarker(&buf)
+			}
+		}
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return nil, err
+}
+
+// skipToSOI skips bytes until the start of image marker.
+func (r *Reader) skipToSOI() error {
+	r.Skipped = 0
+	prevFF := false
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if prevFF && b == markerSOI {
+			r.Skipped--
+			return nil
+		}
+		prevFF = b == 0xff
+		r.Skipped++
+	}
+}
+
+func (r *Reader) append(buf *[]byte, b ...byte) error {
+	if len(*buf)+len(b) > r.MaxFrameSize {
+		return fmt.Errorf("the frame is larger than %v bytes", r.MaxFrameSize)
+	}
+	*buf = append(*buf, b...)
+	return nil
+}
+
+// readMarker reads a marker and returns its code.
+func (r *Reader) readMarker(buf *[]byte) (byte, error) {
+	b, err := r.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b != 0xff {
+		return 0, fmt.Errorf("a marker is expected but got %#02x", b)
+	}
+	if b, err = r.readFill(buf); err != nil {
+		return 0, err
+	}
+	if b == 0 {
+		return 0, errors.New("a marker must not be 0x00")
+	}
+	return b, r.append(buf, b)
+}
+
+// readFill reads 0xff, which has been read, and following fill bytes of 0xff.
+// It returns the byte following them.
+func (r *Reader) readFill(buf *[]byte) (byte, error) {
+	b := byte(0xff)
+	for b == 0xff {
+		if err := r.append(buf, b); err != nil {
+			return 0, err
+		}
+		var err error
+		if b, err = r.r.ReadByte(); err != nil {
+			return 0, err
+		}
+	}
+	return b, nil
+}
+
+// readSegment reads the length and the parameters of a marker segment.
+func (r *Reader) readSegment(buf *[]byte) error {
+	var l [2]byte
+	if _, err := io.ReadFull(r.r, l[:]); err != nil {
+		return err
+	}
+	n := int(l[0])<<8 | int(l[1])
+	if n < 2 {
+		return fmt.Errorf("a marker segment has an invalid length: %v", n)
+	}
+	if err := r.append(buf, l[:]...); err != nil {
+		return err
+	}
+	start := len(*buf)
+	if err := r.append(buf, make([]byte, n-2)...); err != nil {
+		return err
+	}
+	_, err := io.ReadFull(r.r, (*buf)[start:])
+	return err
+}
+
+// readEntropyCodedData reads data following a start of scan segment and
+// returns the code of the marker ending it. 0xff in the data is followed by
+// 0x00 or a restart marker.
+func (r *Reader) readEntropyCodedData(buf *[]byte) (byte, error) {
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != 0xff {
+			if err := r.append(buf, b); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if b, err = r.readFill(buf); err != nil {
+			return 0, err
+		}
+		if err := r.append(buf, b); err != nil {
+			return 0, err
+		}
+		if b == 0 || (b >= markerRST && b <= markerRST+7) {
+			continue
+		}
+		return b, nil
+	}
+}
+
+// EncodeYUYV encodes a frame in the YUYV (YUV 4:2:2) format into JPEG. Each
+// pair of pixels has 4 bytes: Y0, U, Y1, and V. stride is the number of
+// bytes in a row, which is at least 2*width.
+func EncodeYUYV(w io.Writer, b []byte, width, height, stride int, o *jpeg.Options) error {
+	if width <= 0 || height <= 0 || width%2 != 0 {
+		return fmt.Errorf("invalid dimensions of a YUYV frame: %vx%v", width, height)
+	}
+	if stride < 2*width {
+		return fmt.Errorf("the stride of a YUYV frame is too short: %v", stride)
+	}
+	if len(b) < stride*(height-1)+2*width {
+		return fmt.Errorf("a %vx%v YUYV frame is truncated to %v bytes", width, height, len(b))
+	}
+
+	img := image.NewYCbCr(image.Rect(0, 0, width, height), image.YCbCrSubsampleRatio422)
+	for y := 0; y < height; y++ {
+		row := b[y*stride : y*stride+2*width]
+		yy := img.Y[y*img.YStride:]
+		cb := img.Cb[y*img.CStride:]
+		cr := img.Cr[y*img.CStride:]
+		for x := 0; x < width/2; x++ {
+			p := row[4*x : 4*x+4]
+			yy[2*x] = p[0]
+			cb[x] = p[1]
+			yy[2*x+1] = p[2]
+			cr[x] = p[3]
+		}
+	}
+	return jpeg.Encode(w, img, o)
+}
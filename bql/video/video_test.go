@@ -0,0 +1,148 @@
+package video
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+)
+
+func encodeJPEG(w, h int) []byte {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = byte(i)
+	}
+	buf := &bytes.Buffer{}
+	if err := jpeg.Encode(buf, img, nil); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReader(t *testing.T) {
+	Convey("Given a stream of JPEG images", t, func() {
+		img1 := encodeJPEG(16, 8)
+		img2 := encodeJPEG(8, 16)
+
+		// img3 has a thumbnail in APP1 and restart markers, fill bytes,
+		// and stuffed bytes in its entropy-coded data.
+		thumb := encodeJPEG(4, 4)
+		img3 := []byte{0xff, 0xd8, 0xff, 0xe1, byte((len(thumb) + 2) >> 8), byte(len(thumb) + 2)}
+		img3 = append(img3, thumb...)
+		img3 = append(img3,
+			0xff, 0xda, 0x00, 0x04, 0x01, 0x02,
+			0x10, 0xff, 0x00, 0x20, 0xff, 0xd0, 0x30, 0xff, 0xff, 0xd1, 0x40,
+			0xff, 0xd9)
+
+		s := []byte("--boundary\r\nContent-Type: image/jpeg\r\n\r\n")
+		s = append(s, img1...)
+		s = append(s, "\r\n--boundary\r\n\r\n"...)
+		s = append(s, img2...)
+		s = append(s, img3...)
+		s = append(s, "\r\n--boundary--\r\n"...)
+
+		Convey("When reading images", func() {
+			r := NewReader(bytes.NewReader(s))
+
+			Convey("Then each image should be returned", func() {
+				b, err := r.Next()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, img1)
+				So(r.Skipped, ShouldEqual, 40)
+
+				b, err = r.Next()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, img2)
+				So(r.Skipped, ShouldEqual, 16)
+				c, err := jpeg.DecodeConfig(bytes.NewReader(b))
+				So(err, ShouldBeNil)
+				So(c.Width, ShouldEqual, 8)
+				So(c.Height, ShouldEqual, 16)
+
+				b, err = r.Next()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, img3)
+				So(r.Skipped, ShouldEqual, 0)
+
+				_, err = r.Next()
+				So(err, ShouldEqual, io.EOF)
+			})
+		})
+
+		Convey("When reading a truncated image", func() {
+			r := NewReader(bytes.NewReader(img1[:len(img1)-10]))
+			_, err := r.Next()
+
+			Convey("Then it should fail with an unexpected EOF", func() {
+				So(err, ShouldEqual, io.ErrUnexpectedEOF)
+			})
+		})
+
+		Convey("When reading an image larger than the maximum size", func() {
+			r := NewReader(bytes.NewReader(img1))
+			r.MaxFrameSize = len(img1) - 1
+			_, err := r.Next()
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err, ShouldNotEqual, io.ErrUnexpectedEOF)
+			})
+		})
+
+		Convey("When reading a broken image", func() {
+			r := NewReader(bytes.NewReader([]byte{0xff, 0xd8, 0x12, 0x34}))
+			_, err := r.Next()
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestEncodeYUYV(t *testing.T) {
+	Convey("Given a YUYV frame", t, func() {
+		// A 4x2 frame having red pixels in the left half and blue pixels in
+		// the right half. Each row has 4 bytes of padding.
+		ycbcr := func(r, g, b uint8) color.YCbCr {
+			y, cb, cr := color.RGBToYCbCr(r, g, b)
+			return color.YCbCr{Y: y, Cb: cb, Cr: cr}
+		}
+		var b []byte
+		for y := 0; y < 2; y++ {
+			for _, c := range []color.YCbCr{ycbcr(255, 0, 0), ycbcr(0, 0, 255)} {
+				b = append(b, c.Y, c.Cb, c.Y, c.Cr)
+			}
+			b = append(b, 0, 0, 0, 0)
+		}
+
+		Convey("When encoding it", func() {
+			buf := &bytes.Buffer{}
+			So(EncodeYUYV(buf, b, 4, 2, 12, &jpeg.Options{Quality: 100}), ShouldBeNil)
+
+			Convey("Then it should be decoded as the same image", func() {
+				img, err := jpeg.Decode(buf)
+				So(err, ShouldBeNil)
+				So(img.Bounds(), ShouldResemble, image.Rect(0, 0, 4, 2))
+				r, _, bl, _ := img.At(0, 1).RGBA()
+				So(r>>8, ShouldBeGreaterThan, 200)
+				So(bl>>8, ShouldBeLessThan, 60)
+				r, _, bl, _ = img.At(3, 0).RGBA()
+				So(r>>8, ShouldBeLessThan, 60)
+				So(bl>>8, ShouldBeGreaterThan, 200)
+			})
+		})
+
+		Convey("When encoding it with invalid dimensions", func() {
+			Convey("Then it should fail", func() {
+				So(EncodeYUYV(io.Discard, b, 3, 2, 12, nil), ShouldNotBeNil)
+				So(EncodeYUYV(io.Discard, b, 4, 2, 6, nil), ShouldNotBeNil)
+				So(EncodeYUYV(io.Discard, b, 4, 3, 12, nil), ShouldNotBeNil)
+				So(EncodeYUYV(io.Discard, b, 0, 2, 12, nil), ShouldNotBeNil)
+			})
+		})
+	})
+}
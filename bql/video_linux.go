@@ -0,0 +1,333 @@
+//go:build linux
+// +build linux
+
+package bql
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/video"
+	"image/jpeg"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Constants of V4L2 used to capture frames.
+const (
+	v4l2BufTypeVideoCapture = 1
+	v4l2MemoryMMap          = 1
+	v4l2FieldAny            = 0
+	v4l2CapVideoCapture     = 0x00000001
+	v4l2CapStreaming        = 0x04000000
+	v4l2CapDeviceCaps       = 0x80000000
+	v4l2NumBuffers          = 4
+)
+
+var (
+	v4l2PixFmtMJPEG = fourCC("MJPG")
+	v4l2PixFmtJPEG  = fourCC("JPEG")
+	v4l2PixFmtYUYV  = fourCC("YUYV")
+)
+
+func fourCC(s string) uint32 {
+	return uint32(s[0]) | uint32(s[1])<<8 | uint32(s[2])<<16 | uint32(s[3])<<24
+}
+
+// struct v4l2_capability
+type v4l2Capability struct {
+	driver       [16]byte
+	card         [32]byte
+	busInfo      [32]byte
+	version      uint32
+	capabilities uint32
+	deviceCaps   uint32
+	reserved     [3]uint32
+}
+
+// struct v4l2_pix_format
+type v4l2PixFormat struct {
+	width        uint32
+	height       uint32
+	pixelFormat  uint32
+	field        uint32
+	bytesPerLine uint32
+	sizeImage    uint32
+	colorspace   uint32
+	priv         uint32
+	flags        uint32
+	encoding     uint32
+	quantization uint32
+	xferFunc     uint32
+}
+
+// struct v4l2_format, whose union is aligned to pointers.
+type v4l2Format struct {
+	typ uint32
+	_   [unsafe.Sizeof(uintptr(0)) - 4]byte
+	pix v4l2PixFormat
+	_   [200 - unsafe.Sizeof(v4l2PixFormat{})]byte
+}
+
+// struct v4l2_streamparm having struct v4l2_captureparm.
+type v4l2StreamParm struct {
+	typ          uint32
+	capability   uint32
+	captureMode  uint32
+	numerator    uint32
+	denominator  uint32
+	extendedMode uint32
+	readBuffers  uint32
+	_            [200 - 24]byte
+}
+
+// struct v4l2_requestbuffers
+type v4l2RequestBuffers struct {
+	count        uint32
+	typ          uint32
+	memory       uint32
+	capabilities uint32
+	flags        uint8
+	reserved     [3]uint8
+}
+
+// struct v4l2_buffer
+type v4l2Buffer struct {
+	index     uint32
+	typ       uint32
+	bytesUsed uint32
+	flags     uint32
+	field     uint32
+	timestamp syscall.Timeval
+	timecode  [16]byte
+	sequence  uint32
+	memory    uint32
+	m         uintptr // union having the offset of the buffer
+	length    uint32
+	reserved2 uint32
+	requestFD int32
+}
+
+// v4l2IOC returns the number of a V4L2 ioctl having an argument of size
+// bytes. dir is 1 for write, 2 for read, and 3 for both.
+func v4l2IOC(dir, nr, size uintptr) uintptr {
+	return dir<<30 | size<<16 | 'V'<<8 | nr
+}
+
+var (
+	vidiocQueryCap  = v4l2IOC(2, 0, unsafe.Sizeof(v4l2Capability{}))
+	vidiocGetFmt    = v4l2IOC(3, 4, unsafe.Sizeof(v4l2Format{}))
+	vidiocSetFmt    = v4l2IOC(3, 5, unsafe.Sizeof(v4l2Format{}))
+	vidiocReqBufs   = v4l2IOC(3, 8, unsafe.Sizeof(v4l2RequestBuffers{}))
+	vidiocQueryBuf  = v4l2IOC(3, 9, unsafe.Sizeof(v4l2Buffer{}))
+	vidiocQBuf      = v4l2IOC(3, 15, unsafe.Sizeof(v4l2Buffer{}))
+	vidiocDQBuf     = v4l2IOC(3, 17, unsafe.Sizeof(v4l2Buffer{}))
+	vidiocStreamOn  = v4l2IOC(1, 18, unsafe.Sizeof(int32(0)))
+	vidiocStreamOff = v4l2IOC(1, 19, unsafe.Sizeof(int32(0)))
+	vidiocSetParm   = v4l2IOC(3, 22, unsafe.Sizeof(v4l2StreamParm{}))
+)
+
+func v4l2Ioctl(fd, req uintptr, arg unsafe.Pointer) error {
+	for {
+		_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg))
+		if errno == syscall.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	}
+}
+
+// v4l2Stream captures frames from a V4L2 device with memory mapped buffers.
+type v4l2Stream struct {
+	f       *os.File
+	fd      uintptr
+	width   int
+	height  int
+	stride  int
+	format  uint32
+	quality int
+	buffers [][]byte
+
+	// m protects buffers from being unmapped by Close while a frame is
+	// copied from them.
+	m      sync.Mutex
+	closed bool
+}
+
+// openVideoDevice opens a V4L2 device capturing Motion JPEG or YUYV frames.
+// Motion JPEG is preferred, and YUYV frames are encoded into JPEG with the
+// quality. width, height, and fps are requested to the device when they're
+// positive, but the device may choose other values.
+func openVideoDevice(path string, width, height int, fps float64, quality int) (videoStream, error) {
+	fd, err := syscall.Open(path, syscall.O_RDWR|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	s := &v4l2Stream{
+		fd:      uintptr(fd),
+		quality: quality,
+	}
+	if err := s.init(width, height, fps); err != nil {
+		s.release()
+		syscall.Close(fd)
+		return nil, fmt.Errorf("cannot start capturing %v: %v", path, err)
+	}
+
+	// The file is polled by the runtime because it's non-blocking, so that
+	// closing it aborts waiting for frames.
+	s.f = os.NewFile(s.fd, path)
+	return s, nil
+}
+
+func (s *v4l2Stream) init(width, height int, fps float64) error {
+	c := v4l2Capability{}
+	if err := v4l2Ioctl(s.fd, vidiocQueryCap, unsafe.Pointer(&c)); err != nil {
+		return fmt.Errorf("not a V4L2 device: %v", err)
+	}
+	caps := c.capabilities
+	if caps&v4l2CapDeviceCaps != 0 {
+		caps = c.deviceCaps
+	}
+	if caps&v4l2CapVideoCapture == 0 || caps&v4l2CapStreaming == 0 {
+		return errors.New("the device doesn't support streaming capture")
+	}
+
+	f := v4l2Format{typ: v4l2BufTypeVideoCapture}
+	if err := v4l2Ioctl(s.fd, vidiocGetFmt, unsafe.Pointer(&f)); err != nil {
+		return fmt.Errorf("cannot get the format: %v", err)
+	}
+	if width > 0 && height > 0 {
+		f.pix.width, f.pix.height = uint32(width), uint32(height)
+	}
+	f.pix.field = v4l2FieldAny
+	var err error
+	for _, pf := range []uint32{v4l2PixFmtMJPEG, v4l2PixFmtJPEG, v4l2PixFmtYUYV} {
+		f.pix.pixelFormat = pf
+		if err = v4l2Ioctl(s.fd, vidiocSetFmt, unsafe.Pointer(&f)); err != nil {
+			continue
+		}
+		// Drivers replace unsupported formats with supported ones.
+		if f.pix.pixelFormat == pf {
+			break
+		}
+		err = errors.New("the device supports neither Motion JPEG nor YUYV")
+	}
+	if err != nil {
+		return err
+	}
+	s.width, s.height = int(f.pix.width), int(f.pix.height)
+	s.stride, s.format = int(f.pix.bytesPerLine), f.pix.pixelFormat
+	if s.stride == 0 {
+		s.stride = 2 * s.width
+	}
+
+	if fps > 0 {
+		// Devices not supporting the frame rate are still used and frames
+		// are dropped by the source.
+		p := v4l2StreamParm{typ: v4l2BufTypeVideoCapture, numerator: 1000, denominator: uint32(fps * 1000)}
+		v4l2Ioctl(s.fd, vidiocSetParm, unsafe.Pointer(&p))
+	}
+
+	r := v4l2RequestBuffers{count: v4l2NumBuffers, typ: v4l2BufTypeVideoCapture, memory: v4l2MemoryMMap}
+	if err := v4l2Ioctl(s.fd, vidiocReqBufs, unsafe.Pointer(&r)); err != nil {
+		return fmt.Errorf("cannot request buffers: %v", err)
+	}
+	if r.count == 0 {
+		return errors.New("the device has no buffers")
+	}
+	for i := uint32(0); i < r.count; i++ {
+		b := v4l2Buffer{index: i, typ: v4l2BufTypeVideoCapture, memory: v4l2MemoryMMap}
+		if err := v4l2Ioctl(s.fd, vidiocQueryBuf, unsafe.Pointer(&b)); err != nil {
+			return fmt.Errorf("cannot query a buffer: %v", err)
+		}
+		m, err := syscall.Mmap(int(s.fd), int64(uint32(b.m)), int(b.length), syscall.PROT_READ, syscall.MAP_SHARED)
+		if err != nil {
+			return fmt.Errorf("cannot map a buffer: %v", err)
+		}
+		s.buffers = append(s.buffers, m)
+		if err := v4l2Ioctl(s.fd, vidiocQBuf, unsafe.Pointer(&b)); err != nil {
+			return fmt.Errorf("cannot queue a buffer: %v", err)
+		}
+	}
+
+	t := int32(v4l2BufTypeVideoCapture)
+	if err := v4l2Ioctl(s.fd, vidiocStreamOn, unsafe.Pointer(&t)); err != nil {
+		return fmt.Errorf("cannot start streaming: %v", err)
+	}
+	return nil
+}
+
+func (s *v4l2Stream) ReadFrame() (*videoFrame, error) {
+	rc, err := s.f.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	b := v4l2Buffer{typ: v4l2BufTypeVideoCapture, memory: v4l2MemoryMMap}
+	var ioctlErr error
+	if err := rc.Read(func(fd uintptr) bool {
+		ioctlErr = v4l2Ioctl(fd, vidiocDQBuf, unsafe.Pointer(&b))
+		return ioctlErr != syscall.EAGAIN
+	}); err != nil {
+		return nil, err
+	}
+	if ioctlErr != nil {
+		return nil, fmt.Errorf("cannot dequeue a frame: %v", ioctlErr)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil, os.ErrClosed
+	}
+	if int(b.index) >= len(s.buffers) || int(b.bytesUsed) > len(s.buffers[b.index]) {
+		return nil, fmt.Errorf("the device returned an invalid buffer: %v", b.index)
+	}
+	f := &videoFrame{width: s.width, height: s.height}
+	raw := s.buffers[b.index][:b.bytesUsed]
+	if s.format == v4l2PixFmtYUYV {
+		buf := &bytes.Buffer{}
+		err = video.EncodeYUYV(buf, raw, s.width, s.height, s.stride, &jpeg.Options{Quality: s.quality})
+		f.data = buf.Bytes()
+	} else {
+		f.data = append([]byte(nil), raw...)
+	}
+
+	if qErr := rc.Control(func(fd uintptr) {
+		ioctlErr = v4l2Ioctl(fd, vidiocQBuf, unsafe.Pointer(&b))
+	}); qErr != nil {
+		return nil, qErr
+	}
+	if ioctlErr != nil {
+		return nil, fmt.Errorf("cannot queue a buffer: %v", ioctlErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *v4l2Stream) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	t := int32(v4l2BufTypeVideoCapture)
+	v4l2Ioctl(s.fd, vidiocStreamOff, unsafe.Pointer(&t))
+	s.release()
+	return s.f.Close()
+}
+
+// release unmaps buffers.
+func (s *v4l2Stream) release() {
+	for _, b := range s.buffers {
+		syscall.Munmap(b)
+	}
+	s.buffers = nil
+}
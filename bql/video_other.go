@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package bql
+
+import (
+	"errors"
+)
+
+// openVideoDevice opens a video capture device, which is only supported on
+// Linux.
+func openVideoDevice(path string, width, height int, fps float64, quality int) (videoStream, error) {
+	return nil, errors.New("capturing video devices is only supported on Linux")
+}
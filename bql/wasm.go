@@ -0,0 +1,237 @@
+package bql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// WASMProcessFunc is the name of the function a WebAssembly module loaded
+// as a plugin must export.
+const WASMProcessFunc = "process"
+
+// WASMRuntime instantiates WebAssembly modules. A runtime is provided by a
+// package embedding a WebAssembly engine and registered by SetWASMRuntime.
+type WASMRuntime interface {
+	// Instantiate creates a new isolated instance of the module.
+	Instantiate(ctx *core.Context, code []byte) (WASMModule, error)
+}
+
+// WASMModule is an instance of a WebAssembly module.
+//
+// Values are exchanged with the module as msgpack encoded bytes. How the
+// bytes are passed to the module depends on the runtime. A WASMModule isn't
+// called concurrently.
+type WASMModule interface {
+	// Exports returns names of functions exported by the module.
+	Exports() []string
+
+	// Call calls the exported function with the encoded argument and
+	// returns its encoded result.
+	Call(ctx *core.Context, function string, arg []byte) ([]byte, error)
+
+	// Close releases resources of the instance.
+	Close(ctx *core.Context) error
+}
+
+var (
+	wasmRuntimeMutex sync.RWMutex
+	wasmRuntime      WASMRuntime
+
+	// ErrNoWASMRuntime is returned when a WebAssembly module is loaded but no
+	// WASMRuntime is set.
+	ErrNoWASMRuntime = errors.New("WebAssembly runtime isn't available in this build")
+)
+
+// SetWASMRuntime sets the WASMRuntime used to load WebAssembly modules.
+func SetWASMRuntime(r WASMRuntime) {
+	wasmRuntimeMutex.Lock()
+	defer wasmRuntimeMutex.Unlock()
+	wasmRuntime = r
+}
+
+func getWASMRuntime() (WASMRuntime, error) {
+	wasmRuntimeMutex.RLock()
+	defer wasmRuntimeMutex.RUnlock()
+	if wasmRuntime == nil {
+		return nil, ErrNoWASMRuntime
+	}
+	return wasmRuntime, nil
+}
+
+// isWASMPlugin returns true when the plugin file is a WebAssembly module.
+func isWASMPlugin(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".wasm")
+}
+
+// wasmPlugin is a Plugin created from a WebAssembly module exporting
+// WASMProcessFunc. The function is registered as a box type and a UDF both
+// named after the file name without the extension. The function receives a
+// map and returns a map, an array of maps, or null when used as a box. When
+// it's used as a UDF, the argument must be a map and it can return any value.
+type wasmPlugin struct {
+	name string
+	code []byte
+
+	m sync.Mutex
+	// udfModules has modules instantiated for UDFs for each topology.
+	udfModules map[*core.Context]WASMModule
+}
+
+func openWASMPlugin(path string) (Plugin, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if err := core.ValidateSymbol(name); err != nil {
+		return nil, fmt.Errorf("the file name of a WebAssembly module must be a valid name: %v", err)
+	}
+	code, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &wasmPlugin{
+		name:       name,
+		code:       code,
+		udfModules: map[*core.Context]WASMModule{},
+	}, nil
+}
+
+func (p *wasmPlugin) instantiate(ctx *core.Context) (WASMModule, error) {
+	r, err := getWASMRuntime()
+	if err != nil {
+		return nil, err
+	}
+	m, err := r.Instantiate(ctx, p.code)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range m.Exports() {
+		if e == WASMProcessFunc {
+			return m, nil
+		}
+	}
+	m.Close(ctx)
+	return nil, fmt.Errorf("the WebAssembly module %v doesn't export %v function", p.name, WASMProcessFunc)
+}
+
+func (p *wasmPlugin) Register(r *PluginRegistrar) error {
+	ctx := r.Context()
+	m, err := p.instantiate(ctx)
+	if err != nil {
+		return err
+	}
+	p.m.Lock()
+	if old, ok := p.udfModules[ctx]; ok {
+		old.Close(ctx)
+	}
+	p.udfModules[ctx] = m
+	p.m.Unlock()
+
+	if err := r.RegisterBoxCreator(p.name, BoxCreatorFunc(p.createBox)); err != nil {
+		p.Unload(ctx)
+		return err
+	}
+	if err := r.RegisterUDF(p.name, &wasmUDF{module: m}); err != nil {
+		p.Unload(ctx)
+		return err
+	}
+	return nil
+}
+
+func (p *wasmPlugin) Unload(ctx *core.Context) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	m, ok := p.udfModules[ctx]
+	if !ok {
+		return nil
+	}
+	delete(p.udfModules, ctx)
+	return m.Close(ctx)
+}
+
+func (p *wasmPlugin) createBox(ctx *core.Context, ioParams *IOParams, params data.Map) (core.Box, error) {
+	m, err := p.instantiate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &scriptBox{
+		lang:   "wasm",
+		script: &wasmScript{module: m},
+	}, nil
+}
+
+// wasmScript runs WASMProcessFunc of a module as a Script of a box.
+type wasmScript struct {
+	module WASMModule
+}
+
+func (s *wasmScript) Process(ctx *core.Context, d data.Map) (data.Value, error) {
+	return callWASMProcess(ctx, s.module, d)
+}
+
+func (s *wasmScript) Terminate(ctx *core.Context) error {
+	return s.module.Close(ctx)
+}
+
+type wasmUDF struct {
+	m      sync.Mutex
+	module WASMModule
+}
+
+var (
+	_ udf.UDF = &wasmUDF{}
+)
+
+func (f *wasmUDF) Call(ctx *core.Context, args ...data.Value) (data.Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("a WebAssembly function takes one argument: %v", len(args))
+	}
+	m, err := data.AsMap(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("the argument of a WebAssembly function must be a map: %v", err)
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	return callWASMProcess(ctx, f.module, m)
+}
+
+func (f *wasmUDF) Accept(arity int) bool {
+	return arity == 1
+}
+
+func (f *wasmUDF) IsAggregationParameter(k int) bool {
+	return false
+}
+
+var wasmMsgpackHandle = &codec.MsgpackHandle{}
+
+func init() {
+	wasmMsgpackHandle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	wasmMsgpackHandle.RawToString = true
+	wasmMsgpackHandle.WriteExt = false
+}
+
+func callWASMProcess(ctx *core.Context, m WASMModule, d data.Map) (data.Value, error) {
+	in, err := data.MarshalMsgpack(d)
+	if err != nil {
+		return nil, err
+	}
+	out, err := m.Call(ctx, WASMProcessFunc, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return data.Null{}, nil
+	}
+	var v interface{}
+	if err := codec.NewDecoderBytes(out, wasmMsgpackHandle).Decode(&v); err != nil {
+		return nil, fmt.Errorf("cannot decode the result of the WebAssembly function: %v", err)
+	}
+	return data.NewValue(v)
+}
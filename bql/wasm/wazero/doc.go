@@ -0,0 +1,25 @@
+// Package wazero provides a WASMRuntime based on wazero, a WebAssembly runtime
+// written in pure Go. Since it adds a dependency, the runtime is only built
+// with the wazero build tag:
+//
+//	go build -tags wazero
+//
+// Import this package for side effects to load .wasm files by LOAD PLUGIN:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/bql/wasm/wazero"
+//
+// A module must export its memory and following functions:
+//
+//	alloc(size i32) i32
+//	process(ptr i32, size i32) i64
+//
+// alloc returns a pointer to a buffer having size bytes. The runtime writes
+// a msgpack encoded map to the buffer and calls process with it. process
+// returns the pointer of its msgpack encoded result in the upper 32 bits and
+// its size in the lower 32 bits. A size of 0 means null. When the module
+// also exports dealloc(ptr i32, size i32), buffers are released by it after
+// each call.
+//
+// Each instance runs in its own runtime with WASI, so modules cannot access
+// the file system nor the network unless the host allows it.
+package wazero
@@ -0,0 +1,130 @@
+//go:build wazero
+// +build wazero
+
+package wazero
+
+import (
+	"context"
+	"fmt"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+)
+
+// Runtime is a bql.WASMRuntime using wazero.
+type Runtime struct {
+	// MemoryLimitPages is the maximum number of 64KiB pages of the memory of
+	// a module. The limit of wazero is used when it's 0.
+	MemoryLimitPages uint32
+}
+
+// Instantiate compiles the code and instantiates it in a new runtime.
+func (r *Runtime) Instantiate(ctx *core.Context, code []byte) (bql.WASMModule, error) {
+	c := context.Background()
+	conf := wazero.NewRuntimeConfig()
+	if r.MemoryLimitPages > 0 {
+		conf = conf.WithMemoryLimitPages(r.MemoryLimitPages)
+	}
+	rt := wazero.NewRuntimeWithConfig(c, conf)
+	if _, err := wasi_snapshot_preview1.Instantiate(c, rt); err != nil {
+		rt.Close(c)
+		return nil, err
+	}
+
+	compiled, err := rt.CompileModule(c, code)
+	if err != nil {
+		rt.Close(c)
+		return nil, err
+	}
+	mod, err := rt.InstantiateModule(c, compiled, wazero.NewModuleConfig().WithName(""))
+	if err != nil {
+		rt.Close(c)
+		return nil, err
+	}
+	if mod.Memory() == nil {
+		rt.Close(c)
+		return nil, fmt.Errorf("the module doesn't export its memory")
+	}
+	alloc := mod.ExportedFunction("alloc")
+	if alloc == nil {
+		rt.Close(c)
+		return nil, fmt.Errorf("the module doesn't export alloc function")
+	}
+
+	exports := make([]string, 0, len(compiled.ExportedFunctions()))
+	for n := range compiled.ExportedFunctions() {
+		exports = append(exports, n)
+	}
+	return &module{
+		rt:      rt,
+		mod:     mod,
+		alloc:   alloc,
+		dealloc: mod.ExportedFunction("dealloc"),
+		exports: exports,
+	}, nil
+}
+
+type module struct {
+	rt      wazero.Runtime
+	mod     api.Module
+	alloc   api.Function
+	dealloc api.Function
+	exports []string
+}
+
+func (m *module) Exports() []string {
+	return m.exports
+}
+
+func (m *module) Call(ctx *core.Context, function string, arg []byte) ([]byte, error) {
+	c := context.Background()
+	f := m.mod.ExportedFunction(function)
+	if f == nil {
+		return nil, fmt.Errorf("the module doesn't export %v function", function)
+	}
+
+	res, err := m.alloc.Call(c, uint64(len(arg)))
+	if err != nil {
+		return nil, err
+	}
+	ptr := uint32(res[0])
+	if !m.mod.Memory().Write(ptr, arg) {
+		return nil, fmt.Errorf("alloc returned an out of range pointer: %v", ptr)
+	}
+	defer m.free(c, ptr, uint32(len(arg)))
+
+	res, err = f.Call(c, uint64(ptr), uint64(len(arg)))
+	if err != nil {
+		return nil, err
+	}
+	outPtr, outSize := uint32(res[0]>>32), uint32(res[0])
+	if outSize == 0 {
+		return nil, nil
+	}
+	defer m.free(c, outPtr, outSize)
+	b, ok := m.mod.Memory().Read(outPtr, outSize)
+	if !ok {
+		return nil, fmt.Errorf("%v returned an out of range buffer: %v+%v", function, outPtr, outSize)
+	}
+
+	// b refers to the memory of the module and has to be copied.
+	out := make([]byte, len(b))
+	copy(out, b)
+	return out, nil
+}
+
+func (m *module) free(c context.Context, ptr, size uint32) {
+	if m.dealloc != nil {
+		m.dealloc.Call(c, uint64(ptr), uint64(size))
+	}
+}
+
+func (m *module) Close(ctx *core.Context) error {
+	return m.rt.Close(context.Background())
+}
+
+func init() {
+	bql.SetWASMRuntime(&Runtime{})
+}
@@ -0,0 +1,184 @@
+package bql
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// testWASMRuntime interprets the content of a module as the name of a
+// predefined behavior.
+type testWASMRuntime struct {
+	instances int32
+	closed    int32
+}
+
+func (r *testWASMRuntime) Instantiate(ctx *core.Context, code []byte) (WASMModule, error) {
+	switch string(code) {
+	case "twice", "nothing", "noexport":
+	default:
+		return nil, errors.New("invalid module")
+	}
+	atomic.AddInt32(&r.instances, 1)
+	return &testWASMModule{r: r, code: string(code)}, nil
+}
+
+type testWASMModule struct {
+	r    *testWASMRuntime
+	code string
+}
+
+func (m *testWASMModule) Exports() []string {
+	if m.code == "noexport" {
+		return []string{"main"}
+	}
+	return []string{"alloc", WASMProcessFunc}
+}
+
+func (m *testWASMModule) Call(ctx *core.Context, function string, arg []byte) ([]byte, error) {
+	if m.code == "nothing" {
+		return nil, nil
+	}
+	d, err := data.UnmarshalMsgpack(arg)
+	if err != nil {
+		return nil, err
+	}
+	i, err := data.AsInt(d["int"])
+	if err != nil {
+		return nil, err
+	}
+	d["int"] = data.Int(i * 2)
+	return data.MarshalMsgpack(d)
+}
+
+func (m *testWASMModule) Close(ctx *core.Context) error {
+	atomic.AddInt32(&m.r.closed, 1)
+	return nil
+}
+
+func TestWASMPlugin(t *testing.T) {
+	Convey("Given a topology builder and WebAssembly modules", t, func() {
+		dir, err := ioutil.TempDir("", "sensorbee_wasm_test")
+		So(err, ShouldBeNil)
+		for _, n := range []string{"twice", "nothing", "noexport"} {
+			So(ioutil.WriteFile(filepath.Join(dir, n+".wasm"), []byte(n), 0600), ShouldBeNil)
+		}
+		So(ioutil.WriteFile(filepath.Join(dir, "broken.wasm"), []byte("broken"), 0600), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "in-valid.wasm"), []byte("twice"), 0600), ShouldBeNil)
+		r := &testWASMRuntime{}
+		SetWASMRuntime(r)
+		Reset(func() {
+			SetWASMRuntime(nil)
+			os.RemoveAll(dir)
+			pluginsMutex.Lock()
+			plugins = map[string]Plugin{}
+			pluginsMutex.Unlock()
+		})
+
+		tp := newTestTopology()
+		Reset(func() {
+			tp.Stop()
+		})
+		tb, err := NewTopologyBuilder(tp)
+		So(err, ShouldBeNil)
+
+		Convey("When loading a module", func() {
+			So(tb.LoadPlugin(filepath.Join(dir, "twice.wasm")), ShouldBeNil)
+
+			Convey("Then it should be available as a box", func() {
+				So(addBQLToTopology(tb, `
+					CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+					CREATE BOX b TYPE twice FROM source;
+					CREATE SINK snk TYPE collector;
+					INSERT INTO snk FROM b;
+					RESUME SOURCE source;`), ShouldBeNil)
+				sn, err := tp.Sink("snk")
+				So(err, ShouldBeNil)
+				si := sn.Sink().(*tupleCollectorSink)
+				si.Wait(4)
+				So(si.get(0).Data, ShouldResemble, data.Map{"int": data.Int(2)})
+				So(si.get(3).Data, ShouldResemble, data.Map{"int": data.Int(8)})
+			})
+
+			Convey("Then it should be available as a UDF", func() {
+				f, err := tb.Reg.Lookup("twice", 1)
+				So(err, ShouldBeNil)
+				v, err := f.Call(tp.Context(), data.Map{"int": data.Int(3)})
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Map{"int": data.Int(6)})
+
+				Convey("And it should reject a non-map argument", func() {
+					_, err := f.Call(tp.Context(), data.Int(3))
+					So(err, ShouldNotBeNil)
+				})
+			})
+
+			Convey("Then a box should be terminated with its own instance", func() {
+				So(addBQLToTopology(tb, `
+					CREATE PAUSED SOURCE source TYPE dummy WITH num=4;
+					CREATE BOX b TYPE twice FROM source;`), ShouldBeNil)
+				So(r.instances, ShouldEqual, 2)
+				So(tp.Remove("b"), ShouldBeNil)
+				So(r.closed, ShouldEqual, 1)
+			})
+
+			Convey("And unloading it", func() {
+				So(tb.UnloadPlugin(filepath.Join(dir, "twice.wasm")), ShouldBeNil)
+
+				Convey("Then the instance for the UDF should be closed", func() {
+					So(r.closed, ShouldEqual, 1)
+				})
+
+				Convey("Then its components should be removed", func() {
+					_, err := tb.BoxCreators.Lookup("twice")
+					So(core.IsNotExist(err), ShouldBeTrue)
+					_, err = tb.Reg.Lookup("twice", 1)
+					So(core.IsNotExist(err), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When a module returns nothing", func() {
+			So(tb.LoadPlugin(filepath.Join(dir, "nothing.wasm")), ShouldBeNil)
+			f, err := tb.Reg.Lookup("nothing", 1)
+			So(err, ShouldBeNil)
+			v, err := f.Call(tp.Context(), data.Map{"int": data.Int(3)})
+
+			Convey("Then the UDF should return null", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Null{})
+			})
+		})
+
+		Convey("When loading an invalid module", func() {
+			Convey("Then it should fail without process function", func() {
+				So(tb.LoadPlugin(filepath.Join(dir, "noexport.wasm")), ShouldNotBeNil)
+				So(r.closed, ShouldEqual, 1)
+			})
+
+			Convey("Then it should fail when the runtime rejects it", func() {
+				So(tb.LoadPlugin(filepath.Join(dir, "broken.wasm")), ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail with an invalid file name", func() {
+				So(tb.LoadPlugin(filepath.Join(dir, "in-valid.wasm")), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When no runtime is available", func() {
+			SetWASMRuntime(nil)
+			err := tb.LoadPlugin(filepath.Join(dir, "twice.wasm"))
+
+			Convey("Then loading a module should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, ErrNoWASMRuntime.Error())
+			})
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package bql
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// WindowStates returns states of windows of a stream created by a SELECT
+// statement, keyed by aliases of its inputs. It returns false when the box
+// doesn't have windows.
+func WindowStates(b core.Box) (map[string]execution.WindowState, bool) {
+	bb, ok := b.(*bqlBox)
+	if !ok {
+		return nil, false
+	}
+	return bb.windowStates()
+}
+
+// windowStateFuncs returns functions reporting states of windows of streams
+// in the topology. Because they require a topology, they're registered in a
+// function like NewTopologyBuilder. All of them receive the name of a stream:
+//
+//   - window_state(stream): a map having states of all windows of the stream
+//     keyed by aliases of inputs.
+//   - window_num_tuples(stream): the total number of tuples in windows.
+//   - window_oldest_timestamp(stream): the timestamp of the oldest tuple in
+//     windows. It returns null when windows are empty.
+//   - window_newest_timestamp(stream): the timestamp of the newest tuple in
+//     windows. It returns null when windows are empty.
+//   - window_memory_size(stream): the approximate size of tuples kept in
+//     memory by windows in bytes.
+func windowStateFuncs(t core.Topology) map[string]udf.UDF {
+	lookup := func(v data.Value) (map[string]execution.WindowState, error) {
+		name, err := data.AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("the name of a stream must be a string: %v", err)
+		}
+		b, err := t.Box(name)
+		if err != nil {
+			return nil, err
+		}
+		states, ok := WindowStates(b.Box())
+		if !ok {
+			return nil, fmt.Errorf("stream '%v' doesn't have windows", name)
+		}
+		return states, nil
+	}
+
+	// aggregate returns the state of all windows of a stream as if they
+	// were one window.
+	aggregate := func(v data.Value) (*execution.WindowState, error) {
+		states, err := lookup(v)
+		if err != nil {
+			return nil, err
+		}
+		res := &execution.WindowState{}
+		for _, st := range states {
+			if st.NumTuples > 0 {
+				if res.NumTuples == 0 || st.Oldest.Before(res.Oldest) {
+					res.Oldest = st.Oldest
+				}
+				if res.NumTuples == 0 || st.Newest.After(res.Newest) {
+					res.Newest = st.Newest
+				}
+			}
+			res.NumTuples += st.NumTuples
+			res.NumSpilled += st.NumSpilled
+			res.MemorySize += st.MemorySize
+		}
+		return res, nil
+	}
+
+	return map[string]udf.UDF{
+		"window_state": udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			states, err := lookup(v)
+			if err != nil {
+				return nil, err
+			}
+			m := make(data.Map, len(states))
+			for alias, st := range states {
+				m[alias] = st.Map()
+			}
+			return m, nil
+		}),
+		"window_num_tuples": udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			st, err := aggregate(v)
+			if err != nil {
+				return nil, err
+			}
+			return data.Int(st.NumTuples), nil
+		}),
+		"window_oldest_timestamp": udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			st, err := aggregate(v)
+			if err != nil {
+				return nil, err
+			}
+			if st.NumTuples == 0 {
+				return data.Null{}, nil
+			}
+			return data.Timestamp(st.Oldest), nil
+		}),
+		"window_newest_timestamp": udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			st, err := aggregate(v)
+			if err != nil {
+				return nil, err
+			}
+			if st.NumTuples == 0 {
+				return data.Null{}, nil
+			}
+			return data.Timestamp(st.Newest), nil
+		}),
+		"window_memory_size": udf.UnaryFunc(func(ctx *core.Context, v data.Value) (data.Value, error) {
+			st, err := aggregate(v)
+			if err != nil {
+				return nil, err
+			}
+			return data.Int(st.MemorySize), nil
+		}),
+	}
+}
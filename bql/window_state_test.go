@@ -0,0 +1,131 @@
+package bql
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestWindowState(t *testing.T) {
+	Convey("Given a topology with a stream having a window", t, func() {
+		tb, err := setupTopology("CREATE STREAM box AS SELECT RSTREAM * FROM source [RANGE 2 TUPLES]", false)
+		So(err, ShouldBeNil)
+		dt := tb.Topology()
+		Reset(func() {
+			dt.Stop()
+		})
+		call := func(name string, v data.Value) (data.Value, error) {
+			f, err := tb.Reg.Lookup(name, 1)
+			So(err, ShouldBeNil)
+			return f.Call(dt.Context(), v)
+		}
+
+		Convey("When all tuples are processed", func() {
+			sin, err := dt.Sink("snk")
+			So(err, ShouldBeNil)
+			sin.Sink().(*tupleCollectorSink).Wait(7)
+			oldest := time.Date(2015, time.April, 10, 10, 23, 2, 0, time.UTC)
+			newest := time.Date(2015, time.April, 10, 10, 23, 3, 0, time.UTC)
+
+			Convey("Then window_state should return the state of the window", func() {
+				v, err := call("window_state", data.String("box"))
+				So(err, ShouldBeNil)
+				m, err := data.AsMap(v)
+				So(err, ShouldBeNil)
+				st, err := data.AsMap(m["source"])
+				So(err, ShouldBeNil)
+				So(st["num_tuples"], ShouldEqual, data.Int(2))
+				So(st["num_spilled"], ShouldEqual, data.Int(0))
+				So(st["oldest_timestamp"], ShouldResemble, data.Timestamp(oldest))
+				So(st["newest_timestamp"], ShouldResemble, data.Timestamp(newest))
+				So(st["memory_size"], ShouldBeGreaterThan, data.Int(0))
+				So(st["window"], ShouldResemble, data.Map{
+					"size": data.Float(2),
+					"unit": data.String("TUPLES"),
+				})
+			})
+
+			Convey("Then scalar functions should return each value", func() {
+				v, err := call("window_num_tuples", data.String("box"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(2))
+				v, err = call("window_oldest_timestamp", data.String("box"))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Timestamp(oldest))
+				v, err = call("window_newest_timestamp", data.String("box"))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, data.Timestamp(newest))
+				v, err = call("window_memory_size", data.String("box"))
+				So(err, ShouldBeNil)
+				So(v, ShouldBeGreaterThan, data.Int(0))
+			})
+
+			Convey("Then the status of the stream should have the state", func() {
+				b, err := dt.Box("box")
+				So(err, ShouldBeNil)
+				st, err := b.Status().Get(data.MustCompilePath("box.windows.source.num_tuples"))
+				So(err, ShouldBeNil)
+				So(st, ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When calling the functions with a source", func() {
+			_, err := call("window_num_tuples", data.String("source"))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When calling the functions with a non-string", func() {
+			_, err := call("window_state", data.Int(1))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a stream which hasn't received tuples", t, func() {
+		dt := newTestTopology()
+		Reset(func() {
+			dt.Stop()
+		})
+		tb, err := NewTopologyBuilder(dt)
+		So(err, ShouldBeNil)
+		So(addBQLToTopology(tb, `
+			CREATE PAUSED SOURCE source TYPE dummy;
+			CREATE STREAM box AS SELECT ISTREAM * FROM source [RANGE 1 SECONDS];
+		`), ShouldBeNil)
+
+		Convey("When getting the state of the window", func() {
+			b, err := dt.Box("box")
+			So(err, ShouldBeNil)
+			states, ok := WindowStates(b.Box())
+			So(ok, ShouldBeTrue)
+
+			Convey("Then it should be empty", func() {
+				st := states["source"]
+				So(st.NumTuples, ShouldEqual, 0)
+				m := st.Map()
+				So(m["oldest_timestamp"], ShouldResemble, data.Null{})
+				So(m["window"], ShouldResemble, data.Map{
+					"size": data.Float(1),
+					"unit": data.String("SECONDS"),
+				})
+			})
+		})
+
+		Convey("When getting the state of the window of a non-BQL box", func() {
+			_, ok := WindowStates(core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+				return nil
+			}))
+
+			Convey("Then it should not be found", func() {
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
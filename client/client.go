@@ -0,0 +1,296 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/response"
+	"path"
+	"time"
+)
+
+const (
+	defaultRetryInterval = time.Second
+	defaultMaxRetries    = 3
+)
+
+// Client provides typed methods of the API so that applications don't have
+// to build requests and parse responses by themselves. Client is safe for
+// concurrent use as long as its fields aren't modified.
+//
+// Requests are sent with a context.Context, so they can be canceled or have
+// deadlines. GET requests and streams of SELECT statements are retried when
+// they fail because of connection errors. Other requests aren't retried
+// because they might have been processed by the server.
+type Client struct {
+	r *Requester
+
+	// RetryInterval is the interval between retries. The default is 1 second.
+	RetryInterval time.Duration
+
+	// MaxRetries is the maximum number of consecutive retries. A negative
+	// value means unlimited retries. The default is 3.
+	MaxRetries int
+}
+
+// NewClient creates a new client sending requests with the requester.
+func NewClient(r *Requester) *Client {
+	return &Client{
+		r:             r,
+		RetryInterval: defaultRetryInterval,
+		MaxRetries:    defaultMaxRetries,
+	}
+}
+
+// APIError is returned when the server responds with an error.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is the error code defined by the server.
+	Code string
+
+	// Message is the error message.
+	Message string
+
+	// RequestID is the ID of the request which can be used to find logs of
+	// the server.
+	RequestID string
+
+	// Meta has additional information of the error such as validation errors
+	// of each field.
+	Meta data.Map
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("the server returned %v (%v): %v", e.StatusCode, e.Code, e.Message)
+}
+
+// CreateTopology creates a new topology.
+func (c *Client) CreateTopology(ctx context.Context, name string) (*response.Topology, error) {
+	res := struct {
+		Topology *response.Topology `json:"topology"`
+	}{}
+	if err := c.do(ctx, Post, "/topologies", map[string]interface{}{
+		"name": name,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return res.Topology, nil
+}
+
+// DropTopology stops and removes the topology.
+func (c *Client) DropTopology(ctx context.Context, name string) error {
+	return c.do(ctx, Delete, path.Join("/topologies", name), nil, nil)
+}
+
+// SubmitBQL issues BQL statements to the topology. SELECT statements cannot
+// be issued by this method. Use QueryStream instead.
+func (c *Client) SubmitBQL(ctx context.Context, topology, bql string) error {
+	res, err := c.send(ctx, Post, path.Join("/topologies", topology, "queries"), map[string]interface{}{
+		"queries": bql,
+	})
+	if err != nil {
+		return err
+	}
+	if res.IsStream() {
+		res.Close()
+		return errors.New("SELECT statements must be issued by QueryStream")
+	}
+	return readResponse(res, nil)
+}
+
+// NodeStatus returns the status of the node having the type and the name.
+func (c *Client) NodeStatus(ctx context.Context, topology string, nodeType core.NodeType, name string) (data.Map, error) {
+	var (
+		dir string
+		key string
+	)
+	switch nodeType {
+	case core.NTSource:
+		dir, key = "sources", "source"
+	case core.NTBox:
+		dir, key = "streams", "stream"
+	case core.NTSink:
+		dir, key = "sinks", "sink"
+	default:
+		return nil, fmt.Errorf("unsupported node type: %v", nodeType)
+	}
+
+	var res map[string]struct {
+		Status data.Map `json:"status"`
+	}
+	if err := c.do(ctx, Get, path.Join("/topologies", topology, dir, name), nil, &res); err != nil {
+		return nil, err
+	}
+	return res[key].Status, nil
+}
+
+// Stream has results of a SELECT statement issued by QueryStream.
+type Stream struct {
+	// C receives results of the statement. It's closed when the statement
+	// stops, the context is canceled, or the client gives up reconnecting.
+	C <-chan data.Map
+
+	err error
+}
+
+// Err returns the error which closed C. It returns nil when the statement
+// stopped normally or the context was canceled. Don't call this method
+// before C is closed.
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// QueryStream issues a SELECT statement to the topology and returns a stream
+// of its results. When the connection is lost, the statement is issued again
+// so that the stream continues. Results emitted while reconnecting are lost.
+// Canceling the context stops the stream.
+func (c *Client) QueryStream(ctx context.Context, topology, stmt string) (*Stream, error) {
+	res, err := c.openStream(ctx, topology, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan data.Map)
+	s := &Stream{
+		C: ch,
+	}
+	go func() {
+		defer close(ch)
+		for {
+			err := readStream(ctx, res, ch)
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+
+			for retries := 0; ; retries++ {
+				if c.MaxRetries >= 0 && retries >= c.MaxRetries {
+					s.err = err
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(c.RetryInterval):
+				}
+
+				res, err = c.openStream(ctx, topology, stmt)
+				if err == nil {
+					break
+				}
+				if _, ok := err.(*APIError); ok {
+					s.err = err
+					return
+				}
+			}
+		}
+	}()
+	return s, nil
+}
+
+func (c *Client) openStream(ctx context.Context, topology, stmt string) (*Response, error) {
+	res, err := c.send(ctx, Post, path.Join("/topologies", topology, "queries"), map[string]interface{}{
+		"queries": stmt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, readResponse(res, nil)
+	}
+	if !res.IsStream() {
+		res.Close()
+		return nil, errors.New("the statement must be a SELECT statement")
+	}
+	return res, nil
+}
+
+// readStream sends results in the response to ch. It returns nil when the
+// stream ends normally or the context is canceled.
+func readStream(ctx context.Context, res *Response, ch chan<- data.Map) error {
+	defer res.Close()
+	js, err := res.ReadStreamJSON()
+	if err != nil {
+		return err
+	}
+	for {
+		var v interface{}
+		select {
+		case <-ctx.Done():
+			return nil
+		case r, ok := <-js:
+			if !ok {
+				return res.StreamError()
+			}
+			v = r
+		}
+
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("a result must be a JSON object: %v", v)
+		}
+		m, err := data.NewMap(obj)
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case ch <- m:
+		}
+	}
+}
+
+// do sends a request and reads its response into out. GET requests are
+// retried on connection errors.
+func (c *Client) do(ctx context.Context, method Method, apiPath string, body interface{}, out interface{}) error {
+	for retries := 0; ; retries++ {
+		res, err := c.send(ctx, method, apiPath, body)
+		if err == nil {
+			return readResponse(res, out)
+		}
+		if method != Get || ctx.Err() != nil || (c.MaxRetries >= 0 && retries >= c.MaxRetries) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.RetryInterval):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method Method, apiPath string, body interface{}) (*Response, error) {
+	req, err := c.r.NewRequest(method, apiPath, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.r.DoWithRequest(req.WithContext(ctx))
+}
+
+// readResponse reads the JSON response into out. It returns an APIError when
+// the response is an error. The response is closed by this function.
+func readResponse(res *Response, out interface{}) error {
+	defer res.Close()
+	if res.IsError() {
+		e, err := res.Error()
+		if err != nil {
+			return fmt.Errorf("the server returned %v: %v", res.Raw.StatusCode, err)
+		}
+		return &APIError{
+			StatusCode: res.Raw.StatusCode,
+			Code:       e.Code,
+			Message:    e.Message,
+			RequestID:  e.RequestID,
+			Meta:       e.Meta,
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	return res.ReadJSON(out)
+}
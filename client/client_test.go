@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	// A real HTTP server is required to receive results of SELECT statements.
+	testutil.TestAPIWithRealHTTPServer = true
+
+	s := testutil.NewServer()
+	defer func() {
+		testutil.TestAPIWithRealHTTPServer = false
+		s.Close()
+	}()
+	c := NewClient(newTestRequester(s))
+	ctx := context.Background()
+
+	Convey("Given a client connected to an API server", t, func() {
+		Convey("When creating a topology", func() {
+			tp, err := c.CreateTopology(ctx, "test_topology")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				c.DropTopology(ctx, "test_topology")
+			})
+
+			Convey("Then it should return the topology", func() {
+				So(tp.Name, ShouldEqual, "test_topology")
+			})
+
+			Convey("Then creating the same topology again should fail", func() {
+				_, err := c.CreateTopology(ctx, "test_topology")
+				So(err, ShouldNotBeNil)
+				e, ok := err.(*APIError)
+				So(ok, ShouldBeTrue)
+				So(e.StatusCode, ShouldEqual, http.StatusBadRequest)
+			})
+
+			Convey("And submitting a CREATE SOURCE statement", func() {
+				So(c.SubmitBQL(ctx, "test_topology", `CREATE PAUSED SOURCE source TYPE dummy;`), ShouldBeNil)
+
+				Convey("Then the status of the source should be returned", func() {
+					st, err := c.NodeStatus(ctx, "test_topology", core.NTSource, "source")
+					So(err, ShouldBeNil)
+					So(st["state"], ShouldEqual, data.String("paused"))
+				})
+
+				Convey("Then the status of a nonexistent node should not be returned", func() {
+					_, err := c.NodeStatus(ctx, "test_topology", core.NTSink, "sink")
+					So(err, ShouldNotBeNil)
+					e, ok := err.(*APIError)
+					So(ok, ShouldBeTrue)
+					So(e.StatusCode, ShouldEqual, http.StatusNotFound)
+				})
+
+				Convey("Then a SELECT statement should be streamed", func() {
+					st, err := c.QueryStream(ctx, "test_topology", `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`)
+					So(err, ShouldBeNil)
+					So(c.SubmitBQL(ctx, "test_topology", `RESUME SOURCE source;`), ShouldBeNil)
+
+					for i := 0; i < 4; i++ {
+						m, ok := <-st.C
+						So(ok, ShouldBeTrue)
+						So(m["int"], ShouldEqual, data.Float(i))
+					}
+				})
+
+				Convey("Then canceling the context should close the stream", func() {
+					cctx, cancel := context.WithCancel(ctx)
+					st, err := c.QueryStream(cctx, "test_topology", `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`)
+					So(err, ShouldBeNil)
+					cancel()
+
+					select {
+					case _, ok := <-st.C:
+						So(ok, ShouldBeFalse)
+					case <-time.After(5 * time.Second):
+						So("the stream wasn't closed", ShouldBeEmpty)
+					}
+					So(st.Err(), ShouldBeNil)
+				})
+
+				Convey("Then SubmitBQL should reject a SELECT statement", func() {
+					So(c.SubmitBQL(ctx, "test_topology", `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`), ShouldNotBeNil)
+				})
+
+				Convey("Then QueryStream should reject a statement other than SELECT", func() {
+					_, err := c.QueryStream(ctx, "test_topology", `PAUSE SOURCE source;`)
+					So(err, ShouldNotBeNil)
+				})
+			})
+
+			Convey("Then submitting an invalid statement should fail", func() {
+				err := c.SubmitBQL(ctx, "test_topology", `CREATE SOURCE source TYPE no_such_type;`)
+				So(err, ShouldNotBeNil)
+				_, ok := err.(*APIError)
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the server isn't reachable", func() {
+			r, err := NewRequester("http://127.0.0.1:1/", "v1")
+			So(err, ShouldBeNil)
+			c := NewClient(r)
+			c.RetryInterval = time.Millisecond
+
+			Convey("Then a GET request should fail after retries", func() {
+				_, err := c.NodeStatus(ctx, "test_topology", core.NTSource, "source")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCursors(t *testing.T) {
+	s := testutil.NewServer()
+	defer s.Close()
+	r := newTestRequester(s)
+
+	Convey("Given an API server with a topology having a paused source", t, func() {
+		res, _, err := do(r, Post, "/topologies", map[string]interface{}{
+			"name": "test_topology",
+		})
+		So(err, ShouldBeNil)
+		So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+		Reset(func() {
+			do(r, Delete, "/topologies/test_topology", nil)
+		})
+
+		res, _, err = do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+			"queries": `CREATE PAUSED SOURCE source TYPE dummy;`,
+		})
+		So(err, ShouldBeNil)
+		So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+
+		Convey("When creating a cursor having a small buffer", func() {
+			res, js, err := do(r, Post, "/topologies/test_topology/cursors", map[string]interface{}{
+				"queries":     `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`,
+				"buffer_size": 2,
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			id, ok := jscan(js, "/cursor/id").(string)
+			So(ok, ShouldBeTrue)
+			path := "/topologies/test_topology/cursors/" + id
+
+			res, _, err = do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+				"queries": `RESUME SOURCE source;`,
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+
+			Convey("Then results should be fetched until the statement stops", func() {
+				var (
+					results []interface{}
+					dropped float64
+				)
+				for i := 0; ; i++ {
+					So(i, ShouldBeLessThan, 500)
+					res, js, err := do(r, Get, path+"?limit=1", nil)
+					So(err, ShouldBeNil)
+					So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+					results = append(results, js["results"].([]interface{})...)
+					dropped += js["dropped"].(float64)
+					if js["done"].(bool) {
+						break
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				So(float64(len(results))+dropped, ShouldEqual, 4)
+				So(jscan(results[len(results)-1], "/int"), ShouldEqual, 3)
+			})
+
+			Convey("Then it should be removed by DELETE", func() {
+				res, _, err := do(r, Delete, path, nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+
+				res, _, err = do(r, Get, path, nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a cursor isn't accessed for the timeout", func() {
+			res, js, err := do(r, Post, "/topologies/test_topology/cursors", map[string]interface{}{
+				"queries": `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`,
+				"timeout": "10ms",
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			id := jscan(js, "/cursor/id").(string)
+			time.Sleep(100 * time.Millisecond)
+
+			Convey("Then it should expire", func() {
+				res, _, err := do(r, Get, "/topologies/test_topology/cursors/"+id, nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When creating a cursor with a statement other than SELECT", func() {
+			res, _, err := do(r, Post, "/topologies/test_topology/cursors", map[string]interface{}{
+				"queries": `RESUME SOURCE source;`,
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When creating a cursor with an invalid buffer size", func() {
+			res, _, err := do(r, Post, "/topologies/test_topology/cursors", map[string]interface{}{
+				"queries":     `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`,
+				"buffer_size": 0,
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// PreparedStatement is a BQL statement having positional (`?`) or named
+// (`:name`) placeholders. Binding values to it returns a statement which
+// can be sent to the server as "queries":
+//
+//	ps, err := client.PrepareStatement(`UPDATE SINK alert SET device_id = ?;`)
+//	...
+//	q, err := ps.Bind(deviceID)
+//	...
+//	res, err := r.Do(client.Post, "/topologies/t/queries", map[string]interface{}{
+//		"queries": q,
+//	})
+//
+// Bound values are embedded as literals so that they can't change the
+// structure of the statement. Placeholders can only be used where a literal
+// can be written.
+type PreparedStatement struct {
+	stmt *parser.PreparedStmt
+}
+
+// PrepareStatement parses a single BQL statement having placeholders.
+func PrepareStatement(s string) (*PreparedStatement, error) {
+	stmt, err := parser.New().Prepare(s)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedStatement{
+		stmt: stmt,
+	}, nil
+}
+
+// String returns the statement having placeholders.
+func (p *PreparedStatement) String() string {
+	return p.stmt.String()
+}
+
+// Bind binds the given values to positional placeholders in order and
+// returns the resulting statement. Values are converted by data.NewValue.
+func (p *PreparedStatement) Bind(args ...interface{}) (string, error) {
+	vs := make([]data.Value, len(args))
+	for i, a := range args {
+		v, err := data.NewValue(a)
+		if err != nil {
+			return "", err
+		}
+		vs[i] = v
+	}
+	return p.stmt.Bind(vs...)
+}
+
+// BindNamed binds values in args to named placeholders having the same
+// names and returns the resulting statement. Values are converted by
+// data.NewMap.
+func (p *PreparedStatement) BindNamed(args map[string]interface{}) (string, error) {
+	m, err := data.NewMap(args)
+	if err != nil {
+		return "", err
+	}
+	return p.stmt.BindNamed(m)
+}
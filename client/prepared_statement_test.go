@@ -0,0 +1,44 @@
+package client
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestPreparedStatement(t *testing.T) {
+	Convey("Given a prepared statement having positional placeholders", t, func() {
+		ps, err := PrepareStatement(`UPDATE SINK s SET a=?, b=?;`)
+		So(err, ShouldBeNil)
+
+		Convey("When binding Go values", func() {
+			q, err := ps.Bind(1, []interface{}{"x", 2.5})
+
+			Convey("Then they should be embedded as literals", func() {
+				So(err, ShouldBeNil)
+				So(q, ShouldEqual, `UPDATE SINK s SET a=1, b=["x", 2.5];`)
+			})
+		})
+
+		Convey("When binding a value which cannot be converted", func() {
+			_, err := ps.Bind(1, make(chan int))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a prepared statement having named placeholders", t, func() {
+		ps, err := PrepareStatement(`EVAL :name || "!"`)
+		So(err, ShouldBeNil)
+
+		Convey("When binding Go values", func() {
+			q, err := ps.BindNamed(map[string]interface{}{"name": `"quoted"`})
+
+			Convey("Then they should be embedded as literals", func() {
+				So(err, ShouldBeNil)
+				So(q, ShouldEqual, `EVAL """quoted""" || "!"`)
+			})
+		})
+	})
+}
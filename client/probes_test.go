@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func getProbe(s *testutil.Server, path string) (int, map[string]interface{}, error) {
+	res, err := s.HTTPClient().Get(s.URL() + path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+	var js map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&js); err != nil {
+		return 0, nil, err
+	}
+	return res.StatusCode, js, nil
+}
+
+func TestProbes(t *testing.T) {
+	s := testutil.NewServer()
+	defer s.Close()
+	r := newTestRequester(s)
+
+	Convey("Given an API server", t, func() {
+		Convey("When getting healthz", func() {
+			code, js, err := getProbe(s, "/healthz")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should succeed", func() {
+				So(code, ShouldEqual, http.StatusOK)
+				So(js["status"], ShouldEqual, "ok")
+			})
+		})
+
+		Convey("When getting readyz without topologies", func() {
+			code, js, err := getProbe(s, "/readyz")
+			So(err, ShouldBeNil)
+
+			Convey("Then the server should be ready", func() {
+				So(code, ShouldEqual, http.StatusOK)
+				So(js["ready"], ShouldBeTrue)
+			})
+		})
+
+		Convey("When creating a topology with a paused source", func() {
+			res, _, err := do(r, Post, "/topologies", map[string]interface{}{
+				"name": "test_topology",
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			Reset(func() {
+				do(r, Delete, "/topologies/test_topology", nil)
+			})
+			res, _, err = do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+				"queries": `CREATE PAUSED SOURCE test_source TYPE dummy;`,
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+
+			Convey("Then the server should be ready", func() {
+				code, js, err := getProbe(s, "/readyz")
+				So(err, ShouldBeNil)
+				So(code, ShouldEqual, http.StatusOK)
+				So(js["ready"], ShouldBeTrue)
+				So(jscan(js, "/topologies/test_topology/nodes/test_source/state"), ShouldEqual, "paused")
+			})
+
+			Convey("And the source stops after emitting all tuples", func() {
+				res, _, err := do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+					"queries": `RESUME SOURCE test_source;`,
+				})
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+
+				Convey("Then the server shouldn't be ready", func() {
+					var (
+						code int
+						js   map[string]interface{}
+					)
+					for i := 0; i < 100; i++ {
+						code, js, err = getProbe(s, "/readyz")
+						So(err, ShouldBeNil)
+						if code != http.StatusOK {
+							break
+						}
+						time.Sleep(10 * time.Millisecond)
+					}
+					So(code, ShouldEqual, http.StatusServiceUnavailable)
+					So(js["ready"], ShouldBeFalse)
+					So(jscan(js, "/topologies/test_topology/ready"), ShouldBeFalse)
+					So(jscan(js, "/topologies/test_topology/nodes/test_source/ready"), ShouldBeFalse)
+				})
+			})
+		})
+	})
+}
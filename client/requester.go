@@ -17,6 +17,8 @@ type Requester struct {
 	cli    *http.Client
 	url    string
 	prefix string
+	apiKey string
+	token  string
 }
 
 // NewRequester creates a new requester
@@ -44,6 +46,22 @@ func NewRequesterWithClient(url, version string, cli *http.Client) (*Requester,
 	}, nil
 }
 
+// WithAPIKey returns a copy of the requester which sends requests with the
+// API key.
+func (r *Requester) WithAPIKey(key string) *Requester {
+	c := *r
+	c.apiKey = key
+	return &c
+}
+
+// WithToken returns a copy of the requester which sends requests with the
+// bearer token such as a JSON Web Token.
+func (r *Requester) WithToken(token string) *Requester {
+	c := *r
+	c.token = token
+	return &c
+}
+
 // Do sends a JSON request to server. The caller has to close the body of
 // the response.
 func (r *Requester) Do(method Method, path string, body interface{}) (*Response, error) {
@@ -73,6 +91,12 @@ func (r *Requester) NewRequest(method Method, apiPath string, bodyJSON interface
 		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("X-Sensorbee-API-Key", r.apiKey)
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
 	return req, nil
 }
 
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counterState counts tuples written to it.
+type counterState struct {
+	n int64
+}
+
+func (s *counterState) Terminate(ctx *core.Context) error {
+	return nil
+}
+
+func (s *counterState) Write(ctx *core.Context, t *core.Tuple) error {
+	atomic.AddInt64(&s.n, 1)
+	return nil
+}
+
+func (s *counterState) Save(ctx *core.Context, w io.Writer, params data.Map) error {
+	return binary.Write(w, binary.LittleEndian, atomic.LoadInt64(&s.n))
+}
+
+type counterStateCreator struct{}
+
+func (c *counterStateCreator) CreateState(ctx *core.Context, params data.Map) (core.SharedState, error) {
+	return &counterState{}, nil
+}
+
+func (c *counterStateCreator) LoadState(ctx *core.Context, r io.Reader, params data.Map) (core.SharedState, error) {
+	s := &counterState{}
+	if err := binary.Read(r, binary.LittleEndian, &s.n); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func init() {
+	udf.MustRegisterGlobalUDSCreator("test_counter", &counterStateCreator{})
+}
+
+// failableTransport fails all requests after fail is set to 1.
+type failableTransport struct {
+	t    http.RoundTripper
+	fail int32
+}
+
+func (f *failableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.LoadInt32(&f.fail) != 0 {
+		return nil, errors.New("the primary server is down")
+	}
+	return f.t.RoundTrip(req)
+}
+
+func TestStandby(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sensorbee_standby_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bqlFile := filepath.Join(dir, "replication_test.bql")
+	if err := ioutil.WriteFile(bqlFile, []byte(`
+LOAD STATE c TYPE test_counter OR CREATE IF NOT SAVED;
+CREATE SOURCE src TYPE rewindable_dummy;
+CREATE SINK snk TYPE uds WITH name="c";
+INSERT INTO snk FROM src;
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	topologies := data.Map{
+		"replication_test": data.Map{"bql_file": data.String(bqlFile)},
+	}
+
+	pc, err := config.New(data.Map{"topologies": topologies})
+	if err != nil {
+		t.Fatal(err)
+	}
+	primary := testutil.NewServerWithConfig(pc)
+	defer primary.Close()
+	r := newTestRequester(primary)
+
+	Convey("Given a primary server having a topology", t, func() {
+		var js map[string]interface{}
+		for i := 0; i < 1000; i++ {
+			var res *Response
+			res, js, err = do(r, Get, "/topologies/replication_test/replication", nil)
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			if jscan(js, "/offsets/src") == 4.0 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		Convey("When getting a replication snapshot", func() {
+			Convey("Then it should have states and committed offsets", func() {
+				So(jscan(js, "/topology"), ShouldEqual, "replication_test")
+				So(jscan(js, "/states/c/type"), ShouldEqual, "test_counter")
+				So(jscan(js, "/states/c/data"), ShouldNotBeBlank)
+				So(jscan(js, "/offsets/src"), ShouldEqual, 4)
+			})
+		})
+
+		Convey("When running a standby server", func() {
+			sc, err := config.New(data.Map{
+				"topologies": topologies,
+				"standby": data.Map{
+					"primary":           data.String(primary.URL()),
+					"interval":          data.Float(0.01),
+					"failure_threshold": data.Int(2),
+				},
+			})
+			So(err, ShouldBeNil)
+			gvars, err := server.SetUpContextGlobalVariables(sc)
+			So(err, ShouldBeNil)
+			tr := &failableTransport{t: primary.HTTPClient().Transport}
+			if tr.t == nil {
+				tr.t = http.DefaultTransport
+			}
+			gvars.StandbyClient = &http.Client{Transport: tr}
+			standby := testutil.NewServerWithGlobalVariables(gvars)
+			Reset(func() {
+				standby.Close()
+				if tb, err := gvars.Topologies.Unregister("replication_test"); err == nil {
+					tb.Topology().Stop()
+				}
+			})
+
+			Convey("Then it shouldn't create the topology while the primary server is alive", func() {
+				time.Sleep(50 * time.Millisecond)
+				_, err := gvars.Topologies.Lookup("replication_test")
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Then it should take over the primary server after it fails", func() {
+				time.Sleep(50 * time.Millisecond)
+				atomic.StoreInt32(&tr.fail, 1)
+
+				var tb *bql.TopologyBuilder
+				for i := 0; i < 1000; i++ {
+					if tb, err = gvars.Topologies.Lookup("replication_test"); err == nil {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				So(err, ShouldBeNil)
+
+				// Tuples up to the committed offset must not be written to
+				// the state again.
+				time.Sleep(50 * time.Millisecond)
+				st, err := tb.Topology().Context().SharedStates.Get("c")
+				So(err, ShouldBeNil)
+				So(atomic.LoadInt64(&st.(*counterState).n), ShouldEqual, 4)
+			})
+		})
+	})
+}
@@ -7,9 +7,12 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 	"golang.org/x/net/websocket"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"gopkg.in/sensorbee/sensorbee.v0/server"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
 	"gopkg.in/sensorbee/sensorbee.v0/server/testutil"
 	"net/http"
 	"testing"
+	"time"
 )
 
 var jscan = testutil.JScan
@@ -155,6 +158,240 @@ func TestTopologiesCreateInvalidValues(t *testing.T) {
 	})
 }
 
+func TestTopologiesWithTenants(t *testing.T) {
+	c, err := config.New(data.Map{
+		"tenants": data.Map{
+			"team_a": data.Map{"max_tuples": data.Int(100)},
+			"team_b": data.Null{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := testutil.NewServerWithConfig(c)
+	defer s.Close()
+	r := newTestRequester(s)
+
+	doAs := func(tenant string, m Method, path string, body interface{}) (*Response, map[string]interface{}, error) {
+		req, err := r.NewRequest(m, path, body)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set(server.TenantHeader, tenant)
+		res, err := r.DoWithRequest(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		var js map[string]interface{}
+		if err := res.ReadJSON(&js); err != nil {
+			return nil, nil, err
+		}
+		return res, js, nil
+	}
+
+	Convey("Given an API server having tenants", t, func() {
+		Convey("When a tenant creates a topology", func() {
+			res, js, err := doAs("team_a", Post, "/topologies", map[string]interface{}{
+				"name": "team_a__test",
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			Reset(func() {
+				do(r, Delete, "/topologies/team_a__test", nil)
+			})
+			So(jscan(js, "/topology/name"), ShouldEqual, "team_a__test")
+
+			Convey("Then the tenant should see it", func() {
+				res, js, err := doAs("team_a", Get, "/topologies", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+				So(jscan(js, "/topologies[0]/name"), ShouldEqual, "team_a__test")
+
+				res, _, err = doAs("team_a", Get, "/topologies/team_a__test", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then other tenants shouldn't see it", func() {
+				res, js, err := doAs("team_b", Get, "/topologies", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+				So(jscan(js, "/topologies"), ShouldBeEmpty)
+
+				res, _, err = doAs("team_b", Get, "/topologies/team_a__test", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusNotFound)
+			})
+
+			Convey("Then other tenants shouldn't be able to delete it", func() {
+				res, _, err := doAs("team_b", Delete, "/topologies/team_a__test", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusNotFound)
+
+				res, _, err = do(r, Get, "/topologies/team_a__test", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then a request without a tenant should see it", func() {
+				res, js, err := do(r, Get, "/topologies", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+				So(jscan(js, "/topologies[0]/name"), ShouldEqual, "team_a__test")
+			})
+		})
+
+		Convey("When a tenant creates a topology without its prefix", func() {
+			res, js, err := doAs("team_a", Post, "/topologies", map[string]interface{}{
+				"name": "team_b__test",
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusBadRequest)
+				So(jscan(js, "/error/meta/name[0]"), ShouldNotBeBlank)
+			})
+		})
+
+		Convey("When sending a request with an unknown tenant", func() {
+			res, _, err := doAs("team_c", Get, "/topologies", nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be forbidden", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+}
+
+func TestTopologiesWithAuth(t *testing.T) {
+	c, err := config.New(data.Map{
+		"tenants": data.Map{
+			"team_a": data.Null{},
+		},
+		"auth": data.Map{
+			"api_keys": data.Array{
+				data.Map{"key": data.String("admin_key"), "name": data.String("admin"), "roles": data.Map{"*": data.String("admin")}},
+				data.Map{"key": data.String("viewer_key"), "name": data.String("viewer"), "roles": data.Map{"*": data.String("read_only")}},
+				data.Map{"key": data.String("operator_key"), "name": data.String("operator"), "roles": data.Map{"test": data.String("operator")}},
+				data.Map{"key": data.String("tenant_key"), "name": data.String("tenant"), "tenant": data.String("team_a"), "roles": data.Map{"*": data.String("admin")}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := testutil.NewServerWithConfig(c)
+	defer s.Close()
+	r := newTestRequester(s)
+	admin := r.WithAPIKey("admin_key")
+	viewer := r.WithAPIKey("viewer_key")
+	operator := r.WithAPIKey("operator_key")
+	tenant := r.WithAPIKey("tenant_key")
+
+	Convey("Given an API server requiring authentication", t, func() {
+		Convey("When sending a request without credentials", func() {
+			res, _, err := do(r, Get, "/topologies", nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+
+		Convey("When sending a request with an invalid API key", func() {
+			res, _, err := do(r.WithAPIKey("invalid"), Get, "/topologies", nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+
+		Convey("When an admin creates a topology", func() {
+			res, _, err := do(admin, Post, "/topologies", map[string]interface{}{
+				"name": "test",
+			})
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			Reset(func() {
+				do(admin, Delete, "/topologies/test", nil)
+			})
+
+			Convey("Then a read-only principal should view it", func() {
+				res, _, err := do(viewer, Get, "/topologies/test", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then a read-only principal shouldn't issue queries", func() {
+				res, _, err := do(viewer, Post, "/topologies/test/queries", map[string]interface{}{
+					"queries": "CREATE SOURCE s TYPE dummy;",
+				})
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+
+			Convey("Then an operator should issue queries", func() {
+				res, _, err := do(operator, Post, "/topologies/test/queries", map[string]interface{}{
+					"queries": "CREATE SOURCE s TYPE dummy;",
+				})
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then an operator shouldn't drop it", func() {
+				res, _, err := do(operator, Delete, "/topologies/test", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+
+			Convey("Then a principal of a tenant shouldn't see it", func() {
+				res, js, err := do(tenant, Get, "/topologies", nil)
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+				So(jscan(js, "/topologies"), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an operator creates a topology", func() {
+			res, _, err := do(operator, Post, "/topologies", map[string]interface{}{
+				"name": "test2",
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be forbidden", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+		})
+
+		Convey("When a principal of a tenant creates a topology in the tenant", func() {
+			res, _, err := do(tenant, Post, "/topologies", map[string]interface{}{
+				"name": "team_a__test",
+			})
+			So(err, ShouldBeNil)
+			Reset(func() {
+				do(admin, Delete, "/topologies/team_a__test", nil)
+			})
+
+			Convey("Then it should succeed", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When a principal of a tenant creates a topology outside the tenant", func() {
+			res, _, err := do(tenant, Post, "/topologies", map[string]interface{}{
+				"name": "test3",
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
 func TestTopologiesQueries(t *testing.T) {
 	s := testutil.NewServer()
 	defer s.Close()
@@ -248,6 +485,33 @@ func TestTopologiesQueriesSelectStmt(t *testing.T) {
 			})
 		})
 
+		Convey("When issueing a SELECT stmt and closing the connection", func() {
+			streamRes, err := r.Do(Post, "/topologies/test_topology/queries", map[string]interface{}{
+				"queries": `SELECT ISTREAM * FROM source [RANGE 1 TUPLES];`,
+			})
+			So(err, ShouldBeNil)
+			So(streamRes.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			sink := streamRes.Raw.Header.Get("X-Sensorbee-Select-Sink")
+			So(sink, ShouldNotBeBlank)
+
+			res, _, err := do(r, Get, "/topologies/test_topology/sinks/"+sink, nil)
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			So(streamRes.Close(), ShouldBeNil)
+
+			Convey("Then the temporary sink should be removed", func() {
+				for i := 0; ; i++ {
+					So(i, ShouldBeLessThan, 500)
+					res, _, err := do(r, Get, "/topologies/test_topology/sinks/"+sink, nil)
+					So(err, ShouldBeNil)
+					if res.Raw.StatusCode == http.StatusNotFound {
+						break
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			})
+		})
+
 		// TODO: add invalid cases
 	})
 }
@@ -377,6 +641,120 @@ func TestTopologiesQueriesEvalStmt(t *testing.T) {
 	})
 }
 
+func TestTopologiesQueriesShowStmt(t *testing.T) {
+	s := testutil.NewServer()
+	defer s.Close()
+	r := newTestRequester(s)
+
+	Convey("Given an API server with a topology having a paused source", t, func() {
+		res, _, err := do(r, Post, "/topologies", map[string]interface{}{
+			"name": "test_topology",
+		})
+		So(err, ShouldBeNil)
+		So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+		Reset(func() {
+			do(r, Delete, "/topologies/test_topology", nil)
+		})
+
+		res, _, err = do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+			"queries": `CREATE PAUSED SOURCE source TYPE dummy;`,
+		})
+		So(err, ShouldBeNil)
+		So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+
+		Convey("When issuing a SHOW SOURCES statement", func() {
+			res, js, err := do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+				"queries": `SHOW SOURCES`,
+			})
+
+			Convey("Then the result should have the source", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+				rows, ok := js["result"].([]interface{})
+				So(ok, ShouldBeTrue)
+				So(len(rows), ShouldEqual, 1)
+				row := rows[0].(map[string]interface{})
+				So(row["name"], ShouldEqual, "source")
+				So(row["type"], ShouldEqual, "dummy")
+				So(row["status"], ShouldEqual, "paused")
+			})
+		})
+
+		Convey("When issuing a SHOW statement with another statement", func() {
+			res, _, err := do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+				"queries": `SHOW SOURCES; SHOW SINKS;`,
+			})
+
+			Convey("Then the statement should not execute", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When issuing a SHOW statement for a missing topology", func() {
+			res, _, err := do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
+				"queries": `SHOW SOURCES IN no_such_topology`,
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestTopologiesScripts(t *testing.T) {
+	s := testutil.NewServer()
+	defer s.Close()
+	r := newTestRequester(s)
+
+	Convey("Given an API server with a topology", t, func() {
+		res, _, err := do(r, Post, "/topologies", map[string]interface{}{
+			"name": "test_topology",
+		})
+		So(err, ShouldBeNil)
+		So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+		Reset(func() {
+			do(r, Delete, "/topologies/test_topology", nil)
+		})
+
+		countSources := func() float64 {
+			res, js, err := do(r, Get, "/topologies/test_topology/sources", nil)
+			So(err, ShouldBeNil)
+			So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+			return js["count"].(float64)
+		}
+
+		Convey("When applying a valid script", func() {
+			res, js, err := do(r, Post, "/topologies/test_topology/scripts", map[string]interface{}{
+				"queries": `CREATE SOURCE source TYPE dummy; CREATE SINK sink TYPE stdout; INSERT INTO sink FROM source;`,
+			})
+
+			Convey("Then it should succeed", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusOK)
+				So(js["queries"], ShouldHaveLength, 3)
+				So(countSources(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When applying a script having an invalid statement", func() {
+			res, js, err := do(r, Post, "/topologies/test_topology/scripts", map[string]interface{}{
+				"queries": `CREATE SOURCE source TYPE dummy; INSERT INTO no_such_sink FROM source;`,
+			})
+
+			Convey("Then it should fail without creating any node", func() {
+				So(err, ShouldBeNil)
+				So(res.Raw.StatusCode, ShouldEqual, http.StatusBadRequest)
+				e := js["error"].(map[string]interface{})
+				So(e["meta"].(map[string]interface{})["statement_index"], ShouldEqual, 1)
+				So(countSources(), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
 func TestTopologiesQueriesSelectStmtWebSocket(t *testing.T) {
 	// TODO: Because results from a SELECT stmt needs to be returned through
 	// hijacking, a real HTTP server is required. Support Hijack method in test
@@ -426,6 +804,7 @@ func TestTopologiesQueriesSelectStmtWebSocket(t *testing.T) {
 			So(websocket.JSON.Receive(conn, &js), ShouldBeNil)
 			So(jscan(js, "/rid"), ShouldEqual, 123)
 			So(jscan(js, "/type"), ShouldEqual, "sos")
+			So(jscan(js, "/payload/sink"), ShouldStartWith, "sensorbee_tmp_select_sink_")
 
 			res, _, err := do(r, Post, "/topologies/test_topology/queries", map[string]interface{}{
 				"queries": `RESUME SOURCE source;`,
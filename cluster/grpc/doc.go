@@ -0,0 +1,10 @@
+// Package grpc registers the "grpc" transport of the cluster package, which
+// carries tuples between workers over gRPC streams. Since it adds a
+// dependency, the transport is only built with the grpc build tag:
+//
+//	go build -tags grpc
+//
+// Import this package for side effects to register the transport:
+//
+//	import _ "gopkg.in/sensorbee/sensorbee.v0/cluster/grpc"
+package grpc
@@ -0,0 +1,290 @@
+//go:build grpc
+// +build grpc
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/sensorbee/sensorbee.v0/cluster"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	edgeMethod   = "/sensorbee.cluster.Edge/Send"
+	edgeMetadata = "sensorbee-edge"
+)
+
+// Transport is a cluster.Transport carrying tuples over gRPC. Each sender
+// opens a bidirectional stream of the method "/sensorbee.cluster.Edge/Send"
+// having the name of the edge in the "sensorbee-edge" metadata. Messages
+// aren't protocol buffers but raw frames:
+//
+//   - handshake: the receiver sends an empty frame when it receives tuples
+//     of the edge, or fails the stream with codes.NotFound.
+//   - tuple: the sender sends a frame encoded by cluster.TupleEncoder, and
+//     the receiver replies a frame which is empty on success or has the
+//     error message.
+type Transport struct {
+	// Timeout is the timeout of connecting to a worker and of sending a
+	// tuple. There's no timeout when it's 0.
+	Timeout time.Duration
+
+	// ServerOptions are options of the server created by Listen.
+	ServerOptions []grpc.ServerOption
+
+	// DialOptions are options of connections created by Dial. Connections
+	// are insecure when they don't have transport credentials.
+	DialOptions []grpc.DialOption
+}
+
+var _ cluster.Transport = &Transport{}
+
+// Listen listens on the TCP address and serves the edge service on it.
+func (tr *Transport) Listen(addr string, open func(edge string) (cluster.EdgeReceiver, error)) (cluster.Listener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]grpc.ServerOption{grpc.ForceServerCodec(frameCodec{})}, tr.ServerOptions...)
+	gl := &listener{
+		l:      l,
+		server: grpc.NewServer(opts...),
+		open:   open,
+	}
+	gl.server.RegisterService(&edgeServiceDesc, gl)
+	gl.wg.Add(1)
+	go func() {
+		defer gl.wg.Done()
+		gl.server.Serve(l)
+	}()
+	return gl, nil
+}
+
+// Dial opens a stream to the edge of the worker listening on the address.
+func (tr *Transport) Dial(addr, edge string) (cluster.EdgeSender, error) {
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(frameCodec{})),
+	}, tr.DialOptions...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(context.Background(), edgeMetadata, edge))
+	s := &sender{
+		conn:    conn,
+		cancel:  cancel,
+		enc:     cluster.NewTupleEncoder(),
+		timeout: tr.Timeout,
+	}
+	err = s.withTimeout(func() error {
+		st, err := conn.NewStream(ctx, &edgeServiceDesc.Streams[0], edgeMethod)
+		if err != nil {
+			return err
+		}
+		s.stream = st
+		return st.RecvMsg(&frame{})
+	})
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, statusError(err)
+	}
+	return s, nil
+}
+
+// edgeService is the handler type of the edge service.
+type edgeService interface {
+	serve(stream grpc.ServerStream) error
+}
+
+var edgeServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sensorbee.cluster.Edge",
+	HandlerType: (*edgeService)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "Send",
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(edgeService).serve(stream)
+			},
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+type listener struct {
+	l      net.Listener
+	server *grpc.Server
+	open   func(edge string) (cluster.EdgeReceiver, error)
+	wg     sync.WaitGroup
+}
+
+func (gl *listener) Addr() string {
+	return gl.l.Addr().String()
+}
+
+// serve receives tuples sent on the stream until it's closed.
+func (gl *listener) serve(stream grpc.ServerStream) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	edges := md.Get(edgeMetadata)
+	if len(edges) != 1 {
+		return status.Error(codes.InvalidArgument, "the name of the edge must be specified")
+	}
+	recv, err := gl.open(edges[0])
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err := stream.SendMsg(&frame{}); err != nil {
+		return err
+	}
+
+	dec := cluster.NewTupleDecoder()
+	for {
+		f := &frame{}
+		if err := stream.RecvMsg(f); err != nil {
+			return nil
+		}
+		t, err := dec.Decode(f.b)
+		if err != nil {
+			// The dictionary might be inconsistent with the sender's one.
+			stream.SendMsg(replyFrame(err))
+			return nil
+		}
+		if err := stream.SendMsg(replyFrame(recv.Receive(t))); err != nil {
+			return err
+		}
+	}
+}
+
+func (gl *listener) Close() error {
+	gl.server.Stop()
+	gl.wg.Wait()
+	return nil
+}
+
+type sender struct {
+	m       sync.Mutex
+	conn    *grpc.ClientConn
+	stream  grpc.ClientStream
+	cancel  func()
+	enc     *cluster.TupleEncoder
+	timeout time.Duration
+	broken  error
+}
+
+func (s *sender) Send(t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.broken != nil {
+		return fmt.Errorf("the stream has already failed: %v", s.broken)
+	}
+
+	reply := &frame{}
+	err := s.withTimeout(func() error {
+		if err := s.stream.SendMsg(&frame{b: s.enc.Encode(t)}); err != nil {
+			return err
+		}
+		return s.stream.RecvMsg(reply)
+	})
+	if err == nil && len(reply.b) > 0 {
+		err = errors.New(string(reply.b))
+	}
+	if err != nil {
+		err = statusError(err)
+		s.broken = err
+		s.cancel()
+		s.conn.Close()
+	}
+	return err
+}
+
+// withTimeout calls f and cancels the stream when it doesn't return within
+// the timeout.
+func (s *sender) withTimeout(f func() error) error {
+	if s.timeout <= 0 {
+		return f()
+	}
+	timer := time.AfterFunc(s.timeout, s.cancel)
+	err := f()
+	if !timer.Stop() && err != nil {
+		return fmt.Errorf("timed out after %v: %v", s.timeout, err)
+	}
+	return err
+}
+
+func (s *sender) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.broken == nil {
+		s.broken = errors.New("the sender is already closed")
+	}
+	s.cancel()
+	return s.conn.Close()
+}
+
+// frame is a message of the edge service.
+type frame struct {
+	b []byte
+}
+
+func replyFrame(err error) *frame {
+	if err == nil {
+		return &frame{}
+	}
+	msg := err.Error()
+	if msg == "" {
+		msg = "unknown error"
+	}
+	return &frame{b: []byte(msg)}
+}
+
+// frameCodec is a codec passing frames through as they are.
+type frameCodec struct{}
+
+func (frameCodec) Marshal(v interface{}) ([]byte, error) {
+	f, ok := v.(*frame)
+	if !ok {
+		return nil, fmt.Errorf("unsupported message type: %T", v)
+	}
+	return f.b, nil
+}
+
+func (frameCodec) Unmarshal(b []byte, v interface{}) error {
+	f, ok := v.(*frame)
+	if !ok {
+		return fmt.Errorf("unsupported message type: %T", v)
+	}
+	// b might be reused by gRPC.
+	f.b = append([]byte(nil), b...)
+	return nil
+}
+
+func (frameCodec) Name() string {
+	return "sensorbee"
+}
+
+// statusError returns the message of a gRPC status as an error.
+func statusError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return errors.New(st.Message())
+	}
+	return err
+}
+
+func init() {
+	cluster.MustRegisterTransport("grpc", func(timeout time.Duration) cluster.Transport {
+		return &Transport{Timeout: timeout}
+	})
+}
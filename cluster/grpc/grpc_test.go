@@ -0,0 +1,147 @@
+//go:build grpc
+// +build grpc
+
+package grpc
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/cluster"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testEdgeReceiver struct {
+	m      sync.Mutex
+	tuples []*core.Tuple
+	err    error
+}
+
+func (r *testEdgeReceiver) Receive(t *core.Tuple) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.tuples = append(r.tuples, t)
+	return nil
+}
+
+func (r *testEdgeReceiver) received() []*core.Tuple {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]*core.Tuple(nil), r.tuples...)
+}
+
+func TestTransport(t *testing.T) {
+	Convey("Given a gRPC transport listening on an address", t, func() {
+		tr := &Transport{Timeout: 5 * time.Second}
+		recv := &testEdgeReceiver{}
+		l, err := tr.Listen("127.0.0.1:0", func(edge string) (cluster.EdgeReceiver, error) {
+			if edge != "e1" {
+				return nil, errors.New("no such edge")
+			}
+			return recv, nil
+		})
+		So(err, ShouldBeNil)
+		Reset(func() {
+			l.Close()
+		})
+
+		Convey("When dialing an edge which doesn't exist", func() {
+			_, err := tr.Dial(l.Addr(), "e2")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "no such edge")
+			})
+		})
+
+		Convey("When sending tuples to an edge", func() {
+			s, err := tr.Dial(l.Addr(), "e1")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Close()
+			})
+			now := time.Now()
+			for i := 0; i < 3; i++ {
+				So(s.Send(&core.Tuple{
+					Data:      data.Map{"n": data.Int(i)},
+					Timestamp: now,
+					Metadata:  data.Map{"m": data.String("x")},
+				}), ShouldBeNil)
+			}
+
+			Convey("Then the receiver should receive them in order", func() {
+				ts := recv.received()
+				So(ts, ShouldHaveLength, 3)
+				for i, t := range ts {
+					So(t.Data, ShouldResemble, data.Map{"n": data.Int(i)})
+					So(t.Metadata, ShouldResemble, data.Map{"m": data.String("x")})
+					So(t.Timestamp.Equal(now), ShouldBeTrue)
+				}
+			})
+
+			Convey("And the receiver fails", func() {
+				recv.m.Lock()
+				recv.err = errors.New("receiver failed")
+				recv.m.Unlock()
+				err := s.Send(&core.Tuple{Data: data.Map{"n": data.Int(3)}})
+
+				Convey("Then the error should be returned", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, "receiver failed")
+				})
+
+				Convey("Then the sender shouldn't be used again", func() {
+					So(s.Send(&core.Tuple{Data: data.Map{}}), ShouldNotBeNil)
+				})
+			})
+
+			Convey("And the listener is closed", func() {
+				So(l.Close(), ShouldBeNil)
+
+				Convey("Then sending a tuple should fail", func() {
+					So(s.Send(&core.Tuple{Data: data.Map{}}), ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("When multiple senders send tuples concurrently", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				i := i
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s, err := tr.Dial(l.Addr(), "e1")
+					if err != nil {
+						return
+					}
+					defer s.Close()
+					for j := 0; j < 10; j++ {
+						s.Send(&core.Tuple{Data: data.Map{"sender": data.Int(i), "n": data.Int(j)}})
+					}
+				}()
+			}
+			wg.Wait()
+
+			Convey("Then the receiver should receive all of them", func() {
+				So(recv.received(), ShouldHaveLength, 40)
+			})
+		})
+
+		Convey("When looking up the transport by its name", func() {
+			c, err := cluster.LookupTransport("grpc")
+
+			Convey("Then it should be registered", func() {
+				So(err, ShouldBeNil)
+				_, ok := c(time.Second).(*Transport)
+				So(ok, ShouldBeTrue)
+			})
+		})
+	})
+}
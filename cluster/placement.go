@@ -0,0 +1,387 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EdgePrefix is the prefix of names of edges. Names of nodes must not start
+// with it so that nodes added for edges don't conflict with them.
+const EdgePrefix = "sensorbee_edge_"
+
+// NodeSpec is a specification of a node of a distributed topology. Exactly
+// one of Source, Box, and Sink must be given. They're called by the worker
+// on which each instance of the node is placed, so they must create a new
+// node every time they're called.
+type NodeSpec struct {
+	// Name is the name of the node. It must be unique in a topology and
+	// must not start with EdgePrefix.
+	Name string
+
+	// Source creates a source.
+	Source func() (core.Source, error)
+
+	// Box creates a box.
+	Box func() (core.Box, error)
+
+	// Sink creates a sink.
+	Sink func() (core.Sink, error)
+
+	// Inputs has inputs of a box or a sink.
+	Inputs []*InputSpec
+
+	// Partitions is the number of instances of a box or a sink. When it's
+	// greater than 1, instances are named "<Name>_<index>" and each of them
+	// receives tuples assigned to its partition by the Key of each input,
+	// so that tuples having the same key are always sent to the same
+	// instance. It's regarded as 1 when it's 0.
+	Partitions int
+
+	// Weight is the relative load of each instance of the node used for
+	// placement. It's regarded as 1 when it's 0.
+	Weight int
+
+	// Worker is the name of the worker on which all instances of the node
+	// are placed. The coordinator places them when it's empty.
+	Worker string
+}
+
+// InputSpec is a specification of an input of a node.
+type InputSpec struct {
+	// From is the name of the node from which tuples are sent.
+	From string
+
+	// Key computes the partition key of a tuple. It's required when the
+	// node receiving tuples has more than one partition.
+	Key func(t *core.Tuple) (data.Value, error)
+}
+
+func (s *NodeSpec) partitions() int {
+	if s.Partitions <= 0 {
+		return 1
+	}
+	return s.Partitions
+}
+
+func (s *NodeSpec) weight() int {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
+}
+
+// instanceName returns the name of the i-th instance of the node.
+func (s *NodeSpec) instanceName(i int) string {
+	if s.partitions() == 1 {
+		return s.Name
+	}
+	return fmt.Sprintf("%v_%v", s.Name, i)
+}
+
+func (s *NodeSpec) validate() error {
+	if err := core.ValidateSymbol(s.Name); err != nil {
+		return err
+	}
+	if strings.HasPrefix(strings.ToLower(s.Name), EdgePrefix) {
+		return fmt.Errorf("the name of the node %v must not start with %v", s.Name, EdgePrefix)
+	}
+	n := 0
+	for _, f := range []bool{s.Source != nil, s.Box != nil, s.Sink != nil} {
+		if f {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("the node %v must have exactly one of a source, a box, and a sink", s.Name)
+	}
+	if s.Partitions < 0 {
+		return fmt.Errorf("the number of partitions of the node %v must not be negative: %v", s.Name, s.Partitions)
+	}
+	if s.Weight < 0 {
+		return fmt.Errorf("the weight of the node %v must not be negative: %v", s.Name, s.Weight)
+	}
+	if s.Source != nil {
+		if len(s.Inputs) > 0 {
+			return fmt.Errorf("the source %v cannot have inputs", s.Name)
+		}
+		if s.partitions() > 1 {
+			return fmt.Errorf("the source %v cannot have partitions", s.Name)
+		}
+	}
+	for _, in := range s.Inputs {
+		if s.partitions() > 1 && in.Key == nil {
+			return fmt.Errorf("the input from %v of the node %v must have the partition key", in.From, s.Name)
+		}
+	}
+	return nil
+}
+
+// WorkerInfo has information of a worker registered to a Coordinator.
+type WorkerInfo struct {
+	// Name is the name of the worker. It must be unique in a cluster.
+	Name string
+
+	// Addr is the address on which the worker receives tuples from other
+	// workers. It's passed to Transport.Dial.
+	Addr string
+
+	// Capacity is the relative capacity of the worker used for placement.
+	// It's regarded as 1 when it's 0.
+	Capacity int
+}
+
+func (w *WorkerInfo) capacity() int {
+	if w.Capacity <= 0 {
+		return 1
+	}
+	return w.Capacity
+}
+
+// Instance is an instance of a node placed on a worker.
+type Instance struct {
+	// Name is the name of the node in the topology of the worker.
+	Name string
+
+	// Node is the specification of the node.
+	Node *NodeSpec
+
+	// Index is the index of the partition of the instance.
+	Index int
+
+	// Worker is the name of the worker.
+	Worker string
+}
+
+// Edge is a connection from an instance to another.
+type Edge struct {
+	// Name is the name of the edge unique in the placement. It's used as
+	// the name of the nodes sending and receiving tuples of the edge when
+	// the instances are placed on different workers. It has EdgePrefix,
+	// the index of the edge, and names of the instances, or only the index
+	// when the name would be too long for a node.
+	Name string
+
+	// From is the name of the instance sending tuples.
+	From string
+
+	// To is the name of the instance receiving tuples.
+	To string
+
+	// InputName is From of the InputSpec of the edge. Boxes receive tuples
+	// having it as their input name, so that a box can tell inputs apart
+	// by names of nodes regardless of partitions and workers.
+	InputName string
+
+	// Partition has the partition of the receiving instance. It's nil when
+	// the node receiving tuples isn't partitioned.
+	Partition *core.PartitionConfig
+}
+
+// Placement is an assignment of instances of nodes to workers computed by
+// Coordinator.Place.
+type Placement struct {
+	// Workers has workers registered to the coordinator when the placement
+	// was computed. Keys are names of workers.
+	Workers map[string]*WorkerInfo
+
+	// Instances has all instances. Keys are names of instances.
+	Instances map[string]*Instance
+
+	// Edges has all edges in the order of the nodes and their inputs.
+	Edges []*Edge
+}
+
+// Remote returns true when the instances of the edge are placed on different
+// workers.
+func (p *Placement) Remote(e *Edge) bool {
+	return p.Instances[e.From].Worker != p.Instances[e.To].Worker
+}
+
+// InstancesOf returns instances placed on the worker sorted by their names.
+func (p *Placement) InstancesOf(worker string) []*Instance {
+	var is []*Instance
+	for _, i := range p.Instances {
+		if i.Worker == worker {
+			is = append(is, i)
+		}
+	}
+	sort.Sort(instancesByName(is))
+	return is
+}
+
+type instancesByName []*Instance
+
+func (is instancesByName) Len() int           { return len(is) }
+func (is instancesByName) Less(i, j int) bool { return is[i].Name < is[j].Name }
+func (is instancesByName) Swap(i, j int)      { is[i], is[j] = is[j], is[i] }
+
+// Coordinator places nodes of a topology on workers registered to it.
+type Coordinator struct {
+	m       sync.RWMutex
+	workers map[string]*WorkerInfo
+}
+
+// NewCoordinator creates a Coordinator having no worker.
+func NewCoordinator() *Coordinator {
+	return &Coordinator{
+		workers: map[string]*WorkerInfo{},
+	}
+}
+
+// AddWorker registers a worker. It fails when a worker having the same name
+// has already been registered.
+func (c *Coordinator) AddWorker(w *WorkerInfo) error {
+	if err := core.ValidateSymbol(w.Name); err != nil {
+		return err
+	}
+	if w.Capacity < 0 {
+		return fmt.Errorf("the capacity of the worker %v must not be negative: %v", w.Name, w.Capacity)
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	name := strings.ToLower(w.Name)
+	if _, ok := c.workers[name]; ok {
+		return fmt.Errorf("the worker %v is already registered", w.Name)
+	}
+	cp := *w
+	c.workers[name] = &cp
+	return nil
+}
+
+// RemoveWorker unregisters the worker. Placements computed before don't
+// change.
+func (c *Coordinator) RemoveWorker(name string) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	name = strings.ToLower(name)
+	if _, ok := c.workers[name]; !ok {
+		return core.NotExistError(fmt.Errorf("the worker %v isn't registered", name))
+	}
+	delete(c.workers, name)
+	return nil
+}
+
+// Workers returns workers registered to the coordinator. Keys of the map are
+// names of workers.
+func (c *Coordinator) Workers() map[string]*WorkerInfo {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	ws := make(map[string]*WorkerInfo, len(c.workers))
+	for name, w := range c.workers {
+		cp := *w
+		ws[name] = &cp
+	}
+	return ws
+}
+
+// Place assigns instances of nodes to workers. Instances of nodes having
+// Worker are placed on the worker. Other instances are placed in the order
+// of nodes on the worker which has the least load relative to its capacity
+// after placing the instance, so instances of a partitioned node are spread
+// across workers. Ties are broken by names of workers so that the placement
+// is deterministic.
+func (c *Coordinator) Place(nodes []*NodeSpec) (*Placement, error) {
+	workers := c.Workers()
+	if len(workers) == 0 {
+		return nil, errors.New("no worker is registered")
+	}
+	names := make([]string, 0, len(workers))
+	for name := range workers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	specs := map[string]*NodeSpec{}
+	for _, n := range nodes {
+		if err := n.validate(); err != nil {
+			return nil, err
+		}
+		name := strings.ToLower(n.Name)
+		if _, ok := specs[name]; ok {
+			return nil, fmt.Errorf("the node %v is defined more than once", n.Name)
+		}
+		specs[name] = n
+	}
+
+	p := &Placement{
+		Workers:   workers,
+		Instances: map[string]*Instance{},
+	}
+	loads := map[string]int{}
+	for _, n := range nodes {
+		for i := 0; i < n.partitions(); i++ {
+			w := strings.ToLower(n.Worker)
+			if w != "" {
+				if _, ok := workers[w]; !ok {
+					return nil, fmt.Errorf("the worker %v of the node %v isn't registered", n.Worker, n.Name)
+				}
+			} else {
+				best := 0.0
+				for _, name := range names {
+					l := float64(loads[name]+n.weight()) / float64(workers[name].capacity())
+					if w == "" || l < best {
+						w, best = name, l
+					}
+				}
+			}
+			loads[w] += n.weight()
+
+			inst := &Instance{
+				Name:   n.instanceName(i),
+				Node:   n,
+				Index:  i,
+				Worker: w,
+			}
+			if _, ok := p.Instances[strings.ToLower(inst.Name)]; ok {
+				return nil, fmt.Errorf("the instance %v conflicts with another node", inst.Name)
+			}
+			p.Instances[strings.ToLower(inst.Name)] = inst
+		}
+	}
+
+	for _, n := range nodes {
+		for _, in := range n.Inputs {
+			from, ok := specs[strings.ToLower(in.From)]
+			if !ok {
+				return nil, fmt.Errorf("the input %v of the node %v doesn't exist", in.From, n.Name)
+			}
+			if from.Sink != nil {
+				return nil, fmt.Errorf("the sink %v cannot be an input of the node %v", in.From, n.Name)
+			}
+			for i := 0; i < from.partitions(); i++ {
+				for j := 0; j < n.partitions(); j++ {
+					e := &Edge{
+						From:      strings.ToLower(from.instanceName(i)),
+						To:        strings.ToLower(n.instanceName(j)),
+						InputName: in.From,
+					}
+					e.Name = edgeName(len(p.Edges), e.From, e.To)
+					if n.partitions() > 1 {
+						e.Partition = &core.PartitionConfig{
+							Key:   in.Key,
+							Count: n.partitions(),
+							Index: j,
+						}
+					}
+					p.Edges = append(p.Edges, e)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// edgeName returns the name of the i-th edge. The index makes the name
+// unique even when names of instances contain "_to_".
+func edgeName(i int, from, to string) string {
+	name := fmt.Sprintf("%v%v_%v_to_%v", EdgePrefix, i, from, to)
+	if len(name) > 127 {
+		return fmt.Sprint(EdgePrefix, i)
+	}
+	return name
+}
@@ -0,0 +1,177 @@
+package cluster
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"testing"
+)
+
+func testSourceSpec(name string) *NodeSpec {
+	return &NodeSpec{
+		Name:   name,
+		Source: func() (core.Source, error) { return newEdgeSource(), nil },
+	}
+}
+
+func testBoxSpec(name string, inputs ...*InputSpec) *NodeSpec {
+	return &NodeSpec{
+		Name: name,
+		Box: func() (core.Box, error) {
+			return core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+				return w.Write(ctx, t)
+			}), nil
+		},
+		Inputs: inputs,
+	}
+}
+
+func testKey(t *core.Tuple) (data.Value, error) {
+	return t.Data.Get(data.MustCompilePath("key"))
+}
+
+func TestCoordinator(t *testing.T) {
+	Convey("Given a coordinator", t, func() {
+		c := NewCoordinator()
+
+		Convey("When placing nodes without workers", func() {
+			_, err := c.Place([]*NodeSpec{testSourceSpec("src")})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When adding workers", func() {
+			So(c.AddWorker(&WorkerInfo{Name: "w1", Addr: "a1"}), ShouldBeNil)
+			So(c.AddWorker(&WorkerInfo{Name: "w2", Addr: "a2", Capacity: 2}), ShouldBeNil)
+
+			Convey("Then they should be registered", func() {
+				ws := c.Workers()
+				So(ws, ShouldHaveLength, 2)
+				So(ws["w2"].Addr, ShouldEqual, "a2")
+			})
+
+			Convey("Then adding a worker having the same name should fail", func() {
+				So(c.AddWorker(&WorkerInfo{Name: "W1"}), ShouldNotBeNil)
+			})
+
+			Convey("Then adding a worker having an invalid name should fail", func() {
+				So(c.AddWorker(&WorkerInfo{Name: "a-b"}), ShouldNotBeNil)
+			})
+
+			Convey("Then removing a worker should unregister it", func() {
+				So(c.RemoveWorker("W1"), ShouldBeNil)
+				So(c.Workers(), ShouldHaveLength, 1)
+				So(core.IsNotExist(c.RemoveWorker("w1")), ShouldBeTrue)
+			})
+
+			Convey("Then placing invalid nodes should fail", func() {
+				for name, nodes := range map[string][]*NodeSpec{
+					"duplicated names": {testSourceSpec("a"), testSourceSpec("A")},
+					"no factory":       {{Name: "a"}},
+					"two factories": {{Name: "a",
+						Source: testSourceSpec("a").Source, Box: testBoxSpec("a").Box}},
+					"missing input":          {testBoxSpec("b", &InputSpec{From: "a"})},
+					"partitioned source":     {{Name: "a", Source: testSourceSpec("a").Source, Partitions: 2}},
+					"source having inputs":   {{Name: "a", Source: testSourceSpec("a").Source, Inputs: []*InputSpec{{From: "b"}}}},
+					"partitions without key": {testSourceSpec("a"), {Name: "b", Box: testBoxSpec("b").Box, Partitions: 2, Inputs: []*InputSpec{{From: "a"}}}},
+					"unknown worker":         {{Name: "a", Source: testSourceSpec("a").Source, Worker: "w3"}},
+					"negative weight":        {{Name: "a", Source: testSourceSpec("a").Source, Weight: -1}},
+					"reserved prefix":        {testSourceSpec("Sensorbee_Edge_0_a_to_b")},
+				} {
+					Convey("Then it should fail with "+name, func() {
+						_, err := c.Place(nodes)
+						So(err, ShouldNotBeNil)
+					})
+				}
+			})
+
+			Convey("Then placing nodes should balance them by capacities", func() {
+				p, err := c.Place([]*NodeSpec{
+					testSourceSpec("src"),
+					testBoxSpec("b1", &InputSpec{From: "src"}),
+					testBoxSpec("b2", &InputSpec{From: "b1"}),
+				})
+				So(err, ShouldBeNil)
+				So(p.Instances["src"].Worker, ShouldEqual, "w2")
+				So(p.Instances["b1"].Worker, ShouldEqual, "w1")
+				So(p.Instances["b2"].Worker, ShouldEqual, "w2")
+				So(p.InstancesOf("w2"), ShouldHaveLength, 2)
+
+				So(p.Edges, ShouldHaveLength, 2)
+				So(p.Edges[0].Name, ShouldEqual, "sensorbee_edge_0_src_to_b1")
+				So(p.Edges[1].Name, ShouldEqual, "sensorbee_edge_1_b1_to_b2")
+				So(p.Remote(p.Edges[0]), ShouldBeTrue)
+				So(p.Edges[0].Partition, ShouldBeNil)
+			})
+
+			Convey("Then edges should have unique names even when names of nodes contain the separator", func() {
+				p, err := c.Place([]*NodeSpec{
+					testSourceSpec("a_to_b"),
+					testSourceSpec("a"),
+					testBoxSpec("c", &InputSpec{From: "a_to_b"}),
+					testBoxSpec("b_to_c", &InputSpec{From: "a"}),
+				})
+				So(err, ShouldBeNil)
+				So(p.Edges[0].Name, ShouldNotEqual, p.Edges[1].Name)
+			})
+
+			Convey("Then an edge having long names of nodes should only have the index", func() {
+				long := strings.Repeat("a", 120)
+				p, err := c.Place([]*NodeSpec{testSourceSpec(long), testBoxSpec("b", &InputSpec{From: long})})
+				So(err, ShouldBeNil)
+				So(p.Edges[0].Name, ShouldEqual, "sensorbee_edge_0")
+			})
+
+			Convey("Then placing nodes pinned to a worker should place them on it", func() {
+				src := testSourceSpec("src")
+				src.Worker = "W1"
+				b := testBoxSpec("b", &InputSpec{From: "src"})
+				b.Worker = "w1"
+				p, err := c.Place([]*NodeSpec{src, b})
+				So(err, ShouldBeNil)
+				So(p.Instances["src"].Worker, ShouldEqual, "w1")
+				So(p.Instances["b"].Worker, ShouldEqual, "w1")
+				So(p.Remote(p.Edges[0]), ShouldBeFalse)
+			})
+
+			Convey("Then placing a partitioned node should spread its instances", func() {
+				src := testSourceSpec("src")
+				src.Worker = "w2"
+				b := testBoxSpec("b", &InputSpec{From: "src", Key: testKey})
+				b.Partitions = 3
+				p, err := c.Place([]*NodeSpec{src, b})
+				So(err, ShouldBeNil)
+				So(p.Instances, ShouldHaveLength, 4)
+				So(p.Instances["b_0"].Worker, ShouldEqual, "w1")
+				So(p.Instances["b_1"].Worker, ShouldEqual, "w2")
+				So(p.Instances["b_2"].Worker, ShouldEqual, "w2")
+
+				So(p.Edges, ShouldHaveLength, 3)
+				for i, e := range p.Edges {
+					So(e.From, ShouldEqual, "src")
+					So(e.To, ShouldEqual, fmt.Sprintf("b_%v", i))
+					So(e.Partition.Count, ShouldEqual, 3)
+					So(e.Partition.Index, ShouldEqual, i)
+				}
+			})
+
+			Convey("Then inputs from a partitioned node should be fanned in", func() {
+				src := testSourceSpec("src")
+				b := testBoxSpec("b", &InputSpec{From: "src", Key: testKey})
+				b.Partitions = 2
+				p, err := c.Place([]*NodeSpec{src, b, testBoxSpec("c", &InputSpec{From: "b"})})
+				So(err, ShouldBeNil)
+				So(p.Edges, ShouldHaveLength, 4)
+				So(p.Edges[2].From, ShouldEqual, "b_0")
+				So(p.Edges[3].From, ShouldEqual, "b_1")
+				So(p.Edges[3].To, ShouldEqual, "c")
+				So(p.Edges[3].Partition, ShouldBeNil)
+				So(p.Edges[3].InputName, ShouldEqual, "b")
+			})
+		})
+	})
+}
@@ -0,0 +1,279 @@
+package cluster
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxTCPFrameSize is the maximum size of a string or a tuple in a frame. It
+// prevents a broken frame from allocating a huge buffer.
+const maxTCPFrameSize = 256 << 20
+
+// TCPTransport is a Transport carrying tuples over TCP connections. Each
+// sender has its own connection, on which frames are exchanged as follows
+// where a string is a uvarint length followed by the bytes:
+//
+//   - handshake: the sender writes the name of the edge as a string, and the
+//     receiver replies a status.
+//   - tuple: the sender writes a frame encoded by TupleEncoder as a string,
+//     and the receiver replies a status after receiving the tuple.
+//
+// A status is a string which is empty on success or has the error message.
+type TCPTransport struct {
+	// Timeout is the timeout of connecting to a worker and of sending a
+	// tuple. There's no timeout when it's 0.
+	Timeout time.Duration
+}
+
+var _ Transport = &TCPTransport{}
+
+// Listen listens on the TCP address.
+func (tr *TCPTransport) Listen(addr string, open func(edge string) (EdgeReceiver, error)) (Listener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	tl := &tcpListener{
+		l:     l,
+		open:  open,
+		conns: map[net.Conn]struct{}{},
+	}
+	tl.wg.Add(1)
+	go tl.accept()
+	return tl, nil
+}
+
+// Dial connects to the TCP address.
+func (tr *TCPTransport) Dial(addr, edge string) (EdgeSender, error) {
+	conn, err := net.DialTimeout("tcp", addr, tr.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	s := &tcpSender{
+		conn:    conn,
+		r:       bufio.NewReader(conn),
+		w:       bufio.NewWriter(conn),
+		enc:     NewTupleEncoder(),
+		timeout: tr.Timeout,
+	}
+	if err := s.handshake(edge); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+type tcpListener struct {
+	l    net.Listener
+	open func(edge string) (EdgeReceiver, error)
+	wg   sync.WaitGroup
+
+	m      sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+func (tl *tcpListener) Addr() string {
+	return tl.l.Addr().String()
+}
+
+func (tl *tcpListener) accept() {
+	defer tl.wg.Done()
+	for {
+		conn, err := tl.l.Accept()
+		if err != nil {
+			return
+		}
+
+		tl.m.Lock()
+		if tl.closed {
+			tl.m.Unlock()
+			conn.Close()
+			return
+		}
+		tl.conns[conn] = struct{}{}
+		tl.wg.Add(1)
+		tl.m.Unlock()
+
+		go func() {
+			defer tl.wg.Done()
+			defer func() {
+				tl.m.Lock()
+				delete(tl.conns, conn)
+				tl.m.Unlock()
+				conn.Close()
+			}()
+			tl.serve(conn)
+		}()
+	}
+}
+
+// serve receives tuples sent on the connection until it's closed.
+func (tl *tcpListener) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(err error) error {
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+			if msg == "" {
+				msg = "unknown error"
+			}
+		}
+		writeTCPString(w, msg)
+		return w.Flush()
+	}
+
+	edge, err := readTCPString(r)
+	if err != nil {
+		return
+	}
+	recv, err := tl.open(edge)
+	if err := reply(err); err != nil || recv == nil {
+		return
+	}
+
+	dec := NewTupleDecoder()
+	for {
+		b, err := readTCPBytes(r)
+		if err != nil {
+			return
+		}
+		t, err := dec.Decode(b)
+		if err != nil {
+			// The dictionary might be inconsistent with the sender's one.
+			reply(err)
+			return
+		}
+		if err := reply(recv.Receive(t)); err != nil {
+			return
+		}
+	}
+}
+
+func (tl *tcpListener) Close() error {
+	tl.m.Lock()
+	if tl.closed {
+		tl.m.Unlock()
+		return nil
+	}
+	tl.closed = true
+	err := tl.l.Close()
+	for conn := range tl.conns {
+		conn.Close()
+	}
+	tl.m.Unlock()
+
+	tl.wg.Wait()
+	return err
+}
+
+type tcpSender struct {
+	m       sync.Mutex
+	conn    net.Conn
+	r       *bufio.Reader
+	w       *bufio.Writer
+	enc     *TupleEncoder
+	timeout time.Duration
+	broken  error
+}
+
+func (s *tcpSender) handshake(edge string) error {
+	s.setDeadline()
+	writeTCPString(s.w, edge)
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	return s.readStatus()
+}
+
+func (s *tcpSender) Send(t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.broken != nil {
+		return fmt.Errorf("the connection has already failed: %v", s.broken)
+	}
+
+	err := func() error {
+		b := s.enc.Encode(t)
+		s.setDeadline()
+		writeTCPUvarint(s.w, uint64(len(b)))
+		s.w.Write(b)
+		if err := s.w.Flush(); err != nil {
+			return err
+		}
+		return s.readStatus()
+	}()
+	if err != nil {
+		s.broken = err
+		s.conn.Close()
+	}
+	return err
+}
+
+func (s *tcpSender) setDeadline() {
+	if s.timeout > 0 {
+		s.conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+}
+
+// readStatus reads a status replied by the receiver.
+func (s *tcpSender) readStatus() error {
+	msg, err := readTCPString(s.r)
+	if err != nil {
+		return err
+	}
+	if msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (s *tcpSender) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.broken == nil {
+		s.broken = errors.New("the sender is already closed")
+	}
+	return s.conn.Close()
+}
+
+func writeTCPUvarint(w *bufio.Writer, x uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutUvarint(buf[:], x)])
+}
+
+func writeTCPString(w *bufio.Writer, s string) {
+	writeTCPUvarint(w, uint64(len(s)))
+	w.WriteString(s)
+}
+
+func readTCPBytes(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxTCPFrameSize {
+		return nil, fmt.Errorf("the frame is too large: %v bytes", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func readTCPString(r *bufio.Reader) (string, error) {
+	b, err := readTCPBytes(r)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,176 @@
+// Package cluster provides a runtime distributing nodes of a topology across
+// multiple SensorBee worker processes. A Coordinator places nodes on workers,
+// and each Worker adds the nodes placed on it to its own topology. Edges
+// between nodes on different workers are carried by a Transport, which is
+// TCPTransport or the gRPC transport provided by the grpc subpackage.
+//
+// "sensorbee cluster" command runs a worker of a topology defined by a BQL
+// file with this package.
+package cluster
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Transport carries tuples of edges between workers. An edge is identified
+// by its name, which is unique in a Placement.
+type Transport interface {
+	// Listen starts to accept tuples sent to the address. open is called
+	// when a sender connects to an edge, and it returns an error when the
+	// worker doesn't receive tuples of the edge.
+	Listen(addr string, open func(edge string) (EdgeReceiver, error)) (Listener, error)
+
+	// Dial connects to the edge of the worker listening on the address. It
+	// fails when the worker doesn't receive tuples of the edge.
+	Dial(addr, edge string) (EdgeSender, error)
+}
+
+// EdgeSender sends tuples to the worker receiving them.
+type EdgeSender interface {
+	// Send sends the tuple and waits until the receiver receives it. It
+	// returns the error returned from EdgeReceiver.Receive of the receiver.
+	// The sender can't be used after Send returns an error.
+	Send(t *core.Tuple) error
+
+	// Close closes the connection.
+	Close() error
+}
+
+// EdgeReceiver receives tuples sent to an edge.
+type EdgeReceiver interface {
+	// Receive receives the tuple. It can be called concurrently when
+	// multiple senders are connected to the edge.
+	Receive(t *core.Tuple) error
+}
+
+// Listener is a listener returned from Transport.Listen.
+type Listener interface {
+	// Addr returns the address on which the listener accepts tuples. It's
+	// the actual address when the port given to Listen is 0.
+	Addr() string
+
+	// Close stops accepting tuples and closes all connections.
+	Close() error
+}
+
+// TupleEncoder encodes tuples sent to an edge into frames which are decoded
+// by TupleDecoder. A tuple is encoded in the binary format of the data
+// package, and names of fields are assigned IDs by a FieldDictionary shared
+// with the decoder. Names added while encoding a tuple are put in the frame
+// so that the decoder can add them to its dictionary. Therefore, frames must
+// be decoded in the order in which they're encoded.
+//
+// A frame consists of a uvarint number of the added names, the names each
+// of which is a uvarint length followed by the bytes, and the encoded tuple.
+// Transports only have to deliver frames in order.
+type TupleEncoder struct {
+	dict *data.FieldDictionary
+}
+
+// NewTupleEncoder creates a new encoder.
+func NewTupleEncoder() *TupleEncoder {
+	return &TupleEncoder{
+		dict: data.NewFieldDictionary(),
+	}
+}
+
+// Encode encodes the tuple into a frame. Offsets aren't encoded because
+// they're tracked by each process. Calls to Encode must be serialized.
+func (e *TupleEncoder) Encode(t *core.Tuple) []byte {
+	n := e.dict.Len()
+	var meta data.Value = data.Null{}
+	if t.Metadata != nil {
+		meta = t.Metadata
+	}
+	v := data.AppendBinary(nil, data.Array{
+		data.Timestamp(t.Timestamp),
+		data.Timestamp(t.ProcTimestamp),
+		meta,
+		t.Data,
+	}, e.dict)
+
+	var names []string
+	if l := e.dict.Len(); l > n {
+		names = e.dict.Names()[n:l]
+	}
+	b := appendUvarint(nil, uint64(len(names)))
+	for _, name := range names {
+		b = appendUvarint(b, uint64(len(name)))
+		b = append(b, name...)
+	}
+	return append(b, v...)
+}
+
+// TupleDecoder decodes frames encoded by TupleEncoder.
+type TupleDecoder struct {
+	dict *data.FieldDictionary
+}
+
+// NewTupleDecoder creates a new decoder.
+func NewTupleDecoder() *TupleDecoder {
+	return &TupleDecoder{
+		dict: data.NewFieldDictionary(),
+	}
+}
+
+// Decode decodes a tuple after adding names in the frame to the dictionary.
+// Calls to Decode must be serialized.
+func (d *TupleDecoder) Decode(b []byte) (*core.Tuple, error) {
+	n, l := binary.Uvarint(b)
+	if l <= 0 || n > uint64(len(b)) {
+		return nil, errors.New("the frame is broken")
+	}
+	b = b[l:]
+	names := make([]string, 0, n)
+	for i := uint64(0); i < n; i++ {
+		sz, l := binary.Uvarint(b)
+		if l <= 0 || sz > uint64(len(b)-l) {
+			return nil, errors.New("the names of fields are broken")
+		}
+		names = append(names, string(b[l:l+int(sz)]))
+		b = b[l+int(sz):]
+	}
+	if len(names) > 0 {
+		d.dict = data.NewFieldDictionary(append(d.dict.Names(), names...)...)
+	}
+
+	v, err := data.UnmarshalBinary(b, d.dict)
+	if err != nil {
+		return nil, err
+	}
+	a, ok := v.(data.Array)
+	if !ok || len(a) != 4 {
+		return nil, errors.New("the tuple is broken")
+	}
+
+	ts, err := data.AsTimestamp(a[0])
+	if err != nil {
+		return nil, fmt.Errorf("the timestamp is broken: %v", err)
+	}
+	procTS, err := data.AsTimestamp(a[1])
+	if err != nil {
+		return nil, fmt.Errorf("the processing timestamp is broken: %v", err)
+	}
+	t := &core.Tuple{
+		Timestamp:     ts,
+		ProcTimestamp: procTS,
+	}
+	if a[2].Type() != data.TypeNull {
+		if t.Metadata, err = data.AsMap(a[2]); err != nil {
+			return nil, fmt.Errorf("the metadata is broken: %v", err)
+		}
+	}
+	if t.Data, err = data.AsMap(a[3]); err != nil {
+		return nil, fmt.Errorf("the data is broken: %v", err)
+	}
+	return t, nil
+}
+
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], x)]...)
+}
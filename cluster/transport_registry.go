@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TransportCreator creates a Transport having the timeout of connecting to a
+// worker and of sending a tuple. There's no timeout when it's 0.
+type TransportCreator func(timeout time.Duration) Transport
+
+var (
+	transportsMutex sync.RWMutex
+	transports      = map[string]TransportCreator{}
+)
+
+// RegisterTransport registers a TransportCreator with the name so that
+// workers can select a transport by its name. The name is case-insensitive.
+// "tcp" is always available. Other transports such as "grpc" are provided by
+// subpackages and registered when they're imported for side effects.
+func RegisterTransport(name string, c TransportCreator) error {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+	n := strings.ToLower(name)
+	if _, ok := transports[n]; ok {
+		return fmt.Errorf("transport '%v' is already registered", name)
+	}
+	transports[n] = c
+	return nil
+}
+
+// MustRegisterTransport is like RegisterTransport but panics if an error
+// occurred.
+func MustRegisterTransport(name string, c TransportCreator) {
+	if err := RegisterTransport(name, c); err != nil {
+		panic(fmt.Errorf("cluster.MustRegisterTransport: cannot register '%v': %v", name, err))
+	}
+}
+
+// LookupTransport returns the TransportCreator registered with the name. It
+// returns core.NotExistError when the transport isn't registered.
+func LookupTransport(name string) (TransportCreator, error) {
+	transportsMutex.RLock()
+	defer transportsMutex.RUnlock()
+	c, ok := transports[strings.ToLower(name)]
+	if !ok {
+		return nil, core.NotExistError(fmt.Errorf("transport '%v' is not registered", name))
+	}
+	return c, nil
+}
+
+// TransportNames returns names of registered transports in sorted order.
+func TransportNames() []string {
+	transportsMutex.RLock()
+	defer transportsMutex.RUnlock()
+	ns := make([]string, 0, len(transports))
+	for n := range transports {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+func init() {
+	MustRegisterTransport("tcp", func(timeout time.Duration) Transport {
+		return &TCPTransport{Timeout: timeout}
+	})
+}
@@ -0,0 +1,217 @@
+package cluster
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testEdgeReceiver struct {
+	m      sync.Mutex
+	tuples []*core.Tuple
+	err    error
+}
+
+func (r *testEdgeReceiver) Receive(t *core.Tuple) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.tuples = append(r.tuples, t)
+	return nil
+}
+
+func (r *testEdgeReceiver) received() []*core.Tuple {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]*core.Tuple(nil), r.tuples...)
+}
+
+func TestTupleEncoder(t *testing.T) {
+	Convey("Given a tuple encoder and a decoder", t, func() {
+		enc := NewTupleEncoder()
+		dec := NewTupleDecoder()
+		now := time.Date(2026, 10, 17, 1, 2, 3, 4, time.UTC)
+
+		Convey("When encoding tuples", func() {
+			tuples := []*core.Tuple{
+				{
+					Data:          data.Map{"a": data.Int(1), "b": data.Map{"c": data.String("x")}},
+					Timestamp:     now,
+					ProcTimestamp: now.Add(time.Second),
+				},
+				{
+					Data:      data.Map{"a": data.Int(2), "d": data.Array{data.Float(1.5)}},
+					Timestamp: now,
+					Metadata:  data.Map{"partition": data.String("p")},
+				},
+				{
+					Data:      data.Map{"a": data.Int(3)},
+					Timestamp: now,
+				},
+			}
+			var frames [][]byte
+			for _, t := range tuples {
+				frames = append(frames, enc.Encode(t))
+			}
+
+			Convey("Then only new names should be sent with each tuple", func() {
+				So(int(frames[0][0]), ShouldEqual, 3)
+				So(int(frames[1][0]), ShouldEqual, 2)
+				So(string(frames[1]), ShouldContainSubstring, "partition")
+				So(int(frames[2][0]), ShouldEqual, 0)
+			})
+
+			Convey("Then the decoder should decode them", func() {
+				for i, t := range tuples {
+					d, err := dec.Decode(frames[i])
+					So(err, ShouldBeNil)
+					So(d.Data, ShouldResemble, t.Data)
+					So(d.Timestamp.Equal(t.Timestamp), ShouldBeTrue)
+					So(d.ProcTimestamp.Equal(t.ProcTimestamp), ShouldBeTrue)
+					So(d.Metadata, ShouldResemble, t.Metadata)
+				}
+			})
+
+			Convey("Then the decoder should fail without preceding frames", func() {
+				_, err := dec.Decode(frames[2])
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then the decoder should fail with a broken frame", func() {
+				_, err := dec.Decode(frames[0][:3])
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestTCPTransport(t *testing.T) {
+	Convey("Given a TCP transport listening on an address", t, func() {
+		tr := &TCPTransport{Timeout: 5 * time.Second}
+		recv := &testEdgeReceiver{}
+		l, err := tr.Listen("127.0.0.1:0", func(edge string) (EdgeReceiver, error) {
+			if edge != "e1" {
+				return nil, errors.New("no such edge")
+			}
+			return recv, nil
+		})
+		So(err, ShouldBeNil)
+		Reset(func() {
+			l.Close()
+		})
+
+		Convey("When dialing an edge which doesn't exist", func() {
+			_, err := tr.Dial(l.Addr(), "e2")
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "no such edge")
+			})
+		})
+
+		Convey("When sending tuples to an edge", func() {
+			s, err := tr.Dial(l.Addr(), "e1")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				s.Close()
+			})
+			for i := 0; i < 3; i++ {
+				So(s.Send(&core.Tuple{Data: data.Map{"n": data.Int(i)}}), ShouldBeNil)
+			}
+
+			Convey("Then the receiver should receive them in order", func() {
+				ts := recv.received()
+				So(ts, ShouldHaveLength, 3)
+				for i, t := range ts {
+					So(t.Data, ShouldResemble, data.Map{"n": data.Int(i)})
+				}
+			})
+
+			Convey("And the receiver fails", func() {
+				recv.m.Lock()
+				recv.err = errors.New("receiver failed")
+				recv.m.Unlock()
+				err := s.Send(&core.Tuple{Data: data.Map{"n": data.Int(3)}})
+
+				Convey("Then the error should be returned", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, "receiver failed")
+				})
+
+				Convey("Then the sender shouldn't be used again", func() {
+					So(s.Send(&core.Tuple{Data: data.Map{}}), ShouldNotBeNil)
+				})
+			})
+
+			Convey("And the listener is closed", func() {
+				So(l.Close(), ShouldBeNil)
+
+				Convey("Then sending a tuple should fail", func() {
+					So(s.Send(&core.Tuple{Data: data.Map{}}), ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("When multiple senders send tuples concurrently", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				i := i
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s, err := tr.Dial(l.Addr(), "e1")
+					if err != nil {
+						return
+					}
+					defer s.Close()
+					for j := 0; j < 10; j++ {
+						s.Send(&core.Tuple{Data: data.Map{"sender": data.Int(i), "n": data.Int(j)}})
+					}
+				}()
+			}
+			wg.Wait()
+
+			Convey("Then the receiver should receive all of them", func() {
+				So(recv.received(), ShouldHaveLength, 40)
+			})
+		})
+	})
+}
+
+func TestTransportRegistry(t *testing.T) {
+	Convey("Given the transport registry", t, func() {
+		Convey("When looking up the TCP transport", func() {
+			c, err := LookupTransport("TCP")
+			So(err, ShouldBeNil)
+
+			Convey("Then it should create a TCPTransport", func() {
+				tr, ok := c(time.Second).(*TCPTransport)
+				So(ok, ShouldBeTrue)
+				So(tr.Timeout, ShouldEqual, time.Second)
+			})
+		})
+
+		Convey("When looking up a transport which isn't registered", func() {
+			_, err := LookupTransport("no_such_transport")
+
+			Convey("Then it should fail", func() {
+				So(core.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When registering a transport having the same name", func() {
+			err := RegisterTransport("Tcp", func(time.Duration) Transport { return &TCPTransport{} })
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(TransportNames(), ShouldContain, "tcp")
+			})
+		})
+	})
+}
@@ -0,0 +1,389 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWorkerConnectTimeout = 30 * time.Second
+	workerConnectRetryInterval  = 100 * time.Millisecond
+)
+
+// WorkerConfig has parameters of a Worker.
+type WorkerConfig struct {
+	// Name is the name of the worker registered to the coordinator.
+	Name string
+
+	// Addr is the address on which the worker listens for tuples sent from
+	// other workers.
+	Addr string
+
+	// Capacity is the capacity of the worker described in WorkerInfo.
+	Capacity int
+
+	// Transport carries tuples between workers.
+	Transport Transport
+
+	// ConnectTimeout is the time for which Deploy waits for other workers
+	// to be ready to receive tuples. The default value is 30 seconds.
+	ConnectTimeout time.Duration
+}
+
+// Worker runs instances of nodes placed on it in its own topology. Tuples of
+// an edge between instances on different workers are sent by a sink named
+// after the edge on the sending worker, and emitted by a source having the
+// same name on the receiving worker. Partitioning of an edge is done by the
+// sending worker so that only tuples assigned to the partition are sent.
+type Worker struct {
+	name           string
+	capacity       int
+	topology       core.Topology
+	transport      Transport
+	listener       Listener
+	connectTimeout time.Duration
+
+	m         sync.RWMutex
+	receivers map[string]*edgeSource
+}
+
+// NewWorker creates a worker adding instances to the topology and starts to
+// listen on the address.
+func NewWorker(t core.Topology, config *WorkerConfig) (*Worker, error) {
+	if err := core.ValidateSymbol(config.Name); err != nil {
+		return nil, err
+	}
+	if config.Transport == nil {
+		return nil, errors.New("the transport must be specified")
+	}
+	w := &Worker{
+		name:           strings.ToLower(config.Name),
+		capacity:       config.Capacity,
+		topology:       t,
+		transport:      config.Transport,
+		connectTimeout: config.ConnectTimeout,
+		receivers:      map[string]*edgeSource{},
+	}
+	if w.connectTimeout <= 0 {
+		w.connectTimeout = defaultWorkerConnectTimeout
+	}
+	l, err := config.Transport.Listen(config.Addr, w.open)
+	if err != nil {
+		return nil, err
+	}
+	w.listener = l
+	return w, nil
+}
+
+// Info returns the information of the worker to be registered to the
+// coordinator.
+func (w *Worker) Info() *WorkerInfo {
+	return &WorkerInfo{
+		Name:     w.name,
+		Addr:     w.listener.Addr(),
+		Capacity: w.capacity,
+	}
+}
+
+func (w *Worker) open(edge string) (EdgeReceiver, error) {
+	w.m.RLock()
+	defer w.m.RUnlock()
+	s, ok := w.receivers[strings.ToLower(edge)]
+	if !ok {
+		return nil, fmt.Errorf("the edge %v isn't deployed on the worker %v", edge, w.name)
+	}
+	return s, nil
+}
+
+// Deploy adds instances placed on the worker and their edges to the
+// topology. Sources are started after sinks sending tuples to other workers
+// are connected so that tuples aren't lost while other workers are being
+// deployed. It fails when those sinks can't connect within ConnectTimeout,
+// in which case all nodes added by Deploy are removed.
+func (w *Worker) Deploy(p *Placement) (err error) {
+	var added []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(added) - 1; i >= 0; i-- {
+			w.topology.Remove(added[i])
+		}
+		w.m.Lock()
+		defer w.m.Unlock()
+		for _, name := range added {
+			delete(w.receivers, strings.ToLower(name))
+		}
+	}()
+
+	instances := p.InstancesOf(w.name)
+	var sources []core.SourceNode
+	for _, i := range instances {
+		if i.Node.Source == nil {
+			continue
+		}
+		s, err := i.Node.Source()
+		if err != nil {
+			return fmt.Errorf("cannot create the source %v: %v", i.Name, err)
+		}
+		sn, err := w.topology.AddSource(i.Name, s, &core.SourceConfig{
+			PausedOnStartup: true,
+		})
+		if err != nil {
+			return err
+		}
+		added = append(added, i.Name)
+		sources = append(sources, sn)
+	}
+
+	for _, e := range p.Edges {
+		if !p.Remote(e) || p.Instances[e.To].Worker != w.name {
+			continue
+		}
+		s := newEdgeSource()
+		w.m.Lock()
+		w.receivers[e.Name] = s
+		w.m.Unlock()
+		if _, err := w.topology.AddSource(e.Name, s, nil); err != nil {
+			w.m.Lock()
+			delete(w.receivers, e.Name)
+			w.m.Unlock()
+			return err
+		}
+		added = append(added, e.Name)
+	}
+
+	for _, i := range instances {
+		switch {
+		case i.Node.Box != nil:
+			b, err := i.Node.Box()
+			if err != nil {
+				return fmt.Errorf("cannot create the box %v: %v", i.Name, err)
+			}
+			if _, err := w.topology.AddBox(i.Name, b, nil); err != nil {
+				return err
+			}
+			added = append(added, i.Name)
+		case i.Node.Sink != nil:
+			s, err := i.Node.Sink()
+			if err != nil {
+				return fmt.Errorf("cannot create the sink %v: %v", i.Name, err)
+			}
+			if _, err := w.topology.AddSink(i.Name, s, nil); err != nil {
+				return err
+			}
+			added = append(added, i.Name)
+		}
+	}
+
+	var senders []core.SinkNode
+	for _, e := range p.Edges {
+		from, to := p.Instances[e.From], p.Instances[e.To]
+		switch {
+		case from.Worker == w.name && to.Worker == w.name:
+			if err := w.connect(from.Name, e.InputName, to, e.Partition); err != nil {
+				return err
+			}
+		case from.Worker == w.name:
+			addr := p.Workers[to.Worker].Addr
+			sn, err := w.topology.AddSink(e.Name, newEdgeSink(w.transport, addr, e.Name), nil)
+			if err != nil {
+				return err
+			}
+			added = append(added, e.Name)
+			if err := sn.Input(from.Name, &core.SinkInputConfig{Partition: e.Partition}); err != nil {
+				return err
+			}
+			senders = append(senders, sn)
+		case to.Worker == w.name:
+			// The partition has already been filtered by the sending worker.
+			if err := w.connect(e.Name, e.InputName, to, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := w.waitConnected(senders); err != nil {
+		return err
+	}
+	for _, sn := range sources {
+		if err := sn.Resume(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connect adds an input from the node to the instance. Tuples sent to a box
+// have the input name.
+func (w *Worker) connect(from, inputName string, to *Instance, partition *core.PartitionConfig) error {
+	if to.Node.Box != nil {
+		bn, err := w.topology.Box(to.Name)
+		if err != nil {
+			return err
+		}
+		return bn.Input(from, &core.BoxInputConfig{
+			InputName: inputName,
+			Partition: partition,
+		})
+	}
+	sn, err := w.topology.Sink(to.Name)
+	if err != nil {
+		return err
+	}
+	return sn.Input(from, &core.SinkInputConfig{Partition: partition})
+}
+
+// waitConnected waits until all sinks are connected to other workers.
+func (w *Worker) waitConnected(sinks []core.SinkNode) error {
+	deadline := time.Now().Add(w.connectTimeout)
+	for _, sn := range sinks {
+		for {
+			err := sn.Connect()
+			if err == nil {
+				break
+			}
+			if !time.Now().Add(workerConnectRetryInterval).Before(deadline) {
+				return fmt.Errorf("cannot connect the edge %v to the worker: %v", sn.Name(), err)
+			}
+			time.Sleep(workerConnectRetryInterval)
+		}
+	}
+	return nil
+}
+
+// Close stops receiving tuples from other workers. It doesn't stop the
+// topology.
+func (w *Worker) Close() error {
+	return w.listener.Close()
+}
+
+// edgeSink sends tuples to the worker receiving tuples of the edge. It
+// connects to the worker again after a failure.
+type edgeSink struct {
+	transport Transport
+	addr      string
+	edge      string
+
+	m      sync.Mutex
+	sender EdgeSender
+	closed bool
+}
+
+var _ core.Connector = &edgeSink{}
+
+func newEdgeSink(tr Transport, addr, edge string) *edgeSink {
+	return &edgeSink{
+		transport: tr,
+		addr:      addr,
+		edge:      edge,
+	}
+}
+
+// Connect connects to the worker. It implements core.Connector.
+func (s *edgeSink) Connect(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.connect()
+}
+
+// connect connects to the worker unless it's already connected. The caller
+// must hold s.m.
+func (s *edgeSink) connect() error {
+	if s.closed {
+		return errors.New("the sink is already closed")
+	}
+	if s.sender != nil {
+		return nil
+	}
+	sender, err := s.transport.Dial(s.addr, s.edge)
+	if err != nil {
+		return err
+	}
+	s.sender = sender
+	return nil
+}
+
+func (s *edgeSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if err := s.connect(); err != nil {
+		return err
+	}
+	if err := s.sender.Send(t); err != nil {
+		s.sender.Close()
+		s.sender = nil
+		return fmt.Errorf("cannot send a tuple to %v: %v", s.addr, err)
+	}
+	return nil
+}
+
+func (s *edgeSink) Close(ctx *core.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closed = true
+	if s.sender == nil {
+		return nil
+	}
+	err := s.sender.Close()
+	s.sender = nil
+	return err
+}
+
+// edgeSource emits tuples received from another worker. GenerateStream can
+// be called again after it returns, for example, when the source is
+// restarted by the supervisor.
+type edgeSource struct {
+	ready     chan struct{}
+	readyOnce sync.Once
+	stop      chan struct{}
+	stopOnce  sync.Once
+
+	m   sync.Mutex
+	ctx *core.Context
+	w   core.Writer
+}
+
+var _ EdgeReceiver = &edgeSource{}
+
+func newEdgeSource() *edgeSource {
+	return &edgeSource{
+		ready: make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+func (s *edgeSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	s.m.Lock()
+	s.ctx, s.w = ctx, w
+	s.m.Unlock()
+	s.readyOnce.Do(func() {
+		close(s.ready)
+	})
+	<-s.stop
+	return nil
+}
+
+// Receive emits the tuple. It waits until the source starts to generate
+// the stream.
+func (s *edgeSource) Receive(t *core.Tuple) error {
+	select {
+	case <-s.ready:
+	case <-s.stop:
+		return errors.New("the edge is already stopped")
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.w.Write(s.ctx, t)
+}
+
+func (s *edgeSource) Stop(ctx *core.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	return nil
+}
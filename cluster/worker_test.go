@@ -0,0 +1,267 @@
+package cluster
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testTupleSource emits tuples having keys from 0 to n-1 and waits until
+// it's stopped.
+type testTupleSource struct {
+	n    int
+	stop chan struct{}
+}
+
+func (s *testTupleSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	for i := 0; i < s.n; i++ {
+		if err := w.Write(ctx, &core.Tuple{
+			Data:      data.Map{"key": data.Int(i % 5), "n": data.Int(i)},
+			Timestamp: time.Now(),
+		}); err != nil {
+			return err
+		}
+	}
+	<-s.stop
+	return nil
+}
+
+func (s *testTupleSource) Stop(ctx *core.Context) error {
+	close(s.stop)
+	return nil
+}
+
+// testCollectingSinks records tuples written to sinks by names of sinks.
+type testCollectingSinks struct {
+	m      sync.Mutex
+	tuples map[string][]*core.Tuple
+}
+
+func (c *testCollectingSinks) sink(name string) core.Sink {
+	return &testCollectingSink{
+		sinks: c,
+		name:  name,
+	}
+}
+
+func (c *testCollectingSinks) count() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	n := 0
+	for _, ts := range c.tuples {
+		n += len(ts)
+	}
+	return n
+}
+
+type testCollectingSink struct {
+	sinks *testCollectingSinks
+	name  string
+}
+
+func (s *testCollectingSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.sinks.m.Lock()
+	defer s.sinks.m.Unlock()
+	s.sinks.tuples[s.name] = append(s.sinks.tuples[s.name], t)
+	return nil
+}
+
+func (s *testCollectingSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func TestWorker(t *testing.T) {
+	Convey("Given two workers and a coordinator", t, func() {
+		tr := &TCPTransport{Timeout: 5 * time.Second}
+		var workers []*Worker
+		var topologies []core.Topology
+		c := NewCoordinator()
+		for _, name := range []string{"w1", "w2"} {
+			tp, err := core.NewDefaultTopology(core.NewContext(nil), name)
+			So(err, ShouldBeNil)
+			w, err := NewWorker(tp, &WorkerConfig{
+				Name:           name,
+				Addr:           "127.0.0.1:0",
+				Transport:      tr,
+				ConnectTimeout: 5 * time.Second,
+			})
+			So(err, ShouldBeNil)
+			So(c.AddWorker(w.Info()), ShouldBeNil)
+			workers = append(workers, w)
+			topologies = append(topologies, tp)
+		}
+		Reset(func() {
+			for i, w := range workers {
+				topologies[i].Stop()
+				w.Close()
+			}
+		})
+
+		Convey("When deploying a topology having a partitioned sink", func() {
+			sinks := &testCollectingSinks{tuples: map[string][]*core.Tuple{}}
+			var sinkNames []string
+			var snMutex sync.Mutex
+			p, err := c.Place([]*NodeSpec{
+				{
+					Name: "src",
+					Source: func() (core.Source, error) {
+						return &testTupleSource{n: 100, stop: make(chan struct{})}, nil
+					},
+					Worker: "w1",
+				},
+				{
+					Name: "b",
+					Box: func() (core.Box, error) {
+						return core.BoxFunc(func(ctx *core.Context, t *core.Tuple, w core.Writer) error {
+							t.Data["input"] = data.String(t.InputName)
+							return w.Write(ctx, t)
+						}), nil
+					},
+					Inputs: []*InputSpec{{From: "Src"}},
+				},
+				{
+					Name: "snk",
+					Sink: func() (core.Sink, error) {
+						snMutex.Lock()
+						defer snMutex.Unlock()
+						name := "snk" + string(rune('0'+len(sinkNames)))
+						sinkNames = append(sinkNames, name)
+						return sinks.sink(name), nil
+					},
+					Inputs:     []*InputSpec{{From: "b", Key: testKey}},
+					Partitions: 2,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(p.Instances["b"].Worker, ShouldEqual, "w2")
+
+			// The receiving worker is deployed later to make sure that the
+			// sending worker waits for it.
+			errs := make(chan error, 1)
+			go func() {
+				errs <- workers[0].Deploy(p)
+			}()
+			time.Sleep(200 * time.Millisecond)
+			So(workers[1].Deploy(p), ShouldBeNil)
+			So(<-errs, ShouldBeNil)
+
+			Convey("Then all tuples should be written to the sinks", func() {
+				deadline := time.Now().Add(10 * time.Second)
+				for sinks.count() < 100 && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				So(sinks.count(), ShouldEqual, 100)
+			})
+
+			Convey("Then tuples having the same key should be written to the same sink", func() {
+				deadline := time.Now().Add(10 * time.Second)
+				for sinks.count() < 100 && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				sinks.m.Lock()
+				defer sinks.m.Unlock()
+				owners := map[int64]string{}
+				for name, ts := range sinks.tuples {
+					for _, t := range ts {
+						k, _ := data.AsInt(t.Data["key"])
+						if o, ok := owners[k]; ok {
+							So(o, ShouldEqual, name)
+						}
+						owners[k] = name
+					}
+				}
+				So(owners, ShouldHaveLength, 5)
+			})
+
+			Convey("Then the box should receive tuples having the name of the input", func() {
+				deadline := time.Now().Add(10 * time.Second)
+				for sinks.count() < 100 && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				sinks.m.Lock()
+				defer sinks.m.Unlock()
+				for _, ts := range sinks.tuples {
+					for _, t := range ts {
+						So(t.Data["input"], ShouldEqual, data.String("Src"))
+					}
+				}
+			})
+
+			Convey("Then edges should be added to topologies of the workers", func() {
+				_, err := topologies[0].Sink("sensorbee_edge_0_src_to_b")
+				So(err, ShouldBeNil)
+				_, err = topologies[1].Source("sensorbee_edge_0_src_to_b")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When deploying a topology while the receiving worker isn't ready", func() {
+			workers[0].connectTimeout = 300 * time.Millisecond
+			src := &NodeSpec{
+				Name: "src",
+				Source: func() (core.Source, error) {
+					return &testTupleSource{stop: make(chan struct{})}, nil
+				},
+				Worker: "w1",
+			}
+			b := testBoxSpec("b", &InputSpec{From: "src"})
+			b.Worker = "w2"
+			p, err := c.Place([]*NodeSpec{src, b})
+			So(err, ShouldBeNil)
+			err = workers[0].Deploy(p)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then nodes added by the deployment should be removed", func() {
+				So(topologies[0].Nodes(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestEdgeSource(t *testing.T) {
+	Convey("Given an edge source", t, func() {
+		s := newEdgeSource()
+		ctx := core.NewContext(nil)
+		sinks := &testCollectingSinks{tuples: map[string][]*core.Tuple{}}
+
+		Convey("When the stream is generated again after it returns", func() {
+			done := make(chan error, 2)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(sinks.sink("a").Write))
+			}()
+			So(s.Receive(&core.Tuple{Data: data.Map{}}), ShouldBeNil)
+			go func() {
+				done <- s.GenerateStream(ctx, core.WriterFunc(sinks.sink("b").Write))
+			}()
+			deadline := time.Now().Add(5 * time.Second)
+			for time.Now().Before(deadline) {
+				So(s.Receive(&core.Tuple{Data: data.Map{}}), ShouldBeNil)
+				sinks.m.Lock()
+				n := len(sinks.tuples["b"])
+				sinks.m.Unlock()
+				if n > 0 {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			So(s.Stop(ctx), ShouldBeNil)
+
+			Convey("Then tuples should be emitted by the new writer", func() {
+				sinks.m.Lock()
+				defer sinks.m.Unlock()
+				So(sinks.tuples["b"], ShouldNotBeEmpty)
+			})
+
+			Convey("Then both calls should return", func() {
+				So(<-done, ShouldBeNil)
+				So(<-done, ShouldBeNil)
+			})
+		})
+	})
+}
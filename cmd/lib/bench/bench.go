@@ -0,0 +1,290 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// sourceName is the name of the generator source referred from BQL
+	// statements of scenarios.
+	sourceName = "bench"
+
+	// outputStreamName is the name of the stream whose tuples are measured.
+	outputStreamName = "bench_output"
+
+	outputSinkName = "bench_output_sink"
+)
+
+// Scenario is a canned topology measured by the benchmark. The generator
+// source named "bench" emits tuples having following fields:
+//
+//   - id: a sequential integer starting from 0
+//   - key: id modulo the number of groups
+//   - value: a random float in [0, 1)
+type Scenario struct {
+	// Name is the name of the scenario.
+	Name string
+
+	// BQL is a SELECT statement reading from the generator source. Tuples
+	// emitted from it are measured.
+	BQL string
+}
+
+// Scenarios is the list of built-in scenarios.
+var Scenarios = []*Scenario{
+	{
+		Name: "passthrough",
+		BQL:  "SELECT RSTREAM * FROM bench [RANGE 1 TUPLES]",
+	},
+	{
+		Name: "filter",
+		BQL:  "SELECT RSTREAM * FROM bench [RANGE 1 TUPLES] WHERE value < 0.5",
+	},
+	{
+		Name: "groupby",
+		BQL: "SELECT RSTREAM key, count(*) AS n, avg(value) AS v " +
+			"FROM bench [RANGE 100 TUPLES] GROUP BY key",
+	},
+	{
+		Name: "join",
+		BQL: "SELECT RSTREAM l:id AS l, r:id AS r " +
+			"FROM bench [RANGE 10 TUPLES] AS l, bench [RANGE 10 TUPLES] AS r " +
+			"WHERE l:key = r:key",
+	},
+}
+
+// LookupScenario returns the built-in scenario having the name.
+func LookupScenario(name string) (*Scenario, error) {
+	for _, s := range Scenarios {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("scenario '%v' is not found", name)
+}
+
+// Config has parameters of a benchmark run.
+type Config struct {
+	// NumTuples is the number of tuples emitted from the generator source.
+	NumTuples int
+
+	// NumGroups is the number of distinct values of "key" field.
+	NumGroups int
+}
+
+// Validate validates values of Config.
+func (c *Config) Validate() error {
+	if c.NumTuples <= 0 {
+		return errors.New("the number of tuples must be positive")
+	}
+	if c.NumGroups <= 0 {
+		return errors.New("the number of groups must be positive")
+	}
+	return nil
+}
+
+// Result is the result of a scenario.
+type Result struct {
+	// Scenario is the scenario executed.
+	Scenario *Scenario
+
+	// NumInputs is the number of tuples emitted from the generator source.
+	NumInputs int
+
+	// NumOutputs is the number of tuples emitted from the scenario.
+	NumOutputs int
+
+	// Elapsed is the time taken to process all tuples.
+	Elapsed time.Duration
+
+	// P50Latency and P99Latency are percentiles of the time from when a
+	// tuple is emitted from the source until a tuple derived from it reaches
+	// the sink.
+	P50Latency time.Duration
+	P99Latency time.Duration
+
+	// Allocs and AllocBytes are the number of heap allocations and the
+	// number of bytes allocated during the run.
+	Allocs     uint64
+	AllocBytes uint64
+}
+
+// Throughput returns the number of input tuples processed per second.
+func (r *Result) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.NumInputs) / r.Elapsed.Seconds()
+}
+
+// AllocsPerTuple returns the number of allocations per input tuple.
+func (r *Result) AllocsPerTuple() float64 {
+	if r.NumInputs == 0 {
+		return 0
+	}
+	return float64(r.Allocs) / float64(r.NumInputs)
+}
+
+// BytesPerTuple returns the number of bytes allocated per input tuple.
+func (r *Result) BytesPerTuple() float64 {
+	if r.NumInputs == 0 {
+		return 0
+	}
+	return float64(r.AllocBytes) / float64(r.NumInputs)
+}
+
+// RunScenario executes a scenario in a new topology and measures it.
+func RunScenario(s *Scenario, c *Config) (*Result, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	tp, err := core.NewDefaultTopology(core.NewContext(nil), "bench")
+	if err != nil {
+		return nil, err
+	}
+	stopped := false
+	defer func() {
+		if !stopped {
+			tp.Stop()
+		}
+	}()
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := tp.AddSource(sourceName, core.ImplementSourceStop(&generatorSource{
+		numTuples: c.NumTuples,
+		numGroups: c.NumGroups,
+	}), &core.SourceConfig{
+		PausedOnStartup: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stmt, _, err := parser.New().ParseStmt(fmt.Sprintf("CREATE STREAM %v AS %v", outputStreamName, s.BQL))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tb.AddStmt(stmt); err != nil {
+		return nil, err
+	}
+	sink := &latencySink{}
+	sn, err := tp.AddSink(outputSinkName, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := sn.Input(outputStreamName, nil); err != nil {
+		return nil, err
+	}
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	if err := src.Resume(); err != nil {
+		return nil, err
+	}
+	src.State().Wait(core.TSStopped)
+	// Stopping the topology waits until all tuples are processed.
+	stopped = true
+	if err := tp.Stop(); err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	res := &Result{
+		Scenario:   s,
+		NumInputs:  c.NumTuples,
+		NumOutputs: len(sink.latencies),
+		Elapsed:    elapsed,
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+	}
+	res.P50Latency = sink.percentile(0.50)
+	res.P99Latency = sink.percentile(0.99)
+	return res, nil
+}
+
+// generatorSource emits tuples as fast as possible and stops.
+type generatorSource struct {
+	numTuples int
+	numGroups int
+}
+
+func (s *generatorSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < s.numTuples; i++ {
+		now := time.Now()
+		t := &core.Tuple{
+			Data: data.Map{
+				"id":    data.Int(i),
+				"key":   data.Int(i % s.numGroups),
+				"value": data.Float(r.Float64()),
+			},
+			Timestamp:     now,
+			ProcTimestamp: now,
+		}
+		if err := w.Write(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *generatorSource) Stop(ctx *core.Context) error {
+	return nil
+}
+
+// latencySink records the latency of each tuple. Tuples emitted from a BQL
+// statement have ProcTimestamp of the input tuple which caused them.
+type latencySink struct {
+	m         sync.Mutex
+	latencies []time.Duration
+}
+
+func (s *latencySink) Write(ctx *core.Context, t *core.Tuple) error {
+	l := time.Since(t.ProcTimestamp)
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.latencies = append(s.latencies, l)
+	return nil
+}
+
+func (s *latencySink) Close(ctx *core.Context) error {
+	return nil
+}
+
+// percentile returns the p-th percentile of latencies. It must be called
+// after the topology is stopped.
+func (s *latencySink) percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sort.Sort(durations(s.latencies))
+	i := int(p*float64(len(s.latencies))+0.5) - 1
+	if i < 0 {
+		i = 0
+	} else if i >= len(s.latencies) {
+		i = len(s.latencies) - 1
+	}
+	return s.latencies[i]
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
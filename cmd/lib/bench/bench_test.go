@@ -0,0 +1,81 @@
+package bench
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	_ "gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin"
+	"testing"
+)
+
+func TestRunScenario(t *testing.T) {
+	Convey("Given a small benchmark config", t, func() {
+		conf := &Config{
+			NumTuples: 100,
+			NumGroups: 10,
+		}
+
+		for _, s := range Scenarios {
+			s := s
+			Convey("When running "+s.Name+" scenario", func() {
+				res, err := RunScenario(s, conf)
+				So(err, ShouldBeNil)
+
+				Convey("Then all tuples should be processed", func() {
+					So(res.NumInputs, ShouldEqual, 100)
+					So(res.NumOutputs, ShouldBeGreaterThan, 0)
+					So(res.Elapsed, ShouldBeGreaterThan, 0)
+					So(res.Throughput(), ShouldBeGreaterThan, 0)
+					So(res.P99Latency, ShouldBeGreaterThanOrEqualTo, res.P50Latency)
+				})
+			})
+		}
+
+		Convey("When running the passthrough scenario", func() {
+			s, err := LookupScenario("passthrough")
+			So(err, ShouldBeNil)
+			res, err := RunScenario(s, conf)
+			So(err, ShouldBeNil)
+
+			Convey("Then every tuple should be emitted", func() {
+				So(res.NumOutputs, ShouldEqual, 100)
+			})
+		})
+	})
+
+	Convey("Given an invalid config", t, func() {
+		conf := &Config{NumTuples: 0, NumGroups: 1}
+
+		Convey("Then running a scenario should fail", func() {
+			_, err := RunScenario(Scenarios[0], conf)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given an unknown scenario name", t, func() {
+		_, err := LookupScenario("no_such_scenario")
+
+		Convey("Then it shouldn't be found", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRunScenarios(t *testing.T) {
+	Convey("Given scenarios", t, func() {
+		conf := &Config{
+			NumTuples: 10,
+			NumGroups: 2,
+		}
+
+		Convey("When running them", func() {
+			out := bytes.NewBuffer(nil)
+			So(runScenarios(out, Scenarios[:2], conf), ShouldBeNil)
+
+			Convey("Then the report should have a row for each scenario", func() {
+				So(out.String(), ShouldContainSubstring, "tuples/sec")
+				So(out.String(), ShouldContainSubstring, "passthrough")
+				So(out.String(), ShouldContainSubstring, "filter")
+			})
+		})
+	})
+}
@@ -0,0 +1,92 @@
+/*
+Package bench implements sensorbee bench command. This command runs canned
+topologies with a generator source and reports their throughput, latency, and
+allocations so that regressions and effects of tuning can be measured in a
+consistent way.
+*/
+package bench
+
+import (
+	"fmt"
+	"github.com/codegangsta/cli"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// SetUp sets up a command for running benchmarks.
+func SetUp() cli.Command {
+	names := make([]string, len(Scenarios))
+	for i, s := range Scenarios {
+		names[i] = s.Name
+	}
+
+	cmd := cli.Command{
+		Name:      "bench",
+		Usage:     "run benchmarks of canned topologies",
+		ArgsUsage: "[SCENARIO...]",
+		Description: fmt.Sprintf("bench command runs benchmarks of canned topologies and reports "+
+			"tuples/sec, latency, and allocations. All scenarios are run when no scenario "+
+			"is given. Available scenarios: %v", strings.Join(names, ", ")),
+		Action: Run,
+	}
+
+	cmd.Flags = []cli.Flag{
+		cli.IntFlag{
+			Name:  "tuples, n",
+			Value: 100000,
+			Usage: "the number of tuples emitted from the generator source",
+		},
+		cli.IntFlag{
+			Name:  "groups, g",
+			Value: 100,
+			Usage: "the number of distinct keys of generated tuples",
+		},
+	}
+	return cmd
+}
+
+// Run runs "bench" command.
+func Run(c *cli.Context) error {
+	scenarios := Scenarios
+	if len(c.Args()) > 0 {
+		scenarios = make([]*Scenario, 0, len(c.Args()))
+		for _, name := range c.Args() {
+			s, err := LookupScenario(name)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			scenarios = append(scenarios, s)
+		}
+	}
+
+	conf := &Config{
+		NumTuples: c.Int("tuples"),
+		NumGroups: c.Int("groups"),
+	}
+	if err := runScenarios(os.Stdout, scenarios, conf); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	return nil
+}
+
+// runScenarios runs scenarios in order and writes a report of them to w.
+func runScenarios(w io.Writer, scenarios []*Scenario, conf *Config) error {
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "scenario\ttuples/sec\tp50\tp99\toutputs\tallocs/tuple\tbytes/tuple\t")
+	for _, s := range scenarios {
+		res, err := RunScenario(s, conf)
+		if err != nil {
+			tw.Flush()
+			return fmt.Errorf("cannot run scenario '%v': %v", s.Name, err)
+		}
+		fmt.Fprintf(tw, "%v\t%.0f\t%v\t%v\t%v\t%.1f\t%.0f\t\n", s.Name, res.Throughput(),
+			res.P50Latency, res.P99Latency, res.NumOutputs, res.AllocsPerTuple(), res.BytesPerTuple())
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,233 @@
+/*
+Package cluster implements sensorbee cluster command. This command runs a
+worker of a topology distributed across multiple sensorbee processes. Every
+worker reads the same BQL file and cluster config, and computes the same
+placement of nodes by the coordinator of the cluster package, so that each
+of them can deploy the nodes placed on it without a central server.
+*/
+package cluster
+
+import (
+	"fmt"
+	"github.com/Sirupsen/logrus"
+	"github.com/codegangsta/cli"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/cluster"
+	// The gRPC transport is registered when built with the grpc build tag.
+	_ "gopkg.in/sensorbee/sensorbee.v0/cluster/grpc"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// SetUp sets up a command for running a worker of a distributed topology.
+func SetUp() cli.Command {
+	cmd := cli.Command{
+		Name:      "cluster",
+		Usage:     "run a worker of a topology distributed across processes",
+		ArgsUsage: "BQL_FILE",
+		Description: "cluster command runs a worker of a topology defined by a BQL file. " +
+			"Nodes are placed on workers listed in the cluster config, and edges between " +
+			"workers are carried by the transport given in the config. The same BQL file " +
+			"and config must be given to all workers.",
+		Action: Run,
+	}
+
+	cmd.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   "config, c",
+			Value:  "",
+			Usage:  "file path of a cluster config file in YAML format",
+			EnvVar: "SENSORBEE_CLUSTER_CONFIG",
+		},
+		cli.StringFlag{
+			Name:   "worker, w",
+			Value:  "",
+			Usage:  "name of the worker in the cluster config run by this process",
+			EnvVar: "SENSORBEE_CLUSTER_WORKER",
+		},
+		cli.StringFlag{
+			Name:  "listen, l",
+			Value: "",
+			Usage: "address on which the worker listens, which is the address in the config by default",
+		},
+		cli.StringFlag{
+			Name:  "topology, t",
+			Value: "cluster",
+			Usage: "name of the topology",
+		},
+	}
+	return cmd
+}
+
+// Config is a config of a cluster. It's shared by all workers.
+type Config struct {
+	// Transport is the name of the transport carrying tuples between
+	// workers. It's "tcp" by default. "grpc" is available when sensorbee is
+	// built with the grpc build tag.
+	Transport string `yaml:"transport"`
+
+	// Timeout is the timeout of connecting to a worker and of sending a
+	// tuple. There's no timeout when it's 0.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// ConnectTimeout is the time for which a worker waits for other workers
+	// to be ready. It's 30 seconds by default.
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+
+	// Workers has all workers of the cluster.
+	Workers []*WorkerConfig `yaml:"workers"`
+
+	// Nodes has placement options of nodes by their names.
+	Nodes map[string]*NodeConfig `yaml:"nodes"`
+}
+
+// WorkerConfig is a config of a worker.
+type WorkerConfig struct {
+	Name     string `yaml:"name"`
+	Addr     string `yaml:"addr"`
+	Capacity int    `yaml:"capacity"`
+}
+
+// NodeConfig has placement options of a node. See cluster.NodeSpec for
+// details.
+type NodeConfig struct {
+	Worker     string `yaml:"worker"`
+	Partitions int    `yaml:"partitions"`
+	Weight     int    `yaml:"weight"`
+
+	// Key is the path to the partition key in tuples sent to the node. It's
+	// required when Partitions is greater than 1.
+	Key string `yaml:"key"`
+}
+
+func loadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the config file %v: %v", path, err)
+	}
+	conf := &Config{}
+	if err := yaml.Unmarshal(b, conf); err != nil {
+		return nil, fmt.Errorf("cannot parse the config file %v: %v", path, err)
+	}
+	if conf.Transport == "" {
+		conf.Transport = "tcp"
+	}
+	if len(conf.Workers) == 0 {
+		return nil, fmt.Errorf("the config file %v has no workers", path)
+	}
+	return conf, nil
+}
+
+// Run runs "cluster" command.
+func Run(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		cli.ShowSubcommandHelp(c)
+		os.Exit(1)
+	}
+	if err := run(c); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	return nil
+}
+
+func run(c *cli.Context) error {
+	if !c.IsSet("config") {
+		return fmt.Errorf("the cluster config must be specified")
+	}
+	conf, err := loadConfig(c.String("config"))
+	if err != nil {
+		return err
+	}
+	var self *WorkerConfig
+	for _, w := range conf.Workers {
+		if strings.ToLower(w.Name) == strings.ToLower(c.String("worker")) {
+			self = w
+		}
+	}
+	if self == nil {
+		return fmt.Errorf("the worker '%v' isn't in the cluster config", c.String("worker"))
+	}
+	newTransport, err := cluster.LookupTransport(conf.Transport)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadFile(c.Args()[0])
+	if err != nil {
+		return err
+	}
+	stmts, err := parser.New().ParseStmts(string(b))
+	if err != nil {
+		return err
+	}
+
+	logger := logrus.New()
+	tp, err := core.NewDefaultTopology(core.NewContext(&core.ContextConfig{
+		Logger: logger,
+	}), c.String("topology"))
+	if err != nil {
+		return err
+	}
+	defer tp.Stop()
+	tb, err := bql.NewTopologyBuilder(tp)
+	if err != nil {
+		return fmt.Errorf("cannot create a new topology builder: %v", err)
+	}
+	specs, err := nodeSpecs(tb, stmts, conf.Nodes)
+	if err != nil {
+		return err
+	}
+
+	coord := cluster.NewCoordinator()
+	for _, w := range conf.Workers {
+		if err := coord.AddWorker(&cluster.WorkerInfo{
+			Name:     w.Name,
+			Addr:     w.Addr,
+			Capacity: w.Capacity,
+		}); err != nil {
+			return err
+		}
+	}
+	p, err := coord.Place(specs)
+	if err != nil {
+		return err
+	}
+
+	addr := self.Addr
+	if c.IsSet("listen") {
+		addr = c.String("listen")
+	}
+	w, err := cluster.NewWorker(tp, &cluster.WorkerConfig{
+		Name:           self.Name,
+		Addr:           addr,
+		Capacity:       self.Capacity,
+		Transport:      newTransport(conf.Timeout),
+		ConnectTimeout: conf.ConnectTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	logger.WithFields(logrus.Fields{
+		"worker":    self.Name,
+		"instances": len(p.InstancesOf(strings.ToLower(self.Name))),
+	}).Info("Deploying the topology")
+	if err := w.Deploy(p); err != nil {
+		return err
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	<-sig
+	logger.Info("Stopping the worker")
+	return nil
+}
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/cluster"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+)
+
+// nodeSpecs creates specifications of nodes defined by statements. Sources
+// and sinks are created by CREATE SOURCE and CREATE SINK statements, and
+// boxes by CREATE STREAM AS SELECT statements. Inputs of sinks are given by
+// INSERT INTO statements. Nodes are created with registries of the topology
+// builder and in the context of its topology.
+func nodeSpecs(tb *bql.TopologyBuilder, stmts []interface{}, confs map[string]*NodeConfig) ([]*cluster.NodeSpec, error) {
+	lowerConfs := map[string]*NodeConfig{}
+	for name, c := range confs {
+		lowerConfs[strings.ToLower(name)] = c
+	}
+	ctx := tb.Topology().Context()
+
+	var specs []*cluster.NodeSpec
+	byName := map[string]*cluster.NodeSpec{}
+	for _, stmt := range stmts {
+		var spec *cluster.NodeSpec
+		switch stmt := stmt.(type) {
+		case parser.CreateSourceStmt:
+			creator, err := tb.SourceCreators.Lookup(string(stmt.Type))
+			if err != nil {
+				return nil, err
+			}
+			io := &bql.IOParams{
+				TypeName: string(stmt.Type),
+				Name:     string(stmt.Name),
+			}
+			params := stmt.Params
+			spec = &cluster.NodeSpec{
+				Name: string(stmt.Name),
+				Source: func() (core.Source, error) {
+					return creator.CreateSource(ctx, io, paramsMap(params))
+				},
+			}
+
+		case parser.CreateStreamAsSelectStmt:
+			sel := stmt.Select
+			spec = &cluster.NodeSpec{
+				Name: string(stmt.Name),
+				Box: func() (core.Box, error) {
+					s := sel
+					return bql.NewBQLBox(&s, tb.Reg), nil
+				},
+			}
+			connected := map[string]bool{}
+			for _, rel := range sel.Relations {
+				if rel.Type != parser.ActualStream {
+					return nil, fmt.Errorf("the stream %v cannot refer to a UDSF in a cluster", stmt.Name)
+				}
+				if rel.Partition != nil {
+					return nil, fmt.Errorf("the stream %v must be partitioned by the cluster config instead of PARTITION BY", stmt.Name)
+				}
+				if !connected[rel.Name] {
+					connected[rel.Name] = true
+					spec.Inputs = append(spec.Inputs, &cluster.InputSpec{From: rel.Name})
+				}
+			}
+
+		case parser.CreateSinkStmt:
+			creator, err := tb.SinkCreators.Lookup(string(stmt.Type))
+			if err != nil {
+				return nil, err
+			}
+			io := &bql.IOParams{
+				TypeName: string(stmt.Type),
+				Name:     string(stmt.Name),
+			}
+			params := stmt.Params
+			spec = &cluster.NodeSpec{
+				Name: string(stmt.Name),
+				Sink: func() (core.Sink, error) {
+					return creator.CreateSink(ctx, io, paramsMap(params))
+				},
+			}
+
+		case parser.InsertIntoFromStmt:
+			sink, ok := byName[strings.ToLower(string(stmt.Sink))]
+			if !ok || sink.Sink == nil {
+				return nil, fmt.Errorf("the sink %v isn't defined", stmt.Sink)
+			}
+			sink.Inputs = append(sink.Inputs, &cluster.InputSpec{From: string(stmt.Input)})
+			continue
+
+		default:
+			return nil, fmt.Errorf("the statement isn't supported in a cluster: %v", stmt)
+		}
+
+		if _, ok := byName[strings.ToLower(spec.Name)]; ok {
+			return nil, fmt.Errorf("the node %v is defined more than once", spec.Name)
+		}
+		byName[strings.ToLower(spec.Name)] = spec
+		specs = append(specs, spec)
+	}
+
+	for name, c := range lowerConfs {
+		spec, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("the node %v in the cluster config isn't defined", name)
+		}
+		spec.Worker = c.Worker
+		spec.Partitions = c.Partitions
+		spec.Weight = c.Weight
+		if c.Key == "" {
+			continue
+		}
+		path, err := data.CompilePath(c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("the key of the node %v is invalid: %v", name, err)
+		}
+		key := func(t *core.Tuple) (data.Value, error) {
+			return t.Data.Get(path)
+		}
+		for _, in := range spec.Inputs {
+			in.Key = key
+		}
+	}
+	return specs, nil
+}
+
+// paramsMap creates a new parameter map from the AST every time it's called
+// because creators might modify it.
+func paramsMap(params []parser.SourceSinkParamAST) data.Map {
+	m := make(data.Map, len(params))
+	for _, kv := range params {
+		m[string(kv.Key)] = kv.Value
+	}
+	return m
+}
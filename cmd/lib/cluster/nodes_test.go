@@ -0,0 +1,228 @@
+package cluster
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	_ "gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin"
+	"gopkg.in/sensorbee/sensorbee.v0/cluster"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testBQL = `
+CREATE PAUSED SOURCE src TYPE test_source WITH num = 20;
+CREATE STREAM doubled AS SELECT RSTREAM k, n * 2 AS n FROM src [RANGE 1 TUPLES];
+CREATE SINK snk TYPE test_sink;
+INSERT INTO snk FROM doubled;
+`
+
+type testSource struct {
+	num  int
+	stop chan struct{}
+}
+
+func (s *testSource) GenerateStream(ctx *core.Context, w core.Writer) error {
+	for i := 0; i < s.num; i++ {
+		if err := w.Write(ctx, core.NewTuple(data.Map{"k": data.Int(i % 3), "n": data.Int(i)})); err != nil {
+			return err
+		}
+	}
+	<-s.stop
+	return nil
+}
+
+func (s *testSource) Stop(ctx *core.Context) error {
+	close(s.stop)
+	return nil
+}
+
+type testSink struct {
+	m      sync.Mutex
+	tuples []*core.Tuple
+}
+
+func (s *testSink) Write(ctx *core.Context, t *core.Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.tuples = append(s.tuples, t)
+	return nil
+}
+
+func (s *testSink) Close(ctx *core.Context) error {
+	return nil
+}
+
+func (s *testSink) count() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.tuples)
+}
+
+// newTestTopologyBuilder creates a topology builder having the test source
+// and the test sink. All sinks created by it write tuples to snk.
+func newTestTopologyBuilder(name string, snk *testSink) *bql.TopologyBuilder {
+	tp, err := core.NewDefaultTopology(core.NewContext(nil), name)
+	So(err, ShouldBeNil)
+	tb, err := bql.NewTopologyBuilder(tp)
+	So(err, ShouldBeNil)
+	So(tb.SourceCreators.Register("test_source", bql.SourceCreatorFunc(
+		func(ctx *core.Context, io *bql.IOParams, params data.Map) (core.Source, error) {
+			n, err := data.AsInt(params["num"])
+			if err != nil {
+				return nil, err
+			}
+			return &testSource{num: int(n), stop: make(chan struct{})}, nil
+		})), ShouldBeNil)
+	So(tb.SinkCreators.Register("test_sink", bql.SinkCreatorFunc(
+		func(ctx *core.Context, io *bql.IOParams, params data.Map) (core.Sink, error) {
+			return snk, nil
+		})), ShouldBeNil)
+	return tb
+}
+
+func TestNodeSpecs(t *testing.T) {
+	Convey("Given a topology builder and statements", t, func() {
+		snk := &testSink{}
+		tb := newTestTopologyBuilder("test", snk)
+		Reset(func() {
+			tb.Topology().Stop()
+		})
+		stmts, err := parser.New().ParseStmts(testBQL)
+		So(err, ShouldBeNil)
+
+		Convey("When creating specifications of nodes", func() {
+			specs, err := nodeSpecs(tb, stmts, map[string]*NodeConfig{
+				"Doubled": {Partitions: 2, Key: "k"},
+				"snk":     {Worker: "w1"},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then they should be created in the order of statements", func() {
+				So(specs, ShouldHaveLength, 3)
+				So(specs[0].Name, ShouldEqual, "src")
+				So(specs[0].Source, ShouldNotBeNil)
+				So(specs[1].Name, ShouldEqual, "doubled")
+				So(specs[1].Box, ShouldNotBeNil)
+				So(specs[2].Name, ShouldEqual, "snk")
+				So(specs[2].Sink, ShouldNotBeNil)
+			})
+
+			Convey("Then inputs should be taken from FROM and INSERT INTO", func() {
+				So(specs[1].Inputs, ShouldHaveLength, 1)
+				So(specs[1].Inputs[0].From, ShouldEqual, "src")
+				So(specs[2].Inputs, ShouldHaveLength, 1)
+				So(specs[2].Inputs[0].From, ShouldEqual, "doubled")
+			})
+
+			Convey("Then options in the config should be applied", func() {
+				So(specs[1].Partitions, ShouldEqual, 2)
+				k, err := specs[1].Inputs[0].Key(core.NewTuple(data.Map{"k": data.Int(1)}))
+				So(err, ShouldBeNil)
+				So(k, ShouldEqual, data.Int(1))
+				So(specs[2].Worker, ShouldEqual, "w1")
+				So(specs[2].Inputs[0].Key, ShouldBeNil)
+			})
+
+			Convey("Then the source should be created with parameters", func() {
+				s, err := specs[0].Source()
+				So(err, ShouldBeNil)
+				So(s.(*testSource).num, ShouldEqual, 20)
+			})
+		})
+
+		Convey("When the config has a node which isn't defined", func() {
+			_, err := nodeSpecs(tb, stmts, map[string]*NodeConfig{"no_such_node": {}})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the statements have unsupported ones", func() {
+			for name, q := range map[string]string{
+				"UDSF":         `CREATE STREAM s AS SELECT RSTREAM * FROM duplicate("src", 3) [RANGE 1 TUPLES];`,
+				"PARTITION BY": "CREATE STREAM s AS SELECT RSTREAM * FROM src [RANGE 1 TUPLES, PARTITION BY k INSTANCE 1 OF 2];",
+				"INSERT INTO":  "INSERT INTO no_such_sink FROM src;",
+				"SELECT":       "SELECT RSTREAM * FROM src [RANGE 1 TUPLES];",
+			} {
+				Convey("Then it should fail with "+name, func() {
+					stmts, err := parser.New().ParseStmts(testBQL + q)
+					So(err, ShouldBeNil)
+					_, err = nodeSpecs(tb, stmts, nil)
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestDeploy(t *testing.T) {
+	Convey("Given two workers running the same statements", t, func() {
+		snk := &testSink{}
+		stmts, err := parser.New().ParseStmts(testBQL)
+		So(err, ShouldBeNil)
+		confs := map[string]*NodeConfig{
+			"src":     {Worker: "w1"},
+			"doubled": {Partitions: 2, Key: "k"},
+			"snk":     {Worker: "w2"},
+		}
+
+		coord := cluster.NewCoordinator()
+		var workers []*cluster.Worker
+		var builders []*bql.TopologyBuilder
+		for _, name := range []string{"w1", "w2"} {
+			tb := newTestTopologyBuilder(name, snk)
+			w, err := cluster.NewWorker(tb.Topology(), &cluster.WorkerConfig{
+				Name:      name,
+				Addr:      "127.0.0.1:0",
+				Transport: &cluster.TCPTransport{Timeout: 5 * time.Second},
+			})
+			So(err, ShouldBeNil)
+			So(coord.AddWorker(w.Info()), ShouldBeNil)
+			workers = append(workers, w)
+			builders = append(builders, tb)
+		}
+		Reset(func() {
+			for i, w := range workers {
+				builders[i].Topology().Stop()
+				w.Close()
+			}
+		})
+
+		Convey("When deploying nodes placed by the coordinator", func() {
+			errs := make(chan error, len(workers))
+			for i, w := range workers {
+				specs, err := nodeSpecs(builders[i], stmts, confs)
+				So(err, ShouldBeNil)
+				p, err := coord.Place(specs)
+				So(err, ShouldBeNil)
+				go func(w *cluster.Worker) {
+					errs <- w.Deploy(p)
+				}(w)
+			}
+			for range workers {
+				So(<-errs, ShouldBeNil)
+			}
+
+			Convey("Then all tuples should be processed by the boxes", func() {
+				deadline := time.Now().Add(10 * time.Second)
+				for snk.count() < 20 && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				So(snk.count(), ShouldEqual, 20)
+				sum := int64(0)
+				snk.m.Lock()
+				for _, t := range snk.tuples {
+					n, _ := data.AsInt(t.Data["n"])
+					sum += n
+				}
+				snk.m.Unlock()
+				So(sum, ShouldEqual, 2*190)
+			})
+		})
+	})
+}
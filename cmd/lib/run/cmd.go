@@ -4,8 +4,10 @@ package run
 
 import (
 	"fmt"
+	"github.com/Sirupsen/logrus"
 	"github.com/codegangsta/cli"
 	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"gopkg.in/sensorbee/sensorbee.v0/server"
 	"gopkg.in/sensorbee/sensorbee.v0/server/config"
@@ -13,6 +15,9 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 // SetUp sets up SensorBee's HTTP server. The URL or port ID is set with server
@@ -40,23 +45,9 @@ func Run(c *cli.Context) error {
 	err := func() error {
 		var conf *config.Config
 		if c.IsSet("config") {
-			p := c.String("config")
-			in, err := ioutil.ReadFile(p)
-			if err != nil {
-				return fmt.Errorf("Cannot read the config file %v: %v", p, err)
-			}
-
-			var yml map[string]interface{}
-			if err := yaml.Unmarshal(in, &yml); err != nil {
-				return fmt.Errorf("Cannot parse the config file %v: %v", p, err)
-			}
-			m, err := data.NewMap(yml)
+			c, err := loadConfig(c.String("config"))
 			if err != nil {
-				return fmt.Errorf("The config file %v has invalid values: %v", p, err)
-			}
-			c, err := config.New(m)
-			if err != nil {
-				return fmt.Errorf("Cannot apply the cnofig file %v: %v", p, err)
+				return err
 			}
 			conf = c
 
@@ -77,6 +68,13 @@ func Run(c *cli.Context) error {
 		}
 
 		cgvars.Logger.WithField("config", conf.ToMap()).Info("Setting up the server context")
+		if c.IsSet("config") {
+			p := c.String("config")
+			cgvars.Reloader.Load = func() (*config.Config, error) {
+				return loadConfig(p)
+			}
+			reloadOnSIGHUP(cgvars)
+		}
 
 		jascoRoot := jasco.New("/", cgvars.Logger)
 		router, err := server.SetUpContextAndRouter("/", jascoRoot, cgvars)
@@ -97,8 +95,24 @@ func Run(c *cli.Context) error {
 			Addr:    conf.Network.ListenOn,
 			Handler: jascoRoot,
 		}
+		if conf.Network.TLS != nil {
+			tc, err := bql.NewTLSConfig(conf.Network.TLS)
+			if err != nil {
+				return fmt.Errorf("Invalid TLS config: %v", err)
+			}
+			if s.TLSConfig, err = tc.ServerConfig(); err != nil {
+				return fmt.Errorf("Cannot set up TLS: %v", err)
+			}
+		}
+
 		cgvars.Logger.Infof("Starting the server on %v", conf.Network.ListenOn)
-		if err := s.ListenAndServe(); err != nil {
+		if s.TLSConfig != nil {
+			// The certificate is already loaded into s.TLSConfig.
+			err = s.ListenAndServeTLS("", "")
+		} else {
+			err = s.ListenAndServe()
+		}
+		if err != nil {
 			return fmt.Errorf("Cannot start the server: %v", err)
 		}
 		cgvars.Logger.Infof("The server stopped")
@@ -115,3 +129,49 @@ func Run(c *cli.Context) error {
 	}
 	return nil
 }
+
+func loadConfig(p string) (*config.Config, error) {
+	in, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read the config file %v: %v", p, err)
+	}
+
+	var yml map[string]interface{}
+	if err := yaml.Unmarshal(in, &yml); err != nil {
+		return nil, fmt.Errorf("Cannot parse the config file %v: %v", p, err)
+	}
+	m, err := data.NewMap(yml)
+	if err != nil {
+		return nil, fmt.Errorf("The config file %v has invalid values: %v", p, err)
+	}
+	c, err := config.New(m)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot apply the cnofig file %v: %v", p, err)
+	}
+	return c, nil
+}
+
+// reloadOnSIGHUP reloads the config file every time the process receives
+// SIGHUP.
+func reloadOnSIGHUP(cgvars *server.ContextGlobalVariables) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		for range ch {
+			res, err := cgvars.Reloader.Reload()
+			if err != nil {
+				cgvars.Logger.WithField("err", err).Error("Cannot reload the config")
+				continue
+			}
+			l := cgvars.Logger.WithFields(logrus.Fields{
+				"applied":          res.Applied,
+				"requires_restart": res.RequiresRestart,
+			})
+			if len(res.RequiresRestart) > 0 {
+				l.Warn("The config is reloaded but some changes require restart")
+			} else {
+				l.Info("The config is reloaded")
+			}
+		}
+	}()
+}
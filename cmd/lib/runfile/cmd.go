@@ -210,10 +210,34 @@ func setUpTopology(name string, logger *logrus.Logger, conf *config.Config, us u
 		return nil, fmt.Errorf("cannot create a new topology builder: %v", err)
 	}
 	tb.UDSStorage = us
+	tb.Secrets = newSecretProvider(conf.Secrets)
 
 	return tb, nil
 }
 
+// newSecretProvider creates a SecretProvider from the config. It returns nil
+// when no provider is configured.
+func newSecretProvider(conf *config.Secrets) bql.SecretProvider {
+	var ps []bql.SecretProvider
+	if conf.Env != nil {
+		ps = append(ps, bql.NewEnvSecretProvider(conf.Env.Prefix))
+	}
+	if conf.File != nil {
+		ps = append(ps, bql.NewFileSecretProvider(conf.File.Dir))
+	}
+	if v := conf.Vault; v != nil {
+		token := v.Token
+		if token == "" {
+			token = os.Getenv("VAULT_TOKEN")
+		}
+		ps = append(ps, bql.NewVaultSecretProvider(nil, v.Address, token, v.Mount))
+	}
+	if len(ps) == 0 {
+		return nil
+	}
+	return bql.NewMultiSecretProvider(ps...)
+}
+
 func setUpBQLStmt(tb *bql.TopologyBuilder, bqlFile string) error {
 	queries, err := func() (string, error) {
 		f, err := os.Open(bqlFile)
@@ -72,7 +72,7 @@ func (b *bqlCmd) Init() error {
 // Name returns BQL start words.
 func (b *bqlCmd) Name() []string {
 	return []string{"select", "create", "update", "insert", "pause", "resume",
-		"rewind", "drop", "save", "load", "eval"}
+		"rewind", "drop", "save", "load", "eval", "show"}
 }
 
 func (b *bqlCmd) Input(input string) (cmdInputStatusType, error) {
@@ -0,0 +1,79 @@
+/*
+Package test implements sensorbee test command. This command runs unit tests
+of BQL statements written in YAML files. See package bqltest for the format
+of the files.
+*/
+package test
+
+import (
+	"fmt"
+	"github.com/codegangsta/cli"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/bqltest"
+	"io"
+	"os"
+)
+
+// SetUp sets up a command for running tests of BQL statements.
+func SetUp() cli.Command {
+	cmd := cli.Command{
+		Name:        "test",
+		Usage:       "run tests of BQL statements",
+		ArgsUsage:   "FILE...",
+		Description: "test command runs tests of BQL statements written in YAML files",
+		Action:      Run,
+	}
+
+	cmd.Flags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "verbose, v",
+			Usage: "report passed tests as well as failed tests",
+		},
+	}
+	return cmd
+}
+
+// Run runs "test" command.
+func Run(c *cli.Context) error {
+	if len(c.Args()) == 0 {
+		cli.ShowSubcommandHelp(c)
+		os.Exit(1)
+	}
+
+	numFailed, err := runFiles(os.Stdout, c.Args(), c.Bool("verbose"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	if numFailed > 0 {
+		return cli.NewExitError(fmt.Sprintf("%v test(s) failed", numFailed), 1)
+	}
+	return nil
+}
+
+// runFiles runs all test cases in files and writes reports to w. It returns
+// the number of test cases which failed or couldn't be executed.
+func runFiles(w io.Writer, files []string, verbose bool) (int, error) {
+	numTests, numFailed := 0, 0
+	for _, f := range files {
+		cs, err := bqltest.LoadFile(f)
+		if err != nil {
+			return 0, fmt.Errorf("cannot load %v: %v", f, err)
+		}
+		for _, tc := range cs {
+			numTests++
+			res, err := bqltest.Run(tc)
+			if err != nil {
+				numFailed++
+				fmt.Fprintf(w, "ERROR: %v: %v: %v\n", f, tc.Name, err)
+				continue
+			}
+			if !res.Passed() {
+				numFailed++
+			} else if !verbose {
+				continue
+			}
+			fmt.Fprintf(w, "%v\n", res)
+		}
+	}
+	fmt.Fprintf(w, "%v passed, %v failed\n", numTests-numFailed, numFailed)
+	return numFailed, nil
+}
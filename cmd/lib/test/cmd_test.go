@@ -0,0 +1,69 @@
+package test
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	_ "gopkg.in/sensorbee/sensorbee.v0/bql/udf/builtin"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunFiles(t *testing.T) {
+	Convey("Given a YAML file having passing and failing tests", t, func() {
+		dir, err := ioutil.TempDir("", "sensorbee_test_cmd")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		path := filepath.Join(dir, "test.yaml")
+		So(ioutil.WriteFile(path, []byte(`
+tests:
+  - name: pass
+    inputs:
+      src: [{a: 1}, {a: 2}]
+    bql: SELECT RSTREAM a + 1 AS b FROM src [RANGE 1 TUPLES];
+    expected: [{b: 2}, {b: 3}]
+  - name: fail
+    inputs:
+      src: [{a: 1}]
+    bql: SELECT RSTREAM a FROM src [RANGE 1 TUPLES];
+    expected: [{a: 2}]
+  - name: error
+    bql: SELECT RSTREAM a FROM no_such_source [RANGE 1 TUPLES];
+`), 0644), ShouldBeNil)
+
+		Convey("When running it", func() {
+			out := bytes.NewBuffer(nil)
+			n, err := runFiles(out, []string{path}, false)
+			So(err, ShouldBeNil)
+
+			Convey("Then failed tests should be reported", func() {
+				So(n, ShouldEqual, 2)
+				So(out.String(), ShouldNotContainSubstring, "PASS: pass")
+				So(out.String(), ShouldContainSubstring, "FAIL: fail\n")
+				So(out.String(), ShouldContainSubstring, "ERROR: "+path+": error: ")
+				So(out.String(), ShouldEndWith, "1 passed, 2 failed\n")
+			})
+		})
+
+		Convey("When running it in the verbose mode", func() {
+			out := bytes.NewBuffer(nil)
+			_, err := runFiles(out, []string{path}, true)
+			So(err, ShouldBeNil)
+
+			Convey("Then passed tests should also be reported", func() {
+				So(out.String(), ShouldContainSubstring, "PASS: pass\n")
+			})
+		})
+	})
+
+	Convey("Given a missing file", t, func() {
+		_, err := runFiles(ioutil.Discard, []string{"/no/such/file.yaml"}, false)
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
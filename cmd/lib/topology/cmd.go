@@ -1,10 +1,15 @@
 package topology
 
 import (
+	"crypto/tls"
 	"fmt"
 	"github.com/codegangsta/cli"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
 	"gopkg.in/sensorbee/sensorbee.v0/client"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"net/http"
+	"strings"
 )
 
 var (
@@ -57,6 +62,35 @@ var (
 			Value: "v1",
 			Usage: "target API version",
 		},
+		cli.StringFlag{ // TODO: share this flag with others
+			Name:   "api-key",
+			Usage:  "the API key sent to the server",
+			EnvVar: "SENSORBEE_API_KEY",
+		},
+		cli.StringFlag{ // TODO: share this flag with others
+			Name:   "token",
+			Usage:  "the bearer token such as a JSON Web Token sent to the server",
+			EnvVar: "SENSORBEE_API_TOKEN",
+		},
+		cli.StringFlag{ // TODO: share this flag with others
+			Name:   "ca-file",
+			Usage:  "the PEM file of CA certificates to verify the server",
+			EnvVar: "SENSORBEE_CA_FILE",
+		},
+		cli.StringFlag{ // TODO: share this flag with others
+			Name:   "cert-file",
+			Usage:  "the PEM file of the client certificate sent to the server",
+			EnvVar: "SENSORBEE_CERT_FILE",
+		},
+		cli.StringFlag{ // TODO: share this flag with others
+			Name:   "key-file",
+			Usage:  "the PEM file of the private key of the client certificate",
+			EnvVar: "SENSORBEE_KEY_FILE",
+		},
+		cli.BoolFlag{ // TODO: share this flag with others
+			Name:  "insecure-skip-verify",
+			Usage: "don't verify the certificate of the server",
+		},
 	}
 )
 
@@ -72,13 +106,58 @@ func validateFlags(c *cli.Context) error {
 }
 
 func newRequester(c *cli.Context) (*client.Requester, error) {
-	r, err := client.NewRequester(c.String("uri"), c.String("api-version"))
+	tc, err := newTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	hc := http.DefaultClient
+	if tc != nil {
+		hc = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tc,
+			},
+		}
+	}
+	r, err := client.NewRequesterWithClient(c.String("uri"), c.String("api-version"), hc)
 	if err != nil {
 		return nil, fmt.Errorf("Cannot create a API requester: %v", err)
 	}
+	if k := c.String("api-key"); k != "" {
+		r = r.WithAPIKey(k)
+	}
+	if t := c.String("token"); t != "" {
+		r = r.WithToken(t)
+	}
 	return r, nil
 }
 
+// newTLSConfig returns a TLS config of the client created from flags. It
+// returns nil when no TLS related flag is given.
+func newTLSConfig(c *cli.Context) (*tls.Config, error) {
+	params := data.Map{}
+	for _, f := range []string{"ca-file", "cert-file", "key-file"} {
+		if v := c.String(f); v != "" {
+			params[strings.Replace(f, "-", "_", -1)] = data.String(v)
+		}
+	}
+	if c.Bool("insecure-skip-verify") {
+		params["insecure_skip_verify"] = data.Bool(true)
+	}
+	if len(params) == 0 {
+		return nil, nil
+	}
+	tc, err := bql.NewTLSConfig(params)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid TLS flags: %v", err)
+	}
+	conf, err := tc.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot set up TLS: %v", err)
+	}
+	return conf, nil
+}
+
 func do(c *cli.Context, method client.Method, path string, body interface{}, baseErrMsg string) (*client.Response, error) {
 	req, err := newRequester(c)
 	if err != nil {
@@ -0,0 +1,256 @@
+package core
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"time"
+)
+
+// BatchSizer is an optional interface of a Flushable sink writing tuples in
+// batches whose size can be changed at runtime. It's used by adaptive
+// batching of the sink (see AdaptiveBatching).
+type BatchSizer interface {
+	// SetBatchSize changes the maximum number of tuples in a batch. It can
+	// be called concurrently with Write and Flush. A sink which cannot batch
+	// tuples in its current configuration can ignore it.
+	SetBatchSize(ctx *Context, n int)
+}
+
+// findBatchSizer returns the BatchSizer of v. It looks into wrapped sinks
+// when v itself doesn't implement BatchSizer.
+func findBatchSizer(v interface{}) (BatchSizer, bool) {
+	for {
+		if b, ok := v.(BatchSizer); ok {
+			return b, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
+
+// DefaultAdaptiveMaxBatchSize is the default value of
+// AdaptiveBatching.MaxBatchSize.
+const DefaultAdaptiveMaxBatchSize = 1024
+
+const (
+	// adaptiveBatchingChecksPerTarget is the number of times input queues
+	// are checked per latency target.
+	adaptiveBatchingChecksPerTarget = 4
+
+	// Input queues are deep when the ratio of queued tuples to their
+	// capacity is at least adaptiveBatchingDeepRatio, and shallow when it's
+	// at most adaptiveBatchingShallowRatio.
+	adaptiveBatchingDeepRatio    = 0.5
+	adaptiveBatchingShallowRatio = 0.125
+)
+
+// AdaptiveBatching has configuration parameters of adaptive batching of a
+// sink implementing Flushable. Instead of being flushed every
+// SinkConfig.FlushInterval, the sink is controlled by the depth of its input
+// queues, which is checked several times per LatencyTarget:
+//
+//   - when the queues are deep, i.e. at least a half of their capacity is
+//     used, the batch size is doubled so that the sink writes tuples more
+//     efficiently and catches up with its inputs
+//   - when the queues are shallow, i.e. at most an eighth of their capacity
+//     is used, the sink is flushed at once and the batch size is halved so
+//     that tuples don't wait in the buffer
+//
+// In addition, the sink is flushed when LatencyTarget has passed since the
+// last flush regardless of the depth. The batch size is only changed when
+// the sink implements BatchSizer. It starts from MinBatchSize and is
+// reported to Metrics as the gauge "batch_size".
+type AdaptiveBatching struct {
+	// LatencyTarget is the maximum duration tuples should be buffered in the
+	// sink. It must be positive.
+	LatencyTarget time.Duration
+
+	// MinBatchSize and MaxBatchSize are the range of the batch size. 1 and
+	// DefaultAdaptiveMaxBatchSize are used when they're 0, respectively.
+	MinBatchSize int
+	MaxBatchSize int
+}
+
+// NewAdaptiveBatching creates an AdaptiveBatching from parameters. It
+// accepts following parameters:
+//
+//	latency_target: the latency target in seconds or as a duration string
+//	min_batch_size: the minimum batch size
+//	max_batch_size: the maximum batch size
+func NewAdaptiveBatching(params data.Map) (*AdaptiveBatching, error) {
+	b := &AdaptiveBatching{}
+	for k, v := range params {
+		switch k {
+		case "latency_target":
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("latency_target: %v", err)
+			}
+			b.LatencyTarget = d
+
+		case "min_batch_size":
+			i, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("min_batch_size: %v", err)
+			}
+			b.MinBatchSize = int(i)
+
+		case "max_batch_size":
+			i, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("max_batch_size: %v", err)
+			}
+			b.MaxBatchSize = int(i)
+
+		default:
+			return nil, fmt.Errorf("unknown adaptive batching parameter: %v", k)
+		}
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate validates values of AdaptiveBatching.
+func (b *AdaptiveBatching) Validate() error {
+	if b.LatencyTarget <= 0 {
+		return fmt.Errorf("latency_target must be positive: %v", b.LatencyTarget)
+	}
+	if b.MinBatchSize < 0 {
+		return fmt.Errorf("min_batch_size must not be negative: %v", b.MinBatchSize)
+	}
+	if b.MaxBatchSize < 0 {
+		return fmt.Errorf("max_batch_size must not be negative: %v", b.MaxBatchSize)
+	}
+	if min, max := b.batchSizeRange(); min > max {
+		return fmt.Errorf("min_batch_size must not be greater than max_batch_size: %v > %v", min, max)
+	}
+	return nil
+}
+
+// batchSizeRange returns the actual range of the batch size.
+func (b *AdaptiveBatching) batchSizeRange() (int, int) {
+	min, max := b.MinBatchSize, b.MaxBatchSize
+	if min == 0 {
+		min = 1
+	}
+	if max == 0 {
+		max = DefaultAdaptiveMaxBatchSize
+	}
+	return min, max
+}
+
+// batchAdaptively starts controlling batches of the sink according to
+// AdaptiveBatching. It returns a function stopping it, or nil when the sink
+// isn't controlled.
+func (ds *defaultSinkNode) batchAdaptively() func() {
+	b := ds.config.AdaptiveBatching
+	if b == nil {
+		return nil
+	}
+	if _, ok := findFlushable(ds.sink); !ok {
+		return nil
+	}
+	ctx := ds.topology.ctx
+	sizer, _ := findBatchSizer(ds.sink)
+	min, max := b.batchSizeRange()
+	setSize := func(n int) {
+		ds.stateMutex.Lock()
+		changed := ds.batchSize != n
+		ds.batchSize = n
+		ds.stateMutex.Unlock()
+		if sizer == nil || !changed {
+			return
+		}
+		func() {
+			defer func() {
+				if e := recover(); e != nil {
+					ctx.nodeErrLog(NTSink, ds.name, fmt.Errorf("%v", e)).
+						Error("Cannot change the batch size of the sink due to panic")
+				}
+			}()
+			sizer.SetBatchSize(ctx, n)
+		}()
+		ctx.SetGauge(NTSink, ds.name, "batch_size", int64(n))
+	}
+	setSize(min)
+
+	interval := b.LatencyTarget / adaptiveBatchingChecksPerTarget
+	if interval <= 0 {
+		interval = b.LatencyTarget
+	}
+	ticker := ctx.Clock().NewTicker(interval)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lastFlush := ctx.Clock().Now()
+		for {
+			var now time.Time
+			select {
+			case <-stop:
+				return
+			case now = <-ticker.C():
+			}
+
+			depth := ds.srcs.queueDepth()
+			ds.stateMutex.Lock()
+			size := ds.batchSize
+			ds.queueDepth = depth
+			ds.stateMutex.Unlock()
+
+			flush := now.Sub(lastFlush) >= b.LatencyTarget
+			switch {
+			case depth >= adaptiveBatchingDeepRatio:
+				if size *= 2; size > max {
+					size = max
+				}
+			case depth <= adaptiveBatchingShallowRatio:
+				if size /= 2; size < min {
+					size = min
+				}
+				flush = true
+			}
+			setSize(size)
+			if !flush {
+				continue
+			}
+			lastFlush = now
+			if err := ds.Flush(); err != nil {
+				ctx.nodeErrLog(NTSink, ds.name, err).
+					Error("Cannot flush the sink")
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(stop)
+		<-done
+	}
+}
+
+// adaptiveBatchingStatus returns the status of adaptive batching. It returns
+// nil when the sink isn't controlled.
+func (ds *defaultSinkNode) adaptiveBatchingStatus() data.Map {
+	b := ds.config.AdaptiveBatching
+	if b == nil {
+		return nil
+	}
+	if _, ok := findFlushable(ds.sink); !ok {
+		return nil
+	}
+	min, max := b.batchSizeRange()
+	ds.stateMutex.Lock()
+	defer ds.stateMutex.Unlock()
+	return data.Map{
+		"latency_target": data.String(b.LatencyTarget.String()),
+		"min_batch_size": data.Int(min),
+		"max_batch_size": data.Int(max),
+		"batch_size":     data.Int(ds.batchSize),
+		"queue_depth":    data.Float(ds.queueDepth),
+	}
+}
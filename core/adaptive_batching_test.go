@@ -0,0 +1,197 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchingSink records batch sizes and flushes. Write blocks while gate is
+// locked so that input queues of the sink can be filled.
+type batchingSink struct {
+	gate sync.Mutex
+
+	m       sync.Mutex
+	sizes   []int
+	flushes int
+}
+
+func (s *batchingSink) Write(ctx *Context, t *Tuple) error {
+	s.gate.Lock()
+	s.gate.Unlock()
+	return nil
+}
+
+func (s *batchingSink) Close(ctx *Context) error {
+	return nil
+}
+
+func (s *batchingSink) Flush(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.flushes++
+	return nil
+}
+
+func (s *batchingSink) SetBatchSize(ctx *Context, n int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.sizes = append(s.sizes, n)
+}
+
+func (s *batchingSink) lastSize() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if len(s.sizes) == 0 {
+		return 0
+	}
+	return s.sizes[len(s.sizes)-1]
+}
+
+func (s *batchingSink) numFlushes() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.flushes
+}
+
+func TestAdaptiveBatching(t *testing.T) {
+	Convey("Given a topology having a fake clock", t, func() {
+		c := NewFakeClock(time.Unix(100, 0))
+		tp, err := NewDefaultTopology(NewContext(&ContextConfig{Clock: c}), "test")
+		So(err, ShouldBeNil)
+		si := &batchingSink{}
+		gated := false
+		Reset(func() {
+			if gated {
+				si.gate.Unlock()
+			}
+			tp.Stop()
+		})
+
+		// waitFor advances the clock until cond returns true because the
+		// ticker of the sink might not be created yet.
+		waitFor := func(cond func() bool) bool {
+			for i := 0; i < 1000; i++ {
+				if cond() {
+					return true
+				}
+				c.Advance(time.Second)
+				time.Sleep(time.Millisecond)
+			}
+			return cond()
+		}
+
+		Convey("When adding a sink having adaptive batching", func() {
+			sin, err := tp.AddSink("sink", si, &SinkConfig{
+				AdaptiveBatching: &AdaptiveBatching{
+					LatencyTarget: 4 * time.Second,
+					MinBatchSize:  2,
+					MaxBatchSize:  8,
+				},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then the batch size should start from the minimum", func() {
+				So(waitFor(func() bool { return si.lastSize() == 2 }), ShouldBeTrue)
+				st := sin.Status()["adaptive_batching"].(data.Map)
+				So(st["batch_size"], ShouldEqual, data.Int(2))
+				So(st["latency_target"], ShouldEqual, data.String("4s"))
+			})
+
+			Convey("Then it should be flushed quickly while its inputs are shallow", func() {
+				So(waitFor(func() bool { return si.numFlushes() >= 2 }), ShouldBeTrue)
+				So(si.lastSize(), ShouldEqual, 2)
+			})
+
+			Convey("And its inputs become deep", func() {
+				si.gate.Lock()
+				gated = true
+				ts := make([]*Tuple, 20)
+				for i := range ts {
+					ts[i] = NewTuple(data.Map{"int": data.Int(i)})
+				}
+				son, err := tp.AddSource("source", NewTupleEmitterSource(ts), &SourceConfig{
+					PausedOnStartup: true,
+				})
+				So(err, ShouldBeNil)
+				So(sin.Input("source", &SinkInputConfig{Capacity: 8}), ShouldBeNil)
+				So(son.Resume(), ShouldBeNil)
+
+				Convey("Then the batch size should grow up to the maximum", func() {
+					So(waitFor(func() bool { return si.lastSize() == 8 }), ShouldBeTrue)
+					st := sin.Status()["adaptive_batching"].(data.Map)
+					So(st["queue_depth"], ShouldEqual, data.Float(1))
+
+					Convey("And it should shrink when the inputs become shallow", func() {
+						si.gate.Unlock()
+						gated = false
+						So(waitFor(func() bool { return si.lastSize() == 2 }), ShouldBeTrue)
+						si.m.Lock()
+						defer si.m.Unlock()
+						So(si.sizes, ShouldResemble, []int{2, 4, 8, 4, 2})
+					})
+				})
+			})
+		})
+
+		Convey("When adding a sink having invalid adaptive batching", func() {
+			_, err := tp.AddSink("sink", si, &SinkConfig{
+				AdaptiveBatching: &AdaptiveBatching{
+					LatencyTarget: time.Second,
+					MinBatchSize:  10,
+					MaxBatchSize:  5,
+				},
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given parameters of adaptive batching", t, func() {
+		Convey("When creating adaptive batching from valid parameters", func() {
+			b, err := NewAdaptiveBatching(data.Map{
+				"latency_target": data.String("500ms"),
+				"max_batch_size": data.Int(100),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the parameters and defaults", func() {
+				So(b.LatencyTarget, ShouldEqual, 500*time.Millisecond)
+				min, max := b.batchSizeRange()
+				So(min, ShouldEqual, 1)
+				So(max, ShouldEqual, 100)
+			})
+		})
+
+		Convey("When creating adaptive batching from invalid parameters", func() {
+			cases := map[string]data.Map{
+				"no latency target": data.Map{
+					"max_batch_size": data.Int(100),
+				},
+				"a negative batch size": data.Map{
+					"latency_target": data.Float(1),
+					"min_batch_size": data.Int(-1),
+				},
+				"the default maximum less than the minimum": data.Map{
+					"latency_target": data.Float(1),
+					"min_batch_size": data.Int(DefaultAdaptiveMaxBatchSize + 1),
+				},
+				"an unknown parameter": data.Map{
+					"latency_target": data.Float(1),
+					"batch_size":     data.Int(1),
+				},
+			}
+			for title, params := range cases {
+				_, err := NewAdaptiveBatching(params)
+
+				Convey("Then it should fail with "+title, func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 )
 
 // A Box is an elementary building block of a SensorBee topology.
@@ -162,21 +164,43 @@ func checkBoxInputName(b Box, boxName string, inputName string) error {
 // boxWriterAdapter provides a Writer interface which writes tuples to a Box.
 // It also records traces input and output tuples.
 type boxWriterAdapter struct {
-	box  Box
+	c    context.Context
 	name string
 	dst  *traceWriter
+
+	// box has *contextBoxHolder. It's atomically replaced when the box is
+	// recreated by its recovery policy while other goroutines are
+	// processing tuples.
+	box atomic.Value
+}
+
+// contextBoxHolder makes the type of values stored in atomic.Value
+// consistent.
+type contextBoxHolder struct {
+	ContextBox
 }
 
-func newBoxWriterAdapter(b Box, name string, dst WriteCloser) *boxWriterAdapter {
-	return &boxWriterAdapter{
-		box:  b,
+func newBoxWriterAdapter(c context.Context, b Box, name string, dst WriteCloser) *boxWriterAdapter {
+	wa := &boxWriterAdapter{
+		c:    c,
 		name: name,
 		// An output traces is written just after the box Process writes a tuple.
 		dst: newTraceWriter(dst, ETOutput, name),
 	}
+	wa.setBox(b)
+	return wa
+}
+
+// setBox replaces the box to which tuples are written.
+func (wa *boxWriterAdapter) setBox(b Box) {
+	wa.box.Store(&contextBoxHolder{toContextBox(b)})
 }
 
 func (wa *boxWriterAdapter) Write(ctx *Context, t *Tuple) error {
 	tracing(t, ctx, ETInput, wa.name)
-	return wa.box.Process(ctx, t, wa.dst)
+	blobs := findSharedBlobsToCheck(ctx, t)
+	if err := wa.box.Load().(*contextBoxHolder).ProcessContext(wa.c, ctx, t, wa.dst); err != nil {
+		return err
+	}
+	return verifySharedBlobs(blobs)
 }
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Breakpoints manages breakpoints set on boxes and sinks of a topology. A
+// node having a breakpoint holds tuples at its input until they're released
+// by Step, which allows users to step through the topology tuple by tuple and
+// inspect tuples before each node. Node names are case-insensitive.
+//
+// Held tuples block the goroutines writing them, so upstream nodes stop once
+// their output queues get full. Tuples are released when the breakpoint is
+// removed or the node stops.
+//
+// Methods of Breakpoints can be called on a nil pointer, in which case no
+// breakpoint is set.
+type Breakpoints struct {
+	m     sync.Mutex
+	nodes map[string]*breakpoint
+
+	// numBreakpoints is the number of nodes having breakpoints. It's used to
+	// skip looking up breakpoints when none are set.
+	numBreakpoints int32
+}
+
+type breakpoint struct {
+	// steps is the number of tuples allowed to pass the breakpoint.
+	steps int
+
+	held      []*Tuple
+	numPassed int64
+	removed   bool
+
+	// changed is closed and replaced when steps or removed changes.
+	changed chan struct{}
+}
+
+// NewBreakpoints returns a new Breakpoints having no breakpoint.
+func NewBreakpoints() *Breakpoints {
+	return &Breakpoints{
+		nodes: map[string]*breakpoint{},
+	}
+}
+
+// Set sets a breakpoint on the node. It doesn't do anything when the node
+// already has a breakpoint.
+func (bs *Breakpoints) Set(nodeName string) error {
+	if bs == nil {
+		return errors.New("breakpoints aren't supported")
+	}
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	name := strings.ToLower(nodeName)
+	if _, ok := bs.nodes[name]; ok {
+		return nil
+	}
+	bs.nodes[name] = &breakpoint{
+		changed: make(chan struct{}),
+	}
+	atomic.StoreInt32(&bs.numBreakpoints, int32(len(bs.nodes)))
+	return nil
+}
+
+// Has returns true when the node has a breakpoint.
+func (bs *Breakpoints) Has(nodeName string) bool {
+	if bs == nil {
+		return false
+	}
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	_, ok := bs.nodes[strings.ToLower(nodeName)]
+	return ok
+}
+
+// Step releases n tuples held at the breakpoint of the node. When the node
+// holds less than n tuples, the rest of them are applied to tuples arriving
+// later.
+func (bs *Breakpoints) Step(nodeName string, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("the number of steps must be positive: %v", n)
+	}
+	if bs == nil {
+		return errors.New("breakpoints aren't supported")
+	}
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	bp, ok := bs.nodes[strings.ToLower(nodeName)]
+	if !ok {
+		return fmt.Errorf("node '%v' doesn't have a breakpoint", nodeName)
+	}
+	bp.steps += n
+	bp.notify()
+	return nil
+}
+
+// Remove removes the breakpoint from the node and releases all tuples held
+// at it. It returns false when the node doesn't have a breakpoint.
+func (bs *Breakpoints) Remove(nodeName string) bool {
+	if bs == nil {
+		return false
+	}
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	name := strings.ToLower(nodeName)
+	bp, ok := bs.nodes[name]
+	if !ok {
+		return false
+	}
+	delete(bs.nodes, name)
+	atomic.StoreInt32(&bs.numBreakpoints, int32(len(bs.nodes)))
+	bp.removed = true
+	bp.notify()
+	return true
+}
+
+// Status returns breakpoints of all nodes and tuples held at them. Keys of
+// the map are node names.
+func (bs *Breakpoints) Status() data.Map {
+	m := data.Map{}
+	if bs == nil {
+		return m
+	}
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	for name, bp := range bs.nodes {
+		m[name] = bp.status()
+	}
+	return m
+}
+
+// wait blocks until the tuple is allowed to pass the breakpoint of the node.
+// It returns immediately when the node doesn't have a breakpoint or done is
+// closed.
+func (bs *Breakpoints) wait(nodeName string, t *Tuple, done <-chan struct{}) {
+	if bs == nil || atomic.LoadInt32(&bs.numBreakpoints) == 0 {
+		return
+	}
+	bs.m.Lock()
+	defer bs.m.Unlock()
+	bp, ok := bs.nodes[strings.ToLower(nodeName)]
+	if !ok {
+		return
+	}
+
+	bp.held = append(bp.held, t)
+	defer bp.release(t)
+	for !bp.removed && bp.steps == 0 {
+		ch := bp.changed
+		bs.m.Unlock()
+		select {
+		case <-ch:
+		case <-done:
+			bs.m.Lock()
+			return
+		}
+		bs.m.Lock()
+	}
+	if !bp.removed {
+		bp.steps--
+	}
+}
+
+func (bp *breakpoint) notify() {
+	close(bp.changed)
+	bp.changed = make(chan struct{})
+}
+
+func (bp *breakpoint) release(t *Tuple) {
+	for i, h := range bp.held {
+		if h == t {
+			bp.held = append(bp.held[:i], bp.held[i+1:]...)
+			break
+		}
+	}
+	bp.numPassed++
+}
+
+func (bp *breakpoint) status() data.Map {
+	held := make(data.Array, len(bp.held))
+	for i, t := range bp.held {
+		held[i] = data.Map{
+			"timestamp": data.Timestamp(t.Timestamp),
+			"data":      t.Data.Copy(),
+		}
+	}
+	return data.Map{
+		"steps":      data.Int(bp.steps),
+		"num_held":   data.Int(len(bp.held)),
+		"num_passed": data.Int(bp.numPassed),
+		"held":       held,
+	}
+}
+
+// breakpointWriter is a Writer holding tuples at the breakpoint of the node
+// before writing them. done is closed when the node stops so that held tuples
+// don't prevent it from stopping.
+type breakpointWriter struct {
+	w        Writer
+	nodeName string
+	done     <-chan struct{}
+}
+
+func newBreakpointWriter(w Writer, nodeName string, done <-chan struct{}) *breakpointWriter {
+	return &breakpointWriter{
+		w:        w,
+		nodeName: nodeName,
+		done:     done,
+	}
+}
+
+func (bw *breakpointWriter) Write(ctx *Context, t *Tuple) error {
+	ctx.Breakpoints.wait(bw.nodeName, t, bw.done)
+	return bw.w.Write(ctx, t)
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+// waitHeld waits until the breakpoint of the node holds n tuples.
+func waitHeld(bs *Breakpoints, nodeName string, n int) {
+	for {
+		st := bs.Status()
+		if v, err := st.Get(data.MustCompilePath(nodeName + ".num_held")); err == nil {
+			if h, _ := data.AsInt(v); h == int64(n) {
+				return
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBreakpoints(t *testing.T) {
+	Convey("Given Breakpoints", t, func() {
+		bs := NewBreakpoints()
+
+		Convey("When setting a breakpoint on a node", func() {
+			So(bs.Set("Box"), ShouldBeNil)
+
+			Convey("Then it can be found case-insensitively", func() {
+				So(bs.Has("box"), ShouldBeTrue)
+				So(bs.Status(), ShouldContainKey, "box")
+			})
+
+			Convey("Then setting it again should succeed", func() {
+				So(bs.Set("box"), ShouldBeNil)
+			})
+
+			Convey("Then a tuple should be held until stepping", func() {
+				tu := NewTuple(data.Map{"int": data.Int(1)})
+				passed := make(chan struct{})
+				go func() {
+					defer close(passed)
+					bs.wait("box", tu, nil)
+				}()
+				waitHeld(bs, "box", 1)
+				v, err := bs.Status().Get(data.MustCompilePath("box.held[0].data.int"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(1))
+
+				So(bs.Step("box", 1), ShouldBeNil)
+				<-passed
+				v, err = bs.Status().Get(data.MustCompilePath("box.num_passed"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(1))
+			})
+
+			Convey("Then steps should be applied to tuples arriving later", func() {
+				So(bs.Step("box", 2), ShouldBeNil)
+				bs.wait("box", NewTuple(data.Map{}), nil)
+				bs.wait("box", NewTuple(data.Map{}), nil)
+				v, err := bs.Status().Get(data.MustCompilePath("box.steps"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.Int(0))
+			})
+
+			Convey("Then removing it should release held tuples", func() {
+				passed := make(chan struct{})
+				go func() {
+					defer close(passed)
+					bs.wait("box", NewTuple(data.Map{}), nil)
+				}()
+				waitHeld(bs, "box", 1)
+				So(bs.Remove("BOX"), ShouldBeTrue)
+				<-passed
+				So(bs.Has("box"), ShouldBeFalse)
+				So(bs.Remove("box"), ShouldBeFalse)
+			})
+
+			Convey("Then closing done should release held tuples", func() {
+				done := make(chan struct{})
+				passed := make(chan struct{})
+				go func() {
+					defer close(passed)
+					bs.wait("box", NewTuple(data.Map{}), done)
+				}()
+				waitHeld(bs, "box", 1)
+				close(done)
+				<-passed
+				So(bs.Has("box"), ShouldBeTrue)
+			})
+
+			Convey("Then stepping with an invalid number should fail", func() {
+				So(bs.Step("box", 0), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When stepping a node without a breakpoint", func() {
+			err := bs.Step("box", 1)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given nil Breakpoints", t, func() {
+		var bs *Breakpoints
+
+		Convey("Then it shouldn't have any breakpoint", func() {
+			So(bs.Has("box"), ShouldBeFalse)
+			So(bs.Remove("box"), ShouldBeFalse)
+			So(bs.Status(), ShouldBeEmpty)
+			So(bs.Set("box"), ShouldNotBeNil)
+			So(bs.Step("box", 1), ShouldNotBeNil)
+			bs.wait("box", NewTuple(data.Map{}), nil)
+		})
+	})
+}
+
+func TestBreakpointsInTopology(t *testing.T) {
+	Convey("Given a topology with a box having a breakpoint", t, func() {
+		ctx := NewContext(nil)
+		tp, err := NewDefaultTopology(ctx, "breakpoint")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		son, err := tp.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		bn, err := tp.AddBox("box", BoxFunc(forwardBox), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		si := NewTupleCollectorSink()
+		sin, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("box", nil), ShouldBeNil)
+		So(ctx.Breakpoints.Set("box"), ShouldBeNil)
+
+		Convey("When the source emits tuples", func() {
+			So(son.Resume(), ShouldBeNil)
+			waitHeld(ctx.Breakpoints, "box", 1)
+
+			Convey("Then the box should hold the first tuple", func() {
+				So(si.len(), ShouldEqual, 0)
+				v, err := ctx.Breakpoints.Status().Get(data.MustCompilePath("box.held[0].data"))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, freshTuples()[0].Data)
+			})
+
+			Convey("Then stepping should release tuples one by one", func() {
+				So(ctx.Breakpoints.Step("box", 1), ShouldBeNil)
+				si.Wait(1)
+				waitHeld(ctx.Breakpoints, "box", 1)
+				So(si.len(), ShouldEqual, 1)
+			})
+
+			Convey("Then removing the breakpoint should release all tuples", func() {
+				So(ctx.Breakpoints.Remove("box"), ShouldBeTrue)
+				si.Wait(len(freshTuples()))
+				So(si.len(), ShouldEqual, len(freshTuples()))
+			})
+
+			Convey("Then the topology should stop while holding tuples", func() {
+				So(tp.Stop(), ShouldBeNil)
+			})
+		})
+	})
+}
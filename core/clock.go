@@ -0,0 +1,280 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time, tickers, and timers. All time-based
+// features such as timers, periodic emitters, and the now() function of BQL
+// obtain the time from the Clock of a Context so that they can be tested
+// deterministically by replacing it with a FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTicker returns a new Ticker sending the time on its channel after
+	// each tick. The period d must be greater than zero.
+	NewTicker(d time.Duration) Ticker
+
+	// NewTimer returns a new Timer sending the time on its channel after at
+	// least the duration d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker is an abstraction of time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker. It doesn't close the channel.
+	Stop()
+}
+
+// Timer is an abstraction of time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false if the timer has
+	// already expired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after the duration d. It returns
+	// true if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// SystemClock is a Clock using the system time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{t: time.NewTicker(d)}
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{t: time.NewTimer(d)}
+}
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (t *systemTicker) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t *systemTicker) Stop() {
+	t.t.Stop()
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t *systemTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t *systemTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+func (t *systemTimer) Reset(d time.Duration) bool {
+	return t.t.Reset(d)
+}
+
+// FakeClock is a Clock whose time only advances when Advance or Set is
+// called. Tickers and timers created by it fire synchronously while the
+// time is advanced, which allows tests of time-based features to run
+// without sleeping.
+//
+// Like tickers of the time package, channels of tickers and timers created
+// by FakeClock have a buffer of one element and ticks are dropped when a
+// receiver doesn't keep up with them.
+type FakeClock struct {
+	m       sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+var (
+	_ Clock = &FakeClock{}
+)
+
+// NewFakeClock returns a new FakeClock whose current time is t.
+func NewFakeClock(t time.Time) *FakeClock {
+	c := &FakeClock{
+		now: t,
+	}
+	c.cond = sync.NewCond(&c.m)
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.now
+}
+
+// NewTicker returns a new Ticker which fires every d as the clock advances.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+	w := &fakeWaiter{
+		clock:  c,
+		c:      make(chan time.Time, 1),
+		period: d,
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.addWithoutLock(w, c.now.Add(d))
+	return &fakeTicker{w}
+}
+
+// NewTimer returns a new Timer which fires once the clock advances by d.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{
+		clock: c,
+		c:     make(chan time.Time, 1),
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.addWithoutLock(w, c.now.Add(d))
+	c.advanceWithoutLock(c.now) // fire immediately when d <= 0
+	return &fakeTimer{w}
+}
+
+// Advance advances the time of the clock by d and fires tickers and timers
+// scheduled until the new time in chronological order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.advanceWithoutLock(c.now.Add(d))
+}
+
+// Set sets the time of the clock to t. Tickers and timers scheduled until t
+// fire when t is after the current time of the clock.
+func (c *FakeClock) Set(t time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if t.Before(c.now) {
+		c.now = t
+		return
+	}
+	c.advanceWithoutLock(t)
+}
+
+// BlockUntil blocks until the clock has at least n active tickers and
+// timers. It's useful to wait for a goroutine to start waiting on the clock
+// before advancing it.
+func (c *FakeClock) BlockUntil(n int) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) advanceWithoutLock(t time.Time) {
+	for len(c.waiters) > 0 && !c.waiters[0].at.After(t) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.at
+		select {
+		case w.c <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			c.addWithoutLock(w, w.at.Add(w.period))
+		} else {
+			w.active = false
+		}
+	}
+	c.now = t
+}
+
+func (c *FakeClock) addWithoutLock(w *fakeWaiter, at time.Time) {
+	w.at = at
+	w.active = true
+	i := sort.Search(len(c.waiters), func(i int) bool {
+		return c.waiters[i].at.After(at)
+	})
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[i+1:], c.waiters[i:])
+	c.waiters[i] = w
+	c.cond.Broadcast()
+}
+
+func (c *FakeClock) removeWithoutLock(w *fakeWaiter) bool {
+	if !w.active {
+		return false
+	}
+	for i, x := range c.waiters {
+		if x == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			break
+		}
+	}
+	w.active = false
+	return true
+}
+
+// fakeWaiter is a ticker or a timer created by FakeClock.
+type fakeWaiter struct {
+	clock  *FakeClock
+	c      chan time.Time
+	at     time.Time
+	period time.Duration
+	active bool
+}
+
+func (w *fakeWaiter) stop() bool {
+	w.clock.m.Lock()
+	defer w.clock.m.Unlock()
+	return w.clock.removeWithoutLock(w)
+}
+
+type fakeTicker struct {
+	w *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.w.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.w.stop()
+}
+
+type fakeTimer struct {
+	w *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.w.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	return t.w.stop()
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.w.clock
+	c.m.Lock()
+	defer c.m.Unlock()
+	active := c.removeWithoutLock(t.w)
+	c.addWithoutLock(t.w, c.now.Add(d))
+	c.advanceWithoutLock(c.now)
+	return active
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	Convey("Given a fake clock", t, func() {
+		start := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+		c := NewFakeClock(start)
+
+		Convey("When advancing it", func() {
+			c.Advance(time.Minute)
+
+			Convey("Then the time should be advanced", func() {
+				So(c.Now(), ShouldResemble, start.Add(time.Minute))
+			})
+		})
+
+		Convey("When creating a ticker", func() {
+			tk := c.NewTicker(time.Second)
+			Reset(func() {
+				tk.Stop()
+			})
+
+			Convey("Then it shouldn't tick before the period passes", func() {
+				c.Advance(999 * time.Millisecond)
+				So(tk.C(), ShouldHaveLength, 0)
+			})
+
+			Convey("Then it should tick every period", func() {
+				for i := 1; i <= 3; i++ {
+					c.Advance(time.Second)
+					So(<-tk.C(), ShouldResemble, start.Add(time.Duration(i)*time.Second))
+				}
+			})
+
+			Convey("Then ticks should be dropped when they aren't received", func() {
+				c.Advance(3 * time.Second)
+				So(<-tk.C(), ShouldResemble, start.Add(time.Second))
+				So(tk.C(), ShouldHaveLength, 0)
+			})
+
+			Convey("Then it shouldn't tick after being stopped", func() {
+				tk.Stop()
+				c.Advance(time.Hour)
+				So(tk.C(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When creating a timer", func() {
+			tm := c.NewTimer(time.Second)
+
+			Convey("Then it should fire only once", func() {
+				c.Advance(10 * time.Second)
+				So(<-tm.C(), ShouldResemble, start.Add(time.Second))
+				c.Advance(10 * time.Second)
+				So(tm.C(), ShouldHaveLength, 0)
+				So(tm.Stop(), ShouldBeFalse)
+			})
+
+			Convey("Then it shouldn't fire after being stopped", func() {
+				So(tm.Stop(), ShouldBeTrue)
+				c.Advance(time.Hour)
+				So(tm.C(), ShouldHaveLength, 0)
+			})
+
+			Convey("Then it should be reset", func() {
+				So(tm.Reset(2*time.Second), ShouldBeTrue)
+				c.Advance(time.Second)
+				So(tm.C(), ShouldHaveLength, 0)
+				c.Advance(time.Second)
+				So(<-tm.C(), ShouldResemble, start.Add(2*time.Second))
+			})
+
+			Convey("Then it should fire at the time set to the clock", func() {
+				c.Set(start.Add(time.Minute))
+				So(<-tm.C(), ShouldResemble, start.Add(time.Second))
+				So(c.Now(), ShouldResemble, start.Add(time.Minute))
+			})
+		})
+
+		Convey("When creating a timer with a non-positive duration", func() {
+			tm := c.NewTimer(0)
+
+			Convey("Then it should fire immediately", func() {
+				So(<-tm.C(), ShouldResemble, start)
+			})
+		})
+
+		Convey("When waiting for a goroutine to create a timer", func() {
+			ch := make(chan time.Time)
+			go func() {
+				ch <- <-c.NewTimer(time.Second).C()
+			}()
+			c.BlockUntil(1)
+			c.Advance(time.Second)
+
+			Convey("Then the goroutine should receive the time", func() {
+				So(<-ch, ShouldResemble, start.Add(time.Second))
+			})
+		})
+	})
+}
+
+func TestContextClock(t *testing.T) {
+	Convey("Given a context created without a clock", t, func() {
+		ctx := NewContext(nil)
+
+		Convey("Then it should have the system clock", func() {
+			So(ctx.Clock(), ShouldResemble, SystemClock)
+		})
+	})
+
+	Convey("Given a context created with a fake clock", t, func() {
+		c := NewFakeClock(time.Now())
+		ctx := NewContext(&ContextConfig{Clock: c})
+
+		Convey("Then it should have the clock", func() {
+			So(ctx.Clock(), ShouldPointTo, c)
+		})
+	})
+
+	Convey("Given a nil context", t, func() {
+		var ctx *Context
+
+		Convey("Then it should have the system clock", func() {
+			So(ctx.Clock(), ShouldResemble, SystemClock)
+		})
+	})
+}
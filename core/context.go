@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"github.com/Sirupsen/logrus"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
@@ -30,8 +31,62 @@ type Context struct {
 	Flags        ContextFlags
 	SharedStates SharedStateRegistry
 
+	// Resources tracks resources used by the topology and enforces limits
+	// on them.
+	Resources *ResourceController
+
+	// Secrets has secret values used by the topology. They're redacted from
+	// statuses of nodes.
+	Secrets *Redactor
+
+	// Faults has faults injected into nodes of the topology. Faults can be
+	// changed at runtime to test how the topology recovers from them.
+	Faults *FaultInjector
+
+	// Taps has taps attached to edges of the topology to record tuples
+	// flowing through them for debugging.
+	Taps *EdgeTaps
+
+	// Breakpoints has breakpoints set on nodes of the topology to step
+	// through it tuple by tuple for debugging.
+	Breakpoints *Breakpoints
+
+	// Recovery has recovery policies of nodes in the topology. They're
+	// applied when nodes get fatal errors.
+	Recovery *RecoveryPolicies
+
+	// LogLevels has log levels of the topology and its nodes. They can be
+	// changed at runtime.
+	LogLevels *LogLevels
+
+	// Offsets has offsets of tuples acknowledged by sinks in the topology.
+	Offsets *OffsetTracker
+
+	// Latencies has latencies of tuples observed by sources and sinks in the
+	// topology.
+	Latencies *LatencyTracker
+
+	// Settings has parameters of nodes inherited from defaults given by the
+	// server to the topology and to each node. They can be changed at
+	// runtime.
+	Settings *Settings
+
+	clock   Clock
+	metrics Metrics
+
+	// parent is the context.Context given by ContextConfig.Parent. It's nil
+	// when it isn't given.
+	parent context.Context
+
+	// std is derived from parent and canceled when the topology stops.
+	std    context.Context
+	cancel context.CancelFunc
+
 	dtMutex   sync.RWMutex
 	dtSources map[int64]*droppedTupleCollectorSource
+
+	seMutex   sync.RWMutex
+	seSources map[int64]*systemEventSource
 }
 
 // ContextConfig has configuration parameters of a Context.
@@ -39,6 +94,46 @@ type ContextConfig struct {
 	// Logger provides a logrus's logger used by the Context.
 	Logger *logrus.Logger
 	Flags  ContextFlags
+
+	// ResourceLimits has limits of resources the topology can use. If it's
+	// nil, resources aren't limited.
+	ResourceLimits *ResourceLimits
+
+	// Resources is a ResourceController shared with other contexts, e.g.
+	// contexts of topologies owned by the same tenant. When it's set,
+	// ResourceLimits is ignored and the limits of the controller are
+	// applied to the total usage of all topologies sharing it.
+	Resources *ResourceController
+
+	// Clock provides the time to the topology. SystemClock is used when
+	// it's nil. A FakeClock can be given to test time-based features
+	// deterministically.
+	Clock Clock
+
+	// Metrics receives metrics of nodes in the topology. Metrics aren't
+	// reported when it's nil.
+	Metrics Metrics
+
+	// DefaultQueueSize is the capacity of input pipes of boxes and sinks
+	// whose configs don't specify it. 1024 is used when it's 0 or greater
+	// than MaxCapacity. It can be changed at runtime by
+	// Context.SetDefaultQueueSize. It overrides QueueSize of
+	// TopologySettings.
+	DefaultQueueSize int
+
+	// DefaultSettings and TopologySettings are the initial layers of
+	// defaults and the topology in Context.Settings, respectively. Invalid
+	// settings are ignored.
+	DefaultSettings  *NodeSettings
+	TopologySettings *NodeSettings
+
+	// Parent controls the lifetime of the topology. The topology is stopped
+	// when Parent is canceled or its deadline is exceeded. This allows
+	// applications embedding SensorBee to stop topologies with their own
+	// shutdown machinery. Values of Parent, such as tracing information, can
+	// be accessed through Context.StdContext. The topology isn't stopped by
+	// it when it's nil.
+	Parent context.Context
 }
 
 // NewContext creates a new Context based on the config. If config is nil,
@@ -51,15 +146,130 @@ func NewContext(config *ContextConfig) *Context {
 	if logger == nil {
 		logger = logrus.StandardLogger()
 	}
+	resources := config.Resources
+	if resources == nil {
+		resources = NewResourceController(config.ResourceLimits)
+	}
+	clock := config.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	parent := config.Parent
+	if parent == nil {
+		parent = context.Background()
+	}
+	std, cancel := context.WithCancel(parent)
 	c := &Context{
-		logger:    logger,
-		Flags:     config.Flags,
-		dtSources: map[int64]*droppedTupleCollectorSource{},
+		logger:      logger,
+		Flags:       config.Flags,
+		Resources:   resources,
+		Secrets:     NewRedactor(),
+		Faults:      NewFaultInjector(),
+		Taps:        NewEdgeTaps(clock),
+		Breakpoints: NewBreakpoints(),
+		Recovery:    NewRecoveryPolicies(),
+		LogLevels:   NewLogLevels(logger),
+		Offsets:     NewOffsetTracker(),
+		Latencies:   NewLatencyTracker(),
+		Settings:    NewSettings(),
+		clock:       clock,
+		metrics:     config.Metrics,
+		parent:      config.Parent,
+		std:         std,
+		cancel:      cancel,
+		dtSources:   map[int64]*droppedTupleCollectorSource{},
+		seSources:   map[int64]*systemEventSource{},
 	}
 	c.SharedStates = NewDefaultSharedStateRegistry(c)
+	// Invalid settings are ignored.
+	c.Settings.SetDefaults(config.DefaultSettings)
+	c.Settings.SetTopology(config.TopologySettings)
+	if config.DefaultQueueSize != 0 {
+		c.SetDefaultQueueSize(config.DefaultQueueSize)
+	}
 	return c
 }
 
+// defaultCapacity is the capacity of input pipes used when neither their
+// configs nor the Context specify it.
+const defaultCapacity = 1024
+
+// DefaultQueueSize returns the capacity of input pipes whose configs don't
+// specify it. It's QueueSize of the topology resolved by Settings. It returns
+// 1024 when c is nil or the size isn't set.
+func (c *Context) DefaultQueueSize() int {
+	return c.queueSize("")
+}
+
+// queueSize returns the capacity of input pipes of the node whose configs
+// don't specify it.
+func (c *Context) queueSize(nodeName string) int {
+	if c == nil {
+		return defaultCapacity
+	}
+	if n := c.Settings.Resolve(nodeName).QueueSize; n > 0 {
+		return n
+	}
+	return defaultCapacity
+}
+
+// SetDefaultQueueSize changes the capacity of input pipes whose configs don't
+// specify it by updating QueueSize of the topology's layer of Settings. 0
+// resets it to the value inherited from the defaults. It only affects inputs
+// connected after the call and doesn't resize existing pipes.
+func (c *Context) SetDefaultQueueSize(n int) error {
+	if err := validateCapacity(n); err != nil {
+		return err
+	}
+	if c.Settings == nil {
+		return errors.New("settings aren't supported")
+	}
+	c.Settings.m.Lock()
+	defer c.Settings.m.Unlock()
+	c.Settings.topology.QueueSize = n
+	return nil
+}
+
+// Traced returns true when tuples passing the node are traced. An empty name
+// returns whether tuples in the topology are traced by default. Settings
+// has priority over Flags.TupleTrace.
+func (c *Context) Traced(nodeName string) bool {
+	if t, ok := c.Settings.trace(nodeName); ok {
+		return t
+	}
+	return c.Flags.TupleTrace.Enabled()
+}
+
+// recoveryPolicy returns the recovery policy of the node. A policy set by
+// Recovery has priority over Settings.
+func (c *Context) recoveryPolicy(nodeName string) (*RecoveryPolicy, bool) {
+	if p, ok := c.Recovery.Get(nodeName); ok {
+		return p, true
+	}
+	return c.Settings.recovery(nodeName)
+}
+
+// Clock returns the Clock of the Context. It returns SystemClock when c is
+// nil or the Context isn't created by NewContext.
+func (c *Context) Clock() Clock {
+	if c == nil || c.clock == nil {
+		return SystemClock
+	}
+	return c.clock
+}
+
+// StdContext returns a context.Context which is canceled when the topology
+// stops or ContextConfig.Parent is done. Nodes can pass it to libraries
+// supporting context.Context so that their blocking calls are canceled on
+// stop. It returns context.Background() when the Context isn't created by
+// NewContext.
+func (c *Context) StdContext() context.Context {
+	if c == nil || c.std == nil {
+		return context.Background()
+	}
+	return c.std
+}
+
 // Log returns the logger tied to the Context.
 func (c *Context) Log() *logrus.Entry {
 	return c.log(1)
@@ -71,13 +281,39 @@ func (c *Context) ErrLog(err error) *logrus.Entry {
 }
 
 func (c *Context) log(depth int) *logrus.Entry {
+	logger := c.logger
+	if c.LogLevels != nil {
+		logger = c.LogLevels.logger()
+	}
+	return c.logWith(logger, depth+1)
+}
+
+// nodeLog returns the logger of the node. The logger follows the log level
+// of the node set to LogLevels.
+func (c *Context) nodeLog(t NodeType, name string) *logrus.Entry {
+	return c.logWith(c.nodeLogger(name), 1).WithFields(nodeLogFields(t, name))
+}
+
+// nodeErrLog returns the logger of the node having an error information.
+func (c *Context) nodeErrLog(t NodeType, name string, err error) *logrus.Entry {
+	return c.logWith(c.nodeLogger(name), 1).WithFields(nodeLogFields(t, name)).WithField("err", err)
+}
+
+func (c *Context) nodeLogger(name string) *logrus.Logger {
+	if c.LogLevels == nil {
+		return c.logger
+	}
+	return c.LogLevels.nodeLogger(name)
+}
+
+func (c *Context) logWith(logger *logrus.Logger, depth int) *logrus.Entry {
 	// TODO: This is a temporary solution until logrus support filename and line number
 	_, file, line, ok := runtime.Caller(depth + 1)
 	if !ok {
-		return c.logger.WithField("topology", c.topologyName)
+		return logger.WithField("topology", c.topologyName)
 	}
 	file = filepath.Base(file) // only the filename at the moment
-	return c.logger.WithFields(logrus.Fields{
+	return logger.WithFields(logrus.Fields{
 		"file":     file,
 		"line":     line,
 		"topology": c.topologyName,
@@ -98,7 +334,7 @@ func (c *Context) droppedTuple(t *Tuple, nodeType NodeType, nodeName string, et
 			js = t.Data.String()
 		}
 
-		l := c.Log().WithFields(nodeLogFields(nodeType, nodeName)).WithFields(logrus.Fields{
+		l := c.nodeLog(nodeType, nodeName).WithFields(logrus.Fields{
 			"event_type": et.String(),
 			"tuple": logrus.Fields{
 				"timestamp": data.Timestamp(t.Timestamp),
@@ -108,6 +344,9 @@ func (c *Context) droppedTuple(t *Tuple, nodeType NodeType, nodeName string, et
 		})
 		if err != nil {
 			l = l.WithField("err", err)
+			if code := ErrorCode(err); code != "" {
+				l = l.WithField("error_code", code)
+			}
 		}
 		l.Info("A tuple was dropped from the topology") // TODO: debug should be better?
 	}
@@ -131,6 +370,9 @@ func (c *Context) droppedTuple(t *Tuple, nodeType NodeType, nodeName string, et
 	}
 	if err != nil {
 		dt.Data["error"] = data.String(err.Error())
+		if code := ErrorCode(err); code != "" {
+			dt.Data["error_code"] = data.String(code)
+		}
 	}
 	dt.Flags.Set(TFDropped)
 	if len(c.dtSources) > 1 {
@@ -142,6 +384,19 @@ func (c *Context) droppedTuple(t *Tuple, nodeType NodeType, nodeName string, et
 	}
 }
 
+// DivertTuple reports a tuple intentionally dropped by a node as a dropped
+// tuple so that it can be received from a source created by
+// NewDroppedTupleCollectorSource and diverted to another stream. err
+// describes why the tuple was dropped. Boxes call it with tuples they don't
+// write to their output.
+func (c *Context) DivertTuple(t *Tuple, nodeType NodeType, nodeName string, err error) {
+	et := ETOutput
+	if nodeType == NTSink {
+		et = ETInput
+	}
+	c.droppedTuple(t, nodeType, nodeName, et, err)
+}
+
 // addDroppedTupleSource adds a listener which receives dropped tuples. The
 // return value is the ID of the listener and it'll be required for
 // removeDroppedTupleListener.
@@ -195,6 +450,29 @@ type ContextFlags struct {
 	// be a little smaller than the originals. However, they might not be parsed
 	// as JSONs. If the flag is disabled, output JSONs can be parsed.
 	DroppedTupleSummarization AtomicFlag
+
+	// LenientEvaluation is a flag to switch the evaluation mode of BQL
+	// expressions. In the strict mode, which is the default, referring to
+	// a field missing in a tuple is an error and the tuple is dropped. In
+	// the lenient mode, such a field is evaluated to NULL so that the tuple
+	// is processed with NULL propagation, e.g. `a + 1` becomes NULL and
+	// `WHERE a > 1` doesn't match. `IS MISSING` works in both modes.
+	LenientEvaluation AtomicFlag
+
+	// StrictConversion is a flag to switch the conversion policy applied
+	// when values are implicitly converted, e.g. when they're passed to UDF
+	// parameters having different types. When it's enabled,
+	// data.StrictConversion is used instead of data.PermissiveConversion
+	// and, for example, 2.7 cannot be passed to an int parameter. Explicit
+	// conversions by CAST aren't affected.
+	StrictConversion AtomicFlag
+
+	// SharedBlobCheck is a flag to check that boxes and sinks don't modify
+	// data.SharedBlobs in tuples they receive. When it's enabled, shared
+	// blobs are verified after each tuple is processed and the tuple fails
+	// with an error when one of them has been modified. It reads all shared
+	// blobs every time, so it should only be enabled for debugging.
+	SharedBlobCheck AtomicFlag
 }
 
 type droppedTupleCollectorSource struct {
@@ -217,6 +495,7 @@ type droppedTupleCollectorSource struct {
 //	- node_name: the name of the node which dropped the tuple
 //	- event_type: the type of the event indicating when the tuple was dropped
 //	- error(optional): the error information if any
+//	- error_code(optional): the code of the error if any (see ErrorCode)
 //	- data: the original content in which the dropped tuple had
 func NewDroppedTupleCollectorSource() Source {
 	src := &droppedTupleCollectorSource{}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"github.com/Sirupsen/logrus"
+)
+
+// ContextBuilder builds a Context step by step. It's mainly for applications
+// embedding SensorBee as a library:
+//
+//	ctx := core.NewContextBuilder().
+//		WithLogger(logger).
+//		WithParent(appCtx).
+//		Build()
+//
+// Each method modifies the builder and returns it so that calls can be
+// chained. A ContextBuilder isn't thread-safe.
+type ContextBuilder struct {
+	config ContextConfig
+}
+
+// NewContextBuilder returns a new ContextBuilder having the default config.
+func NewContextBuilder() *ContextBuilder {
+	return &ContextBuilder{}
+}
+
+// WithConfig replaces all parameters set so far with a copy of the config.
+// Methods called after it override parameters of the config.
+func (b *ContextBuilder) WithConfig(config *ContextConfig) *ContextBuilder {
+	if config == nil {
+		b.config = ContextConfig{}
+	} else {
+		b.config = *config
+	}
+	return b
+}
+
+// WithLogger sets the logger used by the Context.
+func (b *ContextBuilder) WithLogger(l *logrus.Logger) *ContextBuilder {
+	b.config.Logger = l
+	return b
+}
+
+// WithClock sets the Clock providing the time to the topology.
+func (b *ContextBuilder) WithClock(c Clock) *ContextBuilder {
+	b.config.Clock = c
+	return b
+}
+
+// WithMetrics sets Metrics receiving metrics of nodes in the topology.
+func (b *ContextBuilder) WithMetrics(m Metrics) *ContextBuilder {
+	b.config.Metrics = m
+	return b
+}
+
+// WithParent sets the context.Context controlling the lifetime of the
+// topology. See ContextConfig.Parent for details.
+func (b *ContextBuilder) WithParent(ctx context.Context) *ContextBuilder {
+	b.config.Parent = ctx
+	return b
+}
+
+// Build creates a new Context. The builder can be used again to build
+// another Context after calling it.
+func (b *ContextBuilder) Build() *Context {
+	c := b.config
+	return NewContext(&c)
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+)
+
+// ContextSource is a Source which receives a context.Context in addition to
+// a Context when it generates a stream. The context.Context is derived from
+// Context.StdContext and canceled when the source node is stopped, before
+// Stop method of the source is called. Therefore, GenerateStreamContext can
+// pass it to long blocking calls, such as reading from a network connection,
+// so that they're canceled on stop. It also carries deadlines and values,
+// such as tracing information, of ContextConfig.Parent.
+//
+// When a Source implements ContextSource, GenerateStreamContext is called
+// instead of GenerateStream. GenerateStream is only called when the source
+// is used by code which isn't aware of ContextSource. In that case, it can
+// call GenerateStreamContext with ctx.StdContext().
+type ContextSource interface {
+	Source
+
+	// GenerateStreamContext is a variant of GenerateStream receiving a
+	// context.Context. Rules of GenerateStream apply to this method.
+	GenerateStreamContext(c context.Context, ctx *Context, w Writer) error
+}
+
+// ContextBox is a Box which receives a context.Context in addition to a
+// Context when it processes a tuple. The context.Context is canceled when
+// the box node or the topology is stopped. Tuples remaining in the input
+// queue might still be processed with the canceled context.Context. See
+// ContextSource for details.
+//
+// When a Box implements ContextBox, ProcessContext is called instead of
+// Process.
+type ContextBox interface {
+	Box
+
+	// ProcessContext is a variant of Process receiving a context.Context.
+	// Rules of Process apply to this method.
+	ProcessContext(c context.Context, ctx *Context, t *Tuple, w Writer) error
+}
+
+// ContextSink is a Sink which receives a context.Context in addition to a
+// Context when it writes a tuple. The context.Context is canceled when the
+// sink node or the topology is stopped. See ContextSource for details.
+//
+// When a Sink implements ContextSink, WriteContext is called instead of
+// Write.
+type ContextSink interface {
+	Sink
+
+	// WriteContext is a variant of Write receiving a context.Context. Rules
+	// of Write apply to this method.
+	WriteContext(c context.Context, ctx *Context, t *Tuple) error
+}
+
+// toContextSource returns the source as a ContextSource. When the source
+// doesn't implement ContextSource, the context.Context is ignored.
+func toContextSource(s Source) ContextSource {
+	if cs, ok := s.(ContextSource); ok {
+		return cs
+	}
+	return &contextSourceAdapter{s}
+}
+
+type contextSourceAdapter struct {
+	Source
+}
+
+func (a *contextSourceAdapter) GenerateStreamContext(c context.Context, ctx *Context, w Writer) error {
+	return a.GenerateStream(ctx, w)
+}
+
+// toContextBox returns the box as a ContextBox. When the box doesn't
+// implement ContextBox, the context.Context is ignored.
+func toContextBox(b Box) ContextBox {
+	if cb, ok := b.(ContextBox); ok {
+		return cb
+	}
+	return &contextBoxAdapter{b}
+}
+
+type contextBoxAdapter struct {
+	Box
+}
+
+func (a *contextBoxAdapter) ProcessContext(c context.Context, ctx *Context, t *Tuple, w Writer) error {
+	return a.Process(ctx, t, w)
+}
+
+// contextSinkWriter is a WriteCloser writing tuples to a sink with a
+// context.Context.
+type contextSinkWriter struct {
+	c    context.Context
+	sink ContextSink
+}
+
+func newContextSinkWriter(c context.Context, s Sink) *contextSinkWriter {
+	cs, ok := s.(ContextSink)
+	if !ok {
+		cs = &contextSinkAdapter{s}
+	}
+	return &contextSinkWriter{
+		c:    c,
+		sink: cs,
+	}
+}
+
+func (w *contextSinkWriter) Write(ctx *Context, t *Tuple) error {
+	blobs := findSharedBlobsToCheck(ctx, t)
+	if err := w.sink.WriteContext(w.c, ctx, t); err != nil {
+		return err
+	}
+	return verifySharedBlobs(blobs)
+}
+
+func (w *contextSinkWriter) Close(ctx *Context) error {
+	return w.sink.Close(ctx)
+}
+
+type contextSinkAdapter struct {
+	Sink
+}
+
+func (a *contextSinkAdapter) WriteContext(c context.Context, ctx *Context, t *Tuple) error {
+	return a.Write(ctx, t)
+}
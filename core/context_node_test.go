@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+// blockingSource emits a tuple and blocks until the context.Context is
+// canceled.
+type blockingSource struct {
+	canceled chan struct{}
+}
+
+func (s *blockingSource) GenerateStream(ctx *Context, w Writer) error {
+	return s.GenerateStreamContext(ctx.StdContext(), ctx, w)
+}
+
+func (s *blockingSource) GenerateStreamContext(c context.Context, ctx *Context, w Writer) error {
+	if err := w.Write(ctx, freshTuples()[0]); err != nil {
+		return err
+	}
+	<-c.Done()
+	close(s.canceled)
+	return nil
+}
+
+func (s *blockingSource) Stop(ctx *Context) error {
+	return nil
+}
+
+// blockingBox blocks until the context.Context is canceled.
+type blockingBox struct {
+	values   chan interface{}
+	canceled chan struct{}
+}
+
+func (b *blockingBox) Process(ctx *Context, t *Tuple, w Writer) error {
+	return b.ProcessContext(ctx.StdContext(), ctx, t, w)
+}
+
+func (b *blockingBox) ProcessContext(c context.Context, ctx *Context, t *Tuple, w Writer) error {
+	b.values <- c.Value("key")
+	<-c.Done()
+	close(b.canceled)
+	return nil
+}
+
+// blockingSink blocks until the context.Context is canceled.
+type blockingSink struct {
+	received chan struct{}
+	canceled chan struct{}
+}
+
+func (s *blockingSink) Write(ctx *Context, t *Tuple) error {
+	return s.WriteContext(ctx.StdContext(), ctx, t)
+}
+
+func (s *blockingSink) WriteContext(c context.Context, ctx *Context, t *Tuple) error {
+	close(s.received)
+	<-c.Done()
+	close(s.canceled)
+	return nil
+}
+
+func (s *blockingSink) Close(ctx *Context) error {
+	return nil
+}
+
+func TestContextNodes(t *testing.T) {
+	Convey("Given a topology with a parent context having a value", t, func() {
+		parent := context.WithValue(context.Background(), "key", "value")
+		t, err := NewDefaultTopology(NewContextBuilder().WithParent(parent).Build(), "context_nodes_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		so := &blockingSource{canceled: make(chan struct{})}
+		son, err := t.AddSource("source", so, &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a box blocks on processing a tuple", func() {
+			b := &blockingBox{
+				values:   make(chan interface{}, 1),
+				canceled: make(chan struct{}),
+			}
+			bn, err := t.AddBox("box", b, nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then it should receive values of the parent context", func() {
+				So(<-b.values, ShouldEqual, "value")
+			})
+
+			Convey("Then stopping the box should cancel the context", func() {
+				<-b.values
+				So(bn.Stop(), ShouldBeNil)
+				<-b.canceled
+				So(bn.State().Get(), ShouldEqual, TSStopped)
+			})
+		})
+
+		Convey("When a sink blocks on writing a tuple", func() {
+			si := &blockingSink{
+				received: make(chan struct{}),
+				canceled: make(chan struct{}),
+			}
+			sin, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+			<-si.received
+
+			Convey("Then stopping the topology should cancel contexts of all nodes", func() {
+				So(t.Stop(), ShouldBeNil)
+				<-si.canceled
+				<-so.canceled
+				So(sin.State().Get(), ShouldEqual, TSStopped)
+				So(son.State().Get(), ShouldEqual, TSStopped)
+			})
+		})
+
+		Convey("When stopping the source", func() {
+			So(son.Stop(), ShouldBeNil)
+
+			Convey("Then its context should be canceled", func() {
+				<-so.canceled
+				So(son.State().Get(), ShouldEqual, TSStopped)
+			})
+		})
+	})
+}
@@ -1,8 +1,12 @@
 package core
 
 import (
+	"context"
+	"github.com/Sirupsen/logrus"
 	. "github.com/smartystreets/goconvey/convey"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestAtomicFlag(t *testing.T) {
@@ -44,3 +48,220 @@ func TestAtomicFlag(t *testing.T) {
 		})
 	})
 }
+
+// recordingMetrics records the number of tuples written by each node.
+type recordingMetrics struct {
+	m      sync.Mutex
+	counts map[string]int
+}
+
+func (r *recordingMetrics) TupleWritten(topology string, nodeType NodeType, nodeName string, d time.Duration, err error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.counts == nil {
+		r.counts = map[string]int{}
+	}
+	r.counts[topology+"/"+nodeType.String()+"/"+nodeName]++
+}
+
+func (r *recordingMetrics) CounterAdded(topology string, nodeType NodeType, nodeName string, name string, delta int64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.counts == nil {
+		r.counts = map[string]int{}
+	}
+	r.counts[topology+"/"+nodeType.String()+"/"+nodeName+"/"+name] += int(delta)
+}
+
+func (r *recordingMetrics) GaugeSet(topology string, nodeType NodeType, nodeName string, name string, value int64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.counts == nil {
+		r.counts = map[string]int{}
+	}
+	r.counts[topology+"/"+nodeType.String()+"/"+nodeName+"/"+name] = int(value)
+}
+
+func (r *recordingMetrics) count(key string) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.counts[key]
+}
+
+func TestContextDefaultQueueSize(t *testing.T) {
+	Convey("Given a context having the default queue size", t, func() {
+		ctx := NewContext(&ContextConfig{DefaultQueueSize: 32})
+		t, err := NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+		_, err = t.AddSource("source", NewTupleIncrementalEmitterSource(freshTuples()), nil)
+		So(err, ShouldBeNil)
+
+		queueSize := func(receiver string) interface{} {
+			for _, e := range t.Graph().Edges {
+				if e.Receiver == receiver {
+					return e.Stats["queue_size"]
+				}
+			}
+			return nil
+		}
+
+		Convey("When connecting a node without specifying the capacity", func() {
+			bn, err := t.AddBox("box", BoxFunc(forwardBox), nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+
+			Convey("Then the pipe should have the default queue size", func() {
+				So(ctx.DefaultQueueSize(), ShouldEqual, 32)
+				So(queueSize("box"), ShouldEqual, 32)
+			})
+		})
+
+		Convey("When changing the default queue size", func() {
+			So(ctx.SetDefaultQueueSize(64), ShouldBeNil)
+			sn, err := t.AddSink("sink", NewTupleCollectorSink(), nil)
+			So(err, ShouldBeNil)
+			So(sn.Input("source", nil), ShouldBeNil)
+			bn, err := t.AddBox("box", BoxFunc(forwardBox), nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", &BoxInputConfig{Capacity: 8}), ShouldBeNil)
+
+			Convey("Then a newly connected pipe should have the new size", func() {
+				So(queueSize("sink"), ShouldEqual, 64)
+			})
+
+			Convey("Then an explicit capacity should be preferred", func() {
+				So(queueSize("box"), ShouldEqual, 8)
+			})
+		})
+
+		Convey("When resetting the default queue size", func() {
+			So(ctx.SetDefaultQueueSize(0), ShouldBeNil)
+
+			Convey("Then it should be the default value", func() {
+				So(ctx.DefaultQueueSize(), ShouldEqual, 1024)
+			})
+		})
+
+		Convey("When setting an invalid default queue size", func() {
+			Convey("Then it should fail", func() {
+				So(ctx.SetDefaultQueueSize(-1), ShouldNotBeNil)
+				So(ctx.SetDefaultQueueSize(MaxCapacity+1), ShouldNotBeNil)
+				So(ctx.DefaultQueueSize(), ShouldEqual, 32)
+			})
+		})
+	})
+
+	Convey("Given a context without the default queue size", t, func() {
+		ctx := NewContext(nil)
+
+		Convey("Then it should have the default value", func() {
+			So(ctx.DefaultQueueSize(), ShouldEqual, 1024)
+		})
+	})
+}
+
+func TestContextBuilder(t *testing.T) {
+	Convey("Given a context builder", t, func() {
+		b := NewContextBuilder()
+
+		Convey("When building a context with parameters", func() {
+			l := logrus.New()
+			c := NewFakeClock(time.Unix(1, 0))
+			ctx := b.WithLogger(l).WithClock(c).Build()
+
+			Convey("Then the context should have them", func() {
+				So(ctx.logger, ShouldEqual, l)
+				So(ctx.Clock(), ShouldEqual, c)
+			})
+		})
+
+		Convey("When building a context with a config", func() {
+			l := logrus.New()
+			ctx := b.WithLogger(logrus.New()).WithConfig(&ContextConfig{
+				Logger: l,
+				ResourceLimits: &ResourceLimits{
+					MaxTuples: 10,
+				},
+			}).Build()
+
+			Convey("Then the context should have parameters of the config", func() {
+				So(ctx.logger, ShouldEqual, l)
+				So(ctx.Resources.Limits().MaxTuples, ShouldEqual, 10)
+				So(ctx.Clock(), ShouldResemble, SystemClock)
+			})
+		})
+
+		Convey("When building a context with metrics", func() {
+			m := &recordingMetrics{}
+			ctx := b.WithMetrics(m).Build()
+			t, err := NewDefaultTopology(ctx, "metrics_test")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				t.Stop()
+			})
+
+			fts := freshTuples()
+			son, err := t.AddSource("source", NewTupleEmitterSource(fts), &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+			bn, err := t.AddBox("box", BoxFunc(forwardBox), nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+			si := NewTupleCollectorSink()
+			sin, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("box", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+			si.Wait(len(fts))
+
+			Convey("Then metrics of each node should be reported", func() {
+				So(m.count("metrics_test/source/source"), ShouldEqual, len(fts))
+				So(m.count("metrics_test/box/box"), ShouldEqual, len(fts))
+				for i := 0; i < 1000 && m.count("metrics_test/sink/sink") < len(fts); i++ {
+					time.Sleep(time.Millisecond)
+				}
+				So(m.count("metrics_test/sink/sink"), ShouldEqual, len(fts))
+			})
+
+			Convey("Then counters added by nodes should be reported", func() {
+				ctx.AddCounter(NTBox, "box", "violations", 2)
+				ctx.AddCounter(NTBox, "box", "violations", 3)
+				So(m.count("metrics_test/box/box/violations"), ShouldEqual, 5)
+			})
+
+			Convey("Then gauges set by nodes should be reported", func() {
+				ctx.SetGauge(NTBox, "box", "keys.live", 7)
+				ctx.SetGauge(NTBox, "box", "keys.live", 3)
+				So(m.count("metrics_test/box/box/keys.live"), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When building a context with a parent context", func() {
+			parent, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx := b.WithParent(parent).Build()
+			t, err := NewDefaultTopology(ctx, "parent_test")
+			So(err, ShouldBeNil)
+			Reset(func() {
+				t.Stop()
+			})
+
+			Convey("Then canceling the parent should stop the topology", func() {
+				cancel()
+				So(t.State().Wait(TSStopped), ShouldEqual, TSStopped)
+				So(ctx.StdContext().Err(), ShouldNotBeNil)
+			})
+
+			Convey("Then stopping the topology should cancel its std context", func() {
+				So(ctx.StdContext().Err(), ShouldBeNil)
+				So(t.Stop(), ShouldBeNil)
+				So(ctx.StdContext().Err(), ShouldNotBeNil)
+				So(parent.Err(), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -3,15 +3,19 @@ package core
 import (
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
 )
 
 type defaultBoxNode struct {
 	*defaultNode
 	config *BoxConfig
 	srcs   *dataSources
-	box    Box
 	dsts   *dataDestinations
 
+	// boxMutex protects box, which is replaced when the box is recreated.
+	boxMutex sync.RWMutex
+	box      Box
+
 	gracefulStopEnabled bool
 	stopOnDisconnectDir ConnDir
 	runErr              error
@@ -22,6 +26,8 @@ func (db *defaultBoxNode) Type() NodeType {
 }
 
 func (db *defaultBoxNode) Box() Box {
+	db.boxMutex.RLock()
+	defer db.boxMutex.RUnlock()
 	return db.box
 }
 
@@ -38,12 +44,15 @@ func (db *defaultBoxNode) Input(refname string, config *BoxInputConfig) error {
 		return err
 	}
 
-	if err := checkBoxInputName(db.box, db.name, config.inputName()); err != nil {
+	if err := checkBoxInputName(db.Box(), db.name, config.inputName()); err != nil {
 		return err
 	}
 
-	recv, send := newPipe(config.inputName(), config.capacity())
+	recv, send := newPipeWithType(config.PipeType, config.inputName(), config.capacity(db.topology.ctx, db.name))
+	c := *config
+	recv.config = &c
 	send.dropMode = config.DropMode
+	send.partition = config.Partition
 	if err := s.destinations().add(db.name, send); err != nil {
 		return err
 	}
@@ -54,6 +63,15 @@ func (db *defaultBoxNode) Input(refname string, config *BoxInputConfig) error {
 	return nil
 }
 
+func (db *defaultBoxNode) Inputs() map[string]*BoxInputConfig {
+	m := map[string]*BoxInputConfig{}
+	for name, c := range db.srcs.inputConfigs() {
+		config := *c.(*BoxInputConfig)
+		m[name] = &config
+	}
+	return m
+}
+
 func (db *defaultBoxNode) run() (runErr error) {
 	if err := db.checkAndPrepareForRunning("box"); err != nil {
 		return err
@@ -65,7 +83,7 @@ func (db *defaultBoxNode) run() (runErr error) {
 				if db.runErr == nil {
 					db.runErr = fmt.Errorf("the box couldn't be terminated due to panic: %v", e)
 				} else {
-					db.topology.ctx.ErrLog(fmt.Errorf("%v", e)).WithFields(nodeLogFields(NTBox, db.name)).
+					db.topology.ctx.nodeErrLog(NTBox, db.name, fmt.Errorf("%v", e)).
 						Error("Cannot terminate the box due to panic")
 				}
 			}
@@ -73,23 +91,86 @@ func (db *defaultBoxNode) run() (runErr error) {
 			db.dsts.Close(db.topology.ctx)
 			db.state.Set(TSStopped)
 		}()
-		if sb, ok := db.box.(StatefulBox); ok {
+		if sb, ok := db.Box().(StatefulBox); ok {
 			if err := sb.Terminate(db.topology.ctx); err != nil {
 				if db.runErr == nil {
 					db.runErr = err
 				} else {
-					db.topology.ctx.ErrLog(err).WithFields(nodeLogFields(NTBox, db.name)).
+					db.topology.ctx.nodeErrLog(NTBox, db.name, err).
 						Error("Cannot terminate the box")
 				}
 			}
 		}
 	}()
 	db.state.Set(TSRunning)
-	w := newBoxWriterAdapter(db.box, db.name, db.dsts)
-	db.runErr = db.srcs.pour(db.topology.ctx, w, 1) // TODO: make parallelism configurable
+	w := newBoxWriterAdapter(db.std, db.Box(), db.name, db.dsts)
+	mw := newMetricsWriter(db.topology.ctx, newFaultWriter(w, db.name), NTBox, db.name)
+	rw := newRecoveryWriter(mw, db.topology, NTBox, db.name, &db.recovery, db.reinit(w))
+	bw := newBreakpointWriter(rw, db.name, db.std.Done())
+	db.stateMutex.Lock()
+	parallelism := db.config.parallelism()
+	db.stateMutex.Unlock()
+	db.runErr = db.srcs.pour(db.topology.ctx, bw, parallelism)
 	return
 }
 
+// reinit returns a function initializing the box again when it's restarted by
+// its recovery policy. When recreate is true and the box has
+// BoxConfig.Recreate, the box is replaced with a new instance written by w.
+// Otherwise, a StatefulBox is terminated and initialized again.
+func (db *defaultBoxNode) reinit(w *boxWriterAdapter) func(ctx *Context, recreate bool) error {
+	return func(ctx *Context, recreate bool) (err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("the box cannot be restarted due to panic: %v", e)
+			}
+		}()
+
+		recreate = recreate && db.config.Recreate != nil
+		old := db.Box()
+		sb, ok := old.(StatefulBox)
+		if !ok && !recreate {
+			return nil
+		}
+		if ok {
+			db.terminateForRestart(ctx, sb)
+		}
+		if !recreate {
+			return sb.Init(ctx)
+		}
+
+		b, err := db.config.Recreate(ctx)
+		if err != nil {
+			return fmt.Errorf("the box cannot be recreated: %v", err)
+		}
+		if sb, ok := b.(StatefulBox); ok {
+			if err := sb.Init(ctx); err != nil {
+				return err
+			}
+		}
+		db.boxMutex.Lock()
+		db.box = b
+		db.boxMutex.Unlock()
+		w.setBox(b)
+		ctx.nodeLog(NTBox, db.name).Info("The box has been recreated")
+		return nil
+	}
+}
+
+// terminateForRestart terminates the box before it's restarted. Errors and
+// panics are only logged because the box is restarted anyway.
+func (db *defaultBoxNode) terminateForRestart(ctx *Context, sb StatefulBox) {
+	defer func() {
+		if e := recover(); e != nil {
+			ctx.nodeErrLog(NTBox, db.name, fmt.Errorf("%v", e)).
+				Warn("Cannot terminate the box before restarting it due to panic")
+		}
+	}()
+	if err := sb.Terminate(ctx); err != nil {
+		ctx.nodeErrLog(NTBox, db.name, err).Warn("Cannot terminate the box before restarting it")
+	}
+}
+
 func (db *defaultBoxNode) Stop() error {
 	db.stop()
 	return nil
@@ -124,6 +205,7 @@ func (db *defaultBoxNode) stop() {
 	}
 
 	db.state.Set(TSStopping)
+	db.cancelStd()
 	db.srcs.stop(db.topology.ctx) // waits until all tuples get processed.
 	db.state.Wait(TSStopped)
 }
@@ -146,14 +228,15 @@ func (db *defaultBoxNode) Status() data.Map {
 			"graceful_stop":               data.Bool(gstop),
 			"remove_on_stop":              data.Bool(removeOnStop),
 		},
+		"recovery": db.recovery.status(db.topology.ctx, db.name),
 	}
 	if st == TSStopped && db.runErr != nil {
 		m["error"] = data.String(db.runErr.Error())
 	}
-	if b, ok := db.box.(Statuser); ok {
+	if b, ok := db.Box().(Statuser); ok {
 		m["box"] = b.Status()
 	}
-	return m
+	return db.topology.ctx.Secrets.RedactMap(m)
 }
 
 func (db *defaultBoxNode) destinations() *dataDestinations {
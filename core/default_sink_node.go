@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
 )
 
 type defaultSinkNode struct {
@@ -11,9 +12,26 @@ type defaultSinkNode struct {
 	srcs   *dataSources
 	sink   Sink
 
+	staleness *stalenessWriter
+	latency   *nodeLatency
+
 	gracefulStopEnabled     bool
 	stopOnDisconnectEnabled bool
 	runErr                  error
+
+	// flushMutex serializes calls to Flush of the sink. Statistics of
+	// flushes are protected by stateMutex so that Status isn't blocked by a
+	// slow flush.
+	flushMutex     sync.Mutex
+	flushClosed    bool
+	numFlushes     int64
+	numFlushErrors int64
+	lastFlushError error
+
+	// batchSize and queueDepth are the current state of adaptive batching.
+	// They're protected by stateMutex.
+	batchSize  int
+	queueDepth float64
 }
 
 func (ds *defaultSinkNode) Type() NodeType {
@@ -45,8 +63,11 @@ func (ds *defaultSinkNode) Input(refname string, config *SinkInputConfig) error
 		return err
 	}
 
-	recv, send := newPipe("output", config.capacity())
+	recv, send := newPipeWithType(config.PipeType, "output", config.capacity(ds.topology.ctx, ds.name))
+	c := *config
+	recv.config = &c
 	send.dropMode = config.DropMode
+	send.partition = config.Partition
 	if err := s.destinations().add(ds.name, send); err != nil {
 		return err
 	}
@@ -57,6 +78,27 @@ func (ds *defaultSinkNode) Input(refname string, config *SinkInputConfig) error
 	return nil
 }
 
+func (ds *defaultSinkNode) Inputs() map[string]*SinkInputConfig {
+	m := map[string]*SinkInputConfig{}
+	for name, c := range ds.srcs.inputConfigs() {
+		config := *c.(*SinkInputConfig)
+		m[name] = &config
+	}
+	return m
+}
+
+func (ds *defaultSinkNode) RemoveInput(refname string) error {
+	s, err := ds.topology.dataSource(refname)
+	if err != nil {
+		return err
+	}
+	if _, ok := ds.srcs.inputConfigs()[s.Name()]; !ok {
+		return NotExistError(fmt.Errorf("sink '%v' isn't receiving tuples from '%v'", ds.name, refname))
+	}
+	s.destinations().remove(ds.name)
+	return nil
+}
+
 func (ds *defaultSinkNode) run() (runErr error) {
 	if err := ds.checkAndPrepareForRunning("sink"); err != nil {
 		return err
@@ -69,28 +111,163 @@ func (ds *defaultSinkNode) run() (runErr error) {
 				if ds.runErr == nil {
 					ds.runErr = fmt.Errorf("the box couldn't be terminated due to panic: %v", e)
 				} else {
-					ds.topology.ctx.ErrLog(fmt.Errorf("%v", e)).WithFields(nodeLogFields(NTBox, ds.name)).
+					ds.topology.ctx.nodeErrLog(NTBox, ds.name, fmt.Errorf("%v", e)).
 						Error("Cannot terminate the box due to panic")
 				}
 			}
 			runErr = ds.runErr
 		}()
+		ds.closeFlush()
 		if err := ds.sink.Close(ds.topology.ctx); err != nil {
 			ds.runErr = err
-			ds.topology.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, ds.name)).
+			ds.topology.ctx.nodeErrLog(NTSink, ds.name, err).
 				Error("Cannot stop the sink")
 		}
 	}()
 	ds.state.Set(TSRunning)
-	ds.runErr = ds.srcs.pour(ds.topology.ctx, newTraceWriter(ds.sink, ETInput, ds.name), 1)
+	w := newLatencyWriter(newContextSinkWriter(ds.std, ds.sink), ds.latency)
+	if ds.staleness != nil {
+		ds.staleness.w = w
+		w = ds.staleness
+	}
+	fw := newFaultWriter(newTraceWriter(newAckWriter(w, ds.name), ETInput, ds.name), ds.name)
+	mw := newMetricsWriter(ds.topology.ctx, fw, NTSink, ds.name)
+	ds.stateMutex.Lock()
+	ds.srcs.lockOSThread = ds.config.LockOSThread
+	ds.stateMutex.Unlock()
+	rw := newRecoveryWriter(mw, ds.topology, NTSink, ds.name, &ds.recovery, nil)
+	if stop := ds.batchAdaptively(); stop != nil {
+		defer stop()
+	} else if stop := ds.flushPeriodically(); stop != nil {
+		defer stop()
+	}
+	ds.runErr = ds.srcs.pour(ds.topology.ctx, newBreakpointWriter(rw, ds.name, ds.std.Done()), 1)
 	return
 }
 
+// flushPeriodically starts flushing the sink every FlushInterval. It returns
+// a function stopping it, or nil when the sink isn't flushed periodically.
+func (ds *defaultSinkNode) flushPeriodically() func() {
+	if ds.config.FlushInterval <= 0 {
+		return nil
+	}
+	if _, ok := findFlushable(ds.sink); !ok {
+		return nil
+	}
+
+	ticker := ds.topology.ctx.Clock().NewTicker(ds.config.FlushInterval)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C():
+				if err := ds.Flush(); err != nil {
+					ds.topology.ctx.nodeErrLog(NTSink, ds.name, err).
+						Error("Cannot flush the sink")
+				}
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(stop)
+		<-done
+	}
+}
+
+func (ds *defaultSinkNode) Flush() error {
+	f, ok := findFlushable(ds.sink)
+	if !ok {
+		return nil
+	}
+	ds.flushMutex.Lock()
+	defer ds.flushMutex.Unlock()
+	if ds.flushClosed {
+		return nil
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("the sink couldn't be flushed due to panic: %v", e)
+			}
+		}()
+		return f.Flush(ds.topology.ctx)
+	}()
+	ds.stateMutex.Lock()
+	ds.numFlushes++
+	if err != nil {
+		ds.numFlushErrors++
+		ds.lastFlushError = err
+	}
+	ds.stateMutex.Unlock()
+	return err
+}
+
+func (ds *defaultSinkNode) Connect() (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("the sink couldn't be connected due to panic: %v", e)
+		}
+	}()
+	if c, ok := findConnector(ds.sink); ok {
+		if err := c.Connect(ds.topology.ctx); err != nil {
+			return err
+		}
+	}
+	if h, ok := ds.sink.(HealthChecker); ok {
+		return h.CheckHealth(ds.topology.ctx)
+	}
+	return nil
+}
+
+// closeFlush flushes the sink for the last time before it's closed.
+func (ds *defaultSinkNode) closeFlush() {
+	if err := ds.Flush(); err != nil {
+		ds.topology.ctx.nodeErrLog(NTSink, ds.name, err).
+			Error("Cannot flush the sink before closing it")
+	}
+	ds.flushMutex.Lock()
+	ds.flushClosed = true
+	ds.flushMutex.Unlock()
+}
+
+// flushStatus returns the status of flushes. It returns nil when the sink
+// doesn't implement Flushable.
+func (ds *defaultSinkNode) flushStatus() data.Map {
+	if _, ok := findFlushable(ds.sink); !ok {
+		return nil
+	}
+	ds.stateMutex.Lock()
+	defer ds.stateMutex.Unlock()
+	m := data.Map{
+		"interval":    data.String(ds.config.FlushInterval.String()),
+		"num_flushes": data.Int(ds.numFlushes),
+		"num_errors":  data.Int(ds.numFlushErrors),
+	}
+	if ds.lastFlushError != nil {
+		m["last_error"] = data.String(ds.lastFlushError.Error())
+	}
+	return m
+}
+
 func (ds *defaultSinkNode) Stop() error {
 	ds.stop()
 	return nil
 }
 
+func (ds *defaultSinkNode) Update(params data.Map) error {
+	// stateMutex makes the update atomic with respect to other operations
+	// such as Stop.
+	ds.stateMutex.Lock()
+	defer ds.stateMutex.Unlock()
+	return applyUpdate(ds.topology.ctx, ds.name, ds.sink, params)
+}
+
 func (ds *defaultSinkNode) EnableGracefulStop() {
 	ds.stateMutex.Lock()
 	ds.gracefulStopEnabled = true
@@ -109,6 +286,7 @@ func (ds *defaultSinkNode) stop() {
 	if stopped, err := ds.checkAndPrepareForStopping("sink"); stopped || err != nil {
 		return
 	}
+	ds.cancelStd()
 	ds.srcs.stop(ds.topology.ctx)
 	ds.state.Wait(TSStopped)
 }
@@ -129,14 +307,27 @@ func (ds *defaultSinkNode) Status() data.Map {
 			"graceful_stop":      data.Bool(gstop),
 			"remove_on_stop":     data.Bool(removeOnStop),
 		},
+		"recovery": ds.recovery.status(ds.topology.ctx, ds.name),
 	}
 	if st == TSStopped && ds.runErr != nil {
 		m["error"] = data.String(ds.runErr.Error())
 	}
+	if ds.staleness != nil {
+		m["staleness"] = ds.staleness.status()
+	}
+	if ds.latency != nil {
+		m["latency"] = ds.latency.latency().Map()
+	}
+	if f := ds.flushStatus(); f != nil {
+		m["flush"] = f
+	}
+	if b := ds.adaptiveBatchingStatus(); b != nil {
+		m["adaptive_batching"] = b
+	}
 	if s, ok := ds.sink.(Statuser); ok {
 		m["sink"] = s.Status()
 	}
-	return m
+	return ds.topology.ctx.Secrets.RedactMap(m)
 }
 
 func (ds *defaultSinkNode) RemoveOnStop() {
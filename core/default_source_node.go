@@ -11,9 +11,17 @@ type defaultSourceNode struct {
 	config                  *SourceConfig
 	source                  Source
 	dsts                    *dataDestinations
+	staleness               *stalenessWriter
+	latency                 *nodeLatency
+	startOffset             *startOffsetWriter
 	pausedOnStartup         bool
 	stopOnDisconnectEnabled bool
 	runErr                  error
+
+	// prepareErr and cleanupErr are errors returned from Prepare and Cleanup
+	// of the source. They're reported separately from runErr.
+	prepareErr error
+	cleanupErr error
 }
 
 func (ds *defaultSourceNode) Type() NodeType {
@@ -29,16 +37,31 @@ func (ds *defaultSourceNode) run() (runErr error) {
 		return err
 	}
 
+	prepared := false
 	defer func() {
 		defer ds.state.Set(TSStopped)
+		defer ds.cancelStd()
 		if e := recover(); e != nil {
 			// ds.runErr is always nil here
 			ds.runErr = fmt.Errorf("the source failed to generate a stream due to panic: %v", e)
 		}
 		runErr = ds.runErr
+		if prepared {
+			ds.cleanup()
+		} else if ds.prepareErr != nil {
+			runErr = fmt.Errorf("cannot prepare the source: %v", ds.prepareErr)
+		}
 		ds.dsts.Close(ds.topology.ctx)
 	}()
 
+	if err := ds.prepare(); err != nil {
+		ds.stateMutex.Lock()
+		ds.prepareErr = err
+		ds.stateMutex.Unlock()
+		return
+	}
+	prepared = true
+
 	ds.runErr = func() error {
 		ds.stateMutex.Lock()
 		defer ds.stateMutex.Unlock()
@@ -56,10 +79,59 @@ func (ds *defaultSourceNode) run() (runErr error) {
 		return
 	}
 
-	ds.runErr = ds.source.GenerateStream(ds.topology.ctx, newTraceWriter(ds.dsts, ETOutput, ds.name))
+	w := newLatencyWriter(ds.dsts, ds.latency)
+	if ds.staleness != nil {
+		ds.staleness.w = w
+		w = ds.staleness
+	}
+	if ds.startOffset != nil {
+		ds.startOffset.w = w
+		w = ds.startOffset
+	}
+	fw := newFaultWriter(newTraceWriter(newOffsetSourceWriter(w, ds.name), ETOutput, ds.name), ds.name)
+	ds.runErr = toContextSource(ds.source).GenerateStreamContext(ds.std, ds.topology.ctx,
+		newMetricsWriter(ds.topology.ctx, fw, NTSource, ds.name))
 	return
 }
 
+// prepare calls Prepare of the source when it implements Preparer.
+func (ds *defaultSourceNode) prepare() (err error) {
+	p, ok := findPreparer(ds.source)
+	if !ok {
+		return nil
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("the source couldn't be prepared due to panic: %v", e)
+		}
+	}()
+	return p.Prepare(ds.topology.ctx)
+}
+
+// cleanup calls Cleanup of the source when it implements Cleaner. An error
+// is logged and reported in the status.
+func (ds *defaultSourceNode) cleanup() {
+	c, ok := findCleaner(ds.source)
+	if !ok {
+		return
+	}
+	err := func() (err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				err = fmt.Errorf("the source couldn't be cleaned up due to panic: %v", e)
+			}
+		}()
+		return c.Cleanup(ds.topology.ctx)
+	}()
+	if err == nil {
+		return
+	}
+	ds.topology.ctx.nodeErrLog(NTSource, ds.name, err).Error("Cannot clean up the source")
+	ds.stateMutex.Lock()
+	ds.cleanupErr = err
+	ds.stateMutex.Unlock()
+}
+
 func (ds *defaultSourceNode) Stop() error {
 	ds.stateMutex.Lock()
 	defer ds.stateMutex.Unlock()
@@ -73,6 +145,7 @@ func (ds *defaultSourceNode) Stop() error {
 	} else if stopped {
 		return nil
 	}
+	ds.cancelStd()
 
 	if paused {
 		// The source doesn't have to be resumed since Stop must stop the source
@@ -189,14 +262,39 @@ func (ds *defaultSourceNode) Rewind() error {
 	if ds.state.getWithoutLock() >= TSStopping {
 		return errors.New("the source is stopped")
 	}
+	ds.startOffset.reset()
 	return rs.Rewind(ds.topology.ctx)
 }
 
+func (ds *defaultSourceNode) Update(params data.Map) error {
+	// stateMutex makes the update atomic with respect to other operations
+	// such as Stop.
+	ds.stateMutex.Lock()
+	defer ds.stateMutex.Unlock()
+	return applyUpdate(ds.topology.ctx, ds.name, ds.source, params)
+}
+
+func (ds *defaultSourceNode) RewindTo(offset int64) error {
+	rs, ok := ds.source.(OffsetRewindableSource)
+	if !ok {
+		return errors.New("the source doesn't support rewinding to an offset")
+	}
+
+	ds.stateMutex.Lock()
+	defer ds.stateMutex.Unlock()
+	if ds.state.getWithoutLock() >= TSStopping {
+		return errors.New("the source is stopped")
+	}
+	ds.startOffset.reset()
+	return rs.RewindTo(ds.topology.ctx, offset)
+}
+
 func (ds *defaultSourceNode) Status() data.Map {
 	ds.stateMutex.Lock()
 	st := ds.state.getWithoutLock()
 	stopOnDisconnect := ds.stopOnDisconnectEnabled
 	removeOnStop := ds.config.RemoveOnStop
+	prepareErr, cleanupErr := ds.prepareErr, ds.cleanupErr
 	ds.stateMutex.Unlock()
 
 	m := data.Map{
@@ -210,10 +308,22 @@ func (ds *defaultSourceNode) Status() data.Map {
 	if st == TSStopped && ds.runErr != nil {
 		m["error"] = data.String(ds.runErr.Error())
 	}
+	if prepareErr != nil {
+		m["prepare_error"] = data.String(prepareErr.Error())
+	}
+	if cleanupErr != nil {
+		m["cleanup_error"] = data.String(cleanupErr.Error())
+	}
+	if ds.staleness != nil {
+		m["staleness"] = ds.staleness.status()
+	}
+	if ds.latency != nil {
+		m["latency"] = ds.latency.latency().Map()
+	}
 	if s, ok := ds.source.(Statuser); ok {
 		m["source"] = s.Status()
 	}
-	return m
+	return ds.topology.ctx.Secrets.RedactMap(m)
 }
 
 func (ds *defaultSourceNode) destinations() *dataDestinations {
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -43,9 +44,26 @@ func NewDefaultTopology(ctx *Context, name string) (Topology, error) {
 	}
 	t.state = newTopologyStateHolder(&t.stateMutex)
 	t.state.state = TSRunning // A topology is running by default.
+	t.state.onChange = ctx.topologyStateChanged
+	if ctx.parent != nil {
+		go t.stopOnParentDone()
+	}
 	return t, nil
 }
 
+// stopOnParentDone stops the topology when the parent context.Context of
+// the Context is done. It returns after the topology is stopped.
+func (t *defaultTopology) stopOnParentDone() {
+	<-t.ctx.std.Done()
+	if t.ctx.parent.Err() == nil {
+		return // the topology stopped by itself
+	}
+	t.ctx.ErrLog(t.ctx.parent.Err()).Info("Stopping the topology because the parent context is done")
+	if err := t.Stop(); err != nil {
+		t.ctx.ErrLog(err).Error("Cannot stop the topology")
+	}
+}
+
 func (t *defaultTopology) Name() string {
 	return t.name
 }
@@ -62,6 +80,14 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 	if config == nil {
 		config = &SourceConfig{}
 	}
+	if config.Staleness != nil {
+		if err := config.Staleness.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if config.StartOffset < 0 {
+		return nil, fmt.Errorf("the start offset must not be negative: %v", config.StartOffset)
+	}
 
 	// This method assumes adding a Source having a duplicated name is rare.
 	// Under this assumption, acquiring wlock without checking the existence
@@ -81,14 +107,24 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 		return nil, fmt.Errorf("the topology is already stopped")
 	}
 
+	if ss, ok := s.(*supervisedSource); ok {
+		ss.nodeName = name
+	}
+
 	ds := &defaultSourceNode{
-		defaultNode:     newDefaultNode(t, name, config.Meta),
+		defaultNode:     newDefaultNode(t, NTSource, name, config.Meta),
 		source:          s,
 		dsts:            newDataDestinations(NTSource, name),
 		pausedOnStartup: config.PausedOnStartup,
 	}
 	ds.config = &SourceConfig{}
 	*ds.config = *config
+	if config.Staleness != nil {
+		ds.staleness = newStalenessWriter(ds.dsts, config.Staleness, NTSource, name)
+	}
+	if config.StartOffset > 0 {
+		ds.startOffset = newStartOffsetWriter(config.StartOffset)
+	}
 	ds.dsts.callback = ds.dstCallback
 	if err := t.checkNodeNameDuplication(name); err != nil {
 		// Because the source isn't started yet, it doesn't return an error.
@@ -96,12 +132,15 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 		return nil, err
 	}
 	t.sources[strings.ToLower(name)] = ds
+	ds.latency = t.ctx.Latencies.addNode(t.ctx, NTSource, name, s)
 
 	go func() {
+		setNodeProfileLabels(t.name, NTSource, name)
 		// TODO: Support lazy invocation
 		if err := ds.run(); err != nil {
-			t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSource, name)).
+			t.ctx.nodeErrLog(NTSource, name, err).
 				Error("Cannot generate a stream from the source")
+			t.ctx.nodeFailed(NTSource, name, err)
 		}
 		ds.stateMutex.Lock()
 		removeOnStop := ds.config.RemoveOnStop
@@ -109,7 +148,7 @@ func (t *defaultTopology) AddSource(name string, s Source, config *SourceConfig)
 		if removeOnStop {
 			if err := t.Remove(name); err != nil {
 				if !IsNotExist(err) {
-					t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSource, name)).
+					t.ctx.nodeErrLog(NTSource, name, err).
 						Error("Cannot remove the source from topology")
 				}
 			}
@@ -149,6 +188,9 @@ func (t *defaultTopology) AddBox(name string, b Box, config *BoxConfig) (BoxNode
 	if config == nil {
 		config = &BoxConfig{}
 	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 
 	t.nodeMutex.Lock()
 	defer t.nodeMutex.Unlock()
@@ -179,7 +221,7 @@ func (t *defaultTopology) AddBox(name string, b Box, config *BoxConfig) (BoxNode
 	}
 
 	db := &defaultBoxNode{
-		defaultNode: newDefaultNode(t, name, config.Meta),
+		defaultNode: newDefaultNode(t, NTBox, name, config.Meta),
 		srcs:        newDataSources(NTBox, name),
 		box:         b,
 		dsts:        newDataDestinations(NTBox, name),
@@ -190,9 +232,11 @@ func (t *defaultTopology) AddBox(name string, b Box, config *BoxConfig) (BoxNode
 	t.boxes[strings.ToLower(name)] = db
 
 	go func() {
+		setNodeProfileLabels(t.name, NTBox, name)
 		if err := db.run(); err != nil {
-			t.ctx.ErrLog(err).WithFields(nodeLogFields(NTBox, db.name)).
+			t.ctx.nodeErrLog(NTBox, db.name, err).
 				Error("The box failed")
+			t.ctx.nodeFailed(NTBox, db.name, err)
 		}
 		db.stateMutex.Lock()
 		removeOnStop := db.config.RemoveOnStop
@@ -200,7 +244,7 @@ func (t *defaultTopology) AddBox(name string, b Box, config *BoxConfig) (BoxNode
 		if removeOnStop {
 			if err := t.Remove(name); err != nil {
 				if !IsNotExist(err) {
-					t.ctx.ErrLog(err).WithFields(nodeLogFields(NTBox, db.name)).
+					t.ctx.nodeErrLog(NTBox, db.name, err).
 						Error("Cannot remove the box from topology")
 				}
 			}
@@ -220,12 +264,12 @@ func (t *defaultTopology) AddSink(name string, s Sink, config *SinkConfig) (Sink
 		}
 		defer func() {
 			if e := recover(); e != nil {
-				t.ctx.Log().WithFields(nodeLogFields(NTSink, name)).
+				t.ctx.nodeLog(NTSink, name).
 					Errorf("Cannot close the sink which hasn't been added to the topology: %v", e)
 			}
 		}()
 		if err := s.Close(t.ctx); err != nil {
-			t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, name)).
+			t.ctx.nodeErrLog(NTSink, name, err).
 				Error("Cannot close the sink which hasn't been added to the topology")
 		}
 	}()
@@ -238,6 +282,22 @@ func (t *defaultTopology) AddSink(name string, s Sink, config *SinkConfig) (Sink
 	if config == nil {
 		config = &SinkConfig{}
 	}
+	if config.Staleness != nil {
+		if err := config.Staleness.Validate(); err != nil {
+			closeSinkFlag = true
+			return nil, err
+		}
+	}
+	if config.FlushInterval < 0 {
+		closeSinkFlag = true
+		return nil, fmt.Errorf("the flush interval must not be negative: %v", config.FlushInterval)
+	}
+	if config.AdaptiveBatching != nil {
+		if err := config.AdaptiveBatching.Validate(); err != nil {
+			closeSinkFlag = true
+			return nil, err
+		}
+	}
 
 	t.nodeMutex.Lock()
 	defer t.nodeMutex.Unlock()
@@ -252,18 +312,28 @@ func (t *defaultTopology) AddSink(name string, s Sink, config *SinkConfig) (Sink
 	}
 
 	ds := &defaultSinkNode{
-		defaultNode: newDefaultNode(t, name, config.Meta),
+		defaultNode: newDefaultNode(t, NTSink, name, config.Meta),
 		srcs:        newDataSources(NTSink, name),
 		sink:        s,
 	}
 	ds.config = &SinkConfig{}
 	*ds.config = *config
+	if config.AdaptiveBatching != nil {
+		b := *config.AdaptiveBatching
+		ds.config.AdaptiveBatching = &b
+	}
+	if config.Staleness != nil {
+		ds.staleness = newStalenessWriter(newContextSinkWriter(ds.std, s), config.Staleness, NTSink, name)
+	}
 	t.sinks[strings.ToLower(name)] = ds
+	ds.latency = t.ctx.Latencies.addNode(t.ctx, NTSink, name, s)
 
 	go func() {
+		setNodeProfileLabels(t.name, NTSink, name)
 		if err := ds.run(); err != nil {
-			t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, ds.name)).
+			t.ctx.nodeErrLog(NTSink, ds.name, err).
 				Error("The sink failed")
+			t.ctx.nodeFailed(NTSink, ds.name, err)
 		}
 		ds.stateMutex.Lock()
 		removeOnStop := ds.config.RemoveOnStop
@@ -271,7 +341,7 @@ func (t *defaultTopology) AddSink(name string, s Sink, config *SinkConfig) (Sink
 		if removeOnStop {
 			if err := t.Remove(name); err != nil {
 				if !IsNotExist(err) {
-					t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSink, ds.name)).
+					t.ctx.nodeErrLog(NTSink, ds.name, err).
 						Error("Cannot remove the sink from topology")
 				}
 			}
@@ -291,13 +361,22 @@ func (t *defaultTopology) Stop() error {
 		return nil
 	}
 
+	// Blocking calls in boxes and sinks are canceled first so that they
+	// don't block stopping the topology.
+	for _, b := range t.boxes {
+		b.cancelStd()
+	}
+	for _, s := range t.sinks {
+		s.cancelStd()
+	}
+
 	var lastErr error
 	for name, src := range t.sources {
 		// TODO: this could be run concurrently
 		if err := src.Stop(); err != nil { // Stop doesn't panic
 			lastErr = err
 			src.dsts.Close(t.ctx)
-			t.ctx.ErrLog(err).WithFields(nodeLogFields(NTSource, name)).
+			t.ctx.nodeErrLog(NTSource, name, err).
 				Error("Cannot stop the source")
 		}
 	}
@@ -330,6 +409,9 @@ func (t *defaultTopology) Stop() error {
 	t.boxes = nil
 	t.sinks = nil
 	t.state.Set(TSStopped)
+	if t.ctx.cancel != nil {
+		t.ctx.cancel()
+	}
 	return lastErr
 }
 
@@ -361,6 +443,8 @@ func (t *defaultTopology) Remove(name string) error {
 		return err
 	}
 
+	t.ctx.Offsets.RemoveNode(name)
+	t.ctx.Latencies.RemoveNode(name)
 	if err := n.Stop(); err != nil { // stop never panics
 		if n.Type() == NTSource {
 			s := n.(*defaultSourceNode)
@@ -489,16 +573,72 @@ func (t *defaultTopology) dataSource(nodeName string) (dataSource, error) {
 	return nil, NotExistError(fmt.Errorf("data source node %v was not found", nodeName))
 }
 
+// requestRestart requests all boxes and sinks except the given node to
+// restart. Each node restarts itself before processing the next tuple.
+func (t *defaultTopology) requestRestart(except string) {
+	t.nodeMutex.RLock()
+	defer t.nodeMutex.RUnlock()
+	for name, b := range t.boxes {
+		if name != strings.ToLower(except) {
+			b.recovery.restartRequested.Set(true)
+		}
+	}
+	for name, s := range t.sinks {
+		if name != strings.ToLower(except) {
+			s.recovery.restartRequested.Set(true)
+		}
+	}
+}
+
+// replayOffsets returns offsets of the first tuples which haven't been
+// written by the sink. Keys of the map are sources implementing
+// OffsetRewindableSource and having tuples acknowledged by the sink.
+func (t *defaultTopology) replayOffsets(sinkName string) map[*defaultSourceNode]int64 {
+	t.nodeMutex.RLock()
+	defer t.nodeMutex.RUnlock()
+	offsets := map[*defaultSourceNode]int64{}
+	for _, s := range t.sources {
+		if _, ok := s.source.(OffsetRewindableSource); !ok {
+			continue
+		}
+		if offset, ok := t.ctx.Offsets.Acked(sinkName, s.name); ok {
+			offsets[s] = offset + 1
+		}
+	}
+	return offsets
+}
+
+// replay rewinds sources to offsets returned from replayOffsets so that tuples
+// lost by a failure of the sink are processed again.
+func (t *defaultTopology) replay(sinkName string, offsets map[*defaultSourceNode]int64) {
+	for s, offset := range offsets {
+		if err := s.RewindTo(offset); err != nil {
+			t.ctx.nodeErrLog(NTSource, s.name, err).Warn("Cannot replay the source")
+			continue
+		}
+		t.ctx.nodeLog(NTSource, s.name).WithField("sink", sinkName).
+			WithField("offset", offset).Info("The source has been rewound to replay tuples")
+	}
+}
+
 type defaultNode struct {
+	// recovery must be the first field for 64-bit alignment.
+	recovery nodeRecovery
+
 	topology   *defaultTopology
 	name       string
 	state      *topologyStateHolder
 	stateMutex sync.Mutex
 
 	meta interface{}
+
+	// std is passed to nodes implementing ContextSource, ContextBox, or
+	// ContextSink. It's canceled by cancelStd when the node is stopped.
+	std       context.Context
+	cancelStd context.CancelFunc
 }
 
-func newDefaultNode(t *defaultTopology, name string, meta interface{}) *defaultNode {
+func newDefaultNode(t *defaultTopology, nodeType NodeType, name string, meta interface{}) *defaultNode {
 	if meta == nil {
 		meta = map[string]interface{}{}
 	}
@@ -507,7 +647,11 @@ func newDefaultNode(t *defaultTopology, name string, meta interface{}) *defaultN
 		name:     name,
 		meta:     meta,
 	}
+	dn.std, dn.cancelStd = context.WithCancel(t.ctx.StdContext())
 	dn.state = newTopologyStateHolder(&dn.stateMutex)
+	dn.state.onChange = func(prev, cur TopologyState) {
+		t.ctx.nodeStateChanged(nodeType, name, prev, cur)
+	}
 	return dn
 }
 
@@ -848,6 +848,32 @@ func TestLinearDefaultTopology(t *testing.T) {
 			})
 		})
 
+		Convey("When getting inputs of nodes", func() {
+			Convey("Then each node should return its inputs", func() {
+				So(bn1.Inputs(), ShouldResemble, map[string]*BoxInputConfig{"source": &BoxInputConfig{}})
+				So(bn2.Inputs(), ShouldResemble, map[string]*BoxInputConfig{"box1": &BoxInputConfig{}})
+				So(sin.Inputs(), ShouldResemble, map[string]*SinkInputConfig{"box2": &SinkInputConfig{}})
+			})
+		})
+
+		Convey("When removing an input from the sink", func() {
+			So(sin.RemoveInput("BOX2"), ShouldBeNil)
+
+			Convey("Then the sink shouldn't have the input", func() {
+				So(sin.Inputs(), ShouldBeEmpty)
+			})
+
+			Convey("Then removing it again should fail", func() {
+				So(IsNotExist(sin.RemoveInput("box2")), ShouldBeTrue)
+			})
+		})
+
+		Convey("When removing an input which isn't connected to the sink", func() {
+			Convey("Then it should fail", func() {
+				So(IsNotExist(sin.RemoveInput("box1")), ShouldBeTrue)
+			})
+		})
+
 		Convey("When removing a nonexistent node", func() {
 			Convey("Then it shouldn't fail", func() {
 				So(IsNotExist(t.Remove("no_such_node")), ShouldBeTrue)
@@ -898,6 +924,24 @@ func TestLinearDefaultTopology(t *testing.T) {
 				So(si.len(), ShouldEqual, 2)
 			})
 
+			Convey("Then the box shouldn't be an input of the next box", func() {
+				So(bn2.Inputs(), ShouldBeEmpty)
+			})
+
+			Convey("And adding another box having the same name", func() {
+				b3 := BoxFunc(forwardBox)
+				bn3, err := t.AddBox("box1", b3, nil)
+				So(err, ShouldBeNil)
+				So(bn3.Input("source", nil), ShouldBeNil)
+
+				Convey("Then the next box should be able to reconnect to it", func() {
+					So(bn2.Input("box1", nil), ShouldBeNil)
+					so.EmitTuples(4)
+					si.Wait(6)
+					So(si.len(), ShouldEqual, 6)
+				})
+			})
+
 			Convey("And connecting another box in the topology", func() {
 				b3 := BoxFunc(forwardBox)
 				bn3, err := t.AddBox("box3", b3, nil)
@@ -83,6 +83,24 @@ func TestDroppedTupleCollectorSource(t *testing.T) {
 			})
 		})
 
+		Convey("When tuples are dropped from a Box with errors having a code", func() {
+			bn, err := t.AddBox("box", BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+				return NewError(errors.New("box write error"), "invalid_payload", ECPermanent)
+			}), nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then they should be reported with the code", func() {
+				si.Wait(8)
+				So(si.len(), ShouldEqual, 8)
+				si.forEachTuple(func(t *Tuple) {
+					locationChecker(t, bn)
+					So(t.Data["error_code"], ShouldEqual, "invalid_payload")
+				})
+			})
+		})
+
 		Convey("When tuples are dropped from a Sink", func() {
 			sin2, err := t.AddSink("fail_sink", &writeFailSink{}, nil)
 			So(err, ShouldBeNil)
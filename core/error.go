@@ -90,6 +90,10 @@ func (f *fatalError) Error() string {
 	return f.err.Error()
 }
 
+func (f *fatalError) Unwrap() error {
+	return f.err
+}
+
 func (f *fatalError) Fatal() bool {
 	return true
 }
@@ -136,6 +140,10 @@ func (t *temporaryError) Error() string {
 	return t.err.Error()
 }
 
+func (t *temporaryError) Unwrap() error {
+	return t.err
+}
+
 func (t *temporaryError) Temporary() bool {
 	return true
 }
@@ -150,8 +158,130 @@ func TemporaryError(err error) error {
 	return &temporaryError{err: err}
 }
 
-// TODO: add a hybrid error interface having all possible methods which can
-// customize behavior by setting flags.
+// ErrorClass is the class of an Error. It decides how components in core
+// package handle the error.
+type ErrorClass int
+
+const (
+	// ECPermanent means that the operation will never succeed. A tuple
+	// which caused the error is discarded.
+	ECPermanent ErrorClass = iota
+
+	// ECTemporary means that the operation might succeed if it's retried.
+	// IsTemporaryError returns true for errors having this class.
+	ECTemporary
+
+	// ECFatal means that the component cannot continue its work. IsFatalError
+	// returns true for errors having this class.
+	ECFatal
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ECPermanent:
+		return "permanent"
+	case ECTemporary:
+		return "temporary"
+	case ECFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// Error is an error having a code and a class. It's used by authors of boxes
+// and sinks to wrap errors returned from third-party libraries, which don't
+// have Temporary or Fatal methods, with hints for retries and a code which
+// policies of a topology can dispatch on. For example, a sink calling an API
+// can return
+//
+//	NewError(err, "rate_limited", ECTemporary)
+//
+// when the API responds with 429. The code is reported with the tuple which
+// caused the error as a dropped tuple, and the recovery policy of the node
+// can treat errors having the code as fatal ones (see
+// RecoveryPolicy.FatalCodes).
+type Error struct {
+	// Err is the wrapped error.
+	Err error
+
+	// Code is an application-defined name of the category of the error, such
+	// as "rate_limited" or "invalid_payload". It can be empty.
+	Code string
+
+	// Class is the class of the error.
+	Class ErrorClass
+}
+
+// NewError wraps err with the code and the class. It will panic if err is
+// nil.
+func NewError(err error, code string, class ErrorClass) error {
+	if err == nil {
+		panic(fmt.Errorf("the error cannot be nil"))
+	}
+	return &Error{
+		Err:   err,
+		Code:  code,
+		Class: class,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Temporary returns true when the class of the error is ECTemporary.
+func (e *Error) Temporary() bool {
+	return e.Class == ECTemporary
+}
+
+// Fatal returns true when the class of the error is ECFatal.
+func (e *Error) Fatal() bool {
+	return e.Class == ECFatal
+}
+
+// ErrorCode returns the code of the error.
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the code of the given error. If the error or one of
+// errors wrapped by it implements the following interface, ErrorCode returns
+// the first non-empty return value of ErrorCode method:
+//
+//	interface {
+//		ErrorCode() string
+//	}
+//
+// Wrapped errors are obtained by Unwrap method. Otherwise, the error doesn't
+// have a code and it returns an empty string.
+func ErrorCode(err error) string {
+	type coded interface {
+		ErrorCode() string
+	}
+	type wrapper interface {
+		Unwrap() error
+	}
+
+	for err != nil {
+		if e, ok := err.(coded); ok {
+			if c := e.ErrorCode(); c != "" {
+				return c
+			}
+		}
+		w, ok := err.(wrapper)
+		if !ok {
+			return ""
+		}
+		err = w.Unwrap()
+	}
+	return ""
+}
 
 // IsNotExist returns true when the error is related to "not found" or is
 // os.ErrNotExist. To be consistent with os.IsNotExist, the name of this
@@ -186,6 +316,10 @@ func (n *notExistError) Error() string {
 	return n.err.Error()
 }
 
+func (n *notExistError) Unwrap() error {
+	return n.err
+}
+
 func (n *notExistError) NotExist() bool {
 	return true
 }
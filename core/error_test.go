@@ -225,3 +225,60 @@ func TestNotExistError(t *testing.T) {
 		})
 	})
 }
+
+func TestError(t *testing.T) {
+	Convey("Given an error", t, func() {
+		err := errors.New("test failure")
+
+		Convey("When wrapping it with a code and a class", func() {
+			Convey("Then the class should decide whether it's temporary or fatal", func() {
+				e := NewError(err, "rate_limited", ECTemporary)
+				So(IsTemporaryError(e), ShouldBeTrue)
+				So(IsFatalError(e), ShouldBeFalse)
+
+				e = NewError(err, "session_expired", ECFatal)
+				So(IsTemporaryError(e), ShouldBeFalse)
+				So(IsFatalError(e), ShouldBeTrue)
+
+				e = NewError(err, "invalid_payload", ECPermanent)
+				So(IsTemporaryError(e), ShouldBeFalse)
+				So(IsFatalError(e), ShouldBeFalse)
+			})
+
+			Convey("Then the code should be obtained even if it's wrapped again", func() {
+				e := NewError(err, "rate_limited", ECTemporary)
+				So(ErrorCode(e), ShouldEqual, "rate_limited")
+				So(ErrorCode(FatalError(e)), ShouldEqual, "rate_limited")
+				So(ErrorCode(TemporaryError(NotExistError(e))), ShouldEqual, "rate_limited")
+				So(ErrorCode(NewError(e, "", ECFatal)), ShouldEqual, "rate_limited")
+			})
+
+			Convey("Then the original error message shouldn't be changed", func() {
+				So(NewError(err, "rate_limited", ECTemporary).Error(), ShouldEqual, "test failure")
+			})
+
+			Convey("Then the typed error should have the wrapped error", func() {
+				e, ok := NewError(err, "rate_limited", ECTemporary).(*Error)
+				So(ok, ShouldBeTrue)
+				So(e.Unwrap(), ShouldEqual, err)
+				So(e.Class.String(), ShouldEqual, "temporary")
+			})
+		})
+
+		Convey("When it doesn't have a code", func() {
+			Convey("Then its code should be empty", func() {
+				So(ErrorCode(err), ShouldBeEmpty)
+				So(ErrorCode(FatalError(err)), ShouldBeEmpty)
+				So(ErrorCode(nil), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the error is nil", func() {
+			Convey("Then NewError should panic", func() {
+				So(func() {
+					NewError(nil, "rate_limited", ECTemporary)
+				}, ShouldPanic)
+			})
+		})
+	})
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInjectedFault is the error returned or raised through panic by a node
+// on which a fault is injected.
+var ErrInjectedFault = errors.New("fault injected")
+
+// FaultInjection has parameters of faults injected into a node. It's used to
+// exercise recovery paths of the engine, boxes, and sinks. Each rate is a
+// probability in [0, 1] that a fault happens on a tuple.
+//
+// Faults are injected when a box or a sink receives a tuple and when a
+// source emits a tuple.
+type FaultInjection struct {
+	// ErrorRate is the probability that writing a tuple fails with
+	// ErrInjectedFault. The tuple is reported as a dropped tuple.
+	ErrorRate float64
+
+	// PanicRate is the probability that writing a tuple panics with
+	// ErrInjectedFault. A panic stops the node.
+	PanicRate float64
+
+	// Latency is the delay added before a tuple is written. It's waited on
+	// the Clock of the Context.
+	Latency time.Duration
+
+	// LatencyRate is the probability that Latency is added.
+	LatencyRate float64
+
+	// DuplicateRate is the probability that a tuple is written twice.
+	DuplicateRate float64
+}
+
+// Validate validates values of FaultInjection.
+func (f *FaultInjection) Validate() error {
+	rates := []struct {
+		name string
+		v    float64
+	}{
+		{"error rate", f.ErrorRate},
+		{"panic rate", f.PanicRate},
+		{"latency rate", f.LatencyRate},
+		{"duplicate rate", f.DuplicateRate},
+	}
+	for _, r := range rates {
+		if r.v < 0 || r.v > 1 {
+			return fmt.Errorf("%v of fault injection must be in [0, 1]: %v", r.name, r.v)
+		}
+	}
+	if f.Latency < 0 {
+		return fmt.Errorf("latency of fault injection must not be negative: %v", f.Latency)
+	}
+	return nil
+}
+
+// FaultInjector manages faults injected into nodes of a topology. Faults can
+// be added, changed, or removed while the topology is running. Node names
+// are case-insensitive.
+//
+// Methods of FaultInjector can be called on a nil pointer, in which case no
+// fault is injected.
+type FaultInjector struct {
+	m      sync.RWMutex
+	faults map[string]*nodeFault
+
+	// numFaults is the number of nodes having faults. It's used to skip
+	// looking up faults when none are configured.
+	numFaults int32
+
+	randMutex sync.Mutex
+	rand      *rand.Rand
+}
+
+type nodeFault struct {
+	config FaultInjection
+
+	numErrors     int64
+	numPanics     int64
+	numDelayed    int64
+	numDuplicated int64
+}
+
+// NewFaultInjector returns a new FaultInjector having no fault.
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{
+		faults: map[string]*nodeFault{},
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Set injects faults into the node. It replaces faults previously set to the
+// node and resets their counters.
+func (fi *FaultInjector) Set(nodeName string, f *FaultInjection) error {
+	if fi == nil {
+		return errors.New("fault injection isn't supported")
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	fi.m.Lock()
+	defer fi.m.Unlock()
+	fi.faults[strings.ToLower(nodeName)] = &nodeFault{config: *f}
+	atomic.StoreInt32(&fi.numFaults, int32(len(fi.faults)))
+	return nil
+}
+
+// Get returns faults injected into the node. It returns false when the node
+// doesn't have faults.
+func (fi *FaultInjector) Get(nodeName string) (*FaultInjection, bool) {
+	nf := fi.lookup(nodeName)
+	if nf == nil {
+		return nil, false
+	}
+	f := nf.config
+	return &f, true
+}
+
+// Remove removes faults injected into the node. It returns false when the
+// node doesn't have faults.
+func (fi *FaultInjector) Remove(nodeName string) bool {
+	if fi == nil {
+		return false
+	}
+	fi.m.Lock()
+	defer fi.m.Unlock()
+	name := strings.ToLower(nodeName)
+	if _, ok := fi.faults[name]; !ok {
+		return false
+	}
+	delete(fi.faults, name)
+	atomic.StoreInt32(&fi.numFaults, int32(len(fi.faults)))
+	return true
+}
+
+// Status returns parameters and counters of faults of all nodes. Keys of the
+// map are node names.
+func (fi *FaultInjector) Status() data.Map {
+	m := data.Map{}
+	if fi == nil {
+		return m
+	}
+	fi.m.RLock()
+	defer fi.m.RUnlock()
+	for name, nf := range fi.faults {
+		m[name] = nf.status()
+	}
+	return m
+}
+
+func (fi *FaultInjector) lookup(nodeName string) *nodeFault {
+	if fi == nil || atomic.LoadInt32(&fi.numFaults) == 0 {
+		return nil
+	}
+	fi.m.RLock()
+	defer fi.m.RUnlock()
+	return fi.faults[strings.ToLower(nodeName)]
+}
+
+// happen returns true with the probability p.
+func (fi *FaultInjector) happen(p float64) bool {
+	switch {
+	case p <= 0:
+		return false
+	case p >= 1:
+		return true
+	}
+	fi.randMutex.Lock()
+	defer fi.randMutex.Unlock()
+	return fi.rand.Float64() < p
+}
+
+// write writes a tuple with w after injecting faults configured for the node.
+func (fi *FaultInjector) write(ctx *Context, nodeName string, t *Tuple, w Writer) error {
+	nf := fi.lookup(nodeName)
+	if nf == nil {
+		return w.Write(ctx, t)
+	}
+	c := &nf.config
+
+	if c.Latency > 0 && fi.happen(c.LatencyRate) {
+		atomic.AddInt64(&nf.numDelayed, 1)
+		<-ctx.Clock().NewTimer(c.Latency).C()
+	}
+	if fi.happen(c.PanicRate) {
+		atomic.AddInt64(&nf.numPanics, 1)
+		panic(ErrInjectedFault)
+	}
+	if fi.happen(c.ErrorRate) {
+		atomic.AddInt64(&nf.numErrors, 1)
+		return ErrInjectedFault
+	}
+	if fi.happen(c.DuplicateRate) {
+		atomic.AddInt64(&nf.numDuplicated, 1)
+		if err := w.Write(ctx, t.Copy()); err != nil {
+			return err
+		}
+	}
+	return w.Write(ctx, t)
+}
+
+func (nf *nodeFault) status() data.Map {
+	return data.Map{
+		"error_rate":     data.Float(nf.config.ErrorRate),
+		"panic_rate":     data.Float(nf.config.PanicRate),
+		"latency":        data.String(nf.config.Latency.String()),
+		"latency_rate":   data.Float(nf.config.LatencyRate),
+		"duplicate_rate": data.Float(nf.config.DuplicateRate),
+		"num_errors":     data.Int(atomic.LoadInt64(&nf.numErrors)),
+		"num_panics":     data.Int(atomic.LoadInt64(&nf.numPanics)),
+		"num_delayed":    data.Int(atomic.LoadInt64(&nf.numDelayed)),
+		"num_duplicated": data.Int(atomic.LoadInt64(&nf.numDuplicated)),
+	}
+}
+
+// faultWriter is a Writer injecting faults configured in the FaultInjector
+// of the Context before writing tuples.
+type faultWriter struct {
+	w        Writer
+	nodeName string
+}
+
+func newFaultWriter(w Writer, nodeName string) *faultWriter {
+	return &faultWriter{
+		w:        w,
+		nodeName: nodeName,
+	}
+}
+
+func (fw *faultWriter) Write(ctx *Context, t *Tuple) error {
+	return ctx.Faults.write(ctx, fw.nodeName, t, fw.w)
+}
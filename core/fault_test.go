@@ -0,0 +1,186 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestFaultInjection(t *testing.T) {
+	Convey("Given a FaultInjection", t, func() {
+		f := &FaultInjection{}
+
+		Convey("When all parameters are zero", func() {
+			Convey("Then it should be valid", func() {
+				So(f.Validate(), ShouldBeNil)
+			})
+		})
+
+		Convey("When a rate is out of range", func() {
+			Convey("Then it should be invalid", func() {
+				f.ErrorRate = 1.5
+				So(f.Validate(), ShouldNotBeNil)
+				f.ErrorRate = 0
+				f.DuplicateRate = -0.1
+				So(f.Validate(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the latency is negative", func() {
+			f.Latency = -time.Second
+
+			Convey("Then it should be invalid", func() {
+				So(f.Validate(), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestFaultInjector(t *testing.T) {
+	Convey("Given a FaultInjector", t, func() {
+		fi := NewFaultInjector()
+
+		Convey("When setting faults to a node", func() {
+			So(fi.Set("Box", &FaultInjection{ErrorRate: 0.5}), ShouldBeNil)
+
+			Convey("Then they can be obtained case-insensitively", func() {
+				f, ok := fi.Get("box")
+				So(ok, ShouldBeTrue)
+				So(f.ErrorRate, ShouldEqual, 0.5)
+			})
+
+			Convey("Then the status should have the node", func() {
+				st := fi.Status()
+				So(st, ShouldContainKey, "box")
+			})
+
+			Convey("Then they can be removed", func() {
+				So(fi.Remove("BOX"), ShouldBeTrue)
+				_, ok := fi.Get("box")
+				So(ok, ShouldBeFalse)
+				So(fi.Remove("box"), ShouldBeFalse)
+			})
+		})
+
+		Convey("When setting invalid faults", func() {
+			err := fi.Set("box", &FaultInjection{PanicRate: 2})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				_, ok := fi.Get("box")
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a nil FaultInjector", t, func() {
+		var fi *FaultInjector
+
+		Convey("Then it shouldn't have any fault", func() {
+			_, ok := fi.Get("box")
+			So(ok, ShouldBeFalse)
+			So(fi.Remove("box"), ShouldBeFalse)
+			So(fi.Status(), ShouldBeEmpty)
+			So(fi.Set("box", &FaultInjection{}), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestFaultInjectionInTopology(t *testing.T) {
+	Convey("Given a topology with a box", t, func() {
+		ctx := NewContext(&ContextConfig{
+			Clock: NewFakeClock(time.Now()),
+		})
+		tp, err := NewDefaultTopology(ctx, "fault")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		son, err := tp.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		bn, err := tp.AddBox("box", BoxFunc(forwardBox), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		si := NewTupleCollectorSink()
+		sin, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("box", nil), ShouldBeNil)
+
+		Convey("When injecting errors into the box", func() {
+			So(ctx.Faults.Set("box", &FaultInjection{ErrorRate: 1}), ShouldBeNil)
+			dtso := NewDroppedTupleCollectorSource().(*droppedTupleCollectorSource)
+			_, err = tp.AddSource("dropped_tuples", dtso, nil)
+			So(err, ShouldBeNil)
+			dtso.state.Wait(TSRunning)
+			dsi := NewTupleCollectorSink()
+			dsin, err := tp.AddSink("dropped", dsi, nil)
+			So(err, ShouldBeNil)
+			So(dsin.Input("dropped_tuples", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then all tuples should be dropped", func() {
+				dsi.Wait(len(freshTuples()))
+				So(dsi.len(), ShouldEqual, len(freshTuples()))
+				So(si.len(), ShouldEqual, 0)
+
+				v, err := ctx.Faults.Status().Get(data.MustCompilePath("box.num_errors"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, len(freshTuples()))
+			})
+		})
+
+		Convey("When injecting duplicates into the sink", func() {
+			So(ctx.Faults.Set("sink", &FaultInjection{DuplicateRate: 1}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then each tuple should be written twice", func() {
+				n := len(freshTuples())
+				si.Wait(2 * n)
+				So(si.len(), ShouldEqual, 2*n)
+				So(si.get(0).Data, ShouldResemble, si.get(1).Data)
+			})
+		})
+
+		Convey("When injecting panics into the box", func() {
+			So(ctx.Faults.Set("box", &FaultInjection{PanicRate: 1}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should stop with an error", func() {
+				So(bn.State().Wait(TSStopped), ShouldEqual, TSStopped)
+				So(si.len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When injecting latency into a source", func() {
+			clock := ctx.Clock().(*FakeClock)
+			// The source is added after the fault is set because it starts
+			// writing tuples even if it's paused on startup.
+			So(ctx.Faults.Set("delayed", &FaultInjection{
+				Latency:     time.Second,
+				LatencyRate: 1,
+			}), ShouldBeNil)
+			_, err := tp.AddSource("delayed", NewTupleEmitterSource(freshTuples()), nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("delayed", nil), ShouldBeNil)
+
+			Convey("Then tuples should be emitted as the clock advances", func() {
+				clock.BlockUntil(1)
+				So(si.len(), ShouldEqual, 0)
+				clock.Advance(time.Second)
+				si.Wait(1)
+				So(si.len(), ShouldBeGreaterThanOrEqualTo, 1)
+
+				for i := 1; i < len(freshTuples()); i++ {
+					clock.BlockUntil(1)
+					clock.Advance(time.Second)
+				}
+				si.Wait(len(freshTuples()))
+				So(si.len(), ShouldEqual, len(freshTuples()))
+			})
+		})
+	})
+}
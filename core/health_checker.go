@@ -0,0 +1,11 @@
+package core
+
+// HealthChecker is an optional interface of a Source or a Sink which can
+// check whether it's connected to the external system it reads from or
+// writes to, such as a message broker or a database. It's used by readiness
+// checks of the server.
+type HealthChecker interface {
+	// CheckHealth returns an error when the component cannot reach the
+	// external system. It shouldn't block for a long time.
+	CheckHealth(ctx *Context) error
+}
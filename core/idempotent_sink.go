@@ -0,0 +1,312 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IdempotencyKeys is a SharedState having idempotency keys of tuples written
+// by sinks returned from NewIdempotentSink. A key is kept for TTL after the
+// tuple having it is written. The state can be saved and loaded so that keys
+// survive restarts of the server.
+type IdempotencyKeys struct {
+	m          sync.Mutex
+	ttl        time.Duration
+	keys       map[string]*idempotencyKey
+	terminated bool
+
+	// expiry has keys in the order they were written. Because all keys have
+	// the same TTL, the key at the head expires first. It can have stale
+	// entries of keys written again or removed, which are skipped.
+	expiry []expiringIdempotencyKey
+}
+
+type idempotencyKey struct {
+	expiresAt time.Time
+
+	// pending is true while the tuple having the key is being written.
+	pending bool
+}
+
+type expiringIdempotencyKey struct {
+	key       string
+	expiresAt time.Time
+}
+
+var (
+	_ LoadableSharedState = &IdempotencyKeys{}
+	_ Statuser            = &IdempotencyKeys{}
+)
+
+// NewIdempotencyKeys creates an empty IdempotencyKeys keeping keys for ttl.
+func NewIdempotencyKeys(ttl time.Duration) (*IdempotencyKeys, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("the TTL of idempotency keys must be positive: %v", ttl)
+	}
+	return &IdempotencyKeys{
+		ttl:  ttl,
+		keys: map[string]*idempotencyKey{},
+	}, nil
+}
+
+// TTL returns the duration for which keys are kept.
+func (k *IdempotencyKeys) TTL() time.Duration {
+	k.m.Lock()
+	defer k.m.Unlock()
+	return k.ttl
+}
+
+// expire removes keys expired at now. The caller must hold the lock.
+func (k *IdempotencyKeys) expire(now time.Time) {
+	i := 0
+	for ; i < len(k.expiry); i++ {
+		e := k.expiry[i]
+		if e.expiresAt.After(now) {
+			break
+		}
+		if v, ok := k.keys[e.key]; ok && !v.pending && v.expiresAt.Equal(e.expiresAt) {
+			delete(k.keys, e.key)
+		}
+	}
+	k.expiry = k.expiry[i:]
+}
+
+// reserve marks the key as being written. It returns false when the key has
+// already been written within the TTL. It returns a temporary error when
+// another sink is writing a tuple having the same key.
+func (k *IdempotencyKeys) reserve(key string, now time.Time) (bool, error) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	if k.terminated {
+		return false, errors.New("the state has already been terminated")
+	}
+	k.expire(now)
+	if v, ok := k.keys[key]; ok {
+		if v.pending {
+			return false, TemporaryError(fmt.Errorf("a tuple having the idempotency key %v is being written", key))
+		}
+		return false, nil
+	}
+	k.keys[key] = &idempotencyKey{pending: true}
+	return true, nil
+}
+
+// commit records that the tuple having the key reserved by reserve has been
+// written at now.
+func (k *IdempotencyKeys) commit(key string, now time.Time) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	e := expiringIdempotencyKey{
+		key:       key,
+		expiresAt: now.Add(k.ttl),
+	}
+	k.keys[key] = &idempotencyKey{expiresAt: e.expiresAt}
+	k.expiry = append(k.expiry, e)
+}
+
+// release removes the key reserved by reserve so that the tuple having it can
+// be written again.
+func (k *IdempotencyKeys) release(key string) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	if v, ok := k.keys[key]; ok && v.pending {
+		delete(k.keys, key)
+	}
+}
+
+// Len returns the number of keys including expired ones which haven't been
+// removed yet.
+func (k *IdempotencyKeys) Len() int {
+	k.m.Lock()
+	defer k.m.Unlock()
+	return len(k.keys)
+}
+
+func (k *IdempotencyKeys) Status() data.Map {
+	k.m.Lock()
+	defer k.m.Unlock()
+	return data.Map{
+		"ttl":      data.String(k.ttl.String()),
+		"num_keys": data.Int(len(k.keys)),
+	}
+}
+
+func (k *IdempotencyKeys) Terminate(ctx *Context) error {
+	k.m.Lock()
+	defer k.m.Unlock()
+	k.terminated = true
+	return nil
+}
+
+type savedIdempotencyKeys struct {
+	TTL time.Duration `json:"ttl"`
+
+	// Keys has the expiration time of each key in nanoseconds since the Unix
+	// epoch.
+	Keys map[string]int64 `json:"keys"`
+}
+
+// Save writes keys which have been written. Keys being written aren't saved.
+func (k *IdempotencyKeys) Save(ctx *Context, w io.Writer, params data.Map) error {
+	k.m.Lock()
+	saved := &savedIdempotencyKeys{
+		TTL:  k.ttl,
+		Keys: make(map[string]int64, len(k.keys)),
+	}
+	for key, v := range k.keys {
+		if !v.pending {
+			saved.Keys[key] = v.expiresAt.UnixNano()
+		}
+	}
+	k.m.Unlock()
+	return json.NewEncoder(w).Encode(saved)
+}
+
+// Load overwrites keys with saved data. The TTL is also loaded unless params
+// has "ttl". Keys being written when Load is called are kept.
+func (k *IdempotencyKeys) Load(ctx *Context, r io.Reader, params data.Map) error {
+	saved := &savedIdempotencyKeys{}
+	if err := json.NewDecoder(r).Decode(saved); err != nil {
+		return fmt.Errorf("cannot decode the saved idempotency keys: %v", err)
+	}
+	ttl := saved.TTL
+	if v, ok := params["ttl"]; ok {
+		d, err := data.ToDuration(v)
+		if err != nil {
+			return fmt.Errorf("'ttl' parameter should have a duration: %v", err)
+		}
+		ttl = d
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("the TTL of idempotency keys must be positive: %v", ttl)
+	}
+
+	keys := make(map[string]*idempotencyKey, len(saved.Keys))
+	expiry := make([]expiringIdempotencyKey, 0, len(saved.Keys))
+	for key, ns := range saved.Keys {
+		e := expiringIdempotencyKey{
+			key:       key,
+			expiresAt: time.Unix(0, ns),
+		}
+		keys[key] = &idempotencyKey{expiresAt: e.expiresAt}
+		expiry = append(expiry, e)
+	}
+	sort.Sort(expiringIdempotencyKeys(expiry))
+
+	k.m.Lock()
+	defer k.m.Unlock()
+	for key, v := range k.keys {
+		if v.pending {
+			keys[key] = v
+		}
+	}
+	k.ttl = ttl
+	k.keys = keys
+	k.expiry = expiry
+	return nil
+}
+
+type expiringIdempotencyKeys []expiringIdempotencyKey
+
+func (e expiringIdempotencyKeys) Len() int           { return len(e) }
+func (e expiringIdempotencyKeys) Less(i, j int) bool { return e[i].expiresAt.Before(e[j].expiresAt) }
+func (e expiringIdempotencyKeys) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+type idempotentSink struct {
+	// numSuppressed must be the first field for 64-bit alignment.
+	numSuppressed int64
+
+	sink      Sink
+	key       func(t *Tuple) (data.Value, error)
+	stateName string
+}
+
+var (
+	_ Statuser       = &idempotentSink{}
+	_ updaterWrapper = &idempotentSink{}
+)
+
+// NewIdempotentSink returns a sink writing tuples to the given sink at most
+// once for each idempotency key. key computes the key of a tuple and the
+// keys of written tuples are recorded in the IdempotencyKeys state named
+// stateName. A tuple is silently dropped when a tuple having the same key
+// has been written within the TTL of the state. It makes at-least-once
+// pipelines, which might deliver the same tuple more than once after
+// failures or rewinds, safe for sinks calling non-idempotent APIs.
+//
+// A key is recorded only after the sink successfully writes the tuple so
+// that the tuple can be written again after a failure. Keys are compared by
+// their JSON representations, and tuples having a Null key are always
+// written. When the state is shared by multiple sinks, a tuple is written by
+// only one of them.
+//
+// The state is looked up on each write so that it can be replaced or loaded
+// while the sink is running.
+func NewIdempotentSink(s Sink, key func(t *Tuple) (data.Value, error), stateName string) Sink {
+	return &idempotentSink{
+		sink:      s,
+		key:       key,
+		stateName: stateName,
+	}
+}
+
+func (s *idempotentSink) Write(ctx *Context, t *Tuple) error {
+	k, err := s.key(t)
+	if err != nil {
+		return fmt.Errorf("cannot compute the idempotency key: %v", err)
+	}
+	if k.Type() == data.TypeNull {
+		return s.sink.Write(ctx, t)
+	}
+
+	st, err := ctx.SharedStates.Get(s.stateName)
+	if err != nil {
+		return err
+	}
+	keys, ok := st.(*IdempotencyKeys)
+	if !ok {
+		return fmt.Errorf("the state '%v' doesn't have idempotency keys", s.stateName)
+	}
+
+	key := k.String()
+	if ok, err := keys.reserve(key, ctx.Clock().Now()); err != nil {
+		return err
+	} else if !ok {
+		atomic.AddInt64(&s.numSuppressed, 1)
+		return nil
+	}
+	if err := s.sink.Write(ctx, t); err != nil {
+		keys.release(key)
+		return err
+	}
+	keys.commit(key, ctx.Clock().Now())
+	return nil
+}
+
+func (s *idempotentSink) Close(ctx *Context) error {
+	return s.sink.Close(ctx)
+}
+
+func (s *idempotentSink) Status() data.Map {
+	m := data.Map{
+		"idempotency": data.Map{
+			"state":          data.String(s.stateName),
+			"num_suppressed": data.Int(atomic.LoadInt64(&s.numSuppressed)),
+		},
+	}
+	if st, ok := s.sink.(Statuser); ok {
+		m["internal_sink"] = st.Status()
+	}
+	return m
+}
+
+func (s *idempotentSink) unwrap() interface{} {
+	return s.sink
+}
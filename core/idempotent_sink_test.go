@@ -0,0 +1,179 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+// failingOnceSink fails to write the first tuple and writes others to a
+// TupleCollectorSink.
+type failingOnceSink struct {
+	*TupleCollectorSink
+	failed bool
+	params data.Map
+}
+
+func (s *failingOnceSink) Write(ctx *Context, t *Tuple) error {
+	if !s.failed {
+		s.failed = true
+		return errors.New("service unavailable")
+	}
+	return s.TupleCollectorSink.Write(ctx, t)
+}
+
+func (s *failingOnceSink) Update(ctx *Context, params data.Map) error {
+	s.params = params
+	return nil
+}
+
+func TestIdempotentSink(t *testing.T) {
+	Convey("Given an idempotent sink", t, func() {
+		clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		ctx := NewContext(&ContextConfig{Clock: clock})
+		keys, err := NewIdempotencyKeys(time.Hour)
+		So(err, ShouldBeNil)
+		So(ctx.SharedStates.Add("sent_keys", "idempotency_keys", keys), ShouldBeNil)
+
+		inner := &failingOnceSink{TupleCollectorSink: NewTupleCollectorSink()}
+		s := NewIdempotentSink(inner, func(t *Tuple) (data.Value, error) {
+			return t.Data.Get(data.MustCompilePath("id"))
+		}, "sent_keys")
+		write := func(m data.Map) error {
+			return s.Write(ctx, NewTuple(m))
+		}
+		ids := func() []data.Value {
+			var vs []data.Value
+			for _, t := range inner.Tuples {
+				vs = append(vs, t.Data["id"])
+			}
+			return vs
+		}
+
+		Convey("When writing tuples having duplicated keys", func() {
+			So(write(data.Map{"id": data.Int(1)}), ShouldNotBeNil)
+			for _, id := range []data.Value{data.Int(1), data.Int(1), data.String("1"), data.Int(2), data.Int(1)} {
+				So(write(data.Map{"id": id}), ShouldBeNil)
+			}
+
+			Convey("Then each key should be written once after the failure", func() {
+				So(ids(), ShouldResemble, []data.Value{data.Int(1), data.String("1"), data.Int(2)})
+				So(keys.Len(), ShouldEqual, 3)
+			})
+
+			Convey("Then the status should have the number of suppressed tuples", func() {
+				st := s.(Statuser).Status()
+				So(st["idempotency"], ShouldResemble, data.Map{
+					"state":          data.String("sent_keys"),
+					"num_suppressed": data.Int(2),
+				})
+				So(keys.Status(), ShouldResemble, data.Map{
+					"ttl":      data.String("1h0m0s"),
+					"num_keys": data.Int(3),
+				})
+			})
+
+			Convey("Then keys should be written again after the TTL", func() {
+				clock.Advance(59 * time.Minute)
+				So(write(data.Map{"id": data.Int(1)}), ShouldBeNil)
+				So(len(inner.Tuples), ShouldEqual, 3)
+
+				clock.Advance(time.Minute)
+				So(write(data.Map{"id": data.Int(1)}), ShouldBeNil)
+				So(len(inner.Tuples), ShouldEqual, 4)
+				So(keys.Len(), ShouldEqual, 1)
+			})
+
+			Convey("Then keys should be restored from saved data", func() {
+				buf := &bytes.Buffer{}
+				So(keys.Save(ctx, buf, data.Map{}), ShouldBeNil)
+				clock.Advance(30 * time.Minute)
+				So(write(data.Map{"id": data.Int(3)}), ShouldBeNil)
+
+				loaded, err := NewIdempotencyKeys(time.Minute)
+				So(err, ShouldBeNil)
+				So(loaded.Load(ctx, bytes.NewReader(buf.Bytes()), data.Map{}), ShouldBeNil)
+				So(loaded.TTL(), ShouldEqual, time.Hour)
+				_, err = ctx.SharedStates.Replace("sent_keys", "idempotency_keys", loaded)
+				So(err, ShouldBeNil)
+
+				So(write(data.Map{"id": data.Int(2)}), ShouldBeNil)
+				So(write(data.Map{"id": data.Int(3)}), ShouldBeNil)
+				So(ids(), ShouldResemble, []data.Value{data.Int(1), data.String("1"), data.Int(2), data.Int(3), data.Int(3)})
+
+				clock.Advance(30 * time.Minute)
+				So(write(data.Map{"id": data.Int(2)}), ShouldBeNil)
+				So(len(inner.Tuples), ShouldEqual, 6)
+			})
+		})
+
+		Convey("When writing tuples having null keys", func() {
+			inner.failed = true
+			So(write(data.Map{}), ShouldNotBeNil)
+			So(write(data.Map{"id": data.Null{}}), ShouldBeNil)
+			So(write(data.Map{"id": data.Null{}}), ShouldBeNil)
+
+			Convey("Then they should always be written", func() {
+				So(len(inner.Tuples), ShouldEqual, 2)
+				So(keys.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the state doesn't exist", func() {
+			inner.failed = true
+			_, err := ctx.SharedStates.Remove("sent_keys")
+			So(err, ShouldBeNil)
+
+			Convey("Then writes should fail", func() {
+				So(write(data.Map{"id": data.Int(1)}), ShouldNotBeNil)
+				So(inner.Tuples, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When another sink is writing a tuple having the same key", func() {
+			ok, err := keys.reserve(data.Int(1).String(), clock.Now())
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			err = write(data.Map{"id": data.Int(1)})
+
+			Convey("Then the write should fail with a temporary error", func() {
+				So(IsTemporaryError(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When updating the sink", func() {
+			So(applyUpdate(ctx, "sink", s, data.Map{"a": data.Int(1)}), ShouldBeNil)
+
+			Convey("Then the wrapped sink should be updated", func() {
+				So(inner.params, ShouldResemble, data.Map{"a": data.Int(1)})
+			})
+		})
+	})
+
+	Convey("Given saved idempotency keys", t, func() {
+		ctx := NewContext(nil)
+		keys, err := NewIdempotencyKeys(time.Hour)
+		So(err, ShouldBeNil)
+		buf := &bytes.Buffer{}
+		So(keys.Save(ctx, buf, data.Map{}), ShouldBeNil)
+
+		Convey("When loading them with invalid parameters", func() {
+			Convey("Then it should fail", func() {
+				So(keys.Load(ctx, bytes.NewReader(buf.Bytes()), data.Map{"ttl": data.String("-1s")}), ShouldNotBeNil)
+				So(keys.Load(ctx, bytes.NewReader(buf.Bytes()), data.Map{"ttl": data.String("soon")}), ShouldNotBeNil)
+				So(keys.Load(ctx, bytes.NewReader([]byte("{")), data.Map{}), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When loading them with a TTL", func() {
+			So(keys.Load(ctx, bytes.NewReader(buf.Bytes()), data.Map{"ttl": data.String("5m")}), ShouldBeNil)
+
+			Convey("Then the TTL should be overwritten", func() {
+				So(keys.TTL(), ShouldEqual, 5*time.Minute)
+			})
+		})
+	})
+}
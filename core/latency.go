@@ -0,0 +1,308 @@
+package core
+
+import (
+	"errors"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LatencyTracker tracks latencies of tuples observed by sources and sinks in
+// a topology. The latency of a tuple is the duration from its Timestamp to
+// the time when it's observed:
+//
+//   - for a source, it's observed when the source emits the tuple and tells
+//     how far the source is behind the stream it reads, e.g. the event time
+//     lag of a consumer of a message broker
+//   - for a sink, it's observed after the sink writes the tuple and it's the
+//     end-to-end latency of the tuple through the topology
+//
+// Tuples having zero timestamps aren't observed. Latencies are also reported
+// to Metrics of the Context when it implements LatencyMetrics.
+//
+// A source implementing LagReporter also reports its offset lag, e.g. the
+// number of messages which haven't been read yet. It's sampled when latencies
+// are read and at most once per LagSampleInterval while the source emits
+// tuples so that it's reported to Metrics as the gauge "offset_lag".
+//
+// Methods of LatencyTracker can be called on a nil pointer, in which case
+// latencies aren't tracked. Node names are case-insensitive.
+type LatencyTracker struct {
+	m     sync.RWMutex
+	nodes map[string]*nodeLatency
+}
+
+// LagSampleInterval is the minimum interval between two samples of the
+// offset lag of a source reported to Metrics.
+const LagSampleInterval = time.Second
+
+// latencyAverageWeight is the weight of the latest latency in the moving
+// average.
+const latencyAverageWeight = 0.1
+
+// Latency has statistics of latencies observed by a node.
+type Latency struct {
+	NodeType NodeType
+	NodeName string
+
+	// Count is the number of tuples observed.
+	Count int64
+
+	// Last is the latency of the last tuple and Max is the largest latency
+	// observed so far.
+	Last time.Duration
+	Max  time.Duration
+
+	// Average is the exponentially weighted moving average of latencies.
+	Average time.Duration
+
+	// OffsetLag is the offset lag reported by the source. It's only valid
+	// when HasOffsetLag is true. OffsetLagError is the error returned from
+	// LagReporter.Lag when it failed.
+	OffsetLag      int64
+	HasOffsetLag   bool
+	OffsetLagError error
+}
+
+// Map returns the statistics as a data.Map having "count", "latency",
+// "max_latency", and "average_latency", and "offset_lag" or
+// "offset_lag_error" when the node reports its offset lag. Latencies are in
+// seconds.
+func (l *Latency) Map() data.Map {
+	m := data.Map{
+		"count":           data.Int(l.Count),
+		"latency":         data.Float(l.Last.Seconds()),
+		"max_latency":     data.Float(l.Max.Seconds()),
+		"average_latency": data.Float(l.Average.Seconds()),
+	}
+	if l.HasOffsetLag {
+		m["offset_lag"] = data.Int(l.OffsetLag)
+	} else if l.OffsetLagError != nil {
+		m["offset_lag_error"] = data.String(l.OffsetLagError.Error())
+	}
+	return m
+}
+
+type nodeLatency struct {
+	nodeType NodeType
+	name     string
+
+	// lag returns the offset lag of a source. It's nil when the source
+	// doesn't implement LagReporter.
+	lag func() (int64, error)
+
+	m          sync.Mutex
+	count      int64
+	last       time.Duration
+	max        time.Duration
+	avg        float64
+	lastSample time.Time
+}
+
+func (n *nodeLatency) observe(d time.Duration) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.count++
+	n.last = d
+	if d > n.max {
+		n.max = d
+	}
+	if n.count == 1 {
+		n.avg = float64(d)
+	} else {
+		n.avg += latencyAverageWeight * (float64(d) - n.avg)
+	}
+}
+
+// shouldSampleLag returns true when the offset lag should be sampled at now.
+func (n *nodeLatency) shouldSampleLag(now time.Time) bool {
+	if n.lag == nil {
+		return false
+	}
+	n.m.Lock()
+	defer n.m.Unlock()
+	if !n.lastSample.IsZero() && now.Sub(n.lastSample) < LagSampleInterval {
+		return false
+	}
+	n.lastSample = now
+	return true
+}
+
+func (n *nodeLatency) latency() *Latency {
+	n.m.Lock()
+	l := &Latency{
+		NodeType: n.nodeType,
+		NodeName: n.name,
+		Count:    n.count,
+		Last:     n.last,
+		Max:      n.max,
+		Average:  time.Duration(n.avg),
+	}
+	n.m.Unlock()
+
+	if n.lag != nil {
+		if lag, err := n.lag(); err != nil {
+			l.OffsetLagError = err
+		} else {
+			l.OffsetLag = lag
+			l.HasOffsetLag = true
+		}
+	}
+	return l
+}
+
+// NewLatencyTracker returns a new LatencyTracker having no node.
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{
+		nodes: map[string]*nodeLatency{},
+	}
+}
+
+// addNode starts tracking latencies of the node. v is the source or the sink
+// of the node.
+func (l *LatencyTracker) addNode(ctx *Context, nodeType NodeType, name string, v interface{}) *nodeLatency {
+	if l == nil {
+		return nil
+	}
+	n := &nodeLatency{
+		nodeType: nodeType,
+		name:     name,
+	}
+	if r, ok := findLagReporter(v); ok {
+		n.lag = func() (lag int64, err error) {
+			defer func() {
+				if e := recover(); e != nil {
+					err = errors.New("the source panicked while reporting its lag")
+				}
+			}()
+			return r.Lag(ctx)
+		}
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.nodes[strings.ToLower(name)] = n
+	return n
+}
+
+// findLagReporter returns the LagReporter of v. It looks into wrapped
+// sources when v itself doesn't implement LagReporter.
+func findLagReporter(v interface{}) (LagReporter, bool) {
+	for {
+		if r, ok := v.(LagReporter); ok {
+			return r, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
+
+// Latency returns latencies observed by the node. It returns false when the
+// node isn't tracked.
+func (l *LatencyTracker) Latency(nodeName string) (*Latency, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.m.RLock()
+	n, ok := l.nodes[strings.ToLower(nodeName)]
+	l.m.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return n.latency(), true
+}
+
+// Latencies returns latencies observed by all nodes sorted by their names.
+func (l *LatencyTracker) Latencies() []*Latency {
+	if l == nil {
+		return nil
+	}
+	l.m.RLock()
+	ns := make([]*nodeLatency, 0, len(l.nodes))
+	for _, n := range l.nodes {
+		ns = append(ns, n)
+	}
+	l.m.RUnlock()
+
+	sort.Slice(ns, func(i, j int) bool {
+		return ns[i].name < ns[j].name
+	})
+	ls := make([]*Latency, len(ns))
+	for i, n := range ns {
+		ls[i] = n.latency()
+	}
+	return ls
+}
+
+// RemoveNode stops tracking latencies of the node. It should be called when
+// the node is removed from the topology.
+func (l *LatencyTracker) RemoveNode(name string) {
+	if l == nil {
+		return
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	delete(l.nodes, strings.ToLower(name))
+}
+
+// LatencyMetrics is an optional interface of Metrics receiving latencies of
+// tuples observed by sources and sinks. See LatencyTracker for details.
+type LatencyMetrics interface {
+	Metrics
+
+	// LatencyObserved is called every time a source emits a tuple or a sink
+	// writes a tuple having a timestamp.
+	LatencyObserved(topology string, nodeType NodeType, nodeName string, d time.Duration)
+}
+
+// latencyWriter observes latencies of tuples successfully written through
+// it.
+type latencyWriter struct {
+	w WriteCloser
+	n *nodeLatency
+}
+
+// newLatencyWriter returns w as is when latencies of the node aren't
+// tracked.
+func newLatencyWriter(w WriteCloser, n *nodeLatency) WriteCloser {
+	if n == nil {
+		return w
+	}
+	return &latencyWriter{
+		w: w,
+		n: n,
+	}
+}
+
+func (lw *latencyWriter) Write(ctx *Context, t *Tuple) error {
+	// The timestamp must be read before writing the tuple because receivers
+	// may modify it.
+	ts := t.Timestamp
+	if err := lw.w.Write(ctx, t); err != nil {
+		return err
+	}
+	if ts.IsZero() {
+		return nil
+	}
+
+	now := ctx.Clock().Now()
+	d := now.Sub(ts)
+	lw.n.observe(d)
+	if lm, ok := ctx.metrics.(LatencyMetrics); ok {
+		lm.LatencyObserved(ctx.topologyName, lw.n.nodeType, lw.n.name, d)
+	}
+	if _, ok := ctx.metrics.(GaugeMetrics); ok && lw.n.shouldSampleLag(now) {
+		if lag, err := lw.n.lag(); err == nil {
+			ctx.SetGauge(lw.n.nodeType, lw.n.name, "offset_lag", lag)
+		}
+	}
+	return nil
+}
+
+func (lw *latencyWriter) Close(ctx *Context) error {
+	return lw.w.Close(ctx)
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"time"
+)
+
+type latencySource struct {
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+// NewLatencySource creates a source periodically emitting latencies tracked
+// by Context.Latencies so that they can be queried by BQL statements, e.g.
+// to detect sinks which fall behind. Every interval, it emits a tuple for
+// each source and sink in the topology. A tuple has the following fields in
+// Data:
+//
+//   - topology: the name of the topology
+//   - node_type: the type of the node, which is "source" or "sink"
+//   - node_name: the name of the node
+//   - count: the number of tuples observed by the node
+//   - latency: the latency of the last tuple in seconds
+//   - max_latency: the largest latency in seconds
+//   - average_latency: the moving average of latencies in seconds
+//   - offset_lag(optional): the offset lag of a source implementing
+//     LagReporter
+//   - offset_lag_error(optional): the error returned from LagReporter.Lag
+//
+// See LatencyTracker for how latencies are computed. interval must be
+// positive.
+func NewLatencySource(interval time.Duration) (Source, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("the interval must be positive: %v", interval)
+	}
+	return &latencySource{
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+func (s *latencySource) GenerateStream(ctx *Context, w Writer) error {
+	ticker := ctx.Clock().NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopCh:
+			return nil
+		case now := <-ticker.C():
+			for _, l := range ctx.Latencies.Latencies() {
+				m := l.Map()
+				m["topology"] = data.String(ctx.topologyName)
+				m["node_type"] = data.String(l.NodeType.String())
+				m["node_name"] = data.String(l.NodeName)
+				t := NewTuple(m)
+				t.Timestamp = now
+				if err := w.Write(ctx, t); err != nil {
+					if err == ErrSourceStopped {
+						return nil
+					}
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *latencySource) Stop(ctx *Context) error {
+	close(s.stopCh)
+	return nil
+}
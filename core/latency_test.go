@@ -0,0 +1,210 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+// latencyRecordingMetrics records latencies in addition to metrics recorded
+// by recordingMetrics.
+type latencyRecordingMetrics struct {
+	recordingMetrics
+	lm        sync.Mutex
+	latencies map[string][]time.Duration
+}
+
+func (r *latencyRecordingMetrics) LatencyObserved(topology string, nodeType NodeType, nodeName string, d time.Duration) {
+	r.lm.Lock()
+	defer r.lm.Unlock()
+	if r.latencies == nil {
+		r.latencies = map[string][]time.Duration{}
+	}
+	key := topology + "/" + nodeType.String() + "/" + nodeName
+	r.latencies[key] = append(r.latencies[key], d)
+}
+
+func (r *latencyRecordingMetrics) observed(key string) []time.Duration {
+	r.lm.Lock()
+	defer r.lm.Unlock()
+	return append([]time.Duration{}, r.latencies[key]...)
+}
+
+// laggingSource is a TupleEmitterSource reporting a fixed offset lag.
+type laggingSource struct {
+	*TupleEmitterSource
+	lag int64
+	err error
+}
+
+func (s *laggingSource) Lag(ctx *Context) (int64, error) {
+	return s.lag, s.err
+}
+
+func TestLatencyTracker(t *testing.T) {
+	Convey("Given a topology having a fake clock and latency metrics", t, func() {
+		c := NewFakeClock(time.Unix(100, 0))
+		m := &latencyRecordingMetrics{}
+		ctx := NewContext(&ContextConfig{
+			Clock:   c,
+			Metrics: m,
+		})
+		tp, err := NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		ts := make([]*Tuple, 4)
+		for i, d := range []time.Duration{5 * time.Second, 3 * time.Second, time.Second} {
+			ts[i] = NewTuple(data.Map{"int": data.Int(i)})
+			ts[i].Timestamp = c.Now().Add(-d)
+		}
+		ts[3] = NewTuple(data.Map{"int": data.Int(3)})
+		ts[3].Timestamp = time.Time{}
+
+		si := NewTupleCollectorSink()
+		sin, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		so := &laggingSource{
+			TupleEmitterSource: NewTupleEmitterSource(ts),
+			lag:                10,
+		}
+		son, err := tp.AddSource("source", so, &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		So(sin.Input("source", nil), ShouldBeNil)
+
+		Convey("When the source emits tuples having timestamps", func() {
+			So(son.Resume(), ShouldBeNil)
+			si.Wait(4)
+
+			Convey("Then the sink should observe end-to-end latencies", func() {
+				l, ok := ctx.Latencies.Latency("SINK")
+				So(ok, ShouldBeTrue)
+				So(l.NodeType, ShouldEqual, NTSink)
+				So(l.Count, ShouldEqual, 3)
+				So(l.Last, ShouldEqual, time.Second)
+				So(l.Max, ShouldEqual, 5*time.Second)
+				So(l.Average.Seconds(), ShouldAlmostEqual, 4.42, 1e-6)
+				So(l.HasOffsetLag, ShouldBeFalse)
+			})
+
+			Convey("Then the source should observe latencies and its offset lag", func() {
+				l, ok := ctx.Latencies.Latency("source")
+				So(ok, ShouldBeTrue)
+				So(l.NodeType, ShouldEqual, NTSource)
+				So(l.Count, ShouldEqual, 3)
+				So(l.HasOffsetLag, ShouldBeTrue)
+				So(l.OffsetLag, ShouldEqual, 10)
+
+				st := son.Status()["latency"].(data.Map)
+				So(st["count"], ShouldEqual, data.Int(3))
+				So(st["max_latency"], ShouldEqual, data.Float(5))
+				So(st["offset_lag"], ShouldEqual, data.Int(10))
+			})
+
+			Convey("Then latencies should be reported to metrics", func() {
+				So(m.observed("test/sink/sink"), ShouldResemble,
+					[]time.Duration{5 * time.Second, 3 * time.Second, time.Second})
+				So(m.observed("test/source/source"), ShouldHaveLength, 3)
+			})
+
+			Convey("Then the offset lag should be sampled as a gauge", func() {
+				So(m.count("test/source/source/offset_lag"), ShouldEqual, 10)
+			})
+
+			Convey("Then the status of the sink should have its latency", func() {
+				st := sin.Status()["latency"].(data.Map)
+				So(st["count"], ShouldEqual, data.Int(3))
+				So(st["latency"], ShouldEqual, data.Float(1))
+				So(st, ShouldNotContainKey, "offset_lag")
+			})
+
+			Convey("Then latencies of all nodes should be returned in order", func() {
+				ls := ctx.Latencies.Latencies()
+				So(ls, ShouldHaveLength, 2)
+				So(ls[0].NodeName, ShouldEqual, "sink")
+				So(ls[1].NodeName, ShouldEqual, "source")
+			})
+
+			Convey("And the sink is removed", func() {
+				So(tp.Remove("sink"), ShouldBeNil)
+
+				Convey("Then its latencies shouldn't be tracked", func() {
+					_, ok := ctx.Latencies.Latency("sink")
+					So(ok, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("When the source fails to report its offset lag", func() {
+			so.err = errors.New("lag error")
+
+			Convey("Then the error should be reported", func() {
+				l, ok := ctx.Latencies.Latency("source")
+				So(ok, ShouldBeTrue)
+				So(l.HasOffsetLag, ShouldBeFalse)
+				So(l.OffsetLagError, ShouldNotBeNil)
+				So(l.Map()["offset_lag_error"], ShouldEqual, data.String("lag error"))
+			})
+		})
+
+		Convey("When adding a latency source", func() {
+			ls, err := NewLatencySource(time.Second)
+			So(err, ShouldBeNil)
+			_, err = tp.AddSource("latencies", ls, nil)
+			So(err, ShouldBeNil)
+			lsi := NewTupleCollectorSink()
+			lsin, err := tp.AddSink("latency_sink", lsi, nil)
+			So(err, ShouldBeNil)
+			So(lsin.Input("latencies", nil), ShouldBeNil)
+
+			Convey("Then it should emit latencies of all nodes every interval", func() {
+				So(son.Resume(), ShouldBeNil)
+				si.Wait(4)
+				// The ticker of the source may not be created yet.
+				for lsi.len() < 4 {
+					c.Advance(time.Second)
+					time.Sleep(time.Millisecond)
+				}
+
+				var names []string
+				for i := 0; i < 4; i++ {
+					t := lsi.get(i)
+					names = append(names, string(t.Data["node_name"].(data.String)))
+					So(t.Data["topology"], ShouldEqual, data.String("test"))
+					So(t.Timestamp, ShouldHappenAfter, time.Unix(100, 0))
+				}
+				So(names, ShouldResemble, []string{"latencies", "latency_sink", "sink", "source"})
+				So(lsi.get(2).Data["count"], ShouldEqual, data.Int(3))
+				So(lsi.get(3).Data["node_type"], ShouldEqual, data.String("source"))
+			})
+		})
+	})
+
+	Convey("Given a nil latency tracker", t, func() {
+		var l *LatencyTracker
+
+		Convey("When reading latencies", func() {
+			_, ok := l.Latency("a")
+
+			Convey("Then nothing should be tracked", func() {
+				So(ok, ShouldBeFalse)
+				So(l.Latencies(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("When creating a latency source having an invalid interval", t, func() {
+		_, err := NewLatencySource(0)
+
+		Convey("Then it should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"github.com/Sirupsen/logrus"
+	"sync"
+)
+
+// LogLevels manages log levels of a topology and its nodes. Levels can be
+// changed at runtime without restarting the topology. A node which doesn't
+// have its own level uses the level of the topology.
+//
+// Because a logger is often shared by topologies, LogLevels doesn't modify
+// the base logger given to the Context. Instead, it creates loggers sharing
+// the output, the formatter, and hooks of the base logger with different
+// levels.
+type LogLevels struct {
+	m        sync.RWMutex
+	base     *logrus.Logger
+	topology *logrus.Logger
+	nodes    map[string]*logrus.Logger
+}
+
+// NewLogLevels creates a new LogLevels based on the given logger.
+func NewLogLevels(base *logrus.Logger) *LogLevels {
+	return &LogLevels{
+		base:  base,
+		nodes: map[string]*logrus.Logger{},
+	}
+}
+
+func (l *LogLevels) derive(lvl logrus.Level) *logrus.Logger {
+	return &logrus.Logger{
+		Out:       l.base.Out,
+		Formatter: l.base.Formatter,
+		Hooks:     l.base.Hooks,
+		Level:     lvl,
+	}
+}
+
+// Level returns the log level of the topology.
+func (l *LogLevels) Level() logrus.Level {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	return l.topologyLogger().Level
+}
+
+// SetLevel sets the log level of the topology. It doesn't affect nodes
+// having their own levels.
+func (l *LogLevels) SetLevel(lvl logrus.Level) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.topology = l.derive(lvl)
+}
+
+// NodeLevel returns the log level of the node. It returns false when the
+// node doesn't have its own level.
+func (l *LogLevels) NodeLevel(name string) (logrus.Level, bool) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	lg, ok := l.nodes[name]
+	if !ok {
+		return l.topologyLogger().Level, false
+	}
+	return lg.Level, true
+}
+
+// NodeLevels returns log levels of nodes having their own levels.
+func (l *LogLevels) NodeLevels() map[string]logrus.Level {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	m := make(map[string]logrus.Level, len(l.nodes))
+	for name, lg := range l.nodes {
+		m[name] = lg.Level
+	}
+	return m
+}
+
+// SetNodeLevel sets the log level of the node.
+func (l *LogLevels) SetNodeLevel(name string, lvl logrus.Level) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.nodes[name] = l.derive(lvl)
+}
+
+// RemoveNodeLevel removes the log level of the node so that the node uses
+// the level of the topology.
+func (l *LogLevels) RemoveNodeLevel(name string) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	delete(l.nodes, name)
+}
+
+func (l *LogLevels) topologyLogger() *logrus.Logger {
+	if l.topology != nil {
+		return l.topology
+	}
+	return l.base
+}
+
+func (l *LogLevels) logger() *logrus.Logger {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	return l.topologyLogger()
+}
+
+func (l *LogLevels) nodeLogger(name string) *logrus.Logger {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	if lg, ok := l.nodes[name]; ok {
+		return lg
+	}
+	return l.topologyLogger()
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"bytes"
+	"github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestLogLevels(t *testing.T) {
+	Convey("Given a context with a logger", t, func() {
+		buf := bytes.NewBuffer(nil)
+		logger := logrus.New()
+		logger.Out = buf
+		logger.Level = logrus.InfoLevel
+		ctx := NewContext(&ContextConfig{
+			Logger: logger,
+		})
+		levels := ctx.LogLevels
+
+		Convey("When no level is set", func() {
+			Convey("Then the level of the logger should be used", func() {
+				So(levels.Level(), ShouldEqual, logrus.InfoLevel)
+				lvl, ok := levels.NodeLevel("box")
+				So(ok, ShouldBeFalse)
+				So(lvl, ShouldEqual, logrus.InfoLevel)
+			})
+
+			Convey("Then debug logs of nodes shouldn't be written", func() {
+				ctx.nodeLog(NTBox, "box").Debug("hidden")
+				So(buf.String(), ShouldNotContainSubstring, "hidden")
+			})
+		})
+
+		Convey("When setting the level of a node", func() {
+			levels.SetNodeLevel("box", logrus.DebugLevel)
+
+			Convey("Then debug logs of the node should be written", func() {
+				ctx.nodeLog(NTBox, "box").Debug("visible")
+				So(buf.String(), ShouldContainSubstring, "visible")
+			})
+
+			Convey("Then debug logs of other nodes shouldn't be written", func() {
+				ctx.nodeLog(NTSink, "sink").Debug("hidden")
+				So(buf.String(), ShouldNotContainSubstring, "hidden")
+			})
+
+			Convey("Then the level should be listed", func() {
+				So(levels.NodeLevels(), ShouldResemble, map[string]logrus.Level{
+					"box": logrus.DebugLevel,
+				})
+			})
+
+			Convey("Then the base logger shouldn't be modified", func() {
+				So(logger.Level, ShouldEqual, logrus.InfoLevel)
+			})
+
+			Convey("And removing it", func() {
+				levels.RemoveNodeLevel("box")
+
+				Convey("Then the node should use the level of the topology", func() {
+					_, ok := levels.NodeLevel("box")
+					So(ok, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey("When setting the level of the topology", func() {
+			levels.SetLevel(logrus.ErrorLevel)
+
+			Convey("Then info logs shouldn't be written", func() {
+				ctx.Log().Info("hidden")
+				ctx.nodeLog(NTBox, "box").Info("hidden")
+				So(buf.String(), ShouldNotContainSubstring, "hidden")
+			})
+
+			Convey("Then the base logger shouldn't be modified", func() {
+				So(logger.Level, ShouldEqual, logrus.InfoLevel)
+			})
+		})
+	})
+}
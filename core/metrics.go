@@ -0,0 +1,87 @@
+package core
+
+import (
+	"time"
+)
+
+// Metrics receives metrics of nodes in a topology so that applications
+// embedding SensorBee can export them to their own monitoring systems. It's
+// given to a topology through ContextConfig.Metrics.
+//
+// Methods of Metrics are called from goroutines running nodes. Therefore,
+// they must be thread-safe and shouldn't block.
+type Metrics interface {
+	// TupleWritten is called every time a node writes a tuple. For a source,
+	// it's called after the source emits a tuple and d is the time taken to
+	// send it to destinations. For a box or a sink, it's called after the
+	// node processes a tuple and d is the time taken to process it. err is
+	// the error returned from the node, or nil when it succeeded.
+	TupleWritten(topology string, nodeType NodeType, nodeName string, d time.Duration, err error)
+}
+
+// metricsWriter reports metrics of tuples written by a node.
+type metricsWriter struct {
+	w        Writer
+	nodeType NodeType
+	nodeName string
+}
+
+// newMetricsWriter returns w as is when the Context doesn't have Metrics
+// so that there's no overhead.
+func newMetricsWriter(ctx *Context, w Writer, nodeType NodeType, nodeName string) Writer {
+	if ctx.metrics == nil {
+		return w
+	}
+	return &metricsWriter{
+		w:        w,
+		nodeType: nodeType,
+		nodeName: nodeName,
+	}
+}
+
+func (mw *metricsWriter) Write(ctx *Context, t *Tuple) error {
+	start := time.Now()
+	err := mw.w.Write(ctx, t)
+	ctx.metrics.TupleWritten(ctx.topologyName, mw.nodeType, mw.nodeName, time.Since(start), err)
+	return err
+}
+
+// CounterMetrics is an optional interface of Metrics receiving counters
+// reported by nodes through Context.AddCounter, such as the number of tuples
+// violating each rule of an assertion box.
+type CounterMetrics interface {
+	Metrics
+
+	// CounterAdded is called when a node adds delta to the counter having
+	// the name.
+	CounterAdded(topology string, nodeType NodeType, nodeName string, name string, delta int64)
+}
+
+// AddCounter adds delta to the counter of the node. The counter is reported
+// to Metrics of the Context when it implements CounterMetrics. Otherwise,
+// this method doesn't do anything.
+func (c *Context) AddCounter(nodeType NodeType, nodeName string, name string, delta int64) {
+	if cm, ok := c.metrics.(CounterMetrics); ok {
+		cm.CounterAdded(c.topologyName, nodeType, nodeName, name, delta)
+	}
+}
+
+// GaugeMetrics is an optional interface of Metrics receiving gauges reported
+// by nodes through Context.SetGauge, such as the number of keys whose state
+// is kept by a box.
+type GaugeMetrics interface {
+	Metrics
+
+	// GaugeSet is called when a node sets the gauge having the name to
+	// value.
+	GaugeSet(topology string, nodeType NodeType, nodeName string, name string, value int64)
+}
+
+// SetGauge sets the gauge of the node to value. The gauge is reported to
+// Metrics of the Context when it implements GaugeMetrics. Otherwise, this
+// method doesn't do anything.
+func (c *Context) SetGauge(nodeType NodeType, nodeName string, name string, value int64) {
+	if gm, ok := c.metrics.(GaugeMetrics); ok {
+		gm.GaugeSet(c.topologyName, nodeType, nodeName, name, value)
+	}
+}
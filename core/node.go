@@ -145,6 +145,8 @@ type Node interface {
 	//	* num_received_total: the total number of tuples the node received
 	//	* num_errors: the number of errors that the node failed to process tuples
 	//	              including temporary errors
+	//	* num_in_flight: the number of tuples the node has received but not
+	//	                 finished writing yet
 	//	* inputs: the information of data sources connected to the node
 	//
 	// "inputs" field in "input_stats" contains the input statistics of each
@@ -206,6 +208,24 @@ type SourceNode interface {
 	// node is already stopped.
 	Rewind() error
 
+	// RewindTo rewinds the stream to the given offset if the Source
+	// implements OffsetRewindableSource. Like Rewind, it doesn't resume the
+	// stream if the Source is paused.
+	//
+	// RewindTo returns an error if the Source doesn't support RewindTo, or
+	// the node is already stopped.
+	RewindTo(offset int64) error
+
+	// Update updates parameters of the Source. The Source or a Source wrapped
+	// by functions like NewRewindableSource must implement Updater. When it
+	// also implements UpdateValidator, parameters are validated before being
+	// applied. Update isn't called concurrently with other Update, Rewind, or
+	// Stop of the node.
+	//
+	// Update returns an error if the Source doesn't support updating or the
+	// parameters are invalid.
+	Update(params data.Map) error
+
 	// StopOnDisconnect tells the Source that it may automatically stop when all
 	// outband connections (channels or pipes) are closed. After calling this
 	// method, the Source can automatically stop even if Stop method isn't
@@ -225,6 +245,11 @@ type BoxNode interface {
 	// tuples. There must be a Source or a Box having the name.
 	Input(refname string, config *BoxInputConfig) error
 
+	// Inputs returns configs of inputs currently connected to the Box. Keys
+	// of the returned map are names of input nodes. Modifying returned
+	// configs doesn't affect the Box.
+	Inputs() map[string]*BoxInputConfig
+
 	// EnableGracefulStop activates a graceful stop mode. If it is enabled,
 	// Stop method waits until the Box doesn't have an incoming tuple. The Box
 	// doesn't wait until, for example, a source generates all tuples. It only
@@ -287,19 +312,37 @@ type BoxInputConfig struct {
 	InputName string
 
 	// Capacity is the maximum capacity or buffer size (length) of input pipe.
-	// When this parameter is 0, the default value is used. This parameter is
-	// only used as a hint and doesn't guarantee that the pipe can actually have
+	// When this parameter is 0, QueueSize of the Box resolved by Context.Settings
+	// is used. This parameter is only used as a hint and doesn't guarantee that the pipe can actually have
 	// the specified number of tuples.
 	Capacity int
 
 	// DropMode is a mode which controls the behavior of dropping tuples at the
 	// output side of the queue when it is full.
 	DropMode QueueDropMode
+
+	// Partition is a config of hash partitioning on the input. When it's
+	// non-nil, only tuples assigned to the partition are sent to the Box.
+	Partition *PartitionConfig
+
+	// PipeType is the type of the input pipe. RingPipe can be used for
+	// latency-critical paths. BQL sets it with RING PIPE or CHANNEL PIPE in
+	// the window of an input stream.
+	PipeType PipeType
 }
 
 // Validate validates values of BoxInputConfig.
 func (c *BoxInputConfig) Validate() error {
-	return validateCapacity(c.Capacity)
+	if err := validateCapacity(c.Capacity); err != nil {
+		return err
+	}
+	if err := validatePipeType(c.PipeType, c.DropMode); err != nil {
+		return err
+	}
+	if c.Partition != nil {
+		return c.Partition.Validate()
+	}
+	return nil
 }
 
 func (c *BoxInputConfig) inputName() string {
@@ -309,9 +352,9 @@ func (c *BoxInputConfig) inputName() string {
 	return c.InputName
 }
 
-func (c *BoxInputConfig) capacity() int {
+func (c *BoxInputConfig) capacity(ctx *Context, nodeName string) int {
 	if c.Capacity == 0 {
-		return 1024
+		return ctx.queueSize(nodeName)
 	}
 	return c.Capacity
 }
@@ -325,11 +368,25 @@ type SinkNode interface {
 	// Sink returns internal source passed to Topology.AddSink.
 	Sink() Sink
 
+	// Update updates parameters of the Sink. The Sink must implement Updater.
+	// See SourceNode.Update for details.
+	Update(params data.Map) error
+
 	// Input adds a new input from a Source or a Box. refname refers a name of
 	// node from which the Box want to receive tuples. There must be a Source
 	// or a Box having the name.
 	Input(refname string, config *SinkInputConfig) error
 
+	// Inputs returns configs of inputs currently connected to the Sink. Keys
+	// of the returned map are names of input nodes. Modifying returned
+	// configs doesn't affect the Sink.
+	Inputs() map[string]*SinkInputConfig
+
+	// RemoveInput disconnects the input from the node having refname. It
+	// returns NotExistError when the Sink isn't receiving tuples from the
+	// node.
+	RemoveInput(refname string) error
+
 	// EnableGracefulStop activates a graceful stop mode. If it is enabled,
 	// Stop method waits until the Sink doesn't have an incoming tuple. The Sink
 	// doesn't wait until, for example, a source generates all tuples. It only
@@ -342,30 +399,59 @@ type SinkNode interface {
 	// method, the Sink can automatically stop even if Stop method isn't
 	// explicitly called.
 	StopOnDisconnect()
+
+	// Flush writes tuples buffered by the Sink when it implements Flushable.
+	// It does nothing when the Sink doesn't implement it or has already been
+	// closed.
+	Flush() error
+
+	// Connect connects the Sink to the external system when it implements
+	// Connector, and then checks its health when it implements
+	// HealthChecker. It returns nil when the Sink is ready to receive tuples.
+	// It's used to make sure that sinks are connected before sources sending
+	// tuples to them start.
+	Connect() error
 }
 
 // SinkInputConfig has parameters to customize input behavior of a Sink on
 // each input pipe.
 type SinkInputConfig struct {
 	// Capacity is the maximum capacity (length) of input pipe. When this
-	// parameter is 0, the default value is used. This parameter is only used
-	// as a hint and doesn't guarantee that the pipe can actually have the
+	// parameter is 0, QueueSize of the Sink resolved by Context.Settings is used.
+	// This parameter is only used as a hint and doesn't guarantee that the pipe can actually have the
 	// specified number of tuples.
 	Capacity int
 
 	// DropMode is a mode which controls the behavior of dropping tuples at the
 	// output side of the queue when it is full.
 	DropMode QueueDropMode
+
+	// Partition is a config of hash partitioning on the input. When it's
+	// non-nil, only tuples assigned to the partition are sent to the Sink.
+	Partition *PartitionConfig
+
+	// PipeType is the type of the input pipe. RingPipe can be used for
+	// latency-critical paths.
+	PipeType PipeType
 }
 
 // Validate validates values of SinkInputConfig.
 func (c *SinkInputConfig) Validate() error {
-	return validateCapacity(c.Capacity)
+	if err := validateCapacity(c.Capacity); err != nil {
+		return err
+	}
+	if err := validatePipeType(c.PipeType, c.DropMode); err != nil {
+		return err
+	}
+	if c.Partition != nil {
+		return c.Partition.Validate()
+	}
+	return nil
 }
 
-func (c *SinkInputConfig) capacity() int {
+func (c *SinkInputConfig) capacity(ctx *Context, nodeName string) int {
 	if c.Capacity == 0 {
-		return 1024
+		return ctx.queueSize(nodeName)
 	}
 	return c.Capacity
 }
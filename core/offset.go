@@ -0,0 +1,299 @@
+package core
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// OffsetTracker tracks offsets of tuples acknowledged by sinks. A sink
+// acknowledges the offset of a tuple after it successfully writes the tuple.
+// Because tuples emitted by a box carry the offset of the tuple they're
+// derived from, offsets are tracked even when tuples go through boxes.
+//
+// The tracker keeps the largest offset acknowledged by each sink for each
+// source. It assumes that a sink receives tuples from a source in the order
+// of their offsets, which is true as long as there's only one path from the
+// source to the sink. Otherwise, offsets are approximate and some tuples might
+// be reprocessed more than once when the source is rewound to them.
+//
+// Methods of OffsetTracker can be called on a nil pointer, in which case
+// offsets aren't tracked. Node names are case-insensitive.
+type OffsetTracker struct {
+	m sync.RWMutex
+
+	// acked has the largest offset acknowledged by each sink for each source.
+	// The key of the outer map is the name of a sink and the key of the inner
+	// map is the name of a source.
+	acked map[string]map[string]*int64
+
+	// emitted has the largest offset emitted by each source.
+	emitted map[string]*int64
+}
+
+// NewOffsetTracker returns a new OffsetTracker having no offset.
+func NewOffsetTracker() *OffsetTracker {
+	return &OffsetTracker{
+		acked:   map[string]map[string]*int64{},
+		emitted: map[string]*int64{},
+	}
+}
+
+// Ack acknowledges that the sink has written the tuple having the offset of
+// the source. It doesn't change the acknowledged offset when the sink has
+// already acknowledged a larger offset.
+func (o *OffsetTracker) Ack(sinkName, sourceName string, offset int64) {
+	if o == nil || offset <= 0 {
+		return
+	}
+	sinkName = strings.ToLower(sinkName)
+	sourceName = strings.ToLower(sourceName)
+
+	o.m.RLock()
+	p, ok := o.acked[sinkName][sourceName]
+	o.m.RUnlock()
+	if !ok {
+		o.m.Lock()
+		sources, ok := o.acked[sinkName]
+		if !ok {
+			sources = map[string]*int64{}
+			o.acked[sinkName] = sources
+		}
+		if p, ok = sources[sourceName]; !ok {
+			p = new(int64)
+			sources[sourceName] = p
+		}
+		o.m.Unlock()
+	}
+
+	for {
+		cur := atomic.LoadInt64(p)
+		if cur >= offset || atomic.CompareAndSwapInt64(p, cur, offset) {
+			return
+		}
+	}
+}
+
+// Acked returns the largest offset of the source acknowledged by the sink.
+// It returns false when the sink hasn't acknowledged any tuple of the source.
+func (o *OffsetTracker) Acked(sinkName, sourceName string) (int64, bool) {
+	if o == nil {
+		return 0, false
+	}
+	o.m.RLock()
+	defer o.m.RUnlock()
+	p, ok := o.acked[strings.ToLower(sinkName)][strings.ToLower(sourceName)]
+	if !ok {
+		return 0, false
+	}
+	return atomic.LoadInt64(p), true
+}
+
+// Emit records that the source has emitted the tuple having the offset. It
+// doesn't change the emitted offset when the source has already emitted a
+// larger offset.
+func (o *OffsetTracker) Emit(sourceName string, offset int64) {
+	if o == nil || offset <= 0 {
+		return
+	}
+	sourceName = strings.ToLower(sourceName)
+
+	o.m.RLock()
+	p, ok := o.emitted[sourceName]
+	o.m.RUnlock()
+	if !ok {
+		o.m.Lock()
+		if p, ok = o.emitted[sourceName]; !ok {
+			p = new(int64)
+			o.emitted[sourceName] = p
+		}
+		o.m.Unlock()
+	}
+
+	for {
+		cur := atomic.LoadInt64(p)
+		if cur >= offset || atomic.CompareAndSwapInt64(p, cur, offset) {
+			return
+		}
+	}
+}
+
+// Emitted returns the largest offset emitted by the source. Unlike Committed,
+// tuples up to the offset might not have been written by sinks yet. It
+// returns false when the source hasn't emitted any tuple having an offset.
+func (o *OffsetTracker) Emitted(sourceName string) (int64, bool) {
+	if o == nil {
+		return 0, false
+	}
+	o.m.RLock()
+	defer o.m.RUnlock()
+	p, ok := o.emitted[strings.ToLower(sourceName)]
+	if !ok {
+		return 0, false
+	}
+	return atomic.LoadInt64(p), true
+}
+
+// Committed returns the offset of the source acknowledged by all sinks which
+// have acknowledged the source, that is the smallest offset among them. All
+// tuples up to the offset have been written by those sinks. It returns false
+// when no sink has acknowledged any tuple of the source.
+func (o *OffsetTracker) Committed(sourceName string) (int64, bool) {
+	if o == nil {
+		return 0, false
+	}
+	sourceName = strings.ToLower(sourceName)
+	o.m.RLock()
+	defer o.m.RUnlock()
+	var (
+		min   int64
+		found bool
+	)
+	for _, sources := range o.acked {
+		p, ok := sources[sourceName]
+		if !ok {
+			continue
+		}
+		if off := atomic.LoadInt64(p); !found || off < min {
+			min = off
+			found = true
+		}
+	}
+	return min, found
+}
+
+// RemoveNode removes offsets related to the node. It should be called when
+// the node is removed from the topology.
+func (o *OffsetTracker) RemoveNode(name string) {
+	if o == nil {
+		return
+	}
+	name = strings.ToLower(name)
+	o.m.Lock()
+	defer o.m.Unlock()
+	delete(o.acked, name)
+	delete(o.emitted, name)
+	for sink, sources := range o.acked {
+		delete(sources, name)
+		if len(sources) == 0 {
+			delete(o.acked, sink)
+		}
+	}
+}
+
+// Status returns offsets acknowledged by sinks. Keys of the map are names of
+// sinks and each value is a map from names of sources to offsets.
+func (o *OffsetTracker) Status() data.Map {
+	m := data.Map{}
+	if o == nil {
+		return m
+	}
+	o.m.RLock()
+	defer o.m.RUnlock()
+	for sink, sources := range o.acked {
+		sm := data.Map{}
+		for source, p := range sources {
+			sm[source] = data.Int(atomic.LoadInt64(p))
+		}
+		m[sink] = sm
+	}
+	return m
+}
+
+// offsetSourceWriter sets the name of the source node to tuples having
+// offsets.
+type offsetSourceWriter struct {
+	w          WriteCloser
+	sourceName string
+}
+
+func newOffsetSourceWriter(w WriteCloser, sourceName string) *offsetSourceWriter {
+	return &offsetSourceWriter{
+		w:          w,
+		sourceName: sourceName,
+	}
+}
+
+func (ow *offsetSourceWriter) Write(ctx *Context, t *Tuple) error {
+	// The tuple isn't modified when it already has the name so that tuples
+	// written again after a rewind don't race with nodes reading them.
+	if t.Offset > 0 && t.OffsetSource != ow.sourceName {
+		t.OffsetSource = ow.sourceName
+	}
+	// The offset must be read before writing the tuple because receivers
+	// may modify it.
+	offset := t.Offset
+	if err := ow.w.Write(ctx, t); err != nil {
+		return err
+	}
+	ctx.Offsets.Emit(ow.sourceName, offset)
+	return nil
+}
+
+func (ow *offsetSourceWriter) Close(ctx *Context) error {
+	return ow.w.Close(ctx)
+}
+
+// ackWriter acknowledges offsets of tuples successfully written by a sink.
+type ackWriter struct {
+	w        WriteCloser
+	sinkName string
+}
+
+func newAckWriter(w WriteCloser, sinkName string) *ackWriter {
+	return &ackWriter{
+		w:        w,
+		sinkName: sinkName,
+	}
+}
+
+func (aw *ackWriter) Write(ctx *Context, t *Tuple) error {
+	// Offset and OffsetSource must be read before writing the tuple because
+	// the sink may modify it.
+	offset, source := t.Offset, t.OffsetSource
+	if err := aw.w.Write(ctx, t); err != nil {
+		return err
+	}
+	if offset > 0 && source != "" {
+		ctx.Offsets.Ack(aw.sinkName, source, offset)
+	}
+	return nil
+}
+
+func (aw *ackWriter) Close(ctx *Context) error {
+	return aw.w.Close(ctx)
+}
+
+// startOffsetWriter discards tuples having offsets less than the start
+// offset. Once the source is rewound, all tuples are written regardless of
+// their offsets.
+type startOffsetWriter struct {
+	w     WriteCloser
+	start int64
+}
+
+func newStartOffsetWriter(start int64) *startOffsetWriter {
+	return &startOffsetWriter{
+		start: start,
+	}
+}
+
+func (sw *startOffsetWriter) Write(ctx *Context, t *Tuple) error {
+	if t.Offset > 0 && t.Offset < atomic.LoadInt64(&sw.start) {
+		return nil
+	}
+	return sw.w.Write(ctx, t)
+}
+
+func (sw *startOffsetWriter) Close(ctx *Context) error {
+	return sw.w.Close(ctx)
+}
+
+// reset stops discarding tuples. It can be called on a nil pointer.
+func (sw *startOffsetWriter) reset() {
+	if sw == nil {
+		return
+	}
+	atomic.StoreInt64(&sw.start, 0)
+}
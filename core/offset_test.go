@@ -0,0 +1,288 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failOnceSink returns a fatal error on the first tuple having the offset
+// failAt and writes other tuples to the internal collector sink.
+type failOnceSink struct {
+	*TupleCollectorSink
+	failAt int64
+	failed int32
+}
+
+func (s *failOnceSink) Write(ctx *Context, t *Tuple) error {
+	if t.Offset == s.failAt && atomic.CompareAndSwapInt32(&s.failed, 0, 1) {
+		return FatalError(errors.New("sink failure"))
+	}
+	return s.TupleCollectorSink.Write(ctx, t)
+}
+
+func TestOffsetTracker(t *testing.T) {
+	Convey("Given an offset tracker", t, func() {
+		o := NewOffsetTracker()
+
+		Convey("When acknowledging offsets", func() {
+			o.Ack("sink1", "source", 3)
+			o.Ack("SINK1", "Source", 5)
+			o.Ack("sink1", "source", 4)
+			o.Ack("sink2", "source", 2)
+
+			Convey("Then the largest offset should be acknowledged by each sink", func() {
+				off, ok := o.Acked("sink1", "source")
+				So(ok, ShouldBeTrue)
+				So(off, ShouldEqual, 5)
+				off, ok = o.Acked("sink2", "SOURCE")
+				So(ok, ShouldBeTrue)
+				So(off, ShouldEqual, 2)
+			})
+
+			Convey("Then the committed offset should be the smallest one", func() {
+				off, ok := o.Committed("source")
+				So(ok, ShouldBeTrue)
+				So(off, ShouldEqual, 2)
+			})
+
+			Convey("Then the status should have all offsets", func() {
+				So(o.Status(), ShouldResemble, data.Map{
+					"sink1": data.Map{"source": data.Int(5)},
+					"sink2": data.Map{"source": data.Int(2)},
+				})
+			})
+
+			Convey("Then removing the sink should remove its offsets", func() {
+				o.RemoveNode("sink2")
+				_, ok := o.Acked("sink2", "source")
+				So(ok, ShouldBeFalse)
+				off, _ := o.Committed("source")
+				So(off, ShouldEqual, 5)
+			})
+
+			Convey("Then removing the source should remove its offsets", func() {
+				o.RemoveNode("source")
+				_, ok := o.Committed("source")
+				So(ok, ShouldBeFalse)
+				So(o.Status(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When emitting offsets", func() {
+			o.Emit("source", 3)
+			o.Emit("Source", 5)
+			o.Emit("source", 4)
+			o.Emit("source", 0)
+
+			Convey("Then the largest offset should be emitted", func() {
+				off, ok := o.Emitted("SOURCE")
+				So(ok, ShouldBeTrue)
+				So(off, ShouldEqual, 5)
+			})
+
+			Convey("Then it shouldn't be committed", func() {
+				_, ok := o.Committed("source")
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then removing the source should remove its offset", func() {
+				o.RemoveNode("source")
+				_, ok := o.Emitted("source")
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When acknowledging an invalid offset", func() {
+			o.Ack("sink", "source", 0)
+
+			Convey("Then it should be ignored", func() {
+				_, ok := o.Acked("sink", "source")
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a nil offset tracker", t, func() {
+		var o *OffsetTracker
+
+		Convey("When acknowledging an offset", func() {
+			o.Ack("sink", "source", 1)
+			o.Emit("source", 1)
+
+			Convey("Then it shouldn't have any offset", func() {
+				_, ok := o.Acked("sink", "source")
+				So(ok, ShouldBeFalse)
+				_, ok = o.Emitted("source")
+				So(ok, ShouldBeFalse)
+				_, ok = o.Committed("source")
+				So(ok, ShouldBeFalse)
+				So(o.Status(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestRewindToOffset(t *testing.T) {
+	Convey("Given a topology with a rewindable source", t, func() {
+		ctx := NewContext(nil)
+		t, err := NewDefaultTopology(ctx, "offset_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		fts := freshTuples()
+		so := NewTupleEmitterSource(fts)
+		son, err := t.AddSource("source", NewRewindableSource(so), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When emitting all tuples to a sink", func() {
+			si := NewTupleCollectorSink()
+			sin, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+			si.Wait(len(fts))
+
+			Convey("Then tuples should have offsets", func() {
+				for i := 0; i < si.len(); i++ {
+					So(si.get(i).Offset, ShouldEqual, i+1)
+					So(si.get(i).OffsetSource, ShouldEqual, "source")
+				}
+			})
+
+			Convey("Then the sink should acknowledge the last offset", func() {
+				off, ok := ctx.Offsets.Acked("sink", "source")
+				So(ok, ShouldBeTrue)
+				So(off, ShouldEqual, len(fts))
+			})
+
+			Convey("Then the source should have emitted the last offset", func() {
+				off, ok := ctx.Offsets.Emitted("source")
+				So(ok, ShouldBeTrue)
+				So(off, ShouldEqual, len(fts))
+			})
+
+			Convey("Then rewinding to an offset should only emit tuples after it", func() {
+				So(son.RewindTo(6), ShouldBeNil)
+				si.Wait(len(fts) + 3)
+				So(si.len(), ShouldEqual, len(fts)+3)
+				for i := 0; i < 3; i++ {
+					So(si.get(len(fts)+i).Offset, ShouldEqual, 6+i)
+				}
+			})
+
+			Convey("Then rewinding to an invalid offset should fail", func() {
+				So(son.RewindTo(0), ShouldNotBeNil)
+			})
+
+			Convey("Then removing the sink should remove its offsets", func() {
+				So(t.Remove("sink"), ShouldBeNil)
+				_, ok := ctx.Offsets.Acked("sink", "source")
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the sink fails with a replay policy", func() {
+			si := &failOnceSink{
+				TupleCollectorSink: NewTupleCollectorSink(),
+				failAt:             3,
+			}
+			sin, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(ctx.Recovery.Set("sink", &RecoveryPolicy{
+				Type:           RPRestartNode,
+				InitialBackoff: time.Nanosecond,
+				Replay:         true,
+			}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the failed tuple should be replayed", func() {
+				var offsets map[int64]bool
+				for i := 0; i < 1000 && len(offsets) < len(fts); i++ {
+					time.Sleep(time.Millisecond)
+					offsets = map[int64]bool{}
+					si.forEachTuple(func(t *Tuple) {
+						offsets[t.Offset] = true
+					})
+				}
+				for i := 1; i <= len(fts); i++ {
+					So(offsets[int64(i)], ShouldBeTrue)
+				}
+			})
+		})
+
+		Convey("When calling RewindTo on a non-rewindable source", func() {
+			son2, err := t.AddSource("source2", NewTupleEmitterSource(freshTuples()), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(son2.RewindTo(1), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSourceStartOffset(t *testing.T) {
+	Convey("Given a topology with a source having a start offset", t, func() {
+		t, err := NewDefaultTopology(NewContext(nil), "start_offset_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		fts := freshTuples()
+		son, err := t.AddSource("source", NewRewindableSource(NewTupleEmitterSource(fts)), &SourceConfig{
+			PausedOnStartup: true,
+			StartOffset:     5,
+		})
+		So(err, ShouldBeNil)
+
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("source", nil), ShouldBeNil)
+
+		Convey("When emitting all tuples", func() {
+			So(son.Resume(), ShouldBeNil)
+			si.Wait(len(fts) - 4)
+
+			Convey("Then tuples before the start offset should be discarded", func() {
+				So(si.len(), ShouldEqual, len(fts)-4)
+				So(si.get(0).Offset, ShouldEqual, 5)
+			})
+
+			Convey("Then rewinding the source should emit all tuples", func() {
+				So(son.Rewind(), ShouldBeNil)
+				si.Wait(2*len(fts) - 4)
+				So(si.len(), ShouldEqual, 2*len(fts)-4)
+				So(si.get(len(fts)-4).Offset, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a topology", t, func() {
+		t, err := NewDefaultTopology(NewContext(nil), "start_offset_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		Convey("When adding a source with a negative start offset", func() {
+			_, err := t.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+				StartOffset: -1,
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,359 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+)
+
+// PartitionedSource is a source whose stream consists of partitions which can
+// be read independently, such as files matching a glob pattern. It's run by a
+// ParallelSource created by NewParallelSource.
+type PartitionedSource interface {
+	// Partitions returns IDs of all partitions of the stream. It's called
+	// every time partitions are assigned to readers, i.e. when the source
+	// starts and when it's rescaled.
+	Partitions(ctx *Context) ([]string, error)
+
+	// NewReader creates a Source reading the given partitions. Readers run
+	// concurrently. Since partitions are reassigned to new readers when the
+	// ParallelSource is rescaled, a reader must resume reading a partition
+	// from where the previous reader of the partition stopped. Therefore,
+	// the PartitionedSource is responsible for keeping positions of
+	// partitions.
+	//
+	// Stop of the reader is called when the ParallelSource is stopped or
+	// rescaled. GenerateStream of the reader should return nil in that case.
+	NewReader(ctx *Context, partitions []string) (Source, error)
+}
+
+// LagReporter is an optional interface of a Source, including a reader
+// created by PartitionedSource.NewReader. Lag returns how far the source lags
+// behind the end of the stream, e.g. the number of messages or bytes which
+// haven't been read yet. It's reported as the offset lag of the source (see
+// LatencyTracker).
+type LagReporter interface {
+	Lag(ctx *Context) (int64, error)
+}
+
+// ParallelSource is a Source running multiple readers of a PartitionedSource
+// under one source node. Partitions are assigned to readers in round robin
+// and the number of readers can be changed at runtime by Rescale or by
+// updating the "readers" parameter.
+//
+// Readers decode records concurrently, but writes of tuples are serialized
+// so that tuples are written to the topology as if they're written by a
+// single source. The order of tuples from different partitions isn't
+// defined.
+//
+// GenerateStream returns when all readers return or when a reader fails.
+//
+// Status of a ParallelSource has "readers", which is the number of readers,
+// and "partition_readers", which is an array having "partitions" and "lag" of
+// each running reader. "lag" is only reported by readers implementing
+// LagReporter and "lag_error" is reported instead when it fails.
+type ParallelSource interface {
+	Source
+	Updater
+	UpdateValidator
+	Statuser
+	LagReporter
+
+	// Rescale changes the number of readers. Running readers are stopped and
+	// partitions are reassigned to new readers.
+	Rescale(readers int) error
+
+	// Readers returns the number of readers.
+	Readers() int
+}
+
+type parallelSource struct {
+	ps PartitionedSource
+
+	m          sync.Mutex
+	ctx        *Context
+	numReaders int
+	readers    []*partitionReader
+	stopped    bool
+	stopCh     chan struct{}
+
+	// rescaleCh notifies the running readers that the source is rescaled.
+	rescaleCh chan struct{}
+}
+
+type partitionReader struct {
+	partitions []string
+	source     Source
+}
+
+// NewParallelSource creates a ParallelSource running the given number of
+// readers of the PartitionedSource. readers must be positive.
+func NewParallelSource(ps PartitionedSource, readers int) (ParallelSource, error) {
+	if readers < 1 {
+		return nil, fmt.Errorf("the number of readers must be positive: %v", readers)
+	}
+	return &parallelSource{
+		ps:         ps,
+		numReaders: readers,
+		stopCh:     make(chan struct{}),
+		rescaleCh:  make(chan struct{}, 1),
+	}, nil
+}
+
+func (s *parallelSource) GenerateStream(ctx *Context, w Writer) error {
+	s.m.Lock()
+	s.ctx = ctx
+	s.m.Unlock()
+
+	sw := &serialWriter{w: w}
+	for {
+		rescaled, err := s.generate(ctx, sw)
+		if err != nil || !rescaled {
+			return err
+		}
+	}
+}
+
+// generate runs readers until all of them return, the source is stopped, or
+// the source is rescaled. It returns true when the source is rescaled and
+// readers have to be created again.
+func (s *parallelSource) generate(ctx *Context, w Writer) (bool, error) {
+	// A notification sent before the number of readers is read can be
+	// discarded since it's already reflected.
+	select {
+	case <-s.rescaleCh:
+	default:
+	}
+	s.m.Lock()
+	stopped, n := s.stopped, s.numReaders
+	s.m.Unlock()
+	if stopped {
+		return false, nil
+	}
+
+	parts, err := s.ps.Partitions(ctx)
+	if err != nil {
+		return false, err
+	}
+	var readers []*partitionReader
+	for _, ps := range assignPartitions(parts, n) {
+		src, err := s.ps.NewReader(ctx, ps)
+		if err != nil {
+			return false, fmt.Errorf("cannot create a reader of partitions %v: %v", ps, err)
+		}
+		readers = append(readers, &partitionReader{
+			partitions: ps,
+			source:     src,
+		})
+	}
+
+	s.m.Lock()
+	if s.stopped {
+		s.m.Unlock()
+		return false, nil
+	}
+	s.readers = readers
+	s.m.Unlock()
+	defer func() {
+		s.m.Lock()
+		s.readers = nil
+		s.m.Unlock()
+	}()
+
+	errCh := make(chan error, len(readers))
+	for _, r := range readers {
+		r := r
+		go func() {
+			errCh <- func() (err error) {
+				defer func() {
+					if e := recover(); e != nil {
+						err = fmt.Errorf("the reader of partitions %v panicked: %v", r.partitions, e)
+					}
+				}()
+				return r.source.GenerateStream(ctx, w)
+			}()
+		}()
+	}
+
+	var (
+		firstErr  error
+		rescaled  bool
+		stopping  bool
+		stopCh    = s.stopCh
+		rescaleCh = s.rescaleCh
+	)
+	stopReaders := func() {
+		if stopping {
+			return
+		}
+		stopping = true
+		stopCh, rescaleCh = nil, nil
+		for _, r := range readers {
+			if err := r.source.Stop(ctx); err != nil {
+				ctx.ErrLog(err).WithField("partitions", r.partitions).
+					Error("Cannot stop the reader")
+			}
+		}
+	}
+	for running := len(readers); running > 0; {
+		select {
+		case err := <-errCh:
+			running--
+			if err != nil && !stopping {
+				firstErr = err
+				stopReaders()
+			}
+		case <-stopCh:
+			stopReaders()
+		case <-rescaleCh:
+			rescaled = true
+			stopReaders()
+		}
+	}
+	return rescaled && firstErr == nil, firstErr
+}
+
+// assignPartitions assigns partitions to at most n readers in round robin.
+func assignPartitions(parts []string, n int) [][]string {
+	if n > len(parts) {
+		n = len(parts)
+	}
+	assigned := make([][]string, n)
+	for i, p := range parts {
+		assigned[i%n] = append(assigned[i%n], p)
+	}
+	return assigned
+}
+
+func (s *parallelSource) Stop(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stopCh)
+	}
+	return nil
+}
+
+func (s *parallelSource) Rescale(readers int) error {
+	if readers < 1 {
+		return fmt.Errorf("the number of readers must be positive: %v", readers)
+	}
+	s.m.Lock()
+	changed := s.numReaders != readers
+	s.numReaders = readers
+	s.m.Unlock()
+
+	if changed {
+		select {
+		case s.rescaleCh <- struct{}{}:
+		default: // already notified
+		}
+	}
+	return nil
+}
+
+func (s *parallelSource) Readers() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.numReaders
+}
+
+func (s *parallelSource) ValidateUpdate(ctx *Context, params data.Map) error {
+	_, err := parseReadersParam(params)
+	return err
+}
+
+// Update rescales the source with the "readers" parameter.
+func (s *parallelSource) Update(ctx *Context, params data.Map) error {
+	n, err := parseReadersParam(params)
+	if err != nil {
+		return err
+	}
+	return s.Rescale(n)
+}
+
+func parseReadersParam(params data.Map) (int, error) {
+	var n int
+	for k, v := range params {
+		switch k {
+		case "readers":
+			i, err := data.AsInt(v)
+			if err != nil {
+				return 0, fmt.Errorf("'readers' parameter must be an integer: %v", err)
+			}
+			if i < 1 {
+				return 0, fmt.Errorf("'readers' parameter must be positive: %v", i)
+			}
+			n = int(i)
+		default:
+			return 0, fmt.Errorf("unknown parameter: %v", k)
+		}
+	}
+	if n == 0 {
+		return 0, errors.New("'readers' parameter is missing")
+	}
+	return n, nil
+}
+
+func (s *parallelSource) Status() data.Map {
+	s.m.Lock()
+	ctx, n := s.ctx, s.numReaders
+	readers := append([]*partitionReader{}, s.readers...)
+	s.m.Unlock()
+
+	rs := make(data.Array, 0, len(readers))
+	for _, r := range readers {
+		ps := make(data.Array, len(r.partitions))
+		for i, p := range r.partitions {
+			ps[i] = data.String(p)
+		}
+		m := data.Map{
+			"partitions": ps,
+		}
+		if l, ok := r.source.(LagReporter); ok {
+			if lag, err := l.Lag(ctx); err != nil {
+				m["lag_error"] = data.String(err.Error())
+			} else {
+				m["lag"] = data.Int(lag)
+			}
+		}
+		rs = append(rs, m)
+	}
+	return data.Map{
+		"readers":           data.Int(n),
+		"partition_readers": rs,
+	}
+}
+
+// Lag returns the sum of lags of running readers implementing LagReporter.
+func (s *parallelSource) Lag(ctx *Context) (int64, error) {
+	s.m.Lock()
+	readers := append([]*partitionReader{}, s.readers...)
+	s.m.Unlock()
+
+	var lag int64
+	for _, r := range readers {
+		if l, ok := r.source.(LagReporter); ok {
+			v, err := l.Lag(ctx)
+			if err != nil {
+				return 0, err
+			}
+			lag += v
+		}
+	}
+	return lag, nil
+}
+
+// serialWriter serializes writes from readers running concurrently.
+type serialWriter struct {
+	m sync.Mutex
+	w Writer
+}
+
+func (w *serialWriter) Write(ctx *Context, t *Tuple) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.w.Write(ctx, t)
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryPartitionedSource has partitions each of which has n records. When
+// follow is true, readers keep running after reading all records until
+// they're stopped like readers of a message broker.
+type memoryPartitionedSource struct {
+	partitions []string
+	n          int
+	follow     bool
+
+	m         sync.Mutex
+	positions map[string]int
+}
+
+func newMemoryPartitionedSource(n int, follow bool, partitions ...string) *memoryPartitionedSource {
+	return &memoryPartitionedSource{
+		partitions: partitions,
+		n:          n,
+		follow:     follow,
+		positions:  map[string]int{},
+	}
+}
+
+func (s *memoryPartitionedSource) Partitions(ctx *Context) ([]string, error) {
+	return s.partitions, nil
+}
+
+func (s *memoryPartitionedSource) NewReader(ctx *Context, partitions []string) (Source, error) {
+	return &memoryPartitionReader{
+		s:          s,
+		partitions: partitions,
+		stopCh:     make(chan struct{}),
+	}, nil
+}
+
+type memoryPartitionReader struct {
+	s          *memoryPartitionedSource
+	partitions []string
+	stopCh     chan struct{}
+}
+
+func (r *memoryPartitionReader) GenerateStream(ctx *Context, w Writer) error {
+	for _, p := range r.partitions {
+		for {
+			select {
+			case <-r.stopCh:
+				return nil
+			default:
+			}
+
+			r.s.m.Lock()
+			pos := r.s.positions[p]
+			if pos >= r.s.n {
+				r.s.m.Unlock()
+				break
+			}
+			r.s.positions[p] = pos + 1
+			r.s.m.Unlock()
+
+			if err := w.Write(ctx, NewTuple(data.Map{
+				"partition": data.String(p),
+				"pos":       data.Int(pos),
+			})); err != nil {
+				return err
+			}
+		}
+	}
+	if r.s.follow {
+		<-r.stopCh
+	}
+	return nil
+}
+
+func (r *memoryPartitionReader) Stop(ctx *Context) error {
+	close(r.stopCh)
+	return nil
+}
+
+func (r *memoryPartitionReader) Lag(ctx *Context) (int64, error) {
+	r.s.m.Lock()
+	defer r.s.m.Unlock()
+	var lag int64
+	for _, p := range r.partitions {
+		lag += int64(r.s.n - r.s.positions[p])
+	}
+	return lag, nil
+}
+
+func TestParallelSource(t *testing.T) {
+	Convey("Given a topology", t, func() {
+		tp, err := NewDefaultTopology(NewContext(nil), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+		si := NewTupleCollectorSink()
+		sin, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		addSource := func(ps PartitionedSource, readers int) (SourceNode, ParallelSource) {
+			s, err := NewParallelSource(ps, readers)
+			So(err, ShouldBeNil)
+			son, err := tp.AddSource("source", s, &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+			return son, s
+		}
+		partitionReaders := func(s ParallelSource) data.Array {
+			a, err := data.AsArray(s.Status()["partition_readers"])
+			So(err, ShouldBeNil)
+			return a
+		}
+		waitReaders := func(s ParallelSource, n int) data.Array {
+			for i := 0; i < 1000; i++ {
+				if rs := partitionReaders(s); len(rs) == n {
+					return rs
+				}
+				time.Sleep(time.Millisecond)
+			}
+			return partitionReaders(s)
+		}
+
+		Convey("When running a parallel source of finite partitions", func() {
+			son, _ := addSource(newMemoryPartitionedSource(3, false, "p0", "p1", "p2", "p3"), 2)
+
+			Convey("Then all records should be written", func() {
+				son.State().Wait(TSStopped)
+				si.Wait(12)
+				So(si.len(), ShouldEqual, 12)
+			})
+		})
+
+		Convey("When running a parallel source of partitions being followed", func() {
+			ps := newMemoryPartitionedSource(3, true, "p0", "p1", "p2", "p3")
+			son, s := addSource(ps, 2)
+			si.Wait(12)
+
+			Convey("Then partitions should be assigned in round robin", func() {
+				rs := waitReaders(s, 2)
+				So(rs, ShouldHaveLength, 2)
+				So(rs[0], ShouldResemble, data.Map{
+					"partitions": data.Array{data.String("p0"), data.String("p2")},
+					"lag":        data.Int(0),
+				})
+				So(rs[1].(data.Map)["partitions"], ShouldResemble, data.Array{data.String("p1"), data.String("p3")})
+				So(s.Status()["readers"], ShouldEqual, data.Int(2))
+			})
+
+			Convey("Then it should be rescaled at runtime", func() {
+				So(s.Rescale(3), ShouldBeNil)
+				So(s.Readers(), ShouldEqual, 3)
+				So(waitReaders(s, 3), ShouldHaveLength, 3)
+
+				Convey("And records shouldn't be read again", func() {
+					ps.m.Lock()
+					ps.n = 4
+					ps.m.Unlock()
+					So(s.Rescale(4), ShouldBeNil)
+					si.Wait(16)
+					So(waitReaders(s, 4), ShouldHaveLength, 4)
+					So(si.len(), ShouldEqual, 16)
+				})
+			})
+
+			Convey("Then it should be rescaled by updating the parameter", func() {
+				So(son.Update(data.Map{"readers": data.Int(4)}), ShouldBeNil)
+				So(waitReaders(s, 4), ShouldHaveLength, 4)
+			})
+
+			Convey("Then it should reject invalid parameters", func() {
+				So(son.Update(data.Map{"readers": data.Int(0)}), ShouldNotBeNil)
+				So(son.Update(data.Map{"readers": data.String("a")}), ShouldNotBeNil)
+				So(son.Update(data.Map{"readers": data.Int(2), "a": data.Int(1)}), ShouldNotBeNil)
+				So(s.Readers(), ShouldEqual, 2)
+			})
+
+			Convey("Then it should stop", func() {
+				So(son.Stop(), ShouldBeNil)
+				So(son.State().Get(), ShouldEqual, TSStopped)
+				So(partitionReaders(s), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When running a parallel source having more readers than partitions", func() {
+			_, s := addSource(newMemoryPartitionedSource(1, true, "p0", "p1"), 3)
+			si.Wait(2)
+
+			Convey("Then only readers having partitions should run", func() {
+				So(waitReaders(s, 2), ShouldHaveLength, 2)
+				So(s.Readers(), ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a partitioned source", t, func() {
+		ps := newMemoryPartitionedSource(1, false, "p0")
+
+		Convey("When creating a parallel source without readers", func() {
+			_, err := NewParallelSource(ps, 0)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
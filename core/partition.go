@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// PartitionConfig has parameters of hash partitioning on an input of a node.
+// Tuples written to the input are assigned to one of Count partitions by the
+// hash value of their keys, and only tuples assigned to the partition Index
+// are sent through the input.
+//
+// When Count nodes have inputs from the same node with the same Key and
+// different Index, each tuple is sent to exactly one of them and tuples
+// having the same key are always sent to the same node. This allows stateful
+// processing such as aggregation to be distributed over multiple instances of
+// a box. The mapping from keys to partitions is computed by PartitionOf and
+// doesn't depend on the process, so it's also consistent among processes.
+type PartitionConfig struct {
+	// Key computes the partition key of a tuple. A tuple for which Key
+	// returns an error is treated as having a null key.
+	Key func(t *Tuple) (data.Value, error)
+
+	// Count is the number of partitions. It must be positive.
+	Count int
+
+	// Index is the index of the partition sent through the input. It must be
+	// in [0, Count).
+	Index int
+}
+
+// Validate validates values of PartitionConfig.
+func (c *PartitionConfig) Validate() error {
+	if c.Key == nil {
+		return errors.New("the partition key must be specified")
+	}
+	if c.Count <= 0 {
+		return fmt.Errorf("the number of partitions must be positive: %v", c.Count)
+	}
+	if c.Index < 0 || c.Index >= c.Count {
+		return fmt.Errorf("the partition index must be in [0, %v): %v", c.Count, c.Index)
+	}
+	return nil
+}
+
+// Partition returns the index of the partition to which the tuple is
+// assigned.
+func (c *PartitionConfig) Partition(t *Tuple) int {
+	k, err := c.Key(t)
+	if err != nil {
+		k = data.Null{}
+	}
+	return PartitionOf(k, c.Count)
+}
+
+// includes returns true when the tuple is assigned to the partition Index.
+func (c *PartitionConfig) includes(t *Tuple) bool {
+	return c.Partition(t) == c.Index
+}
+
+// PartitionOf returns the index of the partition to which the key is assigned
+// when there're count partitions. Keys which are equal in terms of data.Equal
+// are always assigned to the same partition.
+func PartitionOf(key data.Value, count int) int {
+	return int(uint64(data.Hash(key)) % uint64(count))
+}
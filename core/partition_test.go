@@ -0,0 +1,146 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func seqKey(t *Tuple) (data.Value, error) {
+	return t.Data.Get(data.MustCompilePath("seq"))
+}
+
+func TestPartitionConfig(t *testing.T) {
+	Convey("Given a partition config", t, func() {
+		c := &PartitionConfig{
+			Key:   seqKey,
+			Count: 3,
+			Index: 1,
+		}
+
+		Convey("When validating it", func() {
+			Convey("Then it should succeed", func() {
+				So(c.Validate(), ShouldBeNil)
+			})
+		})
+
+		Convey("When the key isn't given", func() {
+			c.Key = nil
+
+			Convey("Then the validation should fail", func() {
+				So(c.Validate(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the count isn't positive", func() {
+			c.Count = 0
+
+			Convey("Then the validation should fail", func() {
+				So(c.Validate(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the index is out of range", func() {
+			c.Index = 3
+
+			Convey("Then the validation should fail", func() {
+				So(c.Validate(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When computing partitions of tuples", func() {
+			Convey("Then equal keys should have the same partition", func() {
+				t1 := &Tuple{Data: data.Map{"seq": data.Int(2)}}
+				t2 := &Tuple{Data: data.Map{"seq": data.Float(2)}}
+				So(c.Partition(t1), ShouldEqual, c.Partition(t2))
+				So(c.Partition(t1), ShouldEqual, PartitionOf(data.Int(2), 3))
+			})
+
+			Convey("Then a tuple without the key should have the partition of null", func() {
+				t := &Tuple{Data: data.Map{}}
+				So(c.Partition(t), ShouldEqual, PartitionOf(data.Null{}, 3))
+			})
+		})
+
+		Convey("When the key function fails", func() {
+			c.Key = func(t *Tuple) (data.Value, error) {
+				return nil, errors.New("failure")
+			}
+
+			Convey("Then the tuple should have the partition of null", func() {
+				So(c.Partition(&Tuple{}), ShouldEqual, PartitionOf(data.Null{}, 3))
+			})
+		})
+	})
+}
+
+func TestPartitionedInputs(t *testing.T) {
+	Convey("Given a topology with a source", t, func() {
+		t, err := NewDefaultTopology(NewContext(nil), "partition_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		fts := freshTuples()
+		son, err := t.AddSource("source", NewTupleEmitterSource(fts), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When connecting sinks with partitioned inputs", func() {
+			const n = 3
+			sinks := make([]*TupleCollectorSink, n)
+			for i := range sinks {
+				sinks[i] = NewTupleCollectorSink()
+				sin, err := t.AddSink(fmt.Sprint("sink", i), sinks[i], nil)
+				So(err, ShouldBeNil)
+				So(sin.Input("source", &SinkInputConfig{
+					Partition: &PartitionConfig{
+						Key:   seqKey,
+						Count: n,
+						Index: i,
+					},
+				}), ShouldBeNil)
+			}
+			So(son.Resume(), ShouldBeNil)
+			So(son.State().Wait(TSStopped), ShouldEqual, TSStopped)
+
+			Convey("Then each tuple should be sent to the sink having its partition", func() {
+				total := 0
+				for i, si := range sinks {
+					expected := 0
+					for _, tu := range fts {
+						if PartitionOf(tu.Data["seq"], n) == i {
+							expected++
+						}
+					}
+					si.Wait(expected)
+					So(si.len(), ShouldEqual, expected)
+					si.forEachTuple(func(tu *Tuple) {
+						So(PartitionOf(tu.Data["seq"], n), ShouldEqual, i)
+					})
+					total += si.len()
+				}
+				So(total, ShouldEqual, len(fts))
+			})
+		})
+
+		Convey("When connecting a box with an invalid partition config", func() {
+			bn, err := t.AddBox("box", BoxFunc(forwardBox), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(bn.Input("source", &BoxInputConfig{
+					Partition: &PartitionConfig{
+						Key:   seqKey,
+						Count: 2,
+						Index: 2,
+					},
+				}), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"reflect"
+	"runtime"
 	"sync"
 	"sync/atomic"
 )
@@ -24,9 +25,35 @@ func newPipe(inputName string, capacity int) (*pipeReceiver, *pipeSender) {
 	return r, s
 }
 
+// newRingPipe creates a pipe using tupleRing. Its receiver and sender have a
+// channel which is only used to notify the receiver of new tuples.
+func newRingPipe(inputName string, capacity int) (*pipeReceiver, *pipeSender) {
+	r, s := newPipe(inputName, 1)
+	ring := newTupleRing(capacity)
+	r.ring = ring
+	s.ring = ring
+	return r, s
+}
+
+// newPipeWithType creates a pipe of the given type.
+func newPipeWithType(pt PipeType, inputName string, capacity int) (*pipeReceiver, *pipeSender) {
+	if pt == RingPipe {
+		return newRingPipe(inputName, capacity)
+	}
+	return newPipe(inputName, capacity)
+}
+
 type pipeReceiver struct {
 	in     <-chan *Tuple
 	sender *pipeSender
+
+	// ring is non-nil when the pipe is a RingPipe. Tuples are written to
+	// ring and in only receives nil as a notification in that case.
+	ring *tupleRing
+
+	// config is the input config given when the pipe was connected. It's
+	// either *BoxInputConfig or *SinkInputConfig.
+	config interface{}
 }
 
 // close closes the channel from the receiver side. It doesn't directly close
@@ -65,6 +92,36 @@ const (
 	DropOldest
 )
 
+// PipeType is a type of the implementation of a pipe connecting two nodes.
+type PipeType int
+
+const (
+	// ChannelPipe is a pipe implemented with a channel. This is the default
+	// type.
+	ChannelPipe PipeType = iota
+
+	// RingPipe is a pipe implemented with a lock-free single-producer
+	// single-consumer ring buffer. It's faster than ChannelPipe in
+	// latency-critical paths because the receiver is woken up only once for
+	// a batch of tuples. Writers of the pipe are serialized, so it doesn't
+	// scale when the node writing tuples has parallelism greater than 1.
+	// RingPipe doesn't support DropOldest.
+	RingPipe
+)
+
+func validatePipeType(pt PipeType, mode QueueDropMode) error {
+	switch pt {
+	case ChannelPipe:
+	case RingPipe:
+		if mode == DropOldest {
+			return errors.New("a ring pipe doesn't support dropping the oldest tuple")
+		}
+	default:
+		return fmt.Errorf("unknown pipe type: %v", pt)
+	}
+	return nil
+}
+
 // pipeSender represents a pipe sender. An object of this struct must be
 // placed in a global variable or in memory allocated from the heap.
 // Using an array or a slice of pipeSender may cause panic even if it is
@@ -84,6 +141,16 @@ type pipeSender struct {
 	out       chan *Tuple
 	dropMode  QueueDropMode
 
+	// partition is a config of hash partitioning. When it's non-nil, tuples
+	// not assigned to the partition are silently discarded.
+	partition *PartitionConfig
+
+	// ring is non-nil when the pipe is a RingPipe. out is only used to notify
+	// the receiver in that case. wm serializes writers of ring since it only
+	// supports a single producer.
+	ring *tupleRing
+	wm   sync.Mutex
+
 	// rwm protects out from write-close conflicts.
 	rwm sync.RWMutex
 
@@ -109,6 +176,9 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 	if s.closed {
 		return errPipeClosed
 	}
+	if s.partition != nil && !s.partition.includes(in) {
+		return nil
+	}
 
 	t := in
 	if t.Flags.IsSet(TFShared) {
@@ -116,7 +186,14 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 	}
 	t.InputName = s.inputName
 
-	if s.dropMode == DropNone {
+	// The tuple is accounted before it's sent because the receiver can
+	// release it before this method returns.
+	ctx.Resources.acquireTuple(t)
+	if s.ring != nil {
+		if !s.writeRing(ctx, t, droppedTuple) {
+			return nil
+		}
+	} else if s.dropMode == DropNone {
 		s.out <- t
 	} else {
 	sendLoop:
@@ -126,6 +203,7 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 				break sendLoop
 			default:
 				if s.dropMode == DropLatest {
+					ctx.Resources.releaseTuple(t)
 					droppedTuple(t)
 					return nil
 				}
@@ -134,6 +212,7 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 				// again in the next iteration. This loop can cause starvation.
 				select {
 				case dropped := <-s.out:
+					ctx.Resources.releaseTuple(dropped)
 					droppedTuple(dropped)
 				default: // Another thread may drop it before this thread does.
 				}
@@ -144,6 +223,23 @@ func (s *pipeSender) write(ctx *Context, in *Tuple, droppedTuple func(*Tuple)) e
 	return nil
 }
 
+// writeRing writes the tuple to the ring. It returns false when the tuple is
+// dropped. The caller must hold the read lock of s.rwm.
+func (s *pipeSender) writeRing(ctx *Context, t *Tuple, droppedTuple func(*Tuple)) bool {
+	s.wm.Lock()
+	defer s.wm.Unlock()
+	for !s.ring.push(t) {
+		if s.dropMode == DropLatest {
+			ctx.Resources.releaseTuple(t)
+			droppedTuple(t)
+			return false
+		}
+		s.ring.waitForSpace()
+	}
+	s.ring.notify(s.out)
+	return true
+}
+
 // Close closes a channel. When multiple goroutines try to close the channel,
 // only one goroutine can actually close it. Other goroutines don't wait until
 // the channel is actually closed. Close never fails.
@@ -194,6 +290,9 @@ func (s *pipeSender) queueStatus() (int, int) {
 	if s.closed {
 		return 0, 0
 	}
+	if s.ring != nil {
+		return s.ring.len(), s.ring.cap()
+	}
 	return len(s.out), cap(s.out)
 }
 
@@ -210,14 +309,22 @@ func (s *pipeSender) isClosed() bool {
 // Read godoc for dataDestinations or https://github.com/golang/go/issues/9959
 // for details.
 type dataSources struct {
-	// numReceived and numErrors must be here for 64-bit alignment.
-	// See godoc for this struct.
+	// numReceived, numErrors, and numInFlight must be here for 64-bit
+	// alignment. See godoc for this struct.
 	numReceived int64
 	numErrors   int64
 
+	// numInFlight is the number of tuples being written to the node, which
+	// have been received but whose writes haven't finished yet.
+	numInFlight int64
+
 	nodeType NodeType
 	nodeName string
 
+	// lockOSThread makes goroutines pouring tuples lock their OS threads. It
+	// must be set before calling pour.
+	lockOSThread bool
+
 	// m protects state, recvs, and msgChs.
 	m     sync.RWMutex
 	state *topologyStateHolder
@@ -266,7 +373,9 @@ func (s *dataSources) add(name string, r *pipeReceiver) error {
 	// It's safe even if pouringThread panics while executing this method
 	// because pour method will drain all channels in s.recvs. So, the sender
 	// won't block even if pouringThread doesn't receive the new pipe receiver.
-	if _, ok := s.recvs[name]; ok {
+	// A receiver whose sender is already closed hasn't been removed from
+	// s.recvs yet and it can be replaced with the new one.
+	if recv, ok := s.recvs[name]; ok && !recv.sender.isClosed() {
 		return fmt.Errorf("node '%v' is already receiving tuples from '%v'", s.nodeName, name)
 	}
 	s.recvs[name] = r
@@ -331,6 +440,7 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 		logOnce       sync.Once
 		collectInputs sync.Once
 		inputs        []reflect.SelectCase
+		inputRings    []*tupleRing
 		threadErr     error
 	)
 
@@ -348,7 +458,10 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 			}
 		}
 
-		genCases := func(msgCh <-chan *dataSourcesMessage) []reflect.SelectCase {
+		// genCases returns cases for reflect.Select and rings of receivers
+		// corresponding to the cases. A ring is nil when a case isn't a
+		// RingPipe.
+		genCases := func(msgCh <-chan *dataSourcesMessage) ([]reflect.SelectCase, []*tupleRing) {
 			cs := make([]reflect.SelectCase, 0, len(s.recvs)+2)
 			cs = append(cs, reflect.SelectCase{
 				Dir:  reflect.SelectRecv,
@@ -362,13 +475,15 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 				Dir: reflect.SelectRecv,
 			})
 
+			rings := make([]*tupleRing, len(cs), cap(cs))
 			for _, r := range s.recvs {
 				cs = append(cs, reflect.SelectCase{
 					Dir:  reflect.SelectRecv,
 					Chan: reflect.ValueOf(r.in),
 				})
+				rings = append(rings, r.ring)
 			}
-			return cs
+			return cs, rings
 		}
 
 		// ensureLocked ensures proper lock for s. Removing this introduces
@@ -383,16 +498,20 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 			ensureLocked.Add(1)
 			go func() {
 				defer wg.Done()
+				if s.lockOSThread {
+					runtime.LockOSThread()
+					defer runtime.UnlockOSThread()
+				}
 				needDone := true
 				defer func() {
 					if needDone {
 						ensureLocked.Done()
 					}
 				}()
-				cs := genCases(msgCh)
+				cs, rings := genCases(msgCh)
 				ensureLocked.Done()
 				needDone = false
-				ins, err := s.pouringThread(ctx, w, cs)
+				ins, insRings, err := s.pouringThread(ctx, w, cs, rings)
 				collectInputs.Do(func() {
 					// It's sufficient to collect input only once. The only
 					// problem which might happen is that ins has old receivers.
@@ -412,11 +531,12 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 					// In conclusion, by combining s.recvs and inputs, all
 					// inputs can be drained and no sender will be blocked.
 					inputs = ins
+					inputRings = insRings
 				})
 				if err != nil {
 					logOnce.Do(func() {
 						threadErr = err // return only one error
-						ctx.ErrLog(err).WithFields(nodeLogFields(s.nodeType, s.nodeName)).
+						ctx.nodeErrLog(s.nodeType, s.nodeName, err).
 							Error("the node stopped with a fatal error")
 					})
 				}
@@ -440,25 +560,47 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 
 	drainTargets := make([]reflect.SelectCase, 0, len(s.recvs)+len(inputs))
 	drainTargets = append(drainTargets, inputs...)
+	drainRings := make([]*tupleRing, 0, cap(drainTargets))
+	drainRings = append(drainRings, inputRings...)
 	for _, r := range s.recvs {
 		drainTargets = append(drainTargets, reflect.SelectCase{
 			Dir:  reflect.SelectRecv,
 			Chan: reflect.ValueOf(r.in),
 		})
+		drainRings = append(drainRings, r.ring)
 		r.close()
 	}
 	s.recvs = nil
 
 	go func() {
+		release := func(t *Tuple) error {
+			ctx.Resources.releaseTuple(t)
+			return nil
+		}
+
+		// A ring can have tuples without a pending notification when
+		// pouringThread stopped in the middle of reading it.
+		for _, r := range drainRings {
+			if r != nil {
+				r.drain(release)
+			}
+		}
+
 		// drainTargets might have duplicated channels but it doesn't cause
 		// a problem.
 		for len(drainTargets) != 0 {
-			i, _, ok := reflect.Select(drainTargets)
+			i, v, ok := reflect.Select(drainTargets)
+			if r := drainRings[i]; r != nil {
+				r.drain(release)
+			} else if ok {
+				releaseDrainedTuple(ctx, v)
+			}
 			if ok {
 				continue
 			}
-			drainTargets[i] = drainTargets[len(drainTargets)-1]
-			drainTargets = drainTargets[:len(drainTargets)-1]
+			last := len(drainTargets) - 1
+			drainTargets[i], drainRings[i] = drainTargets[last], drainRings[last]
+			drainTargets, drainRings = drainTargets[:last], drainRings[:last]
 		}
 	}()
 
@@ -469,7 +611,9 @@ func (s *dataSources) pour(ctx *Context, w Writer, parallelism int) error {
 	return threadErr
 }
 
-func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectCase) (inputs []reflect.SelectCase, retErr error) {
+// pouringThread reads tuples from cs and writes them to w. rings has the
+// tupleRing of each case in cs, or nil when the case isn't a RingPipe.
+func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectCase, rings []*tupleRing) (inputs []reflect.SelectCase, inputRings []*tupleRing, retErr error) {
 	const (
 		message = iota
 		defaultCase
@@ -495,6 +639,7 @@ func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectC
 			// Return all inputs this method still has. pour method will read
 			// tuples from those input channels so that senders don't block.
 			inputs = cs[maxControlIndex+1:]
+			inputRings = rings[maxControlIndex+1:]
 		}
 
 		// drain channels specific to pouringThread
@@ -518,6 +663,36 @@ func (s *dataSources) pouringThread(ctx *Context, w Writer, cs []reflect.SelectC
 		ctx.droppedTuple(t, s.nodeType, s.nodeName, ETInput, err)
 	}
 
+	// process writes a received tuple to w. It only returns fatal errors.
+	process := func(t *Tuple) error {
+		atomic.AddInt64(&s.numInFlight, 1)
+		defer atomic.AddInt64(&s.numInFlight, -1)
+		atomic.AddInt64(&s.numReceived, 1)
+		ctx.Resources.releaseTuple(t)
+
+		err := w.Write(ctx, t)
+		if err == nil {
+			return nil
+		}
+
+		switch {
+		case IsFatalError(err):
+			atomic.AddInt64(&s.numErrors, 1)
+			reportDT(t, err)
+			return err
+
+		case IsTemporaryError(err):
+			atomic.AddInt64(&s.numErrors, 1)
+			// TODO: retry
+			reportDT(t, err) // TODO: don't write a tuple until retry fails
+
+		default:
+			// Skip this tuple
+			reportDT(t, err)
+		}
+		return nil
+	}
+
 receiveLoop:
 	for {
 		if stopOnDisconnect && len(cs) == maxControlIndex+1 {
@@ -534,9 +709,21 @@ receiveLoop:
 				return
 			}
 
+			if r := rings[i]; r != nil {
+				// The ring may still have tuples written before the pipe
+				// was closed.
+				if err := r.drain(process); err != nil {
+					// logging is done by pour method
+					retErr = err
+					return
+				}
+			}
+
 			// remove the closed channel by swapping it with the last element.
-			cs[i], cs[len(cs)-1] = cs[len(cs)-1], cs[i]
-			cs = cs[:len(cs)-1]
+			last := len(cs) - 1
+			cs[i], cs[last] = cs[last], cs[i]
+			rings[i], rings[last] = rings[last], rings[i]
+			cs, rings = cs[:last], rings[:last]
 			continue
 		}
 
@@ -544,7 +731,7 @@ receiveLoop:
 		case message:
 			msg, ok := v.Interface().(*dataSourcesMessage)
 			if !ok {
-				ctx.Log().WithFields(nodeLogFields(s.nodeType, s.nodeName)).
+				ctx.nodeLog(s.nodeType, s.nodeName).
 					Warnf("Received an invalid control message in dataSources: %v", v.Interface())
 				continue
 			}
@@ -553,7 +740,7 @@ receiveLoop:
 			case ddscAddReceiver:
 				c, ok := msg.v.(*pipeReceiver)
 				if !ok {
-					ctx.Log().WithFields(nodeLogFields(s.nodeType, s.nodeName)).
+					ctx.nodeLog(s.nodeType, s.nodeName).
 						Warn("Cannot add a new receiver due to a type error")
 					break
 				}
@@ -561,6 +748,7 @@ receiveLoop:
 					Dir:  reflect.SelectRecv,
 					Chan: reflect.ValueOf(c.in),
 				})
+				rings = append(rings, c.ring)
 
 			case ddscStop:
 				if !gracefulStopEnabled {
@@ -580,42 +768,42 @@ receiveLoop:
 			break receiveLoop
 
 		default:
-			atomic.AddInt64(&s.numReceived, 1)
+			if r := rings[i]; r != nil {
+				// A notification was sent, so read all tuples in the ring.
+				if err := r.drain(process); err != nil {
+					// logging is done by pour method
+					retErr = err
+					return
+				}
+				break
+			}
+
 			t, ok := v.Interface().(*Tuple)
 			if !ok {
+				atomic.AddInt64(&s.numReceived, 1)
 				atomic.AddInt64(&s.numErrors, 1)
-				ctx.Log().WithFields(nodeLogFields(s.nodeType, s.nodeName)).
+				ctx.nodeLog(s.nodeType, s.nodeName).
 					Error("Cannot receive a tuple from a receiver due to a type error")
 				break
 			}
-
-			err := w.Write(ctx, t)
-			if err == nil {
-				break
-			}
-
-			switch {
-			case IsFatalError(err):
-				atomic.AddInt64(&s.numErrors, 1)
+			if err := process(t); err != nil {
 				// logging is done by pour method
 				retErr = err
-				reportDT(t, err)
 				return
-
-			case IsTemporaryError(err):
-				atomic.AddInt64(&s.numErrors, 1)
-				// TODO: retry
-				reportDT(t, err) // TODO: don't write a tuple until retry fails
-
-			default:
-				// Skip this tuple
-				reportDT(t, err)
 			}
 		}
 	}
 	return // return values will be set by the deferred function.
 }
 
+// releaseDrainedTuple releases resources of a tuple which was drained from
+// an input channel without being processed.
+func releaseDrainedTuple(ctx *Context, v reflect.Value) {
+	if t, ok := v.Interface().(*Tuple); ok {
+		ctx.Resources.releaseTuple(t)
+	}
+}
+
 // enableGracefulStop enables graceful stop mode. If the mode is enabled, the
 // source automatically stops when it doesn't receive any input after stop is
 // called.
@@ -656,6 +844,39 @@ func (s *dataSources) stop(ctx *Context) {
 	s.state.waitWithoutLock(TSStopped)
 }
 
+// inputConfigs returns configs of inputs which are currently connected. Keys
+// of the returned map are names of input nodes.
+func (s *dataSources) inputConfigs() map[string]interface{} {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	m := make(map[string]interface{}, len(s.recvs))
+	for name, recv := range s.recvs {
+		if recv.sender.isClosed() {
+			continue
+		}
+		m[name] = recv.config
+	}
+	return m
+}
+
+// queueDepth returns the ratio of the number of tuples queued in all inputs
+// to their total capacity.
+func (s *dataSources) queueDepth() float64 {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	var queued, capacity int
+	for _, recv := range s.recvs {
+		l, c := recv.sender.queueStatus()
+		queued += l
+		capacity += c
+	}
+	if capacity == 0 {
+		return 0
+	}
+	return float64(queued) / float64(capacity)
+}
+
 func (s *dataSources) status() data.Map {
 	// mutex of the dataSources doesn't block reading tuples from a channel.
 	s.m.Lock()
@@ -664,6 +885,7 @@ func (s *dataSources) status() data.Map {
 	st := data.Map{}
 	st["num_received_total"] = data.Int(atomic.LoadInt64(&s.numReceived))
 	st["num_errors"] = data.Int(atomic.LoadInt64(&s.numErrors))
+	st["num_in_flight"] = data.Int(atomic.LoadInt64(&s.numInFlight))
 	// TODO: Add num_temporary_errors and num_retries.
 
 	m := make(data.Map, len(s.recvs))
@@ -710,6 +932,10 @@ type dataDestinations struct {
 	dsts     map[string]*pipeSender
 	paused   bool
 
+	// closed is set when Close is called. It's used to cancel waiting for
+	// resources to be released.
+	closed AtomicFlag
+
 	callback func(ddEvent)
 }
 
@@ -787,6 +1013,12 @@ func (d *dataDestinations) len() int {
 // Write writes tuples to destinations. It doesn't return any error including
 // errPipeClosed.
 func (d *dataDestinations) Write(ctx *Context, t *Tuple) error {
+	if d.nodeType == NTSource && ctx.Resources.Enabled() {
+		// Only sources apply backpressure when the topology runs out of
+		// resources. See ResourceController for details.
+		ctx.Resources.wait(d.closed.Enabled)
+	}
+
 	d.rwm.RLock()
 	shouldUnlock := true
 	defer func() {
@@ -832,10 +1064,16 @@ func (d *dataDestinations) Write(ctx *Context, t *Tuple) error {
 		t.Flags.Set(TFShared)
 	}
 	var closed []string
+	tapped := ctx.Taps.active()
 	for name, dst := range d.dsts {
 		// TODO: recovering from panic here instead of using RWLock in
 		// pipeSender might be faster.
 
+		if tapped {
+			// Taps record copies before the tuple is written because the
+			// destination can modify it.
+			ctx.Taps.record(d.nodeName, name, t)
+		}
 		if err := dst.write(ctx, t, reportFunc); err != nil { // never panics
 			// err is always errPipeClosed when it isn't nil.
 			// Because the closed destination doesn't do anything harmful,
@@ -892,6 +1130,8 @@ func (d *dataDestinations) Close(ctx *Context) error {
 	}
 	d.dsts = nil
 	d.setPaused(false)
+	d.closed.Set(true)
+	ctx.Resources.wakeUp()
 	return nil
 }
 
@@ -0,0 +1,525 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"runtime/pprof"
+	"sort"
+	"time"
+)
+
+// Goroutines running nodes have profiler labels having the following keys.
+// Goroutines created by nodes, such as ones created by a Source in
+// GenerateStream, inherit the labels. Samples of CPU and goroutine profiles
+// can be filtered with them, e.g.
+// `go tool pprof -tagfocus sensorbee_node=some_stream`.
+const (
+	// ProfileLabelTopology is the key of the label having the name of the
+	// topology.
+	ProfileLabelTopology = "sensorbee_topology"
+
+	// ProfileLabelNodeType is the key of the label having the type of the
+	// node, i.e. "source", "box", or "sink".
+	ProfileLabelNodeType = "sensorbee_node_type"
+
+	// ProfileLabelNode is the key of the label having the name of the node.
+	ProfileLabelNode = "sensorbee_node"
+)
+
+// setNodeProfileLabels sets profiler labels of the node to the current
+// goroutine. It must be called from a goroutine dedicated to the node.
+func setNodeProfileLabels(topology string, nodeType NodeType, name string) {
+	pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), pprof.Labels(
+		ProfileLabelTopology, topology,
+		ProfileLabelNodeType, nodeType.String(),
+		ProfileLabelNode, name,
+	)))
+}
+
+// NodeProfile has resource usage of a node attributed from profiles.
+type NodeProfile struct {
+	Topology string
+	NodeType NodeType
+	NodeName string
+
+	// CPUTime is the CPU time used by goroutines of the node.
+	CPUTime time.Duration
+
+	// AllocCPUTime is the part of CPUTime spent in allocating memory. The
+	// runtime doesn't record labels in memory profiles, so this is the
+	// measure of allocations attributable to nodes.
+	AllocCPUTime time.Duration
+
+	// Goroutines is the number of goroutines of the node at the end of
+	// profiling.
+	Goroutines int
+}
+
+// NodeProfileReport is a result of ProfileNodes.
+type NodeProfileReport struct {
+	// Duration is the actual duration of profiling.
+	Duration time.Duration
+
+	// TotalCPUTime is the CPU time used by the process during profiling.
+	TotalCPUTime time.Duration
+
+	// UnattributedCPUTime is the part of TotalCPUTime which isn't
+	// attributed to any node, such as CPU time used by the API server or GC.
+	UnattributedCPUTime time.Duration
+
+	// Nodes has profiles of nodes in descending order of CPUTime. It has
+	// nodes which were running at the end of profiling or used CPU during
+	// profiling.
+	Nodes []*NodeProfile
+}
+
+// ProfileNodes collects a CPU profile for the duration and attributes
+// samples to nodes of all topologies in the process. It fails when another
+// CPU profile is being collected or cancel is closed.
+func ProfileNodes(d time.Duration, cancel <-chan struct{}) (*NodeProfileReport, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := pprof.StartCPUProfile(buf); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	select {
+	case <-time.After(d):
+	case <-cancel:
+		pprof.StopCPUProfile()
+		return nil, errors.New("profiling was canceled")
+	}
+	pprof.StopCPUProfile()
+	elapsed := time.Since(start)
+
+	cpu, err := parseProfile(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the CPU profile: %v", err)
+	}
+
+	buf = bytes.NewBuffer(nil)
+	if err := pprof.Lookup("goroutine").WriteTo(buf, 0); err != nil {
+		return nil, err
+	}
+	gr, err := parseProfile(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the goroutine profile: %v", err)
+	}
+	return newNodeProfileReport(elapsed, cpu, gr)
+}
+
+type nodeProfileKey struct {
+	topology string
+	nodeType NodeType
+	name     string
+}
+
+func newNodeProfileReport(d time.Duration, cpu, gr *profileData) (*NodeProfileReport, error) {
+	r := &NodeProfileReport{
+		Duration: d,
+	}
+	nodes := map[nodeProfileKey]*NodeProfile{}
+	node := func(p *profileData, s *profileSample) *NodeProfile {
+		k, ok := p.nodeKey(s)
+		if !ok {
+			return nil
+		}
+		n, ok := nodes[k]
+		if !ok {
+			n = &NodeProfile{
+				Topology: k.topology,
+				NodeType: k.nodeType,
+				NodeName: k.name,
+			}
+			nodes[k] = n
+		}
+		return n
+	}
+
+	cpuIdx := cpu.valueIndex("cpu")
+	if cpuIdx < 0 {
+		return nil, errors.New("the CPU profile doesn't have cpu samples")
+	}
+	malloc := cpu.functionIDs("runtime.mallocgc")
+	for i := range cpu.samples {
+		s := &cpu.samples[i]
+		if cpuIdx >= len(s.values) {
+			continue
+		}
+		v := time.Duration(s.values[cpuIdx])
+		r.TotalCPUTime += v
+		n := node(cpu, s)
+		if n == nil {
+			r.UnattributedCPUTime += v
+			continue
+		}
+		n.CPUTime += v
+		if cpu.calls(s, malloc) {
+			n.AllocCPUTime += v
+		}
+	}
+
+	grIdx := gr.valueIndex("goroutine")
+	if grIdx < 0 {
+		return nil, errors.New("the goroutine profile doesn't have goroutine samples")
+	}
+	for i := range gr.samples {
+		s := &gr.samples[i]
+		if grIdx >= len(s.values) {
+			continue
+		}
+		if n := node(gr, s); n != nil {
+			n.Goroutines += int(s.values[grIdx])
+		}
+	}
+
+	r.Nodes = make([]*NodeProfile, 0, len(nodes))
+	for _, n := range nodes {
+		r.Nodes = append(r.Nodes, n)
+	}
+	sort.Sort(nodeProfilesByCPUTime(r.Nodes))
+	return r, nil
+}
+
+type nodeProfilesByCPUTime []*NodeProfile
+
+func (s nodeProfilesByCPUTime) Len() int {
+	return len(s)
+}
+
+func (s nodeProfilesByCPUTime) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	if a.CPUTime != b.CPUTime {
+		return a.CPUTime > b.CPUTime
+	}
+	if a.Topology != b.Topology {
+		return a.Topology < b.Topology
+	}
+	if a.NodeType != b.NodeType {
+		return a.NodeType < b.NodeType
+	}
+	return a.NodeName < b.NodeName
+}
+
+func (s nodeProfilesByCPUTime) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// profileData has the part of a profile in the format of profile.proto
+// written by runtime/pprof which is necessary to attribute samples to nodes.
+// Strings are kept as indices of the string table.
+type profileData struct {
+	sampleTypes []int64
+	samples     []profileSample
+
+	// locations has IDs of functions of each location including inlined
+	// ones.
+	locations map[uint64][]uint64
+	functions map[uint64]int64
+	strings   []string
+}
+
+type profileSample struct {
+	locations []uint64
+	values    []int64
+	labels    [][2]int64 // pairs of a key and a value
+}
+
+func (p *profileData) str(i int64) string {
+	if i < 0 || i >= int64(len(p.strings)) {
+		return ""
+	}
+	return p.strings[i]
+}
+
+// valueIndex returns the index of the sample type in values of samples. It
+// returns -1 when the profile doesn't have the type.
+func (p *profileData) valueIndex(typ string) int {
+	for i, t := range p.sampleTypes {
+		if p.str(t) == typ {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *profileData) functionIDs(name string) map[uint64]bool {
+	ids := map[uint64]bool{}
+	for id, n := range p.functions {
+		if p.str(n) == name {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// calls returns true when the stack of the sample has one of functions.
+func (p *profileData) calls(s *profileSample, funcs map[uint64]bool) bool {
+	if len(funcs) == 0 {
+		return false
+	}
+	for _, l := range s.locations {
+		for _, f := range p.locations[l] {
+			if funcs[f] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *profileData) nodeKey(s *profileSample) (nodeProfileKey, bool) {
+	var (
+		k                                 nodeProfileKey
+		hasTopology, hasNode, hasNodeType bool
+	)
+	for _, l := range s.labels {
+		v := p.str(l[1])
+		switch p.str(l[0]) {
+		case ProfileLabelTopology:
+			k.topology, hasTopology = v, true
+		case ProfileLabelNode:
+			k.name, hasNode = v, true
+		case ProfileLabelNodeType:
+			for _, t := range []NodeType{NTSource, NTBox, NTSink} {
+				if t.String() == v {
+					k.nodeType, hasNodeType = t, true
+				}
+			}
+		}
+	}
+	return k, hasTopology && hasNode && hasNodeType
+}
+
+// parseProfile parses a gzipped profile written by runtime/pprof.
+func parseProfile(b []byte) (*profileData, error) {
+	if len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if b, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &profileData{
+		locations: map[uint64][]uint64{},
+		functions: map[uint64]int64{},
+	}
+	r := &protoReader{b: b}
+	for {
+		field, wire, ok := r.next()
+		if !ok {
+			break
+		}
+		switch {
+		case field == 1 && wire == protoBytes: // sample_type
+			m := &protoReader{b: r.bytes()}
+			var typ int64
+			for f, w, ok := m.next(); ok; f, w, ok = m.next() {
+				if f == 1 && w == protoVarint {
+					typ = int64(m.varint())
+				} else {
+					m.skip(w)
+				}
+			}
+			r.setErr(m.err)
+			p.sampleTypes = append(p.sampleTypes, typ)
+
+		case field == 2 && wire == protoBytes: // sample
+			s, err := parseProfileSample(r.bytes())
+			if err != nil {
+				return nil, err
+			}
+			p.samples = append(p.samples, s)
+
+		case field == 4 && wire == protoBytes: // location
+			m := &protoReader{b: r.bytes()}
+			var (
+				id    uint64
+				funcs []uint64
+			)
+			for f, w, ok := m.next(); ok; f, w, ok = m.next() {
+				switch {
+				case f == 1 && w == protoVarint:
+					id = m.varint()
+				case f == 4 && w == protoBytes: // line
+					l := &protoReader{b: m.bytes()}
+					for lf, lw, ok := l.next(); ok; lf, lw, ok = l.next() {
+						if lf == 1 && lw == protoVarint {
+							funcs = append(funcs, l.varint())
+						} else {
+							l.skip(lw)
+						}
+					}
+					m.setErr(l.err)
+				default:
+					m.skip(w)
+				}
+			}
+			r.setErr(m.err)
+			p.locations[id] = funcs
+
+		case field == 5 && wire == protoBytes: // function
+			m := &protoReader{b: r.bytes()}
+			var id uint64
+			var name int64
+			for f, w, ok := m.next(); ok; f, w, ok = m.next() {
+				switch {
+				case f == 1 && w == protoVarint:
+					id = m.varint()
+				case f == 2 && w == protoVarint:
+					name = int64(m.varint())
+				default:
+					m.skip(w)
+				}
+			}
+			r.setErr(m.err)
+			p.functions[id] = name
+
+		case field == 6 && wire == protoBytes: // string_table
+			p.strings = append(p.strings, string(r.bytes()))
+
+		default:
+			r.skip(wire)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return p, nil
+}
+
+func parseProfileSample(b []byte) (profileSample, error) {
+	var s profileSample
+	r := &protoReader{b: b}
+	for field, wire, ok := r.next(); ok; field, wire, ok = r.next() {
+		switch field {
+		case 1: // location_id
+			s.locations = r.uvarints(wire, s.locations)
+		case 2: // value
+			for _, v := range r.uvarints(wire, nil) {
+				s.values = append(s.values, int64(v))
+			}
+		case 3: // label
+			if wire != protoBytes {
+				r.skip(wire)
+				break
+			}
+			m := &protoReader{b: r.bytes()}
+			var l [2]int64
+			for f, w, ok := m.next(); ok; f, w, ok = m.next() {
+				if (f == 1 || f == 2) && w == protoVarint {
+					l[f-1] = int64(m.varint())
+				} else {
+					m.skip(w)
+				}
+			}
+			r.setErr(m.err)
+			s.labels = append(s.labels, l)
+		default:
+			r.skip(wire)
+		}
+	}
+	return s, r.err
+}
+
+// Wire types of protocol buffers.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+var errBrokenProfile = errors.New("the profile is broken")
+
+// protoReader reads protocol buffers messages. Once it encounters an error,
+// all subsequent reads return zero values and next returns false.
+type protoReader struct {
+	b   []byte
+	err error
+}
+
+func (r *protoReader) setErr(err error) {
+	if err != nil && r.err == nil {
+		r.err = err
+		r.b = nil
+	}
+}
+
+// next reads the key of the next field.
+func (r *protoReader) next() (field int, wire int, ok bool) {
+	if r.err != nil || len(r.b) == 0 {
+		return 0, 0, false
+	}
+	k := r.varint()
+	if r.err != nil {
+		return 0, 0, false
+	}
+	return int(k >> 3), int(k & 7), true
+}
+
+func (r *protoReader) varint() uint64 {
+	x, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.setErr(errBrokenProfile)
+		return 0
+	}
+	r.b = r.b[n:]
+	return x
+}
+
+func (r *protoReader) bytes() []byte {
+	n := r.varint()
+	if n > uint64(len(r.b)) {
+		r.setErr(errBrokenProfile)
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+// uvarints reads a repeated varint field which may or may not be packed.
+func (r *protoReader) uvarints(wire int, dst []uint64) []uint64 {
+	switch wire {
+	case protoVarint:
+		return append(dst, r.varint())
+	case protoBytes:
+		m := &protoReader{b: r.bytes()}
+		for len(m.b) > 0 && m.err == nil {
+			dst = append(dst, m.varint())
+		}
+		r.setErr(m.err)
+		return dst
+	}
+	r.skip(wire)
+	return dst
+}
+
+func (r *protoReader) skip(wire int) {
+	switch wire {
+	case protoVarint:
+		r.varint()
+	case protoFixed64, protoFixed32:
+		n := 8
+		if wire == protoFixed32 {
+			n = 4
+		}
+		if n > len(r.b) {
+			r.setErr(errBrokenProfile)
+			return
+		}
+		r.b = r.b[n:]
+	case protoBytes:
+		r.bytes()
+	default:
+		r.setErr(errBrokenProfile)
+	}
+}
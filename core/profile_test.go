@@ -0,0 +1,126 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+// findNodeProfile returns the profile of the node or nil if the report
+// doesn't have it.
+func findNodeProfile(r *NodeProfileReport, topology string, nodeType NodeType, name string) *NodeProfile {
+	for _, n := range r.Nodes {
+		if n.Topology == topology && n.NodeType == nodeType && n.NodeName == name {
+			return n
+		}
+	}
+	return nil
+}
+
+var profileTestSink []byte
+
+func TestProfileNodes(t *testing.T) {
+	Convey("Given a goroutine having labels of a node and allocating memory", t, func() {
+		stop := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			setNodeProfileLabels("profile_test", NTBox, "busy_box")
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				for i := 0; i < 100; i++ {
+					profileTestSink = make([]byte, 1024)
+				}
+			}
+		}()
+		Reset(func() {
+			close(stop)
+			<-stopped
+		})
+
+		Convey("When profiling nodes", func() {
+			r, err := ProfileNodes(500*time.Millisecond, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the report should have the node", func() {
+				n := findNodeProfile(r, "profile_test", NTBox, "busy_box")
+				So(n, ShouldNotBeNil)
+				So(n.Goroutines, ShouldEqual, 1)
+
+				Convey("And CPU time used by the goroutine should be attributed to the node", func() {
+					So(n.CPUTime, ShouldBeGreaterThan, 0)
+					So(n.AllocCPUTime, ShouldBeGreaterThan, 0)
+					So(n.AllocCPUTime, ShouldBeLessThanOrEqualTo, n.CPUTime)
+					So(r.TotalCPUTime, ShouldBeGreaterThanOrEqualTo, n.CPUTime+r.UnattributedCPUTime)
+				})
+			})
+
+			Convey("Then the busiest node should come first", func() {
+				So(r.Nodes[0].NodeName, ShouldEqual, "busy_box")
+			})
+		})
+
+		Convey("When canceling profiling", func() {
+			cancel := make(chan struct{})
+			close(cancel)
+			_, err := ProfileNodes(time.Minute, cancel)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a topology having nodes", t, func() {
+		tp, err := NewDefaultTopology(NewContext(nil), "profile_test_topology")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+		_, err = tp.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		bn, err := tp.AddBox("box", BoxFunc(forwardBox), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		sin, err := tp.AddSink("sink", NewTupleCollectorSink(), nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("box", nil), ShouldBeNil)
+
+		Convey("When profiling nodes", func() {
+			r, err := ProfileNodes(10*time.Millisecond, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the report should have goroutines of all nodes", func() {
+				for name, nt := range map[string]NodeType{"source": NTSource, "box": NTBox, "sink": NTSink} {
+					n := findNodeProfile(r, "profile_test_topology", nt, name)
+					So(n, ShouldNotBeNil)
+					So(n.Goroutines, ShouldBeGreaterThan, 0)
+				}
+			})
+		})
+	})
+
+	Convey("Given broken profiles", t, func() {
+		profiles := [][]byte{
+			{0x12},                   // truncated length
+			{0x12, 0x05, 0x0a},       // a sample with a truncated body
+			{0x1f, 0x8b, 0x00, 0x00}, // broken gzip
+			{0x0f},                   // unknown wire type
+		}
+
+		Convey("When parsing them", func() {
+			Convey("Then it should fail", func() {
+				for _, p := range profiles {
+					_, err := parseProfile(p)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
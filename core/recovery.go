@@ -0,0 +1,512 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RecoveryPolicyType represents how a node recovers from a fatal error.
+type RecoveryPolicyType int
+
+const (
+	// RPHalt stops the node when it gets a fatal error. This is the default
+	// behavior.
+	RPHalt RecoveryPolicyType = iota
+
+	// RPRestartNode restarts the node which got a fatal error.
+	RPRestartNode
+
+	// RPRestartTopology restarts all boxes and sinks in the topology when
+	// one of them gets a fatal error. Sources keep running.
+	RPRestartTopology
+)
+
+func (t RecoveryPolicyType) String() string {
+	switch t {
+	case RPHalt:
+		return "halt"
+	case RPRestartNode:
+		return "restart_node"
+	case RPRestartTopology:
+		return "restart_topology"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseRecoveryPolicyType returns a RecoveryPolicyType having the given name.
+// Names are case-insensitive.
+func ParseRecoveryPolicyType(name string) (RecoveryPolicyType, error) {
+	switch strings.ToLower(name) {
+	case "halt":
+		return RPHalt, nil
+	case "restart_node":
+		return RPRestartNode, nil
+	case "restart_topology":
+		return RPRestartTopology, nil
+	default:
+		return 0, fmt.Errorf("unknown recovery policy: %v", name)
+	}
+}
+
+const (
+	// DefaultRestartInitialBackoff is the default value of
+	// RecoveryPolicy.InitialBackoff.
+	DefaultRestartInitialBackoff = 100 * time.Millisecond
+
+	// DefaultRestartMaxBackoff is the default value of
+	// RecoveryPolicy.MaxBackoff.
+	DefaultRestartMaxBackoff = 10 * time.Second
+)
+
+// RecoveryPolicy has parameters of recovery from fatal errors of a node.
+// A policy is applied when Process of a Box or Write of a Sink returns a
+// fatal error, an error having one of FatalCodes, or panics.
+//
+// A node is restarted as follows:
+//
+//  1. It waits for the backoff duration on the Clock of the Context. The
+//     duration starts with InitialBackoff and doubles on each restart up to
+//     MaxBackoff.
+//  2. A box implementing StatefulBox is terminated and initialized again.
+//     Other boxes and sinks don't have anything to be initialized. When
+//     Recreate is true and the box panicked, the box is terminated and
+//     replaced with a new instance created by BoxConfig.Recreate, which is
+//     initialized if it's a StatefulBox.
+//  3. The tuple which caused the error is reported as a dropped tuple and
+//     the node continues to process subsequent tuples.
+//  4. When Replay is true and the node is a sink, sources implementing
+//     OffsetRewindableSource are rewound to the first tuple which hasn't
+//     been written by the sink. See OffsetTracker for details.
+//
+// Because the node keeps its input and output pipes while waiting for the
+// backoff, stopping the node is blocked until the backoff ends.
+type RecoveryPolicy struct {
+	// Type is the type of the policy.
+	Type RecoveryPolicyType
+
+	// MaxRestarts is the maximum number of restarts of the node. The node
+	// halts when it gets a fatal error after it's restarted MaxRestarts
+	// times. When it's 0, the number of restarts isn't limited.
+	MaxRestarts int
+
+	// InitialBackoff is the duration to wait before the first restart.
+	// DefaultRestartInitialBackoff is used when it's 0.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum duration to wait before a restart.
+	// DefaultRestartMaxBackoff is used when it's 0.
+	MaxBackoff time.Duration
+
+	// Replay is a flag to rewind sources after a sink is restarted so that
+	// the tuple which caused the error and tuples after it are processed
+	// again. Other nodes may process those tuples more than once. It's
+	// ignored by boxes.
+	Replay bool
+
+	// Recreate is a flag to replace a box with a new instance when it
+	// panics. A panic often leaves the internal state of the box broken, so
+	// the box would keep panicking if the same instance was initialized
+	// again. It's ignored by sinks and boxes not having
+	// BoxConfig.Recreate.
+	Recreate bool
+
+	// FatalCodes has codes of errors which are treated as fatal errors even
+	// if IsFatalError returns false for them (see ErrorCode). It classifies
+	// errors wrapped by NewError with application-defined codes, e.g. to
+	// restart a sink when its session has expired. The policy is applied to
+	// them in the same way as other fatal errors, so the node halts when
+	// Type is RPHalt.
+	FatalCodes []string
+}
+
+// NewRecoveryPolicy creates a RecoveryPolicy from parameters. It accepts
+// following parameters:
+//
+//	policy: "halt", "restart_node", or "restart_topology"
+//	max_restarts: the maximum number of restarts
+//	initial_backoff: the initial backoff in seconds or as a duration string
+//	max_backoff: the maximum backoff in seconds or as a duration string
+//	replay: true to replay tuples after a sink is restarted
+//	recreate: true to replace a panicking box with a new instance
+//	fatal_codes: an array of codes of errors treated as fatal errors
+func NewRecoveryPolicy(params data.Map) (*RecoveryPolicy, error) {
+	p := &RecoveryPolicy{}
+	for k, v := range params {
+		switch k {
+		case "policy":
+			s, err := data.AsString(v)
+			if err != nil {
+				return nil, fmt.Errorf("policy: %v", err)
+			}
+			t, err := ParseRecoveryPolicyType(s)
+			if err != nil {
+				return nil, err
+			}
+			p.Type = t
+
+		case "max_restarts":
+			i, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("max_restarts: %v", err)
+			}
+			p.MaxRestarts = int(i)
+
+		case "initial_backoff":
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("initial_backoff: %v", err)
+			}
+			p.InitialBackoff = d
+
+		case "max_backoff":
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("max_backoff: %v", err)
+			}
+			p.MaxBackoff = d
+
+		case "replay":
+			b, err := data.AsBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("replay: %v", err)
+			}
+			p.Replay = b
+
+		case "recreate":
+			b, err := data.AsBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("recreate: %v", err)
+			}
+			p.Recreate = b
+
+		case "fatal_codes":
+			a, err := data.AsArray(v)
+			if err != nil {
+				return nil, fmt.Errorf("fatal_codes: %v", err)
+			}
+			codes := make([]string, len(a))
+			for i, c := range a {
+				s, err := data.AsString(c)
+				if err != nil {
+					return nil, fmt.Errorf("fatal_codes: %v", err)
+				}
+				codes[i] = s
+			}
+			p.FatalCodes = codes
+
+		default:
+			return nil, fmt.Errorf("unknown recovery parameter: %v", k)
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate validates values of RecoveryPolicy.
+func (p *RecoveryPolicy) Validate() error {
+	switch p.Type {
+	case RPHalt, RPRestartNode, RPRestartTopology:
+	default:
+		return fmt.Errorf("invalid recovery policy type: %v", int(p.Type))
+	}
+	if p.MaxRestarts < 0 {
+		return fmt.Errorf("max_restarts must not be negative: %v", p.MaxRestarts)
+	}
+	if p.InitialBackoff < 0 {
+		return fmt.Errorf("initial_backoff must not be negative: %v", p.InitialBackoff)
+	}
+	if p.MaxBackoff < 0 {
+		return fmt.Errorf("max_backoff must not be negative: %v", p.MaxBackoff)
+	}
+	for _, c := range p.FatalCodes {
+		if c == "" {
+			return errors.New("fatal_codes must not have an empty code")
+		}
+	}
+	return nil
+}
+
+// classify returns err as a fatal error when it has one of FatalCodes.
+func (p *RecoveryPolicy) classify(err error) error {
+	if err == nil || len(p.FatalCodes) == 0 || IsFatalError(err) {
+		return err
+	}
+	code := ErrorCode(err)
+	if code == "" {
+		return err
+	}
+	for _, c := range p.FatalCodes {
+		if c == code {
+			return FatalError(err)
+		}
+	}
+	return err
+}
+
+// backoff returns the duration to wait before the (n+1)-th restart.
+func (p *RecoveryPolicy) backoff(n int64) time.Duration {
+	d := p.InitialBackoff
+	if d == 0 {
+		d = DefaultRestartInitialBackoff
+	}
+	max := p.MaxBackoff
+	if max == 0 {
+		max = DefaultRestartMaxBackoff
+	}
+	for i := int64(0); i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// Map returns the policy as data.Map having the same keys as parameters of
+// NewRecoveryPolicy.
+func (p *RecoveryPolicy) Map() data.Map {
+	codes := make(data.Array, len(p.FatalCodes))
+	for i, c := range p.FatalCodes {
+		codes[i] = data.String(c)
+	}
+	return data.Map{
+		"policy":          data.String(p.Type.String()),
+		"max_restarts":    data.Int(p.MaxRestarts),
+		"initial_backoff": data.String(p.InitialBackoff.String()),
+		"max_backoff":     data.String(p.MaxBackoff.String()),
+		"replay":          data.Bool(p.Replay),
+		"recreate":        data.Bool(p.Recreate),
+		"fatal_codes":     codes,
+	}
+}
+
+// RecoveryPolicies manages recovery policies of nodes in a topology. Policies
+// can be changed while the topology is running. Node names are
+// case-insensitive.
+//
+// Methods of RecoveryPolicies can be called on a nil pointer, in which case
+// all nodes halt on fatal errors.
+type RecoveryPolicies struct {
+	m        sync.RWMutex
+	policies map[string]*RecoveryPolicy
+
+	// numPolicies is the number of nodes having policies. It's used to skip
+	// looking up policies when none are configured.
+	numPolicies int32
+}
+
+// NewRecoveryPolicies returns a new RecoveryPolicies having no policy.
+func NewRecoveryPolicies() *RecoveryPolicies {
+	return &RecoveryPolicies{
+		policies: map[string]*RecoveryPolicy{},
+	}
+}
+
+// Set sets the recovery policy of the node. It replaces the policy
+// previously set to the node.
+func (rp *RecoveryPolicies) Set(nodeName string, p *RecoveryPolicy) error {
+	if rp == nil {
+		return errors.New("recovery policies aren't supported")
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	c := *p
+	c.FatalCodes = append([]string(nil), p.FatalCodes...)
+	rp.m.Lock()
+	defer rp.m.Unlock()
+	rp.policies[strings.ToLower(nodeName)] = &c
+	atomic.StoreInt32(&rp.numPolicies, int32(len(rp.policies)))
+	return nil
+}
+
+// Get returns the recovery policy of the node. It returns false when the node
+// doesn't have a policy.
+func (rp *RecoveryPolicies) Get(nodeName string) (*RecoveryPolicy, bool) {
+	if rp == nil || atomic.LoadInt32(&rp.numPolicies) == 0 {
+		return nil, false
+	}
+	rp.m.RLock()
+	defer rp.m.RUnlock()
+	p, ok := rp.policies[strings.ToLower(nodeName)]
+	if !ok {
+		return nil, false
+	}
+	c := *p
+	return &c, true
+}
+
+// Remove removes the recovery policy of the node. It returns false when the
+// node doesn't have a policy.
+func (rp *RecoveryPolicies) Remove(nodeName string) bool {
+	if rp == nil {
+		return false
+	}
+	rp.m.Lock()
+	defer rp.m.Unlock()
+	name := strings.ToLower(nodeName)
+	if _, ok := rp.policies[name]; !ok {
+		return false
+	}
+	delete(rp.policies, name)
+	atomic.StoreInt32(&rp.numPolicies, int32(len(rp.policies)))
+	return true
+}
+
+// Status returns policies of all nodes. Keys of the map are node names.
+func (rp *RecoveryPolicies) Status() data.Map {
+	m := data.Map{}
+	if rp == nil {
+		return m
+	}
+	rp.m.RLock()
+	defer rp.m.RUnlock()
+	for name, p := range rp.policies {
+		m[name] = p.Map()
+	}
+	return m
+}
+
+// nodeRecovery has the state of recovery of a node.
+type nodeRecovery struct {
+	// numRestarts must be the first field for 64-bit alignment.
+	numRestarts int64
+
+	// restartRequested is set when another node requests all nodes in the
+	// topology to restart.
+	restartRequested AtomicFlag
+}
+
+// status returns the state of recovery. It's a part of the status of a node.
+func (nr *nodeRecovery) status(ctx *Context, nodeName string) data.Map {
+	m := data.Map{
+		"num_restarts": data.Int(atomic.LoadInt64(&nr.numRestarts)),
+	}
+	if p, ok := ctx.recoveryPolicy(nodeName); ok {
+		m["policy"] = p.Map()
+	}
+	return m
+}
+
+// recoveryWriter is a Writer which applies the recovery policy of the node
+// when the underlying Writer returns a fatal error or panics.
+type recoveryWriter struct {
+	w        Writer
+	nodeType NodeType
+	nodeName string
+	topology *defaultTopology
+	recovery *nodeRecovery
+
+	// reinit initializes the node again. recreate is true when the node
+	// should be replaced with a new instance. It can be nil when the node
+	// doesn't have anything to be initialized.
+	reinit func(ctx *Context, recreate bool) error
+}
+
+func newRecoveryWriter(w Writer, t *defaultTopology, nodeType NodeType, nodeName string,
+	r *nodeRecovery, reinit func(ctx *Context, recreate bool) error) *recoveryWriter {
+	return &recoveryWriter{
+		w:        w,
+		nodeType: nodeType,
+		nodeName: nodeName,
+		topology: t,
+		recovery: r,
+		reinit:   reinit,
+	}
+}
+
+func (r *recoveryWriter) Write(ctx *Context, t *Tuple) error {
+	if r.recovery.restartRequested.Enabled() {
+		r.recovery.restartRequested.Set(false)
+		if err := r.restart(ctx, false); err != nil {
+			return FatalError(err)
+		}
+	}
+
+	p, ok := ctx.recoveryPolicy(r.nodeName)
+	if !ok {
+		return r.w.Write(ctx, t)
+	}
+	if p.Type == RPHalt {
+		return p.classify(r.w.Write(ctx, t))
+	}
+
+	panicked, err := r.write(ctx, t)
+	err = p.classify(err)
+	if err == nil || !IsFatalError(err) {
+		return err
+	}
+
+	n := atomic.LoadInt64(&r.recovery.numRestarts)
+	if p.MaxRestarts > 0 && n >= int64(p.MaxRestarts) {
+		ctx.nodeErrLog(r.nodeType, r.nodeName, err).WithField("num_restarts", n).
+			Error("The node reached the maximum number of restarts")
+		return err
+	}
+
+	// Offsets have to be obtained before other tuples are written by the
+	// sink after the restart.
+	var replay map[*defaultSourceNode]int64
+	if p.Replay && r.nodeType == NTSink {
+		replay = r.topology.replayOffsets(r.nodeName)
+	}
+
+	backoff := p.backoff(n)
+	ctx.nodeErrLog(r.nodeType, r.nodeName, err).WithField("backoff", backoff.String()).
+		Warn("Restarting the node after a fatal error")
+	<-ctx.Clock().NewTimer(backoff).C()
+
+	if p.Type == RPRestartTopology {
+		r.topology.requestRestart(r.nodeName)
+	}
+	if err := r.restart(ctx, panicked && p.Recreate); err != nil {
+		return FatalError(err)
+	}
+	if len(replay) > 0 {
+		// Sources are rewound asynchronously because they might be blocked
+		// until this sink receives the next tuple.
+		go r.topology.replay(r.nodeName, replay)
+	}
+	return fmt.Errorf("the node was restarted due to a fatal error: %v", err)
+}
+
+// write writes the tuple and converts a panic into a fatal error. panicked is
+// true when the underlying Writer panicked.
+func (r *recoveryWriter) write(ctx *Context, t *Tuple) (panicked bool, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			panicked = true
+			if er, ok := e.(error); ok {
+				err = FatalError(er)
+			} else {
+				err = FatalError(fmt.Errorf("'%v' got an unknown error through panic: %v", r.nodeName, e))
+			}
+		}
+	}()
+	return false, r.w.Write(ctx, t)
+}
+
+func (r *recoveryWriter) restart(ctx *Context, recreate bool) error {
+	n := atomic.AddInt64(&r.recovery.numRestarts, 1)
+	if r.reinit != nil {
+		if err := r.reinit(ctx, recreate); err != nil {
+			ctx.nodeErrLog(r.nodeType, r.nodeName, err).Error("Cannot restart the node")
+			return err
+		}
+	}
+	ctx.nodeLog(r.nodeType, r.nodeName).WithField("num_restarts", n).Info("The node has been restarted")
+	ctx.ReportSystemEvent(SETNodeRestarted, data.Map{
+		"node_type":    data.String(r.nodeType.String()),
+		"node_name":    data.String(r.nodeName),
+		"num_restarts": data.Int(n),
+	})
+	return nil
+}
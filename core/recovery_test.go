@@ -0,0 +1,365 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failOnceBox returns a fatal error or panics on the first tuple it receives.
+// When always is true, it fails on all tuples. When code is set, it returns a
+// permanent error having the code instead of a fatal error.
+type failOnceBox struct {
+	panics  bool
+	always  bool
+	code    string
+	numInit int32
+	failed  int32
+}
+
+func (b *failOnceBox) Init(ctx *Context) error {
+	atomic.AddInt32(&b.numInit, 1)
+	return nil
+}
+
+func (b *failOnceBox) Process(ctx *Context, t *Tuple, w Writer) error {
+	if atomic.CompareAndSwapInt32(&b.failed, 0, 1) || b.always {
+		if b.panics {
+			panic(errors.New("box panic"))
+		}
+		if b.code != "" {
+			return NewError(errors.New("box failure"), b.code, ECPermanent)
+		}
+		return FatalError(errors.New("box failure"))
+	}
+	return w.Write(ctx, t)
+}
+
+func (b *failOnceBox) Terminate(ctx *Context) error {
+	return nil
+}
+
+func TestRecoveryPolicy(t *testing.T) {
+	Convey("Given parameters of a recovery policy", t, func() {
+		params := data.Map{
+			"policy":          data.String("restart_node"),
+			"max_restarts":    data.Int(3),
+			"initial_backoff": data.String("10ms"),
+			"max_backoff":     data.Float(0.05),
+			"replay":          data.True,
+			"recreate":        data.True,
+			"fatal_codes":     data.Array{data.String("session_expired")},
+		}
+
+		Convey("When creating a policy", func() {
+			p, err := NewRecoveryPolicy(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the given values", func() {
+				So(p.Type, ShouldEqual, RPRestartNode)
+				So(p.MaxRestarts, ShouldEqual, 3)
+				So(p.InitialBackoff, ShouldEqual, 10*time.Millisecond)
+				So(p.MaxBackoff, ShouldEqual, 50*time.Millisecond)
+				So(p.Replay, ShouldBeTrue)
+				So(p.Recreate, ShouldBeTrue)
+				So(p.FatalCodes, ShouldResemble, []string{"session_expired"})
+			})
+
+			Convey("Then it should only classify errors having the codes as fatal", func() {
+				So(IsFatalError(p.classify(NewError(errors.New("a"), "session_expired", ECTemporary))), ShouldBeTrue)
+				So(IsFatalError(p.classify(NewError(errors.New("a"), "rate_limited", ECTemporary))), ShouldBeFalse)
+				So(IsFatalError(p.classify(errors.New("a"))), ShouldBeFalse)
+				So(p.classify(nil), ShouldBeNil)
+			})
+
+			Convey("Then its map should have the same values", func() {
+				q, err := NewRecoveryPolicy(p.Map())
+				So(err, ShouldBeNil)
+				So(q, ShouldResemble, p)
+			})
+
+			Convey("Then the backoff should double up to the max", func() {
+				So(p.backoff(0), ShouldEqual, 10*time.Millisecond)
+				So(p.backoff(1), ShouldEqual, 20*time.Millisecond)
+				So(p.backoff(2), ShouldEqual, 40*time.Millisecond)
+				So(p.backoff(3), ShouldEqual, 50*time.Millisecond)
+				So(p.backoff(100), ShouldEqual, 50*time.Millisecond)
+			})
+		})
+
+		Convey("When the policy is unknown", func() {
+			params["policy"] = data.String("retry")
+
+			Convey("Then creating a policy should fail", func() {
+				_, err := NewRecoveryPolicy(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When max_restarts is negative", func() {
+			params["max_restarts"] = data.Int(-1)
+
+			Convey("Then creating a policy should fail", func() {
+				_, err := NewRecoveryPolicy(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When fatal_codes has an invalid code", func() {
+			Convey("Then creating a policy should fail", func() {
+				for _, v := range []data.Value{
+					data.String("session_expired"),
+					data.Array{data.Int(1)},
+					data.Array{data.String("")},
+				} {
+					params["fatal_codes"] = v
+					_, err := NewRecoveryPolicy(params)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When an unknown parameter is given", func() {
+			params["foo"] = data.Int(1)
+
+			Convey("Then creating a policy should fail", func() {
+				_, err := NewRecoveryPolicy(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestRecovery(t *testing.T) {
+	Convey("Given a topology with a failing box", t, func() {
+		ctx := NewContext(nil)
+		t, err := NewDefaultTopology(ctx, "recovery")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		so := NewTupleEmitterSource(freshTuples())
+		son, err := t.AddSource("source", so, &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		b := &failOnceBox{}
+		bn, err := t.AddBox("box", b, nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("box", nil), ShouldBeNil)
+
+		Convey("When the box doesn't have a policy", func() {
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should halt", func() {
+				bn.State().Wait(TSStopped)
+				So(si.len(), ShouldEqual, 0)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the box has restart_node policy", func() {
+			So(ctx.Recovery.Set("box", &RecoveryPolicy{
+				Type:           RPRestartNode,
+				InitialBackoff: time.Nanosecond,
+			}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should be restarted and process other tuples", func() {
+				si.Wait(7)
+				So(si.len(), ShouldEqual, 7)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 2)
+				So(bn.State().Get(), ShouldEqual, TSRunning)
+
+				Convey("And the status should have the number of restarts", func() {
+					st := bn.Status()["recovery"].(data.Map)
+					So(st["num_restarts"], ShouldEqual, 1)
+					So(st["policy"].(data.Map)["policy"], ShouldEqual, "restart_node")
+				})
+			})
+		})
+
+		Convey("When the topology has restart_node policy in its settings", func() {
+			So(ctx.Settings.SetTopology(&NodeSettings{
+				Recovery: &RecoveryPolicy{
+					Type:           RPRestartNode,
+					InitialBackoff: time.Nanosecond,
+				},
+			}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should inherit the policy and be restarted", func() {
+				si.Wait(7)
+				So(si.len(), ShouldEqual, 7)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the box has halt policy overriding the topology's settings", func() {
+			So(ctx.Settings.SetTopology(&NodeSettings{
+				Recovery: &RecoveryPolicy{Type: RPRestartNode},
+			}), ShouldBeNil)
+			So(ctx.Recovery.Set("box", &RecoveryPolicy{Type: RPHalt}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should halt", func() {
+				bn.State().Wait(TSStopped)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the box panics with restart_node policy", func() {
+			b.panics = true
+			So(ctx.Recovery.Set("box", &RecoveryPolicy{
+				Type:           RPRestartNode,
+				InitialBackoff: time.Nanosecond,
+			}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should be restarted", func() {
+				si.Wait(7)
+				So(si.len(), ShouldEqual, 7)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the box always fails with max_restarts", func() {
+			b.always = true
+			So(ctx.Recovery.Set("box", &RecoveryPolicy{
+				Type:           RPRestartNode,
+				MaxRestarts:    2,
+				InitialBackoff: time.Nanosecond,
+			}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then the box should halt after restarts", func() {
+				bn.State().Wait(TSStopped)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 3)
+				So(si.len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a box which can be recreated panics", func() {
+			var boxes []*failOnceBox
+			newBox := func() *failOnceBox {
+				// Only the first instance is broken.
+				b := &failOnceBox{panics: true, always: len(boxes) == 0}
+				if len(boxes) > 0 {
+					b.failed = 1
+				}
+				boxes = append(boxes, b)
+				return b
+			}
+			bn2, err := t.AddBox("box2", newBox(), &BoxConfig{
+				Recreate: func(ctx *Context) (Box, error) {
+					return newBox(), nil
+				},
+			})
+			So(err, ShouldBeNil)
+			So(bn2.Input("source", nil), ShouldBeNil)
+			si2 := NewTupleCollectorSink()
+			sin2, err := t.AddSink("sink2", si2, nil)
+			So(err, ShouldBeNil)
+			So(sin2.Input("box2", nil), ShouldBeNil)
+
+			Convey("And its policy recreates it", func() {
+				So(ctx.Recovery.Set("box2", &RecoveryPolicy{
+					Type:           RPRestartNode,
+					InitialBackoff: time.Nanosecond,
+					Recreate:       true,
+				}), ShouldBeNil)
+				So(son.Resume(), ShouldBeNil)
+
+				Convey("Then the box should be replaced with a new initialized one", func() {
+					si2.Wait(7)
+					So(si2.len(), ShouldEqual, 7)
+					So(len(boxes), ShouldEqual, 2)
+					So(bn2.Box(), ShouldEqual, boxes[1])
+					So(atomic.LoadInt32(&boxes[1].numInit), ShouldEqual, 1)
+					So(bn2.Status()["recovery"].(data.Map)["num_restarts"], ShouldEqual, 1)
+				})
+			})
+
+			Convey("And its policy doesn't recreate it", func() {
+				So(ctx.Recovery.Set("box2", &RecoveryPolicy{
+					Type:           RPRestartNode,
+					MaxRestarts:    1,
+					InitialBackoff: time.Nanosecond,
+				}), ShouldBeNil)
+				So(son.Resume(), ShouldBeNil)
+
+				Convey("Then the same box should keep panicking", func() {
+					bn2.State().Wait(TSStopped)
+					So(si2.len(), ShouldEqual, 0)
+					So(len(boxes), ShouldEqual, 1)
+					So(atomic.LoadInt32(&boxes[0].numInit), ShouldEqual, 2)
+				})
+			})
+		})
+
+		Convey("When the box returns an error having a code", func() {
+			b.code = "session_expired"
+
+			Convey("And it doesn't have a policy", func() {
+				So(son.Resume(), ShouldBeNil)
+
+				Convey("Then the tuple should only be dropped", func() {
+					si.Wait(7)
+					So(si.len(), ShouldEqual, 7)
+					So(atomic.LoadInt32(&b.numInit), ShouldEqual, 1)
+				})
+			})
+
+			Convey("And its restart_node policy has the code in fatal codes", func() {
+				So(ctx.Recovery.Set("box", &RecoveryPolicy{
+					Type:           RPRestartNode,
+					InitialBackoff: time.Nanosecond,
+					FatalCodes:     []string{"session_expired"},
+				}), ShouldBeNil)
+				So(son.Resume(), ShouldBeNil)
+
+				Convey("Then the box should be restarted", func() {
+					si.Wait(7)
+					So(si.len(), ShouldEqual, 7)
+					So(atomic.LoadInt32(&b.numInit), ShouldEqual, 2)
+				})
+			})
+
+			Convey("And its halt policy has the code in fatal codes", func() {
+				So(ctx.Recovery.Set("box", &RecoveryPolicy{
+					Type:       RPHalt,
+					FatalCodes: []string{"session_expired"},
+				}), ShouldBeNil)
+				So(son.Resume(), ShouldBeNil)
+
+				Convey("Then the box should halt", func() {
+					bn.State().Wait(TSStopped)
+					So(si.len(), ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey("When the box has restart_topology policy", func() {
+			So(ctx.Recovery.Set("box", &RecoveryPolicy{
+				Type:           RPRestartTopology,
+				InitialBackoff: time.Nanosecond,
+			}), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then other nodes should also be restarted", func() {
+				si.Wait(7)
+				So(atomic.LoadInt32(&b.numInit), ShouldEqual, 2)
+				st := sin.Status()["recovery"].(data.Map)
+				So(st["num_restarts"], ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces secret values in outputs of Redactor.
+const RedactedValue = "********"
+
+// Redactor hides secret values such as passwords in statuses of nodes so
+// that they aren't exposed through the API or logs. Secrets are registered
+// when they are resolved, e.g. when parameters of a source are created from
+// a secret provider.
+type Redactor struct {
+	m      sync.RWMutex
+	values map[string]struct{}
+}
+
+// NewRedactor creates a new Redactor having no secret.
+func NewRedactor() *Redactor {
+	return &Redactor{
+		values: map[string]struct{}{},
+	}
+}
+
+// Add registers secret values to be redacted. Empty strings are ignored.
+func (r *Redactor) Add(secrets ...string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	for _, s := range secrets {
+		if s != "" {
+			r.values[s] = struct{}{}
+		}
+	}
+}
+
+// Redact returns a copy of v in which all occurrences of registered secrets
+// in strings are replaced with RedactedValue. It returns v itself when no
+// secret is registered or r is nil.
+func (r *Redactor) Redact(v data.Value) data.Value {
+	if r == nil {
+		return v
+	}
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if len(r.values) == 0 {
+		return v
+	}
+	return r.redact(v)
+}
+
+// RedactMap is a shortcut of Redact for data.Map.
+func (r *Redactor) RedactMap(m data.Map) data.Map {
+	return r.Redact(m).(data.Map)
+}
+
+func (r *Redactor) redact(v data.Value) data.Value {
+	switch v := v.(type) {
+	case data.String:
+		s := string(v)
+		for secret := range r.values {
+			s = strings.Replace(s, secret, RedactedValue, -1)
+		}
+		return data.String(s)
+	case data.Map:
+		m := make(data.Map, len(v))
+		for k, e := range v {
+			m[k] = r.redact(e)
+		}
+		return m
+	case data.Array:
+		a := make(data.Array, len(v))
+		for i, e := range v {
+			a[i] = r.redact(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+)
+
+// ResourceLimits has limits of resources which a topology can use. A zero
+// value of a field means that the resource isn't limited.
+type ResourceLimits struct {
+	// MaxMemory is the maximum approximate size of memory in bytes used by
+	// tuples queued in pipes and kept in window buffers of the topology.
+	MaxMemory int64
+
+	// MaxTuples is the maximum number of tuples queued in pipes and kept in
+	// window buffers of the topology.
+	MaxTuples int64
+}
+
+// enabled returns true when at least one of the resources is limited.
+func (l *ResourceLimits) enabled() bool {
+	return l.MaxMemory > 0 || l.MaxTuples > 0
+}
+
+// ResourceController tracks approximate resources used by a topology and
+// enforces ResourceLimits on it. Tuples queued in pipes are automatically
+// accounted by core package. Other components keeping tuples, such as window
+// buffers of BQL statements, report their usage through Acquire and Release.
+//
+// When the usage exceeds the limits, sources of the topology block on writing
+// tuples until the usage falls below the limits. Boxes never block because
+// blocking them can result in a dead-lock. Instead, components having window
+// buffers are expected to evict their oldest tuples while Exceeded returns
+// true.
+//
+// All methods of ResourceController are thread-safe.
+type ResourceController struct {
+	m      sync.Mutex
+	cond   *sync.Cond
+	limits ResourceLimits
+	memory int64
+	tuples int64
+
+	// enabled caches limits.enabled() so that pipes can check it without
+	// acquiring the lock.
+	enabled AtomicFlag
+}
+
+// NewResourceController creates a new ResourceController with the given
+// limits. If limits is nil, no resource will be limited.
+func NewResourceController(limits *ResourceLimits) *ResourceController {
+	r := &ResourceController{}
+	r.cond = sync.NewCond(&r.m)
+	if limits != nil {
+		r.limits = *limits
+	}
+	r.enabled.Set(r.limits.enabled())
+	return r
+}
+
+// Limits returns the current limits.
+func (r *ResourceController) Limits() ResourceLimits {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.limits
+}
+
+// SetLimits updates limits. Tuples which have already been queued when limits
+// are set for the first time aren't accounted.
+func (r *ResourceController) SetLimits(limits ResourceLimits) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.limits = limits
+	r.enabled.Set(limits.enabled())
+	r.cond.Broadcast()
+}
+
+// Enabled returns true when at least one of the resources is limited. Usage
+// isn't tracked while this method returns false.
+func (r *ResourceController) Enabled() bool {
+	return r.enabled.Enabled()
+}
+
+// Acquire adds the given number of tuples and the size of memory in bytes to
+// the current usage. It never blocks even if the usage exceeds limits.
+func (r *ResourceController) Acquire(tuples, memory int64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.tuples += tuples
+	r.memory += memory
+}
+
+// Release subtracts the given number of tuples and the size of memory in
+// bytes from the current usage. The values must be the same as those passed
+// to Acquire.
+func (r *ResourceController) Release(tuples, memory int64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.tuples -= tuples
+	r.memory -= memory
+	r.cond.Broadcast()
+}
+
+// Usage returns the current number of tuples and the size of memory in bytes.
+func (r *ResourceController) Usage() (tuples, memory int64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.tuples, r.memory
+}
+
+// Exceeded returns true when the current usage exceeds limits.
+func (r *ResourceController) Exceeded() bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.exceededWithoutLock()
+}
+
+func (r *ResourceController) exceededWithoutLock() bool {
+	if r.limits.MaxTuples > 0 && r.tuples > r.limits.MaxTuples {
+		return true
+	}
+	if r.limits.MaxMemory > 0 && r.memory > r.limits.MaxMemory {
+		return true
+	}
+	return false
+}
+
+// wait blocks while the current usage exceeds limits. It also returns when
+// canceled returns true. canceled is called with the lock of the controller
+// and it must not call any method of ResourceController.
+func (r *ResourceController) wait(canceled func() bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	for r.exceededWithoutLock() && !canceled() {
+		r.cond.Wait()
+	}
+}
+
+// wakeUp wakes up all goroutines blocking in wait so that they can check
+// their cancellation conditions again.
+func (r *ResourceController) wakeUp() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.cond.Broadcast()
+}
+
+// Status returns the current usage and limits of resources.
+func (r *ResourceController) Status() data.Map {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return data.Map{
+		"num_tuples": data.Int(r.tuples),
+		"memory":     data.Int(r.memory),
+		"max_tuples": data.Int(r.limits.MaxTuples),
+		"max_memory": data.Int(r.limits.MaxMemory),
+		"exceeded":   data.Bool(r.exceededWithoutLock()),
+	}
+}
+
+// acquireTuple accounts a tuple being queued in a pipe. It does nothing when
+// no resource is limited.
+func (r *ResourceController) acquireTuple(t *Tuple) {
+	if !r.Enabled() {
+		return
+	}
+	t.queuedSize = t.approxSize()
+	r.Acquire(1, t.queuedSize)
+}
+
+// releaseTuple releases resources of a tuple accounted by acquireTuple. It
+// does nothing when the tuple isn't accounted.
+func (r *ResourceController) releaseTuple(t *Tuple) {
+	if t.queuedSize == 0 {
+		return
+	}
+	r.Release(1, t.queuedSize)
+	t.queuedSize = 0
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestResourceController(t *testing.T) {
+	Convey("Given a resource controller without limits", t, func() {
+		r := NewResourceController(nil)
+
+		Convey("Then it should be disabled", func() {
+			So(r.Enabled(), ShouldBeFalse)
+		})
+
+		Convey("When acquiring resources", func() {
+			r.Acquire(100, 1<<30)
+
+			Convey("Then it should never exceed limits", func() {
+				So(r.Exceeded(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When setting limits", func() {
+			r.SetLimits(ResourceLimits{MaxTuples: 10})
+
+			Convey("Then it should be enabled", func() {
+				So(r.Enabled(), ShouldBeTrue)
+				So(r.Limits().MaxTuples, ShouldEqual, 10)
+				So(r.Limits().MaxMemory, ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a resource controller with limits", t, func() {
+		r := NewResourceController(&ResourceLimits{
+			MaxMemory: 1000,
+			MaxTuples: 10,
+		})
+
+		Convey("When acquiring resources within limits", func() {
+			r.Acquire(10, 1000)
+
+			Convey("Then it shouldn't exceed limits", func() {
+				So(r.Exceeded(), ShouldBeFalse)
+			})
+
+			Convey("Then it should report the usage", func() {
+				n, m := r.Usage()
+				So(n, ShouldEqual, 10)
+				So(m, ShouldEqual, 1000)
+			})
+		})
+
+		Convey("When acquiring too many tuples", func() {
+			r.Acquire(11, 10)
+
+			Convey("Then it should exceed limits", func() {
+				So(r.Exceeded(), ShouldBeTrue)
+				So(r.Status()["exceeded"], ShouldEqual, data.True)
+			})
+
+			Convey("Then it should be within limits after releasing them", func() {
+				r.Release(1, 0)
+				So(r.Exceeded(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When acquiring too much memory", func() {
+			r.Acquire(1, 1001)
+
+			Convey("Then it should exceed limits", func() {
+				So(r.Exceeded(), ShouldBeTrue)
+			})
+
+			Convey("Then wait should block until resources are released", func() {
+				ch := make(chan struct{})
+				go func() {
+					r.wait(func() bool { return false })
+					close(ch)
+				}()
+
+				select {
+				case <-ch:
+					So("wait should block", ShouldBeNil)
+				case <-time.After(10 * time.Millisecond):
+				}
+				r.Release(1, 1001)
+				<-ch
+			})
+		})
+	})
+}
+
+func TestResourceControllerWithPipes(t *testing.T) {
+	Convey("Given a context with a tuple budget", t, func() {
+		ctx := NewContext(&ContextConfig{
+			ResourceLimits: &ResourceLimits{
+				MaxTuples: 1,
+			},
+		})
+
+		Convey("When sending a tuple through a pipe", func() {
+			r, s := newPipe("test", 4)
+			So(s.Write(ctx, &Tuple{Data: data.Map{"v": data.Int(1)}}), ShouldBeNil)
+
+			Convey("Then the queued tuple should be accounted", func() {
+				n, m := ctx.Resources.Usage()
+				So(n, ShouldEqual, 1)
+				So(m, ShouldBeGreaterThan, 0)
+			})
+
+			Convey("Then the resource should be released after receiving it", func() {
+				ctx.Resources.releaseTuple(<-r.in)
+				n, m := ctx.Resources.Usage()
+				So(n, ShouldEqual, 0)
+				So(m, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a source writes tuples exceeding the budget", func() {
+			dsts := newDataDestinations(NTSource, "test_source")
+			r, s := newPipe("test", 4)
+			So(dsts.add("test_node", s), ShouldBeNil)
+			for i := 0; i < 2; i++ {
+				So(dsts.Write(ctx, &Tuple{Data: data.Map{"v": data.Int(i)}}), ShouldBeNil)
+			}
+			So(ctx.Resources.Exceeded(), ShouldBeTrue)
+
+			ch := make(chan struct{})
+			go func() {
+				dsts.Write(ctx, &Tuple{Data: data.Map{"v": data.Int(2)}})
+				close(ch)
+			}()
+
+			Convey("Then the source should block until tuples are received", func() {
+				select {
+				case <-ch:
+					So("the source should block", ShouldBeNil)
+				case <-time.After(10 * time.Millisecond):
+				}
+				ctx.Resources.releaseTuple(<-r.in)
+				<-ch
+			})
+
+			Convey("Then the source should be unblocked by closing destinations", func() {
+				So(dsts.Close(ctx), ShouldBeNil)
+				<-ch
+			})
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"sync/atomic"
+)
+
+// tupleRing is a bounded lock-free queue of tuples having a single producer
+// and a single consumer. A producer notifies the consumer only when the
+// consumer isn't going to read the ring soon, and the consumer reads all
+// tuples in the ring at once after being notified. Therefore, a batch of
+// tuples only costs one wakeup, which is the main overhead of channels in
+// simple pass-through topologies.
+//
+// The producer is responsible for serializing writes and tupleRing itself
+// serializes consumers. An object of this struct must be allocated from the
+// heap for 64-bit alignment.
+type tupleRing struct {
+	// head is the index of the next tuple to be read. It's only updated by
+	// the consumer. head and tail are placed in different cache lines so
+	// that the producer and the consumer don't slow down each other.
+	head uint64
+	_    [56]byte
+
+	// tail is the index of the next tuple to be written. It's only updated
+	// by the producer.
+	tail uint64
+	_    [56]byte
+
+	buf  []*Tuple
+	mask uint64
+
+	// signaled is 1 when the consumer has been notified and hasn't started
+	// reading the ring yet.
+	signaled int32
+
+	// consuming is 1 while a consumer is reading the ring.
+	consuming int32
+
+	// producerWaiting is 1 when the producer is waiting for space. space is
+	// used to wake it up.
+	producerWaiting int32
+	space           chan struct{}
+}
+
+// newTupleRing creates a tupleRing which can have at least capacity tuples.
+// The actual capacity is rounded up to a power of two.
+func newTupleRing(capacity int) *tupleRing {
+	n := 1
+	for n < capacity {
+		n <<= 1
+	}
+	return &tupleRing{
+		buf:   make([]*Tuple, n),
+		mask:  uint64(n - 1),
+		space: make(chan struct{}, 1),
+	}
+}
+
+func (r *tupleRing) len() int {
+	return int(atomic.LoadUint64(&r.tail) - atomic.LoadUint64(&r.head))
+}
+
+func (r *tupleRing) cap() int {
+	return len(r.buf)
+}
+
+// push adds the tuple to the ring. It returns false when the ring is full.
+// It must only be called by the producer.
+func (r *tupleRing) push(t *Tuple) bool {
+	tail := atomic.LoadUint64(&r.tail)
+	if tail-atomic.LoadUint64(&r.head) == uint64(len(r.buf)) {
+		return false
+	}
+	r.buf[tail&r.mask] = t
+	atomic.StoreUint64(&r.tail, tail+1)
+	return true
+}
+
+// notify sends a notification to the consumer through ch after pushing
+// tuples. ch must have a buffer. It doesn't send anything when the consumer
+// has already been notified and hasn't read the ring yet.
+func (r *tupleRing) notify(ch chan<- *Tuple) {
+	if atomic.LoadInt32(&r.signaled) != 0 || !atomic.CompareAndSwapInt32(&r.signaled, 0, 1) {
+		return
+	}
+	select {
+	case ch <- nil:
+	default: // there's already a notification which isn't received yet.
+	}
+}
+
+// waitForSpace blocks the producer until the consumer reads a tuple from the
+// ring. It may return without space being available, so the caller has to
+// check it again.
+func (r *tupleRing) waitForSpace() {
+	atomic.StoreInt32(&r.producerWaiting, 1)
+
+	// The consumer could read all tuples before producerWaiting was set.
+	if r.len() < r.cap() {
+		atomic.StoreInt32(&r.producerWaiting, 0)
+		return
+	}
+	<-r.space
+}
+
+func (r *tupleRing) wakeProducer() {
+	if atomic.LoadInt32(&r.producerWaiting) == 0 || !atomic.CompareAndSwapInt32(&r.producerWaiting, 1, 0) {
+		return
+	}
+	select {
+	case r.space <- struct{}{}:
+	default:
+	}
+}
+
+// drain passes tuples in the ring to f until the ring gets empty or f returns
+// an error. Tuples added while draining the ring are also passed to f. drain
+// returns nil immediately when another consumer is reading the ring, in which
+// case that consumer will read all tuples in the ring including ones added
+// before drain was called.
+func (r *tupleRing) drain(f func(t *Tuple) error) error {
+	atomic.StoreInt32(&r.signaled, 0)
+	for {
+		if !atomic.CompareAndSwapInt32(&r.consuming, 0, 1) {
+			return nil
+		}
+		if err := r.drainOnce(f); err != nil {
+			return err
+		}
+
+		// A producer may have added tuples while the other consumer gave up
+		// reading the ring in the middle of drainOnce.
+		if r.len() == 0 {
+			return nil
+		}
+	}
+}
+
+func (r *tupleRing) drainOnce(f func(t *Tuple) error) error {
+	// f can panic.
+	defer atomic.StoreInt32(&r.consuming, 0)
+
+	head := atomic.LoadUint64(&r.head)
+	for tail := atomic.LoadUint64(&r.tail); head != tail; tail = atomic.LoadUint64(&r.tail) {
+		for ; head != tail; head++ {
+			i := head & r.mask
+			t := r.buf[i]
+			r.buf[i] = nil
+			atomic.StoreUint64(&r.head, head+1)
+			r.wakeProducer()
+
+			if err := f(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package core
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync/atomic"
+	"testing"
+)
+
+// drainRing reads all tuples currently in the ring pipe.
+func drainRing(r *pipeReceiver) []*Tuple {
+	var ts []*Tuple
+	r.ring.drain(func(t *Tuple) error {
+		ts = append(ts, t)
+		return nil
+	})
+	return ts
+}
+
+func TestRingPipe(t *testing.T) {
+	ctx := NewContext(nil)
+
+	Convey("Given a ring pipe", t, func() {
+		r, s := newRingPipe("test", 1)
+		t := &Tuple{
+			InputName: "hoge",
+			Data: data.Map{
+				"v": data.Int(1),
+			},
+		}
+		t2 := t.Copy()
+		t2.Data["v"] = data.Int(2)
+
+		Convey("When sending a tuple via the sender", func() {
+			So(s.Write(ctx, t), ShouldBeNil)
+
+			Convey("Then the receiver should be notified", func() {
+				_, ok := <-r.in
+				So(ok, ShouldBeTrue)
+
+				Convey("And the tuple should be in the ring", func() {
+					ts := drainRing(r)
+					So(len(ts), ShouldEqual, 1)
+					So(ts[0].Data["v"], ShouldEqual, data.Int(1))
+					So(ts[0].InputName, ShouldEqual, "test")
+				})
+			})
+
+			Convey("Then the queue status should have the tuple", func() {
+				l, c := s.queueStatus()
+				So(l, ShouldEqual, 1)
+				So(c, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When sending tuples with DropLatest mode", func() {
+			s.dropMode = DropLatest
+			So(s.Write(ctx, t), ShouldBeNil)
+			So(s.Write(ctx, t2), ShouldBeNil)
+
+			Convey("Then only the first tuple should be received by the receiver", func() {
+				ts := drainRing(r)
+				So(len(ts), ShouldEqual, 1)
+				So(ts[0].Data["v"], ShouldEqual, data.Int(1))
+				So(s.count(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When sending tuples more than its capacity", func() {
+			So(s.Write(ctx, t), ShouldBeNil)
+			written := make(chan error, 1)
+			go func() {
+				written <- s.Write(ctx, t2)
+			}()
+
+			Convey("Then the sender should be unblocked after the receiver reads a tuple", func() {
+				var ts []*Tuple
+				for len(ts) < 2 {
+					<-r.in
+					ts = append(ts, drainRing(r)...)
+				}
+				So(<-written, ShouldBeNil)
+				So(ts[0].Data["v"], ShouldEqual, data.Int(1))
+				So(ts[1].Data["v"], ShouldEqual, data.Int(2))
+			})
+		})
+
+		Convey("When closing the pipe after sending a tuple", func() {
+			So(s.Write(ctx, t), ShouldBeNil)
+			s.close()
+
+			Convey("Then the sender cannot write a tuple", func() {
+				So(s.Write(ctx, t), ShouldPointTo, errPipeClosed)
+			})
+
+			Convey("Then the tuple should still be in the ring", func() {
+				drainReceiver(r)
+				So(len(drainRing(r)), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given an input config having a ring pipe", t, func() {
+		c := &BoxInputConfig{
+			PipeType: RingPipe,
+		}
+
+		Convey("When its drop mode is DropOldest", func() {
+			c.DropMode = DropOldest
+
+			Convey("Then it should be invalid", func() {
+				So(c.Validate(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When its drop mode is DropLatest", func() {
+			c.DropMode = DropLatest
+
+			Convey("Then it should be valid", func() {
+				So(c.Validate(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestDataSourcesWithRingPipes(t *testing.T) {
+	ctx := NewContext(nil)
+
+	Convey("Given a data source having ring pipes", t, func() {
+		srcs := newDataSources(NTBox, "test_component")
+		dsts := make([]*pipeSender, 2)
+		for i := range dsts {
+			r, s := newRingPipe(fmt.Sprint("test", i+1), 4)
+			So(srcs.add(fmt.Sprint("test_node_", i+1), r), ShouldBeNil)
+			dsts[i] = s
+		}
+		si := NewTupleCollectorSink()
+
+		t := &Tuple{
+			Data: data.Map{
+				"v": data.Int(1),
+			},
+		}
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- srcs.pour(ctx, si, 4)
+		}()
+		Reset(func() {
+			srcs.stop(ctx)
+		})
+		srcs.state.Wait(TSRunning)
+
+		Convey("When sending more tuples than their capacity", func() {
+			for i := 0; i < 100; i++ {
+				So(dsts[0].Write(ctx, t), ShouldBeNil)
+				So(dsts[1].Write(ctx, t), ShouldBeNil)
+			}
+			srcs.enableGracefulStop()
+			srcs.stop(ctx)
+			So(<-stopped, ShouldBeNil)
+
+			Convey("Then the sink should receive all tuples", func() {
+				So(si.len(), ShouldEqual, 200)
+			})
+
+			Convey("Then the status should have the number of received tuples", func() {
+				So(srcs.status()["num_received_total"], ShouldEqual, data.Int(200))
+			})
+		})
+
+		Convey("When closing an input after sending tuples", func() {
+			for i := 0; i < 3; i++ {
+				So(dsts[0].Write(ctx, t), ShouldBeNil)
+			}
+			dsts[0].close()
+
+			Convey("Then the sink should receive all tuples", func() {
+				si.Wait(3)
+				So(si.len(), ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a data source having a ring pipe and a failing writer", t, func() {
+		srcs := newDataSources(NTBox, "test_component")
+		r, s := newRingPipe("test", 4)
+		So(srcs.add("test_node", r), ShouldBeNil)
+
+		var cnt int32
+		w := WriterFunc(func(ctx *Context, t *Tuple) error {
+			atomic.AddInt32(&cnt, 1)
+			return FatalError(fmt.Errorf("failure"))
+		})
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- srcs.pour(ctx, w, 1)
+		}()
+		srcs.state.Wait(TSRunning)
+
+		t := &Tuple{
+			Data: data.Map{
+				"v": data.Int(1),
+			},
+		}
+
+		Convey("When sending more tuples than its capacity", func() {
+			for i := 0; i < 100; i++ {
+				if err := s.Write(ctx, t); err != nil {
+					break
+				}
+			}
+
+			Convey("Then the sender should not be blocked and the source should stop", func() {
+				So(<-stopped, ShouldNotBeNil)
+				So(atomic.LoadInt32(&cnt), ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func BenchmarkPassThroughPipe(b *testing.B) {
+	for _, pt := range []PipeType{ChannelPipe, RingPipe} {
+		name := "channel"
+		if pt == RingPipe {
+			name = "ring"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			ctx := NewContext(nil)
+			srcs := newDataSources(NTBox, "test_component")
+			r, s := newPipeWithType(pt, "test", 1024)
+			srcs.add("test_node", r)
+
+			var cnt int64
+			w := WriterFunc(func(ctx *Context, t *Tuple) error {
+				atomic.AddInt64(&cnt, 1)
+				return nil
+			})
+			stopped := make(chan error, 1)
+			go func() {
+				stopped <- srcs.pour(ctx, w, 1)
+			}()
+			srcs.state.Wait(TSRunning)
+
+			t := &Tuple{}
+			t.Data = data.Map{}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Write(ctx, t)
+			}
+			srcs.enableGracefulStop()
+			srcs.stop(ctx)
+			<-stopped
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NodeSettings has parameters of nodes which can be tuned at the scope of the
+// server, a topology, or a node. A parameter having its zero value isn't set
+// at the scope and is inherited from the outer scope. See Settings for
+// details.
+type NodeSettings struct {
+	// QueueSize is the capacity of input pipes of boxes and sinks connected
+	// without specifying it.
+	QueueSize int
+
+	// Recovery is the recovery policy of boxes and sinks.
+	Recovery *RecoveryPolicy
+
+	// Trace turns on or off tuple tracing of nodes.
+	Trace *bool
+}
+
+// NewNodeSettings creates NodeSettings from parameters. It accepts following
+// parameters:
+//
+//	queue_size: the capacity of input pipes
+//	recovery: a map having parameters of NewRecoveryPolicy
+//	trace: true to trace tuples
+func NewNodeSettings(params data.Map) (*NodeSettings, error) {
+	s := &NodeSettings{}
+	for k, v := range params {
+		switch k {
+		case "queue_size":
+			i, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("queue_size: %v", err)
+			}
+			s.QueueSize = int(i)
+
+		case "recovery":
+			m, err := data.AsMap(v)
+			if err != nil {
+				return nil, fmt.Errorf("recovery: %v", err)
+			}
+			p, err := NewRecoveryPolicy(m)
+			if err != nil {
+				return nil, fmt.Errorf("recovery: %v", err)
+			}
+			s.Recovery = p
+
+		case "trace":
+			b, err := data.AsBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("trace: %v", err)
+			}
+			s.Trace = &b
+
+		default:
+			return nil, fmt.Errorf("unknown node setting: %v", k)
+		}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate validates values of NodeSettings.
+func (s *NodeSettings) Validate() error {
+	if err := validateCapacity(s.QueueSize); err != nil {
+		return fmt.Errorf("queue_size: %v", err)
+	}
+	if s.Recovery != nil {
+		if err := s.Recovery.Validate(); err != nil {
+			return fmt.Errorf("recovery: %v", err)
+		}
+	}
+	return nil
+}
+
+// Map returns parameters set in the settings as data.Map having the same
+// keys as parameters of NewNodeSettings.
+func (s *NodeSettings) Map() data.Map {
+	m := data.Map{}
+	if s.QueueSize != 0 {
+		m["queue_size"] = data.Int(s.QueueSize)
+	}
+	if s.Recovery != nil {
+		m["recovery"] = s.Recovery.Map()
+	}
+	if s.Trace != nil {
+		m["trace"] = data.Bool(*s.Trace)
+	}
+	return m
+}
+
+// copy returns a deep copy of the settings so that callers cannot modify
+// settings stored in Settings.
+func (s NodeSettings) copy() NodeSettings {
+	if s.Recovery != nil {
+		p := *s.Recovery
+		s.Recovery = &p
+	}
+	if s.Trace != nil {
+		b := *s.Trace
+		s.Trace = &b
+	}
+	return s
+}
+
+// inherit returns settings having parameters of s and parameters of outer
+// which aren't set in s.
+func (s NodeSettings) inherit(outer NodeSettings) NodeSettings {
+	if s.QueueSize == 0 {
+		s.QueueSize = outer.QueueSize
+	}
+	if s.Recovery == nil {
+		s.Recovery = outer.Recovery
+	}
+	if s.Trace == nil {
+		s.Trace = outer.Trace
+	}
+	return s
+}
+
+// Settings manages NodeSettings of a topology in three layers: defaults given
+// by the server, overrides of the topology, and settings of each node. A
+// parameter which isn't set in a layer is inherited from the outer layer,
+// so that parameters can be tuned at the right scope without specifying them
+// for every node. Settings can be changed while the topology is running. Node
+// names are case-insensitive.
+//
+// Some parameters are also affected by other components of Context:
+//
+//	* A recovery policy set to a node by Context.Recovery takes precedence
+//	  over all layers.
+//	* Tuples are traced according to Context.Flags.TupleTrace when none of
+//	  the layers has Trace.
+//
+// Methods of Settings can be called on a nil pointer, in which case no
+// parameter is set.
+type Settings struct {
+	m        sync.RWMutex
+	defaults NodeSettings
+	topology NodeSettings
+	nodes    map[string]NodeSettings
+
+	// numRecoveries and numTraces are the numbers of layers having Recovery
+	// and Trace, respectively. They're used to skip resolving settings for
+	// each tuple when none of the layers has the parameter.
+	numRecoveries int32
+	numTraces     int32
+}
+
+// NewSettings returns a new Settings having no parameter.
+func NewSettings() *Settings {
+	return &Settings{
+		nodes: map[string]NodeSettings{},
+	}
+}
+
+// set calls f to update a layer and updates counters.
+func (s *Settings) set(ns *NodeSettings, f func(ns NodeSettings)) error {
+	if s == nil {
+		return errors.New("settings aren't supported")
+	}
+	if ns == nil {
+		ns = &NodeSettings{}
+	}
+	if err := ns.Validate(); err != nil {
+		return err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	f(ns.copy())
+	s.updateCounters()
+	return nil
+}
+
+// updateCounters must be called while s.m is locked.
+func (s *Settings) updateCounters() {
+	var r, t int32
+	count := func(ns NodeSettings) {
+		if ns.Recovery != nil {
+			r++
+		}
+		if ns.Trace != nil {
+			t++
+		}
+	}
+	count(s.defaults)
+	count(s.topology)
+	for _, ns := range s.nodes {
+		count(ns)
+	}
+	atomic.StoreInt32(&s.numRecoveries, r)
+	atomic.StoreInt32(&s.numTraces, t)
+}
+
+// SetDefaults replaces the layer of defaults given by the server. Passing
+// nil clears the layer.
+func (s *Settings) SetDefaults(ns *NodeSettings) error {
+	return s.set(ns, func(ns NodeSettings) {
+		s.defaults = ns
+	})
+}
+
+// Defaults returns the layer of defaults given by the server.
+func (s *Settings) Defaults() NodeSettings {
+	if s == nil {
+		return NodeSettings{}
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.defaults.copy()
+}
+
+// SetTopology replaces the layer of the topology. Passing nil clears the
+// layer.
+func (s *Settings) SetTopology(ns *NodeSettings) error {
+	return s.set(ns, func(ns NodeSettings) {
+		s.topology = ns
+	})
+}
+
+// Topology returns the layer of the topology. Parameters inherited from the
+// defaults aren't included.
+func (s *Settings) Topology() NodeSettings {
+	if s == nil {
+		return NodeSettings{}
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.topology.copy()
+}
+
+// SetNode replaces the layer of the node. Passing nil removes the layer.
+func (s *Settings) SetNode(nodeName string, ns *NodeSettings) error {
+	return s.set(ns, func(ns NodeSettings) {
+		name := strings.ToLower(nodeName)
+		if ns == (NodeSettings{}) {
+			delete(s.nodes, name)
+		} else {
+			s.nodes[name] = ns
+		}
+	})
+}
+
+// Node returns the layer of the node. Parameters inherited from outer layers
+// aren't included. It returns false when the node doesn't have its own
+// settings.
+func (s *Settings) Node(nodeName string) (NodeSettings, bool) {
+	if s == nil {
+		return NodeSettings{}, false
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	ns, ok := s.nodes[strings.ToLower(nodeName)]
+	return ns.copy(), ok
+}
+
+// Resolve returns settings of the node including parameters inherited from
+// outer layers. An empty name returns settings of the topology.
+func (s *Settings) Resolve(nodeName string) NodeSettings {
+	if s == nil {
+		return NodeSettings{}
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	ns := s.nodes[strings.ToLower(nodeName)]
+	return ns.inherit(s.topology).inherit(s.defaults).copy()
+}
+
+// recovery returns the recovery policy of the node resolved from all layers.
+func (s *Settings) recovery(nodeName string) (*RecoveryPolicy, bool) {
+	if s == nil || atomic.LoadInt32(&s.numRecoveries) == 0 {
+		return nil, false
+	}
+	p := s.Resolve(nodeName).Recovery
+	return p, p != nil
+}
+
+// trace returns whether tuples passing the node are traced. It returns false
+// as the second value when none of the layers has Trace.
+func (s *Settings) trace(nodeName string) (bool, bool) {
+	if s == nil || atomic.LoadInt32(&s.numTraces) == 0 {
+		return false, false
+	}
+	t := s.Resolve(nodeName).Trace
+	if t == nil {
+		return false, false
+	}
+	return *t, true
+}
+
+// Status returns all layers of the settings. It has "defaults", "topology",
+// and "nodes" keyed by node names.
+func (s *Settings) Status() data.Map {
+	nodes := data.Map{}
+	m := data.Map{
+		"defaults": data.Map{},
+		"topology": data.Map{},
+		"nodes":    nodes,
+	}
+	if s == nil {
+		return m
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	m["defaults"] = s.defaults.Map()
+	m["topology"] = s.topology.Map()
+	for name, ns := range s.nodes {
+		nodes[name] = ns.Map()
+	}
+	return m
+}
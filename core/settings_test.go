@@ -0,0 +1,214 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestNewNodeSettings(t *testing.T) {
+	Convey("Given parameters of node settings", t, func() {
+		Convey("When all parameters are valid", func() {
+			s, err := NewNodeSettings(data.Map{
+				"queue_size": data.Int(64),
+				"trace":      data.True,
+				"recovery": data.Map{
+					"policy":       data.String("restart_node"),
+					"max_restarts": data.Int(3),
+				},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(s.QueueSize, ShouldEqual, 64)
+				So(*s.Trace, ShouldBeTrue)
+				So(s.Recovery.Type, ShouldEqual, RPRestartNode)
+				So(s.Recovery.MaxRestarts, ShouldEqual, 3)
+			})
+
+			Convey("Then Map should return the same parameters", func() {
+				m := s.Map()
+				So(m["queue_size"], ShouldEqual, data.Int(64))
+				So(m["trace"], ShouldEqual, data.True)
+				So(m["recovery"].(data.Map)["policy"], ShouldEqual, data.String("restart_node"))
+			})
+		})
+
+		Convey("When parameters are empty", func() {
+			s, err := NewNodeSettings(data.Map{})
+			So(err, ShouldBeNil)
+
+			Convey("Then nothing should be set", func() {
+				So(*s, ShouldResemble, NodeSettings{})
+				So(s.Map(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When parameters are invalid", func() {
+			for _, m := range []data.Map{
+				{"queue_size": data.Int(-1)},
+				{"queue_size": data.Int(MaxCapacity + 1)},
+				{"trace": data.String("on")},
+				{"recovery": data.Map{"policy": data.String("unknown")}},
+				{"unknown": data.Int(1)},
+			} {
+				_, err := NewNodeSettings(m)
+
+				Convey("Then it should fail: "+m.String(), func() {
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func TestSettings(t *testing.T) {
+	Convey("Given settings having all layers", t, func() {
+		on, off := true, false
+		s := NewSettings()
+		So(s.SetDefaults(&NodeSettings{
+			QueueSize: 16,
+			Recovery:  &RecoveryPolicy{Type: RPRestartNode},
+			Trace:     &off,
+		}), ShouldBeNil)
+		So(s.SetTopology(&NodeSettings{QueueSize: 32}), ShouldBeNil)
+		So(s.SetNode("Box", &NodeSettings{Trace: &on}), ShouldBeNil)
+
+		Convey("When resolving settings of a node having its own layer", func() {
+			ns := s.Resolve("box")
+
+			Convey("Then parameters should be inherited from outer layers", func() {
+				So(ns.QueueSize, ShouldEqual, 32)
+				So(ns.Recovery.Type, ShouldEqual, RPRestartNode)
+				So(*ns.Trace, ShouldBeTrue)
+			})
+		})
+
+		Convey("When resolving settings of a node without its own layer", func() {
+			ns := s.Resolve("sink")
+
+			Convey("Then it should be the same as the topology", func() {
+				So(ns, ShouldResemble, s.Resolve(""))
+				So(*ns.Trace, ShouldBeFalse)
+			})
+		})
+
+		Convey("When modifying resolved settings", func() {
+			ns := s.Resolve("box")
+			ns.Recovery.Type = RPHalt
+			*ns.Trace = false
+
+			Convey("Then stored settings shouldn't be changed", func() {
+				ns := s.Resolve("box")
+				So(ns.Recovery.Type, ShouldEqual, RPRestartNode)
+				So(*ns.Trace, ShouldBeTrue)
+			})
+		})
+
+		Convey("When removing the layer of the node", func() {
+			So(s.SetNode("box", nil), ShouldBeNil)
+
+			Convey("Then the node should inherit all parameters", func() {
+				_, ok := s.Node("box")
+				So(ok, ShouldBeFalse)
+				So(*s.Resolve("box").Trace, ShouldBeFalse)
+			})
+		})
+
+		Convey("When setting invalid settings", func() {
+			err := s.SetTopology(&NodeSettings{QueueSize: -1})
+
+			Convey("Then it should fail without changing the layer", func() {
+				So(err, ShouldNotBeNil)
+				So(s.Topology().QueueSize, ShouldEqual, 32)
+			})
+		})
+
+		Convey("When getting the status", func() {
+			st := s.Status()
+
+			Convey("Then it should have all layers", func() {
+				So(st["defaults"].(data.Map)["queue_size"], ShouldEqual, data.Int(16))
+				So(st["topology"], ShouldResemble, data.Map{"queue_size": data.Int(32)})
+				So(st["nodes"], ShouldResemble, data.Map{
+					"box": data.Map{"trace": data.True},
+				})
+			})
+		})
+	})
+}
+
+func TestContextSettings(t *testing.T) {
+	Convey("Given a topology whose context has settings", t, func() {
+		ctx := NewContext(&ContextConfig{
+			DefaultSettings:  &NodeSettings{QueueSize: 16},
+			TopologySettings: &NodeSettings{QueueSize: 32},
+		})
+		t, err := NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+		so := NewTupleIncrementalEmitterSource(freshTuples())
+		_, err = t.AddSource("source", so, nil)
+		So(err, ShouldBeNil)
+
+		queueSize := func(receiver string) interface{} {
+			for _, e := range t.Graph().Edges {
+				if e.Receiver == receiver {
+					return e.Stats["queue_size"]
+				}
+			}
+			return nil
+		}
+
+		Convey("When a node has its own queue size", func() {
+			So(ctx.Settings.SetNode("sink", &NodeSettings{QueueSize: 64}), ShouldBeNil)
+			sn, err := t.AddSink("sink", NewTupleCollectorSink(), nil)
+			So(err, ShouldBeNil)
+			So(sn.Input("source", nil), ShouldBeNil)
+			bn, err := t.AddBox("box", BoxFunc(forwardBox), nil)
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+
+			Convey("Then its input pipe should have the node's size", func() {
+				So(queueSize("sink"), ShouldEqual, 64)
+			})
+
+			Convey("Then other nodes should have the topology's size", func() {
+				So(ctx.DefaultQueueSize(), ShouldEqual, 32)
+				So(queueSize("box"), ShouldEqual, 32)
+			})
+		})
+
+		Convey("When tracing is only enabled for a node", func() {
+			on := true
+			So(ctx.Settings.SetNode("sink", &NodeSettings{Trace: &on}), ShouldBeNil)
+			si := NewTupleCollectorSink()
+			sn, err := t.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sn.Input("source", nil), ShouldBeNil)
+			so.EmitTuples(1)
+			si.Wait(1)
+
+			Convey("Then only the node should add trace events", func() {
+				So(ctx.Traced("sink"), ShouldBeTrue)
+				So(ctx.Traced("source"), ShouldBeFalse)
+				tr := si.get(0).Trace
+				So(len(tr), ShouldEqual, 1)
+				So(tr[0].Msg, ShouldEqual, "sink")
+			})
+		})
+
+		Convey("When tracing is disabled for a node in a traced topology", func() {
+			off := false
+			ctx.Flags.TupleTrace.Set(true)
+			So(ctx.Settings.SetNode("source", &NodeSettings{Trace: &off}), ShouldBeNil)
+
+			Convey("Then the node shouldn't be traced", func() {
+				So(ctx.Traced("source"), ShouldBeFalse)
+				So(ctx.Traced("sink"), ShouldBeTrue)
+			})
+		})
+	})
+}
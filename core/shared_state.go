@@ -112,6 +112,13 @@ type SharedStateRegistry interface {
 	// and an error. If the registry doesn't have a SharedState having the name,
 	// it returns a nil SharedState and NotExistError.
 	Remove(name string) (SharedState, error)
+
+	// Update updates parameters of a SharedState having the name. The state
+	// must implement Updater. When it also implements UpdateValidator,
+	// parameters are validated before being applied. Updates of states in
+	// the registry are serialized. It returns NotExistError if the registry
+	// doesn't have the state.
+	Update(name string, params data.Map) error
 }
 
 type defaultSharedStateInfo struct {
@@ -123,6 +130,9 @@ type defaultSharedStateRegistry struct {
 	ctx    *Context
 	m      sync.RWMutex
 	states map[string]*defaultSharedStateInfo
+
+	// updateMutex serializes Update.
+	updateMutex sync.Mutex
 }
 
 // NewDefaultSharedStateRegistry create a default registry of SharedStates.
@@ -244,6 +254,16 @@ func (r *defaultSharedStateRegistry) Remove(name string) (SharedState, error) {
 	return s, nil
 }
 
+func (r *defaultSharedStateRegistry) Update(name string, params data.Map) error {
+	s, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+	r.updateMutex.Lock()
+	defer r.updateMutex.Unlock()
+	return applyUpdate(r.ctx, name, s, params)
+}
+
 // sharedStateSink represents a shared state. sharedStateSink refers to a shared state by name.
 type sharedStateSink struct {
 	name string
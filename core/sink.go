@@ -9,3 +9,64 @@ package core
 type Sink interface {
 	WriteCloser
 }
+
+// Flushable is an optional interface of a Sink buffering tuples, e.g. to
+// write them to an external system in batches. Flush writes tuples buffered
+// so far so that they're persisted at a well-defined point. It's called:
+//
+//  1. before Close when the sink stops, including when the topology stops,
+//  2. by SinkNode.Flush, e.g. when a savepoint of the topology is taken,
+//  3. every SinkConfig.FlushInterval when it's positive, or when
+//     SinkConfig.AdaptiveBatching decides to flush it.
+//
+// Flush can be called concurrently with Write, but calls to Flush are
+// serialized. It isn't called after Close.
+type Flushable interface {
+	// Flush writes all buffered tuples. An error means that some of them
+	// might not have been written.
+	Flush(ctx *Context) error
+}
+
+// Connector is an optional interface of a Sink connecting to an external
+// system, such as a database or a message broker. Sinks usually connect
+// lazily on the first Write, which fails while the external system is slow
+// to accept connections. Implementing Connector allows a sink to connect
+// before its upstream sources start to generate tuples (see SinkNode.Connect).
+//
+// Connect can be called multiple times and concurrently with Write. It should
+// return nil when the sink is already connected.
+type Connector interface {
+	// Connect connects the sink to the external system. It shouldn't block
+	// for a long time since it's retried until the sink becomes ready.
+	Connect(ctx *Context) error
+}
+
+// findConnector returns the Connector of v. It looks into wrapped sinks when
+// v itself doesn't implement Connector.
+func findConnector(v interface{}) (Connector, bool) {
+	for {
+		if c, ok := v.(Connector); ok {
+			return c, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
+
+// findFlushable returns the Flushable of v. It looks into wrapped sinks when
+// v itself doesn't implement Flushable.
+func findFlushable(v interface{}) (Flushable, bool) {
+	for {
+		if f, ok := v.(Flushable); ok {
+			return f, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
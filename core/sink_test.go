@@ -0,0 +1,267 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flushingSink buffers tuples and moves them to flushed when it's flushed.
+type flushingSink struct {
+	m                 sync.Mutex
+	buffered          []*Tuple
+	flushed           []*Tuple
+	flushErr          error
+	closed            bool
+	flushedAfterClose bool
+}
+
+func (s *flushingSink) Write(ctx *Context, t *Tuple) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.buffered = append(s.buffered, t)
+	return nil
+}
+
+func (s *flushingSink) Flush(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		s.flushedAfterClose = true
+	}
+	if s.flushErr != nil {
+		return s.flushErr
+	}
+	s.flushed = append(s.flushed, s.buffered...)
+	s.buffered = nil
+	return nil
+}
+
+func (s *flushingSink) Close(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *flushingSink) lens() (int, int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.buffered), len(s.flushed)
+}
+
+// waitBuffered waits until the sink buffers n tuples.
+func (s *flushingSink) waitBuffered(n int) {
+	for i := 0; i < 1000; i++ {
+		if b, _ := s.lens(); b >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFlushableSink(t *testing.T) {
+	Convey("Given a topology", t, func() {
+		clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		tp, err := NewDefaultTopology(NewContext(&ContextConfig{Clock: clock}), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+		n := len(freshTuples())
+		son, err := tp.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		addSink := func(s Sink, config *SinkConfig) SinkNode {
+			sn, err := tp.AddSink("sink", s, config)
+			So(err, ShouldBeNil)
+			So(sn.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+			return sn
+		}
+		flushStatus := func(sn SinkNode) data.Map {
+			v, err := sn.Status().Get(data.MustCompilePath("flush"))
+			So(err, ShouldBeNil)
+			m, err := data.AsMap(v)
+			So(err, ShouldBeNil)
+			return m
+		}
+
+		Convey("When adding a flushable sink having a flush interval", func() {
+			s := &flushingSink{}
+			sn := addSink(s, &SinkConfig{FlushInterval: time.Second})
+			s.waitBuffered(n)
+
+			Convey("Then the sink should be flushed periodically", func() {
+				_, f := s.lens()
+				So(f, ShouldEqual, 0)
+				clock.Advance(time.Second)
+				for i := 0; i < 1000; i++ {
+					if _, f = s.lens(); f == n {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				So(f, ShouldEqual, n)
+
+				st := flushStatus(sn)
+				So(st["interval"], ShouldEqual, data.String("1s"))
+				So(st["num_flushes"], ShouldEqual, data.Int(1))
+				So(st["num_errors"], ShouldEqual, data.Int(0))
+			})
+		})
+
+		Convey("When adding a flushable sink without a flush interval", func() {
+			s := &flushingSink{}
+			sn := addSink(s, nil)
+			s.waitBuffered(n)
+
+			Convey("Then the sink should be flushed by SinkNode.Flush", func() {
+				So(sn.Flush(), ShouldBeNil)
+				b, f := s.lens()
+				So(b, ShouldEqual, 0)
+				So(f, ShouldEqual, n)
+			})
+
+			Convey("Then the sink should be flushed before it's closed", func() {
+				So(tp.Stop(), ShouldBeNil)
+				b, f := s.lens()
+				So(b, ShouldEqual, 0)
+				So(f, ShouldEqual, n)
+				So(flushStatus(sn)["num_flushes"], ShouldEqual, data.Int(1))
+
+				Convey("And it shouldn't be flushed after it's closed", func() {
+					So(sn.Flush(), ShouldBeNil)
+					So(s.flushedAfterClose, ShouldBeFalse)
+					So(flushStatus(sn)["num_flushes"], ShouldEqual, data.Int(1))
+				})
+			})
+
+			Convey("Then errors of flushes should be reported", func() {
+				s.m.Lock()
+				s.flushErr = errors.New("service unavailable")
+				s.m.Unlock()
+				So(sn.Flush(), ShouldNotBeNil)
+
+				st := flushStatus(sn)
+				So(st["num_errors"], ShouldEqual, data.Int(1))
+				So(st["last_error"], ShouldEqual, data.String("service unavailable"))
+			})
+		})
+
+		Convey("When adding a wrapped flushable sink", func() {
+			s := &flushingSink{}
+			sn := addSink(NewIdempotentSink(s, func(t *Tuple) (data.Value, error) {
+				return data.Null{}, nil
+			}, "keys"), nil)
+			s.waitBuffered(n)
+
+			Convey("Then the wrapped sink should be flushed", func() {
+				So(sn.Flush(), ShouldBeNil)
+				_, f := s.lens()
+				So(f, ShouldEqual, n)
+			})
+		})
+
+		Convey("When adding a sink which isn't flushable", func() {
+			sn := addSink(NewTupleCollectorSink(), nil)
+
+			Convey("Then flushing it should do nothing", func() {
+				So(sn.Flush(), ShouldBeNil)
+				_, ok := sn.Status()["flush"]
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When adding a sink having a negative flush interval", func() {
+			_, err := tp.AddSink("sink", &flushingSink{}, &SinkConfig{FlushInterval: -time.Second})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+// connectorSink fails to connect until ready is set and then reports its
+// health by healthErr.
+type connectorSink struct {
+	ready     bool
+	attempts  int
+	healthErr error
+}
+
+func (s *connectorSink) Connect(ctx *Context) error {
+	s.attempts++
+	if !s.ready {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (s *connectorSink) Write(ctx *Context, t *Tuple) error {
+	return nil
+}
+
+func (s *connectorSink) Close(ctx *Context) error {
+	return nil
+}
+
+func (s *connectorSink) CheckHealth(ctx *Context) error {
+	return s.healthErr
+}
+
+func TestConnectorSink(t *testing.T) {
+	Convey("Given a topology", t, func() {
+		tp, err := NewDefaultTopology(NewContext(nil), "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		Convey("When adding a sink implementing Connector", func() {
+			s := &connectorSink{}
+			sn, err := tp.AddSink("sink", s, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then Connect should fail until the sink is connected", func() {
+				So(sn.Connect(), ShouldNotBeNil)
+				s.ready = true
+				So(sn.Connect(), ShouldBeNil)
+				So(s.attempts, ShouldEqual, 2)
+			})
+
+			Convey("Then Connect should fail when the sink isn't healthy", func() {
+				s.ready = true
+				s.healthErr = errors.New("unhealthy")
+				So(sn.Connect(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When adding a wrapped sink implementing Connector", func() {
+			s := &connectorSink{ready: true}
+			sn, err := tp.AddSink("sink", NewIdempotentSink(s, func(t *Tuple) (data.Value, error) {
+				return data.Null{}, nil
+			}, "keys"), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the wrapped sink should be connected", func() {
+				So(sn.Connect(), ShouldBeNil)
+				So(s.attempts, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When adding a sink which doesn't implement Connector", func() {
+			sn, err := tp.AddSink("sink", NewTupleCollectorSink(), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should always be ready", func() {
+				So(sn.Connect(), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -2,8 +2,10 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"sync"
+	"sync/atomic"
 )
 
 // A Source describes an entity that inserts data into a topology
@@ -29,6 +31,54 @@ type Source interface {
 	Stop(ctx *Context) error
 }
 
+// Preparer is an optional interface of a Source which has to be prepared
+// before generating a stream, e.g. to establish subscriptions to a message
+// broker. Prepare is called right before GenerateStream. When it fails, the
+// Source stops without GenerateStream being called and the error is reported
+// as "prepare_error" in the status of the source node.
+type Preparer interface {
+	Prepare(ctx *Context) error
+}
+
+// Cleaner is an optional interface of a Source which has to clean up
+// resources after generating a stream, e.g. to commit the final offsets.
+// Cleanup is called after GenerateStream returns, which usually happens after
+// Stop is called. It isn't called when Prepare of a Preparer failed. An error
+// is reported as "cleanup_error" in the status of the source node.
+type Cleaner interface {
+	Cleanup(ctx *Context) error
+}
+
+// findPreparer returns the Preparer of v. It looks into wrapped sources when
+// v itself doesn't implement Preparer.
+func findPreparer(v interface{}) (Preparer, bool) {
+	for {
+		if p, ok := v.(Preparer); ok {
+			return p, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
+
+// findCleaner returns the Cleaner of v. It looks into wrapped sources when v
+// itself doesn't implement Cleaner.
+func findCleaner(v interface{}) (Cleaner, bool) {
+	for {
+		if c, ok := v.(Cleaner); ok {
+			return c, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
+
 // RewindableSource is a Source which can be rewound and generate the same
 // stream from the beginning again (e.g. file based source).
 //
@@ -49,6 +99,19 @@ type RewindableSource interface {
 	Rewind(ctx *Context) error
 }
 
+// OffsetRewindableSource is a RewindableSource which can be rewound to a
+// specific offset rather than the beginning of the stream. See Tuple.Offset
+// for offsets.
+type OffsetRewindableSource interface {
+	RewindableSource
+
+	// RewindTo rewinds the stream so that the next tuple written by the
+	// Source has the given offset. Tuples before the offset are discarded
+	// even if they're generated again. Other than that, it behaves in the
+	// same way as Rewind. The offset starts from 1.
+	RewindTo(ctx *Context, offset int64) error
+}
+
 type rewindableSource struct {
 	rwm              sync.RWMutex
 	state            *topologyStateHolder
@@ -59,6 +122,14 @@ type rewindableSource struct {
 	// Rewind method. When this is false, Rewind method always returns an error.
 	rewindEnabled bool
 
+	// offset is the offset of the last tuple written by the source since
+	// it's rewound. It's accessed atomically.
+	offset int64
+
+	// skipUntil is the offset set by RewindTo. Tuples having offsets less
+	// than it are discarded.
+	skipUntil int64
+
 	forceStop chan struct{}
 	source    Source
 
@@ -66,8 +137,9 @@ type rewindableSource struct {
 }
 
 var (
-	_ RewindableSource = &rewindableSource{}
-	_ Statuser         = &rewindableSource{}
+	_ OffsetRewindableSource = &rewindableSource{}
+	_ Statuser               = &rewindableSource{}
+	_ updaterWrapper         = &rewindableSource{}
 )
 
 var (
@@ -103,6 +175,15 @@ var (
 //
 //	* Statuser
 //
+// Parameters of the given source can be updated by SourceNode.Update when it
+// implements Updater.
+//
+// The returned source also implements OffsetRewindableSource. It sets offsets
+// to tuples in the order they're written unless the original source sets
+// Tuple.Offset by itself. RewindTo regenerates the stream from the beginning
+// and discards tuples before the offset, so the original source doesn't have
+// to support seeking.
+//
 // Known issue: There's one problem with NewRewindableSource. Stop method could
 // block when the original source's GenerateStream doesn't generate any tuple
 // (i.e. doesn't write any tuple) without returning from GenerateStream since
@@ -163,12 +244,21 @@ func (r *rewindableSource) GenerateStream(ctx *Context, w Writer) error {
 		if err != nil {
 			return err
 		}
+
+		offset := atomic.AddInt64(&r.offset, 1)
+		if t.Offset == 0 {
+			t.Offset = offset
+		}
+		if t.Offset < atomic.LoadInt64(&r.skipUntil) {
+			return nil // discard tuples already processed
+		}
 		return w.Write(ctx, t) // pass the tuple to the original writer
 	})
 
 	ch := make(chan error, 1)
 	go func() {
 		for {
+			atomic.StoreInt64(&r.offset, 0)
 			if err := r.source.GenerateStream(ctx, rewindWriter); err != nil {
 				if err != ErrSourceRewound {
 					r.rwm.Lock()
@@ -258,12 +348,24 @@ func (r *rewindableSource) Resume(ctx *Context) error {
 }
 
 func (r *rewindableSource) Rewind(ctx *Context) error {
+	return r.rewindTo(0)
+}
+
+func (r *rewindableSource) RewindTo(ctx *Context, offset int64) error {
+	if offset < 1 {
+		return fmt.Errorf("the offset must be positive: %v", offset)
+	}
+	return r.rewindTo(offset)
+}
+
+func (r *rewindableSource) rewindTo(offset int64) error {
 	r.rwm.Lock()
 	defer r.rwm.Unlock()
 	if !r.rewindEnabled {
 		return errors.New("this source doesn't support rewind")
 	}
 
+	atomic.StoreInt64(&r.skipUntil, offset)
 	r.rewind = true
 	r.state.cond.Broadcast()
 
@@ -281,6 +383,7 @@ func (r *rewindableSource) Status() data.Map {
 	m := data.Map{
 		"rewindable":         data.Bool(enabled),
 		"waiting_for_rewind": data.Bool(waiting),
+		"offset":             data.Int(atomic.LoadInt64(&r.offset)),
 	}
 	if s, ok := r.source.(Statuser); ok {
 		m["internal_source"] = s.Status()
@@ -288,6 +391,10 @@ func (r *rewindableSource) Status() data.Map {
 	return m
 }
 
+func (r *rewindableSource) unwrap() interface{} {
+	return r.source
+}
+
 // ImplementSourceStop implements Stop method of a Source in a thread-safe
 // manner on behalf of the given Source. Source passed to this function must
 // follow the rule described in NewRewindableSource with one exception that
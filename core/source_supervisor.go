@@ -0,0 +1,294 @@
+package core
+
+import (
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultReconnectInitialBackoff is the default value of
+	// SupervisorConfig.InitialBackoff.
+	DefaultReconnectInitialBackoff = time.Second
+
+	// DefaultReconnectMaxBackoff is the default value of
+	// SupervisorConfig.MaxBackoff.
+	DefaultReconnectMaxBackoff = time.Minute
+)
+
+// SupervisorConfig has parameters of a source supervised by
+// NewSupervisedSource.
+type SupervisorConfig struct {
+	// MaxRetries is the maximum number of consecutive retries. The supervisor
+	// gives up and returns the error from GenerateStream when the source
+	// fails after it's retried MaxRetries times without writing any tuple.
+	// When it's 0, the number of retries isn't limited.
+	MaxRetries int
+
+	// InitialBackoff is the duration to wait before the first retry.
+	// DefaultReconnectInitialBackoff is used when it's 0.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum duration to wait before a retry.
+	// DefaultReconnectMaxBackoff is used when it's 0.
+	MaxBackoff time.Duration
+
+	// Jitter is the ratio of the random variation added to each backoff. For
+	// example, when it's 0.2, the actual backoff is randomly chosen from
+	// 80% to 120% of the original one. It must be in [0, 1].
+	Jitter float64
+}
+
+// NewSupervisorConfig creates a SupervisorConfig from parameters. It accepts
+// following parameters:
+//
+//	max_retries: the maximum number of consecutive retries
+//	initial_backoff: the initial backoff in seconds or as a duration string
+//	max_backoff: the maximum backoff in seconds or as a duration string
+//	jitter: the ratio of the random variation of backoffs
+func NewSupervisorConfig(params data.Map) (*SupervisorConfig, error) {
+	c := &SupervisorConfig{}
+	for k, v := range params {
+		switch k {
+		case "max_retries":
+			i, err := data.AsInt(v)
+			if err != nil {
+				return nil, fmt.Errorf("max_retries: %v", err)
+			}
+			c.MaxRetries = int(i)
+
+		case "initial_backoff":
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("initial_backoff: %v", err)
+			}
+			c.InitialBackoff = d
+
+		case "max_backoff":
+			d, err := data.ToDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("max_backoff: %v", err)
+			}
+			c.MaxBackoff = d
+
+		case "jitter":
+			f, err := data.ToFloat(v)
+			if err != nil {
+				return nil, fmt.Errorf("jitter: %v", err)
+			}
+			c.Jitter = f
+
+		default:
+			return nil, fmt.Errorf("unknown supervisor parameter: %v", k)
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate validates values of SupervisorConfig.
+func (c *SupervisorConfig) Validate() error {
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative: %v", c.MaxRetries)
+	}
+	if c.InitialBackoff < 0 {
+		return fmt.Errorf("initial_backoff must not be negative: %v", c.InitialBackoff)
+	}
+	if c.MaxBackoff < 0 {
+		return fmt.Errorf("max_backoff must not be negative: %v", c.MaxBackoff)
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return fmt.Errorf("jitter must be in [0, 1]: %v", c.Jitter)
+	}
+	return nil
+}
+
+// backoff returns the duration to wait before the (n+1)-th retry. r is a
+// random number in [0, 1) used for the jitter.
+func (c *SupervisorConfig) backoff(n int64, r float64) time.Duration {
+	d := c.InitialBackoff
+	if d == 0 {
+		d = DefaultReconnectInitialBackoff
+	}
+	max := c.MaxBackoff
+	if max == 0 {
+		max = DefaultReconnectMaxBackoff
+	}
+	for i := int64(0); i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return time.Duration(float64(d) * (1 + c.Jitter*(2*r-1)))
+}
+
+// Map returns the config as data.Map having the same keys as parameters of
+// NewSupervisorConfig.
+func (c *SupervisorConfig) Map() data.Map {
+	return data.Map{
+		"max_retries":     data.Int(c.MaxRetries),
+		"initial_backoff": data.String(c.InitialBackoff.String()),
+		"max_backoff":     data.String(c.MaxBackoff.String()),
+		"jitter":          data.Float(c.Jitter),
+	}
+}
+
+type supervisedSource struct {
+	// numRetries must be the first field for 64-bit alignment.
+	numRetries    int64
+	numReconnects int64
+
+	source Source
+	config SupervisorConfig
+
+	// nodeName is the name of the source node. It's set when the source is
+	// added to a topology so that reconnect events can refer to the node.
+	nodeName string
+
+	stopped AtomicFlag
+	stop    chan struct{}
+
+	m         sync.Mutex
+	lastError error
+	rand      *rand.Rand
+}
+
+var (
+	_ Statuser       = &supervisedSource{}
+	_ updaterWrapper = &supervisedSource{}
+)
+
+// NewSupervisedSource returns a source supervising the given source. When
+// GenerateStream of the source returns an error, the supervisor calls it
+// again after waiting for a backoff on the Clock of the Context. The backoff
+// starts with config.InitialBackoff and doubles on each consecutive retry up
+// to config.MaxBackoff. The number of consecutive retries is reset once the
+// source writes a tuple. The supervisor gives up after config.MaxRetries
+// consecutive retries and returns the last error from GenerateStream.
+//
+// It allows sources connecting to external services to simply return an
+// error from GenerateStream when the connection is lost instead of
+// implementing their own reconnect loops. The source passed to this function
+// must satisfy the following requirements:
+//
+//  1. Its GenerateStream can safely be called multiple times.
+//  2. Its GenerateStream must return when ErrSourceStopped is returned from
+//     the Writer.
+//
+// GenerateStream of the supervisor returns nil when the source returns nil,
+// which means that the source has generated all tuples. Errors returned from
+// the source after Stop is called are also ignored.
+//
+// Each retry is reported as a system event having the type
+// "source_reconnecting". A config having all zero values is used when config
+// is nil.
+func NewSupervisedSource(s Source, config *SupervisorConfig) Source {
+	ss := &supervisedSource{
+		source: s,
+		stop:   make(chan struct{}),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if config != nil {
+		ss.config = *config
+	}
+	return ss
+}
+
+func (s *supervisedSource) GenerateStream(ctx *Context, w Writer) error {
+	var written AtomicFlag
+	sw := WriterFunc(func(ctx *Context, t *Tuple) error {
+		if s.stopped.Enabled() {
+			return ErrSourceStopped
+		}
+		written.Set(true)
+		return w.Write(ctx, t)
+	})
+
+	for {
+		if s.stopped.Enabled() {
+			return nil
+		}
+		err := s.source.GenerateStream(ctx, sw)
+		if err == nil || err == ErrSourceStopped || s.stopped.Enabled() {
+			return nil
+		}
+
+		if written.Enabled() {
+			written.Set(false)
+			atomic.StoreInt64(&s.numRetries, 0)
+		}
+		n := atomic.LoadInt64(&s.numRetries)
+		if s.config.MaxRetries > 0 && n >= int64(s.config.MaxRetries) {
+			return fmt.Errorf("the source gave up reconnecting after %v retries: %v", n, err)
+		}
+
+		s.m.Lock()
+		s.lastError = err
+		backoff := s.config.backoff(n, s.rand.Float64())
+		s.m.Unlock()
+		atomic.AddInt64(&s.numRetries, 1)
+		atomic.AddInt64(&s.numReconnects, 1)
+
+		ctx.nodeErrLog(NTSource, s.nodeName, err).WithField("backoff", backoff.String()).
+			Warn("Reconnecting the source after an error")
+		ctx.ReportSystemEvent(SETSourceReconnecting, data.Map{
+			"node_type":   data.String(NTSource.String()),
+			"node_name":   data.String(s.nodeName),
+			"error":       data.String(err.Error()),
+			"num_retries": data.Int(n + 1),
+			"backoff":     data.String(backoff.String()),
+		})
+
+		timer := ctx.Clock().NewTimer(backoff)
+		select {
+		case <-timer.C():
+		case <-s.stop:
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+func (s *supervisedSource) Stop(ctx *Context) error {
+	s.m.Lock()
+	if s.stopped.Enabled() {
+		s.m.Unlock()
+		return nil
+	}
+	s.stopped.Set(true)
+	close(s.stop)
+	s.m.Unlock()
+	return s.source.Stop(ctx)
+}
+
+func (s *supervisedSource) Status() data.Map {
+	s.m.Lock()
+	lastErr := s.lastError
+	s.m.Unlock()
+
+	sv := data.Map{
+		"num_retries":    data.Int(atomic.LoadInt64(&s.numRetries)),
+		"num_reconnects": data.Int(atomic.LoadInt64(&s.numReconnects)),
+		"config":         s.config.Map(),
+	}
+	if lastErr != nil {
+		sv["last_error"] = data.String(lastErr.Error())
+	}
+	m := data.Map{
+		"supervisor": sv,
+	}
+	if st, ok := s.source.(Statuser); ok {
+		m["internal_source"] = st.Status()
+	}
+	return m
+}
+
+func (s *supervisedSource) unwrap() interface{} {
+	return s.source
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakySource returns an error from GenerateStream until it's called
+// numFailures times. Then, it writes all tuples and returns.
+type flakySource struct {
+	numFailures int32
+	numCalls    int32
+	stopped     int32
+	tuples      []*Tuple
+}
+
+func (s *flakySource) GenerateStream(ctx *Context, w Writer) error {
+	if atomic.AddInt32(&s.numCalls, 1) <= s.numFailures {
+		return errors.New("connection refused")
+	}
+	for _, t := range s.tuples {
+		if err := w.Write(ctx, t.Copy()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *flakySource) Stop(ctx *Context) error {
+	atomic.StoreInt32(&s.stopped, 1)
+	return nil
+}
+
+func TestSupervisorConfig(t *testing.T) {
+	Convey("Given parameters of a supervisor", t, func() {
+		params := data.Map{
+			"max_retries":     data.Int(5),
+			"initial_backoff": data.String("1s"),
+			"max_backoff":     data.Int(5),
+			"jitter":          data.Float(0.5),
+		}
+
+		Convey("When creating a config", func() {
+			c, err := NewSupervisorConfig(params)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the given values", func() {
+				So(c.MaxRetries, ShouldEqual, 5)
+				So(c.InitialBackoff, ShouldEqual, time.Second)
+				So(c.MaxBackoff, ShouldEqual, 5*time.Second)
+				So(c.Jitter, ShouldEqual, 0.5)
+			})
+
+			Convey("Then the backoff should double up to the max with jitter", func() {
+				So(c.backoff(0, 0.5), ShouldEqual, time.Second)
+				So(c.backoff(1, 0.5), ShouldEqual, 2*time.Second)
+				So(c.backoff(10, 0.5), ShouldEqual, 5*time.Second)
+				So(c.backoff(0, 0), ShouldEqual, 500*time.Millisecond)
+				So(c.backoff(1, 0.75), ShouldEqual, 2500*time.Millisecond)
+			})
+		})
+
+		Convey("When jitter is too large", func() {
+			params["jitter"] = data.Float(1.5)
+
+			Convey("Then creating a config should fail", func() {
+				_, err := NewSupervisorConfig(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When max_retries is negative", func() {
+			params["max_retries"] = data.Int(-1)
+
+			Convey("Then creating a config should fail", func() {
+				_, err := NewSupervisorConfig(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When an unknown parameter is given", func() {
+			params["foo"] = data.Int(1)
+
+			Convey("Then creating a config should fail", func() {
+				_, err := NewSupervisorConfig(params)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSupervisedSource(t *testing.T) {
+	Convey("Given a topology with a system event source", t, func() {
+		clock := NewFakeClock(time.Now())
+		ctx := NewContext(&ContextConfig{
+			Clock: clock,
+		})
+		t, err := NewDefaultTopology(ctx, "supervisor")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		seso := NewSystemEventSource()
+		_, err = t.AddSource("events", seso, nil)
+		So(err, ShouldBeNil)
+		seso.(*systemEventSource).state.Wait(TSRunning)
+		esi := NewTupleCollectorSink()
+		esin, err := t.AddSink("event_sink", esi, nil)
+		So(err, ShouldBeNil)
+		So(esin.Input("events", nil), ShouldBeNil)
+
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+
+		Convey("When adding a supervised source failing twice", func() {
+			fs := &flakySource{
+				numFailures: 2,
+				tuples:      freshTuples(),
+			}
+			son, err := t.AddSource("flaky", NewSupervisedSource(fs, &SupervisorConfig{
+				InitialBackoff: time.Second,
+			}), nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("flaky", nil), ShouldBeNil)
+
+			Convey("Then it should be reconnected as the clock advances", func() {
+				clock.BlockUntil(1)
+				So(si.len(), ShouldEqual, 0)
+				clock.Advance(time.Second)
+				clock.BlockUntil(1)
+				clock.Advance(2 * time.Second)
+				si.Wait(len(freshTuples()))
+				So(si.len(), ShouldEqual, len(freshTuples()))
+				So(atomic.LoadInt32(&fs.numCalls), ShouldEqual, 3)
+
+				Convey("And the status should have the number of reconnects", func() {
+					v, err := son.Status().Get(data.MustCompilePath("source.supervisor.num_reconnects"))
+					So(err, ShouldBeNil)
+					So(v, ShouldEqual, 2)
+				})
+
+				Convey("And reconnect events should be reported", func() {
+					var events []data.Map
+					for i := 0; i < 1000 && len(events) < 2; i++ {
+						time.Sleep(time.Millisecond)
+						events = nil
+						esi.forEachTuple(func(t *Tuple) {
+							if t.Data["event_type"] == data.String("source_reconnecting") {
+								events = append(events, t.Data)
+							}
+						})
+					}
+					So(len(events), ShouldEqual, 2)
+					So(events[0]["node_name"], ShouldEqual, "flaky")
+					So(events[0]["error"], ShouldEqual, "connection refused")
+					So(events[1]["num_retries"], ShouldEqual, 2)
+					So(events[1]["backoff"], ShouldEqual, "2s")
+				})
+			})
+		})
+
+		Convey("When adding a supervised source failing more than the limit", func() {
+			fs := &flakySource{
+				numFailures: 100,
+			}
+			son, err := t.AddSource("flaky", NewSupervisedSource(fs, &SupervisorConfig{
+				MaxRetries:     2,
+				InitialBackoff: time.Second,
+			}), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should give up", func() {
+				clock.BlockUntil(1)
+				clock.Advance(time.Second)
+				clock.BlockUntil(1)
+				clock.Advance(2 * time.Second)
+				So(son.State().Wait(TSStopped), ShouldEqual, TSStopped)
+				So(atomic.LoadInt32(&fs.numCalls), ShouldEqual, 3)
+
+				st := son.Status()
+				So(st["error"], ShouldNotBeNil)
+				msg, err := data.AsString(st["error"])
+				So(err, ShouldBeNil)
+				So(msg, ShouldContainSubstring, "gave up")
+			})
+		})
+
+		Convey("When stopping a supervised source waiting for a backoff", func() {
+			fs := &flakySource{
+				numFailures: 100,
+			}
+			son, err := t.AddSource("flaky", NewSupervisedSource(fs, nil), nil)
+			So(err, ShouldBeNil)
+			clock.BlockUntil(1)
+			So(son.Stop(), ShouldBeNil)
+
+			Convey("Then it should stop without retrying", func() {
+				So(son.State().Get(), ShouldEqual, TSStopped)
+				So(atomic.LoadInt32(&fs.numCalls), ShouldEqual, 1)
+				So(atomic.LoadInt32(&fs.stopped), ShouldEqual, 1)
+			})
+		})
+	})
+}
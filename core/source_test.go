@@ -4,6 +4,7 @@ import (
 	"errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
 	"testing"
 	"time"
 )
@@ -290,3 +291,131 @@ func waitForLastTuple(si *TupleCollectorSink, t *Tuple) {
 		time.Sleep(time.Nanosecond)
 	}
 }
+
+// hookedSource records calls to its methods including Prepare and Cleanup.
+// It generates no tuples and blocks until it's stopped.
+type hookedSource struct {
+	prepareErr error
+	cleanupErr error
+	generating chan struct{}
+	stop       chan struct{}
+
+	m     sync.Mutex
+	calls []string
+}
+
+func (s *hookedSource) record(name string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.calls = append(s.calls, name)
+}
+
+func (s *hookedSource) called() []string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]string{}, s.calls...)
+}
+
+func (s *hookedSource) Prepare(ctx *Context) error {
+	s.record("prepare")
+	return s.prepareErr
+}
+
+func (s *hookedSource) GenerateStream(ctx *Context, w Writer) error {
+	s.record("generate")
+	close(s.generating)
+	<-s.stop
+	return nil
+}
+
+func (s *hookedSource) Stop(ctx *Context) error {
+	s.record("stop")
+	close(s.stop)
+	return nil
+}
+
+func (s *hookedSource) Cleanup(ctx *Context) error {
+	s.record("cleanup")
+	return s.cleanupErr
+}
+
+func TestSourcePrepareAndCleanup(t *testing.T) {
+	Convey("Given a default topology", t, func() {
+		tp, err := NewDefaultTopology(NewContext(nil), "dt1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+		newSource := func() *hookedSource {
+			return &hookedSource{
+				generating: make(chan struct{}),
+				stop:       make(chan struct{}),
+			}
+		}
+
+		Convey("When adding a source having hooks", func() {
+			s := newSource()
+			son, err := tp.AddSource("source", s, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be prepared before generating a stream", func() {
+				<-s.generating
+				So(s.called(), ShouldResemble, []string{"prepare", "generate"})
+			})
+
+			Convey("Then it should be cleaned up after it stops", func() {
+				<-s.generating
+				So(son.Stop(), ShouldBeNil)
+				So(s.called(), ShouldResemble, []string{"prepare", "generate", "stop", "cleanup"})
+				st := son.Status()
+				So(st, ShouldNotContainKey, "prepare_error")
+				So(st, ShouldNotContainKey, "cleanup_error")
+			})
+		})
+
+		Convey("When adding a wrapped source having hooks", func() {
+			s := newSource()
+			son, err := tp.AddSource("source", NewRewindableSource(s), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then hooks of the wrapped source should be called", func() {
+				<-s.generating
+				So(son.Stop(), ShouldBeNil)
+				So(s.called(), ShouldResemble, []string{"prepare", "generate", "stop", "cleanup"})
+			})
+		})
+
+		Convey("When adding a source failing to be prepared", func() {
+			s := newSource()
+			s.prepareErr = errors.New("cannot subscribe")
+			son, err := tp.AddSource("source", s, nil)
+			So(err, ShouldBeNil)
+			son.State().Wait(TSStopped)
+
+			Convey("Then it should stop without generating a stream", func() {
+				So(son.Stop(), ShouldBeNil)
+				So(s.called(), ShouldResemble, []string{"prepare"})
+			})
+
+			Convey("Then the error should be reported as a prepare error", func() {
+				st := son.Status()
+				So(st["prepare_error"], ShouldEqual, data.String("cannot subscribe"))
+				So(st, ShouldNotContainKey, "error")
+			})
+		})
+
+		Convey("When adding a source failing to be cleaned up", func() {
+			s := newSource()
+			s.cleanupErr = errors.New("cannot commit offsets")
+			son, err := tp.AddSource("source", s, nil)
+			So(err, ShouldBeNil)
+			So(son.Stop(), ShouldBeNil)
+
+			Convey("Then the error should be reported as a cleanup error", func() {
+				st := son.Status()
+				So(st["cleanup_error"], ShouldEqual, data.String("cannot commit offsets"))
+				So(st, ShouldNotContainKey, "error")
+			})
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync/atomic"
+	"time"
+)
+
+// StalenessFilter has configuration parameters to discard stale tuples at
+// ingestion or before a sink. It's useful when a source recovers from a long
+// outage and replays old data which is no longer worth processing.
+type StalenessFilter struct {
+	// MaxAge is the maximum age of tuples. A tuple is stale when more than
+	// MaxAge has elapsed since its timestamp.
+	MaxAge time.Duration
+
+	// TimestampField is a path to a field having the event time of a tuple.
+	// When it's empty, Tuple.Timestamp is used. Tuples not having the field
+	// or having a value which cannot be converted to a timestamp are never
+	// considered stale.
+	TimestampField string
+
+	// Divert controls what happens to stale tuples. When it's false, they're
+	// discarded silently. When it's true, they're reported as dropped tuples
+	// so that they can be received from a source created by
+	// NewDroppedTupleCollectorSource and diverted to another stream.
+	// Tuples reporting dropped tuples are never filtered so that diverted
+	// tuples can reach their destination.
+	Divert bool
+}
+
+// Validate validates values of StalenessFilter.
+func (f *StalenessFilter) Validate() error {
+	if f.MaxAge <= 0 {
+		return errors.New("max age of a staleness filter must be positive")
+	}
+	if f.TimestampField != "" {
+		if _, err := data.CompilePath(f.TimestampField); err != nil {
+			return fmt.Errorf("invalid timestamp field of a staleness filter: %v", err)
+		}
+	}
+	return nil
+}
+
+// stalenessWriter is a WriteCloser which only writes tuples passing a
+// StalenessFilter.
+type stalenessWriter struct {
+	w        WriteCloser
+	filter   StalenessFilter
+	path     data.Path
+	nodeType NodeType
+	nodeName string
+	et       EventType
+
+	numStale int64
+}
+
+func newStalenessWriter(w WriteCloser, f *StalenessFilter, nodeType NodeType, nodeName string) *stalenessWriter {
+	sw := &stalenessWriter{
+		w:        w,
+		filter:   *f,
+		nodeType: nodeType,
+		nodeName: nodeName,
+		et:       ETInput,
+	}
+	if nodeType == NTSource {
+		sw.et = ETOutput
+	}
+	if f.TimestampField != "" {
+		sw.path = data.MustCompilePath(f.TimestampField)
+	}
+	return sw
+}
+
+func (sw *stalenessWriter) Write(ctx *Context, t *Tuple) error {
+	if t.Flags.IsSet(TFDropped) {
+		return sw.w.Write(ctx, t)
+	}
+	ts := t.Timestamp
+	if sw.path != nil {
+		v, err := t.Data.Get(sw.path)
+		if err != nil {
+			return sw.w.Write(ctx, t)
+		}
+		if ts, err = data.ToTimestamp(v); err != nil {
+			return sw.w.Write(ctx, t)
+		}
+	}
+
+	age := ctx.Clock().Now().Sub(ts)
+	if age <= sw.filter.MaxAge {
+		return sw.w.Write(ctx, t)
+	}
+	atomic.AddInt64(&sw.numStale, 1)
+	if sw.filter.Divert {
+		ctx.droppedTuple(t, sw.nodeType, sw.nodeName, sw.et,
+			fmt.Errorf("the tuple is stale: its age %v exceeds %v", age, sw.filter.MaxAge))
+	}
+	return nil
+}
+
+func (sw *stalenessWriter) Close(ctx *Context) error {
+	return sw.w.Close(ctx)
+}
+
+func (sw *stalenessWriter) status() data.Map {
+	return data.Map{
+		"max_age":         data.String(sw.filter.MaxAge.String()),
+		"timestamp_field": data.String(sw.filter.TimestampField),
+		"divert":          data.Bool(sw.filter.Divert),
+		"num_stale":       data.Int(atomic.LoadInt64(&sw.numStale)),
+	}
+}
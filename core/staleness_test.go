@@ -0,0 +1,142 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func staleTestTuples() []*Tuple {
+	now := time.Now()
+	old := now.Add(-time.Hour)
+	ts := []*Tuple{}
+	for i, t := range []time.Time{now, old, now, old} {
+		ts = append(ts, &Tuple{
+			Data: data.Map{
+				"int":        data.Int(i),
+				"event_time": data.Timestamp(t),
+			},
+			Timestamp:     t,
+			ProcTimestamp: now,
+		})
+	}
+	return ts
+}
+
+func TestStalenessFilter(t *testing.T) {
+	Convey("Given a topology", t, func() {
+		ctx := NewContext(nil)
+		tp, err := NewDefaultTopology(ctx, "staleness")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		Convey("When adding a source with a staleness filter", func() {
+			son, err := tp.AddSource("source", NewTupleEmitterSource(staleTestTuples()), &SourceConfig{
+				PausedOnStartup: true,
+				Staleness:       &StalenessFilter{MaxAge: time.Minute},
+			})
+			So(err, ShouldBeNil)
+			si := NewTupleCollectorSink()
+			sin, err := tp.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+			So(son.State().Wait(TSStopped), ShouldEqual, TSStopped)
+
+			Convey("Then only fresh tuples should be emitted", func() {
+				si.Wait(2)
+				So(si.len(), ShouldEqual, 2)
+				So(si.get(0).Data["int"], ShouldEqual, 0)
+				So(si.get(1).Data["int"], ShouldEqual, 2)
+			})
+
+			Convey("Then the status should have the number of stale tuples", func() {
+				v, err := son.Status().Get(data.MustCompilePath("staleness.num_stale"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When adding a sink with a staleness filter diverting tuples", func() {
+			son, err := tp.AddSource("source", NewTupleEmitterSource(staleTestTuples()), &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+			dtso := NewDroppedTupleCollectorSource().(*droppedTupleCollectorSource)
+			_, err = tp.AddSource("dropped_tuples", dtso, nil)
+			So(err, ShouldBeNil)
+			dtso.state.Wait(TSRunning)
+			dsi := NewTupleCollectorSink()
+			dsin, err := tp.AddSink("dropped", dsi, nil)
+			So(err, ShouldBeNil)
+			So(dsin.Input("dropped_tuples", nil), ShouldBeNil)
+
+			si := NewTupleCollectorSink()
+			sin, err := tp.AddSink("sink", si, &SinkConfig{
+				Staleness: &StalenessFilter{
+					MaxAge:         time.Minute,
+					TimestampField: "event_time",
+					Divert:         true,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then only fresh tuples should be written to the sink", func() {
+				si.Wait(2)
+				So(si.len(), ShouldEqual, 2)
+				So(si.get(0).Data["int"], ShouldEqual, 0)
+				So(si.get(1).Data["int"], ShouldEqual, 2)
+			})
+
+			Convey("Then stale tuples should be reported as dropped tuples", func() {
+				dsi.Wait(2)
+				So(dsi.len(), ShouldEqual, 2)
+				d := dsi.get(0).Data
+				So(d["node_type"], ShouldEqual, NTSink.String())
+				So(d["node_name"], ShouldEqual, "sink")
+				So(d["event_type"], ShouldEqual, ETInput.String())
+				So(d["error"], ShouldNotBeNil)
+				So(d["data"].(data.Map)["int"], ShouldEqual, 1)
+			})
+		})
+
+		Convey("When using a timestamp field which some tuples don't have", func() {
+			ts := staleTestTuples()
+			delete(ts[1].Data, "event_time")
+			son, err := tp.AddSource("source", NewTupleEmitterSource(ts), &SourceConfig{
+				PausedOnStartup: true,
+				Staleness:       &StalenessFilter{MaxAge: time.Minute, TimestampField: "event_time"},
+			})
+			So(err, ShouldBeNil)
+			si := NewTupleCollectorSink()
+			sin, err := tp.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then those tuples shouldn't be considered stale", func() {
+				si.Wait(3)
+				So(si.len(), ShouldEqual, 3)
+				So(si.get(1).Data["int"], ShouldEqual, 1)
+			})
+		})
+
+		Convey("When adding nodes with invalid staleness filters", func() {
+			for _, f := range []*StalenessFilter{
+				{},
+				{MaxAge: -time.Second},
+				{MaxAge: time.Second, TimestampField: "a["},
+			} {
+				_, err := tp.AddSource("source", &DoesNothingSource{}, &SourceConfig{Staleness: f})
+				So(err, ShouldNotBeNil)
+				_, err = tp.AddSink("sink", &DoesNothingSink{}, &SinkConfig{Staleness: f})
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -170,6 +170,10 @@ func TestNodeStatus(t *testing.T) {
 					So(is["num_errors"], ShouldEqual, 0)
 				})
 
+				Convey("And it should have no tuple in flight", func() {
+					So(is["num_in_flight"], ShouldEqual, 0)
+				})
+
 				Convey("And it should have the statuses of connected nodes", func() {
 					So(is["inputs"], ShouldNotBeNil)
 					ns := is["inputs"].(data.Map)
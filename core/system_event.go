@@ -0,0 +1,215 @@
+package core
+
+import (
+	"errors"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+)
+
+// SystemEventType represents the type of an operational event which happens
+// in a topology.
+type SystemEventType int
+
+const (
+	// SETStateChanged means that the state of the topology or a node has
+	// changed.
+	SETStateChanged SystemEventType = iota
+
+	// SETFatalError means that a node stopped due to an error.
+	SETFatalError
+
+	// SETStateSaved means that a shared state has been saved by SAVE STATE
+	// and the checkpoint is completed.
+	SETStateSaved
+
+	// SETNodeRestarted means that a node has been restarted by its recovery
+	// policy after a fatal error.
+	SETNodeRestarted
+
+	// SETSourceReconnecting means that a supervised source is going to be
+	// reconnected after an error.
+	SETSourceReconnecting
+)
+
+func (t SystemEventType) String() string {
+	switch t {
+	case SETStateChanged:
+		return "state_changed"
+	case SETFatalError:
+		return "fatal_error"
+	case SETStateSaved:
+		return "state_saved"
+	case SETNodeRestarted:
+		return "node_restarted"
+	case SETSourceReconnecting:
+		return "source_reconnecting"
+	default:
+		return "unknown"
+	}
+}
+
+// systemEventBufferSize is the number of events each system event source can
+// buffer. Events are discarded when the buffer is full so that reporting
+// events never blocks the topology.
+const systemEventBufferSize = 1024
+
+// ReportSystemEvent reports an operational event of the topology to system
+// event sources. fields are added to the data of a tuple generated by the
+// sources. The event is discarded when there's no system event source.
+func (c *Context) ReportSystemEvent(et SystemEventType, fields data.Map) {
+	c.seMutex.RLock()
+	defer c.seMutex.RUnlock()
+	if len(c.seSources) == 0 {
+		return
+	}
+
+	now := c.Clock().Now()
+	for _, s := range c.seSources {
+		m := data.Map{
+			"event_type": data.String(et.String()),
+			"topology":   data.String(c.topologyName),
+		}
+		for k, v := range fields {
+			m[k] = v
+		}
+		t := NewTuple(m)
+		t.Timestamp = now
+		t.ProcTimestamp = now
+		s.enqueue(t)
+	}
+}
+
+func (c *Context) nodeStateChanged(nodeType NodeType, nodeName string, prev, cur TopologyState) {
+	c.ReportSystemEvent(SETStateChanged, data.Map{
+		"node_type":  data.String(nodeType.String()),
+		"node_name":  data.String(nodeName),
+		"prev_state": data.String(prev.String()),
+		"state":      data.String(cur.String()),
+	})
+}
+
+func (c *Context) topologyStateChanged(prev, cur TopologyState) {
+	c.ReportSystemEvent(SETStateChanged, data.Map{
+		"prev_state": data.String(prev.String()),
+		"state":      data.String(cur.String()),
+	})
+}
+
+func (c *Context) nodeFailed(nodeType NodeType, nodeName string, err error) {
+	c.ReportSystemEvent(SETFatalError, data.Map{
+		"node_type": data.String(nodeType.String()),
+		"node_name": data.String(nodeName),
+		"error":     data.String(err.Error()),
+	})
+}
+
+func (c *Context) addSystemEventSource(s *systemEventSource) int64 {
+	c.seMutex.Lock()
+	defer c.seMutex.Unlock()
+	id := NewTemporaryID()
+	c.seSources[id] = s
+	return id
+}
+
+func (c *Context) removeSystemEventSource(id int64) {
+	c.seMutex.Lock()
+	defer c.seMutex.Unlock()
+	delete(c.seSources, id)
+}
+
+type systemEventSource struct {
+	id     int64
+	events chan *Tuple
+	stop   chan struct{}
+
+	m     sync.Mutex
+	state *topologyStateHolder
+}
+
+// NewSystemEventSource returns a source which generates a stream of
+// operational events of the topology, such as state changes of the topology
+// and nodes, fatal errors of nodes, and completion of SAVE STATE. It allows
+// users to monitor the topology with BQL queries and sinks.
+//
+// Tuples generated from this source has the following fields in Data:
+//
+//   - event_type: the type of the event, which is one of "state_changed",
+//     "fatal_error", "state_saved", "node_restarted", or
+//     "source_reconnecting"
+//   - topology: the name of the topology
+//   - node_type(optional): the type of the node related to the event
+//   - node_name(optional): the name of the node related to the event
+//   - prev_state, state: the previous and the new state on "state_changed"
+//   - error: the error information on "fatal_error" and
+//     "source_reconnecting"
+//   - num_restarts: the number of restarts of the node on "node_restarted"
+//   - num_retries, backoff: the number of consecutive retries and the
+//     duration to wait before the retry on "source_reconnecting"
+//   - state_name, state_tag: the name and the tag of the saved state on
+//     "state_saved"
+//
+// Events related to the topology rather than a node don't have node_type
+// and node_name. Events are discarded when the source cannot keep up with
+// them.
+func NewSystemEventSource() Source {
+	src := &systemEventSource{
+		events: make(chan *Tuple, systemEventBufferSize),
+		stop:   make(chan struct{}),
+	}
+	src.state = newTopologyStateHolder(&src.m)
+	return src
+}
+
+func (s *systemEventSource) enqueue(t *Tuple) {
+	select {
+	case s.events <- t:
+	default:
+	}
+}
+
+func (s *systemEventSource) GenerateStream(ctx *Context, w Writer) error {
+	s.m.Lock()
+	if s.state.getWithoutLock() >= TSStopping {
+		s.m.Unlock()
+		return errors.New("the source is already stopped")
+	}
+	s.id = ctx.addSystemEventSource(s)
+	s.state.setWithoutLock(TSRunning)
+	s.m.Unlock()
+	defer s.state.Set(TSStopped)
+	defer ctx.removeSystemEventSource(s.id)
+
+	for {
+		select {
+		case t := <-s.events:
+			if err := w.Write(ctx, t); err != nil {
+				if err == ErrSourceStopped {
+					return nil
+				}
+				ctx.ErrLog(err).Warn("Cannot write a system event")
+			}
+		case <-s.stop:
+			return nil
+		}
+	}
+}
+
+func (s *systemEventSource) Stop(ctx *Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	switch s.state.getWithoutLock() {
+	case TSStopping:
+		s.state.waitWithoutLock(TSStopped)
+		return nil
+	case TSStopped:
+		return nil
+	case TSInitialized:
+		s.state.setWithoutLock(TSStopped)
+		return nil
+	}
+	ctx.removeSystemEventSource(s.id)
+	s.state.setWithoutLock(TSStopping)
+	close(s.stop)
+	s.state.waitWithoutLock(TSStopped)
+	return nil
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+type failingSource struct{}
+
+func (f *failingSource) GenerateStream(ctx *Context, w Writer) error {
+	return errors.New("source failure")
+}
+
+func (f *failingSource) Stop(ctx *Context) error {
+	return nil
+}
+
+func TestSystemEventSource(t *testing.T) {
+	Convey("Given a topology and a system event source", t, func() {
+		ctx := NewContext(nil)
+		t, err := NewDefaultTopology(ctx, "se1")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		seso := NewSystemEventSource().(*systemEventSource)
+		_, err = t.AddSource("system_events", seso, nil)
+		So(err, ShouldBeNil)
+		seso.state.Wait(TSRunning)
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("system_events", nil), ShouldBeNil)
+
+		findEvent := func(eventType, nodeName, state string) *Tuple {
+			var res *Tuple
+			si.forEachTuple(func(t *Tuple) {
+				if res != nil || t.Data["event_type"] != data.String(eventType) {
+					return
+				}
+				if nodeName != "" && t.Data["node_name"] != data.String(nodeName) {
+					return
+				}
+				if state != "" && t.Data["state"] != data.String(state) {
+					return
+				}
+				res = t
+			})
+			return res
+		}
+		waitForEvent := func(eventType, nodeName, state string) *Tuple {
+			for i := 0; i < 1000; i++ {
+				if e := findEvent(eventType, nodeName, state); e != nil {
+					return e
+				}
+				time.Sleep(time.Millisecond)
+			}
+			return nil
+		}
+
+		Convey("When a source is added and stopped", func() {
+			son, err := t.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+			So(son.Stop(), ShouldBeNil)
+
+			Convey("Then its state changes should be reported", func() {
+				So(waitForEvent("state_changed", "source", "paused"), ShouldNotBeNil)
+				e := waitForEvent("state_changed", "source", "stopped")
+				So(e, ShouldNotBeNil)
+				So(e.Data["topology"], ShouldEqual, "se1")
+				So(e.Data["node_type"], ShouldEqual, NTSource.String())
+				So(e.Data, ShouldContainKey, "prev_state")
+			})
+		})
+
+		Convey("When a source fails", func() {
+			_, err := t.AddSource("failing", &failingSource{}, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the fatal error should be reported", func() {
+				e := waitForEvent("fatal_error", "failing", "")
+				So(e, ShouldNotBeNil)
+				So(e.Data["node_type"], ShouldEqual, NTSource.String())
+				So(e.Data["error"], ShouldEqual, "source failure")
+			})
+		})
+
+		Convey("When an event is reported", func() {
+			ctx.ReportSystemEvent(SETStateSaved, data.Map{
+				"state_name": data.String("uds"),
+			})
+
+			Convey("Then it should be emitted with the given fields", func() {
+				e := waitForEvent("state_saved", "", "")
+				So(e, ShouldNotBeNil)
+				So(e.Data["state_name"], ShouldEqual, "uds")
+				So(e.Data, ShouldNotContainKey, "node_name")
+			})
+		})
+	})
+
+	Convey("Given a context without system event sources", t, func() {
+		ctx := NewContext(nil)
+
+		Convey("When reporting many events", func() {
+			for i := 0; i < systemEventBufferSize*2; i++ {
+				ctx.ReportSystemEvent(SETFatalError, data.Map{})
+			}
+
+			Convey("Then they should be discarded without blocking", func() {
+				So(len(ctx.seSources), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,290 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// MaxTapTuples is the maximum number of tuples a tap can record. It's
+	// also used when TapConfig.MaxTuples is zero so that a tap having only
+	// Duration doesn't consume unlimited memory.
+	MaxTapTuples = 10000
+)
+
+// TapConfig has parameters of a tap attached to an edge.
+type TapConfig struct {
+	// MaxTuples is the number of tuples recorded by the tap. When it's zero,
+	// the tap records at most MaxTapTuples tuples.
+	MaxTuples int
+
+	// Duration is the period during which the tap records tuples. When it's
+	// zero, the tap records tuples until it has MaxTuples tuples.
+	Duration time.Duration
+}
+
+// Validate validates values of TapConfig.
+func (c *TapConfig) Validate() error {
+	if c.MaxTuples < 0 || c.MaxTuples > MaxTapTuples {
+		return fmt.Errorf("the number of tuples of a tap must be in [0, %v]: %v", MaxTapTuples, c.MaxTuples)
+	}
+	if c.Duration < 0 {
+		return fmt.Errorf("the duration of a tap must not be negative: %v", c.Duration)
+	}
+	if c.MaxTuples == 0 && c.Duration == 0 {
+		return errors.New("a tap must have the number of tuples or the duration")
+	}
+	return nil
+}
+
+func (c *TapConfig) maxTuples() int {
+	if c.MaxTuples == 0 {
+		return MaxTapTuples
+	}
+	return c.MaxTuples
+}
+
+// EdgeTap records copies of tuples flowing through an edge between two nodes
+// without affecting the flow. It stops recording when it has the number of
+// tuples or the duration specified by its TapConfig passes.
+type EdgeTap struct {
+	id     int64
+	from   string
+	to     string
+	config TapConfig
+
+	startedAt time.Time
+
+	// deadline is the zero value when the tap doesn't have a duration.
+	deadline time.Time
+
+	m        sync.Mutex
+	tuples   []*Tuple
+	finished bool
+}
+
+// ID returns the ID of the tap which is unique in the EdgeTaps.
+func (t *EdgeTap) ID() int64 {
+	return t.id
+}
+
+// From returns the lower-case name of the node writing tuples to the edge.
+func (t *EdgeTap) From() string {
+	return t.from
+}
+
+// To returns the lower-case name of the node reading tuples from the edge.
+func (t *EdgeTap) To() string {
+	return t.to
+}
+
+// Config returns the configuration of the tap.
+func (t *EdgeTap) Config() TapConfig {
+	return t.config
+}
+
+// StartedAt returns the time when the tap was attached.
+func (t *EdgeTap) StartedAt() time.Time {
+	return t.startedAt
+}
+
+// Finished returns true when the tap doesn't record tuples anymore.
+func (t *EdgeTap) Finished(now time.Time) bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.finishedWithoutLock(now)
+}
+
+func (t *EdgeTap) finishedWithoutLock(now time.Time) bool {
+	return t.finished || t.expired(now)
+}
+
+func (t *EdgeTap) expired(now time.Time) bool {
+	return !t.deadline.IsZero() && !now.Before(t.deadline)
+}
+
+// Tuples returns tuples recorded so far. The tuples must not be modified.
+func (t *EdgeTap) Tuples() []*Tuple {
+	t.m.Lock()
+	defer t.m.Unlock()
+	ts := make([]*Tuple, len(t.tuples))
+	copy(ts, t.tuples)
+	return ts
+}
+
+// record records a copy of the tuple. It returns true when the tap finished
+// recording tuples by this call.
+func (t *EdgeTap) record(now time.Time, tu *Tuple) bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.finished {
+		return false
+	}
+	if t.expired(now) {
+		t.finished = true
+		return true
+	}
+	t.tuples = append(t.tuples, tu.Copy())
+	if len(t.tuples) >= t.config.maxTuples() {
+		t.finished = true
+		return true
+	}
+	return false
+}
+
+// EdgeTaps manages taps attached to edges of a topology. Taps can be added
+// and removed while the topology is running. Recorded tuples are kept until
+// the tap is removed even after it finishes recording. Node names are
+// case-insensitive.
+//
+// Methods of EdgeTaps can be called on a nil pointer, in which case no tap
+// is attached.
+type EdgeTaps struct {
+	clock Clock
+
+	m      sync.RWMutex
+	taps   map[int64]*EdgeTap
+	nextID int64
+
+	// numActive is the number of taps which haven't finished. It's used to
+	// skip looking up taps when none are recording.
+	numActive int32
+}
+
+// NewEdgeTaps returns a new EdgeTaps having no tap. The clock is used to
+// determine when taps having a duration finish.
+func NewEdgeTaps(clock Clock) *EdgeTaps {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &EdgeTaps{
+		clock: clock,
+		taps:  map[int64]*EdgeTap{},
+	}
+}
+
+// Add attaches a new tap to the edge from the node to the other node. It
+// doesn't check whether the edge exists. A tap attached to an edge which
+// doesn't exist yet starts recording once the edge is connected.
+func (et *EdgeTaps) Add(from, to string, c *TapConfig) (*EdgeTap, error) {
+	if et == nil {
+		return nil, errors.New("tapping edges isn't supported")
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := et.clock.Now()
+	t := &EdgeTap{
+		from:      strings.ToLower(from),
+		to:        strings.ToLower(to),
+		config:    *c,
+		startedAt: now,
+	}
+	if c.Duration > 0 {
+		t.deadline = now.Add(c.Duration)
+	}
+
+	et.m.Lock()
+	defer et.m.Unlock()
+	et.nextID++
+	t.id = et.nextID
+	et.taps[t.id] = t
+	atomic.AddInt32(&et.numActive, 1)
+	return t, nil
+}
+
+// Get returns the tap having the ID. It returns false when the tap doesn't
+// exist.
+func (et *EdgeTaps) Get(id int64) (*EdgeTap, bool) {
+	if et == nil {
+		return nil, false
+	}
+	et.m.RLock()
+	defer et.m.RUnlock()
+	t, ok := et.taps[id]
+	return t, ok
+}
+
+// List returns all taps sorted by their IDs.
+func (et *EdgeTaps) List() []*EdgeTap {
+	if et == nil {
+		return nil
+	}
+	et.m.RLock()
+	defer et.m.RUnlock()
+	ts := make([]*EdgeTap, 0, len(et.taps))
+	for _, t := range et.taps {
+		ts = append(ts, t)
+	}
+	sort.Sort(edgeTapsByID(ts))
+	return ts
+}
+
+// Remove detaches the tap and discards its tuples. It returns false when the
+// tap doesn't exist.
+func (et *EdgeTaps) Remove(id int64) bool {
+	if et == nil {
+		return false
+	}
+	et.m.Lock()
+	defer et.m.Unlock()
+	t, ok := et.taps[id]
+	if !ok {
+		return false
+	}
+	delete(et.taps, id)
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	if !t.finished {
+		t.finished = true
+		atomic.AddInt32(&et.numActive, -1)
+	}
+	return true
+}
+
+// Now returns the current time of the clock used by taps.
+func (et *EdgeTaps) Now() time.Time {
+	if et == nil {
+		return SystemClock.Now()
+	}
+	return et.clock.Now()
+}
+
+func (et *EdgeTaps) active() bool {
+	return et != nil && atomic.LoadInt32(&et.numActive) > 0
+}
+
+// record records the tuple with taps attached to the edge from the node to
+// the other node.
+func (et *EdgeTaps) record(from, to string, t *Tuple) {
+	if !et.active() {
+		return
+	}
+	from = strings.ToLower(from)
+	to = strings.ToLower(to)
+	now := et.clock.Now()
+
+	et.m.RLock()
+	defer et.m.RUnlock()
+	for _, tap := range et.taps {
+		if tap.from != from || tap.to != to {
+			continue
+		}
+		if tap.record(now, t) {
+			atomic.AddInt32(&et.numActive, -1)
+		}
+	}
+}
+
+type edgeTapsByID []*EdgeTap
+
+func (s edgeTapsByID) Len() int           { return len(s) }
+func (s edgeTapsByID) Less(i, j int) bool { return s[i].id < s[j].id }
+func (s edgeTapsByID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
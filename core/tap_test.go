@@ -0,0 +1,190 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+	"time"
+)
+
+func TestTapConfig(t *testing.T) {
+	Convey("Given a TapConfig", t, func() {
+		c := &TapConfig{}
+
+		Convey("When it has neither the number of tuples nor the duration", func() {
+			Convey("Then it should be invalid", func() {
+				So(c.Validate(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When it has only the duration", func() {
+			c.Duration = time.Second
+
+			Convey("Then it should be valid", func() {
+				So(c.Validate(), ShouldBeNil)
+			})
+
+			Convey("Then it should record at most MaxTapTuples tuples", func() {
+				So(c.maxTuples(), ShouldEqual, MaxTapTuples)
+			})
+		})
+
+		Convey("When it has invalid values", func() {
+			Convey("Then it should be invalid", func() {
+				for _, c := range []*TapConfig{
+					{MaxTuples: -1},
+					{MaxTuples: MaxTapTuples + 1},
+					{MaxTuples: 1, Duration: -time.Second},
+				} {
+					So(c.Validate(), ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestEdgeTaps(t *testing.T) {
+	Convey("Given EdgeTaps", t, func() {
+		clock := NewFakeClock(time.Now())
+		et := NewEdgeTaps(clock)
+		newTuple := func(i int) *Tuple {
+			return NewTuple(data.Map{"int": data.Int(i)})
+		}
+
+		Convey("When adding a tap recording two tuples", func() {
+			tap, err := et.Add("Source", "Box", &TapConfig{MaxTuples: 2})
+			So(err, ShouldBeNil)
+
+			Convey("Then it can be obtained by its ID", func() {
+				t, ok := et.Get(tap.ID())
+				So(ok, ShouldBeTrue)
+				So(t, ShouldEqual, tap)
+				So(t.From(), ShouldEqual, "source")
+				So(t.To(), ShouldEqual, "box")
+				So(et.List(), ShouldResemble, []*EdgeTap{tap})
+			})
+
+			Convey("Then it should only record tuples of the edge", func() {
+				et.record("source", "sink", newTuple(0))
+				et.record("box", "source", newTuple(0))
+				So(tap.Tuples(), ShouldBeEmpty)
+			})
+
+			Convey("Then it should record copies of tuples", func() {
+				tu := newTuple(1)
+				et.record("SOURCE", "box", tu)
+				tu.Data["int"] = data.Int(100)
+				ts := tap.Tuples()
+				So(len(ts), ShouldEqual, 1)
+				So(ts[0].Data["int"], ShouldEqual, data.Int(1))
+			})
+
+			Convey("Then it should finish after recording two tuples", func() {
+				for i := 0; i < 3; i++ {
+					et.record("source", "box", newTuple(i))
+				}
+				So(len(tap.Tuples()), ShouldEqual, 2)
+				So(tap.Finished(clock.Now()), ShouldBeTrue)
+				So(et.active(), ShouldBeFalse)
+
+				Convey("And its tuples should be kept until it's removed", func() {
+					_, ok := et.Get(tap.ID())
+					So(ok, ShouldBeTrue)
+					So(et.Remove(tap.ID()), ShouldBeTrue)
+					_, ok = et.Get(tap.ID())
+					So(ok, ShouldBeFalse)
+					So(et.Remove(tap.ID()), ShouldBeFalse)
+				})
+			})
+
+			Convey("Then removing it should stop recording", func() {
+				So(et.Remove(tap.ID()), ShouldBeTrue)
+				So(et.active(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When adding a tap having a duration", func() {
+			tap, err := et.Add("source", "box", &TapConfig{Duration: time.Second})
+			So(err, ShouldBeNil)
+			et.record("source", "box", newTuple(1))
+
+			Convey("Then it should stop recording after the duration", func() {
+				So(tap.Finished(clock.Now()), ShouldBeFalse)
+				clock.Advance(time.Second)
+				So(tap.Finished(clock.Now()), ShouldBeTrue)
+				et.record("source", "box", newTuple(2))
+				So(len(tap.Tuples()), ShouldEqual, 1)
+				So(et.active(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When adding an invalid tap", func() {
+			_, err := et.Add("source", "box", &TapConfig{})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+				So(et.List(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given nil EdgeTaps", t, func() {
+		var et *EdgeTaps
+
+		Convey("Then it shouldn't have any tap", func() {
+			_, ok := et.Get(1)
+			So(ok, ShouldBeFalse)
+			So(et.List(), ShouldBeEmpty)
+			So(et.Remove(1), ShouldBeFalse)
+			_, err := et.Add("source", "box", &TapConfig{MaxTuples: 1})
+			So(err, ShouldNotBeNil)
+			et.record("source", "box", NewTuple(data.Map{}))
+		})
+	})
+}
+
+func TestEdgeTapsInTopology(t *testing.T) {
+	Convey("Given a topology with a box", t, func() {
+		ctx := NewContext(nil)
+		tp, err := NewDefaultTopology(ctx, "tap")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		son, err := tp.AddSource("source", NewTupleEmitterSource(freshTuples()), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+		bn, err := tp.AddBox("box", BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+			t.Data["tapped"] = data.True
+			return w.Write(ctx, t)
+		}), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+		si := NewTupleCollectorSink()
+		sin, err := tp.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("box", nil), ShouldBeNil)
+
+		Convey("When tapping the edge from the source to the box", func() {
+			tap, err := ctx.Taps.Add("source", "box", &TapConfig{MaxTuples: 2})
+			So(err, ShouldBeNil)
+			So(son.Resume(), ShouldBeNil)
+
+			Convey("Then all tuples should still arrive at the sink", func() {
+				si.Wait(len(freshTuples()))
+				So(si.len(), ShouldEqual, len(freshTuples()))
+			})
+
+			Convey("Then the tap should record the first two tuples before the box", func() {
+				si.Wait(len(freshTuples()))
+				ts := tap.Tuples()
+				So(len(ts), ShouldEqual, 2)
+				for i, t := range ts {
+					So(t.Data, ShouldResemble, freshTuples()[i].Data)
+				}
+			})
+		})
+	})
+}
@@ -1,5 +1,11 @@
 package core
 
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
 // Topology is a topology which can add Sources, Boxes, and Sinks
 // dynamically. Boxes and Sinks can also add inputs dynamically from running
 // Sources or Boxes.
@@ -44,6 +50,10 @@ type Topology interface {
 	// are written into Sinks. Stop method returns after processing all the
 	// tuples.
 	//
+	// Nodes are stopped in the reverse order of the flow: Sources are stopped
+	// first, and then Boxes and Sinks stop after their inputs are drained so
+	// that Sinks are closed last.
+	//
 	// BUG: Currently Stop method doesn't work if the topology has a cycle.
 	Stop() error
 
@@ -84,6 +94,11 @@ type Topology interface {
 	// Sinks returns all sinks registered to the topology. The map returned
 	// from this method can safely be modified.
 	Sinks() map[string]SinkNode
+
+	// Graph returns a snapshot of the DAG of the topology including nodes
+	// and edges connecting them. The graph returned from this method can
+	// safely be modified.
+	Graph() *TopologyGraph
 }
 
 // SourceConfig has configuration parameters of a Source node.
@@ -97,6 +112,17 @@ type SourceConfig struct {
 	// If it is true, the source is removed.
 	RemoveOnStop bool
 
+	// Staleness discards tuples generated by the source when they're too
+	// old. Tuples aren't filtered when it's nil.
+	Staleness *StalenessFilter
+
+	// StartOffset discards tuples generated by the source whose offsets are
+	// less than it until the source is rewound. It's used to resume a stream
+	// from the point where another process, such as a primary server taken
+	// over by a standby, stopped processing it. No tuple is discarded when
+	// it's 0.
+	StartOffset int64
+
 	// Meta contains meta information of the source. This field won't be used
 	// by core package and application can store any form of information
 	// related to the source.
@@ -105,7 +131,17 @@ type SourceConfig struct {
 
 // BoxConfig has configuration parameters of a Box node.
 type BoxConfig struct {
-	// TODO: parallelism
+	// Parallelism is the number of goroutines calling Process of the box
+	// concurrently. The order of tuples written by the box isn't preserved
+	// when it's greater than 1. 0 means 1. When it's ParallelismAuto, the
+	// number is determined from the number of CPUs available to the process
+	// (i.e. GOMAXPROCS) and Nice.
+	Parallelism int
+
+	// Nice lowers the share of CPUs used by the box like nice of Unix. It
+	// ranges from 0 to 19. The number of goroutines determined by
+	// ParallelismAuto is GOMAXPROCS*(20-Nice)/20, but at least 1.
+	Nice int
 
 	// RemoveOnStop is a flag which indicates the stop state of the topology.
 	// If it is true, the box is removed.
@@ -115,6 +151,51 @@ type BoxConfig struct {
 	// by core package and application can store any form of information
 	// related to the box.
 	Meta interface{}
+
+	// Recreate creates a new instance of the box, typically from the creator
+	// and parameters which the box was created with. The returned box
+	// doesn't have to be initialized. When it's given and the recovery
+	// policy of the box has RecoveryPolicy.Recreate, a panicking box is
+	// terminated and replaced with a new instance on restart. Because other
+	// goroutines might still be processing tuples with the old instance
+	// when Parallelism is greater than 1, the box must tolerate calls to
+	// Process after Terminate like other restarts.
+	Recreate func(ctx *Context) (Box, error)
+}
+
+const (
+	// ParallelismAuto is a special value of BoxConfig.Parallelism which
+	// makes the number of goroutines depend on the number of CPUs.
+	ParallelismAuto = -1
+
+	// MaxNice is the maximum value of BoxConfig.Nice.
+	MaxNice = 19
+)
+
+// Validate validates values of BoxConfig.
+func (c *BoxConfig) Validate() error {
+	if c.Parallelism < ParallelismAuto {
+		return fmt.Errorf("parallelism must be positive or ParallelismAuto: %v", c.Parallelism)
+	}
+	if c.Nice < 0 || c.Nice > MaxNice {
+		return fmt.Errorf("nice must be in [0, %v]: %v", MaxNice, c.Nice)
+	}
+	return nil
+}
+
+// parallelism returns the actual number of goroutines of the box.
+func (c *BoxConfig) parallelism() int {
+	switch {
+	case c.Parallelism == ParallelismAuto:
+		n := runtime.GOMAXPROCS(0) * (MaxNice + 1 - c.Nice) / (MaxNice + 1)
+		if n < 1 {
+			return 1
+		}
+		return n
+	case c.Parallelism <= 0:
+		return 1
+	}
+	return c.Parallelism
 }
 
 // SinkConfig has configuration parameters of a Sink node.
@@ -123,6 +204,27 @@ type SinkConfig struct {
 	// If it is true, the sink is removed.
 	RemoveOnStop bool
 
+	// Staleness discards tuples before they're written to the sink when
+	// they're too old. Tuples aren't filtered when it's nil.
+	Staleness *StalenessFilter
+
+	// LockOSThread dedicates an OS thread to the goroutine writing tuples to
+	// the sink so that latency-critical sinks aren't descheduled by the Go
+	// runtime in favor of other goroutines. It uses up one of the threads
+	// counted by GOMAXPROCS while the sink is running.
+	LockOSThread bool
+
+	// FlushInterval is the interval at which the sink is flushed when it
+	// implements Flushable. The sink is only flushed before it's closed or
+	// by SinkNode.Flush when it's 0. It's ignored when AdaptiveBatching is
+	// set.
+	FlushInterval time.Duration
+
+	// AdaptiveBatching controls the batch size and flushes of the sink
+	// according to the depth of its input queues when the sink implements
+	// Flushable. The sink is flushed every FlushInterval when it's nil.
+	AdaptiveBatching *AdaptiveBatching
+
 	// Meta contains meta information of the sink. This field won't be used
 	// by core package and application can store any form of information
 	// related to the sink.
@@ -0,0 +1,235 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+)
+
+// TopologyGraph is a snapshot of the DAG of a topology. It has all nodes and
+// edges connecting them at the time the snapshot was taken. Because a topology
+// can dynamically be modified, the graph might be out of date soon after it's
+// created.
+type TopologyGraph struct {
+	// Name is the name of the topology.
+	Name string
+
+	// State is the state of the topology.
+	State TopologyState
+
+	// Nodes is a list of nodes in the topology sorted by their names.
+	Nodes []*TopologyGraphNode
+
+	// Edges is a list of edges in the topology sorted by names of senders
+	// and receivers.
+	Edges []*TopologyGraphEdge
+}
+
+// TopologyGraphNode is a node in TopologyGraph.
+type TopologyGraphNode struct {
+	// Name is the name of the node.
+	Name string
+
+	// Type is the type of the node.
+	Type NodeType
+
+	// State is the state of the node.
+	State TopologyState
+
+	// Params has parameters of the node. It's obtained from the meta
+	// information of the node when it can be converted to data.Map.
+	// Otherwise, it's an empty map. Secrets registered to the context of the
+	// topology are redacted.
+	Params data.Map
+}
+
+// TopologyGraphEdge is an edge (i.e. a pipe) connecting two nodes in
+// TopologyGraph.
+type TopologyGraphEdge struct {
+	// Sender is the name of the node sending tuples through the edge.
+	Sender string
+
+	// Receiver is the name of the node receiving tuples through the edge.
+	Receiver string
+
+	// Stats has statistical information of the edge's queue. It has the same
+	// fields as an input in "input_stats" of Node.Status: num_received,
+	// queue_size, and num_queued.
+	Stats data.Map
+}
+
+// Map returns the graph as data.Map. The map has following fields:
+//
+//	name: the name of the topology
+//	state: the state of the topology
+//	nodes: an array of nodes having name, node_type, state, and params
+//	edges: an array of edges having sender, receiver, and stats
+func (g *TopologyGraph) Map() data.Map {
+	nodes := make(data.Array, len(g.Nodes))
+	for i, n := range g.Nodes {
+		nodes[i] = data.Map{
+			"name":      data.String(n.Name),
+			"node_type": data.String(n.Type.String()),
+			"state":     data.String(n.State.String()),
+			"params":    n.Params,
+		}
+	}
+
+	edges := make(data.Array, len(g.Edges))
+	for i, e := range g.Edges {
+		edges[i] = data.Map{
+			"sender":   data.String(e.Sender),
+			"receiver": data.String(e.Receiver),
+			"stats":    e.Stats,
+		}
+	}
+
+	return data.Map{
+		"name":  data.String(g.Name),
+		"state": data.String(g.State.String()),
+		"nodes": nodes,
+		"edges": edges,
+	}
+}
+
+// DOT returns the graph in Graphviz's DOT language.
+func (g *TopologyGraph) DOT() string {
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintf(b, "digraph %v {\n", quoteDOTID(g.Name))
+	for _, n := range g.Nodes {
+		shape := "box"
+		switch n.Type {
+		case NTSource:
+			shape = "invhouse"
+		case NTSink:
+			shape = "house"
+		}
+		fmt.Fprintf(b, "  %v [label=%v, shape=%v];\n", quoteDOTID(n.Name),
+			quoteDOTID(fmt.Sprintf(`%v\n(%v, %v)`, n.Name, n.Type, n.State)), shape)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(b, "  %v -> %v [label=%v];\n", quoteDOTID(e.Sender), quoteDOTID(e.Receiver),
+			quoteDOTID(edgeQueueLabel(e)))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the graph in Mermaid's flowchart syntax.
+func (g *TopologyGraph) Mermaid() string {
+	b := bytes.NewBuffer(nil)
+	b.WriteString("graph LR\n")
+	for _, n := range g.Nodes {
+		// Node names are validated by ValidateSymbol, so they can be used
+		// as IDs without escaping. However, they're lowercased because
+		// node names are case-insensitive.
+		label := fmt.Sprintf("%v<br/>%v, %v", n.Name, n.Type, n.State)
+		switch n.Type {
+		case NTSource:
+			fmt.Fprintf(b, "  %v[/\"%v\"/]\n", strings.ToLower(n.Name), label)
+		case NTSink:
+			fmt.Fprintf(b, "  %v[\\\"%v\"\\]\n", strings.ToLower(n.Name), label)
+		default:
+			fmt.Fprintf(b, "  %v[\"%v\"]\n", strings.ToLower(n.Name), label)
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(b, "  %v -->|%v| %v\n", strings.ToLower(e.Sender), edgeQueueLabel(e),
+			strings.ToLower(e.Receiver))
+	}
+	return b.String()
+}
+
+// quoteDOTID quotes an ID in DOT. Backslashes aren't escaped so that labels
+// can have escape sequences like \n. Names of topologies and nodes never
+// contain backslashes.
+func quoteDOTID(s string) string {
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+func edgeQueueLabel(e *TopologyGraphEdge) string {
+	l, _ := data.ToInt(e.Stats["num_queued"])
+	c, _ := data.ToInt(e.Stats["queue_size"])
+	return fmt.Sprintf("%v/%v", l, c)
+}
+
+func (t *defaultTopology) Graph() *TopologyGraph {
+	// Nodes and edges are collected without holding t.nodeMutex because
+	// Status acquires other locks.
+	nodes := t.Nodes()
+	g := &TopologyGraph{
+		Name:  t.name,
+		State: t.state.Get(),
+		Nodes: make([]*TopologyGraphNode, 0, len(nodes)),
+	}
+
+	for _, n := range nodes {
+		g.Nodes = append(g.Nodes, &TopologyGraphNode{
+			Name:   n.Name(),
+			Type:   n.Type(),
+			State:  n.State().Get(),
+			Params: t.ctx.Secrets.RedactMap(nodeParams(n)),
+		})
+
+		var srcs *dataSources
+		switch n := n.(type) {
+		case *defaultBoxNode:
+			srcs = n.srcs
+		case *defaultSinkNode:
+			srcs = n.srcs
+		default:
+			continue
+		}
+
+		inputs, _ := data.AsMap(srcs.status()["inputs"])
+		for sender, st := range inputs {
+			stats, _ := data.AsMap(st)
+			g.Edges = append(g.Edges, &TopologyGraphEdge{
+				Sender:   sender,
+				Receiver: n.Name(),
+				Stats:    stats,
+			})
+		}
+	}
+
+	sort.Sort(graphNodesByName(g.Nodes))
+	sort.Sort(graphEdgesByName(g.Edges))
+	return g
+}
+
+func nodeParams(n Node) data.Map {
+	switch m := n.Meta().(type) {
+	case data.Map:
+		return m.Copy()
+	case nil:
+		return data.Map{}
+	default:
+		v, err := data.NewValue(m)
+		if err != nil {
+			return data.Map{}
+		}
+		if p, err := data.AsMap(v); err == nil {
+			return p
+		}
+		return data.Map{}
+	}
+}
+
+type graphNodesByName []*TopologyGraphNode
+
+func (n graphNodesByName) Len() int           { return len(n) }
+func (n graphNodesByName) Less(i, j int) bool { return n[i].Name < n[j].Name }
+func (n graphNodesByName) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+
+type graphEdgesByName []*TopologyGraphEdge
+
+func (e graphEdgesByName) Len() int { return len(e) }
+func (e graphEdgesByName) Less(i, j int) bool {
+	if e[i].Sender != e[j].Sender {
+		return e[i].Sender < e[j].Sender
+	}
+	return e[i].Receiver < e[j].Receiver
+}
+func (e graphEdgesByName) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
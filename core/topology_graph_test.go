@@ -0,0 +1,111 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"strings"
+	"testing"
+)
+
+func TestTopologyGraph(t *testing.T) {
+	Convey("Given a topology having nodes", t, func() {
+		ctx := NewContext(nil)
+		t, err := NewDefaultTopology(ctx, "test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+		ctx.Secrets.Add("hunter2")
+
+		so := NewTupleIncrementalEmitterSource(freshTuples())
+		_, err = t.AddSource("source", so, &SourceConfig{
+			Meta: data.Map{"type": data.String("dummy"), "password": data.String("hunter2")},
+		})
+		So(err, ShouldBeNil)
+
+		bn, err := t.AddBox("box", BoxFunc(forwardBox), nil)
+		So(err, ShouldBeNil)
+		So(bn.Input("source", nil), ShouldBeNil)
+
+		si := NewTupleCollectorSink()
+		sin, err := t.AddSink("sink", si, nil)
+		So(err, ShouldBeNil)
+		So(sin.Input("box", &SinkInputConfig{Capacity: 16}), ShouldBeNil)
+		so.EmitTuples(2)
+		si.Wait(2)
+
+		Convey("When getting the graph of the topology", func() {
+			g := t.Graph()
+
+			Convey("Then it should have all nodes sorted by their names", func() {
+				So(g.Name, ShouldEqual, "test")
+				So(g.State, ShouldEqual, TSRunning)
+				So(len(g.Nodes), ShouldEqual, 3)
+				So(g.Nodes[0].Name, ShouldEqual, "box")
+				So(g.Nodes[0].Type, ShouldEqual, NTBox)
+				So(g.Nodes[1].Name, ShouldEqual, "sink")
+				So(g.Nodes[1].Type, ShouldEqual, NTSink)
+				So(g.Nodes[2].Name, ShouldEqual, "source")
+				So(g.Nodes[2].Type, ShouldEqual, NTSource)
+				So(g.Nodes[2].State, ShouldEqual, TSRunning)
+			})
+
+			Convey("Then nodes should have their parameters", func() {
+				So(g.Nodes[0].Params, ShouldBeEmpty)
+				So(g.Nodes[2].Params, ShouldResemble, data.Map{
+					"type":     data.String("dummy"),
+					"password": data.String(RedactedValue),
+				})
+			})
+
+			Convey("Then it should have all edges", func() {
+				So(len(g.Edges), ShouldEqual, 2)
+				So(g.Edges[0].Sender, ShouldEqual, "box")
+				So(g.Edges[0].Receiver, ShouldEqual, "sink")
+				So(g.Edges[0].Stats["queue_size"], ShouldEqual, 16)
+				So(g.Edges[0].Stats["num_received"], ShouldEqual, 2)
+				So(g.Edges[1].Sender, ShouldEqual, "source")
+				So(g.Edges[1].Receiver, ShouldEqual, "box")
+			})
+
+			Convey("Then it should be converted to data.Map", func() {
+				m := g.Map()
+				So(m["name"], ShouldEqual, "test")
+				So(m["state"], ShouldEqual, "running")
+				v, err := m.Get(data.MustCompilePath("nodes[1].node_type"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, "sink")
+				v, err = m.Get(data.MustCompilePath("edges[1].sender"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, "source")
+			})
+
+			Convey("Then it should be converted to DOT", func() {
+				dot := g.DOT()
+				So(dot, ShouldStartWith, `digraph "test" {`)
+				So(dot, ShouldContainSubstring, `"source" [label="source\n(source, running)", shape=invhouse];`)
+				So(dot, ShouldContainSubstring, `"box" -> "sink" [label="0/16"];`)
+				So(dot, ShouldContainSubstring, `"source" -> "box"`)
+			})
+
+			Convey("Then it should be converted to Mermaid", func() {
+				mm := g.Mermaid()
+				So(mm, ShouldStartWith, "graph LR\n")
+				So(mm, ShouldContainSubstring, `box["box<br/>box, running"]`)
+				So(mm, ShouldContainSubstring, "box -->|0/16| sink")
+				So(strings.Count(mm, "-->"), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When getting the graph of the stopped topology", func() {
+			So(t.Stop(), ShouldBeNil)
+			g := t.Graph()
+
+			Convey("Then it should be empty", func() {
+				So(g.State, ShouldEqual, TSStopped)
+				So(g.Nodes, ShouldBeEmpty)
+				So(g.Edges, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -70,6 +70,10 @@ type TopologyStateHolder interface {
 type topologyStateHolder struct {
 	state TopologyState
 	cond  *sync.Cond
+
+	// onChange is called with the lock held when the state has changed. It
+	// must not block.
+	onChange func(prev, cur TopologyState)
 }
 
 func newTopologyStateHolder(m sync.Locker) *topologyStateHolder {
@@ -106,8 +110,12 @@ func (h *topologyStateHolder) setWithoutLock(s TopologyState) error {
 			return fmt.Errorf("state cannot be changed from %v to %v", h.state, s)
 		}
 	}
+	prev := h.state
 	h.state = s
 	h.cond.Broadcast()
+	if h.onChange != nil && prev != s {
+		h.onChange(prev, s)
+	}
 	return nil
 }
 
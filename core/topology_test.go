@@ -0,0 +1,124 @@
+package core
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBoxConfig(t *testing.T) {
+	Convey("Given a BoxConfig", t, func() {
+		c := &BoxConfig{}
+
+		Convey("When it has the default values", func() {
+			Convey("Then it should be valid", func() {
+				So(c.Validate(), ShouldBeNil)
+			})
+
+			Convey("Then the box should have one goroutine", func() {
+				So(c.parallelism(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When it has the automatic parallelism", func() {
+			c.Parallelism = ParallelismAuto
+
+			Convey("Then the number of goroutines should be GOMAXPROCS", func() {
+				So(c.parallelism(), ShouldEqual, runtime.GOMAXPROCS(0))
+			})
+
+			Convey("Then the number of goroutines should be decreased by nice", func() {
+				c.Nice = 10
+				n := runtime.GOMAXPROCS(0) / 2
+				if n < 1 {
+					n = 1
+				}
+				So(c.parallelism(), ShouldEqual, n)
+				c.Nice = MaxNice
+				So(c.parallelism(), ShouldBeGreaterThanOrEqualTo, 1)
+			})
+		})
+
+		Convey("When it has invalid values", func() {
+			Convey("Then it should be invalid", func() {
+				for _, c := range []*BoxConfig{
+					{Parallelism: -2},
+					{Nice: -1},
+					{Nice: MaxNice + 1},
+				} {
+					So(c.Validate(), ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestSchedulerHints(t *testing.T) {
+	Convey("Given a topology", t, func() {
+		tp, err := NewDefaultTopology(NewContext(nil), "scheduler_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			tp.Stop()
+		})
+
+		fts := freshTuples()
+		son, err := tp.AddSource("source", NewTupleEmitterSource(fts), &SourceConfig{
+			PausedOnStartup: true,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When adding a box having parallelism", func() {
+			// The box blocks until two goroutines are processing tuples at
+			// the same time.
+			var n int32
+			ready := make(chan struct{})
+			bn, err := tp.AddBox("box", BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+				if atomic.AddInt32(&n, 1) == 2 {
+					close(ready)
+				}
+				<-ready
+				return w.Write(ctx, t)
+			}), &BoxConfig{
+				Parallelism: 2,
+			})
+			So(err, ShouldBeNil)
+			So(bn.Input("source", nil), ShouldBeNil)
+			si := NewTupleCollectorSink()
+			sin, err := tp.AddSink("sink", si, nil)
+			So(err, ShouldBeNil)
+			So(sin.Input("box", nil), ShouldBeNil)
+
+			Convey("Then tuples should be processed concurrently", func() {
+				So(son.Resume(), ShouldBeNil)
+				si.Wait(len(fts))
+				So(si.len(), ShouldEqual, len(fts))
+			})
+		})
+
+		Convey("When adding a box having invalid parallelism", func() {
+			_, err := tp.AddBox("box", BoxFunc(forwardBox), &BoxConfig{
+				Parallelism: -2,
+			})
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When adding a sink having a dedicated thread", func() {
+			si := NewTupleCollectorSink()
+			sin, err := tp.AddSink("sink", si, &SinkConfig{
+				LockOSThread: true,
+			})
+			So(err, ShouldBeNil)
+			So(sin.Input("source", nil), ShouldBeNil)
+
+			Convey("Then it should receive all tuples", func() {
+				So(son.Resume(), ShouldBeNil)
+				si.Wait(len(fts))
+				So(si.len(), ShouldEqual, len(fts))
+			})
+		})
+	})
+}
@@ -51,16 +51,16 @@ func (t EventType) String() string {
 }
 
 func tracing(t *Tuple, ctx *Context, inout EventType, msg string) {
-	if !ctx.Flags.TupleTrace.Enabled() {
+	if !ctx.Traced(msg) {
 		return
 	}
-	ev := newDefaultEvent(inout, msg)
+	ev := newDefaultEvent(ctx.Clock().Now(), inout, msg)
 	t.AddEvent(ev)
 }
 
-func newDefaultEvent(inout EventType, msg string) TraceEvent {
+func newDefaultEvent(now time.Time, inout EventType, msg string) TraceEvent {
 	return TraceEvent{
-		time.Now(),
+		now,
 		inout,
 		msg,
 	}
@@ -33,6 +33,19 @@ type Tuple struct {
 	// BatchID is reserved for future use.
 	BatchID int64
 
+	// Offset is the position of this tuple in the stream of the source which
+	// generated it. It starts from 1 and is 0 when the source doesn't track
+	// offsets. Sources created by NewRewindableSource or ImplementSourceStop
+	// set it automatically unless the original source sets it by itself.
+	// Tuples copied from this tuple have the same offset so that a sink can
+	// acknowledge the offset after writing a tuple derived from it. See
+	// OffsetTracker for details.
+	Offset int64
+
+	// OffsetSource is the name of the source node which generated this tuple
+	// having Offset. It's set by the topology.
+	OffsetSource string
+
 	// Flags has bit flags which controls behavior of this tuple. When a Box
 	// emits a tuple derived from a received one, it must copy this field
 	// otherwise a problem like infinite reporting of a dropped tuple could
@@ -42,6 +55,19 @@ type Tuple struct {
 	// Trace is used during debugging to trace to way of a Tuple through
 	// a topology. See the documentation for TraceEvent.
 	Trace []TraceEvent
+
+	// Metadata has additional information about this tuple which isn't a
+	// part of Data, such as a partition or a tenant. Keys used by SensorBee
+	// are defined as MetaXXX constants and should be accessed by typed
+	// helper methods like Partition or SetPartition. Metadata is deep copied
+	// by Copy and ShallowCopy. It's nil when the tuple doesn't have
+	// metadata.
+	Metadata data.Map
+
+	// queuedSize is the approximate size of the tuple accounted by
+	// ResourceController while the tuple is queued in a pipe. It's 0 when
+	// the tuple isn't accounted.
+	queuedSize int64
 }
 
 // AddEvent adds a TraceEvent to this Tuple's trace. This is not
@@ -54,6 +80,8 @@ func (t *Tuple) AddEvent(ev TraceEvent) {
 // Copy creates a deep copy of a Tuple, including the contained
 // data. This can be used, e.g., by fan-out pipes. When Tuple.Data doesn't
 // need to be cloned, call ShallowCopy. NEVER do newTuple := *oldTuple.
+// data.SharedBlobs in Data aren't copied but the copy gets new handles of
+// them.
 func (t *Tuple) Copy() *Tuple {
 	// except for Data, there are only value types in
 	// Tuple, so we can use normal copy for everything
@@ -78,6 +106,7 @@ func (t *Tuple) ShallowCopy() *Tuple {
 func (t *Tuple) shallowCopy() *Tuple {
 	out := *t
 	out.Flags.Clear(TFShared)
+	out.queuedSize = 0
 
 	// the copied tuple should have new event history,
 	// which is isolated from the original tuple,
@@ -87,9 +116,25 @@ func (t *Tuple) shallowCopy() *Tuple {
 		copy(tr, t.Trace)
 		out.Trace = tr
 	}
+	if t.Metadata != nil {
+		out.Metadata = t.Metadata.Copy()
+	}
 	return &out
 }
 
+// tupleOverhead is a rough estimate of the size of Tuple struct itself.
+const tupleOverhead = 128
+
+// approxSize returns the approximate size of memory in bytes used by the
+// tuple. Trace isn't taken into account.
+func (t *Tuple) approxSize() int64 {
+	size := tupleOverhead + int64(len(t.InputName)) + data.ApproxSize(t.Data)
+	if t.Metadata != nil {
+		size += data.ApproxSize(t.Metadata)
+	}
+	return size
+}
+
 // NewTuple creates and initializes a Tuple with default
 // values. And it copied Data by argument. Timestamp and
 // ProcTimestamp fields will be set time.Now() value.
@@ -164,3 +209,25 @@ func (f *TupleFlags) Clear(v TupleFlags) {
 		}
 	}
 }
+
+// findSharedBlobsToCheck returns data.SharedBlobs in the tuple when
+// Context.Flags.SharedBlobCheck is enabled. Blobs have to be collected
+// before the tuple is processed because the tuple can be passed to another
+// node and modified by it after that.
+func findSharedBlobsToCheck(ctx *Context, t *Tuple) []*data.SharedBlob {
+	if !ctx.Flags.SharedBlobCheck.Enabled() {
+		return nil
+	}
+	return data.FindSharedBlobs(nil, t.Data)
+}
+
+// verifySharedBlobs returns an error when one of the blobs has been
+// modified.
+func verifySharedBlobs(blobs []*data.SharedBlob) error {
+	for _, b := range blobs {
+		if err := b.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+const (
+	// MetaSourceOffset is the metadata key of the offset of a tuple in the
+	// stream of its source. It's the same as Tuple.Offset and isn't stored
+	// in Tuple.Metadata.
+	MetaSourceOffset = "source_offset"
+
+	// MetaPartition is the metadata key of the partition of a tuple. Its
+	// value is an integer.
+	MetaPartition = "partition"
+
+	// MetaSchemaVersion is the metadata key of the version of the schema
+	// which Tuple.Data follows. Its value is an integer.
+	MetaSchemaVersion = "schema_version"
+
+	// MetaTenant is the metadata key of the tenant owning a tuple. Its value
+	// is a string.
+	MetaTenant = "tenant"
+)
+
+// SetMeta sets a metadata value having the key. Like Data, Metadata must not
+// be modified when the tuple has TFShared flag. Use ShallowCopy to get a
+// modifiable tuple in that case.
+func (t *Tuple) SetMeta(key string, v data.Value) {
+	if t.Metadata == nil {
+		t.Metadata = data.Map{}
+	}
+	t.Metadata[key] = v
+}
+
+// Meta returns a metadata value having the key. The second return value is
+// false when the tuple doesn't have the key. MetaSourceOffset is resolved
+// from Tuple.Offset.
+func (t *Tuple) Meta(key string) (data.Value, bool) {
+	if key == MetaSourceOffset {
+		if t.Offset <= 0 {
+			return nil, false
+		}
+		return data.Int(t.Offset), true
+	}
+	v, ok := t.Metadata[key]
+	return v, ok
+}
+
+// AllMeta returns all metadata values of the tuple including the ones
+// resolved from fields like MetaSourceOffset. It returns nil when the tuple
+// doesn't have any metadata. The returned map can be modified without
+// affecting the tuple.
+func (t *Tuple) AllMeta() data.Map {
+	if t.Offset <= 0 && len(t.Metadata) == 0 {
+		return nil
+	}
+	m := data.Map{}
+	if t.Metadata != nil {
+		m = t.Metadata.Copy()
+	}
+	if t.Offset > 0 {
+		m[MetaSourceOffset] = data.Int(t.Offset)
+	}
+	return m
+}
+
+// SourceOffset returns the offset of the tuple in the stream of its source.
+// The second return value is false when the source doesn't track offsets.
+func (t *Tuple) SourceOffset() (int64, bool) {
+	return t.Offset, t.Offset > 0
+}
+
+// SetPartition sets the partition of the tuple.
+func (t *Tuple) SetPartition(p int64) {
+	t.SetMeta(MetaPartition, data.Int(p))
+}
+
+// Partition returns the partition of the tuple. The second return value is
+// false when the partition isn't set or it isn't an integer.
+func (t *Tuple) Partition() (int64, bool) {
+	return t.intMeta(MetaPartition)
+}
+
+// SetSchemaVersion sets the version of the schema of Tuple.Data.
+func (t *Tuple) SetSchemaVersion(v int64) {
+	t.SetMeta(MetaSchemaVersion, data.Int(v))
+}
+
+// SchemaVersion returns the version of the schema of Tuple.Data. The second
+// return value is false when the version isn't set or it isn't an integer.
+func (t *Tuple) SchemaVersion() (int64, bool) {
+	return t.intMeta(MetaSchemaVersion)
+}
+
+// SetTenant sets the tenant owning the tuple.
+func (t *Tuple) SetTenant(tenant string) {
+	t.SetMeta(MetaTenant, data.String(tenant))
+}
+
+// Tenant returns the tenant owning the tuple. The second return value is
+// false when the tenant isn't set or it isn't a string.
+func (t *Tuple) Tenant() (string, bool) {
+	v, ok := t.Metadata[MetaTenant]
+	if !ok {
+		return "", false
+	}
+	s, err := data.AsString(v)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+func (t *Tuple) intMeta(key string) (int64, bool) {
+	v, ok := t.Metadata[key]
+	if !ok {
+		return 0, false
+	}
+	i, err := data.AsInt(v)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
@@ -97,3 +97,147 @@ func TestTuple(t *testing.T) {
 		})
 	})
 }
+
+func TestTupleMetadata(t *testing.T) {
+	Convey("Given a tuple without metadata", t, func() {
+		tup := NewTuple(data.Map{"a": data.Int(1)})
+
+		Convey("Then it shouldn't have any metadata", func() {
+			So(tup.AllMeta(), ShouldBeNil)
+			_, ok := tup.Partition()
+			So(ok, ShouldBeFalse)
+			_, ok = tup.SourceOffset()
+			So(ok, ShouldBeFalse)
+			_, ok = tup.Meta(MetaSourceOffset)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("When setting metadata", func() {
+			tup.Offset = 5
+			tup.SetPartition(3)
+			tup.SetSchemaVersion(2)
+			tup.SetTenant("t1")
+			tup.SetMeta("custom", data.Array{data.Int(1)})
+
+			Convey("Then typed accessors should return them", func() {
+				p, ok := tup.Partition()
+				So(ok, ShouldBeTrue)
+				So(p, ShouldEqual, 3)
+				v, ok := tup.SchemaVersion()
+				So(ok, ShouldBeTrue)
+				So(v, ShouldEqual, 2)
+				tn, ok := tup.Tenant()
+				So(ok, ShouldBeTrue)
+				So(tn, ShouldEqual, "t1")
+				o, ok := tup.Meta(MetaSourceOffset)
+				So(ok, ShouldBeTrue)
+				So(o, ShouldEqual, data.Int(5))
+			})
+
+			Convey("Then AllMeta should return all of them", func() {
+				So(tup.AllMeta(), ShouldResemble, data.Map{
+					MetaSourceOffset:  data.Int(5),
+					MetaPartition:     data.Int(3),
+					MetaSchemaVersion: data.Int(2),
+					MetaTenant:        data.String("t1"),
+					"custom":          data.Array{data.Int(1)},
+				})
+			})
+
+			Convey("Then a value having a wrong type shouldn't be returned", func() {
+				tup.SetMeta(MetaPartition, data.String("3"))
+				_, ok := tup.Partition()
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then copies should preserve metadata without sharing it", func() {
+				for _, c := range []*Tuple{tup.Copy(), tup.ShallowCopy()} {
+					So(c.Metadata, ShouldResemble, tup.Metadata)
+					c.SetTenant("t2")
+					c.Metadata["custom"].(data.Array)[0] = data.Int(2)
+					tn, _ := tup.Tenant()
+					So(tn, ShouldEqual, "t1")
+					So(tup.Metadata["custom"], ShouldResemble, data.Array{data.Int(1)})
+				}
+			})
+		})
+	})
+}
+
+// blobModifyingSink modifies the blob of tuples it receives.
+type blobModifyingSink struct{}
+
+func (s *blobModifyingSink) Write(ctx *Context, t *Tuple) error {
+	b, err := data.AsBlob(t.Data["blob"])
+	if err != nil {
+		return err
+	}
+	b[0] = 'L'
+	return nil
+}
+
+func (s *blobModifyingSink) Close(ctx *Context) error {
+	return nil
+}
+
+func TestSharedBlobCheck(t *testing.T) {
+	Convey("Given a context and a tuple having a shared blob", t, func() {
+		ctx := NewContext(nil)
+		tup := NewTuple(data.Map{
+			"blob": data.NewSharedBlob([]byte("large blob"), nil),
+		})
+		modify := func(t *Tuple) {
+			b, err := data.AsBlob(t.Data["blob"])
+			So(err, ShouldBeNil)
+			b[0] = 'L'
+		}
+		si := NewTupleCollectorSink()
+		dst := newContextSinkWriter(ctx.StdContext(), si)
+
+		Convey("When copying the tuple", func() {
+			c := tup.Copy()
+
+			Convey("Then the copy should refer to the same buffer", func() {
+				b1, _ := data.AsBlob(tup.Data["blob"])
+				b2, _ := data.AsBlob(c.Data["blob"])
+				So(&b1[0], ShouldEqual, &b2[0])
+				So(c.Data["blob"], ShouldNotEqual, tup.Data["blob"])
+			})
+		})
+
+		Convey("When the check is enabled", func() {
+			ctx.Flags.SharedBlobCheck.Set(true)
+
+			Convey("Then a box modifying the blob should fail", func() {
+				w := newBoxWriterAdapter(ctx.StdContext(), BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+					modify(t)
+					return w.Write(ctx, t)
+				}), "box", dst)
+				So(w.Write(ctx, tup), ShouldNotBeNil)
+			})
+
+			Convey("Then a box only reading the blob should succeed", func() {
+				w := newBoxWriterAdapter(ctx.StdContext(), BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+					return w.Write(ctx, t)
+				}), "box", dst)
+				So(w.Write(ctx, tup), ShouldBeNil)
+				So(si.len(), ShouldEqual, 1)
+			})
+
+			Convey("Then a sink modifying the blob should fail", func() {
+				w := newContextSinkWriter(ctx.StdContext(), &blobModifyingSink{})
+				So(w.Write(ctx, tup), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the check is disabled", func() {
+			Convey("Then a box modifying the blob shouldn't fail", func() {
+				w := newBoxWriterAdapter(ctx.StdContext(), BoxFunc(func(ctx *Context, t *Tuple, w Writer) error {
+					modify(t)
+					return nil
+				}), "box", dst)
+				So(w.Write(ctx, tup), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -1,15 +1,66 @@
 package core
 
 import (
+	"fmt"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
 
 // Updater represents an entity that can update its configuration
 // parameters (in particular SourceNode, SinkNode and SharedState
-// instances).
+// instances). Parameters are updated through SourceNode.Update,
+// SinkNode.Update, or SharedStateRegistry.Update, which also take care of
+// UpdateValidator and sources wrapped by functions like NewRewindableSource.
 type Updater interface {
 	// Update updates the configuration parameters of this entity.
 	// It is the updater's responsibility to check the validity
 	// (e.g., data type and value) of the parameters.
 	Update(ctx *Context, params data.Map) error
 }
+
+// UpdateValidator is an optional interface of Updater. When an Updater also
+// implements UpdateValidator, ValidateUpdate is called before Update so that
+// invalid parameters are rejected before any of them is applied. Update is
+// only called when ValidateUpdate returns nil. An Updater implementing this
+// interface still has to apply parameters passed to Update atomically, i.e.
+// it shouldn't partially apply them when Update fails.
+type UpdateValidator interface {
+	// ValidateUpdate validates the parameters without applying them.
+	ValidateUpdate(ctx *Context, params data.Map) error
+}
+
+// updaterWrapper is implemented by sources or sinks wrapping another entity,
+// such as the one returned from NewRewindableSource, so that the Updater or
+// the Flushable of the wrapped entity can be found.
+type updaterWrapper interface {
+	unwrap() interface{}
+}
+
+// findUpdater returns the Updater of v. It looks into wrapped entities when v
+// itself doesn't implement Updater.
+func findUpdater(v interface{}) (Updater, bool) {
+	for {
+		if u, ok := v.(Updater); ok {
+			return u, true
+		}
+		w, ok := v.(updaterWrapper)
+		if !ok {
+			return nil, false
+		}
+		v = w.unwrap()
+	}
+}
+
+// applyUpdate validates and applies parameters to v. name is used in error
+// messages.
+func applyUpdate(ctx *Context, name string, v interface{}, params data.Map) error {
+	u, ok := findUpdater(v)
+	if !ok {
+		return fmt.Errorf("%v cannot be updated", name)
+	}
+	if uv, ok := u.(UpdateValidator); ok {
+		if err := uv.ValidateUpdate(ctx, params); err != nil {
+			return err
+		}
+	}
+	return u.Update(ctx, params)
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sync"
+	"testing"
+)
+
+// updatableEntity records parameters applied to it. It rejects parameters
+// having "invalid" key.
+type updatableEntity struct {
+	m      sync.Mutex
+	params data.Map
+}
+
+func (u *updatableEntity) ValidateUpdate(ctx *Context, params data.Map) error {
+	if _, ok := params["invalid"]; ok {
+		return errors.New("invalid parameter")
+	}
+	return nil
+}
+
+func (u *updatableEntity) Update(ctx *Context, params data.Map) error {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.params = params
+	return nil
+}
+
+func (u *updatableEntity) applied() data.Map {
+	u.m.Lock()
+	defer u.m.Unlock()
+	return u.params
+}
+
+type updatableSource struct {
+	DoesNothingSource
+	updatableEntity
+}
+
+type updatableSink struct {
+	DoesNothingSink
+	updatableEntity
+}
+
+type updatableSharedState struct {
+	stubSharedState
+	updatableEntity
+}
+
+func TestUpdate(t *testing.T) {
+	Convey("Given a topology", t, func() {
+		ctx := NewContext(nil)
+		t, err := NewDefaultTopology(ctx, "update_test")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			t.Stop()
+		})
+
+		Convey("When updating a source wrapped by NewRewindableSource", func() {
+			s := &updatableSource{}
+			sn, err := t.AddSource("source", NewRewindableSource(s), &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+			So(sn.Update(data.Map{"rate": data.Int(10)}), ShouldBeNil)
+
+			Convey("Then the parameters should be applied to the original source", func() {
+				So(s.applied(), ShouldResemble, data.Map{"rate": data.Int(10)})
+			})
+
+			Convey("Then invalid parameters shouldn't be applied", func() {
+				So(sn.Update(data.Map{"rate": data.Int(5), "invalid": data.Int(1)}), ShouldNotBeNil)
+				So(s.applied(), ShouldResemble, data.Map{"rate": data.Int(10)})
+			})
+		})
+
+		Convey("When updating a source which isn't an Updater", func() {
+			sn, err := t.AddSource("source", ImplementSourceStop(&DoesNothingSource{}), &SourceConfig{
+				PausedOnStartup: true,
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail", func() {
+				So(sn.Update(data.Map{"rate": data.Int(10)}), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When updating a sink", func() {
+			s := &updatableSink{}
+			sn, err := t.AddSink("sink", s, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then valid parameters should be applied", func() {
+				So(sn.Update(data.Map{"endpoint": data.String("a")}), ShouldBeNil)
+				So(s.applied(), ShouldResemble, data.Map{"endpoint": data.String("a")})
+			})
+
+			Convey("Then invalid parameters shouldn't be applied", func() {
+				So(sn.Update(data.Map{"invalid": data.Int(1)}), ShouldNotBeNil)
+				So(s.applied(), ShouldBeNil)
+			})
+		})
+
+		Convey("When updating a shared state", func() {
+			s := &updatableSharedState{}
+			So(ctx.SharedStates.Add("state", "test", s), ShouldBeNil)
+
+			Convey("Then valid parameters should be applied", func() {
+				So(ctx.SharedStates.Update("state", data.Map{"n": data.Int(1)}), ShouldBeNil)
+				So(s.applied(), ShouldResemble, data.Map{"n": data.Int(1)})
+			})
+
+			Convey("Then invalid parameters shouldn't be applied", func() {
+				So(ctx.SharedStates.Update("state", data.Map{"invalid": data.Int(1)}), ShouldNotBeNil)
+				So(s.applied(), ShouldBeNil)
+			})
+
+			Convey("Then updating a missing state should fail", func() {
+				err := ctx.SharedStates.Update("missing", data.Map{"n": data.Int(1)})
+				So(IsNotExist(err), ShouldBeTrue)
+			})
+		})
+	})
+}
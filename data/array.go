@@ -1,7 +1,6 @@
 package data
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -58,7 +57,7 @@ func (a Array) clone() Value {
 func (a Array) String() string {
 	// the String return value is defined via the
 	// default JSON serialization
-	bytes, err := json.Marshal(a)
+	bytes, err := appendJSON(nil, a)
 	if err != nil {
 		return fmt.Sprintf("(unserializable array: %v)", err)
 	}
@@ -67,15 +66,18 @@ func (a Array) String() string {
 
 // UnmarshalJSON reconstructs an Array from JSON.
 func (a *Array) UnmarshalJSON(data []byte) error {
-	var j []interface{}
-	if err := json.Unmarshal(data, &j); err != nil {
+	v, err := unmarshalJSONValue(data)
+	if err != nil {
 		return err
 	}
 
-	newArray, err := NewArray(j)
-	if err != nil {
-		return err
+	switch v := v.(type) {
+	case Null:
+		*a = Array{}
+	case Array:
+		*a = v
+	default:
+		return fmt.Errorf("cannot unmarshal %v into Array", v.Type())
 	}
-	*a = newArray
 	return nil
 }
@@ -0,0 +1,408 @@
+package data
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// The binary format is a compact encoding of Values used by internal
+// components such as window spill files. It isn't intended to be exchanged
+// with programs other than SensorBee. Each value starts with a tag byte:
+//
+//  * null, false, true: the tag only
+//  * int: a zigzag-encoded varint
+//  * float: 8 bytes of IEEE 754 bits in little endian
+//  * string, blob: a uvarint length followed by the bytes
+//  * timestamp: a varint of seconds and a uvarint of nanoseconds since the
+//    Unix epoch. The location is not kept and decoded timestamps are in UTC.
+//  * array: a uvarint length followed by the elements
+//  * map: a uvarint length followed by pairs of a key and a value. A key is
+//    a uvarint ID of the name in the FieldDictionary, or 0 followed by the
+//    name written as a string when the name isn't in the dictionary.
+//  * vector: a uvarint length followed by 4 bytes of IEEE 754 bits in
+//    little endian for each element
+const (
+	binaryTagNull byte = iota
+	binaryTagFalse
+	binaryTagTrue
+	binaryTagInt
+	binaryTagFloat
+	binaryTagString
+	binaryTagBlob
+	binaryTagTimestamp
+	binaryTagArray
+	binaryTagMap
+	binaryTagVector
+)
+
+// DefaultFieldDictionarySize is the default maximum number of names a
+// FieldDictionary can have.
+const DefaultFieldDictionarySize = 4096
+
+// FieldDictionary assigns IDs to names of fields so that the binary format
+// doesn't have to repeat them in every tuple of a stream. Names are added
+// while values are encoded and never removed, so a value encoded with a
+// dictionary can be decoded with the same dictionary, or with a dictionary
+// created by NewFieldDictionary with the Names of the original one, at any
+// time later. Once the dictionary becomes full, new names are written in
+// encoded values as they are.
+//
+// A FieldDictionary can be used by multiple goroutines.
+type FieldDictionary struct {
+	m       sync.RWMutex
+	ids     map[string]uint64
+	names   []string
+	maxSize int
+}
+
+// NewFieldDictionary creates a FieldDictionary having given names. The
+// dictionary can have at most DefaultFieldDictionarySize names, or
+// len(names) names if it's greater than that.
+func NewFieldDictionary(names ...string) *FieldDictionary {
+	d := &FieldDictionary{
+		ids:     make(map[string]uint64, len(names)),
+		maxSize: DefaultFieldDictionarySize,
+	}
+	if len(names) > d.maxSize {
+		d.maxSize = len(names)
+	}
+	for _, n := range names {
+		d.add(n)
+	}
+	return d
+}
+
+// Names returns names in the dictionary in the order of their IDs.
+func (d *FieldDictionary) Names() []string {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	return append([]string(nil), d.names...)
+}
+
+// Len returns the number of names in the dictionary.
+func (d *FieldDictionary) Len() int {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	return len(d.names)
+}
+
+// id returns the ID of the name, adding it to the dictionary if it's not
+// registered yet. It returns 0 when the dictionary is full.
+func (d *FieldDictionary) id(name string) uint64 {
+	d.m.RLock()
+	id, ok := d.ids[name]
+	d.m.RUnlock()
+	if ok {
+		return id
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	return d.add(name)
+}
+
+// add adds the name to the dictionary and returns its ID. The caller must
+// hold the write lock unless the dictionary isn't shared yet.
+func (d *FieldDictionary) add(name string) uint64 {
+	if id, ok := d.ids[name]; ok {
+		return id
+	}
+	if len(d.names) >= d.maxSize {
+		return 0
+	}
+	d.names = append(d.names, name)
+	id := uint64(len(d.names)) // IDs start from 1
+	d.ids[name] = id
+	return id
+}
+
+func (d *FieldDictionary) name(id uint64) (string, bool) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if id == 0 || id > uint64(len(d.names)) {
+		return "", false
+	}
+	return d.names[id-1], true
+}
+
+// AppendBinary appends the binary encoding of the value to b and returns
+// the extended buffer. Names of fields in Maps are registered to the
+// dictionary.
+func AppendBinary(b []byte, v Value, d *FieldDictionary) []byte {
+	switch v := v.(type) {
+	case Bool:
+		if v {
+			return append(b, binaryTagTrue)
+		}
+		return append(b, binaryTagFalse)
+	case Int:
+		return appendVarint(append(b, binaryTagInt), int64(v))
+	case Float:
+		return appendUint64(append(b, binaryTagFloat), math.Float64bits(float64(v)))
+	case String:
+		b = appendUvarint(append(b, binaryTagString), uint64(len(v)))
+		return append(b, v...)
+	case Blob:
+		b = appendUvarint(append(b, binaryTagBlob), uint64(len(v)))
+		return append(b, v...)
+	case *SharedBlob:
+		// a shared blob is decoded as a Blob
+		blob, _ := v.asBlob()
+		b = appendUvarint(append(b, binaryTagBlob), uint64(len(blob)))
+		return append(b, blob...)
+	case Timestamp:
+		t := time.Time(v)
+		b = appendVarint(append(b, binaryTagTimestamp), t.Unix())
+		return appendUvarint(b, uint64(t.Nanosecond()))
+	case Array:
+		b = appendUvarint(append(b, binaryTagArray), uint64(len(v)))
+		for _, e := range v {
+			b = AppendBinary(b, e, d)
+		}
+		return b
+	case Map:
+		b = appendUvarint(append(b, binaryTagMap), uint64(len(v)))
+		for k, e := range v {
+			id := d.id(k)
+			b = appendUvarint(b, id)
+			if id == 0 {
+				b = appendUvarint(b, uint64(len(k)))
+				b = append(b, k...)
+			}
+			b = AppendBinary(b, e, d)
+		}
+		return b
+	case Vector:
+		b = appendUvarint(append(b, binaryTagVector), uint64(len(v)))
+		for _, f := range v {
+			x := math.Float32bits(f)
+			b = append(b, byte(x), byte(x>>8), byte(x>>16), byte(x>>24))
+		}
+		return b
+	}
+	return append(b, binaryTagNull)
+}
+
+func appendUvarint(b []byte, x uint64) []byte {
+	for x >= 0x80 {
+		b = append(b, byte(x)|0x80)
+		x >>= 7
+	}
+	return append(b, byte(x))
+}
+
+func appendVarint(b []byte, x int64) []byte {
+	ux := uint64(x) << 1
+	if x < 0 {
+		ux = ^ux
+	}
+	return appendUvarint(b, ux)
+}
+
+func appendUint64(b []byte, x uint64) []byte {
+	return append(b, byte(x), byte(x>>8), byte(x>>16), byte(x>>24),
+		byte(x>>32), byte(x>>40), byte(x>>48), byte(x>>56))
+}
+
+// DecodeBinary decodes a value written by AppendBinary at the beginning of
+// b and returns it with the number of bytes read. The dictionary must have
+// all names registered when the value was encoded.
+func DecodeBinary(b []byte, d *FieldDictionary) (Value, int, error) {
+	dec := binaryDecoder{b: b, d: d}
+	v, err := dec.value(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, dec.off, nil
+}
+
+// UnmarshalBinary decodes a value written by AppendBinary. Unlike
+// DecodeBinary, it fails when b has extra bytes after the value.
+func UnmarshalBinary(b []byte, d *FieldDictionary) (Value, error) {
+	v, n, err := DecodeBinary(b, d)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(b) {
+		return nil, fmt.Errorf("%v extra bytes after a value", len(b)-n)
+	}
+	return v, nil
+}
+
+var errShortBinary = errors.New("unexpected end of binary data")
+
+// maxBinaryDepth limits nesting of arrays and maps in the binary format so
+// that broken data cannot exhaust the stack.
+const maxBinaryDepth = 10000
+
+type binaryDecoder struct {
+	b   []byte
+	off int
+	d   *FieldDictionary
+}
+
+func (r *binaryDecoder) uvarint() (uint64, error) {
+	x, n := binary.Uvarint(r.b[r.off:])
+	if n <= 0 {
+		if n == 0 {
+			return 0, errShortBinary
+		}
+		return 0, errors.New("varint overflows 64 bits")
+	}
+	r.off += n
+	return x, nil
+}
+
+func (r *binaryDecoder) varint() (int64, error) {
+	x, n := binary.Varint(r.b[r.off:])
+	if n <= 0 {
+		if n == 0 {
+			return 0, errShortBinary
+		}
+		return 0, errors.New("varint overflows 64 bits")
+	}
+	r.off += n
+	return x, nil
+}
+
+func (r *binaryDecoder) bytes(n int) ([]byte, error) {
+	if n > len(r.b)-r.off {
+		return nil, errShortBinary
+	}
+	p := r.b[r.off : r.off+n]
+	r.off += n
+	return p, nil
+}
+
+// length reads the length of a string, a blob, an array, a map, or a vector.
+// Each element takes at least minSize bytes, which is used to detect broken
+// lengths before allocating memory for them.
+func (r *binaryDecoder) length(minSize int) (int, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(r.b)-r.off)/uint64(minSize) {
+		return 0, errShortBinary
+	}
+	return int(n), nil
+}
+
+func (r *binaryDecoder) value(depth int) (Value, error) {
+	if depth > maxBinaryDepth {
+		return nil, errors.New("binary data is nested too deeply")
+	}
+	if r.off >= len(r.b) {
+		return nil, errShortBinary
+	}
+	tag := r.b[r.off]
+	r.off++
+
+	switch tag {
+	case binaryTagNull:
+		return Null{}, nil
+	case binaryTagFalse:
+		return False, nil
+	case binaryTagTrue:
+		return True, nil
+	case binaryTagInt:
+		i, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		return Int(i), nil
+	case binaryTagFloat:
+		p, err := r.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return Float(math.Float64frombits(binary.LittleEndian.Uint64(p))), nil
+	case binaryTagString, binaryTagBlob:
+		n, err := r.length(1)
+		if err != nil {
+			return nil, err
+		}
+		p, _ := r.bytes(n)
+		if tag == binaryTagString {
+			return String(p), nil
+		}
+		return Blob(append([]byte(nil), p...)), nil
+	case binaryTagTimestamp:
+		sec, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		nsec, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if nsec >= uint64(time.Second) {
+			return nil, fmt.Errorf("invalid nanoseconds of a timestamp: %v", nsec)
+		}
+		return Timestamp(time.Unix(sec, int64(nsec)).UTC()), nil
+	case binaryTagArray:
+		n, err := r.length(1)
+		if err != nil {
+			return nil, err
+		}
+		a := make(Array, n)
+		for i := range a {
+			if a[i], err = r.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return a, nil
+	case binaryTagMap:
+		n, err := r.length(2)
+		if err != nil {
+			return nil, err
+		}
+		m := make(Map, n)
+		for i := 0; i < n; i++ {
+			k, err := r.key()
+			if err != nil {
+				return nil, err
+			}
+			if m[k], err = r.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case binaryTagVector:
+		n, err := r.length(4)
+		if err != nil {
+			return nil, err
+		}
+		vec := make(Vector, n)
+		for i := range vec {
+			p, _ := r.bytes(4)
+			vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(p))
+		}
+		return vec, nil
+	}
+	return nil, fmt.Errorf("unknown tag in binary data: %v", tag)
+}
+
+func (r *binaryDecoder) key() (string, error) {
+	id, err := r.uvarint()
+	if err != nil {
+		return "", err
+	}
+	if id != 0 {
+		k, ok := r.d.name(id)
+		if !ok {
+			return "", fmt.Errorf("field ID %v isn't in the dictionary", id)
+		}
+		return k, nil
+	}
+	n, err := r.length(1)
+	if err != nil {
+		return "", err
+	}
+	p, _ := r.bytes(n)
+	return string(p), nil
+}
@@ -0,0 +1,181 @@
+package data
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBinary(t *testing.T) {
+	Convey("Given a Map having all types of values", t, func() {
+		now := time.Date(2015, time.April, 10, 10, 23, 0, 123, time.UTC)
+		m := Map{
+			"null":      Null{},
+			"bool":      True,
+			"false":     False,
+			"int":       Int(-123456789),
+			"maxint":    Int(math.MaxInt64),
+			"minint":    Int(math.MinInt64),
+			"float":     Float(1.5),
+			"inf":       Float(math.Inf(-1)),
+			"string":    String("日本語"),
+			"empty":     String(""),
+			"blob":      Blob("blob\x00"),
+			"timestamp": Timestamp(now),
+			"old":       Timestamp(time.Date(1, time.January, 1, 0, 0, 0, 999999999, time.UTC)),
+			"array":     Array{Int(1), String("a"), Array{}, Map{"a": Map{}}},
+			"vector":    Vector{1, 0.5, float32(math.Inf(1))},
+			"":          Map{"int": Int(1)},
+		}
+		d := NewFieldDictionary()
+
+		Convey("When encoding it", func() {
+			b := AppendBinary(nil, m, d)
+
+			Convey("Then it should be decoded to the same Map", func() {
+				v, err := UnmarshalBinary(b, d)
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, m)
+			})
+
+			Convey("Then it should be decoded with a dictionary having the same names", func() {
+				v, err := UnmarshalBinary(b, NewFieldDictionary(d.Names()...))
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, m)
+			})
+
+			Convey("Then names of fields should be registered to the dictionary", func() {
+				So(d.Len(), ShouldEqual, len(m)+1) // "a" in the array
+			})
+
+			Convey("Then encoding it again should produce the data of the same size", func() {
+				So(len(AppendBinary(nil, m, d)), ShouldEqual, len(b))
+			})
+
+			Convey("Then it should fail to be decoded with an empty dictionary", func() {
+				_, err := UnmarshalBinary(b, NewFieldDictionary())
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then it should fail to be decoded when it's truncated", func() {
+				for i := 0; i < len(b); i++ {
+					_, err := UnmarshalBinary(b[:i], d)
+					So(err, ShouldNotBeNil)
+				}
+			})
+
+			Convey("Then it should fail to be decoded with extra bytes", func() {
+				_, err := UnmarshalBinary(append(b, binaryTagNull), d)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When encoding multiple values in a buffer", func() {
+			b := AppendBinary(nil, m, d)
+			b = AppendBinary(b, Int(1), d)
+
+			Convey("Then they should be decoded one by one", func() {
+				v, n, err := DecodeBinary(b, d)
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, m)
+				v, err = UnmarshalBinary(b[n:], d)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, Int(1))
+			})
+		})
+	})
+
+	Convey("Given a full dictionary", t, func() {
+		d := NewFieldDictionary()
+		for i := 0; i < DefaultFieldDictionarySize; i++ {
+			So(d.id(fmt.Sprint(i)), ShouldEqual, i+1)
+		}
+
+		Convey("When encoding a Map having new names", func() {
+			m := Map{"new": Int(1), "0": Int(2)}
+			b := AppendBinary(nil, m, d)
+
+			Convey("Then the names should not be registered", func() {
+				So(d.Len(), ShouldEqual, DefaultFieldDictionarySize)
+			})
+
+			Convey("Then it should be decoded to the same Map", func() {
+				v, err := UnmarshalBinary(b, d)
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, m)
+			})
+		})
+	})
+
+	Convey("Given broken binary data", t, func() {
+		d := NewFieldDictionary()
+		data := [][]byte{
+			{},
+			{0xff},
+			{binaryTagInt, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+			{binaryTagString, 0xff, 0xff, 0xff, 0xff, 0x0f, 'a'},
+			{binaryTagArray, 0x02, binaryTagNull},
+			{binaryTagMap, 0x01, 0x05, binaryTagNull},
+			{binaryTagTimestamp, 0x00, 0x80, 0x94, 0xeb, 0xdc, 0x03},
+			{binaryTagVector, 0x01, 0x00, 0x00},
+		}
+
+		Convey("When decoding it", func() {
+			Convey("Then it should fail", func() {
+				for _, b := range data {
+					_, err := UnmarshalBinary(b, d)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func BenchmarkBinary(b *testing.B) {
+	m := Map{
+		"id":          Int(12345),
+		"name":        String("sensor-01"),
+		"temperature": Float(23.5),
+		"tags":        Array{String("a"), String("b"), String("c")},
+		"location":    Map{"lat": Float(35.6), "lon": Float(139.7)},
+		"active":      True,
+		"note":        Null{},
+	}
+	d := NewFieldDictionary()
+	bin := AppendBinary(nil, m, d)
+	js, _ := MarshalJSON(m)
+
+	b.Run("encode binary", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, len(bin))
+		for i := 0; i < b.N; i++ {
+			buf = AppendBinary(buf[:0], m, d)
+		}
+	})
+	b.Run("encode JSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := MarshalJSON(m); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decode binary", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalBinary(bin, d); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decode JSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalJSON(js); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
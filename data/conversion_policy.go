@@ -0,0 +1,144 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ConversionPolicy decides which implicit conversions between types are
+// allowed, for example, when a value is passed to a function parameter
+// having a different type.
+type ConversionPolicy int
+
+const (
+	// PermissiveConversion allows all conversions supported by ToBool,
+	// ToInt, ToFloat, ToString, ToBlob, ToTimestamp, and ToVector. It's
+	// the default policy.
+	PermissiveConversion ConversionPolicy = iota
+
+	// StrictConversion only allows conversions which don't lose or
+	// reinterpret information:
+	//
+	//  * any type to the same type
+	//  * Int to Float
+	//  * Float to Int when the value doesn't have a fractional part
+	//  * Array to Vector when all elements are Int or Float
+	//
+	// Other conversions, including those from Null, result in an error.
+	StrictConversion
+)
+
+func (p ConversionPolicy) String() string {
+	switch p {
+	case PermissiveConversion:
+		return "permissive"
+	case StrictConversion:
+		return "strict"
+	default:
+		return "unknown"
+	}
+}
+
+// Allows returns true when the policy allows converting a value of the type
+// from to the type to. Conversions from Float to Int can still fail under
+// StrictConversion when the value has a fractional part.
+func (p ConversionPolicy) Allows(from, to TypeID) bool {
+	if p != StrictConversion || from == to {
+		return true
+	}
+	switch {
+	case from == TypeInt && to == TypeFloat:
+		return true
+	case from == TypeFloat && to == TypeInt:
+		return true
+	case from == TypeArray && to == TypeVector:
+		return true
+	}
+	return false
+}
+
+func (p ConversionPolicy) check(v Value, to TypeID) error {
+	if !p.Allows(v.Type(), to) {
+		return fmt.Errorf("cannot convert %v to %v implicitly under the %v conversion policy",
+			v.Type(), to, p)
+	}
+	if p == StrictConversion && v.Type() == TypeFloat && to == TypeInt {
+		f, _ := v.asFloat()
+		if f != math.Trunc(f) {
+			return fmt.Errorf("cannot convert %v to %v without losing its fractional part", f, to)
+		}
+	}
+	return nil
+}
+
+// ToBool converts a given Value to a bool as ToBool does if the policy
+// allows the conversion.
+func (p ConversionPolicy) ToBool(v Value) (bool, error) {
+	if err := p.check(v, TypeBool); err != nil {
+		return false, err
+	}
+	return ToBool(v)
+}
+
+// ToInt converts a given Value to an int64 as ToInt does if the policy
+// allows the conversion.
+func (p ConversionPolicy) ToInt(v Value) (int64, error) {
+	if err := p.check(v, TypeInt); err != nil {
+		return 0, err
+	}
+	return ToInt(v)
+}
+
+// ToFloat converts a given Value to a float64 as ToFloat does if the policy
+// allows the conversion.
+func (p ConversionPolicy) ToFloat(v Value) (float64, error) {
+	if err := p.check(v, TypeFloat); err != nil {
+		return 0, err
+	}
+	return ToFloat(v)
+}
+
+// ToString converts a given Value to a string as ToString does if the
+// policy allows the conversion.
+func (p ConversionPolicy) ToString(v Value) (string, error) {
+	if err := p.check(v, TypeString); err != nil {
+		return "", err
+	}
+	return ToString(v)
+}
+
+// ToBlob converts a given Value to []byte as ToBlob does if the policy
+// allows the conversion.
+func (p ConversionPolicy) ToBlob(v Value) ([]byte, error) {
+	if err := p.check(v, TypeBlob); err != nil {
+		return nil, err
+	}
+	return ToBlob(v)
+}
+
+// ToTimestamp converts a given Value to a time.Time as ToTimestamp does if
+// the policy allows the conversion.
+func (p ConversionPolicy) ToTimestamp(v Value) (time.Time, error) {
+	if err := p.check(v, TypeTimestamp); err != nil {
+		return time.Time{}, err
+	}
+	return ToTimestamp(v)
+}
+
+// ToVector converts a given Value to a Vector as ToVector does if the policy
+// allows the conversion.
+func (p ConversionPolicy) ToVector(v Value) (Vector, error) {
+	if err := p.check(v, TypeVector); err != nil {
+		return nil, err
+	}
+	if p == StrictConversion && v.Type() == TypeArray {
+		a, _ := v.asArray()
+		for i, e := range a {
+			if t := e.Type(); t != TypeInt && t != TypeFloat {
+				return nil, fmt.Errorf("the %v-th element cannot be converted to a float implicitly: %v", i, t)
+			}
+		}
+	}
+	return ToVector(v)
+}
@@ -0,0 +1,92 @@
+package data
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestConversionPolicy(t *testing.T) {
+	Convey("Given the permissive conversion policy", t, func() {
+		p := PermissiveConversion
+
+		Convey("When converting values", func() {
+			Convey("Then it should behave as the default conversion functions", func() {
+				i, err := p.ToInt(Float(2.7))
+				So(err, ShouldBeNil)
+				So(i, ShouldEqual, 2)
+
+				f, err := p.ToFloat(String("1.5"))
+				So(err, ShouldBeNil)
+				So(f, ShouldEqual, 1.5)
+
+				b, err := p.ToBool(Null{})
+				So(err, ShouldBeNil)
+				So(b, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given the strict conversion policy", t, func() {
+		p := StrictConversion
+
+		Convey("When converting values without losing information", func() {
+			Convey("Then it should succeed", func() {
+				i, err := p.ToInt(Int(3))
+				So(err, ShouldBeNil)
+				So(i, ShouldEqual, 3)
+
+				f, err := p.ToFloat(Int(3))
+				So(err, ShouldBeNil)
+				So(f, ShouldEqual, 3.0)
+
+				i, err = p.ToInt(Float(-4))
+				So(err, ShouldBeNil)
+				So(i, ShouldEqual, -4)
+
+				v, err := p.ToVector(Array{Int(1), Float(2.5)})
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, Vector{1, 2.5})
+			})
+		})
+
+		Convey("When converting a float having a fractional part to an int", func() {
+			_, err := p.ToInt(Float(2.7))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When converting values between unrelated types", func() {
+			Convey("Then it should fail", func() {
+				_, err := p.ToInt(String("3"))
+				So(err, ShouldNotBeNil)
+				_, err = p.ToFloat(Bool(true))
+				So(err, ShouldNotBeNil)
+				_, err = p.ToString(Int(1))
+				So(err, ShouldNotBeNil)
+				_, err = p.ToBool(Int(1))
+				So(err, ShouldNotBeNil)
+				_, err = p.ToTimestamp(Int(0))
+				So(err, ShouldNotBeNil)
+				_, err = p.ToBlob(String("AA=="))
+				So(err, ShouldNotBeNil)
+				_, err = p.ToInt(Null{})
+				So(err, ShouldNotBeNil)
+				_, err = p.ToVector(Array{Int(1), String("2")})
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When checking the conversion matrix", func() {
+			Convey("Then it should only allow lossless conversions", func() {
+				So(p.Allows(TypeInt, TypeFloat), ShouldBeTrue)
+				So(p.Allows(TypeFloat, TypeInt), ShouldBeTrue)
+				So(p.Allows(TypeString, TypeString), ShouldBeTrue)
+				So(p.Allows(TypeString, TypeInt), ShouldBeFalse)
+				So(p.Allows(TypeTimestamp, TypeFloat), ShouldBeFalse)
+				So(PermissiveConversion.Allows(TypeString, TypeInt), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -2,8 +2,6 @@ package data
 
 import (
 	"bytes"
-	"hash/fnv"
-	"io"
 	"math"
 	"sync/atomic"
 )
@@ -17,11 +15,16 @@ type HashValue uint64
 // of NaNs always varies. For example, Hash(Float(math.NaN())) isn't equal to
 // Hash(Float(math.NaN())). Therefore, if an array or a map has a NaN, the hash
 // value changes everytime calling Hash function.
+//
+// Hash values are computed with XXH64 without allocating memory. They aren't
+// guaranteed to be the same across versions of SensorBee, so they shouldn't
+// be persisted. Because different values can have the same hash value, Equal
+// must be used to verify values having the same hash value.
 func Hash(v Value) HashValue {
-	h := fnv.New64a()
-	buffer := make([]byte, 0, 16)
-	updateHash(v, h, buffer)
-	return HashValue(h.Sum64())
+	var d xxh64
+	d.reset()
+	updateHash(v, &d)
+	return HashValue(d.sum64())
 }
 
 // Equal tests equality of two Values. When comparing a Float and an Int, Int is
@@ -117,6 +120,19 @@ func Equal(v1 Value, v2 Value) bool {
 		}
 		return true
 
+	case TypeVector:
+		lhs, _ := AsVector(v1)
+		rhs, _ := AsVector(v2)
+		if len(lhs) != len(rhs) {
+			return false
+		}
+		for i, l := range lhs {
+			if l != rhs[i] {
+				return false
+			}
+		}
+		return true
+
 	default:
 		// no such case, though
 		return false
@@ -127,14 +143,14 @@ func Equal(v1 Value, v2 Value) bool {
 // It can be used, for example, with the functions of the sort package.
 // The rules for sorting are as follows:
 // - When the types are different:
-//   Null < Bool < Int/Float < String < Blob < Timestamp < Array < Map
+//   Null < Bool < Int/Float < String < Blob < Timestamp < Array < Map < Vector
 // - When the type is the same:
 //   - Null: always false
 //   - Bool: false < true
 //   - Int/Float: usual < comparison; Ints and Floats can also be compared
 //   - String: usual < comparison
 //   - Timestamp: value as returned by Time.Before()
-//   - Blob, Array, Map: shorter collections are less than longer collections;
+//   - Blob, Array, Map, Vector: shorter collections are less than longer collections;
 //     when length is equal hash values are compared
 func Less(v1 Value, v2 Value) bool {
 	lType := v1.Type()
@@ -209,104 +225,83 @@ func Less(v1 Value, v2 Value) bool {
 		}
 		return len(lhs) < len(rhs)
 
+	case TypeVector:
+		lhs, _ := AsVector(v1)
+		rhs, _ := AsVector(v2)
+		if len(lhs) == len(rhs) {
+			return Hash(v1) < Hash(v2)
+		}
+		return len(lhs) < len(rhs)
+
 	default:
 		// no such case, though
 		return false
 	}
 }
 
-func appendInt32(b []byte, t TypeID, i int32) []byte {
-	i *= 16777619 // multiply fnv.prime32 due to the same reason as appendInt64
-	return append(b, byte(t),
-		byte(i&0xff),
-		byte((i>>8)&0xff),
-		byte((i>>16)&0xff),
-		byte((i>>24)&0xff),
-	)
-}
-
-func appendInt64(b []byte, t TypeID, i int64) []byte {
-	// Because FNV-64a doesn't seem to work well with small numbers,
-	// fnv.prime64 is manually multiplied beforehand.
-	i *= 1099511628211
-	return append(b, byte(t),
-		byte(i&0xff),
-		byte((i>>8)&0xff),
-		byte((i>>16)&0xff),
-		byte((i>>24)&0xff),
-		byte((i>>32)&0xff),
-		byte((i>>40)&0xff),
-		byte((i>>48)&0xff),
-		byte((i>>56)&0xff),
-	)
-}
-
 var (
 	nullHashCounter int64
 )
 
-func updateHash(v Value, h io.Writer, buffer []byte) []byte {
+func updateHash(v Value, d *xxh64) {
 	switch v.Type() {
 	case TypeNull:
-		buffer = appendInt64(buffer, TypeNull, 0)
-		h.Write(buffer)
+		d.writeByte(byte(TypeNull))
+		d.writeUint64(0)
 
 	case TypeBool:
 		b, _ := v.asBool()
+		d.writeByte(byte(TypeBool))
 		if b {
-			buffer = append(buffer, byte(TypeBool), 0xaa)
+			d.writeByte(1)
 		} else {
-			buffer = append(buffer, byte(TypeBool), 0x55)
+			d.writeByte(0)
 		}
-		// 0xaa and 0x55 is to make better distribution of hash values.
-		h.Write(buffer)
 
 	case TypeInt:
 		i, _ := v.asInt()
-		buffer = appendInt64(buffer, TypeInt, i)
-		h.Write(buffer)
+		hashInt(i, d)
 
 	case TypeFloat:
 		f, _ := v.asFloat()
 		if float64(int64(f)) == f {
-			return updateHash(Int(f), h, buffer)
+			hashInt(int64(f), d)
+			return
 		}
 
 		if math.IsNaN(f) {
 			// NaN is processed as Null with a unique counter which results in
 			// generating different hash values for each NaN.
 			cnt := atomic.AddInt64(&nullHashCounter, 1)
-			buffer = appendInt64(buffer, TypeNull, cnt)
+			d.writeByte(byte(TypeNull))
+			d.writeUint64(uint64(cnt))
 		} else {
-			buffer = appendInt64(buffer, TypeFloat, int64(math.Float64bits(f)))
+			d.writeByte(byte(TypeFloat))
+			d.writeUint64(math.Float64bits(f))
 		}
-		h.Write(buffer)
 
 	case TypeString:
 		s, _ := v.asString()
-		buffer = appendInt32(buffer, TypeString, int32(len(s)))
-		h.Write(buffer)
-		io.WriteString(h, s)
+		hashString(s, d)
 
 	case TypeBlob:
 		b, _ := v.asBlob()
-		buffer = appendInt32(buffer, TypeBlob, int32(len(b)))
-		h.Write(buffer)
-		h.Write(b)
+		d.writeByte(byte(TypeBlob))
+		d.writeUint64(uint64(len(b)))
+		d.write(b)
 
 	case TypeTimestamp:
 		t, _ := v.asTimestamp()
-		buffer = appendInt64(buffer, TypeTimestamp, t.Unix())
-		// TODO: This TypeInt isn't necessary.
-		buffer = appendInt32(buffer, TypeInt, int32(t.Nanosecond()/1000)) // Use microseconds
-		h.Write(buffer)
+		d.writeByte(byte(TypeTimestamp))
+		d.writeUint64(uint64(t.Unix()))
+		d.writeUint64(uint64(t.Nanosecond() / 1000)) // Use microseconds
 
 	case TypeArray:
 		a, _ := v.asArray()
-		buffer = appendInt32(buffer, TypeArray, int32(len(a)))
-		h.Write(buffer)
+		d.writeByte(byte(TypeArray))
+		d.writeUint64(uint64(len(a)))
 		for _, item := range a {
-			buffer = updateHash(item, h, buffer[:0])
+			updateHash(item, d)
 		}
 
 	case TypeMap:
@@ -326,29 +321,52 @@ func updateHash(v Value, h io.Writer, buffer []byte) []byte {
 		// value is 64bit and SensorBee usually process less than 1B tuples
 		// at once, the possibility is considered sufficiently low.
 
-		var upper uint32
+		var upper uint64
 		var lower uint64
 
-		subHash := fnv.New64a() // TODO: reduce this allocation
+		var sub xxh64
 		for k, v := range m {
-			subHash.Reset()
+			sub.reset()
 
 			// Because values usually vary more than keys, hash values of values
 			// should be computed first to make better distribution of hash
 			// values.
-			buffer = updateHash(v, subHash, buffer[:0])
-			buffer = updateHash(String(k), subHash, buffer[:0])
-			sh := subHash.Sum64()
+			updateHash(v, &sub)
+			hashString(k, &sub)
+			sh := sub.sum64()
 			if sh+lower < sh|lower { // carried
 				upper++
 			}
 			lower += sh
 		}
 
-		buffer = appendInt32(buffer[:0], TypeMap, int32(len(m)))
-		buffer = appendInt32(buffer, TypeMap, int32(upper))
-		buffer = appendInt64(buffer, TypeMap, int64(lower))
-		h.Write(buffer)
+		d.writeByte(byte(TypeMap))
+		d.writeUint64(uint64(len(m)))
+		d.writeUint64(upper)
+		d.writeUint64(lower)
+
+	case TypeVector:
+		vec, _ := AsVector(v)
+		d.writeByte(byte(TypeVector))
+		d.writeUint64(uint64(len(vec)))
+		for _, f := range vec {
+			var b [4]byte
+			x := math.Float32bits(f)
+			b[0], b[1], b[2], b[3] = byte(x), byte(x>>8), byte(x>>16), byte(x>>24)
+			d.write(b[:])
+		}
 	}
-	return buffer
+}
+
+// hashInt and hashString compute hash values of an Int and a String without
+// converting them to Values, which allocates memory.
+func hashInt(i int64, d *xxh64) {
+	d.writeByte(byte(TypeInt))
+	d.writeUint64(uint64(i))
+}
+
+func hashString(s string, d *xxh64) {
+	d.writeByte(byte(TypeString))
+	d.writeUint64(uint64(len(s)))
+	d.writeString(s)
 }
@@ -5,6 +5,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 	"math"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -287,7 +288,7 @@ func TestLess(t *testing.T) {
 		{Blob("hello"), Blob("a"), false},     // more bytes
 		{Blob("hello"), Blob("abcdef"), true}, // less bytes
 		{Blob("hello"), Blob("hallo"), false}, // hash is smaller
-		{Blob("hello"), Blob("abcde"), true},  // hash is larger
+		{Blob("hello"), Blob("12345"), true},  // hash is larger
 		{Blob("hello"), Timestamp(now), true},
 		{Blob("hello"), Array{Int(1)}, true},
 		{Blob("hello"), Map{"a": Int(1)}, true},
@@ -312,8 +313,8 @@ func TestLess(t *testing.T) {
 		{Array{Int(1)}, Array{Int(1)}, false},         // equal
 		{Array{Int(1)}, Array{Int(1), Int(2)}, true},  // less entries
 		{Array{Int(1), Int(2)}, Array{Int(1)}, false}, // more entries
-		{Array{Int(1)}, Array{Int(6)}, true},          // hash is smaller
-		{Array{Int(1)}, Array{Int(2)}, false},         // hash is larger
+		{Array{Int(1)}, Array{Int(2)}, true},          // hash is smaller
+		{Array{Int(1)}, Array{Int(3)}, false},         // hash is larger
 		{Array{Int(1)}, Map{"a": Int(1)}, true},
 		{Map{"a": Int(1)}, Null{}, false},
 		{Map{"a": Int(1)}, Bool(true), false},
@@ -326,7 +327,7 @@ func TestLess(t *testing.T) {
 		{Map{"a": Int(1)}, Map{"a": Int(1)}, false},              // equal
 		{Map{"a": Int(1)}, Map{"a": Int(1), "b": Int(2)}, true},  // less entries
 		{Map{"a": Int(1), "b": Int(2)}, Map{"a": Int(1)}, false}, // more entries
-		{Map{"a": Int(1)}, Map{"a": Int(8)}, true},               // hash is smaller
+		{Map{"a": Int(1)}, Map{"a": Int(13)}, true},              // hash is smaller
 		{Map{"a": Int(1)}, Map{"a": Int(3)}, false},              // hash is larger
 	}
 
@@ -396,3 +397,57 @@ func BenchmarkHashLargeMap(b *testing.B) {
 		h += Hash(m)
 	}
 }
+
+func TestHashAllocation(t *testing.T) {
+	Convey("Given a Map having nested values", t, func() {
+		m := Map{
+			"int":    Int(1),
+			"float":  Float(1.5),
+			"string": String(strings.Repeat("a", 100)),
+			"array":  Array{Int(1), Map{"a": Blob("b")}},
+			"map":    Map{"time": Timestamp(time.Unix(1, 2)), "vector": Vector{1, 2}},
+		}
+
+		Convey("When computing its hash value", func() {
+			Convey("Then it should not allocate memory", func() {
+				So(testing.AllocsPerRun(100, func() {
+					Hash(m)
+				}), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestXXH64(t *testing.T) {
+	Convey("Given strings and their XXH64 hash values", t, func() {
+		cases := []struct {
+			s string
+			h uint64
+		}{
+			{"", 0xef46db3751d8e999},
+			{"a", 0xd24ec4f1a98c6e5b},
+			{"abc", 0x44bc2cf5ad770999},
+			{"Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+			{strings.Repeat("0123456789", 10), 0xf80e7b96315afffa},
+		}
+
+		Convey("When computing hash values at once and in pieces", func() {
+			Convey("Then they should be the same as the expected values", func() {
+				for _, c := range cases {
+					var d1, d2, d3 xxh64
+					d1.reset()
+					d1.write([]byte(c.s))
+					d2.reset()
+					d2.writeString(c.s)
+					d3.reset()
+					for i := 0; i < len(c.s); i++ {
+						d3.writeByte(c.s[i])
+					}
+					So(d1.sum64(), ShouldEqual, c.h)
+					So(d2.sum64(), ShouldEqual, d1.sum64())
+					So(d3.sum64(), ShouldEqual, d1.sum64())
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,734 @@
+package data
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxJSONDepth is the maximum nesting depth of JSON arrays and objects. It's
+// the same as the limit of encoding/json.
+const maxJSONDepth = 10000
+
+// UnmarshalJSON returns a Map object from a byte array having a JSON object.
+// Values are created directly without decoding the JSON to
+// map[string]interface{} first. Numbers are decoded as Float as
+// json.Unmarshal does.
+func UnmarshalJSON(b []byte) (Map, error) {
+	v, err := unmarshalJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(Map)
+	if !ok {
+		return nil, fmt.Errorf("cannot unmarshal %v into Map", v.Type())
+	}
+	return m, nil
+}
+
+// MarshalJSON returns a byte array having the JSON representation of a Map.
+// The result is the same as the one of json.Marshal, but the Map is written
+// without reflection.
+func MarshalJSON(m Map) ([]byte, error) {
+	return appendJSON(nil, m)
+}
+
+func unmarshalJSONValue(b []byte) (Value, error) {
+	s := jsonScanner{buf: b}
+	v, err := s.value()
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := s.skipSpace(); ok {
+		return nil, invalidJSONChar(c, "after top-level value")
+	}
+	return v, nil
+}
+
+// JSONDecoder reads and decodes a stream of JSON values. Values can be
+// separated by whitespace or written without separators as json.Decoder
+// accepts. Values are created directly without decoding the JSON to
+// interface{} first.
+type JSONDecoder struct {
+	s   jsonScanner
+	err error
+}
+
+// NewJSONDecoder creates a JSONDecoder reading from r. The decoder buffers
+// the input and may read more data than needed to decode a value.
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{
+		s: jsonScanner{
+			r:   r,
+			buf: make([]byte, 0, 4096),
+		},
+	}
+}
+
+// Decode reads the next JSON value from the input. It returns io.EOF when
+// the input doesn't have any more values. Once it returns an error other
+// than io.EOF, it always returns the same error.
+func (d *JSONDecoder) Decode() (Value, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if _, ok := d.s.skipSpace(); !ok {
+		if d.s.err != nil && d.s.err != io.EOF {
+			d.err = d.s.err
+			return nil, d.err
+		}
+		return nil, io.EOF
+	}
+	v, err := d.s.value()
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecodeMap reads the next JSON value from the input as Decode does. It
+// returns an error when the value isn't a JSON object.
+func (d *JSONDecoder) DecodeMap() (Map, error) {
+	v, err := d.Decode()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(Map)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode %v as Map", v.Type())
+	}
+	return m, nil
+}
+
+// JSONEncoder writes JSON representations of values to an output stream.
+type JSONEncoder struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewJSONEncoder creates a JSONEncoder writing to w.
+func NewJSONEncoder(w io.Writer) *JSONEncoder {
+	return &JSONEncoder{
+		w: w,
+	}
+}
+
+// Encode writes the JSON representation of v followed by a newline
+// character as json.Encoder does. The representation is the same as the one
+// of json.Marshal.
+func (e *JSONEncoder) Encode(v Value) error {
+	b, err := appendJSON(e.buf[:0], v)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	e.buf = b
+	_, err = e.w.Write(b)
+	return err
+}
+
+// jsonScanner decodes JSON values from buf. When r isn't nil, buf is
+// refilled from r once all bytes in it have been consumed. Otherwise, buf
+// has the whole input.
+type jsonScanner struct {
+	r   io.Reader
+	buf []byte
+	pos int
+
+	// err is the error returned from r.
+	err error
+
+	// scratch is used to build strings and numbers spanning multiple reads.
+	scratch []byte
+	depth   int
+}
+
+// fill reads the next bytes from r and returns true when any byte is read.
+// Bytes before pos are discarded.
+func (s *jsonScanner) fill() bool {
+	if s.r == nil || s.err != nil {
+		return false
+	}
+	for {
+		n, err := s.r.Read(s.buf[:cap(s.buf)])
+		s.buf = s.buf[:n]
+		s.pos = 0
+		if err != nil {
+			s.err = err
+		}
+		if n > 0 {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+func (s *jsonScanner) peek() (byte, bool) {
+	if s.pos >= len(s.buf) && !s.fill() {
+		return 0, false
+	}
+	return s.buf[s.pos], true
+}
+
+func (s *jsonScanner) next() (byte, error) {
+	c, ok := s.peek()
+	if !ok {
+		return 0, s.unexpectedEOF()
+	}
+	s.pos++
+	return c, nil
+}
+
+// skipSpace skips whitespace and returns the next byte without consuming
+// it. It returns false when the input ends.
+func (s *jsonScanner) skipSpace() (byte, bool) {
+	for {
+		for s.pos < len(s.buf) {
+			switch c := s.buf[s.pos]; c {
+			case ' ', '\t', '\r', '\n':
+				s.pos++
+			default:
+				return c, true
+			}
+		}
+		if !s.fill() {
+			return 0, false
+		}
+	}
+}
+
+func (s *jsonScanner) unexpectedEOF() error {
+	if s.err != nil && s.err != io.EOF {
+		return s.err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+func invalidJSONChar(c byte, context string) error {
+	return fmt.Errorf("invalid character %q %v", rune(c), context)
+}
+
+func (s *jsonScanner) value() (Value, error) {
+	c, ok := s.skipSpace()
+	if !ok {
+		return nil, s.unexpectedEOF()
+	}
+	switch {
+	case c == '{':
+		return s.object()
+	case c == '[':
+		return s.array()
+	case c == '"':
+		str, err := s.str()
+		if err != nil {
+			return nil, err
+		}
+		return String(str), nil
+	case c == 't':
+		return Bool(true), s.literal("true")
+	case c == 'f':
+		return Bool(false), s.literal("false")
+	case c == 'n':
+		return Null{}, s.literal("null")
+	case c == '-' || ('0' <= c && c <= '9'):
+		return s.number()
+	}
+	return nil, invalidJSONChar(c, "looking for beginning of value")
+}
+
+func (s *jsonScanner) enter() error {
+	s.pos++ // '{' or '['
+	s.depth++
+	if s.depth > maxJSONDepth {
+		return errors.New("exceeded max depth")
+	}
+	return nil
+}
+
+func (s *jsonScanner) object() (Value, error) {
+	if err := s.enter(); err != nil {
+		return nil, err
+	}
+	m := Map{}
+	c, ok := s.skipSpace()
+	if !ok {
+		return nil, s.unexpectedEOF()
+	}
+	if c == '}' {
+		s.pos++
+		s.depth--
+		return m, nil
+	}
+
+	for {
+		if c != '"' {
+			return nil, invalidJSONChar(c, "looking for beginning of object key string")
+		}
+		key, err := s.str()
+		if err != nil {
+			return nil, err
+		}
+		if c, ok = s.skipSpace(); !ok {
+			return nil, s.unexpectedEOF()
+		} else if c != ':' {
+			return nil, invalidJSONChar(c, "after object key")
+		}
+		s.pos++
+
+		v, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+
+		if c, ok = s.skipSpace(); !ok {
+			return nil, s.unexpectedEOF()
+		}
+		s.pos++
+		switch c {
+		case ',':
+			if c, ok = s.skipSpace(); !ok {
+				return nil, s.unexpectedEOF()
+			}
+		case '}':
+			s.depth--
+			return m, nil
+		default:
+			return nil, invalidJSONChar(c, "after object key:value pair")
+		}
+	}
+}
+
+func (s *jsonScanner) array() (Value, error) {
+	if err := s.enter(); err != nil {
+		return nil, err
+	}
+	a := Array{}
+	c, ok := s.skipSpace()
+	if !ok {
+		return nil, s.unexpectedEOF()
+	}
+	if c == ']' {
+		s.pos++
+		s.depth--
+		return a, nil
+	}
+
+	for {
+		v, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+
+		if c, ok = s.skipSpace(); !ok {
+			return nil, s.unexpectedEOF()
+		}
+		s.pos++
+		switch c {
+		case ',':
+		case ']':
+			s.depth--
+			return a, nil
+		default:
+			return nil, invalidJSONChar(c, "after array element")
+		}
+	}
+}
+
+func (s *jsonScanner) literal(l string) error {
+	for i := 0; i < len(l); i++ {
+		c, err := s.next()
+		if err != nil {
+			return err
+		}
+		if c != l[i] {
+			return invalidJSONChar(c, fmt.Sprintf("in literal %v (expecting %q)", l, rune(l[i])))
+		}
+	}
+	return nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digits appends consecutive digits to scratch and returns the number of
+// them.
+func (s *jsonScanner) digits() int {
+	n := 0
+	for {
+		c, ok := s.peek()
+		if !ok || !isDigit(c) {
+			return n
+		}
+		s.scratch = append(s.scratch, c)
+		s.pos++
+		n++
+	}
+}
+
+func (s *jsonScanner) number() (Value, error) {
+	s.scratch = s.scratch[:0]
+	if c, _ := s.peek(); c == '-' {
+		s.scratch = append(s.scratch, c)
+		s.pos++
+	}
+
+	c, ok := s.peek()
+	switch {
+	case !ok:
+		return nil, s.unexpectedEOF()
+	case c == '0':
+		s.scratch = append(s.scratch, c)
+		s.pos++
+	case isDigit(c):
+		s.digits()
+	default:
+		return nil, invalidJSONChar(c, "in numeric literal")
+	}
+
+	if c, ok := s.peek(); ok && c == '.' {
+		s.scratch = append(s.scratch, c)
+		s.pos++
+		if s.digits() == 0 {
+			return nil, s.invalidNumber("after decimal point in numeric literal")
+		}
+	}
+	if c, ok := s.peek(); ok && (c == 'e' || c == 'E') {
+		s.scratch = append(s.scratch, c)
+		s.pos++
+		if c, ok := s.peek(); ok && (c == '+' || c == '-') {
+			s.scratch = append(s.scratch, c)
+			s.pos++
+		}
+		if s.digits() == 0 {
+			return nil, s.invalidNumber("in exponent of numeric literal")
+		}
+	}
+
+	f, err := strconv.ParseFloat(string(s.scratch), 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode number %s: %v", s.scratch, err)
+	}
+	return Float(f), nil
+}
+
+func (s *jsonScanner) invalidNumber(context string) error {
+	c, ok := s.peek()
+	if !ok {
+		return s.unexpectedEOF()
+	}
+	return invalidJSONChar(c, context)
+}
+
+// str decodes a string. The current byte must be '"'.
+func (s *jsonScanner) str() (string, error) {
+	s.pos++
+	s.scratch = s.scratch[:0]
+	for {
+		start := s.pos
+		for s.pos < len(s.buf) {
+			if c := s.buf[s.pos]; c == '"' || c == '\\' || c < 0x20 {
+				break
+			}
+			s.pos++
+		}
+		if s.pos < len(s.buf) && s.buf[s.pos] == '"' && len(s.scratch) == 0 {
+			// fast path: the string doesn't have any escape sequence
+			b := s.buf[start:s.pos]
+			s.pos++
+			if !utf8.Valid(b) {
+				return string(appendValidUTF8(nil, b)), nil
+			}
+			return string(b), nil
+		}
+		s.scratch = append(s.scratch, s.buf[start:s.pos]...)
+		if s.pos >= len(s.buf) {
+			if !s.fill() {
+				return "", s.unexpectedEOF()
+			}
+			continue
+		}
+
+		c, err := s.next()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case c == '"':
+			if !utf8.Valid(s.scratch) {
+				return string(appendValidUTF8(nil, s.scratch)), nil
+			}
+			return string(s.scratch), nil
+		case c == '\\':
+			if err := s.escape(); err != nil {
+				return "", err
+			}
+		default:
+			return "", invalidJSONChar(c, "in string literal")
+		}
+	}
+}
+
+// escape decodes an escape sequence following a backslash and appends the
+// character to scratch. Invalid surrogates are replaced with U+FFFD as
+// encoding/json does.
+func (s *jsonScanner) escape() error {
+	c, err := s.next()
+	if err != nil {
+		return err
+	}
+	if c != 'u' {
+		return s.simpleEscape(c)
+	}
+	r, err := s.hex4()
+	if err != nil {
+		return err
+	}
+
+	for utf16.IsSurrogate(r) {
+		if c, ok := s.peek(); !ok || c != '\\' {
+			s.appendRune(unicode.ReplacementChar)
+			return nil
+		}
+		s.pos++
+		c, err := s.next()
+		if err != nil {
+			return err
+		}
+		if c != 'u' {
+			s.appendRune(unicode.ReplacementChar)
+			return s.simpleEscape(c)
+		}
+		r2, err := s.hex4()
+		if err != nil {
+			return err
+		}
+		if dec := utf16.DecodeRune(r, r2); dec != unicode.ReplacementChar {
+			s.appendRune(dec)
+			return nil
+		}
+		// the second escape sequence is decoded independently
+		s.appendRune(unicode.ReplacementChar)
+		r = r2
+	}
+	s.appendRune(r)
+	return nil
+}
+
+func (s *jsonScanner) simpleEscape(c byte) error {
+	switch c {
+	case '"', '\\', '/':
+		s.scratch = append(s.scratch, c)
+	case 'b':
+		s.scratch = append(s.scratch, '\b')
+	case 'f':
+		s.scratch = append(s.scratch, '\f')
+	case 'n':
+		s.scratch = append(s.scratch, '\n')
+	case 'r':
+		s.scratch = append(s.scratch, '\r')
+	case 't':
+		s.scratch = append(s.scratch, '\t')
+	default:
+		return invalidJSONChar(c, "in string escape code")
+	}
+	return nil
+}
+
+func (s *jsonScanner) hex4() (rune, error) {
+	var r rune
+	for i := 0; i < 4; i++ {
+		c, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, invalidJSONChar(c, "in \\u hexadecimal character escape")
+		}
+		r = r*16 + rune(c)
+	}
+	return r, nil
+}
+
+func (s *jsonScanner) appendRune(r rune) {
+	var b [utf8.UTFMax]byte
+	n := utf8.EncodeRune(b[:], r)
+	s.scratch = append(s.scratch, b[:n]...)
+}
+
+// appendValidUTF8 appends b to dst replacing each invalid byte with U+FFFD.
+func appendValidUTF8(dst, b []byte) []byte {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, "\ufffd"...)
+		} else {
+			dst = append(dst, b[:size]...)
+		}
+		b = b[size:]
+	}
+	return dst
+}
+
+// appendJSON appends the JSON representation of v to b. The result is the
+// same as the one of json.Marshal.
+func appendJSON(b []byte, v Value) ([]byte, error) {
+	switch v := v.(type) {
+	case nil, Null:
+		return append(b, "null"...), nil
+	case Bool:
+		return strconv.AppendBool(b, bool(v)), nil
+	case Int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case Float:
+		return appendJSONFloat(b, float64(v)), nil
+	case String:
+		return appendJSONString(b, string(v)), nil
+	case Blob:
+		b = append(b, '"')
+		n := base64.StdEncoding.EncodedLen(len(v))
+		b = append(b, make([]byte, n)...)
+		base64.StdEncoding.Encode(b[len(b)-n:], v)
+		return append(b, '"'), nil
+	case *SharedBlob:
+		blob, err := v.asBlob()
+		if err != nil {
+			return nil, err
+		}
+		return appendJSON(b, Blob(blob))
+	case Timestamp:
+		b = append(b, '"')
+		b = time.Time(v).AppendFormat(b, time.RFC3339Nano)
+		return append(b, '"'), nil
+	case Array:
+		b = append(b, '[')
+		for i, e := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSON(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	case Map:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b = append(b, '{')
+		for i, k := range keys {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, k)
+			b = append(b, ':')
+			var err error
+			if b, err = appendJSON(b, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, '}'), nil
+	case Vector:
+		b = append(b, '[')
+		for i, f := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONFloat(b, float64(f))
+		}
+		return append(b, ']'), nil
+	default:
+		j, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(b, j...), nil
+	}
+}
+
+// appendJSONFloat appends a float in the same way as Float.String.
+func appendJSONFloat(b []byte, f float64) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(b, "null"...)
+	}
+	return strconv.AppendFloat(b, f, 'g', -1, 64)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends a quoted string escaped in the same way as
+// json.Marshal, which also escapes '<', '>', and '&'.
+func appendJSONString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+		case r == '\u2028' || r == '\u2029':
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
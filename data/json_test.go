@@ -0,0 +1,289 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// unmarshalJSONViaInterface decodes JSON in the way Map.UnmarshalJSON did
+// before having its own decoder.
+func unmarshalJSONViaInterface(b []byte) (Map, error) {
+	var j map[string]interface{}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+	return NewMap(j)
+}
+
+var jsonTestDocuments = []string{
+	`{}`,
+	` { "a" : 1 , "b":[ ] ,"c":{}} `,
+	`{"int":1,"neg":-2,"float":1.5,"exp":1e10,"exp2":-2.5E-3,"zero":0,"big":12345678901234567890}`,
+	`{"bool":true,"false":false,"null":null,"str":"hoge"}`,
+	`{"nested":{"array":[1,"a",[true,null],{"b":{"c":[]}}]}}`,
+	`{"escape":"\"\\\/\b\f\n\r\t","unicode":"あAé"}`,
+	`{"pair":"😀","lone":"\ud83d","lone2":"\ude00x","bad_pair":"\ud83dA","high_high":"\ud83d😀","then_escape":"\ud83d\n"}`,
+	"{\"invalid_utf8\":\"a\xffb\xc0\",\"raw\":\"日本語\"}",
+	`{"dup":1,"dup":2}`,
+	`{"日本語":"キー"}`,
+}
+
+var invalidJSONTestDocuments = []string{
+	``,
+	`{`,
+	`{"a"`,
+	`{"a":`,
+	`{"a":1`,
+	`{"a":1,}`,
+	`{"a" 1}`,
+	`{a:1}`,
+	`{"a":[1,]}`,
+	`{"a":[1 2]}`,
+	`{"a":tru}`,
+	`{"a":nul}`,
+	`{"a":01}`,
+	`{"a":1.}`,
+	`{"a":.5}`,
+	`{"a":1e}`,
+	`{"a":-}`,
+	`{"a":+1}`,
+	`{"a":1e400}`,
+	`{"a":"b}`,
+	"{\"a\":\"b\nc\"}",
+	`{"a":"\x"}`,
+	`{"a":"\u12G4"}`,
+	`{"a":1}}`,
+	`{"a":1} x`,
+	`[1]`,
+	`"a"`,
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	Convey("Given JSON documents", t, func() {
+		Convey("When unmarshaling them", func() {
+			Convey("Then the results should be the same as the ones of encoding/json", func() {
+				for _, d := range jsonTestDocuments {
+					expected, err := unmarshalJSONViaInterface([]byte(d))
+					So(err, ShouldBeNil)
+					actual, err := UnmarshalJSON([]byte(d))
+					So(err, ShouldBeNil)
+					So(actual, ShouldResemble, expected)
+				}
+			})
+		})
+
+		Convey("When unmarshaling them with Map.UnmarshalJSON", func() {
+			Convey("Then the results should be the same as the ones of encoding/json", func() {
+				for _, d := range jsonTestDocuments {
+					expected, err := unmarshalJSONViaInterface([]byte(d))
+					So(err, ShouldBeNil)
+					var actual Map
+					So(json.Unmarshal([]byte(d), &actual), ShouldBeNil)
+					So(actual, ShouldResemble, expected)
+				}
+			})
+		})
+	})
+
+	Convey("Given invalid JSON documents", t, func() {
+		for _, d := range invalidJSONTestDocuments {
+			d := d
+			Convey(fmt.Sprintf("When unmarshaling %q", d), func() {
+				_, err := UnmarshalJSON([]byte(d))
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given deeply nested JSON", t, func() {
+		d := `{"a":` + strings.Repeat("[", maxJSONDepth) + strings.Repeat("]", maxJSONDepth) + "}"
+
+		Convey("When unmarshaling it", func() {
+			_, err := UnmarshalJSON([]byte(d))
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given null", t, func() {
+		Convey("When unmarshaling it to a Map and an Array", func() {
+			m := Map{"a": Int(1)}
+			So(json.Unmarshal([]byte("null"), &m), ShouldBeNil)
+			a := Array{Int(1)}
+			So(json.Unmarshal([]byte("null"), &a), ShouldBeNil)
+
+			Convey("Then they should be empty", func() {
+				So(m, ShouldResemble, Map{})
+				So(a, ShouldResemble, Array{})
+			})
+		})
+	})
+}
+
+func TestJSONDecoder(t *testing.T) {
+	Convey("Given a stream of JSON documents", t, func() {
+		src := strings.Join(jsonTestDocuments, "\n") + "\n" + `{"a":1}{"b":2}` + " [1,2] 3 \"s\" "
+		expected := []Value{}
+		for _, d := range jsonTestDocuments {
+			m, err := unmarshalJSONViaInterface([]byte(d))
+			So(err, ShouldBeNil)
+			expected = append(expected, m)
+		}
+		expected = append(expected, Map{"a": Float(1)}, Map{"b": Float(2)},
+			Array{Float(1), Float(2)}, Float(3), String("s"))
+
+		Convey("When decoding it byte by byte", func() {
+			dec := NewJSONDecoder(iotest.OneByteReader(strings.NewReader(src)))
+
+			Convey("Then all values should be decoded", func() {
+				for _, e := range expected {
+					v, err := dec.Decode()
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, e)
+				}
+				_, err := dec.Decode()
+				So(err, ShouldEqual, io.EOF)
+			})
+		})
+
+		Convey("When decoding it with a buffer", func() {
+			dec := NewJSONDecoder(strings.NewReader(src))
+
+			Convey("Then all values should be decoded", func() {
+				for _, e := range expected {
+					v, err := dec.Decode()
+					So(err, ShouldBeNil)
+					So(v, ShouldResemble, e)
+				}
+				_, err := dec.Decode()
+				So(err, ShouldEqual, io.EOF)
+			})
+		})
+
+		Convey("When decoding it as Maps", func() {
+			dec := NewJSONDecoder(strings.NewReader(src))
+			for range jsonTestDocuments {
+				_, err := dec.DecodeMap()
+				So(err, ShouldBeNil)
+			}
+			_, err := dec.DecodeMap()
+			So(err, ShouldBeNil)
+			_, err = dec.DecodeMap()
+			So(err, ShouldBeNil)
+
+			Convey("Then it should fail on an array", func() {
+				_, err := dec.DecodeMap()
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a stream having a broken document", t, func() {
+		dec := NewJSONDecoder(iotest.OneByteReader(strings.NewReader(`{"a":1} {"b":`)))
+
+		Convey("When decoding it", func() {
+			v, err := dec.Decode()
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, Map{"a": Float(1)})
+
+			Convey("Then it should fail on the broken document", func() {
+				_, err := dec.Decode()
+				So(err, ShouldEqual, io.ErrUnexpectedEOF)
+
+				Convey("And it should keep failing", func() {
+					_, err := dec.Decode()
+					So(err, ShouldEqual, io.ErrUnexpectedEOF)
+				})
+			})
+		})
+	})
+}
+
+func TestMarshalJSON(t *testing.T) {
+	Convey("Given a Map having all types of values", t, func() {
+		now := time.Date(2016, 1, 2, 3, 4, 5, 678, time.UTC)
+		m := Map{
+			"null":   Null{},
+			"bool":   True,
+			"int":    Int(-123456789),
+			"float":  Float(1.5),
+			"floats": Array{Float(1e20), Float(1e21), Float(1e-6), Float(123456789), Float(-0.0), Float(math.NaN()), Float(math.Inf(-1))},
+			"string": String("\"\\/<>&\b\f\n\r\t\x01\x1f   日本語 \xff\xc0"),
+			"blob":   Blob("hoge\x00"),
+			"time":   Timestamp(now),
+			"array":  Array{Int(1), String("a"), Map{"b": Array{}}},
+			"map":    Map{"z": Int(1), "a": Int(2), "<key>": Map{}},
+			"vector": Vector{1, 0.5, float32(math.NaN())},
+			"":       String(""),
+		}
+
+		Convey("When marshaling it", func() {
+			b, err := MarshalJSON(m)
+			So(err, ShouldBeNil)
+
+			Convey("Then the result should be the same as the one of encoding/json", func() {
+				expected, err := json.Marshal(m)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, string(expected))
+			})
+
+			Convey("Then String should return the same result", func() {
+				So(m.String(), ShouldEqual, string(b))
+			})
+
+			Convey("Then it should be unmarshaled to the equivalent Map", func() {
+				u, err := UnmarshalJSON(b)
+				So(err, ShouldBeNil)
+				So(u["int"], ShouldEqual, Float(-123456789))
+				So(u["array"], ShouldResemble, Array{Float(1), String("a"), Map{"b": Array{}}})
+			})
+		})
+
+		Convey("When encoding it with JSONEncoder", func() {
+			buf := bytes.NewBuffer(nil)
+			enc := NewJSONEncoder(buf)
+			So(enc.Encode(m), ShouldBeNil)
+			So(enc.Encode(Array{Int(1)}), ShouldBeNil)
+
+			Convey("Then each value should be written in a line", func() {
+				expected, err := json.Marshal(m)
+				So(err, ShouldBeNil)
+				So(buf.String(), ShouldEqual, string(expected)+"\n[1]\n")
+			})
+		})
+	})
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	d := []byte(`{"id":12345,"name":"sensor-01","temperature":23.5,"tags":["a","b","c"],` +
+		`"location":{"lat":35.6,"lon":139.7},"active":true,"note":null}`)
+	b.Run("direct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := UnmarshalJSON(d); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("via interface", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := unmarshalJSONViaInterface(d); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -60,9 +60,93 @@ func CompilePath(s string) (p Path, err error) {
 			containsSlice = true
 		}
 	}
+	if sp := newSimplePath(j); sp != nil {
+		return sp, nil
+	}
 	return j, nil
 }
 
+// simplePath is a Path only having map keys and array indexes such as
+// "a.b[0].c", which are what most paths in queries look like. Its segments
+// are split when the path is compiled so that evaluate can walk through them
+// without extractors, which allocate a Value on each step. Errors reporting
+// missing keys are also created in advance because missing keys aren't rare
+// in streams having optional fields.
+type simplePath struct {
+	segments []pathSegment
+
+	// peg is the original path which is used to set values.
+	peg *jsonPeg
+}
+
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	notFound error
+}
+
+// newSimplePath returns a simplePath equivalent to j, or nil when j has
+// components other than map keys or array indexes.
+func newSimplePath(j *jsonPeg) *simplePath {
+	segs := make([]pathSegment, 0, len(j.components))
+	for _, c := range j.components {
+		switch e := c.(type) {
+		case *mapValueExtractor:
+			segs = append(segs, pathSegment{
+				key:      e.key,
+				notFound: fmt.Errorf("key '%s' was not found in map", e.key),
+			})
+		case *arrayElementExtractor:
+			segs = append(segs, pathSegment{index: e.idx, isIndex: true})
+		default:
+			return nil
+		}
+	}
+	return &simplePath{
+		segments: segs,
+		peg:      j,
+	}
+}
+
+// evaluate returns the same result as jsonPeg.evaluate.
+func (p *simplePath) evaluate(m Map) (Value, error) {
+	var current Value = m
+	for i := range p.segments {
+		s := &p.segments[i]
+		if !s.isIndex {
+			cont, err := current.asMap()
+			if err != nil {
+				return nil, err
+			}
+			v, ok := cont[s.key]
+			if !ok {
+				return nil, s.notFound
+			}
+			current = v
+			continue
+		}
+
+		cont, err := current.asArray()
+		if err != nil {
+			return nil, fmt.Errorf("cannot access a %T using index %d", current, s.index)
+		}
+		idx := s.index
+		if idx < 0 {
+			idx += len(cont)
+		}
+		if idx < 0 || len(cont) <= idx {
+			return nil, fmt.Errorf("out of range access: %d (length %d)", s.index, len(cont))
+		}
+		current = cont[idx]
+	}
+	return current, nil
+}
+
+func (p *simplePath) set(m Map, v Value) error {
+	return p.peg.set(m, v)
+}
+
 // evaluate returns the entry of the map located at the JSON Path
 // represented by this jsonPeg instance.
 func (j *jsonPeg) evaluate(m Map) (Value, error) {
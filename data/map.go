@@ -1,7 +1,6 @@
 package data
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -55,7 +54,7 @@ func (m Map) clone() Value {
 func (m Map) String() string {
 	// the String return value is defined via the
 	// default JSON serialization
-	bytes, err := json.Marshal(m)
+	bytes, err := appendJSON(nil, m)
 	if err != nil {
 		return fmt.Sprintf("(unserializable map: %v)", err)
 	}
@@ -64,16 +63,19 @@ func (m Map) String() string {
 
 // UnmarshalJSON reconstructs a Map from JSON.
 func (m *Map) UnmarshalJSON(data []byte) error {
-	var j map[string]interface{}
-	if err := json.Unmarshal(data, &j); err != nil {
+	v, err := unmarshalJSONValue(data)
+	if err != nil {
 		return err
 	}
 
-	newMap, err := NewMap(j)
-	if err != nil {
-		return err
+	switch v := v.(type) {
+	case Null:
+		*m = Map{}
+	case Map:
+		*m = v
+	default:
+		return fmt.Errorf("cannot unmarshal %v into Map", v.Type())
 	}
-	*m = newMap
 	return nil
 }
 
@@ -1097,3 +1097,116 @@ func BenchmarkMapAccess(b *testing.B) {
 		}
 	}
 }
+
+func TestSimplePath(t *testing.T) {
+	Convey("Given a Map", t, func() {
+		m := Map{
+			"store": Map{
+				"name": String("store name"),
+				"book": Array{
+					Map{"title": String("book name")},
+					Map{"title": String("another book"), "tags": Array{String("a")}},
+				},
+				"null": Null{},
+			},
+			"a.b": Int(1),
+		}
+
+		Convey("When compiling paths only having keys and indexes", func() {
+			paths := []string{
+				"store", "store.name", "store.book[0].title", "store.book[-1].tags[0]",
+				`["store"]["book"][1]["title"]`, `["a.b"]`, "store.book[2]", "store.book[-3]",
+				"store.name[0]", "store.book.title", "store.null.x", "store.missing", "missing.x",
+			}
+
+			Convey("Then they should return the same results as the generic paths", func() {
+				for _, s := range paths {
+					p, err := CompilePath(s)
+					So(err, ShouldBeNil)
+					sp, ok := p.(*simplePath)
+					So(ok, ShouldBeTrue)
+
+					expected, expectedErr := sp.peg.evaluate(m)
+					actual, err := m.Get(p)
+					So(actual, ShouldResemble, expected)
+					if expectedErr == nil {
+						So(err, ShouldBeNil)
+					} else {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, expectedErr.Error())
+					}
+				}
+			})
+
+			Convey("Then evaluating them should not allocate", func() {
+				for _, s := range []string{"store.book[-1].tags[0]", "store.missing"} {
+					p := MustCompilePath(s)
+					allocs := testing.AllocsPerRun(100, func() {
+						m.Get(p)
+					})
+					So(allocs, ShouldEqual, 0)
+				}
+			})
+
+			Convey("Then they should be able to set values", func() {
+				p := MustCompilePath("store.book[0].price")
+				So(m.Set(p, Int(100)), ShouldBeNil)
+				v, err := m.Get(p)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, Int(100))
+			})
+		})
+
+		Convey("When compiling paths having slices or recursive descents", func() {
+			Convey("Then they should not be simple paths", func() {
+				for _, s := range []string{"store.book[0:1]", "store..title"} {
+					p, err := CompilePath(s)
+					So(err, ShouldBeNil)
+					_, ok := p.(*simplePath)
+					So(ok, ShouldBeFalse)
+				}
+			})
+		})
+	})
+}
+
+func BenchmarkPathEvaluation(b *testing.B) {
+	m := Map{
+		"store": Map{
+			"name": String("store name"),
+			"book": Array{
+				Map{"title": String("book name")},
+			},
+		},
+	}
+	sp := MustCompilePath("store.book[0].title").(*simplePath)
+	b.Run("simple", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := m.Get(sp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("extractors", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := m.Get(sp.peg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	missing := MustCompilePath("store.price").(*simplePath)
+	b.Run("simple missing", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m.Get(missing)
+		}
+	})
+	b.Run("extractors missing", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m.Get(missing.peg)
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// SharedBlob is a handle of a large binary object, such as a video segment
+// or a model file, which is passed by reference. While Blob is copied every
+// time a Tuple or a Map having it is copied, e.g. for each destination of
+// a fan-out edge, copying a SharedBlob only creates a new handle referring
+// to the same buffer. A SharedBlob can be assigned to Value interface and
+// its type is TypeBlob, so functions accepting Blob, such as AsBlob, Equal,
+// or Hash, also accept it.
+//
+// The buffer is shared by all handles and must never be modified. A handle
+// can be checked by Verify whether the buffer has been modified since the
+// handle was created. Components which modify blobs must convert them to
+// Blob by Copy first.
+//
+// The buffer is reference-counted by handles. Each handle can be released
+// by Release once it's no longer used, and the release function given to
+// NewSharedBlob is called when all handles have been released so that the
+// buffer can be reused. Handles which aren't released are collected by GC
+// as usual, in which case the release function isn't called.
+type SharedBlob struct {
+	buf *sharedBlobBuffer
+
+	// released is 1 when the handle has been released.
+	released int32
+}
+
+// sharedBlobBuffer is a buffer referred by SharedBlob handles.
+type sharedBlobBuffer struct {
+	data     []byte
+	checksum HashValue
+	refs     int64
+	release  func([]byte)
+}
+
+var errSharedBlobReleased = errors.New("the shared blob has already been released")
+
+// NewSharedBlob creates a new handle of a buffer having b. b must not be
+// modified after the call. release is called with b when all handles of
+// the buffer have been released. It can be nil.
+func NewSharedBlob(b []byte, release func([]byte)) *SharedBlob {
+	return &SharedBlob{
+		buf: &sharedBlobBuffer{
+			data:     b,
+			checksum: Hash(Blob(b)),
+			refs:     1,
+			release:  release,
+		},
+	}
+}
+
+// Type returns TypeID of SharedBlob. It's always TypeBlob.
+func (s *SharedBlob) Type() TypeID {
+	return TypeBlob
+}
+
+func (s *SharedBlob) asBool() (bool, error) {
+	return false, castError(s.Type(), TypeBool)
+}
+
+func (s *SharedBlob) asInt() (int64, error) {
+	return 0, castError(s.Type(), TypeInt)
+}
+
+func (s *SharedBlob) asFloat() (float64, error) {
+	return 0, castError(s.Type(), TypeFloat)
+}
+
+func (s *SharedBlob) asString() (string, error) {
+	return "", castError(s.Type(), TypeString)
+}
+
+// asBlob returns the shared buffer itself. The caller must not modify it.
+func (s *SharedBlob) asBlob() ([]byte, error) {
+	if s.isReleased() {
+		return nil, errSharedBlobReleased
+	}
+	return s.buf.data, nil
+}
+
+func (s *SharedBlob) asTimestamp() (time.Time, error) {
+	return time.Time{}, castError(s.Type(), TypeTimestamp)
+}
+
+func (s *SharedBlob) asArray() (Array, error) {
+	return nil, castError(s.Type(), TypeArray)
+}
+
+func (s *SharedBlob) asMap() (Map, error) {
+	return nil, castError(s.Type(), TypeMap)
+}
+
+// clone creates a new handle of the same buffer instead of copying it. A
+// released handle is cloned as an empty Blob.
+func (s *SharedBlob) clone() Value {
+	if s.isReleased() {
+		return Blob{}
+	}
+	atomic.AddInt64(&s.buf.refs, 1)
+	return &SharedBlob{buf: s.buf}
+}
+
+// Copy returns a copy of the buffer as a Blob which can be modified.
+func (s *SharedBlob) Copy() (Blob, error) {
+	b, err := s.asBlob()
+	if err != nil {
+		return nil, err
+	}
+	out := make(Blob, len(b))
+	copy(out, b)
+	return out, nil
+}
+
+// Len returns the size of the buffer in bytes.
+func (s *SharedBlob) Len() int {
+	return len(s.buf.data)
+}
+
+// Release releases the handle. The handle cannot be used after this call.
+// Releasing a handle more than once doesn't have any effect.
+func (s *SharedBlob) Release() {
+	if !atomic.CompareAndSwapInt32(&s.released, 0, 1) {
+		return
+	}
+	if atomic.AddInt64(&s.buf.refs, -1) == 0 && s.buf.release != nil {
+		s.buf.release(s.buf.data)
+	}
+}
+
+func (s *SharedBlob) isReleased() bool {
+	return atomic.LoadInt32(&s.released) != 0
+}
+
+// refs returns the number of handles of the buffer which haven't been
+// released.
+func (s *SharedBlob) refs() int64 {
+	return atomic.LoadInt64(&s.buf.refs)
+}
+
+// Verify returns an error when the buffer has been modified after the
+// handle was created. It reads the whole buffer, so it should only be used
+// for debugging. A released handle isn't verified because its buffer might
+// already be reused.
+func (s *SharedBlob) Verify() error {
+	if s.isReleased() {
+		return nil
+	}
+	if Hash(Blob(s.buf.data)) != s.buf.checksum {
+		return fmt.Errorf("the shared blob of %v bytes has been modified", len(s.buf.data))
+	}
+	return nil
+}
+
+// MarshalJSON marshals a SharedBlob in the same way as Blob.
+func (s *SharedBlob) MarshalJSON() ([]byte, error) {
+	b, err := s.asBlob()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(b)
+}
+
+// String returns JSON representation of a SharedBlob in the same way as
+// Blob.
+func (s *SharedBlob) String() string {
+	b, err := s.asBlob()
+	if err != nil {
+		return fmt.Sprintf("(unserializable blob: %v)", err)
+	}
+	return Blob(b).String()
+}
+
+// FindSharedBlobs appends all SharedBlobs in v to dst and returns the
+// extended slice. It looks into Arrays and Maps recursively.
+func FindSharedBlobs(dst []*SharedBlob, v Value) []*SharedBlob {
+	switch v := v.(type) {
+	case *SharedBlob:
+		return append(dst, v)
+	case Array:
+		for _, e := range v {
+			dst = FindSharedBlobs(dst, e)
+		}
+	case Map:
+		for _, e := range v {
+			dst = FindSharedBlobs(dst, e)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,106 @@
+package data
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSharedBlob(t *testing.T) {
+	Convey("Given a SharedBlob having a release function", t, func() {
+		var released []byte
+		s := NewSharedBlob([]byte("large blob"), func(b []byte) {
+			released = b
+		})
+
+		Convey("Then it should be a Blob", func() {
+			So(s.Type(), ShouldEqual, TypeBlob)
+			b, err := AsBlob(s)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, "large blob")
+			So(Equal(s, Blob("large blob")), ShouldBeTrue)
+			So(Hash(s), ShouldEqual, Hash(Blob("large blob")))
+			So(s.String(), ShouldEqual, Blob("large blob").String())
+			So(s.Verify(), ShouldBeNil)
+		})
+
+		Convey("When copying a Map having it", func() {
+			m := Map{"blob": s, "a": Array{s}}
+			c := m.Copy()
+
+			Convey("Then the copy should have new handles of the same buffer", func() {
+				cs := c["blob"].(*SharedBlob)
+				So(cs, ShouldNotEqual, s)
+				b1, _ := AsBlob(s)
+				b2, _ := AsBlob(cs)
+				So(&b1[0], ShouldEqual, &b2[0])
+				So(s.refs(), ShouldEqual, 3)
+			})
+
+			Convey("Then all handles should be found", func() {
+				So(FindSharedBlobs(nil, c), ShouldHaveLength, 2)
+			})
+
+			Convey("Then it should be encoded like a Blob", func() {
+				j, err := MarshalJSON(c)
+				So(err, ShouldBeNil)
+				e, err := MarshalJSON(Map{"blob": Blob("large blob"), "a": Array{Blob("large blob")}})
+				So(err, ShouldBeNil)
+				So(string(j), ShouldEqual, string(e))
+
+				d := NewFieldDictionary()
+				v, _, err := DecodeBinary(AppendBinary(nil, c, d), d)
+				So(err, ShouldBeNil)
+				So(v.(Map)["blob"], ShouldResemble, Blob("large blob"))
+			})
+
+			Convey("And releasing all handles", func() {
+				s.Release()
+				So(released, ShouldBeNil)
+				c["blob"].(*SharedBlob).Release()
+				c["a"].(Array)[0].(*SharedBlob).Release()
+
+				Convey("Then the buffer should be released", func() {
+					So(string(released), ShouldEqual, "large blob")
+					So(s.refs(), ShouldEqual, 0)
+				})
+
+				Convey("Then released handles cannot be used", func() {
+					_, err := AsBlob(s)
+					So(err, ShouldNotBeNil)
+					So(s.Verify(), ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("When releasing the handle twice", func() {
+			c := s.clone().(*SharedBlob)
+			s.Release()
+			s.Release()
+
+			Convey("Then only one reference should be released", func() {
+				So(c.refs(), ShouldEqual, 1)
+				So(released, ShouldBeNil)
+			})
+		})
+
+		Convey("When the buffer is modified", func() {
+			b, _ := AsBlob(s)
+			b[0] = 'L'
+
+			Convey("Then Verify should fail", func() {
+				So(s.Verify(), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When copying it to a Blob", func() {
+			b, err := s.Copy()
+			So(err, ShouldBeNil)
+			b[0] = 'L'
+
+			Convey("Then the buffer shouldn't be modified", func() {
+				So(s.Verify(), ShouldBeNil)
+				So(string(b), ShouldEqual, "Large blob")
+			})
+		})
+	})
+}
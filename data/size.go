@@ -0,0 +1,59 @@
+package data
+
+const (
+	// interfaceSize is the size of an interface value on 64-bit machines.
+	interfaceSize = 16
+
+	// sliceHeaderSize is the size of a slice header on 64-bit machines.
+	sliceHeaderSize = 24
+
+	// mapOverhead is a rough estimate of the overhead of a Go map and its
+	// first bucket.
+	mapOverhead = 48
+)
+
+// ApproxSize returns the approximate size of memory in bytes used by a Value.
+// The size doesn't take into account memory shared with other values or
+// overheads of the memory allocator. Therefore, it should only be used for
+// rough accounting of resources such as memory quotas.
+func ApproxSize(v Value) int64 {
+	if v == nil {
+		return interfaceSize
+	}
+
+	switch v.Type() {
+	case TypeString:
+		s, _ := v.asString()
+		return interfaceSize + int64(len(s))
+
+	case TypeBlob:
+		b, _ := v.asBlob()
+		return interfaceSize + sliceHeaderSize + int64(len(b))
+
+	case TypeTimestamp:
+		return interfaceSize + 24 // time.Time has three words
+
+	case TypeArray:
+		a, _ := v.asArray()
+		s := int64(interfaceSize + sliceHeaderSize)
+		for _, e := range a {
+			s += ApproxSize(e)
+		}
+		return s
+
+	case TypeVector:
+		vec, _ := AsVector(v)
+		return interfaceSize + sliceHeaderSize + 4*int64(len(vec))
+
+	case TypeMap:
+		m, _ := v.asMap()
+		s := int64(interfaceSize + mapOverhead)
+		for k, e := range m {
+			s += interfaceSize + int64(len(k)) + ApproxSize(e)
+		}
+		return s
+	}
+
+	// Null, Bool, Int, and Float fit in an interface value.
+	return interfaceSize
+}
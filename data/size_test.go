@@ -0,0 +1,37 @@
+package data
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestApproxSize(t *testing.T) {
+	Convey("Given values of various types", t, func() {
+		Convey("When computing the size of scalar values", func() {
+			Convey("Then they should have the same size", func() {
+				So(ApproxSize(Null{}), ShouldEqual, ApproxSize(Int(1)))
+				So(ApproxSize(Bool(true)), ShouldEqual, ApproxSize(Float(1.5)))
+				So(ApproxSize(nil), ShouldEqual, ApproxSize(Int(1)))
+			})
+		})
+
+		Convey("When computing the size of variable length values", func() {
+			Convey("Then it should grow with the length", func() {
+				So(ApproxSize(String("abcd")), ShouldEqual, ApproxSize(String(""))+4)
+				So(ApproxSize(Blob("abcd")), ShouldEqual, ApproxSize(Blob(""))+4)
+				So(ApproxSize(Timestamp(time.Now())), ShouldBeGreaterThan, ApproxSize(Int(1)))
+			})
+		})
+
+		Convey("When computing the size of containers", func() {
+			a := Array{Int(1), String("abcd")}
+			m := Map{"a": a}
+
+			Convey("Then it should include the size of elements", func() {
+				So(ApproxSize(a), ShouldEqual, ApproxSize(Array{})+ApproxSize(Int(1))+ApproxSize(String("abcd")))
+				So(ApproxSize(m), ShouldEqual, ApproxSize(Map{})+ApproxSize(String("a"))+ApproxSize(a))
+			})
+		})
+	})
+}
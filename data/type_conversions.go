@@ -76,6 +76,7 @@ func AsMap(v Value) (Map, error) {
 //  * Timestamp: true if IsZero() is false
 //  * Array: true if non-empty
 //  * Map: true if non-empty
+//  * Vector: true if non-empty
 func ToBool(v Value) (bool, error) {
 	defaultValue := false
 	switch v.Type() {
@@ -104,6 +105,9 @@ func ToBool(v Value) (bool, error) {
 	case TypeMap:
 		val, _ := v.asMap()
 		return len(val) > 0, nil
+	case TypeVector:
+		val, _ := AsVector(v)
+		return len(val) > 0, nil
 	default:
 		return defaultValue,
 			fmt.Errorf("cannot convert %T to bool", v)
@@ -211,6 +215,7 @@ func ToFloat(v Value) (float64, error) {
 //  * String: the actual string
 //  * Blob: base64-encoded string
 //  * Timestamp: ISO 8601 representation, see time.RFC3339
+//  * Array, Map, Vector: JSON representation
 //  * other: Go's "%#v" representation
 func ToString(v Value) (string, error) {
 	switch v.Type() {
@@ -227,7 +232,7 @@ func ToString(v Value) (string, error) {
 	case TypeTimestamp:
 		val, _ := v.asTimestamp()
 		return val.Format(time.RFC3339Nano), nil
-	case TypeArray, TypeMap:
+	case TypeArray, TypeMap, TypeVector:
 		return v.String(), nil
 	default:
 		return fmt.Sprintf("%#v", v), nil
@@ -240,6 +245,7 @@ func ToString(v Value) (string, error) {
 //  * Null: nil
 //  * String: []byte just copied from string
 //  * Blob: actual value
+//  * Vector: encoded by Vector.MarshalBinary
 //  * other: (error)
 func ToBlob(v Value) ([]byte, error) {
 	switch v.Type() {
@@ -250,6 +256,9 @@ func ToBlob(v Value) ([]byte, error) {
 		return base64.StdEncoding.DecodeString(val)
 	case TypeBlob:
 		return v.asBlob()
+	case TypeVector:
+		vec, _ := AsVector(v)
+		return vec.MarshalBinary()
 	default:
 		return nil, fmt.Errorf("cannot convert %T to Blob", v)
 	}
@@ -64,6 +64,8 @@ const (
 	TypeArray
 	// TypeMap is a TypeID of Map.
 	TypeMap
+	// TypeVector is a TypeID of Vector.
+	TypeVector
 )
 
 func (t TypeID) String() string {
@@ -86,6 +88,8 @@ func (t TypeID) String() string {
 		return "array"
 	case TypeMap:
 		return "map"
+	case TypeVector:
+		return "vector"
 	default:
 		return "unknown"
 	}
@@ -238,6 +242,8 @@ func NewValue(v interface{}) (Value, error) {
 		return String(vt), nil
 	case []byte:
 		return Blob(vt), nil
+	case []float32:
+		return Vector(vt), nil
 	case nil:
 		return Null{}, nil
 
@@ -313,6 +319,9 @@ func newIValue(v Value) interface{} {
 	case TypeMap:
 		innerMap, _ := v.asMap()
 		result = NewIMap(innerMap)
+	case TypeVector:
+		vec, _ := AsVector(v)
+		result = []float32(vec)
 	case TypeNull:
 		result = nil
 	default:
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Vector is a dense vector of 32-bit floating point numbers such as an
+// embedding. It can be assigned to Value interface. Although an Array of
+// Floats can represent the same data, a Vector uses much less memory and
+// can be serialized compactly by MarshalBinary.
+type Vector []float32
+
+// Type returns TypeID of Vector. It's always TypeVector.
+func (v Vector) Type() TypeID {
+	return TypeVector
+}
+
+func (v Vector) asBool() (bool, error) {
+	return false, castError(v.Type(), TypeBool)
+}
+
+func (v Vector) asInt() (int64, error) {
+	return 0, castError(v.Type(), TypeInt)
+}
+
+func (v Vector) asFloat() (float64, error) {
+	return 0, castError(v.Type(), TypeFloat)
+}
+
+func (v Vector) asString() (string, error) {
+	return "", castError(v.Type(), TypeString)
+}
+
+func (v Vector) asBlob() ([]byte, error) {
+	return nil, castError(v.Type(), TypeBlob)
+}
+
+func (v Vector) asTimestamp() (time.Time, error) {
+	return time.Time{}, castError(v.Type(), TypeTimestamp)
+}
+
+func (v Vector) asArray() (Array, error) {
+	return nil, castError(v.Type(), TypeArray)
+}
+
+func (v Vector) asMap() (Map, error) {
+	return nil, castError(v.Type(), TypeMap)
+}
+
+func (v Vector) clone() Value {
+	out := make(Vector, len(v))
+	copy(out, v)
+	return out
+}
+
+// MarshalJSON marshals a Vector to JSON as an array of numbers. NaN and Inf
+// will be encoded as null.
+func (v Vector) MarshalJSON() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// String returns JSON representation of a Vector. NaN and Inf will be encoded
+// as null.
+func (v Vector) String() string {
+	b := bytes.NewBuffer(make([]byte, 0, 2+len(v)*8))
+	b.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(Float(f).String())
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// MarshalBinary encodes a Vector into a compact binary representation, which
+// is a sequence of IEEE 754 single precision numbers in little endian.
+func (v Vector) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a Vector from the representation created by
+// MarshalBinary.
+func (v *Vector) UnmarshalBinary(b []byte) error {
+	if len(b)%4 != 0 {
+		return fmt.Errorf("the length of an encoded vector must be a multiple of 4: %v", len(b))
+	}
+	res := make(Vector, len(b)/4)
+	for i := range res {
+		res[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	*v = res
+	return nil
+}
+
+// AsVector returns a Vector only when the type of Value is TypeVector,
+// otherwise it returns error.
+func AsVector(v Value) (Vector, error) {
+	vec, ok := v.(Vector)
+	if !ok {
+		return nil, castError(v.Type(), TypeVector)
+	}
+	return vec, nil
+}
+
+// ToVector converts a given Value to a Vector, if possible.
+// The conversion rules are as follows:
+//
+//   - Null: nil
+//   - Blob: decoded by Vector.UnmarshalBinary
+//   - Array: each element is converted by ToFloat
+//   - Vector: actual value
+//   - other: (error)
+func ToVector(v Value) (Vector, error) {
+	switch v.Type() {
+	case TypeNull:
+		return nil, nil
+	case TypeBlob:
+		b, _ := v.asBlob()
+		var vec Vector
+		if err := vec.UnmarshalBinary(b); err != nil {
+			return nil, err
+		}
+		return vec, nil
+	case TypeArray:
+		a, _ := v.asArray()
+		vec := make(Vector, len(a))
+		for i, e := range a {
+			f, err := ToFloat(e)
+			if err != nil {
+				return nil, fmt.Errorf("the %v-th element cannot be converted to a float: %v", i, err)
+			}
+			vec[i] = float32(f)
+		}
+		return vec, nil
+	case TypeVector:
+		return AsVector(v)
+	default:
+		return nil, fmt.Errorf("cannot convert %T to Vector", v)
+	}
+}
@@ -0,0 +1,159 @@
+package data
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"testing"
+)
+
+func TestVector(t *testing.T) {
+	Convey("Given a Vector", t, func() {
+		v := Vector{1, -2.5, 0.25}
+
+		Convey("When encoding it into binary", func() {
+			b, err := v.MarshalBinary()
+			So(err, ShouldBeNil)
+
+			Convey("Then it should use 4 bytes per element", func() {
+				So(b, ShouldHaveLength, 12)
+			})
+
+			Convey("Then it should be decoded to the same vector", func() {
+				var d Vector
+				So(d.UnmarshalBinary(b), ShouldBeNil)
+				So(d, ShouldResemble, v)
+			})
+
+			Convey("Then decoding a truncated binary should fail", func() {
+				var d Vector
+				So(d.UnmarshalBinary(b[:5]), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When converting it to a string", func() {
+			s := v.String()
+
+			Convey("Then it should be a JSON array", func() {
+				So(s, ShouldEqual, "[1,-2.5,0.25]")
+			})
+		})
+
+		Convey("When converting it to a string having NaN", func() {
+			s := Vector{float32(math.NaN()), 1}.String()
+
+			Convey("Then NaN should be null", func() {
+				So(s, ShouldEqual, "[null,1]")
+			})
+		})
+
+		Convey("When comparing it with other values", func() {
+			Convey("Then it should equal to the same vector", func() {
+				So(Equal(v, Vector{1, -2.5, 0.25}), ShouldBeTrue)
+				So(Hash(v), ShouldEqual, Hash(Vector{1, -2.5, 0.25}))
+			})
+
+			Convey("Then it shouldn't equal to a different vector", func() {
+				So(Equal(v, Vector{1, -2.5}), ShouldBeFalse)
+				So(Equal(v, Vector{1, -2.5, 0.5}), ShouldBeFalse)
+				So(Hash(v), ShouldNotEqual, Hash(Vector{1, -2.5, 0.5}))
+			})
+
+			Convey("Then it shouldn't equal to an array", func() {
+				So(Equal(v, Array{Float(1), Float(-2.5), Float(0.25)}), ShouldBeFalse)
+			})
+
+			Convey("Then it should be greater than a map", func() {
+				So(Less(Map{}, v), ShouldBeTrue)
+				So(Less(v, Vector{1, 2, 3, 4}), ShouldBeTrue)
+			})
+		})
+
+		Convey("When converting it to other types", func() {
+			Convey("Then it should be converted to a bool", func() {
+				b, err := ToBool(v)
+				So(err, ShouldBeNil)
+				So(b, ShouldBeTrue)
+				b, err = ToBool(Vector{})
+				So(err, ShouldBeNil)
+				So(b, ShouldBeFalse)
+			})
+
+			Convey("Then it should be converted to a blob", func() {
+				b, err := ToBlob(v)
+				So(err, ShouldBeNil)
+				d, err := ToVector(Blob(b))
+				So(err, ShouldBeNil)
+				So(d, ShouldResemble, v)
+			})
+
+			Convey("Then it should be converted to a string", func() {
+				s, err := ToString(v)
+				So(err, ShouldBeNil)
+				So(s, ShouldEqual, "[1,-2.5,0.25]")
+			})
+
+			Convey("Then it should fail to be converted to an array", func() {
+				_, err := AsArray(v)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When copying it in a map", func() {
+			m := Map{"v": v}
+			c := m.Copy()
+			v[0] = 10
+
+			Convey("Then the copy shouldn't be affected", func() {
+				So(c["v"], ShouldResemble, Vector{1, -2.5, 0.25})
+			})
+		})
+	})
+}
+
+func TestToVector(t *testing.T) {
+	Convey("Given values to be converted to vectors", t, func() {
+		Convey("When converting an array of numbers", func() {
+			v, err := ToVector(Array{Int(1), Float(0.5), String("2")})
+
+			Convey("Then it should be a vector", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, Vector{1, 0.5, 2})
+			})
+		})
+
+		Convey("When converting null", func() {
+			v, err := ToVector(Null{})
+
+			Convey("Then it should be nil", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldBeNil)
+			})
+		})
+
+		Convey("When converting invalid values", func() {
+			Convey("Then it should fail", func() {
+				for _, v := range []Value{Array{Map{}}, Int(1), String("a"), Blob("abc")} {
+					_, err := ToVector(v)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When creating a value from []float32", func() {
+			v, err := NewValue([]float32{1, 2})
+
+			Convey("Then it should be a vector", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, Vector{1, 2})
+			})
+
+			Convey("Then it should be marshaled with msgpack as an array", func() {
+				b, err := MarshalMsgpack(Map{"v": v})
+				So(err, ShouldBeNil)
+				m, err := UnmarshalMsgpack(b)
+				So(err, ShouldBeNil)
+				So(m["v"], ShouldResemble, Array{Float(1), Float(2)})
+			})
+		})
+	})
+}
@@ -0,0 +1,426 @@
+package data
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxXMLDepth is the maximum nesting depth of XML elements.
+const maxXMLDepth = maxJSONDepth
+
+// XMLNamespaceMode is how names of elements and attributes having namespaces
+// are converted to keys of Map.
+type XMLNamespaceMode int
+
+const (
+	// XMLStripNamespace uses local names without namespaces, e.g. "Body"
+	// for <soap:Body>. Namespace declarations are removed.
+	XMLStripNamespace XMLNamespaceMode = iota
+
+	// XMLNamespacePrefix uses names as written in the document, e.g.
+	// "soap:Body". Namespace declarations are kept as attributes so that
+	// prefixes can be resolved.
+	XMLNamespacePrefix
+
+	// XMLNamespaceURI uses local names qualified by namespace URIs, e.g.
+	// "{http://www.w3.org/2003/05/soap-envelope}Body". Namespace
+	// declarations are removed.
+	XMLNamespaceURI
+)
+
+func (m XMLNamespaceMode) String() string {
+	switch m {
+	case XMLStripNamespace:
+		return "strip"
+	case XMLNamespacePrefix:
+		return "prefix"
+	case XMLNamespaceURI:
+		return "uri"
+	default:
+		return "unknown"
+	}
+}
+
+// XMLOptions has options of converting XML to Map. An element is converted
+// as follows:
+//
+//   - An element having neither attributes nor child elements is converted
+//     to String having its text, or Null when it's empty.
+//   - Other elements are converted to Map. Attributes are stored with keys
+//     having AttributePrefix and child elements are stored with their names.
+//     Child elements having the same name are stored as Array. The text of
+//     the element is stored with TextKey when it isn't empty.
+//
+// Texts are trimmed and texts of mixed contents are concatenated.
+type XMLOptions struct {
+	// AttributePrefix is prepended to names of attributes so that they
+	// don't conflict with names of child elements.
+	AttributePrefix string
+
+	// TextKey is the key of the text of an element converted to Map.
+	TextKey string
+
+	// IgnoreAttributes removes all attributes.
+	IgnoreAttributes bool
+
+	// Namespace is how names having namespaces are converted.
+	Namespace XMLNamespaceMode
+
+	// ForceArray has names of elements which are always stored as Array
+	// even when their parents have only one of them.
+	ForceArray []string
+
+	// InferTypes converts texts to Null, Bool, Int, or Float when they look
+	// like values of those types. Texts are kept as String otherwise.
+	InferTypes bool
+}
+
+// NewXMLOptions creates XMLOptions from parameters. It accepts following
+// parameters:
+//
+//	attribute_prefix: the prefix of attributes (default: "@")
+//	text_key: the key of texts (default: "#text")
+//	attributes: false to ignore attributes (default: true)
+//	namespaces: "strip", "prefix", or "uri" (default: "strip")
+//	force_array: an array of names of elements always stored as Array
+//	infer_types: true to infer types of texts (default: false)
+//
+// Other parameters are ignored so that params can have parameters of other
+// components.
+func NewXMLOptions(params Map) (*XMLOptions, error) {
+	o := &XMLOptions{
+		AttributePrefix: "@",
+		TextKey:         "#text",
+	}
+	if v, ok := params["attribute_prefix"]; ok {
+		s, err := AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("attribute_prefix: %v", err)
+		}
+		o.AttributePrefix = s
+	}
+	if v, ok := params["text_key"]; ok {
+		s, err := AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("text_key: %v", err)
+		}
+		o.TextKey = s
+	}
+	if v, ok := params["attributes"]; ok {
+		b, err := AsBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("attributes: %v", err)
+		}
+		o.IgnoreAttributes = !b
+	}
+	if v, ok := params["namespaces"]; ok {
+		s, err := AsString(v)
+		if err != nil {
+			return nil, fmt.Errorf("namespaces: %v", err)
+		}
+		switch strings.ToLower(s) {
+		case "strip":
+			o.Namespace = XMLStripNamespace
+		case "prefix":
+			o.Namespace = XMLNamespacePrefix
+		case "uri":
+			o.Namespace = XMLNamespaceURI
+		default:
+			return nil, fmt.Errorf("namespaces: must be one of strip, prefix, and uri: %v", s)
+		}
+	}
+	if v, ok := params["force_array"]; ok {
+		a, err := AsArray(v)
+		if err != nil {
+			return nil, fmt.Errorf("force_array: %v", err)
+		}
+		for _, e := range a {
+			s, err := AsString(e)
+			if err != nil {
+				return nil, fmt.Errorf("force_array: %v", err)
+			}
+			o.ForceArray = append(o.ForceArray, s)
+		}
+	}
+	if v, ok := params["infer_types"]; ok {
+		b, err := AsBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("infer_types: %v", err)
+		}
+		o.InferTypes = b
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Validate validates values of XMLOptions.
+func (o *XMLOptions) Validate() error {
+	if o.TextKey == "" {
+		return errors.New("text_key: cannot be empty")
+	}
+	if !o.IgnoreAttributes && o.AttributePrefix == o.TextKey {
+		return errors.New("attribute_prefix and text_key must be different")
+	}
+	switch o.Namespace {
+	case XMLStripNamespace, XMLNamespacePrefix, XMLNamespaceURI:
+	default:
+		return fmt.Errorf("namespaces: unsupported mode: %v", o.Namespace)
+	}
+	return nil
+}
+
+// UnmarshalXML returns a Map object from a byte array having an XML
+// document. The Map has the root element as its only key. When opts is nil,
+// the default options of NewXMLOptions are used.
+func UnmarshalXML(b []byte, opts *XMLOptions) (Map, error) {
+	d, err := NewXMLDecoder(bytes.NewReader(b), opts)
+	if err != nil {
+		return nil, err
+	}
+	m, err := d.DecodeMap()
+	if err == io.EOF {
+		return nil, errors.New("the document doesn't have the root element")
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err := d.DecodeMap(); err == nil {
+		return nil, errors.New("the document has more than one root element")
+	} else if err != io.EOF {
+		return nil, err
+	}
+	return m, nil
+}
+
+// XMLDecoder reads and converts a stream of XML elements. Each top-level
+// element is decoded as a Map having the element as its only key. When
+// Record is set, elements having the name are decoded as Maps having their
+// contents wherever they are in the stream.
+type XMLDecoder struct {
+	d     *xml.Decoder
+	opts  *XMLOptions
+	force map[string]bool
+	err   error
+
+	// Record is the name of elements decoded as records. The name is
+	// compared after being converted according to XMLOptions.Namespace.
+	Record string
+}
+
+// NewXMLDecoder creates an XMLDecoder reading from r. When opts is nil, the
+// default options of NewXMLOptions are used.
+func NewXMLDecoder(r io.Reader, opts *XMLOptions) (*XMLDecoder, error) {
+	if opts == nil {
+		o, err := NewXMLOptions(Map{})
+		if err != nil {
+			return nil, err
+		}
+		opts = o
+	} else if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	d := xml.NewDecoder(r)
+	d.Strict = true
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if c := strings.ToLower(charset); c == "utf-8" || c == "us-ascii" {
+			return input, nil
+		}
+		return nil, fmt.Errorf("unsupported charset: %v", charset)
+	}
+	force := map[string]bool{}
+	for _, n := range opts.ForceArray {
+		force[n] = true
+	}
+	return &XMLDecoder{
+		d:     d,
+		opts:  opts,
+		force: force,
+	}, nil
+}
+
+func (d *XMLDecoder) token() (xml.Token, error) {
+	if d.opts.Namespace == XMLNamespacePrefix {
+		// RawToken doesn't translate prefixes to namespace URIs.
+		return d.d.RawToken()
+	}
+	return d.d.Token()
+}
+
+// DecodeMap reads the next element from the input. It returns io.EOF when the
+// input doesn't have any more elements. Once it returns an error other than
+// io.EOF, it always returns the same error.
+func (d *XMLDecoder) DecodeMap() (Map, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	m, err := d.decodeMap()
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+	return m, nil
+}
+
+func (d *XMLDecoder) decodeMap() (Map, error) {
+	depth := 0
+	for {
+		t, err := d.token()
+		if err != nil {
+			if err == io.EOF && depth > 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			name := d.name(t.Name)
+			if d.Record == "" {
+				v, err := d.element(t, 1)
+				if err != nil {
+					return nil, err
+				}
+				return Map{name: v}, nil
+			}
+			if name == d.Record {
+				v, err := d.element(t, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				if m, ok := v.(Map); ok {
+					return m, nil
+				}
+				if v.Type() == TypeNull {
+					return Map{}, nil
+				}
+				return Map{d.opts.TextKey: v}, nil
+			}
+			depth++
+
+		case xml.EndElement:
+			depth--
+
+		case xml.CharData:
+			if depth == 0 && d.Record == "" && len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("text cannot be placed outside elements")
+			}
+		}
+	}
+}
+
+// element converts the element started by start. It reads tokens until the
+// end of the element.
+func (d *XMLDecoder) element(start xml.StartElement, depth int) (Value, error) {
+	if depth > maxXMLDepth {
+		return nil, errors.New("elements are nested too deeply")
+	}
+
+	m := Map{}
+	if !d.opts.IgnoreAttributes {
+		for _, a := range start.Attr {
+			if d.opts.Namespace != XMLNamespacePrefix &&
+				(a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns")) {
+				continue
+			}
+			var v Value = String(a.Value)
+			if a.Value != "" {
+				v = d.text(a.Value)
+			}
+			m[d.opts.AttributePrefix+d.name(a.Name)] = v
+		}
+	}
+
+	text := bytes.NewBuffer(nil)
+	children := false
+	for {
+		t, err := d.token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			children = true
+			name := d.name(t.Name)
+			v, err := d.element(t, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if prev, ok := m[name]; ok {
+				if a, ok := prev.(Array); ok {
+					m[name] = append(a, v)
+				} else {
+					m[name] = Array{prev, v}
+				}
+			} else if d.force[name] {
+				m[name] = Array{v}
+			} else {
+				m[name] = v
+			}
+
+		case xml.EndElement:
+			if d.opts.Namespace == XMLNamespacePrefix && t.Name != start.Name {
+				// RawToken doesn't check that elements are closed properly.
+				return nil, fmt.Errorf("element <%v> is closed by </%v>",
+					d.name(start.Name), d.name(t.Name))
+			}
+			s := strings.TrimSpace(text.String())
+			if !children && len(m) == 0 {
+				return d.text(s), nil
+			}
+			if s != "" {
+				m[d.opts.TextKey] = d.text(s)
+			}
+			return m, nil
+
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+}
+
+func (d *XMLDecoder) name(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	switch d.opts.Namespace {
+	case XMLNamespacePrefix:
+		return n.Space + ":" + n.Local
+	case XMLNamespaceURI:
+		return "{" + n.Space + "}" + n.Local
+	default:
+		return n.Local
+	}
+}
+
+func (d *XMLDecoder) text(s string) Value {
+	if s == "" {
+		return Null{}
+	}
+	if !d.opts.InferTypes {
+		return String(s)
+	}
+	switch s {
+	case "true":
+		return True
+	case "false":
+		return False
+	}
+	if strings.Trim(s, "0123456789+-.eE") != "" {
+		return String(s)
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Int(i)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return Float(f)
+	}
+	return String(s)
+}
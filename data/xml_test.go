@@ -0,0 +1,184 @@
+package data
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalXML(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<env:Envelope xmlns:env="http://example.com/env" xmlns="http://example.com/data">
+  <env:Body id="b1" empty="">
+    <reading unit="C">21.5</reading>
+    <reading unit="C">22</reading>
+    <device>sensor1</device>
+    <status/>
+    <note>mixed <b>bold</b> text</note>
+    <ok>true</ok>
+  </env:Body>
+</env:Envelope>`
+
+	Convey("Given an XML document", t, func() {
+		Convey("When unmarshaling it with default options", func() {
+			m, err := UnmarshalXML([]byte(doc), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be converted to a map without namespaces", func() {
+				So(m, ShouldResemble, Map{
+					"Envelope": Map{
+						"Body": Map{
+							"@id":    String("b1"),
+							"@empty": String(""),
+							"reading": Array{
+								Map{"@unit": String("C"), "#text": String("21.5")},
+								Map{"@unit": String("C"), "#text": String("22")},
+							},
+							"device": String("sensor1"),
+							"status": Null{},
+							"note": Map{
+								"b":     String("bold"),
+								"#text": String("mixed  text"),
+							},
+							"ok": String("true"),
+						},
+					},
+				})
+			})
+		})
+
+		Convey("When unmarshaling it with options", func() {
+			opts, err := NewXMLOptions(Map{
+				"attribute_prefix": String("_"),
+				"text_key":         String("value"),
+				"namespaces":       String("prefix"),
+				"force_array":      Array{String("device")},
+				"infer_types":      True,
+			})
+			So(err, ShouldBeNil)
+			m, err := UnmarshalXML([]byte(doc), opts)
+			So(err, ShouldBeNil)
+
+			Convey("Then the options should be applied", func() {
+				env := m["env:Envelope"]
+				So(env.(Map)["_xmlns:env"], ShouldEqual, String("http://example.com/env"))
+				body := env.(Map)["env:Body"].(Map)
+				So(body["reading"], ShouldResemble, Array{
+					Map{"_unit": String("C"), "value": Float(21.5)},
+					Map{"_unit": String("C"), "value": Int(22)},
+				})
+				So(body["device"], ShouldResemble, Array{String("sensor1")})
+				So(body["ok"], ShouldEqual, True)
+			})
+		})
+
+		Convey("When unmarshaling it with namespace URIs and without attributes", func() {
+			opts, err := NewXMLOptions(Map{
+				"namespaces": String("uri"),
+				"attributes": False,
+			})
+			So(err, ShouldBeNil)
+			m, err := UnmarshalXML([]byte(doc), opts)
+			So(err, ShouldBeNil)
+
+			Convey("Then names should be qualified by URIs", func() {
+				env := m["{http://example.com/env}Envelope"].(Map)
+				body := env["{http://example.com/env}Body"].(Map)
+				So(body["{http://example.com/data}device"], ShouldEqual, String("sensor1"))
+				So(body["{http://example.com/data}reading"], ShouldResemble, Array{String("21.5"), String("22")})
+			})
+		})
+	})
+
+	Convey("Given invalid XML documents", t, func() {
+		for _, doc := range []string{
+			``,
+			`<a>`,
+			`<a></b>`,
+			`<a/><b/>`,
+			`text<a/>`,
+			`<a>&nbsp;</a>`,
+			`<?xml version="1.0" encoding="Shift_JIS"?><a/>`,
+		} {
+			doc := doc
+			Convey("When unmarshaling "+doc, func() {
+				_, err := UnmarshalXML([]byte(doc), nil)
+
+				Convey("Then it should fail", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+
+		Convey("When unmarshaling mismatched tags with prefixes", func() {
+			opts, err := NewXMLOptions(Map{"namespaces": String("prefix")})
+			So(err, ShouldBeNil)
+			_, err = UnmarshalXML([]byte(`<a:b></a:c>`), opts)
+
+			Convey("Then it should fail", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given invalid options", t, func() {
+		Convey("Then creating them should fail", func() {
+			for _, p := range []Map{
+				{"text_key": String("")},
+				{"attribute_prefix": String("#text")},
+				{"namespaces": String("drop")},
+				{"force_array": String("a")},
+			} {
+				_, err := NewXMLOptions(p)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestXMLDecoder(t *testing.T) {
+	Convey("Given a stream of XML elements", t, func() {
+		in := `<batch><point id="1"><v>1</v></point><point id="2"/><other/></batch>
+<batch><point>3</point></batch>`
+
+		Convey("When decoding it without records", func() {
+			d, err := NewXMLDecoder(strings.NewReader(in), nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then each top-level element should be decoded", func() {
+				m, err := d.DecodeMap()
+				So(err, ShouldBeNil)
+				So(m, ShouldContainKey, "batch")
+				m, err = d.DecodeMap()
+				So(err, ShouldBeNil)
+				So(m, ShouldResemble, Map{"batch": Map{"point": String("3")}})
+				_, err = d.DecodeMap()
+				So(err, ShouldEqual, io.EOF)
+			})
+		})
+
+		Convey("When decoding records", func() {
+			d, err := NewXMLDecoder(strings.NewReader(in), nil)
+			So(err, ShouldBeNil)
+			d.Record = "point"
+
+			Convey("Then each record should be decoded", func() {
+				var ms []Map
+				for {
+					m, err := d.DecodeMap()
+					if err == io.EOF {
+						break
+					}
+					So(err, ShouldBeNil)
+					ms = append(ms, m)
+				}
+				So(ms, ShouldResemble, []Map{
+					{"@id": String("1"), "v": String("1")},
+					{"@id": String("2")},
+					{"#text": String("3")},
+				})
+			})
+		})
+	})
+}
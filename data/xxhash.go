@@ -0,0 +1,145 @@
+package data
+
+import (
+	"encoding/binary"
+)
+
+// xxh64 computes XXH64 hash values with the seed 0. It's implemented here
+// instead of using hash.Hash64 so that Hash can compute hash values without
+// allocating memory: xxh64 can be kept on the stack and its methods aren't
+// called through interfaces.
+type xxh64 struct {
+	v1, v2, v3, v4 uint64
+	total          uint64
+	mem            [32]byte
+	n              int
+}
+
+const (
+	xxhPrime1 uint64 = 11400714785074694791
+	xxhPrime2 uint64 = 14029467366897019727
+	xxhPrime3 uint64 = 1609587929392839161
+	xxhPrime4 uint64 = 9650029242287828579
+	xxhPrime5 uint64 = 2870177450012600261
+)
+
+func (d *xxh64) reset() {
+	// v1 and v4 are prime1+prime2 and -prime1, which overflow as constants
+	d.v1 = 6983438078262162902
+	d.v2 = xxhPrime2
+	d.v3 = 0
+	d.v4 = 7046029288634856825
+	d.total = 0
+	d.n = 0
+}
+
+func xxhRotl(x uint64, r uint) uint64 {
+	return (x << r) | (x >> (64 - r))
+}
+
+func xxhRound(acc, input uint64) uint64 {
+	acc += input * xxhPrime2
+	acc = xxhRotl(acc, 31)
+	return acc * xxhPrime1
+}
+
+func xxhMergeRound(acc, val uint64) uint64 {
+	val = xxhRound(0, val)
+	acc ^= val
+	return acc*xxhPrime1 + xxhPrime4
+}
+
+// block processes a 32-byte block.
+func (d *xxh64) block(p []byte) {
+	d.v1 = xxhRound(d.v1, binary.LittleEndian.Uint64(p[0:8]))
+	d.v2 = xxhRound(d.v2, binary.LittleEndian.Uint64(p[8:16]))
+	d.v3 = xxhRound(d.v3, binary.LittleEndian.Uint64(p[16:24]))
+	d.v4 = xxhRound(d.v4, binary.LittleEndian.Uint64(p[24:32]))
+}
+
+func (d *xxh64) write(p []byte) {
+	d.total += uint64(len(p))
+	if d.n+len(p) < 32 {
+		d.n += copy(d.mem[d.n:], p)
+		return
+	}
+	if d.n > 0 {
+		c := copy(d.mem[d.n:], p)
+		d.block(d.mem[:])
+		p = p[c:]
+		d.n = 0
+	}
+	for len(p) >= 32 {
+		d.block(p[:32])
+		p = p[32:]
+	}
+	d.n = copy(d.mem[:], p)
+}
+
+// writeString is the same as write except that it takes a string. Strings
+// are copied to mem before they're processed so that they don't have to be
+// converted to []byte.
+func (d *xxh64) writeString(s string) {
+	d.total += uint64(len(s))
+	for len(s) > 0 {
+		c := copy(d.mem[d.n:], s)
+		d.n += c
+		s = s[c:]
+		if d.n == 32 {
+			d.block(d.mem[:])
+			d.n = 0
+		}
+	}
+}
+
+func (d *xxh64) writeByte(b byte) {
+	d.total++
+	d.mem[d.n] = b
+	d.n++
+	if d.n == 32 {
+		d.block(d.mem[:])
+		d.n = 0
+	}
+}
+
+func (d *xxh64) writeUint64(x uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	d.write(b[:])
+}
+
+func (d *xxh64) sum64() uint64 {
+	var h uint64
+	if d.total >= 32 {
+		h = xxhRotl(d.v1, 1) + xxhRotl(d.v2, 7) + xxhRotl(d.v3, 12) + xxhRotl(d.v4, 18)
+		h = xxhMergeRound(h, d.v1)
+		h = xxhMergeRound(h, d.v2)
+		h = xxhMergeRound(h, d.v3)
+		h = xxhMergeRound(h, d.v4)
+	} else {
+		h = xxhPrime5
+	}
+	h += d.total
+
+	p := d.mem[:d.n]
+	for ; len(p) >= 8; p = p[8:] {
+		h ^= xxhRound(0, binary.LittleEndian.Uint64(p))
+		h = xxhRotl(h, 27)*xxhPrime1 + xxhPrime4
+	}
+	if len(p) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(p)) * xxhPrime1
+		h = xxhRotl(h, 23)*xxhPrime2 + xxhPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		h ^= uint64(b) * xxhPrime5
+		h = xxhRotl(h, 11) * xxhPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxhPrime2
+	h ^= h >> 29
+	h *= xxhPrime3
+	h ^= h >> 32
+	return h
+}
@@ -1,23 +1,72 @@
 package server
 
 import (
+	"fmt"
 	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"net/http"
 )
 
 // APIContext is a base context of all API controllers.
 type APIContext struct {
 	*Context
+
+	// principal is the principal which sent the request. It's nil when
+	// authentication is disabled.
+	principal *Principal
 }
 
 // SetUpAPIRouter sets up a router for APIs with user defined custom route.
 // Subrouters needs to have APIContext as their first field.
 func SetUpAPIRouter(prefix string, router *web.Router, route func(prefix string, r *web.Router)) {
 	root := router.Subrouter(APIContext{}, "/api/v1")
+	root.Middleware((*APIContext).authenticate)
 
 	setUpTopologiesRouter(prefix, root)
 	setUpServerStatusRouter(prefix, root)
+	setUpServerConfigRouter(prefix, root)
+	setUpDebugRouter(prefix, root)
 
 	if route != nil {
 		route(prefix, root)
 	}
 }
+
+func (ac *APIContext) authenticate(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if ac.auth == nil {
+		next(rw, req)
+		return
+	}
+
+	p, err := ac.auth.Authenticate(req.Request)
+	if err != nil {
+		ac.ErrLog(err).Error("Cannot authenticate the request")
+		rw.Header().Set("WWW-Authenticate", `Bearer realm="sensorbee"`)
+		ac.RenderError(jasco.NewError(authenticationErrorCode, "The request isn't authenticated",
+			http.StatusUnauthorized, err))
+		return
+	}
+	ac.principal = p
+	ac.AddLogField("principal", p.Name)
+	next(rw, req)
+}
+
+// authorize checks if the principal has the role on the topology. An empty
+// topology name means the server itself. When the principal doesn't have the
+// role, this method renders an error and returns false. The caller can just
+// return from the action in that case.
+func (ac *APIContext) authorize(topology string, r Role) bool {
+	if ac.principal == nil || ac.principal.Role(topology) >= r {
+		return true
+	}
+
+	target := "the server"
+	if topology != "" {
+		target = fmt.Sprintf("the topology '%v'", topology)
+	}
+	err := fmt.Errorf("'%v' doesn't have %v role on %v", ac.principal.Name, r, target)
+	ac.ErrLog(err).Error("Permission denied")
+	ac.RenderError(jasco.NewError(permissionDeniedErrorCode, "Permission denied",
+		http.StatusForbidden, err))
+	return false
+}
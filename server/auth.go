@@ -0,0 +1,185 @@
+package server
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"net/http"
+)
+
+// APIKeyHeader is the name of the HTTP header having an API key.
+const APIKeyHeader = "X-Sensorbee-API-Key"
+
+// Role is a role of a principal on a topology. A role includes all
+// permissions of roles less than it.
+type Role int
+
+const (
+	// RoleNone doesn't have any permission.
+	RoleNone Role = iota
+
+	// RoleReadOnly can view topologies and nodes in them.
+	RoleReadOnly
+
+	// RoleOperator can also issue queries to topologies.
+	RoleOperator
+
+	// RoleAdmin can also create and drop topologies.
+	RoleAdmin
+)
+
+// ParseRole parses a string representation of a role, which is one of
+// "read_only", "operator", and "admin".
+func ParseRole(s string) (Role, error) {
+	switch s {
+	case "read_only":
+		return RoleReadOnly, nil
+	case "operator":
+		return RoleOperator, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleNone, fmt.Errorf("unknown role: %v", s)
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleNone:
+		return "none"
+	case RoleReadOnly:
+		return "read_only"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// Principal is an authenticated user or a program sending requests.
+type Principal struct {
+	// Name is the name of the principal.
+	Name string
+
+	// Tenant is the name of the tenant as which the principal acts. When
+	// it's empty, the principal can access all topologies.
+	Tenant string
+
+	// Roles has roles of the principal keyed by topology names. A role of
+	// "*" applies to all topologies and the server itself.
+	Roles map[string]Role
+}
+
+// Role returns the role of the principal on the topology. An empty name
+// means the server itself.
+func (p *Principal) Role(topology string) Role {
+	r := p.Roles["*"]
+	if topology != "" {
+		if tr := p.Roles[topology]; tr > r {
+			r = tr
+		}
+	}
+	return r
+}
+
+// ErrNoCredentials is returned from Authenticator.Authenticate when a request
+// doesn't have credentials which the Authenticator can handle.
+var ErrNoCredentials = errors.New("the request doesn't have credentials")
+
+// Authenticator authenticates requests sent to the API.
+type Authenticator interface {
+	// Authenticate returns the principal which sent the request. It returns
+	// ErrNoCredentials when the request doesn't have credentials which the
+	// Authenticator can handle. It returns other errors when credentials
+	// are invalid.
+	Authenticate(req *http.Request) (*Principal, error)
+}
+
+type multiAuthenticator []Authenticator
+
+// NewMultiAuthenticator returns an Authenticator which tries the given
+// Authenticators in order until one of them handles credentials of a
+// request.
+func NewMultiAuthenticator(as ...Authenticator) Authenticator {
+	return multiAuthenticator(as)
+}
+
+func (m multiAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	for _, a := range m {
+		p, err := a.Authenticate(req)
+		if err == ErrNoCredentials {
+			continue
+		}
+		return p, err
+	}
+	return nil, ErrNoCredentials
+}
+
+type apiKeyAuthenticator struct {
+	// principals is keyed by SHA-256 hashes of API keys so that the time
+	// to look up a key doesn't depend on how much of it matches.
+	principals map[[sha256.Size]byte]*Principal
+}
+
+// NewAPIKeyAuthenticator returns an Authenticator accepting API keys given
+// by APIKeyHeader.
+func NewAPIKeyAuthenticator(keys map[string]*Principal) Authenticator {
+	a := &apiKeyAuthenticator{
+		principals: make(map[[sha256.Size]byte]*Principal, len(keys)),
+	}
+	for k, p := range keys {
+		a.principals[sha256.Sum256([]byte(k))] = p
+	}
+	return a
+}
+
+func (a *apiKeyAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	key := req.Header.Get(APIKeyHeader)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+	p, ok := a.principals[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, errors.New("the API key is invalid")
+	}
+	return p, nil
+}
+
+// newAuthenticator creates an Authenticator from the config. It returns nil
+// when authentication is disabled.
+func newAuthenticator(conf *config.Auth) (Authenticator, error) {
+	if conf == nil || !conf.Enabled() {
+		return nil, nil
+	}
+
+	var as []Authenticator
+	if len(conf.APIKeys) > 0 {
+		keys := make(map[string]*Principal, len(conf.APIKeys))
+		for _, k := range conf.APIKeys {
+			p := &Principal{
+				Name:   k.Name,
+				Tenant: k.Tenant,
+				Roles:  make(map[string]Role, len(k.Roles)),
+			}
+			for t, r := range k.Roles {
+				role, err := ParseRole(r)
+				if err != nil {
+					return nil, err
+				}
+				p.Roles[t] = role
+			}
+			if _, ok := keys[k.Key]; ok {
+				return nil, fmt.Errorf("the API key of '%v' is used by another principal", k.Name)
+			}
+			keys[k.Key] = p
+		}
+		as = append(as, NewAPIKeyAuthenticator(keys))
+	}
+	if conf.JWT != nil {
+		as = append(as, NewJWTAuthenticator([]byte(conf.JWT.Secret), conf.JWT.Issuer))
+	}
+	return NewMultiAuthenticator(as...), nil
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net/http"
+	"strings"
+)
+
+type breakpoints struct {
+	*topologies
+	nodeName string
+}
+
+func setUpBreakpointsRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(breakpoints{}, "/:topologyName/breakpoints")
+	root.Middleware((*breakpoints).fetchNode)
+	root.Get("/", (*breakpoints).Index)
+	root.Get("/:nodeName", (*breakpoints).Show)
+	root.Put("/:nodeName", (*breakpoints).Update)
+	root.Post("/:nodeName/step", (*breakpoints).Step)
+	root.Delete("/:nodeName", (*breakpoints).Destroy)
+}
+
+// fetchNode looks up the node given in the path. Because breakpoints expose
+// tuples held at nodes, all actions require RoleAdmin.
+func (bc *breakpoints) fetchNode(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !bc.authorize(bc.topologyName, RoleAdmin) {
+		return
+	}
+	tb := bc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	if nodeName := bc.PathParams().String("nodeName", ""); nodeName != "" {
+		n, err := tb.Topology().Node(nodeName)
+		if err == nil && n.Type() == core.NTSource {
+			err = fmt.Errorf("source '%v' cannot have a breakpoint", n.Name())
+		}
+		if err != nil {
+			bc.ErrLog(err).Error("Cannot find the box or the sink")
+			bc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+				"The box or the sink was not found", http.StatusNotFound, err))
+			return
+		}
+		bc.nodeName = n.Name()
+		bc.AddLogField("node_type", n.Type().String())
+		bc.AddLogField("node_name", n.Name())
+	}
+	next(rw, req)
+}
+
+// Index returns breakpoints set on nodes of the topology and tuples held at
+// them.
+func (bc *breakpoints) Index(rw web.ResponseWriter, req *web.Request) {
+	bc.Render(map[string]interface{}{
+		"topology":    bc.topologyName,
+		"breakpoints": bc.topology.Topology().Context().Breakpoints.Status(),
+	})
+}
+
+// Show returns the breakpoint of the node and tuples held at it. It returns
+// 404 when the node doesn't have a breakpoint.
+func (bc *breakpoints) Show(rw web.ResponseWriter, req *web.Request) {
+	st := bc.topology.Topology().Context().Breakpoints.Status()
+	b, ok := st[strings.ToLower(bc.nodeName)]
+	if !ok {
+		err := fmt.Errorf("node '%v' doesn't have a breakpoint", bc.nodeName)
+		bc.ErrLog(err).Error("Cannot find the breakpoint")
+		bc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+			"The breakpoint was not found", http.StatusNotFound, err))
+		return
+	}
+	bc.Render(map[string]interface{}{
+		"topology":   bc.topologyName,
+		"node":       bc.nodeName,
+		"breakpoint": b,
+	})
+}
+
+// Update sets a breakpoint on the node. The node starts to hold tuples at
+// its input.
+func (bc *breakpoints) Update(rw web.ResponseWriter, req *web.Request) {
+	if err := bc.topology.Topology().Context().Breakpoints.Set(bc.nodeName); err != nil {
+		bc.ErrLog(err).Error("Cannot set a breakpoint")
+		bc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	bc.Log().Info("Set a breakpoint on the node")
+	bc.Show(rw, req)
+}
+
+// Step releases "count" tuples held at the breakpoint of the node. count is
+// 1 when it's omitted.
+func (bc *breakpoints) Step(rw web.ResponseWriter, req *web.Request) {
+	var js map[string]interface{}
+	if apiErr := bc.ParseBody(&js); apiErr != nil {
+		bc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		bc.RenderError(apiErr)
+		return
+	}
+	form, err := data.NewMap(js)
+	if err != nil {
+		bc.ErrLog(err).WithField("body", js).Error("The request json may contain invalid value")
+		bc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	count, meta := parseStepParams(form)
+	if len(meta) != 0 {
+		bc.Log().WithField("body", js).Error("The request body has invalid step parameters")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		for k, v := range meta {
+			e.Meta[k] = v
+		}
+		bc.RenderError(e)
+		return
+	}
+
+	if err := bc.topology.Topology().Context().Breakpoints.Step(bc.nodeName, count); err != nil {
+		bc.ErrLog(err).Error("Cannot step the breakpoint")
+		bc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+			"The breakpoint was not found", http.StatusNotFound, err))
+		return
+	}
+	bc.Show(rw, req)
+}
+
+// Destroy removes the breakpoint from the node and releases all tuples held
+// at it. It doesn't return 404 when the node doesn't have a breakpoint.
+func (bc *breakpoints) Destroy(rw web.ResponseWriter, req *web.Request) {
+	if bc.topology.Topology().Context().Breakpoints.Remove(bc.nodeName) {
+		bc.Log().Info("Removed the breakpoint from the node")
+	}
+	bc.Render(map[string]interface{}{})
+}
+
+// parseStepParams returns the number of steps given in the request body. It
+// returns validation errors of each field as the second return value.
+func parseStepParams(form data.Map) (int, map[string][]string) {
+	count := 1
+	meta := map[string][]string{}
+	if v, ok := form["count"]; ok {
+		n, err := data.ToInt(v)
+		switch {
+		case err != nil:
+			meta["count"] = []string{"value must be an integer"}
+		case n <= 0:
+			meta["count"] = []string{fmt.Sprintf("value must be positive: %v", n)}
+		default:
+			count = int(n)
+		}
+	}
+
+	for k := range form {
+		switch k {
+		case "count":
+		default:
+			meta[k] = []string{"unknown field"}
+		}
+	}
+	return count, meta
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Auth has configuration parameters related to authentication and
+// authorization of the API. Authentication is disabled when neither API keys
+// nor JWT is configured.
+type Auth struct {
+	// APIKeys has API keys accepted by the server.
+	APIKeys []*APIKey `json:"api_keys" yaml:"api_keys"`
+
+	// JWT has parameters to verify JSON Web Tokens. JWT isn't accepted when
+	// it's nil.
+	JWT *JWT `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+}
+
+// APIKey has an API key and the principal authenticated by it.
+type APIKey struct {
+	// Key is the API key.
+	Key string `json:"key" yaml:"key"`
+
+	// Name is the name of the principal.
+	Name string `json:"name" yaml:"name"`
+
+	// Tenant is the name of the tenant as which the principal acts. The
+	// principal can access all topologies when it's empty.
+	Tenant string `json:"tenant" yaml:"tenant"`
+
+	// Roles has roles of the principal keyed by topology names. A role of
+	// "*" applies to all topologies and the server itself. A role is one of
+	// "read_only", "operator", and "admin".
+	Roles map[string]string `json:"roles" yaml:"roles"`
+}
+
+// JWT has parameters to verify JSON Web Tokens signed with HS256.
+type JWT struct {
+	// Secret is the key used to verify signatures.
+	Secret string `json:"secret" yaml:"secret"`
+
+	// Issuer is the expected value of "iss" claim. The claim isn't checked
+	// when it's empty.
+	Issuer string `json:"issuer" yaml:"issuer"`
+}
+
+// maskedSecret replaces secrets in the map returned from ToMap because the
+// map can be written to logs.
+const maskedSecret = "********"
+
+var (
+	authSchemaString = `{
+	"type": "object",
+	"properties": {
+		"api_keys": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"key": {
+						"type": "string",
+						"minLength": 1
+					},
+					"name": {
+						"type": "string",
+						"minLength": 1
+					},
+					"tenant": {
+						"type": "string"
+					},
+					"roles": {
+						"type": "object",
+						"patternProperties": {
+							".*": {
+								"enum": ["read_only", "operator", "admin"]
+							}
+						}
+					}
+				},
+				"required": ["key", "name"],
+				"additionalProperties": false
+			}
+		},
+		"jwt": {
+			"type": "object",
+			"properties": {
+				"secret": {
+					"type": "string",
+					"minLength": 1
+				},
+				"issuer": {
+					"type": "string"
+				}
+			},
+			"required": ["secret"],
+			"additionalProperties": false
+		}
+	},
+	"additionalProperties": false
+}`
+	authSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(authSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	authSchema = s
+}
+
+// NewAuth creates an Auth config parameters from a given map.
+func NewAuth(m data.Map) (*Auth, error) {
+	if err := validate(authSchema, m); err != nil {
+		return nil, err
+	}
+	return newAuth(m), nil
+}
+
+func newAuth(m data.Map) *Auth {
+	a := &Auth{}
+	if v, ok := m["api_keys"]; ok {
+		keys, _ := data.AsArray(v)
+		for _, k := range keys {
+			km := mustAsMap(k)
+			key := &APIKey{
+				Key:    mustAsString(km["key"]),
+				Name:   mustAsString(km["name"]),
+				Tenant: mustAsString(getWithDefault(km, "tenant", data.String(""))),
+				Roles:  map[string]string{},
+			}
+			for t, r := range mustAsMap(getWithDefault(km, "roles", data.Map{})) {
+				key.Roles[t] = mustAsString(r)
+			}
+			a.APIKeys = append(a.APIKeys, key)
+		}
+	}
+	if v, ok := m["jwt"]; ok {
+		j := mustAsMap(v)
+		a.JWT = &JWT{
+			Secret: mustAsString(j["secret"]),
+			Issuer: mustAsString(getWithDefault(j, "issuer", data.String(""))),
+		}
+	}
+	return a
+}
+
+// Enabled returns true when authentication is enabled.
+func (a *Auth) Enabled() bool {
+	return len(a.APIKeys) > 0 || a.JWT != nil
+}
+
+// ToMap returns auth config information as data.Map. API keys and secrets
+// are masked.
+func (a *Auth) ToMap() data.Map {
+	keys := data.Array{}
+	for _, k := range a.APIKeys {
+		roles := data.Map{}
+		for t, r := range k.Roles {
+			roles[t] = data.String(r)
+		}
+		keys = append(keys, data.Map{
+			"key":    data.String(maskedSecret),
+			"name":   data.String(k.Name),
+			"tenant": data.String(k.Tenant),
+			"roles":  roles,
+		})
+	}
+	m := data.Map{
+		"api_keys": keys,
+	}
+	if a.JWT != nil {
+		m["jwt"] = data.Map{
+			"secret": data.String(maskedSecret),
+			"issuer": data.String(a.JWT.Issuer),
+		}
+	}
+	return m
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestAuth(t *testing.T) {
+	Convey("Given a JSON config for auth section", t, func() {
+		Convey("When the config is valid", func() {
+			a, err := NewAuth(toMap(`{
+	"api_keys": [
+		{"key": "abc", "name": "alice", "roles": {"*": "read_only", "t1": "admin"}},
+		{"key": "def", "name": "bob", "tenant": "team_a"}
+	],
+	"jwt": {"secret": "secret", "issuer": "issuer"}
+}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(a.Enabled(), ShouldBeTrue)
+				So(a.APIKeys, ShouldHaveLength, 2)
+				So(a.APIKeys[0].Key, ShouldEqual, "abc")
+				So(a.APIKeys[0].Name, ShouldEqual, "alice")
+				So(a.APIKeys[0].Tenant, ShouldBeBlank)
+				So(a.APIKeys[0].Roles, ShouldResemble, map[string]string{"*": "read_only", "t1": "admin"})
+				So(a.APIKeys[1].Tenant, ShouldEqual, "team_a")
+				So(a.APIKeys[1].Roles, ShouldBeEmpty)
+				So(a.JWT.Secret, ShouldEqual, "secret")
+				So(a.JWT.Issuer, ShouldEqual, "issuer")
+			})
+
+			Convey("Then secrets should be masked in a map", func() {
+				m := a.ToMap()
+				v, err := m.Get(data.MustCompilePath("api_keys[0].key"))
+				So(err, ShouldBeNil)
+				So(v, ShouldNotEqual, data.String("abc"))
+				v, err = m.Get(data.MustCompilePath("jwt.secret"))
+				So(err, ShouldBeNil)
+				So(v, ShouldNotEqual, data.String("secret"))
+			})
+		})
+
+		Convey("When the config is empty", func() {
+			a, err := NewAuth(toMap(`{}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then authentication should be disabled", func() {
+				So(a.Enabled(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When validating the config", func() {
+			for _, c := range []string{
+				`{"api_keys":[{"name":"alice"}]}`,
+				`{"api_keys":[{"key":"","name":"alice"}]}`,
+				`{"api_keys":[{"key":"abc","name":"alice","roles":{"*":"root"}}]}`,
+				`{"api_keys":[{"key":"abc","name":"alice","password":"abc"}]}`,
+				`{"jwt":{}}`,
+				`{"jwt":{"secret":"a","algorithm":"none"}}`,
+			} {
+				c := c
+				Convey("Then it should reject "+c, func() {
+					_, err := NewAuth(toMap(c))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -44,6 +44,22 @@ func mustToBool(v data.Value) bool {
 	return b
 }
 
+func mustToInt(v data.Value) int64 {
+	i, err := data.ToInt(v)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func mustToFloat(v data.Value) float64 {
+	f, err := data.ToFloat(v)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
 func validate(schema *gojsonschema.Schema, m data.Map) error {
 	// GoLoader marshal and unmarshal the map.
 	res, err := schema.Validate(gojsonschema.NewGoLoader(m))
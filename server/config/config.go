@@ -17,11 +17,31 @@ type Config struct {
 	// persisting topologies.
 	Topologies Topologies
 
+	// NodeDefaults section has default parameters of nodes which topologies
+	// inherit unless they override them.
+	NodeDefaults *NodeSettings
+
+	// Tenants section has tenants owning topologies. Each tenant has its
+	// own registries of components and its own resource quota.
+	Tenants Tenants
+
 	// Storage section has information of storage of components in SensorBee.
 	Storage *Storage
 
 	// Logging section has parameters related to logging.
 	Logging *Logging
+
+	// Auth section has parameters related to authentication and
+	// authorization of the API.
+	Auth *Auth
+
+	// Secrets section has providers of secrets referred from parameters of
+	// BQL statements.
+	Secrets *Secrets
+
+	// Standby section has parameters of the hot-standby mode. The server
+	// runs as a primary server when it's nil.
+	Standby *Standby
 }
 
 var (
@@ -30,11 +50,17 @@ var (
 	"properties": {
 		"network": %v,
 		"topologies": %v,
+		"node_defaults": %v,
+		"tenants": %v,
 		"storage": %v,
-		"logging": %v
+		"logging": %v,
+		"auth": %v,
+		"secrets": %v,
+		"standby": %v
 	},
 	"additionalProperties": false
-}`, networkSchemaString, topologiesSchemaString, storageSchemaString, loggingSchemaString)
+}`, networkSchemaString, topologiesSchemaString, nodeSettingsSchemaString, tenantsSchemaString, storageSchemaString, loggingSchemaString,
+		authSchemaString, secretsSchemaString, standbySchemaString)
 	rootSchema *gojsonschema.Schema
 )
 
@@ -51,22 +77,39 @@ func New(m data.Map) (*Config, error) {
 	if err := validate(rootSchema, m); err != nil {
 		return nil, err
 	}
+	var standby *Standby
+	if v, ok := m["standby"]; ok {
+		standby = newStandby(mustAsMap(v))
+	}
 	return &Config{
-		Network:    newNetwork(mustAsMap(getWithDefault(m, "network", data.Map{}))),
-		Topologies: newTopologies(mustAsMap(getWithDefault(m, "topologies", data.Map{}))),
-		Storage:    newStorage(mustAsMap(getWithDefault(m, "storage", data.Map{}))),
-		Logging:    newLogging(mustAsMap(getWithDefault(m, "logging", data.Map{}))),
+		Network:      newNetwork(mustAsMap(getWithDefault(m, "network", data.Map{}))),
+		Topologies:   newTopologies(mustAsMap(getWithDefault(m, "topologies", data.Map{}))),
+		NodeDefaults: newNodeSettings(mustAsMap(getWithDefault(m, "node_defaults", data.Map{}))),
+		Tenants:      newTenants(mustAsMap(getWithDefault(m, "tenants", data.Map{}))),
+		Storage:      newStorage(mustAsMap(getWithDefault(m, "storage", data.Map{}))),
+		Logging:      newLogging(mustAsMap(getWithDefault(m, "logging", data.Map{}))),
+		Auth:         newAuth(mustAsMap(getWithDefault(m, "auth", data.Map{}))),
+		Secrets:      newSecrets(mustAsMap(getWithDefault(m, "secrets", data.Map{}))),
+		Standby:      standby,
 	}, nil
 }
 
 // ToMap returns server config information as data.Map.
 func (c *Config) ToMap() data.Map {
-	return data.Map{
-		"network":    c.Network.ToMap(),
-		"topologies": c.Topologies.ToMap(),
-		"storage":    c.Storage.ToMap(),
-		"logging":    c.Logging.ToMap(),
+	m := data.Map{
+		"network":       c.Network.ToMap(),
+		"topologies":    c.Topologies.ToMap(),
+		"node_defaults": c.NodeDefaults.ToMap(),
+		"tenants":       c.Tenants.ToMap(),
+		"storage":       c.Storage.ToMap(),
+		"logging":       c.Logging.ToMap(),
+		"auth":          c.Auth.ToMap(),
+		"secrets":       c.Secrets.ToMap(),
+	}
+	if c.Standby != nil {
+		m["standby"] = c.Standby.ToMap()
 	}
+	return m
 }
 
 // TODO: Add FromJSON or FromYAML if necessary
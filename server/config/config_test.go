@@ -58,10 +58,16 @@ func TestConfigToMap(t *testing.T) {
 			},
 			Topologies: Topologies{
 				"t1": &Topology{
-					BQLFile: "t1.bql",
+					BQLFile:          "t1.bql",
+					EvaluationMode:   "strict",
+					ConversionPolicy: "permissive",
+					BoxParallelism:   1,
 				},
 				"t2": &Topology{
-					BQLFile: "t2.bql",
+					BQLFile:          "t2.bql",
+					EvaluationMode:   "strict",
+					ConversionPolicy: "permissive",
+					BoxParallelism:   1,
 				},
 			},
 			Storage: &Storage{
@@ -78,6 +84,9 @@ func TestConfigToMap(t *testing.T) {
 				LogDroppedTuples:       true,
 				SummarizeDroppedTuples: true,
 			},
+			NodeDefaults: &NodeSettings{},
+			Auth:         &Auth{},
+			Secrets:      &Secrets{},
 		}
 		Convey("When convert to data.Map", func() {
 			ac := c.ToMap()
@@ -88,12 +97,46 @@ func TestConfigToMap(t *testing.T) {
 					},
 					"topologies": data.Map{
 						"t1": data.Map{
-							"bql_file": data.String("t1.bql"),
+							"bql_file":               data.String("t1.bql"),
+							"max_memory":             data.Int(0),
+							"max_tuples":             data.Int(0),
+							"queue_size":             data.Int(0),
+							"evaluation_mode":        data.String("strict"),
+							"conversion_policy":      data.String("permissive"),
+							"window_arena":           data.False,
+							"share_windows":          data.False,
+							"shared_blob_check":      data.False,
+							"key_ttl":                data.Float(0),
+							"box_parallelism":        data.Int(1),
+							"nice":                   data.Int(0),
+							"node_nice":              data.Map{},
+							"dedicated_thread_sinks": data.Array{},
+							"sink_flush_interval":    data.Float(0),
+							"sink_readiness_timeout": data.Float(0),
 						},
 						"t2": data.Map{
-							"bql_file": data.String("t2.bql"),
+							"bql_file":               data.String("t2.bql"),
+							"max_memory":             data.Int(0),
+							"max_tuples":             data.Int(0),
+							"queue_size":             data.Int(0),
+							"evaluation_mode":        data.String("strict"),
+							"conversion_policy":      data.String("permissive"),
+							"window_arena":           data.False,
+							"share_windows":          data.False,
+							"shared_blob_check":      data.False,
+							"key_ttl":                data.Float(0),
+							"box_parallelism":        data.Int(1),
+							"nice":                   data.Int(0),
+							"node_nice":              data.Map{},
+							"dedicated_thread_sinks": data.Array{},
+							"sink_flush_interval":    data.Float(0),
+							"sink_readiness_timeout": data.Float(0),
 						},
 					},
+					"node_defaults": data.Map{
+						"queue_size": data.Int(0),
+					},
+					"tenants": data.Map{},
 					"storage": data.Map{
 						"uds": data.Map{
 							"type": data.String("fs"),
@@ -108,6 +151,10 @@ func TestConfigToMap(t *testing.T) {
 						"log_dropped_tuples":       data.True,
 						"summarize_dropped_tuples": data.True,
 					},
+					"auth": data.Map{
+						"api_keys": data.Array{},
+					},
+					"secrets": data.Map{},
 				}
 				So(ac, ShouldResemble, ex)
 			})
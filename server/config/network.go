@@ -15,6 +15,10 @@ const (
 type Network struct {
 	// ListenOn has binding information in "host:port" format.
 	ListenOn string `json:"listen_on" yaml:"listen_on"`
+
+	// TLS has TLS parameters of the server. The server doesn't use TLS when
+	// it's nil. See bql.TLSConfig for available parameters.
+	TLS data.Map `json:"tls,omitempty" yaml:"tls,omitempty"`
 }
 
 var (
@@ -24,6 +28,24 @@ var (
 		"listen_on": {
 			"type": "string",
 			"pattern": "^.*:[0-9]+$"
+		},
+		"tls": {
+			"type": "object",
+			"properties": {
+				"cert_file": {"type": "string"},
+				"cert": {"type": "string"},
+				"key_file": {"type": "string"},
+				"key": {"type": "string"},
+				"ca_file": {"type": "string"},
+				"ca": {"type": "string"},
+				"client_auth": {
+					"enum": ["none", "request", "require", "verify_if_given", "require_and_verify"]
+				},
+				"min_version": {
+					"enum": ["1.0", "1.1", "1.2", "1.3"]
+				}
+			},
+			"additionalProperties": false
 		}
 	},
 	"additionalProperties": false
@@ -48,14 +70,27 @@ func NewNetwork(m data.Map) (*Network, error) {
 }
 
 func newNetwork(m data.Map) *Network {
-	return &Network{
+	n := &Network{
 		ListenOn: mustAsString(getWithDefault(m, "listen_on", data.String(fmt.Sprintf(":%d", DefaultPort)))),
 	}
+	if v, ok := m["tls"]; ok {
+		n.TLS = mustAsMap(v)
+	}
+	return n
 }
 
-// ToMap returns network config information as data.Map.
+// ToMap returns network config information as data.Map. An inline private
+// key of TLS is masked.
 func (n *Network) ToMap() data.Map {
-	return data.Map{
+	m := data.Map{
 		"listen_on": data.String(n.ListenOn),
 	}
+	if n.TLS != nil {
+		t := n.TLS.Copy()
+		if _, ok := t["key"]; ok {
+			t["key"] = data.String(maskedSecret)
+		}
+		m["tls"] = t
+	}
+	return m
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"testing"
 )
 
@@ -35,6 +36,41 @@ func TestNetwork(t *testing.T) {
 			})
 		})
 
+		Convey("When the config has tls parameters", func() {
+			n, err := NewNetwork(toMap(`{"tls":{"cert_file":"server.crt","key":"secret","client_auth":"require_and_verify"}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(n.TLS, ShouldResemble, data.Map{
+					"cert_file":   data.String("server.crt"),
+					"key":         data.String("secret"),
+					"client_auth": data.String("require_and_verify"),
+				})
+			})
+
+			Convey("Then ToMap should mask the private key", func() {
+				m := n.ToMap()
+				So(m["tls"], ShouldResemble, data.Map{
+					"cert_file":   data.String("server.crt"),
+					"key":         data.String(maskedSecret),
+					"client_auth": data.String("require_and_verify"),
+				})
+				So(n.TLS["key"], ShouldEqual, data.String("secret"))
+			})
+		})
+
+		Convey("When validating tls", func() {
+			for _, tv := range [][]interface{}{{"undefined field", `{"certificate":"a"}`},
+				{"invalid client_auth", `{"client_auth":"always"}`},
+				{"invalid min_version", `{"min_version":"1.4"}`},
+				{"invalid type", `"on"`}} {
+				Convey(fmt.Sprintf("Then it should reject %v", tv[0]), func() {
+					_, err := NewNetwork(toMap(fmt.Sprintf(`{"tls":%v}`, tv[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
 		Convey("When validating listen_on", func() {
 			for _, addr := range []string{fmt.Sprintf("127.0.0.1:%d", DefaultPort), fmt.Sprintf("localhost:%d", DefaultPort), fmt.Sprintf(":%d", DefaultPort)} {
 				Convey(fmt.Sprint("Then it should accept ", addr), func() {
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// NodeSettings has parameters of nodes which topologies inherit from the
+// server. Each topology can override them and each node can override the
+// ones of its topology by BQL statements such as SET TRACE.
+type NodeSettings struct {
+	// QueueSize is the capacity of input pipes of boxes and sinks created
+	// without specifying it. It isn't set when it's 0.
+	QueueSize int `json:"queue_size" yaml:"queue_size"`
+
+	// Trace turns on or off tuple tracing. It isn't set when it's nil.
+	Trace *bool `json:"trace,omitempty" yaml:"trace,omitempty"`
+
+	// Recovery is the recovery policy applied to boxes and sinks when they
+	// get fatal errors. It isn't set when it's nil.
+	Recovery *Recovery `json:"recovery,omitempty" yaml:"recovery,omitempty"`
+}
+
+// Recovery has parameters of a recovery policy of nodes.
+type Recovery struct {
+	// Policy is one of "halt", "restart_node", and "restart_topology".
+	Policy string `json:"policy" yaml:"policy"`
+
+	// MaxRestarts is the maximum number of restarts of a node. It isn't
+	// limited when it's 0.
+	MaxRestarts int `json:"max_restarts" yaml:"max_restarts"`
+
+	// InitialBackoff is the duration in seconds to wait before the first
+	// restart. The default value is used when it's 0.
+	InitialBackoff float64 `json:"initial_backoff" yaml:"initial_backoff"`
+
+	// MaxBackoff is the maximum duration in seconds to wait before a
+	// restart. The default value is used when it's 0.
+	MaxBackoff float64 `json:"max_backoff" yaml:"max_backoff"`
+
+	// Replay rewinds sources after a sink is restarted.
+	Replay bool `json:"replay" yaml:"replay"`
+
+	// Recreate replaces a panicking box with a new instance created from
+	// the parameters of its CREATE BOX statement.
+	Recreate bool `json:"recreate" yaml:"recreate"`
+
+	// FatalCodes has codes of errors which are treated as fatal errors.
+	FatalCodes []string `json:"fatal_codes" yaml:"fatal_codes"`
+}
+
+var (
+	recoverySchemaString = `{
+	"type": "object",
+	"properties": {
+		"policy": {
+			"type": "string",
+			"enum": ["halt", "restart_node", "restart_topology"]
+		},
+		"max_restarts": {
+			"type": "integer",
+			"minimum": 0
+		},
+		"initial_backoff": {
+			"type": "number",
+			"minimum": 0
+		},
+		"max_backoff": {
+			"type": "number",
+			"minimum": 0
+		},
+		"replay": {
+			"type": "boolean"
+		},
+		"recreate": {
+			"type": "boolean"
+		},
+		"fatal_codes": {
+			"type": "array",
+			"items": {
+				"type": "string",
+				"minLength": 1
+			}
+		}
+	},
+	"required": ["policy"],
+	"additionalProperties": false
+}`
+
+	nodeSettingsSchemaString = fmt.Sprintf(`{
+	"type": "object",
+	"properties": {
+		"queue_size": {
+			"type": "integer",
+			"minimum": 0,
+			"maximum": 131071
+		},
+		"trace": {
+			"type": "boolean"
+		},
+		"recovery": %v
+	},
+	"additionalProperties": false
+}`, recoverySchemaString)
+	nodeSettingsSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(nodeSettingsSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	nodeSettingsSchema = s
+}
+
+// NewNodeSettings creates a NodeSettings config parameters from a given map.
+func NewNodeSettings(m data.Map) (*NodeSettings, error) {
+	if err := validate(nodeSettingsSchema, m); err != nil {
+		return nil, err
+	}
+	return newNodeSettings(m), nil
+}
+
+func newNodeSettings(m data.Map) *NodeSettings {
+	s := &NodeSettings{
+		QueueSize: int(mustToInt(getWithDefault(m, "queue_size", data.Int(0)))),
+	}
+	s.Trace, s.Recovery = newTraceAndRecovery(m)
+	return s
+}
+
+// newTraceAndRecovery returns trace and recovery parameters in m. They're
+// shared by NodeSettings and Topology.
+func newTraceAndRecovery(m data.Map) (*bool, *Recovery) {
+	var trace *bool
+	if v, ok := m["trace"]; ok {
+		b := mustToBool(v)
+		trace = &b
+	}
+	var recovery *Recovery
+	if v, ok := m["recovery"]; ok {
+		r := mustAsMap(v)
+		recovery = &Recovery{
+			Policy:         mustAsString(r["policy"]),
+			MaxRestarts:    int(mustToInt(getWithDefault(r, "max_restarts", data.Int(0)))),
+			InitialBackoff: mustToFloat(getWithDefault(r, "initial_backoff", data.Float(0))),
+			MaxBackoff:     mustToFloat(getWithDefault(r, "max_backoff", data.Float(0))),
+			Replay:         mustToBool(getWithDefault(r, "replay", data.False)),
+			Recreate:       mustToBool(getWithDefault(r, "recreate", data.False)),
+		}
+		if v, ok := r["fatal_codes"]; ok {
+			a, _ := data.AsArray(v)
+			for _, c := range a {
+				recovery.FatalCodes = append(recovery.FatalCodes, mustAsString(c))
+			}
+		}
+	}
+	return trace, recovery
+}
+
+// ToMap returns node settings config information as data.Map.
+func (s *NodeSettings) ToMap() data.Map {
+	m := data.Map{
+		"queue_size": data.Int(s.QueueSize),
+	}
+	addTraceAndRecovery(m, s.Trace, s.Recovery)
+	return m
+}
+
+func addTraceAndRecovery(m data.Map, trace *bool, recovery *Recovery) {
+	if trace != nil {
+		m["trace"] = data.Bool(*trace)
+	}
+	if recovery != nil {
+		m["recovery"] = recovery.ToMap()
+	}
+}
+
+// ToMap returns recovery config information as data.Map.
+func (r *Recovery) ToMap() data.Map {
+	codes := make(data.Array, len(r.FatalCodes))
+	for i, c := range r.FatalCodes {
+		codes[i] = data.String(c)
+	}
+	return data.Map{
+		"policy":          data.String(r.Policy),
+		"max_restarts":    data.Int(r.MaxRestarts),
+		"initial_backoff": data.Float(r.InitialBackoff),
+		"max_backoff":     data.Float(r.MaxBackoff),
+		"replay":          data.Bool(r.Replay),
+		"recreate":        data.Bool(r.Recreate),
+		"fatal_codes":     codes,
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestNodeSettings(t *testing.T) {
+	Convey("Given a JSON config for node settings", t, func() {
+		Convey("When the config is valid", func() {
+			s, err := NewNodeSettings(toMap(`{"queue_size":64,"trace":false,"recovery":{"policy":"restart_topology","initial_backoff":0.5,"replay":true,"recreate":true,"fatal_codes":["session_expired"]}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(s.QueueSize, ShouldEqual, 64)
+				So(*s.Trace, ShouldBeFalse)
+				So(*s.Recovery, ShouldResemble, Recovery{
+					Policy:         "restart_topology",
+					InitialBackoff: 0.5,
+					Replay:         true,
+					Recreate:       true,
+					FatalCodes:     []string{"session_expired"},
+				})
+			})
+
+			Convey("Then map should be equal as the config", func() {
+				So(s.ToMap(), ShouldResemble, data.Map{
+					"queue_size": data.Int(64),
+					"trace":      data.False,
+					"recovery": data.Map{
+						"policy":          data.String("restart_topology"),
+						"max_restarts":    data.Int(0),
+						"initial_backoff": data.Float(0.5),
+						"max_backoff":     data.Float(0),
+						"replay":          data.True,
+						"recreate":        data.True,
+						"fatal_codes":     data.Array{data.String("session_expired")},
+					},
+				})
+			})
+		})
+
+		Convey("When the config is empty", func() {
+			s, err := NewNodeSettings(data.Map{})
+			So(err, ShouldBeNil)
+
+			Convey("Then nothing should be set", func() {
+				So(*s, ShouldResemble, NodeSettings{})
+			})
+		})
+
+		Convey("When validating the config", func() {
+			for _, js := range []string{
+				`{"queue_size":-1}`,
+				`{"queue_size":131072}`,
+				`{"trace":"on"}`,
+				`{"recovery":{}}`,
+				`{"recovery":{"policy":"retry"}}`,
+				`{"recovery":{"policy":"halt","max_backoff":-1}}`,
+				`{"recovery":{"policy":"halt","fatal_codes":[""]}}`,
+				`{"recovery":{"policy":"halt","fatal_codes":"session_expired"}}`,
+				`{"unknown":1}`,
+			} {
+				Convey("Then it should reject "+js, func() {
+					_, err := NewNodeSettings(toMap(js))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"sort"
+	"strings"
+)
+
+// Change is a difference between two configs.
+type Change struct {
+	// Path is the dot-separated path to the changed parameter such as
+	// "logging.min_log_level". When a topology or a tenant is added or
+	// removed, it's the path to the topology or the tenant such as
+	// "topologies.t1".
+	Path string `json:"path"`
+
+	// Reloadable is true when the change can be applied to a running server.
+	// Other changes require the server to restart.
+	Reloadable bool `json:"reloadable"`
+}
+
+// reloadablePaths are paths of parameters which can be changed without
+// restarting the server. "*" matches any name of a topology or a tenant.
+// Parameters under a reloadable path are also reloadable.
+var reloadablePaths = []string{
+	"logging.min_log_level",
+	"logging.log_dropped_tuples",
+	"logging.summarize_dropped_tuples",
+	"node_defaults",
+	"topologies.*.max_memory",
+	"topologies.*.max_tuples",
+	"topologies.*.queue_size",
+	"topologies.*.trace",
+	"topologies.*.recovery",
+	"tenants.*.max_memory",
+	"tenants.*.max_tuples",
+}
+
+// Diff returns changes from old to new sorted by their paths.
+func Diff(old, new *Config) []Change {
+	var cs []Change
+	diffMap(&cs, nil, old.ToMap(), new.ToMap())
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].Path < cs[j].Path
+	})
+	return cs
+}
+
+func diffMap(cs *[]Change, path []string, old, new data.Map) {
+	add := func(k string) {
+		p := append(append([]string{}, path...), k)
+		*cs = append(*cs, Change{
+			Path:       strings.Join(p, "."),
+			Reloadable: isReloadable(p),
+		})
+	}
+
+	for k, o := range old {
+		n, ok := new[k]
+		if !ok {
+			add(k)
+			continue
+		}
+		om, oErr := data.AsMap(o)
+		nm, nErr := data.AsMap(n)
+		if oErr == nil && nErr == nil {
+			diffMap(cs, append(append([]string{}, path...), k), om, nm)
+		} else if !data.Equal(o, n) {
+			add(k)
+		}
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			add(k)
+		}
+	}
+}
+
+func isReloadable(path []string) bool {
+	for _, r := range reloadablePaths {
+		rs := strings.Split(r, ".")
+		if len(rs) > len(path) {
+			continue
+		}
+		match := true
+		for i, e := range rs {
+			if e != "*" && e != path[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
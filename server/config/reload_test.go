@@ -0,0 +1,71 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	Convey("Given a config", t, func() {
+		old, err := New(toMap(`{
+	"network": {"listen_on": ":12345"},
+	"topologies": {"t1": {"max_tuples": 100}, "t2": null},
+	"tenants": {"a": {"max_memory": 1024}},
+	"logging": {"min_log_level": "info"}
+}`))
+		So(err, ShouldBeNil)
+
+		Convey("When comparing it with the same config", func() {
+			cs := Diff(old, old)
+
+			Convey("Then there should be no change", func() {
+				So(cs, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When changing reloadable parameters", func() {
+			new, err := New(toMap(`{
+	"network": {"listen_on": ":12345"},
+	"topologies": {"t1": {"max_tuples": 200, "queue_size": 64, "recovery": {"policy": "halt"}}, "t2": null},
+	"node_defaults": {"trace": true},
+	"tenants": {"a": {"max_memory": 2048}},
+	"logging": {"min_log_level": "debug", "log_dropped_tuples": true}
+}`))
+			So(err, ShouldBeNil)
+			cs := Diff(old, new)
+
+			Convey("Then all changes should be reloadable", func() {
+				So(cs, ShouldResemble, []Change{
+					{Path: "logging.log_dropped_tuples", Reloadable: true},
+					{Path: "logging.min_log_level", Reloadable: true},
+					{Path: "node_defaults.trace", Reloadable: true},
+					{Path: "tenants.a.max_memory", Reloadable: true},
+					{Path: "topologies.t1.max_tuples", Reloadable: true},
+					{Path: "topologies.t1.queue_size", Reloadable: true},
+					{Path: "topologies.t1.recovery", Reloadable: true},
+				})
+			})
+		})
+
+		Convey("When changing parameters requiring restart", func() {
+			new, err := New(toMap(`{
+	"network": {"listen_on": ":23456"},
+	"topologies": {"t1": {"max_tuples": 100, "bql_file": "t1.bql"}, "t3": null},
+	"tenants": {"a": {"max_memory": 1024}},
+	"logging": {"min_log_level": "info", "target": "stdout"}
+}`))
+			So(err, ShouldBeNil)
+			cs := Diff(old, new)
+
+			Convey("Then no change should be reloadable", func() {
+				So(cs, ShouldResemble, []Change{
+					{Path: "logging.target", Reloadable: false},
+					{Path: "network.listen_on", Reloadable: false},
+					{Path: "topologies.t1.bql_file", Reloadable: false},
+					{Path: "topologies.t2", Reloadable: false},
+					{Path: "topologies.t3", Reloadable: false},
+				})
+			})
+		})
+	})
+}
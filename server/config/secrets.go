@@ -0,0 +1,168 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Secrets has configuration parameters of providers of secrets referred from
+// parameters of BQL statements like `${secret:name}`. When multiple providers
+// are configured, secrets are looked up in the order of env, file, and vault.
+type Secrets struct {
+	// Env reads secrets from environment variables. The provider isn't used
+	// when it's nil.
+	Env *EnvSecrets `json:"env,omitempty" yaml:"env,omitempty"`
+
+	// File reads secrets from files in a directory. The provider isn't used
+	// when it's nil.
+	File *FileSecrets `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// Vault reads secrets from HashiCorp Vault. The provider isn't used when
+	// it's nil.
+	Vault *VaultSecrets `json:"vault,omitempty" yaml:"vault,omitempty"`
+}
+
+// EnvSecrets has parameters of the provider reading secrets from environment
+// variables.
+type EnvSecrets struct {
+	// Prefix is the prefix of names of environment variables.
+	Prefix string `json:"prefix" yaml:"prefix"`
+}
+
+// FileSecrets has parameters of the provider reading secrets from files.
+type FileSecrets struct {
+	// Dir is the directory having files of secrets.
+	Dir string `json:"dir" yaml:"dir"`
+}
+
+// VaultSecrets has parameters of the provider reading secrets from the KV
+// secrets engine version 2 of HashiCorp Vault.
+type VaultSecrets struct {
+	// Address is the address of the Vault server.
+	Address string `json:"address" yaml:"address"`
+
+	// Token is the token to access Vault. When it's empty, the value of the
+	// environment variable VAULT_TOKEN is used so that the token doesn't
+	// have to be written in the config file.
+	Token string `json:"token" yaml:"token"`
+
+	// Mount is the path where the KV secrets engine is mounted.
+	Mount string `json:"mount" yaml:"mount"`
+}
+
+var (
+	secretsSchemaString = `{
+	"type": "object",
+	"properties": {
+		"env": {
+			"type": "object",
+			"properties": {
+				"prefix": {
+					"type": "string"
+				}
+			},
+			"additionalProperties": false
+		},
+		"file": {
+			"type": "object",
+			"properties": {
+				"dir": {
+					"type": "string",
+					"minLength": 1
+				}
+			},
+			"required": ["dir"],
+			"additionalProperties": false
+		},
+		"vault": {
+			"type": "object",
+			"properties": {
+				"address": {
+					"type": "string",
+					"minLength": 1
+				},
+				"token": {
+					"type": "string"
+				},
+				"mount": {
+					"type": "string",
+					"minLength": 1
+				}
+			},
+			"required": ["address"],
+			"additionalProperties": false
+		}
+	},
+	"additionalProperties": false
+}`
+	secretsSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(secretsSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	secretsSchema = s
+}
+
+// NewSecrets creates a Secrets config parameters from a given map.
+func NewSecrets(m data.Map) (*Secrets, error) {
+	if err := validate(secretsSchema, m); err != nil {
+		return nil, err
+	}
+	return newSecrets(m), nil
+}
+
+func newSecrets(m data.Map) *Secrets {
+	s := &Secrets{}
+	if v, ok := m["env"]; ok {
+		e := mustAsMap(v)
+		s.Env = &EnvSecrets{
+			Prefix: mustAsString(getWithDefault(e, "prefix", data.String("SENSORBEE_SECRET_"))),
+		}
+	}
+	if v, ok := m["file"]; ok {
+		f := mustAsMap(v)
+		s.File = &FileSecrets{
+			Dir: mustAsString(f["dir"]),
+		}
+	}
+	if v, ok := m["vault"]; ok {
+		vm := mustAsMap(v)
+		s.Vault = &VaultSecrets{
+			Address: mustAsString(vm["address"]),
+			Token:   mustAsString(getWithDefault(vm, "token", data.String(""))),
+			Mount:   mustAsString(getWithDefault(vm, "mount", data.String("secret"))),
+		}
+	}
+	return s
+}
+
+// ToMap returns secrets config information as data.Map. The token of Vault
+// is masked.
+func (s *Secrets) ToMap() data.Map {
+	m := data.Map{}
+	if s.Env != nil {
+		m["env"] = data.Map{
+			"prefix": data.String(s.Env.Prefix),
+		}
+	}
+	if s.File != nil {
+		m["file"] = data.Map{
+			"dir": data.String(s.File.Dir),
+		}
+	}
+	if s.Vault != nil {
+		token := ""
+		if s.Vault.Token != "" {
+			token = maskedSecret
+		}
+		m["vault"] = data.Map{
+			"address": data.String(s.Vault.Address),
+			"token":   data.String(token),
+			"mount":   data.String(s.Vault.Mount),
+		}
+	}
+	return m
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestSecrets(t *testing.T) {
+	Convey("Given a JSON config for secrets section", t, func() {
+		Convey("When the config is valid", func() {
+			s, err := NewSecrets(toMap(`{
+	"env": {"prefix": "SB_"},
+	"file": {"dir": "/run/secrets"},
+	"vault": {"address": "https://vault:8200", "token": "t0ken", "mount": "kv"}
+}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(s.Env.Prefix, ShouldEqual, "SB_")
+				So(s.File.Dir, ShouldEqual, "/run/secrets")
+				So(s.Vault.Address, ShouldEqual, "https://vault:8200")
+				So(s.Vault.Token, ShouldEqual, "t0ken")
+				So(s.Vault.Mount, ShouldEqual, "kv")
+			})
+
+			Convey("Then the token should be masked in a map", func() {
+				v, err := s.ToMap().Get(data.MustCompilePath("vault.token"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, data.String(maskedSecret))
+			})
+		})
+
+		Convey("When the config only has required parameters", func() {
+			s, err := NewSecrets(toMap(`{"env": {}, "vault": {"address": "https://vault:8200"}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have default values", func() {
+				So(s.Env.Prefix, ShouldEqual, "SENSORBEE_SECRET_")
+				So(s.File, ShouldBeNil)
+				So(s.Vault.Token, ShouldBeBlank)
+				So(s.Vault.Mount, ShouldEqual, "secret")
+			})
+		})
+
+		Convey("When validating the config", func() {
+			for _, c := range []string{
+				`{"file": {}}`,
+				`{"file": {"dir": ""}}`,
+				`{"vault": {"token": "t0ken"}}`,
+				`{"env": {"prefix": 1}}`,
+				`{"aws": {}}`,
+			} {
+				Convey("Then it should reject "+c, func() {
+					_, err := NewSecrets(toMap(c))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
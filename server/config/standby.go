@@ -0,0 +1,96 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Standby has configuration parameters of a hot-standby server. A standby
+// server periodically replicates states and committed source offsets of
+// topologies from the primary server and doesn't run its own topologies until
+// it detects the failure of the primary. Then, it takes over the primary by
+// creating topologies with the replicated states and offsets.
+type Standby struct {
+	// Primary is the URL of the primary server's API, e.g.
+	// "http://primary:15601".
+	Primary string `json:"primary" yaml:"primary"`
+
+	// APIKey is the API key sent to the primary server. It must have the
+	// admin role of replicated topologies when the primary server enables
+	// authentication.
+	APIKey string `json:"api_key" yaml:"api_key"`
+
+	// Interval is the interval of replication in seconds.
+	Interval float64 `json:"interval" yaml:"interval"`
+
+	// FailureThreshold is the number of consecutive failures of replication
+	// after which the standby server takes over the primary.
+	FailureThreshold int `json:"failure_threshold" yaml:"failure_threshold"`
+}
+
+var (
+	standbySchemaString = `{
+	"type": "object",
+	"properties": {
+		"primary": {
+			"type": "string",
+			"minLength": 1
+		},
+		"api_key": {
+			"type": "string"
+		},
+		"interval": {
+			"type": "number",
+			"exclusiveMinimum": true,
+			"minimum": 0
+		},
+		"failure_threshold": {
+			"type": "integer",
+			"minimum": 1
+		}
+	},
+	"required": ["primary"],
+	"additionalProperties": false
+}`
+	standbySchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(standbySchemaString))
+	if err != nil {
+		panic(err)
+	}
+	standbySchema = s
+}
+
+// NewStandby creates a Standby config parameters from a given map.
+func NewStandby(m data.Map) (*Standby, error) {
+	if err := validate(standbySchema, m); err != nil {
+		return nil, err
+	}
+	return newStandby(m), nil
+}
+
+func newStandby(m data.Map) *Standby {
+	return &Standby{
+		Primary:          mustAsString(m["primary"]),
+		APIKey:           mustAsString(getWithDefault(m, "api_key", data.String(""))),
+		Interval:         mustToFloat(getWithDefault(m, "interval", data.Float(5))),
+		FailureThreshold: int(mustToInt(getWithDefault(m, "failure_threshold", data.Int(3)))),
+	}
+}
+
+// ToMap returns standby config information as data.Map. The API key is
+// masked.
+func (s *Standby) ToMap() data.Map {
+	key := ""
+	if s.APIKey != "" {
+		key = maskedSecret
+	}
+	return data.Map{
+		"primary":           data.String(s.Primary),
+		"api_key":           data.String(key),
+		"interval":          data.Float(s.Interval),
+		"failure_threshold": data.Int(s.FailureThreshold),
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestStandby(t *testing.T) {
+	Convey("Given a JSON config for standby section", t, func() {
+		Convey("When the config is valid", func() {
+			s, err := NewStandby(toMap(`{
+	"primary": "http://primary:15601",
+	"api_key": "k3y",
+	"interval": 0.5,
+	"failure_threshold": 5
+}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(s.Primary, ShouldEqual, "http://primary:15601")
+				So(s.APIKey, ShouldEqual, "k3y")
+				So(s.Interval, ShouldEqual, 0.5)
+				So(s.FailureThreshold, ShouldEqual, 5)
+			})
+
+			Convey("Then the API key should be masked in a map", func() {
+				So(s.ToMap()["api_key"], ShouldEqual, data.String(maskedSecret))
+			})
+		})
+
+		Convey("When the config only has required parameters", func() {
+			s, err := NewStandby(toMap(`{"primary": "http://primary:15601"}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have default values", func() {
+				So(s.APIKey, ShouldBeBlank)
+				So(s.Interval, ShouldEqual, 5)
+				So(s.FailureThreshold, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When validating the config", func() {
+			for _, c := range []string{
+				`{}`,
+				`{"primary": ""}`,
+				`{"primary": "http://primary:15601", "interval": 0}`,
+				`{"primary": "http://primary:15601", "interval": "5"}`,
+				`{"primary": "http://primary:15601", "failure_threshold": 0}`,
+				`{"primary": "http://primary:15601", "failure_threshold": 1.5}`,
+				`{"primary": "http://primary:15601", "timeout": 1}`,
+			} {
+				Convey("Then it should reject "+c, func() {
+					_, err := NewStandby(toMap(c))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// Tenant has configuration parameters of a tenant. A tenant is a group of
+// topologies owned by the same user or team. Topologies owned by a tenant
+// have names prefixed by the tenant's name followed by "__".
+type Tenant struct {
+	// Name is the name of the tenant. This field isn't directly used in a
+	// config file.
+	Name string `json:"-" yaml:"-"`
+
+	// MaxMemory is the maximum approximate size of memory in bytes used by
+	// tuples of all topologies owned by the tenant. 0 means unlimited.
+	MaxMemory int64 `json:"max_memory" yaml:"max_memory"`
+
+	// MaxTuples is the maximum number of tuples kept by all topologies owned
+	// by the tenant. 0 means unlimited.
+	MaxTuples int64 `json:"max_tuples" yaml:"max_tuples"`
+}
+
+// Tenants is a set of configuration of tenants.
+type Tenants map[string]*Tenant
+
+var (
+	tenantsSchemaString = `{
+	"type": "object",
+	"properties": {
+	},
+	"patternProperties": {
+		".*": {
+			"anyOf": [
+				{
+					"type": "object",
+					"properties": {
+						"max_memory": {
+							"type": "integer",
+							"minimum": 0
+						},
+						"max_tuples": {
+							"type": "integer",
+							"minimum": 0
+						}
+					},
+					"additionalProperties": false
+				},
+				{
+					"type": "null"
+				}
+			]
+		}
+	}
+}`
+	tenantsSchema *gojsonschema.Schema
+)
+
+func init() {
+	s, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(tenantsSchemaString))
+	if err != nil {
+		panic(err)
+	}
+	tenantsSchema = s
+}
+
+// NewTenants creates a Tenants config parameters from a given map.
+func NewTenants(m data.Map) (Tenants, error) {
+	if err := validate(tenantsSchema, m); err != nil {
+		return nil, err
+	}
+	return newTenants(m), nil
+}
+
+func newTenants(m data.Map) Tenants {
+	ts := Tenants{}
+	for name, conf := range m {
+		if conf.Type() == data.TypeNull {
+			conf = data.Map{}
+		}
+		c := mustAsMap(conf)
+		ts[name] = &Tenant{
+			Name:      name,
+			MaxMemory: mustToInt(getWithDefault(c, "max_memory", data.Int(0))),
+			MaxTuples: mustToInt(getWithDefault(c, "max_tuples", data.Int(0))),
+		}
+	}
+	return ts
+}
+
+// ToMap returns tenants config information as data.Map.
+func (ts *Tenants) ToMap() data.Map {
+	m := data.Map{}
+	for k, v := range *ts {
+		m[k] = data.Map{
+			"max_memory": data.Int(v.MaxMemory),
+			"max_tuples": data.Int(v.MaxTuples),
+		}
+	}
+	return m
+}
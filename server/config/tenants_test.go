@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"testing"
+)
+
+func TestTenants(t *testing.T) {
+	Convey("Given a JSON config for tenants section", t, func() {
+		Convey("When the config is valid", func() {
+			ts, err := NewTenants(toMap(`{"team_a":{"max_memory":1048576,"max_tuples":1000},"team_b":null}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(ts["team_a"].Name, ShouldEqual, "team_a")
+				So(ts["team_a"].MaxMemory, ShouldEqual, 1048576)
+				So(ts["team_a"].MaxTuples, ShouldEqual, 1000)
+				So(ts["team_b"].Name, ShouldEqual, "team_b")
+				So(ts["team_b"].MaxMemory, ShouldEqual, 0)
+				So(ts["team_b"].MaxTuples, ShouldEqual, 0)
+			})
+
+			Convey("Then it should be converted to a map", func() {
+				So(ts.ToMap(), ShouldResemble, data.Map{
+					"team_a": data.Map{
+						"max_memory": data.Int(1048576),
+						"max_tuples": data.Int(1000),
+					},
+					"team_b": data.Map{
+						"max_memory": data.Int(0),
+						"max_tuples": data.Int(0),
+					},
+				})
+			})
+		})
+
+		Convey("When the config has an undefined field", func() {
+			_, err := NewTenants(toMap(`{"team_a":{"bql_file":"/path/to/hoge.bql"}}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When validating resource limits", func() {
+			for _, l := range []string{"max_memory", "max_tuples"} {
+				for _, b := range [][]interface{}{{"negative", -1}, {"invalid type", `"1"`}, {"float", 1.5}} {
+					Convey(fmt.Sprintf("Then it should reject %v value of %v", b[0], l), func() {
+						_, err := NewTenants(toMap(fmt.Sprintf(`{"team_a":{"%v":%v}}`, l, b[1])))
+						So(err, ShouldNotBeNil)
+					})
+				}
+			}
+		})
+	})
+}
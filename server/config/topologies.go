@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
@@ -13,13 +14,174 @@ type Topology struct {
 
 	// BQLFile is a file path to the BQL file executed on start up.
 	BQLFile string `json:"bql_file" yaml:"bql_file"`
+
+	// MaxMemory is the maximum approximate size of memory in bytes used by
+	// tuples queued in pipes and kept in window buffers of the topology.
+	// 0 means unlimited.
+	MaxMemory int64 `json:"max_memory" yaml:"max_memory"`
+
+	// MaxTuples is the maximum number of tuples queued in pipes and kept in
+	// window buffers of the topology. 0 means unlimited.
+	MaxTuples int64 `json:"max_tuples" yaml:"max_tuples"`
+
+	// QueueSize is the capacity of input pipes of boxes and sinks in the
+	// topology created without specifying it. The value of NodeDefaults is
+	// used when it's 0. A new value only affects pipes connected after it's
+	// reloaded.
+	QueueSize int `json:"queue_size" yaml:"queue_size"`
+
+	// Trace turns on or off tuple tracing of the topology. The value of
+	// NodeDefaults is used when it's nil.
+	Trace *bool `json:"trace,omitempty" yaml:"trace,omitempty"`
+
+	// Recovery is the recovery policy of boxes and sinks in the topology.
+	// The value of NodeDefaults is used when it's nil.
+	Recovery *Recovery `json:"recovery,omitempty" yaml:"recovery,omitempty"`
+
+	// EvaluationMode is either "strict" or "lenient". In the strict mode,
+	// which is the default, referring to a missing field in an expression
+	// is an error and the tuple is dropped. In the lenient mode, a missing
+	// field is evaluated as NULL.
+	EvaluationMode string `json:"evaluation_mode" yaml:"evaluation_mode"`
+
+	// ConversionPolicy is either "permissive" or "strict". It controls
+	// implicit conversions of values passed to UDFs. The strict policy
+	// only allows conversions which don't lose information, e.g. 2.7 cannot
+	// be passed to an int parameter. The default value is "permissive".
+	ConversionPolicy string `json:"conversion_policy" yaml:"conversion_policy"`
+
+	// WindowSpill has configuration parameters to spill old contents of
+	// large windows to disk. Spilling is disabled when it's nil.
+	WindowSpill *WindowSpill `json:"window_spill,omitempty" yaml:"window_spill,omitempty"`
+
+	// WindowArena makes SELECT statements with GROUP BY reuse temporary
+	// structures used to compute results from their windows instead of
+	// allocating them every time. It reduces GC pauses for topologies having
+	// thousands of groups in a window. The default value is false.
+	WindowArena bool `json:"window_arena" yaml:"window_arena"`
+
+	// ShareWindows makes SELECT statements reading the same stream with
+	// identical windows and aliases keep the same tuples in their windows
+	// instead of wrapping and accounting each tuple per statement. It's
+	// ignored when window_spill is set. The default value is false.
+	ShareWindows bool `json:"share_windows" yaml:"share_windows"`
+
+	// SharedBlobCheck makes boxes and sinks check that they don't modify
+	// shared blobs in tuples they receive. It's expensive for large blobs
+	// and should only be enabled for debugging. The default value is false.
+	SharedBlobCheck bool `json:"shared_blob_check" yaml:"shared_blob_check"`
+
+	// ParallelAggregation has configuration parameters to evaluate groups of
+	// SELECT statements with GROUP BY in parallel. Groups are evaluated
+	// sequentially when it's nil.
+	ParallelAggregation *ParallelAggregation `json:"parallel_aggregation,omitempty" yaml:"parallel_aggregation,omitempty"`
+
+	// KeyTTL is how long SELECT statements keep state of each key, such as
+	// the last row emitted for a key of WHEN CHANGED BY, after the key was
+	// seen last time. It's in seconds. State is kept forever when it's 0,
+	// which is the default.
+	KeyTTL float64 `json:"key_ttl" yaml:"key_ttl"`
+
+	// BoxParallelism is the number of goroutines processing tuples in each
+	// box created by a CREATE STREAM statement with a box type. It's either a
+	// positive integer or "auto" in a config file. "auto" sizes the pool by
+	// the number of CPUs and is represented as BoxParallelismAuto. The
+	// default value is 1.
+	BoxParallelism int `json:"box_parallelism" yaml:"box_parallelism"`
+
+	// Nice lowers the share of CPUs used by boxes of the topology like nice
+	// of Unix, which gives control over CPU allocation between topologies.
+	// It ranges from 0 to 19 and only affects pools sized by "auto". The
+	// default value is 0.
+	Nice int `json:"nice" yaml:"nice"`
+
+	// NodeNice has nice of each box created by a CREATE STREAM statement with
+	// a box type, which gives control over CPU allocation between boxes of
+	// the topology. Keys are names of boxes. Listed boxes get pools sized by
+	// "auto" regardless of box_parallelism and their own values override
+	// Nice.
+	NodeNice map[string]int `json:"node_nice" yaml:"node_nice"`
+
+	// DedicatedThreadSinks has names of latency-critical sinks which get
+	// dedicated OS threads.
+	DedicatedThreadSinks []string `json:"dedicated_thread_sinks" yaml:"dedicated_thread_sinks"`
+
+	// SinkFlushInterval is the interval at which sinks buffering tuples,
+	// such as http sinks with batch_size, are flushed in addition to their
+	// own schedules. It's in seconds. Sinks are only flushed before they're
+	// closed and when savepoints are taken when it's 0, which is the default.
+	SinkFlushInterval float64 `json:"sink_flush_interval" yaml:"sink_flush_interval"`
+
+	// SinkReadinessTimeout is how long sources created by the BQL file wait
+	// for sinks to connect before they start. It's in seconds and
+	// DefaultSinkReadinessTimeout by default. Sources start without waiting
+	// when it's 0.
+	SinkReadinessTimeout float64 `json:"sink_readiness_timeout" yaml:"sink_readiness_timeout"`
+
+	// Staleness has configuration parameters to discard stale tuples.
+	// Tuples aren't filtered when it's nil.
+	Staleness *Staleness `json:"staleness,omitempty" yaml:"staleness,omitempty"`
+}
+
+// WindowSpill has configuration parameters to spill old contents of large
+// windows to disk.
+type WindowSpill struct {
+	// Dir is the directory where spill files are created. The default
+	// directory for temporary files is used when it's empty.
+	Dir string `json:"dir" yaml:"dir"`
+
+	// MemoryTuples is the number of the most recent tuples of each window
+	// kept in memory. The default value is used when it's 0.
+	MemoryTuples int `json:"memory_tuples" yaml:"memory_tuples"`
+}
+
+// ParallelAggregation has configuration parameters to evaluate groups of
+// GROUP BY in parallel.
+type ParallelAggregation struct {
+	// Workers is the number of goroutines evaluating groups of a statement.
+	// The number of CPUs is used when it's 0.
+	Workers int `json:"workers" yaml:"workers"`
+
+	// MinGroups is the minimum number of groups in a window to evaluate them
+	// in parallel. The default value is used when it's 0.
+	MinGroups int `json:"min_groups" yaml:"min_groups"`
+}
+
+// Staleness has configuration parameters to discard tuples older than a
+// given age at sources or before sinks.
+type Staleness struct {
+	// MaxAge is the maximum age of tuples in seconds.
+	MaxAge float64 `json:"max_age" yaml:"max_age"`
+
+	// TimestampField is a path to a field having the event time of a tuple.
+	// The timestamp of a tuple is used when it's empty.
+	TimestampField string `json:"timestamp_field" yaml:"timestamp_field"`
+
+	// Divert reports stale tuples as dropped tuples so that they can be
+	// received from a dropped_tuples source. They're discarded silently
+	// when it's false.
+	Divert bool `json:"divert" yaml:"divert"`
+
+	// Sources enables the filter at sources. The default value is true.
+	Sources bool `json:"sources" yaml:"sources"`
+
+	// Sinks enables the filter before sinks. The default value is false.
+	Sinks bool `json:"sinks" yaml:"sinks"`
 }
 
+// BoxParallelismAuto is the value of Topology.BoxParallelism when it's
+// "auto" in a config file. It's the same as core.ParallelismAuto.
+const BoxParallelismAuto = -1
+
+// DefaultSinkReadinessTimeout is the default value of
+// Topology.SinkReadinessTimeout in seconds.
+const DefaultSinkReadinessTimeout = 10
+
 // Topologies is a set of configuration of topologies.
 type Topologies map[string]*Topology
 
 var (
-	topologiesSchemaString = `{
+	topologiesSchemaString = fmt.Sprintf(`{
 	"type": "object",
 	"properties": {
 	},
@@ -32,6 +194,135 @@ var (
 						"bql_file": {
 							"type": "string",
 							"minLength": 1
+						},
+						"max_memory": {
+							"type": "integer",
+							"minimum": 0
+						},
+						"max_tuples": {
+							"type": "integer",
+							"minimum": 0
+						},
+						"queue_size": {
+							"type": "integer",
+							"minimum": 0,
+							"maximum": 131071
+						},
+						"trace": {
+							"type": "boolean"
+						},
+						"recovery": %v,
+						"evaluation_mode": {
+							"type": "string",
+							"enum": ["strict", "lenient"]
+						},
+						"conversion_policy": {
+							"type": "string",
+							"enum": ["permissive", "strict"]
+						},
+						"window_arena": {
+							"type": "boolean"
+						},
+						"share_windows": {
+							"type": "boolean"
+						},
+						"shared_blob_check": {
+							"type": "boolean"
+						},
+						"key_ttl": {
+							"type": "number",
+							"minimum": 0
+						},
+						"sink_flush_interval": {
+							"type": "number",
+							"minimum": 0
+						},
+						"sink_readiness_timeout": {
+							"type": "number",
+							"minimum": 0
+						},
+						"box_parallelism": {
+							"anyOf": [
+								{
+									"type": "integer",
+									"minimum": 1
+								},
+								{
+									"type": "string",
+									"enum": ["auto"]
+								}
+							]
+						},
+						"nice": {
+							"type": "integer",
+							"minimum": 0,
+							"maximum": 19
+						},
+						"node_nice": {
+							"type": "object",
+							"additionalProperties": {
+								"type": "integer",
+								"minimum": 0,
+								"maximum": 19
+							}
+						},
+						"dedicated_thread_sinks": {
+							"type": "array",
+							"items": {
+								"type": "string",
+								"minLength": 1
+							}
+						},
+						"window_spill": {
+							"type": "object",
+							"properties": {
+								"dir": {
+									"type": "string"
+								},
+								"memory_tuples": {
+									"type": "integer",
+									"minimum": 0
+								}
+							},
+							"additionalProperties": false
+						},
+						"parallel_aggregation": {
+							"type": "object",
+							"properties": {
+								"workers": {
+									"type": "integer",
+									"minimum": 0
+								},
+								"min_groups": {
+									"type": "integer",
+									"minimum": 0
+								}
+							},
+							"additionalProperties": false
+						},
+						"staleness": {
+							"type": "object",
+							"properties": {
+								"max_age": {
+									"type": "number",
+									"exclusiveMinimum": true,
+									"minimum": 0
+								},
+								"timestamp_field": {
+									"type": "string"
+								},
+								"divert": {
+									"type": "boolean"
+								},
+								"sources": {
+									"type": "boolean"
+								},
+								"sinks": {
+									"type": "boolean"
+								}
+							},
+							"required": ["max_age"],
+							"additionalProperties": false
 						}
 					},
 					"additionalProperties": false
@@ -42,7 +333,7 @@ var (
 			]
 		}
 	}
-}`
+}`, recoverySchemaString)
 
 	// Because gojsonschema doesn't support partial schema validation, this
 	// has to be defined separately from
@@ -72,9 +363,68 @@ func newTopologies(m data.Map) Topologies {
 		if conf.Type() == data.TypeNull {
 			conf = data.Map{}
 		}
+		c := mustAsMap(conf)
 		t := &Topology{
-			Name:    name,
-			BQLFile: mustAsString(getWithDefault(mustAsMap(conf), "bql_file", data.String(""))),
+			Name:                 name,
+			BQLFile:              mustAsString(getWithDefault(c, "bql_file", data.String(""))),
+			MaxMemory:            mustToInt(getWithDefault(c, "max_memory", data.Int(0))),
+			MaxTuples:            mustToInt(getWithDefault(c, "max_tuples", data.Int(0))),
+			QueueSize:            int(mustToInt(getWithDefault(c, "queue_size", data.Int(0)))),
+			EvaluationMode:       mustAsString(getWithDefault(c, "evaluation_mode", data.String("strict"))),
+			ConversionPolicy:     mustAsString(getWithDefault(c, "conversion_policy", data.String("permissive"))),
+			WindowArena:          mustToBool(getWithDefault(c, "window_arena", data.False)),
+			ShareWindows:         mustToBool(getWithDefault(c, "share_windows", data.False)),
+			SharedBlobCheck:      mustToBool(getWithDefault(c, "shared_blob_check", data.False)),
+			KeyTTL:               mustToFloat(getWithDefault(c, "key_ttl", data.Float(0))),
+			SinkFlushInterval:    mustToFloat(getWithDefault(c, "sink_flush_interval", data.Float(0))),
+			SinkReadinessTimeout: mustToFloat(getWithDefault(c, "sink_readiness_timeout", data.Float(DefaultSinkReadinessTimeout))),
+			BoxParallelism:       1,
+			Nice:                 int(mustToInt(getWithDefault(c, "nice", data.Int(0)))),
+		}
+		t.Trace, t.Recovery = newTraceAndRecovery(c)
+		if v, ok := c["box_parallelism"]; ok {
+			if v.Type() == data.TypeString {
+				t.BoxParallelism = BoxParallelismAuto
+			} else {
+				t.BoxParallelism = int(mustToInt(v))
+			}
+		}
+		if v, ok := c["node_nice"]; ok {
+			n := mustAsMap(v)
+			t.NodeNice = make(map[string]int, len(n))
+			for name, nice := range n {
+				t.NodeNice[name] = int(mustToInt(nice))
+			}
+		}
+		if v, ok := c["dedicated_thread_sinks"]; ok {
+			a, _ := data.AsArray(v)
+			for _, s := range a {
+				t.DedicatedThreadSinks = append(t.DedicatedThreadSinks, mustAsString(s))
+			}
+		}
+		if v, ok := c["window_spill"]; ok {
+			w := mustAsMap(v)
+			t.WindowSpill = &WindowSpill{
+				Dir:          mustAsString(getWithDefault(w, "dir", data.String(""))),
+				MemoryTuples: int(mustToInt(getWithDefault(w, "memory_tuples", data.Int(0)))),
+			}
+		}
+		if v, ok := c["parallel_aggregation"]; ok {
+			p := mustAsMap(v)
+			t.ParallelAggregation = &ParallelAggregation{
+				Workers:   int(mustToInt(getWithDefault(p, "workers", data.Int(0)))),
+				MinGroups: int(mustToInt(getWithDefault(p, "min_groups", data.Int(0)))),
+			}
+		}
+		if v, ok := c["staleness"]; ok {
+			st := mustAsMap(v)
+			t.Staleness = &Staleness{
+				MaxAge:         mustToFloat(st["max_age"]),
+				TimestampField: mustAsString(getWithDefault(st, "timestamp_field", data.String(""))),
+				Divert:         mustToBool(getWithDefault(st, "divert", data.False)),
+				Sources:        mustToBool(getWithDefault(st, "sources", data.True)),
+				Sinks:          mustToBool(getWithDefault(st, "sinks", data.False)),
+			}
 		}
 		ts[name] = t
 	}
@@ -86,9 +436,58 @@ func (ts *Topologies) ToMap() data.Map {
 	m := data.Map{}
 	for k, v := range *ts {
 		v := v
-		m[k] = data.Map{
-			"bql_file": data.String(v.BQLFile),
+		t := data.Map{
+			"bql_file":               data.String(v.BQLFile),
+			"max_memory":             data.Int(v.MaxMemory),
+			"max_tuples":             data.Int(v.MaxTuples),
+			"queue_size":             data.Int(v.QueueSize),
+			"evaluation_mode":        data.String(v.EvaluationMode),
+			"conversion_policy":      data.String(v.ConversionPolicy),
+			"window_arena":           data.Bool(v.WindowArena),
+			"share_windows":          data.Bool(v.ShareWindows),
+			"shared_blob_check":      data.Bool(v.SharedBlobCheck),
+			"key_ttl":                data.Float(v.KeyTTL),
+			"sink_flush_interval":    data.Float(v.SinkFlushInterval),
+			"sink_readiness_timeout": data.Float(v.SinkReadinessTimeout),
+			"box_parallelism":        data.Int(v.BoxParallelism),
+			"nice":                   data.Int(v.Nice),
+		}
+		addTraceAndRecovery(t, v.Trace, v.Recovery)
+		if v.BoxParallelism == BoxParallelismAuto {
+			t["box_parallelism"] = data.String("auto")
+		}
+		sinks := make(data.Array, len(v.DedicatedThreadSinks))
+		for i, s := range v.DedicatedThreadSinks {
+			sinks[i] = data.String(s)
+		}
+		t["dedicated_thread_sinks"] = sinks
+		nices := make(data.Map, len(v.NodeNice))
+		for name, nice := range v.NodeNice {
+			nices[name] = data.Int(nice)
+		}
+		t["node_nice"] = nices
+		if v.WindowSpill != nil {
+			t["window_spill"] = data.Map{
+				"dir":           data.String(v.WindowSpill.Dir),
+				"memory_tuples": data.Int(v.WindowSpill.MemoryTuples),
+			}
+		}
+		if v.ParallelAggregation != nil {
+			t["parallel_aggregation"] = data.Map{
+				"workers":    data.Int(v.ParallelAggregation.Workers),
+				"min_groups": data.Int(v.ParallelAggregation.MinGroups),
+			}
+		}
+		if v.Staleness != nil {
+			t["staleness"] = data.Map{
+				"max_age":         data.Float(v.Staleness.MaxAge),
+				"timestamp_field": data.String(v.Staleness.TimestampField),
+				"divert":          data.Bool(v.Staleness.Divert),
+				"sources":         data.Bool(v.Staleness.Sources),
+				"sinks":           data.Bool(v.Staleness.Sinks),
+			}
 		}
+		m[k] = t
 	}
 	return m
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"testing"
 )
 
@@ -40,6 +41,340 @@ func TestTopologies(t *testing.T) {
 			})
 		})
 
+		Convey("When the config has resource limits", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"max_memory":1048576,"max_tuples":1000}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given limits", func() {
+				So(ts["test"].MaxMemory, ShouldEqual, 1048576)
+				So(ts["test"].MaxTuples, ShouldEqual, 1000)
+			})
+		})
+
+		Convey("When validating resource limits", func() {
+			for _, l := range []string{"max_memory", "max_tuples"} {
+				for _, b := range [][]interface{}{{"negative", -1}, {"invalid type", `"1"`}, {"float", 1.5}} {
+					Convey(fmt.Sprintf("Then it should reject %v value of %v", b[0], l), func() {
+						_, err := NewTopologies(toMap(fmt.Sprintf(`{"test":{"%v":%v}}`, l, b[1])))
+						So(err, ShouldNotBeNil)
+					})
+				}
+			}
+		})
+
+		Convey("When the config has a queue size", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"queue_size":64},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the given size", func() {
+				So(ts["test"].QueueSize, ShouldEqual, 64)
+			})
+
+			Convey("Then 0 should be used by default", func() {
+				So(ts["test2"].QueueSize, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the config has node settings", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"trace":true,"recovery":{"policy":"restart_node","max_restarts":3}},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given settings", func() {
+				So(*ts["test"].Trace, ShouldBeTrue)
+				So(*ts["test"].Recovery, ShouldResemble, Recovery{
+					Policy:      "restart_node",
+					MaxRestarts: 3,
+				})
+			})
+
+			Convey("Then settings should be inherited by default", func() {
+				So(ts["test2"].Trace, ShouldBeNil)
+				So(ts["test2"].Recovery, ShouldBeNil)
+			})
+
+			Convey("Then map should only have given settings", func() {
+				m := ts.ToMap()
+				So(m["test"].(data.Map)["trace"], ShouldEqual, data.True)
+				So(m["test"].(data.Map)["recovery"].(data.Map)["policy"], ShouldEqual, data.String("restart_node"))
+				So(m["test2"], ShouldNotContainKey, "trace")
+				So(m["test2"], ShouldNotContainKey, "recovery")
+			})
+		})
+
+		Convey("When validating a queue size", func() {
+			for _, b := range [][]interface{}{{"negative", -1}, {"too large", 131072}, {"float", 1.5}} {
+				Convey(fmt.Sprintf("Then it should reject %v value", b[0]), func() {
+					_, err := NewTopologies(toMap(fmt.Sprintf(`{"test":{"queue_size":%v}}`, b[1])))
+					So(err, ShouldNotBeNil)
+				})
+			}
+		})
+
+		Convey("When the config has an evaluation mode", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"evaluation_mode":"lenient"},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the given mode", func() {
+				So(ts["test"].EvaluationMode, ShouldEqual, "lenient")
+			})
+
+			Convey("Then the strict mode should be used by default", func() {
+				So(ts["test2"].EvaluationMode, ShouldEqual, "strict")
+			})
+
+			Convey("Then it should reject an unknown mode", func() {
+				_, err := NewTopologies(toMap(`{"test":{"evaluation_mode":"loose"}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config has a conversion policy", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"conversion_policy":"strict"},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the given policy", func() {
+				So(ts["test"].ConversionPolicy, ShouldEqual, "strict")
+			})
+
+			Convey("Then the permissive policy should be used by default", func() {
+				So(ts["test2"].ConversionPolicy, ShouldEqual, "permissive")
+			})
+
+			Convey("Then it should reject an unknown policy", func() {
+				_, err := NewTopologies(toMap(`{"test":{"conversion_policy":"loose"}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config enables the window arena", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"window_arena":true},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be enabled", func() {
+				So(ts["test"].WindowArena, ShouldBeTrue)
+			})
+
+			Convey("Then it should be disabled by default", func() {
+				So(ts["test2"].WindowArena, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the config shares windows", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"share_windows":true},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then windows should be shared", func() {
+				So(ts["test"].ShareWindows, ShouldBeTrue)
+				So(ts.ToMap()["test"].(data.Map)["share_windows"], ShouldEqual, data.True)
+			})
+
+			Convey("Then windows shouldn't be shared by default", func() {
+				So(ts["test2"].ShareWindows, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the config has a key TTL", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"key_ttl":1.5},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the TTL", func() {
+				So(ts["test"].KeyTTL, ShouldEqual, 1.5)
+			})
+
+			Convey("Then keys should be kept forever by default", func() {
+				So(ts["test2"].KeyTTL, ShouldEqual, 0)
+			})
+
+			Convey("Then it should reject a negative TTL", func() {
+				_, err := NewTopologies(toMap(`{"test":{"key_ttl":-1}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config has a sink flush interval", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"sink_flush_interval":0.5},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the interval", func() {
+				So(ts["test"].SinkFlushInterval, ShouldEqual, 0.5)
+			})
+
+			Convey("Then sinks shouldn't be flushed periodically by default", func() {
+				So(ts["test2"].SinkFlushInterval, ShouldEqual, 0)
+			})
+
+			Convey("Then it should reject a negative interval", func() {
+				_, err := NewTopologies(toMap(`{"test":{"sink_flush_interval":-1}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config has a sink readiness timeout", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"sink_readiness_timeout":0},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have the timeout", func() {
+				So(ts["test"].SinkReadinessTimeout, ShouldEqual, 0)
+			})
+
+			Convey("Then sources should wait for sinks by default", func() {
+				So(ts["test2"].SinkReadinessTimeout, ShouldEqual, DefaultSinkReadinessTimeout)
+			})
+
+			Convey("Then it should reject a negative timeout", func() {
+				_, err := NewTopologies(toMap(`{"test":{"sink_readiness_timeout":-1}}`))
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the config has scheduler hints", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"box_parallelism":"auto","nice":10,"node_nice":{"b1":19},"dedicated_thread_sinks":["s1","s2"]},"test2":{"box_parallelism":4},"test3":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(ts["test"].BoxParallelism, ShouldEqual, BoxParallelismAuto)
+				So(ts["test"].Nice, ShouldEqual, 10)
+				So(ts["test"].NodeNice, ShouldResemble, map[string]int{"b1": 19})
+				So(ts["test"].DedicatedThreadSinks, ShouldResemble, []string{"s1", "s2"})
+				So(ts["test2"].BoxParallelism, ShouldEqual, 4)
+			})
+
+			Convey("Then default values should be used when they're missing", func() {
+				So(ts["test3"].BoxParallelism, ShouldEqual, 1)
+				So(ts["test3"].Nice, ShouldEqual, 0)
+				So(ts["test3"].NodeNice, ShouldBeEmpty)
+				So(ts["test3"].DedicatedThreadSinks, ShouldBeEmpty)
+			})
+
+			Convey("Then it should reject invalid values", func() {
+				for _, c := range []string{
+					`{"test":{"box_parallelism":0}}`,
+					`{"test":{"box_parallelism":"many"}}`,
+					`{"test":{"nice":20}}`,
+					`{"test":{"nice":-1}}`,
+					`{"test":{"node_nice":{"b1":20}}}`,
+					`{"test":{"node_nice":{"b1":"high"}}}`,
+					`{"test":{"dedicated_thread_sinks":[""]}}`,
+				} {
+					_, err := NewTopologies(toMap(c))
+					So(err, ShouldNotBeNil)
+				}
+			})
+
+			Convey("Then ToMap should return \"auto\" for the automatic parallelism", func() {
+				m := ts.ToMap()
+				So(m["test"].(data.Map)["box_parallelism"], ShouldEqual, data.String("auto"))
+				So(m["test2"].(data.Map)["box_parallelism"], ShouldEqual, data.Int(4))
+			})
+		})
+
+		Convey("When the config has window spill parameters", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"window_spill":{"dir":"/tmp","memory_tuples":100}},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(ts["test"].WindowSpill, ShouldNotBeNil)
+				So(ts["test"].WindowSpill.Dir, ShouldEqual, "/tmp")
+				So(ts["test"].WindowSpill.MemoryTuples, ShouldEqual, 100)
+			})
+
+			Convey("Then spilling should be disabled when the parameter is missing", func() {
+				So(ts["test2"].WindowSpill, ShouldBeNil)
+			})
+
+			Convey("Then it should be converted to a map", func() {
+				m := ts.ToMap()
+				v, err := m.Get(data.MustCompilePath("test.window_spill.memory_tuples"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 100)
+			})
+		})
+
+		Convey("When the config enables the shared blob check", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"shared_blob_check":true},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should be enabled", func() {
+				So(ts["test"].SharedBlobCheck, ShouldBeTrue)
+			})
+
+			Convey("Then it should be disabled by default", func() {
+				So(ts["test2"].SharedBlobCheck, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the config has parallel aggregation parameters", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"parallel_aggregation":{"workers":4}},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				So(ts["test"].ParallelAggregation, ShouldNotBeNil)
+				So(ts["test"].ParallelAggregation.Workers, ShouldEqual, 4)
+				So(ts["test"].ParallelAggregation.MinGroups, ShouldEqual, 0)
+			})
+
+			Convey("Then parallel aggregation should be disabled when the parameter is missing", func() {
+				So(ts["test2"].ParallelAggregation, ShouldBeNil)
+			})
+
+			Convey("Then it should be converted to a map", func() {
+				m := ts.ToMap()
+				v, err := m.Get(data.MustCompilePath("test.parallel_aggregation.workers"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 4)
+			})
+		})
+
+		Convey("When the config has staleness parameters", func() {
+			ts, err := NewTopologies(toMap(`{"test":{"staleness":{"max_age":1.5,"timestamp_field":"ts","divert":true,"sinks":true}},"test2":{}}`))
+			So(err, ShouldBeNil)
+
+			Convey("Then it should have given parameters", func() {
+				st := ts["test"].Staleness
+				So(st, ShouldNotBeNil)
+				So(st.MaxAge, ShouldEqual, 1.5)
+				So(st.TimestampField, ShouldEqual, "ts")
+				So(st.Divert, ShouldBeTrue)
+				So(st.Sinks, ShouldBeTrue)
+			})
+
+			Convey("Then sources should be filtered by default", func() {
+				So(ts["test"].Staleness.Sources, ShouldBeTrue)
+			})
+
+			Convey("Then filtering should be disabled when the parameter is missing", func() {
+				So(ts["test2"].Staleness, ShouldBeNil)
+			})
+
+			Convey("Then it should be converted to a map", func() {
+				m := ts.ToMap()
+				v, err := m.Get(data.MustCompilePath("test.staleness.max_age"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 1.5)
+			})
+		})
+
+		Convey("When staleness has invalid parameters", func() {
+			for _, c := range []string{
+				`{}`,
+				`{"max_age":0}`,
+				`{"max_age":-1}`,
+				`{"max_age":"1h"}`,
+				`{"max_age":1,"age":1}`,
+			} {
+				_, err := NewTopologies(toMap(fmt.Sprintf(`{"test":{"staleness":%v}}`, c)))
+				So(err, ShouldNotBeNil)
+			}
+		})
+
+		Convey("When window_spill has an undefined field", func() {
+			_, err := NewTopologies(toMap(`{"test":{"window_spill":{"directory":"/tmp"}}}`))
+
+			Convey("Then it should be invalid", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
 		Convey("When validating bql_file", func() {
 			for _, b := range []string{"a", "test.bql", "/path/to/hoge.bql"} {
 				Convey(fmt.Sprint("Then it should accept ", b), func() {
@@ -6,6 +6,7 @@ import (
 	"github.com/gocraft/web"
 	"gopkg.in/pfnet/jasco.v1"
 	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/execution"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
 	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
@@ -14,6 +15,10 @@ import (
 	"gopkg.in/sensorbee/sensorbee.v0/server/udsstorage"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 )
 
 // Context is a context object for gocraft/web.
@@ -22,7 +27,12 @@ type Context struct {
 
 	udsStorage udf.UDSStorage
 	topologies TopologyRegistry
+	cursors    *cursorRegistry
+	tenants    map[string]*bql.Tenant
+	auth       Authenticator
+	secrets    bql.SecretProvider
 	config     *config.Config
+	reloader   *ConfigReloader
 	// logger is used by core.Context, not for the server's Context. This logger
 	// can be shared with jasco.Context.
 	logger *logrus.Logger
@@ -47,8 +57,31 @@ type ContextGlobalVariables struct {
 	// tenancy.
 	Topologies TopologyRegistry
 
-	// Config has configuration parameters.
+	// Tenants has tenants defined in the config, keyed by their names. It
+	// must not be modified after setting up the router.
+	Tenants map[string]*bql.Tenant
+
+	// Authenticator authenticates requests sent to the API. Authentication
+	// is disabled and all requests are allowed when it's nil. It's created
+	// from the config and can be replaced with a custom implementation.
+	Authenticator Authenticator
+
+	// Secrets provides secrets referred from parameters of BQL statements.
+	// References to secrets aren't substituted when it's nil. It's created
+	// from the config and can be replaced with a custom implementation.
+	Secrets bql.SecretProvider
+
+	// Config has configuration parameters used on startup.
 	Config *config.Config
+
+	// Reloader reloads the config at runtime. Set its Load field to enable
+	// reloading. The config cannot be reloaded when it's nil.
+	Reloader *ConfigReloader
+
+	// StandbyClient is the HTTP client used to replicate topologies from the
+	// primary server when the server runs as a standby. http.DefaultClient
+	// is used when it's nil.
+	StandbyClient *http.Client
 }
 
 // SetUpContextGlobalVariables create a new ContextGlobalVariables from a config.
@@ -69,13 +102,32 @@ func SetUpContextGlobalVariables(conf *config.Config) (*ContextGlobalVariables,
 		}
 	}()
 	logger.Out = w
+	lvl, err := logrus.ParseLevel(conf.Logging.MinLogLevel)
+	if err != nil {
+		return nil, err
+	}
+	logger.Level = lvl
+
+	tenants, err := setUpTenants(conf.Tenants)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := newAuthenticator(conf.Auth)
+	if err != nil {
+		return nil, err
+	}
+	secrets := newSecretProvider(conf.Secrets)
 
 	closeWriter = false
 	return &ContextGlobalVariables{
 		Logger:         logger,
 		LogDestination: w,
 		Topologies:     NewDefaultTopologyRegistry(),
+		Tenants:        tenants,
+		Authenticator:  auth,
+		Secrets:        secrets,
 		Config:         conf,
+		Reloader:       newConfigReloader(conf),
 	}, nil
 }
 
@@ -92,18 +144,50 @@ func SetUpContextAndRouter(prefix string, jascoRoot *web.Router, gvariables *Con
 	}
 
 	// Topologies should be created after setting up everything necessary for it.
-	if err := setUpTopologies(gvars.Logger, gvars.Topologies, gvars.Config, udsStorage); err != nil {
-		return nil, err
+	// A standby server creates them when it takes over the primary server.
+	if gvars.Config.Standby == nil {
+		if err := setUpTopologies(gvars.Logger, gvars.Topologies, gvars.Tenants, gvars.Secrets, gvars.Config, udsStorage, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		client := gvars.StandbyClient
+		if client == nil {
+			client = http.DefaultClient
+		}
+		gvars.Logger.WithField("primary", gvars.Config.Standby.Primary).Info("Starting as a standby server")
+		go (&standby{
+			logger:     gvars.Logger,
+			client:     client,
+			registry:   gvars.Topologies,
+			tenants:    gvars.Tenants,
+			secrets:    gvars.Secrets,
+			config:     gvars.Config,
+			udsStorage: udsStorage,
+		}).run()
+	}
+
+	if gvars.Reloader != nil {
+		gvars.Reloader.bind(gvars.Logger, gvars.Topologies, gvars.Tenants)
 	}
 
+	cursors := newCursorRegistry()
 	router := jascoRoot.Subrouter(Context{}, "/")
 	router.Middleware(func(c *Context, rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
 		c.logger = gvars.Logger
 		c.udsStorage = udsStorage
 		c.topologies = gvars.Topologies
+		c.cursors = cursors
+		c.tenants = gvars.Tenants
+		c.auth = gvars.Authenticator
+		c.secrets = gvars.Secrets
 		c.config = gvars.Config
+		c.reloader = gvars.Reloader
+		if c.reloader != nil {
+			c.config = c.reloader.Config()
+		}
 		next(rw, req)
 	})
+	setUpProbesRouter(prefix, router)
 	return router, nil
 }
 
@@ -124,7 +208,99 @@ func setUpUDSStorage(conf *config.UDSStorage) (udf.UDSStorage, error) {
 	}
 }
 
-func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, conf *config.Config, us udf.UDSStorage) error {
+func setUpTenants(conf config.Tenants) (map[string]*bql.Tenant, error) {
+	tenants := make(map[string]*bql.Tenant, len(conf))
+	for name, tc := range conf {
+		t, err := bql.NewTenant(name, &core.ResourceLimits{
+			MaxMemory: tc.MaxMemory,
+			MaxTuples: tc.MaxTuples,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tenants[name] = t
+	}
+	return tenants, nil
+}
+
+// newNodeDefaults converts node settings in the config to core.NodeSettings
+// given to topologies as their defaults.
+func newNodeDefaults(conf *config.NodeSettings) (*core.NodeSettings, error) {
+	return newNodeSettings(conf.QueueSize, conf.Trace, conf.Recovery)
+}
+
+// newTopologyNodeSettings converts node settings of the topology in the
+// config to core.NodeSettings.
+func newTopologyNodeSettings(conf *config.Topology) (*core.NodeSettings, error) {
+	return newNodeSettings(conf.QueueSize, conf.Trace, conf.Recovery)
+}
+
+func newNodeSettings(queueSize int, trace *bool, r *config.Recovery) (*core.NodeSettings, error) {
+	ns := &core.NodeSettings{
+		QueueSize: queueSize,
+		Trace:     trace,
+	}
+	if r != nil {
+		t, err := core.ParseRecoveryPolicyType(r.Policy)
+		if err != nil {
+			return nil, err
+		}
+		ns.Recovery = &core.RecoveryPolicy{
+			Type:           t,
+			MaxRestarts:    r.MaxRestarts,
+			InitialBackoff: time.Duration(r.InitialBackoff * float64(time.Second)),
+			MaxBackoff:     time.Duration(r.MaxBackoff * float64(time.Second)),
+			Replay:         r.Replay,
+			Recreate:       r.Recreate,
+			FatalCodes:     r.FatalCodes,
+		}
+	}
+	if err := ns.Validate(); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// newSecretProvider creates a SecretProvider from the config. It returns nil
+// when no provider is configured.
+func newSecretProvider(conf *config.Secrets) bql.SecretProvider {
+	if conf == nil {
+		return nil
+	}
+	var ps []bql.SecretProvider
+	if conf.Env != nil {
+		ps = append(ps, bql.NewEnvSecretProvider(conf.Env.Prefix))
+	}
+	if conf.File != nil {
+		ps = append(ps, bql.NewFileSecretProvider(conf.File.Dir))
+	}
+	if v := conf.Vault; v != nil {
+		token := v.Token
+		if token == "" {
+			token = os.Getenv("VAULT_TOKEN")
+		}
+		ps = append(ps, bql.NewVaultSecretProvider(nil, v.Address, token, v.Mount))
+	}
+	if len(ps) == 0 {
+		return nil
+	}
+	return bql.NewMultiSecretProvider(ps...)
+}
+
+// newTopologyBuilder creates a new TopologyBuilder of the topology. When the
+// tenant is nil, the builder uses global registries.
+func newTopologyBuilder(tenant *bql.Tenant, tp core.Topology) (*bql.TopologyBuilder, error) {
+	if tenant == nil {
+		return bql.NewTopologyBuilder(tp)
+	}
+	return tenant.NewTopologyBuilder(tp)
+}
+
+// setUpTopologies creates topologies in the config. offsets has offsets from
+// which sources of each topology start streams, keyed by names of topologies.
+// It can be nil.
+func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, tenants map[string]*bql.Tenant, secrets bql.SecretProvider,
+	conf *config.Config, us udf.UDSStorage, offsets map[string]map[string]int64) error {
 	stopAll := true
 	defer func() {
 		if stopAll {
@@ -147,7 +323,7 @@ func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, conf *config.Con
 
 	for name := range conf.Topologies {
 		logger.WithField("topology", name).Info("Setting up the topology")
-		tb, err := setUpTopology(logger, name, conf, us)
+		tb, err := setUpTopology(logger, name, tenants[bql.TenantNameOf(name)], secrets, conf, us, offsets[name])
 		if err != nil {
 			return err
 		}
@@ -164,18 +340,54 @@ func setUpTopologies(logger *logrus.Logger, r TopologyRegistry, conf *config.Con
 	return nil
 }
 
-func setUpTopology(logger *logrus.Logger, name string, conf *config.Config, us udf.UDSStorage) (*bql.TopologyBuilder, error) {
+func setUpTopology(logger *logrus.Logger, name string, tenant *bql.Tenant, secrets bql.SecretProvider,
+	conf *config.Config, us udf.UDSStorage, offsets map[string]int64) (*bql.TopologyBuilder, error) {
+	tc := conf.Topologies[name]
+	defaults, err := newNodeDefaults(conf.NodeDefaults)
+	if err != nil {
+		logger.WithField("err", err).Error("Invalid node defaults")
+		return nil, err
+	}
+	settings, err := newTopologyNodeSettings(tc)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"err":      err,
+			"topology": name,
+		}).Error("Invalid node settings of the topology")
+		return nil, err
+	}
 	cc := &core.ContextConfig{
-		Logger: logger,
+		Logger:           logger,
+		DefaultSettings:  defaults,
+		TopologySettings: settings,
+		ResourceLimits: &core.ResourceLimits{
+			MaxMemory: tc.MaxMemory,
+			MaxTuples: tc.MaxTuples,
+		},
+	}
+	if tenant != nil {
+		if tc.MaxMemory != 0 || tc.MaxTuples != 0 {
+			err := fmt.Errorf("the topology is owned by the tenant '%v' and cannot have its own resource limits",
+				tenant.Name())
+			logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": name,
+			}).Error("Invalid topology configuration")
+			return nil, err
+		}
+		cc.Resources = tenant.Resources
 	}
 	cc.Flags.DroppedTupleLog.Set(conf.Logging.LogDroppedTuples)
 	cc.Flags.DroppedTupleSummarization.Set(conf.Logging.SummarizeDroppedTuples)
+	cc.Flags.LenientEvaluation.Set(tc.EvaluationMode == "lenient")
+	cc.Flags.StrictConversion.Set(tc.ConversionPolicy == "strict")
+	cc.Flags.SharedBlobCheck.Set(tc.SharedBlobCheck)
 
 	tp, err := core.NewDefaultTopology(core.NewContext(cc), name)
 	if err != nil {
 		return nil, err
 	}
-	tb, err := bql.NewTopologyBuilder(tp)
+	tb, err := newTopologyBuilder(tenant, tp)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"err":      err,
@@ -184,8 +396,61 @@ func setUpTopology(logger *logrus.Logger, name string, conf *config.Config, us u
 		return nil, err
 	}
 	tb.UDSStorage = us
+	tb.Secrets = secrets
+	tb.SourceOffsets = offsets
+	tb.WindowArena = tc.WindowArena
+	tb.ShareWindows = tc.ShareWindows
+	tb.KeyTTL = time.Duration(tc.KeyTTL * float64(time.Second))
+	tb.SinkFlushInterval = time.Duration(tc.SinkFlushInterval * float64(time.Second))
+	tb.SinkReadinessTimeout = time.Duration(tc.SinkReadinessTimeout * float64(time.Second))
+	tb.BoxParallelism = tc.BoxParallelism
+	tb.BoxNice = tc.Nice
+	if len(tc.NodeNice) > 0 {
+		tb.NodeNice = make(map[string]int, len(tc.NodeNice))
+		for name, nice := range tc.NodeNice {
+			tb.NodeNice[strings.ToLower(name)] = nice
+		}
+	}
+	if len(tc.DedicatedThreadSinks) > 0 {
+		tb.DedicatedThreadSinks = make(map[string]bool, len(tc.DedicatedThreadSinks))
+		for _, s := range tc.DedicatedThreadSinks {
+			tb.DedicatedThreadSinks[strings.ToLower(s)] = true
+		}
+	}
+	if ws := tc.WindowSpill; ws != nil {
+		tb.WindowSpill = &execution.SpillConfig{
+			Dir:          ws.Dir,
+			MemoryTuples: ws.MemoryTuples,
+		}
+	}
+	if pa := tc.ParallelAggregation; pa != nil {
+		tb.ParallelAggregation = &execution.ParallelAggregation{
+			Workers:   pa.Workers,
+			MinGroups: pa.MinGroups,
+		}
+	}
+	if st := tc.Staleness; st != nil {
+		f := &core.StalenessFilter{
+			MaxAge:         time.Duration(st.MaxAge * float64(time.Second)),
+			TimestampField: st.TimestampField,
+			Divert:         st.Divert,
+		}
+		if err := f.Validate(); err != nil {
+			logger.WithFields(logrus.Fields{
+				"err":      err,
+				"topology": name,
+			}).Error("Invalid staleness configuration")
+			return nil, err
+		}
+		if st.Sources {
+			tb.SourceStaleness = f
+		}
+		if st.Sinks {
+			tb.SinkStaleness = f
+		}
+	}
 
-	bqlFilePath := conf.Topologies[name].BQLFile
+	bqlFilePath := tc.BQLFile
 	if bqlFilePath == "" {
 		return tb, nil
 	}
@@ -219,15 +484,18 @@ func setUpTopology(logger *logrus.Logger, name string, conf *config.Config, us u
 		return nil, err
 	}
 
-	for _, stmt := range stmts {
-		if _, err := tb.AddStmt(stmt); err != nil {
-			logger.WithFields(logrus.Fields{
-				"err":      err,
-				"topology": name,
-				"stmt":     stmt,
-			}).Error("Cannot add a statement to the topology")
-			return nil, err
+	// Sources start after all statements are applied and sinks are ready.
+	if err := tb.AddStartupStmts(stmts); err != nil {
+		fields := logrus.Fields{
+			"err":      err,
+			"topology": name,
 		}
+		if e, ok := err.(*bql.StmtError); ok {
+			fields["err"] = e.Err
+			fields["stmt"] = e.Stmt
+		}
+		logger.WithFields(fields).Error("Cannot add a statement to the topology")
+		return nil, err
 	}
 
 	shouldStop = false
@@ -0,0 +1,374 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/Sirupsen/logrus"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/parser"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCursorBufferSize = 1000
+	maxCursorBufferSize     = 100000
+	defaultCursorTimeout    = time.Minute
+	maxCursorTimeout        = time.Hour
+	defaultCursorFetchLimit = 100
+)
+
+// cursor buffers results of a SELECT statement issued through the API so
+// that a client can fetch them page by page. When the buffer is full, the
+// oldest result is dropped. The SELECT statement is stopped when the cursor
+// isn't accessed for the timeout.
+type cursor struct {
+	id       string
+	topology string
+	stmt     string
+	sink     core.SinkNode
+	timeout  time.Duration
+	timer    *time.Timer
+	capacity int
+
+	m       sync.Mutex
+	buf     []data.Map
+	dropped int64
+	done    bool
+}
+
+func (c *cursor) push(d data.Map) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if len(c.buf) >= c.capacity {
+		c.buf = c.buf[1:]
+		c.dropped++
+	}
+	c.buf = append(c.buf, d)
+}
+
+func (c *cursor) finish() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.done = true
+}
+
+// fetch removes at most limit results from the buffer and returns them. It
+// also returns the number of results dropped since the last fetch and
+// whether the SELECT statement has stopped and all results are fetched.
+func (c *cursor) fetch(limit int) ([]data.Map, int64, bool) {
+	c.timer.Reset(c.timeout)
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	if limit > len(c.buf) {
+		limit = len(c.buf)
+	}
+	res := make([]data.Map, limit)
+	copy(res, c.buf)
+	c.buf = c.buf[limit:]
+	dropped := c.dropped
+	c.dropped = 0
+	return res, dropped, c.done && len(c.buf) == 0
+}
+
+// cursorRegistry has cursors of all topologies.
+type cursorRegistry struct {
+	m       sync.Mutex
+	cursors map[string]*cursor
+}
+
+func newCursorRegistry() *cursorRegistry {
+	return &cursorRegistry{
+		cursors: map[string]*cursor{},
+	}
+}
+
+func (r *cursorRegistry) add(c *cursor) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.cursors[c.id] = c
+}
+
+func (r *cursorRegistry) lookup(topology, id string) (*cursor, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	c, ok := r.cursors[id]
+	if !ok || c.topology != topology {
+		return nil, false
+	}
+	return c, true
+}
+
+// remove removes the cursor from the registry and stops its SELECT
+// statement. It returns false when the registry doesn't have the cursor.
+func (r *cursorRegistry) remove(id string) (bool, error) {
+	r.m.Lock()
+	c, ok := r.cursors[id]
+	delete(r.cursors, id)
+	r.m.Unlock()
+	if !ok {
+		return false, nil
+	}
+	c.timer.Stop()
+	return true, c.sink.Stop()
+}
+
+func newCursorID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type cursors struct {
+	*topologies
+	cursor *cursor
+}
+
+func setUpCursorsRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(cursors{}, "/:topologyName/cursors")
+	root.Middleware((*cursors).fetchCursor)
+	root.Post("/", (*cursors).Create)
+	root.Get("/:cursorID", (*cursors).Show)
+	root.Delete("/:cursorID", (*cursors).Destroy)
+}
+
+// fetchCursor looks up the cursor given in the path. Because a cursor has
+// results of a SELECT statement, all actions require RoleOperator as issuing
+// the statement does.
+func (cc *cursors) fetchCursor(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !cc.authorize(cc.topologyName, RoleOperator) {
+		return
+	}
+	if cc.fetchTopology() == nil {
+		return
+	}
+
+	if id := cc.PathParams().String("cursorID", ""); id != "" {
+		c, ok := cc.cursors.lookup(cc.topologyName, id)
+		if !ok {
+			err := fmt.Errorf("cursor '%v' was not found", id)
+			cc.ErrLog(err).Error("Cannot find the cursor")
+			cc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+				"The cursor was not found", http.StatusNotFound, err))
+			return
+		}
+		cc.cursor = c
+		cc.AddLogField("cursor", id)
+	}
+	next(rw, req)
+}
+
+// Create issues a SELECT statement given in "queries" and returns a cursor
+// buffering its results. "buffer_size" is the maximum number of results
+// buffered and "timeout" is the duration after which the cursor is removed
+// when it isn't accessed.
+func (cc *cursors) Create(rw web.ResponseWriter, req *web.Request) {
+	var js map[string]interface{}
+	if apiErr := cc.ParseBody(&js); apiErr != nil {
+		cc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		cc.RenderError(apiErr)
+		return
+	}
+	form, err := data.NewMap(js)
+	if err != nil {
+		cc.ErrLog(err).WithField("body", js).Error("The request json may contain invalid value")
+		cc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	bufSize, timeout, meta := parseCursorParams(form)
+	if len(meta) != 0 {
+		cc.Log().WithField("body", js).Error("The request body has invalid cursor parameters")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		for k, v := range meta {
+			e.Meta[k] = v
+		}
+		cc.RenderError(e)
+		return
+	}
+
+	stmts, apiErr := cc.parseQueries(form)
+	if apiErr != nil {
+		cc.RenderError(apiErr)
+		return
+	}
+	var stmt parser.SelectUnionStmt
+	if len(stmts) == 1 {
+		switch s := stmts[0].(type) {
+		case parser.SelectStmt:
+			stmt = parser.SelectUnionStmt{Selects: []parser.SelectStmt{s}}
+		case parser.SelectUnionStmt:
+			stmt = s
+		}
+	}
+	if len(stmt.Selects) == 0 {
+		cc.Log().Error("A cursor can only be created from a single SELECT statement")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		e.Meta["queries"] = []string{"must be a single SELECT statement"}
+		cc.RenderError(e)
+		return
+	}
+	stmtStr := fmt.Sprint(stmts[0])
+
+	id, err := newCursorID()
+	if err != nil {
+		cc.ErrLog(err).Error("Cannot generate an ID of the cursor")
+		cc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+
+	sn, ch, err := cc.topology.AddSelectUnionStmt(&stmt)
+	if err != nil {
+		cc.ErrLog(err).Error("Cannot process a statement")
+		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
+		e.Meta["error"] = err.Error()
+		e.Meta["statement"] = stmtStr
+		cc.RenderError(e)
+		return
+	}
+
+	c := &cursor{
+		id:       id,
+		topology: cc.topologyName,
+		stmt:     stmtStr,
+		sink:     sn,
+		timeout:  timeout,
+		capacity: bufSize,
+	}
+	registry := cc.cursors
+	logger := cc.Log()
+	// The timer is reset after the cursor is added to the registry so that
+	// it doesn't expire before being added.
+	c.timer = time.AfterFunc(maxCursorTimeout, func() {
+		removed, err := registry.remove(id)
+		if err != nil {
+			logger.WithField("err", err).WithFields(logrus.Fields{
+				"node_type": core.NTSink,
+				"node_name": sn.Name(),
+			}).Error("Cannot stop the temporary sink of the expired cursor")
+		}
+		if removed {
+			logger.WithField("cursor", id).Info("The cursor expired")
+		}
+	})
+	go func() {
+		for t := range ch {
+			c.push(t.Data)
+		}
+		c.finish()
+	}()
+	cc.cursors.add(c)
+	c.timer.Reset(timeout)
+
+	cc.Log().WithField("cursor", id).WithField("statement", stmtStr).Info("Created a cursor")
+	cc.Render(map[string]interface{}{
+		"topology": cc.topologyName,
+		"cursor":   cursorInfo(c),
+	})
+}
+
+// Show returns results buffered in the cursor. The number of results is
+// limited by "limit" query parameter. Returned results are removed from the
+// cursor. The response has "dropped" which is the number of results dropped
+// since the last request because the buffer was full, and "done" which is
+// true when the SELECT statement has stopped and there're no more results.
+func (cc *cursors) Show(rw web.ResponseWriter, req *web.Request) {
+	limit := defaultCursorFetchLimit
+	if l := req.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			if err == nil {
+				err = fmt.Errorf("limit must be positive: %v", n)
+			}
+			cc.ErrLog(err).Error("Invalid limit")
+			e := jasco.NewError(formValidationErrorCode, "The request is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["limit"] = []string{"must be a positive integer"}
+			cc.RenderError(e)
+			return
+		}
+		limit = n
+	}
+
+	res, dropped, done := cc.cursor.fetch(limit)
+	cc.Render(map[string]interface{}{
+		"topology": cc.topologyName,
+		"cursor":   cursorInfo(cc.cursor),
+		"results":  res,
+		"dropped":  dropped,
+		"done":     done,
+	})
+}
+
+// Destroy removes the cursor and stops its SELECT statement.
+func (cc *cursors) Destroy(rw web.ResponseWriter, req *web.Request) {
+	if _, err := cc.cursors.remove(cc.cursor.id); err != nil {
+		cc.ErrLog(err).WithFields(logrus.Fields{
+			"node_type": core.NTSink,
+			"node_name": cc.cursor.sink.Name(),
+		}).Error("Cannot stop the temporary sink of the cursor")
+	}
+	cc.Log().Info("Removed the cursor")
+	cc.Render(map[string]interface{}{})
+}
+
+func cursorInfo(c *cursor) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          c.id,
+		"statement":   c.stmt,
+		"buffer_size": c.capacity,
+		"timeout":     c.timeout.String(),
+	}
+}
+
+// parseCursorParams returns the buffer size and the timeout of a cursor
+// given in the request body. It returns validation errors of each field as
+// the third return value.
+func parseCursorParams(form data.Map) (int, time.Duration, map[string][]string) {
+	bufSize, timeout := defaultCursorBufferSize, defaultCursorTimeout
+	meta := map[string][]string{}
+	if v, ok := form["buffer_size"]; ok {
+		n, err := data.ToInt(v)
+		switch {
+		case err != nil:
+			meta["buffer_size"] = []string{"value must be an integer"}
+		case n <= 0 || n > maxCursorBufferSize:
+			meta["buffer_size"] = []string{fmt.Sprintf("value must be in [1, %v]: %v", maxCursorBufferSize, n)}
+		default:
+			bufSize = int(n)
+		}
+	}
+
+	if v, ok := form["timeout"]; ok {
+		d, err := data.ToDuration(v)
+		switch {
+		case err != nil:
+			meta["timeout"] = []string{"value must be a number of seconds or a duration string"}
+		case d <= 0 || d > maxCursorTimeout:
+			meta["timeout"] = []string{fmt.Sprintf("value must be positive and at most %v", maxCursorTimeout)}
+		default:
+			timeout = d
+		}
+	}
+
+	for k := range form {
+		switch k {
+		case "queries", "buffer_size", "timeout":
+		default:
+			meta[k] = []string{"unknown field"}
+		}
+	}
+	return bufSize, timeout, meta
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"time"
+)
+
+// defaultNodeProfileSeconds is the default duration of profiling nodes. It's
+// the same as the default of /debug/pprof/profile.
+const defaultNodeProfileSeconds = 30
+
+// debug provides profiles of the server process. All actions require the
+// admin role of the server because profiles expose internals of the process
+// such as its command line.
+type debug struct {
+	*APIContext
+}
+
+func setUpDebugRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(debug{}, "/debug")
+	root.Middleware((*debug).checkPermission)
+	root.Get("/pprof", (*debug).Index)
+	root.Get("/pprof/:name", (*debug).Profile)
+	root.Post("/pprof/:name", (*debug).Profile)
+	root.Get("/node_profile", (*debug).NodeProfile)
+}
+
+func (d *debug) checkPermission(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !d.authorize("", RoleAdmin) {
+		return
+	}
+	next(rw, req)
+}
+
+// Index renders the index page of net/http/pprof.
+func (d *debug) Index(rw web.ResponseWriter, req *web.Request) {
+	pprof.Index(rw, req.Request)
+}
+
+// Profile serves the profile having the name in the same way as
+// /debug/pprof/name of net/http/pprof.
+func (d *debug) Profile(rw web.ResponseWriter, req *web.Request) {
+	switch name := req.PathParams["name"]; name {
+	case "cmdline":
+		pprof.Cmdline(rw, req.Request)
+	case "profile":
+		pprof.Profile(rw, req.Request)
+	case "symbol":
+		pprof.Symbol(rw, req.Request)
+	case "trace":
+		pprof.Trace(rw, req.Request)
+	default:
+		pprof.Handler(name).ServeHTTP(rw, req.Request)
+	}
+}
+
+// NodeProfile collects a CPU profile for the duration specified by "seconds"
+// query parameter and returns CPU time attributed to each node of all
+// topologies.
+func (d *debug) NodeProfile(rw web.ResponseWriter, req *web.Request) {
+	seconds := defaultNodeProfileSeconds
+	if s := req.URL.Query().Get("seconds"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			if err == nil {
+				err = fmt.Errorf("seconds must be positive: %v", n)
+			}
+			d.ErrLog(err).Error("Invalid seconds")
+			e := jasco.NewError(formValidationErrorCode, "The request is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["seconds"] = []string{"must be a positive integer"}
+			d.RenderError(e)
+			return
+		}
+		seconds = n
+	}
+
+	// Profiling is canceled when the client disconnects.
+	r, err := core.ProfileNodes(time.Duration(seconds)*time.Second, req.Request.Context().Done())
+	if err != nil {
+		d.ErrLog(err).Error("Cannot profile nodes")
+		d.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+
+	nodes := make([]map[string]interface{}, 0, len(r.Nodes))
+	for _, n := range r.Nodes {
+		nodes = append(nodes, map[string]interface{}{
+			"topology":          n.Topology,
+			"node_type":         n.NodeType.String(),
+			"name":              n.NodeName,
+			"cpu_seconds":       n.CPUTime.Seconds(),
+			"alloc_cpu_seconds": n.AllocCPUTime.Seconds(),
+			"goroutines":        n.Goroutines,
+		})
+	}
+	d.Render(map[string]interface{}{
+		"duration":                 r.Duration.Seconds(),
+		"total_cpu_seconds":        r.TotalCPUTime.Seconds(),
+		"unattributed_cpu_seconds": r.UnattributedCPUTime.Seconds(),
+		"nodes":                    nodes,
+	})
+}
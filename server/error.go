@@ -25,4 +25,20 @@ const (
 	// nonWebSocketRequestErrorCode is returned when a requested action only
 	// supports WebSocket and a request is a regular HTTP request.
 	nonWebSocketRequestErrorCode = "E0008"
+
+	// unknownTenantErrorCode is returned when a request specifies a tenant
+	// which isn't defined in the server.
+	unknownTenantErrorCode = "E0009"
+
+	// authenticationErrorCode is returned when a request doesn't have valid
+	// credentials while authentication is enabled.
+	authenticationErrorCode = "E0010"
+
+	// permissionDeniedErrorCode is returned when an authenticated principal
+	// doesn't have a role required by the request.
+	permissionDeniedErrorCode = "E0011"
+
+	// configReloadErrorCode is returned when the config cannot be reloaded,
+	// e.g. because the new config is invalid.
+	configReloadErrorCode = "E0012"
 )
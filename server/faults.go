@@ -0,0 +1,174 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net/http"
+	"strings"
+)
+
+type faults struct {
+	*topologies
+	nodeName string
+}
+
+func setUpFaultsRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(faults{}, "/:topologyName/faults")
+	root.Middleware((*faults).fetchNode)
+	root.Get("/", (*faults).Index)
+	root.Get("/:nodeName", (*faults).Show)
+	root.Put("/:nodeName", (*faults).Update)
+	root.Delete("/:nodeName", (*faults).Destroy)
+}
+
+// fetchNode looks up the node given in the path. Because faults make nodes
+// drop or duplicate tuples, all actions require RoleAdmin.
+func (fc *faults) fetchNode(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !fc.authorize(fc.topologyName, RoleAdmin) {
+		return
+	}
+	tb := fc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	if nodeName := fc.PathParams().String("nodeName", ""); nodeName != "" {
+		n, err := tb.Topology().Node(nodeName)
+		if err != nil {
+			fc.ErrLog(err).Error("Cannot find the node")
+			fc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+				"The node was not found", http.StatusNotFound, err))
+			return
+		}
+		fc.nodeName = n.Name()
+		fc.AddLogField("node_type", n.Type().String())
+		fc.AddLogField("node_name", n.Name())
+	}
+	next(rw, req)
+}
+
+// Index returns faults injected into nodes of the topology.
+func (fc *faults) Index(rw web.ResponseWriter, req *web.Request) {
+	fc.Render(map[string]interface{}{
+		"topology": fc.topologyName,
+		"faults":   fc.topology.Topology().Context().Faults.Status(),
+	})
+}
+
+// Show returns faults injected into the node.
+func (fc *faults) Show(rw web.ResponseWriter, req *web.Request) {
+	st := fc.topology.Topology().Context().Faults.Status()
+	f, ok := st[strings.ToLower(fc.nodeName)]
+	if !ok {
+		f = data.Map{}
+	}
+	fc.Render(map[string]interface{}{
+		"topology": fc.topologyName,
+		"node":     fc.nodeName,
+		"fault":    f,
+	})
+}
+
+// Update injects faults into the node. Faults previously injected into the
+// node are replaced.
+func (fc *faults) Update(rw web.ResponseWriter, req *web.Request) {
+	var js map[string]interface{}
+	if apiErr := fc.ParseBody(&js); apiErr != nil {
+		fc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		fc.RenderError(apiErr)
+		return
+	}
+	form, err := data.NewMap(js)
+	if err != nil {
+		fc.ErrLog(err).WithField("body", js).Error("The request json may contain invalid value")
+		fc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	f, meta := parseFaultInjection(form)
+	if len(meta) != 0 {
+		fc.Log().WithField("body", js).Error("The request body has invalid fault parameters")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		for k, v := range meta {
+			e.Meta[k] = v
+		}
+		fc.RenderError(e)
+		return
+	}
+
+	if err := fc.topology.Topology().Context().Faults.Set(fc.nodeName, f); err != nil {
+		fc.ErrLog(err).Error("Cannot inject faults into the node")
+		fc.RenderError(jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, err))
+		return
+	}
+	fc.Log().Info("Faults are injected into the node")
+	fc.Show(rw, req)
+}
+
+// Destroy removes faults injected into the node. It doesn't return 404 when
+// the node doesn't have faults.
+func (fc *faults) Destroy(rw web.ResponseWriter, req *web.Request) {
+	if fc.topology.Topology().Context().Faults.Remove(fc.nodeName) {
+		fc.Log().Info("Faults are removed from the node")
+	}
+	fc.Render(map[string]interface{}{})
+}
+
+// parseFaultInjection creates a core.FaultInjection from the request body.
+// It returns validation errors of each field as the second return value.
+func parseFaultInjection(form data.Map) (*core.FaultInjection, map[string][]string) {
+	f := &core.FaultInjection{}
+	meta := map[string][]string{}
+	rates := []struct {
+		name string
+		v    *float64
+	}{
+		{"error_rate", &f.ErrorRate},
+		{"panic_rate", &f.PanicRate},
+		{"latency_rate", &f.LatencyRate},
+		{"duplicate_rate", &f.DuplicateRate},
+	}
+	for _, r := range rates {
+		v, ok := form[r.name]
+		if !ok {
+			continue
+		}
+		x, err := data.ToFloat(v)
+		if err != nil {
+			meta[r.name] = []string{"value must be a number"}
+			continue
+		}
+		if x < 0 || x > 1 {
+			meta[r.name] = []string{fmt.Sprintf("value must be in [0, 1]: %v", x)}
+			continue
+		}
+		*r.v = x
+	}
+
+	if v, ok := form["latency"]; ok {
+		d, err := data.ToDuration(v)
+		switch {
+		case err != nil:
+			meta["latency"] = []string{"value must be a number of seconds or a duration string"}
+		case d < 0:
+			meta["latency"] = []string{"value must not be negative"}
+		default:
+			f.Latency = d
+		}
+	}
+
+	for k := range form {
+		switch k {
+		case "error_rate", "panic_rate", "latency", "latency_rate", "duplicate_rate":
+		default:
+			meta[k] = []string{"unknown field"}
+		}
+	}
+	return f, meta
+}
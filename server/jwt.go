@@ -0,0 +1,125 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type jwtAuthenticator struct {
+	secret []byte
+	issuer string
+	now    func() time.Time
+}
+
+// NewJWTAuthenticator returns an Authenticator accepting JSON Web Tokens
+// signed with HS256 and given by the Authorization header as a bearer token.
+// When issuer isn't empty, "iss" claim of a token must be equal to it.
+//
+// In addition to the registered claims "sub", "iss", "exp", and "nbf", a
+// token can have the following private claims:
+//
+//	tenant: the name of the tenant as which the principal acts
+//	roles: an object having roles keyed by topology names like
+//	       {"*": "read_only", "some_topology": "admin"}
+func NewJWTAuthenticator(secret []byte, issuer string) Authenticator {
+	return &jwtAuthenticator{
+		secret: secret,
+		issuer: issuer,
+		now:    time.Now,
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type jwtClaims struct {
+	Sub    string            `json:"sub"`
+	Iss    string            `json:"iss"`
+	Exp    *int64            `json:"exp"`
+	Nbf    *int64            `json:"nbf"`
+	Tenant string            `json:"tenant"`
+	Roles  map[string]string `json:"roles"`
+}
+
+func (a *jwtAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	auth := req.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return nil, ErrNoCredentials
+	}
+	token := strings.TrimSpace(auth[len(prefix):])
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the token is malformed")
+	}
+
+	var h jwtHeader
+	if err := decodeJWTPart(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("the header of the token is malformed: %v", err)
+	}
+	// Only HS256 is supported. Other algorithms, especially "none", must
+	// not be accepted.
+	if h.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported signing algorithm: %v", h.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("the signature of the token is malformed")
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, errors.New("the signature of the token is invalid")
+	}
+
+	var c jwtClaims
+	if err := decodeJWTPart(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("the claims of the token are malformed: %v", err)
+	}
+	now := a.now().Unix()
+	if c.Exp != nil && now >= *c.Exp {
+		return nil, errors.New("the token has expired")
+	}
+	if c.Nbf != nil && now < *c.Nbf {
+		return nil, errors.New("the token isn't valid yet")
+	}
+	if a.issuer != "" && c.Iss != a.issuer {
+		return nil, fmt.Errorf("the token has an unexpected issuer: %v", c.Iss)
+	}
+	if c.Sub == "" {
+		return nil, errors.New("the token doesn't have 'sub' claim")
+	}
+
+	p := &Principal{
+		Name:   c.Sub,
+		Tenant: c.Tenant,
+		Roles:  make(map[string]Role, len(c.Roles)),
+	}
+	for t, r := range c.Roles {
+		role, err := ParseRole(r)
+		if err != nil {
+			return nil, err
+		}
+		p.Roles[t] = role
+	}
+	return p, nil
+}
+
+func decodeJWTPart(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
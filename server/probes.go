@@ -0,0 +1,128 @@
+package server
+
+import (
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"net/http"
+	"sort"
+)
+
+// probes provides liveness and readiness probes used by process managers
+// such as Kubernetes. They don't require authentication.
+type probes struct {
+	*Context
+}
+
+func setUpProbesRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(probes{}, "")
+	root.Get("/healthz", (*probes).Healthz)
+	root.Get("/readyz", (*probes).Readyz)
+}
+
+// Healthz returns 200 while the process is up.
+func (p *probes) Healthz(rw web.ResponseWriter, req *web.Request) {
+	p.Render(map[string]interface{}{
+		"status": "ok",
+	})
+}
+
+// Readyz returns 200 when all topologies are running, all sources are
+// running, paused, or stopped, and all boxes and sinks are running. Sources
+// and sinks implementing core.HealthChecker also need to pass their checks.
+// Otherwise, it returns 503. Because probes don't require authentication,
+// the response only has the detail of each node when authentication is
+// disabled. Otherwise, the detail is only written to the log so that names
+// and errors of topologies aren't exposed to unauthenticated clients.
+func (p *probes) Readyz(rw web.ResponseWriter, req *web.Request) {
+	tbs, err := p.topologies.List()
+	if err != nil {
+		p.ErrLog(err).Error("Cannot list topologies")
+		p.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+
+	names := make([]string, 0, len(tbs))
+	for name := range tbs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ready := true
+	tps := make(map[string]interface{}, len(tbs))
+	for _, name := range names {
+		r, st := topologyReadiness(tbs[name].Topology())
+		if !r {
+			ready = false
+		}
+		tps[name] = st
+	}
+
+	res := map[string]interface{}{
+		"ready": ready,
+	}
+	if p.auth == nil {
+		res["topologies"] = tps
+	}
+	if !ready {
+		p.Log().WithField("topologies", tps).Warn("The server isn't ready")
+		// Headers can't be changed after the status is written, so
+		// Content-Type is set before Render writes the body.
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}
+	p.Render(res)
+}
+
+// topologyReadiness returns whether the topology is ready and the detail of
+// its nodes.
+func topologyReadiness(tp core.Topology) (bool, map[string]interface{}) {
+	state := tp.State().Get()
+	ready := state == core.TSRunning
+	nodes := map[string]interface{}{}
+	for name, n := range tp.Nodes() {
+		r, st := nodeReadiness(tp.Context(), n)
+		if !r {
+			ready = false
+		}
+		nodes[name] = st
+	}
+	return ready, map[string]interface{}{
+		"ready": ready,
+		"state": state.String(),
+		"nodes": nodes,
+	}
+}
+
+// nodeReadiness returns whether the node is ready and its detail.
+func nodeReadiness(ctx *core.Context, n core.Node) (bool, map[string]interface{}) {
+	state := n.State().Get()
+	st := map[string]interface{}{
+		"node_type": n.Type().String(),
+		"state":     state.String(),
+	}
+
+	ready := state == core.TSRunning
+	var checker interface{}
+	switch n := n.(type) {
+	case core.SourceNode:
+		if state == core.TSStopped {
+			// a finite source stops after emitting all tuples, and
+			// its connection doesn't have to be checked anymore
+			st["ready"] = true
+			return true, st
+		}
+		ready = ready || state == core.TSPaused
+		checker = n.Source()
+	case core.SinkNode:
+		checker = n.Sink()
+	}
+	if h, ok := checker.(core.HealthChecker); ok && ready {
+		if err := h.CheckHealth(ctx); err != nil {
+			ready = false
+			st["error"] = err.Error()
+		}
+	}
+	st["ready"] = ready
+	return ready, st
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"strings"
+	"sync"
+)
+
+// ConfigReloader reloads the config of the server at runtime. Changes which
+// can safely be applied to the running server, such as the log level and
+// resource limits, are applied immediately. Other changes are reported as
+// requiring restart and aren't applied.
+type ConfigReloader struct {
+	// Load loads the new config, e.g. by reading the config file again.
+	// Reload fails when it's nil.
+	Load func() (*config.Config, error)
+
+	m          sync.RWMutex
+	startup    *config.Config
+	current    *config.Config
+	logger     *logrus.Logger
+	topologies TopologyRegistry
+	tenants    map[string]*bql.Tenant
+}
+
+// ReloadResult is the result of ConfigReloader.Reload.
+type ReloadResult struct {
+	// Applied has paths of parameters applied to the running server, e.g.
+	// "logging.min_log_level".
+	Applied []string `json:"applied"`
+
+	// RequiresRestart has paths of parameters which differ from the config
+	// used on startup but cannot be applied without restarting the server.
+	RequiresRestart []string `json:"requires_restart"`
+}
+
+func newConfigReloader(conf *config.Config) *ConfigReloader {
+	return &ConfigReloader{
+		startup: conf,
+		current: conf,
+	}
+}
+
+// bind sets the logger and registries to which the config is applied. It's
+// called when setting up the router because the caller can replace them in
+// ContextGlobalVariables.
+func (r *ConfigReloader) bind(logger *logrus.Logger, t TopologyRegistry, tenants map[string]*bql.Tenant) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.logger = logger
+	r.topologies = t
+	r.tenants = tenants
+}
+
+// Config returns the config currently applied. Parameters requiring restart
+// may have values different from the ones the server actually uses.
+func (r *ConfigReloader) Config() *config.Config {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.current
+}
+
+// Reload loads a new config by Load and applies changes which don't require
+// restart. Nothing is applied when loading the config fails.
+func (r *ConfigReloader) Reload() (*ReloadResult, error) {
+	if r.Load == nil {
+		return nil, errors.New("the config cannot be reloaded because it isn't loaded from a file")
+	}
+	conf, err := r.Load()
+	if err != nil {
+		return nil, err
+	}
+	lvl, err := logrus.ParseLevel(conf.Logging.MinLogLevel)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := newNodeDefaults(conf.NodeDefaults)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]*core.NodeSettings, len(conf.Topologies))
+	for name, tc := range conf.Topologies {
+		ns, err := newTopologyNodeSettings(tc)
+		if err != nil {
+			return nil, fmt.Errorf("topology '%v': %v", name, err)
+		}
+		settings[name] = ns
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.topologies == nil {
+		return nil, errors.New("the server isn't set up yet")
+	}
+	tbs, err := r.topologies.List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list topologies: %v", err)
+	}
+	res := &ReloadResult{
+		Applied:         []string{},
+		RequiresRestart: []string{},
+	}
+	for _, c := range config.Diff(r.current, conf) {
+		if c.Reloadable {
+			res.Applied = append(res.Applied, c.Path)
+		}
+	}
+	changed := func(path string) bool {
+		for _, p := range res.Applied {
+			if p == path || strings.HasPrefix(p, path+".") {
+				return true
+			}
+		}
+		return false
+	}
+	// Restart-requiring changes are compared with the startup config so that
+	// they're reported until the server restarts.
+	for _, c := range config.Diff(r.startup, conf) {
+		if !c.Reloadable {
+			res.RequiresRestart = append(res.RequiresRestart, c.Path)
+		}
+	}
+
+	r.logger.Level = lvl
+	for name, tc := range conf.Tenants {
+		if t, ok := r.tenants[name]; ok {
+			t.Resources.SetLimits(core.ResourceLimits{
+				MaxMemory: tc.MaxMemory,
+				MaxTuples: tc.MaxTuples,
+			})
+		}
+	}
+
+	for name, tb := range tbs {
+		ctx := tb.Topology().Context()
+		ctx.Flags.DroppedTupleLog.Set(conf.Logging.LogDroppedTuples)
+		ctx.Flags.DroppedTupleSummarization.Set(conf.Logging.SummarizeDroppedTuples)
+		ctx.Settings.SetDefaults(defaults) // already validated
+
+		// Only topologies defined in the config are affected by their
+		// parameters. Topologies created by the API keep theirs.
+		tc, ok := conf.Topologies[name]
+		if !ok {
+			continue
+		}
+
+		// Only changed parameters are applied to the layer of the topology
+		// because they can also be changed by BQL statements.
+		ns := ctx.Settings.Topology()
+		p := "topologies." + name
+		if changed(p + ".queue_size") {
+			ns.QueueSize = settings[name].QueueSize
+		}
+		if changed(p + ".trace") {
+			ns.Trace = settings[name].Trace
+		}
+		if changed(p + ".recovery") {
+			ns.Recovery = settings[name].Recovery
+		}
+		ctx.Settings.SetTopology(&ns) // already validated
+		if _, ok := r.tenants[bql.TenantNameOf(name)]; !ok {
+			ctx.Resources.SetLimits(core.ResourceLimits{
+				MaxMemory: tc.MaxMemory,
+				MaxTuples: tc.MaxTuples,
+			})
+		}
+	}
+	r.current = conf
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bytes"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+)
+
+// replicatedState is a snapshot of a savable state replicated to a standby
+// server.
+type replicatedState struct {
+	// Type is the type name of the state.
+	Type string `json:"type"`
+
+	// Data has the data written by core.SavableSharedState.Save. It's
+	// encoded in base64 in JSON.
+	Data []byte `json:"data"`
+}
+
+// replicationSnapshot is a snapshot of a topology replicated to a standby
+// server.
+type replicationSnapshot struct {
+	Topology string `json:"topology"`
+
+	// States has snapshots of savable states keyed by their names. States
+	// which cannot be saved aren't replicated.
+	States map[string]*replicatedState `json:"states"`
+
+	// Offsets has offsets of sources committed by all sinks. Sources which
+	// have no committed offset aren't included.
+	Offsets map[string]int64 `json:"offsets"`
+}
+
+// newReplicationSnapshot takes a snapshot of the topology.
+func newReplicationSnapshot(name string, tb *bql.TopologyBuilder) (*replicationSnapshot, error) {
+	ctx := tb.Topology().Context()
+	ss := &replicationSnapshot{
+		Topology: name,
+		States:   map[string]*replicatedState{},
+		Offsets:  map[string]int64{},
+	}
+
+	states, err := ctx.SharedStates.List()
+	if err != nil {
+		return nil, err
+	}
+	for n, s := range states {
+		sv, ok := s.(core.SavableSharedState)
+		if !ok {
+			continue
+		}
+		typeName, err := ctx.SharedStates.Type(n)
+		if err != nil {
+			if core.IsNotExist(err) {
+				continue // the state was removed after listing states
+			}
+			return nil, err
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := sv.Save(ctx, buf, data.Map{}); err != nil {
+			return nil, err
+		}
+		ss.States[n] = &replicatedState{
+			Type: typeName,
+			Data: buf.Bytes(),
+		}
+	}
+
+	for n := range tb.Topology().Sources() {
+		if off, ok := ctx.Offsets.Committed(n); ok {
+			ss.Offsets[n] = off
+		}
+	}
+	return ss, nil
+}
+
+// Replication returns a snapshot of savable states and committed offsets of
+// sources of the topology. It's used by standby servers.
+func (tc *topologies) Replication(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+	ss, err := newReplicationSnapshot(tc.topologyName, tb)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot take a snapshot of the topology")
+		tc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	tc.Render(map[string]interface{}{
+		"topology": ss.Topology,
+		"states":   ss.States,
+		"offsets":  ss.Offsets,
+	})
+}
@@ -2,18 +2,42 @@ package response
 
 import (
 	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 )
 
 // Topology is a part of the response which topologies.show action returns.
 type Topology struct {
 	// Name is the name of the topology.
 	Name string `json:"name"`
+
+	// LogLevel is the log level of the topology.
+	LogLevel string `json:"log_level"`
+
+	// NodeLogLevels has log levels of nodes which have their own levels.
+	NodeLogLevels map[string]string `json:"node_log_levels"`
+
+	// TupleTrace is true when tuples in the topology are traced. Nodes can
+	// have their own settings in Settings.
+	TupleTrace bool `json:"tuple_trace"`
+
+	// Settings has layers of settings of nodes in the topology. See
+	// core.Settings.Status for details.
+	Settings data.Map `json:"settings"`
 }
 
 // NewTopology creates a new response of a topology.
 func NewTopology(t core.Topology) *Topology {
+	ctx := t.Context()
+	nodeLevels := map[string]string{}
+	for name, lvl := range ctx.LogLevels.NodeLevels() {
+		nodeLevels[name] = lvl.String()
+	}
 	return &Topology{
-		Name: t.Name(),
+		Name:          t.Name(),
+		LogLevel:      ctx.LogLevels.Level().String(),
+		NodeLogLevels: nodeLevels,
+		TupleTrace:    ctx.Traced(""),
+		Settings:      ctx.Settings.Status(),
 	}
 }
 
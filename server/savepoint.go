@@ -0,0 +1,88 @@
+package server
+
+import (
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net/http"
+	"time"
+)
+
+// defaultSavepointTimeout is the default duration TakeSavepoint waits for the
+// topology to process tuples in pipes.
+const defaultSavepointTimeout = 10 * time.Second
+
+// TakeSavepoint pauses sources of the topology and returns a savepoint of
+// it. The timeout of waiting for the topology to become idle can be given by
+// the "timeout" query parameter as a number of seconds or a duration string.
+func (tc *topologies) TakeSavepoint(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	timeout := defaultSavepointTimeout
+	if v := req.URL.Query().Get("timeout"); v != "" {
+		d, err := data.ToDuration(data.String(v))
+		if err != nil || d <= 0 {
+			tc.Log().WithField("timeout", v).Error("Invalid timeout")
+			e := jasco.NewError(formValidationErrorCode, "The request is invalid.",
+				http.StatusBadRequest, err)
+			e.Meta["timeout"] = []string{"value must be a positive number of seconds or a duration string"}
+			tc.RenderError(e)
+			return
+		}
+		timeout = d
+	}
+
+	sp, err := tb.TakeSavepoint(timeout)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot take a savepoint of the topology")
+		tc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
+	tc.Log().Info("A savepoint of the topology is taken")
+	tc.Render(map[string]interface{}{
+		"topology":  tc.topologyName,
+		"savepoint": sp,
+	})
+}
+
+// RestoreSavepoint restores the topology from the savepoint given in the
+// request body. The topology should be empty.
+func (tc *topologies) RestoreSavepoint(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	var body struct {
+		Savepoint *bql.Savepoint `json:"savepoint"`
+	}
+	if apiErr := tc.ParseBody(&body); apiErr != nil {
+		tc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		tc.RenderError(apiErr)
+		return
+	}
+	if body.Savepoint == nil {
+		tc.Log().Error("The request body doesn't have a savepoint")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		e.Meta["savepoint"] = []string{"field is missing"}
+		tc.RenderError(e)
+		return
+	}
+
+	if err := tb.RestoreSavepoint(body.Savepoint); err != nil {
+		tc.ErrLog(err).Error("Cannot restore the topology from the savepoint")
+		tc.RenderError(jasco.NewError(bqlStmtProcessingErrorCode, "Cannot restore the savepoint",
+			http.StatusBadRequest, err))
+		return
+	}
+	tc.Log().WithField("savepoint_topology", body.Savepoint.Topology).
+		Info("The topology is restored from the savepoint")
+	tc.Render(map[string]interface{}{
+		"topology": tc.topologyName,
+	})
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"net/http"
+)
+
+// serverConfig manages the config of the server. All actions require the
+// admin role of the server.
+type serverConfig struct {
+	*APIContext
+}
+
+func setUpServerConfigRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(serverConfig{}, "/config")
+	root.Middleware((*serverConfig).checkPermission)
+	root.Post("/reload", (*serverConfig).Reload)
+}
+
+func (sc *serverConfig) checkPermission(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !sc.authorize("", RoleAdmin) {
+		return
+	}
+	next(rw, req)
+}
+
+// Reload reloads the config of the server. It applies changes which don't
+// require restart and reports ones which do.
+func (sc *serverConfig) Reload(rw web.ResponseWriter, req *web.Request) {
+	if sc.reloader == nil || sc.reloader.Load == nil {
+		sc.Log().Error("The config cannot be reloaded")
+		sc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode, "The config cannot be reloaded",
+			http.StatusNotFound, nil))
+		return
+	}
+
+	res, err := sc.reloader.Reload()
+	if err != nil {
+		sc.ErrLog(err).Error("Cannot reload the config")
+		sc.RenderError(jasco.NewError(configReloadErrorCode, "Cannot reload the config",
+			http.StatusBadRequest, err))
+		return
+	}
+	sc.Log().WithFields(logrus.Fields{
+		"applied":          res.Applied,
+		"requires_restart": res.RequiresRestart,
+	}).Info("The config is reloaded")
+	sc.Render(res)
+}
@@ -20,9 +20,17 @@ type serverStatus struct {
 
 func setUpServerStatusRouter(prefix string, router *web.Router) {
 	root := router.Subrouter(serverStatus{}, "")
+	root.Middleware((*serverStatus).checkPermission)
 	root.Get("/runtime_status", (*serverStatus).RuntimeStatus)
 }
 
+func (ss *serverStatus) checkPermission(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !ss.authorize("", RoleReadOnly) {
+		return
+	}
+	next(rw, req)
+}
+
 func (ss *serverStatus) RuntimeStatus(rw web.ResponseWriter, req *web.Request) {
 	res := map[string]interface{}{
 		"num_goroutine": runtime.NumGoroutine(),
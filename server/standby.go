@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Sirupsen/logrus"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
+	"gopkg.in/sensorbee/sensorbee.v0/bql/udf"
+	"gopkg.in/sensorbee/sensorbee.v0/server/config"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// standby replicates topologies from the primary server and takes over it
+// when replication fails more than the threshold in a row.
+//
+// States are saved into the UDS storage of the standby server with the
+// default tag. Therefore, BQL files of topologies should load them with
+// LOAD STATE ... OR CREATE IF NOT SAVED so that topologies created on
+// takeover start with the replicated states. Sources start from the offsets
+// next to the ones committed on the primary server when they support
+// offsets. Only topologies in the config are replicated.
+type standby struct {
+	logger     *logrus.Logger
+	client     *http.Client
+	registry   TopologyRegistry
+	tenants    map[string]*bql.Tenant
+	secrets    bql.SecretProvider
+	config     *config.Config
+	udsStorage udf.UDSStorage
+
+	// offsets has offsets of sources from which topologies are resumed on
+	// takeover. The key of the outer map is the name of a topology and the
+	// key of the inner map is the name of a source in lower case.
+	offsets map[string]map[string]int64
+}
+
+func (s *standby) run() {
+	conf := s.config.Standby
+	interval := time.Duration(conf.Interval * float64(time.Second))
+	failures := 0
+	for {
+		time.Sleep(interval)
+		if err := s.replicate(); err != nil {
+			failures++
+			s.logger.WithFields(logrus.Fields{
+				"err":      err,
+				"primary":  conf.Primary,
+				"failures": failures,
+			}).Warn("Cannot replicate topologies from the primary server")
+			if failures >= conf.FailureThreshold {
+				break
+			}
+			continue
+		}
+		failures = 0
+	}
+
+	s.logger.WithField("primary", conf.Primary).Warn("Taking over the primary server")
+	if err := setUpTopologies(s.logger, s.registry, s.tenants, s.secrets, s.config, s.udsStorage, s.offsets); err != nil {
+		s.logger.WithField("err", err).Error("Cannot take over the primary server")
+		return
+	}
+	s.logger.Info("Took over the primary server")
+}
+
+// replicate replicates all topologies in the config. Offsets are only updated
+// when all topologies are successfully replicated so that they're consistent.
+func (s *standby) replicate() error {
+	offsets := make(map[string]map[string]int64, len(s.config.Topologies))
+	for name := range s.config.Topologies {
+		ss, err := s.fetchSnapshot(name)
+		if err != nil {
+			return err
+		}
+		for st, rs := range ss.States {
+			if err := s.saveState(name, st, rs); err != nil {
+				return err
+			}
+		}
+		so := make(map[string]int64, len(ss.Offsets))
+		for src, off := range ss.Offsets {
+			so[strings.ToLower(src)] = off + 1
+		}
+		offsets[name] = so
+	}
+	s.offsets = offsets
+	return nil
+}
+
+func (s *standby) fetchSnapshot(topology string) (*replicationSnapshot, error) {
+	u := fmt.Sprintf("%v/api/v1/topologies/%v/replication",
+		strings.TrimSuffix(s.config.Standby.Primary, "/"), url.QueryEscape(topology))
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if key := s.config.Standby.APIKey; key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the primary server returned an error on topology '%v': %v",
+			topology, res.Status)
+	}
+
+	ss := &replicationSnapshot{}
+	if err := json.NewDecoder(res.Body).Decode(ss); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+func (s *standby) saveState(topology, name string, rs *replicatedState) error {
+	w, err := s.udsStorage.Save(topology, name, "")
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(rs.Data); err != nil {
+		if e := w.Abort(); e != nil {
+			s.logger.WithFields(logrus.Fields{
+				"err":        e,
+				"topology":   topology,
+				"state_name": name,
+			}).Error("Cannot abort saving the replicated state")
+		}
+		return err
+	}
+	return w.Commit()
+}
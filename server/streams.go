@@ -1,9 +1,12 @@
 package server
 
 import (
+	"fmt"
 	"github.com/gocraft/web"
 	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/bql"
 	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
 	"gopkg.in/sensorbee/sensorbee.v0/server/response"
 	"net/http"
 )
@@ -18,6 +21,7 @@ func setUpStreamsRouter(prefix string, router *web.Router) {
 	root.Middleware((*streams).fetchStream)
 	root.Get("/", (*streams).Index)
 	root.Get("/:streamName", (*streams).Show)
+	root.Get("/:streamName/windows", (*streams).Windows)
 }
 
 func (sc *streams) fetchStream(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
@@ -63,5 +67,27 @@ func (sc *streams) Show(rw web.ResponseWriter, req *web.Request) {
 	})
 }
 
+// Windows returns states of windows of the stream so that users can diagnose
+// why a statement having windows behaves unexpectedly.
+func (sc *streams) Windows(rw web.ResponseWriter, req *web.Request) {
+	states, ok := bql.WindowStates(sc.stream.Box())
+	if !ok {
+		err := fmt.Errorf("stream '%v' doesn't have windows", sc.stream.Name())
+		sc.ErrLog(err).Error("Cannot get states of windows")
+		sc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+			"The stream doesn't have windows", http.StatusNotFound, err))
+		return
+	}
+	ws := make(data.Map, len(states))
+	for alias, st := range states {
+		ws[alias] = st.Map()
+	}
+	sc.Render(map[string]interface{}{
+		"topology": sc.topologyName,
+		"stream":   sc.stream.Name(),
+		"windows":  ws,
+	})
+}
+
 // TODO: Support Update(e.g. pause/resume) and Destroy if necessary. They can be
 // done by queries.
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gocraft/web"
+	"gopkg.in/pfnet/jasco.v1"
+	"gopkg.in/sensorbee/sensorbee.v0/core"
+	"gopkg.in/sensorbee/sensorbee.v0/data"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type taps struct {
+	*topologies
+	tap *core.EdgeTap
+}
+
+func setUpTapsRouter(prefix string, router *web.Router) {
+	root := router.Subrouter(taps{}, "/:topologyName/taps")
+	root.Middleware((*taps).fetchTap)
+	root.Get("/", (*taps).Index)
+	root.Post("/", (*taps).Create)
+	root.Get("/:tapID", (*taps).Show)
+	root.Delete("/:tapID", (*taps).Destroy)
+}
+
+// fetchTap looks up the tap given in the path. Because taps expose tuples
+// flowing in the topology, all actions require RoleAdmin.
+func (tc *taps) fetchTap(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	if !tc.authorize(tc.topologyName, RoleAdmin) {
+		return
+	}
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	if s := tc.PathParams().String("tapID", ""); s != "" {
+		id, err := strconv.ParseInt(s, 10, 64)
+		var t *core.EdgeTap
+		if err == nil {
+			var ok bool
+			if t, ok = tb.Topology().Context().Taps.Get(id); !ok {
+				err = fmt.Errorf("tap '%v' was not found", s)
+			}
+		}
+		if err != nil {
+			tc.ErrLog(err).Error("Cannot find the tap")
+			tc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode,
+				"The tap was not found", http.StatusNotFound, err))
+			return
+		}
+		tc.tap = t
+		tc.AddLogField("tap", s)
+	}
+	next(rw, req)
+}
+
+// Index returns taps attached to edges of the topology. It doesn't return
+// recorded tuples.
+func (tc *taps) Index(rw web.ResponseWriter, req *web.Request) {
+	ts := tc.topology.Topology().Context().Taps
+	now := ts.Now()
+	res := []map[string]interface{}{}
+	for _, t := range ts.List() {
+		res = append(res, tapInfo(t, now))
+	}
+	tc.Render(map[string]interface{}{
+		"topology": tc.topologyName,
+		"taps":     res,
+	})
+}
+
+// Create attaches a tap to the edge from the node "from" to the node "to".
+// The tap records the next "count" tuples or tuples flowing for "seconds".
+func (tc *taps) Create(rw web.ResponseWriter, req *web.Request) {
+	var js map[string]interface{}
+	if apiErr := tc.ParseBody(&js); apiErr != nil {
+		tc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		tc.RenderError(apiErr)
+		return
+	}
+	form, err := data.NewMap(js)
+	if err != nil {
+		tc.ErrLog(err).WithField("body", js).Error("The request json may contain invalid value")
+		tc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	from, to, c, meta := parseTapParams(form)
+	if len(meta) == 0 {
+		tc.validateEdge(from, to, meta)
+	}
+	if len(meta) != 0 {
+		tc.Log().WithField("body", js).Error("The request body has invalid tap parameters")
+		e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, nil)
+		for k, v := range meta {
+			e.Meta[k] = v
+		}
+		tc.RenderError(e)
+		return
+	}
+
+	t, err := tc.topology.Topology().Context().Taps.Add(from, to, c)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot attach the tap")
+		tc.RenderError(jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+			http.StatusBadRequest, err))
+		return
+	}
+	tc.tap = t
+	tc.AddLogField("tap", strconv.FormatInt(t.ID(), 10))
+	tc.Log().Info("Attached the tap")
+	tc.Show(rw, req)
+}
+
+// validateEdge checks if the edge can be tapped. from has to be a source or
+// a box and to has to be a box or a sink.
+func (tc *taps) validateEdge(from, to string, meta map[string][]string) {
+	tp := tc.topology.Topology()
+	if n, err := tp.Node(from); err != nil {
+		meta["from"] = []string{"node was not found"}
+	} else if n.Type() == core.NTSink {
+		meta["from"] = []string{"node must be a source or a box"}
+	}
+	if n, err := tp.Node(to); err != nil {
+		meta["to"] = []string{"node was not found"}
+	} else if n.Type() == core.NTSource {
+		meta["to"] = []string{"node must be a box or a sink"}
+	}
+}
+
+// Show returns the tap and tuples recorded by it.
+func (tc *taps) Show(rw web.ResponseWriter, req *web.Request) {
+	ts := tc.tap.Tuples()
+	res := make([]map[string]interface{}, 0, len(ts))
+	for _, t := range ts {
+		res = append(res, map[string]interface{}{
+			"timestamp": t.Timestamp,
+			"data":      t.Data,
+		})
+	}
+	tc.Render(map[string]interface{}{
+		"topology": tc.topologyName,
+		"tap":      tapInfo(tc.tap, tc.topology.Topology().Context().Taps.Now()),
+		"tuples":   res,
+	})
+}
+
+// Destroy detaches the tap and discards its tuples.
+func (tc *taps) Destroy(rw web.ResponseWriter, req *web.Request) {
+	if tc.topology.Topology().Context().Taps.Remove(tc.tap.ID()) {
+		tc.Log().Info("Detached the tap")
+	}
+	tc.Render(map[string]interface{}{})
+}
+
+func tapInfo(t *core.EdgeTap, now time.Time) map[string]interface{} {
+	c := t.Config()
+	return map[string]interface{}{
+		"id":         t.ID(),
+		"from":       t.From(),
+		"to":         t.To(),
+		"count":      c.MaxTuples,
+		"seconds":    c.Duration.Seconds(),
+		"started_at": t.StartedAt(),
+		"finished":   t.Finished(now),
+		"num_tuples": len(t.Tuples()),
+	}
+}
+
+// parseTapParams creates a core.TapConfig from the request body. It returns
+// validation errors of each field as the last return value.
+func parseTapParams(form data.Map) (string, string, *core.TapConfig, map[string][]string) {
+	var from, to string
+	c := &core.TapConfig{}
+	meta := map[string][]string{}
+	nodes := []struct {
+		name string
+		v    *string
+	}{
+		{"from", &from},
+		{"to", &to},
+	}
+	for _, n := range nodes {
+		v, ok := form[n.name]
+		if !ok {
+			meta[n.name] = []string{"field is required"}
+			continue
+		}
+		s, err := data.AsString(v)
+		if err != nil || s == "" {
+			meta[n.name] = []string{"value must be a non-empty string"}
+			continue
+		}
+		*n.v = s
+	}
+
+	if v, ok := form["count"]; ok {
+		n, err := data.ToInt(v)
+		switch {
+		case err != nil:
+			meta["count"] = []string{"value must be an integer"}
+		case n <= 0 || n > core.MaxTapTuples:
+			meta["count"] = []string{fmt.Sprintf("value must be in [1, %v]: %v", core.MaxTapTuples, n)}
+		default:
+			c.MaxTuples = int(n)
+		}
+	}
+
+	if v, ok := form["seconds"]; ok {
+		d, err := data.ToDuration(v)
+		switch {
+		case err != nil:
+			meta["seconds"] = []string{"value must be a number of seconds or a duration string"}
+		case d <= 0:
+			meta["seconds"] = []string{"value must be positive"}
+		default:
+			c.Duration = d
+		}
+	}
+
+	if _, ok := form["count"]; !ok {
+		if _, ok := form["seconds"]; !ok {
+			meta["count"] = []string{"count or seconds is required"}
+		}
+	}
+
+	for k := range form {
+		switch k {
+		case "from", "to", "count", "seconds":
+		default:
+			meta[k] = []string{"unknown field"}
+		}
+	}
+	return from, to, c, meta
+}
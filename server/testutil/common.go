@@ -77,16 +77,28 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 // NewServer returns a temporary running server.
 func NewServer() *Server {
-	s := &Server{}
-
 	c, err := config.New(data.Map{})
 	if err != nil {
 		panic(err)
 	}
+	return NewServerWithConfig(c)
+}
+
+// NewServerWithConfig returns a temporary running server having the given
+// config.
+func NewServerWithConfig(c *config.Config) *Server {
 	gvars, err := server.SetUpContextGlobalVariables(c)
 	if err != nil {
 		panic(err)
 	}
+	return NewServerWithGlobalVariables(gvars)
+}
+
+// NewServerWithGlobalVariables returns a temporary running server having the
+// given global variables. It's used when a test needs to customize them or
+// access them while the server is running.
+func NewServerWithGlobalVariables(gvars *server.ContextGlobalVariables) *Server {
+	s := &Server{}
 	jascoRoot := jasco.New("/", nil)
 	root, err := server.SetUpContextAndRouter("/", jascoRoot, gvars)
 	if err != nil {
@@ -20,32 +20,127 @@ import (
 	"time"
 )
 
+// TenantHeader is the name of the HTTP header specifying the tenant which
+// sends a request. When the header is given, the request can only access
+// topologies owned by the tenant and can only create topologies having names
+// prefixed by the tenant's name followed by bql.TenantSeparator. Without the
+// header, a request can access all topologies.
+const TenantHeader = "X-Sensorbee-Tenant"
+
+// SelectSinkHeader is the header of a response of a SELECT statement. It has
+// the name of the temporary sink streaming results of the statement. The sink
+// is removed when the client disconnects, and dropping the sink with
+// DROP SINK stops the statement.
+const SelectSinkHeader = "X-Sensorbee-Select-Sink"
+
 type topologies struct {
 	*APIContext
 	topologyName string
 	topology     *bql.TopologyBuilder
+
+	// tenant is the tenant given by TenantHeader. It's nil when the header
+	// isn't given.
+	tenant *bql.Tenant
 }
 
 func setUpTopologiesRouter(prefix string, router *web.Router) {
 	root := router.Subrouter(topologies{}, "/topologies")
+	root.Middleware((*topologies).extractTenant)
 	root.Middleware((*topologies).extractName)
+	root.Middleware((*topologies).checkPermission)
 	// TODO validation (root can validate with regex like "\w+")
 	root.Post("/", (*topologies).Create)
 	root.Get("/", (*topologies).Index)
 	root.Get(`/:topologyName`, (*topologies).Show)
 	root.Delete(`/:topologyName`, (*topologies).Destroy)
+	root.Get(`/:topologyName/graph`, (*topologies).Graph)
 	root.Post(`/:topologyName/queries`, (*topologies).Queries)
+	root.Post(`/:topologyName/scripts`, (*topologies).Scripts)
 	root.Get(`/:topologyName/wsqueries`, (*topologies).WebSocketQueries)
+	root.Get(`/:topologyName/replication`, (*topologies).Replication)
+	root.Post(`/:topologyName/savepoint`, (*topologies).TakeSavepoint)
+	root.Put(`/:topologyName/savepoint`, (*topologies).RestoreSavepoint)
 
 	setUpSourcesRouter(prefix, root)
 	setUpStreamsRouter(prefix, root)
 	setUpSinksRouter(prefix, root)
+	setUpFaultsRouter(prefix, root)
+	setUpTapsRouter(prefix, root)
+	setUpBreakpointsRouter(prefix, root)
+	setUpCursorsRouter(prefix, root)
+}
+
+func (tc *topologies) extractTenant(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	name := req.Header.Get(TenantHeader)
+	if tc.principal != nil && tc.principal.Tenant != "" {
+		// A principal bound to a tenant always acts as the tenant.
+		if name != "" && name != tc.principal.Tenant {
+			err := fmt.Errorf("'%v' cannot act as the tenant '%v'", tc.principal.Name, name)
+			tc.ErrLog(err).Error("Permission denied")
+			tc.RenderError(jasco.NewError(permissionDeniedErrorCode, "Permission denied",
+				http.StatusForbidden, err))
+			return
+		}
+		name = tc.principal.Tenant
+	}
+	if name == "" {
+		next(rw, req)
+		return
+	}
+	tc.AddLogField("tenant", name)
+
+	t, ok := tc.tenants[name]
+	if !ok {
+		err := fmt.Errorf("the tenant '%v' doesn't exist", name)
+		tc.ErrLog(err).Error("Unknown tenant")
+		tc.RenderError(jasco.NewError(unknownTenantErrorCode, "The tenant doesn't exist",
+			http.StatusForbidden, err))
+		return
+	}
+	tc.tenant = t
+	next(rw, req)
 }
 
 func (tc *topologies) extractName(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
 	tc.topologyName = tc.PathParams().String("topologyName", "")
 	if tc.topologyName != "" {
 		tc.AddLogField("topology", tc.topologyName)
+
+		// Topologies of other tenants are hidden as if they didn't exist.
+		if tc.tenant != nil && !tc.tenant.Owns(tc.topologyName) {
+			err := fmt.Errorf("the topology isn't owned by the tenant '%v'", tc.tenant.Name())
+			tc.ErrLog(err).Error("The topology is not accessible")
+			tc.RenderError(jasco.NewError(requestResourceNotFoundErrorCode, "The topology doesn't exist",
+				http.StatusNotFound, err))
+			return
+		}
+	}
+	next(rw, req)
+}
+
+// checkPermission checks if the principal has the role required by the
+// request. Viewing requires RoleReadOnly, issuing queries requires
+// RoleOperator, and creating or dropping topologies requires RoleAdmin.
+// Replicating a topology and taking or restoring its savepoint also require
+// RoleAdmin because snapshots have all states of the topology. Listing
+// topologies only requires authentication because topologies which the
+// principal cannot view are excluded from the list.
+func (tc *topologies) checkPermission(rw web.ResponseWriter, req *web.Request, next web.NextMiddlewareFunc) {
+	var role Role
+	switch {
+	case tc.topologyName == "" && req.Method == "GET":
+		role = RoleNone
+	case tc.topologyName == "" || req.Method == "DELETE" ||
+		strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), "/replication") ||
+		strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), "/savepoint"):
+		role = RoleAdmin
+	case req.Method == "GET" && !strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), "/wsqueries"):
+		role = RoleReadOnly
+	default:
+		role = RoleOperator
+	}
+	if !tc.authorize(tc.topologyName, role) {
+		return
 	}
 	next(rw, req)
 }
@@ -115,11 +210,34 @@ func (tc *topologies) Create(rw web.ResponseWriter, req *web.Request) {
 		tc.RenderError(e)
 		return
 	}
+	tenant := tc.tenant
+	if tenant != nil {
+		if !tenant.Owns(name) {
+			tc.Log().WithField("name", name).Error("'name' field isn't prefixed by the tenant's name")
+			e := jasco.NewError(formValidationErrorCode, "The request body is invalid.",
+				http.StatusBadRequest, nil)
+			e.Meta["name"] = []string{fmt.Sprintf("must be prefixed by '%v%v'", tenant.Name(), bql.TenantSeparator)}
+			tc.RenderError(e)
+			return
+		}
+	} else {
+		tenant = tc.tenants[bql.TenantNameOf(name)]
+	}
 
 	// TODO: support other parameters
 
+	defaults, err := newNodeDefaults(tc.config.NodeDefaults)
+	if err != nil {
+		tc.ErrLog(err).Error("Invalid node defaults")
+		tc.RenderError(jasco.NewInternalServerError(err))
+		return
+	}
 	cc := &core.ContextConfig{
-		Logger: tc.logger,
+		Logger:          tc.logger,
+		DefaultSettings: defaults,
+	}
+	if tenant != nil {
+		cc.Resources = tenant.Resources
 	}
 	// TODO: Be careful of race conditions on these fields.
 	cc.Flags.DroppedTupleLog.Set(tc.config.Logging.LogDroppedTuples)
@@ -131,13 +249,14 @@ func (tc *topologies) Create(rw web.ResponseWriter, req *web.Request) {
 		tc.RenderError(jasco.NewInternalServerError(err))
 		return
 	}
-	tb, err := bql.NewTopologyBuilder(tp)
+	tb, err := newTopologyBuilder(tenant, tp)
 	if err != nil {
 		tc.ErrLog(err).Error("Cannot create a new topology builder")
 		tc.RenderError(jasco.NewInternalServerError(err))
 		return
 	}
 	tb.UDSStorage = tc.udsStorage
+	tb.Secrets = tc.secrets
 
 	if err := tc.topologies.Register(name, tb); err != nil {
 		if err := tp.Stop(); err != nil {
@@ -173,7 +292,13 @@ func (tc *topologies) Index(rw web.ResponseWriter, req *web.Request) {
 	}
 
 	res := []*response.Topology{}
-	for _, tb := range ts {
+	for name, tb := range ts {
+		if tc.tenant != nil && !tc.tenant.Owns(name) {
+			continue
+		}
+		if tc.principal != nil && tc.principal.Role(name) < RoleReadOnly {
+			continue
+		}
 		res = append(res, response.NewTopology(tb.Topology()))
 	}
 	tc.Render(map[string]interface{}{
@@ -192,6 +317,51 @@ func (tc *topologies) Show(rw web.ResponseWriter, req *web.Request) {
 	})
 }
 
+// Graph returns the DAG of the topology. The format of the response can be
+// specified by "format" query parameter. It can be "json", "dot", or
+// "mermaid". "json" is the default format. "dot" and "mermaid" return a plain
+// text which can directly be passed to Graphviz or Mermaid.
+func (tc *topologies) Graph(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	g := tb.Topology().Graph()
+	var (
+		contentType string
+		body        string
+	)
+	switch format := req.URL.Query().Get("format"); format {
+	case "", "json":
+		tc.Render(map[string]interface{}{
+			"topology": tc.topologyName,
+			"graph":    g.Map(),
+		})
+		return
+	case "dot":
+		contentType = "text/vnd.graphviz; charset=utf-8"
+		body = g.DOT()
+	case "mermaid":
+		contentType = "text/plain; charset=utf-8"
+		body = g.Mermaid()
+	default:
+		err := fmt.Errorf("unsupported graph format: %v", format)
+		tc.ErrLog(err).Error("Cannot render the graph")
+		e := jasco.NewError(formValidationErrorCode, "The request is invalid.",
+			http.StatusBadRequest, err)
+		e.Meta["format"] = []string{"must be one of json, dot, or mermaid"}
+		tc.RenderError(e)
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(rw, body); err != nil {
+		tc.ErrLog(err).Error("Cannot write the graph")
+	}
+}
+
 // TODO: provide Update action (change state of the topology, etc.)
 
 func (tc *topologies) Destroy(rw web.ResponseWriter, req *web.Request) {
@@ -260,6 +430,13 @@ func (tc *topologies) Queries(rw web.ResponseWriter, req *web.Request) {
 		stmts = ss
 	}
 
+	// LOAD PLUGIN runs arbitrary code in the server process.
+	for _, stmt := range stmts {
+		if _, ok := stmt.(parser.LoadPluginStmt); ok && !tc.authorize(tc.topologyName, RoleAdmin) {
+			return
+		}
+	}
+
 	if len(stmts) == 1 {
 		stmtStr := fmt.Sprint(stmts[0])
 		if stmt, ok := stmts[0].(parser.SelectStmt); ok {
@@ -271,6 +448,9 @@ func (tc *topologies) Queries(rw web.ResponseWriter, req *web.Request) {
 		} else if stmt, ok := stmts[0].(parser.EvalStmt); ok {
 			tc.handleEvalStmt(rw, stmt, stmtStr)
 			return
+		} else if stmt, ok := stmts[0].(parser.ShowStmt); ok {
+			tc.handleShowStmt(rw, stmt, stmtStr)
+			return
 		}
 	}
 
@@ -296,6 +476,60 @@ func (tc *topologies) Queries(rw web.ResponseWriter, req *web.Request) {
 	})
 }
 
+// Scripts applies all statements in "queries" atomically. When one of the
+// statements fails, changes made by other statements are rolled back.
+func (tc *topologies) Scripts(rw web.ResponseWriter, req *web.Request) {
+	tb := tc.fetchTopology()
+	if tb == nil {
+		return
+	}
+
+	var js map[string]interface{}
+	if apiErr := tc.ParseBody(&js); apiErr != nil {
+		tc.ErrLog(apiErr.Err).Error("Cannot parse the request json")
+		tc.RenderError(apiErr)
+		return
+	}
+
+	form, err := data.NewMap(js)
+	if err != nil {
+		tc.ErrLog(err).WithField("body", js).
+			Error("The request json may contain invalid value")
+		tc.RenderError(jasco.NewError(formValidationErrorCode, "The request json may contain invalid values.",
+			http.StatusBadRequest, err))
+		return
+	}
+
+	stmts, apiErr := tc.parseQueries(form)
+	if apiErr != nil {
+		tc.RenderError(apiErr)
+		return
+	}
+
+	if err := tb.AddStmts(stmts); err != nil {
+		stmtErr, ok := err.(*bql.StmtError)
+		if !ok {
+			tc.ErrLog(err).Error("Cannot roll back statements")
+			tc.RenderError(jasco.NewInternalServerError(err))
+			return
+		}
+		tc.ErrLog(stmtErr.Err).WithField("statement_index", stmtErr.Index).
+			Error("Cannot process a statement")
+		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
+		e.Meta["error"] = stmtErr.Err.Error()
+		e.Meta["statement"] = fmt.Sprint(stmtErr.Stmt)
+		e.Meta["statement_index"] = stmtErr.Index
+		tc.RenderError(e)
+		return
+	}
+
+	tc.Render(map[string]interface{}{
+		"topology_name": tc.topologyName,
+		"status":        "running",
+		"queries":       stmts,
+	})
+}
+
 func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error) {
 	// TODO: use mapstructure when parameters get too many
 	var queries string
@@ -334,6 +568,8 @@ func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error)
 			dataReturningStmtIndex = len(stmts)
 		} else if _, ok := stmt.(parser.EvalStmt); ok {
 			dataReturningStmtIndex = len(stmts)
+		} else if _, ok := stmt.(parser.ShowStmt); ok {
+			dataReturningStmtIndex = len(stmts)
 		}
 
 		stmts = append(stmts, stmt)
@@ -342,10 +578,10 @@ func (tc *topologies) parseQueries(form data.Map) ([]interface{}, *jasco.Error)
 
 	if dataReturningStmtIndex >= 0 {
 		if len(stmts) != 1 {
-			errMsg := "A SELECT or EVAL statement cannot be issued with other statements"
+			errMsg := "A SELECT, EVAL, or SHOW statement cannot be issued with other statements"
 			tc.Log().Error(errMsg)
 			e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, nil)
-			e.Meta["error"] = "a SELECT or EVAL statement cannot be issued with other statements"
+			e.Meta["error"] = "a SELECT, EVAL, or SHOW statement cannot be issued with other statements"
 			e.Meta["statement"] = fmt.Sprint(stmts[dataReturningStmtIndex])
 			return nil, e
 		}
@@ -418,6 +654,7 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 	res := []string{
 		"HTTP/1.1 200 OK",
 		fmt.Sprintf(`Content-Type: multipart/mixed; boundary="%v"`, mw.Boundary()),
+		fmt.Sprintf("%v: %v", SelectSinkHeader, sn.Name()),
 		"\r\n",
 	}
 	if _, err := bufrw.WriteString(strings.Join(res, "\r\n")); err != nil {
@@ -428,14 +665,25 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 
 	tc.Log().WithField("statement", stmtStr).Info("Start streaming SELECT responses")
 
+	// The client doesn't send anything after the request, so a read only
+	// returns when the connection is closed. This goroutine exits when conn
+	// is closed by the deferred function above.
+	disconnected := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			if _, err := bufrw.Read(buf); err != nil {
+				disconnected <- err
+				return
+			}
+		}
+	}()
+
 	// All error reporting logs after this is info level because they might be
 	// caused by the client closing the connection.
 	header := textproto.MIMEHeader{}
 	header.Add("Content-Type", "application/json")
 
-	readPoll := time.After(1 * time.Minute)
-	sent := false
-	dummyReadBuf := make([]byte, 1024)
 	for {
 		var t *core.Tuple
 		select {
@@ -444,39 +692,12 @@ func (tc *topologies) handleSelectUnionStmt(rw web.ResponseWriter, stmt parser.S
 				return
 			}
 			t = v
-			sent = true
-		case <-readPoll:
-			if sent {
-				sent = false
-				readPoll = time.After(1 * time.Minute)
-				continue
-			}
-
-			// Assuming there's no more data to be read. Because no tuple was
-			// written for past 1 minute, blocking read for 1ms here isn't a
-			// big deal.
-			// TODO: is there any better way to detect disconnection?
-			// TODO: If general errors are checked before checking the deadline,
-			//       this code doesn't have to add 1ms.
-			if err := conn.SetReadDeadline(time.Now().Add(1 * time.Millisecond)); err != nil {
-				tc.ErrLog(err).Error("Cannot check the status of connection due to the failure of conn.SetReadDeadline. Stopping streaming.")
-				// This isn't handled as a read error because some operating
-				// systems don't support SetReadDeadline.
-				return
-			}
-			if _, err := bufrw.Read(dummyReadBuf); err != nil {
-				type timeout interface {
-					Timeout() bool
-				}
-				if e, ok := err.(timeout); !ok || !e.Timeout() {
-					// Something happened on this connection.
-					readErr = err
-					tc.ErrLog(err).Error("The connection may be closed from the client side")
-					return
-				}
-			}
-			readPoll = time.After(1 * time.Minute)
-			continue
+		case err := <-disconnected:
+			// The temporary sink is stopped by the deferred function as soon
+			// as the client disconnects.
+			readErr = err
+			tc.ErrLog(err).Info("The connection was closed from the client side")
+			return
 		}
 
 		js := t.Data.String()
@@ -522,6 +743,53 @@ func (tc *topologies) handleEvalStmt(rw web.ResponseWriter, stmt parser.EvalStmt
 	})
 }
 
+func (tc *topologies) handleShowStmt(rw web.ResponseWriter, stmt parser.ShowStmt, stmtStr string) {
+	rows, e := tc.runShowStmt(stmt, stmtStr)
+	if e != nil {
+		tc.RenderError(e)
+		return
+	}
+	tc.Render(map[string]interface{}{
+		"result": rows,
+	})
+}
+
+// runShowStmt runs the SHOW statement on the topology specified by its IN
+// clause, or on the topology to which the request is sent when the clause is
+// omitted. Showing another topology requires RoleReadOnly on it.
+func (tc *topologies) runShowStmt(stmt parser.ShowStmt, stmtStr string) ([]data.Map, *jasco.Error) {
+	name := tc.topologyName
+	if stmt.Topology != "" {
+		name = string(stmt.Topology)
+	}
+	if p := tc.principal; p != nil && p.Role(name) < RoleReadOnly {
+		err := fmt.Errorf("'%v' doesn't have %v role on the topology '%v'", p.Name, RoleReadOnly, name)
+		tc.ErrLog(err).Error("Permission denied")
+		return nil, jasco.NewError(permissionDeniedErrorCode, "Permission denied", http.StatusForbidden, err)
+	}
+
+	tb, err := tc.topologies.Lookup(name)
+	if err != nil {
+		if core.IsNotExist(err) {
+			tc.ErrLog(err).Error("The topology is not registered")
+			return nil, jasco.NewError(requestResourceNotFoundErrorCode, "The topology doesn't exist",
+				http.StatusNotFound, err)
+		}
+		tc.ErrLog(err).Error("Cannot lookup the topology")
+		return nil, jasco.NewInternalServerError(err)
+	}
+
+	rows, err := tb.RunShowStmt(&stmt)
+	if err != nil {
+		tc.ErrLog(err).Error("Cannot process a statement")
+		e := jasco.NewError(bqlStmtProcessingErrorCode, "Cannot process a statement", http.StatusBadRequest, err)
+		e.Meta["error"] = err.Error()
+		e.Meta["statement"] = stmtStr
+		return nil, e
+	}
+	return rows, nil
+}
+
 // WebSocketQueries handles requests using WebSocket. A single WebSocket
 // connection can concurrently issue multiple requests including requests
 // containing a SELECT statement.
@@ -576,12 +844,14 @@ func (tc *topologies) handleEvalStmt(rw web.ResponseWriter, stmt parser.EvalStmt
 // "payload" has an error information which is same as the error response
 // that Queries action returns. "sos", start of stream, type is used by SELECT
 // statements to notify the client that a SELECT statement finishes setting up
-// all necessary nodes in the topology. Its payload is always null. "ping"
+// all necessary nodes in the topology. Its payload has "sink" which is the
+// name of the temporary sink streaming results of the statement. "ping"
 // type is used by SELECT statements to validate connection. Its "payload" is
 // always null. SELECT statements send "ping" responses on a regular basis.
 // "eos", end of stream, responses are sent when SELECT statements has sent all
 // tuples. "payload" of "eos" is always null. "eos" isn't sent when an error
-// occurred.
+// occurred. SELECT statements are stopped and their temporary sinks are
+// removed when the connection is closed.
 func (tc *topologies) WebSocketQueries(rw web.ResponseWriter, req *web.Request) {
 	// TODO: add a document describing which BQL statement returns which result.
 	if !strings.EqualFold(req.Header.Get("Upgrade"), "WebSocket") {
@@ -601,7 +871,11 @@ func (tc *topologies) WebSocketQueries(rw web.ResponseWriter, req *web.Request)
 	defer tc.Log().Info("End WebSocket connection")
 
 	websocket.Handler(func(conn *websocket.Conn) {
-		for tc.processWebSocketMessage(conn, tb) {
+		// closed is closed when the connection is lost so that SELECT
+		// statements issued on the connection can stop immediately.
+		closed := make(chan struct{})
+		defer close(closed)
+		for tc.processWebSocketMessage(conn, tb, closed) {
 		}
 	}).ServeHTTP(rw, req.Request)
 }
@@ -609,10 +883,11 @@ func (tc *topologies) WebSocketQueries(rw web.ResponseWriter, req *web.Request)
 // processWebSocketMessage processes a request from the client. It returns true
 // if the caller can call this method again, in other words, the connection is
 // still alive.
-func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.TopologyBuilder) bool {
+func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.TopologyBuilder, closed <-chan struct{}) bool {
 	w := &webSocketTopologyQueryHandler{
-		tc:   tc,
-		conn: conn,
+		tc:     tc,
+		conn:   conn,
+		closed: closed,
 	}
 
 	var js map[string]interface{}
@@ -691,6 +966,17 @@ func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.Topo
 		stmts = ss
 	}
 
+	// LOAD PLUGIN runs arbitrary code in the server process.
+	if p := w.tc.principal; p != nil && p.Role(w.tc.topologyName) < RoleAdmin {
+		for _, stmt := range stmts {
+			if _, ok := stmt.(parser.LoadPluginStmt); ok {
+				err := fmt.Errorf("'%v' doesn't have %v role on the topology '%v'", p.Name, RoleAdmin, w.tc.topologyName)
+				w.ErrLog(err).Error("Permission denied")
+				return w.sendErr(jasco.NewError(permissionDeniedErrorCode, "Permission denied", http.StatusForbidden, err))
+			}
+		}
+	}
+
 	// Although these requests may fail asynchronously, the connect is probably
 	// still alive and next processWebSocketMessage can detect disconnection.
 	// So, the following code block always returns true.
@@ -706,6 +992,9 @@ func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.Topo
 			} else if stmt, ok := stmts[0].(parser.EvalStmt); ok {
 				w.handleEvalStmtWebSocket(conn, stmt, stmtStr)
 				return
+			} else if stmt, ok := stmts[0].(parser.ShowStmt); ok {
+				w.handleShowStmtWebSocket(conn, stmt, stmtStr)
+				return
 			}
 		}
 
@@ -732,9 +1021,10 @@ func (tc *topologies) processWebSocketMessage(conn *websocket.Conn, tb *bql.Topo
 }
 
 type webSocketTopologyQueryHandler struct {
-	tc   *topologies
-	conn *websocket.Conn
-	rid  int64
+	tc     *topologies
+	conn   *websocket.Conn
+	closed <-chan struct{}
+	rid    int64
 }
 
 func (w *webSocketTopologyQueryHandler) Log() *logrus.Entry {
@@ -807,7 +1097,9 @@ func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *web
 
 	w.Log().WithField("statement", stmtStr).Info("Start streaming SELECT responses")
 
-	if err := w.send("sos", nil); err != nil {
+	if err := w.send("sos", map[string]interface{}{
+		"sink": sn.Name(),
+	}); err != nil {
 		w.ErrLog(err).Error("Cannot send an sos to the WebSocket client")
 		return
 	}
@@ -826,6 +1118,9 @@ func (w *webSocketTopologyQueryHandler) handleSelectUnionStmtWebSocket(conn *web
 			}
 			t = v
 			sent = true
+		case <-w.closed:
+			w.Log().Info("The WebSocket connection was closed")
+			return
 		case <-ping:
 			if sent {
 				sent = false
@@ -871,3 +1166,18 @@ func (w *webSocketTopologyQueryHandler) handleEvalStmtWebSocket(conn *websocket.
 		return
 	}
 }
+
+func (w *webSocketTopologyQueryHandler) handleShowStmtWebSocket(conn *websocket.Conn, stmt parser.ShowStmt, stmtStr string) {
+	rows, e := w.tc.runShowStmt(stmt, stmtStr)
+	if e != nil {
+		w.sendErr(e)
+		return
+	}
+
+	if err := w.send("result", map[string]interface{}{
+		"result": rows,
+	}); err != nil {
+		w.ErrLog(err).Error("Cannot send data to the WebSocket client")
+		return
+	}
+}